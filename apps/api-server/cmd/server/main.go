@@ -20,6 +20,8 @@ import (
 	_ "github.com/openoms-org/openoms/apps/api-server/internal/integration/kaufland"
 	_ "github.com/openoms-org/openoms/apps/api-server/internal/integration/mirakl"
 	_ "github.com/openoms-org/openoms/apps/api-server/internal/integration/olx"
+	_ "github.com/openoms-org/openoms/apps/api-server/internal/integration/prestashop"
+	_ "github.com/openoms-org/openoms/apps/api-server/internal/integration/shopify"
 	_ "github.com/openoms-org/openoms/apps/api-server/internal/integration/woocommerce"
 
 	// Register carrier providers via init().
@@ -28,6 +30,7 @@ import (
 	_ "github.com/openoms-org/openoms/apps/api-server/internal/integration/fakturownia"
 
 	"github.com/openoms-org/openoms/apps/api-server/docs"
+	"github.com/openoms-org/openoms/apps/api-server/internal/address"
 	"github.com/openoms-org/openoms/apps/api-server/internal/automation"
 	"github.com/openoms-org/openoms/apps/api-server/internal/config"
 	"github.com/openoms-org/openoms/apps/api-server/internal/database"
@@ -116,6 +119,8 @@ func main() {
 	userRepo := repository.NewUserRepository(pool)
 	auditRepo := repository.NewAuditRepository()
 	orderRepo := repository.NewOrderRepository()
+	orderViewRepo := repository.NewOrderViewRepository()
+	scheduledReportRepo := repository.NewScheduledReportRepository()
 	shipmentRepo := repository.NewShipmentRepository()
 	productRepo := repository.NewProductRepository()
 	integrationRepo := repository.NewIntegrationRepository()
@@ -130,50 +135,101 @@ func main() {
 	supplierRepo := repository.NewSupplierRepository()
 	supplierProductRepo := repository.NewSupplierProductRepository()
 	variantRepo := repository.NewVariantRepository()
+	fulfillmentRuleRepo := repository.NewFulfillmentRuleRepository()
+	supplierOrderRepo := repository.NewSupplierOrderRepository()
 	syncJobRepo := repository.NewSyncJobRepository()
 	warehouseRepo := repository.NewWarehouseRepository()
 	warehouseStockRepo := repository.NewWarehouseStockRepository()
+	stockMovementRepo := repository.NewStockMovementRepository()
 	customerRepo := repository.NewCustomerRepository()
 	priceListRepo := repository.NewPriceListRepository()
 	warehouseDocRepo := repository.NewWarehouseDocumentRepository()
 	warehouseDocItemRepo := repository.NewWarehouseDocItemRepository()
+	inventoryCostRepo := repository.NewInventoryCostRepository()
 	exchangeRateRepo := repository.NewExchangeRateRepository()
 	roleRepo := repository.NewRoleRepository()
 	stocktakeRepo := repository.NewStocktakeRepository()
 	stocktakeItemRepo := repository.NewStocktakeItemRepository()
+	orderEventRepo := repository.NewOrderEventRepository()
+	orderHoldRepo := repository.NewOrderHoldRepository()
+	orderRiskRepo := repository.NewOrderRiskRepository()
 
 	authService := service.NewAuthService(userRepo, tenantRepo, auditRepo, tokenSvc, passwordSvc, pool, encryptionKey)
 	userService := service.NewUserService(userRepo, auditRepo, passwordSvc, pool)
 	roleService := service.NewRoleService(roleRepo, auditRepo, pool)
 	emailService := service.NewEmailService(tenantRepo, pool)
 	smsService := service.NewSMSService(tenantRepo, pool)
+	emailService.SetOrderEventRepo(orderEventRepo)
+	smsService.SetOrderEventRepo(orderEventRepo)
 	webhookDispatchService := service.NewWebhookDispatchService(tenantRepo, webhookDeliveryRepo, pool)
 	orderService := service.NewOrderService(orderRepo, auditRepo, tenantRepo, pool, emailService, webhookDispatchService)
+	orderViewService := service.NewOrderViewService(orderViewRepo, pool)
 	returnService := service.NewReturnService(returnRepo, orderRepo, auditRepo, pool, webhookDispatchService)
 	shipmentService := service.NewShipmentService(shipmentRepo, orderRepo, auditRepo, pool, webhookDispatchService)
 	productService := service.NewProductService(productRepo, auditRepo, pool, webhookDispatchService)
 	integrationService := service.NewIntegrationService(integrationRepo, auditRepo, pool, encryptionKey)
+	var pna *address.PNA
+	if cfg.AddressPNAFile != "" {
+		pna = address.NewPNA()
+		f, err := os.Open(cfg.AddressPNAFile)
+		if err == nil {
+			err = pna.Load(f)
+			f.Close()
+		}
+		if err != nil {
+			slog.Error("failed to load PNA dataset", "path", cfg.AddressPNAFile, "error", err)
+			os.Exit(1)
+		}
+	}
+	addressValidator := address.NewValidator(pna)
 	labelService := service.NewLabelService(
 		shipmentRepo, orderRepo, integrationRepo, auditRepo,
 		pool, encryptionKey, cfg.UploadDir, cfg.BaseURL,
 	)
+	labelService.SetAddressValidator(addressValidator)
 	webhookService := service.NewWebhookService(webhookRepo, pool, cfg.AllegroWebhookSecret, cfg.InPostWebhookSecret)
 	statsService := service.NewStatsService(statsRepo, pool)
+	statsService.SetTenantRepo(tenantRepo)
+	scheduledReportService := service.NewScheduledReportService(
+		scheduledReportRepo, statsRepo, auditRepo, statsService, emailService, objectStorage, pool,
+	)
 	invoiceService := service.NewInvoiceService(invoiceRepo, orderRepo, tenantRepo, auditRepo, pool, encryptionKey)
 	orderService.SetInvoiceService(invoiceService)
 	orderService.SetSMSService(smsService)
 	orderService.SetShipmentService(shipmentService)
+	orderService.SetOrderEventRepo(orderEventRepo)
+	orderHoldService := service.NewOrderHoldService(
+		orderHoldRepo, orderRepo, productRepo, variantRepo, tenantRepo, auditRepo,
+		pool, webhookDispatchService, slog.Default(),
+	)
+	orderHoldService.SetAddressValidator(addressValidator)
+	orderRiskService := service.NewOrderRiskService(orderRiskRepo, orderRepo, tenantRepo, slog.Default())
+	orderHoldService.SetRiskService(orderRiskService)
+	orderService.SetOrderHoldService(orderHoldService)
+	shipmentService.SetTenantRepo(tenantRepo)
 	shipmentService.SetSMSService(smsService)
 	supplierService := service.NewSupplierService(supplierRepo, supplierProductRepo, auditRepo, pool, webhookDispatchService, slog.Default())
+	dropshipService := service.NewDropshipService(
+		orderRepo, productRepo, variantRepo, supplierRepo, supplierProductRepo,
+		fulfillmentRuleRepo, supplierOrderRepo, shipmentRepo, integrationRepo, tenantRepo, auditRepo,
+		pool, webhookDispatchService, cfg.BaseURL, cfg.FrontendURL, slog.Default(),
+	)
 	variantService := service.NewVariantService(variantRepo, productRepo, auditRepo, pool)
-	warehouseService := service.NewWarehouseService(warehouseRepo, warehouseStockRepo, auditRepo, tenantRepo, pool)
+	warehouseService := service.NewWarehouseService(warehouseRepo, warehouseStockRepo, stockMovementRepo, auditRepo, tenantRepo, pool)
 	orderGroupService := service.NewOrderGroupService(orderGroupRepo, orderRepo, auditRepo, pool)
+	orderGroupService.SetStockDeps(productRepo, warehouseStockRepo)
 	bundleService := service.NewBundleService(bundleRepo, productRepo, auditRepo, pool)
 	customerService := service.NewCustomerService(customerRepo, auditRepo, pool, webhookDispatchService, slog.Default())
 	barcodeService := service.NewBarcodeService(productRepo, variantRepo, orderRepo, auditRepo, pool)
 	priceListService := service.NewPriceListService(priceListRepo, productRepo, auditRepo, pool)
-	warehouseDocService := service.NewWarehouseDocumentService(warehouseDocRepo, warehouseDocItemRepo, warehouseStockRepo, auditRepo, pool)
+	warehouseDocService := service.NewWarehouseDocumentService(warehouseDocRepo, warehouseDocItemRepo, warehouseStockRepo, productRepo, variantRepo, auditRepo, pool)
+	inventoryCostingService := service.NewInventoryCostingService(inventoryCostRepo, productRepo, variantRepo, tenantRepo, pool)
+	warehouseDocService.SetCostingService(inventoryCostingService)
+	returnService.SetReceivingDeps(warehouseDocService, productRepo, variantRepo, integrationRepo, tenantRepo, encryptionKey)
+	returnService.SetLabelStorage(cfg.UploadDir, cfg.BaseURL)
+	returnService.SetInvoiceService(invoiceService)
 	exchangeRateService := service.NewExchangeRateService(exchangeRateRepo, auditRepo, pool)
+	orderRiskService.SetExchangeRateService(exchangeRateService)
 	ksefService := service.NewKSeFService(invoiceRepo, orderRepo, tenantRepo, auditRepo, pool)
 	stocktakeService := service.NewStocktakeService(stocktakeRepo, stocktakeItemRepo, warehouseStockRepo, warehouseDocRepo, warehouseDocItemRepo, auditRepo, pool, webhookDispatchService)
 
@@ -181,10 +237,22 @@ func main() {
 	automationRuleRepo := repository.NewAutomationRuleRepository()
 	automationRuleLogRepo := repository.NewAutomationRuleLogRepository()
 	delayedActionRepo := repository.NewDelayedActionRepository()
-	automationExecutor := automation.NewDefaultActionExecutor(slog.Default())
+	automationEventRepo := repository.NewAutomationEventRepository()
+	freshdeskService := service.NewFreshdeskService(tenantRepo, orderRepo, pool, slog.Default())
+	automationExecutor := service.NewAutomationActionExecutor(
+		pool, orderRepo, userRepo, shipmentRepo, integrationRepo, auditRepo,
+		automation.NewDefaultActionExecutor(slog.Default()), encryptionKey, slog.Default(),
+	)
+	automationExecutor.SetShipmentServices(shipmentService, labelService)
+	automationExecutor.SetSMSService(smsService)
+	automationExecutor.SetFreshdeskService(freshdeskService)
+	automationExecutor.SetOrderGroupService(orderGroupService)
+	automationExecutor.SetOrderEventRepo(orderEventRepo)
+	automationExecutor.SetOrderHoldService(orderHoldService)
 	automationEngine := automation.NewEngine(automationRuleRepo, automationRuleLogRepo, pool, automationExecutor, slog.Default())
 	automationEngine.SetDelayedActionRepo(delayedActionRepo)
-	automationService := service.NewAutomationService(automationRuleRepo, automationRuleLogRepo, pool, automationEngine, slog.Default())
+	automationEngine.SetActionRunRepo(automationEventRepo)
+	automationService := service.NewAutomationService(automationRuleRepo, automationRuleLogRepo, automationEventRepo, pool, automationEngine, slog.Default())
 	automationService.SetDelayedActionRepo(delayedActionRepo)
 
 	// Wire automation service into entity services (setter pattern to avoid circular dependency)
@@ -192,6 +260,7 @@ func main() {
 	shipmentService.SetAutomationService(automationService)
 	returnService.SetAutomationService(automationService)
 	productService.SetAutomationService(automationService)
+	orderHoldService.SetAutomationService(automationService)
 
 	// Initialize token blacklist for server-side token revocation
 	tokenBlacklist := middleware.NewTokenBlacklist()
@@ -200,6 +269,7 @@ func main() {
 	authHandler := handler.NewAuthHandler(authService, cfg.IsDevelopment(), tokenBlacklist)
 	userHandler := handler.NewUserHandler(userService)
 	orderHandler := handler.NewOrderHandler(orderService, tenantRepo, pool)
+	orderViewHandler := handler.NewOrderViewHandler(orderViewService)
 	shipmentHandler := handler.NewShipmentHandler(shipmentService, labelService)
 	productImportService := service.NewProductImportService(productRepo, auditRepo, pool)
 	productHandler := handler.NewProductHandler(productService, productImportService)
@@ -207,6 +277,7 @@ func main() {
 	returnHandler := handler.NewReturnHandler(returnService)
 	webhookHandler := handler.NewWebhookHandler(webhookService)
 	statsHandler := handler.NewStatsHandler(statsService)
+	scheduledReportHandler := handler.NewScheduledReportHandler(scheduledReportService)
 	uploadHandler := handler.NewUploadHandler(objectStorage, cfg.MaxUploadSize)
 	settingsHandler := handler.NewSettingsHandler(tenantRepo, auditRepo, emailService, smsService, pool)
 	auditHandler := handler.NewAuditHandler(auditRepo, pool)
@@ -236,7 +307,7 @@ func main() {
 
 	// Allegro listings handler (publish products to Allegro)
 	productListingRepo := repository.NewProductListingRepository()
-	allegroListingsHandler := handler.NewAllegroListingsHandler(integrationService, productService, productListingRepo, encryptionKey, pool, cfg)
+	allegroListingsHandler := handler.NewAllegroListingsHandler(integrationService, productService, variantService, productListingRepo, encryptionKey, pool, cfg)
 
 	// Allegro catalog + finance handler
 	allegroCatalogHandler := handler.NewAllegroCatalogHandler(integrationService, encryptionKey)
@@ -256,6 +327,12 @@ func main() {
 	// Allegro ratings handler
 	allegroRatingsHandler := handler.NewAllegroRatingsHandler(integrationService, encryptionKey)
 
+	// Shopify fulfillment + locations handler
+	shopifyHandler := handler.NewShopifyHandler(integrationService, orderService)
+
+	// PrestaShop order state handler
+	prestashopHandler := handler.NewPrestaShopHandler(integrationService, orderService)
+
 	// Amazon auth handler
 	amazonAuthHandler := handler.NewAmazonAuthHandler(integrationService, encryptionKey)
 
@@ -267,6 +344,10 @@ func main() {
 
 	// Supplier handler
 	supplierHandler := handler.NewSupplierHandler(supplierService)
+	dropshipHandler := handler.NewDropshipHandler(dropshipService)
+
+	// Order hold handler
+	orderHoldHandler := handler.NewOrderHoldHandler(orderHoldService)
 
 	// Import service & handler
 	importService := service.NewImportService(orderRepo, auditRepo, pool)
@@ -298,6 +379,7 @@ func main() {
 
 	// Warehouse document handler
 	warehouseDocHandler := handler.NewWarehouseDocumentHandler(warehouseDocService)
+	inventoryCostingHandler := handler.NewInventoryCostingHandler(inventoryCostingService)
 
 	// WebSocket hub and handler
 	wsHub := ws.NewHub()
@@ -309,6 +391,17 @@ func main() {
 		wsHub.BroadcastToTenant(tenantID, ws.Event{Type: eventType, Payload: payload})
 	})
 
+	// Bulk order jobs (run by BulkJobWorker, progress pushed over the hub)
+	bulkJobService := service.NewBulkJobService(
+		orderRepo, shipmentRepo, invoiceRepo, syncJobRepo, tenantRepo, auditRepo,
+		orderService, shipmentService, labelService, invoiceService,
+		pool, cfg.UploadDir, cfg.BaseURL,
+	)
+	bulkJobService.SetWSBroadcast(func(tenantID uuid.UUID, eventType string, payload any) {
+		wsHub.BroadcastToTenant(tenantID, ws.Event{Type: eventType, Payload: payload})
+	})
+	bulkJobHandler := handler.NewBulkJobHandler(bulkJobService)
+
 	// AI service & handler (Phase 33)
 	aiService := service.NewAIService(cfg.OpenAIAPIKey, cfg.OpenAIModel, productRepo, tenantRepo, pool)
 	aiHandler := handler.NewAIHandler(aiService)
@@ -321,7 +414,6 @@ func main() {
 	marketingHandler := handler.NewMarketingHandler(mailchimpService)
 
 	// Freshdesk helpdesk service & handler (Phase 34)
-	freshdeskService := service.NewFreshdeskService(tenantRepo, orderRepo, pool, slog.Default())
 	helpdeskHandler := handler.NewHelpdeskHandler(freshdeskService)
 
 	// Public return handler (Phase 29)
@@ -342,6 +434,10 @@ func main() {
 	// Sync job handler
 	syncJobHandler := handler.NewSyncJobHandler(syncJobRepo, pool)
 
+	// Catalog import from sales channels (run by CatalogImportWorker)
+	catalogImportService := service.NewCatalogImportService(integrationRepo, productRepo, productService, variantRepo, productListingRepo, syncJobRepo, auditRepo, pool, encryptionKey)
+	catalogImportHandler := handler.NewCatalogImportHandler(catalogImportService)
+
 	// OpenAPI docs handler
 	docsHandler := handler.NewDocsHandler(docs.OpenAPISpec)
 
@@ -361,11 +457,15 @@ func main() {
 		Auth:              authHandler,
 		User:              userHandler,
 		Order:             orderHandler,
+		OrderView:         orderViewHandler,
+		OrderHold:         orderHoldHandler,
+		BulkJob:           bulkJobHandler,
 		Shipment:          shipmentHandler,
 		Product:           productHandler,
 		Integration:       integrationHandler,
 		Webhook:           webhookHandler,
 		Stats:             statsHandler,
+		ScheduledReport:   scheduledReportHandler,
 		Upload:            uploadHandler,
 		Settings:          settingsHandler,
 		Audit:             auditHandler,
@@ -375,8 +475,12 @@ func main() {
 		AllegroAuth:       allegroAuthHandler,
 		Allegro:           allegroHandler,
 		AllegroShipment:   allegroShipmentHandler,
+		Shopify:           shopifyHandler,
+		PrestaShop:        prestashopHandler,
+		CatalogImport:     catalogImportHandler,
 		AmazonAuth:        amazonAuthHandler,
 		Supplier:          supplierHandler,
+		Dropship:          dropshipHandler,
 		Invoice:           invoiceHandler,
 		Automation:        automationHandler,
 		Import:            importHandler,
@@ -392,6 +496,7 @@ func main() {
 		Barcode:           barcodeHandler,
 		PriceList:         priceListHandler,
 		WarehouseDocument: warehouseDocHandler,
+		InventoryCosting:  inventoryCostingHandler,
 		WS:                wsHandler,
 		AI:                aiHandler,
 		Marketing:         marketingHandler,
@@ -418,15 +523,34 @@ func main() {
 	// Start background workers
 	workerMgr := worker.NewManager(pool, slog.Default())
 	workerMgr.Register(worker.NewOAuthRefresher(pool, encryptionKey, slog.Default()))
-	workerMgr.Register(worker.NewAllegroOrderPoller(pool, encryptionKey, orderRepo, shipmentRepo, auditRepo, slog.Default()))
 	workerMgr.Register(worker.NewStockSyncWorker(pool, encryptionKey, slog.Default()))
-	workerMgr.Register(worker.NewTrackingPoller(pool, encryptionKey, shipmentRepo, slog.Default()))
-	workerMgr.Register(worker.NewAmazonOrderPoller(pool, encryptionKey, orderRepo, shipmentRepo, auditRepo, slog.Default()))
-	workerMgr.Register(worker.NewWooCommerceOrderPoller(pool, encryptionKey, orderRepo, shipmentRepo, auditRepo, slog.Default()))
+	workerMgr.Register(worker.NewPriceSyncWorker(pool, encryptionKey, priceListService, exchangeRateService, slog.Default()))
+	workerMgr.Register(worker.NewOrderFeeWorker(pool, encryptionKey, slog.Default()))
+	workerMgr.Register(worker.NewCatalogImportWorker(pool, syncJobRepo, catalogImportService, slog.Default()))
+	workerMgr.Register(worker.NewBulkJobWorker(pool, syncJobRepo, bulkJobService, slog.Default()))
+	workerMgr.Register(worker.NewScheduledReportWorker(pool, scheduledReportRepo, scheduledReportService, slog.Default()))
+	workerMgr.Register(worker.NewTrackingPoller(pool, encryptionKey, shipmentRepo, auditRepo, slog.Default()))
+	workerMgr.Register(worker.NewReturnTrackingPoller(pool, encryptionKey, returnService, slog.Default()))
+	for _, poller := range []*worker.MarketplaceOrderPoller{
+		worker.NewAllegroOrderPoller(pool, encryptionKey, orderRepo, shipmentRepo, auditRepo, slog.Default()),
+		worker.NewAmazonOrderPoller(pool, encryptionKey, orderRepo, shipmentRepo, auditRepo, slog.Default()),
+		worker.NewWooCommerceOrderPoller(pool, encryptionKey, orderRepo, shipmentRepo, auditRepo, variantRepo, slog.Default()),
+		worker.NewShopifyOrderPoller(pool, encryptionKey, orderRepo, shipmentRepo, auditRepo, slog.Default()),
+		worker.NewPrestaShopOrderPoller(pool, encryptionKey, orderRepo, shipmentRepo, auditRepo, variantRepo, slog.Default()),
+	} {
+		poller.SetOrderEventRepo(orderEventRepo)
+		poller.SetOrderHoldService(orderHoldService)
+		workerMgr.Register(poller)
+	}
 	workerMgr.Register(worker.NewSupplierSyncWorker(pool, supplierService, slog.Default()))
+	workerMgr.Register(worker.NewDropshipWorker(pool, dropshipService, slog.Default()))
+	workerMgr.Register(worker.NewOrderHoldReleaseWorker(pool, orderHoldService, slog.Default()))
 	workerMgr.Register(worker.NewExchangeRateWorker(pool, exchangeRateService, slog.Default()))
 	workerMgr.Register(worker.NewKSeFStatusWorker(pool, ksefService, slog.Default()))
+	workerMgr.Register(worker.NewCorrectionInvoiceWorker(pool, invoiceService, slog.Default()))
 	workerMgr.Register(worker.NewDelayedActionWorker(pool, delayedActionRepo, automationExecutor, slog.Default()))
+	workerMgr.Register(worker.NewAutomationEventWorker(pool, automationEventRepo, automationEngine, slog.Default()))
+	workerMgr.Register(worker.NewAutomationScheduleWorker(pool, automationRuleRepo, repository.NewAutomationFiringRepository(), automationEventRepo, slog.Default()))
 	if cfg.WorkersEnabled {
 		go workerMgr.Start(context.Background())
 	}
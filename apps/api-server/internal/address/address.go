@@ -0,0 +1,167 @@
+// Package address validates and normalises shipping addresses before they
+// reach a carrier: country-specific postal code rules for the EU, Polish
+// postal-code-to-city checks against the official PNA list, street and
+// house number splitting and phone normalisation to E.164.
+package address
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/openoms-org/openoms/apps/api-server/internal/model"
+)
+
+// DefaultCountry is assumed for addresses without a country.
+const DefaultCountry = "PL"
+
+// countryNames maps country names commonly typed into marketplace and shop
+// forms to ISO 3166-1 alpha-2 codes. Keys are folded with fold.
+var countryNames = map[string]string{
+	"polska":      "PL",
+	"poland":      "PL",
+	"niemcy":      "DE",
+	"germany":     "DE",
+	"deutschland": "DE",
+	"czechy":      "CZ",
+	"czechia":     "CZ",
+	"slowacja":    "SK",
+	"slovakia":    "SK",
+	"litwa":       "LT",
+	"lithuania":   "LT",
+	"uk":          "GB",
+}
+
+// Result is the outcome of validating a shipping address.
+type Result struct {
+	// Address is the address with its country, postal code and phone
+	// normalised where they are valid.
+	Address model.ShippingAddress
+	// Street is the street split into its name, building and flat number.
+	Street StreetParts
+	// Issues lists the problems found; empty for a valid address.
+	Issues []model.AddressIssue
+}
+
+// Valid reports whether no issues were found.
+func (r Result) Valid() bool {
+	return len(r.Issues) == 0
+}
+
+func (r *Result) issue(field, code, format string, args ...any) {
+	r.Issues = append(r.Issues, model.AddressIssue{Field: field, Code: code, Message: fmt.Sprintf(format, args...)})
+}
+
+// Validator validates shipping addresses. It is safe for concurrent use.
+type Validator struct {
+	pna *PNA
+}
+
+// NewValidator returns a validator checking Polish postal codes against the
+// given PNA dataset; a nil dataset checks only the postal code format.
+func NewValidator(pna *PNA) *Validator {
+	return &Validator{pna: pna}
+}
+
+// Validate checks a shipping address and returns it normalised along with
+// the issues found.
+func (v *Validator) Validate(addr model.ShippingAddress) Result {
+	res := Result{Address: addr}
+	a := &res.Address
+	a.Name = strings.TrimSpace(a.Name)
+	a.Street = strings.Join(strings.Fields(a.Street), " ")
+	a.City = strings.Join(strings.Fields(a.City), " ")
+	a.PostalCode = strings.TrimSpace(a.PostalCode)
+	a.Phone = strings.TrimSpace(a.Phone)
+	a.Email = strings.TrimSpace(a.Email)
+
+	country, ok := NormalizeCountry(a.Country)
+	if ok {
+		a.Country = country
+	} else {
+		res.issue("country", model.AddressIssueInvalidFormat, "country %q is not an ISO 3166-1 alpha-2 code", a.Country)
+	}
+
+	if a.Street == "" {
+		res.issue("street", model.AddressIssueMissing, "street is missing")
+	} else {
+		res.Street = SplitStreet(a.Street, country)
+		if res.Street.Building == "" {
+			res.issue("street", model.AddressIssueHouseNumberMissing, "street %q has no house number", a.Street)
+		}
+	}
+
+	if a.City == "" {
+		res.issue("city", model.AddressIssueMissing, "city is missing")
+	}
+
+	if a.PostalCode == "" {
+		res.issue("postal_code", model.AddressIssueMissing, "postal code is missing")
+	} else if ok {
+		code, err := NormalizePostalCode(a.PostalCode, country)
+		if err != nil {
+			res.issue("postal_code", model.AddressIssueInvalidFormat, "%s", err.Error())
+		} else {
+			a.PostalCode = code
+			if country == "PL" && a.City != "" && v.pna != nil {
+				if cities, known := v.pna.Cities(code); known && !matchesCity(a.City, cities) {
+					res.issue("city", model.AddressIssueCityMismatch, "postal code %s belongs to %s, not %s", code, strings.Join(cities, ", "), a.City)
+				}
+			}
+		}
+	}
+
+	if a.Phone != "" {
+		phone, err := NormalizePhone(a.Phone, country)
+		if err != nil {
+			res.issue("phone", model.AddressIssueInvalidFormat, "%s", err.Error())
+		} else {
+			a.Phone = phone
+		}
+	}
+
+	return res
+}
+
+// NormalizeCountry returns the ISO 3166-1 alpha-2 code of a country given as
+// a code or a common name; an empty country is DefaultCountry. It reports
+// false when the country is not recognised.
+func NormalizeCountry(country string) (string, bool) {
+	country = strings.TrimSpace(country)
+	if country == "" {
+		return DefaultCountry, true
+	}
+	if code, ok := countryNames[fold(country)]; ok {
+		return code, true
+	}
+	if code := strings.ToUpper(country); countryCodes[code] {
+		return code, true
+	}
+	return "", false
+}
+
+// polishFold maps Polish letters to their ASCII base letters.
+var polishFold = strings.NewReplacer(
+	"ą", "a", "ć", "c", "ę", "e", "ł", "l", "ń", "n", "ó", "o", "ś", "s", "ź", "z", "ż", "z",
+)
+
+// fold lowercases s, replaces Polish letters with their ASCII base letters
+// and collapses whitespace, for comparing place names typed by customers.
+func fold(s string) string {
+	s = polishFold.Replace(strings.ToLower(s))
+	return strings.Join(strings.FieldsFunc(s, unicode.IsSpace), " ")
+}
+
+// matchesCity reports whether a city matches one of the cities of a postal
+// code. A city district ("Warszawa-Mokotów", "Kraków Nowa Huta") matches its
+// city.
+func matchesCity(city string, cities []string) bool {
+	c := fold(city)
+	for _, candidate := range cities {
+		want := fold(candidate)
+		if c == want || strings.HasPrefix(c, want+"-") || strings.HasPrefix(c, want+" ") {
+			return true
+		}
+	}
+	return false
+}
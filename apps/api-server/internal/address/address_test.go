@@ -0,0 +1,203 @@
+package address
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openoms-org/openoms/apps/api-server/internal/model"
+)
+
+// testPNA is a fragment of the official PNA list.
+const testPNA = "PNA;Miejscowość;Ulica;Numery;Gmina\n" +
+	"02-123;Warszawa (Ochota);Długa;;Warszawa\n" +
+	"31-019;Kraków;Floriańska;;Kraków\n" +
+	"90-001;Łódź;Piotrkowska;;Łódź\n"
+
+func newTestValidator(t *testing.T) *Validator {
+	pna := NewPNA()
+	require.NoError(t, pna.Load(strings.NewReader(testPNA)))
+	return NewValidator(pna)
+}
+
+func TestValidate_ValidPolishAddress(t *testing.T) {
+	v := newTestValidator(t)
+	res := v.Validate(model.ShippingAddress{
+		Name:       " Jan Kowalski ",
+		Street:     "ul. Długa  5/12",
+		City:       "Warszawa-Mokotów",
+		PostalCode: "02 123",
+		Country:    "Polska",
+		Phone:      "600 123 456",
+	})
+
+	assert.True(t, res.Valid(), "%v", res.Issues)
+	assert.Equal(t, "Jan Kowalski", res.Address.Name)
+	assert.Equal(t, "ul. Długa 5/12", res.Address.Street)
+	assert.Equal(t, "02-123", res.Address.PostalCode)
+	assert.Equal(t, "PL", res.Address.Country)
+	assert.Equal(t, "+48600123456", res.Address.Phone)
+	assert.Equal(t, StreetParts{Street: "ul. Długa", Building: "5", Flat: "12"}, res.Street)
+}
+
+func TestValidate_Issues(t *testing.T) {
+	v := newTestValidator(t)
+	res := v.Validate(model.ShippingAddress{
+		Street:     "Floriańska",
+		City:       "Lodz",
+		PostalCode: "31-019",
+		Phone:      "12345",
+	})
+
+	codes := map[string]string{}
+	for _, issue := range res.Issues {
+		codes[issue.Field] = issue.Code
+	}
+	assert.Equal(t, map[string]string{
+		"street": model.AddressIssueHouseNumberMissing,
+		"city":   model.AddressIssueCityMismatch,
+		"phone":  model.AddressIssueInvalidFormat,
+	}, codes)
+	assert.Equal(t, "PL", res.Address.Country)
+	assert.Equal(t, "12345", res.Address.Phone)
+}
+
+func TestValidate_MissingFields(t *testing.T) {
+	res := NewValidator(nil).Validate(model.ShippingAddress{Country: "Narnia"})
+
+	require.Len(t, res.Issues, 4)
+	assert.Equal(t, model.AddressIssue{Field: "country", Code: model.AddressIssueInvalidFormat, Message: `country "Narnia" is not an ISO 3166-1 alpha-2 code`}, res.Issues[0])
+	for _, issue := range res.Issues[1:] {
+		assert.Equal(t, model.AddressIssueMissing, issue.Code, issue.Field)
+	}
+}
+
+func TestValidate_CityFoldsDiacritics(t *testing.T) {
+	res := newTestValidator(t).Validate(model.ShippingAddress{
+		Street: "Piotrkowska 100", City: "LODZ", PostalCode: "90-001", Country: "pl",
+	})
+	assert.True(t, res.Valid(), "%v", res.Issues)
+}
+
+func TestValidate_PostalCodeFormatOnly(t *testing.T) {
+	for _, v := range []*Validator{NewValidator(nil), newTestValidator(t)} {
+		res := v.Validate(model.ShippingAddress{
+			Street: "Rynek 1", City: "Wieliczka", PostalCode: "32-020", Country: "PL",
+		})
+		assert.True(t, res.Valid(), "a code missing from the dataset is not checked: %v", res.Issues)
+	}
+}
+
+func TestNormalizeCountry(t *testing.T) {
+	for in, want := range map[string]string{"": "PL", "de": "DE", " Niemcy ": "DE", "UK": "GB", "gb": "GB"} {
+		got, ok := NormalizeCountry(in)
+		assert.True(t, ok, in)
+		assert.Equal(t, want, got, in)
+	}
+	for _, in := range []string{"XX", "EU", "QQ", "Narnia", "P"} {
+		_, ok := NormalizeCountry(in)
+		assert.False(t, ok, in)
+	}
+}
+
+func TestNormalizePostalCode(t *testing.T) {
+	tests := []struct {
+		code, country, want string
+	}{
+		{"00001", "PL", "00-001"},
+		{"11000", "CZ", "110 00"},
+		{"1012ab", "NL", "1012 AB"},
+		{"1000001", "PT", "1000-001"},
+		{"lv 1010", "LV", "LV-1010"},
+		{"1010", "LV", "LV-1010"},
+		{"L-1009", "LU", "1009"},
+		{"d02x285", "IE", "D02 X285"},
+		{"vlt1117", "MT", "VLT 1117"},
+		{"10115", "DE", "10115"},
+		{"SW1A 1AA", "GB", "SW1A 1AA"},
+	}
+	for _, tt := range tests {
+		got, err := NormalizePostalCode(tt.code, tt.country)
+		require.NoError(t, err, tt.code)
+		assert.Equal(t, tt.want, got, tt.code)
+	}
+
+	for _, tt := range []struct{ code, country string }{
+		{"0001", "PL"}, {"00-0011", "PL"}, {"1011", "DE"}, {"53001", "ES"}, {"0123 AB", "NL"},
+	} {
+		_, err := NormalizePostalCode(tt.code, tt.country)
+		assert.Error(t, err, tt.code)
+	}
+}
+
+func TestSplitStreet(t *testing.T) {
+	tests := []struct {
+		street, country string
+		want            StreetParts
+	}{
+		{"Długa 5", "PL", StreetParts{Street: "Długa", Building: "5"}},
+		{"Aleje Jerozolimskie 123A/45", "PL", StreetParts{Street: "Aleje Jerozolimskie", Building: "123A", Flat: "45"}},
+		{"Długa 5 m. 12", "PL", StreetParts{Street: "Długa", Building: "5", Flat: "12"}},
+		{"Długa 5, lok. 3", "PL", StreetParts{Street: "Długa", Building: "5", Flat: "3"}},
+		{"Długa 10 - 12", "PL", StreetParts{Street: "Długa", Building: "10-12"}},
+		{"3 Maja 7", "PL", StreetParts{Street: "3 Maja", Building: "7"}},
+		{"Aleja 3 Maja", "PL", StreetParts{Street: "Aleja 3 Maja"}},
+		{"3 Maja", "PL", StreetParts{Street: "3 Maja"}},
+		{"12 rue de la Paix", "FR", StreetParts{Street: "rue de la Paix", Building: "12"}},
+		{"Hauptstraße 7b", "DE", StreetParts{Street: "Hauptstraße", Building: "7b"}},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, SplitStreet(tt.street, tt.country), tt.street)
+	}
+}
+
+func TestNormalizePhone(t *testing.T) {
+	tests := []struct {
+		phone, country, want string
+	}{
+		{"600 123 456", "PL", "+48600123456"},
+		{"+48 600-123-456", "PL", "+48600123456"},
+		{"0048600123456", "PL", "+48600123456"},
+		{"48600123456", "PL", "+48600123456"},
+		{"+49 (0)30 1234567", "DE", "+49301234567"},
+		{"030 1234567", "DE", "+49301234567"},
+		{"06 1234 5678", "IT", "+390612345678"},
+		{"+420 601 123 456", "", "+420601123456"},
+	}
+	for _, tt := range tests {
+		got, err := NormalizePhone(tt.phone, tt.country)
+		require.NoError(t, err, tt.phone)
+		assert.Equal(t, tt.want, got, tt.phone)
+	}
+
+	for _, tt := range []struct{ phone, country string }{
+		{"", "PL"}, {"60012345", "PL"}, {"+48 6001234567", "PL"}, {"600 123 456 wew. 12", "PL"}, {"600123456", "US"},
+	} {
+		_, err := NormalizePhone(tt.phone, tt.country)
+		assert.Error(t, err, tt.phone)
+	}
+}
+
+func TestPNA_Load(t *testing.T) {
+	p := NewPNA()
+	err := p.Load(strings.NewReader("PNA;Miejscowość;Ulica;Numery;Gmina\n" +
+		"05-500;Piaseczno;;;Piaseczno\n" +
+		"05-500;Józefosław;;;Piaseczno\n" +
+		"02-495;Warszawa (Ursus);Aleja;;Warszawa\n"))
+	require.NoError(t, err)
+
+	cities, ok := p.Cities("05-500")
+	assert.True(t, ok)
+	assert.Equal(t, []string{"Piaseczno", "Józefosław"}, cities)
+
+	cities, ok = p.Cities("02-495")
+	assert.True(t, ok)
+	assert.Equal(t, []string{"Warszawa"}, cities)
+
+	_, ok = p.Cities("99-999")
+	assert.False(t, ok)
+
+	assert.Error(t, NewPNA().Load(strings.NewReader("01-000; ;Warszawa\n")))
+}
@@ -0,0 +1,76 @@
+package address
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// callingCodes maps countries to their international calling codes: the EU
+// member states and the neighbours Polish shops most often ship to.
+var callingCodes = map[string]string{
+	"AT": "43", "BE": "32", "BG": "359", "CY": "357", "CZ": "420",
+	"DE": "49", "DK": "45", "EE": "372", "ES": "34", "FI": "358",
+	"FR": "33", "GR": "30", "HR": "385", "HU": "36", "IE": "353",
+	"IT": "39", "LT": "370", "LU": "352", "LV": "371", "MT": "356",
+	"NL": "31", "PL": "48", "PT": "351", "RO": "40", "SE": "46",
+	"SI": "386", "SK": "421",
+	"CH": "41", "GB": "44", "NO": "47", "UA": "380",
+}
+
+// polishNumberLength is the length of a Polish national number.
+const polishNumberLength = 9
+
+// NormalizePhone returns a phone number in E.164 format ("+48600123456").
+// Numbers without an international prefix get the calling code of the
+// country, after dropping a trunk prefix 0 where the country uses one.
+func NormalizePhone(phone, country string) (string, error) {
+	raw := strings.TrimSpace(phone)
+	if raw == "" {
+		return "", errors.New("phone number is empty")
+	}
+	s := strings.ReplaceAll(raw, "(0)", "")
+
+	international := false
+	var digits strings.Builder
+	for i, r := range s {
+		switch {
+		case r >= '0' && r <= '9':
+			digits.WriteRune(r)
+		case r == '+' && i == 0:
+			international = true
+		case r == ' ' || r == '-' || r == '.' || r == '(' || r == ')' || r == '/':
+		default:
+			return "", fmt.Errorf("phone number %q contains invalid characters", raw)
+		}
+	}
+	number := digits.String()
+
+	if !international {
+		if rest, ok := strings.CutPrefix(number, "00"); ok {
+			number, international = rest, true
+		}
+	}
+	if !international {
+		code, ok := callingCodes[country]
+		if !ok {
+			return "", fmt.Errorf("phone number %q has no international prefix and the calling code of %q is unknown", raw, country)
+		}
+		// A Polish number may already carry the calling code, written
+		// without "+"; Italy keeps the leading 0 of landline numbers.
+		if !(country == "PL" && len(number) == len(code)+polishNumberLength && strings.HasPrefix(number, code)) {
+			if country != "IT" {
+				number = strings.TrimPrefix(number, "0")
+			}
+			number = code + number
+		}
+	}
+
+	if len(number) < 8 || len(number) > 15 || number[0] == '0' {
+		return "", fmt.Errorf("phone number %q is not valid", raw)
+	}
+	if national, ok := strings.CutPrefix(number, callingCodes["PL"]); ok && len(national) != polishNumberLength {
+		return "", fmt.Errorf("phone number %q is not valid: Polish numbers have %d digits", raw, polishNumberLength)
+	}
+	return "+" + number, nil
+}
@@ -0,0 +1,91 @@
+package address
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// PNA maps Polish postal codes (PNA) to the cities they serve, as loaded
+// from the official Poczta Polska list. There is no built-in dataset: a
+// partial one would reject valid addresses outside the cities it covers.
+type PNA struct {
+	codes map[int][]string
+}
+
+// NewPNA returns an empty dataset.
+func NewPNA() *PNA {
+	return &PNA{codes: make(map[int][]string)}
+}
+
+// Load adds entries read from semicolon-separated lines of the official
+// list: "00-001;Warszawa", where further columns (street, numbers, commune)
+// are ignored. Lines that do not start with a postal code, such as a header,
+// are skipped.
+func (p *PNA) Load(r io.Reader) error {
+	sc := bufio.NewScanner(r)
+	line := 0
+	for sc.Scan() {
+		line++
+		fields := strings.Split(strings.TrimPrefix(sc.Text(), "\ufeff"), ";")
+		if len(fields) < 2 {
+			continue
+		}
+		code, ok := parsePNA(fields[0])
+		if !ok {
+			continue
+		}
+		city := cityName(fields[1])
+		if city == "" {
+			return fmt.Errorf("line %d: missing city", line)
+		}
+		if !containsFold(p.codes[code], city) {
+			p.codes[code] = append(p.codes[code], city)
+		}
+	}
+	return sc.Err()
+}
+
+// Cities returns the cities served by a postal code in "NN-NNN" form and
+// whether the dataset covers the code.
+func (p *PNA) Cities(code string) ([]string, bool) {
+	n, ok := parsePNA(code)
+	if !ok {
+		return nil, false
+	}
+	cities, ok := p.codes[n]
+	return cities, ok
+}
+
+// parsePNA parses a postal code in "NN-NNN" form into a number.
+func parsePNA(s string) (int, bool) {
+	s = strings.TrimSpace(s)
+	if len(s) != 6 || s[2] != '-' {
+		return 0, false
+	}
+	n, err := strconv.Atoi(s[:2] + s[3:])
+	if err != nil || n < 0 {
+		return 0, false
+	}
+	return n, true
+}
+
+// cityName trims a city and drops the district the official list gives in
+// parentheses ("Warszawa (Mokotów)").
+func cityName(s string) string {
+	if i := strings.Index(s, "("); i >= 0 {
+		s = s[:i]
+	}
+	return strings.TrimSpace(s)
+}
+
+func containsFold(cities []string, city string) bool {
+	for _, c := range cities {
+		if fold(c) == fold(city) {
+			return true
+		}
+	}
+	return false
+}
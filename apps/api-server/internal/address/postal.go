@@ -0,0 +1,99 @@
+package address
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// postalRule is the postal code format of a country. Patterns match the
+// compact form of a code: uppercase, without spaces and hyphens.
+type postalRule struct {
+	pattern *regexp.Regexp
+	// format renders a compact code in its canonical form.
+	format func(string) string
+	// example is a valid code shown in issue messages.
+	example string
+	// prefix is the country prefix some countries write before the code
+	// ("LV-1010"); it is dropped before matching.
+	prefix string
+}
+
+var (
+	digits4 = regexp.MustCompile(`^\d{4}$`)
+	digits5 = regexp.MustCompile(`^\d{5}$`)
+)
+
+func asIs(code string) string { return code }
+
+// split returns a formatter inserting sep after the first n characters.
+func split(n int, sep string) func(string) string {
+	return func(code string) string { return code[:n] + sep + code[n:] }
+}
+
+func prefixed(prefix string) func(string) string {
+	return func(code string) string { return prefix + "-" + code }
+}
+
+// postalRules holds the postal code formats of the EU member states.
+var postalRules = map[string]postalRule{
+	"AT": {pattern: digits4, format: asIs, example: "1010"},
+	"BE": {pattern: digits4, format: asIs, example: "1000"},
+	"BG": {pattern: digits4, format: asIs, example: "1000"},
+	"CY": {pattern: digits4, format: asIs, example: "1010"},
+	"CZ": {pattern: digits5, format: split(3, " "), example: "110 00"},
+	"DE": {pattern: digits5, format: asIs, example: "10115"},
+	"DK": {pattern: digits4, format: asIs, example: "1050"},
+	"EE": {pattern: digits5, format: asIs, example: "10111"},
+	"ES": {pattern: regexp.MustCompile(`^(0[1-9]|[1-4]\d|5[0-2])\d{3}$`), format: asIs, example: "28001"},
+	"FI": {pattern: digits5, format: asIs, example: "00100"},
+	"FR": {pattern: digits5, format: asIs, example: "75001"},
+	"GR": {pattern: digits5, format: split(3, " "), example: "105 57"},
+	"HR": {pattern: digits5, format: asIs, example: "10000"},
+	"HU": {pattern: digits4, format: asIs, example: "1011"},
+	"IE": {pattern: regexp.MustCompile(`^([AC-FHKNPRTV-Y]\d{2}|D6W)[0-9AC-FHKNPRTV-Y]{4}$`), format: split(3, " "), example: "D02 X285"},
+	"IT": {pattern: digits5, format: asIs, example: "00118"},
+	"LT": {pattern: digits5, format: prefixed("LT"), example: "LT-01100", prefix: "LT"},
+	"LU": {pattern: digits4, format: asIs, example: "1009", prefix: "L"},
+	"LV": {pattern: digits4, format: prefixed("LV"), example: "LV-1010", prefix: "LV"},
+	"MT": {pattern: regexp.MustCompile(`^[A-Z]{3}\d{4}$`), format: split(3, " "), example: "VLT 1117"},
+	"NL": {pattern: regexp.MustCompile(`^[1-9]\d{3}[A-Z]{2}$`), format: split(4, " "), example: "1012 AB"},
+	"PL": {pattern: digits5, format: split(2, "-"), example: "00-001"},
+	"PT": {pattern: regexp.MustCompile(`^\d{7}$`), format: split(4, "-"), example: "1000-001"},
+	"RO": {pattern: regexp.MustCompile(`^\d{6}$`), format: asIs, example: "010011"},
+	"SE": {pattern: digits5, format: split(3, " "), example: "111 22"},
+	"SI": {pattern: digits4, format: asIs, example: "1000", prefix: "SI"},
+	"SK": {pattern: digits5, format: split(3, " "), example: "811 01"},
+}
+
+// compactPostalCode uppercases a postal code and drops spaces and hyphens.
+func compactPostalCode(code string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '-', '\u00a0':
+			return -1
+		}
+		return r
+	}, strings.ToUpper(code))
+}
+
+// NormalizePostalCode checks a postal code against the format of an EU
+// country and returns it in its canonical form ("00001" becomes "00-001" in
+// Poland). Codes of countries without a known format are returned trimmed.
+func NormalizePostalCode(code, country string) (string, error) {
+	code = strings.TrimSpace(code)
+	rule, ok := postalRules[country]
+	if !ok {
+		return code, nil
+	}
+	compact := compactPostalCode(code)
+	if rule.prefix != "" {
+		if rest, found := strings.CutPrefix(compact, rule.prefix); found && rule.pattern.MatchString(rest) {
+			compact = rest
+		}
+	}
+	if !rule.pattern.MatchString(compact) {
+		return "", fmt.Errorf("postal code %q is not valid in %s (expected e.g. %s)", code, country, rule.example)
+	}
+	return rule.format(compact), nil
+}
@@ -0,0 +1,47 @@
+package address
+
+import "regexp"
+
+// StreetParts is a street line split into the street name, the building
+// number and the optional flat number.
+type StreetParts struct {
+	Street   string `json:"street"`
+	Building string `json:"building,omitempty"`
+	Flat     string `json:"flat,omitempty"`
+}
+
+var (
+	// numberLast matches "Długa 5", "Długa 5A/12", "Długa 5 m. 12",
+	// "Długa 5 lok. 3", "Długa 10-12" and "3 Maja 7".
+	numberLast = regexp.MustCompile(`(?i)^(.*?\S)[\s,]+(\d+[a-z]?(?:\s*-\s*\d+[a-z]?)?)(?:(?:\s*/\s*|[\s,]+(?:m|lok|apt|flat|apartment)\.?\s*)(\d+[a-z]?))?$`)
+	// numberFirst matches "12 rue de la Paix" and "12/3, High Street".
+	numberFirst = regexp.MustCompile(`(?i)^(\d+[a-z]?(?:-\d+[a-z]?)?)(?:\s*/\s*(\d+[a-z]?))?[\s,]+(\D.*)$`)
+)
+
+// SplitStreet splits a street line into the street name, building and flat
+// number. Polish addresses put the number after the street name; other
+// countries may put it first ("12 rue de la Paix"). A street without a
+// recognisable number is returned with an empty Building.
+func SplitStreet(street, country string) StreetParts {
+	if m := numberLast.FindStringSubmatch(street); m != nil {
+		return StreetParts{Street: m[1], Building: compactBuilding(m[2]), Flat: m[3]}
+	}
+	if country != "PL" {
+		if m := numberFirst.FindStringSubmatch(street); m != nil {
+			return StreetParts{Street: m[3], Building: m[1], Flat: m[2]}
+		}
+	}
+	return StreetParts{Street: street}
+}
+
+// compactBuilding drops spaces from a building number range ("10 - 12"
+// becomes "10-12").
+func compactBuilding(s string) string {
+	out := make([]byte, 0, len(s))
+	for i := 0; i < len(s); i++ {
+		if s[i] != ' ' {
+			out = append(out, s[i])
+		}
+	}
+	return string(out)
+}
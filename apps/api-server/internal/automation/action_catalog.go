@@ -0,0 +1,318 @@
+package automation
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/openoms-org/openoms/apps/api-server/internal/model"
+)
+
+// Action parameter kinds.
+const (
+	ParamString = "string"
+	ParamUUID   = "uuid"
+	ParamBool   = "bool"
+	ParamEnum   = "enum"
+)
+
+const (
+	maxRuleActions     = 20
+	maxActionDelay     = 30 * 24 * 60 * 60
+	maxActionParamSize = 5000
+)
+
+// ActionParam describes a parameter of an action type. Template parameters
+// may reference event data as {{field}} or {{entity.field}}.
+type ActionParam struct {
+	Name     string
+	Kind     string
+	Required bool
+	Template bool
+	Values   []string
+}
+
+// ActionSpec describes an action type. OrderAction actions act on the order
+// of the event: the event entity itself, or the order_id of a shipment or
+// return event. Once actions send something or append a record, so they are
+// not repeated when a queued event is retried after they succeeded.
+type ActionSpec struct {
+	Params      []ActionParam
+	OrderAction bool
+	Once        bool
+}
+
+// ActionCatalog lists the supported action types and their parameters.
+var ActionCatalog = map[string]ActionSpec{
+	"set_status": {Params: []ActionParam{
+		{Name: "status", Kind: ParamString, Required: true},
+	}},
+	"add_tag": {Params: []ActionParam{
+		{Name: "tag", Kind: ParamString, Required: true},
+	}},
+	"send_email": {Once: true, Params: []ActionParam{
+		{Name: "to", Kind: ParamString, Required: true, Template: true},
+		{Name: "subject", Kind: ParamString, Template: true},
+		{Name: "body", Kind: ParamString, Template: true},
+	}},
+	"create_invoice": {Params: []ActionParam{
+		{Name: "invoice_type", Kind: ParamString},
+	}},
+	"webhook": {Once: true, Params: []ActionParam{
+		{Name: "url", Kind: ParamString, Required: true},
+	}},
+	"assign_user": {OrderAction: true, Params: []ActionParam{
+		{Name: "user_id", Kind: ParamUUID, Required: true},
+	}},
+	"set_priority": {OrderAction: true, Params: []ActionParam{
+		{Name: "priority", Kind: ParamEnum, Required: true, Values: []string{"low", "normal", "high", "urgent"}},
+	}},
+	"create_shipment": {OrderAction: true, Params: []ActionParam{
+		{Name: "provider", Kind: ParamString, Required: true},
+		{Name: "generate_label", Kind: ParamBool},
+		{Name: "service_type", Kind: ParamString},
+		{Name: "parcel_size", Kind: ParamString},
+		{Name: "label_format", Kind: ParamEnum, Values: []string{"pdf", "zpl", "epl"}},
+	}},
+	"send_sms": {OrderAction: true, Once: true, Params: []ActionParam{
+		{Name: "message", Kind: ParamString, Required: true, Template: true},
+		{Name: "phone", Kind: ParamString, Template: true},
+	}},
+	"create_freshdesk_ticket": {OrderAction: true, Once: true, Params: []ActionParam{
+		{Name: "subject", Kind: ParamString, Required: true, Template: true},
+		{Name: "description", Kind: ParamString, Required: true, Template: true},
+		{Name: "email", Kind: ParamString, Template: true},
+	}},
+	"split_order_by_warehouse": {OrderAction: true, Once: true},
+	"add_internal_note": {OrderAction: true, Once: true, Params: []ActionParam{
+		{Name: "note", Kind: ParamString, Required: true, Template: true},
+	}},
+	"allegro_message_buyer": {OrderAction: true, Once: true, Params: []ActionParam{
+		{Name: "message", Kind: ParamString, Required: true, Template: true},
+	}},
+	"place_hold": {OrderAction: true, Params: []ActionParam{
+		{Name: "reason", Kind: ParamEnum, Required: true, Values: model.HoldReasons},
+		{Name: "note", Kind: ParamString, Template: true},
+	}},
+	"release_hold": {OrderAction: true, Params: []ActionParam{
+		{Name: "reason", Kind: ParamEnum, Required: true, Values: model.HoldReasons},
+		{Name: "note", Kind: ParamString, Template: true},
+	}},
+}
+
+// UnmarshalJSON reads the action parameters from "params" or, as saved by the
+// dashboard, "config".
+func (a *Action) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Type         string         `json:"type"`
+		Params       map[string]any `json:"params"`
+		Config       map[string]any `json:"config"`
+		DelaySeconds int            `json:"delay_seconds"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	a.Type = raw.Type
+	a.Params = raw.Params
+	if a.Params == nil {
+		a.Params = raw.Config
+	}
+	a.DelaySeconds = raw.DelaySeconds
+	return nil
+}
+
+// ParseActions parses the stored actions of a rule.
+func ParseActions(raw json.RawMessage) ([]Action, error) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || bytes.Equal(raw, []byte("null")) {
+		return nil, nil
+	}
+	var actions []Action
+	if err := json.Unmarshal(raw, &actions); err != nil {
+		return nil, errors.New("actions must be an array of actions")
+	}
+	return actions, nil
+}
+
+// ValidateActions parses and validates the actions of a rule.
+func ValidateActions(raw json.RawMessage) error {
+	actions, err := ParseActions(raw)
+	if err != nil {
+		return err
+	}
+	if len(actions) > maxRuleActions {
+		return fmt.Errorf("a rule may have at most %d actions", maxRuleActions)
+	}
+	for i, action := range actions {
+		if err := ValidateAction(action); err != nil {
+			return fmt.Errorf("action %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+// ValidateAction checks an action's type and parameters. Template parameters
+// are checked for shape only when they hold no placeholders.
+func ValidateAction(action Action) error {
+	spec, ok := ActionCatalog[action.Type]
+	if !ok {
+		return fmt.Errorf("invalid action type %q", action.Type)
+	}
+	if action.DelaySeconds < 0 || action.DelaySeconds > maxActionDelay {
+		return errors.New("delay_seconds must be between 0 and 30 days")
+	}
+	for _, p := range spec.Params {
+		if err := p.validate(action.Params[p.Name]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p ActionParam) validate(v any) error {
+	if v == nil || v == "" {
+		if p.Required {
+			return fmt.Errorf("%s is required", p.Name)
+		}
+		return nil
+	}
+
+	if p.Kind == ParamBool {
+		if _, ok := v.(bool); !ok {
+			return fmt.Errorf("%s must be true or false", p.Name)
+		}
+		return nil
+	}
+
+	s, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("%s must be a string", p.Name)
+	}
+	if p.Required && strings.TrimSpace(s) == "" {
+		return fmt.Errorf("%s is required", p.Name)
+	}
+	if len(s) > maxActionParamSize {
+		return fmt.Errorf("%s may be at most %d characters", p.Name, maxActionParamSize)
+	}
+	if p.Template && HasPlaceholders(s) {
+		return nil
+	}
+	switch p.Kind {
+	case ParamUUID:
+		if _, err := uuid.Parse(s); err != nil {
+			return fmt.Errorf("%s must be a UUID", p.Name)
+		}
+	case ParamEnum:
+		if !slices.Contains(p.Values, s) {
+			return fmt.Errorf("%s must be one of: %s", p.Name, strings.Join(p.Values, ", "))
+		}
+	}
+	return nil
+}
+
+var placeholderPattern = regexp.MustCompile(`\{\{\s*([A-Za-z0-9_.]+)\s*\}\}`)
+
+// HasPlaceholders reports whether s contains {{...}} placeholders.
+func HasPlaceholders(s string) bool {
+	return placeholderPattern.MatchString(s)
+}
+
+// RenderTemplate replaces the {{path}} placeholders in s with values from
+// data. A path prefixed with the event's entity type ({{order.customer_name}}
+// on an order event) also resolves against the top level of data. It returns
+// the placeholders that resolved to nothing; they render as empty strings.
+func RenderTemplate(s string, data map[string]any, entityType string) (string, []string) {
+	var missing []string
+	out := placeholderPattern.ReplaceAllStringFunc(s, func(m string) string {
+		path := placeholderPattern.FindStringSubmatch(m)[1]
+		v := resolveField(path, data)
+		if v == nil && entityType != "" {
+			if rest, ok := strings.CutPrefix(path, entityType+"."); ok {
+				v = resolveField(rest, data)
+			}
+		}
+		if v == nil {
+			missing = append(missing, path)
+			return ""
+		}
+		return templateValue(v)
+	})
+	return out, missing
+}
+
+// RenderParams renders the template parameters of an action against data and
+// returns a copy of its parameters.
+func RenderParams(action Action, data map[string]any, entityType string) (map[string]any, []string) {
+	params := make(map[string]any, len(action.Params))
+	for k, v := range action.Params {
+		params[k] = v
+	}
+	var missing []string
+	for _, p := range ActionCatalog[action.Type].Params {
+		s, ok := params[p.Name].(string)
+		if !ok || !p.Template {
+			continue
+		}
+		rendered, m := RenderTemplate(s, data, entityType)
+		params[p.Name] = rendered
+		missing = append(missing, m...)
+	}
+	return params, missing
+}
+
+func templateValue(v any) string {
+	switch t := v.(type) {
+	case string:
+		return t
+	case float64:
+		return strconv.FormatFloat(t, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(t)
+	case json.Number:
+		return t.String()
+	case []any, map[string]any:
+		b, _ := json.Marshal(t)
+		return string(b)
+	default:
+		return fmt.Sprint(t)
+	}
+}
+
+// ParamValue returns a trimmed string parameter, or "" when it is missing.
+func ParamValue(params map[string]any, name string) string {
+	s, _ := params[name].(string)
+	return strings.TrimSpace(s)
+}
+
+// DryRunAction validates an action and renders its parameters against data
+// without executing it.
+func DryRunAction(action Action, data map[string]any, entityType string) model.ActionDryRun {
+	params, missing := RenderParams(action, data, entityType)
+	result := model.ActionDryRun{
+		Type:         action.Type,
+		Params:       params,
+		DelaySeconds: action.DelaySeconds,
+		Missing:      missing,
+	}
+	if result.Missing == nil {
+		result.Missing = []string{}
+	}
+	if err := ValidateAction(Action{Type: action.Type, Params: params, DelaySeconds: action.DelaySeconds}); err != nil {
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// TriggerEntityType returns the entity type of a trigger event
+// ("order.created" is an order event).
+func TriggerEntityType(triggerEvent string) string {
+	entity, _, _ := strings.Cut(triggerEvent, ".")
+	return entity
+}
@@ -0,0 +1,97 @@
+package automation
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestAction_UnmarshalConfigAlias(t *testing.T) {
+	actions, err := ParseActions(json.RawMessage(`[
+		{"type": "add_tag", "config": {"tag": "vip"}},
+		{"type": "webhook", "params": {"url": "https://example.com"}, "delay_seconds": 60}
+	]`))
+	if err != nil {
+		t.Fatalf("parse actions: %v", err)
+	}
+	if actions[0].Params["tag"] != "vip" {
+		t.Errorf("expected config to be read as params, got %+v", actions[0].Params)
+	}
+	if actions[1].Params["url"] != "https://example.com" || actions[1].DelaySeconds != 60 {
+		t.Errorf("unexpected action %+v", actions[1])
+	}
+}
+
+func TestValidateActions(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		wantErr string
+	}{
+		{"empty", `[]`, ""},
+		{"assign user", `[{"type": "assign_user", "config": {"user_id": "7a4c9f64-1b1e-4f6f-9f3a-7d2b0c1e5a10"}}]`, ""},
+		{"invalid user id", `[{"type": "assign_user", "config": {"user_id": "jan"}}]`, "user_id must be a UUID"},
+		{"invalid priority", `[{"type": "set_priority", "config": {"priority": "asap"}}]`, "priority must be one of"},
+		{"templated sms", `[{"type": "send_sms", "config": {"message": "Dzień dobry {{order.customer_name}}"}}]`, ""},
+		{"missing note", `[{"type": "add_internal_note", "config": {"note": "  "}}]`, "note is required"},
+		{"label flag not bool", `[{"type": "create_shipment", "config": {"provider": "inpost", "generate_label": "yes"}}]`, "generate_label must be true or false"},
+		{"split needs no params", `[{"type": "split_order_by_warehouse"}]`, ""},
+		{"place hold", `[{"type": "place_hold", "config": {"reason": "fraud_check", "note": "{{order.customer_email}}"}}]`, ""},
+		{"invalid hold reason", `[{"type": "release_hold", "config": {"reason": "lunch_break"}}]`, "reason must be one of"},
+		{"unknown type", `[{"type": "launch_rocket"}]`, "invalid action type"},
+		{"negative delay", `[{"type": "add_tag", "config": {"tag": "x"}, "delay_seconds": -1}]`, "delay_seconds"},
+		{"not an array", `{"type": "add_tag"}`, "must be an array"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateActions(json.RawMessage(tt.raw))
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestRenderTemplate(t *testing.T) {
+	data := map[string]any{
+		"customer_name": "Jan Kowalski",
+		"total_amount":  149.5,
+		"order":         map[string]any{"external_id": "A-100"},
+	}
+
+	got, missing := RenderTemplate("{{order.customer_name}}: {{ total_amount }} PLN, {{order.external_id}}", data, "order")
+	if got != "Jan Kowalski: 149.5 PLN, A-100" {
+		t.Errorf("unexpected render %q", got)
+	}
+	if len(missing) != 0 {
+		t.Errorf("expected no missing placeholders, got %v", missing)
+	}
+
+	got, missing = RenderTemplate("Paczka {{shipment.tracking_number}}", data, "order")
+	if got != "Paczka " || len(missing) != 1 || missing[0] != "shipment.tracking_number" {
+		t.Errorf("unexpected render %q, missing %v", got, missing)
+	}
+}
+
+func TestDryRunAction(t *testing.T) {
+	action := Action{Type: "send_sms", Params: map[string]any{"message": "{{order.customer_phone}}"}}
+
+	result := DryRunAction(action, map[string]any{"customer_phone": "+48500100200"}, "order")
+	if result.Params["message"] != "+48500100200" || result.Error != "" {
+		t.Errorf("unexpected dry run %+v", result)
+	}
+
+	result = DryRunAction(action, map[string]any{}, "order")
+	if result.Error == "" || len(result.Missing) != 1 {
+		t.Errorf("expected a missing placeholder to fail the required message, got %+v", result)
+	}
+	if action.Params["message"] != "{{order.customer_phone}}" {
+		t.Error("dry run must not modify the action")
+	}
+}
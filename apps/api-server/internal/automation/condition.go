@@ -3,9 +3,16 @@ package automation
 import (
 	"fmt"
 	"reflect"
+	"regexp"
 	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
 )
 
+// now is the clock for date-relative operators, replaced in tests.
+var now = time.Now
+
 // EvaluateConditions evaluates all conditions against the given data.
 // Returns true if all conditions are met (AND logic).
 // Returns true if there are no conditions.
@@ -47,6 +54,46 @@ func evaluateCondition(cond Condition, data map[string]any) bool {
 		return !compareContains(fieldValue, cond.Value)
 	case "starts_with":
 		return compareStartsWith(fieldValue, cond.Value)
+	case "regex":
+		return compareRegex(fieldValue, cond.Value)
+	case "between":
+		lo, hi, ok := betweenBounds(cond.Value)
+		if !ok || fieldValue == nil {
+			return false
+		}
+		v := toFloat64(fieldValue)
+		return v >= lo && v <= hi
+	case "is_empty":
+		return isEmpty(fieldValue)
+	case "is_not_empty":
+		return !isEmpty(fieldValue)
+	case "length_eq", "length_gt", "length_lt":
+		n, ok := conditionInt(cond.Value)
+		if !ok {
+			return false
+		}
+		length := valueLength(fieldValue)
+		switch cond.Operator {
+		case "length_eq":
+			return length == n
+		case "length_gt":
+			return length > n
+		default:
+			return length < n
+		}
+	case "older_than", "newer_than":
+		age, err := ParseAge(cond.Value)
+		if err != nil {
+			return false
+		}
+		t, ok := toTime(fieldValue)
+		if !ok {
+			return false
+		}
+		if cond.Operator == "older_than" {
+			return now().Sub(t) > age
+		}
+		return now().Sub(t) <= age
 	default:
 		return false
 	}
@@ -150,3 +197,117 @@ func compareStartsWith(fieldValue, prefixValue any) bool {
 	prefixStr := fmt.Sprintf("%v", prefixValue)
 	return strings.HasPrefix(fieldStr, prefixStr)
 }
+
+// regexCache holds compiled patterns; rules are few, so it is not bounded.
+var regexCache sync.Map
+
+func compareRegex(fieldValue, patternValue any) bool {
+	if fieldValue == nil {
+		return false
+	}
+	pattern, ok := patternValue.(string)
+	if !ok {
+		return false
+	}
+	var re *regexp.Regexp
+	if cached, ok := regexCache.Load(pattern); ok {
+		re = cached.(*regexp.Regexp)
+	} else {
+		compiled, err := regexp.Compile(pattern)
+		if err != nil {
+			return false
+		}
+		regexCache.Store(pattern, compiled)
+		re = compiled
+	}
+	return re.MatchString(fmt.Sprintf("%v", fieldValue))
+}
+
+// betweenBounds reads the [min, max] value of the between operator.
+func betweenBounds(v any) (float64, float64, bool) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice || rv.Len() != 2 {
+		return 0, 0, false
+	}
+	lo := toFloat64(rv.Index(0).Interface())
+	hi := toFloat64(rv.Index(1).Interface())
+	return lo, hi, lo <= hi
+}
+
+// conditionInt reads a whole number from a JSON value.
+func conditionInt(v any) (int, bool) {
+	switch n := v.(type) {
+	case float64:
+		if n != float64(int(n)) {
+			return 0, false
+		}
+		return int(n), true
+	case int:
+		return n, true
+	case string:
+		var i int
+		if _, err := fmt.Sscanf(n, "%d", &i); err != nil {
+			return 0, false
+		}
+		return i, true
+	default:
+		return 0, false
+	}
+}
+
+func isEmpty(v any) bool {
+	if v == nil {
+		return true
+	}
+	if s, ok := v.(string); ok {
+		return strings.TrimSpace(s) == ""
+	}
+	return valueLength(v) == 0 && isCollection(v)
+}
+
+func isCollection(v any) bool {
+	switch reflect.ValueOf(v).Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return true
+	}
+	return false
+}
+
+// valueLength is the number of elements of an array or map, or of characters
+// of a string.
+func valueLength(v any) int {
+	if v == nil {
+		return 0
+	}
+	if s, ok := v.(string); ok {
+		return utf8.RuneCountInString(s)
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return rv.Len()
+	}
+	return 0
+}
+
+// toTime reads a timestamp from event data: a time.Time or an RFC 3339 or
+// YYYY-MM-DD string.
+func toTime(v any) (time.Time, bool) {
+	switch t := v.(type) {
+	case time.Time:
+		return t, !t.IsZero()
+	case *time.Time:
+		if t == nil {
+			return time.Time{}, false
+		}
+		return *t, true
+	case string:
+		if parsed, err := time.Parse(time.RFC3339, t); err == nil {
+			return parsed, true
+		}
+		if parsed, err := time.Parse(time.DateOnly, t); err == nil {
+			return parsed, true
+		}
+	}
+	return time.Time{}, false
+}
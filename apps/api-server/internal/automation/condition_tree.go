@@ -0,0 +1,246 @@
+package automation
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/openoms-org/openoms/apps/api-server/internal/model"
+)
+
+// Group operators of a condition tree.
+const (
+	GroupAnd = "and"
+	GroupOr  = "or"
+	GroupNot = "not"
+)
+
+const (
+	maxConditionDepth = 8
+	maxConditionNodes = 100
+	maxRegexLength    = 500
+)
+
+// ConditionNode is a node of a rule's condition tree: either a group that
+// combines its child conditions with "and" or "or", a "not" group negating its
+// single child, or a leaf comparing a field with a value.
+//
+//	{"op": "and", "conditions": [
+//	  {"field": "source", "operator": "eq", "value": "allegro"},
+//	  {"op": "or", "conditions": [
+//	    {"field": "total_amount", "operator": "gt", "value": 500},
+//	    {"field": "tags", "operator": "contains", "value": "vip"}
+//	  ]}
+//	]}
+type ConditionNode struct {
+	Op         string          `json:"op,omitempty"`
+	Conditions []ConditionNode `json:"conditions,omitempty"`
+	Field      string          `json:"field,omitempty"`
+	Operator   string          `json:"operator,omitempty"`
+	Value      any             `json:"value"`
+}
+
+// MarshalJSON writes a group as op and conditions, and a leaf as field,
+// operator and value. A leaf always keeps its value, so comparisons with 0,
+// "" or false survive a round trip.
+func (n ConditionNode) MarshalJSON() ([]byte, error) {
+	if n.Op != "" {
+		return json.Marshal(struct {
+			Op         string          `json:"op"`
+			Conditions []ConditionNode `json:"conditions"`
+		}{n.Op, n.Conditions})
+	}
+	return json.Marshal(struct {
+		Field    string `json:"field"`
+		Operator string `json:"operator"`
+		Value    any    `json:"value"`
+	}{n.Field, n.Operator, n.Value})
+}
+
+// ParseConditions parses the stored conditions of a rule. Rules created before
+// condition trees hold a flat array, which is read as an "and" group. A nil
+// node means the rule has no conditions.
+func ParseConditions(raw json.RawMessage) (*ConditionNode, error) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || bytes.Equal(raw, []byte("null")) {
+		return nil, nil
+	}
+	if raw[0] == '[' {
+		var flat []ConditionNode
+		if err := json.Unmarshal(raw, &flat); err != nil {
+			return nil, fmt.Errorf("parse conditions: %w", err)
+		}
+		if len(flat) == 0 {
+			return nil, nil
+		}
+		return &ConditionNode{Op: GroupAnd, Conditions: flat}, nil
+	}
+	var node ConditionNode
+	if err := json.Unmarshal(raw, &node); err != nil {
+		return nil, fmt.Errorf("parse conditions: %w", err)
+	}
+	return &node, nil
+}
+
+// ValidateConditions parses and validates the conditions of a rule.
+func ValidateConditions(raw json.RawMessage) error {
+	node, err := ParseConditions(raw)
+	if err != nil {
+		return errors.New("conditions must be an array or a condition group")
+	}
+	if node == nil {
+		return nil
+	}
+	count := 0
+	return node.validate(1, &count)
+}
+
+func (n *ConditionNode) validate(depth int, count *int) error {
+	*count++
+	if *count > maxConditionNodes {
+		return fmt.Errorf("conditions may have at most %d nodes", maxConditionNodes)
+	}
+	if depth > maxConditionDepth {
+		return fmt.Errorf("condition groups may be nested at most %d levels deep", maxConditionDepth)
+	}
+
+	if n.Op != "" {
+		if n.Field != "" || n.Operator != "" {
+			return errors.New("a condition group cannot have a field or operator")
+		}
+		switch n.Op {
+		case GroupAnd, GroupOr:
+			if len(n.Conditions) == 0 {
+				return fmt.Errorf("%q group must have at least one condition", n.Op)
+			}
+		case GroupNot:
+			if len(n.Conditions) != 1 {
+				return errors.New(`"not" group must have exactly one condition`)
+			}
+		default:
+			return fmt.Errorf("invalid condition group %q", n.Op)
+		}
+		for i := range n.Conditions {
+			if err := n.Conditions[i].validate(depth+1, count); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if strings.TrimSpace(n.Field) == "" {
+		return errors.New("condition field is required")
+	}
+	if !ValidOperators[n.Operator] {
+		return fmt.Errorf("invalid condition operator %q", n.Operator)
+	}
+	return validateConditionValue(Condition{Field: n.Field, Operator: n.Operator, Value: n.Value})
+}
+
+// validateConditionValue checks the value of operators that need a specific
+// shape.
+func validateConditionValue(cond Condition) error {
+	switch cond.Operator {
+	case "regex":
+		pattern, ok := cond.Value.(string)
+		if !ok || pattern == "" {
+			return fmt.Errorf("%s: regex value must be a pattern", cond.Field)
+		}
+		if len(pattern) > maxRegexLength {
+			return fmt.Errorf("%s: regex may be at most %d characters", cond.Field, maxRegexLength)
+		}
+		if _, err := regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("%s: invalid regex: %v", cond.Field, err)
+		}
+	case "between":
+		if _, _, ok := betweenBounds(cond.Value); !ok {
+			return fmt.Errorf("%s: between value must be [min, max]", cond.Field)
+		}
+	case "length_eq", "length_gt", "length_lt":
+		if _, ok := conditionInt(cond.Value); !ok {
+			return fmt.Errorf("%s: length value must be a whole number", cond.Field)
+		}
+	case "older_than", "newer_than":
+		if _, err := ParseAge(cond.Value); err != nil {
+			return fmt.Errorf("%s: %v", cond.Field, err)
+		}
+	}
+	return nil
+}
+
+// Evaluate reports whether data satisfies the tree. A nil tree always
+// matches.
+func (n *ConditionNode) Evaluate(data map[string]any) bool {
+	if n == nil {
+		return true
+	}
+	switch n.Op {
+	case GroupAnd:
+		for i := range n.Conditions {
+			if !n.Conditions[i].Evaluate(data) {
+				return false
+			}
+		}
+		return true
+	case GroupOr:
+		for i := range n.Conditions {
+			if n.Conditions[i].Evaluate(data) {
+				return true
+			}
+		}
+		return false
+	case GroupNot:
+		return len(n.Conditions) == 1 && !n.Conditions[0].Evaluate(data)
+	case "":
+		return evaluateCondition(Condition{Field: n.Field, Operator: n.Operator, Value: n.Value}, data)
+	default:
+		return false
+	}
+}
+
+// Trace evaluates every node of the tree, without short-circuiting, and
+// returns which branches matched.
+func (n *ConditionNode) Trace(data map[string]any) model.ConditionTreeResult {
+	if n.Op == "" {
+		return model.ConditionTreeResult{
+			Field:    n.Field,
+			Operator: n.Operator,
+			Value:    n.Value,
+			Actual:   resolveField(n.Field, data),
+			Met:      n.Evaluate(data),
+		}
+	}
+
+	result := model.ConditionTreeResult{Op: n.Op, Conditions: []model.ConditionTreeResult{}}
+	for i := range n.Conditions {
+		result.Conditions = append(result.Conditions, n.Conditions[i].Trace(data))
+	}
+	switch n.Op {
+	case GroupAnd:
+		result.Met = true
+		for _, c := range result.Conditions {
+			result.Met = result.Met && c.Met
+		}
+	case GroupOr:
+		for _, c := range result.Conditions {
+			result.Met = result.Met || c.Met
+		}
+	case GroupNot:
+		result.Met = len(result.Conditions) == 1 && !result.Conditions[0].Met
+	}
+	return result
+}
+
+// ParseAge parses the value of the older_than and newer_than operators: a
+// number followed by m, h, d or w ("48h", "7d"), or a Go duration.
+func ParseAge(v any) (time.Duration, error) {
+	s, ok := v.(string)
+	if !ok {
+		return 0, errors.New(`age must be a string such as "48h" or "7d"`)
+	}
+	return model.ParseAge(s)
+}
@@ -0,0 +1,283 @@
+package automation
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func mustParseConditions(t *testing.T, raw string) *ConditionNode {
+	t.Helper()
+	node, err := ParseConditions(json.RawMessage(raw))
+	if err != nil {
+		t.Fatalf("parse conditions: %v", err)
+	}
+	return node
+}
+
+func TestParseConditions_LegacyFlatArray(t *testing.T) {
+	node := mustParseConditions(t, `[
+		{"field": "status", "operator": "eq", "value": "new"},
+		{"field": "total_amount", "operator": "gt", "value": 100}
+	]`)
+	if node == nil || node.Op != GroupAnd || len(node.Conditions) != 2 {
+		t.Fatalf("expected flat array to be read as an and group, got %+v", node)
+	}
+	if !node.Evaluate(map[string]any{"status": "new", "total_amount": 150.0}) {
+		t.Error("expected true when all legacy conditions match")
+	}
+	if node.Evaluate(map[string]any{"status": "new", "total_amount": 50.0}) {
+		t.Error("expected false when a legacy condition fails")
+	}
+}
+
+func TestParseConditions_Empty(t *testing.T) {
+	for _, raw := range []string{"", "null", "[]"} {
+		node := mustParseConditions(t, raw)
+		if node != nil {
+			t.Errorf("expected nil node for %q", raw)
+		}
+		if !node.Evaluate(map[string]any{}) {
+			t.Errorf("expected nil node to match for %q", raw)
+		}
+	}
+}
+
+func TestConditionTree_NestedGroups(t *testing.T) {
+	node := mustParseConditions(t, `{"op": "and", "conditions": [
+		{"field": "source", "operator": "eq", "value": "allegro"},
+		{"op": "or", "conditions": [
+			{"field": "total_amount", "operator": "gt", "value": 500},
+			{"field": "tags", "operator": "contains", "value": "vip"}
+		]},
+		{"op": "not", "conditions": [
+			{"field": "status", "operator": "eq", "value": "cancelled"}
+		]}
+	]}`)
+
+	tests := []struct {
+		name string
+		data map[string]any
+		want bool
+	}{
+		{"large order", map[string]any{"source": "allegro", "total_amount": 600.0, "tags": []any{}, "status": "new"}, true},
+		{"vip order", map[string]any{"source": "allegro", "total_amount": 10.0, "tags": []any{"vip"}, "status": "new"}, true},
+		{"neither branch", map[string]any{"source": "allegro", "total_amount": 10.0, "tags": []any{}, "status": "new"}, false},
+		{"negated status", map[string]any{"source": "allegro", "total_amount": 600.0, "tags": []any{}, "status": "cancelled"}, false},
+		{"other source", map[string]any{"source": "manual", "total_amount": 600.0, "tags": []any{}, "status": "new"}, false},
+	}
+	for _, tt := range tests {
+		if got := node.Evaluate(tt.data); got != tt.want {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, got)
+		}
+	}
+}
+
+func TestConditionTree_Trace(t *testing.T) {
+	node := mustParseConditions(t, `{"op": "or", "conditions": [
+		{"field": "status", "operator": "eq", "value": "new"},
+		{"field": "total_amount", "operator": "gt", "value": 100}
+	]}`)
+
+	result := node.Trace(map[string]any{"status": "new", "total_amount": 50.0})
+	if !result.Met || result.Op != GroupOr {
+		t.Fatalf("expected or group to match, got %+v", result)
+	}
+	if len(result.Conditions) != 2 {
+		t.Fatalf("expected both branches to be traced, got %d", len(result.Conditions))
+	}
+	if !result.Conditions[0].Met {
+		t.Error("expected first branch to match")
+	}
+	if result.Conditions[1].Met {
+		t.Error("expected second branch not to match")
+	}
+	if result.Conditions[1].Actual != 50.0 {
+		t.Errorf("expected actual value 50, got %v", result.Conditions[1].Actual)
+	}
+}
+
+func TestEvaluateConditions_Regex(t *testing.T) {
+	conds := []Condition{{Field: "customer_email", Operator: "regex", Value: `@example\.(com|pl)$`}}
+	if !EvaluateConditions(conds, map[string]any{"customer_email": "jan@example.pl"}) {
+		t.Error("expected true for regex match")
+	}
+	if EvaluateConditions(conds, map[string]any{"customer_email": "jan@example.de"}) {
+		t.Error("expected false for regex mismatch")
+	}
+	if EvaluateConditions(conds, map[string]any{}) {
+		t.Error("expected false for missing field")
+	}
+}
+
+func TestEvaluateConditions_Between(t *testing.T) {
+	conds := []Condition{{Field: "total_amount", Operator: "between", Value: []any{100.0, 200.0}}}
+	for _, v := range []float64{100, 150, 200} {
+		if !EvaluateConditions(conds, map[string]any{"total_amount": v}) {
+			t.Errorf("expected true for %v within bounds", v)
+		}
+	}
+	if EvaluateConditions(conds, map[string]any{"total_amount": 200.01}) {
+		t.Error("expected false above upper bound")
+	}
+}
+
+func TestEvaluateConditions_IsEmpty(t *testing.T) {
+	conds := []Condition{{Field: "notes", Operator: "is_empty"}}
+	for _, v := range []any{nil, "", "  ", []any{}} {
+		if !EvaluateConditions(conds, map[string]any{"notes": v}) {
+			t.Errorf("expected %v to be empty", v)
+		}
+	}
+	if EvaluateConditions(conds, map[string]any{"notes": "call first"}) {
+		t.Error("expected false for non-empty value")
+	}
+
+	notEmpty := []Condition{{Field: "tags", Operator: "is_not_empty"}}
+	if !EvaluateConditions(notEmpty, map[string]any{"tags": []any{"vip"}}) {
+		t.Error("expected true for non-empty array")
+	}
+	if EvaluateConditions(notEmpty, map[string]any{}) {
+		t.Error("expected false for missing field")
+	}
+}
+
+func TestEvaluateConditions_Length(t *testing.T) {
+	data := map[string]any{"items": []any{"a", "b", "c"}}
+	tests := []struct {
+		operator string
+		value    any
+		want     bool
+	}{
+		{"length_eq", 3.0, true},
+		{"length_eq", 2.0, false},
+		{"length_gt", 2.0, true},
+		{"length_gt", 3.0, false},
+		{"length_lt", 4.0, true},
+		{"length_lt", 3.0, false},
+	}
+	for _, tt := range tests {
+		conds := []Condition{{Field: "items", Operator: tt.operator, Value: tt.value}}
+		if got := EvaluateConditions(conds, data); got != tt.want {
+			t.Errorf("%s %v: expected %v, got %v", tt.operator, tt.value, tt.want, got)
+		}
+	}
+}
+
+func TestEvaluateConditions_OlderNewerThan(t *testing.T) {
+	fixed := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	now = func() time.Time { return fixed }
+	defer func() { now = time.Now }()
+
+	data := map[string]any{"created_at": fixed.Add(-72 * time.Hour).Format(time.RFC3339)}
+	if !EvaluateConditions([]Condition{{Field: "created_at", Operator: "older_than", Value: "48h"}}, data) {
+		t.Error("expected 72h old order to be older than 48h")
+	}
+	if EvaluateConditions([]Condition{{Field: "created_at", Operator: "older_than", Value: "7d"}}, data) {
+		t.Error("expected 72h old order not to be older than 7d")
+	}
+	if !EvaluateConditions([]Condition{{Field: "created_at", Operator: "newer_than", Value: "1w"}}, data) {
+		t.Error("expected 72h old order to be newer than 1w")
+	}
+	if EvaluateConditions([]Condition{{Field: "created_at", Operator: "older_than", Value: "48h"}}, map[string]any{}) {
+		t.Error("expected false for missing date")
+	}
+}
+
+func TestParseAge(t *testing.T) {
+	tests := []struct {
+		in   any
+		want time.Duration
+	}{
+		{"30m", 30 * time.Minute},
+		{"48h", 48 * time.Hour},
+		{"7d", 7 * 24 * time.Hour},
+		{"2w", 14 * 24 * time.Hour},
+		{"1h30m", 90 * time.Minute},
+	}
+	for _, tt := range tests {
+		got, err := ParseAge(tt.in)
+		if err != nil {
+			t.Errorf("ParseAge(%v): unexpected error %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseAge(%v) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+	for _, in := range []any{"", "abc", "-5d", 48.0} {
+		if _, err := ParseAge(in); err == nil {
+			t.Errorf("ParseAge(%v): expected error", in)
+		}
+	}
+}
+
+func TestValidateConditions(t *testing.T) {
+	valid := []string{
+		``,
+		`[]`,
+		`[{"field": "status", "operator": "eq", "value": "new"}]`,
+		`{"op": "not", "conditions": [{"field": "tags", "operator": "is_empty"}]}`,
+		`{"op": "and", "conditions": [{"field": "total_amount", "operator": "between", "value": [1, 5]}]}`,
+	}
+	for _, raw := range valid {
+		if err := ValidateConditions(json.RawMessage(raw)); err != nil {
+			t.Errorf("expected %s to be valid, got %v", raw, err)
+		}
+	}
+
+	invalid := []string{
+		`"status"`,
+		`{"op": "xor", "conditions": [{"field": "status", "operator": "eq", "value": "new"}]}`,
+		`{"op": "or", "conditions": []}`,
+		`{"op": "not", "conditions": [{"field": "a", "operator": "eq"}, {"field": "b", "operator": "eq"}]}`,
+		`[{"field": "status", "operator": "like", "value": "new"}]`,
+		`[{"field": "", "operator": "eq", "value": "new"}]`,
+		`[{"field": "email", "operator": "regex", "value": "("}]`,
+		`[{"field": "total_amount", "operator": "between", "value": [5, 1]}]`,
+		`[{"field": "items", "operator": "length_gt", "value": 1.5}]`,
+		`[{"field": "created_at", "operator": "older_than", "value": "soon"}]`,
+	}
+	for _, raw := range invalid {
+		if err := ValidateConditions(json.RawMessage(raw)); err == nil {
+			t.Errorf("expected %s to be invalid", raw)
+		}
+	}
+
+	deep := `{"field": "status", "operator": "eq", "value": "new"}`
+	for i := 0; i < maxConditionDepth; i++ {
+		deep = `{"op": "not", "conditions": [` + deep + `]}`
+	}
+	if err := ValidateConditions(json.RawMessage(deep)); err == nil {
+		t.Error("expected too deeply nested conditions to be invalid")
+	}
+}
+
+func TestConditionNode_MarshalKeepsZeroValues(t *testing.T) {
+	raw := json.RawMessage(`{"op": "or", "conditions": [
+		{"field": "total_amount", "operator": "eq", "value": 0},
+		{"field": "notes", "operator": "eq", "value": ""},
+		{"field": "paid", "operator": "eq", "value": false}
+	]}`)
+	root, err := ParseConditions(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := json.Marshal(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"op":"or","conditions":[{"field":"total_amount","operator":"eq","value":0},` +
+		`{"field":"notes","operator":"eq","value":""},{"field":"paid","operator":"eq","value":false}]}`
+	if string(out) != want {
+		t.Errorf("got %s", out)
+	}
+
+	reparsed, err := ParseConditions(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reparsed.Evaluate(map[string]any{"total_amount": 0.0}) {
+		t.Error("expected the re-marshalled total_amount = 0 comparison to match")
+	}
+}
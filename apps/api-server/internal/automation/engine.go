@@ -3,6 +3,8 @@ package automation
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"log/slog"
 	"time"
 
@@ -33,11 +35,19 @@ type DelayedActionRepo interface {
 	ListPendingByTenant(ctx context.Context, tx pgx.Tx) ([]model.DelayedAction, error)
 }
 
+// ActionRunRepo is the interface needed by the engine to run Once actions at
+// most once per queued event, across retries.
+type ActionRunRepo interface {
+	ActionDone(ctx context.Context, tx pgx.Tx, eventID, ruleID uuid.UUID, actionIndex int) (bool, error)
+	RecordAction(ctx context.Context, tx pgx.Tx, tenantID, eventID, ruleID uuid.UUID, actionIndex int) error
+}
+
 // Engine is the automation rules engine that processes events.
 type Engine struct {
 	ruleRepo    AutomationRuleRepo
 	logRepo     AutomationRuleLogRepo
 	delayedRepo DelayedActionRepo
+	runRepo     ActionRunRepo
 	pool        *pgxpool.Pool
 	executor    ActionExecutor
 	logger      *slog.Logger
@@ -66,83 +76,77 @@ func (e *Engine) SetDelayedActionRepo(repo DelayedActionRepo) {
 	e.delayedRepo = repo
 }
 
-// ProcessEvent processes an automation event by loading matching rules,
-// evaluating conditions, and executing actions.
-// This runs asynchronously and should not block the caller.
-func (e *Engine) ProcessEvent(ctx context.Context, event Event) {
-	go e.processEventAsync(ctx, event)
+// SetActionRunRepo sets the repository recording Once actions of queued
+// events.
+func (e *Engine) SetActionRunRepo(repo ActionRunRepo) {
+	e.runRepo = repo
 }
 
-func (e *Engine) processEventAsync(ctx context.Context, event Event) {
-	err := database.WithTenant(ctx, e.pool, event.TenantID, func(tx pgx.Tx) error {
-		rules, err := e.ruleRepo.FindByTenantAndEvent(ctx, tx, event.Type)
-		if err != nil {
-			return err
-		}
+// HandleEvent runs every enabled rule of the event's type, or only the rule
+// of an event with a RuleID, within tx, which must be scoped to the event's
+// tenant. Every rule runs; the failed actions
+// of all rules are returned together, so that the event queue worker, which
+// calls it in the transaction that marks the event done, rolls the attempt
+// back and retries the event. Once actions that succeeded are not repeated
+// on the retry.
+func (e *Engine) HandleEvent(ctx context.Context, tx pgx.Tx, event Event) error {
+	rules, err := e.ruleRepo.FindByTenantAndEvent(ctx, tx, event.Type)
+	if err != nil {
+		return err
+	}
 
-		for _, rule := range rules {
-			e.processRule(ctx, tx, rule, event)
+	var errs []error
+	for _, rule := range rules {
+		if event.RuleID != uuid.Nil && rule.ID != event.RuleID {
+			continue
+		}
+		if err := e.processRule(ctx, tx, rule, event); err != nil {
+			errs = append(errs, fmt.Errorf("rule %s: %w", rule.ID, err))
 		}
-
-		return nil
-	})
-
-	if err != nil {
-		e.logger.Error("automation engine: failed to process event",
-			"event_type", event.Type,
-			"tenant_id", event.TenantID,
-			"error", err,
-		)
 	}
+
+	return errors.Join(errs...)
 }
 
 // TestRule performs a dry-run evaluation of a rule's conditions against the given data.
-// It does NOT execute any actions.
-func (e *Engine) TestRule(rule model.AutomationRule, data map[string]any) (conditionResults []struct {
-	Condition Condition
-	Met       bool
-}, allMet bool, actions []Action) {
-	var conditions []Condition
-	if err := json.Unmarshal(rule.Conditions, &conditions); err != nil {
+// It does NOT execute any actions. The returned tree shows which branches
+// matched; it is nil when the rule has no conditions.
+func (e *Engine) TestRule(rule model.AutomationRule, data map[string]any) (tree *model.ConditionTreeResult, allMet bool, actions []Action) {
+	root, err := ParseConditions(rule.Conditions)
+	if err != nil {
 		return nil, false, nil
 	}
 
 	allMet = true
-	for _, cond := range conditions {
-		met := evaluateCondition(cond, data)
-		conditionResults = append(conditionResults, struct {
-			Condition Condition
-			Met       bool
-		}{Condition: cond, Met: met})
-		if !met {
-			allMet = false
-		}
-	}
-
-	if len(conditions) == 0 {
-		allMet = true
+	if root != nil {
+		result := root.Trace(data)
+		tree = &result
+		allMet = result.Met
 	}
 
 	if err := json.Unmarshal(rule.Actions, &actions); err != nil {
 		actions = nil
 	}
 
-	return conditionResults, allMet, actions
+	return tree, allMet, actions
 }
 
-func (e *Engine) processRule(ctx context.Context, tx pgx.Tx, rule model.AutomationRule, event Event) {
+// processRule runs a rule against an event and logs the run. It returns the
+// failures of the rule's actions; a rule whose conditions or actions cannot
+// be parsed is logged and skipped, since retrying would not fix it.
+func (e *Engine) processRule(ctx context.Context, tx pgx.Tx, rule model.AutomationRule, event Event) error {
 	// Parse conditions
-	var conditions []Condition
-	if err := json.Unmarshal(rule.Conditions, &conditions); err != nil {
+	conditions, err := ParseConditions(rule.Conditions)
+	if err != nil {
 		e.logger.Error("automation engine: failed to parse conditions",
 			"rule_id", rule.ID,
 			"error", err,
 		)
-		return
+		return nil
 	}
 
 	// Evaluate conditions
-	conditionsMet := EvaluateConditions(conditions, event.Data)
+	conditionsMet := conditions.Evaluate(event.Data)
 
 	// Parse actions
 	var actions []Action
@@ -151,12 +155,13 @@ func (e *Engine) processRule(ctx context.Context, tx pgx.Tx, rule model.Automati
 			"rule_id", rule.ID,
 			"error", err,
 		)
-		return
+		return nil
 	}
 
 	// Execute actions if conditions met
 	var actionsExecuted []map[string]any
 	var errorMessage *string
+	var actionErrs []error
 
 	if conditionsMet {
 		for i, action := range actions {
@@ -196,10 +201,16 @@ func (e *Engine) processRule(ctx context.Context, tx pgx.Tx, rule model.Automati
 					actionResult["error"] = err.Error()
 					errMsg := err.Error()
 					errorMessage = &errMsg
+					actionErrs = append(actionErrs, fmt.Errorf("schedule %s: %w", action.Type, err))
 				} else {
 					actionResult["delayed"] = true
 					actionResult["execute_at"] = da.ExecuteAt.Format(time.RFC3339)
 				}
+			} else if done, err := e.actionDone(ctx, tx, rule, i, action, event); err != nil {
+				return err
+			} else if done {
+				actionResult["success"] = true
+				actionResult["skipped"] = "already executed"
 			} else {
 				if err := e.executor.ExecuteAction(ctx, event.TenantID, action, event); err != nil {
 					e.logger.Error("automation engine: action failed",
@@ -210,8 +221,10 @@ func (e *Engine) processRule(ctx context.Context, tx pgx.Tx, rule model.Automati
 					actionResult["error"] = err.Error()
 					errMsg := err.Error()
 					errorMessage = &errMsg
+					actionErrs = append(actionErrs, fmt.Errorf("%s: %w", action.Type, err))
 				} else {
 					actionResult["success"] = true
+					e.recordAction(ctx, rule, i, action, event)
 				}
 			}
 
@@ -253,4 +266,41 @@ func (e *Engine) processRule(ctx context.Context, tx pgx.Tx, rule model.Automati
 			"error", err,
 		)
 	}
+
+	return errors.Join(actionErrs...)
+}
+
+// tracksRun reports whether the run of an action is recorded: Once actions
+// of queued events.
+func (e *Engine) tracksRun(action Action, event Event) bool {
+	return e.runRepo != nil && event.ID != uuid.Nil && ActionCatalog[action.Type].Once
+}
+
+// actionDone reports whether a Once action already succeeded in an earlier
+// attempt of the event.
+func (e *Engine) actionDone(ctx context.Context, tx pgx.Tx, rule model.AutomationRule, index int, action Action, event Event) (bool, error) {
+	if !e.tracksRun(action, event) {
+		return false, nil
+	}
+	return e.runRepo.ActionDone(ctx, tx, event.ID, rule.ID, index)
+}
+
+// recordAction records a Once action that succeeded. The record is committed
+// on its own, so it survives a rollback of the attempt; if it cannot be
+// written the action may run again on a retry.
+func (e *Engine) recordAction(ctx context.Context, rule model.AutomationRule, index int, action Action, event Event) {
+	if !e.tracksRun(action, event) {
+		return
+	}
+	err := database.WithTenant(ctx, e.pool, event.TenantID, func(tx pgx.Tx) error {
+		return e.runRepo.RecordAction(ctx, tx, event.TenantID, event.ID, rule.ID, index)
+	})
+	if err != nil {
+		e.logger.Error("automation engine: failed to record action run",
+			"rule_id", rule.ID,
+			"event_id", event.ID,
+			"action_type", action.Type,
+			"error", err,
+		)
+	}
 }
@@ -0,0 +1,106 @@
+package automation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/openoms-org/openoms/apps/api-server/internal/model"
+)
+
+type fakeRuleRepo struct{ rules []model.AutomationRule }
+
+func (r *fakeRuleRepo) FindByTenantAndEvent(context.Context, pgx.Tx, string) ([]model.AutomationRule, error) {
+	return r.rules, nil
+}
+
+func (r *fakeRuleRepo) IncrementFireCount(context.Context, pgx.Tx, uuid.UUID, time.Time) error {
+	return nil
+}
+
+type fakeLogRepo struct{ logs []*model.AutomationRuleLog }
+
+func (r *fakeLogRepo) Create(_ context.Context, _ pgx.Tx, log *model.AutomationRuleLog) error {
+	r.logs = append(r.logs, log)
+	return nil
+}
+
+type fakeRunRepo struct{ done map[int]bool }
+
+func (r *fakeRunRepo) ActionDone(_ context.Context, _ pgx.Tx, _, _ uuid.UUID, index int) (bool, error) {
+	return r.done[index], nil
+}
+
+func (r *fakeRunRepo) RecordAction(context.Context, pgx.Tx, uuid.UUID, uuid.UUID, uuid.UUID, int) error {
+	return nil
+}
+
+type fakeExecutor struct{ executed []string }
+
+func (e *fakeExecutor) ExecuteAction(_ context.Context, _ uuid.UUID, action Action, _ Event) error {
+	e.executed = append(e.executed, action.Type)
+	if action.Type == "set_status" {
+		return errors.New("invalid transition")
+	}
+	return nil
+}
+
+func testRule(actions ...Action) model.AutomationRule {
+	raw, _ := json.Marshal(actions)
+	return model.AutomationRule{ID: uuid.New(), Actions: raw}
+}
+
+func TestHandleEvent_ReturnsActionFailures(t *testing.T) {
+	exec := &fakeExecutor{}
+	logs := &fakeLogRepo{}
+	failing := testRule(Action{Type: "set_status", Params: map[string]any{"status": "x"}})
+	ok := testRule(Action{Type: "add_tag", Params: map[string]any{"tag": "vip"}})
+	e := NewEngine(&fakeRuleRepo{rules: []model.AutomationRule{failing, ok}}, logs, nil, exec, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	err := e.HandleEvent(context.Background(), nil, Event{Type: "order.created"})
+	if err == nil {
+		t.Fatal("expected the failed action to be returned")
+	}
+	if len(exec.executed) != 2 || len(logs.logs) != 2 {
+		t.Errorf("expected every rule to run and be logged, got %v and %d logs", exec.executed, len(logs.logs))
+	}
+}
+
+func TestHandleEvent_SkipsOnceActionsOfEarlierAttempts(t *testing.T) {
+	exec := &fakeExecutor{}
+	rule := testRule(
+		Action{Type: "send_sms", Params: map[string]any{"message": "hi"}},
+		Action{Type: "add_tag", Params: map[string]any{"tag": "vip"}},
+	)
+	e := NewEngine(&fakeRuleRepo{rules: []model.AutomationRule{rule}}, &fakeLogRepo{}, nil, exec, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	// add_tag is not a Once action, so its record is never consulted.
+	e.SetActionRunRepo(&fakeRunRepo{done: map[int]bool{0: true, 1: true}})
+
+	if err := e.HandleEvent(context.Background(), nil, Event{ID: uuid.New(), Type: "order.created"}); err != nil {
+		t.Fatal(err)
+	}
+	if len(exec.executed) != 1 || exec.executed[0] != "add_tag" {
+		t.Errorf("expected only add_tag to run, got %v", exec.executed)
+	}
+}
+
+func TestHandleEvent_RuleEventRunsOnlyItsRule(t *testing.T) {
+	exec := &fakeExecutor{}
+	target := testRule(Action{Type: "add_tag", Params: map[string]any{"tag": "late"}})
+	other := testRule(Action{Type: "set_status", Params: map[string]any{"status": "x"}})
+	e := NewEngine(&fakeRuleRepo{rules: []model.AutomationRule{other, target}}, &fakeLogRepo{}, nil, exec, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	if err := e.HandleEvent(context.Background(), nil, Event{Type: "order.status_duration", RuleID: target.ID}); err != nil {
+		t.Fatal(err)
+	}
+	if len(exec.executed) != 1 || exec.executed[0] != "add_tag" {
+		t.Errorf("expected only the event's rule to run, got %v", exec.executed)
+	}
+}
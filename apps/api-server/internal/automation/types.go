@@ -1,18 +1,22 @@
 package automation
 
 import (
-	"context"
-
 	"github.com/google/uuid"
 )
 
 // Event represents an automation trigger event.
 type Event struct {
+	// ID is the ID of the queued event; uuid.Nil for events that did not come
+	// from the queue, such as rule tests and delayed actions.
+	ID         uuid.UUID
 	Type       string // "order.created", "order.status_changed", etc.
 	TenantID   uuid.UUID
 	EntityType string // "order", "shipment", "return"
 	EntityID   uuid.UUID
-	Data       map[string]any // event-specific data
+	// RuleID restricts the event to one rule: the events of time-based
+	// triggers target the rule that fired rather than every rule of a type.
+	RuleID uuid.UUID
+	Data   map[string]any // event-specific data
 }
 
 // Condition defines a single condition to evaluate against event data.
@@ -29,12 +33,6 @@ type Action struct {
 	DelaySeconds int            `json:"delay_seconds,omitempty"`
 }
 
-// EventProcessor is the interface that services use to fire automation events.
-// This avoids circular imports between service and automation packages.
-type EventProcessor interface {
-	ProcessEvent(ctx context.Context, event Event)
-}
-
 // ValidOperators is the set of supported condition operators.
 var ValidOperators = map[string]bool{
 	"eq":           true,
@@ -48,13 +46,22 @@ var ValidOperators = map[string]bool{
 	"contains":     true,
 	"not_contains": true,
 	"starts_with":  true,
+	"regex":        true,
+	"between":      true,
+	"is_empty":     true,
+	"is_not_empty": true,
+	"length_eq":    true,
+	"length_gt":    true,
+	"length_lt":    true,
+	"older_than":   true,
+	"newer_than":   true,
 }
 
 // ValidActionTypes is the set of supported action types.
-var ValidActionTypes = map[string]bool{
-	"set_status":     true,
-	"add_tag":        true,
-	"send_email":     true,
-	"create_invoice": true,
-	"webhook":        true,
-}
+var ValidActionTypes = func() map[string]bool {
+	types := make(map[string]bool, len(ActionCatalog))
+	for t := range ActionCatalog {
+		types[t] = true
+	}
+	return types
+}()
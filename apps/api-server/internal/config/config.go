@@ -43,6 +43,8 @@ type Config struct {
 	OpenAIAPIKey string `env:"OPENAI_API_KEY"`
 	OpenAIModel  string `env:"OPENAI_MODEL" envDefault:"gpt-4o-mini"`
 
+	AddressPNAFile string `env:"ADDRESS_PNA_FILE"` // official PNA list (CSV, UTF-8); without it only the postal code format is checked
+
 	MetricsToken string `env:"METRICS_TOKEN"` // Bearer token for /metrics; if empty, metrics are disabled in production
 }
 
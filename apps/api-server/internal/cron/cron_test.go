@@ -0,0 +1,69 @@
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchedule_Next(t *testing.T) {
+	from := time.Date(2026, 3, 14, 10, 17, 30, 0, time.UTC) // Saturday
+	tests := []struct {
+		expr string
+		want time.Time
+	}{
+		{"*/15 * * * *", time.Date(2026, 3, 14, 10, 30, 0, 0, time.UTC)},
+		{"0 7 * * *", time.Date(2026, 3, 15, 7, 0, 0, 0, time.UTC)},
+		{"0 7 * * 1", time.Date(2026, 3, 16, 7, 0, 0, 0, time.UTC)},
+		{"0 7 * * 1-5", time.Date(2026, 3, 16, 7, 0, 0, 0, time.UTC)},
+		{"30 6 1 * *", time.Date(2026, 4, 1, 6, 30, 0, 0, time.UTC)},
+		{"0 8 1,15 * *", time.Date(2026, 3, 15, 8, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC)},
+		{"0 0 13 * 5", time.Date(2026, 3, 20, 0, 0, 0, 0, time.UTC)}, // day 13 or Friday
+		{"@monthly", time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 9 29 2 *", time.Date(2028, 2, 29, 9, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			s, err := Parse(tt.expr)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, s.Next(from))
+		})
+	}
+}
+
+func TestSchedule_NextInLocation(t *testing.T) {
+	warsaw, err := time.LoadLocation("Europe/Warsaw")
+	require.NoError(t, err)
+
+	s, err := Parse("0 7 * * *")
+	require.NoError(t, err)
+	next := s.Next(time.Date(2026, 3, 28, 12, 0, 0, 0, warsaw))
+	assert.Equal(t, time.Date(2026, 3, 29, 7, 0, 0, 0, warsaw), next, "runs at local time across the DST change")
+}
+
+func TestSchedule_NeverMatches(t *testing.T) {
+	s, err := Parse("0 0 30 2 *")
+	require.NoError(t, err)
+	assert.True(t, s.Next(time.Now()).IsZero())
+}
+
+func TestParse_Errors(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"a * * * *",
+	} {
+		_, err := Parse(expr)
+		assert.Error(t, err, expr)
+	}
+}
@@ -95,6 +95,10 @@ func (h *AllegroHandler) UpdateFulfillment(w http.ResponseWriter, r *http.Reques
 		writeError(w, http.StatusBadGateway, "failed to update fulfillment on Allegro")
 		return
 	}
+	if err := h.orderService.RecordMarketplaceSync(ctx, tenantID, orderID, "allegro", "fulfillment",
+		map[string]string{"status": body.Status}, middleware.UserIDFromContext(ctx)); err != nil {
+		slog.Error("allegro fulfillment: record sync failed", "order_id", orderIDStr, "error", err)
+	}
 
 	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
 }
@@ -150,6 +154,10 @@ func (h *AllegroHandler) AddTracking(w http.ResponseWriter, r *http.Request) {
 		writeError(w, http.StatusBadGateway, "failed to add tracking on Allegro")
 		return
 	}
+	if err := h.orderService.RecordMarketplaceSync(ctx, tenantID, orderID, "allegro", "tracking",
+		map[string]string{"carrier_id": body.CarrierID, "waybill": body.Waybill}, middleware.UserIDFromContext(ctx)); err != nil {
+		slog.Error("allegro tracking: record sync failed", "order_id", orderIDStr, "error", err)
+	}
 
 	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
 }
@@ -31,6 +31,7 @@ import (
 type AllegroListingsHandler struct {
 	integrationService *service.IntegrationService
 	productService     *service.ProductService
+	variantService     *service.VariantService
 	listingRepo        *repository.ProductListingRepository
 	encryptionKey      []byte
 	pool               *pgxpool.Pool
@@ -41,6 +42,7 @@ type AllegroListingsHandler struct {
 func NewAllegroListingsHandler(
 	integrationService *service.IntegrationService,
 	productService *service.ProductService,
+	variantService *service.VariantService,
 	listingRepo *repository.ProductListingRepository,
 	encryptionKey []byte,
 	pool *pgxpool.Pool,
@@ -49,6 +51,7 @@ func NewAllegroListingsHandler(
 	return &AllegroListingsHandler{
 		integrationService: integrationService,
 		productService:     productService,
+		variantService:     variantService,
 		listingRepo:        listingRepo,
 		encryptionKey:      encryptionKey,
 		pool:               pool,
@@ -184,26 +187,7 @@ func (h *AllegroListingsHandler) CreateListing(w http.ResponseWriter, r *http.Re
 	}
 
 	// Split parameters: describesProduct=true → productSet, false → offer level
-	// Also validate EAN (GTIN) — Allegro requires 8, 10, 12, 13, or 14 chars
-	var productParams, offerParams []map[string]any
-	for _, p := range req.Parameters {
-		id, _ := p["id"].(string)
-		if id == "225693" { // EAN (GTIN)
-			if vals, ok := p["values"].([]any); ok && len(vals) > 0 {
-				ean, _ := vals[0].(string)
-				eanLen := len(ean)
-				if ean == "" || (eanLen != 8 && eanLen != 10 && eanLen != 12 && eanLen != 13 && eanLen != 14) {
-					slog.Info("allegro listings: skipping EAN with invalid length", "ean", ean, "len", eanLen)
-					continue
-				}
-			}
-		}
-		if productDescribes[id] {
-			productParams = append(productParams, p)
-		} else {
-			offerParams = append(offerParams, p)
-		}
-	}
+	productParams, offerParams := splitAllegroParameters(req.Parameters, productDescribes)
 
 	// Resolve GPSR responsible producer — use existing or create from location data
 	producerID, err := h.resolveResponsibleProducer(ctx, client, req)
@@ -688,12 +672,24 @@ func (h *AllegroListingsHandler) SyncListing(w http.ResponseWriter, r *http.Requ
 
 	externalID := *listing.ExternalID
 
-	// Determine stock and price to sync
+	// Determine stock and price to sync; variant listings use the variant's own values
 	stock := product.StockQuantity
+	price := product.Price
+	if listing.VariantID != nil {
+		variant, variantErr := h.variantService.Get(ctx, tenantID, *listing.VariantID)
+		if variantErr != nil {
+			slog.Error("allegro listings: failed to get variant for sync", "error", variantErr, "variant_id", *listing.VariantID)
+			writeError(w, http.StatusNotFound, "variant not found")
+			return
+		}
+		stock = variant.StockQuantity
+		if variant.PriceOverride != nil {
+			price = *variant.PriceOverride
+		}
+	}
 	if listing.StockOverride != nil {
 		stock = *listing.StockOverride
 	}
-	price := product.Price
 	if listing.PriceOverride != nil {
 		price = *listing.PriceOverride
 	}
@@ -0,0 +1,382 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+	"unicode/utf8"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	allegrosdk "github.com/openoms-org/openoms/packages/allegro-go-sdk"
+
+	"github.com/openoms-org/openoms/apps/api-server/internal/database"
+	"github.com/openoms-org/openoms/apps/api-server/internal/middleware"
+	"github.com/openoms-org/openoms/apps/api-server/internal/model"
+)
+
+// allegroEANParameterID is the Allegro category parameter holding the EAN (GTIN).
+const allegroEANParameterID = "225693"
+
+// allegroMaxOfferNameLength is the maximum length of an Allegro offer title.
+const allegroMaxOfferNameLength = 75
+
+// createVariantListingsRequest is the request body for publishing product variants
+// as an Allegro variant set. Shared offer settings come from the embedded request;
+// per-variant parameters (e.g. the size value) and overrides come from Variants.
+type createVariantListingsRequest struct {
+	createListingRequest
+	SetName string `json:"set_name"`
+	// VariantParameterIDs are the category parameters that differ between the
+	// offers of the set (e.g. the size parameter), or "color/pattern".
+	VariantParameterIDs []string                `json:"variant_parameter_ids"`
+	Variants            []variantListingRequest `json:"variants"`
+}
+
+type variantListingRequest struct {
+	VariantID     string           `json:"variant_id"`
+	Parameters    []map[string]any `json:"parameters"`
+	ColorPattern  string           `json:"color_pattern"`
+	PriceOverride *float64         `json:"price_override"`
+	StockOverride *int             `json:"stock_override"`
+}
+
+// variantListingsResponse is returned after publishing a variant set.
+type variantListingsResponse struct {
+	VariantSetID string                  `json:"variant_set_id,omitempty"`
+	Listings     []*model.ProductListing `json:"listings"`
+}
+
+// CreateVariantListings publishes each selected variant of a product as its own
+// Allegro offer and groups the offers into an Allegro variant set.
+// POST /v1/products/{productId}/listings/allegro/variants
+func (h *AllegroListingsHandler) CreateVariantListings(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	tenantID := middleware.TenantIDFromContext(ctx)
+
+	productID, err := uuid.Parse(chi.URLParam(r, "productId"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid product ID")
+		return
+	}
+
+	var req createVariantListingsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if req.IntegrationID == "" {
+		writeError(w, http.StatusBadRequest, "integration_id is required")
+		return
+	}
+	if req.CategoryID == "" {
+		writeError(w, http.StatusBadRequest, "category_id is required")
+		return
+	}
+	if len(req.VariantParameterIDs) == 0 {
+		writeError(w, http.StatusBadRequest, "variant_parameter_ids is required")
+		return
+	}
+	if len(req.Variants) < 2 {
+		writeError(w, http.StatusBadRequest, "at least two variants are required for a variant set")
+		return
+	}
+
+	integrationID, err := uuid.Parse(req.IntegrationID)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid integration_id")
+		return
+	}
+
+	product, err := h.productService.Get(ctx, tenantID, productID)
+	if err != nil {
+		slog.Error("allegro variant listings: failed to get product", "error", err)
+		writeError(w, http.StatusNotFound, "product not found")
+		return
+	}
+
+	// Resolve variants and make sure none of them is listed on this integration yet
+	variants := make([]*model.ProductVariant, len(req.Variants))
+	for i, vr := range req.Variants {
+		variantID, parseErr := uuid.Parse(vr.VariantID)
+		if parseErr != nil {
+			writeError(w, http.StatusBadRequest, "invalid variant_id")
+			return
+		}
+		variant, getErr := h.variantService.Get(ctx, tenantID, variantID)
+		if getErr != nil || variant.ProductID != productID {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("variant %s not found for this product", vr.VariantID))
+			return
+		}
+		variants[i] = variant
+	}
+
+	err = database.WithTenant(ctx, h.pool, tenantID, func(tx pgx.Tx) error {
+		for _, v := range variants {
+			existing, findErr := h.listingRepo.FindByVariantAndIntegration(ctx, tx, v.ID, integrationID)
+			if findErr != nil {
+				return findErr
+			}
+			if existing != nil {
+				return &variantListedError{variantName: v.Name}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		var listed *variantListedError
+		if errors.As(err, &listed) {
+			writeError(w, http.StatusConflict, listed.Error())
+			return
+		}
+		slog.Error("allegro variant listings: failed to check existing listings", "error", err)
+		writeError(w, http.StatusInternalServerError, "failed to check existing listings")
+		return
+	}
+
+	client, err := h.newAllegroClient(r)
+	if err != nil {
+		slog.Error("allegro variant listings: failed to create client", "error", err)
+		writeError(w, http.StatusBadRequest, "Integracja Allegro nie jest skonfigurowana")
+		return
+	}
+	defer client.Close()
+
+	// Product images are uploaded once and shared by all offers of the set
+	imageURLs := buildImages(product)
+	if len(imageURLs) == 0 {
+		writeError(w, http.StatusBadRequest, "Produkt musi miec co najmniej jedno zdjecie aby wystawic na Allegro")
+		return
+	}
+	var uploadedImages []string
+	for _, imgURL := range imageURLs {
+		hostedURL, uploadErr := h.uploadImageToAllegro(ctx, client, imgURL)
+		if uploadErr != nil {
+			slog.Warn("allegro variant listings: failed to upload image, skipping", "error", uploadErr, "url", imgURL)
+			continue
+		}
+		uploadedImages = append(uploadedImages, hostedURL)
+	}
+	if len(uploadedImages) == 0 {
+		writeError(w, http.StatusBadRequest, "Nie udalo sie przeslac zadnego zdjecia do Allegro. Upewnij sie ze produkt ma prawidlowe URL-e zdjec (nie placeholder)")
+		return
+	}
+
+	catParams, err := client.Categories.GetParameters(ctx, req.CategoryID)
+	if err != nil {
+		slog.Warn("allegro variant listings: failed to fetch category params for split, sending all as product params", "error", err)
+	}
+	productDescribes := map[string]bool{}
+	if catParams != nil {
+		for _, cp := range catParams.Parameters {
+			if cp.Options != nil && cp.Options.DescribesProduct {
+				productDescribes[cp.ID] = true
+			}
+		}
+	}
+
+	producerID, err := h.resolveResponsibleProducer(ctx, client, req.createListingRequest)
+	if err != nil {
+		slog.Warn("allegro variant listings: failed to resolve responsible producer, offers may fail GPSR", "error", err)
+	}
+
+	metadata, _ := json.Marshal(map[string]any{
+		"category_id":           req.CategoryID,
+		"parameters":            req.Parameters,
+		"variant_parameter_ids": req.VariantParameterIDs,
+		"shipping_rate_id":      req.ShippingRateID,
+		"return_policy_id":      req.ReturnPolicyID,
+		"warranty_id":           req.WarrantyID,
+		"handling_time":         req.HandlingTime,
+		"location":              req.Location,
+	})
+
+	// Create one offer per variant. Offers created before a failure are still
+	// recorded so they are not orphaned on Allegro.
+	now := time.Now()
+	var listings []*model.ProductListing
+	var setOffers []allegrosdk.OfferVariantSetOffer
+	var offerErr error
+	for i, vr := range req.Variants {
+		variant := variants[i]
+		variantProduct, offerReq := variantOfferInput(product, variant, req.createListingRequest, vr)
+		productParams, offerParams := splitAllegroParameters(mergeAllegroParameters(req.Parameters, vr.Parameters, variant), productDescribes)
+
+		payload := buildAllegroOfferPayload(variantProduct, offerReq, uploadedImages, productParams, offerParams, producerID)
+		offer, createErr := client.Offers.Create(ctx, payload)
+		if createErr != nil {
+			slog.Error("allegro variant listings: failed to create offer", "error", createErr, "variant_id", variant.ID)
+			offerErr = fmt.Errorf("%s: %w", variant.Name, createErr)
+			break
+		}
+
+		variantID := variant.ID
+		listings = append(listings, &model.ProductListing{
+			ID:            uuid.New(),
+			TenantID:      tenantID,
+			ProductID:     productID,
+			VariantID:     &variantID,
+			IntegrationID: integrationID,
+			ExternalID:    &offer.ID,
+			Status:        "active",
+			PriceOverride: vr.PriceOverride,
+			StockOverride: vr.StockOverride,
+			SyncStatus:    "synced",
+			LastSyncedAt:  &now,
+			Metadata:      metadata,
+		})
+		setOffers = append(setOffers, allegrosdk.OfferVariantSetOffer{ID: offer.ID, ColorPattern: vr.ColorPattern})
+	}
+
+	// Group the offers into a variant set
+	var setErr error
+	resp := variantListingsResponse{}
+	if offerErr == nil {
+		setName := req.SetName
+		if setName == "" {
+			setName = product.Name
+		}
+		params := make([]allegrosdk.OfferVariantSetParameter, len(req.VariantParameterIDs))
+		for i, id := range req.VariantParameterIDs {
+			params[i] = allegrosdk.OfferVariantSetParameter{ID: id}
+		}
+		set, createErr := client.OfferVariants.Create(ctx, allegrosdk.OfferVariantSet{
+			Name:       truncateRunes(setName, allegroMaxOfferNameLength),
+			Parameters: params,
+			Offers:     setOffers,
+		})
+		if createErr != nil {
+			slog.Error("allegro variant listings: failed to create variant set", "error", createErr, "product_id", productID)
+			setErr = createErr
+		} else {
+			resp.VariantSetID = set.ID
+			for _, l := range listings {
+				l.ExternalGroupID = &set.ID
+			}
+		}
+	}
+
+	if len(listings) > 0 {
+		err = database.WithTenant(ctx, h.pool, tenantID, func(tx pgx.Tx) error {
+			for _, l := range listings {
+				if createErr := h.listingRepo.Create(ctx, tx, l); createErr != nil {
+					return createErr
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			slog.Error("allegro variant listings: failed to save listings", "error", err, "product_id", productID)
+			writeError(w, http.StatusInternalServerError, "offers created on Allegro but failed to save listing records")
+			return
+		}
+	}
+
+	switch {
+	case offerErr != nil:
+		writeError(w, http.StatusBadGateway, allegroErrorMessage(
+			fmt.Sprintf("Utworzono %d z %d ofert, nie udało się utworzyć oferty dla wariantu", len(listings), len(req.Variants)), offerErr))
+		return
+	case setErr != nil:
+		writeError(w, http.StatusBadGateway, allegroErrorMessage("Oferty utworzone, ale nie udało się utworzyć zestawu wariantów", setErr))
+		return
+	}
+
+	resp.Listings = listings
+	writeJSON(w, http.StatusCreated, resp)
+}
+
+// variantListedError reports a variant that already has a listing on the integration.
+type variantListedError struct {
+	variantName string
+}
+
+func (e *variantListedError) Error() string {
+	return fmt.Sprintf("variant %q is already listed on this integration", e.variantName)
+}
+
+// variantOfferInput derives the product data and offer settings used for a
+// single variant's offer: the variant's name suffix, SKU, EAN, image, price and stock.
+func variantOfferInput(product *model.Product, variant *model.ProductVariant, base createListingRequest, vr variantListingRequest) (*model.Product, createListingRequest) {
+	p := *product
+	p.Name = truncateRunes(product.Name+" "+variant.Name, allegroMaxOfferNameLength)
+	p.SKU = variant.SKU
+	if variant.EAN != nil {
+		p.EAN = variant.EAN
+	}
+	if variant.PriceOverride != nil {
+		p.Price = *variant.PriceOverride
+	}
+	p.StockQuantity = variant.StockQuantity
+
+	req := base
+	req.PriceOverride = vr.PriceOverride
+	req.StockOverride = vr.StockOverride
+	return &p, req
+}
+
+// mergeAllegroParameters combines the shared offer parameters with the variant's
+// own parameters (which win on the same ID) and the variant's EAN, if any.
+func mergeAllegroParameters(shared, own []map[string]any, variant *model.ProductVariant) []map[string]any {
+	overridden := map[string]bool{}
+	for _, p := range own {
+		if id, _ := p["id"].(string); id != "" {
+			overridden[id] = true
+		}
+	}
+	if variant.EAN != nil && *variant.EAN != "" {
+		overridden[allegroEANParameterID] = true
+	}
+
+	merged := make([]map[string]any, 0, len(shared)+len(own)+1)
+	for _, p := range shared {
+		if id, _ := p["id"].(string); overridden[id] {
+			continue
+		}
+		merged = append(merged, p)
+	}
+	merged = append(merged, own...)
+	if variant.EAN != nil && *variant.EAN != "" {
+		merged = append(merged, map[string]any{"id": allegroEANParameterID, "values": []any{*variant.EAN}})
+	}
+	return merged
+}
+
+// splitAllegroParameters splits parameters into product-level (describesProduct)
+// and offer-level ones. EAN (GTIN) values are dropped unless they have 8, 10, 12,
+// 13 or 14 characters, as Allegro rejects other lengths.
+func splitAllegroParameters(params []map[string]any, productDescribes map[string]bool) (productParams, offerParams []map[string]any) {
+	for _, p := range params {
+		id, _ := p["id"].(string)
+		if id == allegroEANParameterID {
+			if vals, ok := p["values"].([]any); ok && len(vals) > 0 {
+				ean, _ := vals[0].(string)
+				eanLen := len(ean)
+				if ean == "" || (eanLen != 8 && eanLen != 10 && eanLen != 12 && eanLen != 13 && eanLen != 14) {
+					slog.Info("allegro listings: skipping EAN with invalid length", "ean", ean, "len", eanLen)
+					continue
+				}
+			}
+		}
+		if productDescribes[id] {
+			productParams = append(productParams, p)
+		} else {
+			offerParams = append(offerParams, p)
+		}
+	}
+	return productParams, offerParams
+}
+
+// truncateRunes shortens s to at most n characters.
+func truncateRunes(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return string([]rune(s)[:n])
+}
@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openoms-org/openoms/apps/api-server/internal/model"
+)
+
+func TestAllegroListingsHandler_CreateVariantListings_Validation(t *testing.T) {
+	h := NewAllegroListingsHandler(nil, nil, nil, nil, nil, nil, nil)
+
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{"invalid json", "bad", "invalid request body"},
+		{"missing integration", `{"category_id":"1"}`, "integration_id is required"},
+		{"missing category", `{"integration_id":"` + uuid.New().String() + `"}`, "category_id is required"},
+		{"missing parameters", `{"integration_id":"` + uuid.New().String() + `","category_id":"1","variants":[{},{}]}`, "variant_parameter_ids is required"},
+		{"single variant", `{"integration_id":"` + uuid.New().String() + `","category_id":"1","variant_parameter_ids":["236"],"variants":[{}]}`, "at least two variants are required for a variant set"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("productId", uuid.New().String())
+
+			req := httptest.NewRequest(http.MethodPost, "/v1/products/x/listings/allegro/variants", strings.NewReader(tt.body))
+			req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+			rr := httptest.NewRecorder()
+
+			h.CreateVariantListings(rr, req)
+
+			assert.Equal(t, http.StatusBadRequest, rr.Code)
+			var resp map[string]string
+			require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+			assert.Equal(t, tt.want, resp["error"])
+		})
+	}
+}
+
+func TestMergeAllegroParameters(t *testing.T) {
+	ean := "5901234123457"
+	variant := &model.ProductVariant{Name: "M", EAN: &ean}
+
+	shared := []map[string]any{
+		{"id": "236", "values": []any{"S"}},
+		{"id": "11323", "valuesIds": []any{"11323_1"}},
+		{"id": allegroEANParameterID, "values": []any{"0000000000000"}},
+	}
+	own := []map[string]any{{"id": "236", "values": []any{"M"}}}
+
+	merged := mergeAllegroParameters(shared, own, variant)
+
+	byID := map[string]map[string]any{}
+	for _, p := range merged {
+		byID[p["id"].(string)] = p
+	}
+	require.Len(t, merged, 3)
+	assert.Equal(t, []any{"M"}, byID["236"]["values"])
+	assert.Equal(t, []any{ean}, byID[allegroEANParameterID]["values"])
+	assert.Contains(t, byID, "11323")
+}
+
+func TestSplitAllegroParameters(t *testing.T) {
+	params := []map[string]any{
+		{"id": "236", "values": []any{"M"}},
+		{"id": "11323", "valuesIds": []any{"11323_1"}},
+		{"id": allegroEANParameterID, "values": []any{"123"}},
+	}
+
+	productParams, offerParams := splitAllegroParameters(params, map[string]bool{"236": true})
+
+	require.Len(t, productParams, 1)
+	assert.Equal(t, "236", productParams[0]["id"])
+	require.Len(t, offerParams, 1)
+	assert.Equal(t, "11323", offerParams[0]["id"])
+}
+
+func TestVariantOfferInput(t *testing.T) {
+	sku := "TS-M"
+	price := 59.0
+	product := &model.Product{Name: "Koszulka", Price: 49, StockQuantity: 100}
+	variant := &model.ProductVariant{Name: "M", SKU: &sku, PriceOverride: &price, StockQuantity: 7}
+	stock := 3
+
+	p, req := variantOfferInput(product, variant, createListingRequest{CategoryID: "1"}, variantListingRequest{StockOverride: &stock})
+
+	assert.Equal(t, "Koszulka M", p.Name)
+	assert.Equal(t, &sku, p.SKU)
+	assert.Equal(t, 59.0, p.Price)
+	assert.Equal(t, 7, p.StockQuantity)
+	assert.Equal(t, "1", req.CategoryID)
+	assert.Equal(t, &stock, req.StockOverride)
+	assert.Nil(t, req.PriceOverride)
+	assert.Equal(t, "Koszulka", product.Name, "product must not be modified")
+}
+
+func TestTruncateRunes(t *testing.T) {
+	assert.Equal(t, "Żółw", truncateRunes("Żółw", 10))
+	assert.Equal(t, "Żó", truncateRunes("Żółw", 2))
+}
@@ -175,6 +175,44 @@ func (h *AutomationHandler) ListDelayed(w http.ResponseWriter, r *http.Request)
 	writeJSON(w, http.StatusOK, actions)
 }
 
+func (h *AutomationHandler) ListEvents(w http.ResponseWriter, r *http.Request) {
+	tenantID := middleware.TenantIDFromContext(r.Context())
+
+	filter, err := model.ParseAutomationEventFilter(r.URL.Query(), model.ParsePagination(r))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	resp, err := h.automationService.ListEvents(r.Context(), tenantID, filter)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to list automation events")
+		return
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (h *AutomationHandler) RetryEvent(w http.ResponseWriter, r *http.Request) {
+	tenantID := middleware.TenantIDFromContext(r.Context())
+
+	eventID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid event ID")
+		return
+	}
+
+	event, err := h.automationService.RetryEvent(r.Context(), tenantID, eventID)
+	if err != nil {
+		if errors.Is(err, service.ErrAutomationEventNotFound) {
+			writeError(w, http.StatusNotFound, "dead automation event not found")
+			return
+		}
+		writeError(w, http.StatusInternalServerError, "failed to retry automation event")
+		return
+	}
+	writeJSON(w, http.StatusOK, event)
+}
+
 func (h *AutomationHandler) TestRule(w http.ResponseWriter, r *http.Request) {
 	tenantID := middleware.TenantIDFromContext(r.Context())
 
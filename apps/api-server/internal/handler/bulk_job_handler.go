@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/openoms-org/openoms/apps/api-server/internal/middleware"
+	"github.com/openoms-org/openoms/apps/api-server/internal/model"
+	"github.com/openoms-org/openoms/apps/api-server/internal/service"
+)
+
+type BulkJobHandler struct {
+	bulkJobService *service.BulkJobService
+}
+
+func NewBulkJobHandler(bulkJobService *service.BulkJobService) *BulkJobHandler {
+	return &BulkJobHandler{bulkJobService: bulkJobService}
+}
+
+// Create queues a bulk order action; progress is pushed over /v1/ws and the
+// per-order results are in the job metadata.
+func (h *BulkJobHandler) Create(w http.ResponseWriter, r *http.Request) {
+	tenantID := middleware.TenantIDFromContext(r.Context())
+	actorID := middleware.UserIDFromContext(r.Context())
+
+	var req model.CreateBulkJobRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	job, err := h.bulkJobService.Start(r.Context(), tenantID, req, actorID, clientIP(r))
+	if err != nil {
+		if isValidationError(err) {
+			writeError(w, http.StatusBadRequest, err.Error())
+		} else {
+			writeError(w, http.StatusInternalServerError, "failed to start bulk job")
+		}
+		return
+	}
+	writeJSON(w, http.StatusAccepted, job)
+}
+
+func (h *BulkJobHandler) List(w http.ResponseWriter, r *http.Request) {
+	tenantID := middleware.TenantIDFromContext(r.Context())
+
+	resp, err := h.bulkJobService.List(r.Context(), tenantID, model.ParsePagination(r))
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to list bulk jobs")
+		return
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (h *BulkJobHandler) Get(w http.ResponseWriter, r *http.Request) {
+	tenantID := middleware.TenantIDFromContext(r.Context())
+
+	jobID, err := uuid.Parse(chi.URLParam(r, "jobId"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid bulk job ID")
+		return
+	}
+
+	job, err := h.bulkJobService.Get(r.Context(), tenantID, jobID)
+	if err != nil {
+		if errors.Is(err, service.ErrBulkJobNotFound) {
+			writeError(w, http.StatusNotFound, "bulk job not found")
+		} else {
+			writeError(w, http.StatusInternalServerError, "failed to get bulk job")
+		}
+		return
+	}
+	writeJSON(w, http.StatusOK, job)
+}
@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/openoms-org/openoms/apps/api-server/internal/middleware"
+	"github.com/openoms-org/openoms/apps/api-server/internal/service"
+)
+
+// CatalogImportHandler queues product catalog imports from sales channels.
+// Progress is reported through the sync jobs API.
+type CatalogImportHandler struct {
+	catalogImportService *service.CatalogImportService
+}
+
+func NewCatalogImportHandler(catalogImportService *service.CatalogImportService) *CatalogImportHandler {
+	return &CatalogImportHandler{catalogImportService: catalogImportService}
+}
+
+// Start queues a catalog import for an integration.
+// POST /v1/integrations/{id}/catalog-import
+func (h *CatalogImportHandler) Start(w http.ResponseWriter, r *http.Request) {
+	tenantID := middleware.TenantIDFromContext(r.Context())
+	actorID := middleware.UserIDFromContext(r.Context())
+
+	integrationID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid integration ID")
+		return
+	}
+
+	job, err := h.catalogImportService.Start(r.Context(), tenantID, integrationID, actorID, clientIP(r))
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrIntegrationNotFound):
+			writeError(w, http.StatusNotFound, "integration not found")
+		case errors.Is(err, service.ErrCatalogImportInProgress):
+			writeError(w, http.StatusConflict, err.Error())
+		default:
+			if isValidationError(err) {
+				writeError(w, http.StatusBadRequest, err.Error())
+			} else {
+				writeError(w, http.StatusInternalServerError, "failed to start catalog import")
+			}
+		}
+		return
+	}
+	writeJSON(w, http.StatusAccepted, job)
+}
@@ -0,0 +1,322 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/openoms-org/openoms/apps/api-server/internal/middleware"
+	"github.com/openoms-org/openoms/apps/api-server/internal/model"
+	"github.com/openoms-org/openoms/apps/api-server/internal/service"
+)
+
+type DropshipHandler struct {
+	dropshipService *service.DropshipService
+}
+
+func NewDropshipHandler(dropshipService *service.DropshipService) *DropshipHandler {
+	return &DropshipHandler{dropshipService: dropshipService}
+}
+
+// --- Fulfilment rules ---
+
+func (h *DropshipHandler) ListRules(w http.ResponseWriter, r *http.Request) {
+	tenantID := middleware.TenantIDFromContext(r.Context())
+
+	rules, err := h.dropshipService.ListRules(r.Context(), tenantID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to list fulfillment rules")
+		return
+	}
+	writeJSON(w, http.StatusOK, rules)
+}
+
+func (h *DropshipHandler) GetRule(w http.ResponseWriter, r *http.Request) {
+	tenantID := middleware.TenantIDFromContext(r.Context())
+
+	productID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid product ID")
+		return
+	}
+
+	rule, err := h.dropshipService.GetRule(r.Context(), tenantID, productID)
+	if err != nil {
+		if errors.Is(err, service.ErrFulfillmentRuleNotFound) {
+			writeError(w, http.StatusNotFound, "fulfillment rule not found")
+		} else {
+			writeError(w, http.StatusInternalServerError, "failed to get fulfillment rule")
+		}
+		return
+	}
+	writeJSON(w, http.StatusOK, rule)
+}
+
+func (h *DropshipHandler) SetRule(w http.ResponseWriter, r *http.Request) {
+	tenantID := middleware.TenantIDFromContext(r.Context())
+	actorID := middleware.UserIDFromContext(r.Context())
+
+	productID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid product ID")
+		return
+	}
+
+	var req model.SetFulfillmentRuleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	rule, err := h.dropshipService.SetRule(r.Context(), tenantID, productID, req, actorID, clientIP(r))
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrProductNotFound):
+			writeError(w, http.StatusNotFound, "product not found")
+		case isValidationError(err):
+			writeError(w, http.StatusBadRequest, err.Error())
+		default:
+			writeError(w, http.StatusInternalServerError, "failed to save fulfillment rule")
+		}
+		return
+	}
+	writeJSON(w, http.StatusOK, rule)
+}
+
+func (h *DropshipHandler) DeleteRule(w http.ResponseWriter, r *http.Request) {
+	tenantID := middleware.TenantIDFromContext(r.Context())
+	actorID := middleware.UserIDFromContext(r.Context())
+
+	productID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid product ID")
+		return
+	}
+
+	if err := h.dropshipService.DeleteRule(r.Context(), tenantID, productID, actorID, clientIP(r)); err != nil {
+		if errors.Is(err, service.ErrFulfillmentRuleNotFound) {
+			writeError(w, http.StatusNotFound, "fulfillment rule not found")
+		} else {
+			writeError(w, http.StatusInternalServerError, "failed to delete fulfillment rule")
+		}
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// --- Supplier orders ---
+
+func (h *DropshipHandler) List(w http.ResponseWriter, r *http.Request) {
+	tenantID := middleware.TenantIDFromContext(r.Context())
+
+	filter := model.SupplierOrderListFilter{
+		PaginationParams: model.ParsePagination(r),
+	}
+	q := r.URL.Query()
+	if status := q.Get("status"); status != "" {
+		filter.Status = &status
+	}
+	if v := q.Get("supplier_id"); v != "" {
+		id, err := uuid.Parse(v)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid supplier_id")
+			return
+		}
+		filter.SupplierID = &id
+	}
+	if v := q.Get("order_id"); v != "" {
+		id, err := uuid.Parse(v)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "invalid order_id")
+			return
+		}
+		filter.OrderID = &id
+	}
+
+	resp, err := h.dropshipService.List(r.Context(), tenantID, filter)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to list supplier orders")
+		return
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (h *DropshipHandler) Get(w http.ResponseWriter, r *http.Request) {
+	tenantID := middleware.TenantIDFromContext(r.Context())
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid supplier order ID")
+		return
+	}
+
+	so, err := h.dropshipService.Get(r.Context(), tenantID, id)
+	if err != nil {
+		if errors.Is(err, service.ErrSupplierOrderNotFound) {
+			writeError(w, http.StatusNotFound, "supplier order not found")
+		} else {
+			writeError(w, http.StatusInternalServerError, "failed to get supplier order")
+		}
+		return
+	}
+	writeJSON(w, http.StatusOK, so)
+}
+
+// Resend delivers the supplier order again; the outcome is in its status.
+func (h *DropshipHandler) Resend(w http.ResponseWriter, r *http.Request) {
+	tenantID := middleware.TenantIDFromContext(r.Context())
+	actorID := middleware.UserIDFromContext(r.Context())
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid supplier order ID")
+		return
+	}
+
+	so, err := h.dropshipService.Send(r.Context(), tenantID, id, actorID, clientIP(r))
+	if err != nil {
+		h.writeSupplierOrderError(w, err, "failed to send supplier order")
+		return
+	}
+	writeJSON(w, http.StatusOK, so)
+}
+
+func (h *DropshipHandler) RecordTracking(w http.ResponseWriter, r *http.Request) {
+	tenantID := middleware.TenantIDFromContext(r.Context())
+	actorID := middleware.UserIDFromContext(r.Context())
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid supplier order ID")
+		return
+	}
+
+	var req model.SupplierTrackingRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	so, err := h.dropshipService.RecordTracking(r.Context(), tenantID, id, req, actorID, clientIP(r))
+	if err != nil {
+		h.writeSupplierOrderError(w, err, "failed to record tracking")
+		return
+	}
+	writeJSON(w, http.StatusOK, so)
+}
+
+func (h *DropshipHandler) Cancel(w http.ResponseWriter, r *http.Request) {
+	tenantID := middleware.TenantIDFromContext(r.Context())
+	actorID := middleware.UserIDFromContext(r.Context())
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid supplier order ID")
+		return
+	}
+
+	so, err := h.dropshipService.Cancel(r.Context(), tenantID, id, actorID, clientIP(r))
+	if err != nil {
+		h.writeSupplierOrderError(w, err, "failed to cancel supplier order")
+		return
+	}
+	writeJSON(w, http.StatusOK, so)
+}
+
+// RouteOrder routes the unrouted lines of an order to suppliers.
+func (h *DropshipHandler) RouteOrder(w http.ResponseWriter, r *http.Request) {
+	tenantID := middleware.TenantIDFromContext(r.Context())
+	actorID := middleware.UserIDFromContext(r.Context())
+
+	orderID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid order ID")
+		return
+	}
+
+	routing, err := h.dropshipService.RouteOrder(r.Context(), tenantID, orderID, actorID, clientIP(r))
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrOrderNotFound):
+			writeError(w, http.StatusNotFound, "order not found")
+		case isValidationError(err):
+			writeError(w, http.StatusBadRequest, err.Error())
+		default:
+			writeError(w, http.StatusInternalServerError, "failed to route order")
+		}
+		return
+	}
+	writeJSON(w, http.StatusOK, routing)
+}
+
+func (h *DropshipHandler) ListForOrder(w http.ResponseWriter, r *http.Request) {
+	tenantID := middleware.TenantIDFromContext(r.Context())
+
+	orderID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid order ID")
+		return
+	}
+
+	filter := model.SupplierOrderListFilter{
+		OrderID:          &orderID,
+		PaginationParams: model.ParsePagination(r),
+	}
+	resp, err := h.dropshipService.List(r.Context(), tenantID, filter)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to list supplier orders")
+		return
+	}
+	writeJSON(w, http.StatusOK, resp.Items)
+}
+
+// --- Public supplier access ---
+
+// PublicGet shows a supplier order to the supplier holding its link.
+func (h *DropshipHandler) PublicGet(w http.ResponseWriter, r *http.Request) {
+	view, err := h.dropshipService.GetByToken(r.Context(), chi.URLParam(r, "token"))
+	if err != nil {
+		if errors.Is(err, service.ErrSupplierOrderNotFound) {
+			writeError(w, http.StatusNotFound, "supplier order not found")
+		} else {
+			writeError(w, http.StatusInternalServerError, "failed to get supplier order")
+		}
+		return
+	}
+	writeJSON(w, http.StatusOK, view)
+}
+
+// PublicRecordTracking records the parcel a supplier shipped; it is the
+// callback URL sent with webhook orders and the form behind the email link.
+func (h *DropshipHandler) PublicRecordTracking(w http.ResponseWriter, r *http.Request) {
+	var req model.SupplierTrackingRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	so, err := h.dropshipService.RecordTrackingByToken(r.Context(), chi.URLParam(r, "token"), req, clientIP(r))
+	if err != nil {
+		h.writeSupplierOrderError(w, err, "failed to record tracking")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"id":              so.ID,
+		"status":          so.Status,
+		"carrier":         so.Carrier,
+		"tracking_number": so.TrackingNumber,
+	})
+}
+
+func (h *DropshipHandler) writeSupplierOrderError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case errors.Is(err, service.ErrSupplierOrderNotFound):
+		writeError(w, http.StatusNotFound, "supplier order not found")
+	case isValidationError(err):
+		writeError(w, http.StatusBadRequest, err.Error())
+	default:
+		writeError(w, http.StatusInternalServerError, fallback)
+	}
+}
@@ -0,0 +1,172 @@
+package handler
+
+import (
+	"encoding/csv"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/openoms-org/openoms/apps/api-server/internal/middleware"
+	"github.com/openoms-org/openoms/apps/api-server/internal/model"
+	"github.com/openoms-org/openoms/apps/api-server/internal/service"
+)
+
+type InventoryCostingHandler struct {
+	costingService *service.InventoryCostingService
+}
+
+func NewInventoryCostingHandler(costingService *service.InventoryCostingService) *InventoryCostingHandler {
+	return &InventoryCostingHandler{costingService: costingService}
+}
+
+func (h *InventoryCostingHandler) GetValuation(w http.ResponseWriter, r *http.Request) {
+	tenantID := middleware.TenantIDFromContext(r.Context())
+
+	filter, err := model.ParseInventoryValuationFilter(r.URL.Query(), time.Now())
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	report, err := h.costingService.Valuation(r.Context(), tenantID, filter)
+	if err != nil {
+		slog.Error("inventory valuation failed", "error", err)
+		writeError(w, http.StatusInternalServerError, "failed to retrieve inventory valuation")
+		return
+	}
+	writeJSON(w, http.StatusOK, report)
+}
+
+// ExportValuationCSV writes the inventory valuation with a totals row.
+func (h *InventoryCostingHandler) ExportValuationCSV(w http.ResponseWriter, r *http.Request) {
+	tenantID := middleware.TenantIDFromContext(r.Context())
+
+	filter, err := model.ParseInventoryValuationFilter(r.URL.Query(), time.Now())
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	report, err := h.costingService.Valuation(r.Context(), tenantID, filter)
+	if err != nil {
+		slog.Error("inventory valuation export failed", "error", err)
+		writeError(w, http.StatusInternalServerError, "failed to retrieve inventory valuation")
+		return
+	}
+
+	filename := fmt.Sprintf("wycena-magazynu-%s.csv", report.At.Format("2006-01-02"))
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+
+	// BOM for Excel UTF-8 compatibility
+	w.Write([]byte{0xEF, 0xBB, 0xBF})
+
+	writer := csv.NewWriter(w)
+	defer writer.Flush()
+
+	if err := writer.Write([]string{"Magazyn", "SKU", "Nazwa", "Ilość", "Koszt jednostkowy", "Wartość"}); err != nil {
+		slog.Error("inventory valuation export: failed to write header", "error", err)
+		return
+	}
+	for _, row := range report.Rows {
+		unitCost := ""
+		if row.UnitCost != nil {
+			unitCost = fmt.Sprintf("%.4f", *row.UnitCost)
+		}
+		if err := writer.Write([]string{
+			row.WarehouseName,
+			row.SKU,
+			row.Name,
+			strconv.Itoa(row.Quantity),
+			unitCost,
+			fmt.Sprintf("%.2f", row.Value),
+		}); err != nil {
+			slog.Error("inventory valuation export: failed to write row", "error", err)
+			return
+		}
+	}
+	if err := writer.Write([]string{"Razem", "", "", strconv.Itoa(report.Quantity), "", fmt.Sprintf("%.2f", report.Value)}); err != nil {
+		slog.Error("inventory valuation export: failed to write totals", "error", err)
+	}
+}
+
+func (h *InventoryCostingHandler) GetCOGS(w http.ResponseWriter, r *http.Request) {
+	tenantID := middleware.TenantIDFromContext(r.Context())
+
+	filter, err := model.ParseCOGSFilter(r.URL.Query())
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	report, err := h.costingService.COGS(r.Context(), tenantID, filter)
+	if err != nil {
+		slog.Error("cogs report failed", "error", err)
+		writeError(w, http.StatusInternalServerError, "failed to retrieve cost of goods sold")
+		return
+	}
+	writeJSON(w, http.StatusOK, report)
+}
+
+// ExportCOGSCSV writes the cost of goods sold report with all rows of the
+// grouping and a totals row.
+func (h *InventoryCostingHandler) ExportCOGSCSV(w http.ResponseWriter, r *http.Request) {
+	tenantID := middleware.TenantIDFromContext(r.Context())
+
+	filter, err := model.ParseCOGSFilter(r.URL.Query())
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	filter.Limit = model.MaxProfitabilityRows
+
+	report, err := h.costingService.COGS(r.Context(), tenantID, filter)
+	if err != nil {
+		slog.Error("cogs export failed", "error", err)
+		writeError(w, http.StatusInternalServerError, "failed to retrieve cost of goods sold")
+		return
+	}
+
+	filename := fmt.Sprintf("koszt-sprzedazy-%s-%s.csv", filter.GroupBy, time.Now().Format("2006-01-02"))
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+
+	// BOM for Excel UTF-8 compatibility
+	w.Write([]byte{0xEF, 0xBB, 0xBF})
+
+	writer := csv.NewWriter(w)
+	defer writer.Flush()
+
+	if err := writer.Write([]string{"Klucz", "Nazwa", "Magazyn", "Data", "Dokumenty", "Ilość", "Koszt", "Szacunkowy"}); err != nil {
+		slog.Error("cogs export: failed to write header", "error", err)
+		return
+	}
+	for _, row := range report.Rows {
+		date := ""
+		if row.Date != nil {
+			date = row.Date.Format("2006-01-02")
+		}
+		estimated := "nie"
+		if row.Estimated {
+			estimated = "tak"
+		}
+		if err := writer.Write([]string{
+			row.Key,
+			row.Label,
+			row.Warehouse,
+			date,
+			strconv.Itoa(row.Documents),
+			strconv.Itoa(row.Quantity),
+			fmt.Sprintf("%.2f", row.Cost),
+			estimated,
+		}); err != nil {
+			slog.Error("cogs export: failed to write row", "error", err)
+			return
+		}
+	}
+	if err := writer.Write([]string{"", "Razem", "", "", "", strconv.Itoa(report.Quantity), fmt.Sprintf("%.2f", report.Cost), ""}); err != nil {
+		slog.Error("cogs export: failed to write totals", "error", err)
+	}
+}
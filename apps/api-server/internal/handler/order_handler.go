@@ -33,35 +33,20 @@ func NewOrderHandler(orderService *service.OrderService, tenantRepo repository.T
 	return &OrderHandler{orderService: orderService, tenantRepo: tenantRepo, pool: pool}
 }
 
-// parseOrderFilter extracts common order list filter parameters from the request.
-func parseOrderFilter(r *http.Request) model.OrderListFilter {
-	filter := model.OrderListFilter{}
-	if s := r.URL.Query().Get("status"); s != "" {
-		filter.Status = &s
-	}
-	if s := r.URL.Query().Get("source"); s != "" {
-		filter.Source = &s
-	}
-	if s := r.URL.Query().Get("search"); s != "" {
-		filter.Search = &s
-	}
-	if ps := r.URL.Query().Get("payment_status"); ps != "" {
-		filter.PaymentStatus = &ps
-	}
-	if t := r.URL.Query().Get("tag"); t != "" {
-		filter.Tag = &t
-	}
-	if p := r.URL.Query().Get("priority"); p != "" {
-		filter.Priority = &p
-	}
-	return filter
+// parseOrderFilter extracts the order filter from the query string.
+func parseOrderFilter(r *http.Request) (model.OrderListFilter, error) {
+	return model.ParseOrderFilter(r.URL.Query())
 }
 
 func (h *OrderHandler) List(w http.ResponseWriter, r *http.Request) {
 	tenantID := middleware.TenantIDFromContext(r.Context())
 	pagination := model.ParsePagination(r)
 
-	filter := parseOrderFilter(r)
+	filter, err := parseOrderFilter(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
 	filter.PaginationParams = pagination
 
 	resp, err := h.orderService.List(r.Context(), tenantID, filter)
@@ -192,7 +177,8 @@ func (h *OrderHandler) TransitionStatus(w http.ResponseWriter, r *http.Request)
 		switch {
 		case errors.Is(err, service.ErrOrderNotFound):
 			writeError(w, http.StatusNotFound, "order not found")
-		case errors.Is(err, service.ErrInvalidTransition), errors.Is(err, service.ErrUnknownStatus):
+		case errors.Is(err, service.ErrInvalidTransition), errors.Is(err, service.ErrUnknownStatus), errors.Is(err, service.ErrGuardFailed),
+			errors.Is(err, service.ErrOrderHeld):
 			writeError(w, http.StatusUnprocessableEntity, err.Error())
 		default:
 			if isValidationError(err) {
@@ -246,6 +232,35 @@ func (h *OrderHandler) GetAudit(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusOK, entries)
 }
 
+// GetTimeline handles GET /v1/orders/{id}/timeline.
+// It returns what happened to the order, newest first, filtered by the query
+// parameters of model.ParseTimelineFilter.
+func (h *OrderHandler) GetTimeline(w http.ResponseWriter, r *http.Request) {
+	tenantID := middleware.TenantIDFromContext(r.Context())
+	orderID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid order ID")
+		return
+	}
+	filter, err := model.ParseTimelineFilter(r.URL.Query())
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	entries, err := h.orderService.Timeline(r.Context(), tenantID, orderID, filter)
+	if err != nil {
+		if errors.Is(err, service.ErrOrderNotFound) {
+			writeError(w, http.StatusNotFound, "order not found")
+			return
+		}
+		slog.Error("order timeline failed", "error", err, "order_id", orderID)
+		writeError(w, http.StatusInternalServerError, "failed to retrieve order timeline")
+		return
+	}
+	writeJSON(w, http.StatusOK, entries)
+}
+
 func (h *OrderHandler) DuplicateOrder(w http.ResponseWriter, r *http.Request) {
 	tenantID := middleware.TenantIDFromContext(r.Context())
 	actorID := middleware.UserIDFromContext(r.Context())
@@ -351,7 +366,11 @@ func (h *OrderHandler) loadCustomFieldsConfig(ctx context.Context, tenantID uuid
 func (h *OrderHandler) ExportCSV(w http.ResponseWriter, r *http.Request) {
 	tenantID := middleware.TenantIDFromContext(r.Context())
 
-	filter := parseOrderFilter(r)
+	filter, err := parseOrderFilter(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
 
 	// Load custom field definitions
 	cfConfig := h.loadCustomFieldsConfig(r.Context(), tenantID)
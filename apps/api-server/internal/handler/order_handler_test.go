@@ -162,6 +162,25 @@ func TestOrderHandler_GetAudit_InvalidOrderID(t *testing.T) {
 	assert.Equal(t, http.StatusBadRequest, rr.Code)
 }
 
+func TestOrderHandler_GetTimeline_InvalidFilter(t *testing.T) {
+	h := NewOrderHandler(nil, nil, nil)
+	orderID := uuid.New()
+
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", orderID.String())
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/orders/"+orderID.String()+"/timeline?source=email", nil)
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	rr := httptest.NewRecorder()
+
+	h.GetTimeline(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	var resp map[string]string
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+	assert.Contains(t, resp["error"], "source must be one of")
+}
+
 func TestOrderHandler_Create_ValidationError(t *testing.T) {
 	// OrderService with nil pool will fail at WithTenant, but validation error happens before
 	svc := service.NewOrderService(nil, nil, nil, nil, nil, nil)
@@ -0,0 +1,150 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/openoms-org/openoms/apps/api-server/internal/middleware"
+	"github.com/openoms-org/openoms/apps/api-server/internal/model"
+	"github.com/openoms-org/openoms/apps/api-server/internal/service"
+)
+
+type OrderHoldHandler struct {
+	holdService *service.OrderHoldService
+}
+
+func NewOrderHoldHandler(holdService *service.OrderHoldService) *OrderHoldHandler {
+	return &OrderHoldHandler{holdService: holdService}
+}
+
+// List returns the blocked-order queue, optionally filtered by hold reason
+// and source.
+func (h *OrderHoldHandler) List(w http.ResponseWriter, r *http.Request) {
+	tenantID := middleware.TenantIDFromContext(r.Context())
+
+	filter := model.OrderHoldListFilter{
+		PaginationParams: model.ParsePagination(r),
+	}
+	q := r.URL.Query()
+	if reason := q.Get("reason"); reason != "" {
+		if !slices.Contains(model.HoldReasons, reason) {
+			writeError(w, http.StatusBadRequest, "invalid reason")
+			return
+		}
+		filter.Reason = &reason
+	}
+	if source := q.Get("source"); source != "" {
+		switch source {
+		case model.HoldSourceManual, model.HoldSourceAutomation, model.HoldSourceImport:
+		default:
+			writeError(w, http.StatusBadRequest, "invalid source")
+			return
+		}
+		filter.Source = &source
+	}
+
+	resp, err := h.holdService.List(r.Context(), tenantID, filter)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to list held orders")
+		return
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (h *OrderHoldHandler) ListForOrder(w http.ResponseWriter, r *http.Request) {
+	tenantID := middleware.TenantIDFromContext(r.Context())
+
+	orderID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid order ID")
+		return
+	}
+
+	holds, err := h.holdService.ListForOrder(r.Context(), tenantID, orderID)
+	if err != nil {
+		if errors.Is(err, service.ErrOrderNotFound) {
+			writeError(w, http.StatusNotFound, "order not found")
+		} else {
+			writeError(w, http.StatusInternalServerError, "failed to list order holds")
+		}
+		return
+	}
+	writeJSON(w, http.StatusOK, holds)
+}
+
+func (h *OrderHoldHandler) Place(w http.ResponseWriter, r *http.Request) {
+	tenantID := middleware.TenantIDFromContext(r.Context())
+	actorID := middleware.UserIDFromContext(r.Context())
+
+	orderID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid order ID")
+		return
+	}
+
+	var req model.PlaceHoldRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	hold, err := h.holdService.Place(r.Context(), tenantID, orderID, req, actorID, clientIP(r))
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrOrderNotFound):
+			writeError(w, http.StatusNotFound, "order not found")
+		case errors.Is(err, service.ErrOrderHoldExists):
+			writeError(w, http.StatusConflict, err.Error())
+		case isValidationError(err):
+			writeError(w, http.StatusBadRequest, err.Error())
+		default:
+			writeError(w, http.StatusInternalServerError, "failed to place order hold")
+		}
+		return
+	}
+	writeJSON(w, http.StatusCreated, hold)
+}
+
+func (h *OrderHoldHandler) Release(w http.ResponseWriter, r *http.Request) {
+	tenantID := middleware.TenantIDFromContext(r.Context())
+	actorID := middleware.UserIDFromContext(r.Context())
+
+	orderID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid order ID")
+		return
+	}
+	holdID, err := uuid.Parse(chi.URLParam(r, "holdId"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid hold ID")
+		return
+	}
+
+	var req model.ReleaseHoldRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid request body")
+			return
+		}
+	}
+
+	hold, err := h.holdService.Release(r.Context(), tenantID, orderID, holdID, req, actorID, clientIP(r))
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrOrderHoldNotFound):
+			writeError(w, http.StatusNotFound, "order hold not found")
+		case errors.Is(err, service.ErrOrderHoldReleased):
+			writeError(w, http.StatusConflict, err.Error())
+		case isValidationError(err):
+			writeError(w, http.StatusBadRequest, err.Error())
+		default:
+			writeError(w, http.StatusInternalServerError, "failed to release order hold")
+		}
+		return
+	}
+	writeJSON(w, http.StatusOK, hold)
+}
@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/openoms-org/openoms/apps/api-server/internal/middleware"
+	"github.com/openoms-org/openoms/apps/api-server/internal/model"
+	"github.com/openoms-org/openoms/apps/api-server/internal/service"
+)
+
+// OrderViewHandler handles HTTP requests for the current user's saved order views.
+type OrderViewHandler struct {
+	viewService *service.OrderViewService
+}
+
+// NewOrderViewHandler creates a new OrderViewHandler.
+func NewOrderViewHandler(viewService *service.OrderViewService) *OrderViewHandler {
+	return &OrderViewHandler{viewService: viewService}
+}
+
+func (h *OrderViewHandler) List(w http.ResponseWriter, r *http.Request) {
+	tenantID := middleware.TenantIDFromContext(r.Context())
+	userID := middleware.UserIDFromContext(r.Context())
+
+	views, err := h.viewService.List(r.Context(), tenantID, userID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to list order views")
+		return
+	}
+	writeJSON(w, http.StatusOK, views)
+}
+
+func (h *OrderViewHandler) Create(w http.ResponseWriter, r *http.Request) {
+	tenantID := middleware.TenantIDFromContext(r.Context())
+	userID := middleware.UserIDFromContext(r.Context())
+
+	var req model.CreateOrderViewRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	view, err := h.viewService.Create(r.Context(), tenantID, userID, req)
+	if err != nil {
+		h.writeViewError(w, err, "failed to create order view")
+		return
+	}
+	writeJSON(w, http.StatusCreated, view)
+}
+
+func (h *OrderViewHandler) Update(w http.ResponseWriter, r *http.Request) {
+	tenantID := middleware.TenantIDFromContext(r.Context())
+	userID := middleware.UserIDFromContext(r.Context())
+
+	viewID, err := uuid.Parse(chi.URLParam(r, "viewId"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid view ID")
+		return
+	}
+
+	var req model.UpdateOrderViewRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	view, err := h.viewService.Update(r.Context(), tenantID, userID, viewID, req)
+	if err != nil {
+		h.writeViewError(w, err, "failed to update order view")
+		return
+	}
+	writeJSON(w, http.StatusOK, view)
+}
+
+func (h *OrderViewHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	tenantID := middleware.TenantIDFromContext(r.Context())
+	userID := middleware.UserIDFromContext(r.Context())
+
+	viewID, err := uuid.Parse(chi.URLParam(r, "viewId"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid view ID")
+		return
+	}
+
+	if err := h.viewService.Delete(r.Context(), tenantID, userID, viewID); err != nil {
+		h.writeViewError(w, err, "failed to delete order view")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *OrderViewHandler) writeViewError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case errors.Is(err, service.ErrOrderViewNotFound):
+		writeError(w, http.StatusNotFound, "order view not found")
+	case errors.Is(err, service.ErrOrderViewDuplicateName):
+		writeError(w, http.StatusConflict, err.Error())
+	case isValidationError(err):
+		writeError(w, http.StatusBadRequest, err.Error())
+	default:
+		writeError(w, http.StatusInternalServerError, fallback)
+	}
+}
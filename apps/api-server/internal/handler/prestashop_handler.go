@@ -0,0 +1,128 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	prestashopIntegration "github.com/openoms-org/openoms/apps/api-server/internal/integration/prestashop"
+	"github.com/openoms-org/openoms/apps/api-server/internal/middleware"
+	"github.com/openoms-org/openoms/apps/api-server/internal/service"
+)
+
+// PrestaShopHandler handles PrestaShop-specific API endpoints (order states).
+type PrestaShopHandler struct {
+	integrationService *service.IntegrationService
+	orderService       *service.OrderService
+}
+
+// NewPrestaShopHandler creates a new PrestaShopHandler.
+func NewPrestaShopHandler(integrationService *service.IntegrationService, orderService *service.OrderService) *PrestaShopHandler {
+	return &PrestaShopHandler{
+		integrationService: integrationService,
+		orderService:       orderService,
+	}
+}
+
+// getProvider creates a PrestaShop provider from the integration credentials and settings for the given tenant.
+func (h *PrestaShopHandler) getProvider(ctx context.Context, tenantID uuid.UUID) (*prestashopIntegration.Provider, error) {
+	credJSON, integ, err := h.integrationService.GetDecryptedCredentialsByProvider(ctx, tenantID, "prestashop")
+	if err != nil {
+		return nil, err
+	}
+	return prestashopIntegration.NewProvider(credJSON, integ.Settings)
+}
+
+type updatePrestaShopOrderStateRequest struct {
+	// Status is an OpenOMS order status, translated via the integration's status mapping.
+	Status string `json:"status,omitempty"`
+	// StateID is a PrestaShop order state ID; it takes precedence over Status.
+	StateID string `json:"state_id,omitempty"`
+}
+
+// UpdateOrderState handles POST /v1/integrations/prestashop/orders/{orderId}/state.
+// It moves the PrestaShop order to the given state, or to the state mapped to an OpenOMS status.
+func (h *PrestaShopHandler) UpdateOrderState(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	tenantID := middleware.TenantIDFromContext(ctx)
+	orderIDStr := chi.URLParam(r, "orderId")
+
+	orderID, err := uuid.Parse(orderIDStr)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid order ID")
+		return
+	}
+
+	var body updatePrestaShopOrderStateRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if body.Status == "" && body.StateID == "" {
+		writeError(w, http.StatusBadRequest, "status or state_id is required")
+		return
+	}
+
+	// Get order from DB to find external_id
+	order, err := h.orderService.Get(ctx, tenantID, orderID)
+	if err != nil {
+		slog.Error("prestashop order state: get order failed", "error", err)
+		writeError(w, http.StatusNotFound, "order not found")
+		return
+	}
+	if order.ExternalID == nil || *order.ExternalID == "" {
+		writeError(w, http.StatusBadRequest, "order has no external PrestaShop ID")
+		return
+	}
+
+	provider, err := h.getProvider(ctx, tenantID)
+	if err != nil {
+		slog.Error("prestashop order state: get provider failed", "error", err)
+		writeError(w, http.StatusInternalServerError, "failed to connect to PrestaShop")
+		return
+	}
+
+	if body.StateID != "" {
+		err = provider.ChangeOrderState(ctx, *order.ExternalID, body.StateID)
+	} else {
+		err = provider.UpdateOrderStatus(ctx, *order.ExternalID, body.Status)
+	}
+	if err != nil {
+		slog.Error("prestashop order state: update failed", "order_id", orderIDStr, "error", err)
+		writeError(w, http.StatusBadGateway, "failed to update order state on PrestaShop")
+		return
+	}
+	if err := h.orderService.RecordMarketplaceSync(ctx, tenantID, orderID, "prestashop", "order_state",
+		map[string]string{"status": body.Status, "state_id": body.StateID}, middleware.UserIDFromContext(ctx)); err != nil {
+		slog.Error("prestashop order state: record sync failed", "order_id", orderIDStr, "error", err)
+	}
+
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// ListOrderStates handles GET /v1/integrations/prestashop/order-states.
+// It returns the shop's order states for configuring the status mapping.
+func (h *PrestaShopHandler) ListOrderStates(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	tenantID := middleware.TenantIDFromContext(ctx)
+
+	provider, err := h.getProvider(ctx, tenantID)
+	if err != nil {
+		slog.Error("prestashop order states: get provider failed", "error", err)
+		writeError(w, http.StatusInternalServerError, "failed to connect to PrestaShop")
+		return
+	}
+
+	states, err := provider.ListOrderStates(ctx)
+	if err != nil {
+		slog.Error("prestashop order states: list failed", "error", err)
+		writeError(w, http.StatusBadGateway, "failed to list order states from PrestaShop")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string][]prestashopIntegration.OrderState{"order_states": states})
+}
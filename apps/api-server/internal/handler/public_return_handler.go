@@ -61,6 +61,7 @@ func (h *PublicReturnHandler) GetByToken(w http.ResponseWriter, r *http.Request)
 		"refund_amount":  ret.RefundAmount,
 		"customer_email": ret.CustomerEmail,
 		"customer_notes": ret.CustomerNotes,
+		"return_label":   publicReturnLabel(ret),
 		"created_at":     ret.CreatedAt,
 		"updated_at":     ret.UpdatedAt,
 	})
@@ -85,15 +86,30 @@ func (h *PublicReturnHandler) GetStatusByToken(w http.ResponseWriter, r *http.Re
 	}
 
 	writeJSON(w, http.StatusOK, map[string]any{
-		"id":         ret.ID,
-		"status":     ret.Status,
-		"reason":     ret.Reason,
-		"items":      ret.Items,
-		"created_at": ret.CreatedAt,
-		"updated_at": ret.UpdatedAt,
+		"id":           ret.ID,
+		"status":       ret.Status,
+		"reason":       ret.Reason,
+		"items":        ret.Items,
+		"return_label": publicReturnLabel(ret),
+		"created_at":   ret.CreatedAt,
+		"updated_at":   ret.UpdatedAt,
 	})
 }
 
+// publicReturnLabel is the prepaid return label shown to the customer, or nil
+// when none has been generated.
+func publicReturnLabel(ret *model.Return) map[string]any {
+	if ret.ReturnTrackingNumber == nil {
+		return nil
+	}
+	return map[string]any{
+		"provider":        ret.ReturnProvider,
+		"tracking_number": ret.ReturnTrackingNumber,
+		"label_url":       ret.ReturnLabelURL,
+		"status":          ret.ReturnShipmentStatus,
+	}
+}
+
 // CreatePublicReturn creates a return request from a public form submission.
 func (h *PublicReturnHandler) CreatePublicReturn(w http.ResponseWriter, r *http.Request) {
 	var req model.PublicReturnRequest
@@ -164,6 +180,7 @@ func (h *PublicReturnHandler) CreatePublicReturn(w http.ResponseWriter, r *http.
 		Reason:        req.Reason,
 		Items:         items,
 		RefundAmount:  0,
+		ReceivedItems: json.RawMessage("[]"),
 		Notes:         notes,
 		ReturnToken:   &returnToken,
 		CustomerEmail: &req.Email,
@@ -215,12 +232,14 @@ func (h *PublicReturnHandler) findReturnByToken(ctx context.Context, token strin
 	err := h.pool.QueryRow(ctx,
 		`SELECT id, tenant_id, order_id, status, reason, items, refund_amount, notes,
 		        return_token, customer_email, customer_notes,
+		        return_provider, return_tracking_number, return_label_url, return_shipment_status,
 		        created_at, updated_at
 		 FROM find_return_by_token($1)`, token,
 	).Scan(
 		&r.ID, &r.TenantID, &r.OrderID, &r.Status, &r.Reason,
 		&r.Items, &r.RefundAmount, &r.Notes,
 		&r.ReturnToken, &r.CustomerEmail, &r.CustomerNotes,
+		&r.ReturnProvider, &r.ReturnTrackingNumber, &r.ReturnLabelURL, &r.ReturnShipmentStatus,
 		&r.CreatedAt, &r.UpdatedAt,
 	)
 	if err == pgx.ErrNoRows {
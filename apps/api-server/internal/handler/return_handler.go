@@ -147,6 +147,8 @@ func (h *ReturnHandler) TransitionStatus(w http.ResponseWriter, r *http.Request)
 			writeError(w, http.StatusNotFound, "return not found")
 		case errors.Is(err, service.ErrInvalidReturnTransition):
 			writeError(w, http.StatusUnprocessableEntity, err.Error())
+		case errors.Is(err, service.ErrReturnRefundPending):
+			writeError(w, http.StatusConflict, err.Error())
 		default:
 			if isValidationError(err) {
 				writeError(w, http.StatusBadRequest, err.Error())
@@ -159,6 +161,154 @@ func (h *ReturnHandler) TransitionStatus(w http.ResponseWriter, r *http.Request)
 	writeJSON(w, http.StatusOK, ret)
 }
 
+func (h *ReturnHandler) Receive(w http.ResponseWriter, r *http.Request) {
+	tenantID := middleware.TenantIDFromContext(r.Context())
+	actorID := middleware.UserIDFromContext(r.Context())
+
+	returnID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid return ID")
+		return
+	}
+
+	var req model.ReceiveReturnRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	ret, err := h.returnService.Receive(r.Context(), tenantID, returnID, req, actorID, clientIP(r))
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrReturnNotFound):
+			writeError(w, http.StatusNotFound, "return not found")
+		case errors.Is(err, service.ErrInvalidReturnTransition):
+			writeError(w, http.StatusUnprocessableEntity, err.Error())
+		default:
+			if isValidationError(err) {
+				writeError(w, http.StatusBadRequest, err.Error())
+			} else {
+				writeError(w, http.StatusInternalServerError, "failed to receive return")
+			}
+		}
+		return
+	}
+	writeJSON(w, http.StatusOK, ret)
+}
+
+func (h *ReturnHandler) Refund(w http.ResponseWriter, r *http.Request) {
+	tenantID := middleware.TenantIDFromContext(r.Context())
+	actorID := middleware.UserIDFromContext(r.Context())
+
+	returnID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid return ID")
+		return
+	}
+
+	var req model.RefundReturnRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	ret, err := h.returnService.Refund(r.Context(), tenantID, returnID, req, actorID, clientIP(r))
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrReturnNotFound):
+			writeError(w, http.StatusNotFound, "return not found")
+		case errors.Is(err, service.ErrInvalidReturnTransition):
+			writeError(w, http.StatusUnprocessableEntity, err.Error())
+		case errors.Is(err, service.ErrReturnRefundFailed):
+			writeError(w, http.StatusBadGateway, err.Error())
+		case errors.Is(err, service.ErrReturnRefundPending), errors.Is(err, service.ErrReturnGradingPending):
+			writeError(w, http.StatusConflict, err.Error())
+		default:
+			if isValidationError(err) {
+				writeError(w, http.StatusBadRequest, err.Error())
+			} else {
+				writeError(w, http.StatusInternalServerError, "failed to refund return")
+			}
+		}
+		return
+	}
+	writeJSON(w, http.StatusOK, ret)
+}
+
+// Exchange settles a received exchange return with a replacement order.
+func (h *ReturnHandler) Exchange(w http.ResponseWriter, r *http.Request) {
+	tenantID := middleware.TenantIDFromContext(r.Context())
+	actorID := middleware.UserIDFromContext(r.Context())
+
+	returnID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid return ID")
+		return
+	}
+
+	var req model.ExchangeReturnRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid request body")
+			return
+		}
+	}
+
+	ret, err := h.returnService.Exchange(r.Context(), tenantID, returnID, req, actorID, clientIP(r))
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrReturnNotFound):
+			writeError(w, http.StatusNotFound, "return not found")
+		case errors.Is(err, service.ErrInvalidReturnTransition):
+			writeError(w, http.StatusUnprocessableEntity, err.Error())
+		case errors.Is(err, service.ErrReturnGradingPending):
+			writeError(w, http.StatusConflict, err.Error())
+		default:
+			if isValidationError(err) {
+				writeError(w, http.StatusBadRequest, err.Error())
+			} else {
+				writeError(w, http.StatusInternalServerError, "failed to exchange return")
+			}
+		}
+		return
+	}
+	writeJSON(w, http.StatusOK, ret)
+}
+
+// CreateLabel generates the prepaid return label of an approved return.
+func (h *ReturnHandler) CreateLabel(w http.ResponseWriter, r *http.Request) {
+	tenantID := middleware.TenantIDFromContext(r.Context())
+	actorID := middleware.UserIDFromContext(r.Context())
+
+	returnID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid return ID")
+		return
+	}
+
+	ret, err := h.returnService.CreateReturnLabel(r.Context(), tenantID, returnID, actorID, clientIP(r))
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrReturnNotFound):
+			writeError(w, http.StatusNotFound, "return not found")
+		case errors.Is(err, service.ErrInvalidReturnTransition),
+			errors.Is(err, service.ErrReturnCarrierDisabled),
+			errors.Is(err, service.ErrNoCarrierIntegration):
+			writeError(w, http.StatusUnprocessableEntity, err.Error())
+		case errors.Is(err, service.ErrReturnLabelFailed):
+			writeError(w, http.StatusBadGateway, err.Error())
+		default:
+			if isValidationError(err) {
+				writeError(w, http.StatusBadRequest, err.Error())
+			} else {
+				writeError(w, http.StatusInternalServerError, "failed to generate return label")
+			}
+		}
+		return
+	}
+	writeJSON(w, http.StatusOK, ret)
+}
+
 func (h *ReturnHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	tenantID := middleware.TenantIDFromContext(r.Context())
 	actorID := middleware.UserIDFromContext(r.Context())
@@ -13,6 +13,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/openoms-org/openoms/apps/api-server/internal/model"
 	"github.com/openoms-org/openoms/apps/api-server/internal/service"
 )
 
@@ -209,3 +210,68 @@ func TestReturnHandler_Update_ValidationError(t *testing.T) {
 
 	assert.Equal(t, http.StatusBadRequest, rr.Code)
 }
+
+func TestReturnHandler_Receive_InvalidID(t *testing.T) {
+	svc := service.NewReturnService(nil, nil, nil, nil, nil)
+	h := NewReturnHandler(svc)
+
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", "not-a-uuid")
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/returns/not-a-uuid/receive", strings.NewReader(`{}`))
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	req = req.WithContext(newContextWithTenantAndUser(req.Context(), uuid.New(), uuid.New()))
+	rr := httptest.NewRecorder()
+
+	h.Receive(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestReturnHandler_Refund_ValidationError(t *testing.T) {
+	svc := service.NewReturnService(nil, nil, nil, nil, nil)
+	h := NewReturnHandler(svc)
+
+	returnID := uuid.New()
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", returnID.String())
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/returns/"+returnID.String()+"/refund", strings.NewReader(`{"method":"cash"}`))
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	req = req.WithContext(newContextWithTenantAndUser(req.Context(), uuid.New(), uuid.New()))
+	rr := httptest.NewRecorder()
+
+	h.Refund(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestReturnHandler_CreateLabel_InvalidID(t *testing.T) {
+	h := NewReturnHandler(nil)
+
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", "bad")
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/returns/bad/label", nil)
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+	req = req.WithContext(newContextWithTenantAndUser(req.Context(), uuid.New(), uuid.New()))
+	rr := httptest.NewRecorder()
+
+	h.CreateLabel(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestPublicReturnLabel(t *testing.T) {
+	assert.Nil(t, publicReturnLabel(&model.Return{}))
+
+	provider, tracking, url := "inpost", "RETURN555010", "https://oms.example.com/uploads/t/label.pdf"
+	label := publicReturnLabel(&model.Return{
+		ReturnProvider:       &provider,
+		ReturnTrackingNumber: &tracking,
+		ReturnLabelURL:       &url,
+	})
+	require.NotNil(t, label)
+	assert.Equal(t, &tracking, label["tracking_number"])
+	assert.Equal(t, &url, label["label_url"])
+}
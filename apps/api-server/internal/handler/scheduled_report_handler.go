@@ -0,0 +1,161 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/openoms-org/openoms/apps/api-server/internal/middleware"
+	"github.com/openoms-org/openoms/apps/api-server/internal/model"
+	"github.com/openoms-org/openoms/apps/api-server/internal/service"
+)
+
+// ScheduledReportHandler handles HTTP requests for scheduled email reports.
+type ScheduledReportHandler struct {
+	reportService *service.ScheduledReportService
+}
+
+func NewScheduledReportHandler(reportService *service.ScheduledReportService) *ScheduledReportHandler {
+	return &ScheduledReportHandler{reportService: reportService}
+}
+
+func (h *ScheduledReportHandler) List(w http.ResponseWriter, r *http.Request) {
+	tenantID := middleware.TenantIDFromContext(r.Context())
+
+	reports, err := h.reportService.List(r.Context(), tenantID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to list scheduled reports")
+		return
+	}
+	writeJSON(w, http.StatusOK, reports)
+}
+
+func (h *ScheduledReportHandler) Get(w http.ResponseWriter, r *http.Request) {
+	tenantID := middleware.TenantIDFromContext(r.Context())
+
+	reportID, ok := parseReportID(w, r)
+	if !ok {
+		return
+	}
+
+	report, err := h.reportService.Get(r.Context(), tenantID, reportID)
+	if err != nil {
+		writeReportError(w, err, "failed to get scheduled report")
+		return
+	}
+	writeJSON(w, http.StatusOK, report)
+}
+
+func (h *ScheduledReportHandler) Create(w http.ResponseWriter, r *http.Request) {
+	tenantID := middleware.TenantIDFromContext(r.Context())
+	actorID := middleware.UserIDFromContext(r.Context())
+
+	var req model.CreateScheduledReportRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	report, err := h.reportService.Create(r.Context(), tenantID, req, actorID, clientIP(r))
+	if err != nil {
+		writeReportError(w, err, "failed to create scheduled report")
+		return
+	}
+	writeJSON(w, http.StatusCreated, report)
+}
+
+func (h *ScheduledReportHandler) Update(w http.ResponseWriter, r *http.Request) {
+	tenantID := middleware.TenantIDFromContext(r.Context())
+	actorID := middleware.UserIDFromContext(r.Context())
+
+	reportID, ok := parseReportID(w, r)
+	if !ok {
+		return
+	}
+
+	var req model.UpdateScheduledReportRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	report, err := h.reportService.Update(r.Context(), tenantID, reportID, req, actorID, clientIP(r))
+	if err != nil {
+		writeReportError(w, err, "failed to update scheduled report")
+		return
+	}
+	writeJSON(w, http.StatusOK, report)
+}
+
+func (h *ScheduledReportHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	tenantID := middleware.TenantIDFromContext(r.Context())
+	actorID := middleware.UserIDFromContext(r.Context())
+
+	reportID, ok := parseReportID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.reportService.Delete(r.Context(), tenantID, reportID, actorID, clientIP(r)); err != nil {
+		writeReportError(w, err, "failed to delete scheduled report")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Run generates and sends the report immediately. The response is the
+// recorded run, which carries the error message when generation failed.
+func (h *ScheduledReportHandler) Run(w http.ResponseWriter, r *http.Request) {
+	tenantID := middleware.TenantIDFromContext(r.Context())
+	actorID := middleware.UserIDFromContext(r.Context())
+
+	reportID, ok := parseReportID(w, r)
+	if !ok {
+		return
+	}
+
+	run, err := h.reportService.RunNow(r.Context(), tenantID, reportID, actorID, clientIP(r))
+	if err != nil {
+		writeReportError(w, err, "failed to run scheduled report")
+		return
+	}
+	writeJSON(w, http.StatusOK, run)
+}
+
+func (h *ScheduledReportHandler) ListRuns(w http.ResponseWriter, r *http.Request) {
+	tenantID := middleware.TenantIDFromContext(r.Context())
+
+	reportID, ok := parseReportID(w, r)
+	if !ok {
+		return
+	}
+
+	resp, err := h.reportService.ListRuns(r.Context(), tenantID, reportID, model.ParsePagination(r))
+	if err != nil {
+		writeReportError(w, err, "failed to list report runs")
+		return
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func parseReportID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	reportID, err := uuid.Parse(chi.URLParam(r, "reportId"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid scheduled report ID")
+		return uuid.Nil, false
+	}
+	return reportID, true
+}
+
+func writeReportError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case errors.Is(err, service.ErrScheduledReportNotFound):
+		writeError(w, http.StatusNotFound, "scheduled report not found")
+	case isValidationError(err):
+		writeError(w, http.StatusBadRequest, err.Error())
+	default:
+		writeError(w, http.StatusInternalServerError, fallback)
+	}
+}
@@ -264,32 +264,10 @@ func (h *SettingsHandler) UpdateOrderStatuses(w http.ResponseWriter, r *http.Req
 		return
 	}
 
-	// Validate: no empty keys, no duplicate keys
-	keys := make(map[string]bool)
-	for _, s := range config.Statuses {
-		if s.Key == "" || s.Label == "" {
-			writeError(w, http.StatusBadRequest, "status key and label are required")
-			return
-		}
-		if keys[s.Key] {
-			writeError(w, http.StatusBadRequest, fmt.Sprintf("duplicate status key: %s", s.Key))
-			return
-		}
-		keys[s.Key] = true
-	}
-
-	// Validate: all transition targets reference existing statuses
-	for from, targets := range config.Transitions {
-		if !keys[from] {
-			writeError(w, http.StatusBadRequest, fmt.Sprintf("transition from unknown status: %s", from))
-			return
-		}
-		for _, to := range targets {
-			if !keys[to] {
-				writeError(w, http.StatusBadRequest, fmt.Sprintf("transition to unknown status: %s", to))
-				return
-			}
-		}
+	// Validate: keys, categories, guards and transitions form a workflow
+	if err := config.Validate(); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
 	}
 
 	actorID := middleware.UserIDFromContext(r.Context())
@@ -729,6 +707,10 @@ func (h *SettingsHandler) UpdateInventorySettings(w http.ResponseWriter, r *http
 		writeError(w, http.StatusBadRequest, "invalid request body")
 		return
 	}
+	if err := inventoryCfg.Validate(); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
 
 	err := database.WithTenant(r.Context(), h.pool, tenantID, func(tx pgx.Tx) error {
 		if err := h.updateSettingsSection(r.Context(), tx, tenantID, "inventory", inventoryCfg); err != nil {
@@ -751,6 +733,220 @@ func (h *SettingsHandler) UpdateInventorySettings(w http.ResponseWriter, r *http
 	writeJSON(w, http.StatusOK, inventoryCfg)
 }
 
+func (h *SettingsHandler) GetReturnSettings(w http.ResponseWriter, r *http.Request) {
+	tenantID := middleware.TenantIDFromContext(r.Context())
+
+	returnCfg := model.ReturnSettings{}
+	err := database.WithTenant(r.Context(), h.pool, tenantID, func(tx pgx.Tx) error {
+		return h.getSettingsSection(r.Context(), tx, tenantID, "returns", &returnCfg)
+	})
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to load return settings")
+		return
+	}
+	returnCfg.ShippingRefund = returnCfg.ShippingRefundPolicy()
+
+	writeJSON(w, http.StatusOK, returnCfg)
+}
+
+func (h *SettingsHandler) UpdateReturnSettings(w http.ResponseWriter, r *http.Request) {
+	tenantID := middleware.TenantIDFromContext(r.Context())
+	actorID := middleware.UserIDFromContext(r.Context())
+
+	var returnCfg model.ReturnSettings
+	if err := json.NewDecoder(r.Body).Decode(&returnCfg); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if err := returnCfg.Validate(); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err := database.WithTenant(r.Context(), h.pool, tenantID, func(tx pgx.Tx) error {
+		if err := h.updateSettingsSection(r.Context(), tx, tenantID, "returns", returnCfg); err != nil {
+			return err
+		}
+		return h.auditRepo.Log(r.Context(), tx, model.AuditEntry{
+			TenantID:   tenantID,
+			UserID:     actorID,
+			Action:     "settings.returns_updated",
+			EntityType: "settings",
+			EntityID:   tenantID,
+			IPAddress:  clientIP(r),
+		})
+	})
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to save return settings")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, returnCfg)
+}
+
+func (h *SettingsHandler) GetDropshipSettings(w http.ResponseWriter, r *http.Request) {
+	tenantID := middleware.TenantIDFromContext(r.Context())
+
+	dropshipCfg := model.DropshipSettings{}
+	err := database.WithTenant(r.Context(), h.pool, tenantID, func(tx pgx.Tx) error {
+		return h.getSettingsSection(r.Context(), tx, tenantID, "dropshipping", &dropshipCfg)
+	})
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to load dropshipping settings")
+		return
+	}
+	dropshipCfg.RouteStatuses = dropshipCfg.Statuses()
+
+	writeJSON(w, http.StatusOK, dropshipCfg)
+}
+
+func (h *SettingsHandler) UpdateDropshipSettings(w http.ResponseWriter, r *http.Request) {
+	tenantID := middleware.TenantIDFromContext(r.Context())
+	actorID := middleware.UserIDFromContext(r.Context())
+
+	var dropshipCfg model.DropshipSettings
+	if err := json.NewDecoder(r.Body).Decode(&dropshipCfg); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if err := dropshipCfg.Validate(); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err := database.WithTenant(r.Context(), h.pool, tenantID, func(tx pgx.Tx) error {
+		if err := h.updateSettingsSection(r.Context(), tx, tenantID, "dropshipping", dropshipCfg); err != nil {
+			return err
+		}
+		return h.auditRepo.Log(r.Context(), tx, model.AuditEntry{
+			TenantID:   tenantID,
+			UserID:     actorID,
+			Action:     "settings.dropshipping_updated",
+			EntityType: "settings",
+			EntityID:   tenantID,
+			IPAddress:  clientIP(r),
+		})
+	})
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to save dropshipping settings")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, dropshipCfg)
+}
+
+func (h *SettingsHandler) GetOrderHoldSettings(w http.ResponseWriter, r *http.Request) {
+	tenantID := middleware.TenantIDFromContext(r.Context())
+
+	holdCfg := model.OrderHoldSettings{}
+	err := database.WithTenant(r.Context(), h.pool, tenantID, func(tx pgx.Tx) error {
+		return h.getSettingsSection(r.Context(), tx, tenantID, "order_holds", &holdCfg)
+	})
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to load order hold settings")
+		return
+	}
+	if holdCfg.ImportChecks == nil {
+		holdCfg.ImportChecks = []string{}
+	}
+
+	writeJSON(w, http.StatusOK, holdCfg)
+}
+
+func (h *SettingsHandler) UpdateOrderHoldSettings(w http.ResponseWriter, r *http.Request) {
+	tenantID := middleware.TenantIDFromContext(r.Context())
+	actorID := middleware.UserIDFromContext(r.Context())
+
+	var holdCfg model.OrderHoldSettings
+	if err := json.NewDecoder(r.Body).Decode(&holdCfg); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if err := holdCfg.Validate(); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if holdCfg.ImportChecks == nil {
+		holdCfg.ImportChecks = []string{}
+	}
+
+	err := database.WithTenant(r.Context(), h.pool, tenantID, func(tx pgx.Tx) error {
+		if err := h.updateSettingsSection(r.Context(), tx, tenantID, "order_holds", holdCfg); err != nil {
+			return err
+		}
+		return h.auditRepo.Log(r.Context(), tx, model.AuditEntry{
+			TenantID:   tenantID,
+			UserID:     actorID,
+			Action:     "settings.order_holds_updated",
+			EntityType: "settings",
+			EntityID:   tenantID,
+			IPAddress:  clientIP(r),
+		})
+	})
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to save order hold settings")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, holdCfg)
+}
+
+func (h *SettingsHandler) GetOrderRiskSettings(w http.ResponseWriter, r *http.Request) {
+	tenantID := middleware.TenantIDFromContext(r.Context())
+
+	riskCfg := model.DefaultOrderRiskSettings()
+	err := database.WithTenant(r.Context(), h.pool, tenantID, func(tx pgx.Tx) error {
+		return h.getSettingsSection(r.Context(), tx, tenantID, "order_risk", &riskCfg)
+	})
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to load order risk settings")
+		return
+	}
+	if riskCfg.DisposableDomains == nil {
+		riskCfg.DisposableDomains = []string{}
+	}
+
+	writeJSON(w, http.StatusOK, riskCfg)
+}
+
+func (h *SettingsHandler) UpdateOrderRiskSettings(w http.ResponseWriter, r *http.Request) {
+	tenantID := middleware.TenantIDFromContext(r.Context())
+	actorID := middleware.UserIDFromContext(r.Context())
+
+	riskCfg := model.DefaultOrderRiskSettings()
+	if err := json.NewDecoder(r.Body).Decode(&riskCfg); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if err := riskCfg.Validate(); err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if riskCfg.DisposableDomains == nil {
+		riskCfg.DisposableDomains = []string{}
+	}
+
+	err := database.WithTenant(r.Context(), h.pool, tenantID, func(tx pgx.Tx) error {
+		if err := h.updateSettingsSection(r.Context(), tx, tenantID, "order_risk", riskCfg); err != nil {
+			return err
+		}
+		return h.auditRepo.Log(r.Context(), tx, model.AuditEntry{
+			TenantID:   tenantID,
+			UserID:     actorID,
+			Action:     "settings.order_risk_updated",
+			EntityType: "settings",
+			EntityID:   tenantID,
+			IPAddress:  clientIP(r),
+		})
+	})
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to save order risk settings")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, riskCfg)
+}
+
 func (h *SettingsHandler) SendTestSMS(w http.ResponseWriter, r *http.Request) {
 	tenantID := middleware.TenantIDFromContext(r.Context())
 
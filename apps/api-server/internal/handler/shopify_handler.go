@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	shopifysdk "github.com/openoms-org/openoms/packages/shopify-go-sdk"
+
+	"github.com/openoms-org/openoms/apps/api-server/internal/integration"
+	shopifyIntegration "github.com/openoms-org/openoms/apps/api-server/internal/integration/shopify"
+	"github.com/openoms-org/openoms/apps/api-server/internal/middleware"
+	"github.com/openoms-org/openoms/apps/api-server/internal/service"
+)
+
+// ShopifyHandler handles Shopify-specific API endpoints (fulfillment, locations).
+type ShopifyHandler struct {
+	integrationService *service.IntegrationService
+	orderService       *service.OrderService
+}
+
+// NewShopifyHandler creates a new ShopifyHandler.
+func NewShopifyHandler(integrationService *service.IntegrationService, orderService *service.OrderService) *ShopifyHandler {
+	return &ShopifyHandler{
+		integrationService: integrationService,
+		orderService:       orderService,
+	}
+}
+
+// getProvider creates a Shopify provider from the integration credentials and settings for the given tenant.
+func (h *ShopifyHandler) getProvider(ctx context.Context, tenantID uuid.UUID) (*shopifyIntegration.Provider, error) {
+	credJSON, integ, err := h.integrationService.GetDecryptedCredentialsByProvider(ctx, tenantID, "shopify")
+	if err != nil {
+		return nil, err
+	}
+	return shopifyIntegration.NewProvider(credJSON, integ.Settings)
+}
+
+// CreateFulfillment handles POST /v1/integrations/shopify/orders/{orderId}/fulfillment.
+// It marks the Shopify order as fulfilled, optionally attaching tracking information.
+func (h *ShopifyHandler) CreateFulfillment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	tenantID := middleware.TenantIDFromContext(ctx)
+	orderIDStr := chi.URLParam(r, "orderId")
+
+	orderID, err := uuid.Parse(orderIDStr)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid order ID")
+		return
+	}
+
+	var body integration.FulfillmentTracking
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	// Get order from DB to find external_id
+	order, err := h.orderService.Get(ctx, tenantID, orderID)
+	if err != nil {
+		slog.Error("shopify fulfillment: get order failed", "error", err)
+		writeError(w, http.StatusNotFound, "order not found")
+		return
+	}
+	if order.ExternalID == nil || *order.ExternalID == "" {
+		writeError(w, http.StatusBadRequest, "order has no external Shopify ID")
+		return
+	}
+
+	provider, err := h.getProvider(ctx, tenantID)
+	if err != nil {
+		slog.Error("shopify fulfillment: get provider failed", "error", err)
+		writeError(w, http.StatusInternalServerError, "failed to connect to Shopify")
+		return
+	}
+
+	fulfillmentID, err := provider.CreateFulfillment(ctx, *order.ExternalID, body)
+	if err != nil {
+		slog.Error("shopify fulfillment: create failed", "order_id", orderIDStr, "error", err)
+		writeError(w, http.StatusBadGateway, "failed to create fulfillment on Shopify")
+		return
+	}
+	if err := h.orderService.RecordMarketplaceSync(ctx, tenantID, orderID, "shopify", "fulfillment",
+		map[string]string{"fulfillment_id": fulfillmentID}, middleware.UserIDFromContext(ctx)); err != nil {
+		slog.Error("shopify fulfillment: record sync failed", "order_id", orderIDStr, "error", err)
+	}
+
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok", "fulfillment_id": fulfillmentID})
+}
+
+// ListLocations handles GET /v1/integrations/shopify/locations.
+// It returns the shop's locations for configuring the warehouse → location mapping.
+func (h *ShopifyHandler) ListLocations(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	tenantID := middleware.TenantIDFromContext(ctx)
+
+	provider, err := h.getProvider(ctx, tenantID)
+	if err != nil {
+		slog.Error("shopify locations: get provider failed", "error", err)
+		writeError(w, http.StatusInternalServerError, "failed to connect to Shopify")
+		return
+	}
+
+	locations, err := provider.ListLocations(ctx)
+	if err != nil {
+		slog.Error("shopify locations: list failed", "error", err)
+		writeError(w, http.StatusBadGateway, "failed to list locations from Shopify")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string][]shopifysdk.Location{"locations": locations})
+}
@@ -1,11 +1,15 @@
 package handler
 
 import (
+	"encoding/csv"
+	"fmt"
 	"log/slog"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/openoms-org/openoms/apps/api-server/internal/middleware"
+	"github.com/openoms-org/openoms/apps/api-server/internal/model"
 	"github.com/openoms-org/openoms/apps/api-server/internal/service"
 )
 
@@ -97,3 +101,83 @@ func (h *StatsHandler) GetPaymentMethodStats(w http.ResponseWriter, r *http.Requ
 	}
 	writeJSON(w, http.StatusOK, stats)
 }
+
+func (h *StatsHandler) GetProfitability(w http.ResponseWriter, r *http.Request) {
+	tenantID := middleware.TenantIDFromContext(r.Context())
+
+	filter, err := model.ParseProfitabilityFilter(r.URL.Query())
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	report, err := h.statsService.GetProfitability(r.Context(), tenantID, filter)
+	if err != nil {
+		slog.Error("profitability stats failed", "error", err)
+		writeError(w, http.StatusInternalServerError, "failed to retrieve profitability report")
+		return
+	}
+	writeJSON(w, http.StatusOK, report)
+}
+
+// ExportProfitabilityCSV writes the profitability report with all rows of the
+// grouping and a totals row.
+func (h *StatsHandler) ExportProfitabilityCSV(w http.ResponseWriter, r *http.Request) {
+	tenantID := middleware.TenantIDFromContext(r.Context())
+
+	filter, err := model.ParseProfitabilityFilter(r.URL.Query())
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	filter.Limit = model.MaxProfitabilityRows
+
+	report, err := h.statsService.GetProfitability(r.Context(), tenantID, filter)
+	if err != nil {
+		slog.Error("profitability export failed", "error", err)
+		writeError(w, http.StatusInternalServerError, "failed to retrieve profitability report")
+		return
+	}
+
+	filename := fmt.Sprintf("rentownosc-%s-%s.csv", filter.GroupBy, time.Now().Format("2006-01-02"))
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+
+	// BOM for Excel UTF-8 compatibility
+	w.Write([]byte{0xEF, 0xBB, 0xBF})
+
+	writer := csv.NewWriter(w)
+	defer writer.Flush()
+
+	header := []string{
+		"Klucz", "Nazwa", "Zamówienia", "Ilość", "Przychód", "Koszt produktów",
+		"Koszt wysyłki", "Prowizje", "Marża brutto", "Marża %", "Pozycje bez kosztu", "Waluta",
+	}
+	if err := writer.Write(header); err != nil {
+		slog.Error("profitability export: failed to write header", "error", err)
+		return
+	}
+	for _, row := range append(report.Rows, report.Totals) {
+		margin := ""
+		if row.MarginPercent != nil {
+			margin = fmt.Sprintf("%.2f", *row.MarginPercent)
+		}
+		if err := writer.Write([]string{
+			row.Key,
+			row.Label,
+			strconv.Itoa(row.Orders),
+			strconv.Itoa(row.Quantity),
+			fmt.Sprintf("%.2f", row.Revenue),
+			fmt.Sprintf("%.2f", row.ProductCost),
+			fmt.Sprintf("%.2f", row.ShippingCost),
+			fmt.Sprintf("%.2f", row.MarketplaceFee),
+			fmt.Sprintf("%.2f", row.GrossMargin),
+			margin,
+			strconv.Itoa(row.MissingCostLines),
+			report.Currency,
+		}); err != nil {
+			slog.Error("profitability export: failed to write row", "error", err)
+			return
+		}
+	}
+}
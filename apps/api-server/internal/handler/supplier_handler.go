@@ -3,12 +3,14 @@ package handler
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
 	"github.com/openoms-org/openoms/apps/api-server/internal/middleware"
 	"github.com/openoms-org/openoms/apps/api-server/internal/model"
+	"github.com/openoms-org/openoms/apps/api-server/internal/netutil"
 	"github.com/openoms-org/openoms/apps/api-server/internal/service"
 )
 
@@ -162,6 +164,50 @@ func (h *SupplierHandler) Sync(w http.ResponseWriter, r *http.Request) {
 	writeJSON(w, http.StatusOK, map[string]string{"message": "feed synced successfully"})
 }
 
+// PreviewFeed parses the supplier feed with the saved or the given mapping
+// and returns the first products without saving them.
+func (h *SupplierHandler) PreviewFeed(w http.ResponseWriter, r *http.Request) {
+	tenantID := middleware.TenantIDFromContext(r.Context())
+
+	supplierID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid supplier ID")
+		return
+	}
+
+	var req model.SupplierFeedPreviewRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid request body")
+			return
+		}
+	}
+
+	// SSRF protection: the preview fetches a URL supplied in the request
+	if req.FeedURL != nil && *req.FeedURL != "" && netutil.IsPrivateURL(*req.FeedURL) {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("feed URL %q resolves to a private/internal address", *req.FeedURL))
+		return
+	}
+
+	preview, err := h.supplierService.PreviewFeed(r.Context(), tenantID, supplierID, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrSupplierNotFound):
+			writeError(w, http.StatusNotFound, "supplier not found")
+		case errors.Is(err, service.ErrNoFeedURL):
+			writeError(w, http.StatusBadRequest, "supplier has no feed URL configured")
+		case errors.Is(err, service.ErrFeedReadFailed):
+			writeError(w, http.StatusUnprocessableEntity, err.Error())
+		case isValidationError(err):
+			writeError(w, http.StatusBadRequest, err.Error())
+		default:
+			writeError(w, http.StatusInternalServerError, "failed to preview feed")
+		}
+		return
+	}
+	writeJSON(w, http.StatusOK, preview)
+}
+
 func (h *SupplierHandler) ListProducts(w http.ResponseWriter, r *http.Request) {
 	tenantID := middleware.TenantIDFromContext(r.Context())
 	pagination := model.ParsePagination(r)
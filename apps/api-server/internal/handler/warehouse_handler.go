@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"net/http"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
@@ -231,3 +232,52 @@ func (h *WarehouseHandler) ListProductStock(w http.ResponseWriter, r *http.Reque
 	}
 	writeJSON(w, http.StatusOK, stocks)
 }
+
+// ListStockMovements returns the stock movement history of a product.
+func (h *WarehouseHandler) ListStockMovements(w http.ResponseWriter, r *http.Request) {
+	tenantID := middleware.TenantIDFromContext(r.Context())
+
+	productID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid product ID")
+		return
+	}
+
+	filter, err := model.ParseStockMovementFilter(r.URL.Query(), model.ParsePagination(r))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	resp, err := h.warehouseService.ListStockMovements(r.Context(), tenantID, productID, filter)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to list stock movements")
+		return
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// GetStockAt returns the stock of a product at a past date, reconstructed
+// from its movements.
+func (h *WarehouseHandler) GetStockAt(w http.ResponseWriter, r *http.Request) {
+	tenantID := middleware.TenantIDFromContext(r.Context())
+
+	productID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid product ID")
+		return
+	}
+
+	filter, err := model.ParseStockAtFilter(r.URL.Query(), time.Now())
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	stock, err := h.warehouseService.StockAt(r.Context(), tenantID, productID, filter)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "failed to reconstruct stock")
+		return
+	}
+	writeJSON(w, http.StatusOK, stock)
+}
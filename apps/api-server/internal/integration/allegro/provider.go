@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	"math"
 	"strconv"
 	"time"
 
@@ -34,6 +35,11 @@ type AllegroCredentials struct {
 type Provider struct {
 	client *allegrosdk.Client
 	logger *slog.Logger
+
+	// commission rates by category, resolved while syncing prices
+	commissions map[string]float64
+	// currency of pushed prices, from the integration's pricing rule
+	currency string
 }
 
 // NewProvider creates an Allegro MarketplaceProvider from encrypted credentials.
@@ -54,9 +60,16 @@ func NewProvider(credentials json.RawMessage, settings json.RawMessage) (*Provid
 
 	client := allegrosdk.NewClient(creds.ClientID, creds.ClientSecret, opts...)
 
+	currency := "PLN"
+	if rule, err := model.ParseChannelPricingRule(settings); err == nil && rule != nil {
+		currency = rule.Currency
+	}
+
 	return &Provider{
-		client: client,
-		logger: slog.Default().With("provider", "allegro"),
+		client:      client,
+		logger:      slog.Default().With("provider", "allegro"),
+		commissions: make(map[string]float64),
+		currency:    currency,
 	}, nil
 }
 
@@ -127,9 +140,135 @@ func (p *Provider) UpdateStock(ctx context.Context, externalOfferID string, quan
 	return p.client.Offers.UpdateStock(ctx, externalOfferID, quantity)
 }
 
-// UpdatePrice updates the price for an Allegro offer.
+// UpdatePrice updates the price for an Allegro offer, in the currency of the
+// integration's pricing rule (PLN without one).
 func (p *Provider) UpdatePrice(ctx context.Context, externalOfferID string, price float64) error {
-	return p.client.Offers.UpdatePrice(ctx, externalOfferID, price, "PLN")
+	return p.client.Offers.UpdatePrice(ctx, externalOfferID, price, p.currency)
+}
+
+// CommissionRate returns the commission rate of the offer's category as a
+// fraction. Rates are cached per category for the lifetime of the provider.
+func (p *Provider) CommissionRate(ctx context.Context, externalOfferID string) (float64, error) {
+	offer, err := p.client.Offers.Get(ctx, externalOfferID)
+	if err != nil {
+		return 0, fmt.Errorf("allegro: get offer %s: %w", externalOfferID, err)
+	}
+	if offer.Category == nil || offer.Category.ID == "" {
+		return 0, fmt.Errorf("allegro: offer %s has no category", externalOfferID)
+	}
+	categoryID := offer.Category.ID
+	if rate, ok := p.commissions[categoryID]; ok {
+		return rate, nil
+	}
+
+	list, err := p.client.Pricing.GetCommissions(ctx, categoryID)
+	if err != nil {
+		return 0, fmt.Errorf("allegro: get commissions for category %s: %w", categoryID, err)
+	}
+	var rate float64
+	for _, c := range list.Commissions {
+		for _, r := range c.Rates {
+			// Percent is expressed in percent (e.g. 8.5), Value as a fraction.
+			candidate := r.Percent / 100
+			if candidate == 0 {
+				candidate = r.Value
+			}
+			if candidate > rate {
+				rate = candidate
+			}
+		}
+	}
+	p.commissions[categoryID] = rate
+	return rate, nil
+}
+
+// OrderFees sums the billing entries charged for an Allegro order. Billing
+// amounts are negative for charges and positive for refunds of fees.
+func (p *Provider) OrderFees(ctx context.Context, externalOrderID string) (float64, error) {
+	const pageSize = 100
+	var total float64
+	for offset := 0; ; offset += pageSize {
+		list, err := p.client.Account.ListBilling(ctx, &allegrosdk.BillingParams{
+			Limit:   pageSize,
+			Offset:  offset,
+			OrderID: externalOrderID,
+		})
+		if err != nil {
+			return 0, fmt.Errorf("allegro: list billing for order %s: %w", externalOrderID, err)
+		}
+		for _, entry := range list.BillingEntries {
+			amount, err := strconv.ParseFloat(entry.Amount.Amount, 64)
+			if err != nil {
+				return 0, fmt.Errorf("allegro: parse billing amount %q: %w", entry.Amount.Amount, err)
+			}
+			total -= amount
+		}
+		if len(list.BillingEntries) < pageSize {
+			break
+		}
+	}
+	return math.Round(total*100) / 100, nil
+}
+
+// CreateRefund refunds the buyer of an Allegro order through Allegro Finance.
+// The payment is resolved from the checkout form.
+func (p *Provider) CreateRefund(ctx context.Context, externalOrderID string, req integration.RefundRequest) (string, error) {
+	order, err := p.client.Orders.Get(ctx, externalOrderID)
+	if err != nil {
+		return "", fmt.Errorf("allegro: get order %s: %w", externalOrderID, err)
+	}
+	if order.Payment.ID == "" {
+		return "", fmt.Errorf("allegro: order %s has no payment", externalOrderID)
+	}
+
+	currency := req.Currency
+	if currency == "" {
+		currency = "PLN"
+	}
+	refund := allegrosdk.CreateRefundRequest{
+		Payment: allegrosdk.RefundPayment{ID: order.Payment.ID},
+		Reason:  "REFUND",
+	}
+	for _, line := range req.Lines {
+		refund.LineItems = append(refund.LineItems, allegrosdk.RefundLineItem{
+			OfferID:  line.ExternalID,
+			Quantity: line.Quantity,
+			Amount:   allegrosdk.Amount{Amount: strconv.FormatFloat(line.Amount, 'f', 2, 64), Currency: currency},
+		})
+	}
+	if req.Shipping > 0 {
+		refund.Delivery = &allegrosdk.RefundDelivery{
+			Value: allegrosdk.Amount{Amount: strconv.FormatFloat(req.Shipping, 'f', 2, 64), Currency: currency},
+		}
+	}
+
+	result, err := p.client.Payments.CreateRefund(ctx, refund)
+	if err != nil {
+		return "", fmt.Errorf("allegro: create refund for order %s: %w", externalOrderID, err)
+	}
+	p.logger.Info("refund created", "order_id", externalOrderID, "refund_id", result.ID)
+	return result.ID, nil
+}
+
+// MessageBuyer sends a message to the buyer of an Allegro order, in the
+// buyer's thread with the seller.
+func (p *Provider) MessageBuyer(ctx context.Context, externalOrderID, text string) error {
+	order, err := p.client.Orders.Get(ctx, externalOrderID)
+	if err != nil {
+		return fmt.Errorf("allegro: get order %s: %w", externalOrderID, err)
+	}
+	if order.Buyer.Login == "" {
+		return fmt.Errorf("allegro: order %s has no buyer login", externalOrderID)
+	}
+	_, err = p.client.Messages.CreateMessage(ctx, allegrosdk.NewMessageRequest{
+		Recipient: allegrosdk.MessageRecipient{Login: order.Buyer.Login},
+		Text:      text,
+		Order:     &allegrosdk.MessageOrder{ID: externalOrderID},
+	})
+	if err != nil {
+		return fmt.Errorf("allegro: message buyer of order %s: %w", externalOrderID, err)
+	}
+	return nil
 }
 
 // UpdateFulfillment updates the fulfillment status of an Allegro order.
@@ -0,0 +1,137 @@
+package allegro
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	allegrosdk "github.com/openoms-org/openoms/packages/allegro-go-sdk"
+)
+
+// newTestProvider creates a Provider backed by an Allegro API stand-in served
+// by handler.
+func newTestProvider(t *testing.T, handler http.HandlerFunc) *Provider {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	client := allegrosdk.NewClient("client-id", "client-secret",
+		allegrosdk.WithBaseURL(srv.URL),
+		allegrosdk.WithHTTPClient(srv.Client()),
+		allegrosdk.WithTokens("access-token", "refresh-token", time.Now().Add(time.Hour)),
+	)
+	t.Cleanup(client.Close)
+	return &Provider{
+		client:      client,
+		logger:      slog.Default().With("provider", "allegro-test"),
+		commissions: make(map[string]float64),
+		currency:    "PLN",
+	}
+}
+
+func TestNewProviderPricingCurrency(t *testing.T) {
+	creds := json.RawMessage(`{"client_id":"id","client_secret":"secret"}`)
+
+	p, err := NewProvider(creds, nil)
+	if err != nil {
+		t.Fatalf("NewProvider() error: %v", err)
+	}
+	defer p.Close()
+	if p.currency != "PLN" {
+		t.Errorf("currency without pricing rule = %q, want PLN", p.currency)
+	}
+
+	p, err = NewProvider(creds, json.RawMessage(`{"pricing":{"enabled":true,"currency":"czk"}}`))
+	if err != nil {
+		t.Fatalf("NewProvider() error: %v", err)
+	}
+	defer p.Close()
+	if p.currency != "CZK" {
+		t.Errorf("currency = %q, want CZK", p.currency)
+	}
+}
+
+func TestUpdatePriceUsesRuleCurrency(t *testing.T) {
+	var body map[string]any
+	p := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch || r.URL.Path != "/sale/product-offers/offer-1" {
+			t.Errorf("request = %s %s", r.Method, r.URL.Path)
+		}
+		raw, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(raw, &body); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	})
+	p.currency = "EUR"
+
+	if err := p.UpdatePrice(context.Background(), "offer-1", 12.5); err != nil {
+		t.Fatalf("UpdatePrice() error: %v", err)
+	}
+	price := body["sellingMode"].(map[string]any)["price"].(map[string]any)
+	if price["amount"] != "12.50" || price["currency"] != "EUR" {
+		t.Errorf("price = %v", price)
+	}
+}
+
+func TestOrderFeesSumsAllPages(t *testing.T) {
+	var offsets []string
+	p := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/billing/billing-entries" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		q := r.URL.Query()
+		if q.Get("order.id") != "order-1" || q.Get("limit") != "100" {
+			t.Errorf("query = %s", r.URL.RawQuery)
+		}
+		offsets = append(offsets, q.Get("offset"))
+
+		var list allegrosdk.BillingList
+		if q.Get("offset") == "" {
+			for i := 0; i < 100; i++ {
+				list.BillingEntries = append(list.BillingEntries, allegrosdk.BillingEntry{
+					ID:     fmt.Sprintf("fee-%d", i),
+					Amount: allegrosdk.Amount{Amount: "-0.50", Currency: "PLN"},
+				})
+			}
+		} else {
+			// The second page holds a commission and a refunded listing fee,
+			// which Allegro reports as a positive amount.
+			list.BillingEntries = []allegrosdk.BillingEntry{
+				{ID: "commission", Amount: allegrosdk.Amount{Amount: "-1.23", Currency: "PLN"}},
+				{ID: "refund", Amount: allegrosdk.Amount{Amount: "3.00", Currency: "PLN"}},
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(list)
+	})
+
+	fees, err := p.OrderFees(context.Background(), "order-1")
+	if err != nil {
+		t.Fatalf("OrderFees() error: %v", err)
+	}
+	if fees != 48.23 {
+		t.Errorf("fees = %v, want 48.23", fees)
+	}
+	if len(offsets) != 2 || offsets[0] != "" || offsets[1] != "100" {
+		t.Errorf("offsets = %q, want [\"\" \"100\"]", offsets)
+	}
+}
+
+func TestOrderFeesInvalidAmount(t *testing.T) {
+	p := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"billingEntries":[{"id":"fee","amount":{"amount":"n/a","currency":"PLN"}}],"count":1}`))
+	})
+
+	if _, err := p.OrderFees(context.Background(), "order-1"); err == nil {
+		t.Fatal("OrderFees() error = nil, want parse error")
+	}
+}
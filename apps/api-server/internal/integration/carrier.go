@@ -14,6 +14,10 @@ type CarrierReceiver struct {
 	City       string `json:"city"`
 	PostalCode string `json:"postal_code"`
 	Country    string `json:"country"`
+	// StreetName and BuildingNumber split Street for carriers taking them
+	// separately; empty when the address was not validated.
+	StreetName     string `json:"street_name,omitempty"`
+	BuildingNumber string `json:"building_number,omitempty"`
 }
 
 // CarrierParcel describes the physical parcel to be shipped.
@@ -47,16 +51,19 @@ type PickupPoint struct {
 
 // CarrierShipmentRequest contains all data needed to create a shipment with a carrier.
 type CarrierShipmentRequest struct {
-	OrderID       string          `json:"order_id"`
-	ServiceType   string          `json:"service_type"`
-	Receiver      CarrierReceiver `json:"receiver"`
-	Parcel        CarrierParcel   `json:"parcel"`
-	TargetPoint   string          `json:"target_point,omitempty"`   // locker ID for InPost
-	SendingMethod string          `json:"sending_method,omitempty"` // e.g. parcel_locker, dispatch_order
-	CODAmount     float64         `json:"cod_amount,omitempty"`
-	CODCurrency   string          `json:"cod_currency,omitempty"`
-	InsuredValue  float64         `json:"insured_value,omitempty"`
-	Reference     string          `json:"reference,omitempty"`
+	OrderID     string          `json:"order_id"`
+	ServiceType string          `json:"service_type"`
+	Receiver    CarrierReceiver `json:"receiver"`
+	// Sender overrides the account's default sender address. It is set for
+	// return shipments, where the customer sends the parcel to the warehouse.
+	Sender        *CarrierReceiver `json:"sender,omitempty"`
+	Parcel        CarrierParcel    `json:"parcel"`
+	TargetPoint   string           `json:"target_point,omitempty"`   // locker ID for InPost
+	SendingMethod string           `json:"sending_method,omitempty"` // e.g. parcel_locker, dispatch_order
+	CODAmount     float64          `json:"cod_amount,omitempty"`
+	CODCurrency   string           `json:"cod_currency,omitempty"`
+	InsuredValue  float64          `json:"insured_value,omitempty"`
+	Reference     string           `json:"reference,omitempty"`
 }
 
 // CarrierShipmentResponse is returned after a shipment is created with a carrier.
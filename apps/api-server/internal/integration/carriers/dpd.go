@@ -75,6 +75,18 @@ func (p *DPDProvider) CreateShipment(ctx context.Context, req integration.Carrie
 		Reference: req.Reference,
 	}
 
+	if req.Sender != nil {
+		dpdReq.Sender = dpdsdk.Address{
+			Name:        req.Sender.Name,
+			Email:       req.Sender.Email,
+			Phone:       req.Sender.Phone,
+			Street:      req.Sender.Street,
+			City:        req.Sender.City,
+			PostalCode:  req.Sender.PostalCode,
+			CountryCode: req.Sender.Country,
+		}
+	}
+
 	if req.CODAmount > 0 {
 		currency := req.CODCurrency
 		if currency == "" {
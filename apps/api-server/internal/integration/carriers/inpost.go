@@ -89,6 +89,20 @@ func (p *InPostProvider) CreateShipment(ctx context.Context, req integration.Car
 		Reference: req.Reference,
 	}
 
+	if req.Sender != nil {
+		inpostReq.Sender = &inpostsdk.Receiver{
+			Name:  req.Sender.Name,
+			Phone: req.Sender.Phone,
+			Email: req.Sender.Email,
+			Address: &inpostsdk.Address{
+				Street:      req.Sender.Street,
+				City:        req.Sender.City,
+				PostCode:    req.Sender.PostalCode,
+				CountryCode: req.Sender.Country,
+			},
+		}
+	}
+
 	// Set custom attributes (target point and/or sending method)
 	if req.TargetPoint != "" || req.SendingMethod != "" {
 		inpostReq.CustomAttributes = &inpostsdk.CustomAttributes{}
@@ -108,6 +122,10 @@ func (p *InPostProvider) CreateShipment(ctx context.Context, req integration.Car
 			PostCode:    req.Receiver.PostalCode,
 			CountryCode: req.Receiver.Country,
 		}
+		if req.Receiver.StreetName != "" && req.Receiver.BuildingNumber != "" {
+			inpostReq.Receiver.Address.Street = req.Receiver.StreetName
+			inpostReq.Receiver.Address.BuildingNumber = req.Receiver.BuildingNumber
+		}
 	}
 
 	shipment, err := p.client.Shipments.Create(ctx, inpostReq)
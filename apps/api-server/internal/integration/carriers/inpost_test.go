@@ -269,6 +269,120 @@ func TestInPostCreateShipment_Courier(t *testing.T) {
 	}
 }
 
+func TestInPostCreateShipment_CourierBuildingNumber(t *testing.T) {
+	var receivedBody map[string]any
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &receivedBody)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id": 67891, "tracking_number": "COURIER789013", "status": "confirmed"}`))
+	}))
+	defer srv.Close()
+
+	provider := newTestProvider(t, srv.URL)
+
+	_, err := provider.CreateShipment(context.Background(), integration.CarrierShipmentRequest{
+		OrderID: "ORD-003",
+		Receiver: integration.CarrierReceiver{
+			Name:           "Anna Nowak",
+			Phone:          "600700800",
+			Street:         "Marszalkowska 10/4",
+			StreetName:     "Marszalkowska",
+			BuildingNumber: "10/4",
+			City:           "Warszawa",
+			PostalCode:     "00-001",
+			Country:        "PL",
+		},
+		Parcel: integration.CarrierParcel{WeightKg: 1},
+	})
+	if err != nil {
+		t.Fatalf("CreateShipment() returned error: %v", err)
+	}
+
+	rcv, _ := receivedBody["receiver"].(map[string]any)
+	addr, _ := rcv["address"].(map[string]any)
+	if addr["street"] != "Marszalkowska" {
+		t.Errorf("expected street Marszalkowska, got %v", addr["street"])
+	}
+	if addr["building_number"] != "10/4" {
+		t.Errorf("expected building_number 10/4, got %v", addr["building_number"])
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Test 2b: CreateShipment — Return from a locker to the warehouse
+// ---------------------------------------------------------------------------
+
+func TestInPostCreateShipment_ReturnSender(t *testing.T) {
+	var receivedBody map[string]any
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &receivedBody)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{
+			"id": 55501,
+			"tracking_number": "RETURN555010",
+			"status": "confirmed",
+			"service": "inpost_courier_standard"
+		}`))
+	}))
+	defer srv.Close()
+
+	provider := newTestProvider(t, srv.URL)
+
+	req := integration.CarrierShipmentRequest{
+		OrderID:       "ORD-003",
+		Reference:     "ref-return-003",
+		SendingMethod: "parcel_locker",
+		Receiver: integration.CarrierReceiver{
+			Name:       "Magazyn Sp. z o.o.",
+			Phone:      "500100200",
+			Street:     "Magazynowa 1",
+			City:       "Poznan",
+			PostalCode: "60-001",
+			Country:    "PL",
+		},
+		Sender: &integration.CarrierReceiver{
+			Name:       "Anna Nowak",
+			Phone:      "600700800",
+			Email:      "anna@example.com",
+			Street:     "Marszalkowska 10",
+			City:       "Warszawa",
+			PostalCode: "00-001",
+			Country:    "PL",
+		},
+		Parcel: integration.CarrierParcel{SizeCode: "small"},
+	}
+
+	resp, err := provider.CreateShipment(context.Background(), req)
+	if err != nil {
+		t.Fatalf("CreateShipment() returned error: %v", err)
+	}
+
+	snd, ok := receivedBody["sender"].(map[string]any)
+	if !ok {
+		t.Fatal("expected sender in request body")
+	}
+	if snd["name"] != "Anna Nowak" || snd["phone"] != "600700800" {
+		t.Errorf("unexpected sender contact: %v", snd)
+	}
+	if addr, ok := snd["address"].(map[string]any); !ok || addr["post_code"] != "00-001" {
+		t.Errorf("expected sender address with post_code 00-001, got %v", snd["address"])
+	}
+	if ca, ok := receivedBody["custom_attributes"].(map[string]any); !ok || ca["sending_method"] != "parcel_locker" {
+		t.Errorf("expected sending_method parcel_locker, got %v", receivedBody["custom_attributes"])
+	}
+	if resp.TrackingNumber != "RETURN555010" {
+		t.Errorf("expected TrackingNumber RETURN555010, got %s", resp.TrackingNumber)
+	}
+}
+
 // ---------------------------------------------------------------------------
 // Test 3: GetLabel — PDF format
 // ---------------------------------------------------------------------------
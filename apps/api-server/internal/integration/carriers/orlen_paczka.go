@@ -70,6 +70,14 @@ func (p *OrlenPaczkaProvider) CreateShipment(ctx context.Context, req integratio
 		Reference:   req.Reference,
 	}
 
+	if req.Sender != nil {
+		orlenReq.Sender = &orlensdk.Receiver{
+			Name:  req.Sender.Name,
+			Email: req.Sender.Email,
+			Phone: req.Sender.Phone,
+		}
+	}
+
 	if req.CODAmount > 0 {
 		currency := req.CODCurrency
 		if currency == "" {
@@ -86,6 +86,54 @@ func (p *Provider) CreateInvoice(ctx context.Context, req integration.InvoiceReq
 	}, nil
 }
 
+// CreateCorrection issues a correction invoice of an invoice created in
+// Fakturownia. Positions carry the negative corrected quantities.
+func (p *Provider) CreateCorrection(ctx context.Context, req integration.CorrectionRequest) (*integration.InvoiceResult, error) {
+	correctedID, err := sdk.ParseExternalID(req.CorrectedExternalID)
+	if err != nil {
+		return nil, err
+	}
+
+	positions := make([]sdk.InvoicePosition, 0, len(req.Items))
+	for _, item := range req.Items {
+		positions = append(positions, sdk.InvoicePosition{
+			Name:     item.Name,
+			Quantity: item.Quantity,
+			NetPrice: item.NetPrice,
+			Tax:      item.TaxRate,
+			Unit:     item.Unit,
+		})
+	}
+
+	data := sdk.InvoiceRequestData{
+		Kind:             "correction",
+		SellDate:         req.IssueDate.Format("2006-01-02"),
+		IssueDate:        req.IssueDate.Format("2006-01-02"),
+		PaymentTo:        req.DueDate.Format("2006-01-02"),
+		BuyerName:        req.CustomerName,
+		BuyerEmail:       req.CustomerEmail,
+		BuyerTaxNo:       req.NIP,
+		Currency:         req.Currency,
+		Description:      req.Notes,
+		Positions:        positions,
+		InvoiceID:        &correctedID,
+		FromInvoiceID:    &correctedID,
+		CorrectionReason: req.Reason,
+	}
+
+	resp, err := p.client.Invoices.Create(ctx, data)
+	if err != nil {
+		return nil, fmt.Errorf("fakturownia: create correction: %w", err)
+	}
+
+	return &integration.InvoiceResult{
+		ExternalID:     strconv.Itoa(resp.ID),
+		ExternalNumber: resp.Number,
+		PDFURL:         resp.ViewURL,
+		Status:         mapStatus(resp.Status),
+	}, nil
+}
+
 func (p *Provider) GetInvoice(ctx context.Context, externalID string) (*integration.InvoiceResult, error) {
 	id, err := sdk.ParseExternalID(externalID)
 	if err != nil {
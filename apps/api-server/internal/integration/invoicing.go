@@ -50,6 +50,22 @@ type InvoicingProvider interface {
 	CancelInvoice(ctx context.Context, externalID string) error
 }
 
+// CorrectionRequest is a correction invoice reducing an issued invoice, e.g.
+// for refunded return lines. Items carry the corrected quantities as
+// negative numbers.
+type CorrectionRequest struct {
+	InvoiceRequest
+	// CorrectedExternalID is the provider ID of the invoice being corrected.
+	CorrectedExternalID string `json:"corrected_external_id"`
+	Reason              string `json:"reason,omitempty"`
+}
+
+// CorrectionCreator is an optional interface for invoicing providers that
+// can issue correction invoices.
+type CorrectionCreator interface {
+	CreateCorrection(ctx context.Context, req CorrectionRequest) (*InvoiceResult, error)
+}
+
 // InvoicingProviderFactory is a constructor function for invoicing providers.
 type InvoicingProviderFactory func(credentials json.RawMessage, settings json.RawMessage) (InvoicingProvider, error)
 
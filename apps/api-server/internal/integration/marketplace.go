@@ -18,6 +18,13 @@ type MarketplaceOrderItem struct {
 	UnitPrice  float64 `json:"unit_price"`
 	TotalPrice float64 `json:"total_price"`
 	TaxRate    float64 `json:"tax_rate,omitempty"`
+	// VariantExternalID is the marketplace ID of the ordered variant
+	// (e.g. a PrestaShop combination), empty for simple products.
+	VariantExternalID string `json:"variant_external_id,omitempty"`
+	// ProductID and VariantID link the item to the local catalog when the
+	// order poller could match it by SKU or EAN.
+	ProductID *uuid.UUID `json:"product_id,omitempty"`
+	VariantID *uuid.UUID `json:"variant_id,omitempty"`
 }
 
 // MarketplaceOrder represents an order retrieved from a marketplace provider.
@@ -74,3 +81,123 @@ func MarketplaceOrderToCreateRequest(mo MarketplaceOrder, source string, integra
 	}
 	return req
 }
+
+// FulfillmentTracking carries the carrier tracking data reported back to a marketplace
+// when an order is marked as fulfilled.
+type FulfillmentTracking struct {
+	Number         string `json:"tracking_number,omitempty"`
+	Company        string `json:"tracking_company,omitempty"`
+	URL            string `json:"tracking_url,omitempty"`
+	NotifyCustomer bool   `json:"notify_customer"`
+}
+
+// FulfillmentCreator is an optional interface that marketplace providers can implement
+// to mark orders as fulfilled (shipped) on the marketplace side.
+type FulfillmentCreator interface {
+	CreateFulfillment(ctx context.Context, externalOrderID string, tracking FulfillmentTracking) (externalFulfillmentID string, err error)
+}
+
+// LocationStockUpdater is an optional interface for marketplace providers that keep
+// inventory per location. LocationMapping returns the configured mapping of OpenOMS
+// warehouse IDs to marketplace location IDs; when it is empty the stock sync worker
+// falls back to UpdateStock with the product's total stock.
+type LocationStockUpdater interface {
+	LocationMapping() map[string]string
+	UpdateLocationStock(ctx context.Context, externalOfferID, locationID string, quantity int) error
+}
+
+// VariantStock is the stock quantity of a single local product variant, identified
+// by SKU and EAN so that providers can match it to their own variant IDs.
+type VariantStock struct {
+	SKU      string
+	EAN      string
+	Quantity int
+}
+
+// VariantStockUpdater is an optional interface for marketplace providers that keep
+// stock per variant. The stock sync worker uses it for products with variants.
+type VariantStockUpdater interface {
+	UpdateVariantStock(ctx context.Context, externalOfferID string, variants []VariantStock) error
+}
+
+// CommissionRateProvider is an optional interface for marketplaces that charge a
+// commission on sales. CommissionRate returns the rate for an offer as a fraction
+// (0.1 for 10%); the price sync worker uses it to compensate the commission.
+type CommissionRateProvider interface {
+	CommissionRate(ctx context.Context, externalOfferID string) (float64, error)
+}
+
+// OrderFeeFetcher is an optional interface for marketplaces that bill sellers
+// per order. OrderFees returns the total fees (commission, promotion, delivery
+// surcharges) charged for an order, as a positive amount in the order currency.
+type OrderFeeFetcher interface {
+	OrderFees(ctx context.Context, externalOrderID string) (float64, error)
+}
+
+// RefundLine is a refunded order line, identified by the marketplace offer ID.
+// Amount is the total refunded for the line.
+type RefundLine struct {
+	ExternalID string
+	Quantity   int
+	Amount     float64
+}
+
+// RefundRequest describes a refund of a marketplace order.
+type RefundRequest struct {
+	Lines    []RefundLine
+	Shipping float64
+	Currency string
+}
+
+// BuyerMessenger is an optional interface for marketplaces that let the
+// seller message the buyer of an order.
+type BuyerMessenger interface {
+	MessageBuyer(ctx context.Context, externalOrderID, text string) error
+}
+
+// RefundCreator is an optional interface for marketplaces that handle payments
+// and can refund the buyer directly. CreateRefund returns the marketplace refund ID.
+type RefundCreator interface {
+	CreateRefund(ctx context.Context, externalOrderID string, req RefundRequest) (externalRefundID string, err error)
+}
+
+// CatalogVariant is a single variant of a product listed in a marketplace
+// catalog. ExternalID is the offer ID used for stock and price updates.
+type CatalogVariant struct {
+	ExternalID    string
+	Name          string
+	SKU           string
+	EAN           string
+	Price         float64
+	StockQuantity int
+	ImageURL      string
+	Attributes    map[string]string
+}
+
+// CatalogProduct is a product read from a marketplace catalog. ExternalID is
+// the offer ID of a product without variants and is empty when the product is
+// only sold through its variants; ExternalGroupID is the marketplace product ID.
+type CatalogProduct struct {
+	ExternalID       string
+	ExternalGroupID  string
+	Name             string
+	SKU              string
+	EAN              string
+	Price            float64
+	StockQuantity    int
+	DescriptionShort string
+	DescriptionLong  string
+	Category         string
+	ImageURL         string
+	Images           []string
+	Weight           *float64
+	URL              string
+	Variants         []CatalogVariant
+}
+
+// CatalogLister is an optional interface for marketplace providers that can list
+// their product catalog. ListCatalog returns one page of products and the cursor
+// of the next page, which is empty after the last page.
+type CatalogLister interface {
+	ListCatalog(ctx context.Context, cursor string) ([]CatalogProduct, string, error)
+}
@@ -0,0 +1,625 @@
+package prestashop
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	prestashopsdk "github.com/openoms-org/openoms/packages/prestashop-go-sdk"
+
+	"github.com/openoms-org/openoms/apps/api-server/internal/integration"
+	"github.com/openoms-org/openoms/apps/api-server/internal/model"
+)
+
+func init() {
+	integration.RegisterMarketplaceProvider("prestashop", func(credentials json.RawMessage, settings json.RawMessage) (integration.MarketplaceProvider, error) {
+		return NewProvider(credentials, settings)
+	})
+}
+
+const (
+	// dateLayout is the format of PrestaShop date fields (shop local time).
+	dateLayout = "2006-01-02 15:04:05"
+	// pollPageSize and maxPollPages bound a single poll; the rest is picked up
+	// on the next run via the date_upd cursor.
+	pollPageSize = 50
+	maxPollPages = 10
+	// catalogPageSize is the number of products returned per ListCatalog call.
+	catalogPageSize = 50
+)
+
+// defaultStatusMapping maps OpenOMS order statuses to the IDs of PrestaShop's
+// built-in order states. Tenants can override it with settings.status_mapping.
+var defaultStatusMapping = map[string]string{
+	"confirmed":  "2", // Payment accepted
+	"processing": "3", // Processing in progress
+	"shipped":    "4", // Shipped
+	"in_transit": "4", // Shipped
+	"delivered":  "5", // Delivered
+	"completed":  "5", // Delivered
+	"cancelled":  "6", // Canceled
+	"refunded":   "7", // Refunded
+}
+
+// PrestaShopCredentials is the JSON structure stored in encrypted integration credentials.
+type PrestaShopCredentials struct {
+	ShopURL string `json:"shop_url"`
+	APIKey  string `json:"api_key"`
+}
+
+// PrestaShopSettings is the JSON structure stored in integration settings.
+type PrestaShopSettings struct {
+	// LanguageID selects the translation used for product names (default "1").
+	LanguageID string `json:"language_id,omitempty"`
+	// DefaultCategoryID is assigned to products created by PushOffer (default "2", Home).
+	DefaultCategoryID string `json:"default_category_id,omitempty"`
+	// StatusMapping maps OpenOMS order statuses to PrestaShop order state IDs.
+	StatusMapping map[string]string `json:"status_mapping,omitempty"`
+	// SendStatusEmail makes PrestaShop send its state e-mail to the customer on state changes.
+	SendStatusEmail bool `json:"send_status_email,omitempty"`
+}
+
+// Provider implements integration.MarketplaceProvider for PrestaShop.
+// External offer IDs are PrestaShop product IDs, optionally suffixed with a
+// combination ID as "productID:combinationID".
+type Provider struct {
+	client   *prestashopsdk.Client
+	settings PrestaShopSettings
+	logger   *slog.Logger
+
+	// lookup caches for reference data resolved while mapping orders
+	countries  map[string]string
+	currencies map[string]string
+}
+
+// NewProvider creates a PrestaShop MarketplaceProvider from encrypted credentials.
+func NewProvider(credentials json.RawMessage, settings json.RawMessage) (*Provider, error) {
+	var creds PrestaShopCredentials
+	if err := json.Unmarshal(credentials, &creds); err != nil {
+		return nil, fmt.Errorf("prestashop: parse credentials: %w", err)
+	}
+
+	if creds.ShopURL == "" {
+		return nil, fmt.Errorf("prestashop: shop_url is required")
+	}
+	if creds.APIKey == "" {
+		return nil, fmt.Errorf("prestashop: api_key is required")
+	}
+
+	var s PrestaShopSettings
+	if len(settings) > 0 {
+		if err := json.Unmarshal(settings, &s); err != nil {
+			return nil, fmt.Errorf("prestashop: parse settings: %w", err)
+		}
+	}
+	if s.LanguageID == "" {
+		s.LanguageID = "1"
+	}
+	if s.DefaultCategoryID == "" {
+		s.DefaultCategoryID = "2"
+	}
+
+	return newProvider(prestashopsdk.NewClient(creds.ShopURL, creds.APIKey), s), nil
+}
+
+func newProvider(client *prestashopsdk.Client, s PrestaShopSettings) *Provider {
+	return &Provider{
+		client:     client,
+		settings:   s,
+		logger:     slog.Default().With("provider", "prestashop"),
+		countries:  make(map[string]string),
+		currencies: make(map[string]string),
+	}
+}
+
+func (p *Provider) ProviderName() string { return "prestashop" }
+
+// PollOrders polls PrestaShop for orders updated at or after the given cursor.
+// The cursor is the date_upd value ("YYYY-MM-DD HH:MM:SS") of the most recently updated polled order.
+func (p *Provider) PollOrders(ctx context.Context, cursor string) ([]integration.MarketplaceOrder, string, error) {
+	params := &prestashopsdk.ListParams{
+		Sort:  "[date_upd_ASC]",
+		Limit: pollPageSize,
+	}
+	if cursor != "" {
+		params.Filter = map[string]string{"date_upd": "[" + cursor + ",2999-12-31 23:59:59]"}
+	}
+
+	var orders []integration.MarketplaceOrder
+	newCursor := cursor
+
+	for page := 0; page < maxPollPages; page++ {
+		params.Offset = page * pollPageSize
+		psOrders, err := p.client.Orders.List(ctx, params)
+		if err != nil {
+			return nil, cursor, fmt.Errorf("prestashop: poll orders: %w", err)
+		}
+
+		for i := range psOrders {
+			mo, err := p.mapOrder(ctx, &psOrders[i])
+			if err != nil {
+				// Stop here so the order is retried on the next run.
+				p.logger.Error("prestashop: map order failed", "order_id", psOrders[i].ID, "error", err)
+				return orders, newCursor, nil
+			}
+			orders = append(orders, mo)
+
+			// date_upd has a fixed-width layout, so string comparison orders correctly
+			if psOrders[i].DateUpd > newCursor {
+				newCursor = psOrders[i].DateUpd
+			}
+		}
+
+		if len(psOrders) < pollPageSize {
+			break
+		}
+	}
+
+	return orders, newCursor, nil
+}
+
+// GetOrder retrieves a single order from PrestaShop by external ID.
+func (p *Provider) GetOrder(ctx context.Context, externalID string) (*integration.MarketplaceOrder, error) {
+	order, err := p.client.Orders.Get(ctx, externalID)
+	if err != nil {
+		return nil, fmt.Errorf("prestashop: get order %s: %w", externalID, err)
+	}
+
+	mo, err := p.mapOrder(ctx, order)
+	if err != nil {
+		return nil, err
+	}
+	return &mo, nil
+}
+
+// PushOffer creates a PrestaShop product from a product and listing data.
+func (p *Provider) PushOffer(ctx context.Context, product *model.Product, listingData map[string]any) (string, error) {
+	name := product.Name
+	if v, ok := listingData["name"].(string); ok && v != "" {
+		name = v
+	}
+	price := product.Price
+	if v, ok := listingData["price"].(float64); ok {
+		price = v
+	}
+	categoryID := p.settings.DefaultCategoryID
+	if v, ok := listingData["category_id"].(string); ok && v != "" {
+		categoryID = v
+	}
+
+	lang := p.settings.LanguageID
+	ps := &prestashopsdk.Product{
+		IDCategoryDefault: categoryID,
+		Price:             strconv.FormatFloat(price, 'f', 6, 64),
+		Active:            "1",
+		State:             "1",
+		AvailableForOrder: "1",
+		ShowPrice:         "1",
+		MinimalQuantity:   "1",
+		Name:              prestashopsdk.Multilang{{ID: lang, Value: name}},
+		LinkRewrite:       prestashopsdk.Multilang{{ID: lang, Value: slugify(name)}},
+		Categories:        []prestashopsdk.IDRef{{ID: categoryID}},
+	}
+	if product.SKU != nil {
+		ps.Reference = *product.SKU
+	}
+	if product.EAN != nil {
+		ps.EAN13 = *product.EAN
+	}
+	if product.DescriptionLong != "" {
+		ps.Description = prestashopsdk.Multilang{{ID: lang, Value: product.DescriptionLong}}
+	}
+	if product.DescriptionShort != "" {
+		ps.DescriptionShort = prestashopsdk.Multilang{{ID: lang, Value: product.DescriptionShort}}
+	}
+
+	created, err := p.client.Products.Create(ctx, ps)
+	if err != nil {
+		return "", fmt.Errorf("prestashop: create product: %w", err)
+	}
+	return created.ID, nil
+}
+
+// UpdateStock sets the available quantity of a product or, for "productID:combinationID"
+// offer IDs, of a single combination.
+func (p *Provider) UpdateStock(ctx context.Context, externalOfferID string, quantity int) error {
+	productID, combinationID := parseOfferID(externalOfferID)
+	if err := p.client.Stock.SetQuantity(ctx, productID, combinationID, quantity); err != nil {
+		return fmt.Errorf("prestashop: update stock for %s: %w", externalOfferID, err)
+	}
+	return nil
+}
+
+// UpdatePrice updates the price of a product. For combination offer IDs the
+// combination's price impact is set so that the final price equals price.
+func (p *Provider) UpdatePrice(ctx context.Context, externalOfferID string, price float64) error {
+	productID, combinationID := parseOfferID(externalOfferID)
+	if combinationID == "0" {
+		if err := p.client.Products.UpdatePrice(ctx, productID, price); err != nil {
+			return fmt.Errorf("prestashop: update price for product %s: %w", productID, err)
+		}
+		return nil
+	}
+
+	product, err := p.client.Products.Get(ctx, productID)
+	if err != nil {
+		return fmt.Errorf("prestashop: get product %s: %w", productID, err)
+	}
+	base, _ := strconv.ParseFloat(product.Price, 64)
+	if err := p.client.Combinations.UpdatePriceImpact(ctx, combinationID, price-base); err != nil {
+		return fmt.Errorf("prestashop: update price for combination %s: %w", combinationID, err)
+	}
+	return nil
+}
+
+// UpdateVariantStock pushes per-variant stock to the product's combinations,
+// matching them by reference (SKU) and then by EAN13.
+func (p *Provider) UpdateVariantStock(ctx context.Context, externalOfferID string, variants []integration.VariantStock) error {
+	productID, _ := parseOfferID(externalOfferID)
+	combinations, err := p.client.Combinations.ListByProduct(ctx, productID)
+	if err != nil {
+		return fmt.Errorf("prestashop: list combinations for product %s: %w", productID, err)
+	}
+
+	bySKU := make(map[string]string, len(combinations))
+	byEAN := make(map[string]string, len(combinations))
+	for _, c := range combinations {
+		if c.Reference != "" {
+			bySKU[c.Reference] = c.ID
+		}
+		if c.EAN13 != "" {
+			byEAN[c.EAN13] = c.ID
+		}
+	}
+
+	var unmatched []string
+	for _, v := range variants {
+		combinationID := ""
+		if v.SKU != "" {
+			combinationID = bySKU[v.SKU]
+		}
+		if combinationID == "" && v.EAN != "" {
+			combinationID = byEAN[v.EAN]
+		}
+		if combinationID == "" {
+			unmatched = append(unmatched, v.SKU+v.EAN)
+			continue
+		}
+		if err := p.client.Stock.SetQuantity(ctx, productID, combinationID, v.Quantity); err != nil {
+			return fmt.Errorf("prestashop: update stock for combination %s: %w", combinationID, err)
+		}
+	}
+
+	if len(unmatched) > 0 {
+		p.logger.Warn("prestashop: variants without matching combination", "product_id", productID, "variants", unmatched)
+	}
+	return nil
+}
+
+// ListCatalog returns one page of products with their combinations. The cursor
+// is the webservice offset. Prices are tax-excluded, as stored by PrestaShop.
+func (p *Provider) ListCatalog(ctx context.Context, cursor string) ([]integration.CatalogProduct, string, error) {
+	offset := 0
+	if cursor != "" {
+		n, err := strconv.Atoi(cursor)
+		if err != nil {
+			return nil, "", fmt.Errorf("prestashop: invalid catalog cursor %q", cursor)
+		}
+		offset = n
+	}
+
+	products, err := p.client.Products.List(ctx, &prestashopsdk.ListParams{
+		Sort:   "[id_ASC]",
+		Limit:  catalogPageSize,
+		Offset: offset,
+	})
+	if err != nil {
+		return nil, "", fmt.Errorf("prestashop: list products: %w", err)
+	}
+
+	lang := p.settings.LanguageID
+	result := make([]integration.CatalogProduct, 0, len(products))
+	for i := range products {
+		pp := &products[i]
+		stock, err := p.client.Stock.ListByProduct(ctx, pp.ID)
+		if err != nil {
+			return nil, "", fmt.Errorf("prestashop: list stock for product %s: %w", pp.ID, err)
+		}
+		quantities := make(map[string]int, len(stock))
+		for _, sa := range stock {
+			q, _ := strconv.Atoi(sa.Quantity)
+			quantities[sa.IDProductAttribute] = max(q, 0)
+		}
+
+		price, _ := strconv.ParseFloat(pp.Price, 64)
+		cp := integration.CatalogProduct{
+			ExternalID:       pp.ID,
+			ExternalGroupID:  pp.ID,
+			Name:             pp.Name.Get(lang),
+			SKU:              pp.Reference,
+			EAN:              pp.EAN13,
+			Price:            round2(price),
+			StockQuantity:    quantities["0"],
+			DescriptionShort: pp.DescriptionShort.Get(lang),
+			DescriptionLong:  pp.Description.Get(lang),
+		}
+		if w, err := strconv.ParseFloat(pp.Weight, 64); err == nil && w > 0 {
+			cp.Weight = &w
+		}
+
+		if len(pp.Combinations) > 0 {
+			combinations, err := p.client.Combinations.ListByProduct(ctx, pp.ID)
+			if err != nil {
+				return nil, "", fmt.Errorf("prestashop: list combinations for product %s: %w", pp.ID, err)
+			}
+			for _, c := range combinations {
+				impact, _ := strconv.ParseFloat(c.Price, 64)
+				name := c.Reference
+				if name == "" {
+					name = "Combination " + c.ID
+				}
+				cp.Variants = append(cp.Variants, integration.CatalogVariant{
+					ExternalID:    pp.ID + ":" + c.ID,
+					Name:          name,
+					SKU:           c.Reference,
+					EAN:           c.EAN13,
+					Price:         round2(price + impact),
+					StockQuantity: quantities[c.ID],
+				})
+			}
+			cp.ExternalID = ""
+		}
+		result = append(result, cp)
+	}
+
+	next := ""
+	if len(products) == catalogPageSize {
+		next = strconv.Itoa(offset + catalogPageSize)
+	}
+	return result, next, nil
+}
+
+// UpdateOrderStatus moves a PrestaShop order to the state mapped to the given OpenOMS status.
+func (p *Provider) UpdateOrderStatus(ctx context.Context, externalOrderID, status string) error {
+	stateID := p.stateForStatus(status)
+	if stateID == "" {
+		return fmt.Errorf("prestashop: no order state mapped for status %q", status)
+	}
+	return p.ChangeOrderState(ctx, externalOrderID, stateID)
+}
+
+// ChangeOrderState moves a PrestaShop order to the given order state ID.
+func (p *Provider) ChangeOrderState(ctx context.Context, externalOrderID, stateID string) error {
+	if _, err := p.client.Orders.ChangeState(ctx, externalOrderID, stateID, p.settings.SendStatusEmail); err != nil {
+		return fmt.Errorf("prestashop: change state of order %s: %w", externalOrderID, err)
+	}
+	return nil
+}
+
+// OrderState is a PrestaShop order state with its name in the configured language.
+type OrderState struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Paid      bool   `json:"paid"`
+	Shipped   bool   `json:"shipped"`
+	Delivered bool   `json:"delivered"`
+}
+
+// ListOrderStates returns the shop's order states for configuring the status mapping.
+func (p *Provider) ListOrderStates(ctx context.Context) ([]OrderState, error) {
+	states, err := p.client.Orders.ListStates(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("prestashop: list order states: %w", err)
+	}
+	result := make([]OrderState, 0, len(states))
+	for _, s := range states {
+		result = append(result, OrderState{
+			ID:        s.ID,
+			Name:      s.Name.Get(p.settings.LanguageID),
+			Paid:      s.Paid == "1",
+			Shipped:   s.Shipped == "1",
+			Delivered: s.Delivered == "1",
+		})
+	}
+	return result, nil
+}
+
+func (p *Provider) stateForStatus(status string) string {
+	if id, ok := p.settings.StatusMapping[status]; ok {
+		return id
+	}
+	return defaultStatusMapping[status]
+}
+
+// mapOrder converts a PrestaShop order to the normalized MarketplaceOrder,
+// resolving its addresses, customer, country and currency.
+func (p *Provider) mapOrder(ctx context.Context, o *prestashopsdk.Order) (integration.MarketplaceOrder, error) {
+	mo := integration.MarketplaceOrder{
+		ExternalID:     o.ID,
+		ExternalStatus: o.CurrentState,
+		PaymentMethod:  o.Payment,
+	}
+	mo.TotalAmount, _ = strconv.ParseFloat(o.TotalPaidTaxIncl, 64)
+
+	if o.Valid == "1" {
+		mo.PaymentStatus = "paid"
+	} else {
+		mo.PaymentStatus = "pending"
+	}
+
+	if t, err := time.Parse(dateLayout, o.DateAdd); err == nil {
+		mo.OrderedAt = t
+	}
+
+	currency, err := p.currencyCode(ctx, o.IDCurrency)
+	if err != nil {
+		return mo, err
+	}
+	mo.Currency = currency
+
+	if o.IDCustomer != "" && o.IDCustomer != "0" {
+		customer, err := p.client.Addresses.GetCustomer(ctx, o.IDCustomer)
+		if err != nil {
+			return mo, fmt.Errorf("prestashop: get customer %s: %w", o.IDCustomer, err)
+		}
+		mo.CustomerName = strings.TrimSpace(customer.Firstname + " " + customer.Lastname)
+		mo.CustomerEmail = customer.Email
+	}
+
+	if o.IDAddressDelivery != "" && o.IDAddressDelivery != "0" {
+		addr, phone, err := p.resolveAddress(ctx, o.IDAddressDelivery, mo.CustomerEmail)
+		if err != nil {
+			return mo, err
+		}
+		mo.ShippingAddress = addr
+		mo.CustomerPhone = phone
+		if addr.Name != "" {
+			mo.CustomerName = addr.Name
+		}
+	}
+	if o.IDAddressInvoice != "" && o.IDAddressInvoice != "0" {
+		if o.IDAddressInvoice == o.IDAddressDelivery {
+			billing := mo.ShippingAddress
+			mo.BillingAddress = &billing
+		} else {
+			addr, _, err := p.resolveAddress(ctx, o.IDAddressInvoice, mo.CustomerEmail)
+			if err != nil {
+				return mo, err
+			}
+			mo.BillingAddress = &addr
+		}
+	}
+
+	for _, row := range o.Rows {
+		qty, _ := strconv.Atoi(row.ProductQuantity)
+		unitPrice, _ := strconv.ParseFloat(row.UnitPriceTaxIncl, 64)
+		item := integration.MarketplaceOrderItem{
+			ExternalID: row.ProductID,
+			Name:       row.ProductName,
+			SKU:        row.ProductReference,
+			EAN:        row.ProductEAN13,
+			Quantity:   qty,
+			UnitPrice:  unitPrice,
+			TotalPrice: unitPrice * float64(qty),
+		}
+		if row.ProductAttributeID != "" && row.ProductAttributeID != "0" {
+			item.VariantExternalID = row.ProductAttributeID
+		}
+		if net, _ := strconv.ParseFloat(row.UnitPriceTaxExcl, 64); net > 0 && unitPrice > net {
+			item.TaxRate = round2(((unitPrice / net) - 1) * 100)
+		}
+		mo.Items = append(mo.Items, item)
+	}
+
+	mo.RawData = map[string]any{
+		"prestashop_order_id": o.ID,
+		"reference":           o.Reference,
+		"current_state":       o.CurrentState,
+		"payment_module":      o.Module,
+	}
+	if o.IDCarrier != "" {
+		mo.RawData["carrier_id"] = o.IDCarrier
+	}
+
+	return mo, nil
+}
+
+func (p *Provider) resolveAddress(ctx context.Context, addressID, email string) (model.ShippingAddress, string, error) {
+	a, err := p.client.Addresses.Get(ctx, addressID)
+	if err != nil {
+		return model.ShippingAddress{}, "", fmt.Errorf("prestashop: get address %s: %w", addressID, err)
+	}
+	country, err := p.countryCode(ctx, a.IDCountry)
+	if err != nil {
+		return model.ShippingAddress{}, "", err
+	}
+
+	phone := a.PhoneMobile
+	if phone == "" {
+		phone = a.Phone
+	}
+	street := a.Address1
+	if a.Address2 != "" {
+		street += " " + a.Address2
+	}
+	addr := model.ShippingAddress{
+		Name:       strings.TrimSpace(a.Firstname + " " + a.Lastname),
+		Street:     street,
+		City:       a.City,
+		PostalCode: a.Postcode,
+		Country:    country,
+		Phone:      phone,
+		Email:      email,
+	}
+	if a.Company != "" {
+		company := a.Company
+		addr.Company = &company
+	}
+	return addr, phone, nil
+}
+
+func (p *Provider) countryCode(ctx context.Context, id string) (string, error) {
+	if id == "" || id == "0" {
+		return "", nil
+	}
+	if code, ok := p.countries[id]; ok {
+		return code, nil
+	}
+	c, err := p.client.Addresses.GetCountry(ctx, id)
+	if err != nil {
+		return "", fmt.Errorf("prestashop: get country %s: %w", id, err)
+	}
+	p.countries[id] = c.ISOCode
+	return c.ISOCode, nil
+}
+
+func (p *Provider) currencyCode(ctx context.Context, id string) (string, error) {
+	if id == "" || id == "0" {
+		return "PLN", nil
+	}
+	if code, ok := p.currencies[id]; ok {
+		return code, nil
+	}
+	c, err := p.client.Addresses.GetCurrency(ctx, id)
+	if err != nil {
+		return "", fmt.Errorf("prestashop: get currency %s: %w", id, err)
+	}
+	p.currencies[id] = c.ISOCode
+	return c.ISOCode, nil
+}
+
+// parseOfferID splits "productID[:combinationID]" into its parts; the combination
+// ID is "0" (the product itself) when absent.
+func parseOfferID(externalOfferID string) (productID, combinationID string) {
+	productID, combinationID, found := strings.Cut(externalOfferID, ":")
+	if !found || combinationID == "" {
+		combinationID = "0"
+	}
+	return productID, combinationID
+}
+
+var nonSlugChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+var polishFolding = strings.NewReplacer(
+	"ą", "a", "ć", "c", "ę", "e", "ł", "l", "ń", "n", "ó", "o", "ś", "s", "ź", "z", "ż", "z",
+)
+
+// slugify builds a link_rewrite value (lowercase ASCII, hyphen separated).
+func slugify(s string) string {
+	slug := polishFolding.Replace(strings.ToLower(s))
+	slug = strings.Trim(nonSlugChars.ReplaceAllString(slug, "-"), "-")
+	if slug == "" {
+		return "product"
+	}
+	return slug
+}
+
+// round2 rounds to two decimal places.
+func round2(v float64) float64 {
+	return float64(int64(v*100+0.5)) / 100
+}
@@ -0,0 +1,288 @@
+package prestashop
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	prestashopsdk "github.com/openoms-org/openoms/packages/prestashop-go-sdk"
+
+	"github.com/openoms-org/openoms/apps/api-server/internal/integration"
+)
+
+// newTestProvider creates a Provider backed by a webservice stand-in that
+// answers each request with the XML returned by handler.
+func newTestProvider(t *testing.T, settings PrestaShopSettings, handler func(r *http.Request, body string) string) *Provider {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		w.Write([]byte(handler(r, string(b))))
+	}))
+	t.Cleanup(srv.Close)
+
+	if settings.LanguageID == "" {
+		settings.LanguageID = "1"
+	}
+	client := prestashopsdk.NewClient("https://shop", "KEY", prestashopsdk.WithBaseURL(srv.URL), prestashopsdk.WithHTTPClient(srv.Client()))
+	return newProvider(client, settings)
+}
+
+func TestNewProviderValidation(t *testing.T) {
+	if _, err := NewProvider(json.RawMessage(`{"api_key":"x"}`), nil); err == nil {
+		t.Error("expected error for missing shop_url")
+	}
+	if _, err := NewProvider(json.RawMessage(`{"shop_url":"https://shop"}`), nil); err == nil {
+		t.Error("expected error for missing api_key")
+	}
+	p, err := NewProvider(json.RawMessage(`{"shop_url":"https://shop","api_key":"KEY"}`), nil)
+	if err != nil {
+		t.Fatalf("NewProvider() error: %v", err)
+	}
+	if p.settings.LanguageID != "1" || p.settings.DefaultCategoryID != "2" {
+		t.Errorf("defaults not applied: %+v", p.settings)
+	}
+}
+
+const testOrderXML = `<prestashop><orders><order>
+	<id>5</id><reference>XKBKNABJK</reference>
+	<id_customer>3</id_customer><id_address_delivery>4</id_address_delivery><id_address_invoice>4</id_address_invoice>
+	<id_currency>1</id_currency><current_state>2</current_state><payment>Przelew</payment><valid>1</valid>
+	<total_paid_tax_incl>61.500000</total_paid_tax_incl>
+	<date_add>2025-01-02 09:00:00</date_add><date_upd>2025-01-02 10:00:00</date_upd>
+	<associations><order_rows><order_row>
+		<product_id>1</product_id><product_attribute_id>7</product_attribute_id><product_quantity>2</product_quantity>
+		<product_name>Koszulka - Rozmiar : M</product_name><product_reference>TS-M</product_reference>
+		<unit_price_tax_incl>24.600000</unit_price_tax_incl><unit_price_tax_excl>20.000000</unit_price_tax_excl>
+	</order_row><order_row>
+		<product_id>2</product_id><product_attribute_id>0</product_attribute_id><product_quantity>1</product_quantity>
+		<product_name>Kubek</product_name><product_reference>MUG</product_reference>
+		<unit_price_tax_incl>12.300000</unit_price_tax_incl><unit_price_tax_excl>10.000000</unit_price_tax_excl>
+	</order_row></order_rows></associations>
+</order></orders></prestashop>`
+
+func TestPollOrdersMapsOrder(t *testing.T) {
+	var mu sync.Mutex
+	calls := map[string]int{}
+	p := newTestProvider(t, PrestaShopSettings{}, func(r *http.Request, _ string) string {
+		mu.Lock()
+		calls[r.URL.Path]++
+		mu.Unlock()
+		switch r.URL.Path {
+		case "/orders":
+			if got := r.URL.Query().Get("filter[date_upd]"); got != "[2025-01-01 00:00:00,2999-12-31 23:59:59]" {
+				t.Errorf("filter = %q", got)
+			}
+			return testOrderXML
+		case "/customers/3":
+			return `<prestashop><customer><id>3</id><firstname>Jan</firstname><lastname>Kowalski</lastname><email>jan@example.pl</email></customer></prestashop>`
+		case "/addresses/4":
+			return `<prestashop><address><id>4</id><id_country>14</id_country><firstname>Jan</firstname><lastname>Kowalski</lastname>
+				<address1>ul. Długa 1</address1><postcode>00-001</postcode><city>Warszawa</city><phone_mobile>500600700</phone_mobile></address></prestashop>`
+		case "/countries/14":
+			return `<prestashop><country><id>14</id><iso_code>PL</iso_code></country></prestashop>`
+		case "/currencies/1":
+			return `<prestashop><currency><id>1</id><iso_code>PLN</iso_code></currency></prestashop>`
+		}
+		t.Errorf("unexpected request %s", r.URL.Path)
+		return `<prestashop></prestashop>`
+	})
+
+	orders, cursor, err := p.PollOrders(context.Background(), "2025-01-01 00:00:00")
+	if err != nil {
+		t.Fatalf("PollOrders() error: %v", err)
+	}
+	if cursor != "2025-01-02 10:00:00" {
+		t.Errorf("cursor = %q", cursor)
+	}
+	if len(orders) != 1 {
+		t.Fatalf("got %d orders, want 1", len(orders))
+	}
+
+	o := orders[0]
+	if o.ExternalID != "5" || o.Currency != "PLN" || o.PaymentStatus != "paid" || o.TotalAmount != 61.5 {
+		t.Errorf("unexpected order: %+v", o)
+	}
+	if o.CustomerEmail != "jan@example.pl" || o.CustomerPhone != "500600700" {
+		t.Errorf("customer = %q / %q", o.CustomerEmail, o.CustomerPhone)
+	}
+	if o.ShippingAddress.Country != "PL" || o.ShippingAddress.City != "Warszawa" {
+		t.Errorf("shipping address = %+v", o.ShippingAddress)
+	}
+	if o.BillingAddress == nil || o.BillingAddress.PostalCode != "00-001" {
+		t.Errorf("billing address = %+v", o.BillingAddress)
+	}
+	if calls["/addresses/4"] != 1 {
+		t.Errorf("shared delivery/invoice address fetched %d times", calls["/addresses/4"])
+	}
+
+	if len(o.Items) != 2 {
+		t.Fatalf("got %d items, want 2", len(o.Items))
+	}
+	if it := o.Items[0]; it.VariantExternalID != "7" || it.SKU != "TS-M" || it.TotalPrice != 49.2 || it.TaxRate != 23 {
+		t.Errorf("combination item = %+v", it)
+	}
+	if it := o.Items[1]; it.VariantExternalID != "" || it.ExternalID != "2" {
+		t.Errorf("simple item = %+v", it)
+	}
+}
+
+func TestUpdateVariantStockMatchesCombinations(t *testing.T) {
+	var mu sync.Mutex
+	updated := map[string]string{}
+	p := newTestProvider(t, PrestaShopSettings{}, func(r *http.Request, body string) string {
+		switch {
+		case r.URL.Path == "/combinations":
+			return `<prestashop><combinations>
+				<combination><id>7</id><id_product>1</id_product><reference>TS-M</reference></combination>
+				<combination><id>8</id><id_product>1</id_product><ean13>5901234123457</ean13></combination>
+			</combinations></prestashop>`
+		case r.URL.Path == "/stock_availables" && r.Method == "GET":
+			attr := strings.Trim(r.URL.Query().Get("filter[id_product_attribute]"), "[]")
+			return `<prestashop><stock_availables><stock_available><id>` + attr + `0</id><id_product>1</id_product>
+				<id_product_attribute>` + attr + `</id_product_attribute><quantity>0</quantity></stock_available></stock_availables></prestashop>`
+		case r.Method == "PUT":
+			mu.Lock()
+			updated[r.URL.Path] = body
+			mu.Unlock()
+			return `<prestashop></prestashop>`
+		}
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		return `<prestashop></prestashop>`
+	})
+
+	err := p.UpdateVariantStock(context.Background(), "1", []integration.VariantStock{
+		{SKU: "TS-M", Quantity: 4},
+		{EAN: "5901234123457", Quantity: 9},
+		{SKU: "UNKNOWN", Quantity: 1},
+	})
+	if err != nil {
+		t.Fatalf("UpdateVariantStock() error: %v", err)
+	}
+	if len(updated) != 2 {
+		t.Fatalf("got %d stock updates, want 2: %v", len(updated), updated)
+	}
+	if !strings.Contains(updated["/stock_availables/70"], "<quantity>4</quantity>") {
+		t.Errorf("combination 7 body = %s", updated["/stock_availables/70"])
+	}
+	if !strings.Contains(updated["/stock_availables/80"], "<quantity>9</quantity>") {
+		t.Errorf("combination 8 body = %s", updated["/stock_availables/80"])
+	}
+}
+
+func TestListCatalogMapsCombinations(t *testing.T) {
+	p := newTestProvider(t, PrestaShopSettings{}, func(r *http.Request, _ string) string {
+		switch r.URL.Path {
+		case "/products":
+			if got := r.URL.Query().Get("limit"); got != "0,50" {
+				t.Errorf("limit = %q", got)
+			}
+			return `<prestashop><products>
+				<product><id>1</id><reference>TS</reference><price>20.000000</price><weight>0.2</weight>
+					<name><language id="1">Koszulka</language></name>
+					<associations><combinations><combination><id>7</id></combination></combinations></associations>
+				</product>
+				<product><id>2</id><reference>MUG</reference><ean13>5901234123457</ean13><price>10.000000</price>
+					<name><language id="1">Kubek</language></name>
+				</product>
+			</products></prestashop>`
+		case "/stock_availables":
+			switch strings.Trim(r.URL.Query().Get("filter[id_product]"), "[]") {
+			case "1":
+				return `<prestashop><stock_availables>
+					<stock_available><id>1</id><id_product>1</id_product><id_product_attribute>0</id_product_attribute><quantity>5</quantity></stock_available>
+					<stock_available><id>2</id><id_product>1</id_product><id_product_attribute>7</id_product_attribute><quantity>5</quantity></stock_available>
+				</stock_availables></prestashop>`
+			default:
+				return `<prestashop><stock_availables>
+					<stock_available><id>3</id><id_product>2</id_product><id_product_attribute>0</id_product_attribute><quantity>-2</quantity></stock_available>
+				</stock_availables></prestashop>`
+			}
+		case "/combinations":
+			return `<prestashop><combinations>
+				<combination><id>7</id><id_product>1</id_product><reference>TS-M</reference><price>2.500000</price></combination>
+			</combinations></prestashop>`
+		}
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		return `<prestashop></prestashop>`
+	})
+
+	products, next, err := p.ListCatalog(context.Background(), "")
+	if err != nil {
+		t.Fatalf("ListCatalog() error: %v", err)
+	}
+	if next != "" {
+		t.Errorf("next cursor = %q, want empty after a short page", next)
+	}
+	if len(products) != 2 {
+		t.Fatalf("got %d products, want 2", len(products))
+	}
+
+	shirt := products[0]
+	if shirt.ExternalID != "" || shirt.ExternalGroupID != "1" || shirt.Name != "Koszulka" {
+		t.Errorf("product with combinations = %+v", shirt)
+	}
+	if len(shirt.Variants) != 1 {
+		t.Fatalf("got %d variants, want 1", len(shirt.Variants))
+	}
+	if v := shirt.Variants[0]; v.ExternalID != "1:7" || v.SKU != "TS-M" || v.Price != 22.5 || v.StockQuantity != 5 {
+		t.Errorf("variant = %+v", v)
+	}
+
+	mug := products[1]
+	if mug.ExternalID != "2" || mug.EAN != "5901234123457" || mug.Price != 10 || mug.StockQuantity != 0 {
+		t.Errorf("simple product = %+v", mug)
+	}
+}
+
+func TestUpdateOrderStatusUsesMapping(t *testing.T) {
+	var posted string
+	p := newTestProvider(t, PrestaShopSettings{StatusMapping: map[string]string{"shipped": "12"}}, func(r *http.Request, body string) string {
+		if r.Method == "POST" && r.URL.Path == "/order_histories" {
+			posted = body
+		}
+		return `<prestashop><order_history><id>1</id></order_history></prestashop>`
+	})
+
+	if err := p.UpdateOrderStatus(context.Background(), "5", "shipped"); err != nil {
+		t.Fatalf("UpdateOrderStatus() error: %v", err)
+	}
+	if !strings.Contains(posted, "<id_order_state>12</id_order_state>") {
+		t.Errorf("posted = %s", posted)
+	}
+
+	if err := p.UpdateOrderStatus(context.Background(), "5", "delivered"); err != nil {
+		t.Fatalf("UpdateOrderStatus() default mapping error: %v", err)
+	}
+	if !strings.Contains(posted, "<id_order_state>5</id_order_state>") {
+		t.Errorf("default mapping posted = %s", posted)
+	}
+
+	if err := p.UpdateOrderStatus(context.Background(), "5", "unknown"); err == nil {
+		t.Error("expected error for unmapped status")
+	}
+}
+
+func TestParseOfferID(t *testing.T) {
+	tests := []struct{ in, product, combination string }{
+		{"12", "12", "0"},
+		{"12:34", "12", "34"},
+		{"12:", "12", "0"},
+	}
+	for _, tt := range tests {
+		p, c := parseOfferID(tt.in)
+		if p != tt.product || c != tt.combination {
+			t.Errorf("parseOfferID(%q) = %q, %q", tt.in, p, c)
+		}
+	}
+}
+
+func TestSlugify(t *testing.T) {
+	if got := slugify("Koszulka Żółta – XL!"); got != "koszulka-zolta-xl" {
+		t.Errorf("slugify() = %q", got)
+	}
+}
@@ -0,0 +1,459 @@
+package shopify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	shopifysdk "github.com/openoms-org/openoms/packages/shopify-go-sdk"
+
+	"github.com/openoms-org/openoms/apps/api-server/internal/integration"
+	"github.com/openoms-org/openoms/apps/api-server/internal/model"
+)
+
+func init() {
+	integration.RegisterMarketplaceProvider("shopify", func(credentials json.RawMessage, settings json.RawMessage) (integration.MarketplaceProvider, error) {
+		return NewProvider(credentials, settings)
+	})
+}
+
+// maxPollPages bounds the number of order pages fetched in a single poll.
+// Remaining orders are picked up on the next run via the updated_at cursor.
+const maxPollPages = 10
+
+// ShopifyCredentials is the JSON structure stored in encrypted integration credentials.
+type ShopifyCredentials struct {
+	ShopDomain  string `json:"shop_domain"`  // e.g. "my-shop" or "my-shop.myshopify.com"
+	AccessToken string `json:"access_token"` // Admin API access token (shpat_...)
+	APIVersion  string `json:"api_version,omitempty"`
+}
+
+// ShopifySettings is the JSON structure stored in integration settings.
+type ShopifySettings struct {
+	// LocationMapping maps OpenOMS warehouse IDs to Shopify location IDs.
+	LocationMapping map[string]string `json:"location_mapping,omitempty"`
+	// DefaultLocationID is used for single-quantity stock updates.
+	// When empty, the first active Shopify location is used.
+	DefaultLocationID string `json:"default_location_id,omitempty"`
+}
+
+// Provider implements integration.MarketplaceProvider for Shopify.
+// External offer IDs are Shopify product variant IDs (numeric form).
+type Provider struct {
+	client   *shopifysdk.Client
+	settings ShopifySettings
+	logger   *slog.Logger
+}
+
+// NewProvider creates a Shopify MarketplaceProvider from encrypted credentials.
+func NewProvider(credentials json.RawMessage, settings json.RawMessage) (*Provider, error) {
+	var creds ShopifyCredentials
+	if err := json.Unmarshal(credentials, &creds); err != nil {
+		return nil, fmt.Errorf("shopify: parse credentials: %w", err)
+	}
+
+	if creds.ShopDomain == "" {
+		return nil, fmt.Errorf("shopify: shop_domain is required")
+	}
+	if creds.AccessToken == "" {
+		return nil, fmt.Errorf("shopify: access_token is required")
+	}
+
+	var s ShopifySettings
+	if len(settings) > 0 {
+		if err := json.Unmarshal(settings, &s); err != nil {
+			return nil, fmt.Errorf("shopify: parse settings: %w", err)
+		}
+	}
+
+	client := shopifysdk.NewClient(creds.ShopDomain, creds.AccessToken, shopifysdk.WithAPIVersion(creds.APIVersion))
+
+	return &Provider{
+		client:   client,
+		settings: s,
+		logger:   slog.Default().With("provider", "shopify"),
+	}, nil
+}
+
+func (p *Provider) ProviderName() string { return "shopify" }
+
+// PollOrders polls Shopify for orders updated at or after the given cursor.
+// The cursor is the updatedAt value (RFC3339) of the most recently updated polled order.
+func (p *Provider) PollOrders(ctx context.Context, cursor string) ([]integration.MarketplaceOrder, string, error) {
+	params := &shopifysdk.OrderListParams{
+		UpdatedAtMin: cursor,
+		First:        100,
+	}
+
+	var orders []integration.MarketplaceOrder
+	newCursor := cursor
+
+	for page := 0; page < maxPollPages; page++ {
+		conn, err := p.client.Orders.List(ctx, params)
+		if err != nil {
+			return nil, cursor, fmt.Errorf("shopify: poll orders: %w", err)
+		}
+
+		for i := range conn.Nodes {
+			o := &conn.Nodes[i]
+			orders = append(orders, p.mapShopifyOrder(o))
+
+			// Track the latest updatedAt as the new cursor
+			if laterTimestamp(o.UpdatedAt, newCursor) {
+				newCursor = o.UpdatedAt
+			}
+		}
+
+		if !conn.PageInfo.HasNextPage {
+			break
+		}
+		params.After = conn.PageInfo.EndCursor
+	}
+
+	return orders, newCursor, nil
+}
+
+// GetOrder retrieves a single order from Shopify by external (numeric) ID.
+func (p *Provider) GetOrder(ctx context.Context, externalID string) (*integration.MarketplaceOrder, error) {
+	order, err := p.client.Orders.Get(ctx, externalID)
+	if err != nil {
+		return nil, fmt.Errorf("shopify: get order %s: %w", externalID, err)
+	}
+
+	mo := p.mapShopifyOrder(order)
+	return &mo, nil
+}
+
+// PushOffer creates a Shopify product and returns the ID of its default variant,
+// which is used as the listing's external offer ID.
+func (p *Provider) PushOffer(ctx context.Context, product *model.Product, listingData map[string]any) (string, error) {
+	input := shopifysdk.ProductInput{
+		Title:           product.Name,
+		DescriptionHTML: product.DescriptionLong,
+		Status:          "ACTIVE",
+	}
+	if v, ok := listingData["title"].(string); ok && v != "" {
+		input.Title = v
+	}
+	if v, ok := listingData["description_html"].(string); ok {
+		input.DescriptionHTML = v
+	}
+	if v, ok := listingData["vendor"].(string); ok {
+		input.Vendor = v
+	}
+	if v, ok := listingData["product_type"].(string); ok {
+		input.ProductType = v
+	}
+	if v, ok := listingData["status"].(string); ok && v != "" {
+		input.Status = strings.ToUpper(v)
+	}
+
+	created, err := p.client.Products.Create(ctx, input)
+	if err != nil {
+		return "", fmt.Errorf("shopify: create product: %w", err)
+	}
+	if len(created.Variants.Nodes) == 0 {
+		return "", fmt.Errorf("shopify: created product %s has no variants", created.ID)
+	}
+	variant := created.Variants.Nodes[0]
+
+	price := product.Price
+	if v, ok := listingData["price"].(float64); ok {
+		price = v
+	}
+	update := shopifysdk.VariantUpdate{ID: variant.ID, Price: &price, SKU: product.SKU, Barcode: product.EAN}
+	if _, err := p.client.Products.UpdateVariants(ctx, created.ID, []shopifysdk.VariantUpdate{update}); err != nil {
+		return "", fmt.Errorf("shopify: update default variant: %w", err)
+	}
+
+	return shopifysdk.LegacyID(variant.ID), nil
+}
+
+// UpdateStock sets the available quantity of a variant at the default location.
+func (p *Provider) UpdateStock(ctx context.Context, externalOfferID string, quantity int) error {
+	locationID, err := p.defaultLocationID(ctx)
+	if err != nil {
+		return err
+	}
+	return p.UpdateLocationStock(ctx, externalOfferID, locationID, quantity)
+}
+
+// UpdatePrice updates the price of a Shopify variant.
+func (p *Provider) UpdatePrice(ctx context.Context, externalOfferID string, price float64) error {
+	variant, err := p.client.Products.GetVariant(ctx, externalOfferID)
+	if err != nil {
+		return fmt.Errorf("shopify: get variant %s: %w", externalOfferID, err)
+	}
+	if variant.Product == nil {
+		return fmt.Errorf("shopify: variant %s has no product", externalOfferID)
+	}
+	if err := p.client.Products.UpdateVariantPrice(ctx, variant.Product.ID, variant.ID, price); err != nil {
+		return fmt.Errorf("shopify: update price for variant %s: %w", externalOfferID, err)
+	}
+	return nil
+}
+
+// LocationMapping returns the configured warehouse ID to Shopify location ID mapping.
+func (p *Provider) LocationMapping() map[string]string {
+	return p.settings.LocationMapping
+}
+
+// UpdateLocationStock sets the available quantity of a variant at a specific Shopify location.
+func (p *Provider) UpdateLocationStock(ctx context.Context, externalOfferID, locationID string, quantity int) error {
+	variant, err := p.client.Products.GetVariant(ctx, externalOfferID)
+	if err != nil {
+		return fmt.Errorf("shopify: get variant %s: %w", externalOfferID, err)
+	}
+	if variant.InventoryItem.ID == "" {
+		return fmt.Errorf("shopify: variant %s has no inventory item", externalOfferID)
+	}
+	if err := p.client.Inventory.SetAvailable(ctx, variant.InventoryItem.ID, locationID, quantity); err != nil {
+		return fmt.Errorf("shopify: update stock for variant %s at location %s: %w", externalOfferID, locationID, err)
+	}
+	return nil
+}
+
+// CreateFulfillment fulfils all open fulfillment orders of a Shopify order
+// and attaches the tracking information.
+func (p *Provider) CreateFulfillment(ctx context.Context, externalOrderID string, tracking integration.FulfillmentTracking) (string, error) {
+	f, err := p.client.Fulfillments.Create(ctx, shopifysdk.FulfillmentInput{
+		OrderID:         externalOrderID,
+		TrackingNumber:  tracking.Number,
+		TrackingCompany: tracking.Company,
+		TrackingURL:     tracking.URL,
+		NotifyCustomer:  tracking.NotifyCustomer,
+	})
+	if err != nil {
+		return "", fmt.Errorf("shopify: create fulfillment for order %s: %w", externalOrderID, err)
+	}
+	return shopifysdk.LegacyID(f.ID), nil
+}
+
+// ListCatalog returns one page of products. The cursor is the GraphQL end
+// cursor of the previous page. Products with only Shopify's default variant
+// are listed as simple products offered under that variant's ID.
+func (p *Provider) ListCatalog(ctx context.Context, cursor string) ([]integration.CatalogProduct, string, error) {
+	conn, err := p.client.Products.List(ctx, &shopifysdk.ProductListParams{First: 50, After: cursor})
+	if err != nil {
+		return nil, "", fmt.Errorf("shopify: list products: %w", err)
+	}
+
+	result := make([]integration.CatalogProduct, 0, len(conn.Nodes))
+	for i := range conn.Nodes {
+		sp := &conn.Nodes[i]
+		cp := integration.CatalogProduct{
+			ExternalGroupID: shopifysdk.LegacyID(sp.ID),
+			Name:            sp.Title,
+			DescriptionLong: sp.DescriptionHTML,
+			Category:        sp.ProductType,
+		}
+		variants := sp.Variants.Nodes
+		if len(variants) == 1 && isDefaultVariant(&variants[0]) {
+			v := variants[0]
+			cp.ExternalID = shopifysdk.LegacyID(v.ID)
+			cp.SKU = v.SKU
+			cp.EAN = v.Barcode
+			cp.Price, _ = strconv.ParseFloat(v.Price, 64)
+			cp.StockQuantity = max(v.InventoryQuantity, 0)
+		} else {
+			for _, v := range variants {
+				cv := integration.CatalogVariant{
+					ExternalID:    shopifysdk.LegacyID(v.ID),
+					Name:          v.Title,
+					SKU:           v.SKU,
+					EAN:           v.Barcode,
+					StockQuantity: max(v.InventoryQuantity, 0),
+					Attributes:    make(map[string]string, len(v.SelectedOptions)),
+				}
+				cv.Price, _ = strconv.ParseFloat(v.Price, 64)
+				for _, o := range v.SelectedOptions {
+					cv.Attributes[o.Name] = o.Value
+				}
+				cp.Variants = append(cp.Variants, cv)
+			}
+			if len(cp.Variants) > 0 {
+				cp.Price = cp.Variants[0].Price
+			}
+		}
+		result = append(result, cp)
+	}
+
+	next := ""
+	if conn.PageInfo.HasNextPage {
+		next = conn.PageInfo.EndCursor
+	}
+	return result, next, nil
+}
+
+// isDefaultVariant reports whether v is the placeholder variant Shopify creates
+// for products without options.
+func isDefaultVariant(v *shopifysdk.ProductVariant) bool {
+	return len(v.SelectedOptions) == 1 && v.SelectedOptions[0].Value == "Default Title"
+}
+
+// ListLocations returns the shop's locations.
+func (p *Provider) ListLocations(ctx context.Context) ([]shopifysdk.Location, error) {
+	locations, err := p.client.Inventory.ListLocations(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("shopify: list locations: %w", err)
+	}
+	return locations, nil
+}
+
+// defaultLocationID returns the configured default location or the first active one.
+func (p *Provider) defaultLocationID(ctx context.Context) (string, error) {
+	if p.settings.DefaultLocationID != "" {
+		return p.settings.DefaultLocationID, nil
+	}
+	locations, err := p.ListLocations(ctx)
+	if err != nil {
+		return "", err
+	}
+	for _, l := range locations {
+		if l.IsActive {
+			p.settings.DefaultLocationID = shopifysdk.LegacyID(l.ID)
+			return p.settings.DefaultLocationID, nil
+		}
+	}
+	return "", fmt.Errorf("shopify: no active location found")
+}
+
+// laterTimestamp reports whether RFC3339 timestamp a is after b (an empty b is always earlier).
+func laterTimestamp(a, b string) bool {
+	if b == "" {
+		return a != ""
+	}
+	ta, errA := time.Parse(time.RFC3339, a)
+	tb, errB := time.Parse(time.RFC3339, b)
+	if errA != nil || errB != nil {
+		return a > b
+	}
+	return ta.After(tb)
+}
+
+// mapShopifyOrder converts a Shopify SDK Order to the normalized MarketplaceOrder.
+func (p *Provider) mapShopifyOrder(o *shopifysdk.Order) integration.MarketplaceOrder {
+	mo := integration.MarketplaceOrder{
+		ExternalID:     shopifysdk.LegacyID(o.ID),
+		ExternalStatus: strings.ToLower(o.DisplayFulfillmentStatus),
+		CustomerEmail:  o.Email,
+		CustomerPhone:  o.Phone,
+		TotalAmount:    o.TotalPriceSet.ShopMoney.Float(),
+		Currency:       o.TotalPriceSet.ShopMoney.CurrencyCode,
+	}
+	if mo.Currency == "" {
+		mo.Currency = o.CurrencyCode
+	}
+	if o.CancelledAt != nil {
+		mo.ExternalStatus = "cancelled"
+	}
+	if len(o.PaymentGatewayNames) > 0 {
+		mo.PaymentMethod = strings.Join(o.PaymentGatewayNames, ", ")
+	}
+
+	if o.ShippingAddress != nil {
+		mo.ShippingAddress = mapAddress(o.ShippingAddress, o.Email)
+		mo.CustomerName = mo.ShippingAddress.Name
+		if mo.CustomerPhone == "" {
+			mo.CustomerPhone = o.ShippingAddress.Phone
+		}
+	}
+	if o.BillingAddress != nil {
+		addr := mapAddress(o.BillingAddress, o.Email)
+		mo.BillingAddress = &addr
+		if mo.CustomerName == "" {
+			mo.CustomerName = addr.Name
+		}
+	}
+	if o.Customer != nil {
+		if mo.CustomerName == "" {
+			mo.CustomerName = strings.TrimSpace(o.Customer.FirstName + " " + o.Customer.LastName)
+		}
+		if mo.CustomerEmail == "" {
+			mo.CustomerEmail = o.Customer.Email
+		}
+	}
+
+	// Payment status from Shopify's financial status
+	switch o.DisplayFinancialStatus {
+	case "PAID", "PARTIALLY_REFUNDED":
+		mo.PaymentStatus = "paid"
+	case "REFUNDED":
+		mo.PaymentStatus = "refunded"
+	case "PARTIALLY_PAID":
+		mo.PaymentStatus = "partially_paid"
+	default:
+		mo.PaymentStatus = "pending"
+	}
+
+	if t, err := time.Parse(time.RFC3339, o.CreatedAt); err == nil {
+		mo.OrderedAt = t
+	}
+
+	// Line items — the variant ID is used as the external ID to match listings
+	for _, li := range o.LineItems.Nodes {
+		item := integration.MarketplaceOrderItem{
+			ExternalID: shopifysdk.LegacyID(li.ID),
+			Name:       li.Name,
+			SKU:        li.SKU,
+			Quantity:   li.Quantity,
+			UnitPrice:  li.OriginalUnitPriceSet.ShopMoney.Float(),
+			TotalPrice: li.DiscountedTotalSet.ShopMoney.Float(),
+		}
+		if li.Variant != nil {
+			item.ExternalID = shopifysdk.LegacyID(li.Variant.ID)
+			item.EAN = li.Variant.Barcode
+		}
+		if item.TotalPrice == 0 {
+			item.TotalPrice = item.UnitPrice * float64(li.Quantity)
+		}
+		if len(li.TaxLines) > 0 {
+			item.TaxRate = li.TaxLines[0].Rate * 100
+		}
+		mo.Items = append(mo.Items, item)
+	}
+
+	// RawData: order name (#1001), note and shipping method
+	mo.RawData = map[string]any{
+		"shopify_order_id":   o.ID,
+		"shopify_order_name": o.Name,
+		"financial_status":   o.DisplayFinancialStatus,
+	}
+	if o.Note != "" {
+		mo.RawData["customer_note"] = o.Note
+	}
+	if o.ShippingLine != nil && o.ShippingLine.Title != "" {
+		mo.RawData["shipping_method"] = o.ShippingLine.Title
+	}
+
+	return mo
+}
+
+func mapAddress(a *shopifysdk.MailingAddress, email string) model.ShippingAddress {
+	name := a.Name
+	if name == "" {
+		name = strings.TrimSpace(a.FirstName + " " + a.LastName)
+	}
+	street := a.Address1
+	if a.Address2 != "" {
+		street += " " + a.Address2
+	}
+	addr := model.ShippingAddress{
+		Name:       name,
+		Street:     street,
+		City:       a.City,
+		PostalCode: a.Zip,
+		Country:    a.CountryCode,
+		Phone:      a.Phone,
+		Email:      email,
+	}
+	if a.Company != "" {
+		company := a.Company
+		addr.Company = &company
+	}
+	return addr
+}
@@ -0,0 +1,236 @@
+package shopify
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	shopifysdk "github.com/openoms-org/openoms/packages/shopify-go-sdk"
+
+	"github.com/openoms-org/openoms/apps/api-server/internal/integration"
+)
+
+type gqlRequest struct {
+	Query     string         `json:"query"`
+	Variables map[string]any `json:"variables"`
+}
+
+// newTestProvider creates a Provider backed by a GraphQL stand-in that answers
+// each request with the response returned by handler.
+func newTestProvider(t *testing.T, settings ShopifySettings, handler func(req gqlRequest) string) *Provider {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req gqlRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("decode request: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(handler(req)))
+	}))
+	t.Cleanup(srv.Close)
+
+	return &Provider{
+		client:   shopifysdk.NewClient("test-shop", "shpat_test", shopifysdk.WithBaseURL(srv.URL), shopifysdk.WithHTTPClient(srv.Client())),
+		settings: settings,
+		logger:   slog.Default().With("provider", "shopify-test"),
+	}
+}
+
+func TestNewProviderValidation(t *testing.T) {
+	if _, err := NewProvider(json.RawMessage(`{"access_token":"x"}`), nil); err == nil {
+		t.Error("expected error for missing shop_domain")
+	}
+	if _, err := NewProvider(json.RawMessage(`{"shop_domain":"x"}`), nil); err == nil {
+		t.Error("expected error for missing access_token")
+	}
+	p, err := NewProvider(
+		json.RawMessage(`{"shop_domain":"my-shop","access_token":"shpat_x"}`),
+		json.RawMessage(`{"location_mapping":{"wh-1":"111"},"default_location_id":"111"}`),
+	)
+	if err != nil {
+		t.Fatalf("NewProvider() error: %v", err)
+	}
+	if p.LocationMapping()["wh-1"] != "111" {
+		t.Errorf("location mapping = %v", p.LocationMapping())
+	}
+
+	var _ integration.FulfillmentCreator = p
+	var _ integration.LocationStockUpdater = p
+	var _ integration.CatalogLister = p
+}
+
+func TestListCatalogSplitsDefaultVariant(t *testing.T) {
+	p := newTestProvider(t, ShopifySettings{}, func(req gqlRequest) string {
+		if req.Variables["after"] != "abc" {
+			t.Errorf("after = %v", req.Variables["after"])
+		}
+		return `{"data":{"products":{"nodes":[
+			{"id":"gid://shopify/Product/1","title":"Kubek","productType":"Kuchnia","variants":{"nodes":[
+				{"id":"gid://shopify/ProductVariant/11","title":"Default Title","sku":"MUG","barcode":"5901234123457","price":"19.99","inventoryQuantity":3,
+				 "selectedOptions":[{"name":"Title","value":"Default Title"}]}
+			]}},
+			{"id":"gid://shopify/Product/2","title":"Koszulka","variants":{"nodes":[
+				{"id":"gid://shopify/ProductVariant/21","title":"S","sku":"TS-S","price":"49.00","inventoryQuantity":-1,
+				 "selectedOptions":[{"name":"Rozmiar","value":"S"}]},
+				{"id":"gid://shopify/ProductVariant/22","title":"M","sku":"TS-M","price":"49.00","inventoryQuantity":4,
+				 "selectedOptions":[{"name":"Rozmiar","value":"M"}]}
+			]}}
+		],"pageInfo":{"hasNextPage":true,"endCursor":"def"}}}}`
+	})
+
+	products, next, err := p.ListCatalog(context.Background(), "abc")
+	if err != nil {
+		t.Fatalf("ListCatalog() error: %v", err)
+	}
+	if next != "def" {
+		t.Errorf("next cursor = %q, want def", next)
+	}
+	if len(products) != 2 {
+		t.Fatalf("got %d products, want 2", len(products))
+	}
+
+	mug := products[0]
+	if mug.ExternalID != "11" || mug.ExternalGroupID != "1" || mug.SKU != "MUG" || mug.Price != 19.99 || len(mug.Variants) != 0 {
+		t.Errorf("simple product = %+v", mug)
+	}
+
+	shirt := products[1]
+	if shirt.ExternalID != "" || len(shirt.Variants) != 2 {
+		t.Fatalf("product with variants = %+v", shirt)
+	}
+	if v := shirt.Variants[0]; v.ExternalID != "21" || v.StockQuantity != 0 || v.Attributes["Rozmiar"] != "S" {
+		t.Errorf("variant = %+v", v)
+	}
+}
+
+func TestPollOrdersPaginatesAndAdvancesCursor(t *testing.T) {
+	var afters []any
+	p := newTestProvider(t, ShopifySettings{}, func(req gqlRequest) string {
+		afters = append(afters, req.Variables["after"])
+		if req.Variables["after"] == nil {
+			return `{"data":{"orders":{"nodes":[{
+				"id":"gid://shopify/Order/1001","name":"#1001","email":"jan@example.com",
+				"createdAt":"2025-03-01T10:00:00Z","updatedAt":"2025-03-02T09:00:00Z",
+				"displayFinancialStatus":"PAID","displayFulfillmentStatus":"UNFULFILLED",
+				"paymentGatewayNames":["shopify_payments"],"note":"Leave at door",
+				"totalPriceSet":{"shopMoney":{"amount":"123.00","currencyCode":"PLN"}},
+				"shippingAddress":{"firstName":"Jan","lastName":"Kowalski","address1":"Prosta 1","address2":"m. 2","city":"Warszawa","zip":"00-001","countryCodeV2":"PL","phone":"+48500600700"},
+				"shippingLine":{"title":"InPost Paczkomat"},
+				"lineItems":{"nodes":[{"id":"gid://shopify/LineItem/1","name":"Mug","sku":"MUG-1","quantity":2,
+					"originalUnitPriceSet":{"shopMoney":{"amount":"50.00","currencyCode":"PLN"}},
+					"discountedTotalSet":{"shopMoney":{"amount":"100.00","currencyCode":"PLN"}},
+					"variant":{"id":"gid://shopify/ProductVariant/55","barcode":"5901234123457"},
+					"taxLines":[{"rate":0.23}]}]}}],
+				"pageInfo":{"hasNextPage":true,"endCursor":"c1"}}}}`
+		}
+		return `{"data":{"orders":{"nodes":[{
+			"id":"gid://shopify/Order/1002","createdAt":"2025-03-01T11:00:00Z","updatedAt":"2025-03-01T12:00:00Z",
+			"displayFinancialStatus":"PENDING","totalPriceSet":{"shopMoney":{"amount":"10.00","currencyCode":"PLN"}},
+			"lineItems":{"nodes":[]}}],
+			"pageInfo":{"hasNextPage":false,"endCursor":"c2"}}}}`
+	})
+
+	orders, cursor, err := p.PollOrders(context.Background(), "2025-03-01T00:00:00Z")
+	if err != nil {
+		t.Fatalf("PollOrders() error: %v", err)
+	}
+	if len(orders) != 2 {
+		t.Fatalf("got %d orders, want 2", len(orders))
+	}
+	if len(afters) != 2 || afters[1] != "c1" {
+		t.Errorf("after cursors = %v", afters)
+	}
+	if cursor != "2025-03-02T09:00:00Z" {
+		t.Errorf("cursor = %q, want latest updatedAt", cursor)
+	}
+
+	o := orders[0]
+	if o.ExternalID != "1001" || o.CustomerName != "Jan Kowalski" || o.PaymentStatus != "paid" {
+		t.Errorf("unexpected order: %+v", o)
+	}
+	if o.ShippingAddress.Street != "Prosta 1 m. 2" || o.ShippingAddress.PostalCode != "00-001" {
+		t.Errorf("unexpected address: %+v", o.ShippingAddress)
+	}
+	if len(o.Items) != 1 || o.Items[0].ExternalID != "55" || o.Items[0].TaxRate != 23 || o.Items[0].EAN != "5901234123457" {
+		t.Errorf("unexpected items: %+v", o.Items)
+	}
+	if o.RawData["shipping_method"] != "InPost Paczkomat" || o.RawData["customer_note"] != "Leave at door" {
+		t.Errorf("unexpected raw data: %+v", o.RawData)
+	}
+	if orders[1].PaymentStatus != "pending" {
+		t.Errorf("second order payment status = %q", orders[1].PaymentStatus)
+	}
+}
+
+func TestUpdateLocationStock(t *testing.T) {
+	p := newTestProvider(t, ShopifySettings{}, func(req gqlRequest) string {
+		if strings.Contains(req.Query, "productVariant(") {
+			if req.Variables["id"] != "gid://shopify/ProductVariant/55" {
+				t.Errorf("variant id = %v", req.Variables["id"])
+			}
+			return `{"data":{"productVariant":{"id":"gid://shopify/ProductVariant/55","inventoryItem":{"id":"gid://shopify/InventoryItem/77"},"product":{"id":"gid://shopify/Product/9"}}}}`
+		}
+		q := req.Variables["input"].(map[string]any)["quantities"].([]any)[0].(map[string]any)
+		if q["inventoryItemId"] != "gid://shopify/InventoryItem/77" || q["locationId"] != "gid://shopify/Location/222" || q["quantity"] != float64(7) {
+			t.Errorf("quantities = %v", q)
+		}
+		return `{"data":{"inventorySetQuantities":{"userErrors":[]}}}`
+	})
+
+	if err := p.UpdateLocationStock(context.Background(), "55", "222", 7); err != nil {
+		t.Fatalf("UpdateLocationStock() error: %v", err)
+	}
+}
+
+func TestUpdateStockUsesFirstActiveLocation(t *testing.T) {
+	var locationID any
+	p := newTestProvider(t, ShopifySettings{}, func(req gqlRequest) string {
+		switch {
+		case strings.Contains(req.Query, "locations("):
+			return `{"data":{"locations":{"nodes":[
+				{"id":"gid://shopify/Location/1","name":"Old","isActive":false},
+				{"id":"gid://shopify/Location/2","name":"Main","isActive":true}]}}}`
+		case strings.Contains(req.Query, "productVariant("):
+			return `{"data":{"productVariant":{"id":"gid://shopify/ProductVariant/55","inventoryItem":{"id":"gid://shopify/InventoryItem/77"}}}}`
+		default:
+			locationID = req.Variables["input"].(map[string]any)["quantities"].([]any)[0].(map[string]any)["locationId"]
+			return `{"data":{"inventorySetQuantities":{"userErrors":[]}}}`
+		}
+	})
+
+	if err := p.UpdateStock(context.Background(), "55", 3); err != nil {
+		t.Fatalf("UpdateStock() error: %v", err)
+	}
+	if locationID != "gid://shopify/Location/2" {
+		t.Errorf("locationId = %v, want first active location", locationID)
+	}
+}
+
+func TestCreateFulfillment(t *testing.T) {
+	p := newTestProvider(t, ShopifySettings{}, func(req gqlRequest) string {
+		if strings.Contains(req.Query, "fulfillmentOrders") {
+			return `{"data":{"order":{"fulfillmentOrders":{"nodes":[{"id":"gid://shopify/FulfillmentOrder/5","status":"OPEN"}]}}}}`
+		}
+		f := req.Variables["fulfillment"].(map[string]any)
+		if f["notifyCustomer"] != true {
+			t.Errorf("notifyCustomer = %v", f["notifyCustomer"])
+		}
+		return `{"data":{"fulfillmentCreate":{"fulfillment":{"id":"gid://shopify/Fulfillment/88","status":"SUCCESS"},"userErrors":[]}}}`
+	})
+
+	id, err := p.CreateFulfillment(context.Background(), "1001", integration.FulfillmentTracking{
+		Number:         "TRK1",
+		Company:        "InPost",
+		NotifyCustomer: true,
+	})
+	if err != nil {
+		t.Fatalf("CreateFulfillment() error: %v", err)
+	}
+	if id != "88" {
+		t.Errorf("fulfillment id = %q, want 88", id)
+	}
+}
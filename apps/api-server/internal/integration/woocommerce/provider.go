@@ -7,6 +7,7 @@ import (
 	"log/slog"
 	"maps"
 	"strconv"
+	"strings"
 	"time"
 
 	woocommercesdk "github.com/openoms-org/openoms/packages/woocommerce-go-sdk"
@@ -133,24 +134,163 @@ func (p *Provider) PushOffer(ctx context.Context, product *model.Product, listin
 	return strconv.Itoa(created.ID), nil
 }
 
-// UpdateStock updates the stock quantity for a WooCommerce product.
+// UpdateStock updates the stock quantity for a WooCommerce product, or for a
+// variation when the offer ID has the form "productID:variationID".
 func (p *Provider) UpdateStock(ctx context.Context, externalOfferID string, quantity int) error {
-	id, err := strconv.Atoi(externalOfferID)
+	id, variationID, err := parseOfferID(externalOfferID)
 	if err != nil {
-		return fmt.Errorf("woocommerce: invalid product ID %q: %w", externalOfferID, err)
+		return err
+	}
+	if variationID != 0 {
+		return p.client.Products.UpdateVariationStock(ctx, id, variationID, quantity)
 	}
 	return p.client.Products.UpdateStock(ctx, id, quantity)
 }
 
-// UpdatePrice updates the price for a WooCommerce product.
+// UpdatePrice updates the price for a WooCommerce product or variation.
 func (p *Provider) UpdatePrice(ctx context.Context, externalOfferID string, price float64) error {
-	id, err := strconv.Atoi(externalOfferID)
+	id, variationID, err := parseOfferID(externalOfferID)
 	if err != nil {
-		return fmt.Errorf("woocommerce: invalid product ID %q: %w", externalOfferID, err)
+		return err
+	}
+	if variationID != 0 {
+		return p.client.Products.UpdateVariationPrice(ctx, id, variationID, fmt.Sprintf("%.2f", price))
 	}
 	return p.client.Products.UpdatePrice(ctx, id, fmt.Sprintf("%.2f", price))
 }
 
+const catalogPageSize = 50
+
+// ListCatalog returns one page of shop products with their variations. The
+// cursor is the page number; grouped products are skipped as they have no
+// stock of their own.
+func (p *Provider) ListCatalog(ctx context.Context, cursor string) ([]integration.CatalogProduct, string, error) {
+	page := 1
+	if cursor != "" {
+		n, err := strconv.Atoi(cursor)
+		if err != nil {
+			return nil, "", fmt.Errorf("woocommerce: invalid catalog cursor %q", cursor)
+		}
+		page = n
+	}
+
+	products, err := p.client.Products.List(ctx, woocommercesdk.ProductListParams{
+		Page:    page,
+		PerPage: catalogPageSize,
+		Order:   "asc",
+		OrderBy: "id",
+	})
+	if err != nil {
+		return nil, "", fmt.Errorf("woocommerce: list products page %d: %w", page, err)
+	}
+
+	result := make([]integration.CatalogProduct, 0, len(products))
+	for _, wp := range products {
+		if wp.Type == "grouped" {
+			continue
+		}
+		cp := mapWooCatalogProduct(&wp)
+		if wp.Type == "variable" {
+			variations, err := p.client.Products.ListVariations(ctx, wp.ID)
+			if err != nil {
+				return nil, "", fmt.Errorf("woocommerce: list variations of %d: %w", wp.ID, err)
+			}
+			for _, v := range variations {
+				cp.Variants = append(cp.Variants, mapWooCatalogVariant(wp.ID, &v))
+			}
+			cp.ExternalID = ""
+		}
+		result = append(result, cp)
+	}
+
+	next := ""
+	if len(products) == catalogPageSize {
+		next = strconv.Itoa(page + 1)
+	}
+	return result, next, nil
+}
+
+func mapWooCatalogProduct(wp *woocommercesdk.WooProduct) integration.CatalogProduct {
+	price, _ := strconv.ParseFloat(wp.RegularPrice, 64)
+	if price == 0 {
+		price, _ = strconv.ParseFloat(wp.Price, 64)
+	}
+	cp := integration.CatalogProduct{
+		ExternalID:       strconv.Itoa(wp.ID),
+		ExternalGroupID:  strconv.Itoa(wp.ID),
+		Name:             wp.Name,
+		SKU:              wp.SKU,
+		EAN:              wp.GlobalUniqueID,
+		Price:            price,
+		DescriptionShort: wp.ShortDesc,
+		DescriptionLong:  wp.Description,
+		URL:              wp.Permalink,
+	}
+	if wp.StockQuantity != nil && *wp.StockQuantity > 0 {
+		cp.StockQuantity = *wp.StockQuantity
+	}
+	if len(wp.Categories) > 0 {
+		cp.Category = wp.Categories[0].Name
+	}
+	for _, img := range wp.Images {
+		cp.Images = append(cp.Images, img.Src)
+	}
+	if len(cp.Images) > 0 {
+		cp.ImageURL = cp.Images[0]
+	}
+	if w, err := strconv.ParseFloat(wp.Weight, 64); err == nil {
+		cp.Weight = &w
+	}
+	return cp
+}
+
+func mapWooCatalogVariant(productID int, v *woocommercesdk.WooVariation) integration.CatalogVariant {
+	price, _ := strconv.ParseFloat(v.RegularPrice, 64)
+	if price == 0 {
+		price, _ = strconv.ParseFloat(v.Price, 64)
+	}
+	cv := integration.CatalogVariant{
+		ExternalID: fmt.Sprintf("%d:%d", productID, v.ID),
+		SKU:        v.SKU,
+		EAN:        v.GlobalUniqueID,
+		Price:      price,
+		Attributes: make(map[string]string, len(v.Attributes)),
+	}
+	if v.StockQuantity != nil && *v.StockQuantity > 0 {
+		cv.StockQuantity = *v.StockQuantity
+	}
+	if v.Image != nil {
+		cv.ImageURL = v.Image.Src
+	}
+	options := make([]string, 0, len(v.Attributes))
+	for _, a := range v.Attributes {
+		cv.Attributes[a.Name] = a.Option
+		options = append(options, a.Option)
+	}
+	cv.Name = strings.Join(options, " / ")
+	if cv.Name == "" {
+		cv.Name = strconv.Itoa(v.ID)
+	}
+	return cv
+}
+
+// parseOfferID splits "productID[:variationID]"; variationID is 0 for simple products.
+func parseOfferID(externalOfferID string) (int, int, error) {
+	productPart, variationPart, hasVariation := strings.Cut(externalOfferID, ":")
+	id, err := strconv.Atoi(productPart)
+	if err != nil {
+		return 0, 0, fmt.Errorf("woocommerce: invalid product ID %q: %w", externalOfferID, err)
+	}
+	if !hasVariation {
+		return id, 0, nil
+	}
+	variationID, err := strconv.Atoi(variationPart)
+	if err != nil {
+		return 0, 0, fmt.Errorf("woocommerce: invalid variation ID %q: %w", externalOfferID, err)
+	}
+	return id, variationID, nil
+}
+
 // mapWooOrder converts a WooCommerce SDK WooOrder to the normalized MarketplaceOrder.
 func (p *Provider) mapWooOrder(o *woocommercesdk.WooOrder) integration.MarketplaceOrder {
 	customerName := fmt.Sprintf("%s %s", o.Shipping.FirstName, o.Shipping.LastName)
@@ -210,14 +350,18 @@ func (p *Provider) mapWooOrder(o *woocommercesdk.WooOrder) integration.Marketpla
 		unitPrice := li.Price
 		totalPrice, _ := strconv.ParseFloat(li.Total, 64)
 
-		mo.Items = append(mo.Items, integration.MarketplaceOrderItem{
+		item := integration.MarketplaceOrderItem{
 			ExternalID: strconv.Itoa(li.ProductID),
 			Name:       li.Name,
 			SKU:        li.SKU,
 			Quantity:   li.Quantity,
 			UnitPrice:  unitPrice,
 			TotalPrice: totalPrice,
-		})
+		}
+		if li.VariationID != 0 {
+			item.VariantExternalID = strconv.Itoa(li.VariationID)
+		}
+		mo.Items = append(mo.Items, item)
 	}
 
 	// RawData: store customer note and payment method code
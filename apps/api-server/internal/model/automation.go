@@ -11,32 +11,40 @@ import (
 
 // AutomationRule represents an automation rule record in the system.
 type AutomationRule struct {
-	ID           uuid.UUID       `json:"id"`
-	TenantID     uuid.UUID       `json:"tenant_id"`
-	Name         string          `json:"name"`
-	Description  *string         `json:"description,omitempty"`
-	Enabled      bool            `json:"enabled"`
-	Priority     int             `json:"priority"`
-	TriggerEvent string          `json:"trigger_event"`
-	Conditions   json.RawMessage `json:"conditions"`
-	Actions      json.RawMessage `json:"actions"`
-	LastFiredAt  *time.Time      `json:"last_fired_at,omitempty"`
-	FireCount    int             `json:"fire_count"`
-	CreatedAt    time.Time       `json:"created_at"`
-	UpdatedAt    time.Time       `json:"updated_at"`
+	ID            uuid.UUID       `json:"id"`
+	TenantID      uuid.UUID       `json:"tenant_id"`
+	Name          string          `json:"name"`
+	Description   *string         `json:"description,omitempty"`
+	Enabled       bool            `json:"enabled"`
+	Priority      int             `json:"priority"`
+	TriggerEvent  string          `json:"trigger_event"`
+	TriggerConfig json.RawMessage `json:"trigger_config"`
+	Conditions    json.RawMessage `json:"conditions"`
+	Actions       json.RawMessage `json:"actions"`
+	LastFiredAt   *time.Time      `json:"last_fired_at,omitempty"`
+	NextRunAt     *time.Time      `json:"next_run_at,omitempty"`
+	FireCount     int             `json:"fire_count"`
+	CreatedAt     time.Time       `json:"created_at"`
+	UpdatedAt     time.Time       `json:"updated_at"`
 }
 
 // ValidTriggerEvents is the set of supported trigger events.
 var ValidTriggerEvents = map[string]bool{
-	"order.created":           true,
-	"order.status_changed":    true,
-	"order.updated":           true,
-	"shipment.created":        true,
-	"shipment.status_changed": true,
-	"return.created":          true,
-	"return.status_changed":   true,
-	"product.created":         true,
-	"product.updated":         true,
+	"order.created":               true,
+	"order.status_changed":        true,
+	"order.updated":               true,
+	"order.hold_placed":           true,
+	"order.hold_released":         true,
+	"shipment.created":            true,
+	"shipment.status_changed":     true,
+	"return.created":              true,
+	"return.status_changed":       true,
+	"product.created":             true,
+	"product.updated":             true,
+	TriggerSchedule:               true,
+	TriggerOrderStatusDuration:    true,
+	TriggerShipmentStatusDuration: true,
+	TriggerReturnStatusDuration:   true,
 }
 
 // ValidConditionOperators is the set of supported condition operators.
@@ -52,6 +60,15 @@ var ValidConditionOperators = map[string]bool{
 	"contains":     true,
 	"not_contains": true,
 	"starts_with":  true,
+	"regex":        true,
+	"between":      true,
+	"is_empty":     true,
+	"is_not_empty": true,
+	"length_eq":    true,
+	"length_gt":    true,
+	"length_lt":    true,
+	"older_than":   true,
+	"newer_than":   true,
 }
 
 // ValidActionTypes is the set of supported action types.
@@ -61,6 +78,15 @@ var ValidActionTypes = map[string]bool{
 	"send_email":     true,
 	"create_invoice": true,
 	"webhook":        true,
+
+	"assign_user":              true,
+	"set_priority":             true,
+	"create_shipment":          true,
+	"send_sms":                 true,
+	"create_freshdesk_ticket":  true,
+	"split_order_by_warehouse": true,
+	"add_internal_note":        true,
+	"allegro_message_buyer":    true,
 }
 
 // AutomationCondition represents a single condition in an automation rule.
@@ -95,13 +121,14 @@ type DelayedAction struct {
 
 // CreateAutomationRuleRequest is the payload for creating an automation rule.
 type CreateAutomationRuleRequest struct {
-	Name         string          `json:"name"`
-	Description  *string         `json:"description,omitempty"`
-	Enabled      *bool           `json:"enabled,omitempty"`
-	Priority     *int            `json:"priority,omitempty"`
-	TriggerEvent string          `json:"trigger_event"`
-	Conditions   json.RawMessage `json:"conditions"`
-	Actions      json.RawMessage `json:"actions"`
+	Name          string          `json:"name"`
+	Description   *string         `json:"description,omitempty"`
+	Enabled       *bool           `json:"enabled,omitempty"`
+	Priority      *int            `json:"priority,omitempty"`
+	TriggerEvent  string          `json:"trigger_event"`
+	TriggerConfig json.RawMessage `json:"trigger_config,omitempty"`
+	Conditions    json.RawMessage `json:"conditions"`
+	Actions       json.RawMessage `json:"actions"`
 }
 
 func (r *CreateAutomationRuleRequest) Validate() error {
@@ -122,6 +149,16 @@ func (r *CreateAutomationRuleRequest) Validate() error {
 	if !ValidTriggerEvents[r.TriggerEvent] {
 		return errors.New("invalid trigger_event")
 	}
+	cfg, err := ParseTriggerConfig(r.TriggerConfig)
+	if err != nil {
+		return err
+	}
+	if err := cfg.Validate(r.TriggerEvent); err != nil {
+		return err
+	}
+	if r.TriggerConfig == nil || string(r.TriggerConfig) == "" {
+		r.TriggerConfig = json.RawMessage("{}")
+	}
 	if r.Conditions == nil || string(r.Conditions) == "" {
 		r.Conditions = json.RawMessage("[]")
 	}
@@ -133,18 +170,19 @@ func (r *CreateAutomationRuleRequest) Validate() error {
 
 // UpdateAutomationRuleRequest is the payload for updating an automation rule.
 type UpdateAutomationRuleRequest struct {
-	Name         *string         `json:"name,omitempty"`
-	Description  *string         `json:"description,omitempty"`
-	Enabled      *bool           `json:"enabled,omitempty"`
-	Priority     *int            `json:"priority,omitempty"`
-	TriggerEvent *string         `json:"trigger_event,omitempty"`
-	Conditions   json.RawMessage `json:"conditions,omitempty"`
-	Actions      json.RawMessage `json:"actions,omitempty"`
+	Name          *string         `json:"name,omitempty"`
+	Description   *string         `json:"description,omitempty"`
+	Enabled       *bool           `json:"enabled,omitempty"`
+	Priority      *int            `json:"priority,omitempty"`
+	TriggerEvent  *string         `json:"trigger_event,omitempty"`
+	TriggerConfig json.RawMessage `json:"trigger_config,omitempty"`
+	Conditions    json.RawMessage `json:"conditions,omitempty"`
+	Actions       json.RawMessage `json:"actions,omitempty"`
 }
 
 func (r *UpdateAutomationRuleRequest) Validate() error {
 	if r.Name == nil && r.Description == nil && r.Enabled == nil &&
-		r.Priority == nil && r.TriggerEvent == nil &&
+		r.Priority == nil && r.TriggerEvent == nil && r.TriggerConfig == nil &&
 		r.Conditions == nil && r.Actions == nil {
 		return errors.New("at least one field must be provided")
 	}
@@ -166,6 +204,11 @@ func (r *UpdateAutomationRuleRequest) Validate() error {
 			return errors.New("invalid trigger_event")
 		}
 	}
+	if r.TriggerConfig != nil {
+		if _, err := ParseTriggerConfig(r.TriggerConfig); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -196,10 +239,25 @@ type TestAutomationRuleRequest struct {
 }
 
 // TestAutomationRuleResponse is the response from a dry-run test.
+// ConditionResults lists the leaf conditions; ConditionTree shows how the
+// groups combined them.
 type TestAutomationRuleResponse struct {
-	ConditionResults []ConditionResult  `json:"condition_results"`
-	AllConditionsMet bool               `json:"all_conditions_met"`
-	ActionsToExecute []AutomationAction `json:"actions_to_execute"`
+	ConditionResults []ConditionResult    `json:"condition_results"`
+	ConditionTree    *ConditionTreeResult `json:"condition_tree,omitempty"`
+	AllConditionsMet bool                 `json:"all_conditions_met"`
+	ActionsToExecute []AutomationAction   `json:"actions_to_execute"`
+	ActionResults    []ActionDryRun       `json:"action_results"`
+}
+
+// ActionDryRun is an action of a dry-run test with its template parameters
+// rendered against the test data. Missing lists the placeholders that had no
+// value; Error is set when the rendered action would be rejected.
+type ActionDryRun struct {
+	Type         string         `json:"type"`
+	Params       map[string]any `json:"params"`
+	DelaySeconds int            `json:"delay_seconds,omitempty"`
+	Missing      []string       `json:"missing"`
+	Error        string         `json:"error,omitempty"`
 }
 
 // ConditionResult shows the result of evaluating a single condition.
@@ -207,3 +265,16 @@ type ConditionResult struct {
 	Condition AutomationCondition `json:"condition"`
 	Met       bool                `json:"met"`
 }
+
+// ConditionTreeResult is the dry-run evaluation of a node of a condition
+// tree: a group ("and", "or", "not") with its children, or a leaf with the
+// field value it was compared against.
+type ConditionTreeResult struct {
+	Op         string                `json:"op,omitempty"`
+	Field      string                `json:"field,omitempty"`
+	Operator   string                `json:"operator,omitempty"`
+	Value      any                   `json:"value,omitempty"`
+	Actual     any                   `json:"actual,omitempty"`
+	Met        bool                  `json:"met"`
+	Conditions []ConditionTreeResult `json:"conditions,omitempty"`
+}
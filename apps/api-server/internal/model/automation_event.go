@@ -0,0 +1,77 @@
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Automation event statuses.
+const (
+	AutomationEventPending    = "pending"
+	AutomationEventProcessing = "processing"
+	AutomationEventDone       = "done"
+	AutomationEventDead       = "dead"
+)
+
+// AutomationEventMaxAttempts is the number of attempts after which a failing
+// event is moved to the dead-letter list.
+const AutomationEventMaxAttempts = 8
+
+// AutomationEvent is an event waiting in, or processed from, the durable
+// automation queue. RuleID is set for the events of time-based rules, which
+// run only that rule.
+type AutomationEvent struct {
+	ID            uuid.UUID       `json:"id"`
+	Seq           int64           `json:"seq"`
+	TenantID      uuid.UUID       `json:"tenant_id"`
+	EventType     string          `json:"event_type"`
+	EntityType    string          `json:"entity_type"`
+	EntityID      uuid.UUID       `json:"entity_id"`
+	RuleID        *uuid.UUID      `json:"rule_id,omitempty"`
+	Data          json.RawMessage `json:"data"`
+	Status        string          `json:"status"`
+	Attempts      int             `json:"attempts"`
+	NextAttemptAt time.Time       `json:"next_attempt_at"`
+	LockedAt      *time.Time      `json:"locked_at,omitempty"`
+	LastError     *string         `json:"last_error,omitempty"`
+	ProcessedAt   *time.Time      `json:"processed_at,omitempty"`
+	CreatedAt     time.Time       `json:"created_at"`
+}
+
+// AutomationEventRetryDelay returns how long to wait before retrying an event
+// that failed its attempts-th attempt: 30s doubling up to one hour.
+func AutomationEventRetryDelay(attempts int) time.Duration {
+	delay := 30 * time.Second
+	for i := 1; i < attempts && delay < time.Hour; i++ {
+		delay *= 2
+	}
+	if delay > time.Hour {
+		delay = time.Hour
+	}
+	return delay
+}
+
+// AutomationEventListFilter filters the automation event list. Status
+// defaults to dead, the dead-letter list.
+type AutomationEventListFilter struct {
+	Status string
+	PaginationParams
+}
+
+// ParseAutomationEventFilter reads the status query parameter.
+func ParseAutomationEventFilter(q url.Values, pagination PaginationParams) (AutomationEventListFilter, error) {
+	f := AutomationEventListFilter{Status: AutomationEventDead, PaginationParams: pagination}
+	if s := q.Get("status"); s != "" {
+		switch s {
+		case AutomationEventPending, AutomationEventProcessing, AutomationEventDone, AutomationEventDead:
+			f.Status = s
+		default:
+			return f, errors.New("invalid status: expected pending, processing, done or dead")
+		}
+	}
+	return f, nil
+}
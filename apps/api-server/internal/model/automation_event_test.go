@@ -0,0 +1,31 @@
+package model
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAutomationEventRetryDelay(t *testing.T) {
+	assert.Equal(t, 30*time.Second, AutomationEventRetryDelay(1))
+	assert.Equal(t, time.Minute, AutomationEventRetryDelay(2))
+	assert.Equal(t, 4*time.Minute, AutomationEventRetryDelay(4))
+	assert.Equal(t, time.Hour, AutomationEventRetryDelay(AutomationEventMaxAttempts+10))
+}
+
+func TestParseAutomationEventFilter(t *testing.T) {
+	f, err := ParseAutomationEventFilter(url.Values{}, PaginationParams{Limit: 20})
+	require.NoError(t, err)
+	assert.Equal(t, AutomationEventDead, f.Status)
+	assert.Equal(t, 20, f.Limit)
+
+	f, err = ParseAutomationEventFilter(url.Values{"status": {"pending"}}, PaginationParams{})
+	require.NoError(t, err)
+	assert.Equal(t, AutomationEventPending, f.Status)
+
+	_, err = ParseAutomationEventFilter(url.Values{"status": {"failed"}}, PaginationParams{})
+	assert.Error(t, err)
+}
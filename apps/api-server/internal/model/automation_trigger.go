@@ -0,0 +1,147 @@
+package model
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/openoms-org/openoms/apps/api-server/internal/cron"
+)
+
+// Time-based trigger events. They are not fired by services but evaluated by
+// AutomationScheduleWorker.
+const (
+	// TriggerSchedule fires the rule once per run of its cron schedule.
+	TriggerSchedule = "schedule"
+	// Status duration triggers fire once per entity that has stayed in
+	// trigger_config.status for at least trigger_config.duration.
+	TriggerOrderStatusDuration    = "order.status_duration"
+	TriggerShipmentStatusDuration = "shipment.status_duration"
+	TriggerReturnStatusDuration   = "return.status_duration"
+)
+
+// StatusDurationEntities maps status duration triggers to their entity type.
+var StatusDurationEntities = map[string]string{
+	TriggerOrderStatusDuration:    "order",
+	TriggerShipmentStatusDuration: "shipment",
+	TriggerReturnStatusDuration:   "return",
+}
+
+// minScheduleInterval keeps scheduled rules from firing every minute.
+const minScheduleInterval = 5 * time.Minute
+
+// AutomationTriggerConfig configures time-based triggers: Schedule and
+// Timezone for "schedule", Status and Duration for the status duration
+// triggers.
+type AutomationTriggerConfig struct {
+	Schedule string `json:"schedule,omitempty"`
+	Timezone string `json:"timezone,omitempty"`
+	Status   string `json:"status,omitempty"`
+	Duration string `json:"duration,omitempty"`
+}
+
+// IsTimeTrigger reports whether event is evaluated by the schedule worker
+// rather than fired by a service.
+func IsTimeTrigger(event string) bool {
+	return event == TriggerSchedule || StatusDurationEntities[event] != ""
+}
+
+// ParseTriggerConfig reads a rule's trigger_config; empty means no config.
+func ParseTriggerConfig(raw json.RawMessage) (AutomationTriggerConfig, error) {
+	var cfg AutomationTriggerConfig
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 || bytes.Equal(raw, []byte("null")) {
+		return cfg, nil
+	}
+	if err := json.Unmarshal(raw, &cfg); err != nil {
+		return cfg, errors.New("trigger_config must be an object")
+	}
+	return cfg, nil
+}
+
+// Validate checks the config required by event.
+func (c AutomationTriggerConfig) Validate(event string) error {
+	switch {
+	case event == TriggerSchedule:
+		if strings.TrimSpace(c.Schedule) == "" {
+			return errors.New("trigger_config.schedule is required")
+		}
+		schedule, err := cron.Parse(c.Schedule)
+		if err != nil {
+			return fmt.Errorf("trigger_config.schedule: %w", err)
+		}
+		if c.Timezone != "" {
+			if _, err := time.LoadLocation(c.Timezone); err != nil {
+				return errors.New("trigger_config.timezone is not a valid timezone")
+			}
+		}
+		first := schedule.Next(time.Now())
+		if !first.IsZero() && schedule.Next(first).Sub(first) < minScheduleInterval {
+			return fmt.Errorf("trigger_config.schedule may run at most every %d minutes", int(minScheduleInterval.Minutes()))
+		}
+	case StatusDurationEntities[event] != "":
+		if strings.TrimSpace(c.Status) == "" {
+			return errors.New("trigger_config.status is required")
+		}
+		if err := validateMaxLength("trigger_config.status", c.Status, 50); err != nil {
+			return err
+		}
+		d, err := ParseAge(c.Duration)
+		if err != nil {
+			return fmt.Errorf("trigger_config.duration: %w", err)
+		}
+		if d < time.Minute {
+			return errors.New("trigger_config.duration must be at least 1m")
+		}
+	}
+	return nil
+}
+
+// NextRun returns the first run of a schedule trigger after t, or nil when it
+// never fires.
+func (c AutomationTriggerConfig) NextRun(t time.Time) (*time.Time, error) {
+	schedule, err := cron.Parse(c.Schedule)
+	if err != nil {
+		return nil, err
+	}
+	next := schedule.Next(t.In(ReportLocation(c.Timezone)))
+	if next.IsZero() {
+		return nil, nil
+	}
+	next = next.UTC()
+	return &next, nil
+}
+
+// ParseAge parses a duration written as a whole number followed by m, h, d or
+// w ("48h", "7d"), or as a Go duration ("1h30m").
+func ParseAge(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, errors.New(`age must be a string such as "48h" or "7d"`)
+	}
+	units := map[byte]time.Duration{'m': time.Minute, 'h': time.Hour, 'd': 24 * time.Hour, 'w': 7 * 24 * time.Hour}
+	if unit, ok := units[s[len(s)-1]]; ok {
+		if n, err := strconv.Atoi(s[:len(s)-1]); err == nil && n >= 0 {
+			return time.Duration(n) * unit, nil
+		}
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		return 0, errors.New(`age must be a string such as "48h" or "7d"`)
+	}
+	return d, nil
+}
+
+// AutomationStatusEntity is an entity found by a status duration trigger,
+// with the data its rule is evaluated against.
+type AutomationStatusEntity struct {
+	EntityID        uuid.UUID
+	StatusChangedAt time.Time
+	Data            map[string]any
+}
@@ -0,0 +1,93 @@
+package model
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAutomationTriggerConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		event   string
+		cfg     AutomationTriggerConfig
+		wantErr string
+	}{
+		{"event trigger needs no config", "order.created", AutomationTriggerConfig{}, ""},
+		{"daily schedule", TriggerSchedule, AutomationTriggerConfig{Schedule: "0 8 * * *", Timezone: "Europe/Warsaw"}, ""},
+		{"missing schedule", TriggerSchedule, AutomationTriggerConfig{}, "trigger_config.schedule is required"},
+		{"invalid schedule", TriggerSchedule, AutomationTriggerConfig{Schedule: "every day"}, "trigger_config.schedule"},
+		{"too frequent", TriggerSchedule, AutomationTriggerConfig{Schedule: "* * * * *"}, "at most every 5 minutes"},
+		{"invalid timezone", TriggerSchedule, AutomationTriggerConfig{Schedule: "@daily", Timezone: "Mars/Base"}, "timezone"},
+		{"unpaid for 3 days", TriggerOrderStatusDuration, AutomationTriggerConfig{Status: "new", Duration: "3d"}, ""},
+		{"missing status", TriggerShipmentStatusDuration, AutomationTriggerConfig{Duration: "24h"}, "trigger_config.status is required"},
+		{"invalid duration", TriggerReturnStatusDuration, AutomationTriggerConfig{Status: "requested", Duration: "soon"}, "trigger_config.duration"},
+		{"duration too short", TriggerOrderStatusDuration, AutomationTriggerConfig{Status: "new", Duration: "30s"}, "at least 1m"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate(tt.event)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestAutomationTriggerConfig_NextRun(t *testing.T) {
+	cfg := AutomationTriggerConfig{Schedule: "0 8 * * *", Timezone: "Europe/Warsaw"}
+	next, err := cfg.NextRun(time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.NotNil(t, next)
+	// 08:00 in Warsaw (CET) is 07:00 UTC.
+	assert.Equal(t, time.Date(2026, 3, 11, 7, 0, 0, 0, time.UTC), *next)
+}
+
+func TestParseTriggerConfig(t *testing.T) {
+	cfg, err := ParseTriggerConfig(nil)
+	require.NoError(t, err)
+	assert.Equal(t, AutomationTriggerConfig{}, cfg)
+
+	cfg, err = ParseTriggerConfig(json.RawMessage(`{"status": "ready_to_ship", "duration": "24h"}`))
+	require.NoError(t, err)
+	assert.Equal(t, "ready_to_ship", cfg.Status)
+	assert.Equal(t, "24h", cfg.Duration)
+
+	_, err = ParseTriggerConfig(json.RawMessage(`["24h"]`))
+	assert.Error(t, err)
+}
+
+func TestCreateAutomationRuleRequest_ValidateTrigger(t *testing.T) {
+	req := CreateAutomationRuleRequest{
+		Name:          "Anuluj nieopłacone",
+		TriggerEvent:  TriggerOrderStatusDuration,
+		TriggerConfig: json.RawMessage(`{"status": "new", "duration": "3d"}`),
+	}
+	require.NoError(t, req.Validate())
+
+	req.TriggerConfig = nil
+	assert.Error(t, req.Validate())
+
+	req = CreateAutomationRuleRequest{Name: "Nowe", TriggerEvent: "order.created"}
+	require.NoError(t, req.Validate())
+	assert.JSONEq(t, `{}`, string(req.TriggerConfig))
+}
+
+func TestParseAge(t *testing.T) {
+	d, err := ParseAge("3d")
+	require.NoError(t, err)
+	assert.Equal(t, 72*time.Hour, d)
+
+	d, err = ParseAge("1h30m")
+	require.NoError(t, err)
+	assert.Equal(t, 90*time.Minute, d)
+
+	_, err = ParseAge("-1d")
+	assert.Error(t, err)
+}
@@ -0,0 +1,182 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// SyncJobTypeBulkOrders is the job type of asynchronous bulk order actions.
+const SyncJobTypeBulkOrders = "bulk_orders"
+
+// MaxBulkJobOrders bounds the orders a single bulk job may touch.
+const MaxBulkJobOrders = 1000
+
+// Bulk job actions.
+const (
+	BulkActionStatus       = "status"
+	BulkActionTags         = "tags"
+	BulkActionLabels       = "labels"
+	BulkActionInvoices     = "invoices"
+	BulkActionPackingSlips = "packing_slips"
+	BulkActionLabelPDF     = "label_pdf"
+)
+
+var validBulkActions = []string{
+	BulkActionStatus, BulkActionTags, BulkActionLabels,
+	BulkActionInvoices, BulkActionPackingSlips, BulkActionLabelPDF,
+}
+
+// CreateBulkJobRequest queues a bulk action on orders selected either by ID
+// or by an order filter. Only the parameters of the chosen action are read.
+type CreateBulkJobRequest struct {
+	Action   string            `json:"action"`
+	OrderIDs []uuid.UUID       `json:"order_ids,omitempty"`
+	Filter   OrderFilterValues `json:"filter,omitempty"`
+
+	// status
+	Status string `json:"status,omitempty"`
+	Force  bool   `json:"force,omitempty"`
+	// tags
+	AddTags    []string `json:"add_tags,omitempty"`
+	RemoveTags []string `json:"remove_tags,omitempty"`
+	// labels
+	Label *BulkLabelParams `json:"label,omitempty"`
+	// invoices
+	Invoice *BulkInvoiceParams `json:"invoice,omitempty"`
+}
+
+// BulkLabelParams generates labels with one carrier. Orders without a
+// shipment of that carrier in status "created" get a new shipment; an empty
+// target point is taken from the order's pickup point.
+type BulkLabelParams struct {
+	Provider string `json:"provider"`
+	GenerateLabelRequest
+}
+
+// BulkInvoiceParams issues invoices; the provider defaults to the one in the
+// invoicing settings.
+type BulkInvoiceParams struct {
+	Provider      string `json:"provider,omitempty"`
+	InvoiceType   string `json:"invoice_type,omitempty"`
+	PaymentMethod string `json:"payment_method,omitempty"`
+}
+
+func (r *CreateBulkJobRequest) Validate() error {
+	if !slices.Contains(validBulkActions, r.Action) {
+		return fmt.Errorf("action must be one of: %s", strings.Join(validBulkActions, ", "))
+	}
+	if len(r.OrderIDs) > 0 && len(r.Filter) > 0 {
+		return errors.New("order_ids and filter are mutually exclusive")
+	}
+	if len(r.Filter) > 0 {
+		if _, err := ParseOrderFilter(r.Filter.Values()); err != nil {
+			return err
+		}
+	} else if len(r.OrderIDs) == 0 {
+		return errors.New("at least one order_id is required")
+	}
+	if len(r.OrderIDs) > MaxBulkJobOrders {
+		return fmt.Errorf("maximum %d orders per bulk job", MaxBulkJobOrders)
+	}
+
+	switch r.Action {
+	case BulkActionStatus:
+		if strings.TrimSpace(r.Status) == "" {
+			return errors.New("status is required")
+		}
+	case BulkActionTags:
+		r.AddTags = normalizeTags(r.AddTags)
+		r.RemoveTags = normalizeTags(r.RemoveTags)
+		if len(r.AddTags) == 0 && len(r.RemoveTags) == 0 {
+			return errors.New("add_tags or remove_tags is required")
+		}
+		for _, tag := range append(slices.Clone(r.AddTags), r.RemoveTags...) {
+			if err := validateMaxLength("tag", tag, 100); err != nil {
+				return err
+			}
+		}
+	case BulkActionLabels:
+		if r.Label == nil || strings.TrimSpace(r.Label.Provider) == "" {
+			return errors.New("label.provider is required")
+		}
+		// Lockers come from each order's pickup point, so the target point is
+		// only checked when the label is generated.
+		check := r.Label.GenerateLabelRequest
+		if check.TargetPoint == "" {
+			check.TargetPoint = "-"
+		}
+		if err := check.Validate(); err != nil {
+			return fmt.Errorf("label: %w", err)
+		}
+		r.Label.LabelFormat = check.LabelFormat
+	case BulkActionInvoices:
+		if r.Invoice == nil {
+			r.Invoice = &BulkInvoiceParams{}
+		}
+		if r.Invoice.InvoiceType == "" {
+			r.Invoice.InvoiceType = "vat"
+		}
+	}
+	return nil
+}
+
+func normalizeTags(tags []string) []string {
+	var out []string
+	for _, tag := range tags {
+		if tag = strings.TrimSpace(tag); tag != "" && !slices.Contains(out, tag) {
+			out = append(out, tag)
+		}
+	}
+	return out
+}
+
+// ApplyTags returns tags with the added tags appended and the removed ones
+// dropped, keeping the existing order.
+func ApplyTags(tags, add, remove []string) []string {
+	out := make([]string, 0, len(tags)+len(add))
+	for _, tag := range append(slices.Clone(tags), add...) {
+		if !slices.Contains(remove, tag) && !slices.Contains(out, tag) {
+			out = append(out, tag)
+		}
+	}
+	return out
+}
+
+// BulkJobMetadata is stored as the metadata of a bulk order sync job. The
+// request keeps the resolved order IDs, never the filter, so a job acts on
+// the orders that matched when it was queued.
+type BulkJobMetadata struct {
+	Request   CreateBulkJobRequest `json:"request"`
+	CreatedBy uuid.UUID            `json:"created_by"`
+	IPAddress string               `json:"ip_address,omitempty"`
+	Total     int                  `json:"total"`
+	Results   []BulkJobItemResult  `json:"results"`
+	FileURL   string               `json:"file_url,omitempty"`
+}
+
+// BulkJobItemResult is the outcome of a bulk action for one order. Skipped
+// orders needed no work, e.g. they already had an invoice.
+type BulkJobItemResult struct {
+	OrderID        uuid.UUID  `json:"order_id"`
+	Success        bool       `json:"success"`
+	Skipped        bool       `json:"skipped,omitempty"`
+	Error          string     `json:"error,omitempty"`
+	ShipmentID     *uuid.UUID `json:"shipment_id,omitempty"`
+	TrackingNumber string     `json:"tracking_number,omitempty"`
+	InvoiceID      *uuid.UUID `json:"invoice_id,omitempty"`
+}
+
+// BulkJobEvent is pushed over the WebSocket hub while a bulk job runs.
+type BulkJobEvent struct {
+	JobID     uuid.UUID `json:"job_id"`
+	Action    string    `json:"action"`
+	Status    string    `json:"status"`
+	Total     int       `json:"total"`
+	Processed int       `json:"processed"`
+	Failed    int       `json:"failed"`
+	FileURL   string    `json:"file_url,omitempty"`
+}
@@ -0,0 +1,47 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateBulkJobRequest_Validate(t *testing.T) {
+	ids := []uuid.UUID{uuid.New()}
+
+	assert.ErrorContains(t, (&CreateBulkJobRequest{Action: "delete", OrderIDs: ids}).Validate(), "action")
+	assert.ErrorContains(t, (&CreateBulkJobRequest{Action: BulkActionPackingSlips}).Validate(), "order_id")
+	assert.ErrorContains(t, (&CreateBulkJobRequest{Action: BulkActionPackingSlips, OrderIDs: ids, Filter: OrderFilterValues{"status": "new"}}).Validate(), "mutually exclusive")
+	assert.ErrorContains(t, (&CreateBulkJobRequest{Action: BulkActionPackingSlips, Filter: OrderFilterValues{"amount_min": "x"}}).Validate(), "amount_min")
+	assert.NoError(t, (&CreateBulkJobRequest{Action: BulkActionPackingSlips, Filter: OrderFilterValues{"status": "confirmed"}}).Validate())
+	assert.ErrorContains(t, (&CreateBulkJobRequest{Action: BulkActionLabelPDF, OrderIDs: make([]uuid.UUID, MaxBulkJobOrders+1)}).Validate(), "maximum")
+
+	assert.ErrorContains(t, (&CreateBulkJobRequest{Action: BulkActionStatus, OrderIDs: ids}).Validate(), "status")
+	assert.NoError(t, (&CreateBulkJobRequest{Action: BulkActionStatus, OrderIDs: ids, Status: "shipped"}).Validate())
+
+	tags := CreateBulkJobRequest{Action: BulkActionTags, OrderIDs: ids, AddTags: []string{" vip ", "vip", ""}}
+	require.NoError(t, tags.Validate())
+	assert.Equal(t, []string{"vip"}, tags.AddTags)
+	assert.ErrorContains(t, (&CreateBulkJobRequest{Action: BulkActionTags, OrderIDs: ids, AddTags: []string{" "}}).Validate(), "add_tags")
+
+	assert.ErrorContains(t, (&CreateBulkJobRequest{Action: BulkActionLabels, OrderIDs: ids}).Validate(), "label.provider")
+	assert.ErrorContains(t, (&CreateBulkJobRequest{Action: BulkActionLabels, OrderIDs: ids, Label: &BulkLabelParams{Provider: "inpost"}}).Validate(), "service_type")
+	label := CreateBulkJobRequest{Action: BulkActionLabels, OrderIDs: ids, Label: &BulkLabelParams{Provider: "dpd", GenerateLabelRequest: GenerateLabelRequest{ServiceType: "classic"}}}
+	require.NoError(t, label.Validate())
+	assert.Equal(t, "pdf", label.Label.LabelFormat)
+	// Locker labels take the target point from each order.
+	locker := CreateBulkJobRequest{Action: BulkActionLabels, OrderIDs: ids, Label: &BulkLabelParams{Provider: "inpost", GenerateLabelRequest: GenerateLabelRequest{ServiceType: "inpost_locker_standard"}}}
+	require.NoError(t, locker.Validate())
+	assert.Empty(t, locker.Label.TargetPoint)
+
+	invoice := CreateBulkJobRequest{Action: BulkActionInvoices, OrderIDs: ids}
+	require.NoError(t, invoice.Validate())
+	assert.Equal(t, "vat", invoice.Invoice.InvoiceType)
+}
+
+func TestApplyTags(t *testing.T) {
+	assert.Equal(t, []string{"a", "c", "d"}, ApplyTags([]string{"a", "b", "c"}, []string{"c", "d"}, []string{"b"}))
+	assert.Equal(t, []string{}, ApplyTags(nil, nil, []string{"x"}))
+}
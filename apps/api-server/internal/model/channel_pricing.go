@@ -0,0 +1,145 @@
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// ChannelPricingRule is the per-integration pricing rule stored under the
+// "pricing" key of the integration settings. Product prices are kept in PLN;
+// the rule turns them into the price pushed to the sales channel.
+type ChannelPricingRule struct {
+	Enabled bool `json:"enabled"`
+	// Base is "product" (product or variant price) or "price_list".
+	Base        string     `json:"base,omitempty"`
+	PriceListID *uuid.UUID `json:"price_list_id,omitempty"`
+	// MarkupType is "percent" or "fixed"; a fixed markup is in PLN.
+	MarkupType  string  `json:"markup_type,omitempty"`
+	MarkupValue float64 `json:"markup_value,omitempty"`
+	// CompensateCommission grosses the price up so that the marketplace
+	// commission is paid by the buyer. CommissionPercent overrides the rate
+	// reported by the marketplace.
+	CompensateCommission bool     `json:"compensate_commission,omitempty"`
+	CommissionPercent    *float64 `json:"commission_percent,omitempty"`
+	// Currency is the channel currency; prices are converted from PLN.
+	Currency string `json:"currency,omitempty"`
+	// Rounding is "none", "x99" (19.99) or "whole" (20.00).
+	Rounding string   `json:"rounding,omitempty"`
+	MinPrice *float64 `json:"min_price,omitempty"`
+}
+
+// ParseChannelPricingRule reads the pricing rule from integration settings.
+// It returns nil when the settings have no "pricing" key.
+func ParseChannelPricingRule(settings json.RawMessage) (*ChannelPricingRule, error) {
+	if len(settings) == 0 {
+		return nil, nil
+	}
+	var wrapper struct {
+		Pricing *ChannelPricingRule `json:"pricing"`
+	}
+	if err := json.Unmarshal(settings, &wrapper); err != nil {
+		return nil, fmt.Errorf("parse pricing settings: %w", err)
+	}
+	if wrapper.Pricing == nil {
+		return nil, nil
+	}
+	if err := wrapper.Pricing.Validate(); err != nil {
+		return nil, err
+	}
+	return wrapper.Pricing, nil
+}
+
+func (r *ChannelPricingRule) Validate() error {
+	switch r.Base {
+	case "":
+		r.Base = "product"
+	case "product":
+		// valid
+	case "price_list":
+		if r.PriceListID == nil {
+			return errors.New("pricing.price_list_id is required when base is price_list")
+		}
+	default:
+		return errors.New("pricing.base must be one of: product, price_list")
+	}
+	switch r.MarkupType {
+	case "":
+		r.MarkupType = "percent"
+	case "percent", "fixed":
+		// valid
+	default:
+		return errors.New("pricing.markup_type must be one of: percent, fixed")
+	}
+	if r.MarkupType == "percent" && r.MarkupValue <= -100 {
+		return errors.New("pricing.markup_value must be greater than -100 for percent markup")
+	}
+	if r.CommissionPercent != nil && (*r.CommissionPercent < 0 || *r.CommissionPercent >= 100) {
+		return errors.New("pricing.commission_percent must be between 0 and 100")
+	}
+	r.Currency = strings.ToUpper(strings.TrimSpace(r.Currency))
+	if r.Currency == "" {
+		r.Currency = "PLN"
+	}
+	if len(r.Currency) != 3 {
+		return errors.New("pricing.currency must be a 3-letter ISO code")
+	}
+	switch r.Rounding {
+	case "":
+		r.Rounding = "none"
+	case "none", "x99", "whole":
+		// valid
+	default:
+		return errors.New("pricing.rounding must be one of: none, x99, whole")
+	}
+	if r.MinPrice != nil && *r.MinPrice < 0 {
+		return errors.New("pricing.min_price must not be negative")
+	}
+	return nil
+}
+
+// Apply computes the channel price from a base price in PLN. commissionRate is
+// a fraction (0.1 for 10%) and is used only when CompensateCommission is set;
+// fxRate converts PLN into the channel currency.
+func (r *ChannelPricingRule) Apply(base, commissionRate, fxRate float64) float64 {
+	price := base
+	switch r.MarkupType {
+	case "fixed":
+		price += r.MarkupValue
+	default:
+		price *= 1 + r.MarkupValue/100
+	}
+
+	price *= fxRate
+
+	if r.CompensateCommission {
+		if r.CommissionPercent != nil {
+			commissionRate = *r.CommissionPercent / 100
+		}
+		if commissionRate > 0 && commissionRate < 1 {
+			price /= 1 - commissionRate
+		}
+	}
+
+	switch r.Rounding {
+	case "x99":
+		// Small epsilon so that 19.99 stays 19.99 instead of jumping to 20.99.
+		price = math.Ceil(price-0.005) - 0.01
+	case "whole":
+		price = math.Round(price)
+	default:
+		price = math.Round(price*100) / 100
+	}
+
+	if r.MinPrice != nil && price < *r.MinPrice {
+		price = *r.MinPrice
+	}
+	if price < 0 {
+		price = 0
+	}
+	return math.Round(price*100) / 100
+}
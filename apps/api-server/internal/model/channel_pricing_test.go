@@ -0,0 +1,118 @@
+package model
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ptrFloat(v float64) *float64 { return &v }
+
+func TestParseChannelPricingRule(t *testing.T) {
+	t.Run("no pricing key", func(t *testing.T) {
+		rule, err := ParseChannelPricingRule(json.RawMessage(`{"webhook_secret":"x"}`))
+		require.NoError(t, err)
+		assert.Nil(t, rule)
+	})
+
+	t.Run("defaults", func(t *testing.T) {
+		rule, err := ParseChannelPricingRule(json.RawMessage(`{"pricing":{"enabled":true,"markup_value":10,"currency":"eur"}}`))
+		require.NoError(t, err)
+		require.NotNil(t, rule)
+		assert.Equal(t, "product", rule.Base)
+		assert.Equal(t, "percent", rule.MarkupType)
+		assert.Equal(t, "EUR", rule.Currency)
+		assert.Equal(t, "none", rule.Rounding)
+	})
+
+	tests := []struct {
+		name     string
+		settings string
+		wantErr  string
+	}{
+		{"price list without id", `{"pricing":{"base":"price_list"}}`, "pricing.price_list_id is required when base is price_list"},
+		{"invalid base", `{"pricing":{"base":"cost"}}`, "pricing.base must be one of: product, price_list"},
+		{"invalid markup type", `{"pricing":{"markup_type":"double"}}`, "pricing.markup_type must be one of: percent, fixed"},
+		{"percent markup too low", `{"pricing":{"markup_value":-100}}`, "pricing.markup_value must be greater than -100 for percent markup"},
+		{"commission out of range", `{"pricing":{"commission_percent":100}}`, "pricing.commission_percent must be between 0 and 100"},
+		{"invalid currency", `{"pricing":{"currency":"EURO"}}`, "pricing.currency must be a 3-letter ISO code"},
+		{"invalid rounding", `{"pricing":{"rounding":"x95"}}`, "pricing.rounding must be one of: none, x99, whole"},
+		{"negative min price", `{"pricing":{"min_price":-1}}`, "pricing.min_price must not be negative"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseChannelPricingRule(json.RawMessage(tt.settings))
+			require.Error(t, err)
+			assert.Equal(t, tt.wantErr, err.Error())
+		})
+	}
+}
+
+func TestChannelPricingRule_Apply(t *testing.T) {
+	tests := []struct {
+		name       string
+		rule       ChannelPricingRule
+		base       float64
+		commission float64
+		fx         float64
+		want       float64
+	}{
+		{
+			name: "percent markup",
+			rule: ChannelPricingRule{MarkupType: "percent", MarkupValue: 20, Rounding: "none"},
+			base: 100, fx: 1, want: 120,
+		},
+		{
+			name: "fixed markup",
+			rule: ChannelPricingRule{MarkupType: "fixed", MarkupValue: 5.5, Rounding: "none"},
+			base: 100, fx: 1, want: 105.5,
+		},
+		{
+			name: "commission compensation",
+			rule: ChannelPricingRule{MarkupType: "percent", CompensateCommission: true, Rounding: "none"},
+			base: 90, commission: 0.1, fx: 1, want: 100,
+		},
+		{
+			name: "commission percent overrides marketplace rate",
+			rule: ChannelPricingRule{MarkupType: "percent", CompensateCommission: true, CommissionPercent: ptrFloat(20), Rounding: "none"},
+			base: 80, commission: 0.1, fx: 1, want: 100,
+		},
+		{
+			name: "commission ignored when not compensating",
+			rule: ChannelPricingRule{MarkupType: "percent", Rounding: "none"},
+			base: 90, commission: 0.1, fx: 1, want: 90,
+		},
+		{
+			name: "currency conversion",
+			rule: ChannelPricingRule{MarkupType: "percent", Rounding: "none"},
+			base: 100, fx: 0.2315, want: 23.15,
+		},
+		{
+			name: "x99 rounding up",
+			rule: ChannelPricingRule{MarkupType: "percent", Rounding: "x99"},
+			base: 18.2, fx: 1, want: 18.99,
+		},
+		{
+			name: "x99 keeps x99 price",
+			rule: ChannelPricingRule{MarkupType: "percent", Rounding: "x99"},
+			base: 19.99, fx: 1, want: 19.99,
+		},
+		{
+			name: "whole rounding",
+			rule: ChannelPricingRule{MarkupType: "percent", Rounding: "whole"},
+			base: 19.49, fx: 1, want: 19,
+		},
+		{
+			name: "min price floor",
+			rule: ChannelPricingRule{MarkupType: "percent", MarkupValue: -50, Rounding: "none", MinPrice: ptrFloat(60)},
+			base: 100, fx: 1, want: 60,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.want, tt.rule.Apply(tt.base, tt.commission, tt.fx), 0.001)
+		})
+	}
+}
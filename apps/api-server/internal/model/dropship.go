@@ -0,0 +1,240 @@
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/mail"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Fulfilment modes of a product.
+const (
+	// FulfillmentOwnStock ships the product from own stock only.
+	FulfillmentOwnStock = "own_stock"
+	// FulfillmentStockFirst ships from own stock and falls back to a supplier
+	// when the stock does not cover the ordered quantity.
+	FulfillmentStockFirst = "stock_first"
+	// FulfillmentDropship always orders the product from a supplier.
+	FulfillmentDropship = "dropship"
+)
+
+var validFulfillmentModes = map[string]bool{
+	FulfillmentOwnStock:   true,
+	FulfillmentStockFirst: true,
+	FulfillmentDropship:   true,
+}
+
+// FulfillmentRule decides how a product is fulfilled. Products without a rule
+// ship from own stock.
+type FulfillmentRule struct {
+	ProductID uuid.UUID `json:"product_id"`
+	TenantID  uuid.UUID `json:"tenant_id"`
+	Mode      string    `json:"mode"`
+	// SupplierID pins the supplier. When nil, the cheapest linked supplier
+	// with enough stock is chosen.
+	SupplierID *uuid.UUID `json:"supplier_id,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+type SetFulfillmentRuleRequest struct {
+	Mode       string     `json:"mode"`
+	SupplierID *uuid.UUID `json:"supplier_id,omitempty"`
+}
+
+func (r *SetFulfillmentRuleRequest) Validate() error {
+	if !validFulfillmentModes[r.Mode] {
+		return errors.New("mode must be one of: own_stock, stock_first, dropship")
+	}
+	if r.Mode == FulfillmentOwnStock && r.SupplierID != nil {
+		return errors.New("supplier_id is not used with mode own_stock")
+	}
+	return nil
+}
+
+// DropshipSettings is the "dropshipping" section of the tenant settings.
+type DropshipSettings struct {
+	Enabled bool `json:"enabled"`
+	// RouteStatuses lists the order statuses in which orders are routed to
+	// suppliers; defaults to confirmed.
+	RouteStatuses []string `json:"route_statuses,omitempty"`
+}
+
+func (s DropshipSettings) Validate() error {
+	for _, status := range s.RouteStatuses {
+		if strings.TrimSpace(status) == "" {
+			return errors.New("route_statuses must not contain empty statuses")
+		}
+	}
+	return nil
+}
+
+// Statuses returns the order statuses that trigger routing.
+func (s DropshipSettings) Statuses() []string {
+	if len(s.RouteStatuses) == 0 {
+		return []string{"confirmed"}
+	}
+	return s.RouteStatuses
+}
+
+// Channels a supplier receives orders on.
+const (
+	SupplierOrderChannelEmail   = "email"
+	SupplierOrderChannelWebhook = "webhook"
+)
+
+// SupplierDropshipSettings is the "dropship" section of Supplier.Settings:
+// how purchase orders are sent to the supplier.
+type SupplierDropshipSettings struct {
+	Channel string `json:"channel"`
+	Email   string `json:"email,omitempty"`
+	// Attachment is the format of the order attached to the email: pdf or csv.
+	Attachment    string `json:"attachment,omitempty"`
+	WebhookURL    string `json:"webhook_url,omitempty"`
+	WebhookSecret string `json:"webhook_secret,omitempty"`
+}
+
+// ParseSupplierDropshipSettings reads the "dropship" section of the supplier settings.
+func ParseSupplierDropshipSettings(settings json.RawMessage) (SupplierDropshipSettings, error) {
+	var s struct {
+		Dropship SupplierDropshipSettings `json:"dropship"`
+	}
+	if len(settings) == 0 {
+		return s.Dropship, nil
+	}
+	if err := json.Unmarshal(settings, &s); err != nil {
+		return s.Dropship, fmt.Errorf("invalid dropship settings: %w", err)
+	}
+	return s.Dropship, nil
+}
+
+// Configured reports whether the supplier can receive orders.
+func (s SupplierDropshipSettings) Configured() bool {
+	return s.Channel != ""
+}
+
+func (s SupplierDropshipSettings) Validate() error {
+	switch s.Channel {
+	case "":
+		return nil
+	case SupplierOrderChannelEmail:
+		if _, err := mail.ParseAddress(s.Email); err != nil {
+			return errors.New("dropship.email must be a valid email address")
+		}
+		switch s.Attachment {
+		case "", "pdf", "csv":
+		default:
+			return errors.New("dropship.attachment must be one of: pdf, csv")
+		}
+	case SupplierOrderChannelWebhook:
+		u, err := url.Parse(s.WebhookURL)
+		if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			return errors.New("dropship.webhook_url must be an http(s) URL")
+		}
+	default:
+		return errors.New("dropship.channel must be one of: email, webhook")
+	}
+	return nil
+}
+
+// Supplier order statuses.
+const (
+	SupplierOrderPending   = "pending"
+	SupplierOrderSent      = "sent"
+	SupplierOrderFailed    = "failed"
+	SupplierOrderShipped   = "shipped"
+	SupplierOrderCancelled = "cancelled"
+)
+
+// SupplierOrder is the purchase order sent to a supplier for the dropship
+// lines of a customer order.
+type SupplierOrder struct {
+	ID             uuid.UUID           `json:"id"`
+	TenantID       uuid.UUID           `json:"tenant_id"`
+	SupplierID     uuid.UUID           `json:"supplier_id"`
+	SupplierName   string              `json:"supplier_name,omitempty"`
+	OrderID        uuid.UUID           `json:"order_id"`
+	Status         string              `json:"status"`
+	Items          []SupplierOrderItem `json:"items"`
+	TotalCost      float64             `json:"total_cost"`
+	Channel        string              `json:"channel"`
+	Attempts       int                 `json:"attempts"`
+	ErrorMessage   *string             `json:"error_message,omitempty"`
+	SentAt         *time.Time          `json:"sent_at,omitempty"`
+	Carrier        *string             `json:"carrier,omitempty"`
+	TrackingNumber *string             `json:"tracking_number,omitempty"`
+	ShipmentID     *uuid.UUID          `json:"shipment_id,omitempty"`
+	ShippedAt      *time.Time          `json:"shipped_at,omitempty"`
+	CallbackToken  string              `json:"-"`
+	CreatedAt      time.Time           `json:"created_at"`
+	UpdatedAt      time.Time           `json:"updated_at"`
+}
+
+// SupplierOrderItem is an order line fulfilled by the supplier.
+type SupplierOrderItem struct {
+	// LineIndex is the position of the line in the customer order items.
+	LineIndex         int        `json:"line_index"`
+	ProductID         uuid.UUID  `json:"product_id"`
+	VariantID         *uuid.UUID `json:"variant_id,omitempty"`
+	SupplierProductID uuid.UUID  `json:"supplier_product_id"`
+	ExternalID        string     `json:"external_id"`
+	Name              string     `json:"name"`
+	SKU               string     `json:"sku,omitempty"`
+	EAN               string     `json:"ean,omitempty"`
+	Quantity          int        `json:"quantity"`
+	UnitCost          float64    `json:"unit_cost"`
+}
+
+type SupplierOrderListFilter struct {
+	Status     *string
+	SupplierID *uuid.UUID
+	OrderID    *uuid.UUID
+	PaginationParams
+}
+
+// SupplierTrackingRequest records the parcel a supplier shipped.
+type SupplierTrackingRequest struct {
+	Carrier        string `json:"carrier"`
+	TrackingNumber string `json:"tracking_number"`
+}
+
+func (r *SupplierTrackingRequest) Validate() error {
+	r.Carrier = strings.ToLower(strings.TrimSpace(r.Carrier))
+	r.TrackingNumber = strings.TrimSpace(r.TrackingNumber)
+	if r.TrackingNumber == "" {
+		return errors.New("tracking_number is required")
+	}
+	if err := validateMaxLength("tracking_number", r.TrackingNumber, 200); err != nil {
+		return err
+	}
+	return validateMaxLength("carrier", r.Carrier, 50)
+}
+
+// DropshipRouting is the outcome of routing an order's lines to suppliers.
+type DropshipRouting struct {
+	OrderID        uuid.UUID       `json:"order_id"`
+	SupplierOrders []SupplierOrder `json:"supplier_orders"`
+	// Warnings lists dropship lines that could not be assigned to a supplier.
+	Warnings []string `json:"warnings"`
+}
+
+// PublicSupplierOrder is the view of a supplier order shown to the supplier
+// through the tokenised link sent with the order.
+type PublicSupplierOrder struct {
+	ID              uuid.UUID           `json:"id"`
+	Status          string              `json:"status"`
+	CompanyName     string              `json:"company_name,omitempty"`
+	Items           []SupplierOrderItem `json:"items"`
+	TotalCost       float64             `json:"total_cost"`
+	CustomerName    string              `json:"customer_name"`
+	CustomerPhone   *string             `json:"customer_phone,omitempty"`
+	ShippingAddress json.RawMessage     `json:"shipping_address,omitempty"`
+	Carrier         *string             `json:"carrier,omitempty"`
+	TrackingNumber  *string             `json:"tracking_number,omitempty"`
+	CreatedAt       time.Time           `json:"created_at"`
+}
@@ -0,0 +1,51 @@
+package model
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetFulfillmentRuleRequest_Validate(t *testing.T) {
+	supplierID := uuid.New()
+
+	assert.NoError(t, (&SetFulfillmentRuleRequest{Mode: FulfillmentStockFirst}).Validate())
+	assert.NoError(t, (&SetFulfillmentRuleRequest{Mode: FulfillmentDropship, SupplierID: &supplierID}).Validate())
+	assert.ErrorContains(t, (&SetFulfillmentRuleRequest{Mode: "later"}).Validate(), "mode")
+	assert.ErrorContains(t, (&SetFulfillmentRuleRequest{Mode: FulfillmentOwnStock, SupplierID: &supplierID}).Validate(), "supplier_id")
+}
+
+func TestSupplierDropshipSettings(t *testing.T) {
+	s, err := ParseSupplierDropshipSettings(json.RawMessage(`{"feed":{},"dropship":{"channel":"email","email":"orders@example.com","attachment":"csv"}}`))
+	require.NoError(t, err)
+	assert.True(t, s.Configured())
+	assert.NoError(t, s.Validate())
+
+	s, err = ParseSupplierDropshipSettings(nil)
+	require.NoError(t, err)
+	assert.False(t, s.Configured())
+	assert.NoError(t, s.Validate())
+
+	assert.Error(t, SupplierDropshipSettings{Channel: "email", Email: "nope"}.Validate())
+	assert.Error(t, SupplierDropshipSettings{Channel: "email", Email: "a@b.pl", Attachment: "xls"}.Validate())
+	assert.Error(t, SupplierDropshipSettings{Channel: "webhook", WebhookURL: "ftp://example.com"}.Validate())
+	assert.NoError(t, SupplierDropshipSettings{Channel: "webhook", WebhookURL: "https://example.com/orders"}.Validate())
+	assert.Error(t, SupplierDropshipSettings{Channel: "fax"}.Validate())
+}
+
+func TestSupplierTrackingRequest_Validate(t *testing.T) {
+	req := SupplierTrackingRequest{Carrier: " InPost ", TrackingNumber: " 123 "}
+	require.NoError(t, req.Validate())
+	assert.Equal(t, "inpost", req.Carrier)
+	assert.Equal(t, "123", req.TrackingNumber)
+
+	assert.ErrorContains(t, (&SupplierTrackingRequest{Carrier: "dhl"}).Validate(), "tracking_number")
+}
+
+func TestDropshipSettings_Statuses(t *testing.T) {
+	assert.Equal(t, []string{"confirmed"}, DropshipSettings{}.Statuses())
+	assert.Equal(t, []string{"paid"}, DropshipSettings{RouteStatuses: []string{"paid"}}.Statuses())
+}
@@ -49,6 +49,9 @@ func (r *CreateIntegrationRequest) Validate() error {
 	if err := validateMaxLengthPtr("label", r.Label, 255); err != nil {
 		return err
 	}
+	if _, err := ParseChannelPricingRule(r.Settings); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -74,5 +77,10 @@ func (r *UpdateIntegrationRequest) Validate() error {
 			return errors.New("status must be one of: active, inactive, error")
 		}
 	}
+	if r.Settings != nil {
+		if _, err := ParseChannelPricingRule(*r.Settings); err != nil {
+			return err
+		}
+	}
 	return nil
 }
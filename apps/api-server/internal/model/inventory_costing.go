@@ -0,0 +1,299 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Inventory costing methods, chosen per tenant in the inventory settings.
+const (
+	CostingMethodFIFO            = "fifo"
+	CostingMethodWeightedAverage = "weighted_average"
+)
+
+var validCostingMethods = []string{CostingMethodFIFO, CostingMethodWeightedAverage}
+
+// COGS report groupings.
+const (
+	COGSByDocument  = "document"
+	COGSByProduct   = "product"
+	COGSByWarehouse = "warehouse"
+)
+
+var validCOGSGroups = []string{COGSByDocument, COGSByProduct, COGSByWarehouse}
+
+// CostLayer is a quantity received into a warehouse at one unit cost. Issues
+// reduce Remaining; layers are consumed oldest first.
+type CostLayer struct {
+	ID          uuid.UUID  `json:"id"`
+	TenantID    uuid.UUID  `json:"tenant_id"`
+	WarehouseID uuid.UUID  `json:"warehouse_id"`
+	ProductID   uuid.UUID  `json:"product_id"`
+	VariantID   *uuid.UUID `json:"variant_id,omitempty"`
+	DocumentID  uuid.UUID  `json:"document_id"`
+	Quantity    int        `json:"quantity"`
+	Remaining   int        `json:"remaining"`
+	UnitCost    float64    `json:"unit_cost"`
+	ReceivedAt  time.Time  `json:"received_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// CostMovement is a costed stock movement of one document line: positive
+// quantity and cost for receipts, negative for issues. Estimated is set when
+// part of the cost was not taken from cost layers but from the product's
+// cost price.
+type CostMovement struct {
+	ID           uuid.UUID  `json:"id"`
+	TenantID     uuid.UUID  `json:"tenant_id"`
+	WarehouseID  uuid.UUID  `json:"warehouse_id"`
+	ProductID    uuid.UUID  `json:"product_id"`
+	VariantID    *uuid.UUID `json:"variant_id,omitempty"`
+	DocumentID   uuid.UUID  `json:"document_id"`
+	DocumentType string     `json:"document_type"`
+	Method       string     `json:"method"`
+	Quantity     int        `json:"quantity"`
+	TotalCost    float64    `json:"total_cost"`
+	Estimated    bool       `json:"estimated"`
+	OccurredAt   time.Time  `json:"occurred_at"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// CostConsumption is the result of issuing stock from cost layers.
+type CostConsumption struct {
+	// Layers are the layers that changed, with their new remaining quantity
+	// and, for weighted average, their new unit cost.
+	Layers []CostLayer
+	// Quantity is the part of the issue covered by layers, at Cost.
+	Quantity int
+	Cost     float64
+	// Shortfall is the quantity the layers could not cover.
+	Shortfall int
+}
+
+// ConsumeCostLayers issues quantity from layers ordered oldest first. FIFO
+// costs each unit at its layer's cost. Weighted average costs every unit at
+// the average cost of all open layers and reprices the layers to it, so the
+// value left in stock stays at that average.
+func ConsumeCostLayers(layers []CostLayer, quantity int, method string) CostConsumption {
+	var result CostConsumption
+	if quantity <= 0 {
+		return result
+	}
+
+	var average float64
+	if method == CostingMethodWeightedAverage {
+		var units int
+		var value float64
+		for _, l := range layers {
+			units += l.Remaining
+			value += float64(l.Remaining) * l.UnitCost
+		}
+		if units > 0 {
+			average = RoundUnitCost(value / float64(units))
+		}
+	}
+
+	left := quantity
+	for _, l := range layers {
+		if l.Remaining <= 0 {
+			continue
+		}
+		changed := false
+		if method == CostingMethodWeightedAverage && l.UnitCost != average {
+			l.UnitCost = average
+			changed = true
+		}
+		if left > 0 {
+			take := min(left, l.Remaining)
+			l.Remaining -= take
+			left -= take
+			result.Quantity += take
+			result.Cost += float64(take) * l.UnitCost
+			changed = true
+		}
+		if changed {
+			result.Layers = append(result.Layers, l)
+		}
+	}
+	result.Cost = RoundUnitCost(result.Cost)
+	result.Shortfall = left
+	return result
+}
+
+// RoundUnitCost rounds to the four decimals stored for unit costs.
+func RoundUnitCost(v float64) float64 {
+	return math.Round(v*10000) / 10000
+}
+
+// InventoryValuationFilter selects the stock valued by the valuation report.
+type InventoryValuationFilter struct {
+	// At is the exclusive end of the valuation: movements before it count.
+	At          time.Time
+	WarehouseID *uuid.UUID
+}
+
+// ParseInventoryValuationFilter parses the query parameters of the
+// valuation report:
+//
+//	at            date (YYYY-MM-DD, valued at the end of the day) or RFC 3339; default now
+//	warehouse_id  restrict to one warehouse
+func ParseInventoryValuationFilter(q url.Values, now time.Time) (InventoryValuationFilter, error) {
+	f := InventoryValuationFilter{At: now}
+	if s := q.Get("at"); s != "" {
+		t, err := parseAtParam(s)
+		if err != nil {
+			return f, errors.New("invalid at: expected YYYY-MM-DD or RFC 3339")
+		}
+		f.At = t
+	}
+	id, err := parseWarehouseParam(q)
+	if err != nil {
+		return f, err
+	}
+	f.WarehouseID = id
+	return f, nil
+}
+
+// InventoryValuationRow is the stock of one product or variant in one
+// warehouse, valued at its cost layers.
+type InventoryValuationRow struct {
+	WarehouseID   uuid.UUID  `json:"warehouse_id"`
+	WarehouseName string     `json:"warehouse_name"`
+	ProductID     uuid.UUID  `json:"product_id"`
+	VariantID     *uuid.UUID `json:"variant_id,omitempty"`
+	SKU           string     `json:"sku"`
+	Name          string     `json:"name"`
+	Quantity      int        `json:"quantity"`
+	Value         float64    `json:"value"`
+	UnitCost      *float64   `json:"unit_cost"`
+}
+
+// InventoryValuationTotal is the stock value of one warehouse.
+type InventoryValuationTotal struct {
+	WarehouseID   uuid.UUID `json:"warehouse_id"`
+	WarehouseName string    `json:"warehouse_name"`
+	Quantity      int       `json:"quantity"`
+	Value         float64   `json:"value"`
+}
+
+// InventoryValuationReport is the stock value at a point in time returned by
+// GET /v1/inventory/valuation.
+type InventoryValuationReport struct {
+	At         time.Time                 `json:"at"`
+	Method     string                    `json:"method"`
+	Rows       []InventoryValuationRow   `json:"rows"`
+	Warehouses []InventoryValuationTotal `json:"warehouses"`
+	Quantity   int                       `json:"quantity"`
+	Value      float64                   `json:"value"`
+}
+
+// COGSFilter selects the WZ issues of the cost of goods sold report.
+type COGSFilter struct {
+	GroupBy     string
+	From        *time.Time
+	To          *time.Time
+	WarehouseID *uuid.UUID
+	Limit       int
+}
+
+// ParseCOGSFilter parses the query parameters of the COGS report:
+//
+//	group_by      document (default), product or warehouse
+//	from, to      confirmation date (YYYY-MM-DD or RFC 3339), to is inclusive for dates
+//	warehouse_id  restrict to one warehouse
+//	limit         rows, default 500
+func ParseCOGSFilter(q url.Values) (COGSFilter, error) {
+	f := COGSFilter{GroupBy: COGSByDocument, Limit: 500}
+	if s := q.Get("group_by"); s != "" {
+		if !slices.Contains(validCOGSGroups, s) {
+			return f, fmt.Errorf("group_by must be one of: %s", strings.Join(validCOGSGroups, ", "))
+		}
+		f.GroupBy = s
+	}
+	if s := q.Get("from"); s != "" {
+		t, _, err := parseFilterTime(s)
+		if err != nil {
+			return f, errors.New("invalid from: expected YYYY-MM-DD or RFC 3339")
+		}
+		f.From = &t
+	}
+	if s := q.Get("to"); s != "" {
+		t, err := parseAtParam(s)
+		if err != nil {
+			return f, errors.New("invalid to: expected YYYY-MM-DD or RFC 3339")
+		}
+		f.To = &t
+	}
+	if f.From != nil && f.To != nil && !f.From.Before(*f.To) {
+		return f, errors.New("from must be before to")
+	}
+	id, err := parseWarehouseParam(q)
+	if err != nil {
+		return f, err
+	}
+	f.WarehouseID = id
+	if s := q.Get("limit"); s != "" {
+		limit, err := strconv.Atoi(s)
+		if err != nil || limit < 1 || limit > MaxProfitabilityRows {
+			return f, fmt.Errorf("limit must be between 1 and %d", MaxProfitabilityRows)
+		}
+		f.Limit = limit
+	}
+	return f, nil
+}
+
+// parseAtParam parses an exclusive end of a period: a date stands for the end
+// of that day.
+func parseAtParam(s string) (time.Time, error) {
+	t, dateOnly, err := parseFilterTime(s)
+	if err != nil {
+		return t, err
+	}
+	if dateOnly {
+		t = t.AddDate(0, 0, 1)
+	}
+	return t, nil
+}
+
+func parseWarehouseParam(q url.Values) (*uuid.UUID, error) {
+	s := q.Get("warehouse_id")
+	if s == "" {
+		return nil, nil
+	}
+	id, err := uuid.Parse(s)
+	if err != nil {
+		return nil, errors.New("invalid warehouse_id")
+	}
+	return &id, nil
+}
+
+// COGSRow is the cost of goods issued on WZ documents, grouped by document,
+// product or warehouse. Date is set for documents.
+type COGSRow struct {
+	Key       string     `json:"key"`
+	Label     string     `json:"label"`
+	Warehouse string     `json:"warehouse,omitempty"`
+	Date      *time.Time `json:"date,omitempty"`
+	Documents int        `json:"documents"`
+	Quantity  int        `json:"quantity"`
+	Cost      float64    `json:"cost"`
+	Estimated bool       `json:"estimated"`
+}
+
+// COGSReport is the cost of goods sold report returned by
+// GET /v1/inventory/cogs.
+type COGSReport struct {
+	GroupBy  string    `json:"group_by"`
+	Rows     []COGSRow `json:"rows"`
+	Quantity int       `json:"quantity"`
+	Cost     float64   `json:"cost"`
+}
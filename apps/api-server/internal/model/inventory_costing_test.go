@@ -0,0 +1,94 @@
+package model
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testCostLayers() []CostLayer {
+	return []CostLayer{
+		{ID: uuid.New(), Quantity: 10, Remaining: 4, UnitCost: 10},
+		{ID: uuid.New(), Quantity: 10, Remaining: 10, UnitCost: 13},
+	}
+}
+
+func TestConsumeCostLayers_FIFO(t *testing.T) {
+	c := ConsumeCostLayers(testCostLayers(), 6, CostingMethodFIFO)
+	assert.Equal(t, 6, c.Quantity)
+	assert.Equal(t, 66.0, c.Cost, "4 x 10 from the oldest layer, 2 x 13 from the next")
+	assert.Zero(t, c.Shortfall)
+	require.Len(t, c.Layers, 2)
+	assert.Equal(t, 0, c.Layers[0].Remaining)
+	assert.Equal(t, 8, c.Layers[1].Remaining)
+	assert.Equal(t, 13.0, c.Layers[1].UnitCost)
+}
+
+func TestConsumeCostLayers_WeightedAverage(t *testing.T) {
+	c := ConsumeCostLayers(testCostLayers(), 7, CostingMethodWeightedAverage)
+	// (4 x 10 + 10 x 13) / 14 = 12.1429
+	assert.Equal(t, 7, c.Quantity)
+	assert.Equal(t, 85.0003, c.Cost)
+	require.Len(t, c.Layers, 2)
+	for _, l := range c.Layers {
+		assert.Equal(t, 12.1429, l.UnitCost, "open layers are repriced to the average")
+	}
+	assert.Equal(t, 0, c.Layers[0].Remaining)
+	assert.Equal(t, 7, c.Layers[1].Remaining)
+}
+
+func TestConsumeCostLayers_Shortfall(t *testing.T) {
+	c := ConsumeCostLayers(testCostLayers(), 20, CostingMethodFIFO)
+	assert.Equal(t, 14, c.Quantity)
+	assert.Equal(t, 170.0, c.Cost)
+	assert.Equal(t, 6, c.Shortfall)
+
+	empty := ConsumeCostLayers(nil, 3, CostingMethodWeightedAverage)
+	assert.Equal(t, 3, empty.Shortfall)
+	assert.Empty(t, empty.Layers)
+}
+
+func TestInventorySettings_Costing(t *testing.T) {
+	assert.Equal(t, CostingMethodFIFO, InventorySettings{}.Costing())
+	s := InventorySettings{CostingMethod: CostingMethodWeightedAverage}
+	assert.NoError(t, s.Validate())
+	assert.Equal(t, CostingMethodWeightedAverage, s.Costing())
+	assert.Error(t, (&InventorySettings{CostingMethod: "lifo"}).Validate())
+}
+
+func TestParseInventoryValuationFilter(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	f, err := ParseInventoryValuationFilter(url.Values{}, now)
+	require.NoError(t, err)
+	assert.Equal(t, now, f.At)
+
+	id := uuid.New()
+	f, err = ParseInventoryValuationFilter(url.Values{"at": {"2026-09-30"}, "warehouse_id": {id.String()}}, now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC), f.At, "a date is valued at the end of the day")
+	assert.Equal(t, id, *f.WarehouseID)
+
+	_, err = ParseInventoryValuationFilter(url.Values{"warehouse_id": {"main"}}, now)
+	assert.Error(t, err)
+}
+
+func TestParseCOGSFilter(t *testing.T) {
+	f, err := ParseCOGSFilter(url.Values{})
+	require.NoError(t, err)
+	assert.Equal(t, COGSByDocument, f.GroupBy)
+	assert.Equal(t, 500, f.Limit)
+
+	f, err = ParseCOGSFilter(url.Values{"group_by": {"product"}, "from": {"2026-09-01"}, "to": {"2026-09-30"}})
+	require.NoError(t, err)
+	assert.Equal(t, COGSByProduct, f.GroupBy)
+	assert.Equal(t, time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC), *f.To)
+
+	_, err = ParseCOGSFilter(url.Values{"group_by": {"customer"}})
+	assert.Error(t, err)
+	_, err = ParseCOGSFilter(url.Values{"from": {"2026-10-01"}, "to": {"2026-09-01"}})
+	assert.Error(t, err)
+}
@@ -8,6 +8,8 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+
+	engine "github.com/openoms-org/openoms/packages/order-engine"
 )
 
 type Order struct {
@@ -41,8 +43,18 @@ type Order struct {
 	SplitFrom       *uuid.UUID      `json:"split_from,omitempty"`
 	InternalNotes   string          `json:"internal_notes"`
 	Priority        string          `json:"priority"`
-	CreatedAt       time.Time       `json:"created_at"`
-	UpdatedAt       time.Time       `json:"updated_at"`
+	AssignedTo      *uuid.UUID      `json:"assigned_to,omitempty"`
+	// AddressIssues lists the problems found in the shipping address when the
+	// order was imported or its address edited; nil when it was not checked.
+	AddressIssues []AddressIssue `json:"address_issues"`
+	// RiskScore is the fraud risk score (0-100) computed when the order was
+	// imported, with the signals behind it; nil when it was not scored.
+	RiskScore   *int         `json:"risk_score"`
+	RiskSignals []RiskSignal `json:"risk_signals"`
+	// StatusChangedAt is when the order entered its current status.
+	StatusChangedAt time.Time `json:"status_changed_at"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
 }
 
 type CreateOrderRequest struct {
@@ -81,6 +93,24 @@ func IsValidPriority(p string) bool {
 	return validPriorities[p]
 }
 
+// cashOnDeliveryMethods are the payment methods marketplaces and shops report
+// for cash on delivery, lowercased.
+var cashOnDeliveryMethods = []string{"cod", "cash_on_delivery", "cash on delivery", "za pobraniem", "pobranie", "płatność przy odbiorze"}
+
+// IsCashOnDelivery reports whether a payment method is cash on delivery.
+func IsCashOnDelivery(method *string) bool {
+	if method == nil {
+		return false
+	}
+	return slices.Contains(cashOnDeliveryMethods, strings.ToLower(strings.TrimSpace(*method)))
+}
+
+// CashOnDeliveryMethods returns the lowercased payment methods meaning cash
+// on delivery, for matching in queries.
+func CashOnDeliveryMethods() []string {
+	return slices.Clone(cashOnDeliveryMethods)
+}
+
 func (r *CreateOrderRequest) Validate() error {
 	if strings.TrimSpace(r.CustomerName) == "" {
 		return errors.New("customer_name is required")
@@ -156,6 +186,7 @@ type UpdateOrderRequest struct {
 	PaidAt          *time.Time      `json:"paid_at,omitempty"`
 	InternalNotes   *string         `json:"internal_notes,omitempty"`
 	Priority        *string         `json:"priority,omitempty"`
+	AssignedTo      *uuid.UUID      `json:"assigned_to,omitempty"`
 }
 
 func (r *UpdateOrderRequest) Validate() error {
@@ -165,7 +196,7 @@ func (r *UpdateOrderRequest) Validate() error {
 		r.Notes == nil && r.Metadata == nil && r.Tags == nil &&
 		r.DeliveryMethod == nil && r.PickupPointID == nil &&
 		r.PaymentStatus == nil && r.PaymentMethod == nil && r.PaidAt == nil &&
-		r.InternalNotes == nil && r.Priority == nil {
+		r.InternalNotes == nil && r.Priority == nil && r.AssignedTo == nil {
 		return errors.New("at least one field must be provided")
 	}
 	if r.TotalAmount != nil && *r.TotalAmount < 0 {
@@ -218,22 +249,49 @@ func (r *StatusTransitionRequest) Validate() error {
 
 type OrderListFilter struct {
 	Status        *string
+	Statuses      []string
 	Source        *string
 	Search        *string
 	PaymentStatus *string
 	Tag           *string
 	Priority      *string
+	IntegrationID *uuid.UUID
+	CustomerID    *uuid.UUID
+	Carrier       *string
+	SKU           *string
+	OrderedFrom   *time.Time
+	OrderedTo     *time.Time
+	AmountMin     *float64
+	AmountMax     *float64
+	CustomFields  map[string]string
+	UnpackedDays  *int
+	AddressIssues *bool
+	RiskMin       *int
+
+	// PackedStatuses are the statuses UnpackedDays excludes, resolved from
+	// the tenant's workflow; the default workflow's are used when empty.
+	PackedStatuses []string
 	PaginationParams
 }
 
+// BulkStatusTransitionRequest selects orders either by ID or by an order
+// filter in the query-parameter language of the order list.
 type BulkStatusTransitionRequest struct {
-	OrderIDs []uuid.UUID `json:"order_ids"`
-	Status   string      `json:"status"`
-	Force    bool        `json:"force,omitempty"`
+	OrderIDs []uuid.UUID       `json:"order_ids"`
+	Filter   OrderFilterValues `json:"filter,omitempty"`
+	Status   string            `json:"status"`
+	Force    bool              `json:"force,omitempty"`
 }
 
 func (r *BulkStatusTransitionRequest) Validate() error {
-	if len(r.OrderIDs) == 0 {
+	if len(r.OrderIDs) > 0 && len(r.Filter) > 0 {
+		return errors.New("order_ids and filter are mutually exclusive")
+	}
+	if len(r.Filter) > 0 {
+		if _, err := ParseOrderFilter(r.Filter.Values()); err != nil {
+			return err
+		}
+	} else if len(r.OrderIDs) == 0 {
 		return errors.New("at least one order_id is required")
 	}
 	if len(r.OrderIDs) > 100 {
@@ -259,11 +317,16 @@ type BulkStatusTransitionResponse struct {
 
 // --- Custom Order Statuses ---
 
+// StatusDef is a status of the tenant's order workflow. Category tells the
+// system what the status means (shipped, cancelled, ...); Requires lists the
+// guards an order must meet to enter it.
 type StatusDef struct {
-	Key      string `json:"key"`
-	Label    string `json:"label"`
-	Color    string `json:"color"`
-	Position int    `json:"position"`
+	Key      string   `json:"key"`
+	Label    string   `json:"label"`
+	Color    string   `json:"color"`
+	Position int      `json:"position"`
+	Category string   `json:"category,omitempty"`
+	Requires []string `json:"requires,omitempty"`
 }
 
 type OrderStatusConfig struct {
@@ -297,6 +360,112 @@ func (c *OrderStatusConfig) GetStatusDef(key string) *StatusDef {
 	return nil
 }
 
+// CategoryOf returns the category of a status. Statuses saved without one
+// fall back to the engine default.
+func (c *OrderStatusConfig) CategoryOf(key string) string {
+	if def := c.GetStatusDef(key); def != nil && def.Category != "" {
+		return def.Category
+	}
+	return string(engine.DefaultCategory(engine.OrderStatus(key)))
+}
+
+// FirstInCategory returns the first status of a category, or "" when the
+// workflow has none.
+func (c *OrderStatusConfig) FirstInCategory(category string) string {
+	for _, s := range c.Statuses {
+		if c.CategoryOf(s.Key) == category {
+			return s.Key
+		}
+	}
+	return ""
+}
+
+// Workflow builds the order engine workflow of the configuration.
+func (c *OrderStatusConfig) Workflow() (*engine.Workflow, error) {
+	states := make([]engine.State, 0, len(c.Statuses))
+	for _, s := range c.Statuses {
+		state := engine.State{Key: engine.OrderStatus(s.Key), Category: engine.StatusCategory(s.Category)}
+		for _, g := range s.Requires {
+			state.Requires = append(state.Requires, engine.Guard(g))
+		}
+		states = append(states, state)
+	}
+	transitions := make(map[engine.OrderStatus][]engine.OrderStatus, len(c.Transitions))
+	for from, targets := range c.Transitions {
+		to := make([]engine.OrderStatus, len(targets))
+		for i, t := range targets {
+			to[i] = engine.OrderStatus(t)
+		}
+		transitions[engine.OrderStatus(from)] = to
+	}
+	return engine.NewWorkflow(states, transitions)
+}
+
+// DropUnknownTransitions removes transitions from or to statuses the
+// configuration does not define, which configurations saved before workflows
+// were validated may contain. It returns the dropped edges as "from -> to".
+func (c *OrderStatusConfig) DropUnknownTransitions() []string {
+	var dropped []string
+	for from, targets := range c.Transitions {
+		if !c.IsValidStatus(from) {
+			for _, to := range targets {
+				dropped = append(dropped, from+" -> "+to)
+			}
+			delete(c.Transitions, from)
+			continue
+		}
+		kept := targets[:0:0]
+		for _, to := range targets {
+			if c.IsValidStatus(to) {
+				kept = append(kept, to)
+			} else {
+				dropped = append(dropped, from+" -> "+to)
+			}
+		}
+		c.Transitions[from] = kept
+	}
+	slices.Sort(dropped)
+	return dropped
+}
+
+// Validate checks that every status has a key and label and that the
+// statuses and transitions form a valid workflow.
+func (c *OrderStatusConfig) Validate() error {
+	for _, s := range c.Statuses {
+		if s.Key == "" || s.Label == "" {
+			return errors.New("status key and label are required")
+		}
+	}
+	_, err := c.Workflow()
+	return err
+}
+
+// PackedStatuses returns the statuses whose orders no longer wait for
+// packing: every status outside the new and processing categories.
+func (c *OrderStatusConfig) PackedStatuses() []string {
+	var packed []string
+	for _, s := range c.Statuses {
+		category := engine.StatusCategory(c.CategoryOf(s.Key))
+		if category != engine.CategoryNew && category != engine.CategoryProcessing {
+			packed = append(packed, s.Key)
+		}
+	}
+	return packed
+}
+
+// ExcludedFromSales returns the statuses whose orders do not count as sales:
+// the cancelled and refunded categories and the statuses of merged and split
+// orders.
+func (c *OrderStatusConfig) ExcludedFromSales() []string {
+	excluded := slices.Clone(ProfitabilityExcludedStatuses)
+	for _, s := range c.Statuses {
+		if engine.StatusCategory(c.CategoryOf(s.Key)).Closed() && !slices.Contains(excluded, s.Key) {
+			excluded = append(excluded, s.Key)
+		}
+	}
+	return excluded
+}
+
 var ColorPresetHex = map[string]string{
 	"blue":       "#3b82f6",
 	"indigo":     "#6366f1",
@@ -356,18 +525,18 @@ func DefaultProductCategoriesConfig() ProductCategoriesConfig {
 func DefaultOrderStatusConfig() OrderStatusConfig {
 	return OrderStatusConfig{
 		Statuses: []StatusDef{
-			{Key: "new", Label: "Now", Color: "blue", Position: 1},
-			{Key: "confirmed", Label: "Potwierdzone", Color: "indigo", Position: 2},
-			{Key: "processing", Label: "W realizacji", Color: "yellow", Position: 3},
-			{Key: "ready_to_ship", Label: "Gotowe do wysyłki", Color: "orange", Position: 4},
-			{Key: "shipped", Label: "Wysłane", Color: "purple", Position: 5},
-			{Key: "in_transit", Label: "W transporcie", Color: "purple", Position: 6},
-			{Key: "out_for_delivery", Label: "W doręczeniu", Color: "teal", Position: 7},
-			{Key: "delivered", Label: "Dostarczone", Color: "green", Position: 8},
-			{Key: "completed", Label: "Zakończone", Color: "green-dark", Position: 9},
-			{Key: "on_hold", Label: "Wstrzymane", Color: "gray", Position: 10},
-			{Key: "cancelled", Label: "Anulowane", Color: "red", Position: 11},
-			{Key: "refunded", Label: "Zwrócone", Color: "red-dark", Position: 12},
+			{Key: "new", Label: "Now", Color: "blue", Position: 1, Category: "new"},
+			{Key: "confirmed", Label: "Potwierdzone", Color: "indigo", Position: 2, Category: "processing"},
+			{Key: "processing", Label: "W realizacji", Color: "yellow", Position: 3, Category: "processing"},
+			{Key: "ready_to_ship", Label: "Gotowe do wysyłki", Color: "orange", Position: 4, Category: "processing"},
+			{Key: "shipped", Label: "Wysłane", Color: "purple", Position: 5, Category: "shipped"},
+			{Key: "in_transit", Label: "W transporcie", Color: "purple", Position: 6, Category: "shipped"},
+			{Key: "out_for_delivery", Label: "W doręczeniu", Color: "teal", Position: 7, Category: "shipped"},
+			{Key: "delivered", Label: "Dostarczone", Color: "green", Position: 8, Category: "delivered"},
+			{Key: "completed", Label: "Zakończone", Color: "green-dark", Position: 9, Category: "completed"},
+			{Key: "on_hold", Label: "Wstrzymane", Color: "gray", Position: 10, Category: "on_hold"},
+			{Key: "cancelled", Label: "Anulowane", Color: "red", Position: 11, Category: "cancelled"},
+			{Key: "refunded", Label: "Zwrócone", Color: "red-dark", Position: 12, Category: "refunded"},
 		},
 		Transitions: map[string][]string{
 			"new":              {"confirmed", "cancelled", "on_hold"},
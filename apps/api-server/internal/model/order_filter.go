@@ -0,0 +1,277 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+)
+
+// customFieldParamPrefix marks query parameters filtering on custom field
+// values stored in order metadata, e.g. cf.invoice_nip=1234567890.
+const customFieldParamPrefix = "cf."
+
+// OrderFilterValues is an order filter in the query-parameter language of
+// GET /v1/orders. Saved views, CSV export and bulk operations all use it.
+type OrderFilterValues map[string]string
+
+// Values converts the filter to url.Values for ParseOrderFilter.
+func (f OrderFilterValues) Values() url.Values {
+	v := url.Values{}
+	for key, val := range f {
+		v.Set(key, val)
+	}
+	return v
+}
+
+// ParseOrderFilter parses the order filter language:
+//
+//	status          one status or a comma-separated list
+//	source, payment_status, tag, priority, carrier, sku
+//	search          full-text over customer, addresses, items and notes
+//	integration_id, customer_id
+//	ordered_from    date (YYYY-MM-DD) or RFC 3339 timestamp, inclusive
+//	ordered_to      date (inclusive day) or RFC 3339 timestamp (exclusive)
+//	amount_min, amount_max
+//	unpacked_days   open orders not packed for more than N days
+//	address_issues  true: shipping address failed validation; false: it did not
+//	risk_min        risk score of at least N (0-100)
+//	cf.<key>        custom field value
+//
+// Pagination and sorting parameters are ignored.
+func ParseOrderFilter(q url.Values) (OrderListFilter, error) {
+	var f OrderListFilter
+
+	if s := q.Get("status"); s != "" {
+		var statuses []string
+		for _, part := range strings.Split(s, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				statuses = append(statuses, part)
+			}
+		}
+		if len(statuses) == 1 {
+			f.Status = &statuses[0]
+		} else if len(statuses) > 1 {
+			f.Statuses = statuses
+		}
+	}
+	for param, dst := range map[string]**string{
+		"source":         &f.Source,
+		"search":         &f.Search,
+		"payment_status": &f.PaymentStatus,
+		"tag":            &f.Tag,
+		"priority":       &f.Priority,
+		"carrier":        &f.Carrier,
+		"sku":            &f.SKU,
+	} {
+		if s := strings.TrimSpace(q.Get(param)); s != "" {
+			*dst = &s
+		}
+	}
+
+	for param, dst := range map[string]**uuid.UUID{
+		"integration_id": &f.IntegrationID,
+		"customer_id":    &f.CustomerID,
+	} {
+		if s := q.Get(param); s != "" {
+			id, err := uuid.Parse(s)
+			if err != nil {
+				return f, fmt.Errorf("invalid %s", param)
+			}
+			*dst = &id
+		}
+	}
+
+	if s := q.Get("ordered_from"); s != "" {
+		t, _, err := parseFilterTime(s)
+		if err != nil {
+			return f, errors.New("invalid ordered_from: expected YYYY-MM-DD or RFC 3339")
+		}
+		f.OrderedFrom = &t
+	}
+	if s := q.Get("ordered_to"); s != "" {
+		t, dateOnly, err := parseFilterTime(s)
+		if err != nil {
+			return f, errors.New("invalid ordered_to: expected YYYY-MM-DD or RFC 3339")
+		}
+		if dateOnly {
+			t = t.AddDate(0, 0, 1)
+		}
+		f.OrderedTo = &t
+	}
+
+	for param, dst := range map[string]**float64{
+		"amount_min": &f.AmountMin,
+		"amount_max": &f.AmountMax,
+	} {
+		if s := q.Get(param); s != "" {
+			v, err := strconv.ParseFloat(s, 64)
+			if err != nil || v < 0 {
+				return f, fmt.Errorf("invalid %s", param)
+			}
+			*dst = &v
+		}
+	}
+	if f.AmountMin != nil && f.AmountMax != nil && *f.AmountMin > *f.AmountMax {
+		return f, errors.New("amount_min must not exceed amount_max")
+	}
+
+	if s := q.Get("unpacked_days"); s != "" {
+		days, err := strconv.Atoi(s)
+		if err != nil || days < 0 || days > 365 {
+			return f, errors.New("unpacked_days must be between 0 and 365")
+		}
+		f.UnpackedDays = &days
+	}
+
+	if s := q.Get("address_issues"); s != "" {
+		v, err := strconv.ParseBool(s)
+		if err != nil {
+			return f, errors.New("invalid address_issues")
+		}
+		f.AddressIssues = &v
+	}
+
+	if s := q.Get("risk_min"); s != "" {
+		score, err := strconv.Atoi(s)
+		if err != nil || score < 0 || score > MaxRiskScore {
+			return f, fmt.Errorf("risk_min must be between 0 and %d", MaxRiskScore)
+		}
+		f.RiskMin = &score
+	}
+
+	for param := range q {
+		key, ok := strings.CutPrefix(param, customFieldParamPrefix)
+		if !ok {
+			continue
+		}
+		if !isCustomFieldKey(key) {
+			return f, fmt.Errorf("invalid custom field filter %q", param)
+		}
+		if f.CustomFields == nil {
+			f.CustomFields = map[string]string{}
+		}
+		f.CustomFields[key] = q.Get(param)
+	}
+
+	return f, nil
+}
+
+func parseFilterTime(s string) (t time.Time, dateOnly bool, err error) {
+	if t, err = time.Parse(time.DateOnly, s); err == nil {
+		return t, true, nil
+	}
+	t, err = time.Parse(time.RFC3339, s)
+	return t, false, err
+}
+
+func isCustomFieldKey(key string) bool {
+	if key == "" || len(key) > 64 {
+		return false
+	}
+	for _, r := range key {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' && r != '-' {
+			return false
+		}
+	}
+	return true
+}
+
+// OrderSearchQuery turns free text into a tsquery matching every word as a
+// prefix, e.g. "jan kowal" becomes 'jan':* & 'kowal':*. Characters with a
+// meaning in tsquery syntax split words. Returns "" when nothing is left.
+func OrderSearchQuery(search string) string {
+	words := strings.FieldsFunc(strings.ToLower(search), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && !strings.ContainsRune("@.-_+", r)
+	})
+	terms := make([]string, 0, len(words))
+	for _, w := range words {
+		w = strings.Trim(w, ".-_+")
+		if w == "" {
+			continue
+		}
+		terms = append(terms, "'"+w+"':*")
+	}
+	return strings.Join(terms, " & ")
+}
+
+// --- Saved views ---
+
+// OrderView is a named order filter saved by a user.
+type OrderView struct {
+	ID        uuid.UUID         `json:"id"`
+	TenantID  uuid.UUID         `json:"tenant_id"`
+	UserID    uuid.UUID         `json:"user_id"`
+	Name      string            `json:"name"`
+	Filters   OrderFilterValues `json:"filters"`
+	CreatedAt time.Time         `json:"created_at"`
+	UpdatedAt time.Time         `json:"updated_at"`
+}
+
+type CreateOrderViewRequest struct {
+	Name    string            `json:"name"`
+	Filters OrderFilterValues `json:"filters"`
+}
+
+func (r *CreateOrderViewRequest) Validate() error {
+	r.Name = strings.TrimSpace(r.Name)
+	if r.Name == "" {
+		return errors.New("name is required")
+	}
+	if len(r.Name) > 100 {
+		return errors.New("name must be at most 100 characters")
+	}
+	if r.Filters == nil {
+		r.Filters = OrderFilterValues{}
+	}
+	return validateViewFilters(r.Filters)
+}
+
+type UpdateOrderViewRequest struct {
+	Name    *string           `json:"name,omitempty"`
+	Filters OrderFilterValues `json:"filters,omitempty"`
+}
+
+func (r *UpdateOrderViewRequest) Validate() error {
+	if r.Name == nil && r.Filters == nil {
+		return errors.New("at least one field must be provided")
+	}
+	if r.Name != nil {
+		name := strings.TrimSpace(*r.Name)
+		if name == "" {
+			return errors.New("name must not be empty")
+		}
+		if len(name) > 100 {
+			return errors.New("name must be at most 100 characters")
+		}
+		r.Name = &name
+	}
+	if r.Filters != nil {
+		return validateViewFilters(r.Filters)
+	}
+	return nil
+}
+
+// viewPaginationParams are list parameters a view may carry next to filters.
+var viewPaginationParams = map[string]bool{"sort_by": true, "sort_order": true, "limit": true}
+
+func validateViewFilters(filters OrderFilterValues) error {
+	known := map[string]bool{
+		"status": true, "source": true, "search": true, "payment_status": true, "tag": true,
+		"priority": true, "carrier": true, "sku": true, "integration_id": true, "customer_id": true,
+		"ordered_from": true, "ordered_to": true, "amount_min": true, "amount_max": true, "unpacked_days": true,
+		"address_issues": true, "risk_min": true,
+	}
+	for key := range filters {
+		if !known[key] && !viewPaginationParams[key] && !strings.HasPrefix(key, customFieldParamPrefix) {
+			return fmt.Errorf("unknown filter %q", key)
+		}
+	}
+	_, err := ParseOrderFilter(filters.Values())
+	return err
+}
@@ -0,0 +1,113 @@
+package model
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseOrderFilter(t *testing.T) {
+	integrationID := uuid.New()
+	q := url.Values{}
+	q.Set("status", "new, confirmed")
+	q.Set("search", "  kowalski ")
+	q.Set("integration_id", integrationID.String())
+	q.Set("carrier", "inpost")
+	q.Set("sku", "ABC-1")
+	q.Set("ordered_from", "2026-01-01")
+	q.Set("ordered_to", "2026-01-31")
+	q.Set("amount_min", "10.5")
+	q.Set("amount_max", "200")
+	q.Set("unpacked_days", "2")
+	q.Set("address_issues", "true")
+	q.Set("risk_min", "60")
+	q.Set("cf.invoice_nip", "1234567890")
+	q.Set("limit", "50")
+
+	f, err := ParseOrderFilter(q)
+	require.NoError(t, err)
+
+	assert.Nil(t, f.Status)
+	assert.Equal(t, []string{"new", "confirmed"}, f.Statuses)
+	require.NotNil(t, f.Search)
+	assert.Equal(t, "kowalski", *f.Search)
+	assert.Equal(t, integrationID, *f.IntegrationID)
+	assert.Equal(t, "inpost", *f.Carrier)
+	assert.Equal(t, "ABC-1", *f.SKU)
+	assert.Equal(t, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), *f.OrderedFrom)
+	assert.Equal(t, time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), *f.OrderedTo, "date-only upper bound covers the whole day")
+	assert.Equal(t, 10.5, *f.AmountMin)
+	assert.Equal(t, 200.0, *f.AmountMax)
+	assert.Equal(t, 2, *f.UnpackedDays)
+	assert.True(t, *f.AddressIssues)
+	assert.Equal(t, 60, *f.RiskMin)
+	assert.Equal(t, map[string]string{"invoice_nip": "1234567890"}, f.CustomFields)
+}
+
+func TestParseOrderFilter_SingleStatus(t *testing.T) {
+	f, err := ParseOrderFilter(url.Values{"status": {"shipped"}})
+	require.NoError(t, err)
+	require.NotNil(t, f.Status)
+	assert.Equal(t, "shipped", *f.Status)
+	assert.Empty(t, f.Statuses)
+}
+
+func TestParseOrderFilter_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		q       url.Values
+		wantErr string
+	}{
+		{"bad integration", url.Values{"integration_id": {"x"}}, "invalid integration_id"},
+		{"bad date", url.Values{"ordered_from": {"01.02.2026"}}, "invalid ordered_from"},
+		{"negative amount", url.Values{"amount_min": {"-1"}}, "invalid amount_min"},
+		{"inverted range", url.Values{"amount_min": {"20"}, "amount_max": {"10"}}, "must not exceed"},
+		{"unpacked days", url.Values{"unpacked_days": {"400"}}, "unpacked_days"},
+		{"address issues", url.Values{"address_issues": {"maybe"}}, "invalid address_issues"},
+		{"risk min", url.Values{"risk_min": {"101"}}, "risk_min"},
+		{"custom field key", url.Values{"cf.a b": {"1"}}, "invalid custom field filter"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseOrderFilter(tt.q)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestOrderSearchQuery(t *testing.T) {
+	assert.Equal(t, "'jan':* & 'kowal':*", OrderSearchQuery("Jan  Kowal"))
+	assert.Equal(t, "'jan.k@example.pl':*", OrderSearchQuery("jan.k@example.pl"))
+	assert.Equal(t, "'a':* & 'b':*", OrderSearchQuery("a' | b:*"))
+	assert.Equal(t, "", OrderSearchQuery(" &!() "))
+}
+
+func TestCreateOrderViewRequest_Validate(t *testing.T) {
+	req := CreateOrderViewRequest{Name: "  Do spakowania ", Filters: OrderFilterValues{"unpacked_days": "2", "sort_by": "ordered_at"}}
+	require.NoError(t, req.Validate())
+	assert.Equal(t, "Do spakowania", req.Name)
+
+	req = CreateOrderViewRequest{Name: ""}
+	assert.ErrorContains(t, req.Validate(), "name is required")
+
+	req = CreateOrderViewRequest{Name: "x", Filters: OrderFilterValues{"colour": "red"}}
+	assert.ErrorContains(t, req.Validate(), "unknown filter")
+
+	req = CreateOrderViewRequest{Name: "x", Filters: OrderFilterValues{"ordered_to": "soon"}}
+	assert.ErrorContains(t, req.Validate(), "invalid ordered_to")
+}
+
+func TestUpdateOrderViewRequest_Validate(t *testing.T) {
+	req := UpdateOrderViewRequest{}
+	assert.ErrorContains(t, req.Validate(), "at least one field")
+
+	name := "  Nowa "
+	req = UpdateOrderViewRequest{Name: &name}
+	require.NoError(t, req.Validate())
+	assert.Equal(t, "Nowa", *req.Name)
+}
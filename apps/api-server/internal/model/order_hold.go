@@ -0,0 +1,138 @@
+package model
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	engine "github.com/openoms-org/openoms/packages/order-engine"
+)
+
+// Sources of order holds: placed by a user, by an automation rule or by the
+// checks run when an order is imported.
+const (
+	HoldSourceManual     = "manual"
+	HoldSourceAutomation = "automation"
+	HoldSourceImport     = "import"
+)
+
+// OrderHold blocks fulfilment of an order for a reason until it is released.
+// AutoRelease is true for reasons released once their condition clears.
+type OrderHold struct {
+	ID          uuid.UUID  `json:"id"`
+	TenantID    uuid.UUID  `json:"tenant_id"`
+	OrderID     uuid.UUID  `json:"order_id"`
+	Reason      string     `json:"reason"`
+	Note        string     `json:"note"`
+	Source      string     `json:"source"`
+	CreatedBy   *uuid.UUID `json:"created_by,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	ReleasedAt  *time.Time `json:"released_at,omitempty"`
+	ReleasedBy  *uuid.UUID `json:"released_by,omitempty"`
+	ReleaseNote string     `json:"release_note"`
+	AutoRelease bool       `json:"auto_release"`
+}
+
+// Active reports whether the hold has not been released.
+func (h OrderHold) Active() bool {
+	return h.ReleasedAt == nil
+}
+
+const maxHoldNoteLength = 1000
+
+// HoldReasons lists the hold reasons.
+var HoldReasons = []string{
+	string(engine.HoldFraudCheck),
+	string(engine.HoldAddressVerification),
+	string(engine.HoldAwaitingPayment),
+	string(engine.HoldOutOfStock),
+	string(engine.HoldCustomerRequest),
+}
+
+func validateHoldReason(reason string) error {
+	if !engine.HoldReason(reason).Valid() {
+		return fmt.Errorf("reason must be one of: %s", strings.Join(HoldReasons, ", "))
+	}
+	return nil
+}
+
+// PlaceHoldRequest puts an order on hold.
+type PlaceHoldRequest struct {
+	Reason string `json:"reason"`
+	Note   string `json:"note"`
+}
+
+func (r PlaceHoldRequest) Validate() error {
+	if err := validateHoldReason(r.Reason); err != nil {
+		return err
+	}
+	return validateMaxLength("note", r.Note, maxHoldNoteLength)
+}
+
+// ReleaseHoldRequest releases a hold.
+type ReleaseHoldRequest struct {
+	Note string `json:"note"`
+}
+
+func (r ReleaseHoldRequest) Validate() error {
+	return validateMaxLength("note", r.Note, maxHoldNoteLength)
+}
+
+// HeldOrder is an order in the blocked-order queue with its active holds.
+// HeldSince is when its oldest active hold was placed.
+type HeldOrder struct {
+	OrderID       uuid.UUID   `json:"order_id"`
+	ExternalID    *string     `json:"external_id,omitempty"`
+	Source        string      `json:"source"`
+	Status        string      `json:"status"`
+	CustomerName  string      `json:"customer_name"`
+	TotalAmount   float64     `json:"total_amount"`
+	Currency      string      `json:"currency"`
+	PaymentStatus string      `json:"payment_status"`
+	OrderedAt     *time.Time  `json:"ordered_at,omitempty"`
+	HeldSince     time.Time   `json:"held_since"`
+	Holds         []OrderHold `json:"holds"`
+}
+
+// OrderHoldListFilter selects the orders of the blocked-order queue: orders
+// with an active hold, of Reason and Source when set.
+type OrderHoldListFilter struct {
+	Reason *string
+	Source *string
+	PaginationParams
+}
+
+// ImportCheckReasons lists the holds that can be placed by checks on newly
+// imported orders.
+var ImportCheckReasons = []string{
+	string(engine.HoldAwaitingPayment),
+	string(engine.HoldOutOfStock),
+	string(engine.HoldAddressVerification),
+}
+
+// OrderHoldSettings configures the checks run on new orders. Each entry of
+// ImportChecks is a hold reason; an order failing its check is put on hold:
+//
+//	awaiting_payment      the order is unpaid and not cash on delivery
+//	out_of_stock          an order line is not covered by stock
+//	address_verification  the shipping address or customer phone has issues
+type OrderHoldSettings struct {
+	ImportChecks []string `json:"import_checks"`
+}
+
+func (s OrderHoldSettings) Validate() error {
+	for _, c := range s.ImportChecks {
+		if !slices.Contains(ImportCheckReasons, c) {
+			return fmt.Errorf("import_checks must contain only: %s", strings.Join(ImportCheckReasons, ", "))
+		}
+	}
+	return nil
+}
+
+// Checks reports whether the import check of reason is enabled.
+func (s OrderHoldSettings) Checks(reason engine.HoldReason) bool {
+	return slices.Contains(s.ImportChecks, string(reason))
+}
@@ -0,0 +1,35 @@
+package model
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	engine "github.com/openoms-org/openoms/packages/order-engine"
+)
+
+func TestPlaceHoldRequest_Validate(t *testing.T) {
+	assert.NoError(t, PlaceHoldRequest{Reason: "fraud_check"}.Validate())
+	assert.ErrorContains(t, PlaceHoldRequest{}.Validate(), "reason")
+	assert.ErrorContains(t, PlaceHoldRequest{Reason: "lost"}.Validate(), "reason")
+	assert.ErrorContains(t, PlaceHoldRequest{Reason: "fraud_check", Note: strings.Repeat("x", 1001)}.Validate(), "note")
+	assert.ErrorContains(t, ReleaseHoldRequest{Note: strings.Repeat("x", 1001)}.Validate(), "note")
+}
+
+func TestOrderHoldSettings(t *testing.T) {
+	s := OrderHoldSettings{ImportChecks: []string{"awaiting_payment", "address_verification"}}
+	assert.NoError(t, s.Validate())
+	assert.True(t, s.Checks(engine.HoldAwaitingPayment))
+	assert.False(t, s.Checks(engine.HoldOutOfStock))
+
+	assert.False(t, OrderHoldSettings{}.Checks(engine.HoldAwaitingPayment))
+	assert.Error(t, OrderHoldSettings{ImportChecks: []string{"customer_request"}}.Validate())
+}
+
+func TestMissingAddressFields(t *testing.T) {
+	assert.Empty(t, MissingAddressFields(json.RawMessage(`{"street":"Dluga 1","city":"Gdansk","postal_code":"80-001"}`)))
+	assert.Equal(t, []string{"street", "postal_code"}, MissingAddressFields(json.RawMessage(`{"city":"Gdansk","postal_code":" "}`)))
+	assert.Equal(t, []string{"street", "city", "postal_code"}, MissingAddressFields(nil))
+}
@@ -0,0 +1,236 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// MaxRiskScore is the highest order risk score.
+const MaxRiskScore = 100
+
+var currencyCodeRe = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// Codes of the signals contributing to an order risk score.
+const (
+	RiskSignalRefusedCOD           = "refused_cod"
+	RiskSignalFrequentReturns      = "frequent_returns"
+	RiskSignalHighValueFirstOrder  = "high_value_first_order"
+	RiskSignalManualCODNewCustomer = "manual_cod_new_customer"
+	RiskSignalCountryMismatch      = "country_mismatch"
+	RiskSignalDisposableEmail      = "disposable_email"
+	RiskSignalPhoneVelocity        = "phone_velocity"
+	RiskSignalAddressVelocity      = "address_velocity"
+	RiskSignalTrustedCustomer      = "trusted_customer"
+)
+
+// Points of the risk signals. A trusted customer lowers the score.
+const (
+	riskPointsRefusedCOD           = 30
+	riskPointsRefusedCODMax        = 60
+	riskPointsFrequentReturns      = 15
+	riskPointsHighValueFirstOrder  = 20
+	riskPointsManualCODNewCustomer = 10
+	riskPointsCountryMismatch      = 15
+	riskPointsDisposableEmail      = 25
+	riskPointsPhoneVelocity        = 20
+	riskPointsAddressVelocity      = 15
+	riskPointsTrustedCustomer      = -20
+)
+
+// trustedCustomerOrders is the number of earlier orders, none of them a
+// refused cash-on-delivery shipment, making a customer trusted.
+const trustedCustomerOrders = 5
+
+// RiskSignal is a rule that contributed to an order risk score.
+type RiskSignal struct {
+	Code   string `json:"code"`
+	Points int    `json:"points"`
+	Detail string `json:"detail"`
+}
+
+// CustomerRiskHistory is the history of the customer placing an order: the
+// earlier orders, their returns and their cash-on-delivery shipments that
+// came back to the sender.
+type CustomerRiskHistory struct {
+	Orders     int
+	Returns    int
+	RefusedCOD int
+}
+
+// RiskFacts are the facts an order risk score is computed from.
+type RiskFacts struct {
+	History        CustomerRiskHistory
+	CashOnDelivery bool
+	// Manual is true for orders entered by hand rather than imported.
+	Manual          bool
+	TotalAmount     float64
+	Currency        string
+	ShippingCountry string
+	BillingCountry  string
+	EmailDomain     string
+	// PhoneOrders and AddressOrders count the other orders placed within the
+	// velocity window with the same phone and shipping address.
+	PhoneOrders   int
+	AddressOrders int
+	// ThresholdAmount is TotalAmount converted to the currency of the
+	// high-value threshold, when the order is in another currency.
+	ThresholdAmount *float64
+}
+
+// disposableEmailDomains lists common throwaway email providers.
+var disposableEmailDomains = []string{
+	"10minutemail.com", "discard.email", "dispostable.com", "emailondeck.com",
+	"fakeinbox.com", "getnada.com", "guerrillamail.com", "guerrillamail.net",
+	"maildrop.cc", "mailinator.com", "mintemail.com", "mohmal.com",
+	"mytemp.email", "sharklasers.com", "spamgourmet.com", "temp-mail.org",
+	"tempmail.com", "tempr.email", "throwawaymail.com", "trashmail.com",
+	"yopmail.com",
+}
+
+// OrderRiskSettings configures order risk scoring. Every new order is scored;
+// one scoring at least HoldThreshold is put on a fraud_check hold (0 never
+// holds). A first order of at least HighValueAmount, in Currency (PLN when
+// empty), is high-value; totals in other currencies are converted at the
+// tenant's exchange rates. VelocityLimit other orders with the same phone or
+// address within VelocityHours raise the score. DisposableDomains extends the
+// built-in list of throwaway email providers.
+type OrderRiskSettings struct {
+	HoldThreshold     int      `json:"hold_threshold"`
+	HighValueAmount   float64  `json:"high_value_amount"`
+	Currency          string   `json:"currency"`
+	VelocityHours     int      `json:"velocity_hours"`
+	VelocityLimit     int      `json:"velocity_limit"`
+	DisposableDomains []string `json:"disposable_domains"`
+}
+
+// DefaultOrderRiskSettings returns the settings used by tenants that have not
+// configured risk scoring.
+func DefaultOrderRiskSettings() OrderRiskSettings {
+	return OrderRiskSettings{
+		HighValueAmount:   1000,
+		Currency:          "PLN",
+		VelocityHours:     24,
+		VelocityLimit:     3,
+		DisposableDomains: []string{},
+	}
+}
+
+func (s OrderRiskSettings) Validate() error {
+	if s.HoldThreshold < 0 || s.HoldThreshold > MaxRiskScore {
+		return fmt.Errorf("hold_threshold must be between 0 and %d", MaxRiskScore)
+	}
+	if s.HighValueAmount < 0 {
+		return errors.New("high_value_amount must be non-negative")
+	}
+	if s.Currency != "" && !currencyCodeRe.MatchString(s.Currency) {
+		return errors.New("currency must be a 3-letter ISO 4217 code")
+	}
+	if s.VelocityHours < 1 || s.VelocityHours > 720 {
+		return errors.New("velocity_hours must be between 1 and 720")
+	}
+	if s.VelocityLimit < 1 || s.VelocityLimit > 100 {
+		return errors.New("velocity_limit must be between 1 and 100")
+	}
+	if len(s.DisposableDomains) > 500 {
+		return errors.New("disposable_domains must not have more than 500 entries")
+	}
+	for _, d := range s.DisposableDomains {
+		if d = strings.TrimSpace(d); d == "" || strings.ContainsAny(d, " @/") || !strings.Contains(d, ".") {
+			return fmt.Errorf("invalid disposable domain %q", d)
+		}
+	}
+	return nil
+}
+
+// ThresholdCurrency returns the currency of HighValueAmount.
+func (s OrderRiskSettings) ThresholdCurrency() string {
+	if s.Currency == "" {
+		return "PLN"
+	}
+	return s.Currency
+}
+
+// IsDisposable reports whether an email domain belongs to a throwaway email
+// provider, built-in or configured.
+func (s OrderRiskSettings) IsDisposable(domain string) bool {
+	domain = strings.ToLower(strings.TrimSpace(domain))
+	if domain == "" {
+		return false
+	}
+	if slices.Contains(disposableEmailDomains, domain) {
+		return true
+	}
+	for _, d := range s.DisposableDomains {
+		if strings.EqualFold(strings.TrimSpace(d), domain) {
+			return true
+		}
+	}
+	return false
+}
+
+// ScoreOrderRisk computes the risk score of an order, between 0 and
+// MaxRiskScore, and the signals that contributed to it.
+func ScoreOrderRisk(f RiskFacts, s OrderRiskSettings) (int, []RiskSignal) {
+	signals := []RiskSignal{}
+	add := func(code string, points int, format string, args ...any) {
+		signals = append(signals, RiskSignal{Code: code, Points: points, Detail: fmt.Sprintf(format, args...)})
+	}
+
+	h := f.History
+	if h.RefusedCOD > 0 {
+		add(RiskSignalRefusedCOD, min(h.RefusedCOD*riskPointsRefusedCOD, riskPointsRefusedCODMax),
+			"%d earlier cash-on-delivery shipments returned to sender", h.RefusedCOD)
+	}
+	if h.Returns >= 2 && h.Returns*2 >= h.Orders {
+		add(RiskSignalFrequentReturns, riskPointsFrequentReturns, "%d returns on %d earlier orders", h.Returns, h.Orders)
+	}
+	if h.Orders == 0 {
+		if amount, ok := f.thresholdAmount(s.ThresholdCurrency()); ok && s.HighValueAmount > 0 && amount >= s.HighValueAmount {
+			if amount != f.TotalAmount {
+				add(RiskSignalHighValueFirstOrder, riskPointsHighValueFirstOrder, "first order of %.2f %s (%.2f %s)",
+					f.TotalAmount, f.Currency, amount, s.ThresholdCurrency())
+			} else {
+				add(RiskSignalHighValueFirstOrder, riskPointsHighValueFirstOrder, "first order of %.2f %s", f.TotalAmount, f.Currency)
+			}
+		}
+		if f.Manual && f.CashOnDelivery {
+			add(RiskSignalManualCODNewCustomer, riskPointsManualCODNewCustomer, "manual cash-on-delivery order of a new customer")
+		}
+	}
+	if f.ShippingCountry != "" && f.BillingCountry != "" && f.ShippingCountry != f.BillingCountry {
+		add(RiskSignalCountryMismatch, riskPointsCountryMismatch, "billed to %s, shipped to %s", f.BillingCountry, f.ShippingCountry)
+	}
+	if s.IsDisposable(f.EmailDomain) {
+		add(RiskSignalDisposableEmail, riskPointsDisposableEmail, "disposable email domain %s", strings.ToLower(f.EmailDomain))
+	}
+	if s.VelocityLimit > 0 && f.PhoneOrders >= s.VelocityLimit {
+		add(RiskSignalPhoneVelocity, riskPointsPhoneVelocity, "%d other orders with this phone in %dh", f.PhoneOrders, s.VelocityHours)
+	}
+	if s.VelocityLimit > 0 && f.AddressOrders >= s.VelocityLimit {
+		add(RiskSignalAddressVelocity, riskPointsAddressVelocity, "%d other orders to this address in %dh", f.AddressOrders, s.VelocityHours)
+	}
+	if h.Orders >= trustedCustomerOrders && h.RefusedCOD == 0 {
+		add(RiskSignalTrustedCustomer, riskPointsTrustedCustomer, "%d earlier orders", h.Orders)
+	}
+
+	score := 0
+	for _, sig := range signals {
+		score += sig.Points
+	}
+	return max(0, min(score, MaxRiskScore)), signals
+}
+
+// thresholdAmount returns the order total in the threshold currency. It is
+// false for an order in another currency that could not be converted.
+func (f RiskFacts) thresholdAmount(currency string) (float64, bool) {
+	switch {
+	case f.ThresholdAmount != nil:
+		return *f.ThresholdAmount, true
+	case f.Currency == "" || strings.EqualFold(f.Currency, currency):
+		return f.TotalAmount, true
+	}
+	return 0, false
+}
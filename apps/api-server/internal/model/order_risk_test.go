@@ -0,0 +1,108 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func signalCodes(signals []RiskSignal) []string {
+	codes := make([]string, len(signals))
+	for i, s := range signals {
+		codes[i] = s.Code
+	}
+	return codes
+}
+
+func TestScoreOrderRisk_NoSignals(t *testing.T) {
+	score, signals := ScoreOrderRisk(RiskFacts{History: CustomerRiskHistory{Orders: 2}, TotalAmount: 100}, DefaultOrderRiskSettings())
+	assert.Equal(t, 0, score)
+	assert.Empty(t, signals)
+}
+
+func TestScoreOrderRisk_NewCustomer(t *testing.T) {
+	score, signals := ScoreOrderRisk(RiskFacts{
+		CashOnDelivery:  true,
+		Manual:          true,
+		TotalAmount:     1500,
+		Currency:        "PLN",
+		ShippingCountry: "PL",
+		BillingCountry:  "DE",
+		EmailDomain:     "Mailinator.com",
+		PhoneOrders:     3,
+		AddressOrders:   2,
+	}, DefaultOrderRiskSettings())
+
+	assert.Equal(t, []string{
+		RiskSignalHighValueFirstOrder, RiskSignalManualCODNewCustomer, RiskSignalCountryMismatch,
+		RiskSignalDisposableEmail, RiskSignalPhoneVelocity,
+	}, signalCodes(signals))
+	assert.Equal(t, 90, score)
+	assert.Equal(t, "first order of 1500.00 PLN", signals[0].Detail)
+}
+
+func TestScoreOrderRisk_HighValueCurrency(t *testing.T) {
+	_, signals := ScoreOrderRisk(RiskFacts{TotalAmount: 1000, Currency: "HUF"}, DefaultOrderRiskSettings())
+	assert.Empty(t, signals, "an unconverted total is not compared with the threshold")
+
+	converted := 10.85
+	_, signals = ScoreOrderRisk(RiskFacts{TotalAmount: 1000, Currency: "HUF", ThresholdAmount: &converted}, DefaultOrderRiskSettings())
+	assert.Empty(t, signals)
+
+	converted = 4300
+	_, signals = ScoreOrderRisk(RiskFacts{TotalAmount: 1000, Currency: "EUR", ThresholdAmount: &converted}, DefaultOrderRiskSettings())
+	assert.Equal(t, []string{RiskSignalHighValueFirstOrder}, signalCodes(signals))
+	assert.Equal(t, "first order of 1000.00 EUR (4300.00 PLN)", signals[0].Detail)
+
+	s := DefaultOrderRiskSettings()
+	s.Currency = "EUR"
+	_, signals = ScoreOrderRisk(RiskFacts{TotalAmount: 1000, Currency: "eur"}, s)
+	assert.Equal(t, []string{RiskSignalHighValueFirstOrder}, signalCodes(signals))
+}
+
+func TestScoreOrderRisk_History(t *testing.T) {
+	score, signals := ScoreOrderRisk(RiskFacts{
+		History: CustomerRiskHistory{Orders: 4, Returns: 2, RefusedCOD: 3},
+	}, DefaultOrderRiskSettings())
+	assert.Equal(t, []string{RiskSignalRefusedCOD, RiskSignalFrequentReturns}, signalCodes(signals))
+	assert.Equal(t, 60, signals[0].Points, "refused COD points are capped")
+	assert.Equal(t, 75, score)
+
+	score, signals = ScoreOrderRisk(RiskFacts{
+		History:         CustomerRiskHistory{Orders: 8},
+		ShippingCountry: "PL",
+		BillingCountry:  "CZ",
+	}, DefaultOrderRiskSettings())
+	assert.Equal(t, []string{RiskSignalCountryMismatch, RiskSignalTrustedCustomer}, signalCodes(signals))
+	assert.Equal(t, 0, score, "score does not go below zero")
+}
+
+func TestScoreOrderRisk_CappedAt100(t *testing.T) {
+	score, _ := ScoreOrderRisk(RiskFacts{
+		History:       CustomerRiskHistory{Orders: 2, Returns: 2, RefusedCOD: 2},
+		EmailDomain:   "yopmail.com",
+		PhoneOrders:   5,
+		AddressOrders: 5,
+	}, DefaultOrderRiskSettings())
+	assert.Equal(t, MaxRiskScore, score)
+}
+
+func TestOrderRiskSettings(t *testing.T) {
+	s := DefaultOrderRiskSettings()
+	assert.NoError(t, s.Validate())
+	assert.False(t, s.IsDisposable("example.com"))
+
+	s.DisposableDomains = []string{"spam.example"}
+	assert.True(t, s.IsDisposable("SPAM.example"))
+
+	for _, bad := range []OrderRiskSettings{
+		{HoldThreshold: 101, VelocityHours: 24, VelocityLimit: 3},
+		{HighValueAmount: -1, VelocityHours: 24, VelocityLimit: 3},
+		{Currency: "eur", VelocityHours: 24, VelocityLimit: 3},
+		{VelocityHours: 0, VelocityLimit: 3},
+		{VelocityHours: 24, VelocityLimit: 0},
+		{VelocityHours: 24, VelocityLimit: 3, DisposableDomains: []string{"user@spam.example"}},
+	} {
+		assert.Error(t, bad.Validate(), "%+v", bad)
+	}
+}
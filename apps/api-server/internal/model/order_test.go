@@ -114,6 +114,29 @@ func TestBulkStatusTransitionRequest_Validate(t *testing.T) {
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "status is required")
 	})
+
+	t.Run("filter instead of order_ids", func(t *testing.T) {
+		req := BulkStatusTransitionRequest{Filter: OrderFilterValues{"status": "new"}, Status: "confirmed"}
+		require.NoError(t, req.Validate())
+	})
+
+	t.Run("order_ids and filter", func(t *testing.T) {
+		req := BulkStatusTransitionRequest{
+			OrderIDs: []uuid.UUID{uuid.New()},
+			Filter:   OrderFilterValues{"status": "new"},
+			Status:   "confirmed",
+		}
+		err := req.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "mutually exclusive")
+	})
+
+	t.Run("invalid filter", func(t *testing.T) {
+		req := BulkStatusTransitionRequest{Filter: OrderFilterValues{"amount_min": "abc"}, Status: "confirmed"}
+		err := req.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "amount_min")
+	})
 }
 
 func TestOrderStatusConfig_IsValidStatus(t *testing.T) {
@@ -160,3 +183,72 @@ func TestOrderStatusConfig_GetStatusDef(t *testing.T) {
 
 	assert.Nil(t, cfg.GetStatusDef("nonexistent"))
 }
+
+func TestOrderStatusConfig_CategoryOf(t *testing.T) {
+	cfg := OrderStatusConfig{Statuses: []StatusDef{
+		{Key: "new", Label: "Nowe"},
+		{Key: "packing", Label: "Pakowanie", Category: "processing"},
+		{Key: "rejected", Label: "Odrzucone", Category: "cancelled"},
+	}}
+
+	assert.Equal(t, "new", cfg.CategoryOf("new"))
+	assert.Equal(t, "processing", cfg.CategoryOf("packing"))
+	assert.Equal(t, "cancelled", cfg.CategoryOf("rejected"))
+	assert.Equal(t, "processing", cfg.CategoryOf("custom"))
+}
+
+func TestOrderStatusConfig_PackedStatuses(t *testing.T) {
+	cfg := OrderStatusConfig{Statuses: []StatusDef{
+		{Key: "new", Label: "Nowe"},
+		{Key: "packing", Label: "Pakowanie", Category: "processing"},
+		{Key: "sent", Label: "Wysłane", Category: "shipped"},
+		{Key: "rejected", Label: "Odrzucone", Category: "cancelled"},
+	}}
+	assert.Equal(t, []string{"sent", "rejected"}, cfg.PackedStatuses())
+
+	defaults := DefaultOrderStatusConfig()
+	assert.ElementsMatch(t, []string{"shipped", "in_transit", "out_for_delivery", "delivered", "completed", "on_hold", "cancelled", "refunded"}, defaults.PackedStatuses())
+}
+
+func TestOrderStatusConfig_Validate(t *testing.T) {
+	cfg := DefaultOrderStatusConfig()
+	require.NoError(t, cfg.Validate())
+
+	cfg.Statuses[2].Requires = []string{"paid"}
+	require.NoError(t, cfg.Validate())
+
+	cfg.Statuses[2].Requires = []string{"vip_customer"}
+	assert.ErrorContains(t, cfg.Validate(), "unknown guard")
+
+	cfg = DefaultOrderStatusConfig()
+	cfg.Statuses[0].Category = "archived"
+	assert.ErrorContains(t, cfg.Validate(), "unknown category")
+
+	cfg = DefaultOrderStatusConfig()
+	cfg.Statuses[0].Label = ""
+	assert.ErrorContains(t, cfg.Validate(), "key and label are required")
+}
+
+func TestOrderStatusConfig_DropUnknownTransitions(t *testing.T) {
+	cfg := DefaultOrderStatusConfig()
+	cfg.Transitions["new"] = append(cfg.Transitions["new"], "legacy")
+	cfg.Transitions["archived"] = []string{"new"}
+	require.Error(t, cfg.Validate())
+
+	dropped := cfg.DropUnknownTransitions()
+	assert.Equal(t, []string{"archived -> new", "new -> legacy"}, dropped)
+	assert.True(t, cfg.CanTransition("new", "confirmed"))
+	assert.False(t, cfg.CanTransition("new", "legacy"))
+	assert.NotContains(t, cfg.Transitions, "archived")
+	require.NoError(t, cfg.Validate())
+
+	assert.Empty(t, cfg.DropUnknownTransitions())
+}
+
+func TestOrderStatusConfig_ExcludedFromSales(t *testing.T) {
+	cfg := DefaultOrderStatusConfig()
+	cfg.Statuses = append(cfg.Statuses, StatusDef{Key: "rejected", Label: "Odrzucone", Category: "cancelled"})
+
+	excluded := cfg.ExcludedFromSales()
+	assert.ElementsMatch(t, []string{"cancelled", "refunded", "merged", "split", "rejected"}, excluded)
+}
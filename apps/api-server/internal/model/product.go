@@ -18,6 +18,7 @@ type Product struct {
 	SKU              *string         `json:"sku,omitempty"`
 	EAN              *string         `json:"ean,omitempty"`
 	Price            float64         `json:"price"`
+	CostPrice        *float64        `json:"cost_price,omitempty"`
 	StockQuantity    int             `json:"stock_quantity"`
 	Metadata         json.RawMessage `json:"metadata"`
 	Tags             []string        `json:"tags"`
@@ -43,6 +44,7 @@ type CreateProductRequest struct {
 	SKU              *string         `json:"sku,omitempty"`
 	EAN              *string         `json:"ean,omitempty"`
 	Price            float64         `json:"price"`
+	CostPrice        *float64        `json:"cost_price,omitempty"`
 	StockQty         int             `json:"stock_quantity"`
 	Metadata         json.RawMessage `json:"metadata,omitempty"`
 	Tags             []string        `json:"tags,omitempty"`
@@ -64,14 +66,17 @@ func (r *CreateProductRequest) Validate() error {
 	switch r.Source {
 	case "":
 		r.Source = "manual"
-	case "allegro", "woocommerce", "manual":
+	case "allegro", "woocommerce", "shopify", "prestashop", "manual":
 		// valid
 	default:
-		return errors.New("source must be one of: allegro, woocommerce, manual")
+		return errors.New("source must be one of: allegro, woocommerce, shopify, prestashop, manual")
 	}
 	if r.Price < 0 {
 		return errors.New("price must not be negative")
 	}
+	if r.CostPrice != nil && *r.CostPrice < 0 {
+		return errors.New("cost_price must not be negative")
+	}
 	if r.StockQty < 0 {
 		return errors.New("stock_quantity must not be negative")
 	}
@@ -103,6 +108,7 @@ type UpdateProductRequest struct {
 	SKU              *string          `json:"sku,omitempty"`
 	EAN              *string          `json:"ean,omitempty"`
 	Price            *float64         `json:"price,omitempty"`
+	CostPrice        *float64         `json:"cost_price,omitempty"`
 	StockQuantity    *int             `json:"stock_quantity,omitempty"`
 	Metadata         *json.RawMessage `json:"metadata,omitempty"`
 	Tags             *[]string        `json:"tags,omitempty"`
@@ -120,7 +126,7 @@ type UpdateProductRequest struct {
 
 func (r *UpdateProductRequest) Validate() error {
 	if r.ExternalID == nil && r.Source == nil && r.Name == nil && r.SKU == nil &&
-		r.EAN == nil && r.Price == nil && r.StockQuantity == nil && r.Metadata == nil &&
+		r.EAN == nil && r.Price == nil && r.CostPrice == nil && r.StockQuantity == nil && r.Metadata == nil &&
 		r.Tags == nil && r.DescriptionShort == nil && r.DescriptionLong == nil &&
 		r.Weight == nil && r.Width == nil && r.Height == nil && r.Depth == nil &&
 		r.Category == nil && r.ImageURL == nil && r.Images == nil && r.IsBundle == nil {
@@ -128,15 +134,18 @@ func (r *UpdateProductRequest) Validate() error {
 	}
 	if r.Source != nil {
 		switch *r.Source {
-		case "allegro", "woocommerce", "manual":
+		case "allegro", "woocommerce", "shopify", "prestashop", "manual":
 			// valid
 		default:
-			return errors.New("source must be one of: allegro, woocommerce, manual")
+			return errors.New("source must be one of: allegro, woocommerce, shopify, prestashop, manual")
 		}
 	}
 	if r.Price != nil && *r.Price < 0 {
 		return errors.New("price must not be negative")
 	}
+	if r.CostPrice != nil && *r.CostPrice < 0 {
+		return errors.New("cost_price must not be negative")
+	}
 	if r.StockQuantity != nil && *r.StockQuantity < 0 {
 		return errors.New("stock_quantity must not be negative")
 	}
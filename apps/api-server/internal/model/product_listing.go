@@ -8,33 +8,40 @@ import (
 	"github.com/google/uuid"
 )
 
+// ProductListing links a product, or a single variant of it when VariantID is
+// set, to an external marketplace offer.
 type ProductListing struct {
-	ID            uuid.UUID       `json:"id"`
-	TenantID      uuid.UUID       `json:"tenant_id"`
-	ProductID     uuid.UUID       `json:"product_id"`
-	IntegrationID uuid.UUID       `json:"integration_id"`
-	ExternalID    *string         `json:"external_id,omitempty"`
-	Status        string          `json:"status"`
-	URL           *string         `json:"url,omitempty"`
-	PriceOverride *float64        `json:"price_override,omitempty"`
-	StockOverride *int            `json:"stock_override,omitempty"`
-	SyncStatus    string          `json:"sync_status"`
-	LastSyncedAt  *time.Time      `json:"last_synced_at,omitempty"`
-	ErrorMessage  *string         `json:"error_message,omitempty"`
-	Metadata      json.RawMessage `json:"metadata"`
-	CreatedAt     time.Time       `json:"created_at"`
-	UpdatedAt     time.Time       `json:"updated_at"`
+	ID              uuid.UUID       `json:"id"`
+	TenantID        uuid.UUID       `json:"tenant_id"`
+	ProductID       uuid.UUID       `json:"product_id"`
+	VariantID       *uuid.UUID      `json:"variant_id,omitempty"`
+	IntegrationID   uuid.UUID       `json:"integration_id"`
+	ExternalID      *string         `json:"external_id,omitempty"`
+	ExternalGroupID *string         `json:"external_group_id,omitempty"`
+	Status          string          `json:"status"`
+	URL             *string         `json:"url,omitempty"`
+	PriceOverride   *float64        `json:"price_override,omitempty"`
+	StockOverride   *int            `json:"stock_override,omitempty"`
+	SyncStatus      string          `json:"sync_status"`
+	LastSyncedAt    *time.Time      `json:"last_synced_at,omitempty"`
+	LastSyncedPrice *float64        `json:"last_synced_price,omitempty"`
+	ErrorMessage    *string         `json:"error_message,omitempty"`
+	Metadata        json.RawMessage `json:"metadata"`
+	CreatedAt       time.Time       `json:"created_at"`
+	UpdatedAt       time.Time       `json:"updated_at"`
 }
 
 type CreateProductListingRequest struct {
-	ProductID     uuid.UUID       `json:"product_id"`
-	IntegrationID uuid.UUID       `json:"integration_id"`
-	ExternalID    *string         `json:"external_id,omitempty"`
-	Status        string          `json:"status,omitempty"`
-	URL           *string         `json:"url,omitempty"`
-	PriceOverride *float64        `json:"price_override,omitempty"`
-	StockOverride *int            `json:"stock_override,omitempty"`
-	Metadata      json.RawMessage `json:"metadata,omitempty"`
+	ProductID       uuid.UUID       `json:"product_id"`
+	VariantID       *uuid.UUID      `json:"variant_id,omitempty"`
+	IntegrationID   uuid.UUID       `json:"integration_id"`
+	ExternalID      *string         `json:"external_id,omitempty"`
+	ExternalGroupID *string         `json:"external_group_id,omitempty"`
+	Status          string          `json:"status,omitempty"`
+	URL             *string         `json:"url,omitempty"`
+	PriceOverride   *float64        `json:"price_override,omitempty"`
+	StockOverride   *int            `json:"stock_override,omitempty"`
+	Metadata        json.RawMessage `json:"metadata,omitempty"`
 }
 
 func (r *CreateProductListingRequest) Validate() error {
@@ -44,6 +51,15 @@ func (r *CreateProductListingRequest) Validate() error {
 	if r.IntegrationID == uuid.Nil {
 		return errors.New("integration_id is required")
 	}
+	if r.VariantID != nil && *r.VariantID == uuid.Nil {
+		return errors.New("variant_id must be a valid UUID")
+	}
+	if r.PriceOverride != nil && *r.PriceOverride < 0 {
+		return errors.New("price_override must not be negative")
+	}
+	if r.StockOverride != nil && *r.StockOverride < 0 {
+		return errors.New("stock_override must not be negative")
+	}
 	if r.Status == "" {
 		r.Status = "pending"
 	}
@@ -51,18 +67,19 @@ func (r *CreateProductListingRequest) Validate() error {
 }
 
 type UpdateProductListingRequest struct {
-	ExternalID    *string          `json:"external_id,omitempty"`
-	Status        *string          `json:"status,omitempty"`
-	URL           *string          `json:"url,omitempty"`
-	PriceOverride *float64         `json:"price_override,omitempty"`
-	StockOverride *int             `json:"stock_override,omitempty"`
-	SyncStatus    *string          `json:"sync_status,omitempty"`
-	ErrorMessage  *string          `json:"error_message,omitempty"`
-	Metadata      *json.RawMessage `json:"metadata,omitempty"`
+	ExternalID      *string          `json:"external_id,omitempty"`
+	ExternalGroupID *string          `json:"external_group_id,omitempty"`
+	Status          *string          `json:"status,omitempty"`
+	URL             *string          `json:"url,omitempty"`
+	PriceOverride   *float64         `json:"price_override,omitempty"`
+	StockOverride   *int             `json:"stock_override,omitempty"`
+	SyncStatus      *string          `json:"sync_status,omitempty"`
+	ErrorMessage    *string          `json:"error_message,omitempty"`
+	Metadata        *json.RawMessage `json:"metadata,omitempty"`
 }
 
 func (r *UpdateProductListingRequest) Validate() error {
-	if r.ExternalID == nil && r.Status == nil && r.URL == nil &&
+	if r.ExternalID == nil && r.ExternalGroupID == nil && r.Status == nil && r.URL == nil &&
 		r.PriceOverride == nil && r.StockOverride == nil &&
 		r.SyncStatus == nil && r.ErrorMessage == nil && r.Metadata == nil {
 		return errors.New("at least one field must be provided")
@@ -73,7 +90,7 @@ func (r *UpdateProductListingRequest) Validate() error {
 type SyncJob struct {
 	ID             uuid.UUID       `json:"id"`
 	TenantID       uuid.UUID       `json:"tenant_id"`
-	IntegrationID  uuid.UUID       `json:"integration_id"`
+	IntegrationID  *uuid.UUID      `json:"integration_id,omitempty"`
 	JobType        string          `json:"job_type"`
 	Status         string          `json:"status"`
 	StartedAt      *time.Time      `json:"started_at,omitempty"`
@@ -91,3 +108,22 @@ type SyncJobListFilter struct {
 	Status        *string
 	PaginationParams
 }
+
+// SyncJobTypeCatalogImport is the job type of catalog imports from a sales channel.
+const SyncJobTypeCatalogImport = "catalog_import"
+
+// CatalogImportSummary is stored as the metadata of a catalog import sync job
+// and updated after every imported page.
+type CatalogImportSummary struct {
+	ProductsCreated int `json:"products_created"`
+	ProductsMatched int `json:"products_matched"`
+	VariantsCreated int `json:"variants_created"`
+	VariantsMatched int `json:"variants_matched"`
+	ListingsCreated int `json:"listings_created"`
+	AlreadyListed   int `json:"already_listed"`
+	// UnmatchedVariants are external variant IDs of existing products that
+	// have no variant with the same SKU or EAN; they are not created.
+	UnmatchedVariants []string `json:"unmatched_variants,omitempty"`
+	Errors            []string `json:"errors,omitempty"`
+	Cursor            string   `json:"cursor,omitempty"`
+}
@@ -0,0 +1,152 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Profitability report groupings.
+const (
+	ProfitabilityByOrder   = "order"
+	ProfitabilityBySKU     = "sku"
+	ProfitabilityByChannel = "channel"
+	ProfitabilityByPeriod  = "period"
+)
+
+var (
+	validProfitabilityGroups  = []string{ProfitabilityByOrder, ProfitabilityBySKU, ProfitabilityByChannel, ProfitabilityByPeriod}
+	validProfitabilityPeriods = []string{"day", "week", "month"}
+)
+
+// MaxProfitabilityRows bounds the rows of a report grouped by order or SKU;
+// the CSV export always reads this many.
+const MaxProfitabilityRows = 10000
+
+// ProfitabilityExcludedStatuses are order statuses that never count as sales.
+var ProfitabilityExcludedStatuses = []string{"cancelled", "refunded", "merged", "split"}
+
+// ProfitabilityFilter selects the orders of a profitability report. Only
+// orders in Currency are included; product, shipping and fee costs are
+// assumed to be in the same currency.
+type ProfitabilityFilter struct {
+	GroupBy  string
+	Period   string
+	From     *time.Time
+	To       *time.Time
+	Currency string
+	Limit    int
+	// ExcludedStatuses are the statuses that do not count as sales; empty
+	// means ProfitabilityExcludedStatuses.
+	ExcludedStatuses []string
+}
+
+// ParseProfitabilityFilter parses the query parameters of the profitability
+// report:
+//
+//	group_by  order, sku, channel (default) or period
+//	period    day, week or month (default); used with group_by=period
+//	from, to  order date (YYYY-MM-DD or RFC 3339), to is inclusive for dates
+//	currency  ISO code, default PLN
+//	limit     rows for group_by=order and sku, default 500
+func ParseProfitabilityFilter(q url.Values) (ProfitabilityFilter, error) {
+	f := ProfitabilityFilter{
+		GroupBy:  ProfitabilityByChannel,
+		Period:   "month",
+		Currency: "PLN",
+		Limit:    500,
+	}
+
+	if s := q.Get("group_by"); s != "" {
+		if !slices.Contains(validProfitabilityGroups, s) {
+			return f, fmt.Errorf("group_by must be one of: %s", strings.Join(validProfitabilityGroups, ", "))
+		}
+		f.GroupBy = s
+	}
+	if s := q.Get("period"); s != "" {
+		if !slices.Contains(validProfitabilityPeriods, s) {
+			return f, fmt.Errorf("period must be one of: %s", strings.Join(validProfitabilityPeriods, ", "))
+		}
+		f.Period = s
+	}
+	if s := q.Get("from"); s != "" {
+		t, _, err := parseFilterTime(s)
+		if err != nil {
+			return f, errors.New("invalid from: expected YYYY-MM-DD or RFC 3339")
+		}
+		f.From = &t
+	}
+	if s := q.Get("to"); s != "" {
+		t, dateOnly, err := parseFilterTime(s)
+		if err != nil {
+			return f, errors.New("invalid to: expected YYYY-MM-DD or RFC 3339")
+		}
+		if dateOnly {
+			t = t.AddDate(0, 0, 1)
+		}
+		f.To = &t
+	}
+	if f.From != nil && f.To != nil && !f.From.Before(*f.To) {
+		return f, errors.New("from must be before to")
+	}
+	if s := q.Get("currency"); s != "" {
+		s = strings.ToUpper(s)
+		if len(s) != 3 {
+			return f, errors.New("currency must be a 3-letter ISO code")
+		}
+		f.Currency = s
+	}
+	if s := q.Get("limit"); s != "" {
+		limit, err := strconv.Atoi(s)
+		if err != nil || limit < 1 || limit > MaxProfitabilityRows {
+			return f, fmt.Errorf("limit must be between 1 and %d", MaxProfitabilityRows)
+		}
+		f.Limit = limit
+	}
+	return f, nil
+}
+
+// ProfitabilityRow is one group of a profitability report. Rows grouped by SKU
+// carry only line revenue and product cost; shipping and marketplace fees are
+// charged per order. MissingCostLines counts order lines without a known
+// purchase cost, which are counted at zero cost.
+type ProfitabilityRow struct {
+	Key              string   `json:"key"`
+	Label            string   `json:"label"`
+	Orders           int      `json:"orders"`
+	Quantity         int      `json:"quantity"`
+	Revenue          float64  `json:"revenue"`
+	ProductCost      float64  `json:"product_cost"`
+	ShippingCost     float64  `json:"shipping_cost"`
+	MarketplaceFee   float64  `json:"marketplace_fee"`
+	GrossMargin      float64  `json:"gross_margin"`
+	MarginPercent    *float64 `json:"margin_percent"`
+	MissingCostLines int      `json:"missing_cost_lines"`
+}
+
+// ComputeMargin fills GrossMargin and MarginPercent from the revenue and
+// costs. MarginPercent stays nil without revenue.
+func (r *ProfitabilityRow) ComputeMargin() {
+	r.GrossMargin = roundMoney(r.Revenue - r.ProductCost - r.ShippingCost - r.MarketplaceFee)
+	r.MarginPercent = nil
+	if r.Revenue != 0 {
+		pct := math.Round(r.GrossMargin/r.Revenue*10000) / 100
+		r.MarginPercent = &pct
+	}
+}
+
+// ProfitabilityReport is the gross margin report returned by
+// GET /v1/stats/profitability. Totals always cover whole orders, including
+// shipping and fees, regardless of the grouping.
+type ProfitabilityReport struct {
+	GroupBy  string             `json:"group_by"`
+	Period   string             `json:"period,omitempty"`
+	Currency string             `json:"currency"`
+	Rows     []ProfitabilityRow `json:"rows"`
+	Totals   ProfitabilityRow   `json:"totals"`
+}
@@ -0,0 +1,75 @@
+package model
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseProfitabilityFilter_Defaults(t *testing.T) {
+	f, err := ParseProfitabilityFilter(url.Values{})
+	require.NoError(t, err)
+	assert.Equal(t, ProfitabilityByChannel, f.GroupBy)
+	assert.Equal(t, "month", f.Period)
+	assert.Equal(t, "PLN", f.Currency)
+	assert.Equal(t, 500, f.Limit)
+	assert.Nil(t, f.From)
+	assert.Nil(t, f.To)
+}
+
+func TestParseProfitabilityFilter(t *testing.T) {
+	q := url.Values{}
+	q.Set("group_by", "period")
+	q.Set("period", "week")
+	q.Set("from", "2026-03-01")
+	q.Set("to", "2026-03-31")
+	q.Set("currency", "eur")
+	q.Set("limit", "100")
+
+	f, err := ParseProfitabilityFilter(q)
+	require.NoError(t, err)
+	assert.Equal(t, ProfitabilityByPeriod, f.GroupBy)
+	assert.Equal(t, "week", f.Period)
+	assert.Equal(t, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), *f.From)
+	assert.Equal(t, time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC), *f.To, "date-only upper bound covers the whole day")
+	assert.Equal(t, "EUR", f.Currency)
+	assert.Equal(t, 100, f.Limit)
+}
+
+func TestParseProfitabilityFilter_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		q       url.Values
+		wantErr string
+	}{
+		{"unknown group", url.Values{"group_by": {"customer"}}, "group_by must be one of"},
+		{"unknown period", url.Values{"period": {"year"}}, "period must be one of"},
+		{"bad from", url.Values{"from": {"yesterday"}}, "invalid from"},
+		{"reversed range", url.Values{"from": {"2026-02-01"}, "to": {"2026-01-01"}}, "from must be before to"},
+		{"bad currency", url.Values{"currency": {"zloty"}}, "currency must be"},
+		{"limit too high", url.Values{"limit": {"100000"}}, "limit must be between"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseProfitabilityFilter(tt.q)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestProfitabilityRow_ComputeMargin(t *testing.T) {
+	r := ProfitabilityRow{Revenue: 200, ProductCost: 120, ShippingCost: 15.5, MarketplaceFee: 20.25}
+	r.ComputeMargin()
+	assert.Equal(t, 44.25, r.GrossMargin)
+	require.NotNil(t, r.MarginPercent)
+	assert.Equal(t, 22.13, *r.MarginPercent)
+
+	empty := ProfitabilityRow{ShippingCost: 10}
+	empty.ComputeMargin()
+	assert.Equal(t, -10.0, empty.GrossMargin)
+	assert.Nil(t, empty.MarginPercent)
+}
@@ -3,17 +3,23 @@ package model
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
+	"math"
 	"slices"
 	"time"
 
 	"github.com/google/uuid"
+
+	engine "github.com/openoms-org/openoms/packages/order-engine"
 )
 
 type Return struct {
-	ID            uuid.UUID       `json:"id"`
-	TenantID      uuid.UUID       `json:"tenant_id"`
-	OrderID       uuid.UUID       `json:"order_id"`
-	Status        string          `json:"status"`
+	ID       uuid.UUID `json:"id"`
+	TenantID uuid.UUID `json:"tenant_id"`
+	OrderID  uuid.UUID `json:"order_id"`
+	Status   string    `json:"status"`
+	// ReturnType is refund (money back) or exchange (replacement order).
+	ReturnType    string          `json:"return_type"`
 	Reason        string          `json:"reason"`
 	Items         json.RawMessage `json:"items"`
 	RefundAmount  float64         `json:"refund_amount"`
@@ -21,8 +27,203 @@ type Return struct {
 	ReturnToken   *string         `json:"return_token,omitempty"`
 	CustomerEmail *string         `json:"customer_email,omitempty"`
 	CustomerNotes *string         `json:"customer_notes,omitempty"`
-	CreatedAt     time.Time       `json:"created_at"`
-	UpdatedAt     time.Time       `json:"updated_at"`
+	// ReceivedItems holds the ReturnReceivedItem lines recorded at receipt.
+	ReceivedItems     json.RawMessage `json:"received_items"`
+	ReceivedAt        *time.Time      `json:"received_at,omitempty"`
+	RestockDocumentID *uuid.UUID      `json:"restock_document_id,omitempty"`
+	ShippingRefund    float64         `json:"shipping_refund"`
+	RefundMethod      *string         `json:"refund_method,omitempty"`
+	RefundReference   *string         `json:"refund_reference,omitempty"`
+	RefundedAt        *time.Time      `json:"refunded_at,omitempty"`
+	// RefundedAmount is the sum of the refunds made so far; it is below
+	// RefundAmount while the return is partially refunded.
+	RefundedAmount float64 `json:"refunded_amount"`
+	// RefundKey identifies a channel refund in progress: it is set before the
+	// channel is called and cleared once the refund is recorded or rejected.
+	RefundKey          *uuid.UUID `json:"refund_key,omitempty"`
+	RefundStartedAt    *time.Time `json:"refund_started_at,omitempty"`
+	ReplacementOrderID *uuid.UUID `json:"replacement_order_id,omitempty"`
+	// GradingPending is true for a return received from carrier tracking
+	// whose lines the warehouse has not graded yet.
+	GradingPending bool `json:"grading_pending"`
+	// Prepaid return shipment sent by the customer to the warehouse.
+	ReturnProvider       *string    `json:"return_provider,omitempty"`
+	ReturnIntegrationID  *uuid.UUID `json:"return_integration_id,omitempty"`
+	ReturnExternalID     *string    `json:"return_external_id,omitempty"`
+	ReturnTrackingNumber *string    `json:"return_tracking_number,omitempty"`
+	ReturnLabelURL       *string    `json:"return_label_url,omitempty"`
+	ReturnShipmentStatus *string    `json:"return_shipment_status,omitempty"`
+	CreatedAt            time.Time  `json:"created_at"`
+	UpdatedAt            time.Time  `json:"updated_at"`
+}
+
+// Condition grades of returned items, recorded at receipt.
+const (
+	ReturnConditionResellable = "resellable"
+	ReturnConditionDamaged    = "damaged"
+	ReturnConditionToSupplier = "to_supplier"
+)
+
+var validReturnConditions = map[string]bool{
+	ReturnConditionResellable: true,
+	ReturnConditionDamaged:    true,
+	ReturnConditionToSupplier: true,
+}
+
+// Refund methods: through the sales channel (e.g. Allegro) or a manual bank transfer.
+const (
+	RefundMethodChannel = "channel"
+	RefundMethodManual  = "manual"
+)
+
+// Shipping refund policies of ReturnSettings.
+const (
+	ShippingRefundNever      = "never"
+	ShippingRefundFullReturn = "full_return"
+	ShippingRefundAlways     = "always"
+)
+
+// ReturnSettings controls how received returns are restocked and refunded.
+type ReturnSettings struct {
+	// ShippingRefund decides when the original shipping cost is refunded:
+	// never, only when the whole order is returned (default), or always.
+	ShippingRefund     string     `json:"shipping_refund"`
+	DefaultWarehouseID *uuid.UUID `json:"default_warehouse_id,omitempty"`
+	// ReturnCarrier enables prepaid return labels issued on approval.
+	ReturnCarrier *ReturnCarrierSettings `json:"return_carrier,omitempty"`
+}
+
+func (s ReturnSettings) Validate() error {
+	switch s.ShippingRefund {
+	case "", ShippingRefundNever, ShippingRefundFullReturn, ShippingRefundAlways:
+	default:
+		return fmt.Errorf("shipping_refund must be one of: %s, %s, %s", ShippingRefundNever, ShippingRefundFullReturn, ShippingRefundAlways)
+	}
+	if s.ReturnCarrier != nil {
+		return s.ReturnCarrier.Validate()
+	}
+	return nil
+}
+
+// ReturnCarrierProviders lists the carriers that can issue prepaid return labels.
+var ReturnCarrierProviders = []string{"inpost", "dpd", "orlen_paczka"}
+
+// ReturnCarrierSettings configures the carrier used for prepaid return labels.
+// The customer is the sender and Receiver is the warehouse taking returns in.
+// An empty Provider disables return labels.
+type ReturnCarrierSettings struct {
+	Provider    string `json:"provider"`
+	ServiceType string `json:"service_type,omitempty"`
+	// SendingMethod is how the customer hands the parcel over, e.g.
+	// parcel_locker for InPost locker-to-warehouse returns.
+	SendingMethod string `json:"sending_method,omitempty"`
+	// TargetPoint is the pickup point receiving returns (required by Orlen Paczka).
+	TargetPoint string          `json:"target_point,omitempty"`
+	ParcelSize  string          `json:"parcel_size,omitempty"`
+	WeightKg    float64         `json:"weight_kg,omitempty"`
+	Receiver    ShippingAddress `json:"receiver"`
+}
+
+// Enabled reports whether return labels are configured.
+func (c *ReturnCarrierSettings) Enabled() bool {
+	return c != nil && c.Provider != ""
+}
+
+func (c ReturnCarrierSettings) Validate() error {
+	if c.Provider == "" {
+		return nil
+	}
+	if !slices.Contains(ReturnCarrierProviders, c.Provider) {
+		return fmt.Errorf("return_carrier.provider must be one of: inpost, dpd, orlen_paczka")
+	}
+	if c.Receiver.Name == "" || c.Receiver.Phone == "" {
+		return errors.New("return_carrier.receiver name and phone are required")
+	}
+	if c.Provider == "orlen_paczka" {
+		if c.TargetPoint == "" {
+			return errors.New("return_carrier.target_point is required for orlen_paczka")
+		}
+	} else if c.Receiver.Street == "" || c.Receiver.City == "" || c.Receiver.PostalCode == "" {
+		return errors.New("return_carrier.receiver street, city and postal_code are required")
+	}
+	if c.WeightKg < 0 {
+		return errors.New("return_carrier.weight_kg must be non-negative")
+	}
+	return nil
+}
+
+// ReturnLabel is the prepaid return shipment created for a return.
+type ReturnLabel struct {
+	Provider       string
+	IntegrationID  *uuid.UUID
+	ExternalID     string
+	TrackingNumber string
+	LabelURL       string
+	Status         string
+}
+
+// ShippingRefundPolicy returns the configured policy, defaulting to full_return.
+func (s ReturnSettings) ShippingRefundPolicy() string {
+	if s.ShippingRefund == "" {
+		return ShippingRefundFullReturn
+	}
+	return s.ShippingRefund
+}
+
+// ReturnReceivedItem is a single line of a received return, copied from the
+// order item it refers to and graded by the warehouse.
+type ReturnReceivedItem struct {
+	OrderItemIndex int        `json:"order_item_index"`
+	ProductID      *uuid.UUID `json:"product_id,omitempty"`
+	VariantID      *uuid.UUID `json:"variant_id,omitempty"`
+	Name           string     `json:"name"`
+	SKU            string     `json:"sku,omitempty"`
+	ExternalID     string     `json:"external_id,omitempty"`
+	Quantity       int        `json:"quantity"`
+	UnitPrice      float64    `json:"unit_price"`
+	Condition      string     `json:"condition"`
+	Deduction      float64    `json:"deduction,omitempty"`
+	Notes          string     `json:"notes,omitempty"`
+}
+
+// RefundValue is the amount refunded for the line: quantity times unit price
+// less the deduction for diminished value, never below zero.
+func (i ReturnReceivedItem) RefundValue() float64 {
+	return roundMoney(math.Max(0, float64(i.Quantity)*i.UnitPrice-i.Deduction))
+}
+
+// ReturnRefund is the refund breakdown of a received return.
+type ReturnRefund struct {
+	Items    float64 `json:"items"`
+	Shipping float64 `json:"shipping"`
+	Total    float64 `json:"total"`
+}
+
+// CalculateReturnRefund computes the refund of the received lines. shippingCost
+// is the shipping paid on the order; it is added according to the policy,
+// where full_return requires every ordered unit to be back.
+func CalculateReturnRefund(items []ReturnReceivedItem, shippingCost float64, fullReturn bool, policy string) ReturnRefund {
+	var r ReturnRefund
+	for _, item := range items {
+		r.Items += item.RefundValue()
+	}
+	r.Items = roundMoney(r.Items)
+
+	switch policy {
+	case ShippingRefundAlways:
+		r.Shipping = shippingCost
+	case ShippingRefundFullReturn:
+		if fullReturn {
+			r.Shipping = shippingCost
+		}
+	}
+	r.Shipping = roundMoney(math.Max(0, r.Shipping))
+	r.Total = roundMoney(r.Items + r.Shipping)
+	return r
+}
+
+func roundMoney(v float64) float64 {
+	return math.Round(v*100) / 100
 }
 
 // PublicReturnRequest is the request body for creating a return via the public self-service endpoint.
@@ -59,6 +260,8 @@ type CreateReturnRequest struct {
 	Items        json.RawMessage `json:"items,omitempty"`
 	RefundAmount float64         `json:"refund_amount"`
 	Notes        *string         `json:"notes,omitempty"`
+	// ReturnType defaults to refund.
+	ReturnType string `json:"return_type,omitempty"`
 }
 
 func (r CreateReturnRequest) Validate() error {
@@ -71,6 +274,9 @@ func (r CreateReturnRequest) Validate() error {
 	if r.RefundAmount < 0 {
 		return errors.New("refund_amount must be non-negative")
 	}
+	if r.ReturnType != "" && !engine.ReturnType(r.ReturnType).Valid() {
+		return fmt.Errorf("return_type must be one of: %s, %s", engine.ReturnTypeRefund, engine.ReturnTypeExchange)
+	}
 	if err := validateMaxLength("reason", r.Reason, 2000); err != nil {
 		return err
 	}
@@ -97,6 +303,86 @@ func (r UpdateReturnRequest) Validate() error {
 	return nil
 }
 
+// ReceiveReturnItem grades a quantity of one order line at receipt.
+type ReceiveReturnItem struct {
+	OrderItemIndex int     `json:"order_item_index"`
+	Quantity       int     `json:"quantity"`
+	Condition      string  `json:"condition"`
+	Deduction      float64 `json:"deduction,omitempty"`
+	Notes          string  `json:"notes,omitempty"`
+}
+
+// ReceiveReturnRequest records the receipt of a return. Resellable items are
+// restocked into WarehouseID, falling back to the default from ReturnSettings.
+type ReceiveReturnRequest struct {
+	Items       []ReceiveReturnItem `json:"items"`
+	WarehouseID *uuid.UUID          `json:"warehouse_id,omitempty"`
+}
+
+func (r ReceiveReturnRequest) Validate() error {
+	if len(r.Items) == 0 {
+		return errors.New("at least one item is required")
+	}
+	for i, item := range r.Items {
+		if item.OrderItemIndex < 0 {
+			return fmt.Errorf("items[%d]: order_item_index must be non-negative", i)
+		}
+		if item.Quantity <= 0 {
+			return fmt.Errorf("items[%d]: quantity must be positive", i)
+		}
+		if !validReturnConditions[item.Condition] {
+			return fmt.Errorf("items[%d]: condition must be one of: resellable, damaged, to_supplier", i)
+		}
+		if item.Deduction < 0 {
+			return fmt.Errorf("items[%d]: deduction must be non-negative", i)
+		}
+		if err := validateMaxLength(fmt.Sprintf("items[%d].notes", i), item.Notes, 1000); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RefundReturnRequest executes the refund of a received return. An empty
+// Method refunds through the sales channel when the order's integration
+// supports it and records a manual bank refund otherwise. Amount refunds part
+// of the remaining amount; without it the whole remaining amount is refunded.
+type RefundReturnRequest struct {
+	Method    string   `json:"method,omitempty"`
+	Reference *string  `json:"reference,omitempty"`
+	Amount    *float64 `json:"amount,omitempty"`
+}
+
+func (r RefundReturnRequest) Validate() error {
+	switch r.Method {
+	case "", RefundMethodChannel, RefundMethodManual:
+	default:
+		return fmt.Errorf("method must be one of: %s, %s", RefundMethodChannel, RefundMethodManual)
+	}
+	if r.Amount != nil && *r.Amount <= 0 {
+		return errors.New("amount must be positive")
+	}
+	return validateMaxLengthPtr("reference", r.Reference, 255)
+}
+
+// ExchangeReturnRequest settles a received exchange return with a replacement
+// order. Items are the order items to send; without them the received lines
+// are sent again.
+type ExchangeReturnRequest struct {
+	Items json.RawMessage `json:"items,omitempty"`
+}
+
+func (r ExchangeReturnRequest) Validate() error {
+	if len(r.Items) == 0 {
+		return nil
+	}
+	var items []json.RawMessage
+	if err := json.Unmarshal(r.Items, &items); err != nil || len(items) == 0 {
+		return errors.New("items must be a non-empty array")
+	}
+	return nil
+}
+
 type ReturnStatusRequest struct {
 	Status string `json:"status"`
 }
@@ -107,17 +393,25 @@ type ReturnListFilter struct {
 	PaginationParams
 }
 
-// Valid return status transitions
-var returnTransitions = map[string][]string{
-	"requested": {"approved", "rejected", "cancelled"},
-	"approved":  {"received", "cancelled"},
-	"received":  {"refunded", "cancelled"},
+// IsValidReturnTransition reports whether the return state machine allows
+// moving from one status to another. Guards are not evaluated.
+func IsValidReturnTransition(from, to string) bool {
+	return engine.CanTransitionReturn(engine.ReturnStatus(from), engine.ReturnStatus(to))
 }
 
-func IsValidReturnTransition(from, to string) bool {
-	allowed, ok := returnTransitions[from]
-	if !ok {
-		return false
+// MarkParcelDelivered moves an approved return whose inbound parcel was
+// delivered to received through the return state machine. The warehouse
+// grades the lines later, so nothing is restocked and the return is left
+// pending grading.
+func (r *Return) MarkParcelDelivered(now time.Time) error {
+	facts := engine.ReturnFacts{Type: engine.ReturnType(r.ReturnType)}
+	if _, err := engine.TransitionReturn(engine.ReturnStatus(r.Status), engine.ReturnReceived, facts, now); err != nil {
+		return err
 	}
-	return slices.Contains(allowed, to)
+	delivered := "delivered"
+	r.Status = string(engine.ReturnReceived)
+	r.ReturnShipmentStatus = &delivered
+	r.ReceivedAt = &now
+	r.GradingPending = true
+	return nil
 }
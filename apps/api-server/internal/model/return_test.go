@@ -0,0 +1,103 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReceiveReturnRequest_Validate(t *testing.T) {
+	valid := ReceiveReturnItem{OrderItemIndex: 0, Quantity: 1, Condition: ReturnConditionResellable}
+
+	assert.NoError(t, ReceiveReturnRequest{Items: []ReceiveReturnItem{valid}}.Validate())
+	assert.Error(t, ReceiveReturnRequest{}.Validate())
+
+	bad := valid
+	bad.Quantity = 0
+	assert.ErrorContains(t, ReceiveReturnRequest{Items: []ReceiveReturnItem{bad}}.Validate(), "quantity")
+
+	bad = valid
+	bad.Condition = "broken"
+	assert.ErrorContains(t, ReceiveReturnRequest{Items: []ReceiveReturnItem{bad}}.Validate(), "condition")
+
+	bad = valid
+	bad.Deduction = -5
+	assert.ErrorContains(t, ReceiveReturnRequest{Items: []ReceiveReturnItem{bad}}.Validate(), "deduction")
+}
+
+func TestRefundReturnRequest_Validate(t *testing.T) {
+	assert.NoError(t, RefundReturnRequest{}.Validate())
+	assert.NoError(t, RefundReturnRequest{Method: RefundMethodManual}.Validate())
+	assert.Error(t, RefundReturnRequest{Method: "cash"}.Validate())
+}
+
+func TestReturnSettings(t *testing.T) {
+	assert.Equal(t, ShippingRefundFullReturn, ReturnSettings{}.ShippingRefundPolicy())
+	assert.NoError(t, ReturnSettings{ShippingRefund: ShippingRefundAlways, DefaultWarehouseID: ptrUUID(uuid.New())}.Validate())
+	assert.Error(t, ReturnSettings{ShippingRefund: "sometimes"}.Validate())
+}
+
+func TestReturnCarrierSettings_Validate(t *testing.T) {
+	warehouse := ShippingAddress{Name: "Magazyn", Phone: "500100200", Street: "Magazynowa 1", City: "Poznan", PostalCode: "60-001", Country: "PL"}
+
+	assert.NoError(t, ReturnCarrierSettings{}.Validate(), "disabled carrier is valid")
+	assert.NoError(t, ReturnCarrierSettings{Provider: "inpost", SendingMethod: "parcel_locker", Receiver: warehouse}.Validate())
+	assert.NoError(t, ReturnCarrierSettings{Provider: "dpd", Receiver: warehouse}.Validate())
+	assert.Error(t, ReturnCarrierSettings{Provider: "dhl", Receiver: warehouse}.Validate())
+	assert.Error(t, ReturnCarrierSettings{Provider: "dpd", Receiver: ShippingAddress{Name: "Magazyn", Phone: "500100200"}}.Validate())
+	assert.Error(t, ReturnCarrierSettings{Provider: "orlen_paczka", Receiver: warehouse}.Validate(), "orlen_paczka requires a target point")
+	assert.NoError(t, ReturnCarrierSettings{Provider: "orlen_paczka", TargetPoint: "ORLEN-123", Receiver: ShippingAddress{Name: "Magazyn", Phone: "500100200"}}.Validate())
+
+	assert.Error(t, ReturnSettings{ReturnCarrier: &ReturnCarrierSettings{Provider: "inpost"}}.Validate())
+	var none *ReturnCarrierSettings
+	assert.False(t, none.Enabled())
+	assert.True(t, (&ReturnCarrierSettings{Provider: "dpd"}).Enabled())
+}
+
+func TestCalculateReturnRefund(t *testing.T) {
+	items := []ReturnReceivedItem{
+		{Quantity: 2, UnitPrice: 49.99, Condition: ReturnConditionResellable},
+		{Quantity: 1, UnitPrice: 100, Condition: ReturnConditionDamaged, Deduction: 30},
+	}
+
+	t.Run("partial return keeps shipping", func(t *testing.T) {
+		r := CalculateReturnRefund(items, 15, false, ShippingRefundFullReturn)
+		assert.InDelta(t, 169.98, r.Items, 0.001)
+		assert.Equal(t, 0.0, r.Shipping)
+		assert.InDelta(t, 169.98, r.Total, 0.001)
+	})
+
+	t.Run("full return refunds shipping", func(t *testing.T) {
+		r := CalculateReturnRefund(items, 15, true, ShippingRefundFullReturn)
+		assert.Equal(t, 15.0, r.Shipping)
+		assert.InDelta(t, 184.98, r.Total, 0.001)
+	})
+
+	t.Run("never and always", func(t *testing.T) {
+		assert.Equal(t, 0.0, CalculateReturnRefund(items, 15, true, ShippingRefundNever).Shipping)
+		assert.Equal(t, 15.0, CalculateReturnRefund(items, 15, false, ShippingRefundAlways).Shipping)
+	})
+
+	t.Run("deduction never goes below zero", func(t *testing.T) {
+		item := ReturnReceivedItem{Quantity: 1, UnitPrice: 10, Deduction: 25}
+		assert.Equal(t, 0.0, item.RefundValue())
+	})
+}
+
+func ptrUUID(v uuid.UUID) *uuid.UUID { return &v }
+
+func TestReturn_MarkParcelDelivered(t *testing.T) {
+	now := time.Now()
+	ret := Return{Status: "approved", ReturnType: "refund"}
+	assert.NoError(t, ret.MarkParcelDelivered(now))
+	assert.Equal(t, "received", ret.Status)
+	assert.True(t, ret.GradingPending)
+	if assert.NotNil(t, ret.ReturnShipmentStatus) {
+		assert.Equal(t, "delivered", *ret.ReturnShipmentStatus)
+	}
+
+	requested := Return{Status: "requested", ReturnType: "refund"}
+	assert.Error(t, requested.MarkParcelDelivered(now))
+}
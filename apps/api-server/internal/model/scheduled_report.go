@@ -0,0 +1,283 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/openoms-org/openoms/apps/api-server/internal/cron"
+)
+
+// Scheduled report types.
+const (
+	ReportTypeSalesByChannel = "sales_by_channel"
+	ReportTypeStockValue     = "stock_value"
+	ReportTypeVATSummary     = "vat_summary"
+	ReportTypeProfitability  = "profitability"
+)
+
+// Scheduled report file formats.
+const (
+	ReportFormatCSV  = "csv"
+	ReportFormatXLSX = "xlsx"
+	ReportFormatPDF  = "pdf"
+)
+
+// Report run statuses.
+const (
+	ReportRunSuccess = "success"
+	ReportRunFailed  = "failed"
+)
+
+var (
+	validReportTypes   = []string{ReportTypeSalesByChannel, ReportTypeStockValue, ReportTypeVATSummary, ReportTypeProfitability}
+	validReportFormats = []string{ReportFormatCSV, ReportFormatXLSX, ReportFormatPDF}
+	validReportPeriods = []string{"day", "week", "month"}
+)
+
+// MaxReportRecipients bounds the recipients of a scheduled report.
+const MaxReportRecipients = 20
+
+// DefaultReportTimezone is the timezone of schedules that do not set one.
+const DefaultReportTimezone = "Europe/Warsaw"
+
+// ScheduledReport is a report generated on a cron schedule and emailed to
+// its recipients. Period selects the covered range: the previous full day,
+// week (Monday to Sunday) or month before the run. Stock value reports are a
+// snapshot and ignore it.
+type ScheduledReport struct {
+	ID         uuid.UUID  `json:"id"`
+	TenantID   uuid.UUID  `json:"tenant_id"`
+	Name       string     `json:"name"`
+	ReportType string     `json:"report_type"`
+	Schedule   string     `json:"schedule"`
+	Timezone   string     `json:"timezone"`
+	Period     string     `json:"period"`
+	Format     string     `json:"format"`
+	Recipients []string   `json:"recipients"`
+	Active     bool       `json:"active"`
+	LastRunAt  *time.Time `json:"last_run_at,omitempty"`
+	NextRunAt  *time.Time `json:"next_run_at,omitempty"`
+	CreatedBy  *uuid.UUID `json:"created_by,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+// NextRun returns the first run time of the schedule after t, or nil when
+// the schedule never fires.
+func (r *ScheduledReport) NextRun(t time.Time) (*time.Time, error) {
+	schedule, err := cron.Parse(r.Schedule)
+	if err != nil {
+		return nil, err
+	}
+	next := schedule.Next(t.In(ReportLocation(r.Timezone)))
+	if next.IsZero() {
+		return nil, nil
+	}
+	next = next.UTC()
+	return &next, nil
+}
+
+// ReportLocation loads a report timezone, falling back to UTC.
+func ReportLocation(name string) *time.Location {
+	if name == "" {
+		name = DefaultReportTimezone
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// ReportPeriodWindow returns the previous full day, week or month before t,
+// in t's location. The end is exclusive.
+func ReportPeriodWindow(period string, t time.Time) (from, to time.Time) {
+	today := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	switch period {
+	case "week":
+		daysSinceMonday := (int(today.Weekday()) + 6) % 7
+		to = today.AddDate(0, 0, -daysSinceMonday)
+		return to.AddDate(0, 0, -7), to
+	case "month":
+		to = time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+		return to.AddDate(0, -1, 0), to
+	default:
+		return today.AddDate(0, 0, -1), today
+	}
+}
+
+type CreateScheduledReportRequest struct {
+	Name       string   `json:"name"`
+	ReportType string   `json:"report_type"`
+	Schedule   string   `json:"schedule"`
+	Timezone   string   `json:"timezone,omitempty"`
+	Period     string   `json:"period,omitempty"`
+	Format     string   `json:"format"`
+	Recipients []string `json:"recipients"`
+	Active     *bool    `json:"active,omitempty"`
+}
+
+func (r *CreateScheduledReportRequest) Validate() error {
+	r.Name = strings.TrimSpace(r.Name)
+	if r.Name == "" {
+		return errors.New("name is required")
+	}
+	if err := validateMaxLength("name", r.Name, 200); err != nil {
+		return err
+	}
+	if !slices.Contains(validReportTypes, r.ReportType) {
+		return fmt.Errorf("report_type must be one of: %s", strings.Join(validReportTypes, ", "))
+	}
+	if r.Timezone == "" {
+		r.Timezone = DefaultReportTimezone
+	}
+	if r.Period == "" {
+		r.Period = "day"
+	}
+	if r.Format == "" {
+		r.Format = ReportFormatCSV
+	}
+	var err error
+	if r.Recipients, err = validateReportFields(r.Schedule, r.Timezone, r.Period, r.Format, r.Recipients); err != nil {
+		return err
+	}
+	return nil
+}
+
+// UpdateScheduledReportRequest changes a scheduled report; omitted fields
+// are left unchanged.
+type UpdateScheduledReportRequest struct {
+	Name       *string   `json:"name,omitempty"`
+	ReportType *string   `json:"report_type,omitempty"`
+	Schedule   *string   `json:"schedule,omitempty"`
+	Timezone   *string   `json:"timezone,omitempty"`
+	Period     *string   `json:"period,omitempty"`
+	Format     *string   `json:"format,omitempty"`
+	Recipients *[]string `json:"recipients,omitempty"`
+	Active     *bool     `json:"active,omitempty"`
+}
+
+// Apply validates the update against the current report and returns the
+// updated copy.
+func (r *UpdateScheduledReportRequest) Apply(current ScheduledReport) (ScheduledReport, error) {
+	if r.Name == nil && r.ReportType == nil && r.Schedule == nil && r.Timezone == nil &&
+		r.Period == nil && r.Format == nil && r.Recipients == nil && r.Active == nil {
+		return current, errors.New("at least one field must be provided")
+	}
+
+	updated := current
+	if r.Name != nil {
+		updated.Name = strings.TrimSpace(*r.Name)
+		if updated.Name == "" {
+			return current, errors.New("name is required")
+		}
+		if err := validateMaxLength("name", updated.Name, 200); err != nil {
+			return current, err
+		}
+	}
+	if r.ReportType != nil {
+		if !slices.Contains(validReportTypes, *r.ReportType) {
+			return current, fmt.Errorf("report_type must be one of: %s", strings.Join(validReportTypes, ", "))
+		}
+		updated.ReportType = *r.ReportType
+	}
+	if r.Schedule != nil {
+		updated.Schedule = *r.Schedule
+	}
+	if r.Timezone != nil {
+		updated.Timezone = *r.Timezone
+	}
+	if r.Period != nil {
+		updated.Period = *r.Period
+	}
+	if r.Format != nil {
+		updated.Format = *r.Format
+	}
+	if r.Recipients != nil {
+		updated.Recipients = *r.Recipients
+	}
+	if r.Active != nil {
+		updated.Active = *r.Active
+	}
+
+	recipients, err := validateReportFields(updated.Schedule, updated.Timezone, updated.Period, updated.Format, updated.Recipients)
+	if err != nil {
+		return current, err
+	}
+	updated.Recipients = recipients
+	return updated, nil
+}
+
+// validateReportFields checks the fields shared by create and update and
+// returns the normalized recipients.
+func validateReportFields(schedule, timezone, period, format string, recipients []string) ([]string, error) {
+	if strings.TrimSpace(schedule) == "" {
+		return nil, errors.New("schedule is required")
+	}
+	if _, err := cron.Parse(schedule); err != nil {
+		return nil, fmt.Errorf("invalid schedule: %w", err)
+	}
+	if _, err := time.LoadLocation(timezone); err != nil || timezone == "" {
+		return nil, fmt.Errorf("invalid timezone %q", timezone)
+	}
+	if !slices.Contains(validReportPeriods, period) {
+		return nil, fmt.Errorf("period must be one of: %s", strings.Join(validReportPeriods, ", "))
+	}
+	if !slices.Contains(validReportFormats, format) {
+		return nil, fmt.Errorf("format must be one of: %s", strings.Join(validReportFormats, ", "))
+	}
+
+	var out []string
+	for _, email := range recipients {
+		email = strings.ToLower(strings.TrimSpace(email))
+		if email == "" || slices.Contains(out, email) {
+			continue
+		}
+		if !emailRegex.MatchString(email) {
+			return nil, fmt.Errorf("invalid recipient email %q", email)
+		}
+		out = append(out, email)
+	}
+	if len(out) == 0 {
+		return nil, errors.New("at least one recipient is required")
+	}
+	if len(out) > MaxReportRecipients {
+		return nil, fmt.Errorf("maximum %d recipients", MaxReportRecipients)
+	}
+	return out, nil
+}
+
+// ScheduledReportRun is one generated report, stored in object storage.
+// PeriodFrom and PeriodTo are empty for snapshot reports.
+type ScheduledReportRun struct {
+	ID           uuid.UUID  `json:"id"`
+	TenantID     uuid.UUID  `json:"tenant_id"`
+	ReportID     uuid.UUID  `json:"report_id"`
+	Status       string     `json:"status"`
+	Format       string     `json:"format"`
+	PeriodFrom   *time.Time `json:"period_from,omitempty"`
+	PeriodTo     *time.Time `json:"period_to,omitempty"`
+	Recipients   []string   `json:"recipients"`
+	FileURL      *string    `json:"file_url,omitempty"`
+	ErrorMessage *string    `json:"error_message,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// ReportTable is the generated content of a report before it is rendered
+// to a file. Numeric columns hold float64 or int values.
+type ReportTable struct {
+	Title   string
+	Columns []ReportColumn
+	Rows    [][]any
+}
+
+// ReportColumn is a column of a ReportTable.
+type ReportColumn struct {
+	Title   string
+	Numeric bool
+}
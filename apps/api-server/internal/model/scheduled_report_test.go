@@ -0,0 +1,115 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateScheduledReportRequest_Validate(t *testing.T) {
+	req := CreateScheduledReportRequest{
+		Name:       "  Sprzedaż dzienna ",
+		ReportType: ReportTypeSalesByChannel,
+		Schedule:   "0 7 * * *",
+		Recipients: []string{" Zarzad@Firma.pl", "zarzad@firma.pl", ""},
+	}
+	require.NoError(t, req.Validate())
+	assert.Equal(t, "Sprzedaż dzienna", req.Name)
+	assert.Equal(t, DefaultReportTimezone, req.Timezone)
+	assert.Equal(t, "day", req.Period)
+	assert.Equal(t, ReportFormatCSV, req.Format)
+	assert.Equal(t, []string{"zarzad@firma.pl"}, req.Recipients)
+}
+
+func TestCreateScheduledReportRequest_ValidateErrors(t *testing.T) {
+	valid := func() CreateScheduledReportRequest {
+		return CreateScheduledReportRequest{
+			Name:       "Raport",
+			ReportType: ReportTypeVATSummary,
+			Schedule:   "0 6 1 * *",
+			Period:     "month",
+			Format:     ReportFormatPDF,
+			Recipients: []string{"ksiegowosc@firma.pl"},
+		}
+	}
+	tests := []struct {
+		name    string
+		mutate  func(r *CreateScheduledReportRequest)
+		wantErr string
+	}{
+		{"missing name", func(r *CreateScheduledReportRequest) { r.Name = " " }, "name is required"},
+		{"unknown type", func(r *CreateScheduledReportRequest) { r.ReportType = "orders" }, "report_type must be one of"},
+		{"bad schedule", func(r *CreateScheduledReportRequest) { r.Schedule = "every day" }, "invalid schedule"},
+		{"bad timezone", func(r *CreateScheduledReportRequest) { r.Timezone = "Mars/Olympus" }, "invalid timezone"},
+		{"bad period", func(r *CreateScheduledReportRequest) { r.Period = "year" }, "period must be one of"},
+		{"bad format", func(r *CreateScheduledReportRequest) { r.Format = "docx" }, "format must be one of"},
+		{"bad email", func(r *CreateScheduledReportRequest) { r.Recipients = []string{"zarzad"} }, "invalid recipient email"},
+		{"no recipients", func(r *CreateScheduledReportRequest) { r.Recipients = nil }, "at least one recipient"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := valid()
+			tt.mutate(&req)
+			err := req.Validate()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestUpdateScheduledReportRequest_Apply(t *testing.T) {
+	current := ScheduledReport{
+		Name:       "Raport",
+		ReportType: ReportTypeStockValue,
+		Schedule:   "0 7 * * 1",
+		Timezone:   DefaultReportTimezone,
+		Period:     "week",
+		Format:     ReportFormatXLSX,
+		Recipients: []string{"a@firma.pl"},
+		Active:     true,
+	}
+
+	format := ReportFormatPDF
+	active := false
+	updated, err := (&UpdateScheduledReportRequest{Format: &format, Active: &active}).Apply(current)
+	require.NoError(t, err)
+	assert.Equal(t, ReportFormatPDF, updated.Format)
+	assert.False(t, updated.Active)
+	assert.Equal(t, current.Schedule, updated.Schedule)
+
+	schedule := "61 * * * *"
+	_, err = (&UpdateScheduledReportRequest{Schedule: &schedule}).Apply(current)
+	assert.ErrorContains(t, err, "invalid schedule")
+
+	_, err = (&UpdateScheduledReportRequest{}).Apply(current)
+	assert.ErrorContains(t, err, "at least one field")
+}
+
+func TestScheduledReport_NextRun(t *testing.T) {
+	r := ScheduledReport{Schedule: "0 7 * * *", Timezone: "Europe/Warsaw"}
+	next, err := r.NextRun(time.Date(2026, 7, 1, 12, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.NotNil(t, next)
+	assert.Equal(t, time.Date(2026, 7, 2, 5, 0, 0, 0, time.UTC), *next, "07:00 in Warsaw is 05:00 UTC in summer")
+}
+
+func TestReportPeriodWindow(t *testing.T) {
+	now := time.Date(2026, 3, 18, 7, 0, 0, 0, time.UTC) // Wednesday
+	tests := []struct {
+		period   string
+		from, to time.Time
+	}{
+		{"day", time.Date(2026, 3, 17, 0, 0, 0, 0, time.UTC), time.Date(2026, 3, 18, 0, 0, 0, 0, time.UTC)},
+		{"week", time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC), time.Date(2026, 3, 16, 0, 0, 0, 0, time.UTC)},
+		{"month", time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.period, func(t *testing.T) {
+			from, to := ReportPeriodWindow(tt.period, now)
+			assert.Equal(t, tt.from, from)
+			assert.Equal(t, tt.to, to)
+		})
+	}
+}
@@ -20,6 +20,7 @@ type Shipment struct {
 	LabelURL       *string         `json:"label_url,omitempty"`
 	CarrierData    json.RawMessage `json:"carrier_data,omitempty"`
 	WarehouseID    *uuid.UUID      `json:"warehouse_id,omitempty"`
+	ShippingCost   *float64        `json:"shipping_cost,omitempty"`
 	CreatedAt      time.Time       `json:"created_at"`
 	UpdatedAt      time.Time       `json:"updated_at"`
 }
@@ -32,6 +33,7 @@ type CreateShipmentRequest struct {
 	LabelURL       *string         `json:"label_url,omitempty"`
 	CarrierData    json.RawMessage `json:"carrier_data,omitempty"`
 	WarehouseID    *uuid.UUID      `json:"warehouse_id,omitempty"`
+	ShippingCost   *float64        `json:"shipping_cost,omitempty"`
 }
 
 func (r *CreateShipmentRequest) Validate() error {
@@ -47,19 +49,29 @@ func (r *CreateShipmentRequest) Validate() error {
 	if err := validateMaxLengthPtr("tracking_number", r.TrackingNumber, 200); err != nil {
 		return err
 	}
+	if r.ShippingCost != nil && *r.ShippingCost < 0 {
+		return errors.New("shipping_cost must not be negative")
+	}
 	return nil
 }
 
+// UpdateShipmentRequest updates a shipment. ShippingCost is what the carrier
+// charges the seller; it is estimated from the carrier's rates when a label
+// is generated and can be corrected from the carrier invoice.
 type UpdateShipmentRequest struct {
 	TrackingNumber *string         `json:"tracking_number,omitempty"`
 	LabelURL       *string         `json:"label_url,omitempty"`
 	CarrierData    json.RawMessage `json:"carrier_data,omitempty"`
+	ShippingCost   *float64        `json:"shipping_cost,omitempty"`
 }
 
 func (r *UpdateShipmentRequest) Validate() error {
-	if r.TrackingNumber == nil && r.LabelURL == nil && r.CarrierData == nil {
+	if r.TrackingNumber == nil && r.LabelURL == nil && r.CarrierData == nil && r.ShippingCost == nil {
 		return errors.New("at least one field must be provided")
 	}
+	if r.ShippingCost != nil && *r.ShippingCost < 0 {
+		return errors.New("shipping_cost must not be negative")
+	}
 	return nil
 }
 
@@ -1,5 +1,10 @@
 package model
 
+import (
+	"encoding/json"
+	"strings"
+)
+
 type ShippingAddress struct {
 	Name       string  `json:"name"`
 	Company    *string `json:"company,omitempty"`
@@ -10,3 +15,39 @@ type ShippingAddress struct {
 	Phone      string  `json:"phone,omitempty"`
 	Email      string  `json:"email,omitempty"`
 }
+
+// Codes of address issues.
+const (
+	AddressIssueMissing            = "missing"
+	AddressIssueInvalidFormat      = "invalid_format"
+	AddressIssueCityMismatch       = "city_mismatch"
+	AddressIssueHouseNumberMissing = "house_number_missing"
+)
+
+// AddressIssue is a problem found when validating a shipping address: the
+// field (street, city, postal_code, country, phone or customer_phone), the
+// issue code and a description.
+type AddressIssue struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// MissingAddressFields returns the fields a carrier needs that a shipping
+// address lacks: street, city and postal_code. An address that is not a JSON
+// object lacks all of them.
+func MissingAddressFields(raw json.RawMessage) []string {
+	var addr ShippingAddress
+	_ = json.Unmarshal(raw, &addr)
+	var missing []string
+	if strings.TrimSpace(addr.Street) == "" {
+		missing = append(missing, "street")
+	}
+	if strings.TrimSpace(addr.City) == "" {
+		missing = append(missing, "city")
+	}
+	if strings.TrimSpace(addr.PostalCode) == "" {
+		missing = append(missing, "postal_code")
+	}
+	return missing
+}
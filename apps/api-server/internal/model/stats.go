@@ -54,3 +54,33 @@ type DailyOrderTrend struct {
 	Count    int     `json:"count"`
 	AvgValue float64 `json:"avg_value"`
 }
+
+// ChannelSales is the order count and revenue of one sales channel and
+// currency over a period.
+type ChannelSales struct {
+	Source   string  `json:"source"`
+	Currency string  `json:"currency"`
+	Orders   int     `json:"orders"`
+	Revenue  float64 `json:"revenue"`
+}
+
+// StockValueRow is the stock of one product or variant valued at purchase
+// cost and at sale price. UnitCost is nil when no cost is known.
+type StockValueRow struct {
+	SKU       string   `json:"sku"`
+	Name      string   `json:"name"`
+	Quantity  int      `json:"quantity"`
+	UnitCost  *float64 `json:"unit_cost,omitempty"`
+	CostValue float64  `json:"cost_value"`
+	SaleValue float64  `json:"sale_value"`
+}
+
+// VATSummaryRow totals the invoices of one type and currency over a period.
+type VATSummaryRow struct {
+	InvoiceType string  `json:"invoice_type"`
+	Currency    string  `json:"currency"`
+	Invoices    int     `json:"invoices"`
+	Net         float64 `json:"net"`
+	VAT         float64 `json:"vat"`
+	Gross       float64 `json:"gross"`
+}
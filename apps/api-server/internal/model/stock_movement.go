@@ -0,0 +1,174 @@
+package model
+
+import (
+	"errors"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Stock movement sources.
+const (
+	StockMovementOpening   = "opening"
+	StockMovementManual    = "manual"
+	StockMovementDocument  = "warehouse_document"
+	StockMovementStocktake = "stocktake"
+)
+
+// StockMovementSource describes why a stock quantity changes. Every write to
+// warehouse stock takes one and records it in the stock movement ledger.
+type StockMovementSource struct {
+	Source      string
+	DocumentID  *uuid.UUID
+	StocktakeID *uuid.UUID
+	ActorID     *uuid.UUID
+	Note        string
+}
+
+// StockMovement is an immutable entry of the stock ledger: one change of the
+// quantity of a product or variant in a warehouse.
+type StockMovement struct {
+	ID             uuid.UUID  `json:"id"`
+	TenantID       uuid.UUID  `json:"tenant_id"`
+	WarehouseID    uuid.UUID  `json:"warehouse_id"`
+	ProductID      uuid.UUID  `json:"product_id"`
+	VariantID      *uuid.UUID `json:"variant_id,omitempty"`
+	Source         string     `json:"source"`
+	DocumentID     *uuid.UUID `json:"document_id,omitempty"`
+	StocktakeID    *uuid.UUID `json:"stocktake_id,omitempty"`
+	Delta          int        `json:"delta"`
+	QuantityBefore int        `json:"quantity_before"`
+	QuantityAfter  int        `json:"quantity_after"`
+	ActorID        *uuid.UUID `json:"actor_id,omitempty"`
+	Note           *string    `json:"note,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+
+	// Enriched fields (not stored in DB directly)
+	WarehouseName  string  `json:"warehouse_name,omitempty"`
+	DocumentNumber *string `json:"document_number,omitempty"`
+	DocumentType   *string `json:"document_type,omitempty"`
+	ActorName      *string `json:"actor_name,omitempty"`
+}
+
+// NewStockMovement returns the ledger entry for a change of quantity from
+// before to after.
+func NewStockMovement(stock *WarehouseStock, before int, src StockMovementSource) *StockMovement {
+	m := &StockMovement{
+		ID:             uuid.New(),
+		TenantID:       stock.TenantID,
+		WarehouseID:    stock.WarehouseID,
+		ProductID:      stock.ProductID,
+		VariantID:      stock.VariantID,
+		Source:         src.Source,
+		DocumentID:     src.DocumentID,
+		StocktakeID:    src.StocktakeID,
+		Delta:          stock.Quantity - before,
+		QuantityBefore: before,
+		QuantityAfter:  stock.Quantity,
+		ActorID:        src.ActorID,
+	}
+	if src.Note != "" {
+		note := src.Note
+		m.Note = &note
+	}
+	return m
+}
+
+// StockMovementListFilter holds the filtering/pagination for a product's
+// stock movement history.
+type StockMovementListFilter struct {
+	PaginationParams
+	WarehouseID *uuid.UUID
+	VariantID   *uuid.UUID
+	From        *time.Time
+	To          *time.Time
+}
+
+// ParseStockMovementFilter parses the query parameters of the movement
+// history:
+//
+//	warehouse_id  restrict to one warehouse
+//	variant_id    restrict to one variant
+//	from, to      date (YYYY-MM-DD) or RFC 3339; to is inclusive for dates
+func ParseStockMovementFilter(q url.Values, pagination PaginationParams) (StockMovementListFilter, error) {
+	f := StockMovementListFilter{PaginationParams: pagination}
+	id, err := parseWarehouseParam(q)
+	if err != nil {
+		return f, err
+	}
+	f.WarehouseID = id
+	if s := q.Get("variant_id"); s != "" {
+		id, err := uuid.Parse(s)
+		if err != nil {
+			return f, errors.New("invalid variant_id")
+		}
+		f.VariantID = &id
+	}
+	if s := q.Get("from"); s != "" {
+		t, _, err := parseFilterTime(s)
+		if err != nil {
+			return f, errors.New("invalid from: expected YYYY-MM-DD or RFC 3339")
+		}
+		f.From = &t
+	}
+	if s := q.Get("to"); s != "" {
+		t, err := parseAtParam(s)
+		if err != nil {
+			return f, errors.New("invalid to: expected YYYY-MM-DD or RFC 3339")
+		}
+		f.To = &t
+	}
+	if f.From != nil && f.To != nil && !f.From.Before(*f.To) {
+		return f, errors.New("from must be before to")
+	}
+	return f, nil
+}
+
+// StockAtFilter selects the stock reconstructed from the ledger.
+type StockAtFilter struct {
+	// At is the exclusive end: movements before it count.
+	At          time.Time
+	WarehouseID *uuid.UUID
+}
+
+// ParseStockAtFilter parses the query parameters of the stock-at-date
+// endpoint:
+//
+//	at            date (YYYY-MM-DD, stock at the end of the day) or RFC 3339; default now
+//	warehouse_id  restrict to one warehouse
+func ParseStockAtFilter(q url.Values, now time.Time) (StockAtFilter, error) {
+	f := StockAtFilter{At: now}
+	if s := q.Get("at"); s != "" {
+		t, err := parseAtParam(s)
+		if err != nil {
+			return f, errors.New("invalid at: expected YYYY-MM-DD or RFC 3339")
+		}
+		f.At = t
+	}
+	id, err := parseWarehouseParam(q)
+	if err != nil {
+		return f, err
+	}
+	f.WarehouseID = id
+	return f, nil
+}
+
+// StockAtRow is the quantity of a product or variant in one warehouse at a
+// point in time.
+type StockAtRow struct {
+	WarehouseID   uuid.UUID  `json:"warehouse_id"`
+	WarehouseName string     `json:"warehouse_name"`
+	VariantID     *uuid.UUID `json:"variant_id,omitempty"`
+	VariantName   *string    `json:"variant_name,omitempty"`
+	Quantity      int        `json:"quantity"`
+}
+
+// ProductStockAt is the stock of a product at a point in time, returned by
+// GET /v1/products/{id}/stock-at.
+type ProductStockAt struct {
+	ProductID uuid.UUID    `json:"product_id"`
+	At        time.Time    `json:"at"`
+	Rows      []StockAtRow `json:"rows"`
+	Quantity  int          `json:"quantity"`
+}
@@ -0,0 +1,69 @@
+package model
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewStockMovement(t *testing.T) {
+	docID := uuid.New()
+	actorID := uuid.New()
+	stock := &WarehouseStock{TenantID: uuid.New(), WarehouseID: uuid.New(), ProductID: uuid.New(), Quantity: 7}
+
+	m := NewStockMovement(stock, 10, StockMovementSource{
+		Source:     StockMovementDocument,
+		DocumentID: &docID,
+		ActorID:    &actorID,
+	})
+	assert.Equal(t, -3, m.Delta)
+	assert.Equal(t, 10, m.QuantityBefore)
+	assert.Equal(t, 7, m.QuantityAfter)
+	assert.Equal(t, StockMovementDocument, m.Source)
+	assert.Equal(t, &docID, m.DocumentID)
+	assert.Equal(t, stock.WarehouseID, m.WarehouseID)
+	assert.Nil(t, m.Note)
+
+	m = NewStockMovement(stock, 0, StockMovementSource{Source: StockMovementManual, Note: "korekta"})
+	require.NotNil(t, m.Note)
+	assert.Equal(t, "korekta", *m.Note)
+	assert.Equal(t, 7, m.Delta)
+}
+
+func TestParseStockMovementFilter(t *testing.T) {
+	warehouseID := uuid.New()
+	f, err := ParseStockMovementFilter(url.Values{
+		"warehouse_id": {warehouseID.String()},
+		"from":         {"2026-03-01"},
+		"to":           {"2026-03-31"},
+	}, PaginationParams{Limit: 20})
+	require.NoError(t, err)
+	assert.Equal(t, &warehouseID, f.WarehouseID)
+	assert.Equal(t, 20, f.Limit)
+	assert.Equal(t, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), *f.From)
+	assert.Equal(t, time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC), *f.To, "a date is inclusive")
+
+	_, err = ParseStockMovementFilter(url.Values{"variant_id": {"x"}}, PaginationParams{})
+	assert.EqualError(t, err, "invalid variant_id")
+	_, err = ParseStockMovementFilter(url.Values{"from": {"2026-03-02"}, "to": {"2026-03-01"}}, PaginationParams{})
+	assert.EqualError(t, err, "from must be before to")
+}
+
+func TestParseStockAtFilter(t *testing.T) {
+	now := time.Date(2026, 5, 5, 12, 0, 0, 0, time.UTC)
+
+	f, err := ParseStockAtFilter(url.Values{}, now)
+	require.NoError(t, err)
+	assert.Equal(t, now, f.At)
+
+	f, err = ParseStockAtFilter(url.Values{"at": {"2026-01-31"}}, now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), f.At, "stock at the end of the day")
+
+	_, err = ParseStockAtFilter(url.Values{"at": {"yesterday"}}, now)
+	assert.Error(t, err)
+}
@@ -3,8 +3,10 @@ package model
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
 )
@@ -65,6 +67,9 @@ func (r *CreateSupplierRequest) Validate() error {
 	if err := validateMaxLength("name", r.Name, 500); err != nil {
 		return err
 	}
+	if len(r.Settings) > 0 {
+		return validateSupplierSettings(r.Settings)
+	}
 	return nil
 }
 
@@ -100,6 +105,9 @@ func (r *UpdateSupplierRequest) Validate() error {
 			return errors.New("status must be one of: active, inactive")
 		}
 	}
+	if r.Settings != nil && len(*r.Settings) > 0 {
+		return validateSupplierSettings(*r.Settings)
+	}
 	return nil
 }
 
@@ -125,3 +133,178 @@ func (r *LinkProductRequest) Validate() error {
 	}
 	return nil
 }
+
+// Supplier product fields a feed column or XML path can be mapped to.
+// Extra values are kept as attributes with the "attr:" prefix, e.g. attr:producer.
+var supplierFeedFields = map[string]bool{
+	"external_id": true,
+	"name":        true,
+	"ean":         true,
+	"sku":         true,
+	"price":       true,
+	"stock":       true,
+	"description": true,
+	"category":    true,
+	"image_url":   true,
+	"weight":      true,
+}
+
+// Character encodings accepted for CSV feeds; XML feeds declare their own.
+var supplierFeedEncodings = map[string]bool{
+	"":             true,
+	"utf-8":        true,
+	"cp1250":       true,
+	"windows-1250": true,
+	"iso-8859-2":   true,
+}
+
+// SupplierFeedMapping maps a CSV column or an XML path to a supplier product field.
+type SupplierFeedMapping struct {
+	// Source is the CSV column header (or 1-based column number when the
+	// feed has no header) or an XPath relative to the product element.
+	Source string `json:"source"`
+	Field  string `json:"field"`
+}
+
+// SupplierCSVSettings configures the parser of csv feeds.
+type SupplierCSVSettings struct {
+	// Delimiter is a single character; empty detects ; , or tab from the first line.
+	Delimiter string                `json:"delimiter,omitempty"`
+	Encoding  string                `json:"encoding,omitempty"`
+	NoHeader  bool                  `json:"no_header,omitempty"`
+	Mappings  []SupplierFeedMapping `json:"mappings"`
+}
+
+// SupplierXMLSettings configures the parser of custom XML feeds.
+type SupplierXMLSettings struct {
+	// ItemPath selects the product elements, e.g. //product or /offer/products/product.
+	ItemPath string                `json:"item_path"`
+	Mappings []SupplierFeedMapping `json:"mappings"`
+}
+
+// SupplierFeedSettings is the "feed" section of Supplier.Settings.
+type SupplierFeedSettings struct {
+	CSV *SupplierCSVSettings `json:"csv,omitempty"`
+	XML *SupplierXMLSettings `json:"xml,omitempty"`
+}
+
+// ParseSupplierFeedSettings reads the "feed" section of the supplier settings.
+func ParseSupplierFeedSettings(settings json.RawMessage) (SupplierFeedSettings, error) {
+	var s struct {
+		Feed SupplierFeedSettings `json:"feed"`
+	}
+	if len(settings) == 0 {
+		return s.Feed, nil
+	}
+	if err := json.Unmarshal(settings, &s); err != nil {
+		return s.Feed, fmt.Errorf("invalid feed settings: %w", err)
+	}
+	return s.Feed, nil
+}
+
+func (s SupplierFeedSettings) Validate() error {
+	if s.CSV != nil {
+		if utf8.RuneCountInString(s.CSV.Delimiter) > 1 {
+			return errors.New("feed.csv.delimiter must be a single character")
+		}
+		if !supplierFeedEncodings[strings.ToLower(s.CSV.Encoding)] {
+			return errors.New("feed.csv.encoding must be one of: utf-8, cp1250, iso-8859-2")
+		}
+		if err := validateFeedMappings("feed.csv", s.CSV.Mappings); err != nil {
+			return err
+		}
+	}
+	if s.XML != nil {
+		if strings.TrimSpace(s.XML.ItemPath) == "" {
+			return errors.New("feed.xml.item_path is required")
+		}
+		if err := validateFeedMappings("feed.xml", s.XML.Mappings); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateFeedMappings(prefix string, mappings []SupplierFeedMapping) error {
+	mapped := make(map[string]bool, len(mappings))
+	for i, m := range mappings {
+		if strings.TrimSpace(m.Source) == "" {
+			return fmt.Errorf("%s.mappings[%d].source is required", prefix, i)
+		}
+		if !supplierFeedFields[m.Field] && !(strings.HasPrefix(m.Field, "attr:") && len(m.Field) > len("attr:")) {
+			return fmt.Errorf("%s.mappings[%d].field %q is not a supplier product field", prefix, i, m.Field)
+		}
+		mapped[m.Field] = true
+	}
+	if !mapped["name"] {
+		return fmt.Errorf("%s.mappings must map the name field", prefix)
+	}
+	if !mapped["external_id"] && !mapped["sku"] && !mapped["ean"] {
+		return fmt.Errorf("%s.mappings must map external_id, sku or ean", prefix)
+	}
+	return nil
+}
+
+// validateSupplierSettings checks the feed and dropship sections of raw
+// supplier settings.
+func validateSupplierSettings(settings json.RawMessage) error {
+	feed, err := ParseSupplierFeedSettings(settings)
+	if err != nil {
+		return err
+	}
+	if err := feed.Validate(); err != nil {
+		return err
+	}
+	dropship, err := ParseSupplierDropshipSettings(settings)
+	if err != nil {
+		return err
+	}
+	return dropship.Validate()
+}
+
+// SupplierFeedProduct is a product read from a supplier feed.
+type SupplierFeedProduct struct {
+	ExternalID  string            `json:"external_id"`
+	Name        string            `json:"name"`
+	EAN         string            `json:"ean,omitempty"`
+	SKU         string            `json:"sku,omitempty"`
+	Price       float64           `json:"price"`
+	Stock       int               `json:"stock"`
+	Description string            `json:"description,omitempty"`
+	Category    string            `json:"category,omitempty"`
+	ImageURL    string            `json:"image_url,omitempty"`
+	Weight      float64           `json:"weight,omitempty"`
+	Attributes  map[string]string `json:"attributes,omitempty"`
+}
+
+// SupplierFeedPreviewRequest runs the feed parser without saving anything.
+// Empty fields fall back to the supplier's saved feed URL, format and settings,
+// so a mapping can be tried out before it is saved.
+type SupplierFeedPreviewRequest struct {
+	FeedURL    *string         `json:"feed_url,omitempty"`
+	FeedFormat *string         `json:"feed_format,omitempty"`
+	Settings   json.RawMessage `json:"settings,omitempty"`
+}
+
+func (r *SupplierFeedPreviewRequest) Validate() error {
+	if r.FeedFormat != nil {
+		switch *r.FeedFormat {
+		case "iof", "csv", "custom":
+		default:
+			return errors.New("feed_format must be one of: iof, csv, custom")
+		}
+	}
+	if len(r.Settings) > 0 {
+		return validateSupplierSettings(r.Settings)
+	}
+	return nil
+}
+
+// SupplierFeedPreview is the dry-run result of parsing a supplier feed.
+type SupplierFeedPreview struct {
+	FeedFormat    string                `json:"feed_format"`
+	TotalProducts int                   `json:"total_products"`
+	Products      []SupplierFeedProduct `json:"products"`
+	Columns       []string              `json:"columns,omitempty"`
+	Warnings      []string              `json:"warnings"`
+}
@@ -0,0 +1,140 @@
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Order timeline sources. Order, shipment, return and invoice entries come
+// from the audit log of the order and its related entities; status changes
+// among them are the domain events of the order engine.
+const (
+	TimelineSourceOrder        = "order"
+	TimelineSourceShipment     = "shipment"
+	TimelineSourceReturn       = "return"
+	TimelineSourceInvoice      = "invoice"
+	TimelineSourceAutomation   = "automation"
+	TimelineSourceNotification = "notification"
+	TimelineSourceMarketplace  = "marketplace"
+	TimelineSourceWebhook      = "webhook"
+)
+
+// TimelineSources lists the valid timeline sources.
+var TimelineSources = []string{
+	TimelineSourceOrder, TimelineSourceShipment, TimelineSourceReturn, TimelineSourceInvoice,
+	TimelineSourceAutomation, TimelineSourceNotification, TimelineSourceMarketplace, TimelineSourceWebhook,
+}
+
+// Order event types recorded in order_events.
+const (
+	OrderEventEmailSent         = "email.sent"
+	OrderEventEmailFailed       = "email.failed"
+	OrderEventSMSSent           = "sms.sent"
+	OrderEventSMSFailed         = "sms.failed"
+	OrderEventMarketplaceImport = "marketplace.order_imported"
+	OrderEventMarketplaceSync   = "marketplace.synced"
+	OrderEventBuyerMessaged     = "marketplace.buyer_messaged"
+)
+
+// OrderEvent is something that happened to an order outside the audit log:
+// a notification sent to the customer or a sync action against the order's
+// marketplace. Source is TimelineSourceNotification or
+// TimelineSourceMarketplace.
+type OrderEvent struct {
+	ID        uuid.UUID         `json:"id"`
+	TenantID  uuid.UUID         `json:"tenant_id"`
+	OrderID   uuid.UUID         `json:"order_id"`
+	Source    string            `json:"source"`
+	EventType string            `json:"event_type"`
+	ActorID   *uuid.UUID        `json:"actor_id,omitempty"`
+	Data      map[string]string `json:"data"`
+	CreatedAt time.Time         `json:"created_at"`
+}
+
+// TimelineEntry is one entry of an order timeline. EntityType and EntityID
+// identify the order, shipment, return or invoice the entry is about; Actor
+// is the user who caused it, if any.
+type TimelineEntry struct {
+	OccurredAt time.Time       `json:"occurred_at"`
+	Source     string          `json:"source"`
+	Type       string          `json:"type"`
+	EntityType *string         `json:"entity_type,omitempty"`
+	EntityID   *uuid.UUID      `json:"entity_id,omitempty"`
+	Actor      *string         `json:"actor,omitempty"`
+	Data       json.RawMessage `json:"data"`
+}
+
+// MaxTimelineEntries bounds the entries of one timeline request.
+const MaxTimelineEntries = 1000
+
+// TimelineFilter selects the entries of an order timeline.
+type TimelineFilter struct {
+	Sources []string
+	// Type matches entry types exactly or, when it ends with ".", by prefix.
+	Type  string
+	From  *time.Time
+	To    *time.Time
+	Limit int
+}
+
+// ParseTimelineFilter parses the query parameters of the order timeline:
+//
+//	source    comma-separated sources, default all
+//	type      entry type, e.g. order.status_changed, or a prefix ending in "."
+//	from, to  time (YYYY-MM-DD or RFC 3339), to is inclusive for dates
+//	limit     entries, default 200
+func ParseTimelineFilter(q url.Values) (TimelineFilter, error) {
+	f := TimelineFilter{Limit: 200}
+
+	for _, s := range q["source"] {
+		for _, src := range strings.Split(s, ",") {
+			src = strings.TrimSpace(src)
+			if src == "" {
+				continue
+			}
+			if !slices.Contains(TimelineSources, src) {
+				return f, fmt.Errorf("source must be one of: %s", strings.Join(TimelineSources, ", "))
+			}
+			if !slices.Contains(f.Sources, src) {
+				f.Sources = append(f.Sources, src)
+			}
+		}
+	}
+	f.Type = strings.TrimSpace(q.Get("type"))
+	if s := q.Get("from"); s != "" {
+		t, _, err := parseFilterTime(s)
+		if err != nil {
+			return f, errors.New("invalid from: expected YYYY-MM-DD or RFC 3339")
+		}
+		f.From = &t
+	}
+	if s := q.Get("to"); s != "" {
+		t, dateOnly, err := parseFilterTime(s)
+		if err != nil {
+			return f, errors.New("invalid to: expected YYYY-MM-DD or RFC 3339")
+		}
+		if dateOnly {
+			t = t.AddDate(0, 0, 1)
+		}
+		f.To = &t
+	}
+	if f.From != nil && f.To != nil && !f.From.Before(*f.To) {
+		return f, errors.New("from must be before to")
+	}
+	if s := q.Get("limit"); s != "" {
+		limit, err := strconv.Atoi(s)
+		if err != nil || limit < 1 || limit > MaxTimelineEntries {
+			return f, fmt.Errorf("limit must be between 1 and %d", MaxTimelineEntries)
+		}
+		f.Limit = limit
+	}
+	return f, nil
+}
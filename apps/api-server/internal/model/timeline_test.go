@@ -0,0 +1,58 @@
+package model
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTimelineFilter_Defaults(t *testing.T) {
+	f, err := ParseTimelineFilter(url.Values{})
+	require.NoError(t, err)
+	assert.Empty(t, f.Sources)
+	assert.Empty(t, f.Type)
+	assert.Equal(t, 200, f.Limit)
+	assert.Nil(t, f.From)
+	assert.Nil(t, f.To)
+}
+
+func TestParseTimelineFilter(t *testing.T) {
+	q := url.Values{}
+	q.Add("source", "shipment, notification")
+	q.Add("source", "shipment")
+	q.Set("type", "shipment.")
+	q.Set("from", "2026-03-01")
+	q.Set("to", "2026-03-31")
+	q.Set("limit", "50")
+
+	f, err := ParseTimelineFilter(q)
+	require.NoError(t, err)
+	assert.Equal(t, []string{TimelineSourceShipment, TimelineSourceNotification}, f.Sources)
+	assert.Equal(t, "shipment.", f.Type)
+	assert.Equal(t, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), *f.From)
+	assert.Equal(t, time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC), *f.To, "date-only upper bound covers the whole day")
+	assert.Equal(t, 50, f.Limit)
+}
+
+func TestParseTimelineFilter_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		q       url.Values
+		wantErr string
+	}{
+		{"unknown source", url.Values{"source": {"order,email"}}, "source must be one of"},
+		{"bad to", url.Values{"to": {"tomorrow"}}, "invalid to"},
+		{"reversed range", url.Values{"from": {"2026-02-01"}, "to": {"2026-01-01"}}, "from must be before to"},
+		{"limit too high", url.Values{"limit": {"5000"}}, "limit must be between"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseTimelineFilter(tt.q)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
@@ -2,6 +2,9 @@ package model
 
 import (
 	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -64,8 +67,27 @@ type SMSSettings struct {
 }
 
 // InventorySettings controls warehouse inventory behaviour for a tenant.
+// CostingMethod selects how WZ and MM documents consume cost layers;
+// changing it applies to later documents only.
 type InventorySettings struct {
-	StrictMode bool `json:"strict_mode"`
+	StrictMode    bool   `json:"strict_mode"`
+	CostingMethod string `json:"costing_method,omitempty"`
+}
+
+// Validate checks the costing method; empty means FIFO.
+func (s *InventorySettings) Validate() error {
+	if s.CostingMethod != "" && !slices.Contains(validCostingMethods, s.CostingMethod) {
+		return fmt.Errorf("costing_method must be one of: %s", strings.Join(validCostingMethods, ", "))
+	}
+	return nil
+}
+
+// Costing returns the costing method, defaulting to FIFO.
+func (s InventorySettings) Costing() string {
+	if s.CostingMethod == "" {
+		return CostingMethodFIFO
+	}
+	return s.CostingMethod
 }
 
 // AuditEntry represents an audit log record.
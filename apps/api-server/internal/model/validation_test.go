@@ -301,6 +301,22 @@ func TestCreateReturnRequest_Validate_NegativeRefund(t *testing.T) {
 	assert.Error(t, req.Validate())
 }
 
+func TestCreateReturnRequest_Validate_ReturnType(t *testing.T) {
+	for _, typ := range []string{"", "refund", "exchange"} {
+		req := CreateReturnRequest{OrderID: uuid.New(), Reason: "defective", ReturnType: typ}
+		assert.NoError(t, req.Validate(), "return_type %q should be valid", typ)
+	}
+	req := CreateReturnRequest{OrderID: uuid.New(), Reason: "defective", ReturnType: "store_credit"}
+	assert.Error(t, req.Validate())
+}
+
+func TestRefundReturnRequest_Validate_Amount(t *testing.T) {
+	amount := 25.0
+	assert.NoError(t, RefundReturnRequest{Amount: &amount}.Validate())
+	zero := 0.0
+	assert.Error(t, RefundReturnRequest{Amount: &zero}.Validate())
+}
+
 func TestCreateReturnRequest_Validate_ReasonTooLong(t *testing.T) {
 	req := CreateReturnRequest{OrderID: uuid.New(), Reason: strings.Repeat("x", 2001)}
 	assert.Error(t, req.Validate())
@@ -339,6 +355,11 @@ func TestIsValidReturnTransition(t *testing.T) {
 		{"approved", "cancelled", true},
 		{"received", "refunded", true},
 		{"received", "cancelled", true},
+		{"received", "partially_refunded", true},
+		{"received", "exchanged", true},
+		{"partially_refunded", "refunded", true},
+		{"partially_refunded", "cancelled", false},
+		{"exchanged", "refunded", false},
 		{"refunded", "requested", false},
 		{"rejected", "approved", false},
 		{"nonexistent", "approved", false},
@@ -425,7 +446,7 @@ func TestCreateProductRequest_Validate_InvalidSource(t *testing.T) {
 }
 
 func TestCreateProductRequest_Validate_ValidSources(t *testing.T) {
-	for _, source := range []string{"allegro", "woocommerce", "manual"} {
+	for _, source := range []string{"allegro", "woocommerce", "shopify", "prestashop", "manual"} {
 		req := CreateProductRequest{Name: "Widget", Price: 10, Source: source}
 		assert.NoError(t, req.Validate(), "source %s should be valid", source)
 	}
@@ -526,6 +547,18 @@ func TestCreateProductListingRequest_Validate_MissingIntegrationID(t *testing.T)
 	assert.Error(t, req.Validate())
 }
 
+func TestCreateProductListingRequest_Validate_NilVariantID(t *testing.T) {
+	nilID := uuid.Nil
+	req := CreateProductListingRequest{ProductID: uuid.New(), IntegrationID: uuid.New(), VariantID: &nilID}
+	assert.Error(t, req.Validate())
+}
+
+func TestCreateProductListingRequest_Validate_NegativeStockOverride(t *testing.T) {
+	stock := -1
+	req := CreateProductListingRequest{ProductID: uuid.New(), IntegrationID: uuid.New(), StockOverride: &stock}
+	assert.Error(t, req.Validate())
+}
+
 // --- UpdateProductListingRequest.Validate ---
 
 func TestUpdateProductListingRequest_Validate_NoFields(t *testing.T) {
@@ -539,6 +572,12 @@ func TestUpdateProductListingRequest_Validate_Success(t *testing.T) {
 	assert.NoError(t, req.Validate())
 }
 
+func TestUpdateProductListingRequest_Validate_ExternalGroupIDOnly(t *testing.T) {
+	g := "set-1"
+	req := UpdateProductListingRequest{ExternalGroupID: &g}
+	assert.NoError(t, req.Validate())
+}
+
 // --- LinkProductRequest.Validate ---
 
 func TestLinkProductRequest_Validate_Success(t *testing.T) {
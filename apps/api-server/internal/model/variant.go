@@ -19,6 +19,7 @@ type ProductVariant struct {
 	Name          string          `json:"name"`
 	Attributes    json.RawMessage `json:"attributes"`
 	PriceOverride *float64        `json:"price_override,omitempty"`
+	CostPrice     *float64        `json:"cost_price,omitempty"`
 	StockQuantity int             `json:"stock_quantity"`
 	Weight        *float64        `json:"weight,omitempty"`
 	ImageURL      *string         `json:"image_url,omitempty"`
@@ -35,6 +36,7 @@ type CreateVariantRequest struct {
 	Name          string          `json:"name"`
 	Attributes    json.RawMessage `json:"attributes,omitempty"`
 	PriceOverride *float64        `json:"price_override,omitempty"`
+	CostPrice     *float64        `json:"cost_price,omitempty"`
 	StockQuantity int             `json:"stock_quantity"`
 	Weight        *float64        `json:"weight,omitempty"`
 	ImageURL      *string         `json:"image_url,omitempty"`
@@ -53,6 +55,9 @@ func (r *CreateVariantRequest) Validate() error {
 	if r.PriceOverride != nil && *r.PriceOverride < 0 {
 		return errors.New("price_override must not be negative")
 	}
+	if r.CostPrice != nil && *r.CostPrice < 0 {
+		return errors.New("cost_price must not be negative")
+	}
 	if err := validateMaxLength("name", r.Name, 500); err != nil {
 		return err
 	}
@@ -72,6 +77,7 @@ type UpdateVariantRequest struct {
 	Name          *string          `json:"name,omitempty"`
 	Attributes    *json.RawMessage `json:"attributes,omitempty"`
 	PriceOverride *float64         `json:"price_override,omitempty"`
+	CostPrice     *float64         `json:"cost_price,omitempty"`
 	StockQuantity *int             `json:"stock_quantity,omitempty"`
 	Weight        *float64         `json:"weight,omitempty"`
 	ImageURL      *string          `json:"image_url,omitempty"`
@@ -82,7 +88,7 @@ type UpdateVariantRequest struct {
 // Validate validates the update variant request.
 func (r *UpdateVariantRequest) Validate() error {
 	if r.SKU == nil && r.EAN == nil && r.Name == nil && r.Attributes == nil &&
-		r.PriceOverride == nil && r.StockQuantity == nil && r.Weight == nil &&
+		r.PriceOverride == nil && r.CostPrice == nil && r.StockQuantity == nil && r.Weight == nil &&
 		r.ImageURL == nil && r.Position == nil && r.Active == nil {
 		return errors.New("at least one field must be provided")
 	}
@@ -95,6 +101,9 @@ func (r *UpdateVariantRequest) Validate() error {
 	if r.PriceOverride != nil && *r.PriceOverride < 0 {
 		return errors.New("price_override must not be negative")
 	}
+	if r.CostPrice != nil && *r.CostPrice < 0 {
+		return errors.New("cost_price must not be negative")
+	}
 	if err := validateMaxLengthPtr("name", r.Name, 500); err != nil {
 		return err
 	}
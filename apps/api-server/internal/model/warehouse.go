@@ -94,6 +94,8 @@ type UpsertWarehouseStockRequest struct {
 	Quantity  int        `json:"quantity"`
 	Reserved  int        `json:"reserved"`
 	MinStock  int        `json:"min_stock"`
+	// Reason is recorded in the stock movement ledger.
+	Reason string `json:"reason,omitempty"`
 }
 
 // Validate validates the upsert warehouse stock request.
@@ -110,6 +112,9 @@ func (r *UpsertWarehouseStockRequest) Validate() error {
 	if r.MinStock < 0 {
 		return errors.New("min_stock must not be negative")
 	}
+	if len(r.Reason) > 500 {
+		return errors.New("reason must be at most 500 characters")
+	}
 	return nil
 }
 
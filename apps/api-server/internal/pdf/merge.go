@@ -0,0 +1,646 @@
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// inheritedPageKeys are the page attributes a page may take from its
+// ancestors in the page tree; merged pages carry them explicitly.
+var inheritedPageKeys = []string{"/Resources", "/MediaBox", "/CropBox", "/Rotate"}
+
+var objHeader = regexp.MustCompile(`(\d+)\s+(\d+)\s+obj\b`)
+
+// Merge joins PDF files into one, keeping their pages in order. Objects are
+// read by scanning the files rather than through their cross-reference
+// tables, so both classic files and the object streams of PDF 1.5 — common
+// in carrier labels — are understood. Encrypted files are rejected.
+func Merge(files ...[]byte) ([]byte, error) {
+	if len(files) == 0 {
+		return nil, errors.New("no files to merge")
+	}
+
+	type output struct {
+		num  int
+		body []byte
+	}
+	var objects []output
+	var kids []string
+	next := 3 // 1 and 2 are the new catalog and page tree
+
+	for i, data := range files {
+		f, err := parseFile(data)
+		if err != nil {
+			return nil, fmt.Errorf("file %d: %w", i+1, err)
+		}
+		pages, err := f.pages()
+		if err != nil {
+			return nil, fmt.Errorf("file %d: %w", i+1, err)
+		}
+
+		// Number the pages and everything reachable from them.
+		numbers := map[int]int{}
+		var queue []int
+		assign := func(num int) {
+			if _, ok := numbers[num]; ok {
+				return
+			}
+			if _, ok := f.objects[num]; !ok {
+				return
+			}
+			numbers[num] = next
+			next++
+			queue = append(queue, num)
+		}
+		pageDicts := map[int]dict{}
+		for _, p := range pages {
+			pageDicts[p.num] = p.dict
+			assign(p.num)
+			kids = append(kids, fmt.Sprintf("%d 0 R", numbers[p.num]))
+		}
+
+		for len(queue) > 0 {
+			num := queue[0]
+			queue = queue[1:]
+			o := f.objects[num]
+
+			var body []byte
+			d, isPage := pageDicts[num]
+			if isPage {
+				body = d.without("/Parent").bytes()
+			} else if o.stream != nil {
+				d, _ := parseDict(o.value)
+				d = d.without("/Length")
+				d = append(d, dictEntry{key: "/Length", value: []byte(strconv.Itoa(len(o.stream)))})
+				body = d.bytes()
+			} else {
+				body = o.value
+			}
+
+			walkRefs(body, func(_, _, ref int) { assign(ref) })
+			body = rewriteRefs(body, func(ref int) string {
+				if n, ok := numbers[ref]; ok {
+					return fmt.Sprintf("%d 0 R", n)
+				}
+				return "null"
+			})
+			if isPage {
+				// Hang the page on the new page tree; d.bytes() ends in " >>".
+				body = append(body[:len(body)-3], " /Parent 2 0 R >>"...)
+			}
+			if o.stream != nil {
+				var b bytes.Buffer
+				b.Write(body)
+				b.WriteString("\nstream\n")
+				b.Write(o.stream)
+				b.WriteString("\nendstream")
+				body = b.Bytes()
+			}
+			objects = append(objects, output{num: numbers[num], body: body})
+		}
+	}
+
+	objects = append(objects,
+		output{num: 1, body: []byte("<< /Type /Catalog /Pages 2 0 R >>")},
+		output{num: 2, body: []byte(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(kids)))},
+	)
+	sort.Slice(objects, func(i, j int) bool { return objects[i].num < objects[j].num })
+
+	var out bytes.Buffer
+	out.WriteString("%PDF-1.7\n%\xE2\xE3\xCF\xD3\n")
+	offsets := make([]int, len(objects))
+	for i, o := range objects {
+		offsets[i] = out.Len()
+		fmt.Fprintf(&out, "%d 0 obj\n", o.num)
+		out.Write(o.body)
+		out.WriteString("\nendobj\n")
+	}
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+	return out.Bytes(), nil
+}
+
+// object is an indirect object: its value, and the decoded-as-stored data of
+// a stream object.
+type object struct {
+	value  []byte
+	stream []byte
+}
+
+type file struct {
+	objects map[int]object
+	trailer dict
+}
+
+type page struct {
+	num  int
+	dict dict
+}
+
+func parseFile(data []byte) (*file, error) {
+	if !bytes.HasPrefix(bytes.TrimLeft(data, " \t\r\n"), []byte("%PDF-")) {
+		return nil, errors.New("not a PDF file")
+	}
+
+	f := &file{objects: map[int]object{}}
+	type lazyStream struct {
+		num    int
+		start  int
+		length []byte
+	}
+	var lazy []lazyStream
+	var packed []object
+	trailerPos := -1
+
+	pos := 0
+	for {
+		loc := objHeader.FindSubmatchIndex(data[pos:])
+		if loc == nil {
+			f.scanTrailer(data, pos, len(data), &trailerPos)
+			break
+		}
+		start, end := pos+loc[0], pos+loc[1]
+		f.scanTrailer(data, pos, start, &trailerPos)
+		num, _ := strconv.Atoi(string(data[pos+loc[2] : pos+loc[3]]))
+
+		valStart := skipSpace(data, end)
+		valEnd := skipObject(data, valStart)
+		o := object{value: data[valStart:valEnd]}
+		pos = valEnd
+
+		if after := skipSpace(data, valEnd); bytes.HasPrefix(data[after:], []byte("stream")) {
+			streamStart := after + len("stream")
+			if streamStart < len(data) && data[streamStart] == '\r' {
+				streamStart++
+			}
+			if streamStart < len(data) && data[streamStart] == '\n' {
+				streamStart++
+			}
+			d, _ := parseDict(o.value)
+			length := d.get("/Length")
+			if n, err := strconv.Atoi(string(length)); err == nil && streamStart+n <= len(data) {
+				o.stream = data[streamStart : streamStart+n]
+				pos = streamStart + n
+			} else {
+				e := bytes.Index(data[streamStart:], []byte("endstream"))
+				if e < 0 {
+					return nil, fmt.Errorf("object %d: unterminated stream", num)
+				}
+				o.stream = bytes.TrimSuffix(bytes.TrimSuffix(data[streamStart:streamStart+e], []byte("\n")), []byte("\r"))
+				pos = streamStart + e
+				if length != nil {
+					lazy = append(lazy, lazyStream{num: num, start: streamStart, length: length})
+				}
+			}
+
+			switch string(d.get("/Type")) {
+			case "/XRef":
+				if trailerPos < start {
+					f.trailer, trailerPos = d, start
+				}
+				pos = skipEndobj(data, pos)
+				continue
+			case "/ObjStm":
+				packed = append(packed, o)
+				pos = skipEndobj(data, pos)
+				continue
+			}
+		}
+
+		f.objects[num] = o
+		pos = skipEndobj(data, pos)
+	}
+
+	if f.trailer == nil {
+		return nil, errors.New("no trailer found")
+	}
+	if f.trailer.get("/Encrypt") != nil {
+		return nil, errors.New("encrypted files are not supported")
+	}
+
+	// Streams whose /Length is an indirect object are cut exactly once every
+	// object is known.
+	for _, l := range lazy {
+		var refNum int
+		walkRefs(l.length, func(_, _, ref int) { refNum = ref })
+		o, ok := f.objects[l.num]
+		if !ok {
+			continue
+		}
+		if n, err := strconv.Atoi(strings.TrimSpace(string(f.objects[refNum].value))); err == nil && l.start+n <= len(data) {
+			o.stream = data[l.start : l.start+n]
+			f.objects[l.num] = o
+		}
+	}
+
+	for _, o := range packed {
+		if err := f.unpack(o); err != nil {
+			return nil, err
+		}
+	}
+	return f, nil
+}
+
+// scanTrailer looks for a classic trailer between objects.
+func (f *file) scanTrailer(data []byte, from, to int, trailerPos *int) {
+	i := bytes.LastIndex(data[from:to], []byte("trailer"))
+	if i < 0 {
+		return
+	}
+	start := skipSpace(data, from+i+len("trailer"))
+	d, err := parseDict(data[start:skipObject(data, start)])
+	if err == nil && from+i > *trailerPos {
+		f.trailer, *trailerPos = d, from+i
+	}
+}
+
+// unpack adds the objects stored in an object stream. Objects stored
+// directly in the file take precedence.
+func (f *file) unpack(o object) error {
+	d, _ := parseDict(o.value)
+	data := o.stream
+	switch filter := strings.Trim(string(d.get("/Filter")), "[] \r\n"); filter {
+	case "":
+	case "/FlateDecode":
+		if parms := d.get("/DecodeParms"); parms != nil {
+			if p, _ := parseDict(parms); p != nil {
+				if pred := string(p.get("/Predictor")); pred != "" && pred != "1" {
+					return errors.New("object stream predictors are not supported")
+				}
+			}
+		}
+		r, err := zlib.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return fmt.Errorf("object stream: %w", err)
+		}
+		data, err = io.ReadAll(r)
+		if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+			return fmt.Errorf("object stream: %w", err)
+		}
+	default:
+		return fmt.Errorf("object stream filter %s is not supported", filter)
+	}
+
+	n, err1 := strconv.Atoi(string(d.get("/N")))
+	first, err2 := strconv.Atoi(string(d.get("/First")))
+	if err1 != nil || err2 != nil || first > len(data) {
+		return errors.New("invalid object stream")
+	}
+	header := strings.Fields(string(data[:first]))
+	if len(header) < 2*n {
+		return errors.New("invalid object stream header")
+	}
+	for i := 0; i < n; i++ {
+		num, err1 := strconv.Atoi(header[2*i])
+		off, err2 := strconv.Atoi(header[2*i+1])
+		if err1 != nil || err2 != nil || first+off > len(data) {
+			return errors.New("invalid object stream header")
+		}
+		if _, ok := f.objects[num]; ok {
+			continue
+		}
+		start := skipSpace(data, first+off)
+		f.objects[num] = object{value: data[start:skipObject(data, start)]}
+	}
+	return nil
+}
+
+// pages walks the page tree and returns the pages in order, with inherited
+// attributes copied into each page.
+func (f *file) pages() ([]page, error) {
+	rootNum, ok := singleRef(f.trailer.get("/Root"))
+	if !ok {
+		return nil, errors.New("no document catalog")
+	}
+	root, err := parseDict(f.objects[rootNum].value)
+	if err != nil {
+		return nil, fmt.Errorf("document catalog: %w", err)
+	}
+	treeNum, ok := singleRef(root.get("/Pages"))
+	if !ok {
+		return nil, errors.New("no page tree")
+	}
+
+	var pages []page
+	visited := map[int]bool{}
+	var walk func(num int, inherited dict) error
+	walk = func(num int, inherited dict) error {
+		if visited[num] {
+			return errors.New("page tree has a cycle")
+		}
+		visited[num] = true
+		o, ok := f.objects[num]
+		if !ok {
+			return nil
+		}
+		node, err := parseDict(o.value)
+		if err != nil {
+			return fmt.Errorf("page tree node %d: %w", num, err)
+		}
+		own := dict{}
+		for _, key := range inheritedPageKeys {
+			if v := node.get(key); v != nil {
+				own = append(own, dictEntry{key: key, value: v})
+			} else if v := inherited.get(key); v != nil {
+				own = append(own, dictEntry{key: key, value: v})
+			}
+		}
+
+		if string(node.get("/Type")) != "/Pages" && node.get("/Kids") == nil {
+			for _, e := range own {
+				if node.get(e.key) == nil {
+					node = append(node, e)
+				}
+			}
+			pages = append(pages, page{num: num, dict: node})
+			return nil
+		}
+		var kids []int
+		walkRefs(node.get("/Kids"), func(_, _, ref int) { kids = append(kids, ref) })
+		for _, kid := range kids {
+			if err := walk(kid, own); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := walk(treeNum, nil); err != nil {
+		return nil, err
+	}
+	if len(pages) == 0 {
+		return nil, errors.New("document has no pages")
+	}
+	return pages, nil
+}
+
+func skipEndobj(data []byte, pos int) int {
+	if i := bytes.Index(data[pos:], []byte("endobj")); i >= 0 {
+		return pos + i + len("endobj")
+	}
+	return len(data)
+}
+
+// --- Lexing ---
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\n' || c == '\r' || c == '\t' || c == '\f' || c == 0
+}
+
+func isDelimiter(c byte) bool {
+	return strings.IndexByte("()<>[]{}/%", c) >= 0
+}
+
+// skipSpace skips white space and comments.
+func skipSpace(b []byte, i int) int {
+	for i < len(b) {
+		switch {
+		case isSpace(b[i]):
+			i++
+		case b[i] == '%':
+			for i < len(b) && b[i] != '\n' && b[i] != '\r' {
+				i++
+			}
+		default:
+			return i
+		}
+	}
+	return i
+}
+
+// skipToken returns the end of the token starting at i: a dictionary, array,
+// string, name or a regular token such as a number or keyword.
+func skipToken(b []byte, i int) int {
+	if i >= len(b) {
+		return i
+	}
+	switch c := b[i]; {
+	case c == '<' && i+1 < len(b) && b[i+1] == '<':
+		i += 2
+		for {
+			i = skipSpace(b, i)
+			if i >= len(b) {
+				return i
+			}
+			if b[i] == '>' && i+1 < len(b) && b[i+1] == '>' {
+				return i + 2
+			}
+			i = skipToken(b, i)
+		}
+	case c == '[':
+		i++
+		for {
+			i = skipSpace(b, i)
+			if i >= len(b) {
+				return i
+			}
+			if b[i] == ']' {
+				return i + 1
+			}
+			i = skipToken(b, i)
+		}
+	case c == '(':
+		depth := 0
+		for ; i < len(b); i++ {
+			switch b[i] {
+			case '\\':
+				i++
+			case '(':
+				depth++
+			case ')':
+				depth--
+				if depth == 0 {
+					return i + 1
+				}
+			}
+		}
+		return i
+	case c == '<':
+		if j := bytes.IndexByte(b[i:], '>'); j >= 0 {
+			return i + j + 1
+		}
+		return len(b)
+	case c == '/':
+		i++
+		for i < len(b) && !isSpace(b[i]) && !isDelimiter(b[i]) {
+			i++
+		}
+		return i
+	case isDelimiter(c):
+		// A stray closing delimiter; step over it.
+		return i + 1
+	default:
+		for i < len(b) && !isSpace(b[i]) && !isDelimiter(b[i]) {
+			i++
+		}
+		return i
+	}
+}
+
+// skipObject is skipToken that also takes in "12 0 R" references whole.
+func skipObject(b []byte, i int) int {
+	end := skipToken(b, i)
+	if _, _, refEnd, ok := refAt(b, i); ok {
+		return refEnd
+	}
+	return end
+}
+
+// refAt reports whether an "N G R" reference starts at i.
+func refAt(b []byte, i int) (num, start, end int, ok bool) {
+	j := skipToken(b, i)
+	if !isDigits(b[i:j]) {
+		return 0, 0, 0, false
+	}
+	k := skipSpace(b, j)
+	l := skipToken(b, k)
+	if k == j || !isDigits(b[k:l]) {
+		return 0, 0, 0, false
+	}
+	m := skipSpace(b, l)
+	if m == l || m >= len(b) || b[m] != 'R' || (m+1 < len(b) && !isSpace(b[m+1]) && !isDelimiter(b[m+1])) {
+		return 0, 0, 0, false
+	}
+	num, err := strconv.Atoi(string(b[i:j]))
+	return num, i, m + 1, err == nil
+}
+
+func isDigits(b []byte) bool {
+	if len(b) == 0 {
+		return false
+	}
+	for _, c := range b {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// walkRefs calls fn for every indirect reference in b, skipping strings.
+func walkRefs(b []byte, fn func(start, end, num int)) {
+	for i := 0; i < len(b); {
+		c := b[i]
+		switch {
+		case isSpace(c):
+			i++
+		case c == '<' && i+1 < len(b) && b[i+1] == '<':
+			i += 2
+		case c == '(' || c == '<' || c == '/':
+			i = skipToken(b, i)
+		case c == '%':
+			i = skipSpace(b, i)
+		case c >= '0' && c <= '9':
+			if num, start, end, ok := refAt(b, i); ok {
+				fn(start, end, num)
+				i = end
+			} else {
+				i = skipToken(b, i)
+			}
+		case isDelimiter(c):
+			i++
+		default:
+			i = skipToken(b, i)
+		}
+	}
+}
+
+// rewriteRefs replaces every indirect reference in b with repl(num).
+func rewriteRefs(b []byte, repl func(num int) string) []byte {
+	var out bytes.Buffer
+	last := 0
+	walkRefs(b, func(start, end, num int) {
+		out.Write(b[last:start])
+		out.WriteString(repl(num))
+		last = end
+	})
+	out.Write(b[last:])
+	return out.Bytes()
+}
+
+func singleRef(b []byte) (int, bool) {
+	b = bytes.TrimSpace(b)
+	if len(b) == 0 {
+		return 0, false
+	}
+	num, _, end, ok := refAt(b, 0)
+	return num, ok && end == len(b)
+}
+
+// --- Dictionaries ---
+
+type dictEntry struct {
+	key   string
+	value []byte
+}
+
+// dict is a parsed dictionary that keeps the raw bytes of its values.
+type dict []dictEntry
+
+func parseDict(b []byte) (dict, error) {
+	i := skipSpace(b, 0)
+	if !bytes.HasPrefix(b[i:], []byte("<<")) {
+		return nil, errors.New("not a dictionary")
+	}
+	i += 2
+	d := dict{}
+	for {
+		i = skipSpace(b, i)
+		if i >= len(b) {
+			return nil, errors.New("unterminated dictionary")
+		}
+		if bytes.HasPrefix(b[i:], []byte(">>")) {
+			return d, nil
+		}
+		if b[i] != '/' {
+			return nil, errors.New("dictionary key is not a name")
+		}
+		keyEnd := skipToken(b, i)
+		valStart := skipSpace(b, keyEnd)
+		valEnd := skipObject(b, valStart)
+		d = append(d, dictEntry{key: string(b[i:keyEnd]), value: b[valStart:valEnd]})
+		i = valEnd
+	}
+}
+
+func (d dict) get(key string) []byte {
+	for _, e := range d {
+		if e.key == key {
+			return e.value
+		}
+	}
+	return nil
+}
+
+func (d dict) without(key string) dict {
+	out := make(dict, 0, len(d))
+	for _, e := range d {
+		if e.key != key {
+			out = append(out, e)
+		}
+	}
+	return out
+}
+
+func (d dict) bytes() []byte {
+	var b bytes.Buffer
+	b.WriteString("<<")
+	for _, e := range d {
+		b.WriteString(" ")
+		b.WriteString(e.key)
+		b.WriteString(" ")
+		b.Write(e.value)
+	}
+	b.WriteString(" >>")
+	return b.Bytes()
+}
@@ -0,0 +1,111 @@
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMerge_Documents(t *testing.T) {
+	a := New()
+	a.Heading("Etykieta A")
+	b := New()
+	b.Heading("Etykieta B")
+	b.PageBreak()
+	b.Text("Druga strona")
+
+	out, err := Merge(a.Bytes(), b.Bytes())
+	require.NoError(t, err)
+	assert.Contains(t, string(out), "/Count 3")
+	assertXref(t, out)
+
+	// The merged file merges again.
+	again, err := Merge(out, a.Bytes())
+	require.NoError(t, err)
+	assert.Contains(t, string(again), "/Count 4")
+	assertXref(t, again)
+}
+
+func TestMerge_ObjectStreams(t *testing.T) {
+	out, err := Merge(objectStreamPDF(t))
+	require.NoError(t, err)
+	assertXref(t, out)
+	s := string(out)
+
+	assert.Contains(t, s, "/Count 1")
+	// The page takes the media box and resources of its old page tree.
+	page := regexp.MustCompile(`<< /Type /Page [^\n]*`).FindString(s)
+	assert.Contains(t, page, "/MediaBox [0 0 288 432]")
+	assert.Contains(t, page, "/Resources << /Font << /F1 ")
+	assert.Contains(t, page, "/Parent 2 0 R")
+	// The content stream is copied with a direct length.
+	assert.Contains(t, s, "<< /Length 26 >>\nstream\nBT /F1 12 Tf (label) Tj ET\nendstream")
+	assert.NotContains(t, s, "/ObjStm")
+	assert.NotContains(t, s, "/XRef")
+}
+
+func TestMerge_Rejects(t *testing.T) {
+	_, err := Merge()
+	assert.Error(t, err)
+
+	_, err = Merge([]byte("not a pdf"))
+	assert.ErrorContains(t, err, "file 1: not a PDF file")
+
+	encrypted := []byte("%PDF-1.4\n1 0 obj\n<< /Type /Catalog /Pages 2 0 R >>\nendobj\n" +
+		"trailer\n<< /Root 1 0 R /Encrypt 3 0 R >>\n%%EOF\n")
+	_, err = Merge(New().Bytes(), encrypted)
+	assert.ErrorContains(t, err, "file 2: encrypted")
+}
+
+// objectStreamPDF builds a PDF 1.5 file whose catalog and page tree live in a
+// compressed object stream, indexed by a cross-reference stream.
+func objectStreamPDF(t *testing.T) []byte {
+	t.Helper()
+	packed := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 /MediaBox [0 0 288 432] /Resources << /Font << /F1 8 0 R >> >> >>",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>",
+	}
+	var header, body bytes.Buffer
+	for i, num := range []int{1, 2, 8} {
+		fmt.Fprintf(&header, "%d %d ", num, body.Len())
+		body.WriteString(packed[i] + "\n")
+	}
+	var compressed bytes.Buffer
+	zw := zlib.NewWriter(&compressed)
+	zw.Write(header.Bytes())
+	zw.Write(body.Bytes())
+	require.NoError(t, zw.Close())
+
+	var out bytes.Buffer
+	out.WriteString("%PDF-1.5\n")
+	out.WriteString("3 0 obj\n<< /Type /Page /Parent 2 0 R /Contents 4 0 R >>\nendobj\n")
+	out.WriteString("4 0 obj\n<< /Length 5 0 R >>\nstream\nBT /F1 12 Tf (label) Tj ET\nendstream\nendobj\n")
+	out.WriteString("5 0 obj\n26\nendobj\n")
+	fmt.Fprintf(&out, "6 0 obj\n<< /Type /ObjStm /N 3 /First %d /Filter /FlateDecode /Length %d >>\nstream\n", header.Len(), compressed.Len())
+	out.Write(compressed.Bytes())
+	out.WriteString("\nendstream\nendobj\n")
+	out.WriteString("7 0 obj\n<< /Type /XRef /Size 9 /Root 1 0 R /W [1 2 1] /Length 0 >>\nstream\n\nendstream\nendobj\n")
+	out.WriteString("startxref\n0\n%%EOF\n")
+	return out.Bytes()
+}
+
+func assertXref(t *testing.T, out []byte) {
+	t.Helper()
+	m := regexp.MustCompile(`startxref\n(\d+)`).FindSubmatch(out)
+	require.NotNil(t, m)
+	xref, _ := strconv.Atoi(string(m[1]))
+	require.True(t, bytes.HasPrefix(out[xref:], []byte("xref")))
+	offsets := regexp.MustCompile(`(\d{10}) 00000 n`).FindAllSubmatch(out[xref:], -1)
+	require.NotEmpty(t, offsets)
+	for i, o := range offsets {
+		off, _ := strconv.Atoi(string(o[1]))
+		assert.True(t, bytes.HasPrefix(out[off:], []byte(fmt.Sprintf("%d 0 obj", i+1))), "object %d", i+1)
+	}
+}
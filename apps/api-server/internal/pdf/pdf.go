@@ -0,0 +1,290 @@
+// Package pdf writes simple A4 business documents — headings, text and
+// tables — using the standard Helvetica fonts, so no font files or external
+// libraries are needed. Text is encoded as Windows-1250, which covers Polish.
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"strings"
+
+	"golang.org/x/text/encoding/charmap"
+)
+
+// A4 page size and margins in points.
+const (
+	PageWidth  = 595.28
+	PageHeight = 841.89
+	Margin     = 40.0
+)
+
+// ContentWidth is the usable width between the margins.
+const ContentWidth = PageWidth - 2*Margin
+
+const (
+	textSize    = 10.0
+	headingSize = 16.0
+	lineGap     = 1.4
+)
+
+// Column describes a table column; Width is in points.
+type Column struct {
+	Title      string
+	Width      float64
+	AlignRight bool
+}
+
+// Document is an A4 document built top to bottom.
+type Document struct {
+	pages []*bytes.Buffer
+	y     float64
+}
+
+func New() *Document {
+	d := &Document{}
+	d.newPage()
+	return d
+}
+
+func (d *Document) newPage() {
+	d.pages = append(d.pages, &bytes.Buffer{})
+	d.y = PageHeight - Margin
+}
+
+func (d *Document) page() *bytes.Buffer {
+	return d.pages[len(d.pages)-1]
+}
+
+// ensure starts a new page unless height points still fit on the current one.
+func (d *Document) ensure(height float64) {
+	if d.y-height < Margin {
+		d.newPage()
+	}
+}
+
+func (d *Document) text(x, y, size float64, bold bool, s string) {
+	font := "F1"
+	if bold {
+		font = "F2"
+	}
+	fmt.Fprintf(d.page(), "BT /%s %.1f Tf %.2f %.2f Td (%s) Tj ET\n", font, size, x, y, escape(encode(s)))
+}
+
+// Heading writes a bold title line.
+func (d *Document) Heading(s string) {
+	d.ensure(headingSize * lineGap)
+	d.y -= headingSize
+	d.text(Margin, d.y, headingSize, true, s)
+	d.y -= headingSize * (lineGap - 1)
+}
+
+// Text writes a paragraph, wrapped to the page width.
+func (d *Document) Text(s string) {
+	d.writeLines(s, false)
+}
+
+// Bold writes a bold paragraph, wrapped to the page width.
+func (d *Document) Bold(s string) {
+	d.writeLines(s, true)
+}
+
+func (d *Document) writeLines(s string, bold bool) {
+	for _, line := range wrap(s, ContentWidth, textSize) {
+		d.ensure(textSize * lineGap)
+		d.y -= textSize
+		d.text(Margin, d.y, textSize, bold, line)
+		d.y -= textSize * (lineGap - 1)
+	}
+}
+
+// PageBreak continues on a new page.
+func (d *Document) PageBreak() {
+	d.newPage()
+}
+
+// Space adds vertical space.
+func (d *Document) Space(points float64) {
+	d.y -= points
+}
+
+// Table writes a table with a bold header row, repeated on every page.
+// Cells that do not fit their column are truncated.
+func (d *Document) Table(columns []Column, rows [][]string) {
+	rowHeight := textSize * 1.8
+	header := func() {
+		d.ensure(2 * rowHeight)
+		d.row(columns, nil, true)
+		fmt.Fprintf(d.page(), "%.2f %.2f m %.2f %.2f l S\n", Margin, d.y+textSize*0.4, Margin+ContentWidth, d.y+textSize*0.4)
+	}
+	header()
+	for _, r := range rows {
+		if d.y-rowHeight < Margin {
+			d.newPage()
+			header()
+		}
+		d.row(columns, r, false)
+	}
+}
+
+func (d *Document) row(columns []Column, cells []string, header bool) {
+	d.y -= textSize * 1.3
+	x := Margin
+	for i, c := range columns {
+		value := c.Title
+		if !header {
+			value = ""
+			if i < len(cells) {
+				value = cells[i]
+			}
+		}
+		value = truncate(value, c.Width-4, textSize)
+		cx := x
+		if c.AlignRight {
+			cx = x + c.Width - 4 - textWidth(value, textSize)
+		}
+		d.text(cx, d.y, textSize, header, value)
+		x += c.Width
+	}
+	d.y -= textSize * 0.5
+}
+
+// Bytes renders the document.
+func (d *Document) Bytes() []byte {
+	var out bytes.Buffer
+	var offsets []int
+	obj := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	out.WriteString("%PDF-1.4\n%\xE2\xE3\xCF\xD3\n")
+
+	// Objects 1-4: catalog, page tree and fonts; then a page and its
+	// content stream for every page.
+	firstPage := 5
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", firstPage+2*i)
+	}
+	obj("<< /Type /Catalog /Pages 2 0 R >>")
+	obj(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	obj(fontObject("Helvetica"))
+	obj(fontObject("Helvetica-Bold"))
+
+	for i, p := range d.pages {
+		obj(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.2f %.2f] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			PageWidth, PageHeight, firstPage+2*i+1))
+
+		var compressed bytes.Buffer
+		zw := zlib.NewWriter(&compressed)
+		zw.Write(p.Bytes())
+		zw.Close()
+		obj(fmt.Sprintf("<< /Length %d /Filter /FlateDecode >>\nstream\n%s\nendstream", compressed.Len(), compressed.Bytes()))
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+	return out.Bytes()
+}
+
+// cp1250Differences maps the Windows-1250 codes of Polish letters that
+// differ from WinAnsiEncoding to their glyph names.
+const cp1250Differences = "/Differences [140 /Sacute 143 /Zacute 156 /sacute 159 /zacute " +
+	"163 /Lslash 165 /Aogonek 175 /Zdotaccent 179 /lslash 185 /aogonek 191 /zdotaccent " +
+	"198 /Cacute 202 /Eogonek 209 /Nacute 230 /cacute 234 /eogonek 241 /nacute]"
+
+func fontObject(name string) string {
+	return fmt.Sprintf("<< /Type /Font /Subtype /Type1 /BaseFont /%s /Encoding << /Type /Encoding /BaseEncoding /WinAnsiEncoding %s >> >>",
+		name, cp1250Differences)
+}
+
+// encode converts text to Windows-1250, replacing characters it lacks.
+func encode(s string) []byte {
+	enc := charmap.Windows1250.NewEncoder()
+	out := make([]byte, 0, len(s))
+	for _, r := range s {
+		b, err := enc.Bytes([]byte(string(r)))
+		if err != nil || len(b) != 1 {
+			out = append(out, '?')
+			continue
+		}
+		out = append(out, b[0])
+	}
+	return out
+}
+
+func escape(b []byte) string {
+	var sb strings.Builder
+	for _, c := range b {
+		switch c {
+		case '(', ')', '\\':
+			sb.WriteByte('\\')
+			sb.WriteByte(c)
+		case '\n', '\r', '\t':
+			sb.WriteByte(' ')
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	return sb.String()
+}
+
+// helveticaWidths holds the Helvetica glyph widths of ASCII 32-126 in 1/1000 em.
+var helveticaWidths = [95]int{
+	278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+	1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+	333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+	556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+}
+
+// textWidth estimates the width of s in points. Bold text is slightly wider;
+// the estimate keeps a safety margin for it.
+func textWidth(s string, size float64) float64 {
+	total := 0
+	for _, r := range s {
+		if r >= 32 && r <= 126 {
+			total += helveticaWidths[r-32]
+		} else {
+			total += 611
+		}
+	}
+	return float64(total) * size / 1000 * 1.05
+}
+
+func truncate(s string, width, size float64) string {
+	if textWidth(s, size) <= width {
+		return s
+	}
+	runes := []rune(s)
+	for len(runes) > 0 && textWidth(string(runes)+"...", size) > width {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "..."
+}
+
+func wrap(s string, width, size float64) []string {
+	var lines []string
+	for _, para := range strings.Split(s, "\n") {
+		line := ""
+		for _, word := range strings.Fields(para) {
+			candidate := word
+			if line != "" {
+				candidate = line + " " + word
+			}
+			if line != "" && textWidth(candidate, size) > width {
+				lines = append(lines, line)
+				candidate = word
+			}
+			line = truncate(candidate, width, size)
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
@@ -0,0 +1,71 @@
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocument_Bytes(t *testing.T) {
+	doc := New()
+	doc.Heading("Zamówienie u dostawcy")
+	doc.Text("Adres dostawy: Łódź, ul. Źródlana 5 (lokal 2)")
+
+	rows := make([][]string, 80)
+	for i := range rows {
+		rows[i] = []string{fmt.Sprintf("SKU-%d", i), "Produkt", "1"}
+	}
+	doc.Table([]Column{
+		{Title: "SKU", Width: 120},
+		{Title: "Nazwa", Width: 300},
+		{Title: "Ilość", Width: 95, AlignRight: true},
+	}, rows)
+
+	out := doc.Bytes()
+	require.True(t, bytes.HasPrefix(out, []byte("%PDF-1.4")))
+	assert.True(t, bytes.HasSuffix(out, []byte("%%EOF\n")))
+	assert.Contains(t, string(out), "/Count 2", "80 rows need a second page")
+
+	// Every xref offset points at its object.
+	m := regexp.MustCompile(`startxref\n(\d+)`).FindSubmatch(out)
+	require.NotNil(t, m)
+	xref, _ := strconv.Atoi(string(m[1]))
+	require.True(t, bytes.HasPrefix(out[xref:], []byte("xref")))
+	offsets := regexp.MustCompile(`(\d{10}) 00000 n`).FindAllSubmatch(out[xref:], -1)
+	for i, o := range offsets {
+		off, _ := strconv.Atoi(string(o[1]))
+		assert.True(t, bytes.HasPrefix(out[off:], []byte(fmt.Sprintf("%d 0 obj", i+1))), "object %d", i+1)
+	}
+
+	// Polish letters are written as Windows-1250 codes.
+	content := firstStream(t, out)
+	assert.Contains(t, content, "(Adres dostawy: \xa3\xf3d\x9f, ul. \x8fr\xf3dlana 5 \\(lokal 2\\)) Tj")
+}
+
+func TestWrapAndTruncate(t *testing.T) {
+	lines := wrap("aaa bbb ccc ddd\neee", textWidth("aaa bbb", textSize)+1, textSize)
+	assert.Equal(t, []string{"aaa bbb", "ccc ddd", "eee"}, lines)
+
+	s := truncate("a very long product name", 60, textSize)
+	assert.LessOrEqual(t, textWidth(s, textSize), 60.0)
+	assert.Contains(t, s, "...")
+	assert.Equal(t, "short", truncate("short", 100, textSize))
+}
+
+func firstStream(t *testing.T, out []byte) string {
+	t.Helper()
+	i := bytes.Index(out, []byte("stream\n"))
+	require.GreaterOrEqual(t, i, 0)
+	r, err := zlib.NewReader(bytes.NewReader(out[i+len("stream\n"):]))
+	require.NoError(t, err)
+	content, err := io.ReadAll(r)
+	require.NoError(t, err)
+	return string(content)
+}
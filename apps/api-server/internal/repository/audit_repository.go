@@ -28,7 +28,7 @@ func (r *AuditRepository) Log(ctx context.Context, tx pgx.Tx, entry model.AuditE
 	_, err = tx.Exec(ctx,
 		`INSERT INTO audit_log (tenant_id, user_id, action, entity_type, entity_id, changes, ip_address)
 		 VALUES ($1, $2, $3, $4, $5, $6, $7::inet)`,
-		entry.TenantID, entry.UserID, entry.Action, entry.EntityType, entry.EntityID,
+		entry.TenantID, nilIfNilUUID(entry.UserID), entry.Action, entry.EntityType, entry.EntityID,
 		changesJSON, nilIfEmpty(entry.IPAddress),
 	)
 	if err != nil {
@@ -156,3 +156,11 @@ func nilIfEmpty(s string) *string {
 	}
 	return &s
 }
+
+// nilIfNilUUID stores system actions (workers, automation) without a user.
+func nilIfNilUUID(id uuid.UUID) *uuid.UUID {
+	if id == uuid.Nil {
+		return nil
+	}
+	return &id
+}
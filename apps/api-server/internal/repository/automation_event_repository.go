@@ -0,0 +1,221 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/openoms-org/openoms/apps/api-server/internal/model"
+)
+
+// AutomationEventRepository implements AutomationEventRepo.
+type AutomationEventRepository struct{}
+
+func NewAutomationEventRepository() *AutomationEventRepository {
+	return &AutomationEventRepository{}
+}
+
+const automationEventColumns = `id, seq, tenant_id, event_type, entity_type, entity_id, rule_id, data,
+		        status, attempts, next_attempt_at, locked_at, last_error, processed_at, created_at`
+
+// Enqueue adds an event to the queue. It is called in the transaction of the
+// change that caused the event, so the event is stored if and only if the
+// change is committed. The schedule worker calls it directly, bypassing RLS.
+func (r *AutomationEventRepository) Enqueue(ctx context.Context, tx pgx.Tx, ev *model.AutomationEvent) error {
+	err := tx.QueryRow(ctx,
+		`INSERT INTO automation_events (tenant_id, event_type, entity_type, entity_id, rule_id, data)
+		 VALUES ($1, $2, $3, $4, $5, $6)
+		 RETURNING id, seq, status, attempts, next_attempt_at, created_at`,
+		ev.TenantID, ev.EventType, ev.EntityType, ev.EntityID, ev.RuleID, ev.Data,
+	).Scan(&ev.ID, &ev.Seq, &ev.Status, &ev.Attempts, &ev.NextAttemptAt, &ev.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("enqueue automation event: %w", err)
+	}
+	return nil
+}
+
+// ClaimDue marks up to limit due events as processing and returns them in
+// queue order. Events due for a retry and events whose processing was
+// locked before staleBefore (a crashed worker) are due. An event is only
+// claimed when no earlier event of the same entity is still pending or
+// processing, which keeps the events of an entity in order. This is called
+// from the worker which bypasses RLS.
+func (r *AutomationEventRepository) ClaimDue(ctx context.Context, tx pgx.Tx, now, staleBefore time.Time, limit int) ([]model.AutomationEvent, error) {
+	rows, err := tx.Query(ctx,
+		`WITH due AS (
+		     SELECT e.id FROM automation_events e
+		     WHERE ((e.status = 'pending' AND e.next_attempt_at <= $1)
+		            OR (e.status = 'processing' AND e.locked_at < $2))
+		       AND NOT EXISTS (
+		           SELECT 1 FROM automation_events p
+		           WHERE p.tenant_id = e.tenant_id
+		             AND p.entity_type = e.entity_type
+		             AND p.entity_id = e.entity_id
+		             AND p.seq < e.seq
+		             AND p.status IN ('pending', 'processing'))
+		     ORDER BY e.seq
+		     LIMIT $3
+		     FOR UPDATE SKIP LOCKED
+		 )
+		 UPDATE automation_events e
+		 SET status = 'processing', locked_at = $1, attempts = e.attempts + 1
+		 FROM due
+		 WHERE e.id = due.id
+		 RETURNING e.id, e.seq, e.tenant_id, e.event_type, e.entity_type, e.entity_id, e.rule_id, e.data,
+		           e.status, e.attempts, e.next_attempt_at, e.locked_at, e.last_error,
+		           e.processed_at, e.created_at`,
+		now, staleBefore, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("claim automation events: %w", err)
+	}
+	defer rows.Close()
+
+	events, err := scanAutomationEvents(rows)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(events, func(i, j int) bool { return events[i].Seq < events[j].Seq })
+	return events, nil
+}
+
+// MarkDone records that an event was processed.
+func (r *AutomationEventRepository) MarkDone(ctx context.Context, tx pgx.Tx, id uuid.UUID) error {
+	_, err := tx.Exec(ctx,
+		`UPDATE automation_events
+		 SET status = 'done', processed_at = NOW(), locked_at = NULL, last_error = NULL
+		 WHERE id = $1`, id,
+	)
+	if err != nil {
+		return fmt.Errorf("mark automation event done: %w", err)
+	}
+	return nil
+}
+
+// MarkFailed records a failed attempt: the event is either scheduled for
+// another attempt at nextAttemptAt (status pending) or dead-lettered
+// (status dead).
+func (r *AutomationEventRepository) MarkFailed(ctx context.Context, tx pgx.Tx, id uuid.UUID, status string, nextAttemptAt time.Time, errMsg string) error {
+	_, err := tx.Exec(ctx,
+		`UPDATE automation_events
+		 SET status = $2, next_attempt_at = $3, last_error = $4, locked_at = NULL
+		 WHERE id = $1`, id, status, nextAttemptAt, errMsg,
+	)
+	if err != nil {
+		return fmt.Errorf("mark automation event failed: %w", err)
+	}
+	return nil
+}
+
+// ActionDone reports whether an action of a rule already succeeded for an
+// event in an earlier attempt.
+func (r *AutomationEventRepository) ActionDone(ctx context.Context, tx pgx.Tx, eventID, ruleID uuid.UUID, actionIndex int) (bool, error) {
+	var done bool
+	err := tx.QueryRow(ctx,
+		`SELECT EXISTS (
+		     SELECT 1 FROM automation_action_runs
+		     WHERE event_id = $1 AND rule_id = $2 AND action_index = $3)`,
+		eventID, ruleID, actionIndex,
+	).Scan(&done)
+	if err != nil {
+		return false, fmt.Errorf("check automation action run: %w", err)
+	}
+	return done, nil
+}
+
+// RecordAction records that an action of a rule succeeded for an event. It
+// is called in a transaction of its own, so the record survives a rollback
+// of the attempt.
+func (r *AutomationEventRepository) RecordAction(ctx context.Context, tx pgx.Tx, tenantID, eventID, ruleID uuid.UUID, actionIndex int) error {
+	_, err := tx.Exec(ctx,
+		`INSERT INTO automation_action_runs (tenant_id, event_id, rule_id, action_index)
+		 VALUES ($1, $2, $3, $4)
+		 ON CONFLICT DO NOTHING`,
+		tenantID, eventID, ruleID, actionIndex,
+	)
+	if err != nil {
+		return fmt.Errorf("record automation action run: %w", err)
+	}
+	return nil
+}
+
+// List returns the current tenant's events with the given status, newest
+// first.
+func (r *AutomationEventRepository) List(ctx context.Context, tx pgx.Tx, filter model.AutomationEventListFilter) ([]model.AutomationEvent, int, error) {
+	var total int
+	if err := tx.QueryRow(ctx,
+		`SELECT COUNT(*) FROM automation_events WHERE status = $1`, filter.Status,
+	).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("count automation events: %w", err)
+	}
+
+	rows, err := tx.Query(ctx,
+		`SELECT `+automationEventColumns+`
+		 FROM automation_events
+		 WHERE status = $1
+		 ORDER BY created_at DESC, seq DESC
+		 LIMIT $2 OFFSET $3`,
+		filter.Status, filter.Limit, filter.Offset,
+	)
+	if err != nil {
+		return nil, 0, fmt.Errorf("list automation events: %w", err)
+	}
+	defer rows.Close()
+
+	events, err := scanAutomationEvents(rows)
+	return events, total, err
+}
+
+// Retry moves a dead event back to the queue with a fresh attempt budget.
+func (r *AutomationEventRepository) Retry(ctx context.Context, tx pgx.Tx, id uuid.UUID) (*model.AutomationEvent, error) {
+	rows, err := tx.Query(ctx,
+		`UPDATE automation_events
+		 SET status = 'pending', attempts = 0, next_attempt_at = NOW(), locked_at = NULL
+		 WHERE id = $1 AND status = 'dead'
+		 RETURNING `+automationEventColumns, id,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("retry automation event: %w", err)
+	}
+	defer rows.Close()
+
+	events, err := scanAutomationEvents(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(events) == 0 {
+		return nil, nil
+	}
+	return &events[0], nil
+}
+
+// DeleteDoneBefore removes events processed before the given time. This is
+// called from the worker which bypasses RLS.
+func (r *AutomationEventRepository) DeleteDoneBefore(ctx context.Context, tx pgx.Tx, before time.Time) (int64, error) {
+	ct, err := tx.Exec(ctx,
+		`DELETE FROM automation_events WHERE status = 'done' AND processed_at < $1`, before,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("delete processed automation events: %w", err)
+	}
+	return ct.RowsAffected(), nil
+}
+
+func scanAutomationEvents(rows pgx.Rows) ([]model.AutomationEvent, error) {
+	var events []model.AutomationEvent
+	for rows.Next() {
+		var ev model.AutomationEvent
+		if err := rows.Scan(
+			&ev.ID, &ev.Seq, &ev.TenantID, &ev.EventType, &ev.EntityType, &ev.EntityID, &ev.RuleID, &ev.Data,
+			&ev.Status, &ev.Attempts, &ev.NextAttemptAt, &ev.LockedAt, &ev.LastError,
+			&ev.ProcessedAt, &ev.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scan automation event: %w", err)
+		}
+		events = append(events, ev)
+	}
+	return events, rows.Err()
+}
@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/openoms-org/openoms/apps/api-server/internal/model"
+)
+
+// AutomationFiringRepository implements AutomationFiringRepo.
+type AutomationFiringRepository struct{}
+
+func NewAutomationFiringRepository() *AutomationFiringRepository {
+	return &AutomationFiringRepository{}
+}
+
+// statusEntityTables maps status duration entities to their table and the
+// columns left out of the data rules are evaluated against.
+var statusEntityTables = map[string]struct {
+	table   string
+	exclude string
+}{
+	"order":    {"orders", "'search_vector'"},
+	"shipment": {"shipments", "'label_url'"},
+	"return":   {"returns", "'return_token'"},
+}
+
+// ClaimStatusEntities records a firing of the rule for up to limit entities
+// that entered status at or before before and that the rule has not fired
+// for yet, and returns them. The caller enqueues the rule's events in the
+// same transaction, so each entity fires exactly once per rule.
+func (r *AutomationFiringRepository) ClaimStatusEntities(ctx context.Context, tx pgx.Tx, rule model.AutomationRule, entityType, status string, before time.Time, limit int) ([]model.AutomationStatusEntity, error) {
+	t, ok := statusEntityTables[entityType]
+	if !ok {
+		return nil, fmt.Errorf("unsupported status duration entity %q", entityType)
+	}
+
+	query := fmt.Sprintf(
+		`WITH candidates AS (
+		     SELECT e.id FROM %[1]s e
+		     WHERE e.status = $3 AND e.status_changed_at <= $4
+		       AND NOT EXISTS (
+		           SELECT 1 FROM automation_rule_firings f
+		           WHERE f.rule_id = $1 AND f.entity_id = e.id)
+		     ORDER BY e.status_changed_at
+		     LIMIT $5
+		 ), claimed AS (
+		     INSERT INTO automation_rule_firings (tenant_id, rule_id, entity_type, entity_id)
+		     SELECT $2, $1, $6, id FROM candidates
+		     ON CONFLICT DO NOTHING
+		     RETURNING entity_id
+		 )
+		 SELECT e.id, e.status_changed_at, to_jsonb(e) - %[2]s
+		 FROM %[1]s e
+		 JOIN claimed c ON c.entity_id = e.id
+		 ORDER BY e.status_changed_at`,
+		t.table, t.exclude,
+	)
+
+	rows, err := tx.Query(ctx, query, rule.ID, rule.TenantID, status, before, limit, entityType)
+	if err != nil {
+		return nil, fmt.Errorf("claim %s status entities: %w", entityType, err)
+	}
+	defer rows.Close()
+
+	var entities []model.AutomationStatusEntity
+	for rows.Next() {
+		var e model.AutomationStatusEntity
+		var data []byte
+		if err := rows.Scan(&e.EntityID, &e.StatusChangedAt, &data); err != nil {
+			return nil, fmt.Errorf("scan %s status entity: %w", entityType, err)
+		}
+		if err := json.Unmarshal(data, &e.Data); err != nil {
+			return nil, fmt.Errorf("decode %s status entity: %w", entityType, err)
+		}
+		entities = append(entities, e)
+	}
+	return entities, rows.Err()
+}
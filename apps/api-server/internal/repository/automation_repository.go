@@ -50,8 +50,8 @@ func (r *AutomationRuleRepository) List(ctx context.Context, tx pgx.Tx, filter m
 
 	query := fmt.Sprintf(
 		`SELECT id, tenant_id, name, description, enabled, priority,
-		        trigger_event, conditions, actions, last_fired_at, fire_count,
-		        created_at, updated_at
+		        trigger_event, trigger_config, conditions, actions, last_fired_at,
+		        next_run_at, fire_count, created_at, updated_at
 		 FROM automation_rules %s
 		 %s
 		 LIMIT $%d OFFSET $%d`,
@@ -70,9 +70,9 @@ func (r *AutomationRuleRepository) List(ctx context.Context, tx pgx.Tx, filter m
 		var rule model.AutomationRule
 		if err := rows.Scan(
 			&rule.ID, &rule.TenantID, &rule.Name, &rule.Description,
-			&rule.Enabled, &rule.Priority, &rule.TriggerEvent,
-			&rule.Conditions, &rule.Actions, &rule.LastFiredAt, &rule.FireCount,
-			&rule.CreatedAt, &rule.UpdatedAt,
+			&rule.Enabled, &rule.Priority, &rule.TriggerEvent, &rule.TriggerConfig,
+			&rule.Conditions, &rule.Actions, &rule.LastFiredAt, &rule.NextRunAt,
+			&rule.FireCount, &rule.CreatedAt, &rule.UpdatedAt,
 		); err != nil {
 			return nil, 0, fmt.Errorf("scan automation rule: %w", err)
 		}
@@ -85,14 +85,14 @@ func (r *AutomationRuleRepository) FindByID(ctx context.Context, tx pgx.Tx, id u
 	var rule model.AutomationRule
 	err := tx.QueryRow(ctx,
 		`SELECT id, tenant_id, name, description, enabled, priority,
-		        trigger_event, conditions, actions, last_fired_at, fire_count,
-		        created_at, updated_at
+		        trigger_event, trigger_config, conditions, actions, last_fired_at,
+		        next_run_at, fire_count, created_at, updated_at
 		 FROM automation_rules WHERE id = $1`, id,
 	).Scan(
 		&rule.ID, &rule.TenantID, &rule.Name, &rule.Description,
-		&rule.Enabled, &rule.Priority, &rule.TriggerEvent,
-		&rule.Conditions, &rule.Actions, &rule.LastFiredAt, &rule.FireCount,
-		&rule.CreatedAt, &rule.UpdatedAt,
+		&rule.Enabled, &rule.Priority, &rule.TriggerEvent, &rule.TriggerConfig,
+		&rule.Conditions, &rule.Actions, &rule.LastFiredAt, &rule.NextRunAt,
+		&rule.FireCount, &rule.CreatedAt, &rule.UpdatedAt,
 	)
 	if err != nil {
 		if err == pgx.ErrNoRows {
@@ -106,8 +106,8 @@ func (r *AutomationRuleRepository) FindByID(ctx context.Context, tx pgx.Tx, id u
 func (r *AutomationRuleRepository) FindByTenantAndEvent(ctx context.Context, tx pgx.Tx, event string) ([]model.AutomationRule, error) {
 	rows, err := tx.Query(ctx,
 		`SELECT id, tenant_id, name, description, enabled, priority,
-		        trigger_event, conditions, actions, last_fired_at, fire_count,
-		        created_at, updated_at
+		        trigger_event, trigger_config, conditions, actions, last_fired_at,
+		        next_run_at, fire_count, created_at, updated_at
 		 FROM automation_rules
 		 WHERE trigger_event = $1 AND enabled = true
 		 ORDER BY priority DESC, created_at ASC`, event,
@@ -122,9 +122,9 @@ func (r *AutomationRuleRepository) FindByTenantAndEvent(ctx context.Context, tx
 		var rule model.AutomationRule
 		if err := rows.Scan(
 			&rule.ID, &rule.TenantID, &rule.Name, &rule.Description,
-			&rule.Enabled, &rule.Priority, &rule.TriggerEvent,
-			&rule.Conditions, &rule.Actions, &rule.LastFiredAt, &rule.FireCount,
-			&rule.CreatedAt, &rule.UpdatedAt,
+			&rule.Enabled, &rule.Priority, &rule.TriggerEvent, &rule.TriggerConfig,
+			&rule.Conditions, &rule.Actions, &rule.LastFiredAt, &rule.NextRunAt,
+			&rule.FireCount, &rule.CreatedAt, &rule.UpdatedAt,
 		); err != nil {
 			return nil, fmt.Errorf("scan automation rule: %w", err)
 		}
@@ -137,12 +137,12 @@ func (r *AutomationRuleRepository) Create(ctx context.Context, tx pgx.Tx, rule *
 	return tx.QueryRow(ctx,
 		`INSERT INTO automation_rules (
 			id, tenant_id, name, description, enabled, priority,
-			trigger_event, conditions, actions
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+			trigger_event, trigger_config, conditions, actions, next_run_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 		RETURNING created_at, updated_at`,
 		rule.ID, rule.TenantID, rule.Name, rule.Description,
-		rule.Enabled, rule.Priority, rule.TriggerEvent,
-		rule.Conditions, rule.Actions,
+		rule.Enabled, rule.Priority, rule.TriggerEvent, rule.TriggerConfig,
+		rule.Conditions, rule.Actions, rule.NextRunAt,
 	).Scan(&rule.CreatedAt, &rule.UpdatedAt)
 }
 
@@ -176,6 +176,11 @@ func (r *AutomationRuleRepository) Update(ctx context.Context, tx pgx.Tx, id uui
 		args = append(args, *req.TriggerEvent)
 		argIdx++
 	}
+	if req.TriggerConfig != nil {
+		setClauses = append(setClauses, fmt.Sprintf("trigger_config = $%d", argIdx))
+		args = append(args, req.TriggerConfig)
+		argIdx++
+	}
 	if req.Conditions != nil {
 		setClauses = append(setClauses, fmt.Sprintf("conditions = $%d", argIdx))
 		args = append(args, req.Conditions)
@@ -234,6 +239,74 @@ func (r *AutomationRuleRepository) IncrementFireCount(ctx context.Context, tx pg
 	return nil
 }
 
+// SetNextRun stores when a schedule rule runs next; nil clears it.
+func (r *AutomationRuleRepository) SetNextRun(ctx context.Context, tx pgx.Tx, id uuid.UUID, next *time.Time) error {
+	if _, err := tx.Exec(ctx, `UPDATE automation_rules SET next_run_at = $2 WHERE id = $1`, id, next); err != nil {
+		return fmt.Errorf("set automation rule next run: %w", err)
+	}
+	return nil
+}
+
+// ListDueScheduled returns enabled schedule rules due at now, locking them so
+// concurrent workers skip them. This is called from the worker which
+// bypasses RLS.
+func (r *AutomationRuleRepository) ListDueScheduled(ctx context.Context, tx pgx.Tx, now time.Time, limit int) ([]model.AutomationRule, error) {
+	rows, err := tx.Query(ctx,
+		`SELECT id, tenant_id, name, description, enabled, priority,
+		        trigger_event, trigger_config, conditions, actions, last_fired_at,
+		        next_run_at, fire_count, created_at, updated_at
+		 FROM automation_rules
+		 WHERE trigger_event = $1 AND enabled AND next_run_at <= $2
+		 ORDER BY next_run_at
+		 LIMIT $3
+		 FOR UPDATE SKIP LOCKED`, model.TriggerSchedule, now, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list due scheduled automation rules: %w", err)
+	}
+	defer rows.Close()
+	return scanAutomationRules(rows)
+}
+
+// ListStatusDurationRules returns the enabled status duration rules of all
+// tenants. This is called from the worker which bypasses RLS.
+func (r *AutomationRuleRepository) ListStatusDurationRules(ctx context.Context, tx pgx.Tx) ([]model.AutomationRule, error) {
+	events := make([]string, 0, len(model.StatusDurationEntities))
+	for event := range model.StatusDurationEntities {
+		events = append(events, event)
+	}
+	rows, err := tx.Query(ctx,
+		`SELECT id, tenant_id, name, description, enabled, priority,
+		        trigger_event, trigger_config, conditions, actions, last_fired_at,
+		        next_run_at, fire_count, created_at, updated_at
+		 FROM automation_rules
+		 WHERE trigger_event = ANY($1) AND enabled
+		 ORDER BY tenant_id, priority DESC, created_at ASC`, events,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list status duration automation rules: %w", err)
+	}
+	defer rows.Close()
+	return scanAutomationRules(rows)
+}
+
+func scanAutomationRules(rows pgx.Rows) ([]model.AutomationRule, error) {
+	var rules []model.AutomationRule
+	for rows.Next() {
+		var rule model.AutomationRule
+		if err := rows.Scan(
+			&rule.ID, &rule.TenantID, &rule.Name, &rule.Description,
+			&rule.Enabled, &rule.Priority, &rule.TriggerEvent, &rule.TriggerConfig,
+			&rule.Conditions, &rule.Actions, &rule.LastFiredAt, &rule.NextRunAt,
+			&rule.FireCount, &rule.CreatedAt, &rule.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scan automation rule: %w", err)
+		}
+		rules = append(rules, rule)
+	}
+	return rules, rows.Err()
+}
+
 // AutomationRuleLogRepository implements AutomationRuleLogRepo.
 type AutomationRuleLogRepository struct{}
 
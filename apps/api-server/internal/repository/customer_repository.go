@@ -264,7 +264,7 @@ func (r *CustomerRepository) ListOrdersByCustomerID(ctx context.Context, tx pgx.
 		        ordered_at, shipped_at, delivered_at,
 		        delivery_method, pickup_point_id,
 		        payment_status, payment_method, paid_at, customer_id, merged_into, split_from,
-		        internal_notes, priority, created_at, updated_at
+		        internal_notes, priority, assigned_to, created_at, updated_at
 		 FROM orders %s %s LIMIT $%d OFFSET $%d`,
 		where, orderByClause, argIdx, argIdx+1,
 	)
@@ -287,7 +287,7 @@ func (r *CustomerRepository) ListOrdersByCustomerID(ctx context.Context, tx pgx.
 			&o.OrderedAt, &o.ShippedAt, &o.DeliveredAt,
 			&o.DeliveryMethod, &o.PickupPointID,
 			&o.PaymentStatus, &o.PaymentMethod, &o.PaidAt, &o.CustomerID, &o.MergedInto, &o.SplitFrom,
-			&o.InternalNotes, &o.Priority, &o.CreatedAt, &o.UpdatedAt,
+			&o.InternalNotes, &o.Priority, &o.AssignedTo, &o.CreatedAt, &o.UpdatedAt,
 		); err != nil {
 			return nil, 0, fmt.Errorf("scan customer order: %w", err)
 		}
@@ -0,0 +1,281 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/openoms-org/openoms/apps/api-server/internal/model"
+)
+
+type FulfillmentRuleRepository struct{}
+
+func NewFulfillmentRuleRepository() *FulfillmentRuleRepository {
+	return &FulfillmentRuleRepository{}
+}
+
+func (r *FulfillmentRuleRepository) List(ctx context.Context, tx pgx.Tx) ([]model.FulfillmentRule, error) {
+	rows, err := tx.Query(ctx,
+		`SELECT product_id, tenant_id, mode, supplier_id, created_at, updated_at
+		 FROM fulfillment_rules ORDER BY created_at`,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list fulfillment rules: %w", err)
+	}
+	return scanFulfillmentRules(rows)
+}
+
+func (r *FulfillmentRuleRepository) FindByProductIDs(ctx context.Context, tx pgx.Tx, productIDs []uuid.UUID) ([]model.FulfillmentRule, error) {
+	rows, err := tx.Query(ctx,
+		`SELECT product_id, tenant_id, mode, supplier_id, created_at, updated_at
+		 FROM fulfillment_rules WHERE product_id = ANY($1)`,
+		productIDs,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("find fulfillment rules: %w", err)
+	}
+	return scanFulfillmentRules(rows)
+}
+
+func scanFulfillmentRules(rows pgx.Rows) ([]model.FulfillmentRule, error) {
+	defer rows.Close()
+	var rules []model.FulfillmentRule
+	for rows.Next() {
+		var fr model.FulfillmentRule
+		if err := rows.Scan(&fr.ProductID, &fr.TenantID, &fr.Mode, &fr.SupplierID, &fr.CreatedAt, &fr.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("scan fulfillment rule: %w", err)
+		}
+		rules = append(rules, fr)
+	}
+	return rules, rows.Err()
+}
+
+func (r *FulfillmentRuleRepository) Upsert(ctx context.Context, tx pgx.Tx, rule *model.FulfillmentRule) error {
+	return tx.QueryRow(ctx,
+		`INSERT INTO fulfillment_rules (product_id, tenant_id, mode, supplier_id)
+		 VALUES ($1, $2, $3, $4)
+		 ON CONFLICT (product_id) DO UPDATE SET mode = EXCLUDED.mode, supplier_id = EXCLUDED.supplier_id
+		 RETURNING created_at, updated_at`,
+		rule.ProductID, rule.TenantID, rule.Mode, rule.SupplierID,
+	).Scan(&rule.CreatedAt, &rule.UpdatedAt)
+}
+
+func (r *FulfillmentRuleRepository) Delete(ctx context.Context, tx pgx.Tx, productID uuid.UUID) error {
+	ct, err := tx.Exec(ctx, "DELETE FROM fulfillment_rules WHERE product_id = $1", productID)
+	if err != nil {
+		return fmt.Errorf("delete fulfillment rule: %w", err)
+	}
+	if ct.RowsAffected() == 0 {
+		return fmt.Errorf("fulfillment rule not found")
+	}
+	return nil
+}
+
+// SupplierOrderRepository
+
+type SupplierOrderRepository struct{}
+
+func NewSupplierOrderRepository() *SupplierOrderRepository {
+	return &SupplierOrderRepository{}
+}
+
+const supplierOrderColumns = `so.id, so.tenant_id, so.supplier_id, s.name, so.order_id, so.status, so.items,
+	so.total_cost, so.channel, so.attempts, so.error_message, so.sent_at, so.carrier,
+	so.tracking_number, so.shipment_id, so.shipped_at, so.callback_token, so.created_at, so.updated_at`
+
+func scanSupplierOrder(row interface{ Scan(dest ...any) error }) (*model.SupplierOrder, error) {
+	var so model.SupplierOrder
+	err := row.Scan(
+		&so.ID, &so.TenantID, &so.SupplierID, &so.SupplierName, &so.OrderID, &so.Status, &so.Items,
+		&so.TotalCost, &so.Channel, &so.Attempts, &so.ErrorMessage, &so.SentAt, &so.Carrier,
+		&so.TrackingNumber, &so.ShipmentID, &so.ShippedAt, &so.CallbackToken, &so.CreatedAt, &so.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &so, nil
+}
+
+func (r *SupplierOrderRepository) List(ctx context.Context, tx pgx.Tx, filter model.SupplierOrderListFilter) ([]model.SupplierOrder, int, error) {
+	var conditions []string
+	var args []any
+	argIdx := 1
+
+	if filter.Status != nil {
+		conditions = append(conditions, fmt.Sprintf("so.status = $%d", argIdx))
+		args = append(args, *filter.Status)
+		argIdx++
+	}
+	if filter.SupplierID != nil {
+		conditions = append(conditions, fmt.Sprintf("so.supplier_id = $%d", argIdx))
+		args = append(args, *filter.SupplierID)
+		argIdx++
+	}
+	if filter.OrderID != nil {
+		conditions = append(conditions, fmt.Sprintf("so.order_id = $%d", argIdx))
+		args = append(args, *filter.OrderID)
+		argIdx++
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var total int
+	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM supplier_orders so %s", where)
+	if err := tx.QueryRow(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("count supplier orders: %w", err)
+	}
+
+	allowedSortColumns := map[string]string{
+		"created_at": "so.created_at",
+		"status":     "so.status",
+		"total_cost": "so.total_cost",
+	}
+	orderByClause := model.BuildOrderByClause(filter.SortBy, filter.SortOrder, allowedSortColumns)
+
+	query := fmt.Sprintf(
+		`SELECT %s FROM supplier_orders so JOIN suppliers s ON s.id = so.supplier_id
+		 %s %s LIMIT $%d OFFSET $%d`,
+		supplierOrderColumns, where, orderByClause, argIdx, argIdx+1,
+	)
+	args = append(args, filter.Limit, filter.Offset)
+
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("list supplier orders: %w", err)
+	}
+	defer rows.Close()
+
+	var orders []model.SupplierOrder
+	for rows.Next() {
+		so, err := scanSupplierOrder(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("scan supplier order: %w", err)
+		}
+		orders = append(orders, *so)
+	}
+	return orders, total, rows.Err()
+}
+
+func (r *SupplierOrderRepository) FindByID(ctx context.Context, tx pgx.Tx, id uuid.UUID) (*model.SupplierOrder, error) {
+	so, err := scanSupplierOrder(tx.QueryRow(ctx,
+		`SELECT `+supplierOrderColumns+` FROM supplier_orders so JOIN suppliers s ON s.id = so.supplier_id
+		 WHERE so.id = $1`, id,
+	))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("find supplier order by id: %w", err)
+	}
+	return so, nil
+}
+
+func (r *SupplierOrderRepository) ListByOrderID(ctx context.Context, tx pgx.Tx, orderID uuid.UUID) ([]model.SupplierOrder, error) {
+	rows, err := tx.Query(ctx,
+		`SELECT `+supplierOrderColumns+` FROM supplier_orders so JOIN suppliers s ON s.id = so.supplier_id
+		 WHERE so.order_id = $1 ORDER BY so.created_at`, orderID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list supplier orders by order: %w", err)
+	}
+	defer rows.Close()
+
+	var orders []model.SupplierOrder
+	for rows.Next() {
+		so, err := scanSupplierOrder(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan supplier order: %w", err)
+		}
+		orders = append(orders, *so)
+	}
+	return orders, rows.Err()
+}
+
+func (r *SupplierOrderRepository) Create(ctx context.Context, tx pgx.Tx, so *model.SupplierOrder) error {
+	items, err := json.Marshal(so.Items)
+	if err != nil {
+		return fmt.Errorf("marshal supplier order items: %w", err)
+	}
+	return tx.QueryRow(ctx,
+		`INSERT INTO supplier_orders (id, tenant_id, supplier_id, order_id, status, items, total_cost, channel, callback_token)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		 RETURNING created_at, updated_at`,
+		so.ID, so.TenantID, so.SupplierID, so.OrderID, so.Status, items, so.TotalCost, so.Channel, so.CallbackToken,
+	).Scan(&so.CreatedAt, &so.UpdatedAt)
+}
+
+// RecordDelivery stores the outcome of sending the order to the supplier.
+func (r *SupplierOrderRepository) RecordDelivery(ctx context.Context, tx pgx.Tx, id uuid.UUID, status string, errorMessage *string) error {
+	_, err := tx.Exec(ctx,
+		`UPDATE supplier_orders
+		 SET status = $1, error_message = $2, attempts = attempts + 1,
+		     sent_at = CASE WHEN $1 = 'sent' THEN NOW() ELSE sent_at END,
+		     updated_at = NOW()
+		 WHERE id = $3`,
+		status, errorMessage, id,
+	)
+	if err != nil {
+		return fmt.Errorf("record supplier order delivery: %w", err)
+	}
+	return nil
+}
+
+func (r *SupplierOrderRepository) MarkShipped(ctx context.Context, tx pgx.Tx, id uuid.UUID, carrier, trackingNumber string, shipmentID uuid.UUID) error {
+	_, err := tx.Exec(ctx,
+		`UPDATE supplier_orders
+		 SET status = 'shipped', carrier = $1, tracking_number = $2, shipment_id = $3,
+		     shipped_at = NOW(), error_message = NULL, updated_at = NOW()
+		 WHERE id = $4`,
+		carrier, trackingNumber, shipmentID, id,
+	)
+	if err != nil {
+		return fmt.Errorf("mark supplier order shipped: %w", err)
+	}
+	return nil
+}
+
+func (r *SupplierOrderRepository) UpdateStatus(ctx context.Context, tx pgx.Tx, id uuid.UUID, status string) error {
+	ct, err := tx.Exec(ctx,
+		`UPDATE supplier_orders SET status = $1, updated_at = NOW() WHERE id = $2`,
+		status, id,
+	)
+	if err != nil {
+		return fmt.Errorf("update supplier order status: %w", err)
+	}
+	if ct.RowsAffected() == 0 {
+		return fmt.Errorf("supplier order not found")
+	}
+	return nil
+}
+
+// ListRetryable returns pending and failed supplier orders that have attempts
+// left. Failed orders wait five minutes per attempt before the next one.
+func (r *SupplierOrderRepository) ListRetryable(ctx context.Context, tx pgx.Tx, maxAttempts, limit int) ([]uuid.UUID, error) {
+	rows, err := tx.Query(ctx,
+		`SELECT id FROM supplier_orders
+		 WHERE status IN ('pending', 'failed') AND attempts < $1
+		   AND updated_at < NOW() - make_interval(mins => attempts * 5)
+		 ORDER BY created_at LIMIT $2`,
+		maxAttempts, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list retryable supplier orders: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("scan retryable supplier order: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
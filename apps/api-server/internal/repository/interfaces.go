@@ -20,6 +20,29 @@ type AutomationRuleRepo interface {
 	Update(ctx context.Context, tx pgx.Tx, id uuid.UUID, req model.UpdateAutomationRuleRequest) error
 	Delete(ctx context.Context, tx pgx.Tx, id uuid.UUID) error
 	IncrementFireCount(ctx context.Context, tx pgx.Tx, id uuid.UUID, firedAt time.Time) error
+	SetNextRun(ctx context.Context, tx pgx.Tx, id uuid.UUID, next *time.Time) error
+	ListDueScheduled(ctx context.Context, tx pgx.Tx, now time.Time, limit int) ([]model.AutomationRule, error)
+	ListStatusDurationRules(ctx context.Context, tx pgx.Tx) ([]model.AutomationRule, error)
+}
+
+// AutomationFiringRepo defines the interface for the firings of status
+// duration rules.
+type AutomationFiringRepo interface {
+	ClaimStatusEntities(ctx context.Context, tx pgx.Tx, rule model.AutomationRule, entityType, status string, before time.Time, limit int) ([]model.AutomationStatusEntity, error)
+}
+
+// AutomationEventRepo defines the interface for the durable automation event
+// queue.
+type AutomationEventRepo interface {
+	Enqueue(ctx context.Context, tx pgx.Tx, ev *model.AutomationEvent) error
+	ClaimDue(ctx context.Context, tx pgx.Tx, now, staleBefore time.Time, limit int) ([]model.AutomationEvent, error)
+	MarkDone(ctx context.Context, tx pgx.Tx, id uuid.UUID) error
+	MarkFailed(ctx context.Context, tx pgx.Tx, id uuid.UUID, status string, nextAttemptAt time.Time, errMsg string) error
+	List(ctx context.Context, tx pgx.Tx, filter model.AutomationEventListFilter) ([]model.AutomationEvent, int, error)
+	Retry(ctx context.Context, tx pgx.Tx, id uuid.UUID) (*model.AutomationEvent, error)
+	DeleteDoneBefore(ctx context.Context, tx pgx.Tx, before time.Time) (int64, error)
+	ActionDone(ctx context.Context, tx pgx.Tx, eventID, ruleID uuid.UUID, actionIndex int) (bool, error)
+	RecordAction(ctx context.Context, tx pgx.Tx, tenantID, eventID, ruleID uuid.UUID, actionIndex int) error
 }
 
 // AutomationRuleLogRepo defines the interface for automation rule log persistence.
@@ -39,12 +62,19 @@ type DelayedActionRepo interface {
 // OrderRepo defines the interface for order persistence operations.
 type OrderRepo interface {
 	List(ctx context.Context, tx pgx.Tx, filter model.OrderListFilter) ([]model.Order, int, error)
+	ListIDs(ctx context.Context, tx pgx.Tx, filter model.OrderListFilter, limit int) ([]uuid.UUID, error)
 	FindByID(ctx context.Context, tx pgx.Tx, id uuid.UUID) (*model.Order, error)
 	Create(ctx context.Context, tx pgx.Tx, order *model.Order) error
 	Update(ctx context.Context, tx pgx.Tx, id uuid.UUID, req model.UpdateOrderRequest) error
 	UpdateStatus(ctx context.Context, tx pgx.Tx, id uuid.UUID, status string, shippedAt, deliveredAt *time.Time) error
 	FindByExternalID(ctx context.Context, tx pgx.Tx, source, externalID string) (*model.Order, error)
 	Delete(ctx context.Context, tx pgx.Tx, id uuid.UUID) error
+	SetItems(ctx context.Context, tx pgx.Tx, id uuid.UUID, items json.RawMessage) error
+	SetAddressCheck(ctx context.Context, tx pgx.Tx, id uuid.UUID, shippingAddress json.RawMessage, customerPhone *string, issues []model.AddressIssue) error
+	SetRisk(ctx context.Context, tx pgx.Tx, id uuid.UUID, score int, signals []model.RiskSignal) error
+	MarkFulfillmentRouted(ctx context.Context, tx pgx.Tx, id uuid.UUID) error
+	ListFulfillmentUnrouted(ctx context.Context, tx pgx.Tx, statuses []string, limit int) ([]uuid.UUID, error)
+	MarkStaleFulfillmentRouted(ctx context.Context, tx pgx.Tx, before time.Time) (int64, error)
 }
 
 // UserRepo defines the interface for user persistence operations.
@@ -85,6 +115,32 @@ type AuditRepo interface {
 	List(ctx context.Context, tx pgx.Tx, filter model.AuditListFilter) ([]model.AuditLogEntry, int, error)
 }
 
+// OrderEventRepo defines the interface for order events and the order
+// timeline.
+type OrderEventRepo interface {
+	Create(ctx context.Context, tx pgx.Tx, ev *model.OrderEvent) error
+	Timeline(ctx context.Context, tx pgx.Tx, orderID uuid.UUID, filter model.TimelineFilter) ([]model.TimelineEntry, error)
+}
+
+// OrderHoldRepo defines the interface for order hold persistence operations.
+type OrderHoldRepo interface {
+	Create(ctx context.Context, tx pgx.Tx, h *model.OrderHold) (bool, error)
+	FindByID(ctx context.Context, tx pgx.Tx, id uuid.UUID) (*model.OrderHold, error)
+	ListByOrder(ctx context.Context, tx pgx.Tx, orderID uuid.UUID) ([]model.OrderHold, error)
+	ListActive(ctx context.Context, tx pgx.Tx, orderIDs []uuid.UUID) ([]model.OrderHold, error)
+	ListActiveByReason(ctx context.Context, tx pgx.Tx, reasons []string, after *model.OrderHold, limit int) ([]model.OrderHold, error)
+	HasActive(ctx context.Context, tx pgx.Tx, orderID uuid.UUID) (bool, error)
+	Release(ctx context.Context, tx pgx.Tx, h *model.OrderHold, releasedBy *uuid.UUID, note string) (bool, error)
+	ListHeldOrders(ctx context.Context, tx pgx.Tx, filter model.OrderHoldListFilter) ([]model.HeldOrder, int, error)
+}
+
+// OrderRiskRepo defines the interface for the history and velocity queries
+// behind order risk scores.
+type OrderRiskRepo interface {
+	CustomerHistory(ctx context.Context, tx pgx.Tx, orderID uuid.UUID, customerID *uuid.UUID, email, phoneDigits string, codMethods []string) (model.CustomerRiskHistory, error)
+	Velocity(ctx context.Context, tx pgx.Tx, orderID uuid.UUID, since time.Time, phoneDigits, street, postalCode string) (phoneOrders, addressOrders int, err error)
+}
+
 // ShipmentRepo defines the interface for shipment persistence operations.
 type ShipmentRepo interface {
 	List(ctx context.Context, tx pgx.Tx, filter model.ShipmentListFilter) ([]model.Shipment, int, error)
@@ -124,6 +180,14 @@ type ReturnRepo interface {
 	Create(ctx context.Context, tx pgx.Tx, ret *model.Return) error
 	Update(ctx context.Context, tx pgx.Tx, id uuid.UUID, req model.UpdateReturnRequest) error
 	UpdateStatus(ctx context.Context, tx pgx.Tx, id uuid.UUID, status string) error
+	MarkReceived(ctx context.Context, tx pgx.Tx, id uuid.UUID, receivedItems json.RawMessage, restockDocumentID *uuid.UUID, refundAmount, shippingRefund float64) error
+	MarkDelivered(ctx context.Context, tx pgx.Tx, id uuid.UUID) error
+	MarkRefunded(ctx context.Context, tx pgx.Tx, id uuid.UUID, status string, amount float64, method string, reference *string) error
+	StartRefund(ctx context.Context, tx pgx.Tx, id, key uuid.UUID) (bool, error)
+	ClearRefund(ctx context.Context, tx pgx.Tx, id, key uuid.UUID) error
+	MarkExchanged(ctx context.Context, tx pgx.Tx, id, replacementOrderID uuid.UUID) error
+	SetReturnLabel(ctx context.Context, tx pgx.Tx, id uuid.UUID, label model.ReturnLabel) error
+	UpdateReturnShipmentStatus(ctx context.Context, tx pgx.Tx, id uuid.UUID, status string) error
 	Delete(ctx context.Context, tx pgx.Tx, id uuid.UUID) error
 }
 
@@ -162,6 +226,11 @@ type StatsRepo interface {
 	GetRevenueBySource(ctx context.Context, tx pgx.Tx, days int) ([]model.SourceRevenue, error)
 	GetOrderTrends(ctx context.Context, tx pgx.Tx, days int) ([]model.DailyOrderTrend, error)
 	GetPaymentMethodStats(ctx context.Context, tx pgx.Tx) (map[string]int, error)
+	GetProfitability(ctx context.Context, tx pgx.Tx, filter model.ProfitabilityFilter) ([]model.ProfitabilityRow, error)
+	GetProfitabilityTotals(ctx context.Context, tx pgx.Tx, filter model.ProfitabilityFilter) (model.ProfitabilityRow, error)
+	GetSalesByChannel(ctx context.Context, tx pgx.Tx, from, to time.Time, excluded []string) ([]model.ChannelSales, error)
+	GetStockValue(ctx context.Context, tx pgx.Tx) ([]model.StockValueRow, error)
+	GetVATSummary(ctx context.Context, tx pgx.Tx, from, to time.Time) ([]model.VATSummaryRow, error)
 }
 
 // ProductListingRepo defines the interface for product listing persistence operations.
@@ -170,6 +239,7 @@ type ProductListingRepo interface {
 	Update(ctx context.Context, tx pgx.Tx, id uuid.UUID, req *model.UpdateProductListingRequest) error
 	GetByID(ctx context.Context, tx pgx.Tx, id uuid.UUID) (*model.ProductListing, error)
 	FindByProductAndIntegration(ctx context.Context, tx pgx.Tx, productID, integrationID uuid.UUID) (*model.ProductListing, error)
+	FindByVariantAndIntegration(ctx context.Context, tx pgx.Tx, variantID, integrationID uuid.UUID) (*model.ProductListing, error)
 	ListByProduct(ctx context.Context, tx pgx.Tx, productID uuid.UUID) ([]*model.ProductListing, error)
 	ListByIntegration(ctx context.Context, tx pgx.Tx, integrationID uuid.UUID) ([]*model.ProductListing, error)
 	Delete(ctx context.Context, tx pgx.Tx, id uuid.UUID) error
@@ -179,7 +249,10 @@ type ProductListingRepo interface {
 type SyncJobRepo interface {
 	Create(ctx context.Context, tx pgx.Tx, job *model.SyncJob) error
 	UpdateStatus(ctx context.Context, tx pgx.Tx, id uuid.UUID, status string, itemsProcessed, itemsFailed int, errorMsg *string) error
+	UpdateProgress(ctx context.Context, tx pgx.Tx, id uuid.UUID, itemsProcessed, itemsFailed int, metadata json.RawMessage) error
 	GetByID(ctx context.Context, tx pgx.Tx, id uuid.UUID) (*model.SyncJob, error)
+	FindActive(ctx context.Context, tx pgx.Tx, integrationID uuid.UUID, jobType string) (*model.SyncJob, error)
+	ClaimPending(ctx context.Context, tx pgx.Tx, jobType string, limit int) ([]*model.SyncJob, error)
 	ListByIntegration(ctx context.Context, tx pgx.Tx, integrationID uuid.UUID, limit int) ([]*model.SyncJob, error)
 	List(ctx context.Context, tx pgx.Tx, filter model.SyncJobListFilter) ([]*model.SyncJob, int, error)
 }
@@ -204,6 +277,8 @@ type InvoiceRepo interface {
 	Delete(ctx context.Context, tx pgx.Tx, id uuid.UUID) error
 	FindPendingKSeF(ctx context.Context, tx pgx.Tx) ([]model.Invoice, error)
 	UpdateKSeFStatus(ctx context.Context, tx pgx.Tx, id uuid.UUID, ksefNumber *string, ksefStatus string, ksefResponse []byte) error
+	FindPendingCorrections(ctx context.Context, tx pgx.Tx, before time.Time, limit int) ([]model.Invoice, error)
+	ClaimPendingCorrection(ctx context.Context, tx pgx.Tx, id uuid.UUID, before time.Time) (bool, error)
 }
 
 // SupplierProductRepo defines the interface for supplier product persistence operations.
@@ -217,6 +292,60 @@ type SupplierProductRepo interface {
 	FindBySupplierAndExternalID(ctx context.Context, tx pgx.Tx, supplierID uuid.UUID, externalID string) (*model.SupplierProduct, error)
 	UpsertByExternalID(ctx context.Context, tx pgx.Tx, sp *model.SupplierProduct) error
 	LinkToProduct(ctx context.Context, tx pgx.Tx, id uuid.UUID, productID uuid.UUID) error
+	ListOffersForProducts(ctx context.Context, tx pgx.Tx, productIDs []uuid.UUID) ([]model.SupplierProduct, error)
+}
+
+// OrderViewRepo defines the interface for saved order view persistence operations.
+type OrderViewRepo interface {
+	ListByUser(ctx context.Context, tx pgx.Tx, userID uuid.UUID) ([]model.OrderView, error)
+	FindByID(ctx context.Context, tx pgx.Tx, id uuid.UUID) (*model.OrderView, error)
+	Create(ctx context.Context, tx pgx.Tx, view *model.OrderView) error
+	Update(ctx context.Context, tx pgx.Tx, id uuid.UUID, req model.UpdateOrderViewRequest) error
+	Delete(ctx context.Context, tx pgx.Tx, id uuid.UUID) error
+}
+
+// InventoryCostRepo defines the interface for inventory cost layer and cost journal persistence.
+type InventoryCostRepo interface {
+	ListOpenLayers(ctx context.Context, tx pgx.Tx, warehouseID, productID uuid.UUID, variantID *uuid.UUID) ([]model.CostLayer, error)
+	CreateLayer(ctx context.Context, tx pgx.Tx, layer *model.CostLayer) error
+	UpdateLayer(ctx context.Context, tx pgx.Tx, layer model.CostLayer) error
+	CreateMovement(ctx context.Context, tx pgx.Tx, m *model.CostMovement) error
+	GetValuation(ctx context.Context, tx pgx.Tx, filter model.InventoryValuationFilter) ([]model.InventoryValuationRow, error)
+	GetCOGS(ctx context.Context, tx pgx.Tx, filter model.COGSFilter) ([]model.COGSRow, error)
+	GetCOGSTotals(ctx context.Context, tx pgx.Tx, filter model.COGSFilter) (int, float64, error)
+}
+
+// ScheduledReportRepo defines the interface for scheduled report persistence operations.
+type ScheduledReportRepo interface {
+	List(ctx context.Context, tx pgx.Tx) ([]model.ScheduledReport, error)
+	FindByID(ctx context.Context, tx pgx.Tx, id uuid.UUID) (*model.ScheduledReport, error)
+	Create(ctx context.Context, tx pgx.Tx, report *model.ScheduledReport) error
+	Update(ctx context.Context, tx pgx.Tx, report *model.ScheduledReport) error
+	Delete(ctx context.Context, tx pgx.Tx, id uuid.UUID) error
+	ListDue(ctx context.Context, tx pgx.Tx, now time.Time, limit int) ([]model.ScheduledReport, error)
+	ScheduleNext(ctx context.Context, tx pgx.Tx, id uuid.UUID, lastRun time.Time, nextRun *time.Time) error
+	CreateRun(ctx context.Context, tx pgx.Tx, run *model.ScheduledReportRun) error
+	ListRuns(ctx context.Context, tx pgx.Tx, reportID uuid.UUID, p model.PaginationParams) ([]model.ScheduledReportRun, int, error)
+}
+
+// FulfillmentRuleRepo defines the interface for product fulfilment rule persistence operations.
+type FulfillmentRuleRepo interface {
+	List(ctx context.Context, tx pgx.Tx) ([]model.FulfillmentRule, error)
+	FindByProductIDs(ctx context.Context, tx pgx.Tx, productIDs []uuid.UUID) ([]model.FulfillmentRule, error)
+	Upsert(ctx context.Context, tx pgx.Tx, rule *model.FulfillmentRule) error
+	Delete(ctx context.Context, tx pgx.Tx, productID uuid.UUID) error
+}
+
+// SupplierOrderRepo defines the interface for supplier order persistence operations.
+type SupplierOrderRepo interface {
+	List(ctx context.Context, tx pgx.Tx, filter model.SupplierOrderListFilter) ([]model.SupplierOrder, int, error)
+	FindByID(ctx context.Context, tx pgx.Tx, id uuid.UUID) (*model.SupplierOrder, error)
+	ListByOrderID(ctx context.Context, tx pgx.Tx, orderID uuid.UUID) ([]model.SupplierOrder, error)
+	Create(ctx context.Context, tx pgx.Tx, so *model.SupplierOrder) error
+	RecordDelivery(ctx context.Context, tx pgx.Tx, id uuid.UUID, status string, errorMessage *string) error
+	MarkShipped(ctx context.Context, tx pgx.Tx, id uuid.UUID, carrier, trackingNumber string, shipmentID uuid.UUID) error
+	UpdateStatus(ctx context.Context, tx pgx.Tx, id uuid.UUID, status string) error
+	ListRetryable(ctx context.Context, tx pgx.Tx, maxAttempts, limit int) ([]uuid.UUID, error)
 }
 
 // WarehouseRepo defines the interface for warehouse persistence operations.
@@ -232,8 +361,15 @@ type WarehouseRepo interface {
 type WarehouseStockRepo interface {
 	ListByWarehouse(ctx context.Context, tx pgx.Tx, warehouseID uuid.UUID, filter model.WarehouseStockListFilter) ([]model.WarehouseStock, int, error)
 	ListByProduct(ctx context.Context, tx pgx.Tx, productID uuid.UUID) ([]model.WarehouseStock, error)
-	Upsert(ctx context.Context, tx pgx.Tx, stock *model.WarehouseStock) error
-	AdjustQuantity(ctx context.Context, tx pgx.Tx, warehouseID, productID uuid.UUID, variantID *uuid.UUID, delta int) error
+	Upsert(ctx context.Context, tx pgx.Tx, stock *model.WarehouseStock, src model.StockMovementSource) error
+	AdjustQuantity(ctx context.Context, tx pgx.Tx, warehouseID, productID uuid.UUID, variantID *uuid.UUID, delta int, src model.StockMovementSource) error
+}
+
+// StockMovementRepo defines the interface for reading the stock movement
+// ledger. Entries are written by WarehouseStockRepo.
+type StockMovementRepo interface {
+	ListByProduct(ctx context.Context, tx pgx.Tx, productID uuid.UUID, filter model.StockMovementListFilter) ([]model.StockMovement, int, error)
+	StockAt(ctx context.Context, tx pgx.Tx, productID uuid.UUID, filter model.StockAtFilter) ([]model.StockAtRow, error)
 }
 
 // CustomerRepo defines the interface for customer persistence operations.
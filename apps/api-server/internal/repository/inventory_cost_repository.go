@@ -0,0 +1,197 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/openoms-org/openoms/apps/api-server/internal/model"
+)
+
+// InventoryCostRepository implements InventoryCostRepo.
+type InventoryCostRepository struct{}
+
+// NewInventoryCostRepository creates a new InventoryCostRepository.
+func NewInventoryCostRepository() *InventoryCostRepository {
+	return &InventoryCostRepository{}
+}
+
+const costLayerColumns = `id, tenant_id, warehouse_id, product_id, variant_id, document_id,
+	quantity, remaining, unit_cost, received_at, created_at, updated_at`
+
+// ListOpenLayers locks the layers of a product or variant in a warehouse
+// that still hold stock, oldest first.
+func (r *InventoryCostRepository) ListOpenLayers(ctx context.Context, tx pgx.Tx, warehouseID, productID uuid.UUID, variantID *uuid.UUID) ([]model.CostLayer, error) {
+	rows, err := tx.Query(ctx,
+		`SELECT `+costLayerColumns+` FROM inventory_cost_layers
+		 WHERE warehouse_id = $1 AND product_id = $2 AND variant_id IS NOT DISTINCT FROM $3
+		   AND remaining > 0
+		 ORDER BY received_at, created_at
+		 FOR UPDATE`, warehouseID, productID, variantID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list open cost layers: %w", err)
+	}
+	defer rows.Close()
+
+	var layers []model.CostLayer
+	for rows.Next() {
+		var l model.CostLayer
+		if err := rows.Scan(
+			&l.ID, &l.TenantID, &l.WarehouseID, &l.ProductID, &l.VariantID, &l.DocumentID,
+			&l.Quantity, &l.Remaining, &l.UnitCost, &l.ReceivedAt, &l.CreatedAt, &l.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scan cost layer: %w", err)
+		}
+		layers = append(layers, l)
+	}
+	return layers, rows.Err()
+}
+
+func (r *InventoryCostRepository) CreateLayer(ctx context.Context, tx pgx.Tx, layer *model.CostLayer) error {
+	return tx.QueryRow(ctx,
+		`INSERT INTO inventory_cost_layers (id, tenant_id, warehouse_id, product_id, variant_id, document_id,
+		                                    quantity, remaining, unit_cost, received_at)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		 RETURNING created_at, updated_at`,
+		layer.ID, layer.TenantID, layer.WarehouseID, layer.ProductID, layer.VariantID, layer.DocumentID,
+		layer.Quantity, layer.Remaining, layer.UnitCost, layer.ReceivedAt,
+	).Scan(&layer.CreatedAt, &layer.UpdatedAt)
+}
+
+// UpdateLayer saves the remaining quantity and unit cost of a layer.
+func (r *InventoryCostRepository) UpdateLayer(ctx context.Context, tx pgx.Tx, layer model.CostLayer) error {
+	ct, err := tx.Exec(ctx,
+		`UPDATE inventory_cost_layers SET remaining = $2, unit_cost = $3, updated_at = NOW() WHERE id = $1`,
+		layer.ID, layer.Remaining, layer.UnitCost,
+	)
+	if err != nil {
+		return fmt.Errorf("update cost layer: %w", err)
+	}
+	if ct.RowsAffected() == 0 {
+		return fmt.Errorf("cost layer not found")
+	}
+	return nil
+}
+
+func (r *InventoryCostRepository) CreateMovement(ctx context.Context, tx pgx.Tx, m *model.CostMovement) error {
+	return tx.QueryRow(ctx,
+		`INSERT INTO inventory_cost_movements (id, tenant_id, warehouse_id, product_id, variant_id, document_id,
+		                                       document_type, method, quantity, total_cost, estimated, occurred_at)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		 RETURNING created_at`,
+		m.ID, m.TenantID, m.WarehouseID, m.ProductID, m.VariantID, m.DocumentID,
+		m.DocumentType, m.Method, m.Quantity, m.TotalCost, m.Estimated, m.OccurredAt,
+	).Scan(&m.CreatedAt)
+}
+
+// GetValuation sums the cost journal before filter.At per warehouse and
+// product or variant, skipping items with nothing left.
+func (r *InventoryCostRepository) GetValuation(ctx context.Context, tx pgx.Tx, filter model.InventoryValuationFilter) ([]model.InventoryValuationRow, error) {
+	rows, err := tx.Query(ctx,
+		`SELECT m.warehouse_id, w.name, m.product_id, m.variant_id,
+		        COALESCE(v.sku, p.sku, ''),
+		        CASE WHEN v.id IS NULL THEN p.name ELSE p.name || ' - ' || v.name END,
+		        SUM(m.quantity)::int, SUM(m.total_cost)
+		 FROM inventory_cost_movements m
+		 JOIN warehouses w ON w.id = m.warehouse_id
+		 JOIN products p ON p.id = m.product_id
+		 LEFT JOIN product_variants v ON v.id = m.variant_id
+		 WHERE m.occurred_at < $1
+		   AND ($2::uuid IS NULL OR m.warehouse_id = $2)
+		 GROUP BY m.warehouse_id, w.name, m.product_id, m.variant_id, v.id, v.sku, v.name, p.sku, p.name
+		 HAVING SUM(m.quantity) <> 0 OR SUM(m.total_cost) <> 0
+		 ORDER BY w.name, 6`, filter.At, filter.WarehouseID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("inventory valuation: %w", err)
+	}
+	defer rows.Close()
+
+	result := []model.InventoryValuationRow{}
+	for rows.Next() {
+		var row model.InventoryValuationRow
+		if err := rows.Scan(&row.WarehouseID, &row.WarehouseName, &row.ProductID, &row.VariantID,
+			&row.SKU, &row.Name, &row.Quantity, &row.Value); err != nil {
+			return nil, fmt.Errorf("scan inventory valuation: %w", err)
+		}
+		result = append(result, row)
+	}
+	return result, rows.Err()
+}
+
+// cogsFrom selects the issues of WZ documents ($1 from, $2 to, $3 warehouse).
+const cogsFrom = `
+		 FROM inventory_cost_movements m
+		 JOIN warehouse_documents d ON d.id = m.document_id
+		 JOIN warehouses w ON w.id = m.warehouse_id
+		 JOIN products p ON p.id = m.product_id
+		 LEFT JOIN product_variants v ON v.id = m.variant_id
+		 WHERE m.document_type = 'WZ' AND m.quantity < 0
+		   AND ($1::timestamptz IS NULL OR m.occurred_at >= $1)
+		   AND ($2::timestamptz IS NULL OR m.occurred_at < $2)
+		   AND ($3::uuid IS NULL OR m.warehouse_id = $3)`
+
+// GetCOGS sums the issues of confirmed WZ documents in the filter's period.
+func (r *InventoryCostRepository) GetCOGS(ctx context.Context, tx pgx.Tx, filter model.COGSFilter) ([]model.COGSRow, error) {
+	var selectGroup, groupBy, orderBy string
+	switch filter.GroupBy {
+	case model.COGSByProduct:
+		selectGroup = `COALESCE(m.variant_id, m.product_id)::text,
+		        CASE WHEN v.id IS NULL THEN p.name ELSE p.name || ' - ' || v.name END
+		          || COALESCE(' (' || COALESCE(v.sku, p.sku) || ')', ''),
+		        '', NULL::timestamptz`
+		groupBy = `m.product_id, m.variant_id, v.id, v.name, v.sku, p.name, p.sku`
+		orderBy = `-SUM(m.total_cost) DESC`
+	case model.COGSByWarehouse:
+		selectGroup = `m.warehouse_id::text, w.name, w.name, NULL::timestamptz`
+		groupBy = `m.warehouse_id, w.name`
+		orderBy = `-SUM(m.total_cost) DESC`
+	default:
+		selectGroup = `d.id::text, d.document_number, w.name, MIN(m.occurred_at)`
+		groupBy = `d.id, d.document_number, w.name`
+		orderBy = `MIN(m.occurred_at) DESC`
+	}
+
+	rows, err := tx.Query(ctx,
+		`SELECT `+selectGroup+`,
+		        COUNT(DISTINCT m.document_id)::int,
+		        -SUM(m.quantity)::int,
+		        -SUM(m.total_cost),
+		        BOOL_OR(m.estimated)`+cogsFrom+`
+		 GROUP BY `+groupBy+`
+		 ORDER BY `+orderBy+`
+		 LIMIT $4`, filter.From, filter.To, filter.WarehouseID, filter.Limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("cogs: %w", err)
+	}
+	defer rows.Close()
+
+	result := []model.COGSRow{}
+	for rows.Next() {
+		var row model.COGSRow
+		if err := rows.Scan(&row.Key, &row.Label, &row.Warehouse, &row.Date,
+			&row.Documents, &row.Quantity, &row.Cost, &row.Estimated); err != nil {
+			return nil, fmt.Errorf("scan cogs: %w", err)
+		}
+		result = append(result, row)
+	}
+	return result, rows.Err()
+}
+
+// GetCOGSTotals returns the quantity and cost of all issues matching the
+// filter, regardless of the row limit.
+func (r *InventoryCostRepository) GetCOGSTotals(ctx context.Context, tx pgx.Tx, filter model.COGSFilter) (int, float64, error) {
+	var quantity int
+	var cost float64
+	err := tx.QueryRow(ctx,
+		`SELECT COALESCE(-SUM(m.quantity), 0)::int, COALESCE(-SUM(m.total_cost), 0)`+cogsFrom,
+		filter.From, filter.To, filter.WarehouseID,
+	).Scan(&quantity, &cost)
+	if err != nil {
+		return 0, 0, fmt.Errorf("cogs totals: %w", err)
+	}
+	return quantity, cost, nil
+}
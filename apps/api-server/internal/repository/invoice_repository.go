@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -234,6 +235,59 @@ func (r *InvoiceRepository) FindPendingKSeF(ctx context.Context, tx pgx.Tx) ([]m
 	return invoices, rows.Err()
 }
 
+// FindPendingCorrections returns draft correction invoices carrying the
+// request to issue them that were not touched since before, oldest first.
+func (r *InvoiceRepository) FindPendingCorrections(ctx context.Context, tx pgx.Tx, before time.Time, limit int) ([]model.Invoice, error) {
+	rows, err := tx.Query(ctx,
+		`SELECT id, tenant_id, order_id, provider, external_id, external_number,
+		        status, invoice_type, total_net, total_gross, currency,
+		        issue_date, due_date, pdf_url, metadata, error_message,
+		        ksef_number, ksef_status, ksef_sent_at, ksef_response,
+		        created_at, updated_at
+		 FROM invoices
+		 WHERE status = 'draft' AND invoice_type = 'correction'
+		   AND metadata ? 'correction_request' AND updated_at < $1
+		 ORDER BY created_at ASC LIMIT $2`,
+		before, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("find pending corrections: %w", err)
+	}
+	defer rows.Close()
+
+	var invoices []model.Invoice
+	for rows.Next() {
+		var inv model.Invoice
+		if err := rows.Scan(
+			&inv.ID, &inv.TenantID, &inv.OrderID, &inv.Provider,
+			&inv.ExternalID, &inv.ExternalNumber,
+			&inv.Status, &inv.InvoiceType, &inv.TotalNet, &inv.TotalGross,
+			&inv.Currency, &inv.IssueDate, &inv.DueDate, &inv.PDFURL,
+			&inv.Metadata, &inv.ErrorMessage,
+			&inv.KSeFNumber, &inv.KSeFStatus, &inv.KSeFSentAt, &inv.KSeFResponse,
+			&inv.CreatedAt, &inv.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scan invoice: %w", err)
+		}
+		invoices = append(invoices, inv)
+	}
+	return invoices, rows.Err()
+}
+
+// ClaimPendingCorrection touches a draft correction not touched since before,
+// so no other run issues it meanwhile. It reports whether the claim succeeded.
+func (r *InvoiceRepository) ClaimPendingCorrection(ctx context.Context, tx pgx.Tx, id uuid.UUID, before time.Time) (bool, error) {
+	ct, err := tx.Exec(ctx,
+		`UPDATE invoices SET updated_at = NOW()
+		 WHERE id = $1 AND status = 'draft' AND updated_at < $2`,
+		id, before,
+	)
+	if err != nil {
+		return false, fmt.Errorf("claim pending correction: %w", err)
+	}
+	return ct.RowsAffected() > 0, nil
+}
+
 // UpdateKSeFStatus updates only the KSeF-related fields of an invoice.
 func (r *InvoiceRepository) UpdateKSeFStatus(ctx context.Context, tx pgx.Tx, id uuid.UUID, ksefNumber *string, ksefStatus string, ksefResponse []byte) error {
 	ct, err := tx.Exec(ctx,
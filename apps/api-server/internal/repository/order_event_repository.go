@@ -0,0 +1,167 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/openoms-org/openoms/apps/api-server/internal/model"
+)
+
+// OrderEventRepository implements OrderEventRepo.
+type OrderEventRepository struct{}
+
+func NewOrderEventRepository() *OrderEventRepository {
+	return &OrderEventRepository{}
+}
+
+// Create records an order event.
+func (r *OrderEventRepository) Create(ctx context.Context, tx pgx.Tx, ev *model.OrderEvent) error {
+	data, err := json.Marshal(ev.Data)
+	if err != nil || ev.Data == nil {
+		data = []byte("{}")
+	}
+	err = tx.QueryRow(ctx,
+		`INSERT INTO order_events (tenant_id, order_id, source, event_type, actor_id, data)
+		 VALUES ($1, $2, $3, $4, $5, $6)
+		 RETURNING id, created_at`,
+		ev.TenantID, ev.OrderID, ev.Source, ev.EventType, ev.ActorID, data,
+	).Scan(&ev.ID, &ev.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("create order event: %w", err)
+	}
+	return nil
+}
+
+// timelineAuditSources are the timeline sources read from the audit log; each
+// is the entity type of its entries.
+var timelineAuditSources = []string{
+	model.TimelineSourceOrder, model.TimelineSourceShipment, model.TimelineSourceReturn, model.TimelineSourceInvoice,
+}
+
+// Timeline returns the timeline of an order, newest first: the audit log of
+// the order and its shipments, returns and invoices, automation rule logs of
+// the same entities, order events and webhook deliveries about the order.
+// Only the sources in filter.Sources are read; empty means all.
+func (r *OrderEventRepository) Timeline(ctx context.Context, tx pgx.Tx, orderID uuid.UUID, filter model.TimelineFilter) ([]model.TimelineEntry, error) {
+	wants := func(source string) bool {
+		return len(filter.Sources) == 0 || slices.Contains(filter.Sources, source)
+	}
+
+	args := []any{orderID}
+	var branches []string
+
+	var auditSources []string
+	for _, s := range timelineAuditSources {
+		if wants(s) {
+			auditSources = append(auditSources, s)
+		}
+	}
+	if len(auditSources) > 0 {
+		args = append(args, auditSources)
+		branches = append(branches, fmt.Sprintf(
+			`SELECT a.created_at, a.entity_type::text, a.action::text, a.entity_type::text, a.entity_id, u.name::text, a.changes
+			 FROM audit_log a
+			 JOIN related r ON r.entity_type = a.entity_type AND r.entity_id = a.entity_id
+			 LEFT JOIN users u ON u.id = a.user_id
+			 WHERE a.entity_type = ANY($%d)`, len(args)))
+	}
+	if wants(model.TimelineSourceAutomation) {
+		branches = append(branches,
+			`SELECT l.executed_at, 'automation', l.trigger_event, l.entity_type, l.entity_id, NULL::text,
+			        jsonb_build_object('rule_id', l.rule_id, 'rule_name', ar.name, 'conditions_met', l.conditions_met,
+			                           'actions_executed', l.actions_executed, 'error', l.error_message)
+			 FROM automation_rule_logs l
+			 JOIN related r ON r.entity_type = l.entity_type AND r.entity_id = l.entity_id
+			 LEFT JOIN automation_rules ar ON ar.id = l.rule_id`)
+	}
+	var eventSources []string
+	for _, s := range []string{model.TimelineSourceNotification, model.TimelineSourceMarketplace} {
+		if wants(s) {
+			eventSources = append(eventSources, s)
+		}
+	}
+	if len(eventSources) > 0 {
+		args = append(args, eventSources)
+		branches = append(branches, fmt.Sprintf(
+			`SELECT e.created_at, e.source, e.event_type, 'order', e.order_id, u.name::text, e.data
+			 FROM order_events e
+			 LEFT JOIN users u ON u.id = e.actor_id
+			 WHERE e.order_id = $1 AND e.source = ANY($%d)`, len(args)))
+	}
+	if wants(model.TimelineSourceWebhook) {
+		branches = append(branches,
+			`SELECT w.created_at, 'webhook', w.event_type::text, NULL::text, NULL::uuid, NULL::text,
+			        jsonb_build_object('url', w.url, 'status', w.status, 'response_code', w.response_code, 'error', w.error)
+			 FROM webhook_deliveries w
+			 WHERE COALESCE(w.payload->>'order_id', w.payload->>'id') = $1::uuid::text`)
+	}
+	if len(branches) == 0 {
+		return []model.TimelineEntry{}, nil
+	}
+
+	var conditions []string
+	if filter.Type != "" {
+		args = append(args, filter.Type)
+		if strings.HasSuffix(filter.Type, ".") {
+			conditions = append(conditions, fmt.Sprintf("starts_with(type, $%d)", len(args)))
+		} else {
+			conditions = append(conditions, fmt.Sprintf("type = $%d", len(args)))
+		}
+	}
+	if filter.From != nil {
+		args = append(args, *filter.From)
+		conditions = append(conditions, fmt.Sprintf("occurred_at >= $%d", len(args)))
+	}
+	if filter.To != nil {
+		args = append(args, *filter.To)
+		conditions = append(conditions, fmt.Sprintf("occurred_at < $%d", len(args)))
+	}
+	where := ""
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = 200
+	}
+	args = append(args, limit)
+
+	query := fmt.Sprintf(
+		`WITH related (entity_type, entity_id) AS (
+		     SELECT 'order', $1::uuid
+		     UNION ALL SELECT 'shipment', id FROM shipments WHERE order_id = $1
+		     UNION ALL SELECT 'return', id FROM returns WHERE order_id = $1
+		     UNION ALL SELECT 'invoice', id FROM invoices WHERE order_id = $1
+		 ), entries (occurred_at, source, type, entity_type, entity_id, actor, data) AS (
+		     %s
+		 )
+		 SELECT occurred_at, source, type, entity_type, entity_id, actor, data
+		 FROM entries
+		 %s
+		 ORDER BY occurred_at DESC
+		 LIMIT $%d`,
+		strings.Join(branches, "\n\t\t     UNION ALL\n\t\t     "), where, len(args),
+	)
+
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("order timeline: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []model.TimelineEntry{}
+	for rows.Next() {
+		var e model.TimelineEntry
+		if err := rows.Scan(&e.OccurredAt, &e.Source, &e.Type, &e.EntityType, &e.EntityID, &e.Actor, &e.Data); err != nil {
+			return nil, fmt.Errorf("scan timeline entry: %w", err)
+		}
+		entries = append(entries, e)
+	}
+	return entries, rows.Err()
+}
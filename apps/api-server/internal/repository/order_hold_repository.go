@@ -0,0 +1,199 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/openoms-org/openoms/apps/api-server/internal/model"
+
+	engine "github.com/openoms-org/openoms/packages/order-engine"
+)
+
+// OrderHoldRepository implements OrderHoldRepo.
+type OrderHoldRepository struct{}
+
+func NewOrderHoldRepository() *OrderHoldRepository {
+	return &OrderHoldRepository{}
+}
+
+const orderHoldColumns = `id, tenant_id, order_id, reason, note, source, created_by, created_at,
+	released_at, released_by, release_note`
+
+func scanOrderHold(row pgx.Row) (model.OrderHold, error) {
+	var h model.OrderHold
+	err := row.Scan(&h.ID, &h.TenantID, &h.OrderID, &h.Reason, &h.Note, &h.Source, &h.CreatedBy, &h.CreatedAt,
+		&h.ReleasedAt, &h.ReleasedBy, &h.ReleaseNote)
+	h.AutoRelease = engine.HoldReason(h.Reason).AutoReleases()
+	return h, err
+}
+
+func (r *OrderHoldRepository) queryHolds(ctx context.Context, tx pgx.Tx, query string, args ...any) ([]model.OrderHold, error) {
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("list order holds: %w", err)
+	}
+	defer rows.Close()
+
+	holds := []model.OrderHold{}
+	for rows.Next() {
+		h, err := scanOrderHold(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan order hold: %w", err)
+		}
+		holds = append(holds, h)
+	}
+	return holds, rows.Err()
+}
+
+// Create places a hold. It returns false without placing it when the order
+// already has an active hold with the same reason.
+func (r *OrderHoldRepository) Create(ctx context.Context, tx pgx.Tx, h *model.OrderHold) (bool, error) {
+	err := tx.QueryRow(ctx,
+		`INSERT INTO order_holds (tenant_id, order_id, reason, note, source, created_by)
+		 VALUES ($1, $2, $3, $4, $5, $6)
+		 ON CONFLICT (order_id, reason) WHERE released_at IS NULL DO NOTHING
+		 RETURNING id, created_at`,
+		h.TenantID, h.OrderID, h.Reason, h.Note, h.Source, h.CreatedBy,
+	).Scan(&h.ID, &h.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("create order hold: %w", err)
+	}
+	h.AutoRelease = engine.HoldReason(h.Reason).AutoReleases()
+	return true, nil
+}
+
+func (r *OrderHoldRepository) FindByID(ctx context.Context, tx pgx.Tx, id uuid.UUID) (*model.OrderHold, error) {
+	h, err := scanOrderHold(tx.QueryRow(ctx,
+		`SELECT `+orderHoldColumns+` FROM order_holds WHERE id = $1`, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("find order hold: %w", err)
+	}
+	return &h, nil
+}
+
+// ListByOrder returns the holds of an order, active and released, newest first.
+func (r *OrderHoldRepository) ListByOrder(ctx context.Context, tx pgx.Tx, orderID uuid.UUID) ([]model.OrderHold, error) {
+	return r.queryHolds(ctx, tx,
+		`SELECT `+orderHoldColumns+` FROM order_holds WHERE order_id = $1 ORDER BY created_at DESC`, orderID)
+}
+
+// ListActive returns the active holds of the orders, oldest first.
+func (r *OrderHoldRepository) ListActive(ctx context.Context, tx pgx.Tx, orderIDs []uuid.UUID) ([]model.OrderHold, error) {
+	return r.queryHolds(ctx, tx,
+		`SELECT `+orderHoldColumns+` FROM order_holds
+		 WHERE order_id = ANY($1) AND released_at IS NULL
+		 ORDER BY created_at`, orderIDs)
+}
+
+// ListActiveByReason returns up to limit active holds with one of the
+// reasons, oldest first. Pages after the first continue after the last hold
+// of the previous page; after is nil for the first page.
+func (r *OrderHoldRepository) ListActiveByReason(ctx context.Context, tx pgx.Tx, reasons []string, after *model.OrderHold, limit int) ([]model.OrderHold, error) {
+	var afterCreatedAt *time.Time
+	var afterID *uuid.UUID
+	if after != nil {
+		afterCreatedAt, afterID = &after.CreatedAt, &after.ID
+	}
+	return r.queryHolds(ctx, tx,
+		`SELECT `+orderHoldColumns+` FROM order_holds
+		 WHERE reason = ANY($1) AND released_at IS NULL
+		   AND ($2::timestamptz IS NULL OR (created_at, id) > ($2, $3))
+		 ORDER BY created_at, id
+		 LIMIT $4`, reasons, afterCreatedAt, afterID, limit)
+}
+
+// HasActive reports whether an order has an active hold.
+func (r *OrderHoldRepository) HasActive(ctx context.Context, tx pgx.Tx, orderID uuid.UUID) (bool, error) {
+	var held bool
+	err := tx.QueryRow(ctx,
+		`SELECT EXISTS (SELECT 1 FROM order_holds WHERE order_id = $1 AND released_at IS NULL)`, orderID,
+	).Scan(&held)
+	if err != nil {
+		return false, fmt.Errorf("check order holds: %w", err)
+	}
+	return held, nil
+}
+
+// Release releases an active hold. It returns false when the hold was
+// already released.
+func (r *OrderHoldRepository) Release(ctx context.Context, tx pgx.Tx, h *model.OrderHold, releasedBy *uuid.UUID, note string) (bool, error) {
+	err := tx.QueryRow(ctx,
+		`UPDATE order_holds SET released_at = NOW(), released_by = $2, release_note = $3
+		 WHERE id = $1 AND released_at IS NULL
+		 RETURNING released_at`,
+		h.ID, releasedBy, note,
+	).Scan(&h.ReleasedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("release order hold: %w", err)
+	}
+	h.ReleasedBy = releasedBy
+	h.ReleaseNote = note
+	return true, nil
+}
+
+// ListHeldOrders returns the blocked-order queue: orders with an active hold
+// matching the filter, longest held first. The holds of the orders are not
+// loaded.
+func (r *OrderHoldRepository) ListHeldOrders(ctx context.Context, tx pgx.Tx, filter model.OrderHoldListFilter) ([]model.HeldOrder, int, error) {
+	where := "WHERE released_at IS NULL"
+	args := []any{}
+	argIdx := 1
+
+	if filter.Reason != nil {
+		where += fmt.Sprintf(" AND reason = $%d", argIdx)
+		args = append(args, *filter.Reason)
+		argIdx++
+	}
+	if filter.Source != nil {
+		where += fmt.Sprintf(" AND source = $%d", argIdx)
+		args = append(args, *filter.Source)
+		argIdx++
+	}
+	held := "SELECT order_id, MIN(created_at) AS held_since FROM order_holds " + where + " GROUP BY order_id"
+
+	var total int
+	if err := tx.QueryRow(ctx, "SELECT COUNT(*) FROM ("+held+") h", args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("count held orders: %w", err)
+	}
+
+	query := fmt.Sprintf(
+		`SELECT o.id, o.external_id, o.source, o.status, o.customer_name, o.total_amount, o.currency,
+		        o.payment_status, o.ordered_at, h.held_since
+		 FROM (%s) h
+		 JOIN orders o ON o.id = h.order_id
+		 ORDER BY h.held_since, o.id
+		 LIMIT $%d OFFSET $%d`,
+		held, argIdx, argIdx+1,
+	)
+	args = append(args, filter.Limit, filter.Offset)
+
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("list held orders: %w", err)
+	}
+	defer rows.Close()
+
+	orders := []model.HeldOrder{}
+	for rows.Next() {
+		var o model.HeldOrder
+		if err := rows.Scan(&o.OrderID, &o.ExternalID, &o.Source, &o.Status, &o.CustomerName, &o.TotalAmount, &o.Currency,
+			&o.PaymentStatus, &o.OrderedAt, &o.HeldSince); err != nil {
+			return nil, 0, fmt.Errorf("scan held order: %w", err)
+		}
+		orders = append(orders, o)
+	}
+	return orders, total, rows.Err()
+}
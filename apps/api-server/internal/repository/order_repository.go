@@ -2,7 +2,9 @@ package repository
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -25,7 +27,8 @@ const orderSelectColumns = `id, tenant_id, external_id, source, integration_id,
 		        ordered_at, shipped_at, delivered_at,
 		        delivery_method, pickup_point_id,
 		        payment_status, payment_method, paid_at, customer_id, merged_into, split_from,
-		        internal_notes, priority, created_at, updated_at`
+		        internal_notes, priority, assigned_to, address_issues, risk_score, risk_signals,
+		        status_changed_at, created_at, updated_at`
 
 // scanOrder scans a row into a model.Order using the orderSelectColumns column order.
 func scanOrder(row pgx.Row) (model.Order, error) {
@@ -38,12 +41,15 @@ func scanOrder(row pgx.Row) (model.Order, error) {
 		&o.OrderedAt, &o.ShippedAt, &o.DeliveredAt,
 		&o.DeliveryMethod, &o.PickupPointID,
 		&o.PaymentStatus, &o.PaymentMethod, &o.PaidAt, &o.CustomerID, &o.MergedInto, &o.SplitFrom,
-		&o.InternalNotes, &o.Priority, &o.CreatedAt, &o.UpdatedAt,
+		&o.InternalNotes, &o.Priority, &o.AssignedTo, &o.AddressIssues, &o.RiskScore, &o.RiskSignals,
+		&o.StatusChangedAt, &o.CreatedAt, &o.UpdatedAt,
 	)
 	return o, err
 }
 
-func (r *OrderRepository) List(ctx context.Context, tx pgx.Tx, filter model.OrderListFilter) ([]model.Order, int, error) {
+// orderFilterWhere builds the WHERE clause and arguments for an order filter.
+// It returns the index of the next free placeholder.
+func orderFilterWhere(filter model.OrderListFilter) (string, []any, int) {
 	where := "WHERE 1=1"
 	args := []any{}
 	argIdx := 1
@@ -53,15 +59,30 @@ func (r *OrderRepository) List(ctx context.Context, tx pgx.Tx, filter model.Orde
 		args = append(args, *filter.Status)
 		argIdx++
 	}
+	if len(filter.Statuses) > 0 {
+		where += fmt.Sprintf(" AND status = ANY($%d)", argIdx)
+		args = append(args, filter.Statuses)
+		argIdx++
+	}
 	if filter.Source != nil {
 		where += fmt.Sprintf(" AND source = $%d", argIdx)
 		args = append(args, *filter.Source)
 		argIdx++
 	}
 	if filter.Search != nil {
-		where += fmt.Sprintf(" AND (customer_name ILIKE $%d OR customer_email ILIKE $%d OR customer_phone ILIKE $%d)", argIdx, argIdx, argIdx)
+		// Full-text prefix match over the search document. The customer's
+		// name, email and phone and the external ID are also matched as
+		// substrings, which word prefixes miss (e.g. "kowalski@").
+		where += fmt.Sprintf(" AND (customer_name ILIKE $%d OR customer_email ILIKE $%d OR customer_phone ILIKE $%d OR external_id ILIKE $%d",
+			argIdx, argIdx, argIdx, argIdx)
 		args = append(args, "%"+*filter.Search+"%")
 		argIdx++
+		if q := model.OrderSearchQuery(*filter.Search); q != "" {
+			where += fmt.Sprintf(" OR search_vector @@ to_tsquery('simple', $%d)", argIdx)
+			args = append(args, q)
+			argIdx++
+		}
+		where += ")"
 	}
 	if filter.PaymentStatus != nil {
 		where += fmt.Sprintf(" AND payment_status = $%d", argIdx)
@@ -78,6 +99,85 @@ func (r *OrderRepository) List(ctx context.Context, tx pgx.Tx, filter model.Orde
 		args = append(args, *filter.Priority)
 		argIdx++
 	}
+	if filter.IntegrationID != nil {
+		where += fmt.Sprintf(" AND integration_id = $%d", argIdx)
+		args = append(args, *filter.IntegrationID)
+		argIdx++
+	}
+	if filter.CustomerID != nil {
+		where += fmt.Sprintf(" AND customer_id = $%d", argIdx)
+		args = append(args, *filter.CustomerID)
+		argIdx++
+	}
+	if filter.Carrier != nil {
+		where += fmt.Sprintf(" AND EXISTS (SELECT 1 FROM shipments sh WHERE sh.order_id = orders.id AND sh.provider = $%d)", argIdx)
+		args = append(args, *filter.Carrier)
+		argIdx++
+	}
+	if filter.SKU != nil {
+		where += fmt.Sprintf(" AND EXISTS (SELECT 1 FROM jsonb_array_elements(items) it WHERE it->>'sku' ILIKE $%d)", argIdx)
+		args = append(args, *filter.SKU)
+		argIdx++
+	}
+	if filter.OrderedFrom != nil {
+		where += fmt.Sprintf(" AND ordered_at >= $%d", argIdx)
+		args = append(args, *filter.OrderedFrom)
+		argIdx++
+	}
+	if filter.OrderedTo != nil {
+		where += fmt.Sprintf(" AND ordered_at < $%d", argIdx)
+		args = append(args, *filter.OrderedTo)
+		argIdx++
+	}
+	if filter.AmountMin != nil {
+		where += fmt.Sprintf(" AND total_amount >= $%d", argIdx)
+		args = append(args, *filter.AmountMin)
+		argIdx++
+	}
+	if filter.AmountMax != nil {
+		where += fmt.Sprintf(" AND total_amount <= $%d", argIdx)
+		args = append(args, *filter.AmountMax)
+		argIdx++
+	}
+	if filter.UnpackedDays != nil {
+		packed := filter.PackedStatuses
+		if len(packed) == 0 {
+			defaults := model.DefaultOrderStatusConfig()
+			packed = defaults.PackedStatuses()
+		}
+		where += fmt.Sprintf(` AND metadata->>'packed_at' IS NULL AND shipped_at IS NULL
+			AND status <> ALL($%d) AND ordered_at < NOW() - make_interval(days => $%d)`, argIdx, argIdx+1)
+		args = append(args, packed, *filter.UnpackedDays)
+		argIdx += 2
+	}
+	if filter.AddressIssues != nil {
+		if *filter.AddressIssues {
+			where += " AND jsonb_array_length(address_issues) > 0"
+		} else {
+			where += " AND COALESCE(jsonb_array_length(address_issues), 0) = 0"
+		}
+	}
+	if filter.RiskMin != nil {
+		where += fmt.Sprintf(" AND risk_score >= $%d", argIdx)
+		args = append(args, *filter.RiskMin)
+		argIdx++
+	}
+	keys := make([]string, 0, len(filter.CustomFields))
+	for key := range filter.CustomFields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		where += fmt.Sprintf(" AND metadata->>$%d = $%d", argIdx, argIdx+1)
+		args = append(args, key, filter.CustomFields[key])
+		argIdx += 2
+	}
+
+	return where, args, argIdx
+}
+
+func (r *OrderRepository) List(ctx context.Context, tx pgx.Tx, filter model.OrderListFilter) ([]model.Order, int, error) {
+	where, args, argIdx := orderFilterWhere(filter)
 
 	var total int
 	countQuery := "SELECT COUNT(*) FROM orders " + where
@@ -122,6 +222,29 @@ func (r *OrderRepository) List(ctx context.Context, tx pgx.Tx, filter model.Orde
 	return orders, total, rows.Err()
 }
 
+// ListIDs returns the IDs of orders matching the filter, newest first, up to limit.
+func (r *OrderRepository) ListIDs(ctx context.Context, tx pgx.Tx, filter model.OrderListFilter, limit int) ([]uuid.UUID, error) {
+	where, args, argIdx := orderFilterWhere(filter)
+	query := fmt.Sprintf("SELECT id FROM orders %s ORDER BY created_at DESC LIMIT $%d", where, argIdx)
+	args = append(args, limit)
+
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("list order ids: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("scan order id: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
 func (r *OrderRepository) FindByID(ctx context.Context, tx pgx.Tx, id uuid.UUID) (*model.Order, error) {
 	o, err := scanOrder(tx.QueryRow(ctx,
 		fmt.Sprintf(`SELECT %s FROM orders WHERE id = $1`, orderSelectColumns), id,
@@ -150,7 +273,7 @@ func (r *OrderRepository) Create(ctx context.Context, tx pgx.Tx, order *model.Or
 			payment_status, payment_method,
 			internal_notes, priority
 		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24)
-		RETURNING created_at, updated_at`,
+		RETURNING status_changed_at, created_at, updated_at`,
 		order.ID, order.TenantID, order.ExternalID, order.Source, order.IntegrationID, order.Status,
 		order.CustomerName, order.CustomerEmail, order.CustomerPhone,
 		order.ShippingAddress, order.BillingAddress, order.Items,
@@ -158,7 +281,7 @@ func (r *OrderRepository) Create(ctx context.Context, tx pgx.Tx, order *model.Or
 		order.DeliveryMethod, order.PickupPointID,
 		order.PaymentStatus, order.PaymentMethod,
 		order.InternalNotes, order.Priority,
-	).Scan(&order.CreatedAt, &order.UpdatedAt)
+	).Scan(&order.StatusChangedAt, &order.CreatedAt, &order.UpdatedAt)
 }
 
 func (r *OrderRepository) Update(ctx context.Context, tx pgx.Tx, id uuid.UUID, req model.UpdateOrderRequest) error {
@@ -261,6 +384,11 @@ func (r *OrderRepository) Update(ctx context.Context, tx pgx.Tx, id uuid.UUID, r
 		args = append(args, *req.Priority)
 		argIdx++
 	}
+	if req.AssignedTo != nil {
+		setClauses = append(setClauses, fmt.Sprintf("assigned_to = $%d", argIdx))
+		args = append(args, *req.AssignedTo)
+		argIdx++
+	}
 
 	if len(setClauses) == 0 {
 		return nil
@@ -321,3 +449,100 @@ func (r *OrderRepository) Delete(ctx context.Context, tx pgx.Tx, id uuid.UUID) e
 	}
 	return nil
 }
+
+// SetItems replaces the order lines.
+// SetAddressCheck stores the normalised shipping address and customer phone
+// of an order with the issues found when validating them.
+func (r *OrderRepository) SetAddressCheck(ctx context.Context, tx pgx.Tx, id uuid.UUID, shippingAddress json.RawMessage, customerPhone *string, issues []model.AddressIssue) error {
+	if issues == nil {
+		issues = []model.AddressIssue{}
+	}
+	_, err := tx.Exec(ctx,
+		`UPDATE orders SET shipping_address = $2, customer_phone = $3, address_issues = $4 WHERE id = $1`,
+		id, shippingAddress, customerPhone, issues,
+	)
+	if err != nil {
+		return fmt.Errorf("set order address check: %w", err)
+	}
+	return nil
+}
+
+// SetRisk stores the risk score of an order with the signals behind it.
+func (r *OrderRepository) SetRisk(ctx context.Context, tx pgx.Tx, id uuid.UUID, score int, signals []model.RiskSignal) error {
+	if signals == nil {
+		signals = []model.RiskSignal{}
+	}
+	_, err := tx.Exec(ctx,
+		`UPDATE orders SET risk_score = $2, risk_signals = $3 WHERE id = $1`,
+		id, score, signals,
+	)
+	if err != nil {
+		return fmt.Errorf("set order risk: %w", err)
+	}
+	return nil
+}
+
+func (r *OrderRepository) SetItems(ctx context.Context, tx pgx.Tx, id uuid.UUID, items json.RawMessage) error {
+	ct, err := tx.Exec(ctx,
+		`UPDATE orders SET items = $1, updated_at = NOW() WHERE id = $2`,
+		items, id,
+	)
+	if err != nil {
+		return fmt.Errorf("set order items: %w", err)
+	}
+	if ct.RowsAffected() == 0 {
+		return fmt.Errorf("order not found")
+	}
+	return nil
+}
+
+// MarkFulfillmentRouted records that the order lines were routed to own
+// stock or suppliers, so the dropship worker skips the order.
+func (r *OrderRepository) MarkFulfillmentRouted(ctx context.Context, tx pgx.Tx, id uuid.UUID) error {
+	_, err := tx.Exec(ctx,
+		`UPDATE orders SET fulfillment_routed_at = NOW() WHERE id = $1`, id,
+	)
+	if err != nil {
+		return fmt.Errorf("mark order fulfillment routed: %w", err)
+	}
+	return nil
+}
+
+// ListFulfillmentUnrouted returns the oldest orders in the given statuses whose
+// lines have not been routed yet.
+func (r *OrderRepository) ListFulfillmentUnrouted(ctx context.Context, tx pgx.Tx, statuses []string, limit int) ([]uuid.UUID, error) {
+	rows, err := tx.Query(ctx,
+		`SELECT id FROM orders
+		 WHERE fulfillment_routed_at IS NULL AND status = ANY($1)
+		 ORDER BY created_at LIMIT $2`,
+		statuses, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list unrouted orders: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("scan unrouted order: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// MarkStaleFulfillmentRouted marks unrouted orders created before the given
+// time as routed without routing them.
+func (r *OrderRepository) MarkStaleFulfillmentRouted(ctx context.Context, tx pgx.Tx, before time.Time) (int64, error) {
+	ct, err := tx.Exec(ctx,
+		`UPDATE orders SET fulfillment_routed_at = NOW()
+		 WHERE fulfillment_routed_at IS NULL AND created_at < $1`,
+		before,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("mark stale orders routed: %w", err)
+	}
+	return ct.RowsAffected(), nil
+}
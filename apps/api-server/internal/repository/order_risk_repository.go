@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/openoms-org/openoms/apps/api-server/internal/model"
+)
+
+// OrderRiskRepository implements OrderRiskRepo.
+type OrderRiskRepository struct{}
+
+func NewOrderRiskRepository() *OrderRiskRepository {
+	return &OrderRiskRepository{}
+}
+
+// CustomerHistory returns the history of the customer of an order. Earlier
+// orders are matched by customer ID, lowercased email or the last nine digits
+// of the phone; the order count is at least the customer's total_orders.
+// Returns exclude rejected and cancelled ones; refused cash-on-delivery
+// shipments are returned shipments of orders paid with one of codMethods.
+func (r *OrderRiskRepository) CustomerHistory(ctx context.Context, tx pgx.Tx, orderID uuid.UUID, customerID *uuid.UUID, email, phoneDigits string, codMethods []string) (model.CustomerRiskHistory, error) {
+	var h model.CustomerRiskHistory
+	err := tx.QueryRow(ctx,
+		`WITH prior AS (
+			SELECT o.id, o.payment_method FROM orders o
+			WHERE o.id <> $1 AND o.merged_into IS NULL
+			  AND (($2::uuid IS NOT NULL AND o.customer_id = $2)
+			    OR ($3 <> '' AND lower(o.customer_email) = $3)
+			    OR ($4 <> '' AND right(regexp_replace(o.customer_phone, '\D', '', 'g'), 9) = $4))
+		)
+		SELECT
+			GREATEST(
+				(SELECT COUNT(*) FROM prior),
+				COALESCE((SELECT MAX(c.total_orders) FROM customers c
+				          WHERE ($2::uuid IS NOT NULL AND c.id = $2) OR ($3 <> '' AND lower(c.email) = $3)), 0)),
+			(SELECT COUNT(*) FROM returns rt JOIN prior p ON p.id = rt.order_id
+			 WHERE rt.status NOT IN ('rejected', 'cancelled')),
+			(SELECT COUNT(DISTINCT s.order_id) FROM shipments s JOIN prior p ON p.id = s.order_id
+			 WHERE s.status = 'returned' AND lower(trim(p.payment_method)) = ANY($5))`,
+		orderID, customerID, email, phoneDigits, codMethods,
+	).Scan(&h.Orders, &h.Returns, &h.RefusedCOD)
+	if err != nil {
+		return h, fmt.Errorf("customer risk history: %w", err)
+	}
+	return h, nil
+}
+
+// Velocity counts the orders other than orderID created since a time with
+// the same phone (last nine digits) and with the same shipping address
+// (lowercased street with collapsed spaces and postal code without spaces
+// and hyphens). Empty keys are not matched.
+func (r *OrderRiskRepository) Velocity(ctx context.Context, tx pgx.Tx, orderID uuid.UUID, since time.Time, phoneDigits, street, postalCode string) (phoneOrders, addressOrders int, err error) {
+	err = tx.QueryRow(ctx,
+		`SELECT
+			COUNT(*) FILTER (WHERE $3 <> '' AND right(regexp_replace(customer_phone, '\D', '', 'g'), 9) = $3),
+			COUNT(*) FILTER (WHERE $4 <> ''
+				AND lower(regexp_replace(trim(shipping_address->>'street'), '\s+', ' ', 'g')) = $4
+				AND regexp_replace(shipping_address->>'postal_code', '[\s-]', '', 'g') = $5)
+		FROM orders
+		WHERE id <> $1 AND created_at >= $2`,
+		orderID, since, phoneDigits, street, postalCode,
+	).Scan(&phoneOrders, &addressOrders)
+	if err != nil {
+		return 0, 0, fmt.Errorf("order velocity: %w", err)
+	}
+	return phoneOrders, addressOrders, nil
+}
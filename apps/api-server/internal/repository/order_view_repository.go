@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/openoms-org/openoms/apps/api-server/internal/model"
+)
+
+// OrderViewRepository implements OrderViewRepo.
+type OrderViewRepository struct{}
+
+// NewOrderViewRepository creates a new OrderViewRepository.
+func NewOrderViewRepository() *OrderViewRepository {
+	return &OrderViewRepository{}
+}
+
+const orderViewColumns = `id, tenant_id, user_id, name, filters, created_at, updated_at`
+
+func scanOrderView(row pgx.Row) (*model.OrderView, error) {
+	var v model.OrderView
+	if err := row.Scan(&v.ID, &v.TenantID, &v.UserID, &v.Name, &v.Filters, &v.CreatedAt, &v.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+func (r *OrderViewRepository) ListByUser(ctx context.Context, tx pgx.Tx, userID uuid.UUID) ([]model.OrderView, error) {
+	rows, err := tx.Query(ctx,
+		`SELECT `+orderViewColumns+` FROM order_views WHERE user_id = $1 ORDER BY name`, userID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list order views: %w", err)
+	}
+	defer rows.Close()
+
+	var views []model.OrderView
+	for rows.Next() {
+		v, err := scanOrderView(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan order view: %w", err)
+		}
+		views = append(views, *v)
+	}
+	return views, rows.Err()
+}
+
+func (r *OrderViewRepository) FindByID(ctx context.Context, tx pgx.Tx, id uuid.UUID) (*model.OrderView, error) {
+	v, err := scanOrderView(tx.QueryRow(ctx,
+		`SELECT `+orderViewColumns+` FROM order_views WHERE id = $1`, id,
+	))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("find order view by id: %w", err)
+	}
+	return v, nil
+}
+
+func (r *OrderViewRepository) Create(ctx context.Context, tx pgx.Tx, view *model.OrderView) error {
+	filters, err := json.Marshal(view.Filters)
+	if err != nil {
+		return fmt.Errorf("marshal order view filters: %w", err)
+	}
+	return tx.QueryRow(ctx,
+		`INSERT INTO order_views (id, tenant_id, user_id, name, filters)
+		 VALUES ($1, $2, $3, $4, $5)
+		 RETURNING created_at, updated_at`,
+		view.ID, view.TenantID, view.UserID, view.Name, filters,
+	).Scan(&view.CreatedAt, &view.UpdatedAt)
+}
+
+func (r *OrderViewRepository) Update(ctx context.Context, tx pgx.Tx, id uuid.UUID, req model.UpdateOrderViewRequest) error {
+	setClauses := []string{}
+	args := []any{}
+	argIdx := 1
+
+	if req.Name != nil {
+		setClauses = append(setClauses, fmt.Sprintf("name = $%d", argIdx))
+		args = append(args, *req.Name)
+		argIdx++
+	}
+	if req.Filters != nil {
+		filters, err := json.Marshal(req.Filters)
+		if err != nil {
+			return fmt.Errorf("marshal order view filters: %w", err)
+		}
+		setClauses = append(setClauses, fmt.Sprintf("filters = $%d", argIdx))
+		args = append(args, filters)
+		argIdx++
+	}
+	if len(setClauses) == 0 {
+		return nil
+	}
+
+	query := fmt.Sprintf("UPDATE order_views SET %s, updated_at = NOW() WHERE id = $%d",
+		strings.Join(setClauses, ", "), argIdx)
+	args = append(args, id)
+
+	ct, err := tx.Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("update order view: %w", err)
+	}
+	if ct.RowsAffected() == 0 {
+		return fmt.Errorf("order view not found")
+	}
+	return nil
+}
+
+func (r *OrderViewRepository) Delete(ctx context.Context, tx pgx.Tx, id uuid.UUID) error {
+	ct, err := tx.Exec(ctx, "DELETE FROM order_views WHERE id = $1", id)
+	if err != nil {
+		return fmt.Errorf("delete order view: %w", err)
+	}
+	if ct.RowsAffected() == 0 {
+		return fmt.Errorf("order view not found")
+	}
+	return nil
+}
@@ -19,12 +19,12 @@ func NewProductListingRepository() *ProductListingRepository {
 func (r *ProductListingRepository) Create(ctx context.Context, tx pgx.Tx, listing *model.ProductListing) error {
 	return tx.QueryRow(ctx,
 		`INSERT INTO product_listings (
-			id, tenant_id, product_id, integration_id, external_id,
+			id, tenant_id, product_id, variant_id, integration_id, external_id, external_group_id,
 			status, url, price_override, stock_override,
 			sync_status, last_synced_at, error_message, metadata
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
 		RETURNING created_at, updated_at`,
-		listing.ID, listing.TenantID, listing.ProductID, listing.IntegrationID, listing.ExternalID,
+		listing.ID, listing.TenantID, listing.ProductID, listing.VariantID, listing.IntegrationID, listing.ExternalID, listing.ExternalGroupID,
 		listing.Status, listing.URL, listing.PriceOverride, listing.StockOverride,
 		listing.SyncStatus, listing.LastSyncedAt, listing.ErrorMessage, listing.Metadata,
 	).Scan(&listing.CreatedAt, &listing.UpdatedAt)
@@ -40,6 +40,11 @@ func (r *ProductListingRepository) Update(ctx context.Context, tx pgx.Tx, id uui
 		args = append(args, *req.ExternalID)
 		argIdx++
 	}
+	if req.ExternalGroupID != nil {
+		setClauses = append(setClauses, fmt.Sprintf("external_group_id = $%d", argIdx))
+		args = append(args, *req.ExternalGroupID)
+		argIdx++
+	}
 	if req.Status != nil {
 		setClauses = append(setClauses, fmt.Sprintf("status = $%d", argIdx))
 		args = append(args, *req.Status)
@@ -98,15 +103,15 @@ func (r *ProductListingRepository) Update(ctx context.Context, tx pgx.Tx, id uui
 func (r *ProductListingRepository) GetByID(ctx context.Context, tx pgx.Tx, id uuid.UUID) (*model.ProductListing, error) {
 	var l model.ProductListing
 	err := tx.QueryRow(ctx,
-		`SELECT id, tenant_id, product_id, integration_id, external_id,
+		`SELECT id, tenant_id, product_id, variant_id, integration_id, external_id, external_group_id,
 		        status, url, price_override, stock_override,
-		        sync_status, last_synced_at, error_message, metadata,
+		        sync_status, last_synced_at, last_synced_price, error_message, metadata,
 		        created_at, updated_at
 		 FROM product_listings WHERE id = $1`, id,
 	).Scan(
-		&l.ID, &l.TenantID, &l.ProductID, &l.IntegrationID, &l.ExternalID,
+		&l.ID, &l.TenantID, &l.ProductID, &l.VariantID, &l.IntegrationID, &l.ExternalID, &l.ExternalGroupID,
 		&l.Status, &l.URL, &l.PriceOverride, &l.StockOverride,
-		&l.SyncStatus, &l.LastSyncedAt, &l.ErrorMessage, &l.Metadata,
+		&l.SyncStatus, &l.LastSyncedAt, &l.LastSyncedPrice, &l.ErrorMessage, &l.Metadata,
 		&l.CreatedAt, &l.UpdatedAt,
 	)
 	if err != nil {
@@ -121,15 +126,15 @@ func (r *ProductListingRepository) GetByID(ctx context.Context, tx pgx.Tx, id uu
 func (r *ProductListingRepository) FindByProductAndIntegration(ctx context.Context, tx pgx.Tx, productID, integrationID uuid.UUID) (*model.ProductListing, error) {
 	var l model.ProductListing
 	err := tx.QueryRow(ctx,
-		`SELECT id, tenant_id, product_id, integration_id, external_id,
+		`SELECT id, tenant_id, product_id, variant_id, integration_id, external_id, external_group_id,
 		        status, url, price_override, stock_override,
-		        sync_status, last_synced_at, error_message, metadata,
+		        sync_status, last_synced_at, last_synced_price, error_message, metadata,
 		        created_at, updated_at
-		 FROM product_listings WHERE product_id = $1 AND integration_id = $2`, productID, integrationID,
+		 FROM product_listings WHERE product_id = $1 AND integration_id = $2 AND variant_id IS NULL`, productID, integrationID,
 	).Scan(
-		&l.ID, &l.TenantID, &l.ProductID, &l.IntegrationID, &l.ExternalID,
+		&l.ID, &l.TenantID, &l.ProductID, &l.VariantID, &l.IntegrationID, &l.ExternalID, &l.ExternalGroupID,
 		&l.Status, &l.URL, &l.PriceOverride, &l.StockOverride,
-		&l.SyncStatus, &l.LastSyncedAt, &l.ErrorMessage, &l.Metadata,
+		&l.SyncStatus, &l.LastSyncedAt, &l.LastSyncedPrice, &l.ErrorMessage, &l.Metadata,
 		&l.CreatedAt, &l.UpdatedAt,
 	)
 	if err != nil {
@@ -141,11 +146,35 @@ func (r *ProductListingRepository) FindByProductAndIntegration(ctx context.Conte
 	return &l, nil
 }
 
+// FindByVariantAndIntegration returns the listing of a single product variant on an integration.
+func (r *ProductListingRepository) FindByVariantAndIntegration(ctx context.Context, tx pgx.Tx, variantID, integrationID uuid.UUID) (*model.ProductListing, error) {
+	var l model.ProductListing
+	err := tx.QueryRow(ctx,
+		`SELECT id, tenant_id, product_id, variant_id, integration_id, external_id, external_group_id,
+		        status, url, price_override, stock_override,
+		        sync_status, last_synced_at, last_synced_price, error_message, metadata,
+		        created_at, updated_at
+		 FROM product_listings WHERE variant_id = $1 AND integration_id = $2`, variantID, integrationID,
+	).Scan(
+		&l.ID, &l.TenantID, &l.ProductID, &l.VariantID, &l.IntegrationID, &l.ExternalID, &l.ExternalGroupID,
+		&l.Status, &l.URL, &l.PriceOverride, &l.StockOverride,
+		&l.SyncStatus, &l.LastSyncedAt, &l.LastSyncedPrice, &l.ErrorMessage, &l.Metadata,
+		&l.CreatedAt, &l.UpdatedAt,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("find product listing by variant and integration: %w", err)
+	}
+	return &l, nil
+}
+
 func (r *ProductListingRepository) ListByProduct(ctx context.Context, tx pgx.Tx, productID uuid.UUID) ([]*model.ProductListing, error) {
 	rows, err := tx.Query(ctx,
-		`SELECT id, tenant_id, product_id, integration_id, external_id,
+		`SELECT id, tenant_id, product_id, variant_id, integration_id, external_id, external_group_id,
 		        status, url, price_override, stock_override,
-		        sync_status, last_synced_at, error_message, metadata,
+		        sync_status, last_synced_at, last_synced_price, error_message, metadata,
 		        created_at, updated_at
 		 FROM product_listings WHERE product_id = $1 ORDER BY created_at`, productID,
 	)
@@ -158,9 +187,9 @@ func (r *ProductListingRepository) ListByProduct(ctx context.Context, tx pgx.Tx,
 	for rows.Next() {
 		var l model.ProductListing
 		if err := rows.Scan(
-			&l.ID, &l.TenantID, &l.ProductID, &l.IntegrationID, &l.ExternalID,
+			&l.ID, &l.TenantID, &l.ProductID, &l.VariantID, &l.IntegrationID, &l.ExternalID, &l.ExternalGroupID,
 			&l.Status, &l.URL, &l.PriceOverride, &l.StockOverride,
-			&l.SyncStatus, &l.LastSyncedAt, &l.ErrorMessage, &l.Metadata,
+			&l.SyncStatus, &l.LastSyncedAt, &l.LastSyncedPrice, &l.ErrorMessage, &l.Metadata,
 			&l.CreatedAt, &l.UpdatedAt,
 		); err != nil {
 			return nil, fmt.Errorf("scan product listing: %w", err)
@@ -172,9 +201,9 @@ func (r *ProductListingRepository) ListByProduct(ctx context.Context, tx pgx.Tx,
 
 func (r *ProductListingRepository) ListByIntegration(ctx context.Context, tx pgx.Tx, integrationID uuid.UUID) ([]*model.ProductListing, error) {
 	rows, err := tx.Query(ctx,
-		`SELECT id, tenant_id, product_id, integration_id, external_id,
+		`SELECT id, tenant_id, product_id, variant_id, integration_id, external_id, external_group_id,
 		        status, url, price_override, stock_override,
-		        sync_status, last_synced_at, error_message, metadata,
+		        sync_status, last_synced_at, last_synced_price, error_message, metadata,
 		        created_at, updated_at
 		 FROM product_listings WHERE integration_id = $1 ORDER BY created_at`, integrationID,
 	)
@@ -187,9 +216,9 @@ func (r *ProductListingRepository) ListByIntegration(ctx context.Context, tx pgx
 	for rows.Next() {
 		var l model.ProductListing
 		if err := rows.Scan(
-			&l.ID, &l.TenantID, &l.ProductID, &l.IntegrationID, &l.ExternalID,
+			&l.ID, &l.TenantID, &l.ProductID, &l.VariantID, &l.IntegrationID, &l.ExternalID, &l.ExternalGroupID,
 			&l.Status, &l.URL, &l.PriceOverride, &l.StockOverride,
-			&l.SyncStatus, &l.LastSyncedAt, &l.ErrorMessage, &l.Metadata,
+			&l.SyncStatus, &l.LastSyncedAt, &l.LastSyncedPrice, &l.ErrorMessage, &l.Metadata,
 			&l.CreatedAt, &l.UpdatedAt,
 		); err != nil {
 			return nil, fmt.Errorf("scan product listing: %w", err)
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -48,7 +49,12 @@ func (r *ReturnRepository) List(ctx context.Context, tx pgx.Tx, filter model.Ret
 	query := fmt.Sprintf(
 		`SELECT id, tenant_id, order_id, status, reason, items, refund_amount, notes,
 		        return_token, customer_email, customer_notes,
-		        created_at, updated_at
+		        received_items, received_at, restock_document_id, shipping_refund,
+		        refund_method, refund_reference, refunded_at,
+		        return_type, refunded_amount, replacement_order_id,
+		        return_provider, return_integration_id, return_external_id,
+		        return_tracking_number, return_label_url, return_shipment_status,
+		        refund_key, refund_started_at, grading_pending, created_at, updated_at
 		 FROM returns %s
 		 %s
 		 LIMIT $%d OFFSET $%d`,
@@ -69,7 +75,12 @@ func (r *ReturnRepository) List(ctx context.Context, tx pgx.Tx, filter model.Ret
 			&ret.ID, &ret.TenantID, &ret.OrderID, &ret.Status, &ret.Reason,
 			&ret.Items, &ret.RefundAmount, &ret.Notes,
 			&ret.ReturnToken, &ret.CustomerEmail, &ret.CustomerNotes,
-			&ret.CreatedAt, &ret.UpdatedAt,
+			&ret.ReceivedItems, &ret.ReceivedAt, &ret.RestockDocumentID, &ret.ShippingRefund,
+			&ret.RefundMethod, &ret.RefundReference, &ret.RefundedAt,
+			&ret.ReturnType, &ret.RefundedAmount, &ret.ReplacementOrderID,
+			&ret.ReturnProvider, &ret.ReturnIntegrationID, &ret.ReturnExternalID,
+			&ret.ReturnTrackingNumber, &ret.ReturnLabelURL, &ret.ReturnShipmentStatus,
+			&ret.RefundKey, &ret.RefundStartedAt, &ret.GradingPending, &ret.CreatedAt, &ret.UpdatedAt,
 		); err != nil {
 			return nil, 0, fmt.Errorf("scan return: %w", err)
 		}
@@ -83,13 +94,23 @@ func (r *ReturnRepository) FindByID(ctx context.Context, tx pgx.Tx, id uuid.UUID
 	err := tx.QueryRow(ctx,
 		`SELECT id, tenant_id, order_id, status, reason, items, refund_amount, notes,
 		        return_token, customer_email, customer_notes,
-		        created_at, updated_at
+		        received_items, received_at, restock_document_id, shipping_refund,
+		        refund_method, refund_reference, refunded_at,
+		        return_type, refunded_amount, replacement_order_id,
+		        return_provider, return_integration_id, return_external_id,
+		        return_tracking_number, return_label_url, return_shipment_status,
+		        refund_key, refund_started_at, grading_pending, created_at, updated_at
 		 FROM returns WHERE id = $1`, id,
 	).Scan(
 		&ret.ID, &ret.TenantID, &ret.OrderID, &ret.Status, &ret.Reason,
 		&ret.Items, &ret.RefundAmount, &ret.Notes,
 		&ret.ReturnToken, &ret.CustomerEmail, &ret.CustomerNotes,
-		&ret.CreatedAt, &ret.UpdatedAt,
+		&ret.ReceivedItems, &ret.ReceivedAt, &ret.RestockDocumentID, &ret.ShippingRefund,
+		&ret.RefundMethod, &ret.RefundReference, &ret.RefundedAt,
+		&ret.ReturnType, &ret.RefundedAmount, &ret.ReplacementOrderID,
+		&ret.ReturnProvider, &ret.ReturnIntegrationID, &ret.ReturnExternalID,
+		&ret.ReturnTrackingNumber, &ret.ReturnLabelURL, &ret.ReturnShipmentStatus,
+		&ret.RefundKey, &ret.RefundStartedAt, &ret.GradingPending, &ret.CreatedAt, &ret.UpdatedAt,
 	)
 	if err != nil {
 		if err == pgx.ErrNoRows {
@@ -105,13 +126,23 @@ func (r *ReturnRepository) FindByToken(ctx context.Context, tx pgx.Tx, token str
 	err := tx.QueryRow(ctx,
 		`SELECT id, tenant_id, order_id, status, reason, items, refund_amount, notes,
 		        return_token, customer_email, customer_notes,
-		        created_at, updated_at
+		        received_items, received_at, restock_document_id, shipping_refund,
+		        refund_method, refund_reference, refunded_at,
+		        return_type, refunded_amount, replacement_order_id,
+		        return_provider, return_integration_id, return_external_id,
+		        return_tracking_number, return_label_url, return_shipment_status,
+		        refund_key, refund_started_at, grading_pending, created_at, updated_at
 		 FROM returns WHERE return_token = $1`, token,
 	).Scan(
 		&ret.ID, &ret.TenantID, &ret.OrderID, &ret.Status, &ret.Reason,
 		&ret.Items, &ret.RefundAmount, &ret.Notes,
 		&ret.ReturnToken, &ret.CustomerEmail, &ret.CustomerNotes,
-		&ret.CreatedAt, &ret.UpdatedAt,
+		&ret.ReceivedItems, &ret.ReceivedAt, &ret.RestockDocumentID, &ret.ShippingRefund,
+		&ret.RefundMethod, &ret.RefundReference, &ret.RefundedAt,
+		&ret.ReturnType, &ret.RefundedAmount, &ret.ReplacementOrderID,
+		&ret.ReturnProvider, &ret.ReturnIntegrationID, &ret.ReturnExternalID,
+		&ret.ReturnTrackingNumber, &ret.ReturnLabelURL, &ret.ReturnShipmentStatus,
+		&ret.RefundKey, &ret.RefundStartedAt, &ret.GradingPending, &ret.CreatedAt, &ret.UpdatedAt,
 	)
 	if err != nil {
 		if err == pgx.ErrNoRows {
@@ -126,12 +157,12 @@ func (r *ReturnRepository) Create(ctx context.Context, tx pgx.Tx, ret *model.Ret
 	return tx.QueryRow(ctx,
 		`INSERT INTO returns (
 			id, tenant_id, order_id, status, reason, items, refund_amount, notes,
-			return_token, customer_email, customer_notes
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+			return_token, customer_email, customer_notes, return_type
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
 		RETURNING created_at, updated_at`,
 		ret.ID, ret.TenantID, ret.OrderID, ret.Status, ret.Reason,
 		ret.Items, ret.RefundAmount, ret.Notes,
-		ret.ReturnToken, ret.CustomerEmail, ret.CustomerNotes,
+		ret.ReturnToken, ret.CustomerEmail, ret.CustomerNotes, ret.ReturnType,
 	).Scan(&ret.CreatedAt, &ret.UpdatedAt)
 }
 
@@ -195,6 +226,142 @@ func (r *ReturnRepository) UpdateStatus(ctx context.Context, tx pgx.Tx, id uuid.
 	return nil
 }
 
+// MarkReceived stores the received lines and refund breakdown and moves the return to received.
+// A return received from carrier tracking keeps its receipt time and is no
+// longer pending grading.
+func (r *ReturnRepository) MarkReceived(ctx context.Context, tx pgx.Tx, id uuid.UUID, receivedItems json.RawMessage, restockDocumentID *uuid.UUID, refundAmount, shippingRefund float64) error {
+	ct, err := tx.Exec(ctx,
+		`UPDATE returns SET status = 'received', received_items = $1, received_at = COALESCE(received_at, NOW()),
+		        restock_document_id = $2, refund_amount = $3, shipping_refund = $4, grading_pending = false,
+		        updated_at = NOW()
+		 WHERE id = $5`,
+		receivedItems, restockDocumentID, refundAmount, shippingRefund, id,
+	)
+	if err != nil {
+		return fmt.Errorf("mark return received: %w", err)
+	}
+	if ct.RowsAffected() == 0 {
+		return fmt.Errorf("return not found")
+	}
+	return nil
+}
+
+// MarkDelivered moves an approved return whose inbound parcel was delivered
+// to received, pending the grading of its lines by the warehouse.
+func (r *ReturnRepository) MarkDelivered(ctx context.Context, tx pgx.Tx, id uuid.UUID) error {
+	ct, err := tx.Exec(ctx,
+		`UPDATE returns SET status = 'received', return_shipment_status = 'delivered', received_at = NOW(),
+		        grading_pending = true, updated_at = NOW()
+		 WHERE id = $1 AND status = 'approved'`,
+		id,
+	)
+	if err != nil {
+		return fmt.Errorf("mark return delivered: %w", err)
+	}
+	if ct.RowsAffected() == 0 {
+		return fmt.Errorf("return not found")
+	}
+	return nil
+}
+
+// MarkRefunded records an executed refund of amount, adding it to the refunded
+// total, and moves the return to status (refunded or partially_refunded). It
+// settles a pending channel refund.
+func (r *ReturnRepository) MarkRefunded(ctx context.Context, tx pgx.Tx, id uuid.UUID, status string, amount float64, method string, reference *string) error {
+	ct, err := tx.Exec(ctx,
+		`UPDATE returns SET status = $1, refunded_amount = refunded_amount + $2, refund_method = $3,
+		        refund_reference = $4, refunded_at = NOW(), refund_key = NULL, refund_started_at = NULL,
+		        updated_at = NOW()
+		 WHERE id = $5`,
+		status, amount, method, reference, id,
+	)
+	if err != nil {
+		return fmt.Errorf("mark return refunded: %w", err)
+	}
+	if ct.RowsAffected() == 0 {
+		return fmt.Errorf("return not found")
+	}
+	return nil
+}
+
+// StartRefund marks a channel refund of the return as pending under key. It
+// reports false when another refund is already pending.
+func (r *ReturnRepository) StartRefund(ctx context.Context, tx pgx.Tx, id, key uuid.UUID) (bool, error) {
+	ct, err := tx.Exec(ctx,
+		`UPDATE returns SET refund_key = $1, refund_started_at = NOW(), updated_at = NOW()
+		 WHERE id = $2 AND refund_key IS NULL`,
+		key, id,
+	)
+	if err != nil {
+		return false, fmt.Errorf("start return refund: %w", err)
+	}
+	return ct.RowsAffected() > 0, nil
+}
+
+// ClearRefund drops the pending channel refund started under key, after the
+// channel rejected it.
+func (r *ReturnRepository) ClearRefund(ctx context.Context, tx pgx.Tx, id, key uuid.UUID) error {
+	_, err := tx.Exec(ctx,
+		`UPDATE returns SET refund_key = NULL, refund_started_at = NULL, updated_at = NOW()
+		 WHERE id = $1 AND refund_key = $2`,
+		id, key,
+	)
+	if err != nil {
+		return fmt.Errorf("clear return refund: %w", err)
+	}
+	return nil
+}
+
+// MarkExchanged links the replacement order and moves the return to exchanged.
+func (r *ReturnRepository) MarkExchanged(ctx context.Context, tx pgx.Tx, id, replacementOrderID uuid.UUID) error {
+	ct, err := tx.Exec(ctx,
+		`UPDATE returns SET status = 'exchanged', replacement_order_id = $1, updated_at = NOW()
+		 WHERE id = $2`,
+		replacementOrderID, id,
+	)
+	if err != nil {
+		return fmt.Errorf("mark return exchanged: %w", err)
+	}
+	if ct.RowsAffected() == 0 {
+		return fmt.Errorf("return not found")
+	}
+	return nil
+}
+
+// SetReturnLabel stores the prepaid return shipment created for the customer.
+func (r *ReturnRepository) SetReturnLabel(ctx context.Context, tx pgx.Tx, id uuid.UUID, label model.ReturnLabel) error {
+	ct, err := tx.Exec(ctx,
+		`UPDATE returns SET return_provider = $1, return_integration_id = $2, return_external_id = $3,
+		        return_tracking_number = $4, return_label_url = $5, return_shipment_status = $6,
+		        updated_at = NOW()
+		 WHERE id = $7`,
+		label.Provider, label.IntegrationID, label.ExternalID,
+		label.TrackingNumber, label.LabelURL, label.Status, id,
+	)
+	if err != nil {
+		return fmt.Errorf("set return label: %w", err)
+	}
+	if ct.RowsAffected() == 0 {
+		return fmt.Errorf("return not found")
+	}
+	return nil
+}
+
+// UpdateReturnShipmentStatus records the carrier status of the inbound return parcel.
+func (r *ReturnRepository) UpdateReturnShipmentStatus(ctx context.Context, tx pgx.Tx, id uuid.UUID, status string) error {
+	ct, err := tx.Exec(ctx,
+		"UPDATE returns SET return_shipment_status = $1, updated_at = NOW() WHERE id = $2",
+		status, id,
+	)
+	if err != nil {
+		return fmt.Errorf("update return shipment status: %w", err)
+	}
+	if ct.RowsAffected() == 0 {
+		return fmt.Errorf("return not found")
+	}
+	return nil
+}
+
 func (r *ReturnRepository) Delete(ctx context.Context, tx pgx.Tx, id uuid.UUID) error {
 	ct, err := tx.Exec(ctx, "DELETE FROM returns WHERE id = $1", id)
 	if err != nil {
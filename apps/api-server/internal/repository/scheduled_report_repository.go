@@ -0,0 +1,193 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/openoms-org/openoms/apps/api-server/internal/model"
+)
+
+// ScheduledReportRepository implements ScheduledReportRepo.
+type ScheduledReportRepository struct{}
+
+// NewScheduledReportRepository creates a new ScheduledReportRepository.
+func NewScheduledReportRepository() *ScheduledReportRepository {
+	return &ScheduledReportRepository{}
+}
+
+const scheduledReportColumns = `id, tenant_id, name, report_type, schedule, timezone, period, format,
+	recipients, active, last_run_at, next_run_at, created_by, created_at, updated_at`
+
+func scanScheduledReport(row pgx.Row) (*model.ScheduledReport, error) {
+	var r model.ScheduledReport
+	if err := row.Scan(
+		&r.ID, &r.TenantID, &r.Name, &r.ReportType, &r.Schedule, &r.Timezone, &r.Period, &r.Format,
+		&r.Recipients, &r.Active, &r.LastRunAt, &r.NextRunAt, &r.CreatedBy, &r.CreatedAt, &r.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+func (r *ScheduledReportRepository) List(ctx context.Context, tx pgx.Tx) ([]model.ScheduledReport, error) {
+	rows, err := tx.Query(ctx,
+		`SELECT `+scheduledReportColumns+` FROM scheduled_reports ORDER BY name`,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list scheduled reports: %w", err)
+	}
+	defer rows.Close()
+
+	var reports []model.ScheduledReport
+	for rows.Next() {
+		sr, err := scanScheduledReport(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan scheduled report: %w", err)
+		}
+		reports = append(reports, *sr)
+	}
+	return reports, rows.Err()
+}
+
+func (r *ScheduledReportRepository) FindByID(ctx context.Context, tx pgx.Tx, id uuid.UUID) (*model.ScheduledReport, error) {
+	sr, err := scanScheduledReport(tx.QueryRow(ctx,
+		`SELECT `+scheduledReportColumns+` FROM scheduled_reports WHERE id = $1`, id,
+	))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("find scheduled report by id: %w", err)
+	}
+	return sr, nil
+}
+
+func (r *ScheduledReportRepository) Create(ctx context.Context, tx pgx.Tx, report *model.ScheduledReport) error {
+	return tx.QueryRow(ctx,
+		`INSERT INTO scheduled_reports (id, tenant_id, name, report_type, schedule, timezone, period, format,
+		                                recipients, active, next_run_at, created_by)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		 RETURNING created_at, updated_at`,
+		report.ID, report.TenantID, report.Name, report.ReportType, report.Schedule, report.Timezone,
+		report.Period, report.Format, report.Recipients, report.Active, report.NextRunAt, report.CreatedBy,
+	).Scan(&report.CreatedAt, &report.UpdatedAt)
+}
+
+// Update saves the editable fields and the next run time of a report.
+func (r *ScheduledReportRepository) Update(ctx context.Context, tx pgx.Tx, report *model.ScheduledReport) error {
+	ct, err := tx.Exec(ctx,
+		`UPDATE scheduled_reports
+		 SET name = $2, report_type = $3, schedule = $4, timezone = $5, period = $6, format = $7,
+		     recipients = $8, active = $9, next_run_at = $10, updated_at = NOW()
+		 WHERE id = $1`,
+		report.ID, report.Name, report.ReportType, report.Schedule, report.Timezone, report.Period,
+		report.Format, report.Recipients, report.Active, report.NextRunAt,
+	)
+	if err != nil {
+		return fmt.Errorf("update scheduled report: %w", err)
+	}
+	if ct.RowsAffected() == 0 {
+		return fmt.Errorf("scheduled report not found")
+	}
+	return nil
+}
+
+func (r *ScheduledReportRepository) Delete(ctx context.Context, tx pgx.Tx, id uuid.UUID) error {
+	ct, err := tx.Exec(ctx, "DELETE FROM scheduled_reports WHERE id = $1", id)
+	if err != nil {
+		return fmt.Errorf("delete scheduled report: %w", err)
+	}
+	if ct.RowsAffected() == 0 {
+		return fmt.Errorf("scheduled report not found")
+	}
+	return nil
+}
+
+// ListDue locks active reports whose next run is due, across tenants. The
+// caller moves them to their next run before committing.
+func (r *ScheduledReportRepository) ListDue(ctx context.Context, tx pgx.Tx, now time.Time, limit int) ([]model.ScheduledReport, error) {
+	rows, err := tx.Query(ctx,
+		`SELECT `+scheduledReportColumns+` FROM scheduled_reports
+		 WHERE active AND next_run_at <= $1
+		 ORDER BY next_run_at
+		 LIMIT $2
+		 FOR UPDATE SKIP LOCKED`, now, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list due scheduled reports: %w", err)
+	}
+	defer rows.Close()
+
+	var reports []model.ScheduledReport
+	for rows.Next() {
+		sr, err := scanScheduledReport(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan scheduled report: %w", err)
+		}
+		reports = append(reports, *sr)
+	}
+	return reports, rows.Err()
+}
+
+// ScheduleNext records a run started at lastRun and the next run time; a nil
+// next run leaves the report without further runs.
+func (r *ScheduledReportRepository) ScheduleNext(ctx context.Context, tx pgx.Tx, id uuid.UUID, lastRun time.Time, nextRun *time.Time) error {
+	_, err := tx.Exec(ctx,
+		`UPDATE scheduled_reports SET last_run_at = $2, next_run_at = $3 WHERE id = $1`,
+		id, lastRun, nextRun,
+	)
+	if err != nil {
+		return fmt.Errorf("schedule next report run: %w", err)
+	}
+	return nil
+}
+
+const scheduledReportRunColumns = `id, tenant_id, report_id, status, format, period_from, period_to,
+	recipients, file_url, error_message, created_at`
+
+func (r *ScheduledReportRepository) CreateRun(ctx context.Context, tx pgx.Tx, run *model.ScheduledReportRun) error {
+	return tx.QueryRow(ctx,
+		`INSERT INTO scheduled_report_runs (id, tenant_id, report_id, status, format, period_from, period_to,
+		                                    recipients, file_url, error_message)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		 RETURNING created_at`,
+		run.ID, run.TenantID, run.ReportID, run.Status, run.Format, run.PeriodFrom, run.PeriodTo,
+		run.Recipients, run.FileURL, run.ErrorMessage,
+	).Scan(&run.CreatedAt)
+}
+
+func (r *ScheduledReportRepository) ListRuns(ctx context.Context, tx pgx.Tx, reportID uuid.UUID, p model.PaginationParams) ([]model.ScheduledReportRun, int, error) {
+	var total int
+	if err := tx.QueryRow(ctx,
+		`SELECT COUNT(*) FROM scheduled_report_runs WHERE report_id = $1`, reportID,
+	).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("count scheduled report runs: %w", err)
+	}
+
+	rows, err := tx.Query(ctx,
+		`SELECT `+scheduledReportRunColumns+` FROM scheduled_report_runs
+		 WHERE report_id = $1
+		 ORDER BY created_at DESC
+		 LIMIT $2 OFFSET $3`, reportID, p.Limit, p.Offset,
+	)
+	if err != nil {
+		return nil, 0, fmt.Errorf("list scheduled report runs: %w", err)
+	}
+	defer rows.Close()
+
+	var runs []model.ScheduledReportRun
+	for rows.Next() {
+		var run model.ScheduledReportRun
+		if err := rows.Scan(
+			&run.ID, &run.TenantID, &run.ReportID, &run.Status, &run.Format, &run.PeriodFrom, &run.PeriodTo,
+			&run.Recipients, &run.FileURL, &run.ErrorMessage, &run.CreatedAt,
+		); err != nil {
+			return nil, 0, fmt.Errorf("scan scheduled report run: %w", err)
+		}
+		runs = append(runs, run)
+	}
+	return runs, total, rows.Err()
+}
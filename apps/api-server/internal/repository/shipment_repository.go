@@ -53,7 +53,7 @@ func (r *ShipmentRepository) List(ctx context.Context, tx pgx.Tx, filter model.S
 	query := fmt.Sprintf(
 		`SELECT id, tenant_id, order_id, provider, integration_id,
 		        tracking_number, status, label_url, carrier_data,
-		        warehouse_id, created_at, updated_at
+		        warehouse_id, shipping_cost, created_at, updated_at
 		 FROM shipments %s
 		 %s
 		 LIMIT $%d OFFSET $%d`,
@@ -73,7 +73,7 @@ func (r *ShipmentRepository) List(ctx context.Context, tx pgx.Tx, filter model.S
 		if err := rows.Scan(
 			&s.ID, &s.TenantID, &s.OrderID, &s.Provider, &s.IntegrationID,
 			&s.TrackingNumber, &s.Status, &s.LabelURL, &s.CarrierData,
-			&s.WarehouseID, &s.CreatedAt, &s.UpdatedAt,
+			&s.WarehouseID, &s.ShippingCost, &s.CreatedAt, &s.UpdatedAt,
 		); err != nil {
 			return nil, 0, fmt.Errorf("scan shipment: %w", err)
 		}
@@ -87,12 +87,12 @@ func (r *ShipmentRepository) FindByID(ctx context.Context, tx pgx.Tx, id uuid.UU
 	err := tx.QueryRow(ctx,
 		`SELECT id, tenant_id, order_id, provider, integration_id,
 		        tracking_number, status, label_url, carrier_data,
-		        warehouse_id, created_at, updated_at
+		        warehouse_id, shipping_cost, created_at, updated_at
 		 FROM shipments WHERE id = $1`, id,
 	).Scan(
 		&s.ID, &s.TenantID, &s.OrderID, &s.Provider, &s.IntegrationID,
 		&s.TrackingNumber, &s.Status, &s.LabelURL, &s.CarrierData,
-		&s.WarehouseID, &s.CreatedAt, &s.UpdatedAt,
+		&s.WarehouseID, &s.ShippingCost, &s.CreatedAt, &s.UpdatedAt,
 	)
 	if err != nil {
 		if err == pgx.ErrNoRows {
@@ -107,12 +107,12 @@ func (r *ShipmentRepository) Create(ctx context.Context, tx pgx.Tx, shipment *mo
 	return tx.QueryRow(ctx,
 		`INSERT INTO shipments (
 			id, tenant_id, order_id, provider, integration_id,
-			tracking_number, status, label_url, carrier_data, warehouse_id
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+			tracking_number, status, label_url, carrier_data, warehouse_id, shipping_cost
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 		RETURNING created_at, updated_at`,
 		shipment.ID, shipment.TenantID, shipment.OrderID, shipment.Provider, shipment.IntegrationID,
 		shipment.TrackingNumber, shipment.Status, shipment.LabelURL, shipment.CarrierData,
-		shipment.WarehouseID,
+		shipment.WarehouseID, shipment.ShippingCost,
 	).Scan(&shipment.CreatedAt, &shipment.UpdatedAt)
 }
 
@@ -136,6 +136,11 @@ func (r *ShipmentRepository) Update(ctx context.Context, tx pgx.Tx, id uuid.UUID
 		args = append(args, req.CarrierData)
 		argIdx++
 	}
+	if req.ShippingCost != nil {
+		setClauses = append(setClauses, fmt.Sprintf("shipping_cost = $%d", argIdx))
+		args = append(args, *req.ShippingCost)
+		argIdx++
+	}
 
 	if len(setClauses) == 0 {
 		return nil
@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"fmt"
+	"math"
 	"time"
 
 	"github.com/google/uuid"
@@ -231,3 +232,249 @@ func (r *StatsRepository) GetPaymentMethodStats(ctx context.Context, tx pgx.Tx)
 	}
 	return result, rows.Err()
 }
+
+// profitabilityCTE selects the orders of a profitability report ($1 currency,
+// $2 from, $3 to, $4 excluded statuses) with their shipping costs, and their
+// lines with the unit purchase cost: the variant cost, else the product cost,
+// else the lowest supplier price. Lines reference variants and products by ID
+// or, for older orders, by SKU.
+const profitabilityCTE = `
+WITH scoped AS (
+    SELECT o.id, o.external_id, o.customer_name, o.source, o.ordered_at, o.total_amount,
+           COALESCE(o.marketplace_fee, 0) AS fee,
+           CASE WHEN jsonb_typeof(o.items) = 'array' THEN o.items ELSE '[]'::jsonb END AS items
+    FROM orders o
+    WHERE o.currency = $1
+      AND ($2::timestamptz IS NULL OR o.ordered_at >= $2)
+      AND ($3::timestamptz IS NULL OR o.ordered_at < $3)
+      AND o.status::text <> ALL($4::text[])
+      AND o.merged_into IS NULL
+),
+lines AS (
+    SELECT s.id AS order_id,
+           COALESCE(NULLIF(i.sku, ''), i.name, '') AS sku,
+           COALESCE(i.name, '') AS name,
+           COALESCE(i.quantity, 0) AS quantity,
+           COALESCE(i.total_price, COALESCE(i.unit_price, i.price, 0) * COALESCE(i.quantity, 0)) AS revenue,
+           COALESCE(v.cost_price, p.cost_price, sp.price) AS unit_cost
+    FROM scoped s
+    CROSS JOIN LATERAL jsonb_to_recordset(s.items) AS i(
+        name text, sku text, quantity int, price numeric, unit_price numeric,
+        total_price numeric, product_id text, variant_id text)
+    LEFT JOIN LATERAL (
+        SELECT pv.product_id, pv.cost_price FROM product_variants pv
+        WHERE pv.id::text = i.variant_id
+           OR (i.variant_id IS NULL AND NULLIF(i.sku, '') IS NOT NULL AND pv.sku = i.sku)
+        LIMIT 1
+    ) v ON true
+    LEFT JOIN LATERAL (
+        SELECT pr.id, pr.cost_price FROM products pr
+        WHERE pr.id::text = COALESCE(i.product_id, v.product_id::text)
+           OR (i.product_id IS NULL AND v.product_id IS NULL
+               AND NULLIF(i.sku, '') IS NOT NULL AND pr.sku = i.sku)
+        LIMIT 1
+    ) p ON true
+    LEFT JOIN LATERAL (
+        SELECT MIN(price) AS price FROM supplier_products
+        WHERE product_id = p.id AND price IS NOT NULL
+    ) sp ON true
+),
+order_costs AS (
+    SELECT order_id,
+           SUM(quantity)::int AS quantity,
+           SUM(COALESCE(unit_cost, 0) * quantity) AS product_cost,
+           COUNT(*) FILTER (WHERE unit_cost IS NULL)::int AS missing
+    FROM lines
+    GROUP BY order_id
+),
+shipping AS (
+    SELECT order_id, SUM(shipping_cost) AS cost
+    FROM shipments
+    WHERE shipping_cost IS NOT NULL AND order_id IN (SELECT id FROM scoped)
+    GROUP BY order_id
+)`
+
+// profitabilityOrderColumns aggregates whole orders for the order, channel
+// and period groupings and for the totals.
+const profitabilityOrderColumns = `
+    COUNT(*)::int,
+    COALESCE(SUM(oc.quantity), 0)::int,
+    COALESCE(SUM(s.total_amount), 0),
+    COALESCE(SUM(oc.product_cost), 0),
+    COALESCE(SUM(sh.cost), 0),
+    COALESCE(SUM(s.fee), 0),
+    COALESCE(SUM(oc.missing), 0)::int
+FROM scoped s
+LEFT JOIN order_costs oc ON oc.order_id = s.id
+LEFT JOIN shipping sh ON sh.order_id = s.id`
+
+func profitabilityArgs(filter model.ProfitabilityFilter) []any {
+	excluded := filter.ExcludedStatuses
+	if len(excluded) == 0 {
+		excluded = model.ProfitabilityExcludedStatuses
+	}
+	return []any{filter.Currency, filter.From, filter.To, excluded}
+}
+
+func (r *StatsRepository) GetProfitability(ctx context.Context, tx pgx.Tx, filter model.ProfitabilityFilter) ([]model.ProfitabilityRow, error) {
+	args := profitabilityArgs(filter)
+	var query string
+	switch filter.GroupBy {
+	case model.ProfitabilityByOrder:
+		query = profitabilityCTE + `
+SELECT s.id::text, TRIM(COALESCE(s.external_id, '') || ' ' || s.customer_name),` + profitabilityOrderColumns + `
+GROUP BY s.id, s.external_id, s.customer_name, s.ordered_at
+ORDER BY s.ordered_at DESC
+LIMIT $5`
+		args = append(args, filter.Limit)
+	case model.ProfitabilityBySKU:
+		query = profitabilityCTE + `
+SELECT sku, MAX(name),
+       COUNT(DISTINCT order_id)::int,
+       SUM(quantity)::int,
+       SUM(revenue),
+       SUM(COALESCE(unit_cost, 0) * quantity),
+       0::numeric, 0::numeric,
+       COUNT(*) FILTER (WHERE unit_cost IS NULL)::int
+FROM lines
+GROUP BY sku
+ORDER BY SUM(revenue) DESC
+LIMIT $5`
+		args = append(args, filter.Limit)
+	case model.ProfitabilityByPeriod:
+		query = profitabilityCTE + `
+SELECT to_char(date_trunc($5, s.ordered_at), 'YYYY-MM-DD') AS period, '',` + profitabilityOrderColumns + `
+GROUP BY period
+ORDER BY period`
+		args = append(args, filter.Period)
+	default:
+		query = profitabilityCTE + `
+SELECT s.source::text, s.source::text,` + profitabilityOrderColumns + `
+GROUP BY s.source
+ORDER BY SUM(s.total_amount) DESC`
+	}
+
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("profitability: %w", err)
+	}
+	defer rows.Close()
+
+	result := []model.ProfitabilityRow{}
+	for rows.Next() {
+		var pr model.ProfitabilityRow
+		if err := rows.Scan(&pr.Key, &pr.Label, &pr.Orders, &pr.Quantity, &pr.Revenue,
+			&pr.ProductCost, &pr.ShippingCost, &pr.MarketplaceFee, &pr.MissingCostLines); err != nil {
+			return nil, fmt.Errorf("scan profitability: %w", err)
+		}
+		if pr.Label == "" {
+			pr.Label = pr.Key
+		}
+		result = append(result, pr)
+	}
+	return result, rows.Err()
+}
+
+func (r *StatsRepository) GetProfitabilityTotals(ctx context.Context, tx pgx.Tx, filter model.ProfitabilityFilter) (model.ProfitabilityRow, error) {
+	var pr model.ProfitabilityRow
+	err := tx.QueryRow(ctx, profitabilityCTE+`
+SELECT`+profitabilityOrderColumns, profitabilityArgs(filter)...,
+	).Scan(&pr.Orders, &pr.Quantity, &pr.Revenue, &pr.ProductCost, &pr.ShippingCost, &pr.MarketplaceFee, &pr.MissingCostLines)
+	if err != nil {
+		return pr, fmt.Errorf("profitability totals: %w", err)
+	}
+	return pr, nil
+}
+
+// GetSalesByChannel returns order counts and revenue per channel and currency
+// for orders placed in [from, to), excluding merged orders and orders in the
+// excluded statuses.
+func (r *StatsRepository) GetSalesByChannel(ctx context.Context, tx pgx.Tx, from, to time.Time, excluded []string) ([]model.ChannelSales, error) {
+	rows, err := tx.Query(ctx,
+		`SELECT source::text, currency, COUNT(*)::int, COALESCE(SUM(total_amount), 0)
+		 FROM orders
+		 WHERE ordered_at >= $1 AND ordered_at < $2
+		   AND status::text <> ALL($3::text[])
+		   AND merged_into IS NULL
+		 GROUP BY source, currency
+		 ORDER BY currency, SUM(total_amount) DESC`,
+		from, to, excluded)
+	if err != nil {
+		return nil, fmt.Errorf("sales by channel: %w", err)
+	}
+	defer rows.Close()
+
+	result := []model.ChannelSales{}
+	for rows.Next() {
+		var cs model.ChannelSales
+		if err := rows.Scan(&cs.Source, &cs.Currency, &cs.Orders, &cs.Revenue); err != nil {
+			return nil, fmt.Errorf("scan sales by channel: %w", err)
+		}
+		result = append(result, cs)
+	}
+	return result, rows.Err()
+}
+
+// GetStockValue values the current stock of products without variants and
+// of active variants. Variants fall back to the product cost and price.
+func (r *StatsRepository) GetStockValue(ctx context.Context, tx pgx.Tx) ([]model.StockValueRow, error) {
+	rows, err := tx.Query(ctx,
+		`SELECT COALESCE(p.sku, ''), p.name, p.stock_quantity, p.cost_price, p.price
+		 FROM products p
+		 WHERE NOT p.has_variants AND p.stock_quantity > 0
+		 UNION ALL
+		 SELECT COALESCE(v.sku, p.sku, ''), p.name || ' - ' || v.name, v.stock_quantity,
+		        COALESCE(v.cost_price, p.cost_price), COALESCE(v.price_override, p.price)
+		 FROM product_variants v
+		 JOIN products p ON p.id = v.product_id
+		 WHERE v.active AND v.stock_quantity > 0
+		 ORDER BY 1, 2`)
+	if err != nil {
+		return nil, fmt.Errorf("stock value: %w", err)
+	}
+	defer rows.Close()
+
+	result := []model.StockValueRow{}
+	for rows.Next() {
+		var sv model.StockValueRow
+		var price float64
+		if err := rows.Scan(&sv.SKU, &sv.Name, &sv.Quantity, &sv.UnitCost, &price); err != nil {
+			return nil, fmt.Errorf("scan stock value: %w", err)
+		}
+		if sv.UnitCost != nil {
+			sv.CostValue = *sv.UnitCost * float64(sv.Quantity)
+		}
+		sv.SaleValue = price * float64(sv.Quantity)
+		result = append(result, sv)
+	}
+	return result, rows.Err()
+}
+
+// GetVATSummary totals invoices issued in [from, to) per invoice type and
+// currency. The bounds are taken as calendar dates in their own location.
+// Drafts, cancelled and failed invoices are skipped.
+func (r *StatsRepository) GetVATSummary(ctx context.Context, tx pgx.Tx, from, to time.Time) ([]model.VATSummaryRow, error) {
+	rows, err := tx.Query(ctx,
+		`SELECT invoice_type, currency, COUNT(*)::int,
+		        COALESCE(SUM(total_net), 0), COALESCE(SUM(total_gross), 0)
+		 FROM invoices
+		 WHERE issue_date >= $1::date AND issue_date < $2::date
+		   AND status NOT IN ('draft', 'cancelled', 'error')
+		 GROUP BY invoice_type, currency
+		 ORDER BY currency, invoice_type`, from.Format("2006-01-02"), to.Format("2006-01-02"))
+	if err != nil {
+		return nil, fmt.Errorf("vat summary: %w", err)
+	}
+	defer rows.Close()
+
+	result := []model.VATSummaryRow{}
+	for rows.Next() {
+		var vs model.VATSummaryRow
+		if err := rows.Scan(&vs.InvoiceType, &vs.Currency, &vs.Invoices, &vs.Net, &vs.Gross); err != nil {
+			return nil, fmt.Errorf("scan vat summary: %w", err)
+		}
+		vs.VAT = math.Round((vs.Gross-vs.Net)*100) / 100
+		result = append(result, vs)
+	}
+	return result, rows.Err()
+}
@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/openoms-org/openoms/apps/api-server/internal/model"
+)
+
+// StockMovementRepository implements StockMovementRepo.
+type StockMovementRepository struct{}
+
+// NewStockMovementRepository creates a new StockMovementRepository.
+func NewStockMovementRepository() *StockMovementRepository {
+	return &StockMovementRepository{}
+}
+
+// insertStockMovement appends an entry to the stock ledger. It is called by
+// WarehouseStockRepository on every change of quantity.
+func insertStockMovement(ctx context.Context, tx pgx.Tx, m *model.StockMovement) error {
+	err := tx.QueryRow(ctx,
+		`INSERT INTO stock_movements (id, tenant_id, warehouse_id, product_id, variant_id, source, document_id,
+		                              stocktake_id, delta, quantity_before, quantity_after, actor_id, note)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		 RETURNING created_at`,
+		m.ID, m.TenantID, m.WarehouseID, m.ProductID, m.VariantID, m.Source, m.DocumentID,
+		m.StocktakeID, m.Delta, m.QuantityBefore, m.QuantityAfter, m.ActorID, m.Note,
+	).Scan(&m.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("insert stock movement: %w", err)
+	}
+	return nil
+}
+
+// stockMovementWhere filters a product's movements ($1 product, $2 warehouse,
+// $3 variant, $4 from, $5 to).
+const stockMovementWhere = `
+		 WHERE m.product_id = $1
+		   AND ($2::uuid IS NULL OR m.warehouse_id = $2)
+		   AND ($3::uuid IS NULL OR m.variant_id = $3)
+		   AND ($4::timestamptz IS NULL OR m.created_at >= $4)
+		   AND ($5::timestamptz IS NULL OR m.created_at < $5)`
+
+// ListByProduct returns a product's movements, newest first.
+func (r *StockMovementRepository) ListByProduct(ctx context.Context, tx pgx.Tx, productID uuid.UUID, filter model.StockMovementListFilter) ([]model.StockMovement, int, error) {
+	args := []any{productID, filter.WarehouseID, filter.VariantID, filter.From, filter.To}
+
+	var total int
+	if err := tx.QueryRow(ctx,
+		`SELECT COUNT(*) FROM stock_movements m`+stockMovementWhere, args...,
+	).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("count stock movements: %w", err)
+	}
+
+	rows, err := tx.Query(ctx,
+		`SELECT m.id, m.tenant_id, m.warehouse_id, m.product_id, m.variant_id, m.source, m.document_id,
+		        m.stocktake_id, m.delta, m.quantity_before, m.quantity_after, m.actor_id, m.note, m.created_at,
+		        w.name, d.document_number, d.document_type, u.name
+		 FROM stock_movements m
+		 JOIN warehouses w ON w.id = m.warehouse_id
+		 LEFT JOIN warehouse_documents d ON d.id = m.document_id
+		 LEFT JOIN users u ON u.id = m.actor_id`+stockMovementWhere+`
+		 ORDER BY m.created_at DESC, m.id
+		 LIMIT $6 OFFSET $7`,
+		append(args, filter.Limit, filter.Offset)...,
+	)
+	if err != nil {
+		return nil, 0, fmt.Errorf("list stock movements: %w", err)
+	}
+	defer rows.Close()
+
+	var movements []model.StockMovement
+	for rows.Next() {
+		var m model.StockMovement
+		if err := rows.Scan(
+			&m.ID, &m.TenantID, &m.WarehouseID, &m.ProductID, &m.VariantID, &m.Source, &m.DocumentID,
+			&m.StocktakeID, &m.Delta, &m.QuantityBefore, &m.QuantityAfter, &m.ActorID, &m.Note, &m.CreatedAt,
+			&m.WarehouseName, &m.DocumentNumber, &m.DocumentType, &m.ActorName,
+		); err != nil {
+			return nil, 0, fmt.Errorf("scan stock movement: %w", err)
+		}
+		movements = append(movements, m)
+	}
+	return movements, total, rows.Err()
+}
+
+// StockAt reconstructs a product's stock before filter.At by summing the
+// ledger per warehouse and variant.
+func (r *StockMovementRepository) StockAt(ctx context.Context, tx pgx.Tx, productID uuid.UUID, filter model.StockAtFilter) ([]model.StockAtRow, error) {
+	rows, err := tx.Query(ctx,
+		`SELECT m.warehouse_id, w.name, m.variant_id, v.name, SUM(m.delta)::int
+		 FROM stock_movements m
+		 JOIN warehouses w ON w.id = m.warehouse_id
+		 LEFT JOIN product_variants v ON v.id = m.variant_id
+		 WHERE m.product_id = $1 AND m.created_at < $2
+		   AND ($3::uuid IS NULL OR m.warehouse_id = $3)
+		 GROUP BY m.warehouse_id, w.name, m.variant_id, v.name
+		 HAVING SUM(m.delta) <> 0
+		 ORDER BY w.name, v.name NULLS FIRST`,
+		productID, filter.At, filter.WarehouseID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("stock at date: %w", err)
+	}
+	defer rows.Close()
+
+	result := []model.StockAtRow{}
+	for rows.Next() {
+		var row model.StockAtRow
+		if err := rows.Scan(&row.WarehouseID, &row.WarehouseName, &row.VariantID, &row.VariantName, &row.Quantity); err != nil {
+			return nil, fmt.Errorf("scan stock at date: %w", err)
+		}
+		result = append(result, row)
+	}
+	return result, rows.Err()
+}
@@ -378,3 +378,31 @@ func (r *SupplierProductRepository) LinkToProduct(ctx context.Context, tx pgx.Tx
 	}
 	return nil
 }
+
+// ListOffersForProducts returns the supplier products linked to the given
+// products at active suppliers, cheapest first.
+func (r *SupplierProductRepository) ListOffersForProducts(ctx context.Context, tx pgx.Tx, productIDs []uuid.UUID) ([]model.SupplierProduct, error) {
+	rows, err := tx.Query(ctx,
+		`SELECT sp.id, sp.tenant_id, sp.supplier_id, sp.product_id, sp.external_id, sp.name, sp.ean, sp.sku,
+		        sp.price, sp.stock_quantity, sp.metadata, sp.last_synced_at, sp.created_at, sp.updated_at
+		 FROM supplier_products sp
+		 JOIN suppliers s ON s.id = sp.supplier_id AND s.status = 'active'
+		 WHERE sp.product_id = ANY($1)
+		 ORDER BY sp.price ASC NULLS LAST, sp.stock_quantity DESC`,
+		productIDs,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("list supplier offers: %w", err)
+	}
+	defer rows.Close()
+
+	var offers []model.SupplierProduct
+	for rows.Next() {
+		sp, err := scanSupplierProduct(rows)
+		if err != nil {
+			return nil, err
+		}
+		offers = append(offers, *sp)
+	}
+	return offers, rows.Err()
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -32,6 +33,7 @@ func (r *SyncJobRepository) UpdateStatus(ctx context.Context, tx pgx.Tx, id uuid
 	ct, err := tx.Exec(ctx,
 		`UPDATE sync_jobs
 		 SET status = $1, items_processed = $2, items_failed = $3, error_message = $4,
+		     started_at = CASE WHEN $1 = 'running' THEN COALESCE(started_at, NOW()) ELSE started_at END,
 		     finished_at = CASE WHEN $1 IN ('completed', 'failed') THEN NOW() ELSE finished_at END
 		 WHERE id = $5`,
 		status, itemsProcessed, itemsFailed, errorMsg, id,
@@ -45,6 +47,18 @@ func (r *SyncJobRepository) UpdateStatus(ctx context.Context, tx pgx.Tx, id uuid
 	return nil
 }
 
+// UpdateProgress records the counters and metadata of a running job.
+func (r *SyncJobRepository) UpdateProgress(ctx context.Context, tx pgx.Tx, id uuid.UUID, itemsProcessed, itemsFailed int, metadata json.RawMessage) error {
+	_, err := tx.Exec(ctx,
+		`UPDATE sync_jobs SET items_processed = $1, items_failed = $2, metadata = $3 WHERE id = $4`,
+		itemsProcessed, itemsFailed, metadata, id,
+	)
+	if err != nil {
+		return fmt.Errorf("update sync job progress: %w", err)
+	}
+	return nil
+}
+
 func (r *SyncJobRepository) GetByID(ctx context.Context, tx pgx.Tx, id uuid.UUID) (*model.SyncJob, error) {
 	var j model.SyncJob
 	err := tx.QueryRow(ctx,
@@ -94,6 +108,67 @@ func (r *SyncJobRepository) ListByIntegration(ctx context.Context, tx pgx.Tx, in
 	return jobs, rows.Err()
 }
 
+// FindActive returns the pending or running job of the given type for an
+// integration, or nil when there is none.
+func (r *SyncJobRepository) FindActive(ctx context.Context, tx pgx.Tx, integrationID uuid.UUID, jobType string) (*model.SyncJob, error) {
+	var j model.SyncJob
+	err := tx.QueryRow(ctx,
+		`SELECT id, tenant_id, integration_id, job_type, status,
+		        started_at, finished_at, items_processed, items_failed,
+		        error_message, metadata, created_at
+		 FROM sync_jobs
+		 WHERE integration_id = $1 AND job_type = $2 AND status IN ('pending', 'running')
+		 ORDER BY created_at DESC LIMIT 1`, integrationID, jobType,
+	).Scan(
+		&j.ID, &j.TenantID, &j.IntegrationID, &j.JobType, &j.Status,
+		&j.StartedAt, &j.FinishedAt, &j.ItemsProcessed, &j.ItemsFailed,
+		&j.ErrorMessage, &j.Metadata, &j.CreatedAt,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("find active sync job: %w", err)
+	}
+	return &j, nil
+}
+
+// ClaimPending marks up to limit pending jobs of the given type as running and
+// returns them, oldest first. Jobs locked by a concurrent claim are skipped.
+// Used by workers outside of a tenant context.
+func (r *SyncJobRepository) ClaimPending(ctx context.Context, tx pgx.Tx, jobType string, limit int) ([]*model.SyncJob, error) {
+	rows, err := tx.Query(ctx,
+		`UPDATE sync_jobs SET status = 'running', started_at = NOW()
+		 WHERE id IN (
+		     SELECT id FROM sync_jobs
+		     WHERE job_type = $1 AND status = 'pending'
+		     ORDER BY created_at ASC LIMIT $2
+		     FOR UPDATE SKIP LOCKED
+		 )
+		 RETURNING id, tenant_id, integration_id, job_type, status,
+		           started_at, finished_at, items_processed, items_failed,
+		           error_message, metadata, created_at`, jobType, limit,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("claim pending sync jobs: %w", err)
+	}
+	defer rows.Close()
+
+	var jobs []*model.SyncJob
+	for rows.Next() {
+		var j model.SyncJob
+		if err := rows.Scan(
+			&j.ID, &j.TenantID, &j.IntegrationID, &j.JobType, &j.Status,
+			&j.StartedAt, &j.FinishedAt, &j.ItemsProcessed, &j.ItemsFailed,
+			&j.ErrorMessage, &j.Metadata, &j.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scan sync job: %w", err)
+		}
+		jobs = append(jobs, &j)
+	}
+	return jobs, rows.Err()
+}
+
 func (r *SyncJobRepository) List(ctx context.Context, tx pgx.Tx, filter model.SyncJobListFilter) ([]*model.SyncJob, int, error) {
 	var conditions []string
 	var args []any
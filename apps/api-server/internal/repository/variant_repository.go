@@ -53,7 +53,7 @@ func (r *VariantRepository) List(ctx context.Context, tx pgx.Tx, filter model.Va
 	}
 
 	query := fmt.Sprintf(
-		`SELECT id, tenant_id, product_id, sku, ean, name, attributes, price_override, stock_quantity,
+		`SELECT id, tenant_id, product_id, sku, ean, name, attributes, price_override, cost_price, stock_quantity,
 		        weight, image_url, position, active, created_at, updated_at
 		 FROM product_variants %s %s LIMIT $%d OFFSET $%d`,
 		where, orderByClause, argIdx, argIdx+1,
@@ -71,7 +71,7 @@ func (r *VariantRepository) List(ctx context.Context, tx pgx.Tx, filter model.Va
 		var v model.ProductVariant
 		if err := rows.Scan(
 			&v.ID, &v.TenantID, &v.ProductID, &v.SKU, &v.EAN, &v.Name,
-			&v.Attributes, &v.PriceOverride, &v.StockQuantity,
+			&v.Attributes, &v.PriceOverride, &v.CostPrice, &v.StockQuantity,
 			&v.Weight, &v.ImageURL, &v.Position, &v.Active,
 			&v.CreatedAt, &v.UpdatedAt,
 		); err != nil {
@@ -85,12 +85,12 @@ func (r *VariantRepository) List(ctx context.Context, tx pgx.Tx, filter model.Va
 func (r *VariantRepository) FindByID(ctx context.Context, tx pgx.Tx, id uuid.UUID) (*model.ProductVariant, error) {
 	var v model.ProductVariant
 	err := tx.QueryRow(ctx,
-		`SELECT id, tenant_id, product_id, sku, ean, name, attributes, price_override, stock_quantity,
+		`SELECT id, tenant_id, product_id, sku, ean, name, attributes, price_override, cost_price, stock_quantity,
 		        weight, image_url, position, active, created_at, updated_at
 		 FROM product_variants WHERE id = $1`, id,
 	).Scan(
 		&v.ID, &v.TenantID, &v.ProductID, &v.SKU, &v.EAN, &v.Name,
-		&v.Attributes, &v.PriceOverride, &v.StockQuantity,
+		&v.Attributes, &v.PriceOverride, &v.CostPrice, &v.StockQuantity,
 		&v.Weight, &v.ImageURL, &v.Position, &v.Active,
 		&v.CreatedAt, &v.UpdatedAt,
 	)
@@ -105,7 +105,7 @@ func (r *VariantRepository) FindByID(ctx context.Context, tx pgx.Tx, id uuid.UUI
 
 func (r *VariantRepository) FindBySKU(ctx context.Context, tx pgx.Tx, sku string) ([]model.ProductVariant, error) {
 	rows, err := tx.Query(ctx,
-		`SELECT id, tenant_id, product_id, sku, ean, name, attributes, price_override, stock_quantity,
+		`SELECT id, tenant_id, product_id, sku, ean, name, attributes, price_override, cost_price, stock_quantity,
 		        weight, image_url, position, active, created_at, updated_at
 		 FROM product_variants WHERE sku = $1`, sku,
 	)
@@ -119,7 +119,7 @@ func (r *VariantRepository) FindBySKU(ctx context.Context, tx pgx.Tx, sku string
 		var v model.ProductVariant
 		if err := rows.Scan(
 			&v.ID, &v.TenantID, &v.ProductID, &v.SKU, &v.EAN, &v.Name,
-			&v.Attributes, &v.PriceOverride, &v.StockQuantity,
+			&v.Attributes, &v.PriceOverride, &v.CostPrice, &v.StockQuantity,
 			&v.Weight, &v.ImageURL, &v.Position, &v.Active,
 			&v.CreatedAt, &v.UpdatedAt,
 		); err != nil {
@@ -132,7 +132,7 @@ func (r *VariantRepository) FindBySKU(ctx context.Context, tx pgx.Tx, sku string
 
 func (r *VariantRepository) FindByEAN(ctx context.Context, tx pgx.Tx, ean string) ([]model.ProductVariant, error) {
 	rows, err := tx.Query(ctx,
-		`SELECT id, tenant_id, product_id, sku, ean, name, attributes, price_override, stock_quantity,
+		`SELECT id, tenant_id, product_id, sku, ean, name, attributes, price_override, cost_price, stock_quantity,
 		        weight, image_url, position, active, created_at, updated_at
 		 FROM product_variants WHERE ean = $1`, ean,
 	)
@@ -146,7 +146,7 @@ func (r *VariantRepository) FindByEAN(ctx context.Context, tx pgx.Tx, ean string
 		var v model.ProductVariant
 		if err := rows.Scan(
 			&v.ID, &v.TenantID, &v.ProductID, &v.SKU, &v.EAN, &v.Name,
-			&v.Attributes, &v.PriceOverride, &v.StockQuantity,
+			&v.Attributes, &v.PriceOverride, &v.CostPrice, &v.StockQuantity,
 			&v.Weight, &v.ImageURL, &v.Position, &v.Active,
 			&v.CreatedAt, &v.UpdatedAt,
 		); err != nil {
@@ -159,11 +159,11 @@ func (r *VariantRepository) FindByEAN(ctx context.Context, tx pgx.Tx, ean string
 
 func (r *VariantRepository) Create(ctx context.Context, tx pgx.Tx, variant *model.ProductVariant) error {
 	return tx.QueryRow(ctx,
-		`INSERT INTO product_variants (id, tenant_id, product_id, sku, ean, name, attributes, price_override, stock_quantity, weight, image_url, position, active)
-		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		`INSERT INTO product_variants (id, tenant_id, product_id, sku, ean, name, attributes, price_override, cost_price, stock_quantity, weight, image_url, position, active)
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
 		 RETURNING created_at, updated_at`,
 		variant.ID, variant.TenantID, variant.ProductID, variant.SKU, variant.EAN,
-		variant.Name, variant.Attributes, variant.PriceOverride, variant.StockQuantity,
+		variant.Name, variant.Attributes, variant.PriceOverride, variant.CostPrice, variant.StockQuantity,
 		variant.Weight, variant.ImageURL, variant.Position, variant.Active,
 	).Scan(&variant.CreatedAt, &variant.UpdatedAt)
 }
@@ -198,6 +198,11 @@ func (r *VariantRepository) Update(ctx context.Context, tx pgx.Tx, id uuid.UUID,
 		args = append(args, *req.PriceOverride)
 		argIdx++
 	}
+	if req.CostPrice != nil {
+		setClauses = append(setClauses, fmt.Sprintf("cost_price = $%d", argIdx))
+		args = append(args, *req.CostPrice)
+		argIdx++
+	}
 	if req.StockQuantity != nil {
 		setClauses = append(setClauses, fmt.Sprintf("stock_quantity = $%d", argIdx))
 		args = append(args, *req.StockQuantity)
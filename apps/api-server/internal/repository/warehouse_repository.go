@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -229,8 +230,21 @@ func (r *WarehouseStockRepository) ListByProduct(ctx context.Context, tx pgx.Tx,
 	return stocks, rows.Err()
 }
 
-func (r *WarehouseStockRepository) Upsert(ctx context.Context, tx pgx.Tx, stock *model.WarehouseStock) error {
-	return tx.QueryRow(ctx,
+// Upsert sets a stock entry and records the change of quantity in the stock
+// movement ledger.
+func (r *WarehouseStockRepository) Upsert(ctx context.Context, tx pgx.Tx, stock *model.WarehouseStock, src model.StockMovementSource) error {
+	var before int
+	err := tx.QueryRow(ctx,
+		`SELECT quantity FROM warehouse_stock
+		 WHERE warehouse_id = $1 AND product_id = $2 AND variant_id IS NOT DISTINCT FROM $3
+		 FOR UPDATE`,
+		stock.WarehouseID, stock.ProductID, stock.VariantID,
+	).Scan(&before)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("lock warehouse stock: %w", err)
+	}
+
+	err = tx.QueryRow(ctx,
 		`INSERT INTO warehouse_stock (id, tenant_id, warehouse_id, product_id, variant_id, quantity, reserved, min_stock)
 		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 		 ON CONFLICT (warehouse_id, product_id, variant_id)
@@ -240,31 +254,30 @@ func (r *WarehouseStockRepository) Upsert(ctx context.Context, tx pgx.Tx, stock
 		stock.ID, stock.TenantID, stock.WarehouseID, stock.ProductID,
 		stock.VariantID, stock.Quantity, stock.Reserved, stock.MinStock,
 	).Scan(&stock.ID, &stock.CreatedAt, &stock.UpdatedAt)
+	if err != nil {
+		return err
+	}
+
+	if stock.Quantity == before {
+		return nil
+	}
+	return insertStockMovement(ctx, tx, model.NewStockMovement(stock, before, src))
 }
 
-func (r *WarehouseStockRepository) AdjustQuantity(ctx context.Context, tx pgx.Tx, warehouseID, productID uuid.UUID, variantID *uuid.UUID, delta int) error {
-	if variantID != nil {
-		_, err := tx.Exec(ctx,
-			`INSERT INTO warehouse_stock (id, tenant_id, warehouse_id, product_id, variant_id, quantity)
-			 VALUES (uuid_generate_v4(), current_setting('app.current_tenant_id')::uuid, $1, $2, $3, $4)
-			 ON CONFLICT (warehouse_id, product_id, variant_id)
-			 DO UPDATE SET quantity = warehouse_stock.quantity + EXCLUDED.quantity, updated_at = NOW()`,
-			warehouseID, productID, *variantID, delta,
-		)
-		if err != nil {
-			return fmt.Errorf("adjust stock quantity: %w", err)
-		}
-	} else {
-		_, err := tx.Exec(ctx,
-			`INSERT INTO warehouse_stock (id, tenant_id, warehouse_id, product_id, variant_id, quantity)
-			 VALUES (uuid_generate_v4(), current_setting('app.current_tenant_id')::uuid, $1, $2, NULL, $3)
-			 ON CONFLICT (warehouse_id, product_id, variant_id)
-			 DO UPDATE SET quantity = warehouse_stock.quantity + EXCLUDED.quantity, updated_at = NOW()`,
-			warehouseID, productID, delta,
-		)
-		if err != nil {
-			return fmt.Errorf("adjust stock quantity: %w", err)
-		}
+// AdjustQuantity changes a stock quantity by delta, creating the entry if
+// needed, and records the change in the stock movement ledger.
+func (r *WarehouseStockRepository) AdjustQuantity(ctx context.Context, tx pgx.Tx, warehouseID, productID uuid.UUID, variantID *uuid.UUID, delta int, src model.StockMovementSource) error {
+	stock := model.WarehouseStock{WarehouseID: warehouseID, ProductID: productID, VariantID: variantID}
+	err := tx.QueryRow(ctx,
+		`INSERT INTO warehouse_stock (id, tenant_id, warehouse_id, product_id, variant_id, quantity)
+		 VALUES (uuid_generate_v4(), current_setting('app.current_tenant_id')::uuid, $1, $2, $3, $4)
+		 ON CONFLICT (warehouse_id, product_id, variant_id)
+		 DO UPDATE SET quantity = warehouse_stock.quantity + EXCLUDED.quantity, updated_at = NOW()
+		 RETURNING tenant_id, quantity`,
+		warehouseID, productID, variantID, delta,
+	).Scan(&stock.TenantID, &stock.Quantity)
+	if err != nil {
+		return fmt.Errorf("adjust stock quantity: %w", err)
 	}
-	return nil
+	return insertStockMovement(ctx, tx, model.NewStockMovement(&stock, stock.Quantity-delta, src))
 }
@@ -23,11 +23,14 @@ type RouterDeps struct {
 	Auth              *handler.AuthHandler
 	User              *handler.UserHandler
 	Order             *handler.OrderHandler
+	OrderView         *handler.OrderViewHandler
+	BulkJob           *handler.BulkJobHandler
 	Shipment          *handler.ShipmentHandler
 	Product           *handler.ProductHandler
 	Integration       *handler.IntegrationHandler
 	Webhook           *handler.WebhookHandler
 	Stats             *handler.StatsHandler
+	ScheduledReport   *handler.ScheduledReportHandler
 	Upload            *handler.UploadHandler
 	Settings          *handler.SettingsHandler
 	Audit             *handler.AuditHandler
@@ -37,8 +40,13 @@ type RouterDeps struct {
 	AllegroAuth       *handler.AllegroAuthHandler
 	Allegro           *handler.AllegroHandler
 	AllegroShipment   *handler.AllegroShipmentHandler
+	Shopify           *handler.ShopifyHandler
+	PrestaShop        *handler.PrestaShopHandler
+	CatalogImport     *handler.CatalogImportHandler
 	AmazonAuth        *handler.AmazonAuthHandler
 	Supplier          *handler.SupplierHandler
+	Dropship          *handler.DropshipHandler
+	OrderHold         *handler.OrderHoldHandler
 	Invoice           *handler.InvoiceHandler
 	Automation        *handler.AutomationHandler
 	Import            *handler.ImportHandler
@@ -54,6 +62,7 @@ type RouterDeps struct {
 	Barcode           *handler.BarcodeHandler
 	PriceList         *handler.PriceListHandler
 	WarehouseDocument *handler.WarehouseDocumentHandler
+	InventoryCosting  *handler.InventoryCostingHandler
 	WS                *handler.WSHandler
 	AI                *handler.AIHandler
 	Marketing         *handler.MarketingHandler
@@ -155,6 +164,14 @@ func New(deps RouterDeps) *chi.Mux {
 		r.Get("/{token}/status", deps.PublicReturn.GetStatusByToken)
 	})
 
+	// Public supplier order routes — no JWT, token-authenticated, rate-limited
+	r.Route("/v1/public/supplier-orders", func(r chi.Router) {
+		r.Use(middleware.RateLimit(30, 1*time.Minute))
+		r.Use(middleware.MaxBodySize(1 << 20))
+		r.Get("/{token}", deps.Dropship.PublicGet)
+		r.Post("/{token}/tracking", deps.Dropship.PublicRecordTracking)
+	})
+
 	// WebSocket endpoint — auth via query param, must be before JWT middleware
 	r.Get("/v1/ws", deps.WS.ServeWS)
 
@@ -198,6 +215,14 @@ func New(deps RouterDeps) *chi.Mux {
 				r.Post("/sms/test", deps.Settings.SendTestSMS)
 				r.Get("/inventory", deps.Settings.GetInventorySettings)
 				r.Put("/inventory", deps.Settings.UpdateInventorySettings)
+				r.Get("/returns", deps.Settings.GetReturnSettings)
+				r.Put("/returns", deps.Settings.UpdateReturnSettings)
+				r.Get("/dropshipping", deps.Settings.GetDropshipSettings)
+				r.Put("/dropshipping", deps.Settings.UpdateDropshipSettings)
+				r.Get("/order-holds", deps.Settings.GetOrderHoldSettings)
+				r.Put("/order-holds", deps.Settings.UpdateOrderHoldSettings)
+				r.Get("/order-risk", deps.Settings.GetOrderRiskSettings)
+				r.Put("/order-risk", deps.Settings.UpdateOrderRiskSettings)
 				r.Get("/print-templates", deps.Print.GetPrintTemplates)
 				r.Put("/print-templates", deps.Print.UpdatePrintTemplates)
 				r.Get("/ksef", deps.KSeF.GetSettings)
@@ -240,7 +265,14 @@ func New(deps RouterDeps) *chi.Mux {
 				r.Get("/", deps.Order.List)
 				r.Post("/", deps.Order.Create)
 				r.Get("/export", deps.Order.ExportCSV)
+				r.Get("/views", deps.OrderView.List)
+				r.Post("/views", deps.OrderView.Create)
+				r.Patch("/views/{viewId}", deps.OrderView.Update)
+				r.Delete("/views/{viewId}", deps.OrderView.Delete)
 				r.Post("/bulk-status", deps.Order.BulkTransitionStatus)
+				r.Get("/bulk-jobs", deps.BulkJob.List)
+				r.Post("/bulk-jobs", deps.BulkJob.Create)
+				r.Get("/bulk-jobs/{jobId}", deps.BulkJob.Get)
 				r.Post("/merge", deps.OrderGroup.MergeOrders)
 				r.Post("/import/preview", deps.Import.Preview)
 				r.Post("/import", deps.Import.Import)
@@ -252,14 +284,23 @@ func New(deps RouterDeps) *chi.Mux {
 				r.Post("/{id}/split", deps.OrderGroup.SplitOrder)
 				r.Get("/{id}/groups", deps.OrderGroup.ListByOrder)
 				r.Get("/{id}/audit", deps.Order.GetAudit)
+				r.Get("/{id}/timeline", deps.Order.GetTimeline)
+				r.Get("/{id}/holds", deps.OrderHold.ListForOrder)
+				r.Post("/{id}/holds", deps.OrderHold.Place)
+				r.Post("/{id}/holds/{holdId}/release", deps.OrderHold.Release)
 				r.Get("/{id}/invoices", deps.Invoice.ListByOrder)
 				r.Get("/{id}/packing-slip", deps.Print.GetPackingSlip)
 				r.Get("/{id}/print", deps.Print.GetOrderSummary)
 				r.Post("/{id}/pack", deps.Barcode.PackOrder)
 				r.Get("/{id}/tickets", deps.Helpdesk.ListOrderTickets)
 				r.Post("/{id}/tickets", deps.Helpdesk.CreateOrderTicket)
+				r.Get("/{id}/supplier-orders", deps.Dropship.ListForOrder)
+				r.Post("/{id}/dropship", deps.Dropship.RouteOrder)
 			})
 
+			// Blocked-order queue — any authenticated user
+			r.Get("/order-holds", deps.OrderHold.List)
+
 			// Invoices — any authenticated user
 			r.Route("/invoices", func(r chi.Router) {
 				r.Get("/", deps.Invoice.List)
@@ -298,6 +339,10 @@ func New(deps RouterDeps) *chi.Mux {
 					r.Patch("/", deps.Return.Update)
 					r.Delete("/", deps.Return.Delete)
 					r.Post("/status", deps.Return.TransitionStatus)
+					r.Post("/receive", deps.Return.Receive)
+					r.Post("/refund", deps.Return.Refund)
+					r.Post("/exchange", deps.Return.Exchange)
+					r.Post("/label", deps.Return.CreateLabel)
 					r.Get("/print", deps.Print.GetReturnSlip)
 				})
 			})
@@ -313,6 +358,16 @@ func New(deps RouterDeps) *chi.Mux {
 				r.Patch("/{id}", deps.Product.Update)
 				r.Delete("/{id}", deps.Product.Delete)
 				r.Get("/{id}/stock", deps.Warehouse.ListProductStock)
+				r.Get("/{id}/stock-movements", deps.Warehouse.ListStockMovements)
+				r.Get("/{id}/stock-at", deps.Warehouse.GetStockAt)
+
+				// Fulfilment rule — admin only
+				r.Route("/{id}/fulfillment-rule", func(r chi.Router) {
+					r.Use(middleware.RequireRole("admin"))
+					r.Get("/", deps.Dropship.GetRule)
+					r.Put("/", deps.Dropship.SetRule)
+					r.Delete("/", deps.Dropship.DeleteRule)
+				})
 
 				// Bundles
 				r.Route("/{id}/bundle", func(r chi.Router) {
@@ -338,6 +393,7 @@ func New(deps RouterDeps) *chi.Mux {
 						r.Use(middleware.RequireRole("admin"))
 						r.Get("/", deps.AllegroListings.ListByProduct)
 						r.Post("/allegro", deps.AllegroListings.CreateListing)
+						r.Post("/allegro/variants", deps.AllegroListings.CreateVariantListings)
 						r.Get("/{listingId}", deps.AllegroListings.GetListing)
 						r.Patch("/{listingId}", deps.AllegroListings.UpdateListing)
 						r.Delete("/{listingId}", deps.AllegroListings.DeleteListing)
@@ -469,6 +525,22 @@ func New(deps RouterDeps) *chi.Mux {
 
 				})
 
+				// Shopify fulfillment + locations
+				if deps.Shopify != nil {
+					r.Route("/shopify", func(r chi.Router) {
+						r.Get("/locations", deps.Shopify.ListLocations)
+						r.Post("/orders/{orderId}/fulfillment", deps.Shopify.CreateFulfillment)
+					})
+				}
+
+				// PrestaShop order states
+				if deps.PrestaShop != nil {
+					r.Route("/prestashop", func(r chi.Router) {
+						r.Get("/order-states", deps.PrestaShop.ListOrderStates)
+						r.Post("/orders/{orderId}/state", deps.PrestaShop.UpdateOrderState)
+					})
+				}
+
 				// Amazon SP-API setup
 				r.Post("/amazon/setup", deps.AmazonAuth.Setup)
 
@@ -477,6 +549,11 @@ func New(deps RouterDeps) *chi.Mux {
 				r.Get("/{id}", deps.Integration.Get)
 				r.Patch("/{id}", deps.Integration.Update)
 				r.Delete("/{id}", deps.Integration.Delete)
+
+				// Catalog import (progress via /v1/sync-jobs)
+				if deps.CatalogImport != nil {
+					r.Post("/{id}/catalog-import", deps.CatalogImport.Start)
+				}
 			})
 
 			// Suppliers — admin only
@@ -488,10 +565,22 @@ func New(deps RouterDeps) *chi.Mux {
 				r.Patch("/{id}", deps.Supplier.Update)
 				r.Delete("/{id}", deps.Supplier.Delete)
 				r.Post("/{id}/sync", deps.Supplier.Sync)
+				r.Post("/{id}/feed/preview", deps.Supplier.PreviewFeed)
 				r.Get("/{id}/products", deps.Supplier.ListProducts)
 				r.Post("/{id}/products/{spid}/link", deps.Supplier.LinkProduct)
 			})
 
+			// Dropshipping — admin only
+			r.With(middleware.RequireRole("admin")).Get("/fulfillment-rules", deps.Dropship.ListRules)
+			r.Route("/supplier-orders", func(r chi.Router) {
+				r.Use(middleware.RequireRole("admin"))
+				r.Get("/", deps.Dropship.List)
+				r.Get("/{id}", deps.Dropship.Get)
+				r.Post("/{id}/send", deps.Dropship.Resend)
+				r.Post("/{id}/tracking", deps.Dropship.RecordTracking)
+				r.Post("/{id}/cancel", deps.Dropship.Cancel)
+			})
+
 			// Warehouses — admin only
 			r.Route("/warehouses", func(r chi.Router) {
 				r.Use(middleware.RequireRole("admin"))
@@ -518,6 +607,8 @@ func New(deps RouterDeps) *chi.Mux {
 			r.Route("/automation", func(r chi.Router) {
 				r.Use(middleware.RequireRole("admin"))
 				r.Get("/delayed", deps.Automation.ListDelayed)
+				r.Get("/events", deps.Automation.ListEvents)
+				r.Post("/events/{id}/retry", deps.Automation.RetryEvent)
 				r.Route("/rules", func(r chi.Router) {
 					r.Get("/", deps.Automation.List)
 					r.Post("/", deps.Automation.Create)
@@ -536,6 +627,21 @@ func New(deps RouterDeps) *chi.Mux {
 				r.Get("/revenue/by-source", deps.Stats.GetRevenueBySource)
 				r.Get("/trends", deps.Stats.GetOrderTrends)
 				r.Get("/payment-methods", deps.Stats.GetPaymentMethodStats)
+				// Purchase costs and margins are admin-only
+				r.With(middleware.RequireRole("admin")).Get("/profitability", deps.Stats.GetProfitability)
+				r.With(middleware.RequireRole("admin")).Get("/profitability/export", deps.Stats.ExportProfitabilityCSV)
+			})
+
+			// Scheduled email reports — admin only
+			r.Route("/scheduled-reports", func(r chi.Router) {
+				r.Use(middleware.RequireRole("admin"))
+				r.Get("/", deps.ScheduledReport.List)
+				r.Post("/", deps.ScheduledReport.Create)
+				r.Get("/{reportId}", deps.ScheduledReport.Get)
+				r.Patch("/{reportId}", deps.ScheduledReport.Update)
+				r.Delete("/{reportId}", deps.ScheduledReport.Delete)
+				r.Post("/{reportId}/run", deps.ScheduledReport.Run)
+				r.Get("/{reportId}/runs", deps.ScheduledReport.ListRuns)
 			})
 
 			// Barcode lookup — any authenticated user
@@ -566,6 +672,15 @@ func New(deps RouterDeps) *chi.Mux {
 				r.Post("/{id}/cancel", deps.WarehouseDocument.Cancel)
 			})
 
+			// Inventory valuation and cost of goods sold — admin only
+			r.Route("/inventory", func(r chi.Router) {
+				r.Use(middleware.RequireRole("admin"))
+				r.Get("/valuation", deps.InventoryCosting.GetValuation)
+				r.Get("/valuation/export", deps.InventoryCosting.ExportValuationCSV)
+				r.Get("/cogs", deps.InventoryCosting.GetCOGS)
+				r.Get("/cogs/export", deps.InventoryCosting.ExportCOGSCSV)
+			})
+
 			// Stocktakes (inventory counting) — admin only
 			r.Route("/stocktakes", func(r chi.Router) {
 				r.Use(middleware.RequireRole("admin"))
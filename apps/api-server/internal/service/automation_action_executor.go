@@ -0,0 +1,486 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/openoms-org/openoms/apps/api-server/internal/automation"
+	"github.com/openoms-org/openoms/apps/api-server/internal/crypto"
+	"github.com/openoms-org/openoms/apps/api-server/internal/database"
+	"github.com/openoms-org/openoms/apps/api-server/internal/integration"
+	"github.com/openoms-org/openoms/apps/api-server/internal/model"
+	"github.com/openoms-org/openoms/apps/api-server/internal/repository"
+	engine "github.com/openoms-org/openoms/packages/order-engine"
+)
+
+// ErrAutomationNoOrder is returned when an order action runs for an event
+// that has no order.
+var ErrAutomationNoOrder = errors.New("event has no order")
+
+// AutomationActionExecutor executes automation actions through the service
+// layer. Template parameters are rendered against the event data and the
+// event's order (as {{order.field}}) before the action runs. Action types it
+// does not handle go to the fallback executor.
+//
+// Order changes made here are written directly and audited without a user,
+// and do not enqueue automation events, so a rule cannot trigger itself.
+type AutomationActionExecutor struct {
+	pool             *pgxpool.Pool
+	orderRepo        repository.OrderRepo
+	userRepo         repository.UserRepo
+	shipmentRepo     repository.ShipmentRepo
+	integrationRepo  repository.IntegrationRepo
+	auditRepo        repository.AuditRepo
+	fallback         automation.ActionExecutor
+	encryptionKey    []byte
+	shipmentService  shipmentCreator
+	labelService     labelGenerator
+	smsService       *SMSService
+	freshdeskService *FreshdeskService
+	orderGroups      *OrderGroupService
+	orderEventRepo   repository.OrderEventRepo
+	holdService      *OrderHoldService
+	logger           *slog.Logger
+}
+
+func NewAutomationActionExecutor(
+	pool *pgxpool.Pool,
+	orderRepo repository.OrderRepo,
+	userRepo repository.UserRepo,
+	shipmentRepo repository.ShipmentRepo,
+	integrationRepo repository.IntegrationRepo,
+	auditRepo repository.AuditRepo,
+	fallback automation.ActionExecutor,
+	encryptionKey []byte,
+	logger *slog.Logger,
+) *AutomationActionExecutor {
+	return &AutomationActionExecutor{
+		pool:            pool,
+		orderRepo:       orderRepo,
+		userRepo:        userRepo,
+		shipmentRepo:    shipmentRepo,
+		integrationRepo: integrationRepo,
+		auditRepo:       auditRepo,
+		fallback:        fallback,
+		encryptionKey:   encryptionKey,
+		logger:          logger,
+	}
+}
+
+// shipmentCreator creates shipments for create_shipment. It is implemented
+// by ShipmentService.
+type shipmentCreator interface {
+	Create(ctx context.Context, tenantID uuid.UUID, req model.CreateShipmentRequest, actorID uuid.UUID, ip string) (*model.Shipment, error)
+}
+
+// labelGenerator generates shipment labels for create_shipment. It is
+// implemented by LabelService.
+type labelGenerator interface {
+	GenerateLabel(ctx context.Context, tenantID, shipmentID uuid.UUID, req model.GenerateLabelRequest, actorID uuid.UUID, ip string) (*model.Shipment, error)
+}
+
+// SetShipmentServices sets the services used by create_shipment.
+func (e *AutomationActionExecutor) SetShipmentServices(shipmentSvc *ShipmentService, labelSvc *LabelService) {
+	if shipmentSvc != nil {
+		e.shipmentService = shipmentSvc
+	}
+	if labelSvc != nil {
+		e.labelService = labelSvc
+	}
+}
+
+// SetSMSService sets the service used by send_sms.
+func (e *AutomationActionExecutor) SetSMSService(smsSvc *SMSService) {
+	e.smsService = smsSvc
+}
+
+// SetFreshdeskService sets the service used by create_freshdesk_ticket.
+func (e *AutomationActionExecutor) SetFreshdeskService(freshdeskSvc *FreshdeskService) {
+	e.freshdeskService = freshdeskSvc
+}
+
+// SetOrderGroupService sets the service used by split_order_by_warehouse.
+func (e *AutomationActionExecutor) SetOrderGroupService(orderGroupSvc *OrderGroupService) {
+	e.orderGroups = orderGroupSvc
+}
+
+// SetOrderEventRepo sets the repository that records buyer messages on the
+// order timeline.
+func (e *AutomationActionExecutor) SetOrderEventRepo(orderEventRepo repository.OrderEventRepo) {
+	e.orderEventRepo = orderEventRepo
+}
+
+// SetOrderHoldService sets the service used by place_hold and release_hold.
+func (e *AutomationActionExecutor) SetOrderHoldService(holdSvc *OrderHoldService) {
+	e.holdService = holdSvc
+}
+
+func (e *AutomationActionExecutor) ExecuteAction(ctx context.Context, tenantID uuid.UUID, action automation.Action, event automation.Event) error {
+	spec, ok := automation.ActionCatalog[action.Type]
+	if !ok {
+		return fmt.Errorf("unknown action type: %s", action.Type)
+	}
+
+	data := make(map[string]any, len(event.Data)+1)
+	for k, v := range event.Data {
+		data[k] = v
+	}
+
+	var order *model.Order
+	if spec.OrderAction || hasTemplateParams(action) {
+		var err error
+		order, err = e.eventOrder(ctx, tenantID, event)
+		if err != nil {
+			return err
+		}
+		if order != nil {
+			if _, ok := data["order"]; !ok {
+				data["order"] = orderTemplateData(order)
+			}
+		}
+	}
+	if spec.OrderAction && order == nil {
+		return ErrAutomationNoOrder
+	}
+
+	params, missing := automation.RenderParams(action, data, event.EntityType)
+	if len(missing) > 0 {
+		e.logger.Warn("automation action has empty placeholders",
+			"tenant_id", tenantID, "action_type", action.Type, "missing", missing)
+	}
+	action.Params = params
+	if err := automation.ValidateAction(action); err != nil {
+		return err
+	}
+
+	switch action.Type {
+	case "assign_user":
+		return e.assignUser(ctx, tenantID, order, params)
+	case "set_priority":
+		return e.setPriority(ctx, tenantID, order, params)
+	case "create_shipment":
+		return e.createShipment(ctx, tenantID, order, params)
+	case "send_sms":
+		return e.sendSMS(ctx, tenantID, order, params)
+	case "create_freshdesk_ticket":
+		return e.createFreshdeskTicket(ctx, tenantID, order, params)
+	case "split_order_by_warehouse":
+		return e.splitByWarehouse(ctx, tenantID, order)
+	case "add_internal_note":
+		return e.addInternalNote(ctx, tenantID, order, params)
+	case "allegro_message_buyer":
+		return e.messageBuyer(ctx, tenantID, order, params)
+	case "place_hold", "release_hold":
+		return e.hold(ctx, tenantID, order, action.Type, params)
+	default:
+		return e.fallback.ExecuteAction(ctx, tenantID, action, event)
+	}
+}
+
+// eventOrder loads the order of an order event, or the order_id of a
+// shipment or return event. It returns nil when the event has no order.
+func (e *AutomationActionExecutor) eventOrder(ctx context.Context, tenantID uuid.UUID, event automation.Event) (*model.Order, error) {
+	orderID := uuid.Nil
+	if event.EntityType == "order" {
+		orderID = event.EntityID
+	} else if s, ok := event.Data["order_id"].(string); ok {
+		orderID, _ = uuid.Parse(s)
+	}
+	if orderID == uuid.Nil {
+		return nil, nil
+	}
+
+	var order *model.Order
+	err := database.WithTenant(ctx, e.pool, tenantID, func(tx pgx.Tx) error {
+		var err error
+		order, err = e.orderRepo.FindByID(ctx, tx, orderID)
+		return err
+	})
+	return order, err
+}
+
+func (e *AutomationActionExecutor) assignUser(ctx context.Context, tenantID uuid.UUID, order *model.Order, params map[string]any) error {
+	userID, err := uuid.Parse(automation.ParamValue(params, "user_id"))
+	if err != nil {
+		return NewValidationError(errors.New("user_id must be a UUID"))
+	}
+	return database.WithTenant(ctx, e.pool, tenantID, func(tx pgx.Tx) error {
+		user, err := e.userRepo.FindByID(ctx, tx, userID)
+		if err != nil {
+			return err
+		}
+		if user == nil {
+			return NewValidationError(errors.New("user not found"))
+		}
+		if err := e.orderRepo.Update(ctx, tx, order.ID, model.UpdateOrderRequest{AssignedTo: &userID}); err != nil {
+			return err
+		}
+		return e.auditOrder(ctx, tx, tenantID, order.ID, "order.assigned", map[string]string{"assigned_to": userID.String()})
+	})
+}
+
+func (e *AutomationActionExecutor) setPriority(ctx context.Context, tenantID uuid.UUID, order *model.Order, params map[string]any) error {
+	priority := automation.ParamValue(params, "priority")
+	if priority == order.Priority {
+		return nil
+	}
+	return database.WithTenant(ctx, e.pool, tenantID, func(tx pgx.Tx) error {
+		if err := e.orderRepo.Update(ctx, tx, order.ID, model.UpdateOrderRequest{Priority: &priority}); err != nil {
+			return err
+		}
+		return e.auditOrder(ctx, tx, tenantID, order.ID, "order.updated", map[string]string{"priority": priority})
+	})
+}
+
+func (e *AutomationActionExecutor) addInternalNote(ctx context.Context, tenantID uuid.UUID, order *model.Order, params map[string]any) error {
+	note := model.StripHTMLTags(automation.ParamValue(params, "note"))
+	return database.WithTenant(ctx, e.pool, tenantID, func(tx pgx.Tx) error {
+		// Re-read the notes in this transaction so concurrent notes are kept.
+		current, err := e.orderRepo.FindByID(ctx, tx, order.ID)
+		if err != nil {
+			return err
+		}
+		if current == nil {
+			return ErrOrderNotFound
+		}
+		notes := note
+		if current.InternalNotes != "" {
+			notes = current.InternalNotes + "\n" + note
+		}
+		if err := e.orderRepo.Update(ctx, tx, order.ID, model.UpdateOrderRequest{InternalNotes: &notes}); err != nil {
+			return err
+		}
+		return e.auditOrder(ctx, tx, tenantID, order.ID, "order.note_added", map[string]string{"note": note})
+	})
+}
+
+// createShipment creates a shipment for the order with the provider and
+// optionally generates its label. It does nothing when the order already has
+// a shipment with that provider, so repeated events create one shipment.
+func (e *AutomationActionExecutor) createShipment(ctx context.Context, tenantID uuid.UUID, order *model.Order, params map[string]any) error {
+	if e.shipmentService == nil {
+		return errors.New("shipments are not configured")
+	}
+	provider := automation.ParamValue(params, "provider")
+
+	var existing []model.Shipment
+	err := database.WithTenant(ctx, e.pool, tenantID, func(tx pgx.Tx) error {
+		var err error
+		existing, _, err = e.shipmentRepo.List(ctx, tx, model.ShipmentListFilter{
+			OrderID:          &order.ID,
+			Provider:         &provider,
+			PaginationParams: model.PaginationParams{Limit: 10},
+		})
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	return e.shipOrder(ctx, tenantID, order, params, existing)
+}
+
+// shipOrder creates the shipment unless one of the order's existing shipments
+// with the provider is live, then generates the label when requested. A live
+// shipment still without a label, left by a label failure on an earlier run,
+// gets its label generated instead of a second shipment.
+func (e *AutomationActionExecutor) shipOrder(ctx context.Context, tenantID uuid.UUID, order *model.Order, params map[string]any, existing []model.Shipment) error {
+	generate, _ := params["generate_label"].(bool)
+
+	var shipment *model.Shipment
+	for i := range existing {
+		if existing[i].Status != "cancelled" {
+			shipment = &existing[i]
+			break
+		}
+	}
+	if shipment != nil && (!generate || shipment.LabelURL != nil || shipment.Status != "created") {
+		e.logger.Info("automation create_shipment: order already has a shipment",
+			"tenant_id", tenantID, "order_id", order.ID, "shipment_id", shipment.ID)
+		return nil
+	}
+
+	if shipment == nil {
+		var err error
+		shipment, err = e.shipmentService.Create(ctx, tenantID, model.CreateShipmentRequest{
+			OrderID:  order.ID,
+			Provider: automation.ParamValue(params, "provider"),
+		}, uuid.Nil, "")
+		if err != nil {
+			return fmt.Errorf("create shipment: %w", err)
+		}
+	}
+
+	if !generate {
+		return nil
+	}
+	if e.labelService == nil {
+		return errors.New("labels are not configured")
+	}
+	format := automation.ParamValue(params, "label_format")
+	if format == "" {
+		format = "pdf"
+	}
+	_, err := e.labelService.GenerateLabel(ctx, tenantID, shipment.ID, model.GenerateLabelRequest{
+		ServiceType: automation.ParamValue(params, "service_type"),
+		ParcelSize:  automation.ParamValue(params, "parcel_size"),
+		TargetPoint: stringOrEmpty(order.PickupPointID),
+		LabelFormat: format,
+	}, uuid.Nil, "")
+	if err != nil {
+		return fmt.Errorf("generate label: %w", err)
+	}
+	return nil
+}
+
+func (e *AutomationActionExecutor) sendSMS(ctx context.Context, tenantID uuid.UUID, order *model.Order, params map[string]any) error {
+	if e.smsService == nil {
+		return ErrSMSNotConfigured
+	}
+	phone := automation.ParamValue(params, "phone")
+	if phone == "" {
+		phone = strings.TrimSpace(stringOrEmpty(order.CustomerPhone))
+	}
+	if phone == "" {
+		return errors.New("order has no customer phone")
+	}
+	return e.smsService.SendOrderSMS(ctx, tenantID, order.ID, phone, automation.ParamValue(params, "message"))
+}
+
+func (e *AutomationActionExecutor) createFreshdeskTicket(ctx context.Context, tenantID uuid.UUID, order *model.Order, params map[string]any) error {
+	if e.freshdeskService == nil {
+		return ErrFreshdeskNotConfigured
+	}
+	email := automation.ParamValue(params, "email")
+	if email == "" {
+		email = stringOrEmpty(order.CustomerEmail)
+	}
+	_, err := e.freshdeskService.CreateTicket(ctx, tenantID, order.ID,
+		automation.ParamValue(params, "subject"), automation.ParamValue(params, "description"), email)
+	return err
+}
+
+func (e *AutomationActionExecutor) splitByWarehouse(ctx context.Context, tenantID uuid.UUID, order *model.Order) error {
+	if e.orderGroups == nil {
+		return errors.New("order splitting is not configured")
+	}
+	group, err := e.orderGroups.SplitByWarehouse(ctx, tenantID, uuid.Nil, order.ID)
+	if err != nil {
+		return err
+	}
+	if group == nil {
+		e.logger.Info("automation split_order_by_warehouse: order ships from one warehouse",
+			"tenant_id", tenantID, "order_id", order.ID)
+	}
+	return nil
+}
+
+// messageBuyer sends a message to the buyer through the order's marketplace
+// integration, which must support buyer messages.
+func (e *AutomationActionExecutor) messageBuyer(ctx context.Context, tenantID uuid.UUID, order *model.Order, params map[string]any) error {
+	if order.IntegrationID == nil || order.ExternalID == nil {
+		return errors.New("order is not from a marketplace")
+	}
+
+	var wc *model.IntegrationWithCreds
+	err := database.WithTenant(ctx, e.pool, tenantID, func(tx pgx.Tx) error {
+		var err error
+		wc, err = e.integrationRepo.FindByID(ctx, tx, *order.IntegrationID)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if wc == nil || wc.Status != "active" {
+		return errors.New("order integration is not active")
+	}
+	if wc.Provider != "allegro" {
+		return fmt.Errorf("order is from %s, not allegro", wc.Provider)
+	}
+
+	credJSON, err := crypto.Decrypt(wc.EncryptedCredentials, e.encryptionKey)
+	if err != nil {
+		return fmt.Errorf("decrypt credentials: %w", err)
+	}
+	provider, err := integration.NewMarketplaceProvider(wc.Provider, credJSON, wc.Settings)
+	if err != nil {
+		return err
+	}
+	messenger, ok := provider.(integration.BuyerMessenger)
+	if !ok {
+		return fmt.Errorf("%s does not support buyer messages", wc.Provider)
+	}
+	if err := messenger.MessageBuyer(ctx, *order.ExternalID, automation.ParamValue(params, "message")); err != nil {
+		return fmt.Errorf("message buyer: %w", err)
+	}
+
+	return database.WithTenant(ctx, e.pool, tenantID, func(tx pgx.Tx) error {
+		if err := recordOrderEvent(ctx, tx, e.orderEventRepo, &model.OrderEvent{
+			TenantID:  tenantID,
+			OrderID:   order.ID,
+			Source:    model.TimelineSourceMarketplace,
+			EventType: model.OrderEventBuyerMessaged,
+			Data:      map[string]string{"provider": wc.Provider, "source": "automation"},
+		}); err != nil {
+			return err
+		}
+		return e.auditOrder(ctx, tx, tenantID, order.ID, "order.buyer_messaged", map[string]string{"provider": wc.Provider})
+	})
+}
+
+// hold places or releases the order hold with the reason. Placing a hold the
+// order already has and releasing one it does not have do nothing.
+func (e *AutomationActionExecutor) hold(ctx context.Context, tenantID uuid.UUID, order *model.Order, actionType string, params map[string]any) error {
+	if e.holdService == nil {
+		return errors.New("order holds are not configured")
+	}
+	reason := engine.HoldReason(automation.ParamValue(params, "reason"))
+	note := automation.ParamValue(params, "note")
+	if actionType == "place_hold" {
+		return e.holdService.PlaceByAutomation(ctx, tenantID, order.ID, reason, note)
+	}
+	return e.holdService.ReleaseByAutomation(ctx, tenantID, order.ID, reason, note)
+}
+
+func (e *AutomationActionExecutor) auditOrder(ctx context.Context, tx pgx.Tx, tenantID, orderID uuid.UUID, action string, changes map[string]string) error {
+	changes["source"] = "automation"
+	return e.auditRepo.Log(ctx, tx, model.AuditEntry{
+		TenantID:   tenantID,
+		Action:     action,
+		EntityType: "order",
+		EntityID:   orderID,
+		Changes:    changes,
+	})
+}
+
+// hasTemplateParams reports whether an action has template parameters that
+// may refer to the order.
+func hasTemplateParams(action automation.Action) bool {
+	for _, p := range automation.ActionCatalog[action.Type].Params {
+		if s, ok := action.Params[p.Name].(string); ok && p.Template && automation.HasPlaceholders(s) {
+			return true
+		}
+	}
+	return false
+}
+
+// orderTemplateData returns the order as template data, with the fields
+// named as in the API.
+func orderTemplateData(order *model.Order) map[string]any {
+	b, err := json.Marshal(order)
+	if err != nil {
+		return nil
+	}
+	var data map[string]any
+	if err := json.Unmarshal(b, &data); err != nil {
+		return nil
+	}
+	return data
+}
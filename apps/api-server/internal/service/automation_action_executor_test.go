@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openoms-org/openoms/apps/api-server/internal/model"
+)
+
+// fakeShipments records the shipments created by create_shipment.
+type fakeShipments struct {
+	created []model.Shipment
+}
+
+func (f *fakeShipments) Create(ctx context.Context, tenantID uuid.UUID, req model.CreateShipmentRequest, actorID uuid.UUID, ip string) (*model.Shipment, error) {
+	sh := model.Shipment{ID: uuid.New(), OrderID: req.OrderID, Provider: req.Provider, Status: "created"}
+	f.created = append(f.created, sh)
+	return &sh, nil
+}
+
+// fakeLabels fails as many label requests as failures, then generates labels.
+type fakeLabels struct {
+	failures  int
+	generated []uuid.UUID
+}
+
+func (f *fakeLabels) GenerateLabel(ctx context.Context, tenantID, shipmentID uuid.UUID, req model.GenerateLabelRequest, actorID uuid.UUID, ip string) (*model.Shipment, error) {
+	if f.failures > 0 {
+		f.failures--
+		return nil, errors.New("carrier unavailable")
+	}
+	f.generated = append(f.generated, shipmentID)
+	return &model.Shipment{ID: shipmentID}, nil
+}
+
+func TestShipOrder_RetriesFailedLabel(t *testing.T) {
+	shipments := &fakeShipments{}
+	labels := &fakeLabels{failures: 1}
+	e := &AutomationActionExecutor{shipmentService: shipments, labelService: labels, logger: slog.Default()}
+	order := &model.Order{ID: uuid.New()}
+	params := map[string]any{"provider": "inpost", "generate_label": true}
+	ctx := context.Background()
+
+	err := e.shipOrder(ctx, uuid.New(), order, params, nil)
+	require.Error(t, err)
+	require.Len(t, shipments.created, 1)
+	assert.Empty(t, labels.generated)
+
+	// The retried event finds the shipment without a label and labels it.
+	err = e.shipOrder(ctx, uuid.New(), order, params, shipments.created)
+	require.NoError(t, err)
+	assert.Len(t, shipments.created, 1, "the retry should not create a second shipment")
+	assert.Equal(t, []uuid.UUID{shipments.created[0].ID}, labels.generated)
+
+	// Once labelled, a repeated event does nothing.
+	labelURL := "https://labels.example/1.pdf"
+	shipments.created[0].LabelURL = &labelURL
+	err = e.shipOrder(ctx, uuid.New(), order, params, shipments.created)
+	require.NoError(t, err)
+	assert.Len(t, shipments.created, 1)
+	assert.Len(t, labels.generated, 1)
+}
+
+func TestShipOrder_SkipsLiveShipmentWithoutLabelRequest(t *testing.T) {
+	shipments := &fakeShipments{}
+	labels := &fakeLabels{}
+	e := &AutomationActionExecutor{shipmentService: shipments, labelService: labels, logger: slog.Default()}
+	existing := []model.Shipment{{ID: uuid.New(), Status: "created"}}
+
+	err := e.shipOrder(context.Background(), uuid.New(), &model.Order{ID: uuid.New()}, map[string]any{"provider": "inpost"}, existing)
+	require.NoError(t, err)
+	assert.Empty(t, shipments.created)
+	assert.Empty(t, labels.generated)
+}
@@ -2,22 +2,53 @@ package service
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/openoms-org/openoms/apps/api-server/internal/automation"
+	"github.com/openoms-org/openoms/apps/api-server/internal/model"
 )
 
-// FireAutomationEvent is a shared helper that fires an automation event if the
-// automation service is available. This deduplicates the identical pattern used
-// by OrderService, ShipmentService, ProductService, and ReturnService.
-func FireAutomationEvent(automationSvc *AutomationService, tenantID uuid.UUID, entityType, eventType string, entityID uuid.UUID, data map[string]any) {
-	if automationSvc != nil {
-		automationSvc.ProcessEvent(context.Background(), automation.Event{
-			Type:       eventType,
-			TenantID:   tenantID,
-			EntityType: entityType,
-			EntityID:   entityID,
-			Data:       data,
-		})
+// EnqueueAutomationEvent is a shared helper that queues an automation event
+// within tx if the automation service is available. Callers run it inside the
+// transaction of the change, so the event is queued exactly when the change
+// commits. This deduplicates the identical pattern used by OrderService,
+// ShipmentService, ProductService, and ReturnService.
+func EnqueueAutomationEvent(ctx context.Context, tx pgx.Tx, automationSvc *AutomationService, tenantID uuid.UUID, entityType, eventType string, entityID uuid.UUID, data map[string]any) error {
+	if automationSvc == nil {
+		return nil
+	}
+	return automationSvc.Enqueue(ctx, tx, automation.Event{
+		Type:       eventType,
+		TenantID:   tenantID,
+		EntityType: entityType,
+		EntityID:   entityID,
+		Data:       data,
+	})
+}
+
+// eventTime formats a timestamp of automation event data, which the
+// older_than and newer_than operators compare against.
+func eventTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+// orderEventData returns the automation event data of an order, including
+// the timestamps date conditions such as "created_at older than 48h" need.
+// Callers add the fields specific to the event.
+func orderEventData(order *model.Order) map[string]any {
+	var orderedAt any
+	if order.OrderedAt != nil {
+		orderedAt = eventTime(*order.OrderedAt)
+	}
+	return map[string]any{
+		"status": order.Status, "source": order.Source,
+		"customer_name": order.CustomerName, "total_amount": order.TotalAmount,
+		"currency": order.Currency, "payment_status": order.PaymentStatus,
+		"risk_score":        riskScoreData(order),
+		"ordered_at":        orderedAt,
+		"created_at":        eventTime(order.CreatedAt),
+		"status_changed_at": eventTime(order.StatusChangedAt),
 	}
 }
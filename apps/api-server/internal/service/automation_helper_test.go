@@ -0,0 +1,61 @@
+package service
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openoms-org/openoms/apps/api-server/internal/automation"
+	"github.com/openoms-org/openoms/apps/api-server/internal/model"
+)
+
+// queuedEventData round-trips event data through JSON, as the automation
+// event queue stores it.
+func queuedEventData(t *testing.T, data map[string]any) map[string]any {
+	raw, err := json.Marshal(data)
+	require.NoError(t, err)
+	var out map[string]any
+	require.NoError(t, json.Unmarshal(raw, &out))
+	return out
+}
+
+func TestOrderEventData_DateConditions(t *testing.T) {
+	now := time.Now()
+	orderedAt := now.Add(-80 * time.Hour)
+	order := &model.Order{
+		Status:          "new",
+		OrderedAt:       &orderedAt,
+		CreatedAt:       now.Add(-72 * time.Hour),
+		StatusChangedAt: now.Add(-30 * time.Minute),
+	}
+	data := queuedEventData(t, orderEventData(order))
+
+	tests := []struct {
+		conditions string
+		want       bool
+	}{
+		{`[{"field": "created_at", "operator": "older_than", "value": "48h"}]`, true},
+		{`[{"field": "ordered_at", "operator": "older_than", "value": "3d"}]`, true},
+		{`[{"field": "created_at", "operator": "newer_than", "value": "48h"}]`, false},
+		{`{"op": "and", "conditions": [
+			{"field": "status", "operator": "eq", "value": "new"},
+			{"field": "status_changed_at", "operator": "newer_than", "value": "1h"}
+		]}`, true},
+	}
+	for _, tt := range tests {
+		root, err := automation.ParseConditions(json.RawMessage(tt.conditions))
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, root.Evaluate(data), tt.conditions)
+	}
+}
+
+func TestOrderEventData_NoOrderedAt(t *testing.T) {
+	data := queuedEventData(t, orderEventData(&model.Order{CreatedAt: time.Now()}))
+	root, err := automation.ParseConditions(json.RawMessage(`[{"field": "ordered_at", "operator": "older_than", "value": "1h"}]`))
+	require.NoError(t, err)
+	assert.False(t, root.Evaluate(data))
+	assert.Contains(t, data, "ordered_at")
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"log/slog"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -18,12 +19,16 @@ import (
 
 var (
 	ErrAutomationRuleNotFound = errors.New("automation rule not found")
+	// ErrAutomationEventNotFound is returned when retrying an event that does
+	// not exist or is not dead.
+	ErrAutomationEventNotFound = errors.New("dead automation event not found")
 )
 
 type AutomationService struct {
 	ruleRepo    repository.AutomationRuleRepo
 	logRepo     repository.AutomationRuleLogRepo
 	delayedRepo repository.DelayedActionRepo
+	eventRepo   repository.AutomationEventRepo
 	pool        *pgxpool.Pool
 	engine      *automation.Engine
 	logger      *slog.Logger
@@ -32,16 +37,18 @@ type AutomationService struct {
 func NewAutomationService(
 	ruleRepo repository.AutomationRuleRepo,
 	logRepo repository.AutomationRuleLogRepo,
+	eventRepo repository.AutomationEventRepo,
 	pool *pgxpool.Pool,
 	engine *automation.Engine,
 	logger *slog.Logger,
 ) *AutomationService {
 	return &AutomationService{
-		ruleRepo: ruleRepo,
-		logRepo:  logRepo,
-		pool:     pool,
-		engine:   engine,
-		logger:   logger,
+		ruleRepo:  ruleRepo,
+		logRepo:   logRepo,
+		eventRepo: eventRepo,
+		pool:      pool,
+		engine:    engine,
+		logger:    logger,
 	}
 }
 
@@ -91,6 +98,12 @@ func (s *AutomationService) Create(ctx context.Context, tenantID uuid.UUID, req
 	if err := req.Validate(); err != nil {
 		return nil, NewValidationError(err)
 	}
+	if err := automation.ValidateConditions(req.Conditions); err != nil {
+		return nil, NewValidationError(err)
+	}
+	if err := automation.ValidateActions(req.Actions); err != nil {
+		return nil, NewValidationError(err)
+	}
 
 	req.Name = model.StripHTMLTags(req.Name)
 	if req.Description != nil {
@@ -108,18 +121,24 @@ func (s *AutomationService) Create(ctx context.Context, tenantID uuid.UUID, req
 	}
 
 	rule := &model.AutomationRule{
-		ID:           uuid.New(),
-		TenantID:     tenantID,
-		Name:         req.Name,
-		Description:  req.Description,
-		Enabled:      enabled,
-		Priority:     priority,
-		TriggerEvent: req.TriggerEvent,
-		Conditions:   req.Conditions,
-		Actions:      req.Actions,
+		ID:            uuid.New(),
+		TenantID:      tenantID,
+		Name:          req.Name,
+		Description:   req.Description,
+		Enabled:       enabled,
+		Priority:      priority,
+		TriggerEvent:  req.TriggerEvent,
+		TriggerConfig: req.TriggerConfig,
+		Conditions:    req.Conditions,
+		Actions:       req.Actions,
 	}
+	next, err := nextScheduledRun(rule.TriggerEvent, rule.TriggerConfig, rule.Enabled)
+	if err != nil {
+		return nil, NewValidationError(err)
+	}
+	rule.NextRunAt = next
 
-	err := database.WithTenant(ctx, s.pool, tenantID, func(tx pgx.Tx) error {
+	err = database.WithTenant(ctx, s.pool, tenantID, func(tx pgx.Tx) error {
 		return s.ruleRepo.Create(ctx, tx, rule)
 	})
 	if err != nil {
@@ -132,6 +151,16 @@ func (s *AutomationService) Update(ctx context.Context, tenantID, ruleID uuid.UU
 	if err := req.Validate(); err != nil {
 		return nil, NewValidationError(err)
 	}
+	if req.Conditions != nil {
+		if err := automation.ValidateConditions(req.Conditions); err != nil {
+			return nil, NewValidationError(err)
+		}
+	}
+	if req.Actions != nil {
+		if err := automation.ValidateActions(req.Actions); err != nil {
+			return nil, NewValidationError(err)
+		}
+	}
 
 	if req.Name != nil {
 		sanitized := model.StripHTMLTags(*req.Name)
@@ -152,10 +181,40 @@ func (s *AutomationService) Update(ctx context.Context, tenantID, ruleID uuid.UU
 			return ErrAutomationRuleNotFound
 		}
 
+		// The trigger config is checked against the rule's trigger after
+		// the update, since either may change on its own.
+		triggerEvent, triggerConfig, enabled := existing.TriggerEvent, existing.TriggerConfig, existing.Enabled
+		if req.TriggerEvent != nil {
+			triggerEvent = *req.TriggerEvent
+		}
+		if req.TriggerConfig != nil {
+			triggerConfig = req.TriggerConfig
+		}
+		if req.Enabled != nil {
+			enabled = *req.Enabled
+		}
+		cfg, err := model.ParseTriggerConfig(triggerConfig)
+		if err != nil {
+			return NewValidationError(err)
+		}
+		if err := cfg.Validate(triggerEvent); err != nil {
+			return NewValidationError(err)
+		}
+
 		if err := s.ruleRepo.Update(ctx, tx, ruleID, req); err != nil {
 			return err
 		}
 
+		if req.TriggerEvent != nil || req.TriggerConfig != nil || req.Enabled != nil {
+			next, err := nextScheduledRun(triggerEvent, triggerConfig, enabled)
+			if err != nil {
+				return NewValidationError(err)
+			}
+			if err := s.ruleRepo.SetNextRun(ctx, tx, ruleID, next); err != nil {
+				return err
+			}
+		}
+
 		rule, err = s.ruleRepo.FindByID(ctx, tx, ruleID)
 		return err
 	})
@@ -219,38 +278,119 @@ func (s *AutomationService) TestRule(ctx context.Context, tenantID, ruleID uuid.
 		return nil, ErrAutomationRuleNotFound
 	}
 
-	condResults, allMet, actions := s.engine.TestRule(*rule, testData)
+	tree, allMet, actions := s.engine.TestRule(*rule, testData)
 
 	resp := &model.TestAutomationRuleResponse{
+		ConditionTree:    tree,
 		AllConditionsMet: allMet,
 		ConditionResults: []model.ConditionResult{},
 		ActionsToExecute: []model.AutomationAction{},
+		ActionResults:    []model.ActionDryRun{},
 	}
 
-	for _, cr := range condResults {
-		resp.ConditionResults = append(resp.ConditionResults, model.ConditionResult{
-			Condition: model.AutomationCondition{
-				Field:    cr.Condition.Field,
-				Operator: cr.Condition.Operator,
-				Value:    cr.Condition.Value,
-			},
-			Met: cr.Met,
-		})
+	if tree != nil {
+		resp.ConditionResults = appendConditionLeaves(resp.ConditionResults, *tree)
 	}
 
 	for _, a := range actions {
 		resp.ActionsToExecute = append(resp.ActionsToExecute, model.AutomationAction{
-			Type:   a.Type,
-			Config: a.Params,
+			Type:         a.Type,
+			Config:       a.Params,
+			DelaySeconds: a.DelaySeconds,
 		})
+		resp.ActionResults = append(resp.ActionResults, automation.DryRunAction(a, testData, automation.TriggerEntityType(rule.TriggerEvent)))
 	}
 
 	return resp, nil
 }
 
-// ProcessEvent delegates to the automation engine.
-func (s *AutomationService) ProcessEvent(ctx context.Context, event automation.Event) {
-	s.engine.ProcessEvent(ctx, event)
+// nextScheduledRun returns the first run of an enabled schedule rule, or nil
+// for other rules.
+func nextScheduledRun(triggerEvent string, triggerConfig json.RawMessage, enabled bool) (*time.Time, error) {
+	if triggerEvent != model.TriggerSchedule || !enabled {
+		return nil, nil
+	}
+	cfg, err := model.ParseTriggerConfig(triggerConfig)
+	if err != nil {
+		return nil, err
+	}
+	return cfg.NextRun(time.Now())
+}
+
+// appendConditionLeaves flattens the leaf conditions of a dry-run tree.
+func appendConditionLeaves(results []model.ConditionResult, node model.ConditionTreeResult) []model.ConditionResult {
+	if node.Op == "" {
+		return append(results, model.ConditionResult{
+			Condition: model.AutomationCondition{
+				Field:    node.Field,
+				Operator: node.Operator,
+				Value:    node.Value,
+			},
+			Met: node.Met,
+		})
+	}
+	for _, child := range node.Conditions {
+		results = appendConditionLeaves(results, child)
+	}
+	return results
+}
+
+// Enqueue adds an event to the durable queue within tx, the tenant
+// transaction of the change that caused it. AutomationEventWorker processes
+// it once the transaction commits.
+func (s *AutomationService) Enqueue(ctx context.Context, tx pgx.Tx, event automation.Event) error {
+	data, err := json.Marshal(event.Data)
+	if err != nil {
+		return err
+	}
+	if event.Data == nil {
+		data = []byte("{}")
+	}
+	return s.eventRepo.Enqueue(ctx, tx, &model.AutomationEvent{
+		TenantID:   event.TenantID,
+		EventType:  event.Type,
+		EntityType: event.EntityType,
+		EntityID:   event.EntityID,
+		Data:       data,
+	})
+}
+
+// ListEvents returns queued automation events, by default the dead-letter list.
+func (s *AutomationService) ListEvents(ctx context.Context, tenantID uuid.UUID, filter model.AutomationEventListFilter) (model.ListResponse[model.AutomationEvent], error) {
+	resp := model.ListResponse[model.AutomationEvent]{
+		Items:  []model.AutomationEvent{},
+		Limit:  filter.Limit,
+		Offset: filter.Offset,
+	}
+	err := database.WithTenant(ctx, s.pool, tenantID, func(tx pgx.Tx) error {
+		events, total, err := s.eventRepo.List(ctx, tx, filter)
+		if err != nil {
+			return err
+		}
+		if events != nil {
+			resp.Items = events
+		}
+		resp.Total = total
+		return nil
+	})
+	return resp, err
+}
+
+// RetryEvent moves a dead event back to the queue.
+func (s *AutomationService) RetryEvent(ctx context.Context, tenantID, eventID uuid.UUID) (*model.AutomationEvent, error) {
+	var event *model.AutomationEvent
+	err := database.WithTenant(ctx, s.pool, tenantID, func(tx pgx.Tx) error {
+		var err error
+		event, err = s.eventRepo.Retry(ctx, tx, eventID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if event == nil {
+		return nil, ErrAutomationEventNotFound
+	}
+	return event, nil
 }
 
 // ListDelayed returns pending delayed actions for admin visibility.
@@ -0,0 +1,658 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/openoms-org/openoms/apps/api-server/internal/database"
+	"github.com/openoms-org/openoms/apps/api-server/internal/model"
+	"github.com/openoms-org/openoms/apps/api-server/internal/pdf"
+	"github.com/openoms-org/openoms/apps/api-server/internal/repository"
+)
+
+var ErrBulkJobNotFound = errors.New("bulk job not found")
+
+// bulkJobProgressInterval throttles progress writes and WebSocket pushes.
+const bulkJobProgressInterval = time.Second
+
+// BulkJobService runs bulk order actions asynchronously. Start queues a sync
+// job with the resolved order IDs; the bulk job worker runs it with Run,
+// recording a result per order and pushing progress over the WebSocket hub.
+type BulkJobService struct {
+	orderRepo       repository.OrderRepo
+	shipmentRepo    repository.ShipmentRepo
+	invoiceRepo     repository.InvoiceRepo
+	syncJobRepo     repository.SyncJobRepo
+	tenantRepo      repository.TenantRepo
+	auditRepo       repository.AuditRepo
+	orderService    *OrderService
+	shipmentService *ShipmentService
+	labelService    *LabelService
+	invoiceService  *InvoiceService
+	pool            *pgxpool.Pool
+	uploadDir       string
+	baseURL         string
+	wsBroadcast     wsBroadcastFunc
+	logger          *slog.Logger
+}
+
+func NewBulkJobService(
+	orderRepo repository.OrderRepo,
+	shipmentRepo repository.ShipmentRepo,
+	invoiceRepo repository.InvoiceRepo,
+	syncJobRepo repository.SyncJobRepo,
+	tenantRepo repository.TenantRepo,
+	auditRepo repository.AuditRepo,
+	orderService *OrderService,
+	shipmentService *ShipmentService,
+	labelService *LabelService,
+	invoiceService *InvoiceService,
+	pool *pgxpool.Pool,
+	uploadDir string,
+	baseURL string,
+) *BulkJobService {
+	return &BulkJobService{
+		orderRepo:       orderRepo,
+		shipmentRepo:    shipmentRepo,
+		invoiceRepo:     invoiceRepo,
+		syncJobRepo:     syncJobRepo,
+		tenantRepo:      tenantRepo,
+		auditRepo:       auditRepo,
+		orderService:    orderService,
+		shipmentService: shipmentService,
+		labelService:    labelService,
+		invoiceService:  invoiceService,
+		pool:            pool,
+		uploadDir:       uploadDir,
+		baseURL:         baseURL,
+		logger:          slog.Default().With("service", "bulk_job"),
+	}
+}
+
+// SetWSBroadcast sets the function used to push job progress via WebSocket.
+func (s *BulkJobService) SetWSBroadcast(fn func(tenantID uuid.UUID, eventType string, payload any)) {
+	s.wsBroadcast = fn
+}
+
+// Start validates a bulk request, resolves its orders and queues the job.
+func (s *BulkJobService) Start(ctx context.Context, tenantID uuid.UUID, req model.CreateBulkJobRequest, actorID uuid.UUID, ip string) (*model.SyncJob, error) {
+	if err := req.Validate(); err != nil {
+		return nil, NewValidationError(err)
+	}
+
+	var job *model.SyncJob
+	err := database.WithTenant(ctx, s.pool, tenantID, func(tx pgx.Tx) error {
+		if len(req.Filter) > 0 {
+			filter, err := model.ParseOrderFilter(req.Filter.Values())
+			if err != nil {
+				return NewValidationError(err)
+			}
+			if err := resolveOrderFilter(ctx, tx, s.tenantRepo, tenantID, &filter); err != nil {
+				return err
+			}
+			ids, err := s.orderRepo.ListIDs(ctx, tx, filter, model.MaxBulkJobOrders+1)
+			if err != nil {
+				return err
+			}
+			if len(ids) == 0 {
+				return NewValidationError(errors.New("filter matches no orders"))
+			}
+			if len(ids) > model.MaxBulkJobOrders {
+				return NewValidationError(fmt.Errorf("filter matches more than %d orders; narrow it down", model.MaxBulkJobOrders))
+			}
+			req.OrderIDs = ids
+			req.Filter = nil
+		}
+
+		switch req.Action {
+		case model.BulkActionStatus:
+			config, err := s.orderService.loadStatusConfig(ctx, tx, tenantID)
+			if err != nil {
+				return fmt.Errorf("load status config: %w", err)
+			}
+			if !config.IsValidStatus(req.Status) {
+				return NewValidationError(fmt.Errorf("%w: %q", ErrUnknownStatus, req.Status))
+			}
+		case model.BulkActionInvoices:
+			if req.Invoice.Provider == "" {
+				cfg, err := s.invoiceService.loadInvoicingSettings(ctx, tx, tenantID)
+				if err != nil {
+					return fmt.Errorf("load invoicing settings: %w", err)
+				}
+				if cfg != nil {
+					req.Invoice.Provider = cfg.Provider
+				}
+			}
+			if req.Invoice.Provider == "" {
+				return NewValidationError(errors.New("no invoicing provider configured"))
+			}
+		}
+
+		metadata, err := json.Marshal(model.BulkJobMetadata{
+			Request:   req,
+			CreatedBy: actorID,
+			IPAddress: ip,
+			Total:     len(req.OrderIDs),
+			Results:   []model.BulkJobItemResult{},
+		})
+		if err != nil {
+			return err
+		}
+		job = &model.SyncJob{
+			ID:       uuid.New(),
+			TenantID: tenantID,
+			JobType:  model.SyncJobTypeBulkOrders,
+			Status:   "pending",
+			Metadata: metadata,
+		}
+		if err := s.syncJobRepo.Create(ctx, tx, job); err != nil {
+			return err
+		}
+		return s.auditRepo.Log(ctx, tx, model.AuditEntry{
+			TenantID:   tenantID,
+			UserID:     actorID,
+			Action:     "order.bulk_job_started",
+			EntityType: "sync_job",
+			EntityID:   job.ID,
+			Changes:    map[string]string{"action": req.Action, "orders": strconv.Itoa(len(req.OrderIDs))},
+			IPAddress:  ip,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+func (s *BulkJobService) Get(ctx context.Context, tenantID, jobID uuid.UUID) (*model.SyncJob, error) {
+	var job *model.SyncJob
+	err := database.WithTenant(ctx, s.pool, tenantID, func(tx pgx.Tx) error {
+		var err error
+		job, err = s.syncJobRepo.GetByID(ctx, tx, jobID)
+		if err != nil {
+			return err
+		}
+		if job == nil || job.JobType != model.SyncJobTypeBulkOrders {
+			return ErrBulkJobNotFound
+		}
+		return nil
+	})
+	return job, err
+}
+
+func (s *BulkJobService) List(ctx context.Context, tenantID uuid.UUID, pagination model.PaginationParams) (model.ListResponse[model.SyncJob], error) {
+	var resp model.ListResponse[model.SyncJob]
+	jobType := model.SyncJobTypeBulkOrders
+	err := database.WithTenant(ctx, s.pool, tenantID, func(tx pgx.Tx) error {
+		jobs, total, err := s.syncJobRepo.List(ctx, tx, model.SyncJobListFilter{JobType: &jobType, PaginationParams: pagination})
+		if err != nil {
+			return err
+		}
+		items := make([]model.SyncJob, 0, len(jobs))
+		for _, j := range jobs {
+			items = append(items, *j)
+		}
+		resp = model.ListResponse[model.SyncJob]{Items: items, Total: total, Limit: pagination.Limit, Offset: pagination.Offset}
+		return nil
+	})
+	return resp, err
+}
+
+// bulkRun is the state of a running bulk job.
+type bulkRun struct {
+	job       *model.SyncJob
+	meta      model.BulkJobMetadata
+	processed int
+	failed    int
+	lastSave  time.Time
+}
+
+// Run executes a claimed bulk job order by order. Per-order failures are
+// recorded in the results; the job only fails when it cannot run at all.
+func (s *BulkJobService) Run(ctx context.Context, job *model.SyncJob) error {
+	run := &bulkRun{job: job, lastSave: time.Now()}
+	if err := json.Unmarshal(job.Metadata, &run.meta); err != nil {
+		return s.fail(ctx, run, fmt.Errorf("read job metadata: %w", err))
+	}
+	run.meta.Results = make([]model.BulkJobItemResult, 0, len(run.meta.Request.OrderIDs))
+	s.push(run, "bulk_job.progress", "running")
+
+	req := run.meta.Request
+	switch req.Action {
+	case model.BulkActionPackingSlips:
+		doc := pdf.New()
+		pages := 0
+		s.each(ctx, run, func(orderID uuid.UUID) model.BulkJobItemResult {
+			return s.addPackingSlip(ctx, run, doc, &pages, orderID)
+		})
+		if pages > 0 {
+			if err := s.saveFile(run, doc.Bytes()); err != nil {
+				return s.fail(ctx, run, err)
+			}
+		}
+	case model.BulkActionLabelPDF:
+		var labels [][]byte
+		s.each(ctx, run, func(orderID uuid.UUID) model.BulkJobItemResult {
+			return s.collectLabels(ctx, run, &labels, orderID)
+		})
+		if len(labels) > 0 {
+			merged, err := pdf.Merge(labels...)
+			if err != nil {
+				return s.fail(ctx, run, fmt.Errorf("merge labels: %w", err))
+			}
+			if err := s.saveFile(run, merged); err != nil {
+				return s.fail(ctx, run, err)
+			}
+		}
+	default:
+		s.each(ctx, run, func(orderID uuid.UUID) model.BulkJobItemResult {
+			return s.runItem(ctx, run, orderID)
+		})
+	}
+
+	err := database.WithTenant(ctx, s.pool, job.TenantID, func(tx pgx.Tx) error {
+		metadata, err := json.Marshal(run.meta)
+		if err != nil {
+			return err
+		}
+		if err := s.syncJobRepo.UpdateProgress(ctx, tx, job.ID, run.processed, run.failed, metadata); err != nil {
+			return err
+		}
+		return s.syncJobRepo.UpdateStatus(ctx, tx, job.ID, "completed", run.processed, run.failed, nil)
+	})
+	if err != nil {
+		return err
+	}
+	s.push(run, "bulk_job.finished", "completed")
+	return nil
+}
+
+// each runs fn for every order of the job, saving progress as it goes.
+func (s *BulkJobService) each(ctx context.Context, run *bulkRun, fn func(orderID uuid.UUID) model.BulkJobItemResult) {
+	for _, orderID := range run.meta.Request.OrderIDs {
+		result := fn(orderID)
+		result.OrderID = orderID
+		if result.Error != "" {
+			run.failed++
+		} else {
+			result.Success = true
+			run.processed++
+		}
+		run.meta.Results = append(run.meta.Results, result)
+
+		if time.Since(run.lastSave) >= bulkJobProgressInterval {
+			run.lastSave = time.Now()
+			if err := s.saveProgress(ctx, run); err != nil {
+				s.logger.Error("bulk job: failed to save progress", "sync_job_id", run.job.ID, "error", err)
+			}
+			s.push(run, "bulk_job.progress", "running")
+		}
+	}
+}
+
+func (s *BulkJobService) runItem(ctx context.Context, run *bulkRun, orderID uuid.UUID) model.BulkJobItemResult {
+	req := run.meta.Request
+	tenantID := run.job.TenantID
+	actorID, ip := run.meta.CreatedBy, run.meta.IPAddress
+
+	switch req.Action {
+	case model.BulkActionStatus:
+		_, err := s.orderService.TransitionStatus(ctx, tenantID, orderID, model.StatusTransitionRequest{Status: req.Status, Force: req.Force}, actorID, ip)
+		return bulkItemResult(err)
+
+	case model.BulkActionTags:
+		order, err := s.orderService.Get(ctx, tenantID, orderID)
+		if err != nil {
+			return bulkItemResult(err)
+		}
+		tags := model.ApplyTags(order.Tags, req.AddTags, req.RemoveTags)
+		if slices.Equal(tags, order.Tags) {
+			return model.BulkJobItemResult{Skipped: true}
+		}
+		_, err = s.orderService.Update(ctx, tenantID, orderID, model.UpdateOrderRequest{Tags: &tags}, actorID, ip)
+		return bulkItemResult(err)
+
+	case model.BulkActionLabels:
+		return s.generateLabel(ctx, run, orderID)
+
+	case model.BulkActionInvoices:
+		return s.createInvoice(ctx, run, orderID)
+	}
+	return model.BulkJobItemResult{Error: "unknown action"}
+}
+
+// generateLabel generates a label for the order's shipment of the chosen
+// carrier, creating the shipment when the order has none waiting for one.
+func (s *BulkJobService) generateLabel(ctx context.Context, run *bulkRun, orderID uuid.UUID) model.BulkJobItemResult {
+	params := run.meta.Request.Label
+	tenantID := run.job.TenantID
+	actorID, ip := run.meta.CreatedBy, run.meta.IPAddress
+
+	req := params.GenerateLabelRequest
+	var target *model.Shipment
+	var labelled *model.Shipment
+	var pickupPoint *string
+	err := database.WithTenant(ctx, s.pool, tenantID, func(tx pgx.Tx) error {
+		order, err := s.orderRepo.FindByID(ctx, tx, orderID)
+		if err != nil {
+			return err
+		}
+		if order == nil {
+			return ErrOrderNotFound
+		}
+		pickupPoint = order.PickupPointID
+		shipments, _, err := s.shipmentRepo.List(ctx, tx, model.ShipmentListFilter{
+			OrderID:          &orderID,
+			Provider:         &params.Provider,
+			PaginationParams: model.PaginationParams{Limit: model.MaxLimit},
+		})
+		if err != nil {
+			return err
+		}
+		for i := range shipments {
+			sh := &shipments[i]
+			switch {
+			case sh.Status == "created" && target == nil:
+				target = sh
+			case sh.Status != "failed" && sh.Status != "returned" && sh.LabelURL != nil && *sh.LabelURL != "":
+				labelled = sh
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return bulkItemResult(err)
+	}
+	if target == nil && labelled != nil {
+		return shipmentResult(labelled, true)
+	}
+
+	if target == nil {
+		target, err = s.shipmentService.Create(ctx, tenantID, model.CreateShipmentRequest{OrderID: orderID, Provider: params.Provider}, actorID, ip)
+		if err != nil {
+			return bulkItemResult(err)
+		}
+		if req.TargetPoint == "" && pickupPoint != nil {
+			req.TargetPoint = *pickupPoint
+		}
+	}
+	shipment, err := s.labelService.GenerateLabel(ctx, tenantID, target.ID, req, actorID, ip)
+	if err != nil {
+		result := bulkItemResult(err)
+		result.ShipmentID = &target.ID
+		return result
+	}
+	return shipmentResult(shipment, false)
+}
+
+func shipmentResult(sh *model.Shipment, skipped bool) model.BulkJobItemResult {
+	result := model.BulkJobItemResult{ShipmentID: &sh.ID, Skipped: skipped}
+	if sh.TrackingNumber != nil {
+		result.TrackingNumber = *sh.TrackingNumber
+	}
+	return result
+}
+
+// createInvoice issues an invoice unless the order already has one that was
+// not cancelled or rejected by the provider.
+func (s *BulkJobService) createInvoice(ctx context.Context, run *bulkRun, orderID uuid.UUID) model.BulkJobItemResult {
+	params := run.meta.Request.Invoice
+	tenantID := run.job.TenantID
+
+	var existing *model.Invoice
+	err := database.WithTenant(ctx, s.pool, tenantID, func(tx pgx.Tx) error {
+		invoices, err := s.invoiceRepo.FindByOrderID(ctx, tx, orderID)
+		if err != nil {
+			return err
+		}
+		for i := range invoices {
+			if invoices[i].Status != "cancelled" && invoices[i].Status != "error" {
+				existing = &invoices[i]
+				break
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return bulkItemResult(err)
+	}
+	if existing != nil {
+		return model.BulkJobItemResult{Skipped: true, InvoiceID: &existing.ID}
+	}
+
+	inv, err := s.invoiceService.Create(ctx, tenantID, model.CreateInvoiceRequest{
+		OrderID:       orderID,
+		Provider:      params.Provider,
+		InvoiceType:   params.InvoiceType,
+		PaymentMethod: params.PaymentMethod,
+	}, run.meta.CreatedBy, run.meta.IPAddress)
+	if err != nil {
+		return bulkItemResult(err)
+	}
+	result := model.BulkJobItemResult{InvoiceID: &inv.ID}
+	if inv.Status == "error" {
+		result.Error = "invoice not issued"
+		if inv.ErrorMessage != nil {
+			result.Error = *inv.ErrorMessage
+		}
+	}
+	return result
+}
+
+// addPackingSlip writes the packing slip of an order on its own page.
+func (s *BulkJobService) addPackingSlip(ctx context.Context, run *bulkRun, doc *pdf.Document, pages *int, orderID uuid.UUID) model.BulkJobItemResult {
+	var order *model.Order
+	var company model.CompanySettings
+	err := database.WithTenant(ctx, s.pool, run.job.TenantID, func(tx pgx.Tx) error {
+		var err error
+		order, err = s.orderRepo.FindByID(ctx, tx, orderID)
+		if err != nil {
+			return err
+		}
+		if order == nil {
+			return ErrOrderNotFound
+		}
+		if *pages == 0 {
+			return s.companySettings(ctx, tx, run.job.TenantID, &company)
+		}
+		return nil
+	})
+	if err != nil {
+		return bulkItemResult(err)
+	}
+
+	if *pages > 0 {
+		doc.PageBreak()
+	}
+	*pages++
+	writePackingSlip(doc, order, company.CompanyName)
+	return model.BulkJobItemResult{}
+}
+
+func writePackingSlip(doc *pdf.Document, order *model.Order, companyName string) {
+	number := shortID(order.ID)
+	if order.ExternalID != nil && *order.ExternalID != "" {
+		number = *order.ExternalID
+	}
+	doc.Heading("List przewozowy " + number)
+	if companyName != "" {
+		doc.Text("Nadawca: " + companyName)
+	}
+	date := order.CreatedAt
+	if order.OrderedAt != nil {
+		date = *order.OrderedAt
+	}
+	doc.Text("Data zamówienia: " + date.Format("2006-01-02") + "   Źródło: " + order.Source)
+	doc.Space(10)
+
+	doc.Bold("Adres dostawy")
+	for _, line := range addressLines(order) {
+		doc.Text(html.UnescapeString(line))
+	}
+	doc.Space(10)
+
+	var items []map[string]any
+	if len(order.Items) > 0 {
+		_ = json.Unmarshal(order.Items, &items)
+	}
+	rows := make([][]string, len(items))
+	for i, item := range items {
+		rows[i] = []string{itemString(item["sku"]), itemString(item["name"]), strconv.Itoa(itemInt(item["quantity"]))}
+	}
+	doc.Table([]pdf.Column{
+		{Title: "SKU", Width: 120},
+		{Title: "Nazwa", Width: 330},
+		{Title: "Ilość", Width: pdf.ContentWidth - 450, AlignRight: true},
+	}, rows)
+
+	if order.Notes != nil && *order.Notes != "" {
+		doc.Space(10)
+		doc.Bold("Uwagi")
+		doc.Text(*order.Notes)
+	}
+}
+
+// collectLabels reads the PDF labels of an order's shipments.
+func (s *BulkJobService) collectLabels(ctx context.Context, run *bulkRun, labels *[][]byte, orderID uuid.UUID) model.BulkJobItemResult {
+	var shipments []model.Shipment
+	err := database.WithTenant(ctx, s.pool, run.job.TenantID, func(tx pgx.Tx) error {
+		var err error
+		shipments, _, err = s.shipmentRepo.List(ctx, tx, model.ShipmentListFilter{
+			OrderID:          &orderID,
+			PaginationParams: model.PaginationParams{Limit: model.MaxLimit},
+		})
+		return err
+	})
+	if err != nil {
+		return bulkItemResult(err)
+	}
+
+	var found [][]byte
+	for _, sh := range shipments {
+		if sh.LabelURL == nil || *sh.LabelURL == "" || sh.Status == "failed" || sh.Status == "returned" {
+			continue
+		}
+		data, err := s.readUpload(run.job.TenantID, *sh.LabelURL)
+		if err != nil {
+			return model.BulkJobItemResult{ShipmentID: &sh.ID, Error: "label file not found"}
+		}
+		if _, err := pdf.Merge(data); err != nil {
+			return model.BulkJobItemResult{ShipmentID: &sh.ID, Error: "label is not a readable PDF"}
+		}
+		found = append(found, data)
+	}
+	if len(found) == 0 {
+		return model.BulkJobItemResult{Error: "order has no label"}
+	}
+	*labels = append(*labels, found...)
+	return model.BulkJobItemResult{}
+}
+
+// readUpload reads a file the API stored under the tenant's upload directory
+// from its public URL.
+func (s *BulkJobService) readUpload(tenantID uuid.UUID, fileURL string) ([]byte, error) {
+	_, rel, ok := strings.Cut(fileURL, "/uploads/")
+	if !ok {
+		return nil, fmt.Errorf("not an upload URL: %s", fileURL)
+	}
+	dir := filepath.Join(s.uploadDir, tenantID.String())
+	path := filepath.Join(s.uploadDir, filepath.FromSlash(rel))
+	if !strings.HasPrefix(path, dir+string(filepath.Separator)) {
+		return nil, fmt.Errorf("upload belongs to another tenant: %s", fileURL)
+	}
+	return os.ReadFile(path)
+}
+
+// saveFile stores the job's output PDF next to the tenant's labels.
+func (s *BulkJobService) saveFile(run *bulkRun, data []byte) error {
+	dir := filepath.Join(s.uploadDir, run.job.TenantID.String())
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("creating upload directory: %w", err)
+	}
+	filename := fmt.Sprintf("%s-%s.pdf", strings.ReplaceAll(run.meta.Request.Action, "_", "-"), run.job.ID)
+	if err := os.WriteFile(filepath.Join(dir, filename), data, 0644); err != nil {
+		return fmt.Errorf("saving bulk job file: %w", err)
+	}
+	run.meta.FileURL = fmt.Sprintf("%s/uploads/%s/%s", s.baseURL, run.job.TenantID, filename)
+	return nil
+}
+
+func (s *BulkJobService) companySettings(ctx context.Context, tx pgx.Tx, tenantID uuid.UUID, dest *model.CompanySettings) error {
+	raw, err := s.tenantRepo.GetSettings(ctx, tx, tenantID)
+	if err != nil {
+		return err
+	}
+	var all map[string]json.RawMessage
+	if json.Unmarshal(raw, &all) == nil && all["company"] != nil {
+		_ = json.Unmarshal(all["company"], dest)
+	}
+	return nil
+}
+
+func (s *BulkJobService) saveProgress(ctx context.Context, run *bulkRun) error {
+	metadata, err := json.Marshal(run.meta)
+	if err != nil {
+		return err
+	}
+	return database.WithTenant(ctx, s.pool, run.job.TenantID, func(tx pgx.Tx) error {
+		return s.syncJobRepo.UpdateProgress(ctx, tx, run.job.ID, run.processed, run.failed, metadata)
+	})
+}
+
+// fail marks the job as failed and returns the cause.
+func (s *BulkJobService) fail(ctx context.Context, run *bulkRun, cause error) error {
+	msg := cause.Error()
+	if err := database.WithTenant(ctx, s.pool, run.job.TenantID, func(tx pgx.Tx) error {
+		if metadata, err := json.Marshal(run.meta); err == nil && run.meta.Total > 0 {
+			if err := s.syncJobRepo.UpdateProgress(ctx, tx, run.job.ID, run.processed, run.failed, metadata); err != nil {
+				return err
+			}
+		}
+		return s.syncJobRepo.UpdateStatus(ctx, tx, run.job.ID, "failed", run.processed, run.failed, &msg)
+	}); err != nil {
+		s.logger.Error("bulk job: failed to mark job as failed", "sync_job_id", run.job.ID, "error", err)
+	}
+	s.push(run, "bulk_job.finished", "failed")
+	return cause
+}
+
+func (s *BulkJobService) push(run *bulkRun, eventType, status string) {
+	if s.wsBroadcast == nil {
+		return
+	}
+	s.wsBroadcast(run.job.TenantID, eventType, model.BulkJobEvent{
+		JobID:     run.job.ID,
+		Action:    run.meta.Request.Action,
+		Status:    status,
+		Total:     run.meta.Total,
+		Processed: run.processed,
+		Failed:    run.failed,
+		FileURL:   run.meta.FileURL,
+	})
+}
+
+// bulkItemResult turns the error of a single-order operation into a result.
+func bulkItemResult(err error) model.BulkJobItemResult {
+	switch {
+	case err == nil:
+		return model.BulkJobItemResult{}
+	case errors.Is(err, ErrOrderNotFound):
+		return model.BulkJobItemResult{Error: "order not found"}
+	default:
+		return model.BulkJobItemResult{Error: err.Error()}
+	}
+}
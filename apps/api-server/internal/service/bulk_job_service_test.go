@@ -0,0 +1,57 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openoms-org/openoms/apps/api-server/internal/model"
+	"github.com/openoms-org/openoms/apps/api-server/internal/pdf"
+)
+
+func TestBulkJobService_ReadUpload(t *testing.T) {
+	dir := t.TempDir()
+	tenantID, otherID := uuid.New(), uuid.New()
+	for _, id := range []uuid.UUID{tenantID, otherID} {
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, id.String()), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, id.String(), "label.pdf"), []byte(id.String()), 0644))
+	}
+	s := &BulkJobService{uploadDir: dir}
+
+	data, err := s.readUpload(tenantID, "http://api.example.com/uploads/"+tenantID.String()+"/label.pdf")
+	require.NoError(t, err)
+	assert.Equal(t, tenantID.String(), string(data))
+
+	_, err = s.readUpload(tenantID, "http://api.example.com/uploads/"+otherID.String()+"/label.pdf")
+	assert.ErrorContains(t, err, "another tenant")
+	_, err = s.readUpload(tenantID, "http://api.example.com/uploads/"+tenantID.String()+"/../"+otherID.String()+"/label.pdf")
+	assert.ErrorContains(t, err, "another tenant")
+	_, err = s.readUpload(tenantID, "http://api.example.com/files/label.pdf")
+	assert.Error(t, err)
+}
+
+func TestWritePackingSlip(t *testing.T) {
+	external := "ALG-123"
+	order := &model.Order{
+		ID:              uuid.New(),
+		ExternalID:      &external,
+		Source:          "allegro",
+		CustomerName:    "Jan Kowalski",
+		ShippingAddress: []byte(`{"street":"Długa 1","postal_code":"00-001","city":"Warszawa"}`),
+		Items:           []byte(`[{"sku":"SKU-1","name":"Kubek","quantity":2}]`),
+		CreatedAt:       time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC),
+	}
+
+	doc := pdf.New()
+	writePackingSlip(doc, order, "Sklep")
+	doc.PageBreak()
+	writePackingSlip(doc, order, "Sklep")
+
+	out := string(doc.Bytes())
+	assert.Contains(t, out, "/Count 2")
+}
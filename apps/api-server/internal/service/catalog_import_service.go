@@ -0,0 +1,510 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/openoms-org/openoms/apps/api-server/internal/crypto"
+	"github.com/openoms-org/openoms/apps/api-server/internal/database"
+	"github.com/openoms-org/openoms/apps/api-server/internal/integration"
+	"github.com/openoms-org/openoms/apps/api-server/internal/model"
+	"github.com/openoms-org/openoms/apps/api-server/internal/repository"
+)
+
+var ErrCatalogImportInProgress = errors.New("catalog import already in progress for this integration")
+
+const (
+	// maxCatalogPages bounds a single import run.
+	maxCatalogPages = 500
+	// maxCatalogImportErrors bounds the error messages and unmatched variants
+	// kept in the job metadata.
+	maxCatalogImportErrors = 50
+)
+
+// CatalogImportService imports the product catalog of a sales channel. Start
+// queues a sync job; the catalog import worker runs it with Run.
+type CatalogImportService struct {
+	integrationRepo repository.IntegrationRepo
+	productRepo     repository.ProductRepo
+	productService  *ProductService
+	variantRepo     repository.VariantRepo
+	listingRepo     repository.ProductListingRepo
+	syncJobRepo     repository.SyncJobRepo
+	auditRepo       repository.AuditRepo
+	pool            *pgxpool.Pool
+	encryptionKey   []byte
+	logger          *slog.Logger
+}
+
+func NewCatalogImportService(
+	integrationRepo repository.IntegrationRepo,
+	productRepo repository.ProductRepo,
+	productService *ProductService,
+	variantRepo repository.VariantRepo,
+	listingRepo repository.ProductListingRepo,
+	syncJobRepo repository.SyncJobRepo,
+	auditRepo repository.AuditRepo,
+	pool *pgxpool.Pool,
+	encryptionKey []byte,
+) *CatalogImportService {
+	return &CatalogImportService{
+		integrationRepo: integrationRepo,
+		productRepo:     productRepo,
+		productService:  productService,
+		variantRepo:     variantRepo,
+		listingRepo:     listingRepo,
+		syncJobRepo:     syncJobRepo,
+		auditRepo:       auditRepo,
+		pool:            pool,
+		encryptionKey:   encryptionKey,
+		logger:          slog.Default().With("service", "catalog_import"),
+	}
+}
+
+// Start queues a catalog import for an integration whose provider can list
+// its catalog. Only one import per integration may be pending or running.
+func (s *CatalogImportService) Start(ctx context.Context, tenantID, integrationID uuid.UUID, actorID uuid.UUID, ip string) (*model.SyncJob, error) {
+	var job *model.SyncJob
+	err := database.WithTenant(ctx, s.pool, tenantID, func(tx pgx.Tx) error {
+		wc, err := s.integrationRepo.FindByID(ctx, tx, integrationID)
+		if err != nil {
+			return err
+		}
+		if wc == nil {
+			return ErrIntegrationNotFound
+		}
+		if _, err := s.catalogLister(wc); err != nil {
+			return err
+		}
+
+		active, err := s.syncJobRepo.FindActive(ctx, tx, integrationID, model.SyncJobTypeCatalogImport)
+		if err != nil {
+			return err
+		}
+		if active != nil {
+			return ErrCatalogImportInProgress
+		}
+
+		job = &model.SyncJob{
+			ID:            uuid.New(),
+			TenantID:      tenantID,
+			IntegrationID: &integrationID,
+			JobType:       model.SyncJobTypeCatalogImport,
+			Status:        "pending",
+			Metadata:      []byte("{}"),
+		}
+		if err := s.syncJobRepo.Create(ctx, tx, job); err != nil {
+			return err
+		}
+		return s.auditRepo.Log(ctx, tx, model.AuditEntry{
+			TenantID:   tenantID,
+			UserID:     actorID,
+			Action:     "integration.catalog_import_started",
+			EntityType: "integration",
+			EntityID:   integrationID,
+			Changes:    map[string]string{"provider": wc.Provider, "sync_job_id": job.ID.String()},
+			IPAddress:  ip,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// Run executes a claimed catalog import: it walks the channel catalog page by
+// page, matches products and variants by SKU and then EAN, creates products
+// that do not exist yet and links everything with product listings. Progress
+// is written to the sync job after every page.
+func (s *CatalogImportService) Run(ctx context.Context, job *model.SyncJob) error {
+	var wc *model.IntegrationWithCreds
+	err := database.WithTenant(ctx, s.pool, job.TenantID, func(tx pgx.Tx) error {
+		if job.IntegrationID == nil {
+			return ErrIntegrationNotFound
+		}
+		var err error
+		wc, err = s.integrationRepo.FindByID(ctx, tx, *job.IntegrationID)
+		if err != nil {
+			return err
+		}
+		if wc == nil {
+			return ErrIntegrationNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return s.fail(ctx, job, 0, 0, err)
+	}
+
+	lister, err := s.catalogLister(wc)
+	if err != nil {
+		return s.fail(ctx, job, 0, 0, err)
+	}
+
+	var summary model.CatalogImportSummary
+	processed, failed := 0, 0
+	cursor := ""
+	for page := 0; page < maxCatalogPages; page++ {
+		products, next, err := lister.ListCatalog(ctx, cursor)
+		if err != nil {
+			return s.fail(ctx, job, processed, failed, err)
+		}
+
+		for i := range products {
+			cp := &products[i]
+			// Counters are only kept when the product's transaction commits.
+			result := summary
+			result.Errors = slices.Clone(summary.Errors)
+			result.UnmatchedVariants = slices.Clone(summary.UnmatchedVariants)
+			var created *model.Product
+			err := database.WithTenant(ctx, s.pool, job.TenantID, func(tx pgx.Tx) error {
+				var err error
+				created, err = s.importProduct(ctx, tx, wc, cp, &result)
+				return err
+			})
+			if err != nil {
+				failed++
+				if len(summary.Errors) < maxCatalogImportErrors {
+					summary.Errors = append(summary.Errors, fmt.Sprintf("%s: %v", catalogProductRef(cp), err))
+				}
+				continue
+			}
+			summary = result
+			processed++
+			if created != nil {
+				s.productService.notifyCreated(job.TenantID, created)
+			}
+		}
+
+		summary.Cursor = next
+		if err := s.saveProgress(ctx, job, processed, failed, &summary); err != nil {
+			return err
+		}
+		if next == "" {
+			break
+		}
+		cursor = next
+	}
+
+	return database.WithTenant(ctx, s.pool, job.TenantID, func(tx pgx.Tx) error {
+		return s.syncJobRepo.UpdateStatus(ctx, tx, job.ID, "completed", processed, failed, nil)
+	})
+}
+
+// catalogLister builds the marketplace provider of an integration and checks
+// that it can list its catalog.
+func (s *CatalogImportService) catalogLister(wc *model.IntegrationWithCreds) (integration.CatalogLister, error) {
+	credJSON, err := crypto.Decrypt(wc.EncryptedCredentials, s.encryptionKey)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt credentials: %w", err)
+	}
+	provider, err := integration.NewMarketplaceProvider(wc.Provider, credJSON, wc.Settings)
+	if err != nil {
+		return nil, NewValidationError(err)
+	}
+	lister, ok := provider.(integration.CatalogLister)
+	if !ok {
+		return nil, NewValidationError(fmt.Errorf("provider %s does not support catalog import", wc.Provider))
+	}
+	return lister, nil
+}
+
+// importProduct imports a single catalog product within a tenant transaction.
+// It returns the product when it was created; pass it to the product
+// service's notifyCreated once the transaction commits.
+func (s *CatalogImportService) importProduct(ctx context.Context, tx pgx.Tx, wc *model.IntegrationWithCreds, cp *integration.CatalogProduct, summary *model.CatalogImportSummary) (*model.Product, error) {
+	product, err := s.matchProduct(ctx, tx, cp)
+	if err != nil {
+		return nil, err
+	}
+	created := product == nil
+	if created {
+		product, err = s.createProduct(ctx, tx, wc, cp)
+		if err != nil {
+			return nil, err
+		}
+		summary.ProductsCreated++
+	} else {
+		summary.ProductsMatched++
+	}
+
+	if cp.ExternalID != "" {
+		if err := s.linkListing(ctx, tx, wc, product.ID, nil, cp.ExternalID, cp, summary); err != nil {
+			return nil, err
+		}
+	}
+
+	for i := range cp.Variants {
+		cv := &cp.Variants[i]
+		variant, err := s.matchVariant(ctx, tx, product.ID, cv)
+		if err != nil {
+			return nil, err
+		}
+		if variant == nil {
+			if !created {
+				// Existing products are not restructured; the variant is
+				// reported so it can be added or fixed by hand.
+				if len(summary.UnmatchedVariants) < maxCatalogImportErrors {
+					summary.UnmatchedVariants = append(summary.UnmatchedVariants, cv.ExternalID)
+				}
+				continue
+			}
+			variant, err = s.createVariant(ctx, tx, product, cv, i)
+			if err != nil {
+				return nil, fmt.Errorf("variant %s: %w", cv.ExternalID, err)
+			}
+			summary.VariantsCreated++
+		} else {
+			summary.VariantsMatched++
+		}
+		if err := s.linkListing(ctx, tx, wc, product.ID, &variant.ID, cv.ExternalID, cp, summary); err != nil {
+			return nil, err
+		}
+	}
+	if !created {
+		return nil, nil
+	}
+	return product, nil
+}
+
+// matchProduct finds an existing product by SKU, then EAN, then by the SKU or
+// EAN of one of its variants.
+func (s *CatalogImportService) matchProduct(ctx context.Context, tx pgx.Tx, cp *integration.CatalogProduct) (*model.Product, error) {
+	if cp.SKU != "" {
+		p, err := s.productRepo.FindBySKU(ctx, tx, cp.SKU)
+		if err != nil || p != nil {
+			return p, err
+		}
+	}
+	if cp.EAN != "" {
+		p, err := s.productRepo.FindByEAN(ctx, tx, cp.EAN)
+		if err != nil || p != nil {
+			return p, err
+		}
+	}
+	for _, cv := range cp.Variants {
+		var variants []model.ProductVariant
+		var err error
+		if cv.SKU != "" {
+			variants, err = s.variantRepo.FindBySKU(ctx, tx, cv.SKU)
+		}
+		if err == nil && len(variants) == 0 && cv.EAN != "" {
+			variants, err = s.variantRepo.FindByEAN(ctx, tx, cv.EAN)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(variants) > 0 {
+			return s.productRepo.FindByID(ctx, tx, variants[0].ProductID)
+		}
+	}
+	return nil, nil
+}
+
+// matchVariant finds a variant of the product by SKU, then EAN.
+func (s *CatalogImportService) matchVariant(ctx context.Context, tx pgx.Tx, productID uuid.UUID, cv *integration.CatalogVariant) (*model.ProductVariant, error) {
+	if cv.SKU != "" {
+		variants, err := s.variantRepo.FindBySKU(ctx, tx, cv.SKU)
+		if err != nil {
+			return nil, err
+		}
+		if v := variantOfProduct(variants, productID); v != nil {
+			return v, nil
+		}
+	}
+	if cv.EAN != "" {
+		variants, err := s.variantRepo.FindByEAN(ctx, tx, cv.EAN)
+		if err != nil {
+			return nil, err
+		}
+		return variantOfProduct(variants, productID), nil
+	}
+	return nil, nil
+}
+
+func variantOfProduct(variants []model.ProductVariant, productID uuid.UUID) *model.ProductVariant {
+	for i := range variants {
+		if variants[i].ProductID == productID {
+			return &variants[i]
+		}
+	}
+	return nil
+}
+
+// createProduct creates a catalog product through the product service, so it
+// gets the same audit entry and product.created event as a manual one.
+func (s *CatalogImportService) createProduct(ctx context.Context, tx pgx.Tx, wc *model.IntegrationWithCreds, cp *integration.CatalogProduct) (*model.Product, error) {
+	req := model.CreateProductRequest{
+		ExternalID:       optionalString(cp.ExternalGroupID),
+		Source:           wc.Provider,
+		Name:             cp.Name,
+		SKU:              optionalString(cp.SKU),
+		EAN:              optionalString(cp.EAN),
+		Price:            cp.Price,
+		StockQty:         cp.StockQuantity,
+		DescriptionShort: cp.DescriptionShort,
+		DescriptionLong:  cp.DescriptionLong,
+		Weight:           cp.Weight,
+		Category:         optionalString(cp.Category),
+		ImageURL:         optionalString(cp.ImageURL),
+	}
+	if len(cp.Variants) > 0 {
+		req.StockQty = 0
+		for _, v := range cp.Variants {
+			req.StockQty += v.StockQuantity
+		}
+	}
+
+	images := make([]map[string]any, 0, len(cp.Images))
+	for i, url := range cp.Images {
+		images = append(images, map[string]any{"url": url, "position": i})
+	}
+	imagesJSON, err := json.Marshal(images)
+	if err != nil {
+		return nil, err
+	}
+	req.Images = imagesJSON
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	return s.productService.create(ctx, tx, wc.TenantID, req, uuid.Nil, "")
+}
+
+func (s *CatalogImportService) createVariant(ctx context.Context, tx pgx.Tx, product *model.Product, cv *integration.CatalogVariant, position int) (*model.ProductVariant, error) {
+	req := model.CreateVariantRequest{
+		SKU:           optionalString(cv.SKU),
+		EAN:           optionalString(cv.EAN),
+		Name:          cv.Name,
+		StockQuantity: cv.StockQuantity,
+		ImageURL:      optionalString(cv.ImageURL),
+	}
+	if cv.Price > 0 && cv.Price != product.Price {
+		price := cv.Price
+		req.PriceOverride = &price
+	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	attributes, err := json.Marshal(cv.Attributes)
+	if err != nil {
+		return nil, err
+	}
+	if cv.Attributes == nil {
+		attributes = []byte("{}")
+	}
+
+	variant := &model.ProductVariant{
+		ID:            uuid.New(),
+		TenantID:      product.TenantID,
+		ProductID:     product.ID,
+		SKU:           req.SKU,
+		EAN:           req.EAN,
+		Name:          req.Name,
+		Attributes:    attributes,
+		PriceOverride: req.PriceOverride,
+		StockQuantity: req.StockQuantity,
+		ImageURL:      req.ImageURL,
+		Position:      position,
+		Active:        true,
+	}
+	if err := s.variantRepo.Create(ctx, tx, variant); err != nil {
+		return nil, err
+	}
+	if !product.HasVariants {
+		if _, err := tx.Exec(ctx, "UPDATE products SET has_variants = true WHERE id = $1", product.ID); err != nil {
+			return nil, err
+		}
+		product.HasVariants = true
+	}
+	return variant, nil
+}
+
+// linkListing creates a listing for the product (or variant) unless one already
+// exists for the integration.
+func (s *CatalogImportService) linkListing(ctx context.Context, tx pgx.Tx, wc *model.IntegrationWithCreds, productID uuid.UUID, variantID *uuid.UUID, externalID string, cp *integration.CatalogProduct, summary *model.CatalogImportSummary) error {
+	var existing *model.ProductListing
+	var err error
+	if variantID != nil {
+		existing, err = s.listingRepo.FindByVariantAndIntegration(ctx, tx, *variantID, wc.ID)
+	} else {
+		existing, err = s.listingRepo.FindByProductAndIntegration(ctx, tx, productID, wc.ID)
+	}
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		summary.AlreadyListed++
+		return nil
+	}
+
+	now := time.Now()
+	listing := &model.ProductListing{
+		ID:              uuid.New(),
+		TenantID:        wc.TenantID,
+		ProductID:       productID,
+		VariantID:       variantID,
+		IntegrationID:   wc.ID,
+		ExternalID:      &externalID,
+		ExternalGroupID: optionalString(cp.ExternalGroupID),
+		Status:          "active",
+		URL:             optionalString(cp.URL),
+		SyncStatus:      "synced",
+		LastSyncedAt:    &now,
+		Metadata:        []byte(`{"imported":true}`),
+	}
+	if err := s.listingRepo.Create(ctx, tx, listing); err != nil {
+		return err
+	}
+	summary.ListingsCreated++
+	return nil
+}
+
+func (s *CatalogImportService) saveProgress(ctx context.Context, job *model.SyncJob, processed, failed int, summary *model.CatalogImportSummary) error {
+	metadata, err := json.Marshal(summary)
+	if err != nil {
+		return err
+	}
+	return database.WithTenant(ctx, s.pool, job.TenantID, func(tx pgx.Tx) error {
+		return s.syncJobRepo.UpdateProgress(ctx, tx, job.ID, processed, failed, metadata)
+	})
+}
+
+// fail marks the job as failed and returns the cause.
+func (s *CatalogImportService) fail(ctx context.Context, job *model.SyncJob, processed, failed int, cause error) error {
+	msg := cause.Error()
+	if err := database.WithTenant(ctx, s.pool, job.TenantID, func(tx pgx.Tx) error {
+		return s.syncJobRepo.UpdateStatus(ctx, tx, job.ID, "failed", processed, failed, &msg)
+	}); err != nil {
+		s.logger.Error("catalog import: failed to mark job as failed", "sync_job_id", job.ID, "error", err)
+	}
+	return cause
+}
+
+// catalogProductRef identifies a catalog product in error messages.
+func catalogProductRef(cp *integration.CatalogProduct) string {
+	for _, ref := range []string{cp.SKU, cp.ExternalGroupID, cp.Name} {
+		if ref != "" {
+			return ref
+		}
+	}
+	return "product"
+}
+
+func optionalString(s string) *string {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil
+	}
+	return &s
+}
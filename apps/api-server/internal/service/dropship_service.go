@@ -0,0 +1,1230 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/openoms-org/openoms/apps/api-server/internal/database"
+	"github.com/openoms-org/openoms/apps/api-server/internal/model"
+	"github.com/openoms-org/openoms/apps/api-server/internal/pdf"
+	"github.com/openoms-org/openoms/apps/api-server/internal/repository"
+)
+
+var (
+	ErrSupplierOrderNotFound   = errors.New("supplier order not found")
+	ErrFulfillmentRuleNotFound = errors.New("fulfillment rule not found")
+)
+
+const (
+	// supplierOrderMaxAttempts caps the automatic delivery retries.
+	supplierOrderMaxAttempts = 5
+	// dropshipRouteBatch caps the orders routed per tenant and worker run.
+	dropshipRouteBatch = 50
+	// dropshipRouteMaxAge is the age after which unrouted orders are no
+	// longer picked up by the worker.
+	dropshipRouteMaxAge = 30 * 24 * time.Hour
+)
+
+// DropshipService routes order lines to suppliers according to the product
+// fulfilment rules, sends the resulting supplier orders and records the
+// parcels the suppliers ship.
+type DropshipService struct {
+	orderRepo         repository.OrderRepo
+	productRepo       repository.ProductRepo
+	variantRepo       repository.VariantRepo
+	supplierRepo      repository.SupplierRepo
+	supplierProdRepo  repository.SupplierProductRepo
+	ruleRepo          repository.FulfillmentRuleRepo
+	supplierOrderRepo repository.SupplierOrderRepo
+	shipmentRepo      repository.ShipmentRepo
+	integrationRepo   repository.IntegrationRepo
+	tenantRepo        repository.TenantRepo
+	auditRepo         repository.AuditRepo
+	pool              *pgxpool.Pool
+	webhookDispatch   *WebhookDispatchService
+	httpClient        *http.Client
+	baseURL           string
+	frontendURL       string
+	logger            *slog.Logger
+}
+
+func NewDropshipService(
+	orderRepo repository.OrderRepo,
+	productRepo repository.ProductRepo,
+	variantRepo repository.VariantRepo,
+	supplierRepo repository.SupplierRepo,
+	supplierProdRepo repository.SupplierProductRepo,
+	ruleRepo repository.FulfillmentRuleRepo,
+	supplierOrderRepo repository.SupplierOrderRepo,
+	shipmentRepo repository.ShipmentRepo,
+	integrationRepo repository.IntegrationRepo,
+	tenantRepo repository.TenantRepo,
+	auditRepo repository.AuditRepo,
+	pool *pgxpool.Pool,
+	webhookDispatch *WebhookDispatchService,
+	baseURL, frontendURL string,
+	logger *slog.Logger,
+) *DropshipService {
+	return &DropshipService{
+		orderRepo:         orderRepo,
+		productRepo:       productRepo,
+		variantRepo:       variantRepo,
+		supplierRepo:      supplierRepo,
+		supplierProdRepo:  supplierProdRepo,
+		ruleRepo:          ruleRepo,
+		supplierOrderRepo: supplierOrderRepo,
+		shipmentRepo:      shipmentRepo,
+		integrationRepo:   integrationRepo,
+		tenantRepo:        tenantRepo,
+		auditRepo:         auditRepo,
+		pool:              pool,
+		webhookDispatch:   webhookDispatch,
+		httpClient: &http.Client{
+			Timeout: 15 * time.Second,
+			Transport: &http.Transport{
+				DialContext: noPrivateDialer(),
+			},
+		},
+		baseURL:     strings.TrimRight(baseURL, "/"),
+		frontendURL: strings.TrimRight(frontendURL, "/"),
+		logger:      logger,
+	}
+}
+
+// --- Fulfilment rules ---
+
+func (s *DropshipService) ListRules(ctx context.Context, tenantID uuid.UUID) ([]model.FulfillmentRule, error) {
+	var rules []model.FulfillmentRule
+	err := database.WithTenant(ctx, s.pool, tenantID, func(tx pgx.Tx) error {
+		var err error
+		rules, err = s.ruleRepo.List(ctx, tx)
+		return err
+	})
+	if rules == nil {
+		rules = []model.FulfillmentRule{}
+	}
+	return rules, err
+}
+
+func (s *DropshipService) GetRule(ctx context.Context, tenantID, productID uuid.UUID) (*model.FulfillmentRule, error) {
+	var rule *model.FulfillmentRule
+	err := database.WithTenant(ctx, s.pool, tenantID, func(tx pgx.Tx) error {
+		rules, err := s.ruleRepo.FindByProductIDs(ctx, tx, []uuid.UUID{productID})
+		if err != nil {
+			return err
+		}
+		if len(rules) == 0 {
+			return ErrFulfillmentRuleNotFound
+		}
+		rule = &rules[0]
+		return nil
+	})
+	return rule, err
+}
+
+func (s *DropshipService) SetRule(ctx context.Context, tenantID, productID uuid.UUID, req model.SetFulfillmentRuleRequest, actorID uuid.UUID, ip string) (*model.FulfillmentRule, error) {
+	if err := req.Validate(); err != nil {
+		return nil, NewValidationError(err)
+	}
+
+	rule := &model.FulfillmentRule{
+		ProductID:  productID,
+		TenantID:   tenantID,
+		Mode:       req.Mode,
+		SupplierID: req.SupplierID,
+	}
+	err := database.WithTenant(ctx, s.pool, tenantID, func(tx pgx.Tx) error {
+		product, err := s.productRepo.FindByID(ctx, tx, productID)
+		if err != nil {
+			return err
+		}
+		if product == nil {
+			return ErrProductNotFound
+		}
+		if req.SupplierID != nil {
+			supplier, err := s.supplierRepo.FindByID(ctx, tx, *req.SupplierID)
+			if err != nil {
+				return err
+			}
+			if supplier == nil {
+				return NewValidationError(errors.New("supplier not found"))
+			}
+		}
+		if err := s.ruleRepo.Upsert(ctx, tx, rule); err != nil {
+			return fmt.Errorf("save fulfillment rule: %w", err)
+		}
+		return s.auditRepo.Log(ctx, tx, model.AuditEntry{
+			TenantID:   tenantID,
+			UserID:     actorID,
+			Action:     "product.fulfillment_rule_updated",
+			EntityType: "product",
+			EntityID:   productID,
+			Changes:    map[string]any{"mode": rule.Mode, "supplier_id": rule.SupplierID},
+			IPAddress:  ip,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+func (s *DropshipService) DeleteRule(ctx context.Context, tenantID, productID, actorID uuid.UUID, ip string) error {
+	return database.WithTenant(ctx, s.pool, tenantID, func(tx pgx.Tx) error {
+		if err := s.ruleRepo.Delete(ctx, tx, productID); err != nil {
+			return ErrFulfillmentRuleNotFound
+		}
+		return s.auditRepo.Log(ctx, tx, model.AuditEntry{
+			TenantID:   tenantID,
+			UserID:     actorID,
+			Action:     "product.fulfillment_rule_deleted",
+			EntityType: "product",
+			EntityID:   productID,
+			IPAddress:  ip,
+		})
+	})
+}
+
+// --- Supplier orders ---
+
+func (s *DropshipService) List(ctx context.Context, tenantID uuid.UUID, filter model.SupplierOrderListFilter) (model.ListResponse[model.SupplierOrder], error) {
+	var resp model.ListResponse[model.SupplierOrder]
+	err := database.WithTenant(ctx, s.pool, tenantID, func(tx pgx.Tx) error {
+		orders, total, err := s.supplierOrderRepo.List(ctx, tx, filter)
+		if err != nil {
+			return err
+		}
+		if orders == nil {
+			orders = []model.SupplierOrder{}
+		}
+		resp = model.ListResponse[model.SupplierOrder]{
+			Items:  orders,
+			Total:  total,
+			Limit:  filter.Limit,
+			Offset: filter.Offset,
+		}
+		return nil
+	})
+	return resp, err
+}
+
+func (s *DropshipService) Get(ctx context.Context, tenantID, id uuid.UUID) (*model.SupplierOrder, error) {
+	var so *model.SupplierOrder
+	err := database.WithTenant(ctx, s.pool, tenantID, func(tx pgx.Tx) error {
+		var err error
+		so, err = s.supplierOrderRepo.FindByID(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		if so == nil {
+			return ErrSupplierOrderNotFound
+		}
+		return nil
+	})
+	return so, err
+}
+
+// --- Routing ---
+
+// dropshipLine is an order line with a product that has a fulfilment rule.
+type dropshipLine struct {
+	Index     int
+	ProductID uuid.UUID
+	VariantID *uuid.UUID
+	Name      string
+	SKU       string
+	EAN       string
+	Quantity  int
+	// StockKey identifies the own stock the line draws from: the variant
+	// when the line has one, otherwise the product.
+	StockKey uuid.UUID
+}
+
+// dropshipGroup is the set of lines ordered from one supplier.
+type dropshipGroup struct {
+	SupplierID uuid.UUID
+	Items      []model.SupplierOrderItem
+}
+
+// planDropship decides which lines are ordered from which supplier. Own stock
+// is consumed in line order. offers holds the linked supplier products per
+// product, cheapest first; suppliers that cannot receive orders must already
+// be filtered out.
+func planDropship(lines []dropshipLine, rules map[uuid.UUID]model.FulfillmentRule, stock map[uuid.UUID]int, offers map[uuid.UUID][]model.SupplierProduct) ([]dropshipGroup, []string) {
+	var groups []dropshipGroup
+	groupIdx := make(map[uuid.UUID]int)
+	var warnings []string
+
+	for _, line := range lines {
+		rule, ok := rules[line.ProductID]
+		if !ok || rule.Mode == model.FulfillmentOwnStock {
+			stock[line.StockKey] -= line.Quantity
+			continue
+		}
+		if rule.Mode == model.FulfillmentStockFirst && stock[line.StockKey] >= line.Quantity {
+			stock[line.StockKey] -= line.Quantity
+			continue
+		}
+
+		offer := chooseOffer(line, rule, offers[line.ProductID])
+		if offer == nil {
+			warnings = append(warnings, fmt.Sprintf("line %d (%s): no supplier can deliver %d pcs", line.Index+1, line.Name, line.Quantity))
+			stock[line.StockKey] -= line.Quantity
+			continue
+		}
+
+		item := model.SupplierOrderItem{
+			LineIndex:         line.Index,
+			ProductID:         line.ProductID,
+			VariantID:         line.VariantID,
+			SupplierProductID: offer.ID,
+			ExternalID:        offer.ExternalID,
+			Name:              line.Name,
+			SKU:               line.SKU,
+			EAN:               line.EAN,
+			Quantity:          line.Quantity,
+		}
+		if offer.Price != nil {
+			item.UnitCost = *offer.Price
+		}
+		if item.EAN == "" && offer.EAN != nil {
+			item.EAN = *offer.EAN
+		}
+
+		i, ok := groupIdx[offer.SupplierID]
+		if !ok {
+			i = len(groups)
+			groupIdx[offer.SupplierID] = i
+			groups = append(groups, dropshipGroup{SupplierID: offer.SupplierID})
+		}
+		groups[i].Items = append(groups[i].Items, item)
+	}
+	return groups, warnings
+}
+
+// chooseOffer picks the supplier product for a line. A pinned supplier is
+// used regardless of its reported stock; otherwise the cheapest offer with
+// enough stock wins. Offers matching the variant's EAN or SKU take precedence
+// over other offers of the product.
+func chooseOffer(line dropshipLine, rule model.FulfillmentRule, offers []model.SupplierProduct) *model.SupplierProduct {
+	candidates := offers
+	if line.VariantID != nil {
+		var matching []model.SupplierProduct
+		for _, o := range offers {
+			if (line.EAN != "" && o.EAN != nil && *o.EAN == line.EAN) || (line.SKU != "" && o.SKU != nil && *o.SKU == line.SKU) {
+				matching = append(matching, o)
+			}
+		}
+		if len(matching) > 0 {
+			candidates = matching
+		}
+	}
+
+	for i := range candidates {
+		o := &candidates[i]
+		if rule.SupplierID != nil {
+			if o.SupplierID == *rule.SupplierID {
+				return o
+			}
+			continue
+		}
+		if o.StockQuantity >= line.Quantity {
+			return o
+		}
+	}
+	return nil
+}
+
+// RouteOrder routes the unrouted lines of an order to suppliers and sends
+// the resulting supplier orders. Lines already assigned to a supplier order
+// are left alone, so the order can be routed again after a supplier order
+// was cancelled.
+func (s *DropshipService) RouteOrder(ctx context.Context, tenantID, orderID, actorID uuid.UUID, ip string) (*model.DropshipRouting, error) {
+	routing := &model.DropshipRouting{
+		OrderID:        orderID,
+		SupplierOrders: []model.SupplierOrder{},
+		Warnings:       []string{},
+	}
+
+	err := database.WithTenant(ctx, s.pool, tenantID, func(tx pgx.Tx) error {
+		order, err := s.orderRepo.FindByID(ctx, tx, orderID)
+		if err != nil {
+			return err
+		}
+		if order == nil {
+			return ErrOrderNotFound
+		}
+
+		var items []map[string]any
+		if len(order.Items) > 0 {
+			if err := json.Unmarshal(order.Items, &items); err != nil {
+				return NewValidationError(errors.New("order items cannot be read"))
+			}
+		}
+
+		lines, stock, err := s.resolveLines(ctx, tx, items)
+		if err != nil {
+			return err
+		}
+
+		productIDs := make([]uuid.UUID, 0, len(lines))
+		seen := make(map[uuid.UUID]bool)
+		for _, l := range lines {
+			if !seen[l.ProductID] {
+				seen[l.ProductID] = true
+				productIDs = append(productIDs, l.ProductID)
+			}
+		}
+
+		var groups []dropshipGroup
+		if len(productIDs) > 0 {
+			ruleList, err := s.ruleRepo.FindByProductIDs(ctx, tx, productIDs)
+			if err != nil {
+				return err
+			}
+			rules := make(map[uuid.UUID]model.FulfillmentRule, len(ruleList))
+			for _, r := range ruleList {
+				rules[r.ProductID] = r
+			}
+
+			offers, err := s.loadOffers(ctx, tx, productIDs)
+			if err != nil {
+				return err
+			}
+
+			var warnings []string
+			groups, warnings = planDropship(lines, rules, stock, offers)
+			routing.Warnings = append(routing.Warnings, warnings...)
+		}
+
+		for _, g := range groups {
+			supplier, err := s.supplierRepo.FindByID(ctx, tx, g.SupplierID)
+			if err != nil {
+				return err
+			}
+			if supplier == nil {
+				return ErrSupplierNotFound
+			}
+			settings, _ := model.ParseSupplierDropshipSettings(supplier.Settings)
+
+			token, err := generateCallbackToken()
+			if err != nil {
+				return err
+			}
+			so := &model.SupplierOrder{
+				ID:            uuid.New(),
+				TenantID:      tenantID,
+				SupplierID:    g.SupplierID,
+				SupplierName:  supplier.Name,
+				OrderID:       orderID,
+				Status:        model.SupplierOrderPending,
+				Items:         g.Items,
+				Channel:       settings.Channel,
+				CallbackToken: token,
+			}
+			for _, item := range g.Items {
+				so.TotalCost += item.UnitCost * float64(item.Quantity)
+				items[item.LineIndex]["dropship_supplier_id"] = g.SupplierID.String()
+				items[item.LineIndex]["supplier_order_id"] = so.ID.String()
+			}
+			if err := s.supplierOrderRepo.Create(ctx, tx, so); err != nil {
+				return fmt.Errorf("create supplier order: %w", err)
+			}
+			routing.SupplierOrders = append(routing.SupplierOrders, *so)
+		}
+
+		if len(groups) > 0 {
+			raw, err := json.Marshal(items)
+			if err != nil {
+				return fmt.Errorf("marshal order items: %w", err)
+			}
+			if err := s.orderRepo.SetItems(ctx, tx, orderID, raw); err != nil {
+				return err
+			}
+		}
+		if err := s.orderRepo.MarkFulfillmentRouted(ctx, tx, orderID); err != nil {
+			return err
+		}
+
+		if len(groups) == 0 {
+			return nil
+		}
+		supplierOrderIDs := make([]uuid.UUID, len(routing.SupplierOrders))
+		for i, so := range routing.SupplierOrders {
+			supplierOrderIDs[i] = so.ID
+		}
+		return s.auditRepo.Log(ctx, tx, model.AuditEntry{
+			TenantID:   tenantID,
+			UserID:     actorID,
+			Action:     "order.dropship_routed",
+			EntityType: "order",
+			EntityID:   orderID,
+			Changes:    map[string]any{"supplier_order_ids": supplierOrderIDs, "warnings": routing.Warnings},
+			IPAddress:  ip,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i, so := range routing.SupplierOrders {
+		sent, err := s.Send(ctx, tenantID, so.ID, actorID, ip)
+		if err != nil {
+			s.logger.Error("dropship: send supplier order failed", "supplier_order_id", so.ID, "error", err)
+			continue
+		}
+		routing.SupplierOrders[i] = *sent
+	}
+	return routing, nil
+}
+
+// resolveLines finds the products of the order lines and their own stock.
+// Lines without a product and lines already sent to a supplier are skipped.
+func (s *DropshipService) resolveLines(ctx context.Context, tx pgx.Tx, items []map[string]any) ([]dropshipLine, map[uuid.UUID]int, error) {
+	var lines []dropshipLine
+	stock := make(map[uuid.UUID]int)
+
+	for idx, item := range items {
+		if _, routed := item["supplier_order_id"]; routed {
+			continue
+		}
+		quantity := itemInt(item["quantity"])
+		if quantity <= 0 {
+			quantity = 1
+		}
+
+		var product *model.Product
+		var err error
+		if id, ok := itemUUID(item["product_id"]); ok {
+			product, err = s.productRepo.FindByID(ctx, tx, id)
+		} else if sku := itemString(item["sku"]); sku != "" {
+			product, err = s.productRepo.FindBySKU(ctx, tx, sku)
+		} else if ean := itemString(item["ean"]); ean != "" {
+			product, err = s.productRepo.FindByEAN(ctx, tx, ean)
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		if product == nil {
+			continue
+		}
+
+		line := dropshipLine{
+			Index:     idx,
+			ProductID: product.ID,
+			Name:      itemString(item["name"]),
+			SKU:       itemString(item["sku"]),
+			EAN:       itemString(item["ean"]),
+			Quantity:  quantity,
+			StockKey:  product.ID,
+		}
+		if line.Name == "" {
+			line.Name = product.Name
+		}
+		if line.SKU == "" && product.SKU != nil {
+			line.SKU = *product.SKU
+		}
+		if line.EAN == "" && product.EAN != nil {
+			line.EAN = *product.EAN
+		}
+		stockQty := product.StockQuantity
+
+		if variantID, ok := itemUUID(item["variant_id"]); ok {
+			variant, err := s.variantRepo.FindByID(ctx, tx, variantID)
+			if err != nil {
+				return nil, nil, err
+			}
+			if variant != nil && variant.ProductID == product.ID {
+				line.VariantID = &variant.ID
+				line.StockKey = variant.ID
+				stockQty = variant.StockQuantity
+				if variant.SKU != nil {
+					line.SKU = *variant.SKU
+				}
+				if variant.EAN != nil {
+					line.EAN = *variant.EAN
+				}
+			}
+		}
+
+		if _, ok := stock[line.StockKey]; !ok {
+			stock[line.StockKey] = stockQty
+		}
+		lines = append(lines, line)
+	}
+	return lines, stock, nil
+}
+
+// loadOffers returns the linked supplier products per product, limited to
+// suppliers that can receive orders.
+func (s *DropshipService) loadOffers(ctx context.Context, tx pgx.Tx, productIDs []uuid.UUID) (map[uuid.UUID][]model.SupplierProduct, error) {
+	list, err := s.supplierProdRepo.ListOffersForProducts(ctx, tx, productIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	configured := make(map[uuid.UUID]bool)
+	offers := make(map[uuid.UUID][]model.SupplierProduct)
+	for _, sp := range list {
+		ok, checked := configured[sp.SupplierID]
+		if !checked {
+			supplier, err := s.supplierRepo.FindByID(ctx, tx, sp.SupplierID)
+			if err != nil {
+				return nil, err
+			}
+			if supplier != nil {
+				settings, err := model.ParseSupplierDropshipSettings(supplier.Settings)
+				ok = err == nil && settings.Configured()
+			}
+			configured[sp.SupplierID] = ok
+		}
+		if ok && sp.ProductID != nil {
+			offers[*sp.ProductID] = append(offers[*sp.ProductID], sp)
+		}
+	}
+	return offers, nil
+}
+
+func itemString(v any) string {
+	switch t := v.(type) {
+	case string:
+		return strings.TrimSpace(t)
+	case float64:
+		return strconv.FormatFloat(t, 'f', -1, 64)
+	}
+	return ""
+}
+
+func itemInt(v any) int {
+	switch t := v.(type) {
+	case float64:
+		return int(t)
+	case string:
+		n, _ := strconv.Atoi(t)
+		return n
+	}
+	return 0
+}
+
+func itemUUID(v any) (uuid.UUID, bool) {
+	s, ok := v.(string)
+	if !ok || s == "" {
+		return uuid.Nil, false
+	}
+	id, err := uuid.Parse(s)
+	return id, err == nil
+}
+
+func generateCallbackToken() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("generate callback token: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// --- Sending ---
+
+// Send delivers a supplier order over the supplier's channel. A failed
+// delivery is recorded on the supplier order and is not returned as an error.
+func (s *DropshipService) Send(ctx context.Context, tenantID, id, actorID uuid.UUID, ip string) (*model.SupplierOrder, error) {
+	var (
+		so       *model.SupplierOrder
+		supplier *model.Supplier
+		order    *model.Order
+		emailCfg model.EmailSettings
+		company  model.CompanySettings
+	)
+	err := database.WithTenant(ctx, s.pool, tenantID, func(tx pgx.Tx) error {
+		var err error
+		so, err = s.supplierOrderRepo.FindByID(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		if so == nil {
+			return ErrSupplierOrderNotFound
+		}
+		if so.Status == model.SupplierOrderShipped || so.Status == model.SupplierOrderCancelled {
+			return NewValidationError(fmt.Errorf("supplier order is %s", so.Status))
+		}
+		if supplier, err = s.supplierRepo.FindByID(ctx, tx, so.SupplierID); err != nil {
+			return err
+		}
+		if supplier == nil {
+			return ErrSupplierNotFound
+		}
+		if order, err = s.orderRepo.FindByID(ctx, tx, so.OrderID); err != nil {
+			return err
+		}
+		if order == nil {
+			return ErrOrderNotFound
+		}
+		if err := s.settingsSection(ctx, tx, tenantID, "email", &emailCfg); err != nil {
+			return err
+		}
+		return s.settingsSection(ctx, tx, tenantID, "company", &company)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	settings, err := model.ParseSupplierDropshipSettings(supplier.Settings)
+	if err == nil && !settings.Configured() {
+		err = errors.New("supplier has no dropship channel configured")
+	}
+	if err == nil {
+		switch settings.Channel {
+		case model.SupplierOrderChannelEmail:
+			err = s.sendEmail(emailCfg, company, settings, so, order)
+		case model.SupplierOrderChannelWebhook:
+			err = s.sendWebhook(ctx, settings, so, order)
+		}
+	}
+
+	status := model.SupplierOrderSent
+	var errMsg *string
+	if err != nil {
+		status = model.SupplierOrderFailed
+		msg := err.Error()
+		errMsg = &msg
+		s.logger.Warn("dropship: supplier order delivery failed", "supplier_order_id", id, "channel", settings.Channel, "error", err)
+	}
+
+	err = database.WithTenant(ctx, s.pool, tenantID, func(tx pgx.Tx) error {
+		if err := s.supplierOrderRepo.RecordDelivery(ctx, tx, id, status, errMsg); err != nil {
+			return err
+		}
+		if err := s.auditRepo.Log(ctx, tx, model.AuditEntry{
+			TenantID:   tenantID,
+			UserID:     actorID,
+			Action:     "supplier_order." + status,
+			EntityType: "supplier_order",
+			EntityID:   id,
+			Changes:    map[string]any{"channel": settings.Channel, "error": errMsg},
+			IPAddress:  ip,
+		}); err != nil {
+			return err
+		}
+		so, err = s.supplierOrderRepo.FindByID(ctx, tx, id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return so, nil
+}
+
+func (s *DropshipService) sendEmail(cfg model.EmailSettings, company model.CompanySettings, settings model.SupplierDropshipSettings, so *model.SupplierOrder, order *model.Order) error {
+	subject := fmt.Sprintf("Zamowienie %s", shortID(so.ID))
+	if company.CompanyName != "" {
+		subject += " — " + company.CompanyName
+	}
+
+	var attachment mailAttachment
+	if settings.Attachment == "csv" {
+		data, err := supplierOrderCSV(so, order)
+		if err != nil {
+			return err
+		}
+		attachment = mailAttachment{Filename: "zamowienie-" + shortID(so.ID) + ".csv", ContentType: "text/csv", Data: data}
+	} else {
+		attachment = mailAttachment{Filename: "zamowienie-" + shortID(so.ID) + ".pdf", ContentType: "application/pdf", Data: supplierOrderPDF(so, order, company.CompanyName)}
+	}
+
+	return sendMailWithAttachments(cfg, settings.Email, subject, s.supplierOrderEmailBody(so, order, company.CompanyName), []mailAttachment{attachment})
+}
+
+func (s *DropshipService) supplierOrderEmailBody(so *model.SupplierOrder, order *model.Order, companyName string) string {
+	var rows strings.Builder
+	for _, item := range so.Items {
+		fmt.Fprintf(&rows, `<tr><td style="padding:6px;border-bottom:1px solid #e5e7eb;">%s</td><td style="padding:6px;border-bottom:1px solid #e5e7eb;">%s</td><td style="padding:6px;border-bottom:1px solid #e5e7eb;">%s</td><td style="padding:6px;border-bottom:1px solid #e5e7eb;text-align:right;">%d</td></tr>`,
+			html.EscapeString(item.ExternalID), html.EscapeString(item.Name), html.EscapeString(item.EAN), item.Quantity)
+	}
+	address := strings.Join(addressLines(order), "<br>")
+	link := s.frontendURL + "/supplier-order/" + so.CallbackToken
+
+	return fmt.Sprintf(`<!DOCTYPE html>
+<html><head><meta charset="utf-8"></head>
+<body style="font-family:Arial,sans-serif;max-width:600px;margin:0 auto;padding:20px;">
+<h2 style="color:#1a1a1a;">Zamowienie %s</h2>
+<p>Prosimy o wysylke ponizszych produktow bezposrednio do klienta.</p>
+<table style="width:100%%;border-collapse:collapse;">
+<tr><th style="padding:6px;text-align:left;">Kod</th><th style="padding:6px;text-align:left;">Nazwa</th><th style="padding:6px;text-align:left;">EAN</th><th style="padding:6px;text-align:right;">Ilosc</th></tr>
+%s
+</table>
+<h3 style="margin-top:20px;">Adres dostawy</h3>
+<p>%s</p>
+<p style="margin-top:20px;">Numer przesylki prosimy podac na stronie: <a href="%s">%s</a></p>
+<p style="color:#666;font-size:12px;margin-top:30px;">%s — Wiadomosc wygenerowana automatycznie przez OpenOMS</p>
+</body></html>`,
+		shortID(so.ID), rows.String(), address, link, link, html.EscapeString(companyName))
+}
+
+// addressLines formats the recipient and shipping address of an order.
+func addressLines(order *model.Order) []string {
+	lines := []string{html.EscapeString(order.CustomerName)}
+	var addr map[string]any
+	if len(order.ShippingAddress) > 0 && json.Unmarshal(order.ShippingAddress, &addr) == nil {
+		if name := itemString(addr["name"]); name != "" && name != order.CustomerName {
+			lines[0] = html.EscapeString(name)
+		}
+		for _, key := range []string{"company", "street"} {
+			if v := itemString(addr[key]); v != "" {
+				lines = append(lines, html.EscapeString(v))
+			}
+		}
+		city := strings.TrimSpace(itemString(addr["postal_code"]) + " " + itemString(addr["city"]))
+		if city != "" {
+			lines = append(lines, html.EscapeString(city))
+		}
+		if v := itemString(addr["country"]); v != "" {
+			lines = append(lines, html.EscapeString(v))
+		}
+	}
+	if order.CustomerPhone != nil && *order.CustomerPhone != "" {
+		lines = append(lines, "tel. "+html.EscapeString(*order.CustomerPhone))
+	}
+	return lines
+}
+
+// supplierOrderCSV renders the supplier order lines as a semicolon separated
+// file, one line per product with the shipping address repeated.
+func supplierOrderCSV(so *model.SupplierOrder, order *model.Order) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	w.Comma = ';'
+	address := strings.Join(addressLines(order), ", ")
+	address = html.UnescapeString(address)
+
+	if err := w.Write([]string{"order", "external_id", "name", "sku", "ean", "quantity", "unit_cost", "ship_to"}); err != nil {
+		return nil, err
+	}
+	for _, item := range so.Items {
+		if err := w.Write([]string{
+			shortID(so.ID), item.ExternalID, item.Name, item.SKU, item.EAN,
+			strconv.Itoa(item.Quantity), strconv.FormatFloat(item.UnitCost, 'f', 2, 64), address,
+		}); err != nil {
+			return nil, err
+		}
+	}
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
+
+func supplierOrderPDF(so *model.SupplierOrder, order *model.Order, companyName string) []byte {
+	doc := pdf.New()
+	doc.Heading("Zamówienie " + shortID(so.ID))
+	if companyName != "" {
+		doc.Text("Zamawiający: " + companyName)
+	}
+	doc.Text("Data: " + so.CreatedAt.Format("2006-01-02"))
+	doc.Space(10)
+
+	doc.Bold("Adres dostawy")
+	for _, line := range addressLines(order) {
+		doc.Text(html.UnescapeString(line))
+	}
+	doc.Space(10)
+
+	rows := make([][]string, len(so.Items))
+	for i, item := range so.Items {
+		rows[i] = []string{item.ExternalID, item.Name, item.EAN, strconv.Itoa(item.Quantity)}
+	}
+	doc.Table([]pdf.Column{
+		{Title: "Kod", Width: 90},
+		{Title: "Nazwa", Width: 250},
+		{Title: "EAN", Width: 110},
+		{Title: "Ilość", Width: pdf.ContentWidth - 450, AlignRight: true},
+	}, rows)
+	return doc.Bytes()
+}
+
+func shortID(id uuid.UUID) string {
+	return strings.ToUpper(id.String()[:8])
+}
+
+// supplierOrderWebhookPayload is the JSON posted to a supplier's webhook.
+type supplierOrderWebhookPayload struct {
+	Event           string                    `json:"event"`
+	SupplierOrderID uuid.UUID                 `json:"supplier_order_id"`
+	OrderNumber     string                    `json:"order_number"`
+	Items           []model.SupplierOrderItem `json:"items"`
+	TotalCost       float64                   `json:"total_cost"`
+	Customer        map[string]any            `json:"customer"`
+	ShippingAddress json.RawMessage           `json:"shipping_address,omitempty"`
+	CallbackURL     string                    `json:"callback_url"`
+	CreatedAt       time.Time                 `json:"created_at"`
+}
+
+func (s *DropshipService) sendWebhook(ctx context.Context, settings model.SupplierDropshipSettings, so *model.SupplierOrder, order *model.Order) error {
+	body, err := json.Marshal(supplierOrderWebhookPayload{
+		Event:           "supplier_order.created",
+		SupplierOrderID: so.ID,
+		OrderNumber:     shortID(so.ID),
+		Items:           so.Items,
+		TotalCost:       so.TotalCost,
+		Customer: map[string]any{
+			"name":  order.CustomerName,
+			"phone": order.CustomerPhone,
+		},
+		ShippingAddress: order.ShippingAddress,
+		CallbackURL:     s.baseURL + "/v1/public/supplier-orders/" + so.CallbackToken + "/tracking",
+		CreatedAt:       so.CreatedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("marshal webhook payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, settings.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Webhook-Event", "supplier_order.created")
+	if settings.WebhookSecret != "" {
+		mac := hmac.New(sha256.New, []byte(settings.WebhookSecret))
+		mac.Write(body)
+		req.Header.Set("X-Webhook-Signature", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10)) //nolint:errcheck
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// --- Tracking ---
+
+// RecordTracking records the parcel a supplier shipped: it creates a
+// shipment for the order, tracked by the carrier integration when one is
+// active, and marks the order lines as fulfilled by the supplier.
+func (s *DropshipService) RecordTracking(ctx context.Context, tenantID, id uuid.UUID, req model.SupplierTrackingRequest, actorID uuid.UUID, ip string) (*model.SupplierOrder, error) {
+	if err := req.Validate(); err != nil {
+		return nil, NewValidationError(err)
+	}
+
+	var so *model.SupplierOrder
+	err := database.WithTenant(ctx, s.pool, tenantID, func(tx pgx.Tx) error {
+		var err error
+		so, err = s.supplierOrderRepo.FindByID(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		if so == nil {
+			return ErrSupplierOrderNotFound
+		}
+		if so.Status == model.SupplierOrderShipped || so.Status == model.SupplierOrderCancelled {
+			return NewValidationError(fmt.Errorf("supplier order is %s", so.Status))
+		}
+
+		provider := req.Carrier
+		if provider == "" {
+			provider = "supplier"
+		}
+		shipment := &model.Shipment{
+			ID:             uuid.New(),
+			TenantID:       tenantID,
+			OrderID:        so.OrderID,
+			Provider:       provider,
+			TrackingNumber: &req.TrackingNumber,
+			Status:         "picked_up",
+		}
+		if req.Carrier != "" {
+			integ, err := s.integrationRepo.FindByProvider(ctx, tx, req.Carrier)
+			if err != nil {
+				return err
+			}
+			if integ != nil && integ.Status == "active" {
+				shipment.IntegrationID = &integ.ID
+			}
+		}
+		shipment.CarrierData, _ = json.Marshal(map[string]any{
+			"supplier_id":       so.SupplierID,
+			"supplier_order_id": so.ID,
+		})
+		if err := s.shipmentRepo.Create(ctx, tx, shipment); err != nil {
+			return fmt.Errorf("create shipment: %w", err)
+		}
+
+		if err := s.markLines(ctx, tx, so, func(item map[string]any) {
+			item["fulfilled_by_supplier_id"] = so.SupplierID.String()
+		}); err != nil {
+			return err
+		}
+
+		if err := s.supplierOrderRepo.MarkShipped(ctx, tx, so.ID, req.Carrier, req.TrackingNumber, shipment.ID); err != nil {
+			return err
+		}
+		if err := s.auditRepo.Log(ctx, tx, model.AuditEntry{
+			TenantID:   tenantID,
+			UserID:     actorID,
+			Action:     "supplier_order.shipped",
+			EntityType: "supplier_order",
+			EntityID:   so.ID,
+			Changes:    map[string]any{"carrier": req.Carrier, "tracking_number": req.TrackingNumber, "shipment_id": shipment.ID},
+			IPAddress:  ip,
+		}); err != nil {
+			return err
+		}
+		so, err = s.supplierOrderRepo.FindByID(ctx, tx, id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if s.webhookDispatch != nil {
+		go s.webhookDispatch.Dispatch(context.Background(), tenantID, "supplier_order.shipped", so)
+	}
+	return so, nil
+}
+
+// Cancel cancels a supplier order that has not shipped and releases its
+// order lines, so they can be routed again.
+func (s *DropshipService) Cancel(ctx context.Context, tenantID, id, actorID uuid.UUID, ip string) (*model.SupplierOrder, error) {
+	var so *model.SupplierOrder
+	err := database.WithTenant(ctx, s.pool, tenantID, func(tx pgx.Tx) error {
+		var err error
+		so, err = s.supplierOrderRepo.FindByID(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		if so == nil {
+			return ErrSupplierOrderNotFound
+		}
+		if so.Status == model.SupplierOrderShipped || so.Status == model.SupplierOrderCancelled {
+			return NewValidationError(fmt.Errorf("supplier order is %s", so.Status))
+		}
+		if err := s.supplierOrderRepo.UpdateStatus(ctx, tx, id, model.SupplierOrderCancelled); err != nil {
+			return err
+		}
+		if err := s.markLines(ctx, tx, so, func(item map[string]any) {
+			delete(item, "dropship_supplier_id")
+			delete(item, "supplier_order_id")
+		}); err != nil {
+			return err
+		}
+		if err := s.auditRepo.Log(ctx, tx, model.AuditEntry{
+			TenantID:   tenantID,
+			UserID:     actorID,
+			Action:     "supplier_order.cancelled",
+			EntityType: "supplier_order",
+			EntityID:   id,
+			IPAddress:  ip,
+		}); err != nil {
+			return err
+		}
+		so, err = s.supplierOrderRepo.FindByID(ctx, tx, id)
+		return err
+	})
+	return so, err
+}
+
+// markLines applies fn to the order lines assigned to the supplier order.
+func (s *DropshipService) markLines(ctx context.Context, tx pgx.Tx, so *model.SupplierOrder, fn func(item map[string]any)) error {
+	order, err := s.orderRepo.FindByID(ctx, tx, so.OrderID)
+	if err != nil {
+		return err
+	}
+	if order == nil || len(order.Items) == 0 {
+		return nil
+	}
+	var items []map[string]any
+	if err := json.Unmarshal(order.Items, &items); err != nil {
+		return nil
+	}
+	changed := false
+	for _, item := range items {
+		if itemString(item["supplier_order_id"]) == so.ID.String() {
+			fn(item)
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+	raw, err := json.Marshal(items)
+	if err != nil {
+		return fmt.Errorf("marshal order items: %w", err)
+	}
+	return s.orderRepo.SetItems(ctx, tx, order.ID, raw)
+}
+
+// --- Public supplier access ---
+
+// findByToken resolves a supplier order callback token using a SECURITY
+// DEFINER function, since the public endpoints have no tenant context.
+func (s *DropshipService) findByToken(ctx context.Context, token string) (uuid.UUID, uuid.UUID, error) {
+	var id, tenantID uuid.UUID
+	err := s.pool.QueryRow(ctx,
+		"SELECT id, tenant_id FROM find_supplier_order_by_token($1)", token,
+	).Scan(&id, &tenantID)
+	if err == pgx.ErrNoRows {
+		return uuid.Nil, uuid.Nil, ErrSupplierOrderNotFound
+	}
+	if err != nil {
+		return uuid.Nil, uuid.Nil, fmt.Errorf("find supplier order by token: %w", err)
+	}
+	return id, tenantID, nil
+}
+
+// GetByToken returns the supplier's view of a supplier order.
+func (s *DropshipService) GetByToken(ctx context.Context, token string) (*model.PublicSupplierOrder, error) {
+	id, tenantID, err := s.findByToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	var view *model.PublicSupplierOrder
+	err = database.WithTenant(ctx, s.pool, tenantID, func(tx pgx.Tx) error {
+		so, err := s.supplierOrderRepo.FindByID(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		if so == nil || so.Status == model.SupplierOrderCancelled {
+			return ErrSupplierOrderNotFound
+		}
+		order, err := s.orderRepo.FindByID(ctx, tx, so.OrderID)
+		if err != nil {
+			return err
+		}
+		if order == nil {
+			return ErrSupplierOrderNotFound
+		}
+		var company model.CompanySettings
+		if err := s.settingsSection(ctx, tx, tenantID, "company", &company); err != nil {
+			return err
+		}
+		view = &model.PublicSupplierOrder{
+			ID:              so.ID,
+			Status:          so.Status,
+			CompanyName:     company.CompanyName,
+			Items:           so.Items,
+			TotalCost:       so.TotalCost,
+			CustomerName:    order.CustomerName,
+			CustomerPhone:   order.CustomerPhone,
+			ShippingAddress: order.ShippingAddress,
+			Carrier:         so.Carrier,
+			TrackingNumber:  so.TrackingNumber,
+			CreatedAt:       so.CreatedAt,
+		}
+		return nil
+	})
+	return view, err
+}
+
+// RecordTrackingByToken records tracking sent by the supplier through the
+// callback URL of a supplier order.
+func (s *DropshipService) RecordTrackingByToken(ctx context.Context, token string, req model.SupplierTrackingRequest, ip string) (*model.SupplierOrder, error) {
+	id, tenantID, err := s.findByToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	return s.RecordTracking(ctx, tenantID, id, req, uuid.Nil, ip)
+}
+
+// --- Worker ---
+
+// ProcessTenant routes the tenant's unrouted orders in the configured
+// statuses and retries undelivered supplier orders. It returns the number
+// of routed orders and sent supplier orders.
+func (s *DropshipService) ProcessTenant(ctx context.Context, tenantID uuid.UUID) (int, int, error) {
+	var settings model.DropshipSettings
+	var orderIDs, retryIDs []uuid.UUID
+	err := database.WithTenant(ctx, s.pool, tenantID, func(tx pgx.Tx) error {
+		if err := s.settingsSection(ctx, tx, tenantID, "dropshipping", &settings); err != nil {
+			return err
+		}
+		staleBefore := time.Now().Add(-dropshipRouteMaxAge)
+		if !settings.Enabled {
+			staleBefore = time.Now()
+		}
+		if _, err := s.orderRepo.MarkStaleFulfillmentRouted(ctx, tx, staleBefore); err != nil {
+			return err
+		}
+		if !settings.Enabled {
+			return nil
+		}
+		var err error
+		if orderIDs, err = s.orderRepo.ListFulfillmentUnrouted(ctx, tx, settings.Statuses(), dropshipRouteBatch); err != nil {
+			return err
+		}
+		retryIDs, err = s.supplierOrderRepo.ListRetryable(ctx, tx, supplierOrderMaxAttempts, dropshipRouteBatch)
+		return err
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	routed, sent := 0, 0
+	for _, orderID := range orderIDs {
+		routing, err := s.RouteOrder(ctx, tenantID, orderID, uuid.Nil, "")
+		if err != nil {
+			s.logger.Error("dropship: route order failed", "tenant_id", tenantID, "order_id", orderID, "error", err)
+			continue
+		}
+		routed++
+		for _, so := range routing.SupplierOrders {
+			if so.Status == model.SupplierOrderSent {
+				sent++
+			}
+		}
+	}
+
+	// Retry supplier orders that were not delivered before this run.
+	for _, id := range retryIDs {
+		so, err := s.Send(ctx, tenantID, id, uuid.Nil, "")
+		if err != nil {
+			s.logger.Error("dropship: retry supplier order failed", "tenant_id", tenantID, "supplier_order_id", id, "error", err)
+			continue
+		}
+		if so.Status == model.SupplierOrderSent {
+			sent++
+		}
+	}
+	return routed, sent, nil
+}
+
+// settingsSection reads a section of the tenant settings into dest.
+func (s *DropshipService) settingsSection(ctx context.Context, tx pgx.Tx, tenantID uuid.UUID, key string, dest any) error {
+	raw, err := s.tenantRepo.GetSettings(ctx, tx, tenantID)
+	if err != nil {
+		return err
+	}
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &all); err != nil {
+		return nil
+	}
+	if section, ok := all[key]; ok {
+		if err := json.Unmarshal(section, dest); err != nil {
+			s.logger.Warn("dropship: invalid settings section", "key", key, "tenant_id", tenantID, "error", err)
+		}
+	}
+	return nil
+}
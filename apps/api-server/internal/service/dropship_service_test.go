@@ -0,0 +1,102 @@
+package service
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openoms-org/openoms/apps/api-server/internal/model"
+)
+
+func TestPlanDropship(t *testing.T) {
+	cheap, pricey, pinned := uuid.New(), uuid.New(), uuid.New()
+	ownOnly, stockFirst, always, variantProduct := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	variantID := uuid.New()
+	ean := "5901234123457"
+
+	offer := func(supplierID, productID uuid.UUID, price float64, stock int) model.SupplierProduct {
+		return model.SupplierProduct{ID: uuid.New(), SupplierID: supplierID, ProductID: &productID, ExternalID: "X-" + supplierID.String()[:4], Price: &price, StockQuantity: stock}
+	}
+	variantOffer := offer(pricey, variantProduct, 30, 10)
+	variantOffer.EAN = &ean
+
+	rules := map[uuid.UUID]model.FulfillmentRule{
+		ownOnly:        {ProductID: ownOnly, Mode: model.FulfillmentOwnStock},
+		stockFirst:     {ProductID: stockFirst, Mode: model.FulfillmentStockFirst},
+		always:         {ProductID: always, Mode: model.FulfillmentDropship, SupplierID: &pinned},
+		variantProduct: {ProductID: variantProduct, Mode: model.FulfillmentDropship},
+	}
+	offers := map[uuid.UUID][]model.SupplierProduct{
+		stockFirst:     {offer(cheap, stockFirst, 10, 1), offer(pricey, stockFirst, 12, 50)},
+		always:         {offer(cheap, always, 5, 100), offer(pinned, always, 8, 0)},
+		variantProduct: {offer(cheap, variantProduct, 20, 10), variantOffer},
+	}
+	stock := map[uuid.UUID]int{ownOnly: 0, stockFirst: 3, always: 100, variantID: 0}
+
+	lines := []dropshipLine{
+		{Index: 0, ProductID: ownOnly, Name: "Own", Quantity: 5, StockKey: ownOnly},
+		// Covered by own stock.
+		{Index: 1, ProductID: stockFirst, Name: "First", Quantity: 2, StockKey: stockFirst},
+		// Only one left in stock; the cheap supplier has too little, too.
+		{Index: 2, ProductID: stockFirst, Name: "First again", Quantity: 2, StockKey: stockFirst},
+		// Pinned supplier is used despite a cheaper offer and no stock.
+		{Index: 3, ProductID: always, Name: "Always", Quantity: 1, StockKey: always},
+		// Variant matches the offer with its EAN.
+		{Index: 4, ProductID: variantProduct, VariantID: &variantID, EAN: ean, Name: "Variant", Quantity: 1, StockKey: variantID},
+	}
+
+	groups, warnings := planDropship(lines, rules, stock, offers)
+	assert.Empty(t, warnings)
+	require.Len(t, groups, 2)
+
+	assert.Equal(t, pricey, groups[0].SupplierID)
+	require.Len(t, groups[0].Items, 2)
+	assert.Equal(t, 2, groups[0].Items[0].LineIndex)
+	assert.Equal(t, 12.0, groups[0].Items[0].UnitCost)
+	assert.Equal(t, 4, groups[0].Items[1].LineIndex)
+	assert.Equal(t, variantOffer.ID, groups[0].Items[1].SupplierProductID)
+
+	assert.Equal(t, pinned, groups[1].SupplierID)
+	assert.Equal(t, 3, groups[1].Items[0].LineIndex)
+}
+
+func TestPlanDropship_NoSupplier(t *testing.T) {
+	productID := uuid.New()
+	rules := map[uuid.UUID]model.FulfillmentRule{productID: {ProductID: productID, Mode: model.FulfillmentDropship}}
+	lines := []dropshipLine{{Index: 0, ProductID: productID, Name: "Lamp", Quantity: 3, StockKey: productID}}
+
+	groups, warnings := planDropship(lines, rules, map[uuid.UUID]int{}, nil)
+	assert.Empty(t, groups)
+	require.Len(t, warnings, 1)
+	assert.Contains(t, warnings[0], "Lamp")
+}
+
+func TestSupplierOrderAttachments(t *testing.T) {
+	phone := "+48 600 100 200"
+	order := &model.Order{
+		CustomerName:    "Jan Kowalski",
+		CustomerPhone:   &phone,
+		ShippingAddress: []byte(`{"street":"Długa 5","city":"Łódź","postal_code":"90-001","country":"PL"}`),
+	}
+	so := &model.SupplierOrder{
+		ID:    uuid.New(),
+		Items: []model.SupplierOrderItem{{ExternalID: "A1", Name: "Kubek", EAN: "590", Quantity: 2, UnitCost: 7.5}},
+	}
+
+	data, err := supplierOrderCSV(so, order)
+	require.NoError(t, err)
+	r := csv.NewReader(bytes.NewReader(data))
+	r.Comma = ';'
+	records, err := r.ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, []string{"A1", "Kubek"}, records[1][1:3])
+	assert.Equal(t, "7.50", records[1][6])
+	assert.Equal(t, "Jan Kowalski, Długa 5, 90-001 Łódź, PL, tel. +48 600 100 200", records[1][7])
+
+	assert.True(t, bytes.HasPrefix(supplierOrderPDF(so, order, "Sklep"), []byte("%PDF-")))
+}
@@ -1,11 +1,16 @@
 package service
 
 import (
+	"bytes"
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	"mime"
+	"mime/multipart"
 	"net/smtp"
+	"net/textproto"
 	"slices"
 	"strings"
 
@@ -18,35 +23,29 @@ import (
 )
 
 type EmailService struct {
-	tenantRepo repository.TenantRepo
-	pool       *pgxpool.Pool
+	tenantRepo     repository.TenantRepo
+	pool           *pgxpool.Pool
+	orderEventRepo repository.OrderEventRepo
 }
 
 func NewEmailService(tenantRepo repository.TenantRepo, pool *pgxpool.Pool) *EmailService {
 	return &EmailService{tenantRepo: tenantRepo, pool: pool}
 }
 
+// SetOrderEventRepo sets the repository that records sent emails on the order
+// timeline.
+func (s *EmailService) SetOrderEventRepo(orderEventRepo repository.OrderEventRepo) {
+	s.orderEventRepo = orderEventRepo
+}
+
 func (s *EmailService) loadStatusConfig(ctx context.Context, tenantID uuid.UUID) *model.OrderStatusConfig {
 	var config *model.OrderStatusConfig
 	if err := database.WithTenant(ctx, s.pool, tenantID, func(tx pgx.Tx) error {
-		settings, err := s.tenantRepo.GetSettings(ctx, tx, tenantID)
+		cfg, err := loadOrderStatusConfig(ctx, tx, s.tenantRepo, tenantID)
 		if err != nil {
 			return err
 		}
-		if settings != nil {
-			var allSettings map[string]json.RawMessage
-			if err := json.Unmarshal(settings, &allSettings); err == nil {
-				if raw, ok := allSettings["order_statuses"]; ok {
-					var cfg model.OrderStatusConfig
-					if err := json.Unmarshal(raw, &cfg); err == nil && len(cfg.Statuses) > 0 {
-						config = &cfg
-						return nil
-					}
-				}
-			}
-		}
-		cfg := model.DefaultOrderStatusConfig()
-		config = &cfg
+		config = cfg
 		return nil
 	}); err != nil {
 		slog.Error("email: failed to load status config", "error", err, "tenant_id", tenantID)
@@ -98,10 +97,14 @@ func (s *EmailService) SendOrderStatusEmail(ctx context.Context, tenantID uuid.U
 
 	statusCfg := s.loadStatusConfig(ctx, tenantID)
 	subject, body := renderEmailTemplate(order, newStatus, emailCfg.FromName, statusCfg)
+	data := map[string]string{"recipient": *order.CustomerEmail, "subject": subject, "status": newStatus}
 	if err := sendMail(emailCfg, *order.CustomerEmail, subject, body); err != nil {
 		slog.Error("email: failed to send", "error", err, "to", *order.CustomerEmail, "status", newStatus, "order_id", order.ID)
+		data["error"] = err.Error()
+		recordNotification(ctx, s.pool, s.orderEventRepo, tenantID, order.ID, model.OrderEventEmailFailed, data)
 	} else {
 		slog.Info("email: sent successfully", "to", *order.CustomerEmail, "status", newStatus, "order_id", order.ID)
+		recordNotification(ctx, s.pool, s.orderEventRepo, tenantID, order.ID, model.OrderEventEmailSent, data)
 	}
 }
 
@@ -118,6 +121,47 @@ func (s *EmailService) SendTestEmail(ctx context.Context, settings model.EmailSe
 	return sendMail(settings, toEmail, subject, body)
 }
 
+// SendReport emails a generated report file to each recipient using the
+// tenant's SMTP settings. It fails when email is not enabled for the tenant
+// or any recipient could not be reached.
+func (s *EmailService) SendReport(ctx context.Context, tenantID uuid.UUID, recipients []string, subject, htmlBody, filename, contentType string, data []byte) error {
+	var settings json.RawMessage
+	err := database.WithTenant(ctx, s.pool, tenantID, func(tx pgx.Tx) error {
+		var err error
+		settings, err = s.tenantRepo.GetSettings(ctx, tx, tenantID)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("load tenant settings: %w", err)
+	}
+
+	var emailCfg model.EmailSettings
+	var allSettings map[string]json.RawMessage
+	if err := json.Unmarshal(settings, &allSettings); err == nil {
+		if emailRaw, ok := allSettings["email"]; ok {
+			if err := json.Unmarshal(emailRaw, &emailCfg); err != nil {
+				return fmt.Errorf("invalid email settings: %w", err)
+			}
+		}
+	}
+	if !emailCfg.Enabled {
+		return fmt.Errorf("email is not enabled in tenant settings")
+	}
+
+	attachments := []mailAttachment{{Filename: filename, ContentType: contentType, Data: data}}
+	var failed []string
+	for _, to := range recipients {
+		if err := sendMailWithAttachments(emailCfg, to, subject, htmlBody, attachments); err != nil {
+			slog.Error("email: failed to send report", "error", err, "to", to, "tenant_id", tenantID)
+			failed = append(failed, to)
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to send report to: %s", strings.Join(failed, ", "))
+	}
+	return nil
+}
+
 func renderEmailTemplate(order *model.Order, newStatus string, companyName string, statusCfg *model.OrderStatusConfig) (string, string) {
 	orderShort := order.ID.String()[:8]
 	customerName := order.CustomerName
@@ -142,14 +186,19 @@ func renderEmailTemplate(order *model.Order, newStatus string, companyName strin
 		}
 	}
 
+	// Extra info by status category, so custom statuses get it too
+	categoryCfg := statusCfg
+	if categoryCfg == nil {
+		defaultCfg := model.DefaultOrderStatusConfig()
+		categoryCfg = &defaultCfg
+	}
 	var extraInfo string
-	if newStatus == "shipped" || newStatus == "in_transit" {
+	switch categoryCfg.CategoryOf(newStatus) {
+	case "shipped":
 		extraInfo = `<p style="margin-top:15px;padding:12px;background:#f0f9ff;border-radius:6px;">Twoje zamowienie jest w drodze. Sledz przesylke u swojego kuriera.</p>`
-	}
-	if newStatus == "cancelled" {
+	case "cancelled":
 		extraInfo = `<p style="margin-top:15px;padding:12px;background:#fef2f2;border-radius:6px;">Jesli masz pytania dotyczace anulowania, skontaktuj sie z nami.</p>`
-	}
-	if newStatus == "refunded" {
+	case "refunded":
 		extraInfo = `<p style="margin-top:15px;padding:12px;background:#fffbeb;border-radius:6px;">Zwrot srodkow zostal zainicjowany. Pieniadze pojawia sie na Twoim koncie w ciagu kilku dni roboczych.</p>`
 	}
 
@@ -180,6 +229,19 @@ func renderEmailTemplate(order *model.Order, newStatus string, companyName strin
 }
 
 func sendMail(cfg model.EmailSettings, to, subject, htmlBody string) error {
+	return sendMailWithAttachments(cfg, to, subject, htmlBody, nil)
+}
+
+// mailAttachment is a file attached to an outgoing email.
+type mailAttachment struct {
+	Filename    string
+	ContentType string
+	Data        []byte
+}
+
+// sendMailWithAttachments sends an HTML email; with attachments the message
+// is sent as multipart/mixed.
+func sendMailWithAttachments(cfg model.EmailSettings, to, subject, htmlBody string, attachments []mailAttachment) error {
 	if cfg.SMTPHost == "" || cfg.FromEmail == "" {
 		return fmt.Errorf("SMTP not configured")
 	}
@@ -189,9 +251,14 @@ func sendMail(cfg model.EmailSettings, to, subject, htmlBody string) error {
 		from = fmt.Sprintf("%s <%s>", cfg.FromName, cfg.FromEmail)
 	}
 
-	headers := fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\nMIME-Version: 1.0\r\nContent-Type: text/html; charset=utf-8\r\n\r\n",
-		from, to, subject)
-	msg := []byte(headers + htmlBody)
+	var msg []byte
+	if len(attachments) == 0 {
+		headers := fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\nMIME-Version: 1.0\r\nContent-Type: text/html; charset=utf-8\r\n\r\n",
+			from, to, subject)
+		msg = []byte(headers + htmlBody)
+	} else {
+		msg = buildMultipartMessage(from, to, subject, htmlBody, attachments)
+	}
 
 	addr := fmt.Sprintf("%s:%d", cfg.SMTPHost, cfg.SMTPPort)
 
@@ -202,3 +269,30 @@ func sendMail(cfg model.EmailSettings, to, subject, htmlBody string) error {
 
 	return smtp.SendMail(addr, auth, cfg.FromEmail, []string{to}, msg)
 }
+
+func buildMultipartMessage(from, to, subject, htmlBody string, attachments []mailAttachment) []byte {
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+
+	fmt.Fprintf(&buf, "From: %s\r\nTo: %s\r\nSubject: %s\r\nMIME-Version: 1.0\r\nContent-Type: multipart/mixed; boundary=%q\r\n\r\n",
+		from, to, mime.QEncoding.Encode("utf-8", subject), mw.Boundary())
+
+	part, _ := mw.CreatePart(textproto.MIMEHeader{"Content-Type": {"text/html; charset=utf-8"}})
+	part.Write([]byte(htmlBody))
+
+	for _, a := range attachments {
+		part, _ := mw.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {fmt.Sprintf("%s; name=%q", a.ContentType, a.Filename)},
+			"Content-Disposition":       {fmt.Sprintf("attachment; filename=%q", a.Filename)},
+			"Content-Transfer-Encoding": {"base64"},
+		})
+		encoded := base64.StdEncoding.EncodeToString(a.Data)
+		for len(encoded) > 76 {
+			part.Write([]byte(encoded[:76] + "\r\n"))
+			encoded = encoded[76:]
+		}
+		part.Write([]byte(encoded + "\r\n"))
+	}
+	mw.Close()
+	return buf.Bytes()
+}
@@ -40,6 +40,25 @@ func TestRenderEmailTemplate_ShippedStatus(t *testing.T) {
 	assert.Contains(t, body, "w drodze")
 }
 
+func TestRenderEmailTemplate_CustomShippedCategory(t *testing.T) {
+	order := &model.Order{
+		ID:           uuid.New(),
+		CustomerName: "Anna Nowak",
+		TotalAmount:  50.00,
+		Currency:     "PLN",
+	}
+
+	cfg := &model.OrderStatusConfig{
+		Statuses: []model.StatusDef{
+			{Key: "handed_to_courier", Label: "Przekazane kurierowi", Color: "purple", Position: 1, Category: "shipped"},
+		},
+	}
+
+	_, body := renderEmailTemplate(order, "handed_to_courier", "TestShop", cfg)
+
+	assert.Contains(t, body, "w drodze")
+}
+
 func TestRenderEmailTemplate_CancelledStatus(t *testing.T) {
 	order := &model.Order{
 		ID:           uuid.New(),
@@ -113,3 +132,15 @@ func TestSendMail_MissingFromEmail(t *testing.T) {
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "SMTP not configured")
 }
+
+func TestBuildMultipartMessage(t *testing.T) {
+	msg := string(buildMultipartMessage("shop@test.com", "to@test.com", "Zamówienie", "<p>Body</p>", []mailAttachment{
+		{Filename: "order.csv", ContentType: "text/csv", Data: []byte("a;b\n")},
+	}))
+
+	assert.Contains(t, msg, "Content-Type: multipart/mixed; boundary=")
+	assert.Contains(t, msg, "Subject: =?utf-8?q?Zam=C3=B3wienie?=")
+	assert.Contains(t, msg, "<p>Body</p>")
+	assert.Contains(t, msg, `attachment; filename="order.csv"`)
+	assert.Contains(t, msg, "YTtiCg==")
+}
@@ -191,27 +191,8 @@ func (s *ExchangeRateService) ConvertAmount(ctx context.Context, tenantID uuid.U
 	var rate *model.ExchangeRate
 	err := database.WithTenant(ctx, s.pool, tenantID, func(tx pgx.Tx) error {
 		var err error
-		rate, err = s.exchangeRateRepo.GetRate(ctx, tx, from, to)
-		if err != nil {
-			return err
-		}
-		if rate == nil {
-			// Try reverse direction
-			rate, err = s.exchangeRateRepo.GetRate(ctx, tx, to, from)
-			if err != nil {
-				return err
-			}
-			if rate != nil {
-				// Invert the rate
-				invertedRate := 1.0 / rate.Rate
-				rate = &model.ExchangeRate{
-					Rate:           invertedRate,
-					BaseCurrency:   from,
-					TargetCurrency: to,
-				}
-			}
-		}
-		return nil
+		rate, err = s.rate(ctx, tx, from, to)
+		return err
 	})
 	if err != nil {
 		return nil, err
@@ -231,6 +212,42 @@ func (s *ExchangeRateService) ConvertAmount(ctx context.Context, tenantID uuid.U
 	}, nil
 }
 
+// convert converts amount between currencies within tx. It returns
+// ErrRateNotAvailable when no rate between them is stored.
+func (s *ExchangeRateService) convert(ctx context.Context, tx pgx.Tx, amount float64, from, to string) (float64, error) {
+	if from == to {
+		return amount, nil
+	}
+	rate, err := s.rate(ctx, tx, from, to)
+	if err != nil {
+		return 0, err
+	}
+	if rate == nil {
+		return 0, ErrRateNotAvailable
+	}
+	return math.Round(amount*rate.Rate*100) / 100, nil
+}
+
+// rate returns the rate converting from into to, inverting the reverse rate
+// when only that one is stored. It returns nil when neither is.
+func (s *ExchangeRateService) rate(ctx context.Context, tx pgx.Tx, from, to string) (*model.ExchangeRate, error) {
+	rate, err := s.exchangeRateRepo.GetRate(ctx, tx, from, to)
+	if err != nil || rate != nil {
+		return rate, err
+	}
+	// Try reverse direction
+	rate, err = s.exchangeRateRepo.GetRate(ctx, tx, to, from)
+	if err != nil || rate == nil {
+		return nil, err
+	}
+	// Invert the rate
+	return &model.ExchangeRate{
+		Rate:           1.0 / rate.Rate,
+		BaseCurrency:   from,
+		TargetCurrency: to,
+	}, nil
+}
+
 // NBP API response structures
 type nbpTable struct {
 	Table         string    `json:"table"`
@@ -0,0 +1,274 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/openoms-org/openoms/apps/api-server/internal/database"
+	"github.com/openoms-org/openoms/apps/api-server/internal/model"
+	"github.com/openoms-org/openoms/apps/api-server/internal/repository"
+)
+
+// InventoryCostingService values warehouse stock. Confirmed PZ documents and
+// the receiving side of MM documents open cost layers; WZ documents and the
+// issuing side of MM consume them by FIFO or weighted average, as set in the
+// tenant's inventory settings. Each line is journaled as a cost movement,
+// from which stock value at a date and cost of goods sold are reported.
+//
+// Stock not covered by layers (received before costing was introduced, or
+// adjusted outside documents) is costed at the product or variant cost price
+// and its movements are marked as estimated.
+type InventoryCostingService struct {
+	costRepo    repository.InventoryCostRepo
+	productRepo repository.ProductRepo
+	variantRepo repository.VariantRepo
+	tenantRepo  repository.TenantRepo
+	pool        *pgxpool.Pool
+}
+
+func NewInventoryCostingService(
+	costRepo repository.InventoryCostRepo,
+	productRepo repository.ProductRepo,
+	variantRepo repository.VariantRepo,
+	tenantRepo repository.TenantRepo,
+	pool *pgxpool.Pool,
+) *InventoryCostingService {
+	return &InventoryCostingService{
+		costRepo:    costRepo,
+		productRepo: productRepo,
+		variantRepo: variantRepo,
+		tenantRepo:  tenantRepo,
+		pool:        pool,
+	}
+}
+
+// ApplyDocument costs the items of a warehouse document being confirmed,
+// within the caller's transaction. PZ documents of returned orders carry
+// sale prices, so returned goods are received at the cost price instead.
+func (s *InventoryCostingService) ApplyDocument(ctx context.Context, tx pgx.Tx, doc *model.WarehouseDocument, items []model.WarehouseDocItem) error {
+	method, err := s.method(ctx, tx, doc.TenantID)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+
+	for _, item := range items {
+		switch doc.DocumentType {
+		case "PZ":
+			unitCost, estimated := 0.0, false
+			if item.UnitPrice != nil && doc.OrderID == nil {
+				unitCost = *item.UnitPrice
+			} else {
+				if unitCost, err = s.fallbackCost(ctx, tx, item); err != nil {
+					return err
+				}
+				estimated = true
+			}
+			if err := s.receive(ctx, tx, doc, item, doc.WarehouseID, method, unitCost, estimated, now); err != nil {
+				return fmt.Errorf("PZ costing: %w", err)
+			}
+		case "WZ":
+			if _, _, err := s.issue(ctx, tx, doc, item, doc.WarehouseID, method, now); err != nil {
+				return fmt.Errorf("WZ costing: %w", err)
+			}
+		case "MM":
+			if doc.TargetWarehouseID == nil {
+				continue
+			}
+			cost, estimated, err := s.issue(ctx, tx, doc, item, doc.WarehouseID, method, now)
+			if err != nil {
+				return fmt.Errorf("MM source costing: %w", err)
+			}
+			unitCost := model.RoundUnitCost(cost / float64(item.Quantity))
+			if err := s.receive(ctx, tx, doc, item, *doc.TargetWarehouseID, method, unitCost, estimated, now); err != nil {
+				return fmt.Errorf("MM target costing: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+// receive opens a cost layer and journals the receipt.
+func (s *InventoryCostingService) receive(ctx context.Context, tx pgx.Tx, doc *model.WarehouseDocument, item model.WarehouseDocItem, warehouseID uuid.UUID, method string, unitCost float64, estimated bool, at time.Time) error {
+	layer := &model.CostLayer{
+		ID:          uuid.New(),
+		TenantID:    doc.TenantID,
+		WarehouseID: warehouseID,
+		ProductID:   item.ProductID,
+		VariantID:   item.VariantID,
+		DocumentID:  doc.ID,
+		Quantity:    item.Quantity,
+		Remaining:   item.Quantity,
+		UnitCost:    unitCost,
+		ReceivedAt:  at,
+	}
+	if err := s.costRepo.CreateLayer(ctx, tx, layer); err != nil {
+		return err
+	}
+	return s.costRepo.CreateMovement(ctx, tx, &model.CostMovement{
+		ID:           uuid.New(),
+		TenantID:     doc.TenantID,
+		WarehouseID:  warehouseID,
+		ProductID:    item.ProductID,
+		VariantID:    item.VariantID,
+		DocumentID:   doc.ID,
+		DocumentType: doc.DocumentType,
+		Method:       method,
+		Quantity:     item.Quantity,
+		TotalCost:    model.RoundUnitCost(unitCost * float64(item.Quantity)),
+		Estimated:    estimated,
+		OccurredAt:   at,
+	})
+}
+
+// issue consumes cost layers for an item, journals the issue and returns its
+// cost and whether any of it was estimated.
+func (s *InventoryCostingService) issue(ctx context.Context, tx pgx.Tx, doc *model.WarehouseDocument, item model.WarehouseDocItem, warehouseID uuid.UUID, method string, at time.Time) (float64, bool, error) {
+	layers, err := s.costRepo.ListOpenLayers(ctx, tx, warehouseID, item.ProductID, item.VariantID)
+	if err != nil {
+		return 0, false, err
+	}
+	consumption := model.ConsumeCostLayers(layers, item.Quantity, method)
+	for _, layer := range consumption.Layers {
+		if err := s.costRepo.UpdateLayer(ctx, tx, layer); err != nil {
+			return 0, false, err
+		}
+	}
+
+	cost := consumption.Cost
+	estimated := consumption.Shortfall > 0
+	if estimated {
+		unitCost, err := s.fallbackCost(ctx, tx, item)
+		if err != nil {
+			return 0, false, err
+		}
+		cost = model.RoundUnitCost(cost + unitCost*float64(consumption.Shortfall))
+	}
+
+	err = s.costRepo.CreateMovement(ctx, tx, &model.CostMovement{
+		ID:           uuid.New(),
+		TenantID:     doc.TenantID,
+		WarehouseID:  warehouseID,
+		ProductID:    item.ProductID,
+		VariantID:    item.VariantID,
+		DocumentID:   doc.ID,
+		DocumentType: doc.DocumentType,
+		Method:       method,
+		Quantity:     -item.Quantity,
+		TotalCost:    -cost,
+		Estimated:    estimated,
+		OccurredAt:   at,
+	})
+	return cost, estimated, err
+}
+
+// fallbackCost is the cost price of the variant, else of the product, else
+// zero.
+func (s *InventoryCostingService) fallbackCost(ctx context.Context, tx pgx.Tx, item model.WarehouseDocItem) (float64, error) {
+	if item.VariantID != nil {
+		variant, err := s.variantRepo.FindByID(ctx, tx, *item.VariantID)
+		if err != nil {
+			return 0, err
+		}
+		if variant != nil && variant.CostPrice != nil {
+			return *variant.CostPrice, nil
+		}
+	}
+	product, err := s.productRepo.FindByID(ctx, tx, item.ProductID)
+	if err != nil {
+		return 0, err
+	}
+	if product != nil && product.CostPrice != nil {
+		return *product.CostPrice, nil
+	}
+	return 0, nil
+}
+
+// method returns the tenant's costing method.
+func (s *InventoryCostingService) method(ctx context.Context, tx pgx.Tx, tenantID uuid.UUID) (string, error) {
+	raw, err := s.tenantRepo.GetSettings(ctx, tx, tenantID)
+	if err != nil {
+		return "", err
+	}
+	var cfg model.InventorySettings
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &all); err == nil {
+		if section, ok := all["inventory"]; ok {
+			_ = json.Unmarshal(section, &cfg)
+		}
+	}
+	return cfg.Costing(), nil
+}
+
+// Valuation returns the value of stock at filter.At per warehouse and item.
+func (s *InventoryCostingService) Valuation(ctx context.Context, tenantID uuid.UUID, filter model.InventoryValuationFilter) (*model.InventoryValuationReport, error) {
+	report := model.InventoryValuationReport{At: filter.At}
+	err := database.WithTenant(ctx, s.pool, tenantID, func(tx pgx.Tx) error {
+		method, err := s.method(ctx, tx, tenantID)
+		if err != nil {
+			return err
+		}
+		report.Method = method
+		report.Rows, err = s.costRepo.GetValuation(ctx, tx, filter)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	report.Warehouses = []model.InventoryValuationTotal{}
+	index := map[uuid.UUID]int{}
+	for i := range report.Rows {
+		row := &report.Rows[i]
+		row.Value = roundReportAmount(row.Value)
+		if row.Quantity > 0 {
+			unitCost := model.RoundUnitCost(row.Value / float64(row.Quantity))
+			row.UnitCost = &unitCost
+		}
+		j, ok := index[row.WarehouseID]
+		if !ok {
+			j = len(report.Warehouses)
+			index[row.WarehouseID] = j
+			report.Warehouses = append(report.Warehouses, model.InventoryValuationTotal{
+				WarehouseID:   row.WarehouseID,
+				WarehouseName: row.WarehouseName,
+			})
+		}
+		report.Warehouses[j].Quantity += row.Quantity
+		report.Warehouses[j].Value += row.Value
+		report.Quantity += row.Quantity
+		report.Value += row.Value
+	}
+	for i := range report.Warehouses {
+		report.Warehouses[i].Value = roundReportAmount(report.Warehouses[i].Value)
+	}
+	report.Value = roundReportAmount(report.Value)
+	return &report, nil
+}
+
+// COGS returns the cost of goods issued on WZ documents in a period.
+func (s *InventoryCostingService) COGS(ctx context.Context, tenantID uuid.UUID, filter model.COGSFilter) (*model.COGSReport, error) {
+	report := model.COGSReport{GroupBy: filter.GroupBy}
+	err := database.WithTenant(ctx, s.pool, tenantID, func(tx pgx.Tx) error {
+		var err error
+		if report.Rows, err = s.costRepo.GetCOGS(ctx, tx, filter); err != nil {
+			return err
+		}
+		report.Quantity, report.Cost, err = s.costRepo.GetCOGSTotals(ctx, tx, filter)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	for i := range report.Rows {
+		report.Rows[i].Cost = roundReportAmount(report.Rows[i].Cost)
+	}
+	report.Cost = roundReportAmount(report.Cost)
+	return &report, nil
+}
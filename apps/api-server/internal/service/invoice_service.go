@@ -38,6 +38,7 @@ type InvoiceService struct {
 	auditRepo   repository.AuditRepo
 	pool        *pgxpool.Pool
 	encKey      []byte
+	logger      *slog.Logger
 }
 
 func NewInvoiceService(
@@ -55,6 +56,7 @@ func NewInvoiceService(
 		auditRepo:   auditRepo,
 		pool:        pool,
 		encKey:      encKey,
+		logger:      slog.Default().With("service", "invoice"),
 	}
 }
 
@@ -253,7 +255,7 @@ func (s *InvoiceService) Cancel(ctx context.Context, tenantID, invoiceID, actorI
 			provider, provErr := s.getProvider(ctx, tx, tenantID, inv.Provider)
 			if provErr == nil {
 				if cancelErr := provider.CancelInvoice(ctx, *inv.ExternalID); cancelErr != nil {
-					slog.Error("failed to cancel invoice with provider", "invoice_id", invoiceID, "error", cancelErr)
+					s.logger.Error("failed to cancel invoice with provider", "invoice_id", invoiceID, "error", cancelErr)
 				}
 			}
 		}
@@ -413,7 +415,7 @@ func (s *InvoiceService) HandleOrderStatusChange(ctx context.Context, tenantID u
 		})
 	})
 	if err != nil {
-		slog.Error("auto-invoice failed", "tenant_id", tenantID, "order_id", order.ID, "error", err)
+		s.logger.Error("auto-invoice failed", "tenant_id", tenantID, "order_id", order.ID, "error", err)
 	}
 }
 
@@ -548,3 +550,266 @@ func (s *InvoiceService) buildInvoiceItems(order *model.Order, taxRate int) []in
 
 	return items
 }
+
+// correctableInvoice returns the latest issued VAT invoice of the order, or
+// nil when the order has none.
+func (s *InvoiceService) correctableInvoice(ctx context.Context, tx pgx.Tx, orderID uuid.UUID) (*model.Invoice, error) {
+	invoices, err := s.invoiceRepo.FindByOrderID(ctx, tx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	var latest *model.Invoice
+	for i := range invoices {
+		inv := &invoices[i]
+		if inv.InvoiceType != "vat" || inv.ExternalID == nil || *inv.ExternalID == "" {
+			continue
+		}
+		if inv.Status == "cancelled" || inv.Status == "error" || inv.Status == "draft" {
+			continue
+		}
+		if latest == nil || inv.CreatedAt.After(latest.CreatedAt) {
+			latest = inv
+		}
+	}
+	return latest, nil
+}
+
+// correctionRetryAfter is how long a draft return correction stays untouched
+// before IssuePendingCorrections retries it.
+const correctionRetryAfter = 5 * time.Minute
+
+// ReturnCorrection is a correction invoice recorded as a draft with a refund,
+// issued with the invoicing provider once the refund commits.
+type ReturnCorrection struct {
+	Invoice *model.Invoice
+	Request integration.CorrectionRequest
+}
+
+// CreateReturnCorrection records a draft correction invoice of original for a
+// refund of amount on the return, within tx. A full refund lists the received
+// lines, a partial refund a single line. The correction is issued with
+// IssueReturnCorrection after tx commits; the request is kept in the draft's
+// metadata so IssuePendingCorrections can retry it.
+func (s *InvoiceService) CreateReturnCorrection(ctx context.Context, tx pgx.Tx, tenantID uuid.UUID, order *model.Order, original *model.Invoice, ret *model.Return, amount float64, actorID uuid.UUID, ip string) (*ReturnCorrection, error) {
+	invoicingCfg, err := s.loadInvoicingSettings(ctx, tx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("load invoicing settings: %w", err)
+	}
+	taxRate := 23
+	if invoicingCfg != nil && invoicingCfg.DefaultTaxRate > 0 {
+		taxRate = invoicingCfg.DefaultTaxRate
+	}
+
+	issueDate := time.Now()
+	totalGross := -amount
+	totalNet := totalGross / (1 + float64(taxRate)/100)
+	customerEmail := ""
+	if order.CustomerEmail != nil {
+		customerEmail = *order.CustomerEmail
+	}
+	request := integration.CorrectionRequest{
+		InvoiceRequest: integration.InvoiceRequest{
+			OrderID:       order.ID.String(),
+			CustomerName:  order.CustomerName,
+			CustomerEmail: customerEmail,
+			Items:         correctionItems(ret, amount, taxRate),
+			TotalNet:      totalNet,
+			TotalGross:    totalGross,
+			Currency:      order.Currency,
+			IssueDate:     issueDate,
+			DueDate:       issueDate,
+		},
+		CorrectedExternalID: *original.ExternalID,
+		Reason:              "Zwrot towaru",
+	}
+	metadata, err := json.Marshal(correctionMetadata{
+		ReturnID:           ret.ID.String(),
+		CorrectedInvoiceID: original.ID.String(),
+		Request:            &request,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	correction := &model.Invoice{
+		ID:          uuid.New(),
+		TenantID:    tenantID,
+		OrderID:     order.ID,
+		Provider:    original.Provider,
+		Status:      "draft",
+		InvoiceType: "correction",
+		TotalNet:    &totalNet,
+		TotalGross:  &totalGross,
+		Currency:    order.Currency,
+		IssueDate:   &issueDate,
+		DueDate:     &issueDate,
+		Metadata:    metadata,
+	}
+	if err := s.invoiceRepo.Create(ctx, tx, correction); err != nil {
+		return nil, err
+	}
+
+	if err := s.auditRepo.Log(ctx, tx, model.AuditEntry{
+		TenantID:   tenantID,
+		UserID:     actorID,
+		Action:     "invoice.created",
+		EntityType: "invoice",
+		EntityID:   correction.ID,
+		Changes: map[string]string{
+			"order_id":          order.ID.String(),
+			"provider":          original.Provider,
+			"invoice_type":      "correction",
+			"corrected_invoice": original.ID.String(),
+		},
+		IPAddress: ip,
+	}); err != nil {
+		return nil, err
+	}
+	return &ReturnCorrection{Invoice: correction, Request: request}, nil
+}
+
+// correctionMetadata is the metadata of a return correction invoice. Request
+// is dropped once the correction is issued.
+type correctionMetadata struct {
+	ReturnID           string                         `json:"return_id"`
+	CorrectedInvoiceID string                         `json:"corrected_invoice_id"`
+	Request            *integration.CorrectionRequest `json:"correction_request,omitempty"`
+}
+
+// IssueReturnCorrection issues a draft return correction with the invoicing
+// provider outside any transaction and records the result. It runs on a
+// context detached from ctx, so a cancelled request does not abandon it. A
+// failed correction stays a draft with the error recorded and is retried by
+// IssuePendingCorrections; the refund does not fail.
+func (s *InvoiceService) IssueReturnCorrection(ctx context.Context, tenantID uuid.UUID, rc *ReturnCorrection) error {
+	ctx = context.WithoutCancel(ctx)
+	correction := rc.Invoice
+	var provider integration.InvoicingProvider
+	issueErr := database.WithTenant(ctx, s.pool, tenantID, func(tx pgx.Tx) error {
+		var err error
+		provider, err = s.getProvider(ctx, tx, tenantID, correction.Provider)
+		return err
+	})
+	if issueErr == nil {
+		if corrector, ok := provider.(integration.CorrectionCreator); !ok {
+			issueErr = fmt.Errorf("%s does not support correction invoices", correction.Provider)
+		} else if result, err := corrector.CreateCorrection(ctx, rc.Request); err != nil {
+			issueErr = err
+		} else {
+			correction.ExternalID = &result.ExternalID
+			correction.ExternalNumber = &result.ExternalNumber
+			correction.PDFURL = &result.PDFURL
+			correction.ErrorMessage = nil
+			correction.Status = "issued"
+			var meta correctionMetadata
+			if err := json.Unmarshal(correction.Metadata, &meta); err == nil {
+				meta.Request = nil
+				if raw, err := json.Marshal(meta); err == nil {
+					correction.Metadata = raw
+				}
+			}
+		}
+	}
+	if issueErr != nil {
+		errMsg := issueErr.Error()
+		correction.ErrorMessage = &errMsg
+		s.logger.Warn("correction invoice failed, will be retried",
+			"tenant_id", tenantID, "invoice_id", correction.ID, "error", issueErr)
+	}
+	return database.WithTenant(ctx, s.pool, tenantID, func(tx pgx.Tx) error {
+		return s.invoiceRepo.Update(ctx, tx, correction)
+	})
+}
+
+// IssuePendingCorrections retries the draft return corrections of the tenant
+// that were not issued after their refund, for example because the provider
+// failed or the process stopped. It returns how many were issued.
+func (s *InvoiceService) IssuePendingCorrections(ctx context.Context, tenantID uuid.UUID) (int, error) {
+	before := time.Now().Add(-correctionRetryAfter)
+	var pending []model.Invoice
+	err := database.WithTenant(ctx, s.pool, tenantID, func(tx pgx.Tx) error {
+		var err error
+		pending, err = s.invoiceRepo.FindPendingCorrections(ctx, tx, before, 50)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	issued := 0
+	for i := range pending {
+		correction := &pending[i]
+		var meta correctionMetadata
+		if err := json.Unmarshal(correction.Metadata, &meta); err != nil || meta.Request == nil {
+			s.logger.Error("pending correction has no request", "tenant_id", tenantID, "invoice_id", correction.ID, "error", err)
+			continue
+		}
+		var claimed bool
+		err := database.WithTenant(ctx, s.pool, tenantID, func(tx pgx.Tx) error {
+			var err error
+			claimed, err = s.invoiceRepo.ClaimPendingCorrection(ctx, tx, correction.ID, before)
+			return err
+		})
+		if err != nil {
+			return issued, err
+		}
+		if !claimed {
+			continue
+		}
+		if err := s.IssueReturnCorrection(ctx, tenantID, &ReturnCorrection{Invoice: correction, Request: *meta.Request}); err != nil {
+			return issued, err
+		}
+		if correction.Status == "issued" {
+			issued++
+		}
+	}
+	return issued, nil
+}
+
+// correctionItems builds the negative lines of a return correction. The
+// received lines are used when the whole refund due is corrected at once.
+func correctionItems(ret *model.Return, amount float64, taxRate int) []integration.InvoiceItem {
+	net := func(gross float64) float64 { return gross / (1 + float64(taxRate)/100) }
+	summary := []integration.InvoiceItem{{
+		Name:     "Zwrot " + ret.ID.String()[:8],
+		Quantity: -1,
+		NetPrice: net(amount),
+		TaxRate:  taxRate,
+		Unit:     "szt.",
+	}}
+	if ret.RefundedAmount > 0 || amount != ret.RefundAmount {
+		return summary
+	}
+
+	var received []model.ReturnReceivedItem
+	if err := json.Unmarshal(ret.ReceivedItems, &received); err != nil {
+		return summary
+	}
+	items := make([]integration.InvoiceItem, 0, len(received)+1)
+	for _, item := range received {
+		value := item.RefundValue()
+		if value <= 0 {
+			continue
+		}
+		items = append(items, integration.InvoiceItem{
+			Name:     item.Name,
+			Quantity: -item.Quantity,
+			NetPrice: net(value / float64(item.Quantity)),
+			TaxRate:  taxRate,
+			Unit:     "szt.",
+		})
+	}
+	if ret.ShippingRefund > 0 {
+		items = append(items, integration.InvoiceItem{
+			Name:     "Wysyłka",
+			Quantity: -1,
+			NetPrice: net(ret.ShippingRefund),
+			TaxRate:  taxRate,
+			Unit:     "szt.",
+		})
+	}
+	if len(items) == 0 {
+		return summary
+	}
+	return items
+}
@@ -9,11 +9,13 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 
+	"github.com/openoms-org/openoms/apps/api-server/internal/address"
 	"github.com/openoms-org/openoms/apps/api-server/internal/crypto"
 	"github.com/openoms-org/openoms/apps/api-server/internal/database"
 	"github.com/openoms-org/openoms/apps/api-server/internal/integration"
@@ -28,14 +30,15 @@ var (
 )
 
 type LabelService struct {
-	shipmentRepo    repository.ShipmentRepo
-	orderRepo       repository.OrderRepo
-	integrationRepo repository.IntegrationRepo
-	auditRepo       repository.AuditRepo
-	pool            *pgxpool.Pool
-	encryptionKey   []byte
-	uploadDir       string
-	baseURL         string
+	shipmentRepo     repository.ShipmentRepo
+	orderRepo        repository.OrderRepo
+	integrationRepo  repository.IntegrationRepo
+	auditRepo        repository.AuditRepo
+	pool             *pgxpool.Pool
+	encryptionKey    []byte
+	uploadDir        string
+	baseURL          string
+	addressValidator *address.Validator
 }
 
 func NewLabelService(
@@ -60,6 +63,12 @@ func NewLabelService(
 	}
 }
 
+// SetAddressValidator sets the validator checking the receiver address of
+// courier shipments before a label is requested from the carrier.
+func (s *LabelService) SetAddressValidator(v *address.Validator) {
+	s.addressValidator = v
+}
+
 func (s *LabelService) GenerateLabel(ctx context.Context, tenantID, shipmentID uuid.UUID, req model.GenerateLabelRequest, actorID uuid.UUID, ip string) (*model.Shipment, error) {
 	// First transaction: load all required data from the database
 	var shipment *model.Shipment
@@ -181,6 +190,24 @@ func (s *LabelService) GenerateLabel(ctx context.Context, tenantID, shipmentID u
 		}
 	}
 
+	// Courier shipments need a deliverable address; catch bad postal codes
+	// and missing house numbers before the carrier rejects them.
+	var street address.StreetParts
+	if s.addressValidator != nil && req.TargetPoint == "" {
+		res := s.addressValidator.Validate(addr)
+		var problems []string
+		for _, issue := range res.Issues {
+			if issue.Field != "phone" {
+				problems = append(problems, issue.Message)
+			}
+		}
+		if len(problems) > 0 {
+			return nil, NewValidationError(fmt.Errorf("invalid shipping address: %s", strings.Join(problems, "; ")))
+		}
+		addr = res.Address
+		street = res.Street
+	}
+
 	customerEmail := ""
 	if order.CustomerEmail != nil {
 		customerEmail = *order.CustomerEmail
@@ -194,13 +221,15 @@ func (s *LabelService) GenerateLabel(ctx context.Context, tenantID, shipmentID u
 		OrderID:     shipment.OrderID.String(),
 		ServiceType: req.ServiceType,
 		Receiver: integration.CarrierReceiver{
-			Name:       order.CustomerName,
-			Email:      customerEmail,
-			Phone:      customerPhone,
-			Street:     addr.Street,
-			City:       addr.City,
-			PostalCode: addr.PostalCode,
-			Country:    addr.Country,
+			Name:           order.CustomerName,
+			Email:          customerEmail,
+			Phone:          customerPhone,
+			Street:         addr.Street,
+			StreetName:     street.Street,
+			BuildingNumber: buildingNumber(street),
+			City:           addr.City,
+			PostalCode:     addr.PostalCode,
+			Country:        addr.Country,
 		},
 		Parcel: integration.CarrierParcel{
 			SizeCode: req.ParcelSize,
@@ -243,6 +272,7 @@ func (s *LabelService) GenerateLabel(ctx context.Context, tenantID, shipmentID u
 
 	labelURL := fmt.Sprintf("%s/uploads/%s/%s", s.baseURL, tenantID.String(), filename)
 	trackingNum := resp.TrackingNumber
+	shippingCost := estimateShippingCost(ctx, carrier, req, addr)
 
 	slog.Info("carrier label generated",
 		"shipment_id", shipmentID,
@@ -275,6 +305,9 @@ func (s *LabelService) GenerateLabel(ctx context.Context, tenantID, shipmentID u
 			LabelURL:       &labelURL,
 			CarrierData:    carrierDataJSON,
 		}
+		if shipment.ShippingCost == nil {
+			updateReq.ShippingCost = shippingCost
+		}
 		if err := s.shipmentRepo.Update(ctx, tx, shipmentID, updateReq); err != nil {
 			return err
 		}
@@ -305,6 +338,47 @@ func (s *LabelService) GenerateLabel(ctx context.Context, tenantID, shipmentID u
 	return updatedShipment, nil
 }
 
+// estimateShippingCost prices a shipment from the carrier's rate card: the
+// cheapest service of the same kind (pickup point or door to door). It
+// returns nil when the carrier has no matching rate.
+// buildingNumber joins the building and flat number of a street the way
+// Polish carriers expect them ("5/12").
+func buildingNumber(p address.StreetParts) string {
+	if p.Building == "" || p.Flat == "" {
+		return p.Building
+	}
+	return p.Building + "/" + p.Flat
+}
+
+func estimateShippingCost(ctx context.Context, carrier integration.CarrierProvider, req model.GenerateLabelRequest, addr model.ShippingAddress) *float64 {
+	rateReq := integration.RateRequest{
+		ToPostalCode:  addr.PostalCode,
+		ToCountry:     addr.Country,
+		Weight:        req.WeightKg,
+		Width:         req.WidthCm,
+		Height:        req.HeightCm,
+		Length:        req.DepthCm,
+		COD:           req.CODAmount,
+		IsPickupPoint: req.TargetPoint != "",
+	}
+	rates, err := carrier.GetRates(ctx, rateReq)
+	if err != nil {
+		slog.Warn("shipping cost estimate failed", "provider", carrier.ProviderName(), "error", err)
+		return nil
+	}
+	var cost *float64
+	for _, rate := range rates {
+		if rate.PickupPoint != rateReq.IsPickupPoint {
+			continue
+		}
+		if cost == nil || rate.Price < *cost {
+			price := rate.Price
+			cost = &price
+		}
+	}
+	return cost
+}
+
 // GetTracking fetches real-time tracking events from the carrier API.
 func (s *LabelService) GetTracking(ctx context.Context, tenantID, shipmentID uuid.UUID) ([]integration.TrackingEvent, error) {
 	var shipment *model.Shipment
@@ -0,0 +1,53 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/openoms-org/openoms/apps/api-server/internal/database"
+	"github.com/openoms-org/openoms/apps/api-server/internal/model"
+	"github.com/openoms-org/openoms/apps/api-server/internal/repository"
+)
+
+// recordOrderEvent is a shared helper that records an event on the order
+// timeline within tx if the order event repository is available. It is used
+// by the notification services, the automation executor and OrderService.
+func recordOrderEvent(ctx context.Context, tx pgx.Tx, repo repository.OrderEventRepo, ev *model.OrderEvent) error {
+	if repo == nil {
+		return nil
+	}
+	return repo.Create(ctx, tx, ev)
+}
+
+// nilIfNilUUID returns nil for system actions (workers, automation) that have
+// no user.
+func nilIfNilUUID(id uuid.UUID) *uuid.UUID {
+	if id == uuid.Nil {
+		return nil
+	}
+	return &id
+}
+
+// recordNotification records a notification sent to the customer of an order
+// in its own transaction. Notifications are sent after the change that caused
+// them has committed, so a failure to record one is only logged.
+func recordNotification(ctx context.Context, pool *pgxpool.Pool, repo repository.OrderEventRepo, tenantID, orderID uuid.UUID, eventType string, data map[string]string) {
+	if repo == nil {
+		return
+	}
+	err := database.WithTenant(ctx, pool, tenantID, func(tx pgx.Tx) error {
+		return repo.Create(ctx, tx, &model.OrderEvent{
+			TenantID:  tenantID,
+			OrderID:   orderID,
+			Source:    model.TimelineSourceNotification,
+			EventType: eventType,
+			Data:      data,
+		})
+	})
+	if err != nil {
+		slog.Error("failed to record order notification", "error", err, "order_id", orderID, "event_type", eventType)
+	}
+}
@@ -21,10 +21,12 @@ var (
 )
 
 type OrderGroupService struct {
-	orderGroupRepo *repository.OrderGroupRepository
-	orderRepo      repository.OrderRepo
-	auditRepo      repository.AuditRepo
-	pool           *pgxpool.Pool
+	orderGroupRepo     *repository.OrderGroupRepository
+	orderRepo          repository.OrderRepo
+	auditRepo          repository.AuditRepo
+	productRepo        repository.ProductRepo
+	warehouseStockRepo repository.WarehouseStockRepo
+	pool               *pgxpool.Pool
 }
 
 func NewOrderGroupService(
@@ -41,6 +43,12 @@ func NewOrderGroupService(
 	}
 }
 
+// SetStockDeps sets the repositories used to split orders by warehouse stock.
+func (s *OrderGroupService) SetStockDeps(productRepo repository.ProductRepo, warehouseStockRepo repository.WarehouseStockRepo) {
+	s.productRepo = productRepo
+	s.warehouseStockRepo = warehouseStockRepo
+}
+
 func (s *OrderGroupService) MergeOrders(ctx context.Context, tenantID, userID uuid.UUID, req model.MergeOrdersRequest) (*model.OrderGroup, error) {
 	if err := req.Validate(); err != nil {
 		return nil, NewValidationError(err)
@@ -271,7 +279,9 @@ func (s *OrderGroupService) SplitOrder(ctx context.Context, tenantID, userID uui
 			SourceOrderIDs: []uuid.UUID{orderID},
 			TargetOrderIDs: targetIDs,
 			Notes:          req.Notes,
-			CreatedBy:      &userID,
+		}
+		if userID != uuid.Nil {
+			group.CreatedBy = &userID
 		}
 
 		if err := s.orderGroupRepo.Create(ctx, tx, group); err != nil {
@@ -305,3 +315,179 @@ func (s *OrderGroupService) ListByOrderID(ctx context.Context, tenantID, orderID
 	}
 	return groups, err
 }
+
+// warehouseSplitLine is an order item line with the stock it can be shipped
+// from. Lines with the same stockKey draw on the same stock.
+type warehouseSplitLine struct {
+	item      map[string]any
+	quantity  int
+	stockKey  string
+	available map[uuid.UUID]int
+}
+
+// SplitByWarehouse splits an order into one order per warehouse that can ship
+// its items, based on available warehouse stock. Items no warehouse can ship
+// in full go to a remainder order. It returns nil when the whole order ships
+// from a single warehouse and no split is needed.
+func (s *OrderGroupService) SplitByWarehouse(ctx context.Context, tenantID, userID, orderID uuid.UUID) (*model.OrderGroup, error) {
+	if s.productRepo == nil || s.warehouseStockRepo == nil {
+		return nil, errors.New("warehouse stock is not configured")
+	}
+
+	var lines []warehouseSplitLine
+	err := database.WithTenant(ctx, s.pool, tenantID, func(tx pgx.Tx) error {
+		order, err := s.orderRepo.FindByID(ctx, tx, orderID)
+		if err != nil {
+			return err
+		}
+		if order == nil {
+			return ErrOrderNotFound
+		}
+		var items []map[string]any
+		if err := json.Unmarshal(order.Items, &items); err != nil {
+			return NewValidationError(errors.New("order items cannot be read"))
+		}
+		lines, err = s.warehouseSplitLines(ctx, tx, items)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	groups := planWarehouseSplit(lines)
+	if len(groups) < 2 {
+		return nil, nil
+	}
+
+	req := model.SplitOrderRequest{}
+	for _, items := range groups {
+		itemsJSON, err := json.Marshal(items)
+		if err != nil {
+			return nil, fmt.Errorf("marshal split items: %w", err)
+		}
+		req.Splits = append(req.Splits, model.SplitSpec{Items: itemsJSON})
+	}
+	notes := "Podzielone wg dostępności w magazynach"
+	req.Notes = &notes
+	return s.SplitOrder(ctx, tenantID, userID, orderID, req)
+}
+
+// warehouseSplitLines loads the available stock per warehouse of each item.
+// Items are matched by product_id, or by sku when they have none.
+func (s *OrderGroupService) warehouseSplitLines(ctx context.Context, tx pgx.Tx, items []map[string]any) ([]warehouseSplitLine, error) {
+	stockCache := map[uuid.UUID][]model.WarehouseStock{}
+	lines := make([]warehouseSplitLine, 0, len(items))
+	for _, item := range items {
+		line := warehouseSplitLine{item: item, quantity: 1, available: map[uuid.UUID]int{}}
+		if q, ok := item["quantity"].(float64); ok && q > 0 {
+			line.quantity = int(q)
+		}
+
+		var productID uuid.UUID
+		if id, ok := item["product_id"].(string); ok {
+			productID, _ = uuid.Parse(id)
+		}
+		if productID == uuid.Nil {
+			if sku, ok := item["sku"].(string); ok && sku != "" {
+				product, err := s.productRepo.FindBySKU(ctx, tx, sku)
+				if err != nil {
+					return nil, err
+				}
+				if product != nil {
+					productID = product.ID
+				}
+			}
+		}
+		var variantID uuid.UUID
+		if id, ok := item["variant_id"].(string); ok {
+			variantID, _ = uuid.Parse(id)
+		}
+
+		if productID != uuid.Nil {
+			line.stockKey = productID.String() + "/" + variantID.String() + "/"
+			stocks, ok := stockCache[productID]
+			if !ok {
+				var err error
+				stocks, err = s.warehouseStockRepo.ListByProduct(ctx, tx, productID)
+				if err != nil {
+					return nil, err
+				}
+				stockCache[productID] = stocks
+			}
+			for _, st := range stocks {
+				if variantID != uuid.Nil && (st.VariantID == nil || *st.VariantID != variantID) {
+					continue
+				}
+				if variantID == uuid.Nil && st.VariantID != nil {
+					continue
+				}
+				line.available[st.WarehouseID] += st.Quantity - st.Reserved
+			}
+		}
+		lines = append(lines, line)
+	}
+	return lines, nil
+}
+
+// planWarehouseSplit assigns each line to a warehouse that has enough stock
+// for it. It prefers warehouses already used by earlier lines, then the ones
+// that can ship the most lines of the order, so the order is split as little
+// as possible. Stock is consumed as lines are assigned. Lines no warehouse can
+// ship form the last group.
+func planWarehouseSplit(lines []warehouseSplitLine) [][]map[string]any {
+	coverage := map[uuid.UUID]int{}
+	for _, line := range lines {
+		for wh, avail := range line.available {
+			if avail >= line.quantity {
+				coverage[wh]++
+			}
+		}
+	}
+
+	consumed := map[string]int{}
+	var order []uuid.UUID
+	byWarehouse := map[uuid.UUID][]map[string]any{}
+	var unassigned []map[string]any
+	for _, line := range lines {
+		best := uuid.Nil
+		bestUsed := false
+		for wh, avail := range line.available {
+			if avail-consumed[line.stockKey+wh.String()] < line.quantity {
+				continue
+			}
+			_, used := byWarehouse[wh]
+			var better bool
+			switch {
+			case best == uuid.Nil:
+				better = true
+			case used != bestUsed:
+				better = used
+			case coverage[wh] != coverage[best]:
+				better = coverage[wh] > coverage[best]
+			default:
+				better = wh.String() < best.String()
+			}
+			if better {
+				best, bestUsed = wh, used
+			}
+		}
+		if best == uuid.Nil {
+			unassigned = append(unassigned, line.item)
+			continue
+		}
+		consumed[line.stockKey+best.String()] += line.quantity
+		if _, ok := byWarehouse[best]; !ok {
+			order = append(order, best)
+		}
+		byWarehouse[best] = append(byWarehouse[best], line.item)
+	}
+
+	groups := make([][]map[string]any, 0, len(order)+1)
+	for _, wh := range order {
+		groups = append(groups, byWarehouse[wh])
+	}
+	if len(unassigned) > 0 {
+		groups = append(groups, unassigned)
+	}
+	return groups
+}
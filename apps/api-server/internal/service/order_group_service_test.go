@@ -0,0 +1,56 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlanWarehouseSplit(t *testing.T) {
+	whA := uuid.MustParse("00000000-0000-0000-0000-00000000000a")
+	whB := uuid.MustParse("00000000-0000-0000-0000-00000000000b")
+
+	line := func(sku string, qty int, key string, avail map[uuid.UUID]int) warehouseSplitLine {
+		return warehouseSplitLine{item: map[string]any{"sku": sku}, quantity: qty, stockKey: key, available: avail}
+	}
+
+	t.Run("one warehouse ships everything", func(t *testing.T) {
+		groups := planWarehouseSplit([]warehouseSplitLine{
+			line("A", 1, "p1", map[uuid.UUID]int{whA: 5, whB: 10}),
+			line("B", 2, "p2", map[uuid.UUID]int{whA: 2}),
+		})
+		require.Len(t, groups, 1)
+		assert.Len(t, groups[0], 2)
+	})
+
+	t.Run("split across warehouses", func(t *testing.T) {
+		groups := planWarehouseSplit([]warehouseSplitLine{
+			line("A", 1, "p1", map[uuid.UUID]int{whA: 1}),
+			line("B", 1, "p2", map[uuid.UUID]int{whB: 1}),
+			line("C", 1, "p3", map[uuid.UUID]int{whA: 3, whB: 3}),
+		})
+		require.Len(t, groups, 2)
+		assert.Equal(t, "A", groups[0][0]["sku"])
+		assert.Len(t, groups[0], 2)
+		assert.Equal(t, "B", groups[1][0]["sku"])
+	})
+
+	t.Run("stock is consumed by earlier lines", func(t *testing.T) {
+		groups := planWarehouseSplit([]warehouseSplitLine{
+			line("A", 2, "p1", map[uuid.UUID]int{whA: 3, whB: 2}),
+			line("A", 2, "p1", map[uuid.UUID]int{whA: 3, whB: 2}),
+		})
+		require.Len(t, groups, 2)
+	})
+
+	t.Run("unavailable items go to a remainder", func(t *testing.T) {
+		groups := planWarehouseSplit([]warehouseSplitLine{
+			line("A", 1, "p1", map[uuid.UUID]int{whA: 1}),
+			line("X", 1, "", map[uuid.UUID]int{}),
+		})
+		require.Len(t, groups, 2)
+		assert.Equal(t, "X", groups[1][0]["sku"])
+	})
+}
@@ -0,0 +1,727 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/openoms-org/openoms/apps/api-server/internal/address"
+	"github.com/openoms-org/openoms/apps/api-server/internal/database"
+	"github.com/openoms-org/openoms/apps/api-server/internal/model"
+	"github.com/openoms-org/openoms/apps/api-server/internal/repository"
+	engine "github.com/openoms-org/openoms/packages/order-engine"
+)
+
+var (
+	ErrOrderHoldNotFound = errors.New("order hold not found")
+	ErrOrderHoldExists   = errors.New("order already has an active hold with this reason")
+	ErrOrderHoldReleased = errors.New("order hold is already released")
+)
+
+// holdReleaseBatch is the number of automatically released holds checked per
+// transaction.
+const holdReleaseBatch = 200
+
+// autoReleaseReasons are the hold reasons released once their condition clears.
+var autoReleaseReasons = []string{string(engine.HoldAwaitingPayment), string(engine.HoldOutOfStock)}
+
+// OrderHoldService places and releases order holds. A held order cannot move
+// to a fulfilment status until all its holds are released; holds awaiting
+// payment or stock are released automatically once the order is paid or its
+// lines are in stock.
+type OrderHoldService struct {
+	holdRepo          repository.OrderHoldRepo
+	orderRepo         repository.OrderRepo
+	productRepo       repository.ProductRepo
+	variantRepo       repository.VariantRepo
+	tenantRepo        repository.TenantRepo
+	auditRepo         repository.AuditRepo
+	pool              *pgxpool.Pool
+	webhookDispatch   *WebhookDispatchService
+	automationService *AutomationService
+	addressValidator  *address.Validator
+	riskService       *OrderRiskService
+	logger            *slog.Logger
+}
+
+func NewOrderHoldService(
+	holdRepo repository.OrderHoldRepo,
+	orderRepo repository.OrderRepo,
+	productRepo repository.ProductRepo,
+	variantRepo repository.VariantRepo,
+	tenantRepo repository.TenantRepo,
+	auditRepo repository.AuditRepo,
+	pool *pgxpool.Pool,
+	webhookDispatch *WebhookDispatchService,
+	logger *slog.Logger,
+) *OrderHoldService {
+	return &OrderHoldService{
+		holdRepo:        holdRepo,
+		orderRepo:       orderRepo,
+		productRepo:     productRepo,
+		variantRepo:     variantRepo,
+		tenantRepo:      tenantRepo,
+		auditRepo:       auditRepo,
+		pool:            pool,
+		webhookDispatch: webhookDispatch,
+		logger:          logger,
+	}
+}
+
+// SetAutomationService sets the automation service for hold events.
+// Called after construction to avoid circular dependency.
+func (s *OrderHoldService) SetAutomationService(automationSvc *AutomationService) {
+	s.automationService = automationSvc
+}
+
+// SetAddressValidator sets the validator checking shipping addresses of new
+// orders. Without it the address verification check only looks for missing
+// fields.
+func (s *OrderHoldService) SetAddressValidator(v *address.Validator) {
+	s.addressValidator = v
+}
+
+// SetRiskService sets the service scoring the fraud risk of new orders.
+func (s *OrderHoldService) SetRiskService(riskSvc *OrderRiskService) {
+	s.riskService = riskSvc
+}
+
+// ListForOrder returns the holds of an order, active and released.
+func (s *OrderHoldService) ListForOrder(ctx context.Context, tenantID, orderID uuid.UUID) ([]model.OrderHold, error) {
+	var holds []model.OrderHold
+	err := database.WithTenant(ctx, s.pool, tenantID, func(tx pgx.Tx) error {
+		order, err := s.orderRepo.FindByID(ctx, tx, orderID)
+		if err != nil {
+			return err
+		}
+		if order == nil {
+			return ErrOrderNotFound
+		}
+		holds, err = s.holdRepo.ListByOrder(ctx, tx, orderID)
+		return err
+	})
+	return holds, err
+}
+
+// List returns the blocked-order queue: orders with active holds, longest
+// held first, each with its active holds.
+func (s *OrderHoldService) List(ctx context.Context, tenantID uuid.UUID, filter model.OrderHoldListFilter) (model.ListResponse[model.HeldOrder], error) {
+	var resp model.ListResponse[model.HeldOrder]
+	err := database.WithTenant(ctx, s.pool, tenantID, func(tx pgx.Tx) error {
+		orders, total, err := s.holdRepo.ListHeldOrders(ctx, tx, filter)
+		if err != nil {
+			return err
+		}
+		ids := make([]uuid.UUID, len(orders))
+		byID := make(map[uuid.UUID]*model.HeldOrder, len(orders))
+		for i := range orders {
+			ids[i] = orders[i].OrderID
+			orders[i].Holds = []model.OrderHold{}
+			byID[orders[i].OrderID] = &orders[i]
+		}
+		if len(ids) > 0 {
+			holds, err := s.holdRepo.ListActive(ctx, tx, ids)
+			if err != nil {
+				return err
+			}
+			for _, h := range holds {
+				if o := byID[h.OrderID]; o != nil {
+					o.Holds = append(o.Holds, h)
+				}
+			}
+		}
+		resp = model.ListResponse[model.HeldOrder]{
+			Items:  orders,
+			Total:  total,
+			Limit:  filter.Limit,
+			Offset: filter.Offset,
+		}
+		return nil
+	})
+	return resp, err
+}
+
+// Place puts an order on hold. An order has at most one active hold per
+// reason.
+func (s *OrderHoldService) Place(ctx context.Context, tenantID, orderID uuid.UUID, req model.PlaceHoldRequest, actorID uuid.UUID, ip string) (*model.OrderHold, error) {
+	if err := req.Validate(); err != nil {
+		return nil, NewValidationError(err)
+	}
+	req.Note = model.StripHTMLTags(strings.TrimSpace(req.Note))
+
+	var hold *model.OrderHold
+	err := database.WithTenant(ctx, s.pool, tenantID, func(tx pgx.Tx) error {
+		order, err := s.orderRepo.FindByID(ctx, tx, orderID)
+		if err != nil {
+			return err
+		}
+		if order == nil {
+			return ErrOrderNotFound
+		}
+		var created bool
+		hold, created, err = s.placeHold(ctx, tx, order, engine.HoldReason(req.Reason), req.Note, model.HoldSourceManual, actorID, ip)
+		if err != nil {
+			return err
+		}
+		if !created {
+			return ErrOrderHoldExists
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.NotifyHolds(tenantID, []model.OrderHold{*hold})
+	return hold, nil
+}
+
+// Release releases a hold of an order.
+func (s *OrderHoldService) Release(ctx context.Context, tenantID, orderID, holdID uuid.UUID, req model.ReleaseHoldRequest, actorID uuid.UUID, ip string) (*model.OrderHold, error) {
+	if err := req.Validate(); err != nil {
+		return nil, NewValidationError(err)
+	}
+	req.Note = model.StripHTMLTags(strings.TrimSpace(req.Note))
+
+	var hold *model.OrderHold
+	err := database.WithTenant(ctx, s.pool, tenantID, func(tx pgx.Tx) error {
+		var err error
+		hold, err = s.holdRepo.FindByID(ctx, tx, holdID)
+		if err != nil {
+			return err
+		}
+		if hold == nil || hold.OrderID != orderID {
+			return ErrOrderHoldNotFound
+		}
+		released, err := s.releaseHold(ctx, tx, hold, req.Note, model.HoldSourceManual, actorID, ip)
+		if err != nil {
+			return err
+		}
+		if !released {
+			return ErrOrderHoldReleased
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.NotifyHolds(tenantID, []model.OrderHold{*hold})
+	return hold, nil
+}
+
+// PlaceByAutomation puts an order on hold for an automation rule. It does
+// nothing when the order already has an active hold with the reason.
+func (s *OrderHoldService) PlaceByAutomation(ctx context.Context, tenantID, orderID uuid.UUID, reason engine.HoldReason, note string) error {
+	var hold *model.OrderHold
+	var created bool
+	err := database.WithTenant(ctx, s.pool, tenantID, func(tx pgx.Tx) error {
+		order, err := s.orderRepo.FindByID(ctx, tx, orderID)
+		if err != nil {
+			return err
+		}
+		if order == nil {
+			return ErrOrderNotFound
+		}
+		hold, created, err = s.placeHold(ctx, tx, order, reason, model.StripHTMLTags(note), model.HoldSourceAutomation, uuid.Nil, "")
+		return err
+	})
+	if err == nil && created {
+		s.NotifyHolds(tenantID, []model.OrderHold{*hold})
+	}
+	return err
+}
+
+// ReleaseByAutomation releases the active hold of an order with the reason
+// for an automation rule. It does nothing when there is none.
+func (s *OrderHoldService) ReleaseByAutomation(ctx context.Context, tenantID, orderID uuid.UUID, reason engine.HoldReason, note string) error {
+	var released []model.OrderHold
+	err := database.WithTenant(ctx, s.pool, tenantID, func(tx pgx.Tx) error {
+		holds, err := s.holdRepo.ListActive(ctx, tx, []uuid.UUID{orderID})
+		if err != nil {
+			return err
+		}
+		for i := range holds {
+			if holds[i].Reason != string(reason) {
+				continue
+			}
+			ok, err := s.releaseHold(ctx, tx, &holds[i], model.StripHTMLTags(note), model.HoldSourceAutomation, uuid.Nil, "")
+			if err != nil {
+				return err
+			}
+			if ok {
+				released = append(released, holds[i])
+			}
+		}
+		return nil
+	})
+	if err == nil {
+		s.NotifyHolds(tenantID, released)
+	}
+	return err
+}
+
+// CheckNewOrder validates the shipping address of a new order, scores its
+// fraud risk and runs the import checks enabled in the tenant's order hold
+// settings on it, within the transaction creating it, and puts the order on
+// hold for each check it fails and when its risk score reaches the hold
+// threshold. It returns the holds placed; pass them to NotifyHolds once the
+// transaction commits.
+func (s *OrderHoldService) CheckNewOrder(ctx context.Context, tx pgx.Tx, order *model.Order) ([]model.OrderHold, error) {
+	if err := s.checkAddress(ctx, tx, order); err != nil {
+		return nil, err
+	}
+
+	var settings model.OrderHoldSettings
+	if err := s.settingsSection(ctx, tx, order.TenantID, "order_holds", &settings); err != nil {
+		return nil, err
+	}
+
+	type check struct {
+		reason engine.HoldReason
+		note   string
+	}
+	var failed []check
+	if settings.Checks(engine.HoldAwaitingPayment) && order.PaymentStatus != "paid" && !model.IsCashOnDelivery(order.PaymentMethod) {
+		failed = append(failed, check{engine.HoldAwaitingPayment, "order is not paid"})
+	}
+	if settings.Checks(engine.HoldOutOfStock) {
+		short, err := s.stockShortage(ctx, tx, order)
+		if err != nil {
+			return nil, err
+		}
+		if len(short) > 0 {
+			failed = append(failed, check{engine.HoldOutOfStock, "not in stock: " + strings.Join(short, ", ")})
+		}
+	}
+	if settings.Checks(engine.HoldAddressVerification) {
+		if s.addressValidator != nil {
+			if len(order.AddressIssues) > 0 {
+				messages := make([]string, len(order.AddressIssues))
+				for i, issue := range order.AddressIssues {
+					messages[i] = issue.Message
+				}
+				failed = append(failed, check{engine.HoldAddressVerification, strings.Join(messages, "; ")})
+			}
+		} else if stringOrEmpty(order.PickupPointID) == "" {
+			if missing := model.MissingAddressFields(order.ShippingAddress); len(missing) > 0 {
+				failed = append(failed, check{engine.HoldAddressVerification, "shipping address lacks " + strings.Join(missing, ", ")})
+			}
+		}
+	}
+	if s.riskService != nil {
+		riskSettings, err := s.riskService.Score(ctx, tx, order)
+		if err != nil {
+			return nil, err
+		}
+		if score := *order.RiskScore; riskSettings.HoldThreshold > 0 && score >= riskSettings.HoldThreshold {
+			var details []string
+			for _, sig := range order.RiskSignals {
+				if sig.Points > 0 {
+					details = append(details, sig.Detail)
+				}
+			}
+			failed = append(failed, check{engine.HoldFraudCheck, fmt.Sprintf("risk score %d: %s", score, strings.Join(details, "; "))})
+		}
+	}
+
+	var placed []model.OrderHold
+	for _, c := range failed {
+		hold, created, err := s.placeHold(ctx, tx, order, c.reason, c.note, model.HoldSourceImport, uuid.Nil, "")
+		if err != nil {
+			return nil, err
+		}
+		if created {
+			placed = append(placed, *hold)
+		}
+	}
+	return placed, nil
+}
+
+// checkAddress validates the shipping address and customer phone of an
+// order, stores them normalised along with the issues found and updates the
+// order in place. The shipping address of an order delivered to a pickup
+// point is not checked. It does nothing without an address validator.
+func (s *OrderHoldService) checkAddress(ctx context.Context, tx pgx.Tx, order *model.Order) error {
+	if s.addressValidator == nil {
+		return nil
+	}
+
+	var addr model.ShippingAddress
+	_ = json.Unmarshal(order.ShippingAddress, &addr)
+	country, _ := address.NormalizeCountry(addr.Country)
+
+	issues := []model.AddressIssue{}
+	shippingAddress := order.ShippingAddress
+	if stringOrEmpty(order.PickupPointID) == "" {
+		res := s.addressValidator.Validate(addr)
+		issues = append(issues, res.Issues...)
+		shippingAddress = mergeShippingAddress(order.ShippingAddress, res.Address)
+	}
+
+	customerPhone := order.CustomerPhone
+	if phone := strings.TrimSpace(stringOrEmpty(order.CustomerPhone)); phone != "" {
+		normalized, err := address.NormalizePhone(phone, country)
+		if err != nil {
+			issues = append(issues, model.AddressIssue{Field: "customer_phone", Code: model.AddressIssueInvalidFormat, Message: err.Error()})
+		} else {
+			customerPhone = &normalized
+		}
+	}
+
+	if err := s.orderRepo.SetAddressCheck(ctx, tx, order.ID, shippingAddress, customerPhone, issues); err != nil {
+		return err
+	}
+	order.ShippingAddress = shippingAddress
+	order.CustomerPhone = customerPhone
+	order.AddressIssues = issues
+	return nil
+}
+
+// mergeShippingAddress writes the normalised fields of a validated address
+// into the stored address JSON, keeping any other keys. Addresses that are not
+// a JSON object are returned unchanged.
+func mergeShippingAddress(raw json.RawMessage, addr model.ShippingAddress) json.RawMessage {
+	var fields map[string]any
+	if err := json.Unmarshal(raw, &fields); err != nil || fields == nil {
+		return raw
+	}
+	set := func(key, value string) {
+		if value != "" {
+			fields[key] = value
+		}
+	}
+	set("name", addr.Name)
+	set("street", addr.Street)
+	set("city", addr.City)
+	set("postal_code", addr.PostalCode)
+	set("country", addr.Country)
+	set("phone", addr.Phone)
+	set("email", addr.Email)
+	merged, err := json.Marshal(fields)
+	if err != nil {
+		return raw
+	}
+	return merged
+}
+
+// ReleaseCleared releases the tenant's holds awaiting payment or stock whose
+// condition has cleared, checking them in pages of holdReleaseBatch. It
+// returns the number of released holds.
+func (s *OrderHoldService) ReleaseCleared(ctx context.Context, tenantID uuid.UUID) (int, error) {
+	total := 0
+	var after *model.OrderHold
+	for {
+		released, last, err := s.releaseClearedPage(ctx, tenantID, after)
+		if err != nil {
+			return total, err
+		}
+		total += len(released)
+		s.NotifyHolds(tenantID, released)
+		if last == nil {
+			return total, nil
+		}
+		after = last
+	}
+}
+
+// releaseClearedPage checks one page of holds after the given hold. It
+// returns the released holds and the last hold of the page, or nil when no
+// page follows.
+func (s *OrderHoldService) releaseClearedPage(ctx context.Context, tenantID uuid.UUID, after *model.OrderHold) ([]model.OrderHold, *model.OrderHold, error) {
+	var released []model.OrderHold
+	var last *model.OrderHold
+	err := database.WithTenant(ctx, s.pool, tenantID, func(tx pgx.Tx) error {
+		holds, err := s.holdRepo.ListActiveByReason(ctx, tx, autoReleaseReasons, after, holdReleaseBatch)
+		if err != nil {
+			return err
+		}
+		if len(holds) == holdReleaseBatch {
+			last = &holds[len(holds)-1]
+		}
+		byOrder := make(map[uuid.UUID][]model.OrderHold)
+		var orderIDs []uuid.UUID
+		for _, h := range holds {
+			if _, ok := byOrder[h.OrderID]; !ok {
+				orderIDs = append(orderIDs, h.OrderID)
+			}
+			byOrder[h.OrderID] = append(byOrder[h.OrderID], h)
+		}
+		for _, orderID := range orderIDs {
+			order, err := s.orderRepo.FindByID(ctx, tx, orderID)
+			if err != nil {
+				return err
+			}
+			if order == nil {
+				continue
+			}
+			r, err := s.releaseClearedHolds(ctx, tx, order, byOrder[orderID])
+			if err != nil {
+				return err
+			}
+			released = append(released, r...)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return released, last, nil
+}
+
+// releaseCleared releases the holds of an order whose condition has cleared,
+// within tx. It returns the released holds; pass them to NotifyHolds once
+// the transaction commits.
+func (s *OrderHoldService) releaseCleared(ctx context.Context, tx pgx.Tx, order *model.Order) ([]model.OrderHold, error) {
+	holds, err := s.holdRepo.ListActive(ctx, tx, []uuid.UUID{order.ID})
+	if err != nil {
+		return nil, err
+	}
+	return s.releaseClearedHolds(ctx, tx, order, holds)
+}
+
+func (s *OrderHoldService) releaseClearedHolds(ctx context.Context, tx pgx.Tx, order *model.Order, holds []model.OrderHold) ([]model.OrderHold, error) {
+	facts := engine.HoldFacts{Paid: order.PaymentStatus == "paid"}
+	stockChecked := false
+
+	var released []model.OrderHold
+	for i := range holds {
+		h := &holds[i]
+		reason := engine.HoldReason(h.Reason)
+		if !reason.AutoReleases() {
+			continue
+		}
+		if reason == engine.HoldOutOfStock && !stockChecked {
+			short, err := s.stockShortage(ctx, tx, order)
+			if err != nil {
+				return nil, err
+			}
+			facts.InStock = len(short) == 0
+			stockChecked = true
+		}
+		if !reason.Cleared(facts) {
+			continue
+		}
+		note := "payment received"
+		if reason == engine.HoldOutOfStock {
+			note = "stock available"
+		}
+		ok, err := s.releaseHold(ctx, tx, h, note, "auto", uuid.Nil, "")
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			released = append(released, *h)
+		}
+	}
+	return released, nil
+}
+
+// placeHold places a hold within tx and records it in the audit log. It
+// returns the order's active hold and false when one with the reason exists.
+// Holds can only be placed on orders that are not yet shipped or closed.
+// Holds placed by automation rules do not enqueue automation events, so a
+// rule cannot trigger itself.
+func (s *OrderHoldService) placeHold(ctx context.Context, tx pgx.Tx, order *model.Order, reason engine.HoldReason, note, source string, actorID uuid.UUID, ip string) (*model.OrderHold, bool, error) {
+	if !reason.Valid() {
+		return nil, false, NewValidationError(fmt.Errorf("invalid hold reason %q", reason))
+	}
+	config, err := loadOrderStatusConfig(ctx, tx, s.tenantRepo, order.TenantID)
+	if err != nil {
+		return nil, false, err
+	}
+	switch engine.StatusCategory(config.CategoryOf(order.Status)) {
+	case engine.CategoryNew, engine.CategoryProcessing, engine.CategoryOnHold:
+	default:
+		return nil, false, NewValidationError(fmt.Errorf("a %s order cannot be put on hold", order.Status))
+	}
+
+	hold := &model.OrderHold{
+		TenantID:  order.TenantID,
+		OrderID:   order.ID,
+		Reason:    string(reason),
+		Note:      note,
+		Source:    source,
+		CreatedBy: nilIfNilUUID(actorID),
+	}
+	created, err := s.holdRepo.Create(ctx, tx, hold)
+	if err != nil {
+		return nil, false, err
+	}
+	if !created {
+		return hold, false, nil
+	}
+
+	if source != model.HoldSourceAutomation {
+		if err := EnqueueAutomationEvent(ctx, tx, s.automationService, order.TenantID, "order", "order.hold_placed", order.ID, holdEventData(order, hold)); err != nil {
+			return nil, false, err
+		}
+	}
+	return hold, true, s.auditRepo.Log(ctx, tx, model.AuditEntry{
+		TenantID:   order.TenantID,
+		UserID:     actorID,
+		Action:     "order.hold_placed",
+		EntityType: "order",
+		EntityID:   order.ID,
+		Changes:    map[string]string{"hold_id": hold.ID.String(), "reason": hold.Reason, "note": note, "source": source},
+		IPAddress:  ip,
+	})
+}
+
+// releaseHold releases an active hold within tx and records it in the audit
+// log. Source is who released it: manual, automation, or auto when its
+// condition cleared. It returns false when the hold was already released.
+func (s *OrderHoldService) releaseHold(ctx context.Context, tx pgx.Tx, hold *model.OrderHold, note, source string, actorID uuid.UUID, ip string) (bool, error) {
+	released, err := s.holdRepo.Release(ctx, tx, hold, nilIfNilUUID(actorID), note)
+	if err != nil || !released {
+		return false, err
+	}
+
+	if source != model.HoldSourceAutomation {
+		order, err := s.orderRepo.FindByID(ctx, tx, hold.OrderID)
+		if err != nil {
+			return false, err
+		}
+		if order != nil {
+			if err := EnqueueAutomationEvent(ctx, tx, s.automationService, hold.TenantID, "order", "order.hold_released", order.ID, holdEventData(order, hold)); err != nil {
+				return false, err
+			}
+		}
+	}
+	return true, s.auditRepo.Log(ctx, tx, model.AuditEntry{
+		TenantID:   hold.TenantID,
+		UserID:     actorID,
+		Action:     "order.hold_released",
+		EntityType: "order",
+		EntityID:   hold.OrderID,
+		Changes:    map[string]string{"hold_id": hold.ID.String(), "reason": hold.Reason, "note": note, "source": source},
+		IPAddress:  ip,
+	})
+}
+
+// holdEventData is the automation event data of a hold event: the order
+// fields of the other order events and the hold.
+func holdEventData(order *model.Order, hold *model.OrderHold) map[string]any {
+	data := orderEventData(order)
+	data["hold_id"], data["hold_reason"], data["hold_source"] = hold.ID.String(), hold.Reason, hold.Source
+	return data
+}
+
+// NotifyHolds dispatches the webhooks of holds placed or released in a
+// committed transaction.
+func (s *OrderHoldService) NotifyHolds(tenantID uuid.UUID, holds []model.OrderHold) {
+	if s.webhookDispatch == nil {
+		return
+	}
+	for _, h := range holds {
+		event := "order.hold_placed"
+		if !h.Active() {
+			event = "order.hold_released"
+		}
+		go s.webhookDispatch.Dispatch(context.Background(), tenantID, event, map[string]any{
+			"order_id": h.OrderID.String(), "hold_id": h.ID.String(), "reason": h.Reason, "source": h.Source,
+		})
+	}
+}
+
+// hasActiveHolds reports whether an order has an active hold.
+func (s *OrderHoldService) hasActiveHolds(ctx context.Context, tx pgx.Tx, orderID uuid.UUID) (bool, error) {
+	return s.holdRepo.HasActive(ctx, tx, orderID)
+}
+
+// stockShortage returns the SKUs (or names) of the order lines not covered by
+// the stock of their product or variant. Lines without a known product are
+// not checked.
+func (s *OrderHoldService) stockShortage(ctx context.Context, tx pgx.Tx, order *model.Order) ([]string, error) {
+	var items []map[string]any
+	if err := json.Unmarshal(order.Items, &items); err != nil {
+		return nil, nil
+	}
+
+	type demand struct {
+		label    string
+		quantity int
+		stock    int
+	}
+	var keys []uuid.UUID
+	demands := make(map[uuid.UUID]*demand)
+	for _, item := range items {
+		quantity := itemInt(item["quantity"])
+		if quantity <= 0 {
+			quantity = 1
+		}
+
+		var product *model.Product
+		var err error
+		if id, ok := itemUUID(item["product_id"]); ok {
+			product, err = s.productRepo.FindByID(ctx, tx, id)
+		} else if sku := itemString(item["sku"]); sku != "" {
+			product, err = s.productRepo.FindBySKU(ctx, tx, sku)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if product == nil {
+			continue
+		}
+
+		key, stock := product.ID, product.StockQuantity
+		label := itemString(item["sku"])
+		if label == "" && product.SKU != nil {
+			label = *product.SKU
+		}
+		if variantID, ok := itemUUID(item["variant_id"]); ok && s.variantRepo != nil {
+			variant, err := s.variantRepo.FindByID(ctx, tx, variantID)
+			if err != nil {
+				return nil, err
+			}
+			if variant != nil && variant.ProductID == product.ID {
+				key, stock = variant.ID, variant.StockQuantity
+				if variant.SKU != nil {
+					label = *variant.SKU
+				}
+			}
+		}
+		if label == "" {
+			label = product.Name
+		}
+
+		d, ok := demands[key]
+		if !ok {
+			d = &demand{label: label, stock: stock}
+			demands[key] = d
+			keys = append(keys, key)
+		}
+		d.quantity += quantity
+	}
+
+	var short []string
+	for _, key := range keys {
+		if d := demands[key]; d.quantity > d.stock {
+			short = append(short, d.label)
+		}
+	}
+	return short, nil
+}
+
+func (s *OrderHoldService) settingsSection(ctx context.Context, tx pgx.Tx, tenantID uuid.UUID, key string, dest any) error {
+	raw, err := s.tenantRepo.GetSettings(ctx, tx, tenantID)
+	if err != nil {
+		return err
+	}
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &all); err != nil {
+		return nil
+	}
+	if section, ok := all[key]; ok {
+		if err := json.Unmarshal(section, dest); err != nil {
+			s.logger.Warn("order holds: invalid settings section", "key", key, "tenant_id", tenantID, "error", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,183 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/openoms-org/openoms/apps/api-server/internal/address"
+	"github.com/openoms-org/openoms/apps/api-server/internal/model"
+	"github.com/openoms-org/openoms/apps/api-server/internal/repository"
+)
+
+// phoneKeyDigits is the number of trailing phone digits orders are matched
+// on, so that numbers with and without a calling code match.
+const phoneKeyDigits = 9
+
+// OrderRiskService scores the fraud risk of new orders from the customer's
+// history (earlier orders, returns and refused cash-on-delivery shipments),
+// the order itself and the number of recent orders per phone and address.
+type OrderRiskService struct {
+	riskRepo   repository.OrderRiskRepo
+	orderRepo  repository.OrderRepo
+	tenantRepo repository.TenantRepo
+	logger     *slog.Logger
+
+	exchangeRates *ExchangeRateService
+}
+
+func NewOrderRiskService(
+	riskRepo repository.OrderRiskRepo,
+	orderRepo repository.OrderRepo,
+	tenantRepo repository.TenantRepo,
+	logger *slog.Logger,
+) *OrderRiskService {
+	return &OrderRiskService{
+		riskRepo:   riskRepo,
+		orderRepo:  orderRepo,
+		tenantRepo: tenantRepo,
+		logger:     logger,
+	}
+}
+
+// SetExchangeRateService sets the service converting order totals to the
+// currency of the high-value threshold.
+func (s *OrderRiskService) SetExchangeRateService(exchangeRates *ExchangeRateService) {
+	s.exchangeRates = exchangeRates
+}
+
+// Score computes the risk score of a new order within the transaction
+// creating it, stores it and sets it on the order. It returns the tenant's
+// risk settings, whose hold threshold decides on a fraud_check hold.
+func (s *OrderRiskService) Score(ctx context.Context, tx pgx.Tx, order *model.Order) (model.OrderRiskSettings, error) {
+	settings, err := s.settings(ctx, tx, order.TenantID)
+	if err != nil {
+		return settings, err
+	}
+
+	email := strings.ToLower(strings.TrimSpace(stringOrEmpty(order.CustomerEmail)))
+	phone := phoneKey(stringOrEmpty(order.CustomerPhone))
+	history, err := s.riskRepo.CustomerHistory(ctx, tx, order.ID, order.CustomerID, email, phone, model.CashOnDeliveryMethods())
+	if err != nil {
+		return settings, err
+	}
+
+	var shipping, billing model.ShippingAddress
+	_ = json.Unmarshal(order.ShippingAddress, &shipping)
+	_ = json.Unmarshal(order.BillingAddress, &billing)
+
+	facts := model.RiskFacts{
+		History:         history,
+		CashOnDelivery:  model.IsCashOnDelivery(order.PaymentMethod),
+		Manual:          order.Source == "manual",
+		TotalAmount:     order.TotalAmount,
+		Currency:        order.Currency,
+		ShippingCountry: countryCode(shipping.Country),
+		BillingCountry:  countryCode(billing.Country),
+	}
+	if _, domain, ok := strings.Cut(email, "@"); ok {
+		facts.EmailDomain = domain
+	}
+	if err := s.convertTotal(ctx, tx, &facts, settings.ThresholdCurrency()); err != nil {
+		return settings, err
+	}
+
+	street := strings.ToLower(strings.Join(strings.Fields(shipping.Street), " "))
+	postalCode := strings.NewReplacer(" ", "", "-", "").Replace(shipping.PostalCode)
+	if stringOrEmpty(order.PickupPointID) != "" {
+		street, postalCode = "", ""
+	}
+	since := time.Now().Add(-time.Duration(settings.VelocityHours) * time.Hour)
+	facts.PhoneOrders, facts.AddressOrders, err = s.riskRepo.Velocity(ctx, tx, order.ID, since, phone, street, postalCode)
+	if err != nil {
+		return settings, err
+	}
+
+	score, signals := model.ScoreOrderRisk(facts, settings)
+	if err := s.orderRepo.SetRisk(ctx, tx, order.ID, score, signals); err != nil {
+		return settings, err
+	}
+	order.RiskScore = &score
+	order.RiskSignals = signals
+	return settings, nil
+}
+
+// convertTotal sets the order total in the threshold currency on facts. An
+// order without a stored exchange rate is left unconverted, so it is not
+// scored as high-value.
+func (s *OrderRiskService) convertTotal(ctx context.Context, tx pgx.Tx, facts *model.RiskFacts, currency string) error {
+	from := strings.ToUpper(facts.Currency)
+	if from == "" || from == currency || s.exchangeRates == nil {
+		return nil
+	}
+	amount, err := s.exchangeRates.convert(ctx, tx, facts.TotalAmount, from, currency)
+	if errors.Is(err, ErrRateNotAvailable) {
+		s.logger.Warn("order risk: no exchange rate for order total", "from", from, "to", currency)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	facts.ThresholdAmount = &amount
+	return nil
+}
+
+// settings returns the tenant's risk settings over the defaults.
+func (s *OrderRiskService) settings(ctx context.Context, tx pgx.Tx, tenantID uuid.UUID) (model.OrderRiskSettings, error) {
+	settings := model.DefaultOrderRiskSettings()
+	raw, err := s.tenantRepo.GetSettings(ctx, tx, tenantID)
+	if err != nil {
+		return settings, err
+	}
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &all); err != nil {
+		return settings, nil
+	}
+	if section, ok := all["order_risk"]; ok {
+		if err := json.Unmarshal(section, &settings); err != nil {
+			s.logger.Warn("order risk: invalid settings section", "tenant_id", tenantID, "error", err)
+			return model.DefaultOrderRiskSettings(), nil
+		}
+	}
+	return settings, nil
+}
+
+// phoneKey returns the last nine digits of a phone number, or "" for a
+// number with fewer digits.
+func phoneKey(phone string) string {
+	digits := strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, phone)
+	if len(digits) < phoneKeyDigits {
+		return ""
+	}
+	return digits[len(digits)-phoneKeyDigits:]
+}
+
+// countryCode normalises a country of an address; "" when it is not set or
+// not recognised.
+func countryCode(country string) string {
+	if strings.TrimSpace(country) == "" {
+		return ""
+	}
+	code, _ := address.NormalizeCountry(country)
+	return code
+}
+
+// riskScoreData returns the risk score of an order for automation event data:
+// the score, or nil when the order was not scored.
+func riskScoreData(order *model.Order) any {
+	if order.RiskScore == nil {
+		return nil
+	}
+	return *order.RiskScore
+}
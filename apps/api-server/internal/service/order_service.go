@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
@@ -15,12 +16,15 @@ import (
 	"github.com/openoms-org/openoms/apps/api-server/internal/database"
 	"github.com/openoms-org/openoms/apps/api-server/internal/model"
 	"github.com/openoms-org/openoms/apps/api-server/internal/repository"
+	engine "github.com/openoms-org/openoms/packages/order-engine"
 )
 
 var (
 	ErrOrderNotFound     = errors.New("order not found")
-	ErrInvalidTransition = errors.New("invalid status transition")
-	ErrUnknownStatus     = errors.New("unknown status")
+	ErrInvalidTransition = engine.ErrInvalidTransition
+	ErrUnknownStatus     = engine.ErrUnknownStatus
+	ErrGuardFailed       = engine.ErrGuardFailed
+	ErrOrderHeld         = engine.ErrOrderHeld
 )
 
 type OrderService struct {
@@ -34,6 +38,8 @@ type OrderService struct {
 	smsService        *SMSService
 	automationService *AutomationService
 	shipmentService   *ShipmentService
+	orderEventRepo    repository.OrderEventRepo
+	holdService       *OrderHoldService
 }
 
 func NewOrderService(
@@ -86,8 +92,26 @@ func (s *OrderService) SetShipmentService(shipmentSvc *ShipmentService) {
 	s.shipmentService = shipmentSvc
 }
 
+// SetOrderEventRepo sets the repository for order events and the order
+// timeline.
+func (s *OrderService) SetOrderEventRepo(orderEventRepo repository.OrderEventRepo) {
+	s.orderEventRepo = orderEventRepo
+}
+
+// SetOrderHoldService sets the hold service that blocks held orders, checks
+// new orders and releases holds awaiting payment once an order is paid.
+func (s *OrderService) SetOrderHoldService(holdSvc *OrderHoldService) {
+	s.holdService = holdSvc
+}
+
 func (s *OrderService) loadStatusConfig(ctx context.Context, tx pgx.Tx, tenantID uuid.UUID) (*model.OrderStatusConfig, error) {
-	settings, err := s.tenantRepo.GetSettings(ctx, tx, tenantID)
+	return loadOrderStatusConfig(ctx, tx, s.tenantRepo, tenantID)
+}
+
+// loadOrderStatusConfig reads the tenant's order workflow from the
+// "order_statuses" settings, falling back to the default workflow.
+func loadOrderStatusConfig(ctx context.Context, tx pgx.Tx, tenantRepo repository.TenantRepo, tenantID uuid.UUID) (*model.OrderStatusConfig, error) {
+	settings, err := tenantRepo.GetSettings(ctx, tx, tenantID)
 	if err != nil {
 		return nil, err
 	}
@@ -108,9 +132,90 @@ func (s *OrderService) loadStatusConfig(ctx context.Context, tx pgx.Tx, tenantID
 	return &cfg, nil
 }
 
+// resolveOrderFilter fills the parts of an order filter that depend on the
+// tenant's order workflow.
+func resolveOrderFilter(ctx context.Context, tx pgx.Tx, tenantRepo repository.TenantRepo, tenantID uuid.UUID, filter *model.OrderListFilter) error {
+	if filter.UnpackedDays == nil {
+		return nil
+	}
+	config, err := loadOrderStatusConfig(ctx, tx, tenantRepo, tenantID)
+	if err != nil {
+		return fmt.Errorf("load status config: %w", err)
+	}
+	filter.PackedStatuses = config.PackedStatuses()
+	return nil
+}
+
+// loadWorkflow builds the tenant's order workflow. Saved transitions to or
+// from undefined statuses are dropped with a warning rather than failing
+// every status change.
+func (s *OrderService) loadWorkflow(ctx context.Context, tx pgx.Tx, tenantID uuid.UUID) (*engine.Workflow, error) {
+	config, err := s.loadStatusConfig(ctx, tx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("load status config: %w", err)
+	}
+	if dropped := config.DropUnknownTransitions(); len(dropped) > 0 {
+		slog.Warn("order workflow: dropped transitions with unknown statuses", "tenant_id", tenantID, "transitions", dropped)
+	}
+	workflow, err := config.Workflow()
+	if err != nil {
+		return nil, fmt.Errorf("order workflow: %w", err)
+	}
+	return workflow, nil
+}
+
+// transitionOrder checks a status change against the workflow, including the
+// guards of the target state and the order's holds, and returns its side
+// effects. Force skips the transition map, guards and holds.
+func (s *OrderService) transitionOrder(ctx context.Context, tx pgx.Tx, workflow *engine.Workflow, order *model.Order, to string, force bool) (engine.OrderTransitionResult, error) {
+	from, target := engine.OrderStatus(order.Status), engine.OrderStatus(to)
+	now := time.Now()
+	if force {
+		return workflow.ForceTransition(from, target, now)
+	}
+
+	facts := engine.OrderFacts{
+		Paid:               order.PaymentStatus == "paid",
+		HasCustomerEmail:   order.CustomerEmail != nil && *order.CustomerEmail != "",
+		HasShippingAddress: hasAddress(order.ShippingAddress),
+	}
+	if state, ok := workflow.State(target); ok && slices.Contains(state.Requires, engine.GuardHasShipment) && s.shipmentService != nil {
+		has, err := s.shipmentService.hasActiveShipment(ctx, tx, order.ID)
+		if err != nil {
+			return engine.OrderTransitionResult{}, err
+		}
+		facts.HasShipment = has
+	}
+	if s.holdService != nil && workflow.Category(target).BlockedByHolds() {
+		held, err := s.holdService.hasActiveHolds(ctx, tx, order.ID)
+		if err != nil {
+			return engine.OrderTransitionResult{}, err
+		}
+		facts.Held = held
+	}
+	return workflow.Transition(from, target, facts, now)
+}
+
+// hasAddress reports whether an address holds at least one non-empty field.
+func hasAddress(raw json.RawMessage) bool {
+	var addr map[string]any
+	if err := json.Unmarshal(raw, &addr); err != nil {
+		return false
+	}
+	for _, v := range addr {
+		if v != nil && v != "" {
+			return true
+		}
+	}
+	return false
+}
+
 func (s *OrderService) List(ctx context.Context, tenantID uuid.UUID, filter model.OrderListFilter) (model.ListResponse[model.Order], error) {
 	var resp model.ListResponse[model.Order]
 	err := database.WithTenant(ctx, s.pool, tenantID, func(tx pgx.Tx) error {
+		if err := resolveOrderFilter(ctx, tx, s.tenantRepo, tenantID, &filter); err != nil {
+			return err
+		}
 		orders, total, err := s.orderRepo.List(ctx, tx, filter)
 		if err != nil {
 			return err
@@ -215,10 +320,20 @@ func (s *OrderService) Create(ctx context.Context, tenantID uuid.UUID, req model
 		order.PaymentMethod = req.PaymentMethod
 	}
 
+	var holds []model.OrderHold
 	err := database.WithTenant(ctx, s.pool, tenantID, func(tx pgx.Tx) error {
 		if err := s.orderRepo.Create(ctx, tx, order); err != nil {
 			return err
 		}
+		if s.holdService != nil {
+			var err error
+			if holds, err = s.holdService.CheckNewOrder(ctx, tx, order); err != nil {
+				return err
+			}
+		}
+		if err := EnqueueAutomationEvent(ctx, tx, s.automationService, tenantID, "order", "order.created", order.ID, orderEventData(order)); err != nil {
+			return err
+		}
 		return s.auditRepo.Log(ctx, tx, model.AuditEntry{
 			TenantID:   tenantID,
 			UserID:     actorID,
@@ -233,11 +348,9 @@ func (s *OrderService) Create(ctx context.Context, tenantID uuid.UUID, req model
 		return nil, err
 	}
 	go s.webhookDispatch.Dispatch(context.Background(), tenantID, "order.created", order)
-	FireAutomationEvent(s.automationService, tenantID, "order", "order.created", order.ID, map[string]any{
-		"status": order.Status, "source": order.Source,
-		"customer_name": order.CustomerName, "total_amount": order.TotalAmount,
-		"currency": order.Currency, "payment_status": order.PaymentStatus,
-	})
+	if s.holdService != nil {
+		s.holdService.NotifyHolds(tenantID, holds)
+	}
 
 	// Auto-create shipment if requested (best effort — never fails order creation)
 	if req.AutoCreateShipment && req.ShipmentProvider != nil && *req.ShipmentProvider != "" && s.shipmentService != nil {
@@ -266,6 +379,7 @@ func (s *OrderService) Update(ctx context.Context, tenantID, orderID uuid.UUID,
 	}
 
 	var order *model.Order
+	var releasedHolds []model.OrderHold
 	err := database.WithTenant(ctx, s.pool, tenantID, func(tx pgx.Tx) error {
 		existing, err := s.orderRepo.FindByID(ctx, tx, orderID)
 		if err != nil {
@@ -284,6 +398,25 @@ func (s *OrderService) Update(ctx context.Context, tenantID, orderID uuid.UUID,
 			return err
 		}
 
+		// Re-validate an edited address; the address verification hold, if
+		// any, stays until released by hand.
+		if s.holdService != nil && (req.ShippingAddress != nil || req.CustomerPhone != nil || req.PickupPointID != nil) {
+			if err := s.holdService.checkAddress(ctx, tx, order); err != nil {
+				return err
+			}
+		}
+
+		// Release holds awaiting payment as soon as the order is paid.
+		if s.holdService != nil && existing.PaymentStatus != "paid" && order.PaymentStatus == "paid" {
+			if releasedHolds, err = s.holdService.releaseCleared(ctx, tx, order); err != nil {
+				return err
+			}
+		}
+
+		if err := EnqueueAutomationEvent(ctx, tx, s.automationService, tenantID, "order", "order.updated", order.ID, orderEventData(order)); err != nil {
+			return err
+		}
+
 		return s.auditRepo.Log(ctx, tx, model.AuditEntry{
 			TenantID:   tenantID,
 			UserID:     actorID,
@@ -295,11 +428,9 @@ func (s *OrderService) Update(ctx context.Context, tenantID, orderID uuid.UUID,
 	})
 	if err == nil && order != nil {
 		go s.webhookDispatch.Dispatch(context.Background(), tenantID, "order.updated", order)
-		FireAutomationEvent(s.automationService, tenantID, "order", "order.updated", order.ID, map[string]any{
-			"status": order.Status, "source": order.Source,
-			"customer_name": order.CustomerName, "total_amount": order.TotalAmount,
-			"currency": order.Currency, "payment_status": order.PaymentStatus,
-		})
+		if s.holdService != nil {
+			s.holdService.NotifyHolds(tenantID, releasedHolds)
+		}
 	}
 	return order, err
 }
@@ -351,39 +482,17 @@ func (s *OrderService) TransitionStatus(ctx context.Context, tenantID, orderID u
 		}
 		oldStatus = existing.Status
 
-		config, err := s.loadStatusConfig(ctx, tx, tenantID)
+		workflow, err := s.loadWorkflow(ctx, tx, tenantID)
 		if err != nil {
-			return fmt.Errorf("load status config: %w", err)
-		}
-
-		if !config.IsValidStatus(req.Status) {
-			return fmt.Errorf("%w: %q", ErrUnknownStatus, req.Status)
-		}
-
-		var setShippedAt, setDeliveredAt *time.Time
-
-		if req.Force {
-			// Force mode: skip transition validation
-		} else {
-			if !config.IsValidStatus(existing.Status) {
-				return fmt.Errorf("%w: current %q", ErrUnknownStatus, existing.Status)
-			}
-			if !config.CanTransition(existing.Status, req.Status) {
-				return fmt.Errorf("%w: %s -> %s", ErrInvalidTransition, existing.Status, req.Status)
-			}
+			return err
 		}
 
-		// Set timestamps for special statuses
-		if req.Status == "shipped" {
-			now := time.Now()
-			setShippedAt = &now
-		}
-		if req.Status == "delivered" {
-			now := time.Now()
-			setDeliveredAt = &now
+		result, err := s.transitionOrder(ctx, tx, workflow, existing, req.Status, req.Force)
+		if err != nil {
+			return err
 		}
 
-		if err := s.orderRepo.UpdateStatus(ctx, tx, orderID, req.Status, setShippedAt, setDeliveredAt); err != nil {
+		if err := s.orderRepo.UpdateStatus(ctx, tx, orderID, req.Status, result.SetShippedAt, result.SetDeliveredAt); err != nil {
 			return err
 		}
 
@@ -392,6 +501,12 @@ func (s *OrderService) TransitionStatus(ctx context.Context, tenantID, orderID u
 			return err
 		}
 
+		data := orderEventData(order)
+		data["old_status"], data["new_status"] = oldStatus, req.Status
+		if err := EnqueueAutomationEvent(ctx, tx, s.automationService, tenantID, "order", "order.status_changed", order.ID, data); err != nil {
+			return err
+		}
+
 		return s.auditRepo.Log(ctx, tx, model.AuditEntry{
 			TenantID:   tenantID,
 			UserID:     actorID,
@@ -411,12 +526,6 @@ func (s *OrderService) TransitionStatus(ctx context.Context, tenantID, orderID u
 		if s.smsService != nil {
 			go s.smsService.SendOrderStatusSMS(context.Background(), tenantID, order, oldStatus, req.Status)
 		}
-		FireAutomationEvent(s.automationService, tenantID, "order", "order.status_changed", order.ID, map[string]any{
-			"status": order.Status, "old_status": oldStatus, "new_status": req.Status,
-			"source": order.Source, "customer_name": order.CustomerName,
-			"total_amount": order.TotalAmount, "currency": order.Currency,
-			"payment_status": order.PaymentStatus,
-		})
 	}
 	return order, err
 }
@@ -445,15 +554,34 @@ func (s *OrderService) BulkTransitionStatus(ctx context.Context, tenantID uuid.U
 	var pendingWebhooks []webhookNotification
 
 	err := database.WithTenant(ctx, s.pool, tenantID, func(tx pgx.Tx) error {
-		config, err := s.loadStatusConfig(ctx, tx, tenantID)
+		workflow, err := s.loadWorkflow(ctx, tx, tenantID)
 		if err != nil {
-			return fmt.Errorf("load status config: %w", err)
+			return err
 		}
 
-		if !config.IsValidStatus(req.Status) {
+		if _, ok := workflow.State(engine.OrderStatus(req.Status)); !ok {
 			return fmt.Errorf("%w: %q", ErrUnknownStatus, req.Status)
 		}
 
+		if len(req.Filter) > 0 {
+			filter, err := model.ParseOrderFilter(req.Filter.Values())
+			if err != nil {
+				return NewValidationError(err)
+			}
+			if err := resolveOrderFilter(ctx, tx, s.tenantRepo, tenantID, &filter); err != nil {
+				return err
+			}
+			ids, err := s.orderRepo.ListIDs(ctx, tx, filter, model.MaxLimit+1)
+			if err != nil {
+				return err
+			}
+			if len(ids) > model.MaxLimit {
+				return NewValidationError(fmt.Errorf("filter matches more than %d orders; narrow it down", model.MaxLimit))
+			}
+			req.OrderIDs = ids
+			resp.Results = make([]model.BulkStatusResult, 0, len(ids))
+		}
+
 		for _, orderID := range req.OrderIDs {
 			result := model.BulkStatusResult{OrderID: orderID}
 
@@ -465,31 +593,20 @@ func (s *OrderService) BulkTransitionStatus(ctx context.Context, tenantID uuid.U
 				continue
 			}
 
-			var setShippedAt, setDeliveredAt *time.Time
-
-			if req.Force {
-				// Force mode: skip transition validation
-			} else {
-				if !config.CanTransition(existing.Status, req.Status) {
-					result.Error = fmt.Sprintf("invalid transition: %s -> %s", existing.Status, req.Status)
-					resp.Results = append(resp.Results, result)
-					resp.Failed++
-					continue
+			transition, err := s.transitionOrder(ctx, tx, workflow, existing, req.Status, req.Force)
+			if err != nil {
+				if !errors.Is(err, ErrInvalidTransition) && !errors.Is(err, ErrUnknownStatus) && !errors.Is(err, ErrGuardFailed) && !errors.Is(err, ErrOrderHeld) {
+					return err
 				}
-			}
-
-			if req.Status == "shipped" {
-				now := time.Now()
-				setShippedAt = &now
-			}
-			if req.Status == "delivered" {
-				now := time.Now()
-				setDeliveredAt = &now
+				result.Error = err.Error()
+				resp.Results = append(resp.Results, result)
+				resp.Failed++
+				continue
 			}
 
 			oldStatus := existing.Status
 
-			if err := s.orderRepo.UpdateStatus(ctx, tx, orderID, req.Status, setShippedAt, setDeliveredAt); err != nil {
+			if err := s.orderRepo.UpdateStatus(ctx, tx, orderID, req.Status, transition.SetShippedAt, transition.SetDeliveredAt); err != nil {
 				result.Error = "failed to update status"
 				resp.Results = append(resp.Results, result)
 				resp.Failed++
@@ -513,6 +630,11 @@ func (s *OrderService) BulkTransitionStatus(ctx context.Context, tenantID uuid.U
 				pendingEmails = append(pendingEmails, emailNotification{
 					order: updated, oldStatus: oldStatus, newStatus: req.Status,
 				})
+				data := orderEventData(updated)
+				data["old_status"], data["new_status"] = oldStatus, req.Status
+				if err := EnqueueAutomationEvent(ctx, tx, s.automationService, tenantID, "order", "order.status_changed", updated.ID, data); err != nil {
+					return err
+				}
 			}
 
 			result.Success = true
@@ -555,6 +677,50 @@ func (s *OrderService) GetAudit(ctx context.Context, tenantID, orderID uuid.UUID
 	return entries, err
 }
 
+// Timeline returns the timeline of an order: what happened to the order and
+// its shipments, returns and invoices, newest first.
+func (s *OrderService) Timeline(ctx context.Context, tenantID, orderID uuid.UUID, filter model.TimelineFilter) ([]model.TimelineEntry, error) {
+	if s.orderEventRepo == nil {
+		return nil, errors.New("order timeline is not configured")
+	}
+	var entries []model.TimelineEntry
+	err := database.WithTenant(ctx, s.pool, tenantID, func(tx pgx.Tx) error {
+		order, err := s.orderRepo.FindByID(ctx, tx, orderID)
+		if err != nil {
+			return err
+		}
+		if order == nil {
+			return ErrOrderNotFound
+		}
+		entries, err = s.orderEventRepo.Timeline(ctx, tx, orderID, filter)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// RecordMarketplaceSync records a sync action made against the marketplace of
+// an order, such as a fulfillment or tracking update, on the order timeline.
+func (s *OrderService) RecordMarketplaceSync(ctx context.Context, tenantID, orderID uuid.UUID, provider, action string, data map[string]string, actorID uuid.UUID) error {
+	if data == nil {
+		data = map[string]string{}
+	}
+	data["provider"] = provider
+	data["action"] = action
+	return database.WithTenant(ctx, s.pool, tenantID, func(tx pgx.Tx) error {
+		return recordOrderEvent(ctx, tx, s.orderEventRepo, &model.OrderEvent{
+			TenantID:  tenantID,
+			OrderID:   orderID,
+			Source:    model.TimelineSourceMarketplace,
+			EventType: model.OrderEventMarketplaceSync,
+			ActorID:   nilIfNilUUID(actorID),
+			Data:      data,
+		})
+	})
+}
+
 func stringOrEmpty(s *string) string {
 	if s == nil {
 		return ""
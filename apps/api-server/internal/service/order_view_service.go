@@ -0,0 +1,133 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/openoms-org/openoms/apps/api-server/internal/database"
+	"github.com/openoms-org/openoms/apps/api-server/internal/model"
+	"github.com/openoms-org/openoms/apps/api-server/internal/repository"
+)
+
+var (
+	ErrOrderViewNotFound      = errors.New("order view not found")
+	ErrOrderViewDuplicateName = errors.New("order view with this name already exists")
+)
+
+// OrderViewService manages the saved order filters of each user.
+type OrderViewService struct {
+	viewRepo repository.OrderViewRepo
+	pool     *pgxpool.Pool
+}
+
+// NewOrderViewService creates a new OrderViewService.
+func NewOrderViewService(viewRepo repository.OrderViewRepo, pool *pgxpool.Pool) *OrderViewService {
+	return &OrderViewService{viewRepo: viewRepo, pool: pool}
+}
+
+// List returns the views saved by the user.
+func (s *OrderViewService) List(ctx context.Context, tenantID, userID uuid.UUID) ([]model.OrderView, error) {
+	var views []model.OrderView
+	err := database.WithTenant(ctx, s.pool, tenantID, func(tx pgx.Tx) error {
+		var err error
+		views, err = s.viewRepo.ListByUser(ctx, tx, userID)
+		return err
+	})
+	if views == nil {
+		views = []model.OrderView{}
+	}
+	return views, err
+}
+
+// Get returns a view owned by the user.
+func (s *OrderViewService) Get(ctx context.Context, tenantID, userID, id uuid.UUID) (*model.OrderView, error) {
+	var view *model.OrderView
+	err := database.WithTenant(ctx, s.pool, tenantID, func(tx pgx.Tx) error {
+		var err error
+		view, err = s.findOwned(ctx, tx, userID, id)
+		return err
+	})
+	return view, err
+}
+
+func (s *OrderViewService) Create(ctx context.Context, tenantID, userID uuid.UUID, req model.CreateOrderViewRequest) (*model.OrderView, error) {
+	if err := req.Validate(); err != nil {
+		return nil, NewValidationError(err)
+	}
+
+	view := &model.OrderView{
+		ID:       uuid.New(),
+		TenantID: tenantID,
+		UserID:   userID,
+		Name:     model.StripHTMLTags(req.Name),
+		Filters:  req.Filters,
+	}
+	err := database.WithTenant(ctx, s.pool, tenantID, func(tx pgx.Tx) error {
+		if err := s.viewRepo.Create(ctx, tx, view); err != nil {
+			if isDuplicateKeyError(err) {
+				return ErrOrderViewDuplicateName
+			}
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return view, nil
+}
+
+func (s *OrderViewService) Update(ctx context.Context, tenantID, userID, id uuid.UUID, req model.UpdateOrderViewRequest) (*model.OrderView, error) {
+	if err := req.Validate(); err != nil {
+		return nil, NewValidationError(err)
+	}
+	if req.Name != nil {
+		name := model.StripHTMLTags(*req.Name)
+		req.Name = &name
+	}
+
+	var view *model.OrderView
+	err := database.WithTenant(ctx, s.pool, tenantID, func(tx pgx.Tx) error {
+		if _, err := s.findOwned(ctx, tx, userID, id); err != nil {
+			return err
+		}
+		if err := s.viewRepo.Update(ctx, tx, id, req); err != nil {
+			if isDuplicateKeyError(err) {
+				return ErrOrderViewDuplicateName
+			}
+			return err
+		}
+		var err error
+		view, err = s.viewRepo.FindByID(ctx, tx, id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return view, nil
+}
+
+func (s *OrderViewService) Delete(ctx context.Context, tenantID, userID, id uuid.UUID) error {
+	return database.WithTenant(ctx, s.pool, tenantID, func(tx pgx.Tx) error {
+		if _, err := s.findOwned(ctx, tx, userID, id); err != nil {
+			return err
+		}
+		return s.viewRepo.Delete(ctx, tx, id)
+	})
+}
+
+// findOwned hides views of other users behind ErrOrderViewNotFound.
+func (s *OrderViewService) findOwned(ctx context.Context, tx pgx.Tx, userID, id uuid.UUID) (*model.OrderView, error) {
+	view, err := s.viewRepo.FindByID(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	if view == nil || view.UserID != userID {
+		return nil, ErrOrderViewNotFound
+	}
+	return view, nil
+}
@@ -76,6 +76,26 @@ func (s *ProductService) Create(ctx context.Context, tenantID uuid.UUID, req mod
 		return nil, NewValidationError(err)
 	}
 
+	var product *model.Product
+	err := database.WithTenant(ctx, s.pool, tenantID, func(tx pgx.Tx) error {
+		var err error
+		product, err = s.create(ctx, tx, tenantID, req, actorID, ip)
+		return err
+	})
+	if err != nil {
+		if isDuplicateKeyError(err) {
+			return nil, ErrDuplicateSKU
+		}
+		return nil, err
+	}
+	s.notifyCreated(tenantID, product)
+	return product, nil
+}
+
+// create creates a validated product within tx, with its product.created
+// automation event and audit entry. Pass the product to notifyCreated once
+// the transaction commits.
+func (s *ProductService) create(ctx context.Context, tx pgx.Tx, tenantID uuid.UUID, req model.CreateProductRequest, actorID uuid.UUID, ip string) (*model.Product, error) {
 	// Sanitize user-facing text fields to prevent stored XSS
 	req.Name = model.StripHTMLTags(req.Name)
 	req.DescriptionShort = model.StripHTMLTags(req.DescriptionShort)
@@ -105,6 +125,7 @@ func (s *ProductService) Create(ctx context.Context, tenantID uuid.UUID, req mod
 		SKU:              req.SKU,
 		EAN:              req.EAN,
 		Price:            req.Price,
+		CostPrice:        req.CostPrice,
 		StockQuantity:    req.StockQty,
 		Metadata:         metadata,
 		Tags:             tags,
@@ -119,34 +140,34 @@ func (s *ProductService) Create(ctx context.Context, tenantID uuid.UUID, req mod
 		Images:           images,
 	}
 
-	err := database.WithTenant(ctx, s.pool, tenantID, func(tx pgx.Tx) error {
-		if err := s.productRepo.Create(ctx, tx, product); err != nil {
-			return err
-		}
-		return s.auditRepo.Log(ctx, tx, model.AuditEntry{
-			TenantID:   tenantID,
-			UserID:     actorID,
-			Action:     "product.created",
-			EntityType: "product",
-			EntityID:   product.ID,
-			Changes:    map[string]string{"name": req.Name, "source": req.Source},
-			IPAddress:  ip,
-		})
-	})
-	if err != nil {
-		if isDuplicateKeyError(err) {
-			return nil, ErrDuplicateSKU
-		}
+	if err := s.productRepo.Create(ctx, tx, product); err != nil {
 		return nil, err
 	}
-	go s.webhookDispatch.Dispatch(context.Background(), tenantID, "product.created", product)
-	FireAutomationEvent(s.automationService, tenantID, "product", "product.created", product.ID, map[string]any{
+	if err := EnqueueAutomationEvent(ctx, tx, s.automationService, tenantID, "product", "product.created", product.ID, map[string]any{
 		"name": product.Name, "price": product.Price, "stock_quantity": product.StockQuantity,
 		"source": product.Source,
-	})
+	}); err != nil {
+		return nil, err
+	}
+	if err := s.auditRepo.Log(ctx, tx, model.AuditEntry{
+		TenantID:   tenantID,
+		UserID:     actorID,
+		Action:     "product.created",
+		EntityType: "product",
+		EntityID:   product.ID,
+		Changes:    map[string]string{"name": req.Name, "source": req.Source},
+		IPAddress:  ip,
+	}); err != nil {
+		return nil, err
+	}
 	return product, nil
 }
 
+// notifyCreated dispatches the product.created webhook of a committed product.
+func (s *ProductService) notifyCreated(tenantID uuid.UUID, product *model.Product) {
+	go s.webhookDispatch.Dispatch(context.Background(), tenantID, "product.created", product)
+}
+
 func (s *ProductService) Update(ctx context.Context, tenantID, productID uuid.UUID, req model.UpdateProductRequest, actorID uuid.UUID, ip string) (*model.Product, error) {
 	if err := req.Validate(); err != nil {
 		return nil, NewValidationError(err)
@@ -187,6 +208,13 @@ func (s *ProductService) Update(ctx context.Context, tenantID, productID uuid.UU
 			return err
 		}
 
+		if err := EnqueueAutomationEvent(ctx, tx, s.automationService, tenantID, "product", "product.updated", product.ID, map[string]any{
+			"name": product.Name, "price": product.Price, "stock_quantity": product.StockQuantity,
+			"source": product.Source,
+		}); err != nil {
+			return err
+		}
+
 		return s.auditRepo.Log(ctx, tx, model.AuditEntry{
 			TenantID:   tenantID,
 			UserID:     actorID,
@@ -204,10 +232,6 @@ func (s *ProductService) Update(ctx context.Context, tenantID, productID uuid.UU
 	}
 	if product != nil {
 		go s.webhookDispatch.Dispatch(context.Background(), tenantID, "product.updated", product)
-		FireAutomationEvent(s.automationService, tenantID, "product", "product.updated", product.ID, map[string]any{
-			"name": product.Name, "price": product.Price, "stock_quantity": product.StockQuantity,
-			"source": product.Source,
-		})
 	}
 	return product, err
 }
@@ -5,19 +5,32 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 
+	"github.com/openoms-org/openoms/apps/api-server/internal/crypto"
 	"github.com/openoms-org/openoms/apps/api-server/internal/database"
+	"github.com/openoms-org/openoms/apps/api-server/internal/integration"
 	"github.com/openoms-org/openoms/apps/api-server/internal/model"
 	"github.com/openoms-org/openoms/apps/api-server/internal/repository"
+	engine "github.com/openoms-org/openoms/packages/order-engine"
 )
 
 var (
 	ErrReturnNotFound          = errors.New("return not found")
 	ErrInvalidReturnTransition = errors.New("invalid return status transition")
+	ErrReturnRefundFailed      = errors.New("channel refund failed")
+	ErrReturnRefundPending     = errors.New("a channel refund of the return is pending")
+	ErrReturnGradingPending    = errors.New("the received return has not been graded yet")
+	ErrReturnLabelFailed       = errors.New("return label generation failed")
+	ErrReturnCarrierDisabled   = errors.New("return carrier is not configured")
 )
 
 type ReturnService struct {
@@ -27,6 +40,21 @@ type ReturnService struct {
 	pool              *pgxpool.Pool
 	webhookDispatch   *WebhookDispatchService
 	automationService *AutomationService
+
+	// receiving and refund execution
+	warehouseDocService *WarehouseDocumentService
+	productRepo         repository.ProductRepo
+	variantRepo         repository.VariantRepo
+	integrationRepo     repository.IntegrationRepo
+	tenantRepo          repository.TenantRepo
+	encryptionKey       []byte
+	invoiceService      *InvoiceService
+
+	// prepaid return labels
+	uploadDir string
+	baseURL   string
+
+	logger *slog.Logger
 }
 
 // SetAutomationService sets the automation service for rule processing.
@@ -34,6 +62,36 @@ func (s *ReturnService) SetAutomationService(automationSvc *AutomationService) {
 	s.automationService = automationSvc
 }
 
+// SetInvoiceService sets the invoice service used to issue correction
+// invoices for refunded returns.
+func (s *ReturnService) SetInvoiceService(invoiceSvc *InvoiceService) {
+	s.invoiceService = invoiceSvc
+}
+
+// SetReceivingDeps wires the dependencies used to restock received returns
+// and to execute refunds through the order's sales channel.
+func (s *ReturnService) SetReceivingDeps(
+	warehouseDocService *WarehouseDocumentService,
+	productRepo repository.ProductRepo,
+	variantRepo repository.VariantRepo,
+	integrationRepo repository.IntegrationRepo,
+	tenantRepo repository.TenantRepo,
+	encryptionKey []byte,
+) {
+	s.warehouseDocService = warehouseDocService
+	s.productRepo = productRepo
+	s.variantRepo = variantRepo
+	s.integrationRepo = integrationRepo
+	s.tenantRepo = tenantRepo
+	s.encryptionKey = encryptionKey
+}
+
+// SetLabelStorage sets where generated return labels are saved and served from.
+func (s *ReturnService) SetLabelStorage(uploadDir, baseURL string) {
+	s.uploadDir = uploadDir
+	s.baseURL = baseURL
+}
+
 func NewReturnService(
 	returnRepo repository.ReturnRepo,
 	orderRepo repository.OrderRepo,
@@ -47,6 +105,7 @@ func NewReturnService(
 		auditRepo:       auditRepo,
 		pool:            pool,
 		webhookDispatch: webhookDispatch,
+		logger:          slog.Default().With("service", "return"),
 	}
 }
 
@@ -104,15 +163,22 @@ func (s *ReturnService) Create(ctx context.Context, tenantID uuid.UUID, req mode
 		items = json.RawMessage("[]")
 	}
 
+	returnType := req.ReturnType
+	if returnType == "" {
+		returnType = string(engine.ReturnTypeRefund)
+	}
+
 	ret := &model.Return{
-		ID:           uuid.New(),
-		TenantID:     tenantID,
-		OrderID:      req.OrderID,
-		Status:       "requested",
-		Reason:       req.Reason,
-		Items:        items,
-		RefundAmount: req.RefundAmount,
-		Notes:        req.Notes,
+		ID:            uuid.New(),
+		TenantID:      tenantID,
+		OrderID:       req.OrderID,
+		Status:        "requested",
+		ReturnType:    returnType,
+		Reason:        req.Reason,
+		Items:         items,
+		RefundAmount:  req.RefundAmount,
+		Notes:         req.Notes,
+		ReceivedItems: json.RawMessage("[]"),
 	}
 
 	err := database.WithTenant(ctx, s.pool, tenantID, func(tx pgx.Tx) error {
@@ -127,6 +193,13 @@ func (s *ReturnService) Create(ctx context.Context, tenantID uuid.UUID, req mode
 		if err := s.returnRepo.Create(ctx, tx, ret); err != nil {
 			return err
 		}
+		if err := EnqueueAutomationEvent(ctx, tx, s.automationService, tenantID, "return", "return.created", ret.ID, map[string]any{
+			"status": ret.Status, "reason": ret.Reason, "order_id": ret.OrderID.String(),
+			"refund_amount": ret.RefundAmount, "created_at": eventTime(ret.CreatedAt),
+			"status_changed_at": eventTime(ret.CreatedAt),
+		}); err != nil {
+			return err
+		}
 		return s.auditRepo.Log(ctx, tx, model.AuditEntry{
 			TenantID:   tenantID,
 			UserID:     actorID,
@@ -141,10 +214,6 @@ func (s *ReturnService) Create(ctx context.Context, tenantID uuid.UUID, req mode
 		return nil, err
 	}
 	go s.webhookDispatch.Dispatch(context.Background(), tenantID, "return.created", ret)
-	FireAutomationEvent(s.automationService, tenantID, "return", "return.created", ret.ID, map[string]any{
-		"status": ret.Status, "reason": ret.Reason, "order_id": ret.OrderID.String(),
-		"refund_amount": ret.RefundAmount,
-	})
 	return ret, nil
 }
 
@@ -162,6 +231,9 @@ func (s *ReturnService) Update(ctx context.Context, tenantID, returnID uuid.UUID
 		if existing == nil {
 			return ErrReturnNotFound
 		}
+		if req.RefundAmount != nil && existing.ReceivedAt != nil {
+			return NewValidationError(errors.New("refund_amount is calculated from the received items"))
+		}
 
 		if err := s.returnRepo.Update(ctx, tx, returnID, req); err != nil {
 			return err
@@ -191,6 +263,16 @@ func (s *ReturnService) TransitionStatus(ctx context.Context, tenantID, returnID
 	if req.Status == "" {
 		return nil, NewValidationError(errors.New("status is required"))
 	}
+	// Receipt, refund and exchange have side effects (restock, payment,
+	// replacement order) and go through Receive, Refund and Exchange.
+	switch engine.ReturnStatus(req.Status) {
+	case engine.ReturnReceived:
+		return nil, NewValidationError(errors.New("use the receive endpoint to mark a return as received"))
+	case engine.ReturnRefunded, engine.ReturnPartiallyRefunded:
+		return nil, NewValidationError(errors.New("use the refund endpoint to refund a return"))
+	case engine.ReturnExchanged:
+		return nil, NewValidationError(errors.New("use the exchange endpoint to exchange a return"))
+	}
 
 	var ret *model.Return
 	var oldStatus string
@@ -203,9 +285,12 @@ func (s *ReturnService) TransitionStatus(ctx context.Context, tenantID, returnID
 			return ErrReturnNotFound
 		}
 		oldStatus = existing.Status
+		if existing.RefundKey != nil {
+			return ErrReturnRefundPending
+		}
 
-		if !model.IsValidReturnTransition(existing.Status, req.Status) {
-			return fmt.Errorf("%w: %s -> %s", ErrInvalidReturnTransition, existing.Status, req.Status)
+		if _, err := transitionReturn(existing, engine.ReturnStatus(req.Status), engine.ReturnFacts{}); err != nil {
+			return err
 		}
 
 		if err := s.returnRepo.UpdateStatus(ctx, tx, returnID, req.Status); err != nil {
@@ -216,8 +301,11 @@ func (s *ReturnService) TransitionStatus(ctx context.Context, tenantID, returnID
 		if err != nil {
 			return err
 		}
+		if err := s.enqueueStatusChanged(ctx, tx, tenantID, ret, oldStatus); err != nil {
+			return err
+		}
 
-		if err := s.auditRepo.Log(ctx, tx, model.AuditEntry{
+		return s.auditRepo.Log(ctx, tx, model.AuditEntry{
 			TenantID:   tenantID,
 			UserID:     actorID,
 			Action:     "return.status_changed",
@@ -225,29 +313,63 @@ func (s *ReturnService) TransitionStatus(ctx context.Context, tenantID, returnID
 			EntityID:   returnID,
 			Changes:    map[string]string{"from": existing.Status, "to": req.Status},
 			IPAddress:  ip,
-		}); err != nil {
-			return err
-		}
+		})
+	})
+	if err != nil || ret == nil {
+		return ret, err
+	}
+	s.notifyStatusChanged(tenantID, ret, oldStatus)
 
-		// Auto-update order payment_status when return is refunded
-		if req.Status == "refunded" {
-			refunded := "refunded"
-			updateReq := model.UpdateOrderRequest{PaymentStatus: &refunded}
-			if err := s.orderRepo.Update(ctx, tx, existing.OrderID, updateReq); err != nil {
-				return fmt.Errorf("sync order payment status to refunded: %w", err)
-			}
+	// Approved returns get a prepaid label when a return carrier is configured.
+	// A carrier failure does not undo the approval; the label can be retried.
+	if ret.Status == "approved" {
+		labeled, err := s.CreateReturnLabel(ctx, tenantID, returnID, actorID, ip)
+		switch {
+		case err == nil:
+			ret = labeled
+		case errors.Is(err, ErrReturnCarrierDisabled):
+		default:
+			s.logger.Error("return label generation failed", "tenant_id", tenantID, "return_id", returnID, "error", err)
 		}
+	}
+	return ret, nil
+}
 
-		return nil
-	})
-	if err == nil && ret != nil {
-		go s.webhookDispatch.Dispatch(context.Background(), tenantID, "return.status_changed", map[string]any{"return_id": returnID.String(), "from": oldStatus, "to": req.Status})
-		FireAutomationEvent(s.automationService, tenantID, "return", "return.status_changed", ret.ID, map[string]any{
-			"status": ret.Status, "old_status": oldStatus, "new_status": req.Status,
-			"order_id": ret.OrderID.String(), "refund_amount": ret.RefundAmount,
-		})
+// transitionReturn runs a status change of ret through the return state
+// machine, filling the refund facts from the return. Disallowed transitions
+// and unmet guards are reported as ErrInvalidReturnTransition, invalid refund
+// amounts as validation errors.
+func transitionReturn(ret *model.Return, to engine.ReturnStatus, facts engine.ReturnFacts) (engine.ReturnTransitionResult, error) {
+	facts.Type = engine.ReturnType(ret.ReturnType)
+	facts.RefundDue = ret.RefundAmount
+	facts.Refunded = ret.RefundedAmount
+	result, err := engine.TransitionReturn(engine.ReturnStatus(ret.Status), to, facts, time.Now())
+	switch {
+	case err == nil:
+		return result, nil
+	case errors.Is(err, engine.ErrInvalidRefund):
+		return result, NewValidationError(err)
+	case errors.Is(err, engine.ErrGuardFailed):
+		return result, fmt.Errorf("%w: %v", ErrInvalidReturnTransition, err)
+	default:
+		return result, fmt.Errorf("%w: %s -> %s", ErrInvalidReturnTransition, ret.Status, to)
 	}
-	return ret, err
+}
+
+// enqueueStatusChanged queues the status change automation event within tx.
+// ret is read back after the change, so its updated_at is the time of the
+// status change.
+func (s *ReturnService) enqueueStatusChanged(ctx context.Context, tx pgx.Tx, tenantID uuid.UUID, ret *model.Return, oldStatus string) error {
+	return EnqueueAutomationEvent(ctx, tx, s.automationService, tenantID, "return", "return.status_changed", ret.ID, map[string]any{
+		"status": ret.Status, "old_status": oldStatus, "new_status": ret.Status,
+		"order_id": ret.OrderID.String(), "refund_amount": ret.RefundAmount,
+		"created_at": eventTime(ret.CreatedAt), "status_changed_at": eventTime(ret.UpdatedAt),
+	})
+}
+
+// notifyStatusChanged dispatches the status change webhook.
+func (s *ReturnService) notifyStatusChanged(tenantID uuid.UUID, ret *model.Return, oldStatus string) {
+	go s.webhookDispatch.Dispatch(context.Background(), tenantID, "return.status_changed", map[string]any{"return_id": ret.ID.String(), "from": oldStatus, "to": ret.Status})
 }
 
 func (s *ReturnService) Delete(ctx context.Context, tenantID, returnID, actorID uuid.UUID, ip string) error {
@@ -279,3 +401,1042 @@ func (s *ReturnService) Delete(ctx context.Context, tenantID, returnID, actorID
 	}
 	return err
 }
+
+// returnOrderItem is the subset of an order item needed to receive and refund it.
+// Marketplace orders carry unit_price, manual orders price.
+type returnOrderItem struct {
+	Name       string     `json:"name"`
+	SKU        string     `json:"sku"`
+	ExternalID string     `json:"external_id"`
+	Quantity   int        `json:"quantity"`
+	UnitPrice  float64    `json:"unit_price"`
+	Price      float64    `json:"price"`
+	TotalPrice float64    `json:"total_price"`
+	ProductID  *uuid.UUID `json:"product_id"`
+	VariantID  *uuid.UUID `json:"variant_id"`
+}
+
+func (i returnOrderItem) unitPrice() float64 {
+	switch {
+	case i.UnitPrice > 0:
+		return i.UnitPrice
+	case i.Price > 0:
+		return i.Price
+	case i.TotalPrice > 0 && i.Quantity > 0:
+		return i.TotalPrice / float64(i.Quantity)
+	}
+	return 0
+}
+
+// Receive records the receipt of an approved return, or grades a return
+// received from carrier tracking. Every line is graded; resellable lines are
+// restocked through a confirmed PZ document and the refund is calculated from
+// the received lines and the shipping policy.
+func (s *ReturnService) Receive(ctx context.Context, tenantID, returnID uuid.UUID, req model.ReceiveReturnRequest, actorID uuid.UUID, ip string) (*model.Return, error) {
+	if err := req.Validate(); err != nil {
+		return nil, NewValidationError(err)
+	}
+	for i := range req.Items {
+		req.Items[i].Notes = model.StripHTMLTags(req.Items[i].Notes)
+	}
+
+	var ret *model.Return
+	var oldStatus string
+	var grading bool
+	err := database.WithTenant(ctx, s.pool, tenantID, func(tx pgx.Tx) error {
+		existing, err := s.returnRepo.FindByID(ctx, tx, returnID)
+		if err != nil {
+			return err
+		}
+		if existing == nil {
+			return ErrReturnNotFound
+		}
+		oldStatus = existing.Status
+		grading = existing.GradingPending && existing.Status == string(engine.ReturnReceived)
+		if !grading && !engine.CanTransitionReturn(engine.ReturnStatus(existing.Status), engine.ReturnReceived) {
+			return fmt.Errorf("%w: %s -> received", ErrInvalidReturnTransition, existing.Status)
+		}
+
+		order, err := s.orderRepo.FindByID(ctx, tx, existing.OrderID)
+		if err != nil {
+			return err
+		}
+		if order == nil {
+			return NewValidationError(errors.New("order not found"))
+		}
+		var orderItems []returnOrderItem
+		if len(order.Items) > 0 {
+			if err := json.Unmarshal(order.Items, &orderItems); err != nil {
+				return NewValidationError(errors.New("order items cannot be read"))
+			}
+		}
+
+		// Quantities and shipping already received by other returns of the order.
+		prevQty, prevShipping, err := s.previouslyReceived(ctx, tx, existing)
+		if err != nil {
+			return err
+		}
+
+		received := make([]model.ReturnReceivedItem, 0, len(req.Items))
+		receivedQty := make(map[int]int)
+		for i, item := range req.Items {
+			if item.OrderItemIndex >= len(orderItems) {
+				return NewValidationError(fmt.Errorf("items[%d]: order item %d does not exist", i, item.OrderItemIndex))
+			}
+			oi := orderItems[item.OrderItemIndex]
+			receivedQty[item.OrderItemIndex] += item.Quantity
+			if receivedQty[item.OrderItemIndex]+prevQty[item.OrderItemIndex] > oi.Quantity {
+				return NewValidationError(fmt.Errorf("items[%d]: more units of %q received than ordered", i, oi.Name))
+			}
+			received = append(received, model.ReturnReceivedItem{
+				OrderItemIndex: item.OrderItemIndex,
+				ProductID:      oi.ProductID,
+				VariantID:      oi.VariantID,
+				Name:           oi.Name,
+				SKU:            oi.SKU,
+				ExternalID:     oi.ExternalID,
+				Quantity:       item.Quantity,
+				UnitPrice:      oi.unitPrice(),
+				Condition:      item.Condition,
+				Deduction:      item.Deduction,
+				Notes:          item.Notes,
+			})
+		}
+
+		settings, err := s.returnSettings(ctx, tx, tenantID)
+		if err != nil {
+			return err
+		}
+
+		hasResellable := slices.ContainsFunc(received, func(item model.ReturnReceivedItem) bool {
+			return item.Condition == model.ReturnConditionResellable
+		})
+		// A return received from carrier tracking is already received; grading
+		// only restocks what the warehouse found resellable.
+		restock := hasResellable
+		if !grading {
+			result, err := transitionReturn(existing, engine.ReturnReceived, engine.ReturnFacts{HasResellable: hasResellable})
+			if err != nil {
+				return err
+			}
+			restock = result.Restock
+		}
+
+		var restockDoc *model.WarehouseDocument
+		var restockDocID *uuid.UUID
+		if restock {
+			restockDoc, err = s.restock(ctx, tx, tenantID, existing, received, req.WarehouseID, settings, actorID)
+			if err != nil {
+				return err
+			}
+		}
+		if restockDoc != nil {
+			restockDocID = &restockDoc.ID
+		}
+
+		fullReturn := true
+		var itemsTotal float64
+		for idx, oi := range orderItems {
+			itemsTotal += oi.unitPrice() * float64(oi.Quantity)
+			if prevQty[idx]+receivedQty[idx] < oi.Quantity {
+				fullReturn = false
+			}
+		}
+		shippingCost := 0.0
+		if prevShipping == 0 {
+			shippingCost = max(0, order.TotalAmount-itemsTotal)
+		}
+		refund := model.CalculateReturnRefund(received, shippingCost, fullReturn, settings.ShippingRefundPolicy())
+
+		receivedJSON, err := json.Marshal(received)
+		if err != nil {
+			return err
+		}
+		if err := s.returnRepo.MarkReceived(ctx, tx, returnID, receivedJSON, restockDocID, refund.Total, refund.Shipping); err != nil {
+			return err
+		}
+
+		ret, err = s.returnRepo.FindByID(ctx, tx, returnID)
+		if err != nil {
+			return err
+		}
+
+		action := "return.graded"
+		changes := map[string]string{
+			"refund_amount": fmt.Sprintf("%.2f", refund.Total),
+		}
+		if !grading {
+			if err := s.enqueueStatusChanged(ctx, tx, tenantID, ret, oldStatus); err != nil {
+				return err
+			}
+			action = "return.received"
+			changes["from"] = existing.Status
+			changes["to"] = "received"
+		}
+		if restockDoc != nil {
+			changes["restock_document"] = restockDoc.DocumentNumber
+		}
+		return s.auditRepo.Log(ctx, tx, model.AuditEntry{
+			TenantID:   tenantID,
+			UserID:     actorID,
+			Action:     action,
+			EntityType: "return",
+			EntityID:   returnID,
+			Changes:    changes,
+			IPAddress:  ip,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !grading {
+		s.notifyStatusChanged(tenantID, ret, oldStatus)
+	}
+	return ret, nil
+}
+
+// RecordDelivered receives an approved return whose inbound parcel was
+// delivered to the warehouse. The return moves to received pending grading:
+// nothing is restocked and no refund is due until the warehouse grades the
+// lines with Receive. A return no longer approved only gets the parcel status.
+func (s *ReturnService) RecordDelivered(ctx context.Context, tenantID, returnID uuid.UUID) (*model.Return, error) {
+	var ret *model.Return
+	var oldStatus string
+	err := database.WithTenant(ctx, s.pool, tenantID, func(tx pgx.Tx) error {
+		existing, err := s.returnRepo.FindByID(ctx, tx, returnID)
+		if err != nil {
+			return err
+		}
+		if existing == nil {
+			return ErrReturnNotFound
+		}
+		oldStatus = existing.Status
+		if existing.Status != string(engine.ReturnApproved) {
+			ret = existing
+			return s.returnRepo.UpdateReturnShipmentStatus(ctx, tx, returnID, "delivered")
+		}
+
+		if err := existing.MarkParcelDelivered(time.Now()); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidReturnTransition, err)
+		}
+		if err := s.returnRepo.MarkDelivered(ctx, tx, returnID); err != nil {
+			return err
+		}
+
+		ret, err = s.returnRepo.FindByID(ctx, tx, returnID)
+		if err != nil {
+			return err
+		}
+		if err := s.enqueueStatusChanged(ctx, tx, tenantID, ret, oldStatus); err != nil {
+			return err
+		}
+		return s.auditRepo.Log(ctx, tx, model.AuditEntry{
+			TenantID:   tenantID,
+			UserID:     uuid.Nil,
+			Action:     "return.received",
+			EntityType: "return",
+			EntityID:   returnID,
+			Changes: map[string]string{
+				"from":            oldStatus,
+				"to":              ret.Status,
+				"source":          "carrier_tracking",
+				"grading_pending": "true",
+			},
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	if ret.Status != oldStatus {
+		s.notifyStatusChanged(tenantID, ret, oldStatus)
+	}
+	return ret, nil
+}
+
+// UpdateReturnShipmentStatus records the carrier status of the inbound parcel
+// of a return.
+func (s *ReturnService) UpdateReturnShipmentStatus(ctx context.Context, tenantID, returnID uuid.UUID, status string) error {
+	return database.WithTenant(ctx, s.pool, tenantID, func(tx pgx.Tx) error {
+		return s.returnRepo.UpdateReturnShipmentStatus(ctx, tx, returnID, status)
+	})
+}
+
+// previouslyReceived sums the quantities per order item and the shipping refunded
+// by the other received or refunded returns of the same order.
+func (s *ReturnService) previouslyReceived(ctx context.Context, tx pgx.Tx, ret *model.Return) (map[int]int, float64, error) {
+	orderID := ret.OrderID
+	others, _, err := s.returnRepo.List(ctx, tx, model.ReturnListFilter{
+		OrderID:          &orderID,
+		PaginationParams: model.PaginationParams{Limit: 100},
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	qty := make(map[int]int)
+	var shipping float64
+	for _, other := range others {
+		if other.ID == ret.ID || !receivedReturnStatuses[other.Status] {
+			continue
+		}
+		var items []model.ReturnReceivedItem
+		if len(other.ReceivedItems) > 0 {
+			if err := json.Unmarshal(other.ReceivedItems, &items); err != nil {
+				return nil, 0, fmt.Errorf("parse received items of return %s: %w", other.ID, err)
+			}
+		}
+		for _, item := range items {
+			qty[item.OrderItemIndex] += item.Quantity
+		}
+		shipping += other.ShippingRefund
+	}
+	return qty, shipping, nil
+}
+
+// receivedReturnStatuses are the statuses of returns whose goods were received.
+var receivedReturnStatuses = map[string]bool{
+	string(engine.ReturnReceived):          true,
+	string(engine.ReturnPartiallyRefunded): true,
+	string(engine.ReturnRefunded):          true,
+	string(engine.ReturnExchanged):         true,
+}
+
+// restock puts resellable lines back into stock with a confirmed PZ document.
+// It returns nil when nothing is resellable.
+func (s *ReturnService) restock(ctx context.Context, tx pgx.Tx, tenantID uuid.UUID, ret *model.Return, received []model.ReturnReceivedItem, warehouseID *uuid.UUID, settings model.ReturnSettings, actorID uuid.UUID) (*model.WarehouseDocument, error) {
+	var docItems []model.CreateWarehouseDocItemRequest
+	for i := range received {
+		item := &received[i]
+		if item.Condition != model.ReturnConditionResellable {
+			continue
+		}
+		if err := s.resolveProduct(ctx, tx, item); err != nil {
+			return nil, err
+		}
+		docItems = append(docItems, model.CreateWarehouseDocItemRequest{
+			ProductID: *item.ProductID,
+			VariantID: item.VariantID,
+			Quantity:  item.Quantity,
+		})
+	}
+	if len(docItems) == 0 {
+		return nil, nil
+	}
+
+	if warehouseID == nil {
+		warehouseID = settings.DefaultWarehouseID
+	}
+	if warehouseID == nil {
+		return nil, NewValidationError(errors.New("warehouse_id is required to restock resellable items"))
+	}
+	if s.warehouseDocService == nil {
+		return nil, errors.New("warehouse documents are not configured")
+	}
+
+	orderID := ret.OrderID
+	notes := "Zwrot " + ret.ID.String()[:8]
+	return s.warehouseDocService.CreateConfirmed(ctx, tx, tenantID, model.CreateWarehouseDocumentRequest{
+		DocumentType: "PZ",
+		WarehouseID:  *warehouseID,
+		OrderID:      &orderID,
+		Notes:        &notes,
+		Items:        docItems,
+	}, actorID)
+}
+
+// resolveProduct links a received line to the local catalog by SKU when the
+// order item carries no product ID.
+func (s *ReturnService) resolveProduct(ctx context.Context, tx pgx.Tx, item *model.ReturnReceivedItem) error {
+	if item.ProductID != nil {
+		return nil
+	}
+	if item.SKU != "" && s.variantRepo != nil {
+		variants, err := s.variantRepo.FindBySKU(ctx, tx, item.SKU)
+		if err != nil {
+			return err
+		}
+		if len(variants) > 0 {
+			productID, variantID := variants[0].ProductID, variants[0].ID
+			item.ProductID = &productID
+			item.VariantID = &variantID
+			return nil
+		}
+	}
+	if item.SKU != "" && s.productRepo != nil {
+		product, err := s.productRepo.FindBySKU(ctx, tx, item.SKU)
+		if err != nil {
+			return err
+		}
+		if product != nil {
+			item.ProductID = &product.ID
+			return nil
+		}
+	}
+	return NewValidationError(fmt.Errorf("%q is not linked to a product and cannot be restocked", item.Name))
+}
+
+// returnSettings reads the "returns" section of the tenant settings.
+func (s *ReturnService) returnSettings(ctx context.Context, tx pgx.Tx, tenantID uuid.UUID) (model.ReturnSettings, error) {
+	var settings model.ReturnSettings
+	if s.tenantRepo == nil {
+		return settings, nil
+	}
+	raw, err := s.tenantRepo.GetSettings(ctx, tx, tenantID)
+	if err != nil {
+		return settings, err
+	}
+	var all map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &all); err != nil {
+		return settings, nil
+	}
+	if section, ok := all["returns"]; ok {
+		if err := json.Unmarshal(section, &settings); err != nil {
+			return model.ReturnSettings{}, nil
+		}
+	}
+	return settings, nil
+}
+
+// refundPendingTimeout is how long a pending channel refund counts as in
+// flight. After it, the refund can be settled with the channel's refund
+// reference or as a manual refund.
+const refundPendingTimeout = 15 * time.Minute
+
+// Refund executes the refund of a received return. Orders from a channel that
+// handles payments (Allegro) are refunded there; other refunds are recorded
+// as manual bank transfers. A refund of part of the remaining amount leaves
+// the return partially refunded; partial refunds are always manual. When the
+// order has an issued invoice, a correction invoice is recorded with the refund
+// and issued with the invoicing provider after it commits.
+//
+// A channel refund is marked pending with a refund key before the channel is
+// called outside the transaction, and recorded in a second transaction. While
+// it is pending, further refunds fail with ErrReturnRefundPending.
+func (s *ReturnService) Refund(ctx context.Context, tenantID, returnID uuid.UUID, req model.RefundReturnRequest, actorID uuid.UUID, ip string) (*model.Return, error) {
+	if err := req.Validate(); err != nil {
+		return nil, NewValidationError(err)
+	}
+
+	var ret *model.Return
+	var oldStatus string
+	var pending *channelRefund
+	var correction *ReturnCorrection
+	err := database.WithTenant(ctx, s.pool, tenantID, func(tx pgx.Tx) error {
+		existing, err := s.returnRepo.FindByID(ctx, tx, returnID)
+		if err != nil {
+			return err
+		}
+		if existing == nil {
+			return ErrReturnNotFound
+		}
+		oldStatus = existing.Status
+		if existing.GradingPending {
+			return ErrReturnGradingPending
+		}
+
+		order, err := s.orderRepo.FindByID(ctx, tx, existing.OrderID)
+		if err != nil {
+			return err
+		}
+		if order == nil {
+			return NewValidationError(errors.New("order not found"))
+		}
+
+		var invoice *model.Invoice
+		if s.invoiceService != nil {
+			invoice, err = s.invoiceService.correctableInvoice(ctx, tx, order.ID)
+			if err != nil {
+				return err
+			}
+		}
+
+		facts := engine.ReturnFacts{
+			Type:         engine.ReturnType(existing.ReturnType),
+			RefundDue:    existing.RefundAmount,
+			Refunded:     existing.RefundedAmount,
+			RefundAmount: existing.RefundAmount - existing.RefundedAmount,
+			Invoiced:     invoice != nil,
+		}
+		if req.Amount != nil {
+			facts.RefundAmount = *req.Amount
+		}
+		result, err := transitionReturn(existing, engine.RefundTarget(facts), facts)
+		if err != nil {
+			return err
+		}
+
+		// A stale pending refund is settled with the refund reference from
+		// the channel, or as a manual refund when the channel did not refund.
+		if existing.RefundKey != nil {
+			if time.Since(*existing.RefundStartedAt) < refundPendingTimeout {
+				return ErrReturnRefundPending
+			}
+			switch {
+			case req.Method == model.RefundMethodManual:
+			case req.Reference != nil:
+				ret, correction, err = s.recordRefund(ctx, tx, tenantID, existing, order, invoice, result, model.RefundMethodChannel, req.Reference, actorID, ip)
+				return err
+			default:
+				return ErrReturnRefundPending
+			}
+		}
+
+		// The channel refunds every received line, so it can only settle
+		// the whole refund in one go.
+		wholeRefund := existing.RefundedAmount == 0 && result.To == engine.ReturnRefunded
+
+		refunder, err := s.channelRefunder(ctx, tx, order)
+		if err != nil {
+			return err
+		}
+		method := req.Method
+		if method == "" {
+			method = model.RefundMethodManual
+			if refunder != nil && result.Refund > 0 && wholeRefund {
+				method = model.RefundMethodChannel
+			}
+		}
+
+		if method == model.RefundMethodChannel {
+			if refunder == nil {
+				return NewValidationError(errors.New("the order's sales channel does not support refunds"))
+			}
+			if !wholeRefund {
+				return NewValidationError(errors.New("partial refunds are recorded as manual refunds"))
+			}
+			refundReq, err := channelRefundRequest(order, existing)
+			if err != nil {
+				return err
+			}
+			key := uuid.New()
+			started, err := s.returnRepo.StartRefund(ctx, tx, returnID, key)
+			if err != nil {
+				return err
+			}
+			if !started {
+				return ErrReturnRefundPending
+			}
+			pending = &channelRefund{
+				key:      key,
+				refunder: refunder,
+				order:    order,
+				request:  refundReq,
+				invoice:  invoice,
+				result:   result,
+			}
+			return nil
+		}
+
+		ret, correction, err = s.recordRefund(ctx, tx, tenantID, existing, order, invoice, result, method, req.Reference, actorID, ip)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if pending != nil {
+		ret, correction, err = s.completeChannelRefund(ctx, tenantID, returnID, pending, actorID, ip)
+		if err != nil {
+			return nil, err
+		}
+	}
+	s.notifyStatusChanged(tenantID, ret, oldStatus)
+	if correction != nil {
+		if err := s.invoiceService.IssueReturnCorrection(ctx, tenantID, correction); err != nil {
+			s.logger.Error("record return correction", "tenant_id", tenantID, "return_id", returnID, "invoice_id", correction.Invoice.ID, "error", err)
+		}
+	}
+	return ret, nil
+}
+
+// channelRefund is a channel refund marked pending by Refund, to be sent to
+// the channel after the transaction commits.
+type channelRefund struct {
+	key      uuid.UUID
+	refunder integration.RefundCreator
+	order    *model.Order
+	request  integration.RefundRequest
+	invoice  *model.Invoice
+	result   engine.ReturnTransitionResult
+}
+
+// completeChannelRefund sends a pending refund to the channel and records it
+// in a new transaction. A rejected refund is no longer pending. When the
+// refund succeeds but cannot be recorded it stays pending and, once stale, is
+// settled with the logged channel refund ID.
+func (s *ReturnService) completeChannelRefund(ctx context.Context, tenantID, returnID uuid.UUID, pending *channelRefund, actorID uuid.UUID, ip string) (*model.Return, *ReturnCorrection, error) {
+	refundID, err := pending.refunder.CreateRefund(ctx, *pending.order.ExternalID, pending.request)
+	if err != nil {
+		if clearErr := database.WithTenant(ctx, s.pool, tenantID, func(tx pgx.Tx) error {
+			return s.returnRepo.ClearRefund(ctx, tx, returnID, pending.key)
+		}); clearErr != nil {
+			s.logger.Error("clear rejected return refund", "tenant_id", tenantID, "return_id", returnID, "error", clearErr)
+		}
+		return nil, nil, fmt.Errorf("%w: %v", ErrReturnRefundFailed, err)
+	}
+
+	var ret *model.Return
+	var correction *ReturnCorrection
+	err = database.WithTenant(ctx, s.pool, tenantID, func(tx pgx.Tx) error {
+		existing, err := s.returnRepo.FindByID(ctx, tx, returnID)
+		if err != nil {
+			return err
+		}
+		if existing == nil || existing.RefundKey == nil || *existing.RefundKey != pending.key {
+			return fmt.Errorf("refund %s is no longer pending", pending.key)
+		}
+		ret, correction, err = s.recordRefund(ctx, tx, tenantID, existing, pending.order, pending.invoice, pending.result, model.RefundMethodChannel, &refundID, actorID, ip)
+		return err
+	})
+	if err != nil {
+		s.logger.Error("channel refund made but not recorded", "tenant_id", tenantID, "return_id", returnID, "refund_key", pending.key, "channel_refund_id", refundID, "error", err)
+		return nil, nil, err
+	}
+	return ret, correction, nil
+}
+
+// recordRefund records an executed refund of the return within tx: the
+// refunded amount and status, the order's payment status, the draft
+// correction invoice, the status change event and the audit entry. The
+// correction is issued by the caller once tx commits.
+func (s *ReturnService) recordRefund(ctx context.Context, tx pgx.Tx, tenantID uuid.UUID, existing *model.Return, order *model.Order, invoice *model.Invoice, result engine.ReturnTransitionResult, method string, reference *string, actorID uuid.UUID, ip string) (*model.Return, *ReturnCorrection, error) {
+	if err := s.returnRepo.MarkRefunded(ctx, tx, existing.ID, string(result.To), result.Refund, method, reference); err != nil {
+		return nil, nil, err
+	}
+
+	// Auto-update order payment_status once the return is fully refunded
+	if result.To == engine.ReturnRefunded {
+		refunded := "refunded"
+		if err := s.orderRepo.Update(ctx, tx, existing.OrderID, model.UpdateOrderRequest{PaymentStatus: &refunded}); err != nil {
+			return nil, nil, fmt.Errorf("sync order payment status to refunded: %w", err)
+		}
+	}
+
+	var correction *ReturnCorrection
+	if result.CorrectionInvoice {
+		var err error
+		correction, err = s.invoiceService.CreateReturnCorrection(ctx, tx, tenantID, order, invoice, existing, result.Refund, actorID, ip)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	ret, err := s.returnRepo.FindByID(ctx, tx, existing.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := s.enqueueStatusChanged(ctx, tx, tenantID, ret, existing.Status); err != nil {
+		return nil, nil, err
+	}
+
+	changes := map[string]string{
+		"from":          existing.Status,
+		"to":            string(result.To),
+		"method":        method,
+		"refund_amount": fmt.Sprintf("%.2f", result.Refund),
+	}
+	if reference != nil {
+		changes["reference"] = *reference
+	}
+	if correction != nil {
+		changes["correction_invoice"] = correction.Invoice.ID.String()
+	}
+	if err := s.auditRepo.Log(ctx, tx, model.AuditEntry{
+		TenantID:   tenantID,
+		UserID:     actorID,
+		Action:     "return.refunded",
+		EntityType: "return",
+		EntityID:   existing.ID,
+		Changes:    changes,
+		IPAddress:  ip,
+	}); err != nil {
+		return nil, nil, err
+	}
+	return ret, correction, nil
+}
+
+// Exchange settles a received exchange return with a replacement order. The
+// replacement is a new, paid order for the customer of the original order,
+// holding the requested items or, by default, the received lines again.
+func (s *ReturnService) Exchange(ctx context.Context, tenantID, returnID uuid.UUID, req model.ExchangeReturnRequest, actorID uuid.UUID, ip string) (*model.Return, error) {
+	if err := req.Validate(); err != nil {
+		return nil, NewValidationError(err)
+	}
+
+	var ret *model.Return
+	var replacement *model.Order
+	var oldStatus string
+	err := database.WithTenant(ctx, s.pool, tenantID, func(tx pgx.Tx) error {
+		existing, err := s.returnRepo.FindByID(ctx, tx, returnID)
+		if err != nil {
+			return err
+		}
+		if existing == nil {
+			return ErrReturnNotFound
+		}
+		oldStatus = existing.Status
+		if existing.GradingPending {
+			return ErrReturnGradingPending
+		}
+
+		result, err := transitionReturn(existing, engine.ReturnExchanged, engine.ReturnFacts{})
+		if err != nil {
+			return err
+		}
+
+		order, err := s.orderRepo.FindByID(ctx, tx, existing.OrderID)
+		if err != nil {
+			return err
+		}
+		if order == nil {
+			return NewValidationError(errors.New("order not found"))
+		}
+
+		items := req.Items
+		if len(items) == 0 {
+			items, err = replacementItems(existing)
+			if err != nil {
+				return err
+			}
+		}
+
+		metadata, err := json.Marshal(map[string]string{
+			"return_id":         existing.ID.String(),
+			"original_order_id": order.ID.String(),
+		})
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		replacement = &model.Order{
+			ID:              uuid.New(),
+			TenantID:        tenantID,
+			Source:          "manual",
+			Status:          "new",
+			CustomerName:    order.CustomerName,
+			CustomerEmail:   order.CustomerEmail,
+			CustomerPhone:   order.CustomerPhone,
+			ShippingAddress: order.ShippingAddress,
+			BillingAddress:  order.BillingAddress,
+			Items:           items,
+			TotalAmount:     0,
+			Currency:        order.Currency,
+			PaymentStatus:   "paid",
+			Tags:            []string{"exchange"},
+			Metadata:        metadata,
+			OrderedAt:       &now,
+		}
+		if err := s.orderRepo.Create(ctx, tx, replacement); err != nil {
+			return fmt.Errorf("create replacement order: %w", err)
+		}
+		data := orderEventData(replacement)
+		data["return_id"] = existing.ID.String()
+		if err := EnqueueAutomationEvent(ctx, tx, s.automationService, tenantID, "order", "order.created", replacement.ID, data); err != nil {
+			return err
+		}
+
+		if err := s.returnRepo.MarkExchanged(ctx, tx, returnID, replacement.ID); err != nil {
+			return err
+		}
+
+		ret, err = s.returnRepo.FindByID(ctx, tx, returnID)
+		if err != nil {
+			return err
+		}
+		if err := s.enqueueStatusChanged(ctx, tx, tenantID, ret, oldStatus); err != nil {
+			return err
+		}
+
+		return s.auditRepo.Log(ctx, tx, model.AuditEntry{
+			TenantID:   tenantID,
+			UserID:     actorID,
+			Action:     "return.exchanged",
+			EntityType: "return",
+			EntityID:   returnID,
+			Changes: map[string]string{
+				"from":              existing.Status,
+				"to":                string(result.To),
+				"replacement_order": replacement.ID.String(),
+			},
+			IPAddress: ip,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.notifyStatusChanged(tenantID, ret, oldStatus)
+	go s.webhookDispatch.Dispatch(context.Background(), tenantID, "order.created", replacement)
+	return ret, nil
+}
+
+// replacementItems builds the items of a replacement order from the received
+// lines of the return.
+func replacementItems(ret *model.Return) (json.RawMessage, error) {
+	var received []model.ReturnReceivedItem
+	if len(ret.ReceivedItems) > 0 {
+		if err := json.Unmarshal(ret.ReceivedItems, &received); err != nil {
+			return nil, fmt.Errorf("parse received items: %w", err)
+		}
+	}
+	if len(received) == 0 {
+		return nil, NewValidationError(errors.New("items are required: the return has no received lines"))
+	}
+	type orderItem struct {
+		Name      string     `json:"name"`
+		SKU       string     `json:"sku,omitempty"`
+		Quantity  int        `json:"quantity"`
+		Price     float64    `json:"price"`
+		ProductID *uuid.UUID `json:"product_id,omitempty"`
+		VariantID *uuid.UUID `json:"variant_id,omitempty"`
+	}
+	items := make([]orderItem, 0, len(received))
+	for _, item := range received {
+		items = append(items, orderItem{
+			Name:      item.Name,
+			SKU:       item.SKU,
+			Quantity:  item.Quantity,
+			Price:     item.UnitPrice,
+			ProductID: item.ProductID,
+			VariantID: item.VariantID,
+		})
+	}
+	return json.Marshal(items)
+}
+
+// channelRefunder returns the refund-capable provider of the order's
+// integration, or nil when the order cannot be refunded through its channel.
+func (s *ReturnService) channelRefunder(ctx context.Context, tx pgx.Tx, order *model.Order) (integration.RefundCreator, error) {
+	if order.IntegrationID == nil || order.ExternalID == nil || s.integrationRepo == nil {
+		return nil, nil
+	}
+	wc, err := s.integrationRepo.FindByID(ctx, tx, *order.IntegrationID)
+	if err != nil {
+		return nil, err
+	}
+	if wc == nil || wc.Status != "active" {
+		return nil, nil
+	}
+	credJSON, err := crypto.Decrypt(wc.EncryptedCredentials, s.encryptionKey)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt credentials: %w", err)
+	}
+	provider, err := integration.NewMarketplaceProvider(wc.Provider, credJSON, wc.Settings)
+	if err != nil {
+		// Not a marketplace (e.g. a carrier integration) — refund manually.
+		return nil, nil
+	}
+	refunder, ok := provider.(integration.RefundCreator)
+	if !ok {
+		return nil, nil
+	}
+	return refunder, nil
+}
+
+// channelRefundRequest builds the channel refund of the received lines of a
+// return.
+func channelRefundRequest(order *model.Order, ret *model.Return) (integration.RefundRequest, error) {
+	var received []model.ReturnReceivedItem
+	if len(ret.ReceivedItems) > 0 {
+		if err := json.Unmarshal(ret.ReceivedItems, &received); err != nil {
+			return integration.RefundRequest{}, fmt.Errorf("parse received items: %w", err)
+		}
+	}
+
+	refundReq := integration.RefundRequest{Shipping: ret.ShippingRefund, Currency: order.Currency}
+	for _, item := range received {
+		amount := item.RefundValue()
+		if amount <= 0 {
+			continue
+		}
+		if item.ExternalID == "" {
+			return integration.RefundRequest{}, NewValidationError(fmt.Errorf("%q has no marketplace offer and cannot be refunded through the channel", item.Name))
+		}
+		refundReq.Lines = append(refundReq.Lines, integration.RefundLine{
+			ExternalID: item.ExternalID,
+			Quantity:   item.Quantity,
+			Amount:     amount,
+		})
+	}
+	if len(refundReq.Lines) == 0 && refundReq.Shipping <= 0 {
+		return integration.RefundRequest{}, NewValidationError(errors.New("nothing to refund"))
+	}
+	return refundReq, nil
+}
+
+// CreateReturnLabel generates a prepaid return label for an approved return
+// with the carrier from the tenant's return settings. The customer is the
+// sender and the configured warehouse address the receiver.
+func (s *ReturnService) CreateReturnLabel(ctx context.Context, tenantID, returnID, actorID uuid.UUID, ip string) (*model.Return, error) {
+	var carrierCfg *model.ReturnCarrierSettings
+	var order *model.Order
+	var wc *model.IntegrationWithCreds
+	var credJSON []byte
+
+	err := database.WithTenant(ctx, s.pool, tenantID, func(tx pgx.Tx) error {
+		existing, err := s.returnRepo.FindByID(ctx, tx, returnID)
+		if err != nil {
+			return err
+		}
+		if existing == nil {
+			return ErrReturnNotFound
+		}
+		if existing.Status != "approved" {
+			return fmt.Errorf("%w: return labels are issued for approved returns only", ErrInvalidReturnTransition)
+		}
+		if existing.ReturnTrackingNumber != nil {
+			return NewValidationError(errors.New("return label already generated"))
+		}
+
+		settings, err := s.returnSettings(ctx, tx, tenantID)
+		if err != nil {
+			return err
+		}
+		if !settings.ReturnCarrier.Enabled() {
+			return ErrReturnCarrierDisabled
+		}
+		carrierCfg = settings.ReturnCarrier
+
+		order, err = s.orderRepo.FindByID(ctx, tx, existing.OrderID)
+		if err != nil {
+			return err
+		}
+		if order == nil {
+			return NewValidationError(errors.New("order not found"))
+		}
+
+		if s.integrationRepo == nil {
+			return ErrNoCarrierIntegration
+		}
+		wc, err = s.integrationRepo.FindByProvider(ctx, tx, carrierCfg.Provider)
+		if err != nil {
+			return err
+		}
+		if wc == nil {
+			return ErrNoCarrierIntegration
+		}
+		credJSON, err = crypto.Decrypt(wc.EncryptedCredentials, s.encryptionKey)
+		if err != nil {
+			return fmt.Errorf("decrypting integration credentials: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	carrier, err := integration.NewCarrierProvider(carrierCfg.Provider, credJSON, wc.Settings)
+	if err != nil {
+		return nil, fmt.Errorf("creating carrier provider: %w", err)
+	}
+
+	resp, err := carrier.CreateShipment(ctx, returnShipmentRequest(carrierCfg, order, returnID))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrReturnLabelFailed, err)
+	}
+	labelBytes, err := carrier.GetLabel(ctx, resp.ExternalID, "pdf")
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrReturnLabelFailed, err)
+	}
+	labelURL, err := s.saveLabel(tenantID, labelBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	var ret *model.Return
+	err = database.WithTenant(ctx, s.pool, tenantID, func(tx pgx.Tx) error {
+		integrationID := wc.ID
+		if err := s.returnRepo.SetReturnLabel(ctx, tx, returnID, model.ReturnLabel{
+			Provider:       carrierCfg.Provider,
+			IntegrationID:  &integrationID,
+			ExternalID:     resp.ExternalID,
+			TrackingNumber: resp.TrackingNumber,
+			LabelURL:       labelURL,
+			Status:         "label_ready",
+		}); err != nil {
+			return err
+		}
+
+		ret, err = s.returnRepo.FindByID(ctx, tx, returnID)
+		if err != nil {
+			return err
+		}
+
+		return s.auditRepo.Log(ctx, tx, model.AuditEntry{
+			TenantID:   tenantID,
+			UserID:     actorID,
+			Action:     "return.label_generated",
+			EntityType: "return",
+			EntityID:   returnID,
+			Changes:    map[string]string{"provider": carrierCfg.Provider, "tracking_number": resp.TrackingNumber},
+			IPAddress:  ip,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// returnShipmentRequest builds the carrier request of a return parcel sent by
+// the customer from the order's shipping address to the warehouse.
+func returnShipmentRequest(cfg *model.ReturnCarrierSettings, order *model.Order, returnID uuid.UUID) integration.CarrierShipmentRequest {
+	var addr model.ShippingAddress
+	if len(order.ShippingAddress) > 0 {
+		if err := json.Unmarshal(order.ShippingAddress, &addr); err != nil {
+			slog.Warn("failed to parse shipping address", "order_id", order.ID, "error", err)
+		}
+	}
+	sender := integration.CarrierReceiver{
+		Name:       order.CustomerName,
+		Email:      addr.Email,
+		Phone:      addr.Phone,
+		Street:     addr.Street,
+		City:       addr.City,
+		PostalCode: addr.PostalCode,
+		Country:    addr.Country,
+	}
+	if order.CustomerEmail != nil && *order.CustomerEmail != "" {
+		sender.Email = *order.CustomerEmail
+	}
+	if order.CustomerPhone != nil && *order.CustomerPhone != "" {
+		sender.Phone = *order.CustomerPhone
+	}
+
+	weight := cfg.WeightKg
+	if weight == 0 {
+		weight = 1
+	}
+	return integration.CarrierShipmentRequest{
+		OrderID:     order.ID.String(),
+		ServiceType: cfg.ServiceType,
+		Receiver: integration.CarrierReceiver{
+			Name:       cfg.Receiver.Name,
+			Email:      cfg.Receiver.Email,
+			Phone:      cfg.Receiver.Phone,
+			Street:     cfg.Receiver.Street,
+			City:       cfg.Receiver.City,
+			PostalCode: cfg.Receiver.PostalCode,
+			Country:    cfg.Receiver.Country,
+		},
+		Sender:        &sender,
+		Parcel:        integration.CarrierParcel{SizeCode: cfg.ParcelSize, WeightKg: weight},
+		TargetPoint:   cfg.TargetPoint,
+		SendingMethod: cfg.SendingMethod,
+		Reference:     "ZWROT-" + returnID.String()[:8],
+	}
+}
+
+// saveLabel writes the label PDF to the uploads directory and returns its public URL.
+func (s *ReturnService) saveLabel(tenantID uuid.UUID, label []byte) (string, error) {
+	if s.uploadDir == "" {
+		return "", errors.New("label storage is not configured")
+	}
+	labelDir := filepath.Join(s.uploadDir, tenantID.String())
+	if err := os.MkdirAll(labelDir, 0755); err != nil {
+		return "", fmt.Errorf("creating label directory: %w", err)
+	}
+	filename := uuid.New().String() + ".pdf"
+	if err := os.WriteFile(filepath.Join(labelDir, filename), label, 0644); err != nil {
+		return "", fmt.Errorf("saving label file: %w", err)
+	}
+	return fmt.Sprintf("%s/uploads/%s/%s", s.baseURL, tenantID.String(), filename), nil
+}
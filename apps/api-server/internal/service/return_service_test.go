@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"testing"
 
@@ -9,7 +10,9 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/openoms-org/openoms/apps/api-server/internal/integration"
 	"github.com/openoms-org/openoms/apps/api-server/internal/model"
+	engine "github.com/openoms-org/openoms/packages/order-engine"
 )
 
 func TestReturnService_Create_ValidationError_MissingOrderID(t *testing.T) {
@@ -74,3 +77,145 @@ func TestReturnService_TransitionStatus_ValidationError_EmptyStatus(t *testing.T
 	assert.True(t, errors.As(err, &ve))
 	assert.Contains(t, err.Error(), "status")
 }
+
+func TestReturnService_TransitionStatus_RejectsReceivedAndRefunded(t *testing.T) {
+	svc := NewReturnService(nil, nil, nil, nil, nil)
+
+	for _, status := range []string{"received", "refunded", "partially_refunded", "exchanged"} {
+		_, err := svc.TransitionStatus(context.Background(), uuid.New(), uuid.New(),
+			model.ReturnStatusRequest{Status: status}, uuid.New(), "127.0.0.1")
+
+		require.Error(t, err)
+		var ve *ValidationError
+		assert.True(t, errors.As(err, &ve))
+	}
+}
+
+func TestReturnService_Receive_ValidationError_NoItems(t *testing.T) {
+	svc := NewReturnService(nil, nil, nil, nil, nil)
+
+	_, err := svc.Receive(context.Background(), uuid.New(), uuid.New(),
+		model.ReceiveReturnRequest{}, uuid.New(), "127.0.0.1")
+
+	require.Error(t, err)
+	var ve *ValidationError
+	assert.True(t, errors.As(err, &ve))
+}
+
+func TestReturnService_Refund_ValidationError_InvalidMethod(t *testing.T) {
+	svc := NewReturnService(nil, nil, nil, nil, nil)
+
+	_, err := svc.Refund(context.Background(), uuid.New(), uuid.New(),
+		model.RefundReturnRequest{Method: "cash"}, uuid.New(), "127.0.0.1")
+
+	require.Error(t, err)
+	var ve *ValidationError
+	assert.True(t, errors.As(err, &ve))
+	assert.Contains(t, err.Error(), "method")
+}
+
+func TestReturnService_Exchange_ValidationError_InvalidItems(t *testing.T) {
+	svc := NewReturnService(nil, nil, nil, nil, nil)
+
+	_, err := svc.Exchange(context.Background(), uuid.New(), uuid.New(),
+		model.ExchangeReturnRequest{Items: json.RawMessage(`{"name":"x"}`)}, uuid.New(), "127.0.0.1")
+
+	require.Error(t, err)
+	var ve *ValidationError
+	assert.True(t, errors.As(err, &ve))
+	assert.Contains(t, err.Error(), "items")
+}
+
+func TestTransitionReturn_Errors(t *testing.T) {
+	ret := &model.Return{Status: "received", ReturnType: "refund", RefundAmount: 100, RefundedAmount: 0}
+
+	_, err := transitionReturn(ret, engine.ReturnExchanged, engine.ReturnFacts{})
+	assert.ErrorIs(t, err, ErrInvalidReturnTransition, "refund returns cannot be exchanged")
+
+	_, err = transitionReturn(ret, engine.ReturnPartiallyRefunded, engine.ReturnFacts{RefundAmount: 150})
+	var ve *ValidationError
+	assert.True(t, errors.As(err, &ve), "refund above the amount due")
+
+	ret.Status = "approved"
+	_, err = transitionReturn(ret, engine.ReturnRefunded, engine.ReturnFacts{RefundAmount: 100})
+	assert.ErrorIs(t, err, ErrInvalidReturnTransition)
+
+	result, err := transitionReturn(&model.Return{Status: "received", ReturnType: "refund", RefundAmount: 100, RefundedAmount: 30},
+		engine.ReturnRefunded, engine.ReturnFacts{RefundAmount: 70})
+	require.NoError(t, err)
+	assert.Equal(t, 70.0, result.Refund)
+}
+
+func TestCorrectionItems(t *testing.T) {
+	received, _ := json.Marshal([]model.ReturnReceivedItem{
+		{Name: "Kubek", Quantity: 2, UnitPrice: 61.5, Condition: model.ReturnConditionResellable},
+		{Name: "Talerz", Quantity: 1, UnitPrice: 20, Deduction: 20, Condition: model.ReturnConditionDamaged},
+	})
+	ret := &model.Return{ID: uuid.New(), RefundAmount: 133, ShippingRefund: 10, ReceivedItems: received}
+
+	items := correctionItems(ret, 133, 23)
+	require.Len(t, items, 2)
+	assert.Equal(t, "Kubek", items[0].Name)
+	assert.Equal(t, -2, items[0].Quantity)
+	assert.InDelta(t, 50.0, items[0].NetPrice, 0.001)
+	assert.Equal(t, -1, items[1].Quantity)
+
+	partial := correctionItems(ret, 50, 23)
+	require.Len(t, partial, 1)
+	assert.Equal(t, -1, partial[0].Quantity)
+	assert.InDelta(t, 50/1.23, partial[0].NetPrice, 0.001)
+}
+
+func TestChannelRefundRequest(t *testing.T) {
+	order := &model.Order{Currency: "PLN"}
+	received, _ := json.Marshal([]model.ReturnReceivedItem{
+		{Name: "Kubek", ExternalID: "offer-1", Quantity: 2, UnitPrice: 50},
+		{Name: "Talerz", Quantity: 1, UnitPrice: 20, Deduction: 20},
+	})
+
+	req, err := channelRefundRequest(order, &model.Return{ReceivedItems: received, ShippingRefund: 12})
+	require.NoError(t, err)
+	assert.Equal(t, []integration.RefundLine{{ExternalID: "offer-1", Quantity: 2, Amount: 100}}, req.Lines)
+	assert.Equal(t, 12.0, req.Shipping)
+	assert.Equal(t, "PLN", req.Currency)
+
+	noOffer, _ := json.Marshal([]model.ReturnReceivedItem{{Name: "Kubek", Quantity: 1, UnitPrice: 50}})
+	_, err = channelRefundRequest(order, &model.Return{ReceivedItems: noOffer})
+	var ve *ValidationError
+	assert.True(t, errors.As(err, &ve))
+	assert.ErrorContains(t, err, "no marketplace offer")
+
+	_, err = channelRefundRequest(order, &model.Return{ReceivedItems: []byte(`[]`)})
+	assert.ErrorContains(t, err, "nothing to refund")
+}
+
+func TestReturnShipmentRequest(t *testing.T) {
+	email, phone := "anna@example.com", "600700800"
+	order := &model.Order{
+		ID:              uuid.New(),
+		CustomerName:    "Anna Nowak",
+		CustomerEmail:   &email,
+		CustomerPhone:   &phone,
+		ShippingAddress: []byte(`{"street":"Marszalkowska 10","city":"Warszawa","postal_code":"00-001","country":"PL"}`),
+	}
+	cfg := &model.ReturnCarrierSettings{
+		Provider:      "inpost",
+		SendingMethod: "parcel_locker",
+		ParcelSize:    "small",
+		Receiver:      model.ShippingAddress{Name: "Magazyn", Phone: "500100200", Street: "Magazynowa 1", City: "Poznan", PostalCode: "60-001", Country: "PL"},
+	}
+	returnID := uuid.New()
+
+	req := returnShipmentRequest(cfg, order, returnID)
+
+	assert.Equal(t, "Magazyn", req.Receiver.Name)
+	assert.Equal(t, "60-001", req.Receiver.PostalCode)
+	require.NotNil(t, req.Sender)
+	assert.Equal(t, "Anna Nowak", req.Sender.Name)
+	assert.Equal(t, phone, req.Sender.Phone)
+	assert.Equal(t, email, req.Sender.Email)
+	assert.Equal(t, "00-001", req.Sender.PostalCode)
+	assert.Equal(t, "parcel_locker", req.SendingMethod)
+	assert.Equal(t, 1.0, req.Parcel.WeightKg, "weight defaults to 1 kg")
+	assert.Equal(t, "ZWROT-"+returnID.String()[:8], req.Reference)
+}
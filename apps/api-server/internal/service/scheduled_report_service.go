@@ -0,0 +1,564 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"html"
+	"log/slog"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/openoms-org/openoms/apps/api-server/internal/database"
+	"github.com/openoms-org/openoms/apps/api-server/internal/model"
+	"github.com/openoms-org/openoms/apps/api-server/internal/pdf"
+	"github.com/openoms-org/openoms/apps/api-server/internal/repository"
+	"github.com/openoms-org/openoms/apps/api-server/internal/storage"
+	"github.com/openoms-org/openoms/apps/api-server/internal/xlsx"
+)
+
+var ErrScheduledReportNotFound = errors.New("scheduled report not found")
+
+// reportTitles are the Polish names of the report types, used in file
+// headings and email subjects.
+var reportTitles = map[string]string{
+	model.ReportTypeSalesByChannel: "Sprzedaż wg kanałów",
+	model.ReportTypeStockValue:     "Wartość magazynu",
+	model.ReportTypeVATSummary:     "Zestawienie VAT",
+	model.ReportTypeProfitability:  "Rentowność wg kanałów",
+}
+
+// ScheduledReportService manages report definitions and generates them: the
+// data is rendered to CSV, XLSX or PDF, stored in object storage and emailed
+// to the recipients through the tenant's SMTP settings. Every generation is
+// recorded as a run.
+type ScheduledReportService struct {
+	reportRepo   repository.ScheduledReportRepo
+	statsRepo    repository.StatsRepo
+	auditRepo    repository.AuditRepo
+	statsService *StatsService
+	emailService *EmailService
+	storage      storage.ObjectStorage
+	pool         *pgxpool.Pool
+	logger       *slog.Logger
+}
+
+func NewScheduledReportService(
+	reportRepo repository.ScheduledReportRepo,
+	statsRepo repository.StatsRepo,
+	auditRepo repository.AuditRepo,
+	statsService *StatsService,
+	emailService *EmailService,
+	store storage.ObjectStorage,
+	pool *pgxpool.Pool,
+) *ScheduledReportService {
+	return &ScheduledReportService{
+		reportRepo:   reportRepo,
+		statsRepo:    statsRepo,
+		auditRepo:    auditRepo,
+		statsService: statsService,
+		emailService: emailService,
+		storage:      store,
+		pool:         pool,
+		logger:       slog.Default().With("service", "scheduled_report"),
+	}
+}
+
+func (s *ScheduledReportService) List(ctx context.Context, tenantID uuid.UUID) ([]model.ScheduledReport, error) {
+	var reports []model.ScheduledReport
+	err := database.WithTenant(ctx, s.pool, tenantID, func(tx pgx.Tx) error {
+		var err error
+		reports, err = s.reportRepo.List(ctx, tx)
+		return err
+	})
+	if reports == nil {
+		reports = []model.ScheduledReport{}
+	}
+	return reports, err
+}
+
+func (s *ScheduledReportService) Get(ctx context.Context, tenantID, id uuid.UUID) (*model.ScheduledReport, error) {
+	var report *model.ScheduledReport
+	err := database.WithTenant(ctx, s.pool, tenantID, func(tx pgx.Tx) error {
+		var err error
+		report, err = s.find(ctx, tx, id)
+		return err
+	})
+	return report, err
+}
+
+func (s *ScheduledReportService) Create(ctx context.Context, tenantID uuid.UUID, req model.CreateScheduledReportRequest, actorID uuid.UUID, ip string) (*model.ScheduledReport, error) {
+	if err := req.Validate(); err != nil {
+		return nil, NewValidationError(err)
+	}
+
+	report := &model.ScheduledReport{
+		ID:         uuid.New(),
+		TenantID:   tenantID,
+		Name:       model.StripHTMLTags(req.Name),
+		ReportType: req.ReportType,
+		Schedule:   req.Schedule,
+		Timezone:   req.Timezone,
+		Period:     req.Period,
+		Format:     req.Format,
+		Recipients: req.Recipients,
+		Active:     req.Active == nil || *req.Active,
+		CreatedBy:  &actorID,
+	}
+	if err := s.scheduleFrom(report, time.Now()); err != nil {
+		return nil, err
+	}
+
+	err := database.WithTenant(ctx, s.pool, tenantID, func(tx pgx.Tx) error {
+		if err := s.reportRepo.Create(ctx, tx, report); err != nil {
+			return err
+		}
+		return s.auditRepo.Log(ctx, tx, model.AuditEntry{
+			TenantID:   tenantID,
+			UserID:     actorID,
+			Action:     "scheduled_report.created",
+			EntityType: "scheduled_report",
+			EntityID:   report.ID,
+			Changes:    map[string]string{"name": report.Name, "report_type": report.ReportType, "schedule": report.Schedule},
+			IPAddress:  ip,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+func (s *ScheduledReportService) Update(ctx context.Context, tenantID, id uuid.UUID, req model.UpdateScheduledReportRequest, actorID uuid.UUID, ip string) (*model.ScheduledReport, error) {
+	var report *model.ScheduledReport
+	err := database.WithTenant(ctx, s.pool, tenantID, func(tx pgx.Tx) error {
+		current, err := s.find(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		updated, err := req.Apply(*current)
+		if err != nil {
+			return NewValidationError(err)
+		}
+		updated.Name = model.StripHTMLTags(updated.Name)
+		if err := s.scheduleFrom(&updated, time.Now()); err != nil {
+			return err
+		}
+		if err := s.reportRepo.Update(ctx, tx, &updated); err != nil {
+			return err
+		}
+		report = &updated
+		return s.auditRepo.Log(ctx, tx, model.AuditEntry{
+			TenantID:   tenantID,
+			UserID:     actorID,
+			Action:     "scheduled_report.updated",
+			EntityType: "scheduled_report",
+			EntityID:   id,
+			Changes:    map[string]string{"name": updated.Name, "schedule": updated.Schedule, "active": strconv.FormatBool(updated.Active)},
+			IPAddress:  ip,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+func (s *ScheduledReportService) Delete(ctx context.Context, tenantID, id, actorID uuid.UUID, ip string) error {
+	return database.WithTenant(ctx, s.pool, tenantID, func(tx pgx.Tx) error {
+		report, err := s.find(ctx, tx, id)
+		if err != nil {
+			return err
+		}
+		if err := s.reportRepo.Delete(ctx, tx, id); err != nil {
+			return err
+		}
+		return s.auditRepo.Log(ctx, tx, model.AuditEntry{
+			TenantID:   tenantID,
+			UserID:     actorID,
+			Action:     "scheduled_report.deleted",
+			EntityType: "scheduled_report",
+			EntityID:   id,
+			Changes:    map[string]string{"name": report.Name},
+			IPAddress:  ip,
+		})
+	})
+}
+
+// ListRuns returns the generated reports of a definition, newest first.
+func (s *ScheduledReportService) ListRuns(ctx context.Context, tenantID, id uuid.UUID, pagination model.PaginationParams) (model.ListResponse[model.ScheduledReportRun], error) {
+	var resp model.ListResponse[model.ScheduledReportRun]
+	err := database.WithTenant(ctx, s.pool, tenantID, func(tx pgx.Tx) error {
+		if _, err := s.find(ctx, tx, id); err != nil {
+			return err
+		}
+		runs, total, err := s.reportRepo.ListRuns(ctx, tx, id, pagination)
+		if err != nil {
+			return err
+		}
+		if runs == nil {
+			runs = []model.ScheduledReportRun{}
+		}
+		resp = model.ListResponse[model.ScheduledReportRun]{
+			Items:  runs,
+			Total:  total,
+			Limit:  pagination.Limit,
+			Offset: pagination.Offset,
+		}
+		return nil
+	})
+	return resp, err
+}
+
+// RunNow generates and sends a report immediately, outside its schedule.
+// A failed generation is still recorded and returned with its error message.
+func (s *ScheduledReportService) RunNow(ctx context.Context, tenantID, id, actorID uuid.UUID, ip string) (*model.ScheduledReportRun, error) {
+	report, err := s.Get(ctx, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	run, err := s.Execute(ctx, *report, time.Now())
+	if run == nil {
+		return nil, err
+	}
+	if err := database.WithTenant(ctx, s.pool, tenantID, func(tx pgx.Tx) error {
+		return s.auditRepo.Log(ctx, tx, model.AuditEntry{
+			TenantID:   tenantID,
+			UserID:     actorID,
+			Action:     "scheduled_report.run",
+			EntityType: "scheduled_report",
+			EntityID:   id,
+			Changes:    map[string]string{"status": run.Status},
+			IPAddress:  ip,
+		})
+	}); err != nil {
+		s.logger.Warn("failed to log report run", "report_id", id, "error", err)
+	}
+	return run, nil
+}
+
+// Execute generates the report for the period before at, stores the file and
+// emails it. The run is recorded whether or not it succeeds; the returned
+// error describes a failed run. A nil run means not even the failure could
+// be recorded.
+func (s *ScheduledReportService) Execute(ctx context.Context, report model.ScheduledReport, at time.Time) (*model.ScheduledReportRun, error) {
+	local := at.In(model.ReportLocation(report.Timezone))
+	run := &model.ScheduledReportRun{
+		ID:         uuid.New(),
+		TenantID:   report.TenantID,
+		ReportID:   report.ID,
+		Status:     model.ReportRunSuccess,
+		Format:     report.Format,
+		Recipients: report.Recipients,
+	}
+	fileDate := local
+	if report.ReportType != model.ReportTypeStockValue {
+		from, to := model.ReportPeriodWindow(report.Period, local)
+		run.PeriodFrom, run.PeriodTo = &from, &to
+		fileDate = from
+	}
+
+	runErr := s.generate(ctx, report, run, local, fileDate)
+	if runErr != nil {
+		run.Status = model.ReportRunFailed
+		msg := runErr.Error()
+		run.ErrorMessage = &msg
+	}
+
+	err := database.WithTenant(ctx, s.pool, report.TenantID, func(tx pgx.Tx) error {
+		return s.reportRepo.CreateRun(ctx, tx, run)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("record report run: %w", err)
+	}
+	return run, runErr
+}
+
+// generate builds, stores and sends the report file, filling the run's
+// file URL once it is stored.
+func (s *ScheduledReportService) generate(ctx context.Context, report model.ScheduledReport, run *model.ScheduledReportRun, local, fileDate time.Time) error {
+	table, err := s.buildTable(ctx, report, run)
+	if err != nil {
+		return fmt.Errorf("build report: %w", err)
+	}
+	data, contentType, err := RenderReport(table, report.Format)
+	if err != nil {
+		return fmt.Errorf("render report: %w", err)
+	}
+
+	filename := fmt.Sprintf("%s-%s.%s", strings.ReplaceAll(report.ReportType, "_", "-"), fileDate.Format("2006-01-02"), report.Format)
+	key := fmt.Sprintf("%s/reports/%s-%s", report.TenantID, run.ID.String()[:8], filename)
+	url, err := s.storage.Upload(ctx, key, bytes.NewReader(data), contentType)
+	if err != nil {
+		return fmt.Errorf("store report: %w", err)
+	}
+	run.FileURL = &url
+
+	subject := fmt.Sprintf("%s — %s", report.Name, table.Title)
+	body := fmt.Sprintf(`<!DOCTYPE html>
+<html><head><meta charset="utf-8"></head>
+<body style="font-family:Arial,sans-serif;max-width:600px;margin:0 auto;padding:20px;">
+<h2 style="color:#1a1a1a;">%s</h2>
+<p>%s</p>
+<p>Raport w załączniku (wygenerowano %s).</p>
+<p style="color:#666;font-size:12px;margin-top:30px;">— OpenOMS</p>
+</body></html>`,
+		html.EscapeString(report.Name), html.EscapeString(table.Title), local.Format("2006-01-02 15:04"))
+	return s.emailService.SendReport(ctx, report.TenantID, report.Recipients, subject, body, filename, contentType, data)
+}
+
+// buildTable loads the data of a report.
+func (s *ScheduledReportService) buildTable(ctx context.Context, report model.ScheduledReport, run *model.ScheduledReportRun) (model.ReportTable, error) {
+	table := model.ReportTable{Title: reportTitles[report.ReportType]}
+	if run.PeriodFrom != nil {
+		table.Title += fmt.Sprintf(" %s – %s", run.PeriodFrom.Format("2006-01-02"), run.PeriodTo.AddDate(0, 0, -1).Format("2006-01-02"))
+	}
+
+	if report.ReportType == model.ReportTypeProfitability {
+		return s.profitabilityTable(ctx, report.TenantID, table, *run.PeriodFrom, *run.PeriodTo)
+	}
+
+	err := database.WithTenant(ctx, s.pool, report.TenantID, func(tx pgx.Tx) error {
+		switch report.ReportType {
+		case model.ReportTypeSalesByChannel:
+			excluded, err := s.statsService.salesExcludedStatuses(ctx, tx, report.TenantID)
+			if err != nil {
+				return err
+			}
+			rows, err := s.statsRepo.GetSalesByChannel(ctx, tx, *run.PeriodFrom, *run.PeriodTo, excluded)
+			if err != nil {
+				return err
+			}
+			table.Columns = []model.ReportColumn{
+				{Title: "Kanał"}, {Title: "Waluta"}, {Title: "Zamówienia", Numeric: true},
+				{Title: "Przychód", Numeric: true}, {Title: "Średnia wartość", Numeric: true},
+			}
+			for _, r := range rows {
+				avg := 0.0
+				if r.Orders > 0 {
+					avg = roundReportAmount(r.Revenue / float64(r.Orders))
+				}
+				table.Rows = append(table.Rows, []any{r.Source, r.Currency, r.Orders, r.Revenue, avg})
+			}
+		case model.ReportTypeStockValue:
+			rows, err := s.statsRepo.GetStockValue(ctx, tx)
+			if err != nil {
+				return err
+			}
+			table.Columns = []model.ReportColumn{
+				{Title: "SKU"}, {Title: "Nazwa"}, {Title: "Ilość", Numeric: true}, {Title: "Koszt jedn.", Numeric: true},
+				{Title: "Wartość zakupu", Numeric: true}, {Title: "Wartość sprzedaży", Numeric: true},
+			}
+			var quantity int
+			var costValue, saleValue float64
+			for _, r := range rows {
+				var unitCost any
+				if r.UnitCost != nil {
+					unitCost = *r.UnitCost
+				}
+				table.Rows = append(table.Rows, []any{r.SKU, r.Name, r.Quantity, unitCost, roundReportAmount(r.CostValue), roundReportAmount(r.SaleValue)})
+				quantity += r.Quantity
+				costValue += r.CostValue
+				saleValue += r.SaleValue
+			}
+			table.Rows = append(table.Rows, []any{"", "Razem", quantity, nil, roundReportAmount(costValue), roundReportAmount(saleValue)})
+		case model.ReportTypeVATSummary:
+			rows, err := s.statsRepo.GetVATSummary(ctx, tx, *run.PeriodFrom, *run.PeriodTo)
+			if err != nil {
+				return err
+			}
+			table.Columns = []model.ReportColumn{
+				{Title: "Typ faktury"}, {Title: "Waluta"}, {Title: "Liczba faktur", Numeric: true},
+				{Title: "Netto", Numeric: true}, {Title: "VAT", Numeric: true}, {Title: "Brutto", Numeric: true},
+			}
+			for _, r := range rows {
+				table.Rows = append(table.Rows, []any{r.InvoiceType, r.Currency, r.Invoices, r.Net, r.VAT, r.Gross})
+			}
+		default:
+			return fmt.Errorf("unknown report type %q", report.ReportType)
+		}
+		return nil
+	})
+	return table, err
+}
+
+// profitabilityTable builds the profitability report grouped by channel, in
+// the tenant's most common order currency.
+func (s *ScheduledReportService) profitabilityTable(ctx context.Context, tenantID uuid.UUID, table model.ReportTable, from, to time.Time) (model.ReportTable, error) {
+	currency := "PLN"
+	err := database.WithTenant(ctx, s.pool, tenantID, func(tx pgx.Tx) error {
+		c, err := s.statsRepo.GetMostCommonCurrency(ctx, tx)
+		if err == nil && c != "" {
+			currency = c
+		}
+		return nil
+	})
+	if err != nil {
+		return table, err
+	}
+
+	report, err := s.statsService.GetProfitability(ctx, tenantID, model.ProfitabilityFilter{
+		GroupBy:  model.ProfitabilityByChannel,
+		From:     &from,
+		To:       &to,
+		Currency: currency,
+		Limit:    model.MaxProfitabilityRows,
+	})
+	if err != nil {
+		return table, err
+	}
+
+	table.Title += " (" + currency + ")"
+	table.Columns = []model.ReportColumn{
+		{Title: "Kanał"}, {Title: "Zamówienia", Numeric: true}, {Title: "Przychód", Numeric: true},
+		{Title: "Koszt produktów", Numeric: true}, {Title: "Koszt wysyłki", Numeric: true},
+		{Title: "Prowizje", Numeric: true}, {Title: "Marża brutto", Numeric: true}, {Title: "Marża %", Numeric: true},
+	}
+	for _, r := range append(report.Rows, report.Totals) {
+		var margin any
+		if r.MarginPercent != nil {
+			margin = *r.MarginPercent
+		}
+		table.Rows = append(table.Rows, []any{
+			r.Label, r.Orders, roundReportAmount(r.Revenue), roundReportAmount(r.ProductCost),
+			roundReportAmount(r.ShippingCost), roundReportAmount(r.MarketplaceFee), r.GrossMargin, margin,
+		})
+	}
+	return table, nil
+}
+
+func (s *ScheduledReportService) find(ctx context.Context, tx pgx.Tx, id uuid.UUID) (*model.ScheduledReport, error) {
+	report, err := s.reportRepo.FindByID(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+	if report == nil {
+		return nil, ErrScheduledReportNotFound
+	}
+	return report, nil
+}
+
+// scheduleFrom sets the next run of an active report after now; inactive
+// reports are not scheduled.
+func (s *ScheduledReportService) scheduleFrom(report *model.ScheduledReport, now time.Time) error {
+	report.NextRunAt = nil
+	if !report.Active {
+		return nil
+	}
+	next, err := report.NextRun(now)
+	if err != nil {
+		return NewValidationError(fmt.Errorf("invalid schedule: %w", err))
+	}
+	if next == nil {
+		return NewValidationError(errors.New("schedule never fires"))
+	}
+	report.NextRunAt = next
+	return nil
+}
+
+// RenderReport renders a report table in the given format and returns the
+// file with its content type.
+func RenderReport(table model.ReportTable, format string) ([]byte, string, error) {
+	var buf bytes.Buffer
+	switch format {
+	case model.ReportFormatCSV:
+		// BOM for Excel UTF-8 compatibility
+		buf.Write([]byte{0xEF, 0xBB, 0xBF})
+		writer := csv.NewWriter(&buf)
+		header := make([]string, len(table.Columns))
+		for i, c := range table.Columns {
+			header[i] = c.Title
+		}
+		if err := writer.Write(header); err != nil {
+			return nil, "", err
+		}
+		for _, row := range table.Rows {
+			if err := writer.Write(reportCells(row)); err != nil {
+				return nil, "", err
+			}
+		}
+		writer.Flush()
+		if err := writer.Error(); err != nil {
+			return nil, "", err
+		}
+		return buf.Bytes(), "text/csv; charset=utf-8", nil
+	case model.ReportFormatXLSX:
+		header := make([]any, len(table.Columns))
+		for i, c := range table.Columns {
+			header[i] = c.Title
+		}
+		rows := append([][]any{header}, table.Rows...)
+		if err := xlsx.Write(&buf, xlsx.Sheet{Name: table.Title, Rows: rows}); err != nil {
+			return nil, "", err
+		}
+		return buf.Bytes(), "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", nil
+	case model.ReportFormatPDF:
+		doc := pdf.New()
+		doc.Heading(table.Title)
+		doc.Space(10)
+		rows := make([][]string, len(table.Rows))
+		for i, row := range table.Rows {
+			rows[i] = reportCells(row)
+		}
+		doc.Table(reportPDFColumns(table.Columns), rows)
+		return doc.Bytes(), "application/pdf", nil
+	default:
+		return nil, "", fmt.Errorf("unknown report format %q", format)
+	}
+}
+
+// reportCells formats a table row as text; amounts get two decimals.
+func reportCells(row []any) []string {
+	cells := make([]string, len(row))
+	for i, v := range row {
+		switch v := v.(type) {
+		case nil:
+		case string:
+			cells[i] = v
+		case int:
+			cells[i] = strconv.Itoa(v)
+		case float64:
+			cells[i] = strconv.FormatFloat(v, 'f', 2, 64)
+		default:
+			cells[i] = fmt.Sprint(v)
+		}
+	}
+	return cells
+}
+
+// reportPDFColumns fits the columns to the page: numeric columns get a
+// fixed width and text columns share the rest.
+func reportPDFColumns(columns []model.ReportColumn) []pdf.Column {
+	const numericWidth = 62.0
+	var text int
+	for _, c := range columns {
+		if !c.Numeric {
+			text++
+		}
+	}
+	textWidth := 0.0
+	if text > 0 {
+		textWidth = (pdf.ContentWidth - numericWidth*float64(len(columns)-text)) / float64(text)
+	}
+
+	out := make([]pdf.Column, len(columns))
+	for i, c := range columns {
+		out[i] = pdf.Column{Title: c.Title, Width: textWidth, AlignRight: c.Numeric}
+		if c.Numeric {
+			out[i].Width = numericWidth
+		}
+	}
+	return out
+}
+
+func roundReportAmount(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openoms-org/openoms/apps/api-server/internal/model"
+	"github.com/openoms-org/openoms/apps/api-server/internal/pdf"
+)
+
+func testReportTable() model.ReportTable {
+	return model.ReportTable{
+		Title: "Sprzedaż wg kanałów 2026-10-01 – 2026-10-31",
+		Columns: []model.ReportColumn{
+			{Title: "Kanał"}, {Title: "Zamówienia", Numeric: true}, {Title: "Przychód", Numeric: true},
+		},
+		Rows: [][]any{
+			{"allegro", 12, 1530.5},
+			{"woocommerce", 3, nil},
+		},
+	}
+}
+
+func TestRenderReport_CSV(t *testing.T) {
+	data, contentType, err := RenderReport(testReportTable(), model.ReportFormatCSV)
+	require.NoError(t, err)
+	assert.Equal(t, "text/csv; charset=utf-8", contentType)
+	assert.True(t, bytes.HasPrefix(data, []byte{0xEF, 0xBB, 0xBF}), "CSV starts with a BOM")
+	lines := strings.Split(strings.TrimSpace(string(data[3:])), "\n")
+	assert.Equal(t, []string{"Kanał,Zamówienia,Przychód", "allegro,12,1530.50", "woocommerce,3,"}, lines)
+}
+
+func TestRenderReport_XLSX(t *testing.T) {
+	data, contentType, err := RenderReport(testReportTable(), model.ReportFormatXLSX)
+	require.NoError(t, err)
+	assert.Contains(t, contentType, "spreadsheetml")
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+	assert.NotEmpty(t, zr.File)
+}
+
+func TestRenderReport_PDF(t *testing.T) {
+	data, contentType, err := RenderReport(testReportTable(), model.ReportFormatPDF)
+	require.NoError(t, err)
+	assert.Equal(t, "application/pdf", contentType)
+	assert.True(t, bytes.HasPrefix(data, []byte("%PDF-")))
+}
+
+func TestRenderReport_UnknownFormat(t *testing.T) {
+	_, _, err := RenderReport(testReportTable(), "docx")
+	assert.Error(t, err)
+}
+
+func TestReportPDFColumns(t *testing.T) {
+	cols := reportPDFColumns(testReportTable().Columns)
+	require.Len(t, cols, 3)
+	assert.False(t, cols[0].AlignRight)
+	assert.True(t, cols[1].AlignRight)
+	assert.InDelta(t, pdf.ContentWidth, cols[0].Width+cols[1].Width+cols[2].Width, 0.001)
+}
@@ -31,6 +31,13 @@ type ShipmentService struct {
 	webhookDispatch   *WebhookDispatchService
 	smsService        *SMSService
 	automationService *AutomationService
+	tenantRepo        repository.TenantRepo
+}
+
+// SetTenantRepo sets the tenant repository used to read the order workflow
+// when syncing order statuses with shipments.
+func (s *ShipmentService) SetTenantRepo(tenantRepo repository.TenantRepo) {
+	s.tenantRepo = tenantRepo
 }
 
 // SetSMSService sets the SMS service for sending SMS notifications on shipment status change.
@@ -117,6 +124,7 @@ func (s *ShipmentService) Create(ctx context.Context, tenantID uuid.UUID, req mo
 		LabelURL:       req.LabelURL,
 		CarrierData:    carrierData,
 		WarehouseID:    req.WarehouseID,
+		ShippingCost:   req.ShippingCost,
 	}
 
 	err := database.WithTenant(ctx, s.pool, tenantID, func(tx pgx.Tx) error {
@@ -131,6 +139,12 @@ func (s *ShipmentService) Create(ctx context.Context, tenantID uuid.UUID, req mo
 		if err := s.shipmentRepo.Create(ctx, tx, shipment); err != nil {
 			return err
 		}
+		if err := EnqueueAutomationEvent(ctx, tx, s.automationService, tenantID, "shipment", "shipment.created", shipment.ID, map[string]any{
+			"status": shipment.Status, "provider": shipment.Provider, "order_id": shipment.OrderID.String(),
+			"created_at": eventTime(shipment.CreatedAt), "status_changed_at": eventTime(shipment.CreatedAt),
+		}); err != nil {
+			return err
+		}
 		return s.auditRepo.Log(ctx, tx, model.AuditEntry{
 			TenantID:   tenantID,
 			UserID:     actorID,
@@ -145,9 +159,6 @@ func (s *ShipmentService) Create(ctx context.Context, tenantID uuid.UUID, req mo
 		return nil, err
 	}
 	go s.webhookDispatch.Dispatch(context.Background(), tenantID, "shipment.created", shipment)
-	FireAutomationEvent(s.automationService, tenantID, "shipment", "shipment.created", shipment.ID, map[string]any{
-		"status": shipment.Status, "provider": shipment.Provider, "order_id": shipment.OrderID.String(),
-	})
 	return shipment, nil
 }
 
@@ -258,6 +269,13 @@ func (s *ShipmentService) TransitionStatus(ctx context.Context, tenantID, shipme
 			return err
 		}
 
+		if err := EnqueueAutomationEvent(ctx, tx, s.automationService, tenantID, "shipment", "shipment.status_changed", shipment.ID, map[string]any{
+			"status": shipment.Status, "provider": shipment.Provider, "order_id": shipment.OrderID.String(),
+			"created_at": eventTime(shipment.CreatedAt), "status_changed_at": eventTime(shipment.UpdatedAt),
+		}); err != nil {
+			return err
+		}
+
 		if err := s.auditRepo.Log(ctx, tx, model.AuditEntry{
 			TenantID:   tenantID,
 			UserID:     actorID,
@@ -270,32 +288,13 @@ func (s *ShipmentService) TransitionStatus(ctx context.Context, tenantID, shipme
 			return err
 		}
 
-		// Order-shipment status sync
-		switch req.Status {
-		case "delivered":
-			if err := s.orderRepo.UpdateStatus(ctx, tx, existing.OrderID, "delivered", nil, func() *time.Time { t := time.Now(); return &t }()); err != nil {
-				return fmt.Errorf("sync order status to delivered: %w", err)
-			}
-		case "picked_up", "in_transit":
-			order, err := s.orderRepo.FindByID(ctx, tx, existing.OrderID)
-			if err == nil && order != nil && order.Status != "shipped" && order.Status != "delivered" {
-				now := time.Now()
-				if err := s.orderRepo.UpdateStatus(ctx, tx, existing.OrderID, "shipped", &now, nil); err != nil {
-					return fmt.Errorf("sync order status to shipped: %w", err)
-				}
-			}
-		}
-
-		return nil
+		return s.syncOrderStatus(ctx, tx, tenantID, existing.OrderID, req.Status)
 	})
 	if err == nil && shipment != nil {
 		go s.webhookDispatch.Dispatch(context.Background(), tenantID, "shipment.status_changed", shipment)
 		if s.smsService != nil {
 			go s.smsService.SendShipmentStatusSMS(context.Background(), tenantID, shipment, "")
 		}
-		FireAutomationEvent(s.automationService, tenantID, "shipment", "shipment.status_changed", shipment.ID, map[string]any{
-			"status": shipment.Status, "provider": shipment.Provider, "order_id": shipment.OrderID.String(),
-		})
 	}
 	return shipment, err
 }
@@ -391,3 +390,72 @@ func readLabelFile(labelURL string) ([]byte, error) {
 
 	return nil, fmt.Errorf("label file not found for URL: %s", labelURL)
 }
+
+// syncOrderStatus moves the order of a shipment to the first status of the
+// tenant workflow in the shipped or delivered category.
+func (s *ShipmentService) syncOrderStatus(ctx context.Context, tx pgx.Tx, tenantID, orderID uuid.UUID, shipmentStatus string) error {
+	var category engine.StatusCategory
+	switch shipmentStatus {
+	case "delivered":
+		category = engine.CategoryDelivered
+	case "picked_up", "in_transit":
+		category = engine.CategoryShipped
+	default:
+		return nil
+	}
+
+	config := model.DefaultOrderStatusConfig()
+	if s.tenantRepo != nil {
+		loaded, err := loadOrderStatusConfig(ctx, tx, s.tenantRepo, tenantID)
+		if err != nil {
+			return fmt.Errorf("load status config: %w", err)
+		}
+		config = *loaded
+	}
+	target := config.FirstInCategory(string(category))
+	if target == "" {
+		return nil
+	}
+
+	order, err := s.orderRepo.FindByID(ctx, tx, orderID)
+	if err != nil || order == nil {
+		return err
+	}
+	current := engine.StatusCategory(config.CategoryOf(order.Status))
+	now := time.Now()
+	switch category {
+	case engine.CategoryDelivered:
+		if current == engine.CategoryDelivered || current == engine.CategoryCompleted {
+			return nil
+		}
+		if err := s.orderRepo.UpdateStatus(ctx, tx, orderID, target, nil, &now); err != nil {
+			return fmt.Errorf("sync order status to %s: %w", target, err)
+		}
+	case engine.CategoryShipped:
+		if current == engine.CategoryShipped || current == engine.CategoryDelivered || current == engine.CategoryCompleted {
+			return nil
+		}
+		if err := s.orderRepo.UpdateStatus(ctx, tx, orderID, target, &now, nil); err != nil {
+			return fmt.Errorf("sync order status to %s: %w", target, err)
+		}
+	}
+	return nil
+}
+
+// hasActiveShipment reports whether an order has a shipment that is not
+// cancelled, failed or returned.
+func (s *ShipmentService) hasActiveShipment(ctx context.Context, tx pgx.Tx, orderID uuid.UUID) (bool, error) {
+	shipments, _, err := s.shipmentRepo.List(ctx, tx, model.ShipmentListFilter{
+		OrderID:          &orderID,
+		PaginationParams: model.PaginationParams{Limit: 100},
+	})
+	if err != nil {
+		return false, err
+	}
+	for _, sh := range shipments {
+		if sh.Status != "cancelled" && sh.Status != "failed" && sh.Status != "returned" {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"log/slog"
 	"slices"
 	"text/template"
@@ -18,15 +19,36 @@ import (
 	smsapi "github.com/openoms-org/openoms/packages/smsapi-go-sdk"
 )
 
+// ErrSMSNotConfigured is returned when the tenant has no enabled SMS settings.
+var ErrSMSNotConfigured = errors.New("sms is not configured")
+
 type SMSService struct {
-	tenantRepo repository.TenantRepo
-	pool       *pgxpool.Pool
+	tenantRepo     repository.TenantRepo
+	pool           *pgxpool.Pool
+	orderEventRepo repository.OrderEventRepo
 }
 
 func NewSMSService(tenantRepo repository.TenantRepo, pool *pgxpool.Pool) *SMSService {
 	return &SMSService{tenantRepo: tenantRepo, pool: pool}
 }
 
+// SetOrderEventRepo sets the repository that records sent SMS messages on the
+// order timeline.
+func (s *SMSService) SetOrderEventRepo(orderEventRepo repository.OrderEventRepo) {
+	s.orderEventRepo = orderEventRepo
+}
+
+// recordSMS records an SMS sent to the customer of an order, or the failure to
+// send it.
+func (s *SMSService) recordSMS(ctx context.Context, tenantID, orderID uuid.UUID, data map[string]string, sendErr error) {
+	eventType := model.OrderEventSMSSent
+	if sendErr != nil {
+		eventType = model.OrderEventSMSFailed
+		data["error"] = sendErr.Error()
+	}
+	recordNotification(ctx, s.pool, s.orderEventRepo, tenantID, orderID, eventType, data)
+}
+
 func (s *SMSService) loadSMSSettings(ctx context.Context, tenantID uuid.UUID) *model.SMSSettings {
 	var cfg *model.SMSSettings
 	if err := database.WithTenant(ctx, s.pool, tenantID, func(tx pgx.Tx) error {
@@ -109,6 +131,7 @@ func (s *SMSService) SendOrderStatusSMS(ctx context.Context, tenantID uuid.UUID,
 	} else {
 		slog.Info("sms: sent successfully", "to", *order.CustomerPhone, "status", newStatus, "order_id", order.ID)
 	}
+	s.recordSMS(ctx, tenantID, order.ID, map[string]string{"recipient": *order.CustomerPhone, "message": message, "status": newStatus}, err)
 }
 
 // SendShipmentStatusSMS sends an SMS when shipment status changes (tracking update).
@@ -191,6 +214,35 @@ func (s *SMSService) SendShipmentStatusSMS(ctx context.Context, tenantID uuid.UU
 	} else {
 		slog.Info("sms: shipment SMS sent", "to", *order.CustomerPhone, "status", shipment.Status, "shipment_id", shipment.ID)
 	}
+	s.recordSMS(ctx, tenantID, shipment.OrderID, map[string]string{
+		"recipient": *order.CustomerPhone, "message": message, "status": shipment.Status, "shipment_id": shipment.ID.String(),
+	}, err)
+}
+
+// SendOrderSMS sends a message about an order with SendSMS and records it on
+// the order timeline. It is used by the send_sms automation action.
+func (s *SMSService) SendOrderSMS(ctx context.Context, tenantID, orderID uuid.UUID, phone, message string) error {
+	err := s.SendSMS(ctx, tenantID, phone, message)
+	if errors.Is(err, ErrSMSNotConfigured) {
+		return err
+	}
+	s.recordSMS(ctx, tenantID, orderID, map[string]string{"recipient": phone, "message": message, "source": "automation"}, err)
+	return err
+}
+
+// SendSMS sends a message to phone with the tenant's SMS settings. It is used
+// by automation actions, which report failures instead of only logging them.
+func (s *SMSService) SendSMS(ctx context.Context, tenantID uuid.UUID, phone, message string) error {
+	cfg := s.loadSMSSettings(ctx, tenantID)
+	if cfg == nil || !cfg.Enabled || cfg.APIToken == "" {
+		return ErrSMSNotConfigured
+	}
+	client := smsapi.NewClient(cfg.APIToken, smsapi.WithFrom(cfg.From))
+	_, err := client.SendSMS(ctx, smsapi.SendSMSRequest{
+		To:      phone,
+		Message: message,
+	})
+	return err
 }
 
 // SendTestSMS sends a test SMS to the provided phone number.
@@ -13,8 +13,9 @@ import (
 )
 
 type StatsService struct {
-	statsRepo repository.StatsRepo
-	pool      *pgxpool.Pool
+	statsRepo  repository.StatsRepo
+	tenantRepo repository.TenantRepo
+	pool       *pgxpool.Pool
 }
 
 func NewStatsService(
@@ -27,6 +28,25 @@ func NewStatsService(
 	}
 }
 
+// SetTenantRepo sets the tenant repository used to read the order workflow,
+// so that custom cancelled and refunded statuses are excluded from sales.
+func (s *StatsService) SetTenantRepo(tenantRepo repository.TenantRepo) {
+	s.tenantRepo = tenantRepo
+}
+
+// salesExcludedStatuses returns the order statuses that do not count as
+// sales for the tenant.
+func (s *StatsService) salesExcludedStatuses(ctx context.Context, tx pgx.Tx, tenantID uuid.UUID) ([]string, error) {
+	if s.tenantRepo == nil {
+		return model.ProfitabilityExcludedStatuses, nil
+	}
+	config, err := loadOrderStatusConfig(ctx, tx, s.tenantRepo, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	return config.ExcludedFromSales(), nil
+}
+
 func (s *StatsService) GetDashboardStats(ctx context.Context, tenantID uuid.UUID) (*model.DashboardStats, error) {
 	var stats model.DashboardStats
 	err := database.WithTenant(ctx, s.pool, tenantID, func(tx pgx.Tx) error {
@@ -172,3 +192,46 @@ func (s *StatsService) GetPaymentMethodStats(ctx context.Context, tenantID uuid.
 	}
 	return result, nil
 }
+
+// GetProfitability builds the gross margin report. Margins are computed from
+// the aggregated revenue and costs of each row and of the totals.
+func (s *StatsService) GetProfitability(ctx context.Context, tenantID uuid.UUID, filter model.ProfitabilityFilter) (*model.ProfitabilityReport, error) {
+	report := model.ProfitabilityReport{
+		GroupBy:  filter.GroupBy,
+		Currency: filter.Currency,
+	}
+	if filter.GroupBy == model.ProfitabilityByPeriod {
+		report.Period = filter.Period
+	}
+	err := database.WithTenant(ctx, s.pool, tenantID, func(tx pgx.Tx) error {
+		excluded, err := s.salesExcludedStatuses(ctx, tx, tenantID)
+		if err != nil {
+			return err
+		}
+		filter.ExcludedStatuses = excluded
+		rows, err := s.statsRepo.GetProfitability(ctx, tx, filter)
+		if err != nil {
+			return err
+		}
+		totals, err := s.statsRepo.GetProfitabilityTotals(ctx, tx, filter)
+		if err != nil {
+			return err
+		}
+		report.Rows = rows
+		report.Totals = totals
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if report.Rows == nil {
+		report.Rows = []model.ProfitabilityRow{}
+	}
+	for i := range report.Rows {
+		report.Rows[i].ComputeMargin()
+	}
+	report.Totals.Key = "total"
+	report.Totals.Label = "Razem"
+	report.Totals.ComputeMargin()
+	return &report, nil
+}
@@ -324,6 +324,13 @@ func (s *StocktakeService) CompleteStocktake(ctx context.Context, tenantID, stoc
 			if err := s.docRepo.Create(ctx, tx, doc); err != nil {
 				return fmt.Errorf("create PZ doc: %w", err)
 			}
+			src := model.StockMovementSource{
+				Source:      model.StockMovementStocktake,
+				DocumentID:  &doc.ID,
+				StocktakeID: &existing.ID,
+				ActorID:     &actorID,
+				Note:        notes,
+			}
 
 			for _, item := range surplusItems {
 				docItem := &model.WarehouseDocItem{
@@ -338,7 +345,7 @@ func (s *StocktakeService) CompleteStocktake(ctx context.Context, tenantID, stoc
 				}
 
 				// Adjust stock: add surplus
-				if err := s.stockRepo.AdjustQuantity(ctx, tx, existing.WarehouseID, item.ProductID, nil, item.Difference); err != nil {
+				if err := s.stockRepo.AdjustQuantity(ctx, tx, existing.WarehouseID, item.ProductID, nil, item.Difference, src); err != nil {
 					return fmt.Errorf("PZ stock adjust: %w", err)
 				}
 			}
@@ -372,6 +379,13 @@ func (s *StocktakeService) CompleteStocktake(ctx context.Context, tenantID, stoc
 			if err := s.docRepo.Create(ctx, tx, doc); err != nil {
 				return fmt.Errorf("create WZ doc: %w", err)
 			}
+			src := model.StockMovementSource{
+				Source:      model.StockMovementStocktake,
+				DocumentID:  &doc.ID,
+				StocktakeID: &existing.ID,
+				ActorID:     &actorID,
+				Note:        notes,
+			}
 
 			for _, item := range shortageItems {
 				absQty := -item.Difference // make positive
@@ -387,7 +401,7 @@ func (s *StocktakeService) CompleteStocktake(ctx context.Context, tenantID, stoc
 				}
 
 				// Adjust stock: subtract shortage
-				if err := s.stockRepo.AdjustQuantity(ctx, tx, existing.WarehouseID, item.ProductID, nil, item.Difference); err != nil {
+				if err := s.stockRepo.AdjustQuantity(ctx, tx, existing.WarehouseID, item.ProductID, nil, item.Difference, src); err != nil {
 					return fmt.Errorf("WZ stock adjust: %w", err)
 				}
 			}
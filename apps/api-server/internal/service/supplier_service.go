@@ -15,16 +15,19 @@ import (
 	"github.com/openoms-org/openoms/apps/api-server/internal/database"
 	"github.com/openoms-org/openoms/apps/api-server/internal/model"
 	"github.com/openoms-org/openoms/apps/api-server/internal/repository"
-
-	iof "github.com/openoms-org/openoms/packages/iof-parser"
+	"github.com/openoms-org/openoms/apps/api-server/internal/supplierfeed"
 )
 
 var (
 	ErrSupplierNotFound        = errors.New("supplier not found")
 	ErrSupplierProductNotFound = errors.New("supplier product not found")
 	ErrNoFeedURL               = errors.New("supplier has no feed URL configured")
+	ErrFeedReadFailed          = errors.New("supplier feed could not be read")
 )
 
+// feedPreviewLimit caps the products returned by a feed preview.
+const feedPreviewLimit = 20
+
 type SupplierService struct {
 	supplierRepo     repository.SupplierRepo
 	supplierProdRepo repository.SupplierProductRepo
@@ -270,8 +273,7 @@ func (s *SupplierService) SyncFeed(ctx context.Context, tenantID, supplierID uui
 		return ErrNoFeedURL
 	}
 
-	// Parse the IOF feed
-	products, err := iof.ParseURL(ctx, *supplier.FeedURL)
+	feed, err := readFeed(ctx, *supplier.FeedURL, supplier.FeedFormat, supplier.Settings)
 	if err != nil {
 		// Record the error on the supplier
 		errMsg := err.Error()
@@ -282,11 +284,15 @@ func (s *SupplierService) SyncFeed(ctx context.Context, tenantID, supplierID uui
 		}
 		return fmt.Errorf("parse feed: %w", err)
 	}
+	if len(feed.Warnings) > 0 {
+		s.logger.Warn("supplier feed parsed with warnings",
+			"supplier_id", supplierID, "warnings", len(feed.Warnings), "first", feed.Warnings[0])
+	}
 
 	// Upsert products and auto-link by EAN
 	syncedAt := time.Now()
 	err = database.WithTenant(ctx, s.pool, tenantID, func(tx pgx.Tx) error {
-		for _, fp := range products {
+		for _, fp := range feed.Products {
 			attrs, _ := json.Marshal(fp.Attributes)
 
 			var ean, sku *string
@@ -305,7 +311,7 @@ func (s *SupplierService) SyncFeed(ctx context.Context, tenantID, supplierID uui
 				ID:            uuid.New(),
 				TenantID:      tenantID,
 				SupplierID:    supplierID,
-				ExternalID:    fp.ID,
+				ExternalID:    fp.ExternalID,
 				Name:          fp.Name,
 				EAN:           ean,
 				SKU:           sku,
@@ -317,7 +323,7 @@ func (s *SupplierService) SyncFeed(ctx context.Context, tenantID, supplierID uui
 
 			if err := s.supplierProdRepo.UpsertByExternalID(ctx, tx, sp); err != nil {
 				s.logger.Error("failed to upsert supplier product",
-					"supplier_id", supplierID, "external_id", fp.ID, "error", err)
+					"supplier_id", supplierID, "external_id", fp.ExternalID, "error", err)
 				continue
 			}
 
@@ -344,6 +350,71 @@ func (s *SupplierService) SyncFeed(ctx context.Context, tenantID, supplierID uui
 	}
 
 	s.logger.Info("supplier feed synced",
-		"supplier_id", supplierID, "products_count", len(products))
+		"supplier_id", supplierID, "products_count", len(feed.Products))
 	return nil
 }
+
+// PreviewFeed parses a supplier feed without saving anything. Fields left
+// empty in the request fall back to the supplier's saved configuration.
+func (s *SupplierService) PreviewFeed(ctx context.Context, tenantID, supplierID uuid.UUID, req model.SupplierFeedPreviewRequest) (*model.SupplierFeedPreview, error) {
+	if err := req.Validate(); err != nil {
+		return nil, NewValidationError(err)
+	}
+
+	supplier, err := s.Get(ctx, tenantID, supplierID)
+	if err != nil {
+		return nil, err
+	}
+
+	feedURL, format, settings := supplier.FeedURL, supplier.FeedFormat, supplier.Settings
+	if req.FeedURL != nil {
+		feedURL = req.FeedURL
+	}
+	if req.FeedFormat != nil {
+		format = *req.FeedFormat
+	}
+	if len(req.Settings) > 0 {
+		settings = req.Settings
+	}
+	if feedURL == nil || *feedURL == "" {
+		return nil, ErrNoFeedURL
+	}
+
+	res, err := readFeed(ctx, *feedURL, format, settings)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrFeedReadFailed, err)
+	}
+
+	preview := &model.SupplierFeedPreview{
+		FeedFormat:    format,
+		TotalProducts: len(res.Products),
+		Products:      res.Products[:min(len(res.Products), feedPreviewLimit)],
+		Columns:       res.Columns,
+		Warnings:      res.Warnings,
+	}
+	if preview.Products == nil {
+		preview.Products = []model.SupplierFeedProduct{}
+	}
+	if preview.Warnings == nil {
+		preview.Warnings = []string{}
+	}
+	return preview, nil
+}
+
+// readFeed downloads a feed and parses it with the parser of its format.
+func readFeed(ctx context.Context, feedURL, format string, settings json.RawMessage) (*supplierfeed.Result, error) {
+	feedSettings, err := model.ParseSupplierFeedSettings(settings)
+	if err != nil {
+		return nil, err
+	}
+	parser, err := supplierfeed.NewParser(format, feedSettings)
+	if err != nil {
+		return nil, err
+	}
+	body, err := supplierfeed.Fetch(ctx, feedURL)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+	return parser.Parse(body)
+}
@@ -48,3 +48,31 @@ func TestSupplierService_Update_ValidationError_NoFields(t *testing.T) {
 	var ve *ValidationError
 	assert.True(t, errors.As(err, &ve))
 }
+
+func TestSupplierService_Create_ValidationError_FeedMapping(t *testing.T) {
+	svc := NewSupplierService(nil, nil, nil, nil, nil, nil)
+
+	_, err := svc.Create(context.Background(), uuid.New(), model.CreateSupplierRequest{
+		Name:       "Test Supplier",
+		FeedFormat: "csv",
+		Settings:   []byte(`{"feed":{"csv":{"delimiter":";","mappings":[{"source":"Nazwa","field":"name"}]}}}`),
+	}, uuid.New(), "127.0.0.1")
+
+	require.Error(t, err)
+	var ve *ValidationError
+	assert.True(t, errors.As(err, &ve))
+	assert.Contains(t, err.Error(), "external_id, sku or ean")
+}
+
+func TestSupplierService_PreviewFeed_ValidationError(t *testing.T) {
+	svc := NewSupplierService(nil, nil, nil, nil, nil, nil)
+
+	_, err := svc.PreviewFeed(context.Background(), uuid.New(), uuid.New(), model.SupplierFeedPreviewRequest{
+		Settings: []byte(`{"feed":{"csv":{"encoding":"latin-9","mappings":[{"source":"a","field":"name"},{"source":"b","field":"sku"}]}}}`),
+	})
+
+	require.Error(t, err)
+	var ve *ValidationError
+	assert.True(t, errors.As(err, &ve))
+	assert.Contains(t, err.Error(), "encoding")
+}
@@ -101,6 +101,7 @@ func (s *VariantService) Create(ctx context.Context, tenantID, productID uuid.UU
 		Name:          req.Name,
 		Attributes:    attributes,
 		PriceOverride: req.PriceOverride,
+		CostPrice:     req.CostPrice,
 		StockQuantity: req.StockQuantity,
 		Weight:        req.Weight,
 		ImageURL:      req.ImageURL,
@@ -22,11 +22,14 @@ var (
 
 // WarehouseDocumentService provides business logic for warehouse documents.
 type WarehouseDocumentService struct {
-	docRepo   repository.WarehouseDocumentRepo
-	itemRepo  repository.WarehouseDocItemRepo
-	stockRepo repository.WarehouseStockRepo
-	auditRepo repository.AuditRepo
-	pool      *pgxpool.Pool
+	docRepo     repository.WarehouseDocumentRepo
+	itemRepo    repository.WarehouseDocItemRepo
+	stockRepo   repository.WarehouseStockRepo
+	productRepo repository.ProductRepo
+	variantRepo repository.VariantRepo
+	auditRepo   repository.AuditRepo
+	costing     *InventoryCostingService
+	pool        *pgxpool.Pool
 }
 
 // NewWarehouseDocumentService creates a new WarehouseDocumentService.
@@ -34,18 +37,27 @@ func NewWarehouseDocumentService(
 	docRepo repository.WarehouseDocumentRepo,
 	itemRepo repository.WarehouseDocItemRepo,
 	stockRepo repository.WarehouseStockRepo,
+	productRepo repository.ProductRepo,
+	variantRepo repository.VariantRepo,
 	auditRepo repository.AuditRepo,
 	pool *pgxpool.Pool,
 ) *WarehouseDocumentService {
 	return &WarehouseDocumentService{
-		docRepo:   docRepo,
-		itemRepo:  itemRepo,
-		stockRepo: stockRepo,
-		auditRepo: auditRepo,
-		pool:      pool,
+		docRepo:     docRepo,
+		itemRepo:    itemRepo,
+		stockRepo:   stockRepo,
+		productRepo: productRepo,
+		variantRepo: variantRepo,
+		auditRepo:   auditRepo,
+		pool:        pool,
 	}
 }
 
+// SetCostingService enables inventory costing of confirmed documents.
+func (s *WarehouseDocumentService) SetCostingService(costing *InventoryCostingService) {
+	s.costing = costing
+}
+
 // List lists warehouse documents for a tenant.
 func (s *WarehouseDocumentService) List(ctx context.Context, tenantID uuid.UUID, filter model.WarehouseDocumentListFilter) (model.ListResponse[model.WarehouseDocument], error) {
 	var resp model.ListResponse[model.WarehouseDocument]
@@ -104,59 +116,18 @@ func (s *WarehouseDocumentService) Create(ctx context.Context, tenantID uuid.UUI
 
 	var doc *model.WarehouseDocument
 	err := database.WithTenant(ctx, s.pool, tenantID, func(tx pgx.Tx) error {
-		// Generate document number: TYPE/YEAR/SEQ
-		year := time.Now().Year()
-		seq, err := s.docRepo.NextDocumentNumber(ctx, tx, req.DocumentType, year)
+		var err error
+		doc, err = s.create(ctx, tx, tenantID, req, actorID)
 		if err != nil {
 			return err
 		}
-		docNumber := fmt.Sprintf("%s/%d/%03d", req.DocumentType, year, seq)
-
-		doc = &model.WarehouseDocument{
-			ID:                uuid.New(),
-			TenantID:          tenantID,
-			DocumentNumber:    docNumber,
-			DocumentType:      req.DocumentType,
-			Status:            "draft",
-			WarehouseID:       req.WarehouseID,
-			TargetWarehouseID: req.TargetWarehouseID,
-			SupplierID:        req.SupplierID,
-			OrderID:           req.OrderID,
-			Notes:             req.Notes,
-			CreatedBy:         &actorID,
-		}
-
-		if err := s.docRepo.Create(ctx, tx, doc); err != nil {
-			return err
-		}
-
-		// Create items
-		var items []model.WarehouseDocItem
-		for _, itemReq := range req.Items {
-			item := &model.WarehouseDocItem{
-				ID:         uuid.New(),
-				TenantID:   tenantID,
-				DocumentID: doc.ID,
-				ProductID:  itemReq.ProductID,
-				VariantID:  itemReq.VariantID,
-				Quantity:   itemReq.Quantity,
-				UnitPrice:  itemReq.UnitPrice,
-				Notes:      itemReq.Notes,
-			}
-			if err := s.itemRepo.Create(ctx, tx, item); err != nil {
-				return err
-			}
-			items = append(items, *item)
-		}
-		doc.Items = items
-
 		return s.auditRepo.Log(ctx, tx, model.AuditEntry{
 			TenantID:   tenantID,
 			UserID:     actorID,
 			Action:     "warehouse_document.created",
 			EntityType: "warehouse_document",
 			EntityID:   doc.ID,
-			Changes:    map[string]string{"document_number": docNumber, "type": req.DocumentType},
+			Changes:    map[string]string{"document_number": doc.DocumentNumber, "type": req.DocumentType},
 			IPAddress:  ip,
 		})
 	})
@@ -166,6 +137,81 @@ func (s *WarehouseDocumentService) Create(ctx context.Context, tenantID uuid.UUI
 	return doc, nil
 }
 
+// CreateConfirmed creates and immediately confirms a warehouse document within
+// the caller's transaction, so that stock follows another operation atomically
+// (e.g. restocking received returns).
+func (s *WarehouseDocumentService) CreateConfirmed(ctx context.Context, tx pgx.Tx, tenantID uuid.UUID, req model.CreateWarehouseDocumentRequest, actorID uuid.UUID) (*model.WarehouseDocument, error) {
+	if err := req.Validate(); err != nil {
+		return nil, NewValidationError(err)
+	}
+	doc, err := s.create(ctx, tx, tenantID, req, actorID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.applyStock(ctx, tx, doc, doc.Items, actorID); err != nil {
+		return nil, err
+	}
+	if err := s.docRepo.Confirm(ctx, tx, doc.ID, actorID); err != nil {
+		return nil, err
+	}
+	confirmed, err := s.docRepo.FindByID(ctx, tx, doc.ID)
+	if err != nil {
+		return nil, err
+	}
+	confirmed.Items = doc.Items
+	return confirmed, nil
+}
+
+// create inserts a draft document and its items with an auto-generated document number.
+func (s *WarehouseDocumentService) create(ctx context.Context, tx pgx.Tx, tenantID uuid.UUID, req model.CreateWarehouseDocumentRequest, actorID uuid.UUID) (*model.WarehouseDocument, error) {
+	// Generate document number: TYPE/YEAR/SEQ
+	year := time.Now().Year()
+	seq, err := s.docRepo.NextDocumentNumber(ctx, tx, req.DocumentType, year)
+	if err != nil {
+		return nil, err
+	}
+	docNumber := fmt.Sprintf("%s/%d/%03d", req.DocumentType, year, seq)
+
+	doc := &model.WarehouseDocument{
+		ID:                uuid.New(),
+		TenantID:          tenantID,
+		DocumentNumber:    docNumber,
+		DocumentType:      req.DocumentType,
+		Status:            "draft",
+		WarehouseID:       req.WarehouseID,
+		TargetWarehouseID: req.TargetWarehouseID,
+		SupplierID:        req.SupplierID,
+		OrderID:           req.OrderID,
+		Notes:             req.Notes,
+		CreatedBy:         &actorID,
+	}
+
+	if err := s.docRepo.Create(ctx, tx, doc); err != nil {
+		return nil, err
+	}
+
+	// Create items
+	var items []model.WarehouseDocItem
+	for _, itemReq := range req.Items {
+		item := &model.WarehouseDocItem{
+			ID:         uuid.New(),
+			TenantID:   tenantID,
+			DocumentID: doc.ID,
+			ProductID:  itemReq.ProductID,
+			VariantID:  itemReq.VariantID,
+			Quantity:   itemReq.Quantity,
+			UnitPrice:  itemReq.UnitPrice,
+			Notes:      itemReq.Notes,
+		}
+		if err := s.itemRepo.Create(ctx, tx, item); err != nil {
+			return nil, err
+		}
+		items = append(items, *item)
+	}
+	doc.Items = items
+	return doc, nil
+}
+
 // Update updates a warehouse document (only draft documents).
 func (s *WarehouseDocumentService) Update(ctx context.Context, tenantID, docID uuid.UUID, req model.UpdateWarehouseDocumentRequest, actorID uuid.UUID, ip string) (*model.WarehouseDocument, error) {
 	if err := req.Validate(); err != nil {
@@ -267,31 +313,8 @@ func (s *WarehouseDocumentService) Confirm(ctx context.Context, tenantID, docID
 			return err
 		}
 
-		// Update stock based on document type
-		for _, item := range items {
-			switch existing.DocumentType {
-			case "PZ":
-				// Add stock to warehouse
-				if err := s.stockRepo.AdjustQuantity(ctx, tx, existing.WarehouseID, item.ProductID, item.VariantID, item.Quantity); err != nil {
-					return fmt.Errorf("PZ stock adjust: %w", err)
-				}
-			case "WZ":
-				// Subtract stock from warehouse
-				if err := s.stockRepo.AdjustQuantity(ctx, tx, existing.WarehouseID, item.ProductID, item.VariantID, -item.Quantity); err != nil {
-					return fmt.Errorf("WZ stock adjust: %w", err)
-				}
-			case "MM":
-				// Subtract from source warehouse
-				if err := s.stockRepo.AdjustQuantity(ctx, tx, existing.WarehouseID, item.ProductID, item.VariantID, -item.Quantity); err != nil {
-					return fmt.Errorf("MM source stock adjust: %w", err)
-				}
-				// Add to target warehouse
-				if existing.TargetWarehouseID != nil {
-					if err := s.stockRepo.AdjustQuantity(ctx, tx, *existing.TargetWarehouseID, item.ProductID, item.VariantID, item.Quantity); err != nil {
-						return fmt.Errorf("MM target stock adjust: %w", err)
-					}
-				}
-			}
+		if err := s.applyStock(ctx, tx, existing, items, actorID); err != nil {
+			return err
 		}
 
 		// Mark as confirmed
@@ -321,6 +344,59 @@ func (s *WarehouseDocumentService) Confirm(ctx context.Context, tenantID, docID
 	return doc, nil
 }
 
+// applyStock updates stock levels for the items of a document being confirmed
+// and records them in the stock ledger on behalf of actorID.
+// A PZ purchase price becomes the cost price of the product or variant;
+// PZ documents of returned orders carry sale prices and are skipped.
+func (s *WarehouseDocumentService) applyStock(ctx context.Context, tx pgx.Tx, doc *model.WarehouseDocument, items []model.WarehouseDocItem, actorID uuid.UUID) error {
+	src := model.StockMovementSource{
+		Source:     model.StockMovementDocument,
+		DocumentID: &doc.ID,
+		ActorID:    &actorID,
+	}
+	for _, item := range items {
+		switch doc.DocumentType {
+		case "PZ":
+			// Add stock to warehouse
+			if err := s.stockRepo.AdjustQuantity(ctx, tx, doc.WarehouseID, item.ProductID, item.VariantID, item.Quantity, src); err != nil {
+				return fmt.Errorf("PZ stock adjust: %w", err)
+			}
+			if item.UnitPrice != nil && doc.OrderID == nil {
+				if err := s.updateCostPrice(ctx, tx, item); err != nil {
+					return fmt.Errorf("PZ cost price: %w", err)
+				}
+			}
+		case "WZ":
+			// Subtract stock from warehouse
+			if err := s.stockRepo.AdjustQuantity(ctx, tx, doc.WarehouseID, item.ProductID, item.VariantID, -item.Quantity, src); err != nil {
+				return fmt.Errorf("WZ stock adjust: %w", err)
+			}
+		case "MM":
+			// Subtract from source warehouse
+			if err := s.stockRepo.AdjustQuantity(ctx, tx, doc.WarehouseID, item.ProductID, item.VariantID, -item.Quantity, src); err != nil {
+				return fmt.Errorf("MM source stock adjust: %w", err)
+			}
+			// Add to target warehouse
+			if doc.TargetWarehouseID != nil {
+				if err := s.stockRepo.AdjustQuantity(ctx, tx, *doc.TargetWarehouseID, item.ProductID, item.VariantID, item.Quantity, src); err != nil {
+					return fmt.Errorf("MM target stock adjust: %w", err)
+				}
+			}
+		}
+	}
+	if s.costing != nil {
+		return s.costing.ApplyDocument(ctx, tx, doc, items)
+	}
+	return nil
+}
+
+func (s *WarehouseDocumentService) updateCostPrice(ctx context.Context, tx pgx.Tx, item model.WarehouseDocItem) error {
+	if item.VariantID != nil {
+		return s.variantRepo.Update(ctx, tx, *item.VariantID, model.UpdateVariantRequest{CostPrice: item.UnitPrice})
+	}
+	return s.productRepo.Update(ctx, tx, item.ProductID, model.UpdateProductRequest{CostPrice: item.UnitPrice})
+}
+
 // Cancel cancels a warehouse document (only draft documents).
 func (s *WarehouseDocumentService) Cancel(ctx context.Context, tenantID, docID uuid.UUID, actorID uuid.UUID, ip string) (*model.WarehouseDocument, error) {
 	var doc *model.WarehouseDocument
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"strings"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -24,6 +25,7 @@ var (
 type WarehouseService struct {
 	warehouseRepo      repository.WarehouseRepo
 	warehouseStockRepo repository.WarehouseStockRepo
+	stockMovementRepo  repository.StockMovementRepo
 	auditRepo          repository.AuditRepo
 	tenantRepo         repository.TenantRepo
 	pool               *pgxpool.Pool
@@ -33,6 +35,7 @@ type WarehouseService struct {
 func NewWarehouseService(
 	warehouseRepo repository.WarehouseRepo,
 	warehouseStockRepo repository.WarehouseStockRepo,
+	stockMovementRepo repository.StockMovementRepo,
 	auditRepo repository.AuditRepo,
 	tenantRepo repository.TenantRepo,
 	pool *pgxpool.Pool,
@@ -40,6 +43,7 @@ func NewWarehouseService(
 	return &WarehouseService{
 		warehouseRepo:      warehouseRepo,
 		warehouseStockRepo: warehouseStockRepo,
+		stockMovementRepo:  stockMovementRepo,
 		auditRepo:          auditRepo,
 		tenantRepo:         tenantRepo,
 		pool:               pool,
@@ -301,7 +305,12 @@ func (s *WarehouseService) UpsertStock(ctx context.Context, tenantID, warehouseI
 			return ErrWarehouseNotFound
 		}
 
-		if err := s.warehouseStockRepo.Upsert(ctx, tx, stock); err != nil {
+		src := model.StockMovementSource{
+			Source:  model.StockMovementManual,
+			ActorID: &actorID,
+			Note:    strings.TrimSpace(req.Reason),
+		}
+		if err := s.warehouseStockRepo.Upsert(ctx, tx, stock, src); err != nil {
 			return err
 		}
 
@@ -340,3 +349,43 @@ func (s *WarehouseService) ListProductStock(ctx context.Context, tenantID, produ
 	}
 	return stocks, nil
 }
+
+// ListStockMovements lists the stock ledger entries of a product, newest first.
+func (s *WarehouseService) ListStockMovements(ctx context.Context, tenantID, productID uuid.UUID, filter model.StockMovementListFilter) (model.ListResponse[model.StockMovement], error) {
+	var resp model.ListResponse[model.StockMovement]
+	err := database.WithTenant(ctx, s.pool, tenantID, func(tx pgx.Tx) error {
+		movements, total, err := s.stockMovementRepo.ListByProduct(ctx, tx, productID, filter)
+		if err != nil {
+			return err
+		}
+		if movements == nil {
+			movements = []model.StockMovement{}
+		}
+		resp = model.ListResponse[model.StockMovement]{
+			Items:  movements,
+			Total:  total,
+			Limit:  filter.Limit,
+			Offset: filter.Offset,
+		}
+		return nil
+	})
+	return resp, err
+}
+
+// StockAt reconstructs the stock of a product at a point in time from the
+// stock ledger.
+func (s *WarehouseService) StockAt(ctx context.Context, tenantID, productID uuid.UUID, filter model.StockAtFilter) (*model.ProductStockAt, error) {
+	result := &model.ProductStockAt{ProductID: productID, At: filter.At}
+	err := database.WithTenant(ctx, s.pool, tenantID, func(tx pgx.Tx) error {
+		var err error
+		result.Rows, err = s.stockMovementRepo.StockAt(ctx, tx, productID, filter)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range result.Rows {
+		result.Quantity += row.Quantity
+	}
+	return result, nil
+}
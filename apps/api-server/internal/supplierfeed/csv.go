@@ -0,0 +1,136 @@
+package supplierfeed
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"golang.org/x/text/encoding/charmap"
+
+	"github.com/openoms-org/openoms/apps/api-server/internal/model"
+)
+
+// CSVParser reads CSV feeds using a column mapping.
+type CSVParser struct {
+	settings model.SupplierCSVSettings
+}
+
+func NewCSVParser(settings model.SupplierCSVSettings) *CSVParser {
+	return &CSVParser{settings: settings}
+}
+
+func (p *CSVParser) Parse(r io.Reader) (*Result, error) {
+	raw, err := io.ReadAll(decodeCharset(r, p.settings.Encoding))
+	if err != nil {
+		return nil, fmt.Errorf("read csv: %w", err)
+	}
+	raw = bytes.TrimPrefix(raw, []byte("\xEF\xBB\xBF"))
+
+	reader := csv.NewReader(bytes.NewReader(raw))
+	reader.Comma = p.delimiter(raw)
+	reader.LazyQuotes = true
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	res := &Result{}
+	var columns map[string]int
+	if !p.settings.NoHeader {
+		header, err := reader.Read()
+		if err == io.EOF {
+			return nil, errors.New("csv feed is empty")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("parse csv header: %w", err)
+		}
+		res.Columns = header
+		columns = make(map[string]int, len(header))
+		for i, h := range header {
+			columns[strings.ToLower(strings.TrimSpace(h))] = i
+		}
+	}
+
+	// Resolve the mapped columns once.
+	indexes := make([]int, len(p.settings.Mappings))
+	for i, m := range p.settings.Mappings {
+		idx, err := columnIndex(m.Source, columns)
+		if err != nil {
+			return nil, err
+		}
+		indexes[i] = idx
+	}
+
+	line := 1
+	if !p.settings.NoHeader {
+		line++
+	}
+	for ; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("parse csv line %d: %w", line, err)
+		}
+		if len(record) == 1 && strings.TrimSpace(record[0]) == "" {
+			continue
+		}
+
+		var product model.SupplierFeedProduct
+		for i, m := range p.settings.Mappings {
+			if indexes[i] >= len(record) {
+				continue
+			}
+			if err := setField(&product, m.Field, record[indexes[i]]); err != nil {
+				res.warn("line %d: %v", line, err)
+			}
+		}
+		res.add(product, fmt.Sprintf("line %d", line))
+	}
+	return res, nil
+}
+
+// delimiter returns the configured delimiter or detects it from the first line.
+func (p *CSVParser) delimiter(raw []byte) rune {
+	if p.settings.Delimiter != "" {
+		return []rune(p.settings.Delimiter)[0]
+	}
+	first, _, _ := bufio.NewReader(bytes.NewReader(raw)).ReadLine()
+	best, bestCount := ';', -1
+	for _, c := range []rune{';', ',', '\t', '|'} {
+		if n := strings.Count(string(first), string(c)); n > bestCount {
+			best, bestCount = c, n
+		}
+	}
+	return best
+}
+
+// columnIndex resolves a mapping source to a column: a header name, or a
+// 1-based column number for feeds without a header.
+func columnIndex(source string, columns map[string]int) (int, error) {
+	if columns != nil {
+		if idx, ok := columns[strings.ToLower(strings.TrimSpace(source))]; ok {
+			return idx, nil
+		}
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(source))
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf("column %q not found in the feed header", source)
+	}
+	return n - 1, nil
+}
+
+// decodeCharset converts single-byte Polish encodings to UTF-8.
+func decodeCharset(r io.Reader, encoding string) io.Reader {
+	switch strings.ToLower(encoding) {
+	case "cp1250", "windows-1250":
+		return charmap.Windows1250.NewDecoder().Reader(r)
+	case "iso-8859-2":
+		return charmap.ISO8859_2.NewDecoder().Reader(r)
+	}
+	return r
+}
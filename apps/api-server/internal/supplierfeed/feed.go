@@ -0,0 +1,204 @@
+// Package supplierfeed parses supplier product feeds — IOF XML, CSV with a
+// column mapping and custom XML with XPath mappings — into supplier products.
+package supplierfeed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/openoms-org/openoms/apps/api-server/internal/model"
+
+	iof "github.com/openoms-org/openoms/packages/iof-parser"
+)
+
+// maxFeedSize caps the size of a downloaded feed.
+const maxFeedSize = 200 << 20
+
+// maxWarnings caps the warnings collected while parsing a feed.
+const maxWarnings = 100
+
+// Result is the outcome of parsing a feed.
+type Result struct {
+	Products []model.SupplierFeedProduct
+	// Columns lists the CSV header, to help building a column mapping.
+	Columns []string
+	// Warnings lists skipped products and values that could not be read.
+	Warnings []string
+}
+
+func (r *Result) warn(format string, args ...any) {
+	if len(r.Warnings) < maxWarnings {
+		r.Warnings = append(r.Warnings, fmt.Sprintf(format, args...))
+	}
+}
+
+// add validates a mapped product and appends it, falling back to the SKU or
+// EAN as the external ID.
+func (r *Result) add(p model.SupplierFeedProduct, position string) {
+	if p.ExternalID == "" {
+		p.ExternalID = p.SKU
+	}
+	if p.ExternalID == "" {
+		p.ExternalID = p.EAN
+	}
+	if p.ExternalID == "" {
+		r.warn("%s: skipped, no external_id, sku or ean", position)
+		return
+	}
+	if p.Name == "" {
+		r.warn("%s: skipped, no name", position)
+		return
+	}
+	r.Products = append(r.Products, p)
+}
+
+// Parser reads a supplier feed.
+type Parser interface {
+	Parse(r io.Reader) (*Result, error)
+}
+
+// NewParser returns the parser of a supplier feed format.
+func NewParser(format string, settings model.SupplierFeedSettings) (Parser, error) {
+	switch format {
+	case "", "iof":
+		return IOFParser{}, nil
+	case "csv":
+		if settings.CSV == nil {
+			return nil, errors.New("csv feed mapping is not configured")
+		}
+		return NewCSVParser(*settings.CSV), nil
+	case "custom":
+		if settings.XML == nil {
+			return nil, errors.New("custom XML feed mapping is not configured")
+		}
+		return NewXMLParser(*settings.XML)
+	default:
+		return nil, fmt.Errorf("unsupported feed format %q", format)
+	}
+}
+
+var httpClient = &http.Client{Timeout: 5 * time.Minute}
+
+// Fetch downloads a feed. The caller closes the returned body.
+func Fetch(ctx context.Context, url string) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch feed: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("feed returned status %d", resp.StatusCode)
+	}
+	return struct {
+		io.Reader
+		io.Closer
+	}{io.LimitReader(resp.Body, maxFeedSize), resp.Body}, nil
+}
+
+// IOFParser reads IOF XML feeds.
+type IOFParser struct{}
+
+func (IOFParser) Parse(r io.Reader) (*Result, error) {
+	products, err := iof.Parse(r)
+	if err != nil {
+		return nil, err
+	}
+	res := &Result{Products: make([]model.SupplierFeedProduct, 0, len(products))}
+	for i, p := range products {
+		res.add(model.SupplierFeedProduct{
+			ExternalID:  p.ID,
+			Name:        p.Name,
+			EAN:         p.EAN,
+			SKU:         p.SKU,
+			Price:       p.Price,
+			Stock:       p.Stock,
+			Description: p.Description,
+			Category:    p.Category,
+			ImageURL:    p.ImageURL,
+			Weight:      p.Weight,
+			Attributes:  p.Attributes,
+		}, fmt.Sprintf("product %d", i+1))
+	}
+	return res, nil
+}
+
+// setField stores a mapped feed value on the product.
+func setField(p *model.SupplierFeedProduct, field, value string) error {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil
+	}
+	switch field {
+	case "external_id":
+		p.ExternalID = value
+	case "name":
+		p.Name = value
+	case "ean":
+		p.EAN = value
+	case "sku":
+		p.SKU = value
+	case "description":
+		p.Description = value
+	case "category":
+		p.Category = value
+	case "image_url":
+		p.ImageURL = value
+	case "price":
+		v, err := parseDecimal(value)
+		if err != nil {
+			return fmt.Errorf("invalid price %q", value)
+		}
+		p.Price = v
+	case "weight":
+		v, err := parseDecimal(value)
+		if err != nil {
+			return fmt.Errorf("invalid weight %q", value)
+		}
+		p.Weight = v
+	case "stock":
+		v, err := parseDecimal(strings.TrimLeft(value, "<>+ "))
+		if err != nil {
+			return fmt.Errorf("invalid stock %q", value)
+		}
+		p.Stock = max(0, int(v))
+	default:
+		name, ok := strings.CutPrefix(field, "attr:")
+		if !ok {
+			return fmt.Errorf("unknown field %q", field)
+		}
+		if p.Attributes == nil {
+			p.Attributes = make(map[string]string)
+		}
+		p.Attributes[name] = value
+	}
+	return nil
+}
+
+// parseDecimal reads numbers written with a decimal comma or point and
+// optional thousands separators, e.g. "1 234,50", "1.234,50" or "1234.5".
+func parseDecimal(s string) (float64, error) {
+	s = strings.NewReplacer(" ", "", "\u00a0", "").Replace(s)
+	lastComma, lastDot := strings.LastIndex(s, ","), strings.LastIndex(s, ".")
+	switch {
+	case lastComma >= 0 && lastDot >= 0:
+		if lastComma > lastDot {
+			s = strings.ReplaceAll(s, ".", "")
+			s = strings.Replace(s, ",", ".", 1)
+		} else {
+			s = strings.ReplaceAll(s, ",", "")
+		}
+	case lastComma >= 0:
+		s = strings.Replace(s, ",", ".", 1)
+	}
+	return strconv.ParseFloat(s, 64)
+}
@@ -0,0 +1,191 @@
+package supplierfeed
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/encoding/charmap"
+
+	"github.com/openoms-org/openoms/apps/api-server/internal/model"
+)
+
+func TestCSVParser_CP1250Semicolon(t *testing.T) {
+	feed := "Kod;Nazwa;EAN;Cena brutto;Stan;Producent\r\n" +
+		"A-1;Żółta łódź;5901234123457;1 234,50;>10;Łucznik\r\n" +
+		"A-2;Kubek;;12,99;0;\r\n" +
+		";Bez kodu;;1;1;\r\n"
+	encoded, err := charmap.Windows1250.NewEncoder().String(feed)
+	require.NoError(t, err)
+
+	p := NewCSVParser(model.SupplierCSVSettings{
+		Encoding: "cp1250",
+		Mappings: []model.SupplierFeedMapping{
+			{Source: "kod", Field: "sku"},
+			{Source: "Nazwa", Field: "name"},
+			{Source: "EAN", Field: "ean"},
+			{Source: "Cena brutto", Field: "price"},
+			{Source: "Stan", Field: "stock"},
+			{Source: "Producent", Field: "attr:producer"},
+		},
+	})
+	res, err := p.Parse(strings.NewReader(encoded))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"Kod", "Nazwa", "EAN", "Cena brutto", "Stan", "Producent"}, res.Columns)
+	require.Len(t, res.Products, 2)
+	first := res.Products[0]
+	assert.Equal(t, "A-1", first.ExternalID)
+	assert.Equal(t, "Żółta łódź", first.Name)
+	assert.Equal(t, "5901234123457", first.EAN)
+	assert.InDelta(t, 1234.50, first.Price, 0.001)
+	assert.Equal(t, 10, first.Stock)
+	assert.Equal(t, map[string]string{"producer": "Łucznik"}, first.Attributes)
+	assert.Equal(t, "A-2", res.Products[1].ExternalID)
+	assert.Equal(t, []string{"line 4: skipped, no external_id, sku or ean"}, res.Warnings)
+}
+
+func TestCSVParser_NoHeaderColumnNumbers(t *testing.T) {
+	p := NewCSVParser(model.SupplierCSVSettings{
+		Delimiter: ",",
+		NoHeader:  true,
+		Mappings: []model.SupplierFeedMapping{
+			{Source: "1", Field: "external_id"},
+			{Source: "2", Field: "name"},
+			{Source: "3", Field: "price"},
+		},
+	})
+	res, err := p.Parse(strings.NewReader("\xEF\xBB\xBF10,\"Pen, blue\",abc\n"))
+	require.NoError(t, err)
+
+	require.Len(t, res.Products, 1)
+	assert.Equal(t, "10", res.Products[0].ExternalID)
+	assert.Equal(t, "Pen, blue", res.Products[0].Name)
+	assert.Equal(t, []string{`line 1: invalid price "abc"`}, res.Warnings)
+}
+
+func TestCSVParser_UnknownColumn(t *testing.T) {
+	p := NewCSVParser(model.SupplierCSVSettings{
+		Mappings: []model.SupplierFeedMapping{{Source: "Missing", Field: "name"}},
+	})
+	_, err := p.Parse(strings.NewReader("id\tname\n1\tx\n"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `"Missing"`)
+}
+
+func TestCSVParser_DetectDelimiter(t *testing.T) {
+	p := NewCSVParser(model.SupplierCSVSettings{})
+	assert.Equal(t, ';', p.delimiter([]byte("a;b;c\n1,5;2;3")))
+	assert.Equal(t, ',', p.delimiter([]byte("a,b,c\n")))
+	assert.Equal(t, '\t', p.delimiter([]byte("a\tb\n")))
+}
+
+const customFeed = `<?xml version="1.0" encoding="UTF-8"?>
+<rss xmlns:g="http://base.google.com/ns/1.0">
+  <channel>
+    <item id="100">
+      <g:title>Lampa <b>LED</b></g:title>
+      <g:price>49.99 PLN</g:price>
+      <prices><price type="net">40,64</price><price type="gross">49,99</price></prices>
+      <stock warehouse="main">7</stock>
+      <stock warehouse="ext">2</stock>
+      <params><param name="color">white</param><param name="power">10W</param></params>
+    </item>
+    <item id="101">
+      <g:title>Kabel</g:title>
+      <prices><price type="gross">9.90</price></prices>
+    </item>
+    <item><g:title>No id</g:title></item>
+  </channel>
+</rss>`
+
+func TestXMLParser_Mappings(t *testing.T) {
+	p, err := NewXMLParser(model.SupplierXMLSettings{
+		ItemPath: "//item",
+		Mappings: []model.SupplierFeedMapping{
+			{Source: "@id", Field: "external_id"},
+			{Source: "g:title", Field: "name"},
+			{Source: "prices/price[@type='gross']", Field: "price"},
+			{Source: "stock[@warehouse=\"main\"]", Field: "stock"},
+			{Source: "params/param[@name='color']/text()", Field: "attr:color"},
+			{Source: "params/param[2]", Field: "attr:power"},
+		},
+	})
+	require.NoError(t, err)
+
+	res, err := p.Parse(strings.NewReader(customFeed))
+	require.NoError(t, err)
+
+	require.Len(t, res.Products, 2)
+	first := res.Products[0]
+	assert.Equal(t, "100", first.ExternalID)
+	assert.Equal(t, "Lampa LED", first.Name)
+	assert.InDelta(t, 49.99, first.Price, 0.001)
+	assert.Equal(t, 7, first.Stock)
+	assert.Equal(t, map[string]string{"color": "white", "power": "10W"}, first.Attributes)
+	assert.Equal(t, "101", res.Products[1].ExternalID)
+	assert.InDelta(t, 9.90, res.Products[1].Price, 0.001)
+	assert.Equal(t, []string{"item 3: skipped, no external_id, sku or ean"}, res.Warnings)
+}
+
+func TestXMLParser_AbsolutePathAndCharset(t *testing.T) {
+	feed, err := charmap.ISO8859_2.NewEncoder().String(`<?xml version="1.0" encoding="ISO-8859-2"?>
+<offer><products><product><code>X</code><name>Łyżka</name></product></products></offer>`)
+	require.NoError(t, err)
+
+	p, err := NewXMLParser(model.SupplierXMLSettings{
+		ItemPath: "/offer/products/product",
+		Mappings: []model.SupplierFeedMapping{
+			{Source: "code", Field: "sku"},
+			{Source: "./name", Field: "name"},
+			{Source: "/offer/products/product[code='X']/../product/code", Field: "attr:first"},
+		},
+	})
+	require.NoError(t, err)
+
+	res, err := p.Parse(strings.NewReader(feed))
+	require.NoError(t, err)
+	require.Len(t, res.Products, 1)
+	assert.Equal(t, "X", res.Products[0].ExternalID)
+	assert.Equal(t, "Łyżka", res.Products[0].Name)
+	assert.Equal(t, "X", res.Products[0].Attributes["first"])
+}
+
+func TestCompileXPath_Invalid(t *testing.T) {
+	for _, expr := range []string{"", "items/", "@id/name", "item[", "item[@id=1]", "item[0]", "text()[1]", "a///b"} {
+		_, err := compileXPath(expr)
+		assert.Error(t, err, expr)
+	}
+}
+
+func TestParseDecimal(t *testing.T) {
+	tests := map[string]float64{
+		"12":       12,
+		"12,5":     12.5,
+		"12.5":     12.5,
+		"1 234,50": 1234.5,
+		"1.234,50": 1234.5,
+		"1,234.50": 1234.5,
+		"1 000":    1000,
+	}
+	for in, want := range tests {
+		got, err := parseDecimal(in)
+		require.NoError(t, err, in)
+		assert.InDelta(t, want, got, 0.0001, in)
+	}
+	_, err := parseDecimal("abc")
+	assert.Error(t, err)
+}
+
+func TestNewParser(t *testing.T) {
+	_, err := NewParser("csv", model.SupplierFeedSettings{})
+	assert.Error(t, err)
+
+	p, err := NewParser("iof", model.SupplierFeedSettings{})
+	require.NoError(t, err)
+	assert.IsType(t, IOFParser{}, p)
+
+	_, err = NewParser("custom", model.SupplierFeedSettings{XML: &model.SupplierXMLSettings{ItemPath: "//item["}})
+	assert.Error(t, err)
+}
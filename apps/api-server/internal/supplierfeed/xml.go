@@ -0,0 +1,475 @@
+package supplierfeed
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"golang.org/x/text/encoding/charmap"
+
+	"github.com/openoms-org/openoms/apps/api-server/internal/model"
+)
+
+// XMLParser reads custom XML feeds using XPath mappings.
+//
+// Paths support the XPath subset found in supplier feeds: child (/) and
+// descendant (//) steps, element names (namespace prefixes are ignored), *,
+// ., .., @attr, text() and the predicates [n], [@attr], [@attr='v'],
+// [name] and [name='v'].
+type XMLParser struct {
+	items    xpath
+	mappings []xmlMapping
+}
+
+type xmlMapping struct {
+	path  xpath
+	field string
+}
+
+func NewXMLParser(settings model.SupplierXMLSettings) (*XMLParser, error) {
+	items, err := compileXPath(settings.ItemPath)
+	if err != nil {
+		return nil, fmt.Errorf("item_path: %w", err)
+	}
+	p := &XMLParser{items: items}
+	for _, m := range settings.Mappings {
+		path, err := compileXPath(m.Source)
+		if err != nil {
+			return nil, fmt.Errorf("mapping %q: %w", m.Source, err)
+		}
+		p.mappings = append(p.mappings, xmlMapping{path: path, field: m.Field})
+	}
+	return p, nil
+}
+
+func (p *XMLParser) Parse(r io.Reader) (*Result, error) {
+	doc, err := parseXMLDocument(r)
+	if err != nil {
+		return nil, err
+	}
+	res := &Result{}
+	items := p.items.eval(doc)
+	if len(items) == 0 {
+		res.warn("item_path matched no elements")
+	}
+	for i, item := range items {
+		var product model.SupplierFeedProduct
+		for _, m := range p.mappings {
+			nodes := m.path.eval(item)
+			if len(nodes) == 0 {
+				continue
+			}
+			if err := setField(&product, m.field, nodes[0].value()); err != nil {
+				res.warn("item %d: %v", i+1, err)
+			}
+		}
+		res.add(product, fmt.Sprintf("item %d", i+1))
+	}
+	return res, nil
+}
+
+// xmlNode is an element, or a text value when name is empty.
+type xmlNode struct {
+	name     string
+	attrs    []xml.Attr
+	parent   *xmlNode
+	children []*xmlNode
+	text     string
+}
+
+func (n *xmlNode) attr(name string) (string, bool) {
+	for _, a := range n.attrs {
+		if a.Name.Local == name {
+			return a.Value, true
+		}
+	}
+	return "", false
+}
+
+// value returns the trimmed text content of the node.
+func (n *xmlNode) value() string {
+	if n.name == "" {
+		return strings.TrimSpace(n.text)
+	}
+	var b strings.Builder
+	var walk func(*xmlNode)
+	walk = func(n *xmlNode) {
+		for _, c := range n.children {
+			if c.name == "" {
+				b.WriteString(c.text)
+			} else {
+				walk(c)
+			}
+		}
+	}
+	walk(n)
+	return strings.TrimSpace(b.String())
+}
+
+// parseXMLDocument reads the feed into a tree under a nameless document node.
+func parseXMLDocument(r io.Reader) (*xmlNode, error) {
+	dec := xml.NewDecoder(r)
+	dec.Strict = false
+	dec.CharsetReader = func(label string, input io.Reader) (io.Reader, error) {
+		switch strings.ToLower(label) {
+		case "windows-1250", "cp1250":
+			return charmap.Windows1250.NewDecoder().Reader(input), nil
+		case "iso-8859-2":
+			return charmap.ISO8859_2.NewDecoder().Reader(input), nil
+		}
+		return nil, fmt.Errorf("unsupported XML encoding %q", label)
+	}
+
+	doc := &xmlNode{}
+	cur := doc
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("parse xml: %w", err)
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			n := &xmlNode{name: t.Name.Local, attrs: t.Attr, parent: cur}
+			cur.children = append(cur.children, n)
+			cur = n
+		case xml.EndElement:
+			if cur.parent != nil {
+				cur = cur.parent
+			}
+		case xml.CharData:
+			if cur != doc {
+				cur.children = append(cur.children, &xmlNode{text: string(t), parent: cur})
+			}
+		}
+	}
+	if len(doc.children) == 0 {
+		return nil, errors.New("xml feed is empty")
+	}
+	return doc, nil
+}
+
+type stepKind int
+
+const (
+	stepElement stepKind = iota
+	stepSelf
+	stepParent
+	stepAttr
+	stepText
+)
+
+type predicate struct {
+	index    int // 1-based position; 0 when the predicate tests a value
+	attr     bool
+	name     string
+	value    string
+	hasValue bool
+}
+
+type step struct {
+	descendant bool
+	kind       stepKind
+	name       string // element or attribute name, "*" for any
+	predicates []predicate
+}
+
+// xpath is a compiled location path.
+type xpath struct {
+	absolute bool
+	steps    []step
+}
+
+func compileXPath(expr string) (xpath, error) {
+	expr = strings.TrimSpace(expr)
+	if expr == "" {
+		return xpath{}, errors.New("empty path")
+	}
+	var x xpath
+	descendant := false
+	switch {
+	case strings.HasPrefix(expr, "//"):
+		x.absolute, descendant = true, true
+		expr = expr[2:]
+	case strings.HasPrefix(expr, "/"):
+		x.absolute = true
+		expr = expr[1:]
+	}
+
+	parts, err := splitSteps(expr)
+	if err != nil {
+		return xpath{}, err
+	}
+	for _, part := range parts {
+		if part == "" {
+			// An empty part comes from "//": the next step searches descendants.
+			if descendant {
+				return xpath{}, fmt.Errorf("invalid path %q", expr)
+			}
+			descendant = true
+			continue
+		}
+		s, err := compileStep(part)
+		if err != nil {
+			return xpath{}, err
+		}
+		s.descendant = descendant
+		descendant = false
+		x.steps = append(x.steps, s)
+	}
+	if descendant || len(x.steps) == 0 {
+		return xpath{}, errors.New("path must not end with /")
+	}
+	for i, s := range x.steps[:len(x.steps)-1] {
+		if s.kind == stepAttr || s.kind == stepText {
+			return xpath{}, fmt.Errorf("step %d: @attr and text() must be the last step", i+1)
+		}
+	}
+	return x, nil
+}
+
+// splitSteps splits a path on slashes outside predicates and quotes.
+func splitSteps(expr string) ([]string, error) {
+	var parts []string
+	depth, start := 0, 0
+	var quote rune
+	for i, c := range expr {
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"':
+			quote = c
+		case c == '[':
+			depth++
+		case c == ']':
+			depth--
+			if depth < 0 {
+				return nil, errors.New("unbalanced ]")
+			}
+		case c == '/' && depth == 0:
+			parts = append(parts, expr[start:i])
+			start = i + 1
+		}
+	}
+	if depth != 0 || quote != 0 {
+		return nil, errors.New("unterminated predicate")
+	}
+	return append(parts, expr[start:]), nil
+}
+
+func compileStep(part string) (step, error) {
+	test, rest, _ := strings.Cut(part, "[")
+	test = strings.TrimSpace(test)
+	var s step
+	switch {
+	case test == ".":
+		s.kind = stepSelf
+	case test == "..":
+		s.kind = stepParent
+	case test == "text()":
+		s.kind = stepText
+	case strings.HasPrefix(test, "@"):
+		s.kind, s.name = stepAttr, localName(test[1:])
+	default:
+		s.kind, s.name = stepElement, localName(test)
+	}
+	if (s.kind == stepElement || s.kind == stepAttr) && !validName(s.name) {
+		return step{}, fmt.Errorf("invalid step %q", part)
+	}
+
+	for rest != "" {
+		body, after, ok := strings.Cut(rest, "]")
+		if !ok {
+			return step{}, fmt.Errorf("invalid step %q", part)
+		}
+		pred, err := compilePredicate(body)
+		if err != nil {
+			return step{}, fmt.Errorf("step %q: %w", part, err)
+		}
+		s.predicates = append(s.predicates, pred)
+		rest = strings.TrimSpace(after)
+		if rest == "" {
+			break
+		}
+		if rest[0] != '[' {
+			return step{}, fmt.Errorf("invalid step %q", part)
+		}
+		rest = rest[1:]
+	}
+	if len(s.predicates) > 0 && s.kind != stepElement {
+		return step{}, fmt.Errorf("step %q: predicates are only supported on elements", part)
+	}
+	return s, nil
+}
+
+func compilePredicate(body string) (predicate, error) {
+	body = strings.TrimSpace(body)
+	if n, err := strconv.Atoi(body); err == nil {
+		if n < 1 {
+			return predicate{}, errors.New("positions start at 1")
+		}
+		return predicate{index: n}, nil
+	}
+
+	var p predicate
+	name, value, hasValue := strings.Cut(body, "=")
+	name = strings.TrimSpace(name)
+	if strings.HasPrefix(name, "@") {
+		p.attr = true
+		name = name[1:]
+	}
+	p.name = localName(name)
+	if !validName(p.name) || p.name == "*" {
+		return predicate{}, fmt.Errorf("unsupported predicate [%s]", body)
+	}
+	if hasValue {
+		value = strings.TrimSpace(value)
+		if len(value) < 2 || (value[0] != '\'' && value[0] != '"') || value[len(value)-1] != value[0] {
+			return predicate{}, fmt.Errorf("predicate value must be quoted in [%s]", body)
+		}
+		p.value, p.hasValue = value[1:len(value)-1], true
+	}
+	return p, nil
+}
+
+func localName(name string) string {
+	if i := strings.LastIndex(name, ":"); i >= 0 {
+		return name[i+1:]
+	}
+	return name
+}
+
+func validName(name string) bool {
+	if name == "*" {
+		return true
+	}
+	if name == "" {
+		return false
+	}
+	for _, c := range name {
+		if strings.ContainsRune(" /[]@='\"()", c) {
+			return false
+		}
+	}
+	return true
+}
+
+// eval returns the nodes the path selects from the context node. Absolute
+// paths start at the document node.
+func (x xpath) eval(ctx *xmlNode) []*xmlNode {
+	if x.absolute {
+		for ctx.parent != nil {
+			ctx = ctx.parent
+		}
+	}
+	nodes := []*xmlNode{ctx}
+	for _, s := range x.steps {
+		var next []*xmlNode
+		seen := make(map[*xmlNode]bool)
+		for _, n := range nodes {
+			contexts := []*xmlNode{n}
+			if s.descendant {
+				contexts = descendantsOrSelf(n)
+			}
+			for _, c := range contexts {
+				for _, m := range s.apply(c) {
+					if !seen[m] {
+						seen[m] = true
+						next = append(next, m)
+					}
+				}
+			}
+		}
+		nodes = next
+		if len(nodes) == 0 {
+			break
+		}
+	}
+	return nodes
+}
+
+// apply evaluates a single step against one context node.
+func (s step) apply(n *xmlNode) []*xmlNode {
+	switch s.kind {
+	case stepSelf:
+		return []*xmlNode{n}
+	case stepParent:
+		if n.parent == nil {
+			return nil
+		}
+		return []*xmlNode{n.parent}
+	case stepAttr:
+		var out []*xmlNode
+		for _, a := range n.attrs {
+			if s.name == "*" || a.Name.Local == s.name {
+				out = append(out, &xmlNode{text: a.Value, parent: n})
+			}
+		}
+		return out
+	case stepText:
+		var out []*xmlNode
+		for _, c := range n.children {
+			if c.name == "" && strings.TrimSpace(c.text) != "" {
+				out = append(out, c)
+			}
+		}
+		return out
+	}
+
+	var out []*xmlNode
+	for _, c := range n.children {
+		if c.name != "" && (s.name == "*" || c.name == s.name) {
+			out = append(out, c)
+		}
+	}
+	for _, p := range s.predicates {
+		out = p.filter(out)
+	}
+	return out
+}
+
+func (p predicate) filter(nodes []*xmlNode) []*xmlNode {
+	if p.index > 0 {
+		if p.index > len(nodes) {
+			return nil
+		}
+		return nodes[p.index-1 : p.index]
+	}
+	var out []*xmlNode
+	for _, n := range nodes {
+		if p.matches(n) {
+			out = append(out, n)
+		}
+	}
+	return out
+}
+
+func (p predicate) matches(n *xmlNode) bool {
+	if p.attr {
+		v, ok := n.attr(p.name)
+		return ok && (!p.hasValue || v == p.value)
+	}
+	for _, c := range n.children {
+		if c.name == p.name && (!p.hasValue || c.value() == p.value) {
+			return true
+		}
+	}
+	return false
+}
+
+func descendantsOrSelf(n *xmlNode) []*xmlNode {
+	out := []*xmlNode{n}
+	for _, c := range n.children {
+		if c.name != "" {
+			out = append(out, descendantsOrSelf(c)...)
+		}
+	}
+	return out
+}
@@ -0,0 +1,168 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/openoms-org/openoms/apps/api-server/internal/automation"
+	"github.com/openoms-org/openoms/apps/api-server/internal/database"
+	"github.com/openoms-org/openoms/apps/api-server/internal/model"
+	"github.com/openoms-org/openoms/apps/api-server/internal/repository"
+)
+
+const (
+	// automationEventBatch is the number of queued events claimed per run.
+	automationEventBatch = 100
+	// automationEventLockTimeout is how long a claimed event may stay in
+	// processing before another run reclaims it, e.g. after a crash.
+	automationEventLockTimeout = 10 * time.Minute
+	// automationEventRetention is how long processed events are kept.
+	automationEventRetention = 7 * 24 * time.Hour
+	// automationEventCleanupInterval is how often processed events are pruned.
+	automationEventCleanupInterval = time.Hour
+)
+
+// AutomationEventWorker consumes the durable automation event queue. Each
+// event runs its rules in the transaction that marks it done, so an event
+// whose processing fails, including a failed action of any of its rules, is
+// retried with backoff and, after
+// model.AutomationEventMaxAttempts attempts, moved to the dead-letter list.
+// Events of the same entity are processed in the order they were queued.
+type AutomationEventWorker struct {
+	pool        *pgxpool.Pool
+	eventRepo   repository.AutomationEventRepo
+	engine      *automation.Engine
+	logger      *slog.Logger
+	lastCleanup time.Time
+}
+
+func NewAutomationEventWorker(
+	pool *pgxpool.Pool,
+	eventRepo repository.AutomationEventRepo,
+	engine *automation.Engine,
+	logger *slog.Logger,
+) *AutomationEventWorker {
+	return &AutomationEventWorker{
+		pool:      pool,
+		eventRepo: eventRepo,
+		engine:    engine,
+		logger:    logger,
+	}
+}
+
+func (w *AutomationEventWorker) Name() string {
+	return "automation_events"
+}
+
+func (w *AutomationEventWorker) Interval() time.Duration {
+	return 5 * time.Second
+}
+
+func (w *AutomationEventWorker) Run(ctx context.Context) error {
+	now := time.Now()
+
+	// Claim due events directly (bypassing RLS for cross-tenant)
+	var events []model.AutomationEvent
+	err := func() error {
+		tx, err := w.pool.Begin(ctx)
+		if err != nil {
+			return err
+		}
+		defer tx.Rollback(ctx) //nolint:errcheck
+
+		events, err = w.eventRepo.ClaimDue(ctx, tx, now, now.Add(-automationEventLockTimeout), automationEventBatch)
+		if err != nil {
+			return err
+		}
+		return tx.Commit(ctx)
+	}()
+	if err != nil {
+		return err
+	}
+
+	for _, ev := range events {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		w.process(ctx, ev)
+	}
+
+	if now.Sub(w.lastCleanup) >= automationEventCleanupInterval {
+		w.lastCleanup = now
+		w.cleanup(ctx, now)
+	}
+	return nil
+}
+
+func (w *AutomationEventWorker) process(ctx context.Context, ev model.AutomationEvent) {
+	event := automation.Event{
+		ID:         ev.ID,
+		Type:       ev.EventType,
+		TenantID:   ev.TenantID,
+		EntityType: ev.EntityType,
+		EntityID:   ev.EntityID,
+	}
+	if ev.RuleID != nil {
+		event.RuleID = *ev.RuleID
+	}
+	err := json.Unmarshal(ev.Data, &event.Data)
+	if err == nil {
+		err = database.WithTenant(ctx, w.pool, ev.TenantID, func(tx pgx.Tx) error {
+			if err := w.engine.HandleEvent(ctx, tx, event); err != nil {
+				return err
+			}
+			return w.eventRepo.MarkDone(ctx, tx, ev.ID)
+		})
+	}
+	if err == nil {
+		return
+	}
+
+	status := model.AutomationEventPending
+	nextAttempt := time.Now().Add(model.AutomationEventRetryDelay(ev.Attempts))
+	if ev.Attempts >= model.AutomationEventMaxAttempts {
+		status = model.AutomationEventDead
+	}
+	w.logger.Error("automation event worker: event processing failed",
+		"tenant_id", ev.TenantID,
+		"event_id", ev.ID,
+		"event_type", ev.EventType,
+		"attempts", ev.Attempts,
+		"status", status,
+		"error", err,
+	)
+
+	markErr := database.WithTenant(ctx, w.pool, ev.TenantID, func(tx pgx.Tx) error {
+		return w.eventRepo.MarkFailed(ctx, tx, ev.ID, status, nextAttempt, err.Error())
+	})
+	if markErr != nil {
+		w.logger.Error("automation event worker: failed to record event failure",
+			"event_id", ev.ID,
+			"error", markErr,
+		)
+	}
+}
+
+func (w *AutomationEventWorker) cleanup(ctx context.Context, now time.Time) {
+	// Prune processed events directly (bypassing RLS for cross-tenant)
+	err := func() error {
+		tx, err := w.pool.Begin(ctx)
+		if err != nil {
+			return err
+		}
+		defer tx.Rollback(ctx) //nolint:errcheck
+
+		if _, err := w.eventRepo.DeleteDoneBefore(ctx, tx, now.Add(-automationEventRetention)); err != nil {
+			return err
+		}
+		return tx.Commit(ctx)
+	}()
+	if err != nil {
+		w.logger.Error("automation event worker: failed to prune processed events", "error", err)
+	}
+}
@@ -0,0 +1,190 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/openoms-org/openoms/apps/api-server/internal/database"
+	"github.com/openoms-org/openoms/apps/api-server/internal/model"
+	"github.com/openoms-org/openoms/apps/api-server/internal/repository"
+)
+
+const (
+	// automationScheduleBatch is the number of due schedule rules claimed per run.
+	automationScheduleBatch = 50
+	// automationStatusEntityBatch is the number of entities a status duration
+	// rule fires for per run; the rest are picked up on the next runs.
+	automationStatusEntityBatch = 100
+)
+
+// AutomationScheduleWorker fires time-based automation rules: schedule rules
+// whose cron schedule is due, and status duration rules for entities that
+// have stayed in a status long enough. A schedule rule is moved to its next
+// run, and an entity is recorded as fired, in the transaction that enqueues
+// the rule's event, so a rule fires once per scheduled run and once per
+// entity. AutomationEventWorker runs the rule, with the queue's retries and
+// dead-letter handling.
+type AutomationScheduleWorker struct {
+	pool       *pgxpool.Pool
+	ruleRepo   repository.AutomationRuleRepo
+	firingRepo repository.AutomationFiringRepo
+	eventRepo  repository.AutomationEventRepo
+	logger     *slog.Logger
+}
+
+func NewAutomationScheduleWorker(
+	pool *pgxpool.Pool,
+	ruleRepo repository.AutomationRuleRepo,
+	firingRepo repository.AutomationFiringRepo,
+	eventRepo repository.AutomationEventRepo,
+	logger *slog.Logger,
+) *AutomationScheduleWorker {
+	return &AutomationScheduleWorker{
+		pool:       pool,
+		ruleRepo:   ruleRepo,
+		firingRepo: firingRepo,
+		eventRepo:  eventRepo,
+		logger:     logger,
+	}
+}
+
+func (w *AutomationScheduleWorker) Name() string {
+	return "automation_schedule"
+}
+
+func (w *AutomationScheduleWorker) Interval() time.Duration {
+	return time.Minute
+}
+
+func (w *AutomationScheduleWorker) Run(ctx context.Context) error {
+	now := time.Now()
+	if err := w.runScheduled(ctx, now); err != nil {
+		return err
+	}
+	return w.runStatusDurations(ctx, now)
+}
+
+func (w *AutomationScheduleWorker) runScheduled(ctx context.Context, now time.Time) error {
+	// Claim due rules directly (bypassing RLS for cross-tenant)
+	tx, err := w.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	rules, err := w.ruleRepo.ListDueScheduled(ctx, tx, now, automationScheduleBatch)
+	if err != nil {
+		return err
+	}
+	for _, rule := range rules {
+		cfg, err := model.ParseTriggerConfig(rule.TriggerConfig)
+		var next *time.Time
+		if err == nil {
+			next, err = cfg.NextRun(now)
+		}
+		if err != nil {
+			w.logger.Warn("automation rule has an invalid schedule", "tenant_id", rule.TenantID, "rule_id", rule.ID, "error", err)
+		}
+		if err := w.ruleRepo.SetNextRun(ctx, tx, rule.ID, next); err != nil {
+			return err
+		}
+
+		scheduledAt := now
+		if rule.NextRunAt != nil {
+			scheduledAt = *rule.NextRunAt
+		}
+		if err := w.enqueue(ctx, tx, rule, "rule", rule.ID, map[string]any{
+			"rule_id":      rule.ID.String(),
+			"scheduled_at": scheduledAt.Format(time.RFC3339),
+			"fired_at":     now.Format(time.RFC3339),
+		}); err != nil {
+			return err
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+func (w *AutomationScheduleWorker) runStatusDurations(ctx context.Context, now time.Time) error {
+	// List rules directly (bypassing RLS for cross-tenant)
+	var rules []model.AutomationRule
+	err := func() error {
+		tx, err := w.pool.Begin(ctx)
+		if err != nil {
+			return err
+		}
+		defer tx.Rollback(ctx) //nolint:errcheck
+
+		rules, err = w.ruleRepo.ListStatusDurationRules(ctx, tx)
+		if err != nil {
+			return err
+		}
+		return tx.Commit(ctx)
+	}()
+	if err != nil {
+		return err
+	}
+
+	for _, rule := range rules {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		w.runStatusDurationRule(ctx, rule, now)
+	}
+	return nil
+}
+
+func (w *AutomationScheduleWorker) runStatusDurationRule(ctx context.Context, rule model.AutomationRule, now time.Time) {
+	entityType := model.StatusDurationEntities[rule.TriggerEvent]
+	cfg, err := model.ParseTriggerConfig(rule.TriggerConfig)
+	if err == nil {
+		err = cfg.Validate(rule.TriggerEvent)
+	}
+	if err != nil {
+		w.logger.Warn("automation rule has an invalid status duration", "tenant_id", rule.TenantID, "rule_id", rule.ID, "error", err)
+		return
+	}
+	duration, _ := model.ParseAge(cfg.Duration)
+
+	err = database.WithTenant(ctx, w.pool, rule.TenantID, func(tx pgx.Tx) error {
+		entities, err := w.firingRepo.ClaimStatusEntities(ctx, tx, rule, entityType, cfg.Status, now.Add(-duration), automationStatusEntityBatch)
+		if err != nil {
+			return err
+		}
+		for _, entity := range entities {
+			data := entity.Data
+			if data == nil {
+				data = map[string]any{}
+			}
+			data["hours_in_status"] = now.Sub(entity.StatusChangedAt).Hours()
+			if err := w.enqueue(ctx, tx, rule, entityType, entity.EntityID, data); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		w.logger.Error("status duration automation rule failed", "tenant_id", rule.TenantID, "rule_id", rule.ID, "error", err)
+	}
+}
+
+// enqueue queues the event of a time-based rule for the rule alone.
+func (w *AutomationScheduleWorker) enqueue(ctx context.Context, tx pgx.Tx, rule model.AutomationRule, entityType string, entityID uuid.UUID, data map[string]any) error {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	return w.eventRepo.Enqueue(ctx, tx, &model.AutomationEvent{
+		TenantID:   rule.TenantID,
+		EventType:  rule.TriggerEvent,
+		EntityType: entityType,
+		EntityID:   entityID,
+		RuleID:     &rule.ID,
+		Data:       raw,
+	})
+}
@@ -0,0 +1,73 @@
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/openoms-org/openoms/apps/api-server/internal/model"
+	"github.com/openoms-org/openoms/apps/api-server/internal/repository"
+	"github.com/openoms-org/openoms/apps/api-server/internal/service"
+)
+
+// bulkJobBatch is the number of queued bulk jobs claimed per run.
+const bulkJobBatch = 2
+
+// BulkJobWorker runs bulk order jobs queued through the API. It polls often
+// because users wait for the result.
+type BulkJobWorker struct {
+	pool           *pgxpool.Pool
+	syncJobRepo    repository.SyncJobRepo
+	bulkJobService *service.BulkJobService
+	logger         *slog.Logger
+}
+
+func NewBulkJobWorker(pool *pgxpool.Pool, syncJobRepo repository.SyncJobRepo, bulkJobService *service.BulkJobService, logger *slog.Logger) *BulkJobWorker {
+	return &BulkJobWorker{
+		pool:           pool,
+		syncJobRepo:    syncJobRepo,
+		bulkJobService: bulkJobService,
+		logger:         logger,
+	}
+}
+
+func (w *BulkJobWorker) Name() string {
+	return "bulk_job"
+}
+
+func (w *BulkJobWorker) Interval() time.Duration {
+	return 5 * time.Second
+}
+
+func (w *BulkJobWorker) Run(ctx context.Context) error {
+	// Claim queued jobs directly (bypassing RLS for cross-tenant)
+	var jobs []*model.SyncJob
+	err := func() error {
+		tx, err := w.pool.Begin(ctx)
+		if err != nil {
+			return err
+		}
+		defer tx.Rollback(ctx) //nolint:errcheck
+
+		jobs, err = w.syncJobRepo.ClaimPending(ctx, tx, model.SyncJobTypeBulkOrders, bulkJobBatch)
+		if err != nil {
+			return err
+		}
+		return tx.Commit(ctx)
+	}()
+	if err != nil {
+		return err
+	}
+
+	for _, job := range jobs {
+		w.logger.Info("bulk job started", "tenant_id", job.TenantID, "sync_job_id", job.ID)
+		if err := w.bulkJobService.Run(ctx, job); err != nil {
+			w.logger.Error("bulk job failed", "tenant_id", job.TenantID, "sync_job_id", job.ID, "error", err)
+			continue
+		}
+		w.logger.Info("bulk job completed", "tenant_id", job.TenantID, "sync_job_id", job.ID)
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/openoms-org/openoms/apps/api-server/internal/model"
+	"github.com/openoms-org/openoms/apps/api-server/internal/repository"
+	"github.com/openoms-org/openoms/apps/api-server/internal/service"
+)
+
+// catalogImportBatch is the number of queued imports claimed per run.
+const catalogImportBatch = 5
+
+// CatalogImportWorker runs catalog import jobs queued through the API.
+type CatalogImportWorker struct {
+	pool                 *pgxpool.Pool
+	syncJobRepo          repository.SyncJobRepo
+	catalogImportService *service.CatalogImportService
+	logger               *slog.Logger
+}
+
+func NewCatalogImportWorker(pool *pgxpool.Pool, syncJobRepo repository.SyncJobRepo, catalogImportService *service.CatalogImportService, logger *slog.Logger) *CatalogImportWorker {
+	return &CatalogImportWorker{
+		pool:                 pool,
+		syncJobRepo:          syncJobRepo,
+		catalogImportService: catalogImportService,
+		logger:               logger,
+	}
+}
+
+func (w *CatalogImportWorker) Name() string {
+	return "catalog_import"
+}
+
+func (w *CatalogImportWorker) Interval() time.Duration {
+	return 30 * time.Second
+}
+
+func (w *CatalogImportWorker) Run(ctx context.Context) error {
+	// Claim queued jobs directly (bypassing RLS for cross-tenant)
+	var jobs []*model.SyncJob
+	err := func() error {
+		tx, err := w.pool.Begin(ctx)
+		if err != nil {
+			return err
+		}
+		defer tx.Rollback(ctx) //nolint:errcheck
+
+		jobs, err = w.syncJobRepo.ClaimPending(ctx, tx, model.SyncJobTypeCatalogImport, catalogImportBatch)
+		if err != nil {
+			return err
+		}
+		return tx.Commit(ctx)
+	}()
+	if err != nil {
+		return err
+	}
+
+	for _, job := range jobs {
+		w.logger.Info("catalog import started", "tenant_id", job.TenantID, "integration_id", job.IntegrationID, "sync_job_id", job.ID)
+		if err := w.catalogImportService.Run(ctx, job); err != nil {
+			w.logger.Error("catalog import failed", "tenant_id", job.TenantID, "sync_job_id", job.ID, "error", err)
+			continue
+		}
+		w.logger.Info("catalog import completed", "tenant_id", job.TenantID, "sync_job_id", job.ID)
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/openoms-org/openoms/apps/api-server/internal/service"
+)
+
+// CorrectionInvoiceWorker issues the return correction invoices left as
+// drafts after their refund, retrying until the invoicing provider accepts
+// them.
+type CorrectionInvoiceWorker struct {
+	pool           *pgxpool.Pool
+	invoiceService *service.InvoiceService
+	logger         *slog.Logger
+}
+
+// NewCorrectionInvoiceWorker creates a new correction invoice worker.
+func NewCorrectionInvoiceWorker(pool *pgxpool.Pool, invoiceService *service.InvoiceService, logger *slog.Logger) *CorrectionInvoiceWorker {
+	return &CorrectionInvoiceWorker{
+		pool:           pool,
+		invoiceService: invoiceService,
+		logger:         logger,
+	}
+}
+
+func (w *CorrectionInvoiceWorker) Name() string {
+	return "correction_invoice_worker"
+}
+
+func (w *CorrectionInvoiceWorker) Interval() time.Duration {
+	return 5 * time.Minute
+}
+
+func (w *CorrectionInvoiceWorker) Run(ctx context.Context) error {
+	// Only tenants with a pending draft correction; invoices are read
+	// without RLS here, as in the other cross-tenant workers.
+	rows, err := w.pool.Query(ctx,
+		`SELECT DISTINCT tenant_id FROM invoices
+		 WHERE status = 'draft' AND invoice_type = 'correction' AND metadata ? 'correction_request'`,
+	)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var tenantIDs []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return err
+		}
+		tenantIDs = append(tenantIDs, id)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	totalIssued := 0
+	for _, tenantID := range tenantIDs {
+		issued, err := w.invoiceService.IssuePendingCorrections(ctx, tenantID)
+		if err != nil {
+			w.logger.Error("correction invoice worker: issue pending corrections", "tenant_id", tenantID, "error", err)
+		}
+		totalIssued += issued
+	}
+
+	if totalIssued > 0 {
+		w.logger.Info("correction invoice worker completed", "tenants", len(tenantIDs), "issued", totalIssued)
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/openoms-org/openoms/apps/api-server/internal/service"
+)
+
+// DropshipWorker routes newly confirmed orders to suppliers and retries
+// supplier orders that could not be delivered.
+type DropshipWorker struct {
+	pool            *pgxpool.Pool
+	dropshipService *service.DropshipService
+	logger          *slog.Logger
+}
+
+func NewDropshipWorker(pool *pgxpool.Pool, dropshipService *service.DropshipService, logger *slog.Logger) *DropshipWorker {
+	return &DropshipWorker{
+		pool:            pool,
+		dropshipService: dropshipService,
+		logger:          logger,
+	}
+}
+
+func (w *DropshipWorker) Name() string {
+	return "dropship"
+}
+
+func (w *DropshipWorker) Interval() time.Duration {
+	return 1 * time.Minute
+}
+
+func (w *DropshipWorker) Run(ctx context.Context) error {
+	// Tenants with unrouted orders or undelivered supplier orders (bypasses RLS)
+	rows, err := w.pool.Query(ctx,
+		`SELECT tenant_id FROM orders WHERE fulfillment_routed_at IS NULL
+		 UNION
+		 SELECT tenant_id FROM supplier_orders WHERE status IN ('pending', 'failed') AND attempts < 5`,
+	)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var tenantIDs []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return err
+		}
+		tenantIDs = append(tenantIDs, id)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	totalRouted, totalSent := 0, 0
+	for _, tenantID := range tenantIDs {
+		routed, sent, err := w.dropshipService.ProcessTenant(ctx, tenantID)
+		if err != nil {
+			w.logger.Error("worker: dropship failed",
+				"operation", "dropship.process",
+				"tenant_id", tenantID,
+				"error", err,
+			)
+			continue
+		}
+		totalRouted += routed
+		totalSent += sent
+	}
+
+	if totalRouted > 0 || totalSent > 0 {
+		w.logger.Info("dropship: run complete", "routed_orders", totalRouted, "sent_supplier_orders", totalSent)
+	}
+	return nil
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/openoms-org/openoms/apps/api-server/internal/integration"
 	"github.com/openoms-org/openoms/apps/api-server/internal/model"
 	"github.com/openoms-org/openoms/apps/api-server/internal/repository"
+	"github.com/openoms-org/openoms/apps/api-server/internal/service"
 )
 
 // OrderMapper converts a MarketplaceOrder into a model.Order for a specific provider.
@@ -35,6 +36,14 @@ type MarketplaceOrderPoller struct {
 	// mapOrder allows provider-specific customization of the order mapping.
 	// If nil, a default mapping is used.
 	mapOrder OrderMapper
+	// variantRepo, when set, links items carrying a VariantExternalID to
+	// product variants by SKU or EAN before the order is stored.
+	variantRepo repository.VariantRepo
+	// orderEventRepo, when set, records each import on the order timeline.
+	orderEventRepo repository.OrderEventRepo
+	// holdService, when set, runs the import checks that put new orders on
+	// hold.
+	holdService *service.OrderHoldService
 }
 
 // MarketplaceOrderPollerConfig configures a MarketplaceOrderPoller.
@@ -48,6 +57,7 @@ type MarketplaceOrderPollerConfig struct {
 	ProviderName  string
 	Interval      time.Duration
 	MapOrder      OrderMapper
+	VariantRepo   repository.VariantRepo
 }
 
 func NewMarketplaceOrderPoller(cfg MarketplaceOrderPollerConfig) *MarketplaceOrderPoller {
@@ -61,9 +71,22 @@ func NewMarketplaceOrderPoller(cfg MarketplaceOrderPollerConfig) *MarketplaceOrd
 		providerName:  cfg.ProviderName,
 		interval:      cfg.Interval,
 		mapOrder:      cfg.MapOrder,
+		variantRepo:   cfg.VariantRepo,
 	}
 }
 
+// SetOrderEventRepo sets the repository that records imported orders on the
+// order timeline.
+func (p *MarketplaceOrderPoller) SetOrderEventRepo(orderEventRepo repository.OrderEventRepo) {
+	p.orderEventRepo = orderEventRepo
+}
+
+// SetOrderHoldService sets the service that runs the import checks on new
+// orders.
+func (p *MarketplaceOrderPoller) SetOrderHoldService(holdService *service.OrderHoldService) {
+	p.holdService = holdService
+}
+
 func (p *MarketplaceOrderPoller) Name() string {
 	return p.providerName + "_order_poller"
 }
@@ -107,6 +130,7 @@ func (p *MarketplaceOrderPoller) Run(ctx context.Context) error {
 		for _, mo := range orders {
 			req := integration.MarketplaceOrderToCreateRequest(mo, p.providerName, ti.IntegrationID)
 			order := p.buildOrder(mo, ti, req)
+			var holds []model.OrderHold
 
 			if err := database.WithTenant(ctx, p.pool, ti.TenantID, func(tx pgx.Tx) error {
 				existing, err := p.orderRepo.FindByExternalID(ctx, tx, p.providerName, mo.ExternalID)
@@ -117,9 +141,35 @@ func (p *MarketplaceOrderPoller) Run(ctx context.Context) error {
 					return nil // duplicate, skip
 				}
 
+				if p.variantRepo != nil {
+					if err := p.linkVariants(ctx, tx, &order, mo.Items); err != nil {
+						return err
+					}
+				}
+
 				if err := p.orderRepo.Create(ctx, tx, &order); err != nil {
 					return err
 				}
+				if p.orderEventRepo != nil {
+					if err := p.orderEventRepo.Create(ctx, tx, &model.OrderEvent{
+						TenantID:  ti.TenantID,
+						OrderID:   order.ID,
+						Source:    model.TimelineSourceMarketplace,
+						EventType: model.OrderEventMarketplaceImport,
+						Data: map[string]string{
+							"provider":       p.providerName,
+							"external_id":    mo.ExternalID,
+							"integration_id": ti.IntegrationID.String(),
+						},
+					}); err != nil {
+						return err
+					}
+				}
+				if p.holdService != nil {
+					if holds, err = p.holdService.CheckNewOrder(ctx, tx, &order); err != nil {
+						return err
+					}
+				}
 
 				p.logger.Info("worker: order created",
 					"operation", "order.create",
@@ -135,6 +185,9 @@ func (p *MarketplaceOrderPoller) Run(ctx context.Context) error {
 				continue
 			}
 			totalOrders++
+			if p.holdService != nil {
+				p.holdService.NotifyHolds(ti.TenantID, holds)
+			}
 
 			// Auto-create shipment based on integration carrier mapping (best effort)
 			if p.shipmentRepo != nil {
@@ -246,6 +299,50 @@ func (p *MarketplaceOrderPoller) buildOrder(mo integration.MarketplaceOrder, ti
 	return order
 }
 
+// linkVariants resolves order items of variant listings (those with a
+// VariantExternalID) to local product variants, matching by SKU first and EAN
+// second, and rewrites the order items with the resolved IDs.
+func (p *MarketplaceOrderPoller) linkVariants(ctx context.Context, tx pgx.Tx, order *model.Order, items []integration.MarketplaceOrderItem) error {
+	linked := false
+	for i := range items {
+		item := &items[i]
+		if item.VariantExternalID == "" || item.VariantID != nil {
+			continue
+		}
+
+		var matches []model.ProductVariant
+		var err error
+		if item.SKU != "" {
+			if matches, err = p.variantRepo.FindBySKU(ctx, tx, item.SKU); err != nil {
+				return err
+			}
+		}
+		if len(matches) == 0 && item.EAN != "" {
+			if matches, err = p.variantRepo.FindByEAN(ctx, tx, item.EAN); err != nil {
+				return err
+			}
+		}
+		if len(matches) == 0 {
+			continue
+		}
+
+		variantID, productID := matches[0].ID, matches[0].ProductID
+		item.VariantID = &variantID
+		item.ProductID = &productID
+		linked = true
+	}
+
+	if !linked {
+		return nil
+	}
+	itemsJSON, err := json.Marshal(items)
+	if err != nil {
+		return err
+	}
+	order.Items = itemsJSON
+	return nil
+}
+
 // resolveCarrier looks up the delivery method in carrier_mapping, falling back to default_carrier.
 func resolveCarrier(settings json.RawMessage, deliveryMethod string) string {
 	if len(settings) == 0 {
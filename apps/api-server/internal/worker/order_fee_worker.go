@@ -0,0 +1,154 @@
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/openoms-org/openoms/apps/api-server/internal/crypto"
+	"github.com/openoms-org/openoms/apps/api-server/internal/database"
+	"github.com/openoms-org/openoms/apps/api-server/internal/integration"
+)
+
+const (
+	// orderFeeWindow is how long after an order its fees are still refreshed;
+	// marketplaces keep adjusting fees (refunds, late promotions) for weeks.
+	orderFeeWindow = 60 * 24 * time.Hour
+	// orderFeeRefresh is the minimum time between two fee syncs of an order.
+	orderFeeRefresh = 24 * time.Hour
+	// orderFeeBatch bounds the orders synced per integration and run.
+	orderFeeBatch = 50
+)
+
+// orderFeeTarget is a marketplace order whose fees are fetched.
+type orderFeeTarget struct {
+	ID         uuid.UUID
+	ExternalID string
+	Fee        float64
+	Err        error
+}
+
+// OrderFeeWorker stores the fees billed by marketplaces per order in
+// orders.marketplace_fee, for providers implementing integration.OrderFeeFetcher.
+type OrderFeeWorker struct {
+	pool          *pgxpool.Pool
+	encryptionKey []byte
+	logger        *slog.Logger
+}
+
+func NewOrderFeeWorker(pool *pgxpool.Pool, encryptionKey []byte, logger *slog.Logger) *OrderFeeWorker {
+	return &OrderFeeWorker{
+		pool:          pool,
+		encryptionKey: encryptionKey,
+		logger:        logger,
+	}
+}
+
+func (w *OrderFeeWorker) Name() string {
+	return "order_fee_sync"
+}
+
+func (w *OrderFeeWorker) Interval() time.Duration {
+	return 1 * time.Hour
+}
+
+func (w *OrderFeeWorker) Run(ctx context.Context) error {
+	tis, err := ListAllActiveMarketplaceIntegrations(ctx, w.pool)
+	if err != nil {
+		return err
+	}
+
+	totalSynced := 0
+
+	for _, ti := range tis {
+		credJSON, err := crypto.Decrypt(ti.Credentials, w.encryptionKey)
+		if err != nil {
+			w.logger.Error("order fee sync: failed to decrypt credentials", "integration_id", ti.IntegrationID, "error", err)
+			continue
+		}
+
+		provider, err := integration.NewMarketplaceProvider(ti.Provider, credJSON, ti.Settings)
+		if err != nil {
+			w.logger.Error("order fee sync: failed to create provider", "integration_id", ti.IntegrationID, "error", err)
+			continue
+		}
+		fetcher, ok := provider.(integration.OrderFeeFetcher)
+		if !ok {
+			continue
+		}
+
+		synced, err := w.syncIntegration(ctx, ti, fetcher)
+		if err != nil {
+			w.logger.Error("order fee sync: tenant error", "tenant_id", ti.TenantID, "integration_id", ti.IntegrationID, "error", err)
+		}
+		totalSynced += synced
+	}
+
+	w.logger.Info("order fee sync completed", "integrations", len(tis), "synced", totalSynced)
+	return nil
+}
+
+// syncIntegration fetches the fees of the integration's recent orders that
+// were never synced or not within the last day, oldest sync first.
+func (w *OrderFeeWorker) syncIntegration(ctx context.Context, ti TenantIntegration, fetcher integration.OrderFeeFetcher) (int, error) {
+	var targets []orderFeeTarget
+	if err := database.WithTenant(ctx, w.pool, ti.TenantID, func(tx pgx.Tx) error {
+		rows, err := tx.Query(ctx,
+			`SELECT id, external_id FROM orders
+			 WHERE integration_id = $1 AND external_id IS NOT NULL AND merged_into IS NULL
+			   AND created_at > $2 AND (fees_synced_at IS NULL OR fees_synced_at < $3)
+			 ORDER BY fees_synced_at NULLS FIRST, created_at
+			 LIMIT $4`,
+			ti.IntegrationID, time.Now().Add(-orderFeeWindow), time.Now().Add(-orderFeeRefresh), orderFeeBatch,
+		)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var t orderFeeTarget
+			if err := rows.Scan(&t.ID, &t.ExternalID); err != nil {
+				return err
+			}
+			targets = append(targets, t)
+		}
+		return rows.Err()
+	}); err != nil {
+		return 0, err
+	}
+	if len(targets) == 0 {
+		return 0, nil
+	}
+
+	for i := range targets {
+		targets[i].Fee, targets[i].Err = fetcher.OrderFees(ctx, targets[i].ExternalID)
+	}
+
+	synced := 0
+	err := database.WithTenant(ctx, w.pool, ti.TenantID, func(tx pgx.Tx) error {
+		for _, t := range targets {
+			if t.Err != nil {
+				w.logger.Error("order fee sync: fetch fees failed",
+					"operation", "order.fee_sync",
+					"tenant_id", ti.TenantID,
+					"entity_id", t.ID,
+					"error", t.Err,
+				)
+				continue
+			}
+			if _, err := tx.Exec(ctx,
+				`UPDATE orders SET marketplace_fee = $2, fees_synced_at = NOW() WHERE id = $1`,
+				t.ID, t.Fee,
+			); err != nil {
+				return err
+			}
+			synced++
+		}
+		return nil
+	})
+	return synced, err
+}
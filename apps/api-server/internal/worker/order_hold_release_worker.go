@@ -0,0 +1,79 @@
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/openoms-org/openoms/apps/api-server/internal/service"
+)
+
+// OrderHoldReleaseWorker releases holds awaiting payment or stock once the
+// order is paid or its lines are in stock.
+type OrderHoldReleaseWorker struct {
+	pool        *pgxpool.Pool
+	holdService *service.OrderHoldService
+	logger      *slog.Logger
+}
+
+func NewOrderHoldReleaseWorker(pool *pgxpool.Pool, holdService *service.OrderHoldService, logger *slog.Logger) *OrderHoldReleaseWorker {
+	return &OrderHoldReleaseWorker{
+		pool:        pool,
+		holdService: holdService,
+		logger:      logger,
+	}
+}
+
+func (w *OrderHoldReleaseWorker) Name() string {
+	return "order_hold_release"
+}
+
+func (w *OrderHoldReleaseWorker) Interval() time.Duration {
+	return 5 * time.Minute
+}
+
+func (w *OrderHoldReleaseWorker) Run(ctx context.Context) error {
+	// Tenants with holds released automatically (bypasses RLS)
+	rows, err := w.pool.Query(ctx,
+		`SELECT DISTINCT tenant_id FROM order_holds
+		 WHERE released_at IS NULL AND reason IN ('awaiting_payment', 'out_of_stock')`,
+	)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var tenantIDs []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return err
+		}
+		tenantIDs = append(tenantIDs, id)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	totalReleased := 0
+	for _, tenantID := range tenantIDs {
+		released, err := w.holdService.ReleaseCleared(ctx, tenantID)
+		if err != nil {
+			w.logger.Error("worker: order hold release failed",
+				"operation", "order_hold.release",
+				"tenant_id", tenantID,
+				"error", err,
+			)
+			continue
+		}
+		totalReleased += released
+	}
+
+	if totalReleased > 0 {
+		w.logger.Info("order hold release: run complete", "released_holds", totalReleased)
+	}
+	return nil
+}
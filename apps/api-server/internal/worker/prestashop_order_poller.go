@@ -0,0 +1,84 @@
+package worker
+
+import (
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/openoms-org/openoms/apps/api-server/internal/integration"
+	"github.com/openoms-org/openoms/apps/api-server/internal/model"
+	"github.com/openoms-org/openoms/apps/api-server/internal/repository"
+)
+
+func NewPrestaShopOrderPoller(pool *pgxpool.Pool, encryptionKey []byte, orderRepo repository.OrderRepo, shipmentRepo repository.ShipmentRepo, auditRepo repository.AuditRepo, variantRepo repository.VariantRepo, logger *slog.Logger) *MarketplaceOrderPoller {
+	return NewMarketplaceOrderPoller(MarketplaceOrderPollerConfig{
+		Pool:          pool,
+		EncryptionKey: encryptionKey,
+		OrderRepo:     orderRepo,
+		ShipmentRepo:  shipmentRepo,
+		AuditRepo:     auditRepo,
+		Logger:        logger,
+		ProviderName:  "prestashop",
+		Interval:      60 * time.Second,
+		MapOrder:      prestashopOrderMapper,
+		VariantRepo:   variantRepo,
+	})
+}
+
+func prestashopOrderMapper(mo integration.MarketplaceOrder, ti TenantIntegration, req model.CreateOrderRequest) model.Order {
+	order := model.Order{
+		ID:            uuid.New(),
+		TenantID:      ti.TenantID,
+		ExternalID:    req.ExternalID,
+		Source:        req.Source,
+		IntegrationID: req.IntegrationID,
+		Status:        "new",
+		CustomerName:  req.CustomerName,
+		CustomerEmail: req.CustomerEmail,
+		CustomerPhone: req.CustomerPhone,
+		TotalAmount:   req.TotalAmount,
+		Currency:      req.Currency,
+		OrderedAt:     req.OrderedAt,
+		PaymentMethod: req.PaymentMethod,
+	}
+
+	if req.PaymentStatus != nil {
+		order.PaymentStatus = *req.PaymentStatus
+	} else {
+		order.PaymentStatus = "pending"
+	}
+
+	addrJSON, err := json.Marshal(mo.ShippingAddress)
+	if err == nil {
+		order.ShippingAddress = addrJSON
+	}
+	if mo.BillingAddress != nil {
+		if billingJSON, err := json.Marshal(mo.BillingAddress); err == nil {
+			order.BillingAddress = billingJSON
+		}
+	}
+
+	itemsJSON, err := json.Marshal(mo.Items)
+	if err == nil {
+		order.Items = itemsJSON
+	}
+
+	// PrestaShop-specific: keep the shop's order ID and reference
+	metadata := map[string]any{"external_id": mo.ExternalID}
+	if mo.RawData != nil {
+		if psID, ok := mo.RawData["prestashop_order_id"]; ok {
+			metadata["prestashop_order_id"] = psID
+		}
+		if ref, ok := mo.RawData["reference"]; ok {
+			metadata["prestashop_reference"] = ref
+		}
+	}
+	metadataJSON, _ := json.Marshal(metadata)
+	order.Metadata = metadataJSON
+	order.Tags = []string{}
+
+	return order
+}
@@ -0,0 +1,242 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/openoms-org/openoms/apps/api-server/internal/crypto"
+	"github.com/openoms-org/openoms/apps/api-server/internal/database"
+	"github.com/openoms-org/openoms/apps/api-server/internal/integration"
+	"github.com/openoms-org/openoms/apps/api-server/internal/model"
+	"github.com/openoms-org/openoms/apps/api-server/internal/service"
+)
+
+// priceSyncListing is an active listing whose price is pushed to the marketplace.
+// BasePrice is the variant price override or the product price, in PLN.
+type priceSyncListing struct {
+	ID              uuid.UUID
+	ProductID       uuid.UUID
+	VariantID       *uuid.UUID
+	ExternalID      string
+	PriceOverride   *float64
+	BasePrice       float64
+	LastSyncedPrice *float64
+}
+
+// priceSyncResult is the outcome of pushing one listing price.
+type priceSyncResult struct {
+	ListingID uuid.UUID
+	Price     float64
+	Err       error
+}
+
+// PriceSyncWorker pushes listing prices computed from the per-integration
+// pricing rule (settings "pricing") to the marketplaces. A listing's
+// price_override is pushed as-is; prices are only sent when they changed
+// since the last sync.
+type PriceSyncWorker struct {
+	pool                *pgxpool.Pool
+	encryptionKey       []byte
+	priceListService    *service.PriceListService
+	exchangeRateService *service.ExchangeRateService
+	logger              *slog.Logger
+}
+
+func NewPriceSyncWorker(pool *pgxpool.Pool, encryptionKey []byte, priceListService *service.PriceListService, exchangeRateService *service.ExchangeRateService, logger *slog.Logger) *PriceSyncWorker {
+	return &PriceSyncWorker{
+		pool:                pool,
+		encryptionKey:       encryptionKey,
+		priceListService:    priceListService,
+		exchangeRateService: exchangeRateService,
+		logger:              logger,
+	}
+}
+
+func (w *PriceSyncWorker) Name() string {
+	return "price_sync"
+}
+
+func (w *PriceSyncWorker) Interval() time.Duration {
+	return 15 * time.Minute
+}
+
+func (w *PriceSyncWorker) Run(ctx context.Context) error {
+	tis, err := ListAllActiveMarketplaceIntegrations(ctx, w.pool)
+	if err != nil {
+		return err
+	}
+
+	totalSynced := 0
+
+	for _, ti := range tis {
+		rule, err := model.ParseChannelPricingRule(ti.Settings)
+		if err != nil {
+			w.logger.Error("price sync: invalid pricing settings", "integration_id", ti.IntegrationID, "error", err)
+			continue
+		}
+		if rule == nil || !rule.Enabled {
+			continue
+		}
+
+		credJSON, err := crypto.Decrypt(ti.Credentials, w.encryptionKey)
+		if err != nil {
+			w.logger.Error("price sync: failed to decrypt credentials", "integration_id", ti.IntegrationID, "error", err)
+			continue
+		}
+
+		provider, err := integration.NewMarketplaceProvider(ti.Provider, credJSON, ti.Settings)
+		if err != nil {
+			w.logger.Error("price sync: failed to create provider", "integration_id", ti.IntegrationID, "error", err)
+			continue
+		}
+
+		synced, err := w.syncIntegration(ctx, ti, rule, provider)
+		if err != nil {
+			w.logger.Error("price sync: tenant error", "tenant_id", ti.TenantID, "integration_id", ti.IntegrationID, "error", err)
+		}
+		totalSynced += synced
+	}
+
+	w.logger.Info("price sync completed", "integrations", len(tis), "synced", totalSynced)
+	return nil
+}
+
+// syncIntegration computes and pushes the prices of all active listings of one
+// integration. Listings are read and results written in short tenant
+// transactions; the price list and exchange rate services open their own.
+func (w *PriceSyncWorker) syncIntegration(ctx context.Context, ti TenantIntegration, rule *model.ChannelPricingRule, provider integration.MarketplaceProvider) (int, error) {
+	fxRate := 1.0
+	if rule.Currency != "PLN" {
+		conv, err := w.exchangeRateService.ConvertAmount(ctx, ti.TenantID, 1, "PLN", rule.Currency)
+		if err != nil {
+			return 0, fmt.Errorf("convert PLN to %s: %w", rule.Currency, err)
+		}
+		fxRate = conv.Rate
+	}
+
+	var listings []priceSyncListing
+	if err := database.WithTenant(ctx, w.pool, ti.TenantID, func(tx pgx.Tx) error {
+		rows, err := tx.Query(ctx,
+			`SELECT pl.id, pl.product_id, pl.variant_id, pl.external_id, pl.price_override,
+			        COALESCE(v.price_override, p.price), pl.last_synced_price
+			 FROM product_listings pl
+			 JOIN products p ON p.id = pl.product_id
+			 LEFT JOIN product_variants v ON v.id = pl.variant_id
+			 WHERE pl.integration_id = $1 AND pl.status = 'active' AND pl.external_id IS NOT NULL`,
+			ti.IntegrationID,
+		)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+		for rows.Next() {
+			var l priceSyncListing
+			if err := rows.Scan(&l.ID, &l.ProductID, &l.VariantID, &l.ExternalID, &l.PriceOverride, &l.BasePrice, &l.LastSyncedPrice); err != nil {
+				return err
+			}
+			listings = append(listings, l)
+		}
+		return rows.Err()
+	}); err != nil {
+		return 0, err
+	}
+
+	commissions, hasCommissions := provider.(integration.CommissionRateProvider)
+
+	var results []priceSyncResult
+	for _, l := range listings {
+		price, err := w.listingPrice(ctx, ti.TenantID, rule, fxRate, l, commissions, hasCommissions)
+		if err != nil {
+			results = append(results, priceSyncResult{ListingID: l.ID, Err: err})
+			continue
+		}
+		if l.LastSyncedPrice != nil && math.Abs(*l.LastSyncedPrice-price) < 0.005 {
+			continue
+		}
+		err = provider.UpdatePrice(ctx, l.ExternalID, price)
+		results = append(results, priceSyncResult{ListingID: l.ID, Price: price, Err: err})
+	}
+	if len(results) == 0 {
+		return 0, nil
+	}
+
+	synced := 0
+	err := database.WithTenant(ctx, w.pool, ti.TenantID, func(tx pgx.Tx) error {
+		for _, r := range results {
+			if r.Err != nil {
+				w.logger.Error("price sync: update price failed",
+					"operation", "listing.price_update",
+					"tenant_id", ti.TenantID,
+					"entity_id", r.ListingID,
+					"error", r.Err,
+				)
+				if _, err := tx.Exec(ctx,
+					`UPDATE product_listings SET sync_status = 'error', error_message = $2, updated_at = NOW() WHERE id = $1`,
+					r.ListingID, r.Err.Error(),
+				); err != nil {
+					return err
+				}
+				continue
+			}
+
+			if _, err := tx.Exec(ctx,
+				`UPDATE product_listings
+				 SET last_synced_price = $2, sync_status = 'synced', error_message = NULL,
+				     last_synced_at = NOW(), updated_at = NOW()
+				 WHERE id = $1`,
+				r.ListingID, r.Price,
+			); err != nil {
+				return err
+			}
+			w.logger.Info("worker: price synced",
+				"operation", "listing.price_update",
+				"tenant_id", ti.TenantID,
+				"entity_id", r.ListingID,
+				"price", r.Price,
+			)
+			synced++
+		}
+		return nil
+	})
+	return synced, err
+}
+
+// listingPrice resolves the price to push for a listing. An explicit listing
+// price_override wins; otherwise the pricing rule is applied to the product,
+// variant or price-list price.
+func (w *PriceSyncWorker) listingPrice(ctx context.Context, tenantID uuid.UUID, rule *model.ChannelPricingRule, fxRate float64, l priceSyncListing, commissions integration.CommissionRateProvider, hasCommissions bool) (float64, error) {
+	if l.PriceOverride != nil {
+		return *l.PriceOverride, nil
+	}
+
+	base := l.BasePrice
+	if rule.Base == "price_list" && rule.PriceListID != nil {
+		calc, err := w.priceListService.CalculatePrice(ctx, tenantID, l.ProductID, l.VariantID, 1, *rule.PriceListID)
+		if err != nil {
+			return 0, fmt.Errorf("calculate price list price: %w", err)
+		}
+		// Without a matching item the price list falls back to the product
+		// price, which would drop the variant's own price.
+		if calc.DiscountType != "none" {
+			base = calc.EffectivePrice
+		}
+	}
+
+	var commissionRate float64
+	if rule.CompensateCommission && rule.CommissionPercent == nil && hasCommissions {
+		rate, err := commissions.CommissionRate(ctx, l.ExternalID)
+		if err != nil {
+			return 0, fmt.Errorf("commission rate: %w", err)
+		}
+		commissionRate = rate
+	}
+
+	return rule.Apply(base, commissionRate, fxRate), nil
+}
@@ -0,0 +1,187 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/openoms-org/openoms/apps/api-server/internal/crypto"
+	"github.com/openoms-org/openoms/apps/api-server/internal/integration"
+	"github.com/openoms-org/openoms/apps/api-server/internal/model"
+	"github.com/openoms-org/openoms/apps/api-server/internal/service"
+)
+
+type inboundReturn struct {
+	ID             uuid.UUID
+	TenantID       uuid.UUID
+	Provider       string
+	TrackingNumber string
+	Status         *string
+	Credentials    *string
+	Settings       json.RawMessage
+}
+
+// returnParcels records the carrier progress of inbound return parcels. It is
+// implemented by service.ReturnService.
+type returnParcels interface {
+	RecordDelivered(ctx context.Context, tenantID, returnID uuid.UUID) (*model.Return, error)
+	UpdateReturnShipmentStatus(ctx context.Context, tenantID, returnID uuid.UUID, status string) error
+}
+
+// ReturnTrackingPoller tracks the prepaid return parcels of approved returns
+// and receives a return once its parcel is delivered to the warehouse. The
+// warehouse grades the received lines afterwards.
+type ReturnTrackingPoller struct {
+	pool          *pgxpool.Pool
+	encryptionKey []byte
+	returns       returnParcels
+	logger        *slog.Logger
+}
+
+func NewReturnTrackingPoller(pool *pgxpool.Pool, encryptionKey []byte, returnService *service.ReturnService, logger *slog.Logger) *ReturnTrackingPoller {
+	return &ReturnTrackingPoller{
+		pool:          pool,
+		encryptionKey: encryptionKey,
+		returns:       returnService,
+		logger:        logger,
+	}
+}
+
+func (w *ReturnTrackingPoller) Name() string {
+	return "return_tracking_poller"
+}
+
+func (w *ReturnTrackingPoller) Interval() time.Duration {
+	return 15 * time.Minute
+}
+
+func (w *ReturnTrackingPoller) Run(ctx context.Context) error {
+	rows, err := w.pool.Query(ctx,
+		`SELECT r.id, r.tenant_id, r.return_provider, r.return_tracking_number, r.return_shipment_status,
+		        i.credentials, i.settings
+		 FROM returns r
+		 LEFT JOIN integrations i ON i.id = r.return_integration_id AND i.status = 'active'
+		 WHERE r.status = 'approved'
+		   AND r.return_tracking_number IS NOT NULL
+		   AND r.return_shipment_status IS DISTINCT FROM 'delivered'`,
+	)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var returns []inboundReturn
+	for rows.Next() {
+		var ir inboundReturn
+		if err := rows.Scan(
+			&ir.ID, &ir.TenantID, &ir.Provider, &ir.TrackingNumber, &ir.Status,
+			&ir.Credentials, &ir.Settings,
+		); err != nil {
+			return err
+		}
+		returns = append(returns, ir)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	if len(returns) == 0 {
+		return nil
+	}
+
+	// Group by provider+credentials to reuse carrier instances
+	type carrierKey struct {
+		provider    string
+		credentials string
+	}
+	groups := make(map[carrierKey][]inboundReturn)
+	for _, ir := range returns {
+		key := carrierKey{provider: ir.Provider}
+		if ir.Credentials != nil {
+			key.credentials = *ir.Credentials
+		}
+		groups[key] = append(groups[key], ir)
+	}
+
+	updated, received, errCount := 0, 0, 0
+
+	for key, group := range groups {
+		if key.credentials == "" {
+			w.logger.Warn("return tracking poller: skipping returns with no integration credentials",
+				"provider", key.provider, "count", len(group))
+			continue
+		}
+
+		credJSON, err := crypto.Decrypt(key.credentials, w.encryptionKey)
+		if err != nil {
+			w.logger.Error("return tracking poller: decrypt failed",
+				"provider", key.provider, "error", err)
+			errCount += len(group)
+			continue
+		}
+
+		carrier, err := integration.NewCarrierProvider(key.provider, credJSON, group[0].Settings)
+		if err != nil {
+			w.logger.Error("return tracking poller: create carrier provider failed",
+				"provider", key.provider, "error", err)
+			errCount += len(group)
+			continue
+		}
+
+		for _, ir := range group {
+			status, err := w.track(ctx, carrier, ir)
+			if err != nil {
+				w.logger.Error("return tracking poller: track return failed",
+					"tenant_id", ir.TenantID, "return_id", ir.ID, "tracking_number", ir.TrackingNumber, "error", err)
+				errCount++
+				continue
+			}
+			switch {
+			case status == "delivered":
+				w.logger.Info("return tracking poller: return received",
+					"tenant_id", ir.TenantID, "return_id", ir.ID, "tracking_number", ir.TrackingNumber)
+				received++
+			case status != "":
+				updated++
+			}
+		}
+	}
+
+	w.logger.Info("return tracking poller: completed",
+		"total", len(returns), "updated", updated, "received", received, "errors", errCount)
+	return nil
+}
+
+// track polls the parcel of one return and records its new carrier status,
+// receiving the return when the parcel is delivered. It returns the recorded
+// status, or "" when the status is unchanged or unknown.
+func (w *ReturnTrackingPoller) track(ctx context.Context, carrier integration.CarrierProvider, ir inboundReturn) (string, error) {
+	events, err := carrier.GetTracking(ctx, ir.TrackingNumber)
+	if err != nil {
+		return "", fmt.Errorf("get tracking: %w", err)
+	}
+	if len(events) == 0 {
+		return "", nil
+	}
+
+	omsStatus, ok := carrier.MapStatus(events[len(events)-1].Status)
+	if !ok || (ir.Status != nil && *ir.Status == omsStatus) {
+		return "", nil
+	}
+
+	if omsStatus == "delivered" {
+		if _, err := w.returns.RecordDelivered(ctx, ir.TenantID, ir.ID); err != nil {
+			return "", fmt.Errorf("receive delivered return: %w", err)
+		}
+		return omsStatus, nil
+	}
+	if err := w.returns.UpdateReturnShipmentStatus(ctx, ir.TenantID, ir.ID, omsStatus); err != nil {
+		return "", fmt.Errorf("update shipment status: %w", err)
+	}
+	return omsStatus, nil
+}
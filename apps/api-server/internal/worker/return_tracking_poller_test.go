@@ -0,0 +1,75 @@
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/openoms-org/openoms/apps/api-server/internal/integration"
+	"github.com/openoms-org/openoms/apps/api-server/internal/model"
+)
+
+// fakeCarrier reports a fixed tracking history.
+type fakeCarrier struct {
+	integration.CarrierProvider
+	events []integration.TrackingEvent
+}
+
+func (c *fakeCarrier) GetTracking(ctx context.Context, trackingNumber string) ([]integration.TrackingEvent, error) {
+	return c.events, nil
+}
+
+func (c *fakeCarrier) MapStatus(carrierStatus string) (string, bool) {
+	switch carrierStatus {
+	case "in_transit", "delivered":
+		return carrierStatus, true
+	}
+	return "", false
+}
+
+// fakeReturnParcels keeps returns in memory and receives them through the
+// return state machine, as ReturnService does.
+type fakeReturnParcels struct {
+	returns map[uuid.UUID]*model.Return
+}
+
+func (f *fakeReturnParcels) RecordDelivered(ctx context.Context, tenantID, returnID uuid.UUID) (*model.Return, error) {
+	ret := f.returns[returnID]
+	if err := ret.MarkParcelDelivered(time.Now()); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+func (f *fakeReturnParcels) UpdateReturnShipmentStatus(ctx context.Context, tenantID, returnID uuid.UUID, status string) error {
+	f.returns[returnID].ReturnShipmentStatus = &status
+	return nil
+}
+
+func TestReturnTrackingPollerTrack(t *testing.T) {
+	ret := &model.Return{ID: uuid.New(), TenantID: uuid.New(), Status: "approved", ReturnType: "refund"}
+	parcels := &fakeReturnParcels{returns: map[uuid.UUID]*model.Return{ret.ID: ret}}
+	w := &ReturnTrackingPoller{returns: parcels, logger: slog.Default()}
+	ir := inboundReturn{ID: ret.ID, TenantID: ret.TenantID, Provider: "inpost", TrackingNumber: "RET-1"}
+	ctx := context.Background()
+
+	carrier := &fakeCarrier{events: []integration.TrackingEvent{{Status: "in_transit"}}}
+	status, err := w.track(ctx, carrier, ir)
+	require.NoError(t, err)
+	assert.Equal(t, "in_transit", status)
+	assert.Equal(t, "approved", ret.Status)
+
+	ir.Status = ret.ReturnShipmentStatus
+	carrier.events = append(carrier.events, integration.TrackingEvent{Status: "delivered"})
+	status, err = w.track(ctx, carrier, ir)
+	require.NoError(t, err)
+	assert.Equal(t, "delivered", status)
+	assert.Equal(t, "received", ret.Status)
+	assert.True(t, ret.GradingPending, "delivered return should be pending grading")
+	assert.NotNil(t, ret.ReceivedAt)
+}
@@ -0,0 +1,90 @@
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/openoms-org/openoms/apps/api-server/internal/model"
+	"github.com/openoms-org/openoms/apps/api-server/internal/repository"
+	"github.com/openoms-org/openoms/apps/api-server/internal/service"
+)
+
+// scheduledReportBatch is the number of due reports claimed per run.
+const scheduledReportBatch = 10
+
+// ScheduledReportWorker generates the reports whose cron schedule is due and
+// emails them. Reports are moved to their next run when claimed, so a report
+// that fails is not retried until its next scheduled time.
+type ScheduledReportWorker struct {
+	pool          *pgxpool.Pool
+	reportRepo    repository.ScheduledReportRepo
+	reportService *service.ScheduledReportService
+	logger        *slog.Logger
+}
+
+func NewScheduledReportWorker(pool *pgxpool.Pool, reportRepo repository.ScheduledReportRepo, reportService *service.ScheduledReportService, logger *slog.Logger) *ScheduledReportWorker {
+	return &ScheduledReportWorker{
+		pool:          pool,
+		reportRepo:    reportRepo,
+		reportService: reportService,
+		logger:        logger,
+	}
+}
+
+func (w *ScheduledReportWorker) Name() string {
+	return "scheduled_reports"
+}
+
+func (w *ScheduledReportWorker) Interval() time.Duration {
+	return time.Minute
+}
+
+func (w *ScheduledReportWorker) Run(ctx context.Context) error {
+	now := time.Now()
+
+	// Claim due reports directly (bypassing RLS for cross-tenant)
+	var reports []model.ScheduledReport
+	err := func() error {
+		tx, err := w.pool.Begin(ctx)
+		if err != nil {
+			return err
+		}
+		defer tx.Rollback(ctx) //nolint:errcheck
+
+		reports, err = w.reportRepo.ListDue(ctx, tx, now, scheduledReportBatch)
+		if err != nil {
+			return err
+		}
+		for _, report := range reports {
+			next, err := report.NextRun(now)
+			if err != nil {
+				w.logger.Warn("scheduled report has an invalid schedule", "tenant_id", report.TenantID, "report_id", report.ID, "error", err)
+			}
+			if err := w.reportRepo.ScheduleNext(ctx, tx, report.ID, now, next); err != nil {
+				return err
+			}
+		}
+		return tx.Commit(ctx)
+	}()
+	if err != nil {
+		return err
+	}
+
+	for _, report := range reports {
+		// Report the period before the scheduled time, even when running late.
+		at := now
+		if report.NextRunAt != nil {
+			at = *report.NextRunAt
+		}
+		run, err := w.reportService.Execute(ctx, report, at)
+		if err != nil {
+			w.logger.Error("scheduled report failed", "tenant_id", report.TenantID, "report_id", report.ID, "error", err)
+			continue
+		}
+		w.logger.Info("scheduled report sent", "tenant_id", report.TenantID, "report_id", report.ID, "run_id", run.ID)
+	}
+	return nil
+}
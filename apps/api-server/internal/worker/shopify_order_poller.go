@@ -0,0 +1,84 @@
+package worker
+
+import (
+	"encoding/json"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/openoms-org/openoms/apps/api-server/internal/integration"
+	"github.com/openoms-org/openoms/apps/api-server/internal/model"
+	"github.com/openoms-org/openoms/apps/api-server/internal/repository"
+)
+
+func NewShopifyOrderPoller(pool *pgxpool.Pool, encryptionKey []byte, orderRepo repository.OrderRepo, shipmentRepo repository.ShipmentRepo, auditRepo repository.AuditRepo, logger *slog.Logger) *MarketplaceOrderPoller {
+	return NewMarketplaceOrderPoller(MarketplaceOrderPollerConfig{
+		Pool:          pool,
+		EncryptionKey: encryptionKey,
+		OrderRepo:     orderRepo,
+		ShipmentRepo:  shipmentRepo,
+		AuditRepo:     auditRepo,
+		Logger:        logger,
+		ProviderName:  "shopify",
+		Interval:      60 * time.Second,
+		MapOrder:      shopifyOrderMapper,
+	})
+}
+
+func shopifyOrderMapper(mo integration.MarketplaceOrder, ti TenantIntegration, req model.CreateOrderRequest) model.Order {
+	order := model.Order{
+		ID:            uuid.New(),
+		TenantID:      ti.TenantID,
+		ExternalID:    req.ExternalID,
+		Source:        req.Source,
+		IntegrationID: req.IntegrationID,
+		Status:        "new",
+		CustomerName:  req.CustomerName,
+		CustomerEmail: req.CustomerEmail,
+		CustomerPhone: req.CustomerPhone,
+		TotalAmount:   req.TotalAmount,
+		Currency:      req.Currency,
+		OrderedAt:     req.OrderedAt,
+		PaymentMethod: req.PaymentMethod,
+	}
+
+	if req.PaymentStatus != nil {
+		order.PaymentStatus = *req.PaymentStatus
+	} else {
+		order.PaymentStatus = "pending"
+	}
+
+	addrJSON, err := json.Marshal(mo.ShippingAddress)
+	if err == nil {
+		order.ShippingAddress = addrJSON
+	}
+
+	itemsJSON, err := json.Marshal(mo.Items)
+	if err == nil {
+		order.Items = itemsJSON
+	}
+
+	// Shopify-specific: order note and shipping line from RawData
+	if mo.RawData != nil {
+		if note, ok := mo.RawData["customer_note"].(string); ok && note != "" {
+			order.Notes = &note
+		}
+		if method, ok := mo.RawData["shipping_method"].(string); ok && method != "" {
+			order.DeliveryMethod = &method
+		}
+	}
+
+	metadata := map[string]any{"external_id": mo.ExternalID}
+	if mo.RawData != nil {
+		if name, ok := mo.RawData["shopify_order_name"]; ok {
+			metadata["shopify_order_name"] = name
+		}
+	}
+	metadataJSON, _ := json.Marshal(metadata)
+	order.Metadata = metadataJSON
+	order.Tags = []string{}
+
+	return order
+}
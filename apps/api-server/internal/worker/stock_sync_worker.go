@@ -2,6 +2,7 @@ package worker
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 	"time"
 
@@ -13,6 +14,48 @@ import (
 	"github.com/openoms-org/openoms/apps/api-server/internal/integration"
 )
 
+// stockSyncListing is an active listing whose stock is pushed to the marketplace.
+// VariantID is set for variant-level listings; StockQuantity is already resolved
+// from the listing override, the variant or the product, in that order.
+type stockSyncListing struct {
+	ID            string
+	ProductID     string
+	VariantID     *string
+	ExternalID    string
+	StockQuantity int
+	HasVariants   bool
+	// Overridden is true when StockQuantity comes from the listing's
+	// stock_override.
+	Overridden bool
+}
+
+// stockSyncMode is how the stock of a listing is pushed.
+type stockSyncMode int
+
+const (
+	// stockSyncQuantity pushes StockQuantity as a single quantity.
+	stockSyncQuantity stockSyncMode = iota
+	// stockSyncVariants pushes the stock of each variant of the product.
+	stockSyncVariants
+	// stockSyncLocations pushes the stock of each mapped warehouse.
+	stockSyncLocations
+)
+
+// syncMode picks how the listing's stock is pushed to a provider with the
+// given capabilities. A stock override is pushed as is, never replaced by
+// variant or warehouse quantities.
+func (l stockSyncListing) syncMode(variantStock, locationStock bool) stockSyncMode {
+	switch {
+	case l.Overridden:
+		return stockSyncQuantity
+	case l.VariantID == nil && variantStock && l.HasVariants:
+		return stockSyncVariants
+	case locationStock:
+		return stockSyncLocations
+	}
+	return stockSyncQuantity
+}
+
 type StockSyncWorker struct {
 	pool          *pgxpool.Pool
 	encryptionKey []byte
@@ -57,40 +100,68 @@ func (w *StockSyncWorker) Run(ctx context.Context) error {
 			continue
 		}
 
+		// Providers that keep stock per location get per-warehouse quantities
+		// when a warehouse → location mapping is configured.
+		var locationMapping map[string]string
+		locationUpdater, hasLocations := provider.(integration.LocationStockUpdater)
+		if hasLocations {
+			locationMapping = locationUpdater.LocationMapping()
+		}
+		variantUpdater, hasVariantStock := provider.(integration.VariantStockUpdater)
+
 		if err := database.WithTenant(ctx, w.pool, ti.TenantID, func(tx pgx.Tx) error {
 			// Query product_listings with active status that have external_id
 			rows, err := tx.Query(ctx,
-				`SELECT pl.id, pl.external_id, p.stock_quantity
+				`SELECT pl.id, pl.product_id, pl.variant_id, pl.external_id,
+				        COALESCE(pl.stock_override, v.stock_quantity, p.stock_quantity), p.has_variants,
+				        pl.stock_override IS NOT NULL
 				 FROM product_listings pl
 				 JOIN products p ON p.id = pl.product_id
+				 LEFT JOIN product_variants v ON v.id = pl.variant_id
 				 WHERE pl.integration_id = $1 AND pl.status = 'active' AND pl.external_id IS NOT NULL`,
 				ti.IntegrationID,
 			)
 			if err != nil {
 				return err
 			}
-			defer rows.Close()
 
+			var listings []stockSyncListing
 			for rows.Next() {
-				var listingID, externalID string
-				var stockQty int
-				if err := rows.Scan(&listingID, &externalID, &stockQty); err != nil {
+				var l stockSyncListing
+				if err := rows.Scan(&l.ID, &l.ProductID, &l.VariantID, &l.ExternalID, &l.StockQuantity, &l.HasVariants, &l.Overridden); err != nil {
 					w.logger.Error("stock sync: scan listing", "error", err)
 					continue
 				}
+				listings = append(listings, l)
+			}
+			rows.Close()
+			if err := rows.Err(); err != nil {
+				return err
+			}
+
+			for _, l := range listings {
+				var syncErr error
+				switch l.syncMode(hasVariantStock, len(locationMapping) > 0) {
+				case stockSyncVariants:
+					syncErr = w.syncVariantStock(ctx, tx, variantUpdater, l)
+				case stockSyncLocations:
+					syncErr = w.syncLocationStock(ctx, tx, locationUpdater, locationMapping, l)
+				default:
+					syncErr = provider.UpdateStock(ctx, l.ExternalID, l.StockQuantity)
+				}
 
-				if err := provider.UpdateStock(ctx, externalID, stockQty); err != nil {
+				if syncErr != nil {
 					w.logger.Error("stock sync: update stock failed",
 						"operation", "listing.stock_update",
 						"tenant_id", ti.TenantID,
-						"entity_id", listingID,
-						"external_id", externalID,
-						"error", err,
+						"entity_id", l.ID,
+						"external_id", l.ExternalID,
+						"error", syncErr,
 					)
 					// Update listing sync status to error
 					_, _ = tx.Exec(ctx,
 						`UPDATE product_listings SET sync_status = 'error', updated_at = NOW() WHERE id = $1`,
-						listingID,
+						l.ID,
 					)
 					continue
 				}
@@ -98,18 +169,18 @@ func (w *StockSyncWorker) Run(ctx context.Context) error {
 				// Update listing sync status
 				_, _ = tx.Exec(ctx,
 					`UPDATE product_listings SET sync_status = 'synced', last_synced_at = NOW(), updated_at = NOW() WHERE id = $1`,
-					listingID,
+					l.ID,
 				)
 				w.logger.Info("worker: stock synced",
 					"operation", "listing.stock_update",
 					"tenant_id", ti.TenantID,
-					"entity_id", listingID,
-					"external_id", externalID,
-					"stock_quantity", stockQty,
+					"entity_id", l.ID,
+					"external_id", l.ExternalID,
+					"stock_quantity", l.StockQuantity,
 				)
 				totalSynced++
 			}
-			return rows.Err()
+			return nil
 		}); err != nil {
 			w.logger.Error("stock sync: tenant error", "tenant_id", ti.TenantID, "error", err)
 			continue
@@ -119,3 +190,71 @@ func (w *StockSyncWorker) Run(ctx context.Context) error {
 	w.logger.Info("stock sync completed", "tenants", len(tis), "synced", totalSynced)
 	return nil
 }
+
+// syncLocationStock pushes the available quantity (quantity - reserved) of each
+// mapped warehouse to its marketplace location. Warehouses without a stock row
+// for the product (or the listed variant) are synced as zero.
+func (w *StockSyncWorker) syncLocationStock(ctx context.Context, tx pgx.Tx, updater integration.LocationStockUpdater, mapping map[string]string, l stockSyncListing) error {
+	rows, err := tx.Query(ctx,
+		`SELECT warehouse_id::text, GREATEST(quantity - reserved, 0)
+		 FROM warehouse_stock
+		 WHERE product_id = $1 AND variant_id IS NOT DISTINCT FROM $2::uuid`,
+		l.ProductID, l.VariantID,
+	)
+	if err != nil {
+		return fmt.Errorf("query warehouse stock: %w", err)
+	}
+	available := make(map[string]int)
+	for rows.Next() {
+		var warehouseID string
+		var qty int
+		if err := rows.Scan(&warehouseID, &qty); err != nil {
+			rows.Close()
+			return fmt.Errorf("scan warehouse stock: %w", err)
+		}
+		available[warehouseID] = qty
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for warehouseID, locationID := range mapping {
+		if err := updater.UpdateLocationStock(ctx, l.ExternalID, locationID, available[warehouseID]); err != nil {
+			return fmt.Errorf("warehouse %s: %w", warehouseID, err)
+		}
+	}
+	return nil
+}
+
+// syncVariantStock pushes the stock of each active variant of the listed
+// product; the provider matches variants to its own by SKU or EAN.
+func (w *StockSyncWorker) syncVariantStock(ctx context.Context, tx pgx.Tx, updater integration.VariantStockUpdater, l stockSyncListing) error {
+	rows, err := tx.Query(ctx,
+		`SELECT COALESCE(sku, ''), COALESCE(ean, ''), stock_quantity
+		 FROM product_variants
+		 WHERE product_id = $1 AND active = true`,
+		l.ProductID,
+	)
+	if err != nil {
+		return fmt.Errorf("query variants: %w", err)
+	}
+	var variants []integration.VariantStock
+	for rows.Next() {
+		var v integration.VariantStock
+		if err := rows.Scan(&v.SKU, &v.EAN, &v.Quantity); err != nil {
+			rows.Close()
+			return fmt.Errorf("scan variant: %w", err)
+		}
+		variants = append(variants, v)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	if len(variants) == 0 {
+		return nil
+	}
+	return updater.UpdateVariantStock(ctx, l.ExternalID, variants)
+}
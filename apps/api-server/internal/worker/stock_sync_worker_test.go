@@ -0,0 +1,28 @@
+package worker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStockSyncListing_SyncMode(t *testing.T) {
+	variantID := "variant-1"
+	tests := []struct {
+		name          string
+		listing       stockSyncListing
+		variantStock  bool
+		locationStock bool
+		want          stockSyncMode
+	}{
+		{"plain listing", stockSyncListing{}, true, false, stockSyncQuantity},
+		{"product with variants", stockSyncListing{HasVariants: true}, true, true, stockSyncVariants},
+		{"variant listing with locations", stockSyncListing{VariantID: &variantID, HasVariants: true}, true, true, stockSyncLocations},
+		{"locations", stockSyncListing{}, false, true, stockSyncLocations},
+		{"override with locations", stockSyncListing{Overridden: true}, false, true, stockSyncQuantity},
+		{"override with variants", stockSyncListing{HasVariants: true, Overridden: true}, true, true, stockSyncQuantity},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, tt.listing.syncMode(tt.variantStock, tt.locationStock), tt.name)
+	}
+}
@@ -51,7 +51,7 @@ func ListActiveIntegrations(ctx context.Context, pool *pgxpool.Pool, provider st
 }
 
 // ListAllActiveMarketplaceIntegrations queries all active marketplace integrations
-// across all providers. This is used by the stock sync worker.
+// across all providers. This is used by the stock and price sync workers.
 func ListAllActiveMarketplaceIntegrations(ctx context.Context, pool *pgxpool.Pool) ([]TenantIntegration, error) {
 	rows, err := pool.Query(ctx,
 		`SELECT tenant_id, id, provider, sync_cursor, credentials, settings
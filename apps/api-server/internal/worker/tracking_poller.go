@@ -13,6 +13,7 @@ import (
 	"github.com/openoms-org/openoms/apps/api-server/internal/crypto"
 	"github.com/openoms-org/openoms/apps/api-server/internal/database"
 	"github.com/openoms-org/openoms/apps/api-server/internal/integration"
+	"github.com/openoms-org/openoms/apps/api-server/internal/model"
 	"github.com/openoms-org/openoms/apps/api-server/internal/repository"
 )
 
@@ -32,14 +33,16 @@ type TrackingPoller struct {
 	pool          *pgxpool.Pool
 	encryptionKey []byte
 	shipmentRepo  repository.ShipmentRepo
+	auditRepo     repository.AuditRepo
 	logger        *slog.Logger
 }
 
-func NewTrackingPoller(pool *pgxpool.Pool, encryptionKey []byte, shipmentRepo repository.ShipmentRepo, logger *slog.Logger) *TrackingPoller {
+func NewTrackingPoller(pool *pgxpool.Pool, encryptionKey []byte, shipmentRepo repository.ShipmentRepo, auditRepo repository.AuditRepo, logger *slog.Logger) *TrackingPoller {
 	return &TrackingPoller{
 		pool:          pool,
 		encryptionKey: encryptionKey,
 		shipmentRepo:  shipmentRepo,
+		auditRepo:     auditRepo,
 		logger:        logger,
 	}
 }
@@ -152,9 +155,34 @@ func (w *TrackingPoller) Run(ctx context.Context) error {
 				continue
 			}
 
-			// Update shipment status within tenant context
+			// Update shipment status within tenant context. The carrier's
+			// event is audited so it shows on the order timeline.
 			err = database.WithTenant(ctx, w.pool, ts.TenantID, func(tx pgx.Tx) error {
-				return w.shipmentRepo.UpdateStatus(ctx, tx, ts.ID, omsStatus)
+				if err := w.shipmentRepo.UpdateStatus(ctx, tx, ts.ID, omsStatus); err != nil {
+					return err
+				}
+				if w.auditRepo == nil {
+					return nil
+				}
+				changes := map[string]string{
+					"from":            ts.Status,
+					"to":              omsStatus,
+					"source":          "tracking",
+					"tracking_status": lastEvent.Status,
+				}
+				if lastEvent.Location != "" {
+					changes["location"] = lastEvent.Location
+				}
+				if lastEvent.Details != "" {
+					changes["details"] = lastEvent.Details
+				}
+				return w.auditRepo.Log(ctx, tx, model.AuditEntry{
+					TenantID:   ts.TenantID,
+					Action:     "shipment.status_changed",
+					EntityType: "shipment",
+					EntityID:   ts.ID,
+					Changes:    changes,
+				})
 			})
 			if err != nil {
 				w.logger.Error("tracking poller: update status failed",
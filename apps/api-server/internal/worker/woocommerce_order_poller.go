@@ -13,7 +13,7 @@ import (
 	"github.com/openoms-org/openoms/apps/api-server/internal/repository"
 )
 
-func NewWooCommerceOrderPoller(pool *pgxpool.Pool, encryptionKey []byte, orderRepo repository.OrderRepo, shipmentRepo repository.ShipmentRepo, auditRepo repository.AuditRepo, logger *slog.Logger) *MarketplaceOrderPoller {
+func NewWooCommerceOrderPoller(pool *pgxpool.Pool, encryptionKey []byte, orderRepo repository.OrderRepo, shipmentRepo repository.ShipmentRepo, auditRepo repository.AuditRepo, variantRepo repository.VariantRepo, logger *slog.Logger) *MarketplaceOrderPoller {
 	return NewMarketplaceOrderPoller(MarketplaceOrderPollerConfig{
 		Pool:          pool,
 		EncryptionKey: encryptionKey,
@@ -24,6 +24,7 @@ func NewWooCommerceOrderPoller(pool *pgxpool.Pool, encryptionKey []byte, orderRe
 		ProviderName:  "woocommerce",
 		Interval:      60 * time.Second,
 		MapOrder:      woocommerceOrderMapper,
+		VariantRepo:   variantRepo,
 	})
 }
 
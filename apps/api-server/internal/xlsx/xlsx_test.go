@@ -0,0 +1,60 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWrite(t *testing.T) {
+	var buf bytes.Buffer
+	err := Write(&buf, Sheet{
+		Name: "Sprzedaż: kanały",
+		Rows: [][]any{
+			{"Kanał", "Zamówienia", "Przychód"},
+			{"allegro", 12, 1534.5},
+			{"<sklep> & co", 3, nil},
+		},
+	})
+	require.NoError(t, err)
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	files := map[string]string{}
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		data, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		rc.Close()
+		files[f.Name] = string(data)
+	}
+
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels", "xl/styles.xml", "xl/worksheets/sheet1.xml"} {
+		assert.Contains(t, files, name)
+	}
+	assert.Contains(t, files["xl/workbook.xml"], `name="Sprzedaż- kanały"`)
+
+	sheet := files["xl/worksheets/sheet1.xml"]
+	assert.Contains(t, sheet, `<c r="A1" s="1" t="inlineStr"><is><t xml:space="preserve">Kanał</t></is></c>`)
+	assert.Contains(t, sheet, `<c r="B2"><v>12</v></c>`)
+	assert.Contains(t, sheet, `<c r="C2"><v>1534.5</v></c>`)
+	assert.Contains(t, sheet, `&lt;sklep&gt; &amp; co`)
+	assert.NotContains(t, sheet, `r="C3"`)
+}
+
+func TestWrite_NoSheets(t *testing.T) {
+	assert.Error(t, Write(io.Discard))
+}
+
+func TestColumnName(t *testing.T) {
+	assert.Equal(t, "A", ColumnName(0))
+	assert.Equal(t, "Z", ColumnName(25))
+	assert.Equal(t, "AA", ColumnName(26))
+	assert.Equal(t, "AZ", ColumnName(51))
+	assert.Equal(t, "BA", ColumnName(52))
+}
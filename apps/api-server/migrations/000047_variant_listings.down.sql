@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_product_listings_variant_integration;
+DROP INDEX IF EXISTS idx_product_listings_variant;
+ALTER TABLE product_listings DROP COLUMN IF EXISTS external_group_id;
+ALTER TABLE product_listings DROP COLUMN IF EXISTS variant_id;
@@ -0,0 +1,9 @@
+-- Variant-level marketplace listings: a listing may target a single product variant.
+-- external_group_id holds the marketplace grouping of variant offers
+-- (Allegro variant set, WooCommerce parent product, Shopify product).
+ALTER TABLE product_listings ADD COLUMN variant_id UUID REFERENCES product_variants(id) ON DELETE CASCADE;
+ALTER TABLE product_listings ADD COLUMN external_group_id TEXT;
+
+CREATE INDEX idx_product_listings_variant ON product_listings(variant_id) WHERE variant_id IS NOT NULL;
+CREATE UNIQUE INDEX idx_product_listings_variant_integration ON product_listings(tenant_id, integration_id, variant_id)
+    WHERE variant_id IS NOT NULL;
@@ -0,0 +1 @@
+ALTER TABLE product_listings DROP COLUMN IF EXISTS last_synced_price;
@@ -0,0 +1,3 @@
+-- Price last pushed to the marketplace by the price sync worker, in the channel currency.
+-- The worker only calls the marketplace when the computed price differs from it.
+ALTER TABLE product_listings ADD COLUMN last_synced_price DECIMAL(12,2);
@@ -0,0 +1,7 @@
+ALTER TABLE returns DROP COLUMN IF EXISTS refunded_at;
+ALTER TABLE returns DROP COLUMN IF EXISTS refund_reference;
+ALTER TABLE returns DROP COLUMN IF EXISTS refund_method;
+ALTER TABLE returns DROP COLUMN IF EXISTS shipping_refund;
+ALTER TABLE returns DROP COLUMN IF EXISTS restock_document_id;
+ALTER TABLE returns DROP COLUMN IF EXISTS received_at;
+ALTER TABLE returns DROP COLUMN IF EXISTS received_items;
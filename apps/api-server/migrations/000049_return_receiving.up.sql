@@ -0,0 +1,8 @@
+-- Migration 000049: Return receiving, restock and refund execution
+ALTER TABLE returns ADD COLUMN received_items JSONB NOT NULL DEFAULT '[]';
+ALTER TABLE returns ADD COLUMN received_at TIMESTAMPTZ;
+ALTER TABLE returns ADD COLUMN restock_document_id UUID REFERENCES warehouse_documents(id) ON DELETE SET NULL;
+ALTER TABLE returns ADD COLUMN shipping_refund DECIMAL(12,2) NOT NULL DEFAULT 0;
+ALTER TABLE returns ADD COLUMN refund_method VARCHAR(20);
+ALTER TABLE returns ADD COLUMN refund_reference TEXT;
+ALTER TABLE returns ADD COLUMN refunded_at TIMESTAMPTZ;
@@ -0,0 +1,29 @@
+DROP FUNCTION IF EXISTS public.find_return_by_token(TEXT);
+
+CREATE FUNCTION public.find_return_by_token(p_token TEXT)
+RETURNS TABLE(
+    id UUID, tenant_id UUID, order_id UUID, status VARCHAR,
+    reason TEXT, items JSONB, refund_amount DECIMAL,
+    notes TEXT, return_token TEXT, customer_email TEXT,
+    customer_notes TEXT, created_at TIMESTAMPTZ, updated_at TIMESTAMPTZ
+)
+SECURITY DEFINER
+SET search_path = public
+AS $$
+    SELECT r.id, r.tenant_id, r.order_id, r.status,
+           r.reason, r.items, r.refund_amount,
+           r.notes, r.return_token, r.customer_email,
+           r.customer_notes, r.created_at, r.updated_at
+    FROM returns r
+    WHERE r.return_token = p_token;
+$$ LANGUAGE sql STABLE;
+
+GRANT EXECUTE ON FUNCTION public.find_return_by_token(TEXT) TO openoms_app;
+
+DROP INDEX IF EXISTS idx_returns_inbound_tracking;
+ALTER TABLE returns DROP COLUMN IF EXISTS return_shipment_status;
+ALTER TABLE returns DROP COLUMN IF EXISTS return_label_url;
+ALTER TABLE returns DROP COLUMN IF EXISTS return_tracking_number;
+ALTER TABLE returns DROP COLUMN IF EXISTS return_external_id;
+ALTER TABLE returns DROP COLUMN IF EXISTS return_integration_id;
+ALTER TABLE returns DROP COLUMN IF EXISTS return_provider;
@@ -0,0 +1,37 @@
+-- Migration 000050: Prepaid return labels and inbound return tracking
+ALTER TABLE returns ADD COLUMN return_provider VARCHAR(30);
+ALTER TABLE returns ADD COLUMN return_integration_id UUID REFERENCES integrations(id) ON DELETE SET NULL;
+ALTER TABLE returns ADD COLUMN return_external_id TEXT;
+ALTER TABLE returns ADD COLUMN return_tracking_number TEXT;
+ALTER TABLE returns ADD COLUMN return_label_url TEXT;
+ALTER TABLE returns ADD COLUMN return_shipment_status VARCHAR(30);
+
+CREATE INDEX idx_returns_inbound_tracking ON returns (status)
+    WHERE return_tracking_number IS NOT NULL;
+
+-- The public lookup exposes the return label, so its result columns change.
+DROP FUNCTION IF EXISTS public.find_return_by_token(TEXT);
+
+CREATE FUNCTION public.find_return_by_token(p_token TEXT)
+RETURNS TABLE(
+    id UUID, tenant_id UUID, order_id UUID, status VARCHAR,
+    reason TEXT, items JSONB, refund_amount DECIMAL,
+    notes TEXT, return_token TEXT, customer_email TEXT,
+    customer_notes TEXT, return_provider VARCHAR, return_tracking_number TEXT,
+    return_label_url TEXT, return_shipment_status VARCHAR,
+    created_at TIMESTAMPTZ, updated_at TIMESTAMPTZ
+)
+SECURITY DEFINER
+SET search_path = public
+AS $$
+    SELECT r.id, r.tenant_id, r.order_id, r.status,
+           r.reason, r.items, r.refund_amount,
+           r.notes, r.return_token, r.customer_email,
+           r.customer_notes, r.return_provider, r.return_tracking_number,
+           r.return_label_url, r.return_shipment_status,
+           r.created_at, r.updated_at
+    FROM returns r
+    WHERE r.return_token = p_token;
+$$ LANGUAGE sql STABLE;
+
+GRANT EXECUTE ON FUNCTION public.find_return_by_token(TEXT) TO openoms_app;
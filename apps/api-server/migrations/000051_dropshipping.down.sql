@@ -0,0 +1,5 @@
+DROP FUNCTION IF EXISTS public.find_supplier_order_by_token(TEXT);
+DROP INDEX IF EXISTS idx_orders_fulfillment_unrouted;
+ALTER TABLE orders DROP COLUMN IF EXISTS fulfillment_routed_at;
+DROP TABLE IF EXISTS supplier_orders;
+DROP TABLE IF EXISTS fulfillment_rules;
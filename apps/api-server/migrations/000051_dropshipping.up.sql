@@ -0,0 +1,78 @@
+-- Migration 000051: Dropshipping — per-product fulfilment rules and supplier orders
+
+-- Fulfilment rule per product. Products without a rule ship from own stock.
+CREATE TABLE fulfillment_rules (
+    product_id  UUID PRIMARY KEY REFERENCES products(id) ON DELETE CASCADE,
+    tenant_id   UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    mode        VARCHAR(30) NOT NULL DEFAULT 'stock_first'
+                CHECK (mode IN ('own_stock', 'stock_first', 'dropship')),
+    supplier_id UUID REFERENCES suppliers(id) ON DELETE SET NULL,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_fulfillment_rules_tenant ON fulfillment_rules(tenant_id);
+ALTER TABLE fulfillment_rules ENABLE ROW LEVEL SECURITY;
+ALTER TABLE fulfillment_rules FORCE ROW LEVEL SECURITY;
+CREATE POLICY fulfillment_rules_tenant_isolation ON fulfillment_rules
+    USING (tenant_id = current_setting('app.current_tenant_id', true)::uuid);
+GRANT SELECT, INSERT, UPDATE, DELETE ON fulfillment_rules TO openoms_app;
+
+CREATE TRIGGER trigger_fulfillment_rules_updated_at
+    BEFORE UPDATE ON fulfillment_rules FOR EACH ROW EXECUTE FUNCTION update_updated_at();
+
+-- Purchase order sent to a supplier for the dropship lines of a customer order.
+CREATE TABLE supplier_orders (
+    id              UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id       UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    supplier_id     UUID NOT NULL REFERENCES suppliers(id) ON DELETE CASCADE,
+    order_id        UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    status          VARCHAR(20) NOT NULL DEFAULT 'pending'
+                    CHECK (status IN ('pending', 'sent', 'failed', 'shipped', 'cancelled')),
+    items           JSONB NOT NULL DEFAULT '[]',
+    total_cost      DECIMAL(12,2) NOT NULL DEFAULT 0,
+    channel         VARCHAR(20) NOT NULL DEFAULT 'email',
+    attempts        INTEGER NOT NULL DEFAULT 0,
+    error_message   TEXT,
+    sent_at         TIMESTAMPTZ,
+    carrier         VARCHAR(50),
+    tracking_number TEXT,
+    shipment_id     UUID REFERENCES shipments(id) ON DELETE SET NULL,
+    shipped_at      TIMESTAMPTZ,
+    callback_token  TEXT NOT NULL UNIQUE,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at      TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_supplier_orders_tenant ON supplier_orders(tenant_id);
+CREATE INDEX idx_supplier_orders_order ON supplier_orders(order_id);
+CREATE INDEX idx_supplier_orders_supplier ON supplier_orders(supplier_id, status);
+CREATE INDEX idx_supplier_orders_unsent ON supplier_orders(status)
+    WHERE status IN ('pending', 'failed');
+ALTER TABLE supplier_orders ENABLE ROW LEVEL SECURITY;
+ALTER TABLE supplier_orders FORCE ROW LEVEL SECURITY;
+CREATE POLICY supplier_orders_tenant_isolation ON supplier_orders
+    USING (tenant_id = current_setting('app.current_tenant_id', true)::uuid);
+GRANT SELECT, INSERT, UPDATE, DELETE ON supplier_orders TO openoms_app;
+
+CREATE TRIGGER trigger_supplier_orders_updated_at
+    BEFORE UPDATE ON supplier_orders FOR EACH ROW EXECUTE FUNCTION update_updated_at();
+
+-- Orders are routed once; existing orders are treated as already routed.
+ALTER TABLE orders ADD COLUMN fulfillment_routed_at TIMESTAMPTZ;
+UPDATE orders SET fulfillment_routed_at = NOW();
+CREATE INDEX idx_orders_fulfillment_unrouted ON orders(status)
+    WHERE fulfillment_routed_at IS NULL;
+
+-- Lets suppliers post tracking numbers to the public callback without a tenant context.
+CREATE OR REPLACE FUNCTION public.find_supplier_order_by_token(p_token TEXT)
+RETURNS TABLE(id UUID, tenant_id UUID)
+SECURITY DEFINER
+SET search_path = public
+AS $$
+    SELECT so.id, so.tenant_id
+    FROM supplier_orders so
+    WHERE so.callback_token = p_token;
+$$ LANGUAGE sql STABLE;
+
+GRANT EXECUTE ON FUNCTION public.find_supplier_order_by_token(TEXT) TO openoms_app;
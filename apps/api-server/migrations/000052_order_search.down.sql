@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS order_views;
+DROP INDEX IF EXISTS idx_orders_tenant_ordered;
+DROP INDEX IF EXISTS idx_orders_search;
+ALTER TABLE orders DROP COLUMN IF EXISTS search_vector;
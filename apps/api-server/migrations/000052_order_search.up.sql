@@ -0,0 +1,41 @@
+-- Migration 000052: Order full-text search and per-user saved views
+
+-- Full-text document over customer, addresses, items and notes. The 'simple'
+-- configuration keeps names, codes and SKUs intact instead of stemming them.
+ALTER TABLE orders ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+    to_tsvector('simple',
+        coalesce(customer_name, '') || ' ' ||
+        coalesce(customer_email, '') || ' ' ||
+        coalesce(customer_phone, '') || ' ' ||
+        coalesce(external_id, '') || ' ' ||
+        coalesce(notes, '') || ' ' ||
+        coalesce(internal_notes, ''))
+    || jsonb_to_tsvector('simple', coalesce(shipping_address, '{}'::jsonb), '["string"]')
+    || jsonb_to_tsvector('simple', coalesce(billing_address, '{}'::jsonb), '["string"]')
+    || jsonb_to_tsvector('simple', coalesce(items, '[]'::jsonb), '["string"]')
+) STORED;
+
+CREATE INDEX idx_orders_search ON orders USING GIN (search_vector);
+CREATE INDEX idx_orders_tenant_ordered ON orders(tenant_id, ordered_at DESC);
+
+-- Saved order list filters, private to the user who created them.
+CREATE TABLE order_views (
+    id         UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id  UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    user_id    UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name       VARCHAR(100) NOT NULL,
+    filters    JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (user_id, name)
+);
+
+CREATE INDEX idx_order_views_tenant ON order_views(tenant_id);
+ALTER TABLE order_views ENABLE ROW LEVEL SECURITY;
+ALTER TABLE order_views FORCE ROW LEVEL SECURITY;
+CREATE POLICY order_views_tenant_isolation ON order_views
+    USING (tenant_id = current_setting('app.current_tenant_id', true)::uuid);
+GRANT SELECT, INSERT, UPDATE, DELETE ON order_views TO openoms_app;
+
+CREATE TRIGGER trigger_order_views_updated_at
+    BEFORE UPDATE ON order_views FOR EACH ROW EXECUTE FUNCTION update_updated_at();
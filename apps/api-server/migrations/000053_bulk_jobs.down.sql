@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_sync_jobs_type;
+DELETE FROM sync_jobs WHERE integration_id IS NULL;
+ALTER TABLE sync_jobs ALTER COLUMN integration_id SET NOT NULL;
@@ -0,0 +1,7 @@
+-- Migration 000053: Asynchronous bulk order jobs
+--
+-- Bulk jobs run through sync_jobs like catalog imports but belong to no
+-- integration; their request and per-order results live in metadata.
+ALTER TABLE sync_jobs ALTER COLUMN integration_id DROP NOT NULL;
+
+CREATE INDEX idx_sync_jobs_type ON sync_jobs(tenant_id, job_type, created_at DESC);
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_orders_fee_sync;
+ALTER TABLE orders DROP COLUMN IF EXISTS fees_synced_at;
+ALTER TABLE orders DROP COLUMN IF EXISTS marketplace_fee;
+ALTER TABLE shipments DROP COLUMN IF EXISTS shipping_cost;
+ALTER TABLE product_variants DROP COLUMN IF EXISTS cost_price;
+ALTER TABLE products DROP COLUMN IF EXISTS cost_price;
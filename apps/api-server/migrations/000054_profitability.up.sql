@@ -0,0 +1,14 @@
+-- Migration 000054: Profitability reporting
+--
+-- Purchase costs of products and variants (updated from PZ receipts), the
+-- carrier cost of shipments and the marketplace fees billed per order feed the
+-- gross margin reports.
+ALTER TABLE products ADD COLUMN cost_price NUMERIC(12,2);
+ALTER TABLE product_variants ADD COLUMN cost_price NUMERIC(12,2);
+ALTER TABLE shipments ADD COLUMN shipping_cost NUMERIC(12,2);
+
+ALTER TABLE orders ADD COLUMN marketplace_fee NUMERIC(12,2);
+ALTER TABLE orders ADD COLUMN fees_synced_at TIMESTAMPTZ;
+
+CREATE INDEX idx_orders_fee_sync ON orders(integration_id, fees_synced_at)
+    WHERE external_id IS NOT NULL;
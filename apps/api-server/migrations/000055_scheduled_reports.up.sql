@@ -0,0 +1,53 @@
+-- Migration 000055: Scheduled reports delivered by email
+--
+-- Report definitions run on a cron schedule by ScheduledReportWorker; every
+-- generated file is kept in object storage and recorded as a run.
+CREATE TABLE scheduled_reports (
+    id          UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id   UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    name        VARCHAR(200) NOT NULL,
+    report_type TEXT NOT NULL,
+    schedule    TEXT NOT NULL,
+    timezone    TEXT NOT NULL DEFAULT 'Europe/Warsaw',
+    period      TEXT NOT NULL DEFAULT 'day',
+    format      TEXT NOT NULL DEFAULT 'csv',
+    recipients  TEXT[] NOT NULL DEFAULT '{}',
+    active      BOOLEAN NOT NULL DEFAULT true,
+    last_run_at TIMESTAMPTZ,
+    next_run_at TIMESTAMPTZ,
+    created_by  UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_scheduled_reports_tenant ON scheduled_reports(tenant_id, created_at DESC);
+CREATE INDEX idx_scheduled_reports_due ON scheduled_reports(next_run_at) WHERE active;
+ALTER TABLE scheduled_reports ENABLE ROW LEVEL SECURITY;
+ALTER TABLE scheduled_reports FORCE ROW LEVEL SECURITY;
+CREATE POLICY scheduled_reports_tenant_isolation ON scheduled_reports
+    USING (tenant_id = current_setting('app.current_tenant_id', true)::uuid);
+GRANT SELECT, INSERT, UPDATE, DELETE ON scheduled_reports TO openoms_app;
+
+CREATE TRIGGER trigger_scheduled_reports_updated_at
+    BEFORE UPDATE ON scheduled_reports FOR EACH ROW EXECUTE FUNCTION update_updated_at();
+
+CREATE TABLE scheduled_report_runs (
+    id            UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id     UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    report_id     UUID NOT NULL REFERENCES scheduled_reports(id) ON DELETE CASCADE,
+    status        TEXT NOT NULL,
+    format        TEXT NOT NULL,
+    period_from   TIMESTAMPTZ,
+    period_to     TIMESTAMPTZ,
+    recipients    TEXT[] NOT NULL DEFAULT '{}',
+    file_url      TEXT,
+    error_message TEXT,
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_scheduled_report_runs_report ON scheduled_report_runs(report_id, created_at DESC);
+ALTER TABLE scheduled_report_runs ENABLE ROW LEVEL SECURITY;
+ALTER TABLE scheduled_report_runs FORCE ROW LEVEL SECURITY;
+CREATE POLICY scheduled_report_runs_tenant_isolation ON scheduled_report_runs
+    USING (tenant_id = current_setting('app.current_tenant_id', true)::uuid);
+GRANT SELECT, INSERT, UPDATE, DELETE ON scheduled_report_runs TO openoms_app;
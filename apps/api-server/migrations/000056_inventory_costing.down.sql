@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS inventory_cost_movements;
+DROP TABLE IF EXISTS inventory_cost_layers;
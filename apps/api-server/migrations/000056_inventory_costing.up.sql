@@ -0,0 +1,56 @@
+-- Migration 000056: Inventory costing (FIFO / weighted average)
+--
+-- Confirmed PZ documents and the receiving side of MM documents open cost
+-- layers per warehouse; WZ and the issuing side of MM consume them. Every
+-- costed movement is journaled with its signed quantity and value, so stock
+-- value at any date and the cost of goods sold per WZ are sums over the
+-- journal. Layers only hold the current state.
+CREATE TABLE inventory_cost_layers (
+    id            UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id     UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    warehouse_id  UUID NOT NULL REFERENCES warehouses(id) ON DELETE CASCADE,
+    product_id    UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    variant_id    UUID REFERENCES product_variants(id) ON DELETE CASCADE,
+    document_id   UUID NOT NULL REFERENCES warehouse_documents(id) ON DELETE CASCADE,
+    quantity      INTEGER NOT NULL CHECK (quantity > 0),
+    remaining     INTEGER NOT NULL CHECK (remaining >= 0),
+    unit_cost     DECIMAL(14,4) NOT NULL DEFAULT 0,
+    received_at   TIMESTAMPTZ NOT NULL,
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at    TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_inventory_cost_layers_open ON inventory_cost_layers(warehouse_id, product_id, variant_id, received_at)
+    WHERE remaining > 0;
+ALTER TABLE inventory_cost_layers ENABLE ROW LEVEL SECURITY;
+ALTER TABLE inventory_cost_layers FORCE ROW LEVEL SECURITY;
+CREATE POLICY inventory_cost_layers_tenant_isolation ON inventory_cost_layers
+    USING (tenant_id = current_setting('app.current_tenant_id', true)::uuid);
+GRANT SELECT, INSERT, UPDATE, DELETE ON inventory_cost_layers TO openoms_app;
+
+CREATE TRIGGER trigger_inventory_cost_layers_updated_at
+    BEFORE UPDATE ON inventory_cost_layers FOR EACH ROW EXECUTE FUNCTION update_updated_at();
+
+CREATE TABLE inventory_cost_movements (
+    id            UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id     UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    warehouse_id  UUID NOT NULL REFERENCES warehouses(id) ON DELETE CASCADE,
+    product_id    UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    variant_id    UUID REFERENCES product_variants(id) ON DELETE CASCADE,
+    document_id   UUID NOT NULL REFERENCES warehouse_documents(id) ON DELETE CASCADE,
+    document_type TEXT NOT NULL,
+    method        TEXT NOT NULL,
+    quantity      INTEGER NOT NULL,
+    total_cost    DECIMAL(16,4) NOT NULL,
+    estimated     BOOLEAN NOT NULL DEFAULT false,
+    occurred_at   TIMESTAMPTZ NOT NULL,
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_inventory_cost_movements_tenant ON inventory_cost_movements(tenant_id, occurred_at);
+CREATE INDEX idx_inventory_cost_movements_document ON inventory_cost_movements(document_id);
+ALTER TABLE inventory_cost_movements ENABLE ROW LEVEL SECURITY;
+ALTER TABLE inventory_cost_movements FORCE ROW LEVEL SECURITY;
+CREATE POLICY inventory_cost_movements_tenant_isolation ON inventory_cost_movements
+    USING (tenant_id = current_setting('app.current_tenant_id', true)::uuid);
+GRANT SELECT, INSERT ON inventory_cost_movements TO openoms_app;
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS stock_movements;
+
+ALTER TABLE warehouse_stock DROP CONSTRAINT IF EXISTS warehouse_stock_warehouse_id_product_id_variant_id_key;
+ALTER TABLE warehouse_stock ADD CONSTRAINT warehouse_stock_warehouse_id_product_id_variant_id_key
+    UNIQUE (warehouse_id, product_id, variant_id);
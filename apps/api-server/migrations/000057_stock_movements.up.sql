@@ -0,0 +1,69 @@
+-- Migration 000057: Stock movement ledger
+--
+-- Every change of warehouse_stock.quantity is appended to stock_movements with
+-- its source (manual edit, warehouse document, stocktake), actor and the
+-- quantity before and after. The application role may only read and insert,
+-- so the ledger cannot be rewritten; stock at a past date is the sum of the
+-- deltas recorded before it.
+
+-- Product-level stock (variant_id NULL) never conflicted on the old unique
+-- constraint, so adjustments inserted a new row each time. Merge those rows
+-- and make NULL variants collide.
+WITH merged AS (
+    SELECT (array_agg(id ORDER BY created_at))[1] AS keep_id,
+           SUM(quantity) AS quantity, SUM(reserved) AS reserved, MAX(min_stock) AS min_stock,
+           warehouse_id, product_id
+    FROM warehouse_stock
+    WHERE variant_id IS NULL
+    GROUP BY warehouse_id, product_id
+    HAVING COUNT(*) > 1
+), kept AS (
+    UPDATE warehouse_stock ws
+    SET quantity = m.quantity, reserved = m.reserved, min_stock = m.min_stock
+    FROM merged m
+    WHERE ws.id = m.keep_id
+    RETURNING ws.id, ws.warehouse_id, ws.product_id
+)
+DELETE FROM warehouse_stock ws
+USING kept k
+WHERE ws.warehouse_id = k.warehouse_id AND ws.product_id = k.product_id
+  AND ws.variant_id IS NULL AND ws.id <> k.id;
+
+ALTER TABLE warehouse_stock DROP CONSTRAINT IF EXISTS warehouse_stock_warehouse_id_product_id_variant_id_key;
+ALTER TABLE warehouse_stock ADD CONSTRAINT warehouse_stock_warehouse_id_product_id_variant_id_key
+    UNIQUE NULLS NOT DISTINCT (warehouse_id, product_id, variant_id);
+
+CREATE TABLE stock_movements (
+    id              UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id       UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    warehouse_id    UUID NOT NULL REFERENCES warehouses(id) ON DELETE CASCADE,
+    product_id      UUID NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    variant_id      UUID REFERENCES product_variants(id) ON DELETE CASCADE,
+    source          TEXT NOT NULL CHECK (source IN ('opening', 'manual', 'warehouse_document', 'stocktake')),
+    document_id     UUID REFERENCES warehouse_documents(id) ON DELETE SET NULL,
+    stocktake_id    UUID REFERENCES stocktakes(id) ON DELETE SET NULL,
+    delta           INTEGER NOT NULL,
+    quantity_before INTEGER NOT NULL,
+    quantity_after  INTEGER NOT NULL,
+    actor_id        UUID REFERENCES users(id) ON DELETE SET NULL,
+    note            TEXT,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK (quantity_after = quantity_before + delta)
+);
+
+CREATE INDEX idx_stock_movements_product ON stock_movements(product_id, created_at);
+CREATE INDEX idx_stock_movements_warehouse ON stock_movements(warehouse_id, created_at);
+CREATE INDEX idx_stock_movements_document ON stock_movements(document_id) WHERE document_id IS NOT NULL;
+ALTER TABLE stock_movements ENABLE ROW LEVEL SECURITY;
+ALTER TABLE stock_movements FORCE ROW LEVEL SECURITY;
+CREATE POLICY stock_movements_tenant_isolation ON stock_movements
+    USING (tenant_id = current_setting('app.current_tenant_id', true)::uuid);
+GRANT SELECT, INSERT ON stock_movements TO openoms_app;
+
+-- Open the ledger with the current stock, dated at its last change.
+INSERT INTO stock_movements (tenant_id, warehouse_id, product_id, variant_id, source,
+                             delta, quantity_before, quantity_after, note, created_at)
+SELECT tenant_id, warehouse_id, product_id, variant_id, 'opening',
+       quantity, 0, quantity, 'Stan otwarcia', updated_at
+FROM warehouse_stock
+WHERE quantity <> 0;
@@ -0,0 +1,18 @@
+DROP TABLE IF EXISTS automation_rule_firings;
+
+DROP TRIGGER IF EXISTS trigger_returns_status_changed_at ON returns;
+DROP TRIGGER IF EXISTS trigger_shipments_status_changed_at ON shipments;
+DROP TRIGGER IF EXISTS trigger_orders_status_changed_at ON orders;
+DROP FUNCTION IF EXISTS set_status_changed_at();
+
+DROP INDEX IF EXISTS idx_returns_status_changed;
+DROP INDEX IF EXISTS idx_shipments_status_changed;
+DROP INDEX IF EXISTS idx_orders_status_changed;
+ALTER TABLE returns DROP COLUMN IF EXISTS status_changed_at;
+ALTER TABLE shipments DROP COLUMN IF EXISTS status_changed_at;
+ALTER TABLE orders DROP COLUMN IF EXISTS status_changed_at;
+
+DROP INDEX IF EXISTS idx_automation_rules_next_run;
+ALTER TABLE automation_rules
+    DROP COLUMN IF EXISTS next_run_at,
+    DROP COLUMN IF EXISTS trigger_config;
@@ -0,0 +1,69 @@
+-- Migration 000058: Scheduled and status duration automation triggers
+--
+-- Rules with trigger_event "schedule" run on the cron schedule in
+-- trigger_config; "<entity>.status_duration" rules fire for entities that have
+-- stayed in a status for a duration. Both are evaluated by
+-- AutomationScheduleWorker.
+ALTER TABLE automation_rules
+    ADD COLUMN trigger_config JSONB NOT NULL DEFAULT '{}',
+    ADD COLUMN next_run_at    TIMESTAMPTZ;
+
+CREATE INDEX idx_automation_rules_next_run ON automation_rules(next_run_at)
+    WHERE enabled AND trigger_event = 'schedule';
+
+-- When an entity entered its current status. Existing rows are backfilled
+-- from updated_at, with the updated_at triggers disabled so they keep it.
+CREATE OR REPLACE FUNCTION set_status_changed_at()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'INSERT' OR NEW.status IS DISTINCT FROM OLD.status THEN
+        NEW.status_changed_at = NOW();
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+ALTER TABLE orders ADD COLUMN status_changed_at TIMESTAMPTZ NOT NULL DEFAULT NOW();
+ALTER TABLE orders DISABLE TRIGGER trigger_orders_updated_at;
+UPDATE orders SET status_changed_at = updated_at;
+ALTER TABLE orders ENABLE TRIGGER trigger_orders_updated_at;
+CREATE TRIGGER trigger_orders_status_changed_at
+    BEFORE INSERT OR UPDATE OF status ON orders
+    FOR EACH ROW EXECUTE FUNCTION set_status_changed_at();
+CREATE INDEX idx_orders_status_changed ON orders(tenant_id, status, status_changed_at);
+
+ALTER TABLE shipments ADD COLUMN status_changed_at TIMESTAMPTZ NOT NULL DEFAULT NOW();
+ALTER TABLE shipments DISABLE TRIGGER trigger_shipments_updated_at;
+UPDATE shipments SET status_changed_at = updated_at;
+ALTER TABLE shipments ENABLE TRIGGER trigger_shipments_updated_at;
+CREATE TRIGGER trigger_shipments_status_changed_at
+    BEFORE INSERT OR UPDATE OF status ON shipments
+    FOR EACH ROW EXECUTE FUNCTION set_status_changed_at();
+CREATE INDEX idx_shipments_status_changed ON shipments(tenant_id, status, status_changed_at);
+
+ALTER TABLE returns ADD COLUMN status_changed_at TIMESTAMPTZ NOT NULL DEFAULT NOW();
+ALTER TABLE returns DISABLE TRIGGER trigger_returns_updated_at;
+UPDATE returns SET status_changed_at = updated_at;
+ALTER TABLE returns ENABLE TRIGGER trigger_returns_updated_at;
+CREATE TRIGGER trigger_returns_status_changed_at
+    BEFORE INSERT OR UPDATE OF status ON returns
+    FOR EACH ROW EXECUTE FUNCTION set_status_changed_at();
+CREATE INDEX idx_returns_status_changed ON returns(tenant_id, status, status_changed_at);
+
+-- One row per rule and entity a status duration rule has fired for, so each
+-- entity fires at most once per rule.
+CREATE TABLE automation_rule_firings (
+    tenant_id   UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    rule_id     UUID NOT NULL REFERENCES automation_rules(id) ON DELETE CASCADE,
+    entity_type TEXT NOT NULL,
+    entity_id   UUID NOT NULL,
+    fired_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (rule_id, entity_id)
+);
+
+CREATE INDEX idx_automation_rule_firings_tenant ON automation_rule_firings(tenant_id);
+ALTER TABLE automation_rule_firings ENABLE ROW LEVEL SECURITY;
+ALTER TABLE automation_rule_firings FORCE ROW LEVEL SECURITY;
+CREATE POLICY automation_rule_firings_tenant_isolation ON automation_rule_firings
+    USING (tenant_id = current_setting('app.current_tenant_id', true)::uuid);
+GRANT SELECT, INSERT ON automation_rule_firings TO openoms_app;
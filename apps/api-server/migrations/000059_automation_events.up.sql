@@ -0,0 +1,37 @@
+-- Migration 000059: Durable automation event queue
+--
+-- Services insert automation events in the transaction of the change that
+-- caused them; AutomationEventWorker consumes them with retries. seq orders
+-- the events of each entity: an event is only processed once every earlier
+-- event of its entity is done or dead.
+CREATE TABLE automation_events (
+    id              UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    seq             BIGSERIAL NOT NULL,
+    tenant_id       UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    event_type      TEXT NOT NULL,
+    entity_type     TEXT NOT NULL,
+    entity_id       UUID NOT NULL,
+    data            JSONB NOT NULL DEFAULT '{}',
+    status          TEXT NOT NULL DEFAULT 'pending'
+                    CHECK (status IN ('pending', 'processing', 'done', 'dead')),
+    attempts        INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    locked_at       TIMESTAMPTZ,
+    last_error      TEXT,
+    processed_at    TIMESTAMPTZ,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_automation_events_due ON automation_events(next_attempt_at)
+    WHERE status IN ('pending', 'processing');
+CREATE INDEX idx_automation_events_entity ON automation_events(tenant_id, entity_type, entity_id, seq)
+    WHERE status IN ('pending', 'processing');
+CREATE INDEX idx_automation_events_tenant_status ON automation_events(tenant_id, status, created_at DESC);
+CREATE INDEX idx_automation_events_processed ON automation_events(processed_at) WHERE status = 'done';
+
+ALTER TABLE automation_events ENABLE ROW LEVEL SECURITY;
+ALTER TABLE automation_events FORCE ROW LEVEL SECURITY;
+CREATE POLICY automation_events_tenant_isolation ON automation_events
+    USING (tenant_id = current_setting('app.current_tenant_id', true)::uuid);
+GRANT SELECT, INSERT, UPDATE, DELETE ON automation_events TO openoms_app;
+GRANT USAGE, SELECT ON SEQUENCE automation_events_seq_seq TO openoms_app;
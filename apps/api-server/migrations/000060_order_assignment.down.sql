@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_orders_assigned_to;
+ALTER TABLE orders DROP COLUMN IF EXISTS assigned_to;
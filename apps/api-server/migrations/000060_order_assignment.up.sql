@@ -0,0 +1,6 @@
+-- Migration 000060: Order assignment
+--
+-- Orders can be assigned to a user, e.g. by the assign_user automation action.
+ALTER TABLE orders ADD COLUMN assigned_to UUID REFERENCES users(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_orders_assigned_to ON orders(tenant_id, assigned_to) WHERE assigned_to IS NOT NULL;
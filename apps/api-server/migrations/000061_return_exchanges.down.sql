@@ -0,0 +1,3 @@
+ALTER TABLE returns DROP COLUMN IF EXISTS replacement_order_id;
+ALTER TABLE returns DROP COLUMN IF EXISTS refunded_amount;
+ALTER TABLE returns DROP COLUMN IF EXISTS return_type;
@@ -0,0 +1,9 @@
+-- Migration 000061: Return exchanges and partial refunds
+--
+-- Returns are either refunded or exchanged for a replacement order. Refunds
+-- may be split into several payments tracked in refunded_amount.
+ALTER TABLE returns ADD COLUMN return_type VARCHAR(20) NOT NULL DEFAULT 'refund';
+ALTER TABLE returns ADD COLUMN refunded_amount DECIMAL(12,2) NOT NULL DEFAULT 0;
+ALTER TABLE returns ADD COLUMN replacement_order_id UUID REFERENCES orders(id) ON DELETE SET NULL;
+
+UPDATE returns SET refunded_amount = refund_amount WHERE status = 'refunded';
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_webhook_deliveries_order;
+DROP INDEX IF EXISTS idx_automation_rule_logs_entity;
+DROP TABLE IF EXISTS order_events;
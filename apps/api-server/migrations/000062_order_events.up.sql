@@ -0,0 +1,30 @@
+-- Migration 000062: Order events and timeline indexes
+--
+-- order_events records what happened to an order outside the audit log:
+-- notifications sent to the customer and sync actions against the order's
+-- marketplace. The order timeline merges it with the audit log, automation
+-- rule logs and webhook deliveries, which get indexes for per-order lookups.
+CREATE TABLE order_events (
+    id          UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id   UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    order_id    UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    source      TEXT NOT NULL CHECK (source IN ('notification', 'marketplace')),
+    event_type  TEXT NOT NULL,
+    actor_id    UUID REFERENCES users(id) ON DELETE SET NULL,
+    data        JSONB NOT NULL DEFAULT '{}',
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_order_events_order ON order_events(tenant_id, order_id, created_at DESC);
+
+ALTER TABLE order_events ENABLE ROW LEVEL SECURITY;
+ALTER TABLE order_events FORCE ROW LEVEL SECURITY;
+CREATE POLICY order_events_tenant_isolation ON order_events
+    USING (tenant_id = current_setting('app.current_tenant_id', true)::uuid);
+GRANT SELECT, INSERT ON order_events TO openoms_app;
+
+CREATE INDEX idx_automation_rule_logs_entity ON automation_rule_logs(tenant_id, entity_id, executed_at DESC);
+
+-- Webhook payloads reference their order as order_id (shipments, returns,
+-- status changes) or, for order events, as id.
+CREATE INDEX idx_webhook_deliveries_order ON webhook_deliveries(tenant_id, (COALESCE(payload->>'order_id', payload->>'id')));
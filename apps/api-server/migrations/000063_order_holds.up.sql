@@ -0,0 +1,30 @@
+-- Migration 000063: Order holds
+--
+-- An order hold blocks fulfilment of an order for a reason: fraud check,
+-- address verification, awaiting payment, out of stock or customer request.
+-- An order may have several active holds, at most one per reason; a hold is
+-- active until released_at is set. Holds awaiting payment or stock are
+-- released automatically once the order is paid or its lines are in stock.
+CREATE TABLE order_holds (
+    id            UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tenant_id     UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    order_id      UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    reason        TEXT NOT NULL CHECK (reason IN ('fraud_check', 'address_verification', 'awaiting_payment', 'out_of_stock', 'customer_request')),
+    note          TEXT NOT NULL DEFAULT '',
+    source        TEXT NOT NULL CHECK (source IN ('manual', 'automation', 'import')),
+    created_by    UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    released_at   TIMESTAMPTZ,
+    released_by   UUID REFERENCES users(id) ON DELETE SET NULL,
+    release_note  TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX idx_order_holds_order ON order_holds(tenant_id, order_id, created_at DESC);
+CREATE UNIQUE INDEX idx_order_holds_active_reason ON order_holds(order_id, reason) WHERE released_at IS NULL;
+CREATE INDEX idx_order_holds_active ON order_holds(tenant_id, created_at) WHERE released_at IS NULL;
+
+ALTER TABLE order_holds ENABLE ROW LEVEL SECURITY;
+ALTER TABLE order_holds FORCE ROW LEVEL SECURITY;
+CREATE POLICY order_holds_tenant_isolation ON order_holds
+    USING (tenant_id = current_setting('app.current_tenant_id', true)::uuid);
+GRANT SELECT, INSERT, UPDATE ON order_holds TO openoms_app;
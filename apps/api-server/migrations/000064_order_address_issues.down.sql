@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_orders_address_issues;
+ALTER TABLE orders DROP COLUMN IF EXISTS address_issues;
@@ -0,0 +1,9 @@
+-- Migration 000064: Shipping address validation
+--
+-- Problems found in the shipping address when an order is imported or its
+-- address edited (postal code format, postal code not matching the city,
+-- missing house number, invalid phone). NULL when the address was not checked.
+ALTER TABLE orders ADD COLUMN address_issues JSONB;
+
+CREATE INDEX idx_orders_address_issues ON orders(tenant_id)
+    WHERE jsonb_array_length(address_issues) > 0;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_orders_risk_score;
+ALTER TABLE orders DROP COLUMN IF EXISTS risk_signals;
+ALTER TABLE orders DROP COLUMN IF EXISTS risk_score;
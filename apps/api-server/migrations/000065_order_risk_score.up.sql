@@ -0,0 +1,10 @@
+-- Migration 000065: Order risk scoring
+--
+-- Fraud risk score (0-100) computed when an order is imported, from customer
+-- history, order facts and velocity per phone and address, with the signals
+-- that contributed to it. NULL when the order was not scored.
+ALTER TABLE orders ADD COLUMN risk_score SMALLINT CHECK (risk_score BETWEEN 0 AND 100);
+ALTER TABLE orders ADD COLUMN risk_signals JSONB;
+
+CREATE INDEX idx_orders_risk_score ON orders(tenant_id, risk_score)
+    WHERE risk_score IS NOT NULL;
@@ -0,0 +1,21 @@
+-- Migration 000066: De-duplication of automation actions across retries
+--
+-- A queued event whose rules fail is processed again from the start. Actions
+-- that must not repeat (messages, tickets, webhooks) record a row per event,
+-- rule and action once they succeed, and are skipped when the event is
+-- retried. The rows go with their event when processed events are pruned.
+CREATE TABLE automation_action_runs (
+    tenant_id    UUID NOT NULL REFERENCES tenants(id) ON DELETE CASCADE,
+    event_id     UUID NOT NULL REFERENCES automation_events(id) ON DELETE CASCADE,
+    rule_id      UUID NOT NULL,
+    action_index INT NOT NULL,
+    executed_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (event_id, rule_id, action_index)
+);
+
+CREATE INDEX idx_automation_action_runs_tenant ON automation_action_runs(tenant_id);
+ALTER TABLE automation_action_runs ENABLE ROW LEVEL SECURITY;
+ALTER TABLE automation_action_runs FORCE ROW LEVEL SECURITY;
+CREATE POLICY automation_action_runs_tenant_isolation ON automation_action_runs
+    USING (tenant_id = current_setting('app.current_tenant_id', true)::uuid);
+GRANT SELECT, INSERT ON automation_action_runs TO openoms_app;
@@ -0,0 +1 @@
+ALTER TABLE automation_events DROP COLUMN IF EXISTS rule_id;
@@ -0,0 +1,7 @@
+-- Migration 000067: Queued events of time-based automation rules
+--
+-- Schedule and status duration rules enqueue their events in the transaction
+-- that claims the run, so they are retried and dead-lettered like other
+-- events. Such an event targets one rule rather than every rule of its type.
+ALTER TABLE automation_events
+    ADD COLUMN rule_id UUID REFERENCES automation_rules(id) ON DELETE CASCADE;
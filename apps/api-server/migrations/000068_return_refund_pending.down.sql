@@ -0,0 +1,3 @@
+ALTER TABLE returns
+    DROP COLUMN IF EXISTS refund_started_at,
+    DROP COLUMN IF EXISTS refund_key;
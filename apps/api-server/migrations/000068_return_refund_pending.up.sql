@@ -0,0 +1,8 @@
+-- Migration 000068: Pending channel refunds of returns
+--
+-- A channel refund is marked pending under a key before the marketplace is
+-- called outside the transaction, and recorded in a second transaction. The
+-- key blocks a second refund of the return while the first is in flight.
+ALTER TABLE returns
+    ADD COLUMN refund_key UUID,
+    ADD COLUMN refund_started_at TIMESTAMPTZ;
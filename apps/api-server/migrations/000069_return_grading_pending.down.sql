@@ -0,0 +1,2 @@
+ALTER TABLE returns
+    DROP COLUMN IF EXISTS grading_pending;
@@ -0,0 +1,7 @@
+-- Migration 000069: Returns received from carrier tracking
+--
+-- A return whose inbound parcel is delivered is received automatically, but
+-- its lines are graded by the warehouse later. Until then nothing is
+-- restocked and the refund is not calculated.
+ALTER TABLE returns
+    ADD COLUMN grading_pending BOOLEAN NOT NULL DEFAULT false;
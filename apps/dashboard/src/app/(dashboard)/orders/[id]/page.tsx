@@ -4,13 +4,14 @@ import { useState, useEffect } from "react";
 import { useParams, useRouter } from "next/navigation";
 import Link from "next/link";
 import { toast } from "sonner";
-import { Package, RotateCcw, Printer, FileText, Scissors, GitBranch, Headphones, Loader2, Plus, ExternalLink, Copy, Check, StickyNote, Save, Tag, Send, ChevronDown } from "lucide-react";
+import { Package, RotateCcw, Printer, FileText, Scissors, GitBranch, Headphones, Loader2, Plus, ExternalLink, Copy, Check, StickyNote, Save, Tag, Send, ChevronDown, Truck } from "lucide-react";
 import { RateShopping } from "@/components/shipping/rate-shopping";
 import { AllegroShipmentDialog } from "@/components/integrations/allegro-shipment-dialog";
 import { useAllegroCarriers, useAllegroFulfillment, useAllegroTracking } from "@/hooks/use-allegro";
 import { useOrder, useUpdateOrder, useDeleteOrder, useTransitionOrderStatus, useDuplicateOrder } from "@/hooks/use-orders";
 import { useShipments } from "@/hooks/use-shipments";
 import { useReturns } from "@/hooks/use-returns";
+import { useOrderSupplierOrders, useRouteOrderToSuppliers } from "@/hooks/use-dropship";
 import { useOrderGroups, useSplitOrder } from "@/hooks/use-order-groups";
 import { useOrderTickets, useCreateOrderTicket } from "@/hooks/use-helpdesk";
 import {
@@ -24,6 +25,9 @@ import {
 import { Input } from "@/components/ui/input";
 import { Label } from "@/components/ui/label";
 import { OrderTimeline } from "@/components/orders/order-timeline";
+import { OrderHoldsCard } from "@/components/orders/order-holds-card";
+import { AddressIssuesCard } from "@/components/orders/address-issues-card";
+import { OrderRiskCard } from "@/components/orders/order-risk-card";
 import { OrderForm } from "@/components/orders/order-form";
 import { OrderStatusActions } from "@/components/orders/order-status-actions";
 import { StatusBadge } from "@/components/shared/status-badge";
@@ -49,7 +53,7 @@ import {
   SelectTrigger,
   SelectValue,
 } from "@/components/ui/select";
-import { ORDER_STATUSES, PAYMENT_STATUSES, SHIPMENT_STATUSES, RETURN_STATUSES, ORDER_PRIORITIES } from "@/lib/constants";
+import { ORDER_STATUSES, PAYMENT_STATUSES, SHIPMENT_STATUSES, RETURN_STATUSES, ORDER_PRIORITIES, SUPPLIER_ORDER_STATUSES } from "@/lib/constants";
 import { useOrderStatuses, statusesToMap } from "@/hooks/use-order-statuses";
 import { useCustomFields } from "@/hooks/use-custom-fields";
 import { formatDate, formatCurrency, shortId, cn } from "@/lib/utils";
@@ -126,6 +130,8 @@ export default function OrderDetailPage() {
 
   const { data: shipmentsData, isLoading: isLoadingShipments } = useShipments({ order_id: params.id });
   const { data: returnsData, isLoading: isLoadingReturns } = useReturns({ order_id: params.id });
+  const { data: supplierOrders, isLoading: isLoadingSupplierOrders } = useOrderSupplierOrders(params.id);
+  const routeToSuppliers = useRouteOrderToSuppliers(params.id);
   const { data: orderGroups } = useOrderGroups(params.id);
   const splitOrder = useSplitOrder(params.id);
   const { data: ticketsData, isLoading: isLoadingTickets } = useOrderTickets(params.id);
@@ -542,6 +548,16 @@ export default function OrderDetailPage() {
             </CollapsibleSection>
           )}
 
+          {order.address_issues && order.address_issues.length > 0 && (
+            <AddressIssuesCard issues={order.address_issues} />
+          )}
+
+          {order.risk_score != null && (
+            <OrderRiskCard score={order.risk_score} signals={order.risk_signals ?? []} />
+          )}
+
+          <OrderHoldsCard orderId={params.id} />
+
           <Card>
             <CardHeader>
               <CardTitle>Zmiana statusu</CardTitle>
@@ -668,6 +684,79 @@ export default function OrderDetailPage() {
             )}
           </CollapsibleSection>
 
+          <CollapsibleSection
+            title="Dostawcy (dropshipping)"
+            icon={Truck}
+            defaultOpen={!!(supplierOrders && supplierOrders.length > 0)}
+            badge={
+              supplierOrders && supplierOrders.length > 0 ? (
+                <span className="rounded-full bg-muted px-2 py-0.5 text-xs font-medium text-muted-foreground">
+                  {supplierOrders.length}
+                </span>
+              ) : undefined
+            }
+            headerAction={
+              <Button
+                variant="outline"
+                size="sm"
+                disabled={routeToSuppliers.isPending}
+                onClick={() =>
+                  routeToSuppliers.mutate(undefined, {
+                    onSuccess: (routing) => {
+                      if (routing.supplier_orders.length > 0) {
+                        toast.success(`Utworzono zamówienia u dostawców: ${routing.supplier_orders.length}`);
+                      } else {
+                        toast.info("Brak pozycji do skierowania do dostawców");
+                      }
+                      routing.warnings.forEach((w) => toast.warning(w));
+                    },
+                    onError: (error) => toast.error(getErrorMessage(error)),
+                  })
+                }
+              >
+                <Send className="h-4 w-4" />
+                Skieruj do dostawców
+              </Button>
+            }
+          >
+            {isLoadingSupplierOrders ? (
+              <div className="space-y-2">
+                <Skeleton className="h-4 w-full" />
+                <Skeleton className="h-4 w-3/4" />
+              </div>
+            ) : supplierOrders && supplierOrders.length > 0 ? (
+              <Table>
+                <TableHeader>
+                  <TableRow>
+                    <TableHead>Dostawca</TableHead>
+                    <TableHead>Status</TableHead>
+                    <TableHead>Pozycje</TableHead>
+                    <TableHead>Przesyłka</TableHead>
+                  </TableRow>
+                </TableHeader>
+                <TableBody>
+                  {supplierOrders.map((so) => (
+                    <TableRow key={so.id}>
+                      <TableCell className="font-medium">{so.supplier_name}</TableCell>
+                      <TableCell>
+                        <StatusBadge status={so.status} statusMap={SUPPLIER_ORDER_STATUSES} />
+                      </TableCell>
+                      <TableCell className="max-w-[240px] truncate">
+                        {so.items.map((i) => `${i.quantity}× ${i.name}`).join(", ")}
+                      </TableCell>
+                      <TableCell className="font-mono text-xs">{so.tracking_number || "---"}</TableCell>
+                    </TableRow>
+                  ))}
+                </TableBody>
+              </Table>
+            ) : (
+              <div className="flex flex-col items-center justify-center py-8 text-center">
+                <Truck className="h-8 w-8 text-muted-foreground/50 mb-2" />
+                <p className="text-sm text-muted-foreground">Żadna pozycja nie jest realizowana przez dostawcę.</p>
+              </div>
+            )}
+          </CollapsibleSection>
+
           {/* Helpdesk Tickets */}
           <CollapsibleSection
             title="Zgłoszenia"
@@ -0,0 +1,184 @@
+"use client";
+
+import { useState } from "react";
+import { useRouter } from "next/navigation";
+import { PauseCircle } from "lucide-react";
+import { useHeldOrders } from "@/hooks/use-order-holds";
+import { DataTable, type ColumnDef } from "@/components/shared/data-table";
+import { DataTablePagination } from "@/components/shared/data-table-pagination";
+import { StatusBadge } from "@/components/shared/status-badge";
+import { EmptyState } from "@/components/shared/empty-state";
+import { Badge } from "@/components/ui/badge";
+import { Button } from "@/components/ui/button";
+import {
+  Select,
+  SelectContent,
+  SelectItem,
+  SelectTrigger,
+  SelectValue,
+} from "@/components/ui/select";
+import { HOLD_REASONS, HOLD_SOURCES, ORDER_STATUSES } from "@/lib/constants";
+import { useOrderStatuses, statusesToMap } from "@/hooks/use-order-statuses";
+import { formatDate, formatCurrency, shortId } from "@/lib/utils";
+import type { HeldOrder, HoldReason, HoldSource } from "@/types/api";
+
+export default function HeldOrdersPage() {
+  const router = useRouter();
+  const [reasonFilter, setReasonFilter] = useState<HoldReason | "">("");
+  const [sourceFilter, setSourceFilter] = useState<HoldSource | "">("");
+  const [limit, setLimit] = useState(20);
+  const [offset, setOffset] = useState(0);
+
+  const { data: statusConfig } = useOrderStatuses();
+  const orderStatuses = statusConfig ? statusesToMap(statusConfig) : ORDER_STATUSES;
+
+  const { data, isLoading, isError, refetch } = useHeldOrders({
+    reason: reasonFilter || undefined,
+    source: sourceFilter || undefined,
+    limit,
+    offset,
+  });
+
+  const columns: ColumnDef<HeldOrder>[] = [
+    {
+      header: "Zamówienie",
+      accessorKey: "order_id",
+      cell: (row) => (
+        <span className="font-mono text-xs">{row.external_id || shortId(row.order_id)}</span>
+      ),
+    },
+    {
+      header: "Status",
+      accessorKey: "status",
+      cell: (row) => <StatusBadge status={row.status} statusMap={orderStatuses} />,
+    },
+    {
+      header: "Klient",
+      accessorKey: "customer_name",
+    },
+    {
+      header: "Powody wstrzymania",
+      accessorKey: "holds",
+      cell: (row) => (
+        <div className="flex flex-wrap gap-1">
+          {row.holds.map((hold) => (
+            <Badge key={hold.id} variant={hold.auto_release ? "secondary" : "warning"}>
+              {HOLD_REASONS[hold.reason] || hold.reason}
+            </Badge>
+          ))}
+        </div>
+      ),
+    },
+    {
+      header: "Kwota",
+      accessorKey: "total_amount",
+      cell: (row) => formatCurrency(row.total_amount, row.currency),
+    },
+    {
+      header: "Wstrzymane od",
+      accessorKey: "held_since",
+      cell: (row) => formatDate(row.held_since),
+    },
+  ];
+
+  const handleReasonChange = (value: string) => {
+    setReasonFilter(value === "all" ? "" : (value as HoldReason));
+    setOffset(0);
+  };
+
+  const handleSourceChange = (value: string) => {
+    setSourceFilter(value === "all" ? "" : (value as HoldSource));
+    setOffset(0);
+  };
+
+  const handlePageSizeChange = (newLimit: number) => {
+    setLimit(newLimit);
+    setOffset(0);
+  };
+
+  return (
+    <div className="space-y-6">
+      <div>
+        <h1 className="text-2xl font-bold">Wstrzymane zamówienia</h1>
+        <p className="text-muted-foreground mt-1">
+          Zamówienia zablokowane przed realizacją, od najdłużej oczekujących
+        </p>
+      </div>
+
+      <div className="flex items-center gap-4">
+        <div className="w-[260px]">
+          <Select value={reasonFilter || "all"} onValueChange={handleReasonChange}>
+            <SelectTrigger className="w-full">
+              <SelectValue placeholder="Powód" />
+            </SelectTrigger>
+            <SelectContent>
+              <SelectItem value="all">Wszystkie powody</SelectItem>
+              {Object.entries(HOLD_REASONS).map(([value, label]) => (
+                <SelectItem key={value} value={value}>
+                  {label}
+                </SelectItem>
+              ))}
+            </SelectContent>
+          </Select>
+        </div>
+        <div className="w-[200px]">
+          <Select value={sourceFilter || "all"} onValueChange={handleSourceChange}>
+            <SelectTrigger className="w-full">
+              <SelectValue placeholder="Źródło" />
+            </SelectTrigger>
+            <SelectContent>
+              <SelectItem value="all">Wszystkie źródła</SelectItem>
+              {Object.entries(HOLD_SOURCES).map(([value, label]) => (
+                <SelectItem key={value} value={value}>
+                  {label}
+                </SelectItem>
+              ))}
+            </SelectContent>
+          </Select>
+        </div>
+      </div>
+
+      {isError && (
+        <div className="rounded-md border border-destructive bg-destructive/10 p-4">
+          <p className="text-sm text-destructive">
+            Wystąpił błąd podczas ładowania danych. Spróbuj odświeżyć stronę.
+          </p>
+          <Button
+            variant="outline"
+            size="sm"
+            className="mt-2"
+            onClick={() => refetch()}
+          >
+            Spróbuj ponownie
+          </Button>
+        </div>
+      )}
+
+      <div className="rounded-md border">
+        <DataTable<HeldOrder>
+          columns={columns}
+          data={data?.items || []}
+          isLoading={isLoading}
+          emptyState={
+            <EmptyState
+              icon={PauseCircle}
+              title="Brak wstrzymanych zamówień"
+              description="Żadne zamówienie nie czeka na zwolnienie wstrzymania."
+            />
+          }
+          onRowClick={(row) => router.push(`/orders/${row.order_id}`)}
+        />
+      </div>
+
+      {data && (
+        <DataTablePagination
+          total={data.total}
+          limit={limit}
+          offset={offset}
+          onPageChange={setOffset}
+          onPageSizeChange={handlePageSizeChange}
+        />
+      )}
+    </div>
+  );
+}
@@ -8,19 +8,22 @@ import { DataTable, type ColumnDef, type EditableColumnConfig } from "@/componen
 import { DataTablePagination } from "@/components/shared/data-table-pagination";
 import { StatusBadge } from "@/components/shared/status-badge";
 import { OrderFilters } from "@/components/orders/order-filters";
+import { SavedViews } from "@/components/orders/saved-views";
 import { BulkActions } from "@/components/orders/bulk-actions";
+import { BulkJobActions } from "@/components/orders/bulk-job-actions";
+import { BulkJobsPanel } from "@/components/orders/bulk-jobs-panel";
 import { KanbanBoard } from "@/components/orders/kanban-board";
 import { Button } from "@/components/ui/button";
 import { ORDER_STATUSES, PAYMENT_STATUSES, ORDER_SOURCE_LABELS, ORDER_PRIORITIES } from "@/lib/constants";
 import { useOrderStatuses, statusesToMap } from "@/hooks/use-order-statuses";
 import { formatDate, formatCurrency, shortId, cn } from "@/lib/utils";
-import { Download, ShoppingCart, Merge, Printer, LayoutGrid, Columns3 } from "lucide-react";
+import { Download, ShoppingCart, Merge, LayoutGrid, Columns3 } from "lucide-react";
 import { EmptyState } from "@/components/shared/empty-state";
 import { apiClient } from "@/lib/api-client";
 import { getErrorMessage } from "@/lib/api-client";
 import { useQueryClient } from "@tanstack/react-query";
 import { useMergeOrders } from "@/hooks/use-order-groups";
-import { useBatchLabels } from "@/hooks/use-shipments";
+import { orderFilterValues } from "@/lib/order-filters";
 import { toast } from "sonner";
 import {
   Tooltip,
@@ -37,7 +40,7 @@ import {
   DialogHeader,
   DialogTitle,
 } from "@/components/ui/dialog";
-import type { Order } from "@/types/api";
+import type { Order, OrderFilterParams } from "@/types/api";
 
 type ViewMode = "table" | "kanban";
 
@@ -64,16 +67,17 @@ export default function OrdersPage() {
   const queryClient = useQueryClient();
   const { data: statusConfig } = useOrderStatuses();
   const orderStatuses = statusConfig ? statusesToMap(statusConfig) : ORDER_STATUSES;
-  const [filters, setFilters] = useState<{ status?: string; source?: string; search?: string; payment_status?: string; tag?: string; priority?: string }>({});
+  const [filters, setFilters] = useState<OrderFilterParams>({});
   const [limit, setLimit] = useState(20);
   const [offset, setOffset] = useState(0);
   const [selectedIds, setSelectedIds] = useState<Set<string>>(new Set());
+  // Extends the selection to every order matching the filters (bulk jobs only).
+  const [allMatching, setAllMatching] = useState(false);
   const [sortBy, setSortBy] = useState<string>("created_at");
   const [sortOrder, setSortOrder] = useState<"asc" | "desc">("desc");
   const [showMergeDialog, setShowMergeDialog] = useState(false);
   const [viewMode, setViewMode] = useViewMode();
   const mergeOrders = useMergeOrders();
-  const batchLabels = useBatchLabels();
 
   const handleSort = (column: string) => {
     if (sortBy === column) {
@@ -94,6 +98,15 @@ export default function OrdersPage() {
   });
 
   const selectedOrders = (data?.items || []).filter((o) => selectedIds.has(o.id));
+  const pageSelected = !!data && data.items.length > 0 && selectedOrders.length === data.items.length;
+  const total = data?.total ?? 0;
+
+  const handleSelectionChange = (ids: Set<string>) => {
+    setSelectedIds(ids);
+    setAllMatching(false);
+  };
+
+  const clearSelection = () => handleSelectionChange(new Set());
 
   const statusOptions = useMemo(() => {
     return Object.entries(orderStatuses).map(([key, val]) => ({
@@ -219,21 +232,21 @@ export default function OrdersPage() {
     },
   ];
 
-  const handleFilterChange = (newFilters: { status?: string; source?: string; search?: string; payment_status?: string; tag?: string; priority?: string }) => {
+  const handleFilterChange = (newFilters: OrderFilterParams) => {
     setFilters(newFilters);
     setOffset(0);
-    setSelectedIds(new Set());
+    clearSelection();
   };
 
   const handlePageSizeChange = (newLimit: number) => {
     setLimit(newLimit);
     setOffset(0);
-    setSelectedIds(new Set());
+    clearSelection();
   };
 
   const handlePageChange = (newOffset: number) => {
     setOffset(newOffset);
-    setSelectedIds(new Set());
+    clearSelection();
   };
 
   return (
@@ -285,7 +298,7 @@ export default function OrdersPage() {
             </div>
           </TooltipProvider>
 
-          <Button variant="outline" onClick={() => exportOrdersCSV({ ...filters, limit: 10000, offset: 0 })}>
+          <Button variant="outline" onClick={() => exportOrdersCSV(filters)}>
             <Download className="mr-2 h-4 w-4" />
             Eksportuj CSV
           </Button>
@@ -295,7 +308,10 @@ export default function OrdersPage() {
         </div>
       </div>
 
-      <OrderFilters filters={filters} onFilterChange={handleFilterChange} />
+      <div className="space-y-3">
+        <SavedViews filters={filters} onApply={handleFilterChange} />
+        <OrderFilters filters={filters} onFilterChange={handleFilterChange} />
+      </div>
 
       {isError && viewMode === "table" && (
         <div className="rounded-md border border-destructive bg-destructive/10 p-4">
@@ -313,14 +329,37 @@ export default function OrdersPage() {
         </div>
       )}
 
+      {viewMode === "table" && <BulkJobsPanel />}
+
       {viewMode === "table" && selectedIds.size > 0 && (
         <div className="space-y-3">
-          <BulkActions
-            selectedOrders={selectedOrders}
-            onClearSelection={() => setSelectedIds(new Set())}
-          />
+          {!allMatching && (
+            <BulkActions
+              selectedOrders={selectedOrders}
+              onClearSelection={clearSelection}
+            />
+          )}
+          {pageSelected && total > selectedIds.size && (
+            <div className="rounded-lg border bg-muted/50 p-3 text-sm">
+              {allMatching ? (
+                <>
+                  Zaznaczono wszystkie {total} pasujące zamówienia.{" "}
+                  <button className="font-medium text-primary hover:underline" onClick={clearSelection}>
+                    Odznacz
+                  </button>
+                </>
+              ) : (
+                <>
+                  Zaznaczono {selectedIds.size} zamówień na tej stronie.{" "}
+                  <button className="font-medium text-primary hover:underline" onClick={() => setAllMatching(true)}>
+                    Zaznacz wszystkie {total} pasujące
+                  </button>
+                </>
+              )}
+            </div>
+          )}
           <div className="flex gap-2">
-            {selectedIds.size >= 2 && (
+            {selectedIds.size >= 2 && !allMatching && (
               <Button
                 variant="outline"
                 size="sm"
@@ -330,41 +369,12 @@ export default function OrdersPage() {
                 Scal zamówienia ({selectedIds.size})
               </Button>
             )}
-            <Button
-              variant="outline"
-              size="sm"
-              onClick={async () => {
-                try {
-                  // Fetch shipments for selected orders and batch download labels
-                  const orderIds = Array.from(selectedIds);
-                  const responses = await Promise.all(
-                    orderIds.map((orderId) =>
-                      apiClient<{ items: { id: string; label_url?: string }[] }>(
-                        `/v1/shipments?order_id=${orderId}&limit=100`
-                      ).catch(() => ({ items: [] }))
-                    )
-                  );
-                  const shipmentIds = responses
-                    .flatMap((r) => r.items)
-                    .filter((s) => s.label_url)
-                    .map((s) => s.id);
-
-                  if (shipmentIds.length === 0) {
-                    toast.error("Brak przesylek z etykietami dla wybranych zamowien");
-                    return;
-                  }
-
-                  await batchLabels.mutateAsync({ shipment_ids: shipmentIds });
-                  toast.success(`Pobrano etykiety (${shipmentIds.length})`);
-                } catch (error) {
-                  toast.error(getErrorMessage(error));
-                }
-              }}
-              disabled={batchLabels.isPending}
-            >
-              <Printer className="mr-2 h-4 w-4" />
-              Generuj etykiety ({selectedIds.size})
-            </Button>
+            <BulkJobActions
+              orderIds={Array.from(selectedIds)}
+              filter={allMatching ? orderFilterValues(filters) : undefined}
+              count={allMatching ? total : selectedIds.size}
+              onStarted={clearSelection}
+            />
           </div>
         </div>
       )}
@@ -393,7 +403,7 @@ export default function OrdersPage() {
               onRowClick={(row) => router.push(`/orders/${row.id}`)}
               selectable
               selectedIds={selectedIds}
-              onSelectionChange={setSelectedIds}
+              onSelectionChange={handleSelectionChange}
               rowId={(row) => row.id}
               sortBy={sortBy}
               sortOrder={sortOrder}
@@ -447,7 +457,7 @@ export default function OrdersPage() {
                   });
                   toast.success("Zamówienia zostały scalone");
                   setShowMergeDialog(false);
-                  setSelectedIds(new Set());
+                  clearSelection();
                 } catch (error) {
                   toast.error(getErrorMessage(error));
                 }
@@ -41,6 +41,8 @@ import {
 } from "@/hooks/use-bundles";
 import { useProducts } from "@/hooks/use-products";
 import { ProductForm } from "@/components/products/product-form";
+import { FulfillmentRuleCard } from "@/components/products/fulfillment-rule-card";
+import { StockHistoryCard } from "@/components/products/stock-history-card";
 import {
   useProduct,
   useUpdateProduct,
@@ -614,6 +616,8 @@ export default function ProductDetailPage() {
             )}
           </CardContent>
         </Card>
+        <FulfillmentRuleCard productId={params.id} />
+        <StockHistoryCard productId={params.id} />
         </>
       )}
 
@@ -158,6 +158,7 @@ export default function ProductVariantsPage() {
     sku: string;
     ean: string;
     price_override: string;
+    cost_price: string;
     stock_quantity: string;
     weight: string;
     position: string;
@@ -168,6 +169,7 @@ export default function ProductVariantsPage() {
     sku: "",
     ean: "",
     price_override: "",
+    cost_price: "",
     stock_quantity: "0",
     weight: "",
     position: "0",
@@ -181,6 +183,7 @@ export default function ProductVariantsPage() {
       sku: "",
       ean: "",
       price_override: "",
+      cost_price: "",
       stock_quantity: "0",
       weight: "",
       position: "0",
@@ -201,6 +204,8 @@ export default function ProductVariantsPage() {
     if (createForm.ean) data.ean = createForm.ean;
     if (createForm.price_override)
       data.price_override = parseFloat(createForm.price_override);
+    if (createForm.cost_price)
+      data.cost_price = parseFloat(createForm.cost_price);
     if (createForm.weight) data.weight = parseFloat(createForm.weight);
 
     createVariant.mutate(data, {
@@ -423,7 +428,7 @@ export default function ProductVariantsPage() {
                 />
               </div>
             </div>
-            <div className="grid grid-cols-2 gap-4">
+            <div className="grid grid-cols-3 gap-4">
               <div>
                 <Label htmlFor="create-price">Cena nadpisana</Label>
                 <Input
@@ -440,6 +445,22 @@ export default function ProductVariantsPage() {
                   }
                 />
               </div>
+              <div>
+                <Label htmlFor="create-cost-price">Cena zakupu</Label>
+                <Input
+                  id="create-cost-price"
+                  type="number"
+                  step="0.01"
+                  min="0"
+                  value={createForm.cost_price}
+                  onChange={(e) =>
+                    setCreateForm({
+                      ...createForm,
+                      cost_price: e.target.value,
+                    })
+                  }
+                />
+              </div>
               <div>
                 <Label htmlFor="create-stock">Stan magazynowy</Label>
                 <Input
@@ -565,6 +586,7 @@ function EditVariantDialog({
     ean: variant.ean || "",
     price_override:
       variant.price_override != null ? String(variant.price_override) : "",
+    cost_price: variant.cost_price != null ? String(variant.cost_price) : "",
     stock_quantity: String(variant.stock_quantity),
     weight: variant.weight != null ? String(variant.weight) : "",
     position: String(variant.position),
@@ -583,6 +605,9 @@ function EditVariantDialog({
     const newPrice = form.price_override ? parseFloat(form.price_override) : undefined;
     if (newPrice !== variant.price_override) data.price_override = newPrice;
 
+    const newCost = form.cost_price ? parseFloat(form.cost_price) : undefined;
+    if (newCost !== variant.cost_price) data.cost_price = newCost;
+
     const newStock = parseInt(form.stock_quantity) || 0;
     if (newStock !== variant.stock_quantity) data.stock_quantity = newStock;
 
@@ -647,7 +672,7 @@ function EditVariantDialog({
               />
             </div>
           </div>
-          <div className="grid grid-cols-2 gap-4">
+          <div className="grid grid-cols-3 gap-4">
             <div>
               <Label htmlFor="edit-price">Cena nadpisana</Label>
               <Input
@@ -661,6 +686,19 @@ function EditVariantDialog({
                 }
               />
             </div>
+            <div>
+              <Label htmlFor="edit-cost-price">Cena zakupu</Label>
+              <Input
+                id="edit-cost-price"
+                type="number"
+                step="0.01"
+                min="0"
+                value={form.cost_price}
+                onChange={(e) =>
+                  setForm({ ...form, cost_price: e.target.value })
+                }
+              />
+            </div>
             <div>
               <Label htmlFor="edit-stock">Stan magazynowy</Label>
               <Input
@@ -31,6 +31,9 @@ import {
   TableRow,
 } from "@/components/ui/table";
 import { formatCurrency } from "@/lib/utils";
+import { ProfitabilityReport } from "@/components/reports/profitability-report";
+import { ScheduledReports } from "@/components/reports/scheduled-reports";
+import { InventoryCostingReport } from "@/components/reports/inventory-costing-report";
 import {
   useTopProducts,
   useRevenueBySource,
@@ -433,6 +436,9 @@ export default function ReportsPage() {
           <TabsTrigger value="products">Produkty</TabsTrigger>
           <TabsTrigger value="trends">Trendy</TabsTrigger>
           <TabsTrigger value="payments">Płatności</TabsTrigger>
+          <TabsTrigger value="profitability">Rentowność</TabsTrigger>
+          <TabsTrigger value="inventory">Magazyn</TabsTrigger>
+          <TabsTrigger value="scheduled">Raporty cykliczne</TabsTrigger>
         </TabsList>
 
         <TabsContent value="revenue" className="space-y-6">
@@ -451,6 +457,18 @@ export default function ReportsPage() {
         <TabsContent value="payments" className="space-y-6">
           <PaymentMethodChart />
         </TabsContent>
+
+        <TabsContent value="profitability" className="space-y-6">
+          <ProfitabilityReport />
+        </TabsContent>
+
+        <TabsContent value="inventory" className="space-y-6">
+          <InventoryCostingReport />
+        </TabsContent>
+
+        <TabsContent value="scheduled" className="space-y-6">
+          <ScheduledReports />
+        </TabsContent>
       </Tabs>
     </div>
     </AdminGuard>
@@ -23,7 +23,14 @@ import { Card, CardContent, CardHeader, CardTitle } from "@/components/ui/card";
 import { Separator } from "@/components/ui/separator";
 import { Skeleton } from "@/components/ui/skeleton";
 import { ConfirmDialog } from "@/components/ui/confirm-dialog";
-import { RETURN_STATUSES, RETURN_TRANSITIONS } from "@/lib/constants";
+import {
+  ExchangeReturnCard,
+  ReceiveReturnCard,
+  RefundReturnCard,
+  ReceivedItemsCard,
+  ReturnLabelCard,
+} from "@/components/returns/return-receiving";
+import { RETURN_STATUSES, RETURN_TRANSITIONS, RETURN_TYPES } from "@/lib/constants";
 import { formatDate, formatCurrency, shortId } from "@/lib/utils";
 import { getErrorMessage } from "@/lib/api-client";
 
@@ -39,16 +46,16 @@ const TRANSITION_LABELS: Record<string, string> = {
   approved: "Zatwierdź",
   rejected: "Odrzuć",
   cancelled: "Anuluj",
-  received: "Oznacz jako odebrane",
-  refunded: "Zwróć środki",
 };
 
+// Receiving, refunding and exchanging have their own cards with restock,
+// payment and replacement order side effects.
+const DEDICATED_TRANSITIONS = ["received", "refunded", "partially_refunded", "exchanged"];
+
 const TRANSITION_VARIANTS: Record<string, "default" | "destructive" | "outline" | "secondary"> = {
   approved: "default",
   rejected: "destructive",
   cancelled: "outline",
-  received: "default",
-  refunded: "default",
 };
 
 export default function ReturnDetailPage() {
@@ -83,11 +90,13 @@ export default function ReturnDetailPage() {
     setIsEditing(true);
   };
 
+  const refundCalculated = !!returnData?.received_at;
+
   const handleUpdate = async (data: EditFormValues) => {
     try {
       await updateReturn.mutateAsync({
         reason: data.reason,
-        refund_amount: data.refund_amount,
+        refund_amount: refundCalculated ? undefined : data.refund_amount,
         notes: data.notes || undefined,
       });
       toast.success("Zwrot został zaktualizowany");
@@ -140,7 +149,9 @@ export default function ReturnDetailPage() {
     );
   }
 
-  const allowedTransitions = RETURN_TRANSITIONS[returnData.status] || [];
+  const allowedTransitions = (RETURN_TRANSITIONS[returnData.status] || []).filter(
+    (status) => !DEDICATED_TRANSITIONS.includes(status)
+  );
 
   return (
     <div className="space-y-6">
@@ -190,8 +201,14 @@ export default function ReturnDetailPage() {
                   id="refund_amount"
                   type="number"
                   step="0.01"
+                  readOnly={refundCalculated}
                   {...register("refund_amount", { valueAsNumber: true })}
                 />
+                {refundCalculated && (
+                  <p className="text-sm text-muted-foreground">
+                    Kwota wyliczona z przyjętych pozycji i polityki zwrotu kosztów wysyłki
+                  </p>
+                )}
                 {errors.refund_amount && (
                   <p className="text-sm text-destructive">{errors.refund_amount.message}</p>
                 )}
@@ -243,11 +260,22 @@ export default function ReturnDetailPage() {
                       <StatusBadge status={returnData.status} statusMap={RETURN_STATUSES} />
                     </div>
                   </div>
+                  <div>
+                    <p className="text-sm text-muted-foreground">Rodzaj</p>
+                    <p className="mt-1 text-sm">
+                      {RETURN_TYPES[returnData.return_type] || returnData.return_type}
+                    </p>
+                  </div>
                   <div>
                     <p className="text-sm text-muted-foreground">Kwota zwrotu</p>
                     <p className="mt-1 font-medium">
                       {formatCurrency(returnData.refund_amount)}
                     </p>
+                    {returnData.status === "partially_refunded" && (
+                      <p className="text-xs text-muted-foreground">
+                        Zwrócono {formatCurrency(returnData.refunded_amount)}
+                      </p>
+                    )}
                   </div>
                   <div>
                     <p className="text-sm text-muted-foreground">Utworzono</p>
@@ -344,6 +372,28 @@ export default function ReturnDetailPage() {
               </CardContent>
             </Card>
 
+            {returnData.received_items?.length > 0 && (
+              <ReceivedItemsCard returnData={returnData} />
+            )}
+
+            {(returnData.status === "approved" || returnData.return_tracking_number) && (
+              <ReturnLabelCard returnData={returnData} />
+            )}
+
+            {(returnData.status === "approved" || returnData.grading_pending) && (
+              <ReceiveReturnCard returnData={returnData} />
+            )}
+
+            {returnData.return_type !== "exchange" &&
+              !returnData.grading_pending &&
+              (returnData.status === "received" || returnData.status === "partially_refunded") && (
+                <RefundReturnCard returnData={returnData} />
+              )}
+
+            {returnData.return_type === "exchange" &&
+              returnData.status === "received" &&
+              !returnData.grading_pending && <ExchangeReturnCard returnData={returnData} />}
+
             {allowedTransitions.length > 0 && (
               <Card>
                 <CardHeader>
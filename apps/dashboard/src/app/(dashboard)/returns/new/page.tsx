@@ -15,14 +15,23 @@ import { Input } from "@/components/ui/input";
 import { Label } from "@/components/ui/label";
 import { Textarea } from "@/components/ui/textarea";
 import { Card, CardContent, CardHeader, CardTitle } from "@/components/ui/card";
+import {
+  Select,
+  SelectContent,
+  SelectItem,
+  SelectTrigger,
+  SelectValue,
+} from "@/components/ui/select";
 import { OrderSearchCombobox } from "@/components/shared/order-search-combobox";
-import type { Order, OrderItem } from "@/types/api";
+import { RETURN_TYPES } from "@/lib/constants";
+import type { Order, OrderItem, ReturnType } from "@/types/api";
 
 const returnSchema = z.object({
   order_id: z.string().min(1, "ID zamówienia jest wymagane"),
   reason: z.string().min(1, "Powód zwrotu jest wymagany"),
   refund_amount: z.number().min(0, "Kwota musi być dodatnia"),
   notes: z.string().optional(),
+  return_type: z.enum(["refund", "exchange"]),
 });
 
 type ReturnFormValues = z.infer<typeof returnSchema>;
@@ -57,10 +66,12 @@ export default function NewReturnPage() {
       reason: "",
       refund_amount: 0,
       notes: "",
+      return_type: "refund",
     },
   });
 
   const orderId = watch("order_id");
+  const returnType = watch("return_type");
 
   // Fetch order items when order_id changes
   useEffect(() => {
@@ -123,6 +134,7 @@ export default function NewReturnPage() {
         reason: data.reason,
         refund_amount: data.refund_amount,
         notes: data.notes || undefined,
+        return_type: data.return_type,
         items: selectedItems.length > 0 ? selectedItems : undefined,
       });
       toast.success("Zwrot został utworzony");
@@ -242,6 +254,25 @@ export default function NewReturnPage() {
               )}
             </div>
 
+            <div className="space-y-2">
+              <Label>Rodzaj zwrotu</Label>
+              <Select
+                value={returnType}
+                onValueChange={(value) => setValue("return_type", value as ReturnType)}
+              >
+                <SelectTrigger className="w-72">
+                  <SelectValue />
+                </SelectTrigger>
+                <SelectContent>
+                  {Object.entries(RETURN_TYPES).map(([value, label]) => (
+                    <SelectItem key={value} value={value}>
+                      {label}
+                    </SelectItem>
+                  ))}
+                </SelectContent>
+              </Select>
+            </div>
+
             <div className="space-y-2">
               <Label htmlFor="refund_amount">Kwota zwrotu (PLN)</Label>
               <Input
@@ -6,6 +6,9 @@ import { toast } from "sonner";
 import { useAuth } from "@/hooks/use-auth";
 import { useCreateAutomationRule } from "@/hooks/use-automation";
 import { LoadingSkeleton } from "@/components/shared/loading-skeleton";
+import { TriggerConfigFields, triggerConfigFor } from "@/components/automation/trigger-config-fields";
+import { ActionParamsFields } from "@/components/automation/action-params-fields";
+import { ConditionTreeEditor, fromConditionGroup } from "@/components/automation/condition-tree-editor";
 import { Button } from "@/components/ui/button";
 import { Input } from "@/components/ui/input";
 import { Label } from "@/components/ui/label";
@@ -27,13 +30,11 @@ import {
 import {
   AUTOMATION_TRIGGER_EVENTS,
   AUTOMATION_TRIGGER_LABELS,
-  AUTOMATION_OPERATORS,
-  AUTOMATION_OPERATOR_LABELS,
   AUTOMATION_ACTION_TYPES,
   AUTOMATION_ACTION_LABELS,
 } from "@/lib/constants";
 import { ArrowLeft, Save, Plus, Trash2, Loader2 } from "lucide-react";
-import type { AutomationCondition, AutomationAction } from "@/types/api";
+import type { AutomationConditionGroup, AutomationTriggerConfig, AutomationAction } from "@/types/api";
 
 export default function NewAutomationRulePage() {
   const router = useRouter();
@@ -45,7 +46,8 @@ export default function NewAutomationRulePage() {
   const [enabled, setEnabled] = useState(true);
   const [priority, setPriority] = useState(0);
   const [triggerEvent, setTriggerEvent] = useState("");
-  const [conditions, setConditions] = useState<AutomationCondition[]>([]);
+  const [triggerConfig, setTriggerConfig] = useState<AutomationTriggerConfig>({});
+  const [conditions, setConditions] = useState<AutomationConditionGroup>({ op: "and", conditions: [] });
   const [actions, setActions] = useState<AutomationAction[]>([]);
 
   useEffect(() => {
@@ -58,18 +60,6 @@ export default function NewAutomationRulePage() {
     return <LoadingSkeleton />;
   }
 
-  const addCondition = () => {
-    setConditions([...conditions, { field: "", operator: "eq", value: "" }]);
-  };
-
-  const updateCondition = (index: number, updates: Partial<AutomationCondition>) => {
-    setConditions(conditions.map((c, i) => (i === index ? { ...c, ...updates } : c)));
-  };
-
-  const removeCondition = (index: number) => {
-    setConditions(conditions.filter((_, i) => i !== index));
-  };
-
   const addAction = () => {
     setActions([...actions, { type: "set_status", config: {}, delay_seconds: 0 }]);
   };
@@ -99,7 +89,8 @@ export default function NewAutomationRulePage() {
         enabled,
         priority,
         trigger_event: triggerEvent,
-        conditions,
+        trigger_config: triggerConfigFor(triggerEvent, triggerConfig),
+        conditions: fromConditionGroup(conditions),
         actions,
       });
       toast.success("Reguła została utworzona");
@@ -174,7 +165,7 @@ export default function NewAutomationRulePage() {
         <CardHeader>
           <CardTitle>Zdarzenie wyzwalające *</CardTitle>
         </CardHeader>
-        <CardContent>
+        <CardContent className="space-y-4">
           <Select value={triggerEvent || "none"} onValueChange={(v) => setTriggerEvent(v === "none" ? "" : v)}>
             <SelectTrigger className="w-full max-w-md">
               <SelectValue placeholder="Wybierz zdarzenie" />
@@ -188,75 +179,21 @@ export default function NewAutomationRulePage() {
               ))}
             </SelectContent>
           </Select>
+          <TriggerConfigFields
+            triggerEvent={triggerEvent}
+            value={triggerConfig}
+            onChange={setTriggerConfig}
+          />
         </CardContent>
       </Card>
 
       {/* Conditions */}
       <Card>
         <CardHeader>
-          <div className="flex items-center justify-between">
-            <CardTitle>Warunki</CardTitle>
-            <Button variant="outline" size="sm" onClick={addCondition}>
-              <Plus className="h-4 w-4" />
-              Dodaj warunek
-            </Button>
-          </div>
+          <CardTitle>Warunki</CardTitle>
         </CardHeader>
-        <CardContent className="space-y-4">
-          {conditions.length === 0 ? (
-            <p className="text-sm text-muted-foreground">
-              Brak warunków - reguła uruchomi się przy każdym zdarzeniu wybranego typu.
-            </p>
-          ) : (
-            conditions.map((condition, index) => (
-              <div key={index} className="flex items-start gap-3 rounded-md border p-3">
-                <div className="grid flex-1 grid-cols-1 gap-3 sm:grid-cols-3">
-                  <div className="space-y-1">
-                    <Label className="text-xs">Pole</Label>
-                    <Input
-                      value={condition.field}
-                      onChange={(e) => updateCondition(index, { field: e.target.value })}
-                      placeholder="np. status, total_amount"
-                    />
-                  </div>
-                  <div className="space-y-1">
-                    <Label className="text-xs">Operator</Label>
-                    <Select
-                      value={condition.operator}
-                      onValueChange={(v) => updateCondition(index, { operator: v })}
-                    >
-                      <SelectTrigger className="w-full">
-                        <SelectValue />
-                      </SelectTrigger>
-                      <SelectContent>
-                        {AUTOMATION_OPERATORS.map((op) => (
-                          <SelectItem key={op} value={op}>
-                            {AUTOMATION_OPERATOR_LABELS[op] || op}
-                          </SelectItem>
-                        ))}
-                      </SelectContent>
-                    </Select>
-                  </div>
-                  <div className="space-y-1">
-                    <Label className="text-xs">Wartość</Label>
-                    <Input
-                      value={String(condition.value ?? "")}
-                      onChange={(e) => updateCondition(index, { value: e.target.value })}
-                      placeholder="wartość"
-                    />
-                  </div>
-                </div>
-                <Button
-                  variant="ghost"
-                  size="sm"
-                  className="mt-5"
-                  onClick={() => removeCondition(index)}
-                >
-                  <Trash2 className="h-4 w-4 text-destructive" />
-                </Button>
-              </div>
-            ))
-          )}
+        <CardContent>
+          <ConditionTreeEditor value={conditions} onChange={setConditions} />
         </CardContent>
       </Card>
 
@@ -279,95 +216,47 @@ export default function NewAutomationRulePage() {
           ) : (
             actions.map((action, index) => (
               <div key={index} className="flex items-start gap-3 rounded-md border p-3">
-                <div className="grid flex-1 grid-cols-1 gap-3 sm:grid-cols-3">
-                  <div className="space-y-1">
-                    <Label className="text-xs">Typ akcji</Label>
-                    <Select
-                      value={action.type}
-                      onValueChange={(v) => updateAction(index, { type: v, config: {} })}
-                    >
-                      <SelectTrigger className="w-full">
-                        <SelectValue />
-                      </SelectTrigger>
-                      <SelectContent>
-                        {AUTOMATION_ACTION_TYPES.map((t) => (
-                          <SelectItem key={t} value={t}>
-                            {AUTOMATION_ACTION_LABELS[t] || t}
-                          </SelectItem>
-                        ))}
-                      </SelectContent>
-                    </Select>
-                  </div>
-                  <div className="space-y-1">
-                    <Label className="text-xs">
-                      {action.type === "set_status" && "Nowy status"}
-                      {action.type === "add_tag" && "Tag"}
-                      {action.type === "send_email" && "Adres e-mail"}
-                      {action.type === "create_invoice" && "Typ faktury"}
-                      {action.type === "webhook" && "URL webhooka"}
-                    </Label>
-                    <Input
-                      value={String(
-                        action.type === "set_status"
-                          ? action.config.status ?? ""
-                          : action.type === "add_tag"
-                          ? action.config.tag ?? ""
-                          : action.type === "send_email"
-                          ? action.config.to ?? ""
-                          : action.type === "create_invoice"
-                          ? action.config.invoice_type ?? "vat"
-                          : action.type === "webhook"
-                          ? action.config.url ?? ""
-                          : ""
-                      )}
-                      onChange={(e) => {
-                        const key =
-                          action.type === "set_status"
-                            ? "status"
-                            : action.type === "add_tag"
-                            ? "tag"
-                            : action.type === "send_email"
-                            ? "to"
-                            : action.type === "create_invoice"
-                            ? "invoice_type"
-                            : action.type === "webhook"
-                            ? "url"
-                            : "value";
-                        updateAction(index, {
-                          config: { ...action.config, [key]: e.target.value },
-                        });
-                      }}
-                      placeholder={
-                        action.type === "set_status"
-                          ? "np. confirmed"
-                          : action.type === "add_tag"
-                          ? "np. vip"
-                          : action.type === "send_email"
-                          ? "np. admin@firma.pl"
-                          : action.type === "create_invoice"
-                          ? "np. vat"
-                          : action.type === "webhook"
-                          ? "https://..."
-                          : ""
-                      }
-                    />
-                  </div>
-                  <div className="space-y-1">
-                    <Label className="text-xs">Opoznienie (minuty)</Label>
-                    <Input
-                      type="number"
-                      min={0}
-                      value={Math.round((action.delay_seconds ?? 0) / 60)}
-                      onChange={(e) => {
-                        const minutes = parseInt(e.target.value) || 0;
-                        updateAction(index, { delay_seconds: minutes * 60 });
-                      }}
-                      placeholder="0 = natychmiast"
-                    />
-                    <p className="text-xs text-muted-foreground">
-                      0 = natychmiast
-                    </p>
+                <div className="flex-1 space-y-3">
+                  <div className="grid grid-cols-1 gap-3 sm:grid-cols-2">
+                    <div className="space-y-1">
+                      <Label className="text-xs">Typ akcji</Label>
+                      <Select
+                        value={action.type}
+                        onValueChange={(v) => updateAction(index, { type: v, config: {} })}
+                      >
+                        <SelectTrigger className="w-full">
+                          <SelectValue />
+                        </SelectTrigger>
+                        <SelectContent>
+                          {AUTOMATION_ACTION_TYPES.map((t) => (
+                            <SelectItem key={t} value={t}>
+                              {AUTOMATION_ACTION_LABELS[t] || t}
+                            </SelectItem>
+                          ))}
+                        </SelectContent>
+                      </Select>
+                    </div>
+                    <div className="space-y-1">
+                      <Label className="text-xs">Opoznienie (minuty)</Label>
+                      <Input
+                        type="number"
+                        min={0}
+                        value={Math.round((action.delay_seconds ?? 0) / 60)}
+                        onChange={(e) => {
+                          const minutes = parseInt(e.target.value) || 0;
+                          updateAction(index, { delay_seconds: minutes * 60 });
+                        }}
+                        placeholder="0 = natychmiast"
+                      />
+                      <p className="text-xs text-muted-foreground">
+                        0 = natychmiast
+                      </p>
+                    </div>
                   </div>
+                  <ActionParamsFields
+                    action={action}
+                    onChange={(config) => updateAction(index, { config })}
+                  />
                 </div>
                 <Button
                   variant="ghost"
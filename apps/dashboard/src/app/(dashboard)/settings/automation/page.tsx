@@ -7,8 +7,10 @@ import { AdminGuard } from "@/components/shared/admin-guard";
 import { useAutomationRules, useDeleteAutomationRule } from "@/hooks/use-automation";
 import { DataTablePagination } from "@/components/shared/data-table-pagination";
 import { LoadingSkeleton } from "@/components/shared/loading-skeleton";
+import { DeadLetterEvents } from "@/components/automation/dead-letter-events";
 import { Button } from "@/components/ui/button";
 import { Badge } from "@/components/ui/badge";
+import { Tabs, TabsContent, TabsList, TabsTrigger } from "@/components/ui/tabs";
 import {
   Select,
   SelectContent,
@@ -81,122 +83,135 @@ export default function AutomationRulesPage() {
         </Button>
       </div>
 
-      <div className="flex items-center gap-4">
-        <div className="w-[280px]">
-          <Select value={triggerFilter || "all"} onValueChange={handleTriggerChange}>
-            <SelectTrigger className="w-full">
-              <SelectValue placeholder="Zdarzenie wyzwalające" />
-            </SelectTrigger>
-            <SelectContent>
-              <SelectItem value="all">Wszystkie zdarzenia</SelectItem>
-              {AUTOMATION_TRIGGER_EVENTS.map((event) => (
-                <SelectItem key={event} value={event}>
-                  {AUTOMATION_TRIGGER_LABELS[event] || event}
-                </SelectItem>
-              ))}
-            </SelectContent>
-          </Select>
-        </div>
-      </div>
+      <Tabs defaultValue="rules">
+        <TabsList>
+          <TabsTrigger value="rules">Reguły</TabsTrigger>
+          <TabsTrigger value="dead-letter">Nieudane zdarzenia</TabsTrigger>
+        </TabsList>
 
-      {isError && (
-        <div className="rounded-md border border-destructive bg-destructive/10 p-4">
-          <p className="text-sm text-destructive">
-            Wystąpił błąd podczas ładowania danych. Spróbuj odświeżyć stronę.
-          </p>
-          <Button variant="outline" size="sm" className="mt-2" onClick={() => refetch()}>
-            Spróbuj ponownie
-          </Button>
-        </div>
-      )}
+        <TabsContent value="rules" className="space-y-6">
+          <div className="flex items-center gap-4">
+            <div className="w-[280px]">
+              <Select value={triggerFilter || "all"} onValueChange={handleTriggerChange}>
+                <SelectTrigger className="w-full">
+                  <SelectValue placeholder="Zdarzenie wyzwalające" />
+                </SelectTrigger>
+                <SelectContent>
+                  <SelectItem value="all">Wszystkie zdarzenia</SelectItem>
+                  {AUTOMATION_TRIGGER_EVENTS.map((event) => (
+                    <SelectItem key={event} value={event}>
+                      {AUTOMATION_TRIGGER_LABELS[event] || event}
+                    </SelectItem>
+                  ))}
+                </SelectContent>
+              </Select>
+            </div>
+          </div>
 
-      {isLoading ? (
-        <LoadingSkeleton />
-      ) : (
-        <div className="rounded-md border">
-          <Table>
-            <TableHeader>
-              <TableRow>
-                <TableHead>Nazwa</TableHead>
-                <TableHead>Zdarzenie</TableHead>
-                <TableHead>Priorytet</TableHead>
-                <TableHead>Status</TableHead>
-                <TableHead className="text-right">Wykonania</TableHead>
-                <TableHead className="w-[60px]" />
-              </TableRow>
-            </TableHeader>
-            <TableBody>
-              {data?.items && data.items.length > 0 ? (
-                data.items.map((rule: AutomationRule) => (
-                  <TableRow
-                    key={rule.id}
-                    className="cursor-pointer"
-                    onClick={() => router.push(`/settings/automation/${rule.id}`)}
-                  >
-                    <TableCell>
-                      <div>
-                        <div className="font-medium">{rule.name}</div>
-                        {rule.description && (
-                          <div className="text-sm text-muted-foreground truncate max-w-[300px]">
-                            {rule.description}
-                          </div>
-                        )}
-                      </div>
-                    </TableCell>
-                    <TableCell>
-                      <Badge variant="outline">
-                        {AUTOMATION_TRIGGER_LABELS[rule.trigger_event] || rule.trigger_event}
-                      </Badge>
-                    </TableCell>
-                    <TableCell>{rule.priority}</TableCell>
-                    <TableCell>
-                      <Badge
-                        className={
-                          rule.enabled
-                            ? "bg-green-100 text-green-800 dark:bg-green-900 dark:text-green-200"
-                            : "bg-gray-100 text-gray-800 dark:bg-gray-800 dark:text-gray-200"
-                        }
-                      >
-                        {rule.enabled ? "Aktywna" : "Wyłączona"}
-                      </Badge>
-                    </TableCell>
-                    <TableCell className="text-right">{rule.fire_count}</TableCell>
-                    <TableCell>
-                      <Button
-                        variant="ghost"
-                        size="sm"
-                        onClick={(e) => handleDelete(e, rule.id)}
-                        disabled={deleteRule.isPending}
-                      >
-                        <Trash2 className="h-4 w-4 text-destructive" />
-                      </Button>
-                    </TableCell>
+          {isError && (
+            <div className="rounded-md border border-destructive bg-destructive/10 p-4">
+              <p className="text-sm text-destructive">
+                Wystąpił błąd podczas ładowania danych. Spróbuj odświeżyć stronę.
+              </p>
+              <Button variant="outline" size="sm" className="mt-2" onClick={() => refetch()}>
+                Spróbuj ponownie
+              </Button>
+            </div>
+          )}
+
+          {isLoading ? (
+            <LoadingSkeleton />
+          ) : (
+            <div className="rounded-md border">
+              <Table>
+                <TableHeader>
+                  <TableRow>
+                    <TableHead>Nazwa</TableHead>
+                    <TableHead>Zdarzenie</TableHead>
+                    <TableHead>Priorytet</TableHead>
+                    <TableHead>Status</TableHead>
+                    <TableHead className="text-right">Wykonania</TableHead>
+                    <TableHead className="w-[60px]" />
                   </TableRow>
-                ))
-              ) : (
-                <TableRow>
-                  <TableCell colSpan={6} className="h-24 text-center text-muted-foreground">
-                    Brak reguł automatyzacji
-                  </TableCell>
-                </TableRow>
-              )}
-            </TableBody>
-          </Table>
-        </div>
-      )}
+                </TableHeader>
+                <TableBody>
+                  {data?.items && data.items.length > 0 ? (
+                    data.items.map((rule: AutomationRule) => (
+                      <TableRow
+                        key={rule.id}
+                        className="cursor-pointer"
+                        onClick={() => router.push(`/settings/automation/${rule.id}`)}
+                      >
+                        <TableCell>
+                          <div>
+                            <div className="font-medium">{rule.name}</div>
+                            {rule.description && (
+                              <div className="text-sm text-muted-foreground truncate max-w-[300px]">
+                                {rule.description}
+                              </div>
+                            )}
+                          </div>
+                        </TableCell>
+                        <TableCell>
+                          <Badge variant="outline">
+                            {AUTOMATION_TRIGGER_LABELS[rule.trigger_event] || rule.trigger_event}
+                          </Badge>
+                        </TableCell>
+                        <TableCell>{rule.priority}</TableCell>
+                        <TableCell>
+                          <Badge
+                            className={
+                              rule.enabled
+                                ? "bg-green-100 text-green-800 dark:bg-green-900 dark:text-green-200"
+                                : "bg-gray-100 text-gray-800 dark:bg-gray-800 dark:text-gray-200"
+                            }
+                          >
+                            {rule.enabled ? "Aktywna" : "Wyłączona"}
+                          </Badge>
+                        </TableCell>
+                        <TableCell className="text-right">{rule.fire_count}</TableCell>
+                        <TableCell>
+                          <Button
+                            variant="ghost"
+                            size="sm"
+                            onClick={(e) => handleDelete(e, rule.id)}
+                            disabled={deleteRule.isPending}
+                          >
+                            <Trash2 className="h-4 w-4 text-destructive" />
+                          </Button>
+                        </TableCell>
+                      </TableRow>
+                    ))
+                  ) : (
+                    <TableRow>
+                      <TableCell colSpan={6} className="h-24 text-center text-muted-foreground">
+                        Brak reguł automatyzacji
+                      </TableCell>
+                    </TableRow>
+                  )}
+                </TableBody>
+              </Table>
+            </div>
+          )}
+
+          {data && (
+            <DataTablePagination
+              total={data.total}
+              limit={limit}
+              offset={offset}
+              onPageChange={setOffset}
+              onPageSizeChange={(newLimit) => {
+                setLimit(newLimit);
+                setOffset(0);
+              }}
+            />
+          )}
+        </TabsContent>
 
-      {data && (
-        <DataTablePagination
-          total={data.total}
-          limit={limit}
-          offset={offset}
-          onPageChange={setOffset}
-          onPageSizeChange={(newLimit) => {
-            setLimit(newLimit);
-            setOffset(0);
-          }}
-        />
-      )}
+        <TabsContent value="dead-letter">
+          <DeadLetterEvents />
+        </TabsContent>
+      </Tabs>
     </div>
     </AdminGuard>
   );
@@ -0,0 +1,112 @@
+"use client";
+
+import { toast } from "sonner";
+import { AdminGuard } from "@/components/shared/admin-guard";
+import { useDropshipSettings, useUpdateDropshipSettings } from "@/hooks/use-settings";
+import { useOrderStatuses } from "@/hooks/use-order-statuses";
+import { LoadingSkeleton } from "@/components/shared/loading-skeleton";
+import { getErrorMessage } from "@/lib/api-client";
+import { Switch } from "@/components/ui/switch";
+import { Checkbox } from "@/components/ui/checkbox";
+import { Label } from "@/components/ui/label";
+import {
+  Card,
+  CardContent,
+  CardDescription,
+  CardHeader,
+  CardTitle,
+} from "@/components/ui/card";
+import type { DropshipSettings } from "@/types/api";
+
+export default function DropshipSettingsPage() {
+  const { data, isLoading } = useDropshipSettings();
+  const { data: statusConfig } = useOrderStatuses();
+  const updateSettings = useUpdateDropshipSettings();
+
+  if (isLoading) {
+    return <LoadingSkeleton />;
+  }
+
+  const settings: DropshipSettings = data ?? { enabled: false, route_statuses: ["confirmed"] };
+  const routeStatuses = settings.route_statuses ?? ["confirmed"];
+
+  const save = (patch: Partial<DropshipSettings>) => {
+    updateSettings.mutate(
+      { ...settings, ...patch },
+      {
+        onSuccess: () => {
+          toast.success("Ustawienia dropshippingu zostały zapisane");
+        },
+        onError: (error) => {
+          toast.error(getErrorMessage(error));
+        },
+      }
+    );
+  };
+
+  const toggleStatus = (status: string, checked: boolean) => {
+    const next = checked
+      ? [...routeStatuses, status]
+      : routeStatuses.filter((s) => s !== status);
+    if (next.length === 0) {
+      toast.error("Wybierz co najmniej jeden status");
+      return;
+    }
+    save({ route_statuses: next });
+  };
+
+  return (
+    <AdminGuard>
+      <div className="mx-auto max-w-4xl space-y-6">
+        <div>
+          <h1 className="text-2xl font-bold tracking-tight">Dropshipping</h1>
+          <p className="text-muted-foreground">
+            Automatyczne kierowanie pozycji zamówień do dostawców
+          </p>
+        </div>
+
+        <Card>
+          <CardHeader>
+            <CardTitle>Kierowanie zamówień</CardTitle>
+            <CardDescription>
+              Pozycje produktów z regułą realizacji &quot;dropshipping&quot; lub &quot;najpierw
+              własny stan&quot; są zamawiane u dostawcy, który otrzymuje zamówienie e-mailem
+              (PDF/CSV) lub webhookiem. Regułę ustawisz na karcie produktu, a kanał wysyłki na
+              karcie dostawcy.
+            </CardDescription>
+          </CardHeader>
+          <CardContent className="space-y-6">
+            <div className="flex items-center gap-3">
+              <Switch
+                id="dropship_enabled"
+                checked={settings.enabled}
+                onCheckedChange={(checked) => save({ enabled: checked })}
+                disabled={updateSettings.isPending}
+              />
+              <Label htmlFor="dropship_enabled">Kieruj zamówienia automatycznie</Label>
+            </div>
+
+            <div className="space-y-3">
+              <Label>Statusy zamówień uruchamiające kierowanie</Label>
+              <div className="grid grid-cols-2 gap-2 md:grid-cols-3">
+                {(statusConfig?.statuses ?? []).map((s) => (
+                  <div key={s.key} className="flex items-center gap-2">
+                    <Checkbox
+                      id={`status_${s.key}`}
+                      checked={routeStatuses.includes(s.key)}
+                      onCheckedChange={(checked) => toggleStatus(s.key, checked === true)}
+                      disabled={!settings.enabled || updateSettings.isPending}
+                    />
+                    <Label htmlFor={`status_${s.key}`} className="font-normal">
+                      {s.label}
+                    </Label>
+                  </div>
+                ))}
+              </div>
+            </div>
+          </CardContent>
+        </Card>
+      </div>
+    </AdminGuard>
+  );
+}
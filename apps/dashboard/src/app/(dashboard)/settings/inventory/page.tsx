@@ -10,6 +10,13 @@ import { getErrorMessage } from "@/lib/api-client";
 import { Switch } from "@/components/ui/switch";
 import { Label } from "@/components/ui/label";
 import { Button } from "@/components/ui/button";
+import {
+  Select,
+  SelectContent,
+  SelectItem,
+  SelectTrigger,
+  SelectValue,
+} from "@/components/ui/select";
 import {
   Card,
   CardContent,
@@ -27,6 +34,7 @@ import {
   AlertDialogHeader,
   AlertDialogTitle,
 } from "@/components/ui/alert-dialog";
+import type { CostingMethod } from "@/types/api";
 
 export default function InventorySettingsPage() {
   const { data, isLoading } = useInventorySettings();
@@ -38,6 +46,21 @@ export default function InventorySettingsPage() {
   }
 
   const strictMode = data?.strict_mode ?? false;
+  const costingMethod = data?.costing_method ?? "fifo";
+
+  const handleCostingMethod = (value: string) => {
+    updateSettings.mutate(
+      { strict_mode: strictMode, costing_method: value as CostingMethod },
+      {
+        onSuccess: () => {
+          toast.success("Metoda wyceny magazynu zapisana");
+        },
+        onError: (error) => {
+          toast.error(getErrorMessage(error));
+        },
+      }
+    );
+  };
 
   const handleToggle = (checked: boolean) => {
     if (checked) {
@@ -46,7 +69,7 @@ export default function InventorySettingsPage() {
     } else {
       // Disable directly
       updateSettings.mutate(
-        { strict_mode: false },
+        { strict_mode: false, costing_method: costingMethod },
         {
           onSuccess: () => {
             toast.success("Tryb ścisłej kontroli magazynowej wyłączony");
@@ -61,7 +84,7 @@ export default function InventorySettingsPage() {
 
   const confirmEnable = () => {
     updateSettings.mutate(
-      { strict_mode: true },
+      { strict_mode: true, costing_method: costingMethod },
       {
         onSuccess: () => {
           toast.success("Tryb ścisłej kontroli magazynowej włączony");
@@ -128,6 +151,45 @@ export default function InventorySettingsPage() {
             )}
           </CardContent>
         </Card>
+
+        <Card>
+          <CardHeader>
+            <CardTitle>Wycena magazynu</CardTitle>
+            <CardDescription>
+              Metoda wyceny rozchodów dla raportów wartości magazynu i kosztu własnego sprzedaży
+            </CardDescription>
+          </CardHeader>
+          <CardContent className="space-y-4">
+            <div className="flex items-center justify-between rounded-lg border p-4">
+              <div className="space-y-0.5">
+                <Label htmlFor="costing-method" className="text-base font-medium">
+                  Metoda wyceny
+                </Label>
+                <p className="text-sm text-muted-foreground">
+                  FIFO rozchoduje najstarsze dostawy po ich cenie zakupu, średnia ważona
+                  wycenia każde wydanie po średnim koszcie zapasu
+                </p>
+              </div>
+              <Select
+                value={costingMethod}
+                onValueChange={handleCostingMethod}
+                disabled={updateSettings.isPending}
+              >
+                <SelectTrigger id="costing-method" className="w-48">
+                  <SelectValue />
+                </SelectTrigger>
+                <SelectContent>
+                  <SelectItem value="fifo">FIFO</SelectItem>
+                  <SelectItem value="weighted_average">Średnia ważona</SelectItem>
+                </SelectContent>
+              </Select>
+            </div>
+            <p className="text-sm text-muted-foreground">
+              Zmiana metody dotyczy kolejnych dokumentów — wcześniejsze wydania zachowują
+              swój koszt.
+            </p>
+          </CardContent>
+        </Card>
       </div>
 
       <AlertDialog open={showWarning} onOpenChange={setShowWarning}>
@@ -0,0 +1,96 @@
+"use client";
+
+import { toast } from "sonner";
+import { AdminGuard } from "@/components/shared/admin-guard";
+import { useOrderHoldSettings, useUpdateOrderHoldSettings } from "@/hooks/use-settings";
+import { LoadingSkeleton } from "@/components/shared/loading-skeleton";
+import { getErrorMessage } from "@/lib/api-client";
+import { Label } from "@/components/ui/label";
+import { Switch } from "@/components/ui/switch";
+import {
+  Card,
+  CardContent,
+  CardDescription,
+  CardHeader,
+  CardTitle,
+} from "@/components/ui/card";
+import { HOLD_AUTO_RELEASE_REASONS, HOLD_IMPORT_CHECKS, HOLD_REASONS } from "@/lib/constants";
+import type { HoldReason, OrderHoldSettings } from "@/types/api";
+
+const IMPORT_CHECK_DESCRIPTIONS: Record<(typeof HOLD_IMPORT_CHECKS)[number], string> = {
+  awaiting_payment: "Zamówienie nieopłacone i nie za pobraniem",
+  out_of_stock: "Stan magazynowy nie pokrywa którejś pozycji zamówienia",
+  address_verification: "Adres dostawy lub telefon klienta nie przeszedł walidacji (kod pocztowy, miejscowość, numer domu, telefon)",
+};
+
+export default function OrderHoldSettingsPage() {
+  const { data, isLoading } = useOrderHoldSettings();
+  const updateSettings = useUpdateOrderHoldSettings();
+
+  if (isLoading) {
+    return <LoadingSkeleton />;
+  }
+
+  const settings: OrderHoldSettings = { import_checks: data?.import_checks ?? [] };
+
+  const toggleCheck = (reason: HoldReason, enabled: boolean) => {
+    const importChecks = enabled
+      ? [...settings.import_checks, reason]
+      : settings.import_checks.filter((r) => r !== reason);
+    updateSettings.mutate(
+      { import_checks: importChecks },
+      {
+        onSuccess: () => {
+          toast.success("Ustawienia wstrzymań zostały zapisane");
+        },
+        onError: (error) => {
+          toast.error(getErrorMessage(error));
+        },
+      }
+    );
+  };
+
+  return (
+    <AdminGuard>
+      <div className="mx-auto max-w-4xl space-y-6">
+        <div>
+          <h1 className="text-2xl font-bold tracking-tight">Wstrzymania</h1>
+          <p className="text-muted-foreground">
+            Automatyczne wstrzymywanie nowych zamówień przed realizacją
+          </p>
+        </div>
+
+        <Card>
+          <CardHeader>
+            <CardTitle>Kontrole nowych zamówień</CardTitle>
+            <CardDescription>
+              Nowe zamówienie, które nie przejdzie włączonej kontroli, zostaje wstrzymane i nie
+              może przejść do realizacji. Wstrzymania z powodu płatności i braku towaru są
+              zwalniane automatycznie, gdy przyczyna ustąpi.
+            </CardDescription>
+          </CardHeader>
+          <CardContent className="space-y-4">
+            {HOLD_IMPORT_CHECKS.map((reason) => (
+              <div key={reason} className="flex items-start gap-3">
+                <Switch
+                  id={`check_${reason}`}
+                  checked={settings.import_checks.includes(reason)}
+                  onCheckedChange={(checked) => toggleCheck(reason, checked)}
+                  disabled={updateSettings.isPending}
+                />
+                <div className="space-y-1">
+                  <Label htmlFor={`check_${reason}`}>{HOLD_REASONS[reason]}</Label>
+                  <p className="text-sm text-muted-foreground">
+                    {IMPORT_CHECK_DESCRIPTIONS[reason]}
+                    {(HOLD_AUTO_RELEASE_REASONS as readonly string[]).includes(reason) &&
+                      " — zwalniane automatycznie"}
+                  </p>
+                </div>
+              </div>
+            ))}
+          </CardContent>
+        </Card>
+      </div>
+    </AdminGuard>
+  );
+}
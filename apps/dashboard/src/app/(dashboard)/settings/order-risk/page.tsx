@@ -0,0 +1,192 @@
+"use client";
+
+import { useState } from "react";
+import { toast } from "sonner";
+import { AdminGuard } from "@/components/shared/admin-guard";
+import { useOrderRiskSettings, useUpdateOrderRiskSettings } from "@/hooks/use-settings";
+import { LoadingSkeleton } from "@/components/shared/loading-skeleton";
+import { getErrorMessage } from "@/lib/api-client";
+import { Button } from "@/components/ui/button";
+import { Input } from "@/components/ui/input";
+import { Label } from "@/components/ui/label";
+import { Textarea } from "@/components/ui/textarea";
+import {
+  Card,
+  CardContent,
+  CardDescription,
+  CardHeader,
+  CardTitle,
+} from "@/components/ui/card";
+import type { OrderRiskSettings } from "@/types/api";
+
+const DEFAULT_SETTINGS: OrderRiskSettings = {
+  hold_threshold: 0,
+  high_value_amount: 1000,
+  currency: "PLN",
+  velocity_hours: 24,
+  velocity_limit: 3,
+  disposable_domains: [],
+};
+
+export default function OrderRiskSettingsPage() {
+  const { data, isLoading } = useOrderRiskSettings();
+  const updateSettings = useUpdateOrderRiskSettings();
+
+  if (isLoading) {
+    return <LoadingSkeleton />;
+  }
+
+  const save = (settings: OrderRiskSettings) => {
+    updateSettings.mutate(settings, {
+      onSuccess: () => {
+        toast.success("Ustawienia oceny ryzyka zostały zapisane");
+      },
+      onError: (error) => {
+        toast.error(getErrorMessage(error));
+      },
+    });
+  };
+
+  return (
+    <AdminGuard>
+      <div className="mx-auto max-w-4xl space-y-6">
+        <div>
+          <h1 className="text-2xl font-bold tracking-tight">Ocena ryzyka</h1>
+          <p className="text-muted-foreground">
+            Każde nowe zamówienie otrzymuje ocenę ryzyka od 0 do 100 na podstawie historii klienta,
+            samego zamówienia i liczby ostatnich zamówień na ten sam telefon i adres
+          </p>
+        </div>
+
+        <RiskSettingsCard
+          initial={{ ...DEFAULT_SETTINGS, ...data }}
+          isPending={updateSettings.isPending}
+          onSave={save}
+        />
+      </div>
+    </AdminGuard>
+  );
+}
+
+function RiskSettingsCard({
+  initial,
+  isPending,
+  onSave,
+}: {
+  initial: OrderRiskSettings;
+  isPending: boolean;
+  onSave: (settings: OrderRiskSettings) => void;
+}) {
+  const [settings, setSettings] = useState<OrderRiskSettings>(initial);
+  const [domains, setDomains] = useState((initial.disposable_domains ?? []).join("\n"));
+
+  const setNumber = (field: Exclude<keyof OrderRiskSettings, "currency" | "disposable_domains">, value: string) => {
+    setSettings((prev) => ({ ...prev, [field]: Number(value) || 0 }));
+  };
+
+  const handleSave = () => {
+    onSave({
+      ...settings,
+      disposable_domains: domains
+        .split(/[\s,]+/)
+        .map((d) => d.trim().toLowerCase())
+        .filter(Boolean),
+    });
+  };
+
+  return (
+    <Card>
+      <CardHeader>
+        <CardTitle>Reguły</CardTitle>
+        <CardDescription>
+          Zamówienie z oceną co najmniej równą progowi zostaje wstrzymane z powodem „Weryfikacja
+          pod kątem oszustwa”. Próg 0 wyłącza wstrzymywanie — ocena jest nadal liczona i dostępna w
+          warunkach automatyzacji jako risk_score.
+        </CardDescription>
+      </CardHeader>
+      <CardContent className="space-y-6">
+        <div className="grid gap-4 sm:grid-cols-2">
+          <div className="space-y-2">
+            <Label htmlFor="hold_threshold">Próg wstrzymania (0–100)</Label>
+            <Input
+              id="hold_threshold"
+              type="number"
+              min="0"
+              max="100"
+              value={settings.hold_threshold}
+              onChange={(e) => setNumber("hold_threshold", e.target.value)}
+            />
+          </div>
+          <div className="space-y-2">
+            <Label htmlFor="high_value_amount">Wysoka kwota pierwszego zamówienia</Label>
+            <Input
+              id="high_value_amount"
+              type="number"
+              min="0"
+              step="0.01"
+              value={settings.high_value_amount}
+              onChange={(e) => setNumber("high_value_amount", e.target.value)}
+            />
+          </div>
+          <div className="space-y-2">
+            <Label htmlFor="currency">Waluta wysokiej kwoty</Label>
+            <Input
+              id="currency"
+              maxLength={3}
+              placeholder="PLN"
+              value={settings.currency}
+              onChange={(e) =>
+                setSettings((prev) => ({ ...prev, currency: e.target.value.toUpperCase() }))
+              }
+            />
+            <p className="text-xs text-muted-foreground">
+              Zamówienia w innych walutach są przeliczane po kursach z ustawień walut. Zamówienie
+              bez kursu nie jest oceniane jako wysokiej wartości.
+            </p>
+          </div>
+          <div className="space-y-2">
+            <Label htmlFor="velocity_hours">Okno częstotliwości (godziny)</Label>
+            <Input
+              id="velocity_hours"
+              type="number"
+              min="1"
+              max="720"
+              value={settings.velocity_hours}
+              onChange={(e) => setNumber("velocity_hours", e.target.value)}
+            />
+          </div>
+          <div className="space-y-2">
+            <Label htmlFor="velocity_limit">Limit zamówień w oknie</Label>
+            <Input
+              id="velocity_limit"
+              type="number"
+              min="1"
+              max="100"
+              value={settings.velocity_limit}
+              onChange={(e) => setNumber("velocity_limit", e.target.value)}
+            />
+          </div>
+        </div>
+
+        <div className="space-y-2">
+          <Label htmlFor="disposable_domains">Dodatkowe domeny jednorazowych e-maili</Label>
+          <Textarea
+            id="disposable_domains"
+            rows={5}
+            placeholder="np. spam.example"
+            value={domains}
+            onChange={(e) => setDomains(e.target.value)}
+          />
+          <p className="text-xs text-muted-foreground">
+            Jedna domena w wierszu. Popularne serwisy (np. mailinator.com, yopmail.com) są
+            rozpoznawane bez konfiguracji.
+          </p>
+        </div>
+
+        <Button onClick={handleSave} disabled={isPending}>
+          {isPending ? "Zapisywanie..." : "Zapisz"}
+        </Button>
+      </CardContent>
+    </Card>
+  );
+}
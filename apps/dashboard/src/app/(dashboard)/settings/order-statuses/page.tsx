@@ -17,6 +17,7 @@ import {
   SelectValue,
 } from "@/components/ui/select";
 import { Trash2, Plus } from "lucide-react";
+import { ORDER_STATUS_CATEGORIES, ORDER_STATUS_GUARDS } from "@/lib/constants";
 import type { StatusDef, OrderStatusConfig } from "@/types/api";
 
 const COLOR_OPTIONS = Object.entries(COLOR_PRESETS).map(([key, classes]) => ({
@@ -40,7 +41,7 @@ export default function OrderStatusesPage() {
 
   const handleAddStatus = () => {
     const newPosition = statuses.length + 1;
-    setStatuses([...statuses, { key: "", label: "", color: "gray", position: newPosition }]);
+    setStatuses([...statuses, { key: "", label: "", color: "gray", position: newPosition, category: "processing" }]);
   };
 
   const handleRemoveStatus = (index: number) => {
@@ -62,6 +63,16 @@ export default function OrderStatusesPage() {
     setStatuses(newStatuses);
   };
 
+  const handleRequiresToggle = (index: number, guard: string) => {
+    const newStatuses = [...statuses];
+    const requires = newStatuses[index].requires || [];
+    newStatuses[index] = {
+      ...newStatuses[index],
+      requires: requires.includes(guard) ? requires.filter((g) => g !== guard) : [...requires, guard],
+    };
+    setStatuses(newStatuses);
+  };
+
   const handleTransitionToggle = (from: string, to: string) => {
     const newTransitions = { ...transitions };
     const targets = newTransitions[from] || [];
@@ -113,7 +124,8 @@ export default function OrderStatusesPage() {
       <div>
         <h1 className="text-2xl font-bold">Statusy zamówień</h1>
         <p className="text-muted-foreground mt-1">
-          Zdefiniuj statusy i przejścia dla zamówień
+          Zdefiniuj statusy, przejścia i warunki dla zamówień. Kategoria określa znaczenie
+          statusu: daty wysyłki i doręczenia, powiadomienia oraz wykluczenie z raportów sprzedaży.
         </p>
       </div>
 
@@ -155,6 +167,21 @@ export default function OrderStatusesPage() {
                   ))}
                 </SelectContent>
               </Select>
+              <Select
+                value={status.category || "processing"}
+                onValueChange={(v) => handleStatusChange(index, "category", v)}
+              >
+                <SelectTrigger className="w-40">
+                  <SelectValue />
+                </SelectTrigger>
+                <SelectContent>
+                  {Object.entries(ORDER_STATUS_CATEGORIES).map(([key, label]) => (
+                    <SelectItem key={key} value={key}>
+                      {label}
+                    </SelectItem>
+                  ))}
+                </SelectContent>
+              </Select>
               <Button
                 variant="ghost"
                 size="sm"
@@ -207,6 +234,44 @@ export default function OrderStatusesPage() {
         </CardContent>
       </Card>
 
+      <Card>
+        <CardHeader>
+          <CardTitle>Warunki wejścia</CardTitle>
+        </CardHeader>
+        <CardContent>
+          <p className="text-sm text-muted-foreground mb-4">
+            Zamówienie może przejść do statusu tylko wtedy, gdy spełnia wszystkie zaznaczone warunki.
+            Wymuszona zmiana statusu pomija warunki.
+          </p>
+          <div className="space-y-4">
+            {statuses.map((status, index) => (
+              <div key={status.key || index} className="space-y-1">
+                <p className="text-sm font-medium">{status.label || status.key}</p>
+                <div className="flex flex-wrap gap-2">
+                  {Object.entries(ORDER_STATUS_GUARDS).map(([guard, label]) => {
+                    const isRequired = (status.requires || []).includes(guard);
+                    return (
+                      <button
+                        key={guard}
+                        type="button"
+                        onClick={() => handleRequiresToggle(index, guard)}
+                        className={`rounded-full px-3 py-1 text-xs font-medium border transition-colors ${
+                          isRequired
+                            ? "bg-primary text-primary-foreground border-primary"
+                            : "bg-background text-muted-foreground border-border hover:border-primary/50"
+                        }`}
+                      >
+                        {label}
+                      </button>
+                    );
+                  })}
+                </div>
+              </div>
+            ))}
+          </div>
+        </CardContent>
+      </Card>
+
       <div className="flex justify-end">
         <Button onClick={handleSave} disabled={updateStatuses.isPending}>
           {updateStatuses.isPending ? "Zapisywanie..." : "Zapisz zmiany"}
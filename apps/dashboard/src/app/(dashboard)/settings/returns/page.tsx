@@ -0,0 +1,274 @@
+"use client";
+
+import { useState } from "react";
+import { toast } from "sonner";
+import { AdminGuard } from "@/components/shared/admin-guard";
+import { useReturnSettings, useUpdateReturnSettings } from "@/hooks/use-settings";
+import { useWarehouses } from "@/hooks/use-warehouses";
+import { LoadingSkeleton } from "@/components/shared/loading-skeleton";
+import { getErrorMessage } from "@/lib/api-client";
+import { Button } from "@/components/ui/button";
+import { Input } from "@/components/ui/input";
+import { Label } from "@/components/ui/label";
+import {
+  Card,
+  CardContent,
+  CardDescription,
+  CardHeader,
+  CardTitle,
+} from "@/components/ui/card";
+import {
+  Select,
+  SelectContent,
+  SelectItem,
+  SelectTrigger,
+  SelectValue,
+} from "@/components/ui/select";
+import type { ReturnCarrierSettings, ReturnSettings } from "@/types/api";
+
+const SHIPPING_REFUND_POLICIES: Record<ReturnSettings["shipping_refund"], string> = {
+  full_return: "Tylko przy zwrocie całego zamówienia",
+  always: "Zawsze",
+  never: "Nigdy",
+};
+
+const NO_WAREHOUSE = "none";
+const NO_CARRIER = "none";
+
+const RETURN_CARRIERS: Record<string, string> = {
+  inpost: "InPost (nadanie w paczkomacie, doręczenie do magazynu)",
+  dpd: "DPD (kurier)",
+  orlen_paczka: "Orlen Paczka (doręczenie do punktu)",
+};
+
+const EMPTY_CARRIER: ReturnCarrierSettings = { provider: "", receiver: { country: "PL" } };
+
+export default function ReturnSettingsPage() {
+  const { data, isLoading } = useReturnSettings();
+  const { data: warehousesData } = useWarehouses({ limit: 100 });
+  const updateSettings = useUpdateReturnSettings();
+
+  if (isLoading) {
+    return <LoadingSkeleton />;
+  }
+
+  const settings: ReturnSettings = data ?? { shipping_refund: "full_return" };
+  const warehouses = warehousesData?.items || [];
+
+  const save = (patch: Partial<ReturnSettings>) => {
+    updateSettings.mutate(
+      { ...settings, ...patch },
+      {
+        onSuccess: () => {
+          toast.success("Ustawienia zwrotów zostały zapisane");
+        },
+        onError: (error) => {
+          toast.error(getErrorMessage(error));
+        },
+      }
+    );
+  };
+
+  return (
+    <AdminGuard>
+      <div className="mx-auto max-w-4xl space-y-6">
+        <div>
+          <h1 className="text-2xl font-bold tracking-tight">Zwroty</h1>
+          <p className="text-muted-foreground">
+            Przyjmowanie zwrotów na magazyn i wyliczanie kwoty zwrotu
+          </p>
+        </div>
+
+        <Card>
+          <CardHeader>
+            <CardTitle>Zwrot środków</CardTitle>
+            <CardDescription>
+              Kwota zwrotu jest wyliczana z przyjętych pozycji, pomniejszonych o potrącenia
+            </CardDescription>
+          </CardHeader>
+          <CardContent className="space-y-6">
+            <div className="space-y-2">
+              <Label>Zwrot kosztów wysyłki</Label>
+              <Select
+                value={settings.shipping_refund}
+                onValueChange={(value) =>
+                  save({ shipping_refund: value as ReturnSettings["shipping_refund"] })
+                }
+                disabled={updateSettings.isPending}
+              >
+                <SelectTrigger className="w-80">
+                  <SelectValue />
+                </SelectTrigger>
+                <SelectContent>
+                  {Object.entries(SHIPPING_REFUND_POLICIES).map(([value, label]) => (
+                    <SelectItem key={value} value={value}>
+                      {label}
+                    </SelectItem>
+                  ))}
+                </SelectContent>
+              </Select>
+            </div>
+
+            <div className="space-y-2">
+              <Label>Domyślny magazyn przyjęć (PZ)</Label>
+              <Select
+                value={settings.default_warehouse_id || NO_WAREHOUSE}
+                onValueChange={(value) =>
+                  save({ default_warehouse_id: value === NO_WAREHOUSE ? undefined : value })
+                }
+                disabled={updateSettings.isPending}
+              >
+                <SelectTrigger className="w-80">
+                  <SelectValue />
+                </SelectTrigger>
+                <SelectContent>
+                  <SelectItem value={NO_WAREHOUSE}>Wybierany przy przyjęciu</SelectItem>
+                  {warehouses.map((w) => (
+                    <SelectItem key={w.id} value={w.id}>
+                      {w.name} {w.code ? `(${w.code})` : ""}
+                    </SelectItem>
+                  ))}
+                </SelectContent>
+              </Select>
+            </div>
+          </CardContent>
+        </Card>
+
+        <ReturnCarrierCard
+          initial={settings.return_carrier ?? EMPTY_CARRIER}
+          isPending={updateSettings.isPending}
+          onSave={(returnCarrier) => save({ return_carrier: returnCarrier })}
+        />
+      </div>
+    </AdminGuard>
+  );
+}
+
+function ReturnCarrierCard({
+  initial,
+  isPending,
+  onSave,
+}: {
+  initial: ReturnCarrierSettings;
+  isPending: boolean;
+  onSave: (carrier: ReturnCarrierSettings | undefined) => void;
+}) {
+  const [carrier, setCarrier] = useState<ReturnCarrierSettings>(initial);
+
+  const setReceiver = (field: keyof ReturnCarrierSettings["receiver"], value: string) => {
+    setCarrier((prev) => ({ ...prev, receiver: { ...prev.receiver, [field]: value } }));
+  };
+
+  const receiverFields: { field: keyof ReturnCarrierSettings["receiver"]; label: string }[] = [
+    { field: "name", label: "Nazwa odbiorcy" },
+    { field: "phone", label: "Telefon" },
+    { field: "email", label: "E-mail" },
+    { field: "street", label: "Ulica i numer" },
+    { field: "postal_code", label: "Kod pocztowy" },
+    { field: "city", label: "Miasto" },
+  ];
+
+  return (
+    <Card>
+      <CardHeader>
+        <CardTitle>Etykiety zwrotne</CardTitle>
+        <CardDescription>
+          Po zatwierdzeniu zwrotu klient otrzymuje opłaconą etykietę na stronie statusu zwrotu.
+          Doręczenie przesyłki do magazynu automatycznie oznacza zwrot jako przyjęty.
+        </CardDescription>
+      </CardHeader>
+      <CardContent className="space-y-6">
+        <div className="space-y-2">
+          <Label>Przewoźnik</Label>
+          <Select
+            value={carrier.provider || NO_CARRIER}
+            onValueChange={(value) =>
+              setCarrier((prev) => ({
+                ...prev,
+                provider: value === NO_CARRIER ? "" : (value as ReturnCarrierSettings["provider"]),
+                sending_method: value === "inpost" ? prev.sending_method || "parcel_locker" : undefined,
+              }))
+            }
+          >
+            <SelectTrigger className="w-96">
+              <SelectValue />
+            </SelectTrigger>
+            <SelectContent>
+              <SelectItem value={NO_CARRIER}>Wyłączone</SelectItem>
+              {Object.entries(RETURN_CARRIERS).map(([value, label]) => (
+                <SelectItem key={value} value={value}>
+                  {label}
+                </SelectItem>
+              ))}
+            </SelectContent>
+          </Select>
+        </div>
+
+        {carrier.provider && (
+          <>
+            <div className="grid grid-cols-2 gap-4">
+              {carrier.provider === "orlen_paczka" && (
+                <div className="space-y-2">
+                  <Label htmlFor="target_point">Punkt odbioru zwrotów</Label>
+                  <Input
+                    id="target_point"
+                    value={carrier.target_point ?? ""}
+                    onChange={(e) => setCarrier((prev) => ({ ...prev, target_point: e.target.value }))}
+                  />
+                </div>
+              )}
+              <div className="space-y-2">
+                <Label htmlFor="parcel_size">Gabaryt paczki</Label>
+                <Input
+                  id="parcel_size"
+                  placeholder="np. small"
+                  value={carrier.parcel_size ?? ""}
+                  onChange={(e) => setCarrier((prev) => ({ ...prev, parcel_size: e.target.value }))}
+                />
+              </div>
+              <div className="space-y-2">
+                <Label htmlFor="weight_kg">Waga (kg)</Label>
+                <Input
+                  id="weight_kg"
+                  type="number"
+                  min={0}
+                  step="0.1"
+                  value={carrier.weight_kg ?? ""}
+                  onChange={(e) =>
+                    setCarrier((prev) => ({
+                      ...prev,
+                      weight_kg: e.target.value ? Number(e.target.value) : undefined,
+                    }))
+                  }
+                />
+              </div>
+            </div>
+
+            <div className="space-y-2">
+              <h3 className="text-sm font-medium">Adres magazynu zwrotów</h3>
+              <div className="grid grid-cols-2 gap-4">
+                {receiverFields.map(({ field, label }) => (
+                  <div key={field} className="space-y-2">
+                    <Label htmlFor={`receiver_${field}`}>{label}</Label>
+                    <Input
+                      id={`receiver_${field}`}
+                      value={carrier.receiver[field] ?? ""}
+                      onChange={(e) => setReceiver(field, e.target.value)}
+                    />
+                  </div>
+                ))}
+              </div>
+            </div>
+          </>
+        )}
+
+        <Button
+          onClick={() => onSave(carrier.provider ? carrier : undefined)}
+          disabled={isPending}
+        >
+          {isPending ? "Zapisywanie..." : "Zapisz"}
+        </Button>
+      </CardContent>
+    </Card>
+  );
+}
@@ -75,6 +75,7 @@ export default function WarehouseDetailPage() {
   const [stockQuantity, setStockQuantity] = useState("0");
   const [stockReserved, setStockReserved] = useState("0");
   const [stockMinStock, setStockMinStock] = useState("0");
+  const [stockReason, setStockReason] = useState("");
 
   useEffect(() => {
     if (!authLoading && !isAdmin) {
@@ -127,6 +128,7 @@ export default function WarehouseDetailPage() {
         quantity: parseInt(stockQuantity) || 0,
         reserved: parseInt(stockReserved) || 0,
         min_stock: parseInt(stockMinStock) || 0,
+        reason: stockReason || undefined,
       },
       {
         onSuccess: () => {
@@ -136,6 +138,7 @@ export default function WarehouseDetailPage() {
           setStockQuantity("0");
           setStockReserved("0");
           setStockMinStock("0");
+          setStockReason("");
         },
         onError: (error) =>
           toast.error(getErrorMessage(error)),
@@ -335,6 +338,16 @@ export default function WarehouseDetailPage() {
                     />
                   </div>
                 </div>
+                <div className="space-y-2">
+                  <Label htmlFor="stock-reason">Powód zmiany</Label>
+                  <Input
+                    id="stock-reason"
+                    maxLength={500}
+                    placeholder="np. korekta po uszkodzeniu"
+                    value={stockReason}
+                    onChange={(e) => setStockReason(e.target.value)}
+                  />
+                </div>
               </div>
               <DialogFooter>
                 <Button
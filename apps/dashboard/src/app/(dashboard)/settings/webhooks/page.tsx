@@ -18,6 +18,8 @@ const WEBHOOK_EVENTS: { value: string; label: string }[] = [
   { value: "order.created", label: "Zamówienie utworzone" },
   { value: "order.status_changed", label: "Status zamówienia zmieniony" },
   { value: "order.deleted", label: "Zamówienie usunięte" },
+  { value: "order.hold_placed", label: "Zamówienie wstrzymane" },
+  { value: "order.hold_released", label: "Wstrzymanie zamówienia zwolnione" },
   { value: "product.created", label: "Produkt utworzony" },
   { value: "product.updated", label: "Produkt zaktualizowany" },
   { value: "product.deleted", label: "Produkt usunięty" },
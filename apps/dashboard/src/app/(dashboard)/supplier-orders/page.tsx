@@ -0,0 +1,333 @@
+"use client";
+
+import { useState } from "react";
+import Link from "next/link";
+import { toast } from "sonner";
+import { PackageSearch, Send, Truck, XCircle } from "lucide-react";
+import { AdminGuard } from "@/components/shared/admin-guard";
+import {
+  useSupplierOrders,
+  useResendSupplierOrder,
+  useRecordSupplierTracking,
+  useCancelSupplierOrder,
+} from "@/hooks/use-dropship";
+import { DataTable, type ColumnDef } from "@/components/shared/data-table";
+import { DataTablePagination } from "@/components/shared/data-table-pagination";
+import { StatusBadge } from "@/components/shared/status-badge";
+import { EmptyState } from "@/components/shared/empty-state";
+import { ConfirmDialog } from "@/components/ui/confirm-dialog";
+import { getErrorMessage } from "@/lib/api-client";
+import { SUPPLIER_ORDER_STATUSES } from "@/lib/constants";
+import { formatDate, formatCurrency, shortId } from "@/lib/utils";
+import { Button } from "@/components/ui/button";
+import { Input } from "@/components/ui/input";
+import { Label } from "@/components/ui/label";
+import {
+  Select,
+  SelectContent,
+  SelectItem,
+  SelectTrigger,
+  SelectValue,
+} from "@/components/ui/select";
+import {
+  Dialog,
+  DialogContent,
+  DialogDescription,
+  DialogFooter,
+  DialogHeader,
+  DialogTitle,
+} from "@/components/ui/dialog";
+import type { SupplierOrder, SupplierOrderStatus } from "@/types/api";
+
+export default function SupplierOrdersPage() {
+  const [statusFilter, setStatusFilter] = useState<string>("");
+  const [limit, setLimit] = useState(20);
+  const [offset, setOffset] = useState(0);
+  const [trackingFor, setTrackingFor] = useState<SupplierOrder | null>(null);
+  const [carrier, setCarrier] = useState("");
+  const [trackingNumber, setTrackingNumber] = useState("");
+  const [cancelId, setCancelId] = useState<string | null>(null);
+
+  const { data, isLoading, isError, refetch } = useSupplierOrders({
+    status: (statusFilter || undefined) as SupplierOrderStatus | undefined,
+    limit,
+    offset,
+  });
+  const resend = useResendSupplierOrder();
+  const recordTracking = useRecordSupplierTracking();
+  const cancel = useCancelSupplierOrder();
+
+  const isOpen = (so: SupplierOrder) => so.status !== "shipped" && so.status !== "cancelled";
+
+  const handleResend = (so: SupplierOrder) => {
+    resend.mutate(so.id, {
+      onSuccess: (updated) => {
+        if (updated.status === "sent") {
+          toast.success("Zamówienie zostało wysłane do dostawcy");
+        } else {
+          toast.error(updated.error_message || "Nie udało się wysłać zamówienia");
+        }
+      },
+      onError: (error) => toast.error(getErrorMessage(error)),
+    });
+  };
+
+  const handleTracking = () => {
+    if (!trackingFor) return;
+    recordTracking.mutate(
+      { id: trackingFor.id, data: { carrier: carrier || undefined, tracking_number: trackingNumber } },
+      {
+        onSuccess: () => {
+          toast.success("Numer przesyłki został zapisany");
+          setTrackingFor(null);
+        },
+        onError: (error) => toast.error(getErrorMessage(error)),
+      }
+    );
+  };
+
+  const handleCancel = () => {
+    if (!cancelId) return;
+    cancel.mutate(cancelId, {
+      onSuccess: () => {
+        toast.success("Zamówienie u dostawcy zostało anulowane");
+        setCancelId(null);
+      },
+      onError: (error) => toast.error(getErrorMessage(error)),
+    });
+  };
+
+  const columns: ColumnDef<SupplierOrder>[] = [
+    {
+      header: "Status",
+      accessorKey: "status",
+      cell: (row) => (
+        <div className="space-y-1">
+          <StatusBadge status={row.status} statusMap={SUPPLIER_ORDER_STATUSES} />
+          {row.status === "failed" && row.error_message && (
+            <p className="max-w-[220px] truncate text-xs text-destructive" title={row.error_message}>
+              {row.error_message}
+            </p>
+          )}
+        </div>
+      ),
+    },
+    {
+      header: "Dostawca",
+      accessorKey: "supplier_name",
+      cell: (row) => (
+        <Link href={`/suppliers/${row.supplier_id}`} className="text-primary hover:underline">
+          {row.supplier_name || shortId(row.supplier_id)}
+        </Link>
+      ),
+    },
+    {
+      header: "Zamówienie",
+      accessorKey: "order_id",
+      cell: (row) => (
+        <Link href={`/orders/${row.order_id}`} className="font-mono text-xs text-primary hover:underline">
+          {shortId(row.order_id)}
+        </Link>
+      ),
+    },
+    {
+      header: "Pozycje",
+      accessorKey: "items",
+      cell: (row) => (
+        <span className="text-sm">
+          {row.items.map((i) => `${i.quantity}× ${i.name}`).join(", ")}
+        </span>
+      ),
+    },
+    {
+      header: "Koszt",
+      accessorKey: "total_cost",
+      cell: (row) => formatCurrency(row.total_cost),
+    },
+    {
+      header: "Przesyłka",
+      accessorKey: "tracking_number",
+      cell: (row) =>
+        row.tracking_number ? (
+          <span className="font-mono text-xs">
+            {row.carrier ? `${row.carrier}: ` : ""}
+            {row.tracking_number}
+          </span>
+        ) : (
+          "---"
+        ),
+    },
+    {
+      header: "Data",
+      accessorKey: "created_at",
+      cell: (row) => formatDate(row.created_at),
+    },
+    {
+      header: "",
+      accessorKey: "id",
+      cell: (row) =>
+        isOpen(row) ? (
+          <div className="flex items-center gap-1">
+            <Button
+              variant="ghost"
+              size="icon-xs"
+              title="Wyślij ponownie"
+              onClick={() => handleResend(row)}
+              disabled={resend.isPending}
+            >
+              <Send className="h-4 w-4" />
+            </Button>
+            <Button
+              variant="ghost"
+              size="icon-xs"
+              title="Wpisz numer przesyłki"
+              onClick={() => {
+                setCarrier("");
+                setTrackingNumber("");
+                setTrackingFor(row);
+              }}
+            >
+              <Truck className="h-4 w-4" />
+            </Button>
+            <Button
+              variant="ghost"
+              size="icon-xs"
+              title="Anuluj"
+              onClick={() => setCancelId(row.id)}
+            >
+              <XCircle className="h-4 w-4" />
+            </Button>
+          </div>
+        ) : null,
+    },
+  ];
+
+  return (
+    <AdminGuard>
+      <div className="space-y-6">
+        <div>
+          <h1 className="text-2xl font-bold">Zamówienia u dostawców</h1>
+          <p className="text-muted-foreground mt-1">
+            Pozycje zamówień realizowane bezpośrednio przez dostawców (dropshipping)
+          </p>
+        </div>
+
+        <div className="flex items-center gap-4">
+          <div className="w-[200px]">
+            <Select
+              value={statusFilter || "all"}
+              onValueChange={(value) => {
+                setStatusFilter(value === "all" ? "" : value);
+                setOffset(0);
+              }}
+            >
+              <SelectTrigger className="w-full">
+                <SelectValue placeholder="Status" />
+              </SelectTrigger>
+              <SelectContent>
+                <SelectItem value="all">Wszystkie</SelectItem>
+                {Object.entries(SUPPLIER_ORDER_STATUSES).map(([value, { label }]) => (
+                  <SelectItem key={value} value={value}>
+                    {label}
+                  </SelectItem>
+                ))}
+              </SelectContent>
+            </Select>
+          </div>
+        </div>
+
+        {isError && (
+          <div className="rounded-md border border-destructive bg-destructive/10 p-4">
+            <p className="text-sm text-destructive">
+              Wystąpił błąd podczas ładowania danych. Spróbuj odświeżyć stronę.
+            </p>
+            <Button variant="outline" size="sm" className="mt-2" onClick={() => refetch()}>
+              Spróbuj ponownie
+            </Button>
+          </div>
+        )}
+
+        <div className="rounded-md border">
+          <DataTable<SupplierOrder>
+            columns={columns}
+            data={data?.items || []}
+            isLoading={isLoading}
+            emptyState={
+              <EmptyState
+                icon={PackageSearch}
+                title="Brak zamówień u dostawców"
+                description="Zamówienia pojawią się, gdy pozycje zamówień zostaną skierowane do dostawców."
+              />
+            }
+          />
+        </div>
+
+        {data && (
+          <DataTablePagination
+            total={data.total}
+            limit={limit}
+            offset={offset}
+            onPageChange={setOffset}
+            onPageSizeChange={(newLimit) => {
+              setLimit(newLimit);
+              setOffset(0);
+            }}
+          />
+        )}
+      </div>
+
+      <Dialog open={!!trackingFor} onOpenChange={(open) => !open && setTrackingFor(null)}>
+        <DialogContent>
+          <DialogHeader>
+            <DialogTitle>Numer przesyłki od dostawcy</DialogTitle>
+            <DialogDescription>
+              Do zamówienia zostanie dodana przesyłka. Jeśli przewoźnik ma aktywną integrację,
+              jej status będzie śledzony automatycznie.
+            </DialogDescription>
+          </DialogHeader>
+          <div className="space-y-4">
+            <div className="space-y-2">
+              <Label htmlFor="carrier">Przewoźnik</Label>
+              <Input
+                id="carrier"
+                placeholder="np. inpost, dpd, dhl"
+                value={carrier}
+                onChange={(e) => setCarrier(e.target.value)}
+              />
+            </div>
+            <div className="space-y-2">
+              <Label htmlFor="tracking_number">Numer przesyłki</Label>
+              <Input
+                id="tracking_number"
+                value={trackingNumber}
+                onChange={(e) => setTrackingNumber(e.target.value)}
+              />
+            </div>
+          </div>
+          <DialogFooter>
+            <Button variant="outline" onClick={() => setTrackingFor(null)}>
+              Anuluj
+            </Button>
+            <Button
+              onClick={handleTracking}
+              disabled={!trackingNumber.trim() || recordTracking.isPending}
+            >
+              {recordTracking.isPending ? "Zapisywanie..." : "Zapisz"}
+            </Button>
+          </DialogFooter>
+        </DialogContent>
+      </Dialog>
+
+      <ConfirmDialog
+        open={!!cancelId}
+        onOpenChange={(open) => !open && setCancelId(null)}
+        title="Anuluj zamówienie u dostawcy"
+        description="Pozycje wrócą do realizacji z własnego stanu i mogą zostać ponownie skierowane do dostawcy."
+        confirmLabel="Anuluj zamówienie"
+        variant="destructive"
+        onConfirm={handleCancel}
+        isLoading={cancel.isPending}
+      />
+    </AdminGuard>
+  );
+}
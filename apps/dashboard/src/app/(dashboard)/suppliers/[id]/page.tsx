@@ -14,6 +14,8 @@ import {
 } from "@/hooks/use-suppliers";
 import { useProducts } from "@/hooks/use-products";
 import { LoadingSkeleton } from "@/components/shared/loading-skeleton";
+import { FeedMappingCard } from "@/components/suppliers/feed-mapping-card";
+import { DropshipSettingsCard } from "@/components/suppliers/dropship-settings-card";
 import { StatusBadge } from "@/components/shared/status-badge";
 import { getErrorMessage } from "@/lib/api-client";
 import { formatDate, formatCurrency } from "@/lib/utils";
@@ -183,7 +185,7 @@ export default function SupplierDetailPage() {
                   <SelectContent>
                     <SelectItem value="iof">IOF</SelectItem>
                     <SelectItem value="csv">CSV</SelectItem>
-                    <SelectItem value="xml">XML</SelectItem>
+                    <SelectItem value="custom">XML (własne mapowanie)</SelectItem>
                   </SelectContent>
                 </Select>
               </div>
@@ -233,6 +235,12 @@ export default function SupplierDetailPage() {
         </Card>
       </div>
 
+      {(feedFormat === "csv" || feedFormat === "custom") && (
+        <FeedMappingCard supplier={supplier} feedUrl={feedUrl} feedFormat={feedFormat} />
+      )}
+
+      <DropshipSettingsCard supplier={supplier} />
+
       <Card>
         <CardHeader>
           <CardTitle>Produkty dostawcy ({productsData?.total ?? 0})</CardTitle>
@@ -108,7 +108,7 @@ export default function NewSupplierPage() {
                 <SelectContent>
                   <SelectItem value="iof">IOF (Internet Offer Format)</SelectItem>
                   <SelectItem value="csv">CSV</SelectItem>
-                  <SelectItem value="xml">XML</SelectItem>
+                  <SelectItem value="custom">XML (własne mapowanie)</SelectItem>
                 </SelectContent>
               </Select>
             </div>
@@ -2,7 +2,7 @@
 
 import { useEffect, useState } from "react";
 import { useParams } from "next/navigation";
-import { RotateCcw, Clock, CheckCircle2, XCircle, Package, ArrowLeft } from "lucide-react";
+import { RotateCcw, Clock, CheckCircle2, XCircle, Package, ArrowLeft, Download } from "lucide-react";
 import Link from "next/link";
 import { Button } from "@/components/ui/button";
 import {
@@ -21,13 +21,27 @@ const STATUS_CONFIG: Record<string, { label: string; color: string; icon: React.
   requested: { label: "Zgłoszone", color: "bg-yellow-100 text-yellow-800 dark:bg-yellow-900 dark:text-yellow-200", icon: Clock },
   approved: { label: "Zatwierdzone", color: "bg-blue-100 text-blue-800 dark:bg-blue-900 dark:text-blue-200", icon: CheckCircle2 },
   received: { label: "Odebrane", color: "bg-indigo-100 text-indigo-800 dark:bg-indigo-900 dark:text-indigo-200", icon: Package },
+  partially_refunded: { label: "Częściowo zwrócone", color: "bg-teal-100 text-teal-800 dark:bg-teal-900 dark:text-teal-200", icon: Package },
   refunded: { label: "Zwrócone", color: "bg-green-100 text-green-800 dark:bg-green-900 dark:text-green-200", icon: CheckCircle2 },
+  exchanged: { label: "Wymienione", color: "bg-green-100 text-green-800 dark:bg-green-900 dark:text-green-200", icon: CheckCircle2 },
   rejected: { label: "Odrzucone", color: "bg-red-100 text-red-800 dark:bg-red-900 dark:text-red-200", icon: XCircle },
   cancelled: { label: "Anulowane", color: "bg-gray-100 text-gray-800 dark:bg-gray-800 dark:text-gray-200", icon: XCircle },
 };
 
 const STATUS_ORDER = ["requested", "approved", "received", "refunded"];
 
+// Statuses shown at a step of STATUS_ORDER in the progress bar.
+const STATUS_PROGRESS: Record<string, string> = {
+  partially_refunded: "received",
+  exchanged: "refunded",
+};
+
+const RETURN_CARRIERS: Record<string, string> = {
+  inpost: "InPost",
+  dpd: "DPD",
+  orlen_paczka: "Orlen Paczka",
+};
+
 export default function PublicReturnStatusPage() {
   const params = useParams();
   const token = params.token as string;
@@ -71,7 +85,9 @@ export default function PublicReturnStatusPage() {
     });
   };
 
-  const currentStatusIndex = data ? STATUS_ORDER.indexOf(data.status) : -1;
+  const currentStatusIndex = data
+    ? STATUS_ORDER.indexOf(STATUS_PROGRESS[data.status] ?? data.status)
+    : -1;
   const isTerminal = data?.status === "rejected" || data?.status === "cancelled";
 
   return (
@@ -179,6 +195,32 @@ export default function PublicReturnStatusPage() {
                 </div>
               )}
 
+              {/* Prepaid return label */}
+              {data.return_label && data.status === "approved" && (
+                <div className="rounded-md border p-4 space-y-3">
+                  <h3 className="text-sm font-medium">Etykieta zwrotna</h3>
+                  <p className="text-sm text-muted-foreground">
+                    Wydrukuj etykietę i nadaj paczkę
+                    {data.return_label.provider
+                      ? ` w ${RETURN_CARRIERS[data.return_label.provider] || data.return_label.provider}`
+                      : ""}
+                    . Przesyłka jest opłacona.
+                  </p>
+                  <p className="text-sm">
+                    Numer przesyłki:{" "}
+                    <span className="font-mono font-medium">{data.return_label.tracking_number}</span>
+                  </p>
+                  {data.return_label.label_url && (
+                    <Button className="w-full" asChild>
+                      <a href={data.return_label.label_url} target="_blank" rel="noopener noreferrer">
+                        <Download className="h-4 w-4 mr-2" />
+                        Pobierz etykietę
+                      </a>
+                    </Button>
+                  )}
+                </div>
+              )}
+
               {/* Reason */}
               <div>
                 <h3 className="text-sm font-medium text-muted-foreground mb-1">
@@ -0,0 +1,222 @@
+"use client";
+
+import { useEffect, useState } from "react";
+import { useParams } from "next/navigation";
+import { Truck, XCircle, CheckCircle2 } from "lucide-react";
+import { Button } from "@/components/ui/button";
+import { Input } from "@/components/ui/input";
+import { Label } from "@/components/ui/label";
+import {
+  Card,
+  CardContent,
+  CardDescription,
+  CardHeader,
+  CardTitle,
+} from "@/components/ui/card";
+import type { PublicSupplierOrder } from "@/types/api";
+
+const API_URL = process.env.NEXT_PUBLIC_API_URL || "http://localhost:8080";
+
+export default function PublicSupplierOrderPage() {
+  const params = useParams();
+  const token = params.token as string;
+  const [data, setData] = useState<PublicSupplierOrder | null>(null);
+  const [error, setError] = useState<string | null>(null);
+  const [isLoading, setIsLoading] = useState(true);
+  const [carrier, setCarrier] = useState("");
+  const [trackingNumber, setTrackingNumber] = useState("");
+  const [isSubmitting, setIsSubmitting] = useState(false);
+  const [submitError, setSubmitError] = useState<string | null>(null);
+
+  useEffect(() => {
+    if (!token) return;
+
+    const fetchOrder = async () => {
+      try {
+        const res = await fetch(`${API_URL}/v1/public/supplier-orders/${token}`);
+        if (!res.ok) {
+          if (res.status === 404) {
+            setError("Nie znaleziono zamówienia o podanym linku.");
+          } else {
+            setError("Wystąpił błąd podczas ładowania zamówienia.");
+          }
+          return;
+        }
+        setData(await res.json());
+      } catch {
+        setError("Nie udało się połączyć z serwerem.");
+      } finally {
+        setIsLoading(false);
+      }
+    };
+
+    fetchOrder();
+  }, [token]);
+
+  const handleSubmit = async (e: React.FormEvent) => {
+    e.preventDefault();
+    if (!data) return;
+    setIsSubmitting(true);
+    setSubmitError(null);
+    try {
+      const res = await fetch(`${API_URL}/v1/public/supplier-orders/${token}/tracking`, {
+        method: "POST",
+        headers: { "Content-Type": "application/json" },
+        body: JSON.stringify({ carrier: carrier || undefined, tracking_number: trackingNumber }),
+      });
+      if (!res.ok) {
+        const body = await res.json().catch(() => null);
+        setSubmitError(body?.error || "Nie udało się zapisać numeru przesyłki.");
+        return;
+      }
+      const result = await res.json();
+      setData({
+        ...data,
+        status: result.status,
+        carrier: result.carrier,
+        tracking_number: result.tracking_number,
+      });
+    } catch {
+      setSubmitError("Nie udało się połączyć z serwerem.");
+    } finally {
+      setIsSubmitting(false);
+    }
+  };
+
+  const formatAmount = (amount: number) =>
+    new Intl.NumberFormat("pl-PL", { style: "currency", currency: "PLN" }).format(amount);
+
+  const address = data?.shipping_address;
+
+  return (
+    <div className="min-h-screen bg-gray-50 dark:bg-gray-950 flex items-center justify-center p-4">
+      <div className="w-full max-w-2xl">
+        <div className="text-center mb-8">
+          <div className="inline-flex items-center gap-2 mb-2">
+            <Truck className="h-8 w-8 text-primary" />
+            <span className="text-2xl font-bold">{data?.company_name || "OpenOMS"}</span>
+          </div>
+          <p className="text-muted-foreground">Zamówienie do wysyłki</p>
+        </div>
+
+        {isLoading && (
+          <Card>
+            <CardContent className="py-12">
+              <div className="flex items-center justify-center">
+                <div className="animate-spin rounded-full h-8 w-8 border-b-2 border-primary" />
+              </div>
+              <p className="text-center mt-4 text-muted-foreground">
+                Ładowanie...
+              </p>
+            </CardContent>
+          </Card>
+        )}
+
+        {error && (
+          <Card>
+            <CardContent className="py-12">
+              <div className="text-center">
+                <XCircle className="h-12 w-12 text-destructive mx-auto mb-4" />
+                <p className="text-destructive">{error}</p>
+              </div>
+            </CardContent>
+          </Card>
+        )}
+
+        {data && !error && (
+          <div className="space-y-4">
+            <Card>
+              <CardHeader>
+                <CardTitle>Zamówienie #{data.id.slice(0, 8)}</CardTitle>
+                <CardDescription>
+                  Prosimy o wysyłkę poniższych pozycji bezpośrednio do klienta.
+                </CardDescription>
+              </CardHeader>
+              <CardContent className="space-y-6">
+                <div className="space-y-2">
+                  {data.items.map((item) => (
+                    <div key={item.line_index} className="flex justify-between gap-4 text-sm">
+                      <div>
+                        <p className="font-medium">{item.name}</p>
+                        <p className="text-xs text-muted-foreground">
+                          Kod: {item.external_id}
+                          {item.ean ? ` · EAN: ${item.ean}` : ""}
+                        </p>
+                      </div>
+                      <span className="whitespace-nowrap">{item.quantity} szt.</span>
+                    </div>
+                  ))}
+                  <div className="flex justify-between border-t pt-2 text-sm font-medium">
+                    <span>Wartość</span>
+                    <span>{formatAmount(data.total_cost)}</span>
+                  </div>
+                </div>
+
+                <div className="text-sm">
+                  <p className="font-medium mb-1">Adres dostawy</p>
+                  <p>{address?.name || data.customer_name}</p>
+                  {address?.company && <p>{address.company}</p>}
+                  {address?.street && <p>{address.street}</p>}
+                  {(address?.postal_code || address?.city) && (
+                    <p>
+                      {address?.postal_code} {address?.city}
+                    </p>
+                  )}
+                  {address?.country && <p>{address.country}</p>}
+                  {data.customer_phone && <p>Tel. {data.customer_phone}</p>}
+                </div>
+              </CardContent>
+            </Card>
+
+            <Card>
+              <CardHeader>
+                <CardTitle>Przesyłka</CardTitle>
+              </CardHeader>
+              <CardContent>
+                {data.status === "shipped" ? (
+                  <div className="flex items-center gap-3 text-sm">
+                    <CheckCircle2 className="h-5 w-5 text-green-600" />
+                    <span>
+                      Wysłano{data.carrier ? ` (${data.carrier})` : ""}: {data.tracking_number}
+                    </span>
+                  </div>
+                ) : data.status === "cancelled" ? (
+                  <p className="text-sm text-muted-foreground">
+                    Zamówienie zostało anulowane — prosimy go nie wysyłać.
+                  </p>
+                ) : (
+                  <form onSubmit={handleSubmit} className="space-y-4">
+                    <div className="grid gap-4 md:grid-cols-2">
+                      <div className="space-y-2">
+                        <Label htmlFor="carrier">Przewoźnik</Label>
+                        <Input
+                          id="carrier"
+                          placeholder="np. inpost, dpd, dhl"
+                          value={carrier}
+                          onChange={(e) => setCarrier(e.target.value)}
+                        />
+                      </div>
+                      <div className="space-y-2">
+                        <Label htmlFor="tracking_number">Numer przesyłki</Label>
+                        <Input
+                          id="tracking_number"
+                          value={trackingNumber}
+                          onChange={(e) => setTrackingNumber(e.target.value)}
+                          required
+                        />
+                      </div>
+                    </div>
+                    {submitError && <p className="text-sm text-destructive">{submitError}</p>}
+                    <Button type="submit" disabled={isSubmitting || !trackingNumber.trim()}>
+                      {isSubmitting ? "Zapisywanie..." : "Potwierdź wysyłkę"}
+                    </Button>
+                  </form>
+                )}
+              </CardContent>
+            </Card>
+          </div>
+        )}
+      </div>
+    </div>
+  );
+}
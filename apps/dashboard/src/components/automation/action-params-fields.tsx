@@ -0,0 +1,195 @@
+"use client";
+
+import { Input } from "@/components/ui/input";
+import { Label } from "@/components/ui/label";
+import { Textarea } from "@/components/ui/textarea";
+import { Switch } from "@/components/ui/switch";
+import {
+  Select,
+  SelectContent,
+  SelectItem,
+  SelectTrigger,
+  SelectValue,
+} from "@/components/ui/select";
+import { useUsers } from "@/hooks/use-users";
+import {
+  AUTOMATION_ACTION_LABELS,
+  AUTOMATION_ACTION_PARAMS,
+  type AutomationActionParamField,
+} from "@/lib/constants";
+import type { AutomationAction, ActionDryRun } from "@/types/api";
+
+export function ActionParamsFields({
+  action,
+  onChange,
+}: {
+  action: AutomationAction;
+  onChange: (config: Record<string, unknown>) => void;
+}) {
+  const fields = AUTOMATION_ACTION_PARAMS[action.type] ?? [];
+  if (fields.length === 0) {
+    return (
+      <p className="text-xs text-muted-foreground">
+        {action.type === "split_order_by_warehouse"
+          ? "Zamówienie zostanie podzielone tylko wtedy, gdy żaden magazyn nie ma wszystkich pozycji."
+          : "Akcja nie wymaga parametrów."}
+      </p>
+    );
+  }
+
+  const hasTemplates = fields.some((f) => f.template);
+
+  return (
+    <div className="space-y-3">
+      <div className="grid grid-cols-1 gap-3 sm:grid-cols-2">
+        {fields.map((field) => (
+          <ActionParamField
+            key={field.key}
+            field={field}
+            value={action.config[field.key]}
+            onChange={(value) => onChange({ ...action.config, [field.key]: value })}
+          />
+        ))}
+      </div>
+      {hasTemplates && (
+        <p className="text-xs text-muted-foreground">
+          Pola tekstowe obsługują zmienne, np. {"{{order.customer_name}}"}, {"{{order.external_id}}"}, {"{{order.total_amount}}"}.
+        </p>
+      )}
+    </div>
+  );
+}
+
+function ActionParamField({
+  field,
+  value,
+  onChange,
+}: {
+  field: AutomationActionParamField;
+  value: unknown;
+  onChange: (value: unknown) => void;
+}) {
+  const label = field.required ? `${field.label} *` : field.label;
+
+  if (field.type === "checkbox") {
+    return (
+      <div className="flex items-center gap-2 pt-5">
+        <Switch checked={value === true} onCheckedChange={(checked) => onChange(checked)} />
+        <Label className="text-xs">{label}</Label>
+      </div>
+    );
+  }
+
+  if (field.type === "select") {
+    return (
+      <div className="space-y-1">
+        <Label className="text-xs">{label}</Label>
+        <Select value={typeof value === "string" ? value : ""} onValueChange={onChange}>
+          <SelectTrigger className="w-full">
+            <SelectValue placeholder="Wybierz..." />
+          </SelectTrigger>
+          <SelectContent>
+            {field.options?.map((opt) => (
+              <SelectItem key={opt.value} value={opt.value}>
+                {opt.label}
+              </SelectItem>
+            ))}
+          </SelectContent>
+        </Select>
+      </div>
+    );
+  }
+
+  if (field.type === "user") {
+    return <UserParamField label={label} value={value} onChange={onChange} />;
+  }
+
+  if (field.type === "textarea") {
+    return (
+      <div className="space-y-1 sm:col-span-2">
+        <Label className="text-xs">{label}</Label>
+        <Textarea
+          value={typeof value === "string" ? value : ""}
+          onChange={(e) => onChange(e.target.value)}
+          placeholder={field.placeholder}
+          rows={3}
+        />
+      </div>
+    );
+  }
+
+  return (
+    <div className="space-y-1">
+      <Label className="text-xs">{label}</Label>
+      <Input
+        value={typeof value === "string" ? value : ""}
+        onChange={(e) => onChange(e.target.value)}
+        placeholder={field.placeholder}
+      />
+    </div>
+  );
+}
+
+function UserParamField({
+  label,
+  value,
+  onChange,
+}: {
+  label: string;
+  value: unknown;
+  onChange: (value: unknown) => void;
+}) {
+  const { data: users } = useUsers();
+
+  return (
+    <div className="space-y-1">
+      <Label className="text-xs">{label}</Label>
+      <Select value={typeof value === "string" ? value : ""} onValueChange={onChange}>
+        <SelectTrigger className="w-full">
+          <SelectValue placeholder="Wybierz użytkownika..." />
+        </SelectTrigger>
+        <SelectContent>
+          {users?.map((u) => (
+            <SelectItem key={u.id} value={u.id}>
+              {u.name} ({u.email})
+            </SelectItem>
+          ))}
+        </SelectContent>
+      </Select>
+    </div>
+  );
+}
+
+// ActionDryRunList shows the actions of a dry-run test with their rendered
+// parameters.
+export function ActionDryRunList({ results }: { results: ActionDryRun[] }) {
+  if (results.length === 0) {
+    return <p className="text-sm text-muted-foreground">Reguła nie ma akcji.</p>;
+  }
+
+  return (
+    <div className="space-y-2">
+      {results.map((result, index) => (
+        <div key={index} className="rounded-md border p-3 space-y-2">
+          <div className="flex items-center justify-between gap-2">
+            <span className="text-sm font-medium">{AUTOMATION_ACTION_LABELS[result.type] || result.type}</span>
+            {result.delay_seconds ? (
+              <span className="text-xs text-muted-foreground">
+                po {Math.round(result.delay_seconds / 60)} min
+              </span>
+            ) : null}
+          </div>
+          <pre className="rounded-md bg-muted p-2 text-xs font-mono overflow-auto max-h-[200px]">
+            {JSON.stringify(result.params ?? {}, null, 2)}
+          </pre>
+          {result.missing.length > 0 && (
+            <p className="text-xs text-amber-600">
+              Brak wartości dla: {result.missing.map((m) => `{{${m}}}`).join(", ")}
+            </p>
+          )}
+          {result.error && <p className="text-xs text-destructive">{result.error}</p>}
+        </div>
+      ))}
+    </div>
+  );
+}
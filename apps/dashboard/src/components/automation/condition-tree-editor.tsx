@@ -0,0 +1,313 @@
+"use client";
+
+import { Button } from "@/components/ui/button";
+import { Input } from "@/components/ui/input";
+import { Label } from "@/components/ui/label";
+import { Badge } from "@/components/ui/badge";
+import {
+  Select,
+  SelectContent,
+  SelectItem,
+  SelectTrigger,
+  SelectValue,
+} from "@/components/ui/select";
+import {
+  AUTOMATION_OPERATORS,
+  AUTOMATION_OPERATOR_LABELS,
+  AUTOMATION_GROUP_LABELS,
+} from "@/lib/constants";
+import { FolderPlus, Plus, Trash2 } from "lucide-react";
+import type {
+  AutomationCondition,
+  AutomationConditionGroup,
+  AutomationConditionGroupOp,
+  AutomationConditionNode,
+  AutomationConditions,
+  ConditionTreeResult,
+} from "@/types/api";
+
+const MAX_DEPTH = 8;
+
+const VALUELESS_OPERATORS = new Set(["is_empty", "is_not_empty"]);
+
+const VALUE_PLACEHOLDERS: Record<string, string> = {
+  regex: "np. ^PL",
+  length_eq: "liczba",
+  length_gt: "liczba",
+  length_lt: "liczba",
+  older_than: "np. 48h, 7d, 2w",
+  newer_than: "np. 48h, 7d, 2w",
+};
+
+export function isConditionGroup(node: AutomationConditionNode): node is AutomationConditionGroup {
+  return "op" in node && !!node.op;
+}
+
+// toConditionGroup reads stored conditions; a legacy flat list becomes an AND group.
+export function toConditionGroup(conditions: AutomationConditions | null | undefined): AutomationConditionGroup {
+  if (!conditions) return { op: "and", conditions: [] };
+  if (Array.isArray(conditions)) return { op: "and", conditions };
+  return { op: conditions.op, conditions: conditions.conditions ?? [] };
+}
+
+// fromConditionGroup returns the payload to save; a rule without conditions is an empty list.
+export function fromConditionGroup(group: AutomationConditionGroup): AutomationConditions {
+  return group.conditions.length === 0 ? [] : group;
+}
+
+function emptyCondition(): AutomationCondition {
+  return { field: "", operator: "eq", value: "" };
+}
+
+function defaultValue(operator: string): unknown {
+  if (operator === "between") return [0, 0];
+  if (VALUELESS_OPERATORS.has(operator)) return undefined;
+  return "";
+}
+
+function ConditionRow({
+  condition,
+  onChange,
+  onRemove,
+}: {
+  condition: AutomationCondition;
+  onChange: (condition: AutomationCondition) => void;
+  onRemove: () => void;
+}) {
+  const bounds = Array.isArray(condition.value) ? condition.value : ["", ""];
+
+  return (
+    <div className="flex items-start gap-3 rounded-md border bg-background p-3">
+      <div className="grid flex-1 grid-cols-1 gap-3 sm:grid-cols-3">
+        <div className="space-y-1">
+          <Label className="text-xs">Pole</Label>
+          <Input
+            value={condition.field}
+            onChange={(e) => onChange({ ...condition, field: e.target.value })}
+            placeholder="np. status, total_amount"
+          />
+        </div>
+        <div className="space-y-1">
+          <Label className="text-xs">Operator</Label>
+          <Select
+            value={condition.operator}
+            onValueChange={(v) => onChange({ ...condition, operator: v, value: defaultValue(v) })}
+          >
+            <SelectTrigger className="w-full">
+              <SelectValue />
+            </SelectTrigger>
+            <SelectContent>
+              {AUTOMATION_OPERATORS.map((op) => (
+                <SelectItem key={op} value={op}>
+                  {AUTOMATION_OPERATOR_LABELS[op] || op}
+                </SelectItem>
+              ))}
+            </SelectContent>
+          </Select>
+        </div>
+        {condition.operator === "between" ? (
+          <div className="space-y-1">
+            <Label className="text-xs">Zakres</Label>
+            <div className="flex items-center gap-2">
+              <Input
+                type="number"
+                value={String(bounds[0] ?? "")}
+                onChange={(e) => onChange({ ...condition, value: [Number(e.target.value), bounds[1]] })}
+                placeholder="od"
+              />
+              <Input
+                type="number"
+                value={String(bounds[1] ?? "")}
+                onChange={(e) => onChange({ ...condition, value: [bounds[0], Number(e.target.value)] })}
+                placeholder="do"
+              />
+            </div>
+          </div>
+        ) : !VALUELESS_OPERATORS.has(condition.operator) ? (
+          <div className="space-y-1">
+            <Label className="text-xs">Wartość</Label>
+            <Input
+              value={String(condition.value ?? "")}
+              onChange={(e) => onChange({ ...condition, value: e.target.value })}
+              placeholder={VALUE_PLACEHOLDERS[condition.operator] ?? "wartość"}
+            />
+          </div>
+        ) : null}
+      </div>
+      <Button variant="ghost" size="sm" className="mt-5" onClick={onRemove}>
+        <Trash2 className="h-4 w-4 text-destructive" />
+      </Button>
+    </div>
+  );
+}
+
+function ConditionGroupEditor({
+  group,
+  depth,
+  onChange,
+  onRemove,
+}: {
+  group: AutomationConditionGroup;
+  depth: number;
+  onChange: (group: AutomationConditionGroup) => void;
+  onRemove?: () => void;
+}) {
+  const canAdd = group.op !== "not" || group.conditions.length === 0;
+
+  const updateChild = (index: number, node: AutomationConditionNode) => {
+    onChange({ ...group, conditions: group.conditions.map((c, i) => (i === index ? node : c)) });
+  };
+
+  const removeChild = (index: number) => {
+    onChange({ ...group, conditions: group.conditions.filter((_, i) => i !== index) });
+  };
+
+  const setOp = (op: AutomationConditionGroupOp) => {
+    // NOT negates a single condition, so extra children are wrapped in an AND group.
+    if (op === "not" && group.conditions.length > 1) {
+      onChange({ op, conditions: [{ op: "and", conditions: group.conditions }] });
+      return;
+    }
+    onChange({ ...group, op });
+  };
+
+  return (
+    <div className={`space-y-3 rounded-md border p-3 ${depth > 0 ? "bg-muted/40" : ""}`}>
+      <div className="flex flex-wrap items-center gap-2">
+        <Select value={group.op} onValueChange={(v) => setOp(v as AutomationConditionGroupOp)}>
+          <SelectTrigger className="w-48">
+            <SelectValue />
+          </SelectTrigger>
+          <SelectContent>
+            {Object.entries(AUTOMATION_GROUP_LABELS).map(([op, label]) => (
+              <SelectItem key={op} value={op}>
+                {label}
+              </SelectItem>
+            ))}
+          </SelectContent>
+        </Select>
+        {canAdd && (
+          <>
+            <Button
+              variant="outline"
+              size="sm"
+              onClick={() => onChange({ ...group, conditions: [...group.conditions, emptyCondition()] })}
+            >
+              <Plus className="h-4 w-4" />
+              Warunek
+            </Button>
+            {depth + 1 < MAX_DEPTH && (
+              <Button
+                variant="outline"
+                size="sm"
+                onClick={() =>
+                  onChange({
+                    ...group,
+                    conditions: [...group.conditions, { op: "and", conditions: [emptyCondition()] }],
+                  })
+                }
+              >
+                <FolderPlus className="h-4 w-4" />
+                Grupa
+              </Button>
+            )}
+          </>
+        )}
+        {onRemove && (
+          <Button variant="ghost" size="sm" className="ml-auto" onClick={onRemove}>
+            <Trash2 className="h-4 w-4 text-destructive" />
+          </Button>
+        )}
+      </div>
+      {group.conditions.map((node, index) =>
+        isConditionGroup(node) ? (
+          <ConditionGroupEditor
+            key={index}
+            group={node}
+            depth={depth + 1}
+            onChange={(g) => updateChild(index, g)}
+            onRemove={() => removeChild(index)}
+          />
+        ) : (
+          <ConditionRow
+            key={index}
+            condition={node}
+            onChange={(c) => updateChild(index, c)}
+            onRemove={() => removeChild(index)}
+          />
+        )
+      )}
+    </div>
+  );
+}
+
+export function ConditionTreeEditor({
+  value,
+  onChange,
+}: {
+  value: AutomationConditionGroup;
+  onChange: (group: AutomationConditionGroup) => void;
+}) {
+  if (value.conditions.length === 0) {
+    return (
+      <div className="space-y-3">
+        <p className="text-sm text-muted-foreground">
+          Brak warunków - reguła uruchomi się przy każdym zdarzeniu wybranego typu.
+        </p>
+        <Button
+          variant="outline"
+          size="sm"
+          onClick={() => onChange({ op: "and", conditions: [emptyCondition()] })}
+        >
+          <Plus className="h-4 w-4" />
+          Dodaj warunek
+        </Button>
+      </div>
+    );
+  }
+  return <ConditionGroupEditor group={value} depth={0} onChange={onChange} />;
+}
+
+function formatValue(value: unknown): string {
+  if (value === undefined) return "";
+  if (typeof value === "string") return value;
+  return JSON.stringify(value);
+}
+
+export function ConditionTreeResultView({ result }: { result: ConditionTreeResult }) {
+  const badge = (
+    <Badge variant={result.met ? "success" : "secondary"}>
+      {result.met ? "Spełniony" : "Niespełniony"}
+    </Badge>
+  );
+
+  if (!result.op) {
+    return (
+      <div className="flex flex-wrap items-center gap-2 text-sm">
+        {badge}
+        <span className="font-mono">{result.field}</span>
+        <span className="text-muted-foreground">
+          {AUTOMATION_OPERATOR_LABELS[result.operator ?? ""] || result.operator}
+        </span>
+        <span className="font-mono">{formatValue(result.value)}</span>
+        <span className="text-xs text-muted-foreground">
+          (wartość: <span className="font-mono">{formatValue(result.actual) || "brak"}</span>)
+        </span>
+      </div>
+    );
+  }
+
+  return (
+    <div className="space-y-2">
+      <div className="flex items-center gap-2 text-sm font-medium">
+        {badge}
+        {AUTOMATION_GROUP_LABELS[result.op] || result.op}
+      </div>
+      <div className="space-y-2 border-l pl-4">
+        {(result.conditions ?? []).map((child, index) => (
+          <ConditionTreeResultView key={index} result={child} />
+        ))}
+      </div>
+    </div>
+  );
+}
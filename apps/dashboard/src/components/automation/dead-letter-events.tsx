@@ -0,0 +1,137 @@
+"use client";
+
+import { useState } from "react";
+import { toast } from "sonner";
+import { useAutomationEvents, useRetryAutomationEvent } from "@/hooks/use-automation";
+import { DataTablePagination } from "@/components/shared/data-table-pagination";
+import { LoadingSkeleton } from "@/components/shared/loading-skeleton";
+import { Button } from "@/components/ui/button";
+import { Badge } from "@/components/ui/badge";
+import {
+  Table,
+  TableBody,
+  TableCell,
+  TableHead,
+  TableHeader,
+  TableRow,
+} from "@/components/ui/table";
+import { AUTOMATION_TRIGGER_LABELS } from "@/lib/constants";
+import { formatDateTime } from "@/lib/utils";
+import { RotateCcw } from "lucide-react";
+
+// DeadLetterEvents lists automation events that failed every attempt and
+// lets an admin put them back in the queue.
+export function DeadLetterEvents() {
+  const [limit, setLimit] = useState(20);
+  const [offset, setOffset] = useState(0);
+
+  const { data, isLoading, isError, refetch } = useAutomationEvents({
+    status: "dead",
+    limit,
+    offset,
+  });
+  const retryEvent = useRetryAutomationEvent();
+
+  const handleRetry = async (id: string) => {
+    try {
+      await retryEvent.mutateAsync(id);
+      toast.success("Zdarzenie wróciło do kolejki");
+    } catch (err) {
+      const message = err instanceof Error ? err.message : "Nie udało się ponowić zdarzenia";
+      toast.error(message);
+    }
+  };
+
+  return (
+    <div className="space-y-4">
+      <p className="text-sm text-muted-foreground">
+        Zdarzenia, których nie udało się przetworzyć mimo ponawiania. Po usunięciu przyczyny błędu można je
+        ponowić — reguły zostaną uruchomione jeszcze raz.
+      </p>
+
+      {isError && (
+        <div className="rounded-md border border-destructive bg-destructive/10 p-4">
+          <p className="text-sm text-destructive">
+            Wystąpił błąd podczas ładowania danych. Spróbuj odświeżyć stronę.
+          </p>
+          <Button variant="outline" size="sm" className="mt-2" onClick={() => refetch()}>
+            Spróbuj ponownie
+          </Button>
+        </div>
+      )}
+
+      {isLoading ? (
+        <LoadingSkeleton />
+      ) : (
+        <div className="rounded-md border">
+          <Table>
+            <TableHeader>
+              <TableRow>
+                <TableHead>Zdarzenie</TableHead>
+                <TableHead>Obiekt</TableHead>
+                <TableHead>Utworzono</TableHead>
+                <TableHead className="text-right">Próby</TableHead>
+                <TableHead>Ostatni błąd</TableHead>
+                <TableHead className="w-[110px]" />
+              </TableRow>
+            </TableHeader>
+            <TableBody>
+              {data?.items && data.items.length > 0 ? (
+                data.items.map((event) => (
+                  <TableRow key={event.id}>
+                    <TableCell>
+                      <Badge variant="outline">
+                        {AUTOMATION_TRIGGER_LABELS[event.event_type] || event.event_type}
+                      </Badge>
+                    </TableCell>
+                    <TableCell>
+                      <div className="text-sm">{event.entity_type}</div>
+                      <div className="font-mono text-xs text-muted-foreground">{event.entity_id}</div>
+                    </TableCell>
+                    <TableCell className="whitespace-nowrap">{formatDateTime(event.created_at)}</TableCell>
+                    <TableCell className="text-right">{event.attempts}</TableCell>
+                    <TableCell>
+                      <div className="max-w-[320px] truncate text-sm text-destructive" title={event.last_error}>
+                        {event.last_error || "—"}
+                      </div>
+                    </TableCell>
+                    <TableCell>
+                      <Button
+                        variant="outline"
+                        size="sm"
+                        onClick={() => handleRetry(event.id)}
+                        disabled={retryEvent.isPending}
+                      >
+                        <RotateCcw className="h-4 w-4" />
+                        Ponów
+                      </Button>
+                    </TableCell>
+                  </TableRow>
+                ))
+              ) : (
+                <TableRow>
+                  <TableCell colSpan={6} className="h-24 text-center text-muted-foreground">
+                    Brak nieudanych zdarzeń
+                  </TableCell>
+                </TableRow>
+              )}
+            </TableBody>
+          </Table>
+        </div>
+      )}
+
+      {data && data.total > 0 && (
+        <DataTablePagination
+          total={data.total}
+          limit={limit}
+          offset={offset}
+          onPageChange={setOffset}
+          onPageSizeChange={(newLimit) => {
+            setLimit(newLimit);
+            setOffset(0);
+          }}
+        />
+      )}
+    </div>
+  );
+}
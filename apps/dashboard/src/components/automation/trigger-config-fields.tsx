@@ -0,0 +1,92 @@
+"use client";
+
+import { Input } from "@/components/ui/input";
+import { Label } from "@/components/ui/label";
+import { AUTOMATION_STATUS_DURATION_TRIGGERS } from "@/lib/constants";
+import type { AutomationTriggerConfig } from "@/types/api";
+
+export function isStatusDurationTrigger(event: string): boolean {
+  return (AUTOMATION_STATUS_DURATION_TRIGGERS as readonly string[]).includes(event);
+}
+
+// triggerConfigFor keeps only the fields used by the selected trigger.
+export function triggerConfigFor(event: string, config: AutomationTriggerConfig): AutomationTriggerConfig {
+  if (event === "schedule") {
+    return { schedule: config.schedule?.trim(), timezone: config.timezone?.trim() || undefined };
+  }
+  if (isStatusDurationTrigger(event)) {
+    return { status: config.status?.trim(), duration: config.duration?.trim() };
+  }
+  return {};
+}
+
+export function TriggerConfigFields({
+  triggerEvent,
+  value,
+  onChange,
+}: {
+  triggerEvent: string;
+  value: AutomationTriggerConfig;
+  onChange: (config: AutomationTriggerConfig) => void;
+}) {
+  if (triggerEvent === "schedule") {
+    return (
+      <div className="grid max-w-2xl grid-cols-1 gap-4 sm:grid-cols-2">
+        <div className="space-y-2">
+          <Label htmlFor="trigger-schedule">Harmonogram (cron) *</Label>
+          <Input
+            id="trigger-schedule"
+            value={value.schedule ?? ""}
+            onChange={(e) => onChange({ ...value, schedule: e.target.value })}
+            placeholder="np. 0 8 * * 1-5"
+            className="font-mono"
+          />
+          <p className="text-xs text-muted-foreground">
+            Minuta, godzina, dzień miesiąca, miesiąc, dzień tygodnia. Najczęściej co 5 minut.
+          </p>
+        </div>
+        <div className="space-y-2">
+          <Label htmlFor="trigger-timezone">Strefa czasowa</Label>
+          <Input
+            id="trigger-timezone"
+            value={value.timezone ?? ""}
+            onChange={(e) => onChange({ ...value, timezone: e.target.value })}
+            placeholder="Europe/Warsaw"
+          />
+        </div>
+      </div>
+    );
+  }
+
+  if (isStatusDurationTrigger(triggerEvent)) {
+    return (
+      <div className="space-y-2">
+        <div className="grid max-w-2xl grid-cols-1 gap-4 sm:grid-cols-2">
+          <div className="space-y-2">
+            <Label htmlFor="trigger-status">Status *</Label>
+            <Input
+              id="trigger-status"
+              value={value.status ?? ""}
+              onChange={(e) => onChange({ ...value, status: e.target.value })}
+              placeholder="np. ready_to_ship"
+            />
+          </div>
+          <div className="space-y-2">
+            <Label htmlFor="trigger-duration">Przez co najmniej *</Label>
+            <Input
+              id="trigger-duration"
+              value={value.duration ?? ""}
+              onChange={(e) => onChange({ ...value, duration: e.target.value })}
+              placeholder="np. 24h, 3d, 2w"
+            />
+          </div>
+        </div>
+        <p className="text-xs text-muted-foreground">
+          Reguła uruchomi się raz dla każdego obiektu, który pozostaje w tym statusie dłużej niż podany czas.
+        </p>
+      </div>
+    );
+  }
+
+  return null;
+}
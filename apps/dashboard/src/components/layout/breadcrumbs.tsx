@@ -61,6 +61,9 @@ const segmentLabels: Record<string, string> = {
   stocktakes: "Inwentaryzacja",
   security: "Bezpieczeństwo",
   inventory: "Kontrola magazynowa",
+  holds: "Wstrzymane",
+  "order-holds": "Wstrzymania",
+  "order-risk": "Ocena ryzyka",
 };
 
 export function Breadcrumbs() {
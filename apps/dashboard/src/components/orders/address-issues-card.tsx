@@ -0,0 +1,37 @@
+import { MapPinOff } from "lucide-react";
+import { Card, CardContent, CardHeader, CardTitle } from "@/components/ui/card";
+import { ADDRESS_ISSUE_CODES, ADDRESS_ISSUE_FIELDS } from "@/lib/constants";
+import type { AddressIssue } from "@/types/api";
+
+interface AddressIssuesCardProps {
+  issues: AddressIssue[];
+}
+
+export function AddressIssuesCard({ issues }: AddressIssuesCardProps) {
+  return (
+    <Card className="border-amber-300 dark:border-amber-700">
+      <CardHeader>
+        <CardTitle className="flex items-center gap-2">
+          <MapPinOff className="h-4 w-4" />
+          Problemy z adresem dostawy
+        </CardTitle>
+      </CardHeader>
+      <CardContent className="space-y-3">
+        <p className="text-sm text-amber-800 dark:text-amber-200">
+          Popraw adres przed wygenerowaniem etykiety — przewoźnik może go odrzucić.
+        </p>
+        <ul className="space-y-2 text-sm">
+          {issues.map((issue, i) => (
+            <li key={i}>
+              <span className="font-medium">
+                {ADDRESS_ISSUE_FIELDS[issue.field] || issue.field}:
+              </span>{" "}
+              {ADDRESS_ISSUE_CODES[issue.code] || issue.code}
+              <p className="text-xs text-muted-foreground">{issue.message}</p>
+            </li>
+          ))}
+        </ul>
+      </CardContent>
+    </Card>
+  );
+}
@@ -0,0 +1,253 @@
+"use client";
+
+import { useState } from "react";
+import { SlidersHorizontal } from "lucide-react";
+import { Button } from "@/components/ui/button";
+import { Input } from "@/components/ui/input";
+import { Label } from "@/components/ui/label";
+import { Popover, PopoverContent, PopoverTrigger } from "@/components/ui/popover";
+import {
+  Select,
+  SelectContent,
+  SelectItem,
+  SelectTrigger,
+  SelectValue,
+} from "@/components/ui/select";
+import { useIntegrations } from "@/hooks/use-integrations";
+import { useCustomFields } from "@/hooks/use-custom-fields";
+import { SHIPMENT_PROVIDERS } from "@/lib/constants";
+import { countAdvancedOrderFilters } from "@/lib/order-filters";
+import type { OrderFilterParams } from "@/types/api";
+
+const ANY = "all";
+
+interface AdvancedOrderFiltersProps {
+  filters: OrderFilterParams;
+  onFilterChange: (filters: OrderFilterParams) => void;
+}
+
+export function AdvancedOrderFilters({ filters, onFilterChange }: AdvancedOrderFiltersProps) {
+  const [open, setOpen] = useState(false);
+  const [draft, setDraft] = useState<OrderFilterParams>(filters);
+  const [customFieldKey, setCustomFieldKey] = useState("");
+  const { data: integrations } = useIntegrations();
+  const { data: customFieldsConfig } = useCustomFields();
+  const customFields = customFieldsConfig?.fields ?? [];
+  const activeCount = countAdvancedOrderFilters(filters);
+
+  const set = (key: keyof Omit<OrderFilterParams, "custom_fields">, value: string) => {
+    setDraft((prev) => ({ ...prev, [key]: value || undefined }));
+  };
+
+  const handleOpenChange = (next: boolean) => {
+    if (next) {
+      setDraft(filters);
+      setCustomFieldKey(Object.keys(filters.custom_fields ?? {})[0] ?? customFields[0]?.key ?? "");
+    }
+    setOpen(next);
+  };
+
+  const apply = () => {
+    onFilterChange(draft);
+    setOpen(false);
+  };
+
+  const clear = () => {
+    onFilterChange({
+      status: filters.status,
+      source: filters.source,
+      search: filters.search,
+      payment_status: filters.payment_status,
+      tag: filters.tag,
+      priority: filters.priority,
+    });
+    setOpen(false);
+  };
+
+  return (
+    <Popover open={open} onOpenChange={handleOpenChange}>
+      <PopoverTrigger asChild>
+        <Button variant="outline" size="sm">
+          <SlidersHorizontal className="h-4 w-4" />
+          Więcej filtrów
+          {activeCount > 0 && (
+            <span className="rounded-full bg-primary px-1.5 text-xs text-primary-foreground">
+              {activeCount}
+            </span>
+          )}
+        </Button>
+      </PopoverTrigger>
+      <PopoverContent className="w-[420px]" align="end">
+        <div className="space-y-4">
+          <div className="grid grid-cols-2 gap-3">
+            <div className="space-y-1">
+              <Label htmlFor="ordered_from">Data zamówienia od</Label>
+              <Input
+                id="ordered_from"
+                type="date"
+                value={draft.ordered_from ?? ""}
+                onChange={(e) => set("ordered_from", e.target.value)}
+              />
+            </div>
+            <div className="space-y-1">
+              <Label htmlFor="ordered_to">Data zamówienia do</Label>
+              <Input
+                id="ordered_to"
+                type="date"
+                value={draft.ordered_to ?? ""}
+                onChange={(e) => set("ordered_to", e.target.value)}
+              />
+            </div>
+            <div className="space-y-1">
+              <Label htmlFor="amount_min">Kwota od</Label>
+              <Input
+                id="amount_min"
+                type="number"
+                min="0"
+                step="0.01"
+                value={draft.amount_min ?? ""}
+                onChange={(e) => set("amount_min", e.target.value)}
+              />
+            </div>
+            <div className="space-y-1">
+              <Label htmlFor="amount_max">Kwota do</Label>
+              <Input
+                id="amount_max"
+                type="number"
+                min="0"
+                step="0.01"
+                value={draft.amount_max ?? ""}
+                onChange={(e) => set("amount_max", e.target.value)}
+              />
+            </div>
+            <div className="space-y-1">
+              <Label>Integracja</Label>
+              <Select
+                value={draft.integration_id || ANY}
+                onValueChange={(v) => set("integration_id", v === ANY ? "" : v)}
+              >
+                <SelectTrigger className="w-full"><SelectValue /></SelectTrigger>
+                <SelectContent>
+                  <SelectItem value={ANY}>Wszystkie</SelectItem>
+                  {(integrations ?? []).map((i) => (
+                    <SelectItem key={i.id} value={i.id}>
+                      {i.label || i.provider}
+                    </SelectItem>
+                  ))}
+                </SelectContent>
+              </Select>
+            </div>
+            <div className="space-y-1">
+              <Label>Przewoźnik</Label>
+              <Select
+                value={draft.carrier || ANY}
+                onValueChange={(v) => set("carrier", v === ANY ? "" : v)}
+              >
+                <SelectTrigger className="w-full"><SelectValue /></SelectTrigger>
+                <SelectContent>
+                  <SelectItem value={ANY}>Wszyscy</SelectItem>
+                  {SHIPMENT_PROVIDERS.map((p) => (
+                    <SelectItem key={p} value={p}>
+                      {p}
+                    </SelectItem>
+                  ))}
+                </SelectContent>
+              </Select>
+            </div>
+            <div className="space-y-1">
+              <Label htmlFor="sku">Zawiera SKU</Label>
+              <Input
+                id="sku"
+                value={draft.sku ?? ""}
+                onChange={(e) => set("sku", e.target.value)}
+              />
+            </div>
+            <div className="space-y-1">
+              <Label htmlFor="unpacked_days">Niespakowane dłużej niż (dni)</Label>
+              <Input
+                id="unpacked_days"
+                type="number"
+                min="0"
+                max="365"
+                value={draft.unpacked_days ?? ""}
+                onChange={(e) => set("unpacked_days", e.target.value)}
+              />
+            </div>
+            <div className="space-y-1">
+              <Label>Adres dostawy</Label>
+              <Select
+                value={draft.address_issues || ANY}
+                onValueChange={(v) => set("address_issues", v === ANY ? "" : v)}
+              >
+                <SelectTrigger className="w-full"><SelectValue /></SelectTrigger>
+                <SelectContent>
+                  <SelectItem value={ANY}>Wszystkie</SelectItem>
+                  <SelectItem value="true">Z problemami</SelectItem>
+                  <SelectItem value="false">Bez problemów</SelectItem>
+                </SelectContent>
+              </Select>
+            </div>
+            <div className="space-y-1">
+              <Label htmlFor="risk_min">Ryzyko od (0–100)</Label>
+              <Input
+                id="risk_min"
+                type="number"
+                min="0"
+                max="100"
+                value={draft.risk_min ?? ""}
+                onChange={(e) => set("risk_min", e.target.value)}
+              />
+            </div>
+          </div>
+
+          {customFields.length > 0 && (
+            <div className="space-y-1">
+              <Label>Pole niestandardowe</Label>
+              <div className="flex gap-2">
+                <Select
+                  value={customFieldKey}
+                  onValueChange={(key) => {
+                    const value = draft.custom_fields?.[customFieldKey] ?? "";
+                    setCustomFieldKey(key);
+                    setDraft((prev) => ({
+                      ...prev,
+                      custom_fields: value ? { [key]: value } : undefined,
+                    }));
+                  }}
+                >
+                  <SelectTrigger className="w-[180px]"><SelectValue /></SelectTrigger>
+                  <SelectContent>
+                    {customFields.map((f) => (
+                      <SelectItem key={f.key} value={f.key}>
+                        {f.label}
+                      </SelectItem>
+                    ))}
+                  </SelectContent>
+                </Select>
+                <Input
+                  placeholder="Wartość"
+                  value={draft.custom_fields?.[customFieldKey] ?? ""}
+                  onChange={(e) =>
+                    setDraft((prev) => ({
+                      ...prev,
+                      custom_fields: e.target.value ? { [customFieldKey]: e.target.value } : undefined,
+                    }))
+                  }
+                />
+              </div>
+            </div>
+          )}
+
+          <div className="flex justify-end gap-2">
+            <Button variant="ghost" size="sm" onClick={clear}>
+              Wyczyść
+            </Button>
+            <Button size="sm" onClick={apply}>
+              Zastosuj
+            </Button>
+          </div>
+        </div>
+      </PopoverContent>
+    </Popover>
+  );
+}
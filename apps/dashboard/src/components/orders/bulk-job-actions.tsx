@@ -0,0 +1,304 @@
+"use client";
+
+import { useState } from "react";
+import { toast } from "sonner";
+import { ChevronDown, Loader2 } from "lucide-react";
+import { useCreateBulkJob } from "@/hooks/use-bulk-jobs";
+import { useOrderStatuses, statusesToMap } from "@/hooks/use-order-statuses";
+import { Button } from "@/components/ui/button";
+import { Input } from "@/components/ui/input";
+import { Label } from "@/components/ui/label";
+import { Checkbox } from "@/components/ui/checkbox";
+import {
+  DropdownMenu,
+  DropdownMenuContent,
+  DropdownMenuItem,
+  DropdownMenuSeparator,
+  DropdownMenuTrigger,
+} from "@/components/ui/dropdown-menu";
+import {
+  Dialog,
+  DialogContent,
+  DialogDescription,
+  DialogFooter,
+  DialogHeader,
+  DialogTitle,
+} from "@/components/ui/dialog";
+import {
+  Select,
+  SelectContent,
+  SelectItem,
+  SelectTrigger,
+  SelectValue,
+} from "@/components/ui/select";
+import { ConfirmDialog } from "@/components/ui/confirm-dialog";
+import { CarrierFields, type CarrierFieldValues } from "@/components/shipments/carrier-fields";
+import { getErrorMessage } from "@/lib/api-client";
+import { ORDER_STATUSES, SHIPMENT_PROVIDERS, SHIPMENT_PROVIDER_LABELS } from "@/lib/constants";
+import type { BulkJobAction, CreateBulkJobRequest, GenerateLabelRequest } from "@/types/api";
+
+interface BulkJobActionsProps {
+  /** Selected orders; ignored when filter is set. */
+  orderIds: string[];
+  /** Acts on every order matching the filter instead of the selection. */
+  filter?: Record<string, string>;
+  count: number;
+  onStarted: () => void;
+}
+
+const DEFAULT_SERVICE_TYPES: Record<string, string> = {
+  inpost: "inpost_locker_standard",
+  dhl: "dhl_parcel",
+  dpd: "dpd_classic",
+};
+
+const LABEL_PROVIDERS = SHIPMENT_PROVIDERS.filter((p) => p !== "manual");
+
+function parseTags(value: string): string[] {
+  return value
+    .split(",")
+    .map((t) => t.trim())
+    .filter(Boolean);
+}
+
+export function BulkJobActions({ orderIds, filter, count, onStarted }: BulkJobActionsProps) {
+  const createJob = useCreateBulkJob();
+  const { data: statusConfig } = useOrderStatuses();
+  const orderStatuses = statusConfig ? statusesToMap(statusConfig) : ORDER_STATUSES;
+
+  const [dialog, setDialog] = useState<"tags" | "labels" | "status" | null>(null);
+  const [confirmInvoices, setConfirmInvoices] = useState(false);
+  const [addTags, setAddTags] = useState("");
+  const [removeTags, setRemoveTags] = useState("");
+  const [provider, setProvider] = useState<string>("inpost");
+  const [carrierValues, setCarrierValues] = useState<CarrierFieldValues>({
+    service_type: DEFAULT_SERVICE_TYPES.inpost,
+  });
+  const [targetStatus, setTargetStatus] = useState("");
+  const [force, setForce] = useState(false);
+
+  const start = (data: Omit<CreateBulkJobRequest, "order_ids" | "filter">) => {
+    const request: CreateBulkJobRequest = filter
+      ? { ...data, filter }
+      : { ...data, order_ids: orderIds };
+    createJob.mutate(request, {
+      onSuccess: () => {
+        toast.success(`Uruchomiono zadanie w tle (${count} zamówień)`);
+        setDialog(null);
+        setConfirmInvoices(false);
+        onStarted();
+      },
+      onError: (error) => toast.error(getErrorMessage(error)),
+    });
+  };
+
+  const startSimple = (action: BulkJobAction) => start({ action });
+
+  const handleProviderChange = (value: string) => {
+    setProvider(value);
+    setCarrierValues({ service_type: DEFAULT_SERVICE_TYPES[value] ?? value });
+  };
+
+  const handleLabels = () => {
+    const values = carrierValues;
+    const label: GenerateLabelRequest & { provider: string } = {
+      provider,
+      service_type: values.service_type ?? provider,
+      label_format: "pdf",
+    };
+    if (values.parcel_size) label.parcel_size = values.parcel_size;
+    if (values.sending_method) label.sending_method = values.sending_method;
+    if (values.weight_kg != null) label.weight_kg = values.weight_kg;
+    if (values.width_cm != null) label.width_cm = values.width_cm;
+    if (values.height_cm != null) label.height_cm = values.height_cm;
+    if (values.depth_cm != null) label.depth_cm = values.depth_cm;
+    if (values.insured_value != null) label.insured_value = values.insured_value;
+    start({ action: "labels", label });
+  };
+
+  const tagsValid = parseTags(addTags).length > 0 || parseTags(removeTags).length > 0;
+
+  return (
+    <>
+      <DropdownMenu>
+        <DropdownMenuTrigger asChild>
+          <Button variant="outline" size="sm" disabled={createJob.isPending}>
+            {createJob.isPending && <Loader2 className="mr-2 h-4 w-4 animate-spin" />}
+            Akcje masowe ({count})
+            <ChevronDown className="ml-2 h-4 w-4" />
+          </Button>
+        </DropdownMenuTrigger>
+        <DropdownMenuContent align="start">
+          {filter && (
+            <DropdownMenuItem onSelect={() => setDialog("status")}>
+              Zmień status
+            </DropdownMenuItem>
+          )}
+          <DropdownMenuItem onSelect={() => setDialog("tags")}>
+            Dodaj / usuń tagi
+          </DropdownMenuItem>
+          <DropdownMenuSeparator />
+          <DropdownMenuItem onSelect={() => setDialog("labels")}>
+            Generuj etykiety
+          </DropdownMenuItem>
+          <DropdownMenuItem onSelect={() => setConfirmInvoices(true)}>
+            Wystaw faktury
+          </DropdownMenuItem>
+          <DropdownMenuSeparator />
+          <DropdownMenuItem onSelect={() => startSimple("label_pdf")}>
+            Etykiety zbiorczo (PDF)
+          </DropdownMenuItem>
+          <DropdownMenuItem onSelect={() => startSimple("packing_slips")}>
+            Listy kompletacyjne (PDF)
+          </DropdownMenuItem>
+        </DropdownMenuContent>
+      </DropdownMenu>
+
+      <Dialog open={dialog === "tags"} onOpenChange={(open) => !open && setDialog(null)}>
+        <DialogContent>
+          <DialogHeader>
+            <DialogTitle>Tagi zamówień</DialogTitle>
+            <DialogDescription>
+              Tagi rozdzielaj przecinkami. Zmiana obejmie {count} zamówień.
+            </DialogDescription>
+          </DialogHeader>
+          <div className="space-y-4 py-2">
+            <div className="space-y-2">
+              <Label htmlFor="bulk-add-tags">Dodaj tagi</Label>
+              <Input
+                id="bulk-add-tags"
+                value={addTags}
+                onChange={(e) => setAddTags(e.target.value)}
+                placeholder="np. vip, priorytet"
+              />
+            </div>
+            <div className="space-y-2">
+              <Label htmlFor="bulk-remove-tags">Usuń tagi</Label>
+              <Input
+                id="bulk-remove-tags"
+                value={removeTags}
+                onChange={(e) => setRemoveTags(e.target.value)}
+              />
+            </div>
+          </div>
+          <DialogFooter>
+            <Button variant="outline" onClick={() => setDialog(null)}>
+              Anuluj
+            </Button>
+            <Button
+              disabled={!tagsValid || createJob.isPending}
+              onClick={() =>
+                start({
+                  action: "tags",
+                  add_tags: parseTags(addTags),
+                  remove_tags: parseTags(removeTags),
+                })
+              }
+            >
+              Zapisz tagi
+            </Button>
+          </DialogFooter>
+        </DialogContent>
+      </Dialog>
+
+      <Dialog open={dialog === "labels"} onOpenChange={(open) => !open && setDialog(null)}>
+        <DialogContent>
+          <DialogHeader>
+            <DialogTitle>Generuj etykiety</DialogTitle>
+            <DialogDescription>
+              Zamówienia bez przesyłki tego przewoźnika otrzymają nową przesyłkę.
+              Zamówienia z wygenerowaną etykietą zostaną pominięte.
+            </DialogDescription>
+          </DialogHeader>
+          <div className="space-y-4 py-2">
+            <div className="space-y-2">
+              <Label>Przewoźnik</Label>
+              <Select value={provider} onValueChange={handleProviderChange}>
+                <SelectTrigger className="w-full">
+                  <SelectValue />
+                </SelectTrigger>
+                <SelectContent>
+                  {LABEL_PROVIDERS.map((p) => (
+                    <SelectItem key={p} value={p}>
+                      {SHIPMENT_PROVIDER_LABELS[p] || p}
+                    </SelectItem>
+                  ))}
+                </SelectContent>
+              </Select>
+            </div>
+            <CarrierFields
+              provider={provider}
+              values={carrierValues}
+              onChange={(field, value) => setCarrierValues((prev) => ({ ...prev, [field]: value }))}
+              hideTargetPoint
+            />
+          </div>
+          <DialogFooter>
+            <Button variant="outline" onClick={() => setDialog(null)}>
+              Anuluj
+            </Button>
+            <Button disabled={createJob.isPending} onClick={handleLabels}>
+              Generuj etykiety
+            </Button>
+          </DialogFooter>
+        </DialogContent>
+      </Dialog>
+
+      <Dialog open={dialog === "status"} onOpenChange={(open) => !open && setDialog(null)}>
+        <DialogContent>
+          <DialogHeader>
+            <DialogTitle>Zmień status</DialogTitle>
+            <DialogDescription>
+              Status zmieni się we wszystkich {count} pasujących zamówieniach.
+              Zamówienia, dla których przejście jest niedozwolone, zostaną oznaczone jako błędy.
+            </DialogDescription>
+          </DialogHeader>
+          <div className="space-y-4 py-2">
+            <Select value={targetStatus} onValueChange={setTargetStatus}>
+              <SelectTrigger className="w-full">
+                <SelectValue placeholder="Wybierz status" />
+              </SelectTrigger>
+              <SelectContent>
+                {Object.entries(orderStatuses).map(([key, val]) => (
+                  <SelectItem key={key} value={key}>
+                    {val.label}
+                  </SelectItem>
+                ))}
+              </SelectContent>
+            </Select>
+            <div className="flex items-center gap-2">
+              <Checkbox
+                id="bulk-force"
+                checked={force}
+                onCheckedChange={(checked) => setForce(checked === true)}
+              />
+              <Label htmlFor="bulk-force">Wymuś zmianę niezgodną z flow</Label>
+            </div>
+          </div>
+          <DialogFooter>
+            <Button variant="outline" onClick={() => setDialog(null)}>
+              Anuluj
+            </Button>
+            <Button
+              variant={force ? "destructive" : "default"}
+              disabled={!targetStatus || createJob.isPending}
+              onClick={() => start({ action: "status", status: targetStatus, force })}
+            >
+              Zmień status
+            </Button>
+          </DialogFooter>
+        </DialogContent>
+      </Dialog>
+
+      <ConfirmDialog
+        open={confirmInvoices}
+        onOpenChange={setConfirmInvoices}
+        title="Wystaw faktury"
+        description={`Faktury zostaną wystawione dla ${count} zamówień w domyślnym systemie fakturowym. Zamówienia z wystawioną fakturą zostaną pominięte.`}
+        confirmLabel="Wystaw faktury"
+        onConfirm={() => startSimple("invoices")}
+        isLoading={createJob.isPending}
+      />
+    </>
+  );
+}
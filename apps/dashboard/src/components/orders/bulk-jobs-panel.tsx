@@ -0,0 +1,116 @@
+"use client";
+
+import { useState } from "react";
+import Link from "next/link";
+import { Download } from "lucide-react";
+import { useBulkJobs } from "@/hooks/use-bulk-jobs";
+import { Button } from "@/components/ui/button";
+import {
+  Dialog,
+  DialogContent,
+  DialogDescription,
+  DialogHeader,
+  DialogTitle,
+} from "@/components/ui/dialog";
+import { formatDate, shortId } from "@/lib/utils";
+import type { BulkJob } from "@/types/api";
+
+const ACTION_LABELS: Record<string, string> = {
+  status: "Zmiana statusu",
+  tags: "Tagi",
+  labels: "Etykiety",
+  invoices: "Faktury",
+  packing_slips: "Listy kompletacyjne",
+  label_pdf: "Etykiety zbiorczo",
+};
+
+const STATUS_LABELS: Record<string, string> = {
+  pending: "W kolejce",
+  running: "W toku",
+  completed: "Zakończone",
+  failed: "Błąd",
+};
+
+function JobRow({ job, onShowErrors }: { job: BulkJob; onShowErrors: () => void }) {
+  const total = job.metadata.total || 1;
+  const done = job.items_processed + job.items_failed;
+  const percent = Math.min(100, Math.round((done / total) * 100));
+  const active = job.status === "pending" || job.status === "running";
+
+  return (
+    <div className="space-y-1.5">
+      <div className="flex items-center justify-between gap-3 text-sm">
+        <span className="font-medium">
+          {ACTION_LABELS[job.metadata.request.action] ?? job.metadata.request.action}
+          <span className="ml-2 text-xs text-muted-foreground">{formatDate(job.created_at)}</span>
+        </span>
+        <span className="flex items-center gap-3 text-muted-foreground">
+          <span>
+            {done}/{job.metadata.total} · {STATUS_LABELS[job.status] ?? job.status}
+          </span>
+          {job.items_failed > 0 && (
+            <button className="text-destructive hover:underline" onClick={onShowErrors}>
+              Błędy: {job.items_failed}
+            </button>
+          )}
+          {job.metadata.file_url && (
+            <Button variant="outline" size="sm" asChild>
+              <a href={job.metadata.file_url} target="_blank" rel="noopener noreferrer">
+                <Download className="mr-2 h-4 w-4" />
+                Pobierz PDF
+              </a>
+            </Button>
+          )}
+        </span>
+      </div>
+      {active && (
+        <div className="h-2 w-full overflow-hidden rounded-full bg-muted">
+          <div className="h-full bg-primary transition-all" style={{ width: `${percent}%` }} />
+        </div>
+      )}
+      {job.status === "failed" && job.error_message && (
+        <p className="text-xs text-destructive">{job.error_message}</p>
+      )}
+    </div>
+  );
+}
+
+export function BulkJobsPanel() {
+  const { data } = useBulkJobs();
+  const [errorsJob, setErrorsJob] = useState<BulkJob | null>(null);
+
+  const jobs = data?.items ?? [];
+  if (jobs.length === 0) return null;
+
+  const failures = (errorsJob?.metadata.results ?? []).filter((r) => !r.success);
+
+  return (
+    <div className="space-y-3 rounded-lg border p-3">
+      <p className="text-sm font-medium">Zadania masowe</p>
+      {jobs.map((job) => (
+        <JobRow key={job.id} job={job} onShowErrors={() => setErrorsJob(job)} />
+      ))}
+
+      <Dialog open={!!errorsJob} onOpenChange={(open) => !open && setErrorsJob(null)}>
+        <DialogContent>
+          <DialogHeader>
+            <DialogTitle>Błędy zadania</DialogTitle>
+            <DialogDescription>
+              Zamówienia, dla których akcja się nie powiodła.
+            </DialogDescription>
+          </DialogHeader>
+          <ul className="max-h-96 space-y-2 overflow-y-auto text-sm">
+            {failures.map((r) => (
+              <li key={r.order_id}>
+                <Link href={`/orders/${r.order_id}`} className="font-mono text-xs hover:underline">
+                  {shortId(r.order_id)}
+                </Link>
+                <span className="ml-2 text-muted-foreground">{r.error}</span>
+              </li>
+            ))}
+          </ul>
+        </DialogContent>
+      </Dialog>
+    </div>
+  );
+}
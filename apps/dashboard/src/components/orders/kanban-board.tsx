@@ -26,16 +26,11 @@ import { Skeleton } from "@/components/ui/skeleton";
 import { Package } from "lucide-react";
 import { toast } from "sonner";
 import { cn } from "@/lib/utils";
-import type { Order, ListResponse } from "@/types/api";
+import { orderFilterSearchParams } from "@/lib/order-filters";
+import type { Order, ListResponse, OrderFilterParams } from "@/types/api";
 
 interface KanbanBoardProps {
-  filters: {
-    status?: string;
-    source?: string;
-    search?: string;
-    payment_status?: string;
-    tag?: string;
-  };
+  filters: OrderFilterParams;
 }
 
 const COLUMN_PAGE_SIZE = 20;
@@ -46,15 +41,11 @@ function useColumnOrders(
   filters: KanbanBoardProps["filters"],
   enabled: boolean
 ) {
-  const params = new URLSearchParams();
+  const params = orderFilterSearchParams(filters);
   params.set("status", status);
   params.set("limit", String(COLUMN_PAGE_SIZE));
   params.set("sort_by", "created_at");
   params.set("sort_order", "desc");
-  if (filters.source) params.set("source", filters.source);
-  if (filters.search) params.set("search", filters.search);
-  if (filters.payment_status) params.set("payment_status", filters.payment_status);
-  if (filters.tag) params.set("tag", filters.tag);
 
   return useQuery({
     queryKey: ["orders-kanban", status, filters],
@@ -99,16 +90,12 @@ function KanbanColumn({
     const nextPage = loadedPages + 1;
     const nextOffset = loadedPages * COLUMN_PAGE_SIZE;
 
-    const params = new URLSearchParams();
+    const params = orderFilterSearchParams(filters);
     params.set("status", statusKey);
     params.set("limit", String(COLUMN_PAGE_SIZE));
     params.set("offset", String(nextOffset));
     params.set("sort_by", "created_at");
     params.set("sort_order", "desc");
-    if (filters.source) params.set("source", filters.source);
-    if (filters.search) params.set("search", filters.search);
-    if (filters.payment_status) params.set("payment_status", filters.payment_status);
-    if (filters.tag) params.set("tag", filters.tag);
 
     try {
       const moreData = await apiClient<ListResponse<Order>>(
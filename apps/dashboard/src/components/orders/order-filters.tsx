@@ -11,19 +11,12 @@ import {
 import { Input } from "@/components/ui/input";
 import { ORDER_STATUSES, ORDER_SOURCES, PAYMENT_STATUSES, ORDER_SOURCE_LABELS, ORDER_PRIORITIES } from "@/lib/constants";
 import { useOrderStatuses, statusesToMap } from "@/hooks/use-order-statuses";
-
-interface OrderFilters {
-  status?: string;
-  source?: string;
-  search?: string;
-  payment_status?: string;
-  tag?: string;
-  priority?: string;
-}
+import { AdvancedOrderFilters } from "@/components/orders/advanced-order-filters";
+import type { OrderFilterParams } from "@/types/api";
 
 interface OrderFiltersProps {
-  filters: OrderFilters;
-  onFilterChange: (filters: OrderFilters) => void;
+  filters: OrderFilterParams;
+  onFilterChange: (filters: OrderFilterParams) => void;
 }
 
 export function OrderFilters({ filters, onFilterChange }: OrderFiltersProps) {
@@ -80,7 +73,7 @@ export function OrderFilters({ filters, onFilterChange }: OrderFiltersProps) {
   return (
     <div className="flex flex-wrap items-center gap-4">
       <Input
-        placeholder="Szukaj klienta (imię, email, telefon)..."
+        placeholder="Szukaj (klient, adres, produkty, notatki)..."
         value={localSearch}
         onChange={(e) => handleSearchChange(e.target.value)}
         className="w-[300px]"
@@ -98,6 +91,11 @@ export function OrderFilters({ filters, onFilterChange }: OrderFiltersProps) {
           </SelectTrigger>
           <SelectContent>
             <SelectItem value="all">Wszystkie</SelectItem>
+            {filters.status?.includes(",") && (
+              <SelectItem value={filters.status}>
+                Kilka statusów ({filters.status.split(",").length})
+              </SelectItem>
+            )}
             {Object.entries(orderStatuses).map(([key, config]) => (
               <SelectItem key={key} value={key}>
                 {config.label}
@@ -175,6 +173,7 @@ export function OrderFilters({ filters, onFilterChange }: OrderFiltersProps) {
         onChange={(e) => handleTagChange(e.target.value)}
         className="w-[180px]"
       />
+      <AdvancedOrderFilters filters={filters} onFilterChange={onFilterChange} />
     </div>
   );
 }
@@ -0,0 +1,209 @@
+"use client";
+
+import { useState } from "react";
+import { toast } from "sonner";
+import { PauseCircle, Plus } from "lucide-react";
+import { Badge } from "@/components/ui/badge";
+import { Button } from "@/components/ui/button";
+import { Card, CardContent, CardHeader, CardTitle } from "@/components/ui/card";
+import {
+  Dialog,
+  DialogContent,
+  DialogDescription,
+  DialogFooter,
+  DialogHeader,
+  DialogTitle,
+} from "@/components/ui/dialog";
+import { Label } from "@/components/ui/label";
+import {
+  Select,
+  SelectContent,
+  SelectItem,
+  SelectTrigger,
+  SelectValue,
+} from "@/components/ui/select";
+import { Textarea } from "@/components/ui/textarea";
+import { useOrderHolds, usePlaceHold, useReleaseHold } from "@/hooks/use-order-holds";
+import { HOLD_REASONS, HOLD_SOURCES } from "@/lib/constants";
+import { getErrorMessage } from "@/lib/api-client";
+import { formatDate } from "@/lib/utils";
+import type { HoldReason, OrderHold } from "@/types/api";
+
+interface OrderHoldsCardProps {
+  orderId: string;
+}
+
+export function OrderHoldsCard({ orderId }: OrderHoldsCardProps) {
+  const { data: holds } = useOrderHolds(orderId);
+  const placeHold = usePlaceHold(orderId);
+  const releaseHold = useReleaseHold(orderId);
+
+  const [placeOpen, setPlaceOpen] = useState(false);
+  const [reason, setReason] = useState<HoldReason | "">("");
+  const [note, setNote] = useState("");
+  const [releasing, setReleasing] = useState<OrderHold | null>(null);
+  const [releaseNote, setReleaseNote] = useState("");
+
+  const active = (holds ?? []).filter((h) => !h.released_at);
+  const released = (holds ?? []).filter((h) => h.released_at);
+
+  const handlePlace = () => {
+    if (!reason) return;
+    placeHold.mutate(
+      { reason, note: note || undefined },
+      {
+        onSuccess: () => {
+          toast.success("Zamówienie zostało wstrzymane");
+          setPlaceOpen(false);
+          setReason("");
+          setNote("");
+        },
+        onError: (error) => {
+          toast.error(getErrorMessage(error));
+        },
+      }
+    );
+  };
+
+  const handleRelease = () => {
+    if (!releasing) return;
+    releaseHold.mutate(
+      { holdId: releasing.id, note: releaseNote || undefined },
+      {
+        onSuccess: () => {
+          toast.success("Wstrzymanie zostało zwolnione");
+          setReleasing(null);
+          setReleaseNote("");
+        },
+        onError: (error) => {
+          toast.error(getErrorMessage(error));
+        },
+      }
+    );
+  };
+
+  return (
+    <Card className={active.length > 0 ? "border-amber-300 dark:border-amber-700" : undefined}>
+      <CardHeader className="flex flex-row items-center justify-between space-y-0">
+        <CardTitle className="flex items-center gap-2">
+          <PauseCircle className="h-4 w-4" />
+          Wstrzymania
+          {active.length > 0 && <Badge variant="destructive">{active.length}</Badge>}
+        </CardTitle>
+        <Button variant="outline" size="sm" onClick={() => setPlaceOpen(true)}>
+          <Plus className="h-4 w-4" />
+          Wstrzymaj
+        </Button>
+      </CardHeader>
+      <CardContent className="space-y-3">
+        {active.length === 0 ? (
+          <p className="text-sm text-muted-foreground">
+            Zamówienie nie jest wstrzymane.
+          </p>
+        ) : (
+          <>
+            <p className="text-sm text-amber-800 dark:text-amber-200">
+              Realizacja zamówienia jest zablokowana do czasu zwolnienia wszystkich wstrzymań.
+            </p>
+            {active.map((hold) => (
+              <div key={hold.id} className="flex items-start justify-between gap-3 rounded-md border p-3">
+                <div className="space-y-1 text-sm">
+                  <div className="flex flex-wrap items-center gap-2 font-medium">
+                    {HOLD_REASONS[hold.reason] || hold.reason}
+                    <Badge variant="outline">{HOLD_SOURCES[hold.source] || hold.source}</Badge>
+                    {hold.auto_release && (
+                      <Badge variant="secondary">Zwalniane automatycznie</Badge>
+                    )}
+                  </div>
+                  {hold.note && <p className="text-muted-foreground">{hold.note}</p>}
+                  <p className="text-xs text-muted-foreground">od {formatDate(hold.created_at)}</p>
+                </div>
+                <Button variant="outline" size="sm" onClick={() => setReleasing(hold)}>
+                  Zwolnij
+                </Button>
+              </div>
+            ))}
+          </>
+        )}
+        {released.length > 0 && (
+          <details className="text-sm">
+            <summary className="cursor-pointer text-muted-foreground">
+              Zwolnione wstrzymania ({released.length})
+            </summary>
+            <ul className="mt-2 space-y-1">
+              {released.map((hold) => (
+                <li key={hold.id} className="text-muted-foreground">
+                  {HOLD_REASONS[hold.reason] || hold.reason} — zwolniono {formatDate(hold.released_at!)}
+                  {hold.release_note && `: ${hold.release_note}`}
+                </li>
+              ))}
+            </ul>
+          </details>
+        )}
+      </CardContent>
+
+      <Dialog open={placeOpen} onOpenChange={setPlaceOpen}>
+        <DialogContent>
+          <DialogHeader>
+            <DialogTitle>Wstrzymaj zamówienie</DialogTitle>
+            <DialogDescription>
+              Wstrzymane zamówienie nie może przejść do realizacji ani wysyłki.
+            </DialogDescription>
+          </DialogHeader>
+          <div className="space-y-4">
+            <div className="space-y-2">
+              <Label>Powód</Label>
+              <Select value={reason} onValueChange={(v) => setReason(v as HoldReason)}>
+                <SelectTrigger>
+                  <SelectValue placeholder="Wybierz powód" />
+                </SelectTrigger>
+                <SelectContent>
+                  {Object.entries(HOLD_REASONS).map(([value, label]) => (
+                    <SelectItem key={value} value={value}>
+                      {label}
+                    </SelectItem>
+                  ))}
+                </SelectContent>
+              </Select>
+            </div>
+            <div className="space-y-2">
+              <Label>Notatka</Label>
+              <Textarea value={note} onChange={(e) => setNote(e.target.value)} maxLength={1000} />
+            </div>
+          </div>
+          <DialogFooter>
+            <Button variant="outline" onClick={() => setPlaceOpen(false)}>
+              Anuluj
+            </Button>
+            <Button onClick={handlePlace} disabled={!reason || placeHold.isPending}>
+              Wstrzymaj
+            </Button>
+          </DialogFooter>
+        </DialogContent>
+      </Dialog>
+
+      <Dialog open={!!releasing} onOpenChange={(open) => !open && setReleasing(null)}>
+        <DialogContent>
+          <DialogHeader>
+            <DialogTitle>Zwolnij wstrzymanie</DialogTitle>
+            <DialogDescription>
+              {releasing && (HOLD_REASONS[releasing.reason] || releasing.reason)}
+            </DialogDescription>
+          </DialogHeader>
+          <div className="space-y-2">
+            <Label>Notatka</Label>
+            <Textarea value={releaseNote} onChange={(e) => setReleaseNote(e.target.value)} maxLength={1000} />
+          </div>
+          <DialogFooter>
+            <Button variant="outline" onClick={() => setReleasing(null)}>
+              Anuluj
+            </Button>
+            <Button onClick={handleRelease} disabled={releaseHold.isPending}>
+              Zwolnij
+            </Button>
+          </DialogFooter>
+        </DialogContent>
+      </Dialog>
+    </Card>
+  );
+}
@@ -0,0 +1,47 @@
+import { ShieldAlert } from "lucide-react";
+import { Badge } from "@/components/ui/badge";
+import { Card, CardContent, CardHeader, CardTitle } from "@/components/ui/card";
+import { RISK_SIGNALS, riskLevel } from "@/lib/constants";
+import type { RiskSignal } from "@/types/api";
+
+interface OrderRiskCardProps {
+  score: number;
+  signals: RiskSignal[];
+}
+
+export function OrderRiskCard({ score, signals }: OrderRiskCardProps) {
+  const level = riskLevel(score);
+
+  return (
+    <Card>
+      <CardHeader>
+        <CardTitle className="flex items-center gap-2">
+          <ShieldAlert className="h-4 w-4" />
+          Ocena ryzyka
+          <Badge variant="outline" className={level.color}>
+            {score} / 100 · {level.label}
+          </Badge>
+        </CardTitle>
+      </CardHeader>
+      <CardContent>
+        {signals.length === 0 ? (
+          <p className="text-sm text-muted-foreground">Brak sygnałów ryzyka.</p>
+        ) : (
+          <ul className="space-y-2 text-sm">
+            {signals.map((signal) => (
+              <li key={signal.code} className="flex items-start justify-between gap-4">
+                <div>
+                  <span className="font-medium">{RISK_SIGNALS[signal.code] || signal.code}</span>
+                  <p className="text-xs text-muted-foreground">{signal.detail}</p>
+                </div>
+                <span className={signal.points < 0 ? "text-green-600" : "text-red-600"}>
+                  {signal.points > 0 ? `+${signal.points}` : signal.points}
+                </span>
+              </li>
+            ))}
+          </ul>
+        )}
+      </CardContent>
+    </Card>
+  );
+}
@@ -1,7 +1,7 @@
 "use client";
 
 import { useState } from "react";
-import { formatDistanceToNow } from "date-fns";
+import { format, formatDistanceToNow } from "date-fns";
 import { pl } from "date-fns/locale";
 import {
   Plus,
@@ -10,24 +10,72 @@ import {
   Trash2,
   Clock,
   ChevronDown,
+  Truck,
+  RotateCcw,
+  FileText,
+  Zap,
+  Mail,
+  MessageSquare,
+  Store,
+  Webhook,
 } from "lucide-react";
-import { useOrderAudit } from "@/hooks/use-orders";
+import { useOrderTimeline } from "@/hooks/use-orders";
 import { Card, CardContent } from "@/components/ui/card";
 import { Skeleton } from "@/components/ui/skeleton";
-import { ORDER_STATUSES } from "@/lib/constants";
+import {
+  ORDER_STATUSES,
+  SHIPMENT_STATUSES,
+  RETURN_STATUSES,
+  TIMELINE_SOURCES,
+} from "@/lib/constants";
 import { useOrderStatuses, statusesToMap } from "@/hooks/use-order-statuses";
 import { cn } from "@/lib/utils";
-import type { AuditLogEntry } from "@/types/api";
+import type { TimelineEntry, TimelineSource } from "@/types/api";
+
+type StatusMap = Record<string, { label: string; color: string }>;
 
-const ACTION_LABELS: Record<string, string> = {
+const TYPE_LABELS: Record<string, string> = {
   "order.created": "Utworzono zamówienie",
   "order.updated": "Zaktualizowano zamówienie",
   "order.deleted": "Usunięto zamówienie",
   "order.status_changed": "Zmieniono status",
+  "order.buyer_messaged": "Wysłano wiadomość do kupującego",
+  "order.hold_placed": "Wstrzymano zamówienie",
+  "order.hold_released": "Zwolniono wstrzymanie",
+  "shipment.created": "Utworzono przesyłkę",
+  "shipment.updated": "Zaktualizowano przesyłkę",
+  "shipment.deleted": "Usunięto przesyłkę",
+  "shipment.status_changed": "Zmieniono status przesyłki",
+  "return.created": "Utworzono zwrot",
+  "return.updated": "Zaktualizowano zwrot",
+  "return.status_changed": "Zmieniono status zwrotu",
+  "return.received": "Przyjęto zwrot",
+  "return.refunded": "Zwrócono pieniądze",
+  "invoice.created": "Wystawiono fakturę",
+  "invoice.auto_created": "Wystawiono fakturę automatycznie",
+  "invoice.cancelled": "Anulowano fakturę",
+  "email.sent": "Wysłano e-mail",
+  "email.failed": "Nie udało się wysłać e-maila",
+  "sms.sent": "Wysłano SMS",
+  "sms.failed": "Nie udało się wysłać SMS",
+  "marketplace.order_imported": "Zaimportowano z marketplace",
+  "marketplace.synced": "Zsynchronizowano z marketplace",
+  "marketplace.buyer_messaged": "Wysłano wiadomość przez marketplace",
 };
 
-function getActionIcon(action: string) {
-  switch (action) {
+const SOURCE_ICONS: Record<TimelineSource, typeof Clock> = {
+  order: Clock,
+  shipment: Truck,
+  return: RotateCcw,
+  invoice: FileText,
+  automation: Zap,
+  notification: Mail,
+  marketplace: Store,
+  webhook: Webhook,
+};
+
+function getEntryIcon(entry: TimelineEntry) {
+  switch (entry.type) {
     case "order.created":
       return <Plus className="h-4 w-4" />;
     case "order.status_changed":
@@ -36,61 +84,99 @@ function getActionIcon(action: string) {
       return <Pencil className="h-4 w-4" />;
     case "order.deleted":
       return <Trash2 className="h-4 w-4" />;
-    default:
-      return <Clock className="h-4 w-4" />;
+    case "sms.sent":
+    case "sms.failed":
+      return <MessageSquare className="h-4 w-4" />;
   }
+  const Icon = SOURCE_ICONS[entry.source] ?? Clock;
+  return <Icon className="h-4 w-4" />;
 }
 
-function EntryChanges({ entry, orderStatuses }: { entry: AuditLogEntry; orderStatuses: Record<string, { label: string; color: string }> }) {
-  if (!entry.changes || Object.keys(entry.changes).length === 0) {
-    return null;
+function entryLabel(entry: TimelineEntry): string {
+  if (TYPE_LABELS[entry.type]) return TYPE_LABELS[entry.type];
+  if (entry.source === "automation") {
+    return `Reguła automatyzacji: ${formatValue(entry.data.rule_name) || entry.type}`;
+  }
+  if (entry.source === "webhook") {
+    return `Webhook ${entry.type}`;
   }
+  return entry.type;
+}
+
+function formatValue(value: unknown): string {
+  if (value === null || value === undefined) return "";
+  if (typeof value === "string") return value;
+  return JSON.stringify(value);
+}
+
+function EntryDetails({ entry, statuses }: { entry: TimelineEntry; statuses: StatusMap }) {
+  const data = entry.data ?? {};
 
-  if (entry.action === "order.status_changed") {
-    const from = entry.changes.from;
-    const to = entry.changes.to;
+  if (entry.type.endsWith(".status_changed")) {
+    const from = formatValue(data.from);
+    const to = formatValue(data.to);
     if (from && to) {
       return (
         <p className="text-xs text-muted-foreground mt-1">
-          {orderStatuses[from]?.label || from} &rarr; {orderStatuses[to]?.label || to}
+          {statuses[from]?.label || from} &rarr; {statuses[to]?.label || to}
+          {data.location ? ` (${formatValue(data.location)})` : null}
         </p>
       );
     }
   }
 
+  const rows = Object.entries(data).filter(([, value]) => formatValue(value) !== "");
+  if (rows.length === 0) return null;
+
   return (
     <div className="text-xs text-muted-foreground mt-1">
-      {Object.entries(entry.changes).map(([key, value]) => (
-        <p key={key}>
-          {key}: {value}
+      {rows.map(([key, value]) => (
+        <p key={key} className="break-all">
+          {key}: {formatValue(value)}
         </p>
       ))}
     </div>
   );
 }
 
-function TimelineEntry({ entry, orderStatuses }: { entry: AuditLogEntry; orderStatuses: Record<string, { label: string; color: string }> }) {
-  const label = ACTION_LABELS[entry.action] || entry.action;
-  const relativeTime = formatDistanceToNow(new Date(entry.created_at), {
-    addSuffix: true,
-    locale: pl,
-  });
+function statusesFor(entry: TimelineEntry, orderStatuses: StatusMap): StatusMap {
+  if (entry.source === "shipment") return SHIPMENT_STATUSES;
+  if (entry.source === "return") return RETURN_STATUSES;
+  return orderStatuses;
+}
+
+function TimelineItem({ entry, orderStatuses }: { entry: TimelineEntry; orderStatuses: StatusMap }) {
+  const date = new Date(entry.occurred_at);
+  const relativeTime = formatDistanceToNow(date, { addSuffix: true, locale: pl });
+  const failed = entry.type.endsWith(".failed") || entry.data?.status === "failed" || !!entry.data?.error;
 
   return (
     <div className="relative flex gap-3 pb-6 last:pb-0">
       <div className="relative flex flex-col items-center">
-        <div className="flex h-8 w-8 shrink-0 items-center justify-center rounded-full border bg-background">
-          {getActionIcon(entry.action)}
+        <div
+          className={cn(
+            "flex h-8 w-8 shrink-0 items-center justify-center rounded-full border bg-background",
+            failed && "border-destructive text-destructive"
+          )}
+        >
+          {getEntryIcon(entry)}
         </div>
         <div className="absolute top-8 bottom-0 w-px bg-border last:hidden" />
       </div>
       <div className="flex-1 pt-1">
-        <p className="text-sm font-medium">{label}</p>
-        {entry.user_name && (
-          <p className="text-xs text-muted-foreground">{entry.user_name}</p>
+        <div className="flex flex-wrap items-center gap-2">
+          <p className="text-sm font-medium">{entryLabel(entry)}</p>
+          <span className="rounded-full bg-muted px-2 py-0.5 text-xs text-muted-foreground">
+            {TIMELINE_SOURCES[entry.source] ?? entry.source}
+          </span>
+        </div>
+        {entry.actor && (
+          <p className="text-xs text-muted-foreground">{entry.actor}</p>
         )}
-        <EntryChanges entry={entry} orderStatuses={orderStatuses} />
-        <p className="text-xs text-muted-foreground mt-1">{relativeTime}</p>
+        <EntryDetails entry={entry} statuses={statusesFor(entry, orderStatuses)} />
+        <p className="text-xs text-muted-foreground mt-1" title={format(date, "dd.MM.yyyy HH:mm:ss")}>
+          {relativeTime}
+        </p>
       </div>
     </div>
   );
@@ -101,12 +187,19 @@ interface OrderTimelineProps {
 }
 
 export function OrderTimeline({ orderId }: OrderTimelineProps) {
-  const { data: entries, isLoading } = useOrderAudit(orderId);
+  const [sources, setSources] = useState<TimelineSource[]>([]);
+  const { data: entries, isLoading } = useOrderTimeline(orderId, { source: sources });
   const { data: statusConfig } = useOrderStatuses();
   const orderStatuses = statusConfig ? statusesToMap(statusConfig) : ORDER_STATUSES;
   const hasEntries = !!(entries && entries.length > 0);
   const [open, setOpen] = useState(hasEntries);
 
+  const toggleSource = (source: TimelineSource) => {
+    setSources((current) =>
+      current.includes(source) ? current.filter((s) => s !== source) : [...current, source]
+    );
+  };
+
   return (
     <Card>
       <button
@@ -131,6 +224,23 @@ export function OrderTimeline({ orderId }: OrderTimelineProps) {
       </button>
       {open && (
         <CardContent>
+          <div className="mb-4 flex flex-wrap gap-1">
+            {(Object.keys(TIMELINE_SOURCES) as TimelineSource[]).map((source) => (
+              <button
+                key={source}
+                type="button"
+                onClick={() => toggleSource(source)}
+                className={cn(
+                  "rounded-full border px-2.5 py-0.5 text-xs transition-colors",
+                  sources.includes(source)
+                    ? "border-primary bg-primary text-primary-foreground"
+                    : "text-muted-foreground hover:bg-muted"
+                )}
+              >
+                {TIMELINE_SOURCES[source]}
+              </button>
+            ))}
+          </div>
           {isLoading ? (
             <div className="space-y-4">
               <Skeleton className="h-12 w-full" />
@@ -144,8 +254,8 @@ export function OrderTimeline({ orderId }: OrderTimelineProps) {
             </div>
           ) : (
             <div>
-              {entries.map((entry) => (
-                <TimelineEntry key={entry.id} entry={entry} orderStatuses={orderStatuses} />
+              {entries.map((entry, i) => (
+                <TimelineItem key={`${entry.occurred_at}-${entry.type}-${i}`} entry={entry} orderStatuses={orderStatuses} />
               ))}
             </div>
           )}
@@ -0,0 +1,179 @@
+"use client";
+
+import { useState } from "react";
+import { toast } from "sonner";
+import { Bookmark, Trash2 } from "lucide-react";
+import { Button } from "@/components/ui/button";
+import { Input } from "@/components/ui/input";
+import { Label } from "@/components/ui/label";
+import {
+  Select,
+  SelectContent,
+  SelectItem,
+  SelectTrigger,
+  SelectValue,
+} from "@/components/ui/select";
+import {
+  Dialog,
+  DialogContent,
+  DialogDescription,
+  DialogFooter,
+  DialogHeader,
+  DialogTitle,
+} from "@/components/ui/dialog";
+import { ConfirmDialog } from "@/components/ui/confirm-dialog";
+import {
+  useOrderViews,
+  useCreateOrderView,
+  useUpdateOrderView,
+  useDeleteOrderView,
+} from "@/hooks/use-orders";
+import { getErrorMessage } from "@/lib/api-client";
+import { orderFilterValues, parseOrderFilterValues } from "@/lib/order-filters";
+import type { OrderFilterParams } from "@/types/api";
+
+const NO_VIEW = "none";
+
+interface SavedViewsProps {
+  filters: OrderFilterParams;
+  onApply: (filters: OrderFilterParams) => void;
+}
+
+export function SavedViews({ filters, onApply }: SavedViewsProps) {
+  const { data: views } = useOrderViews();
+  const createView = useCreateOrderView();
+  const updateView = useUpdateOrderView();
+  const deleteView = useDeleteOrderView();
+  const [activeId, setActiveId] = useState<string>(NO_VIEW);
+  const [showSaveDialog, setShowSaveDialog] = useState(false);
+  const [showDeleteDialog, setShowDeleteDialog] = useState(false);
+  const [name, setName] = useState("");
+
+  const activeView = views?.find((v) => v.id === activeId);
+
+  const handleSelect = (id: string) => {
+    setActiveId(id);
+    if (id === NO_VIEW) {
+      onApply({});
+      return;
+    }
+    const view = views?.find((v) => v.id === id);
+    if (view) onApply(parseOrderFilterValues(view.filters));
+  };
+
+  const handleSave = () => {
+    const data = { name: name.trim(), filters: orderFilterValues(filters) };
+    createView.mutate(data, {
+      onSuccess: (view) => {
+        toast.success("Widok zapisany");
+        setActiveId(view.id);
+        setShowSaveDialog(false);
+      },
+      onError: (error) => toast.error(getErrorMessage(error)),
+    });
+  };
+
+  const handleOverwrite = () => {
+    if (!activeView) return;
+    updateView.mutate(
+      { id: activeView.id, data: { filters: orderFilterValues(filters) } },
+      {
+        onSuccess: () => toast.success(`Widok "${activeView.name}" zaktualizowany`),
+        onError: (error) => toast.error(getErrorMessage(error)),
+      }
+    );
+  };
+
+  const handleDelete = () => {
+    if (!activeView) return;
+    deleteView.mutate(activeView.id, {
+      onSuccess: () => {
+        toast.success("Widok usunięty");
+        setActiveId(NO_VIEW);
+        setShowDeleteDialog(false);
+      },
+      onError: (error) => toast.error(getErrorMessage(error)),
+    });
+  };
+
+  return (
+    <div className="flex items-center gap-2">
+      <Select value={activeId} onValueChange={handleSelect}>
+        <SelectTrigger className="w-[200px]" size="sm">
+          <SelectValue placeholder="Zapisane widoki" />
+        </SelectTrigger>
+        <SelectContent>
+          <SelectItem value={NO_VIEW}>Wszystkie zamówienia</SelectItem>
+          {(views ?? []).map((v) => (
+            <SelectItem key={v.id} value={v.id}>
+              {v.name}
+            </SelectItem>
+          ))}
+        </SelectContent>
+      </Select>
+      {activeView ? (
+        <>
+          <Button variant="outline" size="sm" onClick={handleOverwrite} disabled={updateView.isPending}>
+            <Bookmark className="h-4 w-4" />
+            Zapisz zmiany
+          </Button>
+          <Button variant="ghost" size="icon-sm" title="Usuń widok" onClick={() => setShowDeleteDialog(true)}>
+            <Trash2 className="h-4 w-4" />
+          </Button>
+        </>
+      ) : (
+        <Button
+          variant="outline"
+          size="sm"
+          onClick={() => {
+            setName("");
+            setShowSaveDialog(true);
+          }}
+        >
+          <Bookmark className="h-4 w-4" />
+          Zapisz widok
+        </Button>
+      )}
+
+      <Dialog open={showSaveDialog} onOpenChange={setShowSaveDialog}>
+        <DialogContent>
+          <DialogHeader>
+            <DialogTitle>Zapisz widok</DialogTitle>
+            <DialogDescription>
+              Bieżące filtry zostaną zapisane pod wybraną nazwą. Widok jest widoczny tylko dla Ciebie.
+            </DialogDescription>
+          </DialogHeader>
+          <div className="space-y-2">
+            <Label htmlFor="view-name">Nazwa</Label>
+            <Input
+              id="view-name"
+              value={name}
+              onChange={(e) => setName(e.target.value)}
+              placeholder="np. Do spakowania ponad 2 dni"
+              maxLength={100}
+            />
+          </div>
+          <DialogFooter>
+            <Button variant="outline" onClick={() => setShowSaveDialog(false)}>
+              Anuluj
+            </Button>
+            <Button onClick={handleSave} disabled={!name.trim() || createView.isPending}>
+              {createView.isPending ? "Zapisywanie..." : "Zapisz"}
+            </Button>
+          </DialogFooter>
+        </DialogContent>
+      </Dialog>
+
+      <ConfirmDialog
+        open={showDeleteDialog}
+        onOpenChange={setShowDeleteDialog}
+        title="Usuń widok"
+        description={`Czy na pewno chcesz usunąć widok "${activeView?.name ?? ""}"?`}
+        confirmLabel="Usuń"
+        variant="destructive"
+        onConfirm={handleDelete}
+        isLoading={deleteView.isPending}
+      />
+    </div>
+  );
+}
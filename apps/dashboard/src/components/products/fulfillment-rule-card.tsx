@@ -0,0 +1,150 @@
+"use client";
+
+import { useState, useEffect } from "react";
+import { toast } from "sonner";
+import {
+  useFulfillmentRule,
+  useSetFulfillmentRule,
+  useDeleteFulfillmentRule,
+} from "@/hooks/use-dropship";
+import { useSuppliers } from "@/hooks/use-suppliers";
+import { getErrorMessage } from "@/lib/api-client";
+import { Button } from "@/components/ui/button";
+import { Label } from "@/components/ui/label";
+import {
+  Select,
+  SelectContent,
+  SelectItem,
+  SelectTrigger,
+  SelectValue,
+} from "@/components/ui/select";
+import {
+  Card,
+  CardContent,
+  CardDescription,
+  CardHeader,
+  CardTitle,
+} from "@/components/ui/card";
+import type { FulfillmentMode } from "@/types/api";
+
+const FULFILLMENT_MODES: { value: FulfillmentMode; label: string; description: string }[] = [
+  {
+    value: "own_stock",
+    label: "Własny magazyn",
+    description: "Produkt jest zawsze wysyłany z własnego magazynu.",
+  },
+  {
+    value: "stock_first",
+    label: "Najpierw własny magazyn",
+    description: "Gdy brakuje stanu, pozycja trafia do dostawcy z najtańszą dostępną ofertą.",
+  },
+  {
+    value: "dropship",
+    label: "Dropshipping",
+    description: "Pozycja zawsze trafia do dostawcy i jest wysyłana bezpośrednio do klienta.",
+  },
+];
+
+const ANY_SUPPLIER = "any";
+
+interface FulfillmentRuleCardProps {
+  productId: string;
+}
+
+export function FulfillmentRuleCard({ productId }: FulfillmentRuleCardProps) {
+  const { data: rule, isLoading } = useFulfillmentRule(productId);
+  const { data: suppliersData } = useSuppliers({ status: "active", limit: 100 });
+  const setRule = useSetFulfillmentRule(productId);
+  const deleteRule = useDeleteFulfillmentRule(productId);
+
+  const [mode, setMode] = useState<FulfillmentMode>("own_stock");
+  const [supplierId, setSupplierId] = useState(ANY_SUPPLIER);
+
+  useEffect(() => {
+    setMode(rule?.mode ?? "own_stock");
+    setSupplierId(rule?.supplier_id ?? ANY_SUPPLIER);
+  }, [rule]);
+
+  const handleSave = () => {
+    setRule.mutate(
+      {
+        mode,
+        supplier_id: mode !== "own_stock" && supplierId !== ANY_SUPPLIER ? supplierId : undefined,
+      },
+      {
+        onSuccess: () => toast.success("Reguła realizacji zapisana"),
+        onError: (error) => toast.error(getErrorMessage(error)),
+      }
+    );
+  };
+
+  const handleDelete = () => {
+    deleteRule.mutate(undefined, {
+      onSuccess: () => toast.success("Reguła realizacji usunięta"),
+      onError: (error) => toast.error(getErrorMessage(error)),
+    });
+  };
+
+  const current = FULFILLMENT_MODES.find((m) => m.value === mode);
+
+  return (
+    <Card>
+      <CardHeader>
+        <CardTitle>Realizacja</CardTitle>
+        <CardDescription>
+          {rule
+            ? "Reguła określa, czy pozycje tego produktu są wysyłane z magazynu, czy przez dostawcę"
+            : "Brak reguły — produkt jest wysyłany z własnego magazynu"}
+        </CardDescription>
+      </CardHeader>
+      <CardContent className="space-y-4">
+        <div className="grid gap-4 md:grid-cols-2">
+          <div className="space-y-2">
+            <Label>Tryb realizacji</Label>
+            <Select
+              value={mode}
+              onValueChange={(v) => setMode(v as FulfillmentMode)}
+              disabled={isLoading}
+            >
+              <SelectTrigger><SelectValue /></SelectTrigger>
+              <SelectContent>
+                {FULFILLMENT_MODES.map((m) => (
+                  <SelectItem key={m.value} value={m.value}>
+                    {m.label}
+                  </SelectItem>
+                ))}
+              </SelectContent>
+            </Select>
+          </div>
+          {mode !== "own_stock" && (
+            <div className="space-y-2">
+              <Label>Dostawca</Label>
+              <Select value={supplierId} onValueChange={setSupplierId}>
+                <SelectTrigger><SelectValue /></SelectTrigger>
+                <SelectContent>
+                  <SelectItem value={ANY_SUPPLIER}>Najtańsza dostępna oferta</SelectItem>
+                  {(suppliersData?.items ?? []).map((s) => (
+                    <SelectItem key={s.id} value={s.id}>
+                      {s.name}
+                    </SelectItem>
+                  ))}
+                </SelectContent>
+              </Select>
+            </div>
+          )}
+        </div>
+        {current && <p className="text-sm text-muted-foreground">{current.description}</p>}
+        <div className="flex gap-2">
+          <Button onClick={handleSave} disabled={setRule.isPending}>
+            {setRule.isPending ? "Zapisywanie..." : "Zapisz"}
+          </Button>
+          {rule && (
+            <Button variant="outline" onClick={handleDelete} disabled={deleteRule.isPending}>
+              Usuń regułę
+            </Button>
+          )}
+        </div>
+      </CardContent>
+    </Card>
+  );
+}
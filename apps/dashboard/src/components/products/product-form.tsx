@@ -29,12 +29,14 @@ import { toast } from "sonner";
 import { useProductCategories } from "@/hooks/use-product-categories";
 import { useImproveDescription, useTranslateDescription } from "@/hooks/use-ai";
 
-const PRODUCT_SOURCES = ["manual", "allegro", "woocommerce"] as const;
+const PRODUCT_SOURCES = ["manual", "allegro", "woocommerce", "shopify", "prestashop"] as const;
 
 const PRODUCT_SOURCE_LABELS: Record<string, string> = {
   manual: "Ręczne",
   allegro: "Allegro",
   woocommerce: "WooCommerce",
+  shopify: "Shopify",
+  prestashop: "PrestaShop",
 };
 
 const productSchema = z.object({
@@ -42,11 +44,12 @@ const productSchema = z.object({
   sku: z.string().optional(),
   ean: z.string().optional(),
   price: z.number().min(0, "Cena musi być większa lub równa 0"),
+  cost_price: z.number().min(0, "Cena zakupu musi być większa lub równa 0").optional(),
   stock_quantity: z
     .number()
     .int("Ilość musi być liczbą całkowitą")
     .min(0, "Ilość musi być większa lub równa 0"),
-  source: z.enum(["manual", "allegro", "woocommerce"]),
+  source: z.enum(["manual", "allegro", "woocommerce", "shopify", "prestashop"]),
   description_short: z.string().optional(),
   description_long: z.string().optional(),
   weight: z.number().min(0).optional(),
@@ -91,6 +94,7 @@ export function ProductForm({ product, onSubmit, isLoading }: ProductFormProps)
       sku: product?.sku ?? "",
       ean: product?.ean ?? "",
       price: product?.price ?? 0,
+      cost_price: product?.cost_price ?? undefined,
       stock_quantity: product?.stock_quantity ?? 0,
       source: (product?.source as ProductFormValues["source"]) ?? "manual",
       description_short: product?.description_short || "",
@@ -149,6 +153,7 @@ export function ProductForm({ product, onSubmit, isLoading }: ProductFormProps)
       ...data,
       description_short: data.description_short || undefined,
       description_long: data.description_long || undefined,
+      cost_price: isNaN(data.cost_price as number) ? undefined : data.cost_price,
       weight: isNaN(data.weight as number) ? undefined : data.weight,
       width: isNaN(data.width as number) ? undefined : data.width,
       height: isNaN(data.height as number) ? undefined : data.height,
@@ -212,7 +217,7 @@ export function ProductForm({ product, onSubmit, isLoading }: ProductFormProps)
         </div>
       </div>
 
-      <div className="grid grid-cols-1 gap-4 sm:grid-cols-2">
+      <div className="grid grid-cols-1 gap-4 sm:grid-cols-3">
         <div className="space-y-2">
           <Label htmlFor="price">Cena <span className="text-destructive">*</span></Label>
           <Input
@@ -229,6 +234,22 @@ export function ProductForm({ product, onSubmit, isLoading }: ProductFormProps)
           )}
         </div>
 
+        <div className="space-y-2">
+          <Label htmlFor="cost_price">Cena zakupu</Label>
+          <Input
+            id="cost_price"
+            type="number"
+            step="0.01"
+            min="0"
+            placeholder="Aktualizowana z dokumentów PZ"
+            aria-invalid={!!errors.cost_price}
+            {...register("cost_price", { valueAsNumber: true })}
+          />
+          {errors.cost_price && (
+            <p className="text-destructive text-xs mt-1">{errors.cost_price.message}</p>
+          )}
+        </div>
+
         <div className="space-y-2">
           <Label htmlFor="stock_quantity">Stan magazynowy <span className="text-destructive">*</span></Label>
           <Input
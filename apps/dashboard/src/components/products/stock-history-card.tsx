@@ -0,0 +1,227 @@
+"use client";
+
+import { useState } from "react";
+import Link from "next/link";
+import { useStockMovements, useProductStockAt, useWarehouses } from "@/hooks/use-warehouses";
+import { DataTablePagination } from "@/components/shared/data-table-pagination";
+import { Input } from "@/components/ui/input";
+import { Label } from "@/components/ui/label";
+import { Skeleton } from "@/components/ui/skeleton";
+import {
+  Card,
+  CardContent,
+  CardDescription,
+  CardHeader,
+  CardTitle,
+} from "@/components/ui/card";
+import {
+  Select,
+  SelectContent,
+  SelectItem,
+  SelectTrigger,
+  SelectValue,
+} from "@/components/ui/select";
+import {
+  Table,
+  TableBody,
+  TableCell,
+  TableHead,
+  TableHeader,
+  TableRow,
+} from "@/components/ui/table";
+import { formatDate } from "@/lib/utils";
+import type { StockMovement, StockMovementParams, StockMovementSource } from "@/types/api";
+
+const ALL_WAREHOUSES = "all";
+
+const SOURCE_LABELS: Record<StockMovementSource, string> = {
+  opening: "Stan otwarcia",
+  manual: "Ręczna zmiana",
+  warehouse_document: "Dokument magazynowy",
+  stocktake: "Inwentaryzacja",
+};
+
+function MovementSource({ movement }: { movement: StockMovement }) {
+  if (movement.document_id && movement.document_number) {
+    return (
+      <div>
+        <Link
+          href={`/settings/warehouse-documents/${movement.document_id}`}
+          className="font-medium hover:underline"
+        >
+          {movement.document_number}
+        </Link>
+        <p className="text-xs text-muted-foreground">{SOURCE_LABELS[movement.source]}</p>
+      </div>
+    );
+  }
+  return <span>{SOURCE_LABELS[movement.source] ?? movement.source}</span>;
+}
+
+function StockAtDate({ productId }: { productId: string }) {
+  const [at, setAt] = useState("");
+  const { data, isLoading } = useProductStockAt(productId, at || undefined);
+
+  return (
+    <div className="space-y-2 rounded-lg border p-4">
+      <div className="flex flex-wrap items-end gap-3">
+        <div className="space-y-1">
+          <Label htmlFor="stock-at">Stan na dzień</Label>
+          <Input
+            id="stock-at"
+            type="date"
+            className="w-40"
+            value={at}
+            onChange={(e) => setAt(e.target.value)}
+          />
+        </div>
+        {data && (
+          <p className="pb-2 text-sm">
+            Razem: <span className="font-semibold">{data.quantity} szt.</span>
+          </p>
+        )}
+      </div>
+      {at && isLoading && <Skeleton className="h-12 w-full" />}
+      {data && data.rows.length > 0 && (
+        <ul className="space-y-1 text-sm">
+          {data.rows.map((row) => (
+            <li key={`${row.warehouse_id}-${row.variant_id ?? ""}`} className="flex justify-between">
+              <span>
+                {row.warehouse_name}
+                {row.variant_name && (
+                  <span className="text-muted-foreground"> — {row.variant_name}</span>
+                )}
+              </span>
+              <span className="font-medium">{row.quantity}</span>
+            </li>
+          ))}
+        </ul>
+      )}
+      {data && data.rows.length === 0 && (
+        <p className="text-sm text-muted-foreground">Brak stanu w tym dniu</p>
+      )}
+    </div>
+  );
+}
+
+export function StockHistoryCard({ productId }: { productId: string }) {
+  const [params, setParams] = useState<StockMovementParams>({ limit: 20, offset: 0 });
+  const { data, isLoading } = useStockMovements(productId, params);
+  const { data: warehouses } = useWarehouses({ limit: 100 });
+
+  return (
+    <Card>
+      <CardHeader>
+        <CardTitle>Historia ruchów magazynowych</CardTitle>
+        <CardDescription>
+          Każda zmiana stanu z dokumentów, inwentaryzacji i ręcznych korekt
+        </CardDescription>
+      </CardHeader>
+      <CardContent className="space-y-4">
+        <StockAtDate productId={productId} />
+
+        <div className="flex flex-wrap items-end gap-3">
+          <div className="space-y-1">
+            <Label>Magazyn</Label>
+            <Select
+              value={params.warehouse_id ?? ALL_WAREHOUSES}
+              onValueChange={(value) =>
+                setParams((prev) => ({
+                  ...prev,
+                  offset: 0,
+                  warehouse_id: value === ALL_WAREHOUSES ? undefined : value,
+                }))
+              }
+            >
+              <SelectTrigger className="w-48">
+                <SelectValue />
+              </SelectTrigger>
+              <SelectContent>
+                <SelectItem value={ALL_WAREHOUSES}>Wszystkie</SelectItem>
+                {warehouses?.items.map((warehouse) => (
+                  <SelectItem key={warehouse.id} value={warehouse.id}>
+                    {warehouse.name}
+                  </SelectItem>
+                ))}
+              </SelectContent>
+            </Select>
+          </div>
+          <div className="space-y-1">
+            <Label htmlFor="movements-from">Od</Label>
+            <Input
+              id="movements-from"
+              type="date"
+              className="w-40"
+              value={params.from ?? ""}
+              onChange={(e) =>
+                setParams((prev) => ({ ...prev, offset: 0, from: e.target.value || undefined }))
+              }
+            />
+          </div>
+          <div className="space-y-1">
+            <Label htmlFor="movements-to">Do</Label>
+            <Input
+              id="movements-to"
+              type="date"
+              className="w-40"
+              value={params.to ?? ""}
+              onChange={(e) =>
+                setParams((prev) => ({ ...prev, offset: 0, to: e.target.value || undefined }))
+              }
+            />
+          </div>
+        </div>
+
+        {isLoading ? (
+          <Skeleton className="h-[200px] w-full" />
+        ) : !data || data.items.length === 0 ? (
+          <p className="text-sm text-muted-foreground">Brak ruchów magazynowych</p>
+        ) : (
+          <>
+            <Table>
+              <TableHeader>
+                <TableRow>
+                  <TableHead>Data</TableHead>
+                  <TableHead>Magazyn</TableHead>
+                  <TableHead>Źródło</TableHead>
+                  <TableHead className="text-right">Zmiana</TableHead>
+                  <TableHead className="text-right">Przed</TableHead>
+                  <TableHead className="text-right">Po</TableHead>
+                  <TableHead>Użytkownik</TableHead>
+                  <TableHead>Uwagi</TableHead>
+                </TableRow>
+              </TableHeader>
+              <TableBody>
+                {data.items.map((movement) => (
+                  <TableRow key={movement.id}>
+                    <TableCell className="whitespace-nowrap">{formatDate(movement.created_at)}</TableCell>
+                    <TableCell>{movement.warehouse_name}</TableCell>
+                    <TableCell>
+                      <MovementSource movement={movement} />
+                    </TableCell>
+                    <TableCell
+                      className={`text-right font-medium ${movement.delta < 0 ? "text-destructive" : "text-success"}`}
+                    >
+                      {movement.delta > 0 ? `+${movement.delta}` : movement.delta}
+                    </TableCell>
+                    <TableCell className="text-right">{movement.quantity_before}</TableCell>
+                    <TableCell className="text-right">{movement.quantity_after}</TableCell>
+                    <TableCell>{movement.actor_name ?? "-"}</TableCell>
+                    <TableCell className="text-sm text-muted-foreground">{movement.note ?? ""}</TableCell>
+                  </TableRow>
+                ))}
+              </TableBody>
+            </Table>
+            <DataTablePagination
+              total={data.total}
+              limit={params.limit ?? 20}
+              offset={params.offset ?? 0}
+              onPageChange={(offset) => setParams((prev) => ({ ...prev, offset }))}
+              onPageSizeChange={(limit) => setParams((prev) => ({ ...prev, limit, offset: 0 }))}
+            />
+          </>
+        )}
+      </CardContent>
+    </Card>
+  );
+}
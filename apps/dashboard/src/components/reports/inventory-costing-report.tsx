@@ -0,0 +1,335 @@
+"use client";
+
+import { useState } from "react";
+import { toast } from "sonner";
+import { Download } from "lucide-react";
+import {
+  useInventoryValuation,
+  useCOGS,
+  exportInventoryValuationCSV,
+  exportCOGSCSV,
+} from "@/hooks/use-inventory-costing";
+import { useWarehouses } from "@/hooks/use-warehouses";
+import { Button } from "@/components/ui/button";
+import { Input } from "@/components/ui/input";
+import { Label } from "@/components/ui/label";
+import { Card, CardContent, CardHeader, CardTitle } from "@/components/ui/card";
+import { Skeleton } from "@/components/ui/skeleton";
+import {
+  Select,
+  SelectContent,
+  SelectItem,
+  SelectTrigger,
+  SelectValue,
+} from "@/components/ui/select";
+import {
+  Table,
+  TableBody,
+  TableCell,
+  TableHead,
+  TableHeader,
+  TableRow,
+} from "@/components/ui/table";
+import { getErrorMessage } from "@/lib/api-client";
+import { formatCurrency } from "@/lib/utils";
+import type {
+  COGSGroupBy,
+  COGSParams,
+  CostingMethod,
+  InventoryValuationParams,
+} from "@/types/api";
+
+const ALL_WAREHOUSES = "all";
+
+const METHOD_LABELS: Record<CostingMethod, string> = {
+  fifo: "FIFO",
+  weighted_average: "średnia ważona",
+};
+
+const GROUP_LABELS: Record<COGSGroupBy, string> = {
+  document: "Dokument WZ",
+  product: "Produkt",
+  warehouse: "Magazyn",
+};
+
+function WarehouseSelect({
+  value,
+  onChange,
+}: {
+  value?: string;
+  onChange: (value?: string) => void;
+}) {
+  const { data } = useWarehouses({ limit: 100 });
+  return (
+    <div className="space-y-1">
+      <Label>Magazyn</Label>
+      <Select
+        value={value ?? ALL_WAREHOUSES}
+        onValueChange={(v) => onChange(v === ALL_WAREHOUSES ? undefined : v)}
+      >
+        <SelectTrigger className="w-48">
+          <SelectValue />
+        </SelectTrigger>
+        <SelectContent>
+          <SelectItem value={ALL_WAREHOUSES}>Wszystkie</SelectItem>
+          {data?.items.map((warehouse) => (
+            <SelectItem key={warehouse.id} value={warehouse.id}>
+              {warehouse.name}
+            </SelectItem>
+          ))}
+        </SelectContent>
+      </Select>
+    </div>
+  );
+}
+
+function InventoryValuation() {
+  const [params, setParams] = useState<InventoryValuationParams>({});
+  const [exporting, setExporting] = useState(false);
+  const { data, isLoading } = useInventoryValuation(params);
+
+  const update = (patch: Partial<InventoryValuationParams>) =>
+    setParams((prev) => ({ ...prev, ...patch }));
+
+  const handleExport = async () => {
+    setExporting(true);
+    try {
+      await exportInventoryValuationCSV(params);
+    } catch (error) {
+      toast.error(getErrorMessage(error));
+    } finally {
+      setExporting(false);
+    }
+  };
+
+  return (
+    <Card>
+      <CardHeader className="flex flex-row items-center justify-between space-y-0">
+        <CardTitle>Wycena magazynu</CardTitle>
+        <Button variant="outline" size="sm" onClick={handleExport} disabled={exporting}>
+          <Download className="mr-2 h-4 w-4" />
+          Eksportuj CSV
+        </Button>
+      </CardHeader>
+      <CardContent className="space-y-4">
+        <div className="flex flex-wrap items-end gap-3">
+          <div className="space-y-1">
+            <Label htmlFor="valuation-at">Na dzień</Label>
+            <Input
+              id="valuation-at"
+              type="date"
+              className="w-40"
+              value={params.at ?? ""}
+              onChange={(e) => update({ at: e.target.value || undefined })}
+            />
+          </div>
+          <WarehouseSelect
+            value={params.warehouse_id}
+            onChange={(warehouse_id) => update({ warehouse_id })}
+          />
+        </div>
+
+        {isLoading ? (
+          <Skeleton className="h-[300px] w-full" />
+        ) : !data || data.rows.length === 0 ? (
+          <div className="flex h-[200px] items-center justify-center text-muted-foreground">
+            Brak danych
+          </div>
+        ) : (
+          <>
+            <div className="flex flex-wrap gap-3">
+              {data.warehouses.map((warehouse) => (
+                <div key={warehouse.warehouse_id} className="rounded-lg border px-4 py-2">
+                  <p className="text-sm text-muted-foreground">{warehouse.warehouse_name}</p>
+                  <p className="text-lg font-semibold">{formatCurrency(warehouse.value)}</p>
+                </div>
+              ))}
+            </div>
+            <Table>
+              <TableHeader>
+                <TableRow>
+                  <TableHead>Magazyn</TableHead>
+                  <TableHead>SKU</TableHead>
+                  <TableHead>Nazwa</TableHead>
+                  <TableHead className="text-right">Ilość</TableHead>
+                  <TableHead className="text-right">Koszt jedn.</TableHead>
+                  <TableHead className="text-right">Wartość</TableHead>
+                </TableRow>
+              </TableHeader>
+              <TableBody>
+                {data.rows.map((row) => (
+                  <TableRow key={`${row.warehouse_id}-${row.variant_id ?? row.product_id}`}>
+                    <TableCell>{row.warehouse_name}</TableCell>
+                    <TableCell className="font-mono text-sm">{row.sku || "-"}</TableCell>
+                    <TableCell className="font-medium">{row.name}</TableCell>
+                    <TableCell className="text-right">{row.quantity}</TableCell>
+                    <TableCell className="text-right">
+                      {row.unit_cost != null ? formatCurrency(row.unit_cost) : "-"}
+                    </TableCell>
+                    <TableCell className="text-right">{formatCurrency(row.value)}</TableCell>
+                  </TableRow>
+                ))}
+                <TableRow className="font-semibold">
+                  <TableCell colSpan={3}>Razem</TableCell>
+                  <TableCell className="text-right">{data.quantity}</TableCell>
+                  <TableCell />
+                  <TableCell className="text-right">{formatCurrency(data.value)}</TableCell>
+                </TableRow>
+              </TableBody>
+            </Table>
+            <p className="text-xs text-muted-foreground">
+              Metoda wyceny: {METHOD_LABELS[data.method]}. Wycena obejmuje przyjęcia i wydania
+              z zatwierdzonych dokumentów PZ, WZ i MM.
+            </p>
+          </>
+        )}
+      </CardContent>
+    </Card>
+  );
+}
+
+function COGS() {
+  const [params, setParams] = useState<COGSParams>({ group_by: "document" });
+  const [exporting, setExporting] = useState(false);
+  const { data, isLoading } = useCOGS(params);
+
+  const update = (patch: Partial<COGSParams>) =>
+    setParams((prev) => ({ ...prev, ...patch }));
+
+  const handleExport = async () => {
+    setExporting(true);
+    try {
+      await exportCOGSCSV(params);
+    } catch (error) {
+      toast.error(getErrorMessage(error));
+    } finally {
+      setExporting(false);
+    }
+  };
+
+  return (
+    <Card>
+      <CardHeader className="flex flex-row items-center justify-between space-y-0">
+        <CardTitle>Koszt własny sprzedaży</CardTitle>
+        <Button variant="outline" size="sm" onClick={handleExport} disabled={exporting}>
+          <Download className="mr-2 h-4 w-4" />
+          Eksportuj CSV
+        </Button>
+      </CardHeader>
+      <CardContent className="space-y-4">
+        <div className="flex flex-wrap items-end gap-3">
+          <div className="space-y-1">
+            <Label>Grupowanie</Label>
+            <Select
+              value={params.group_by}
+              onValueChange={(value) => update({ group_by: value as COGSGroupBy })}
+            >
+              <SelectTrigger className="w-44">
+                <SelectValue />
+              </SelectTrigger>
+              <SelectContent>
+                {Object.entries(GROUP_LABELS).map(([key, label]) => (
+                  <SelectItem key={key} value={key}>
+                    {label}
+                  </SelectItem>
+                ))}
+              </SelectContent>
+            </Select>
+          </div>
+          <div className="space-y-1">
+            <Label htmlFor="cogs-from">Od</Label>
+            <Input
+              id="cogs-from"
+              type="date"
+              className="w-40"
+              value={params.from ?? ""}
+              onChange={(e) => update({ from: e.target.value || undefined })}
+            />
+          </div>
+          <div className="space-y-1">
+            <Label htmlFor="cogs-to">Do</Label>
+            <Input
+              id="cogs-to"
+              type="date"
+              className="w-40"
+              value={params.to ?? ""}
+              onChange={(e) => update({ to: e.target.value || undefined })}
+            />
+          </div>
+          <WarehouseSelect
+            value={params.warehouse_id}
+            onChange={(warehouse_id) => update({ warehouse_id })}
+          />
+        </div>
+
+        {isLoading ? (
+          <Skeleton className="h-[300px] w-full" />
+        ) : !data || data.rows.length === 0 ? (
+          <div className="flex h-[200px] items-center justify-center text-muted-foreground">
+            Brak danych
+          </div>
+        ) : (
+          <>
+            <Table>
+              <TableHeader>
+                <TableRow>
+                  <TableHead>{GROUP_LABELS[params.group_by]}</TableHead>
+                  {params.group_by === "document" && <TableHead>Magazyn</TableHead>}
+                  {params.group_by === "document" && <TableHead>Data</TableHead>}
+                  {params.group_by !== "document" && (
+                    <TableHead className="text-right">Dokumenty</TableHead>
+                  )}
+                  <TableHead className="text-right">Ilość</TableHead>
+                  <TableHead className="text-right">Koszt</TableHead>
+                </TableRow>
+              </TableHeader>
+              <TableBody>
+                {data.rows.map((row) => (
+                  <TableRow key={row.key}>
+                    <TableCell className="font-medium">
+                      {row.label}
+                      {row.estimated && (
+                        <span className="ml-1 text-muted-foreground" title="Koszt szacunkowy">
+                          *
+                        </span>
+                      )}
+                    </TableCell>
+                    {params.group_by === "document" && <TableCell>{row.warehouse}</TableCell>}
+                    {params.group_by === "document" && (
+                      <TableCell>
+                        {row.date ? new Date(row.date).toLocaleDateString("pl-PL") : "-"}
+                      </TableCell>
+                    )}
+                    {params.group_by !== "document" && (
+                      <TableCell className="text-right">{row.documents}</TableCell>
+                    )}
+                    <TableCell className="text-right">{row.quantity}</TableCell>
+                    <TableCell className="text-right">{formatCurrency(row.cost)}</TableCell>
+                  </TableRow>
+                ))}
+                <TableRow className="font-semibold">
+                  <TableCell colSpan={params.group_by === "document" ? 3 : 2}>Razem</TableCell>
+                  <TableCell className="text-right">{data.quantity}</TableCell>
+                  <TableCell className="text-right">{formatCurrency(data.cost)}</TableCell>
+                </TableRow>
+              </TableBody>
+            </Table>
+            <p className="text-xs text-muted-foreground">
+              * Koszt szacunkowy — część wydanej ilości nie miała pokrycia w przyjęciach
+              i została wyceniona według ceny zakupu produktu.
+            </p>
+          </>
+        )}
+      </CardContent>
+    </Card>
+  );
+}
+
+export function InventoryCostingReport() {
+  return (
+    <div className="space-y-6">
+      <InventoryValuation />
+      <COGS />
+    </div>
+  );
+}
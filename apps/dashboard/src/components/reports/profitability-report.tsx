@@ -0,0 +1,224 @@
+"use client";
+
+import { useState } from "react";
+import { toast } from "sonner";
+import { Download } from "lucide-react";
+import { useProfitability, exportProfitabilityCSV } from "@/hooks/use-reports";
+import { Button } from "@/components/ui/button";
+import { Input } from "@/components/ui/input";
+import { Label } from "@/components/ui/label";
+import { Card, CardContent, CardHeader, CardTitle } from "@/components/ui/card";
+import { Skeleton } from "@/components/ui/skeleton";
+import {
+  Select,
+  SelectContent,
+  SelectItem,
+  SelectTrigger,
+  SelectValue,
+} from "@/components/ui/select";
+import {
+  Table,
+  TableBody,
+  TableCell,
+  TableHead,
+  TableHeader,
+  TableRow,
+} from "@/components/ui/table";
+import { getErrorMessage } from "@/lib/api-client";
+import { formatCurrency } from "@/lib/utils";
+import type { ProfitabilityGroupBy, ProfitabilityParams, ProfitabilityRow } from "@/types/api";
+
+const GROUP_LABELS: Record<ProfitabilityGroupBy, string> = {
+  channel: "Kanał sprzedaży",
+  period: "Okres",
+  sku: "SKU",
+  order: "Zamówienie",
+};
+
+const PERIOD_LABELS: Record<string, string> = {
+  day: "Dzień",
+  week: "Tydzień",
+  month: "Miesiąc",
+};
+
+function marginClass(row: ProfitabilityRow) {
+  return row.gross_margin < 0 ? "text-destructive" : "";
+}
+
+export function ProfitabilityReport() {
+  const [params, setParams] = useState<ProfitabilityParams>({
+    group_by: "channel",
+    period: "month",
+    currency: "PLN",
+  });
+  const [exporting, setExporting] = useState(false);
+  const { data, isLoading } = useProfitability(params);
+
+  const currency = data?.currency ?? params.currency ?? "PLN";
+  const perOrderCosts = params.group_by !== "sku";
+
+  const update = (patch: Partial<ProfitabilityParams>) =>
+    setParams((prev) => ({ ...prev, ...patch }));
+
+  const handleExport = async () => {
+    setExporting(true);
+    try {
+      await exportProfitabilityCSV(params);
+    } catch (error) {
+      toast.error(getErrorMessage(error));
+    } finally {
+      setExporting(false);
+    }
+  };
+
+  const renderRow = (row: ProfitabilityRow, total = false) => (
+    <TableRow key={row.key} className={total ? "font-semibold" : undefined}>
+      <TableCell className={total ? undefined : "font-medium"}>{row.label}</TableCell>
+      <TableCell className="text-right">{row.orders}</TableCell>
+      <TableCell className="text-right">{row.quantity}</TableCell>
+      <TableCell className="text-right">{formatCurrency(row.revenue, currency)}</TableCell>
+      <TableCell className="text-right">{formatCurrency(row.product_cost, currency)}</TableCell>
+      {(perOrderCosts || total) && (
+        <>
+          <TableCell className="text-right">{formatCurrency(row.shipping_cost, currency)}</TableCell>
+          <TableCell className="text-right">{formatCurrency(row.marketplace_fee, currency)}</TableCell>
+        </>
+      )}
+      {!perOrderCosts && !total && (
+        <>
+          <TableCell className="text-right text-muted-foreground">-</TableCell>
+          <TableCell className="text-right text-muted-foreground">-</TableCell>
+        </>
+      )}
+      <TableCell className={`text-right ${marginClass(row)}`}>
+        {formatCurrency(row.gross_margin, currency)}
+      </TableCell>
+      <TableCell className={`text-right ${marginClass(row)}`}>
+        {row.margin_percent != null ? `${row.margin_percent.toFixed(1)}%` : "-"}
+      </TableCell>
+      <TableCell className="text-right text-muted-foreground">
+        {row.missing_cost_lines || ""}
+      </TableCell>
+    </TableRow>
+  );
+
+  return (
+    <Card>
+      <CardHeader className="flex flex-row items-center justify-between space-y-0">
+        <CardTitle>Rentowność</CardTitle>
+        <Button variant="outline" size="sm" onClick={handleExport} disabled={exporting}>
+          <Download className="mr-2 h-4 w-4" />
+          Eksportuj CSV
+        </Button>
+      </CardHeader>
+      <CardContent className="space-y-4">
+        <div className="flex flex-wrap items-end gap-3">
+          <div className="space-y-1">
+            <Label>Grupowanie</Label>
+            <Select
+              value={params.group_by}
+              onValueChange={(value) => update({ group_by: value as ProfitabilityGroupBy })}
+            >
+              <SelectTrigger className="w-44">
+                <SelectValue />
+              </SelectTrigger>
+              <SelectContent>
+                {Object.entries(GROUP_LABELS).map(([key, label]) => (
+                  <SelectItem key={key} value={key}>
+                    {label}
+                  </SelectItem>
+                ))}
+              </SelectContent>
+            </Select>
+          </div>
+          {params.group_by === "period" && (
+            <div className="space-y-1">
+              <Label>Okres</Label>
+              <Select
+                value={params.period}
+                onValueChange={(value) => update({ period: value as ProfitabilityParams["period"] })}
+              >
+                <SelectTrigger className="w-32">
+                  <SelectValue />
+                </SelectTrigger>
+                <SelectContent>
+                  {Object.entries(PERIOD_LABELS).map(([key, label]) => (
+                    <SelectItem key={key} value={key}>
+                      {label}
+                    </SelectItem>
+                  ))}
+                </SelectContent>
+              </Select>
+            </div>
+          )}
+          <div className="space-y-1">
+            <Label htmlFor="profitability-from">Od</Label>
+            <Input
+              id="profitability-from"
+              type="date"
+              className="w-40"
+              value={params.from ?? ""}
+              onChange={(e) => update({ from: e.target.value || undefined })}
+            />
+          </div>
+          <div className="space-y-1">
+            <Label htmlFor="profitability-to">Do</Label>
+            <Input
+              id="profitability-to"
+              type="date"
+              className="w-40"
+              value={params.to ?? ""}
+              onChange={(e) => update({ to: e.target.value || undefined })}
+            />
+          </div>
+          <div className="space-y-1">
+            <Label htmlFor="profitability-currency">Waluta</Label>
+            <Input
+              id="profitability-currency"
+              className="w-20"
+              maxLength={3}
+              value={params.currency ?? ""}
+              onChange={(e) => update({ currency: e.target.value.toUpperCase() || undefined })}
+            />
+          </div>
+        </div>
+
+        {isLoading ? (
+          <Skeleton className="h-[300px] w-full" />
+        ) : !data || data.rows.length === 0 ? (
+          <div className="flex h-[200px] items-center justify-center text-muted-foreground">
+            Brak danych
+          </div>
+        ) : (
+          <>
+            <Table>
+              <TableHeader>
+                <TableRow>
+                  <TableHead>{GROUP_LABELS[params.group_by]}</TableHead>
+                  <TableHead className="text-right">Zamówienia</TableHead>
+                  <TableHead className="text-right">Ilość</TableHead>
+                  <TableHead className="text-right">Przychód</TableHead>
+                  <TableHead className="text-right">Koszt produktów</TableHead>
+                  <TableHead className="text-right">Wysyłka</TableHead>
+                  <TableHead className="text-right">Prowizje</TableHead>
+                  <TableHead className="text-right">Marża brutto</TableHead>
+                  <TableHead className="text-right">Marża %</TableHead>
+                  <TableHead className="text-right">Bez kosztu</TableHead>
+                </TableRow>
+              </TableHeader>
+              <TableBody>
+                {data.rows.map((row) => renderRow(row))}
+                {renderRow(data.totals, true)}
+              </TableBody>
+            </Table>
+            <p className="text-xs text-muted-foreground">
+              Koszt produktów pochodzi z cen zakupu (dokumenty PZ lub cennik dostawcy).
+              Pozycje bez kosztu liczone są jako zerowe. Suma obejmuje całe zamówienia
+              wraz z kosztami wysyłki i prowizjami marketplace.
+            </p>
+          </>
+        )}
+      </CardContent>
+    </Card>
+  );
+}
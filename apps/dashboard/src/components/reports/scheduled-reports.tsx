@@ -0,0 +1,518 @@
+"use client";
+
+import { useState } from "react";
+import { toast } from "sonner";
+import { format } from "date-fns";
+import { History, Pencil, Play, Plus, Trash2 } from "lucide-react";
+import {
+  useScheduledReports,
+  useScheduledReportRuns,
+  useCreateScheduledReport,
+  useUpdateScheduledReport,
+  useDeleteScheduledReport,
+  useRunScheduledReport,
+} from "@/hooks/use-scheduled-reports";
+import { Badge } from "@/components/ui/badge";
+import { Button } from "@/components/ui/button";
+import { Input } from "@/components/ui/input";
+import { Label } from "@/components/ui/label";
+import { Switch } from "@/components/ui/switch";
+import { Textarea } from "@/components/ui/textarea";
+import { Card, CardContent, CardHeader, CardTitle } from "@/components/ui/card";
+import { Skeleton } from "@/components/ui/skeleton";
+import { ConfirmDialog } from "@/components/ui/confirm-dialog";
+import {
+  Dialog,
+  DialogContent,
+  DialogDescription,
+  DialogFooter,
+  DialogHeader,
+  DialogTitle,
+} from "@/components/ui/dialog";
+import {
+  Select,
+  SelectContent,
+  SelectItem,
+  SelectTrigger,
+  SelectValue,
+} from "@/components/ui/select";
+import {
+  Table,
+  TableBody,
+  TableCell,
+  TableHead,
+  TableHeader,
+  TableRow,
+} from "@/components/ui/table";
+import { getErrorMessage } from "@/lib/api-client";
+import type {
+  CreateScheduledReportRequest,
+  ScheduledReport,
+  ScheduledReportFormat,
+  ScheduledReportPeriod,
+  ScheduledReportType,
+} from "@/types/api";
+
+const TYPE_LABELS: Record<ScheduledReportType, string> = {
+  sales_by_channel: "Sprzedaż wg kanałów",
+  stock_value: "Wartość magazynu",
+  vat_summary: "Zestawienie VAT",
+  profitability: "Rentowność wg kanałów",
+};
+
+const PERIOD_LABELS: Record<ScheduledReportPeriod, string> = {
+  day: "Poprzedni dzień",
+  week: "Poprzedni tydzień",
+  month: "Poprzedni miesiąc",
+};
+
+const FORMAT_LABELS: Record<ScheduledReportFormat, string> = {
+  csv: "CSV",
+  xlsx: "Excel (XLSX)",
+  pdf: "PDF",
+};
+
+const SCHEDULE_PRESETS = [
+  { label: "Codziennie o 7:00", value: "0 7 * * *" },
+  { label: "W poniedziałki o 7:00", value: "0 7 * * 1" },
+  { label: "1. dnia miesiąca o 7:00", value: "0 7 1 * *" },
+];
+
+const emptyForm: CreateScheduledReportRequest = {
+  name: "",
+  report_type: "sales_by_channel",
+  schedule: "0 7 * * *",
+  timezone: "Europe/Warsaw",
+  period: "day",
+  format: "xlsx",
+  recipients: [],
+  active: true,
+};
+
+function formatDateTime(value?: string) {
+  return value ? format(new Date(value), "yyyy-MM-dd HH:mm") : "-";
+}
+
+function ScheduledReportDialog({
+  report,
+  open,
+  onOpenChange,
+}: {
+  report: ScheduledReport | null;
+  open: boolean;
+  onOpenChange: (open: boolean) => void;
+}) {
+  const [form, setForm] = useState<CreateScheduledReportRequest>(
+    report
+      ? {
+          name: report.name,
+          report_type: report.report_type,
+          schedule: report.schedule,
+          timezone: report.timezone,
+          period: report.period,
+          format: report.format,
+          recipients: report.recipients,
+          active: report.active,
+        }
+      : emptyForm
+  );
+  const [recipients, setRecipients] = useState(form.recipients.join("\n"));
+  const createReport = useCreateScheduledReport();
+  const updateReport = useUpdateScheduledReport(report?.id ?? "");
+  const isPending = createReport.isPending || updateReport.isPending;
+
+  const update = (patch: Partial<CreateScheduledReportRequest>) =>
+    setForm((prev) => ({ ...prev, ...patch }));
+
+  const handleSubmit = async () => {
+    const data = {
+      ...form,
+      recipients: recipients
+        .split(/[\s,;]+/)
+        .map((email) => email.trim())
+        .filter(Boolean),
+    };
+    try {
+      if (report) {
+        await updateReport.mutateAsync(data);
+        toast.success("Raport zaktualizowany");
+      } else {
+        await createReport.mutateAsync(data);
+        toast.success("Raport utworzony");
+      }
+      onOpenChange(false);
+    } catch (error) {
+      toast.error(getErrorMessage(error));
+    }
+  };
+
+  return (
+    <Dialog open={open} onOpenChange={onOpenChange}>
+      <DialogContent className="max-w-lg">
+        <DialogHeader>
+          <DialogTitle>{report ? "Edytuj raport cykliczny" : "Nowy raport cykliczny"}</DialogTitle>
+          <DialogDescription>
+            Raport jest generowany według harmonogramu i wysyłany e-mailem przez skonfigurowany serwer SMTP.
+          </DialogDescription>
+        </DialogHeader>
+        <div className="space-y-4">
+          <div className="space-y-1">
+            <Label htmlFor="report-name">Nazwa</Label>
+            <Input
+              id="report-name"
+              value={form.name}
+              onChange={(e) => update({ name: e.target.value })}
+            />
+          </div>
+          <div className="grid grid-cols-2 gap-3">
+            <div className="space-y-1">
+              <Label>Raport</Label>
+              <Select
+                value={form.report_type}
+                onValueChange={(value) => update({ report_type: value as ScheduledReportType })}
+              >
+                <SelectTrigger>
+                  <SelectValue />
+                </SelectTrigger>
+                <SelectContent>
+                  {Object.entries(TYPE_LABELS).map(([key, label]) => (
+                    <SelectItem key={key} value={key}>
+                      {label}
+                    </SelectItem>
+                  ))}
+                </SelectContent>
+              </Select>
+            </div>
+            <div className="space-y-1">
+              <Label>Format</Label>
+              <Select
+                value={form.format}
+                onValueChange={(value) => update({ format: value as ScheduledReportFormat })}
+              >
+                <SelectTrigger>
+                  <SelectValue />
+                </SelectTrigger>
+                <SelectContent>
+                  {Object.entries(FORMAT_LABELS).map(([key, label]) => (
+                    <SelectItem key={key} value={key}>
+                      {label}
+                    </SelectItem>
+                  ))}
+                </SelectContent>
+              </Select>
+            </div>
+          </div>
+          {form.report_type !== "stock_value" && (
+            <div className="space-y-1">
+              <Label>Zakres danych</Label>
+              <Select
+                value={form.period}
+                onValueChange={(value) => update({ period: value as ScheduledReportPeriod })}
+              >
+                <SelectTrigger>
+                  <SelectValue />
+                </SelectTrigger>
+                <SelectContent>
+                  {Object.entries(PERIOD_LABELS).map(([key, label]) => (
+                    <SelectItem key={key} value={key}>
+                      {label}
+                    </SelectItem>
+                  ))}
+                </SelectContent>
+              </Select>
+            </div>
+          )}
+          <div className="grid grid-cols-2 gap-3">
+            <div className="space-y-1">
+              <Label htmlFor="report-schedule">Harmonogram (cron)</Label>
+              <Input
+                id="report-schedule"
+                className="font-mono"
+                value={form.schedule}
+                onChange={(e) => update({ schedule: e.target.value })}
+              />
+            </div>
+            <div className="space-y-1">
+              <Label htmlFor="report-timezone">Strefa czasowa</Label>
+              <Input
+                id="report-timezone"
+                value={form.timezone ?? ""}
+                onChange={(e) => update({ timezone: e.target.value })}
+              />
+            </div>
+          </div>
+          <div className="flex flex-wrap gap-2">
+            {SCHEDULE_PRESETS.map((preset) => (
+              <Button
+                key={preset.value}
+                type="button"
+                variant="outline"
+                size="sm"
+                onClick={() => update({ schedule: preset.value })}
+              >
+                {preset.label}
+              </Button>
+            ))}
+          </div>
+          <div className="space-y-1">
+            <Label htmlFor="report-recipients">Odbiorcy</Label>
+            <Textarea
+              id="report-recipients"
+              rows={3}
+              placeholder="jeden adres e-mail w wierszu"
+              value={recipients}
+              onChange={(e) => setRecipients(e.target.value)}
+            />
+          </div>
+          <div className="flex items-center gap-2">
+            <Switch
+              id="report-active"
+              checked={form.active ?? true}
+              onCheckedChange={(checked) => update({ active: checked })}
+            />
+            <Label htmlFor="report-active">Aktywny</Label>
+          </div>
+        </div>
+        <DialogFooter>
+          <Button variant="outline" onClick={() => onOpenChange(false)}>
+            Anuluj
+          </Button>
+          <Button onClick={handleSubmit} disabled={isPending}>
+            Zapisz
+          </Button>
+        </DialogFooter>
+      </DialogContent>
+    </Dialog>
+  );
+}
+
+function ScheduledReportRuns({ report }: { report: ScheduledReport }) {
+  const { data, isLoading } = useScheduledReportRuns(report.id);
+
+  return (
+    <Card>
+      <CardHeader>
+        <CardTitle>Historia: {report.name}</CardTitle>
+      </CardHeader>
+      <CardContent>
+        {isLoading ? (
+          <Skeleton className="h-[120px] w-full" />
+        ) : !data || data.items.length === 0 ? (
+          <div className="flex h-[120px] items-center justify-center text-muted-foreground">
+            Raport nie był jeszcze generowany
+          </div>
+        ) : (
+          <Table>
+            <TableHeader>
+              <TableRow>
+                <TableHead>Data</TableHead>
+                <TableHead>Okres</TableHead>
+                <TableHead>Status</TableHead>
+                <TableHead>Plik</TableHead>
+              </TableRow>
+            </TableHeader>
+            <TableBody>
+              {data.items.map((run) => (
+                <TableRow key={run.id}>
+                  <TableCell>{formatDateTime(run.created_at)}</TableCell>
+                  <TableCell>
+                    {run.period_from && run.period_to
+                      ? `${format(new Date(run.period_from), "yyyy-MM-dd")} – ${format(
+                          new Date(new Date(run.period_to).getTime() - 1),
+                          "yyyy-MM-dd"
+                        )}`
+                      : "Stan bieżący"}
+                  </TableCell>
+                  <TableCell>
+                    {run.status === "success" ? (
+                      <Badge variant="success">Wysłany</Badge>
+                    ) : (
+                      <Badge variant="destructive" title={run.error_message}>
+                        Błąd
+                      </Badge>
+                    )}
+                    {run.error_message && (
+                      <p className="mt-1 text-xs text-muted-foreground">{run.error_message}</p>
+                    )}
+                  </TableCell>
+                  <TableCell>
+                    {run.file_url ? (
+                      <a
+                        href={run.file_url}
+                        target="_blank"
+                        rel="noopener noreferrer"
+                        className="text-primary underline-offset-4 hover:underline"
+                      >
+                        {run.format.toUpperCase()}
+                      </a>
+                    ) : (
+                      "-"
+                    )}
+                  </TableCell>
+                </TableRow>
+              ))}
+            </TableBody>
+          </Table>
+        )}
+      </CardContent>
+    </Card>
+  );
+}
+
+export function ScheduledReports() {
+  const { data, isLoading } = useScheduledReports();
+  const deleteReport = useDeleteScheduledReport();
+  const runReport = useRunScheduledReport();
+  const [editing, setEditing] = useState<ScheduledReport | null>(null);
+  const [dialogOpen, setDialogOpen] = useState(false);
+  const [deleting, setDeleting] = useState<ScheduledReport | null>(null);
+  const [selected, setSelected] = useState<ScheduledReport | null>(null);
+
+  const openDialog = (report: ScheduledReport | null) => {
+    setEditing(report);
+    setDialogOpen(true);
+  };
+
+  const handleRun = async (report: ScheduledReport) => {
+    try {
+      const run = await runReport.mutateAsync(report.id);
+      if (run.status === "success") {
+        toast.success("Raport wygenerowany i wysłany");
+      } else {
+        toast.error(run.error_message ?? "Nie udało się wygenerować raportu");
+      }
+      setSelected(report);
+    } catch (error) {
+      toast.error(getErrorMessage(error));
+    }
+  };
+
+  const handleDelete = async () => {
+    if (!deleting) return;
+    try {
+      await deleteReport.mutateAsync(deleting.id);
+      toast.success("Raport usunięty");
+      if (selected?.id === deleting.id) setSelected(null);
+      setDeleting(null);
+    } catch (error) {
+      toast.error(getErrorMessage(error));
+    }
+  };
+
+  return (
+    <div className="space-y-6">
+      <Card>
+        <CardHeader className="flex flex-row items-center justify-between space-y-0">
+          <CardTitle>Raporty cykliczne</CardTitle>
+          <Button size="sm" onClick={() => openDialog(null)}>
+            <Plus className="mr-2 h-4 w-4" />
+            Nowy raport
+          </Button>
+        </CardHeader>
+        <CardContent>
+          {isLoading ? (
+            <Skeleton className="h-[200px] w-full" />
+          ) : !data || data.length === 0 ? (
+            <div className="flex h-[200px] items-center justify-center text-muted-foreground">
+              Brak raportów cyklicznych
+            </div>
+          ) : (
+            <Table>
+              <TableHeader>
+                <TableRow>
+                  <TableHead>Nazwa</TableHead>
+                  <TableHead>Raport</TableHead>
+                  <TableHead>Harmonogram</TableHead>
+                  <TableHead>Format</TableHead>
+                  <TableHead>Odbiorcy</TableHead>
+                  <TableHead>Następne wysłanie</TableHead>
+                  <TableHead className="text-right">Akcje</TableHead>
+                </TableRow>
+              </TableHeader>
+              <TableBody>
+                {data.map((report) => (
+                  <TableRow key={report.id}>
+                    <TableCell className="font-medium">
+                      {report.name}
+                      {!report.active && (
+                        <Badge variant="secondary" className="ml-2">
+                          Wyłączony
+                        </Badge>
+                      )}
+                    </TableCell>
+                    <TableCell>{TYPE_LABELS[report.report_type]}</TableCell>
+                    <TableCell className="font-mono text-xs">{report.schedule}</TableCell>
+                    <TableCell>{report.format.toUpperCase()}</TableCell>
+                    <TableCell className="max-w-[200px] truncate" title={report.recipients.join(", ")}>
+                      {report.recipients.join(", ")}
+                    </TableCell>
+                    <TableCell>{formatDateTime(report.next_run_at)}</TableCell>
+                    <TableCell className="text-right">
+                      <div className="flex justify-end gap-1">
+                        <Button
+                          variant="ghost"
+                          size="icon"
+                          title="Wyślij teraz"
+                          disabled={runReport.isPending}
+                          onClick={() => handleRun(report)}
+                        >
+                          <Play className="h-4 w-4" />
+                        </Button>
+                        <Button
+                          variant="ghost"
+                          size="icon"
+                          title="Historia"
+                          onClick={() => setSelected(report)}
+                        >
+                          <History className="h-4 w-4" />
+                        </Button>
+                        <Button
+                          variant="ghost"
+                          size="icon"
+                          title="Edytuj"
+                          onClick={() => openDialog(report)}
+                        >
+                          <Pencil className="h-4 w-4" />
+                        </Button>
+                        <Button
+                          variant="ghost"
+                          size="icon"
+                          title="Usuń"
+                          onClick={() => setDeleting(report)}
+                        >
+                          <Trash2 className="h-4 w-4" />
+                        </Button>
+                      </div>
+                    </TableCell>
+                  </TableRow>
+                ))}
+              </TableBody>
+            </Table>
+          )}
+        </CardContent>
+      </Card>
+
+      {selected && <ScheduledReportRuns report={selected} />}
+
+      {dialogOpen && (
+        <ScheduledReportDialog
+          key={editing?.id ?? "new"}
+          report={editing}
+          open={dialogOpen}
+          onOpenChange={setDialogOpen}
+        />
+      )}
+
+      <ConfirmDialog
+        open={!!deleting}
+        onOpenChange={(open) => !open && setDeleting(null)}
+        title="Usuń raport cykliczny"
+        description={`Czy na pewno chcesz usunąć raport "${deleting?.name ?? ""}" wraz z historią?`}
+        confirmLabel="Usuń"
+        variant="destructive"
+        onConfirm={handleDelete}
+        isLoading={deleteReport.isPending}
+      />
+    </div>
+  );
+}
@@ -0,0 +1,478 @@
+"use client";
+
+import { useState } from "react";
+import Link from "next/link";
+import { toast } from "sonner";
+import { useOrder } from "@/hooks/use-orders";
+import { useWarehouses } from "@/hooks/use-warehouses";
+import { useReturnSettings } from "@/hooks/use-settings";
+import {
+  useCreateReturnLabel,
+  useExchangeReturn,
+  useReceiveReturn,
+  useRefundReturn,
+} from "@/hooks/use-returns";
+import { Button } from "@/components/ui/button";
+import { Input } from "@/components/ui/input";
+import { Label } from "@/components/ui/label";
+import { Card, CardContent, CardHeader, CardTitle } from "@/components/ui/card";
+import {
+  Select,
+  SelectContent,
+  SelectItem,
+  SelectTrigger,
+  SelectValue,
+} from "@/components/ui/select";
+import {
+  Table,
+  TableBody,
+  TableCell,
+  TableHead,
+  TableHeader,
+  TableRow,
+} from "@/components/ui/table";
+import { formatCurrency, formatDate, shortId } from "@/lib/utils";
+import { getErrorMessage } from "@/lib/api-client";
+import type { OrderItem, Return, ReturnCondition, RefundMethod } from "@/types/api";
+
+export const RETURN_CONDITIONS: Record<ReturnCondition, string> = {
+  resellable: "Pełnowartościowy",
+  damaged: "Uszkodzony",
+  to_supplier: "Do dostawcy",
+};
+
+const RETURN_CARRIERS: Record<string, string> = {
+  inpost: "InPost",
+  dpd: "DPD",
+  orlen_paczka: "Orlen Paczka",
+};
+
+const REFUND_METHODS: Record<RefundMethod, string> = {
+  channel: "Przez kanał sprzedaży",
+  manual: "Przelew bankowy",
+};
+
+interface ReceiveRow {
+  quantity: number;
+  condition: ReturnCondition;
+  deduction: number;
+}
+
+function itemUnitPrice(item: OrderItem): number {
+  return item.unit_price ?? item.price ?? 0;
+}
+
+export function ReceiveReturnCard({ returnData }: { returnData: Return }) {
+  const { data: order } = useOrder(returnData.order_id);
+  const { data: warehousesData } = useWarehouses({ limit: 100 });
+  const { data: settings } = useReturnSettings();
+  const receiveReturn = useReceiveReturn(returnData.id);
+  const [rows, setRows] = useState<Record<number, ReceiveRow>>({});
+  const [warehouseId, setWarehouseId] = useState("");
+
+  const orderItems: OrderItem[] = order?.items || [];
+  const warehouses = warehousesData?.items || [];
+  const selectedWarehouse = warehouseId || settings?.default_warehouse_id || "";
+
+  const rowFor = (index: number): ReceiveRow =>
+    rows[index] ?? { quantity: 0, condition: "resellable", deduction: 0 };
+
+  const updateRow = (index: number, patch: Partial<ReceiveRow>) => {
+    setRows((prev) => ({ ...prev, [index]: { ...rowFor(index), ...patch } }));
+  };
+
+  const handleReceive = async () => {
+    const items = orderItems
+      .map((_, index) => ({ index, row: rowFor(index) }))
+      .filter(({ row }) => row.quantity > 0)
+      .map(({ index, row }) => ({
+        order_item_index: index,
+        quantity: row.quantity,
+        condition: row.condition,
+        deduction: row.deduction || undefined,
+      }));
+    if (items.length === 0) {
+      toast.error("Podaj ilość co najmniej jednej przyjętej pozycji");
+      return;
+    }
+    try {
+      await receiveReturn.mutateAsync({
+        items,
+        warehouse_id: selectedWarehouse || undefined,
+      });
+      toast.success("Zwrot został przyjęty");
+    } catch (error) {
+      toast.error(getErrorMessage(error));
+    }
+  };
+
+  return (
+    <Card>
+      <CardHeader>
+        <CardTitle>Przyjęcie zwrotu</CardTitle>
+      </CardHeader>
+      <CardContent className="space-y-4">
+        {returnData.grading_pending && (
+          <p className="text-sm text-muted-foreground">
+            Paczka zwrotna została doręczona i zwrot przyjęto automatycznie. Oceń stan pozycji,
+            aby przyjąć towar na stan i naliczyć zwrot środków.
+          </p>
+        )}
+        <Table>
+          <TableHeader>
+            <TableRow>
+              <TableHead>Produkt</TableHead>
+              <TableHead>Zamówiono</TableHead>
+              <TableHead>Przyjęto</TableHead>
+              <TableHead>Stan</TableHead>
+              <TableHead>Potrącenie</TableHead>
+            </TableRow>
+          </TableHeader>
+          <TableBody>
+            {orderItems.map((item, index) => {
+              const row = rowFor(index);
+              return (
+                <TableRow key={index}>
+                  <TableCell>
+                    <p className="font-medium">{item.name}</p>
+                    <p className="text-xs text-muted-foreground">
+                      {item.sku ? `${item.sku} · ` : ""}
+                      {formatCurrency(itemUnitPrice(item))}
+                    </p>
+                  </TableCell>
+                  <TableCell>{item.quantity}</TableCell>
+                  <TableCell>
+                    <Input
+                      type="number"
+                      min={0}
+                      max={item.quantity}
+                      className="w-20"
+                      value={row.quantity}
+                      onChange={(e) => updateRow(index, { quantity: Number(e.target.value) })}
+                    />
+                  </TableCell>
+                  <TableCell>
+                    <Select
+                      value={row.condition}
+                      onValueChange={(value) => updateRow(index, { condition: value as ReturnCondition })}
+                    >
+                      <SelectTrigger className="w-44">
+                        <SelectValue />
+                      </SelectTrigger>
+                      <SelectContent>
+                        {Object.entries(RETURN_CONDITIONS).map(([value, label]) => (
+                          <SelectItem key={value} value={value}>
+                            {label}
+                          </SelectItem>
+                        ))}
+                      </SelectContent>
+                    </Select>
+                  </TableCell>
+                  <TableCell>
+                    <Input
+                      type="number"
+                      min={0}
+                      step="0.01"
+                      className="w-24"
+                      value={row.deduction}
+                      onChange={(e) => updateRow(index, { deduction: Number(e.target.value) })}
+                    />
+                  </TableCell>
+                </TableRow>
+              );
+            })}
+          </TableBody>
+        </Table>
+
+        <div className="space-y-2">
+          <Label>Magazyn dla pełnowartościowych produktów (PZ)</Label>
+          <Select value={selectedWarehouse} onValueChange={setWarehouseId}>
+            <SelectTrigger className="w-72">
+              <SelectValue placeholder="Wybierz magazyn" />
+            </SelectTrigger>
+            <SelectContent>
+              {warehouses.map((w) => (
+                <SelectItem key={w.id} value={w.id}>
+                  {w.name} {w.code ? `(${w.code})` : ""}
+                </SelectItem>
+              ))}
+            </SelectContent>
+          </Select>
+        </div>
+
+        <Button onClick={handleReceive} disabled={receiveReturn.isPending}>
+          {receiveReturn.isPending ? "Przyjmowanie..." : "Przyjmij zwrot"}
+        </Button>
+      </CardContent>
+    </Card>
+  );
+}
+
+export function RefundReturnCard({ returnData }: { returnData: Return }) {
+  const refundReturn = useRefundReturn(returnData.id);
+  const [method, setMethod] = useState<RefundMethod | "auto">("auto");
+  const [reference, setReference] = useState("");
+  const remaining = Math.max(0, returnData.refund_amount - returnData.refunded_amount);
+  const [amount, setAmount] = useState(remaining.toFixed(2));
+
+  const parsedAmount = parseFloat(amount);
+  const partial = !isNaN(parsedAmount) && parsedAmount < remaining;
+
+  const handleRefund = async () => {
+    try {
+      await refundReturn.mutateAsync({
+        method: method === "auto" ? undefined : method,
+        reference: method === "manual" && reference ? reference : undefined,
+        amount: partial && parsedAmount > 0 ? parsedAmount : undefined,
+      });
+      toast.success(partial ? "Zwrócono część środków" : "Środki zostały zwrócone");
+    } catch (error) {
+      toast.error(getErrorMessage(error));
+    }
+  };
+
+  return (
+    <Card>
+      <CardHeader>
+        <CardTitle>Zwrot środków</CardTitle>
+      </CardHeader>
+      <CardContent className="space-y-4">
+        <div className="grid grid-cols-3 gap-4 text-sm">
+          <div>
+            <p className="text-muted-foreground">Produkty</p>
+            <p className="mt-1 font-medium">
+              {formatCurrency(returnData.refund_amount - returnData.shipping_refund)}
+            </p>
+          </div>
+          <div>
+            <p className="text-muted-foreground">Wysyłka</p>
+            <p className="mt-1 font-medium">{formatCurrency(returnData.shipping_refund)}</p>
+          </div>
+          <div>
+            <p className="text-muted-foreground">Razem</p>
+            <p className="mt-1 font-medium">{formatCurrency(returnData.refund_amount)}</p>
+          </div>
+          {returnData.refunded_amount > 0 && (
+            <>
+              <div>
+                <p className="text-muted-foreground">Już zwrócono</p>
+                <p className="mt-1 font-medium">{formatCurrency(returnData.refunded_amount)}</p>
+              </div>
+              <div>
+                <p className="text-muted-foreground">Pozostało</p>
+                <p className="mt-1 font-medium">{formatCurrency(remaining)}</p>
+              </div>
+            </>
+          )}
+        </div>
+
+        <div className="space-y-2">
+          <Label htmlFor="refund_amount_partial">Kwota do zwrotu</Label>
+          <Input
+            id="refund_amount_partial"
+            type="number"
+            step="0.01"
+            min="0"
+            max={remaining}
+            className="w-72"
+            value={amount}
+            onChange={(e) => setAmount(e.target.value)}
+          />
+          {partial && (
+            <p className="text-sm text-muted-foreground">
+              Zwrot częściowy — zostanie zarejestrowany jako przelew ręczny
+            </p>
+          )}
+        </div>
+
+        <div className="space-y-2">
+          <Label>Metoda zwrotu</Label>
+          <Select value={method} onValueChange={(value) => setMethod(value as RefundMethod | "auto")}>
+            <SelectTrigger className="w-72">
+              <SelectValue />
+            </SelectTrigger>
+            <SelectContent>
+              <SelectItem value="auto">Automatycznie</SelectItem>
+              {Object.entries(REFUND_METHODS).map(([value, label]) => (
+                <SelectItem key={value} value={value}>
+                  {label}
+                </SelectItem>
+              ))}
+            </SelectContent>
+          </Select>
+        </div>
+
+        {method === "manual" && (
+          <div className="space-y-2">
+            <Label htmlFor="refund_reference">Tytuł / numer przelewu</Label>
+            <Input
+              id="refund_reference"
+              value={reference}
+              onChange={(e) => setReference(e.target.value)}
+            />
+          </div>
+        )}
+
+        <Button onClick={handleRefund} disabled={refundReturn.isPending}>
+          {refundReturn.isPending ? "Zwracanie..." : "Zwróć środki"}
+        </Button>
+      </CardContent>
+    </Card>
+  );
+}
+
+export function ExchangeReturnCard({ returnData }: { returnData: Return }) {
+  const exchangeReturn = useExchangeReturn(returnData.id);
+
+  const handleExchange = async () => {
+    try {
+      await exchangeReturn.mutateAsync({});
+      toast.success("Utworzono zamówienie zamienne");
+    } catch (error) {
+      toast.error(getErrorMessage(error));
+    }
+  };
+
+  return (
+    <Card>
+      <CardHeader>
+        <CardTitle>Wymiana</CardTitle>
+      </CardHeader>
+      <CardContent className="space-y-4">
+        <p className="text-sm text-muted-foreground">
+          Zostanie utworzone nowe, opłacone zamówienie z przyjętymi pozycjami dla klienta
+          z oryginalnego zamówienia.
+        </p>
+        <Button onClick={handleExchange} disabled={exchangeReturn.isPending}>
+          {exchangeReturn.isPending ? "Tworzenie..." : "Utwórz zamówienie zamienne"}
+        </Button>
+      </CardContent>
+    </Card>
+  );
+}
+
+export function ReceivedItemsCard({ returnData }: { returnData: Return }) {
+  return (
+    <Card>
+      <CardHeader>
+        <CardTitle>Przyjęte pozycje</CardTitle>
+      </CardHeader>
+      <CardContent className="space-y-4">
+        <Table>
+          <TableHeader>
+            <TableRow>
+              <TableHead>Produkt</TableHead>
+              <TableHead>Ilość</TableHead>
+              <TableHead>Stan</TableHead>
+              <TableHead>Potrącenie</TableHead>
+            </TableRow>
+          </TableHeader>
+          <TableBody>
+            {returnData.received_items.map((item, index) => (
+              <TableRow key={index}>
+                <TableCell>
+                  <p className="font-medium">{item.name}</p>
+                  {item.sku && <p className="text-xs text-muted-foreground">{item.sku}</p>}
+                </TableCell>
+                <TableCell>{item.quantity}</TableCell>
+                <TableCell>{RETURN_CONDITIONS[item.condition] || item.condition}</TableCell>
+                <TableCell>{formatCurrency(item.deduction ?? 0)}</TableCell>
+              </TableRow>
+            ))}
+          </TableBody>
+        </Table>
+        <div className="grid grid-cols-2 gap-4 text-sm">
+          {returnData.received_at && (
+            <div>
+              <p className="text-muted-foreground">Przyjęto</p>
+              <p className="mt-1">{formatDate(returnData.received_at)}</p>
+            </div>
+          )}
+          {returnData.refunded_at && (
+            <div>
+              <p className="text-muted-foreground">Zwrócono środki</p>
+              <p className="mt-1">
+                {formatDate(returnData.refunded_at)}
+                {returnData.refund_method && ` · ${REFUND_METHODS[returnData.refund_method]}`}
+                {returnData.refund_reference && ` · ${returnData.refund_reference}`}
+              </p>
+            </div>
+          )}
+          {returnData.replacement_order_id && (
+            <div>
+              <p className="text-muted-foreground">Zamówienie zamienne</p>
+              <Link
+                href={`/orders/${returnData.replacement_order_id}`}
+                className="mt-1 font-mono text-sm text-primary hover:underline"
+              >
+                {shortId(returnData.replacement_order_id)}
+              </Link>
+            </div>
+          )}
+        </div>
+      </CardContent>
+    </Card>
+  );
+}
+
+export function ReturnLabelCard({ returnData }: { returnData: Return }) {
+  const createLabel = useCreateReturnLabel(returnData.id);
+
+  const handleCreate = async () => {
+    try {
+      await createLabel.mutateAsync();
+      toast.success("Etykieta zwrotna została wygenerowana");
+    } catch (error) {
+      toast.error(getErrorMessage(error));
+    }
+  };
+
+  return (
+    <Card>
+      <CardHeader>
+        <CardTitle>Etykieta zwrotna</CardTitle>
+      </CardHeader>
+      <CardContent className="space-y-4">
+        {returnData.return_tracking_number ? (
+          <div className="grid grid-cols-3 gap-4 text-sm">
+            <div>
+              <p className="text-muted-foreground">Przewoźnik</p>
+              <p className="mt-1 font-medium">
+                {RETURN_CARRIERS[returnData.return_provider ?? ""] || returnData.return_provider}
+              </p>
+            </div>
+            <div>
+              <p className="text-muted-foreground">Numer przesyłki</p>
+              <p className="mt-1 font-mono">{returnData.return_tracking_number}</p>
+            </div>
+            <div>
+              <p className="text-muted-foreground">Status przesyłki</p>
+              <p className="mt-1">{returnData.return_shipment_status || "-"}</p>
+            </div>
+            {returnData.return_label_url && (
+              <div className="col-span-3">
+                <Button variant="outline" asChild>
+                  <a href={returnData.return_label_url} target="_blank" rel="noopener noreferrer">
+                    Pobierz etykietę
+                  </a>
+                </Button>
+              </div>
+            )}
+          </div>
+        ) : (
+          <div className="space-y-2">
+            <p className="text-sm text-muted-foreground">
+              Etykieta jest generowana automatycznie po zatwierdzeniu zwrotu, jeśli w ustawieniach
+              zwrotów skonfigurowano przewoźnika.
+            </p>
+            {returnData.status === "approved" && (
+              <Button onClick={handleCreate} disabled={createLabel.isPending}>
+                {createLabel.isPending ? "Generowanie..." : "Generuj etykietę"}
+              </Button>
+            )}
+          </div>
+        )}
+      </CardContent>
+    </Card>
+  );
+}
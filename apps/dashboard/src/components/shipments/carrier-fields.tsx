@@ -30,12 +30,14 @@ interface CarrierFieldsProps {
   provider: string;
   values: CarrierFieldValues;
   onChange: (field: string, value: unknown) => void;
+  /** Bulk labels take the locker from each order's pickup point. */
+  hideTargetPoint?: boolean;
 }
 
-export function CarrierFields({ provider, values, onChange }: CarrierFieldsProps) {
+export function CarrierFields({ provider, values, onChange, hideTargetPoint }: CarrierFieldsProps) {
   switch (provider) {
     case "inpost":
-      return <InPostFields values={values} onChange={onChange} />;
+      return <InPostFields values={values} onChange={onChange} hideTargetPoint={hideTargetPoint} />;
     case "dhl":
       return <DHLFields values={values} onChange={onChange} />;
     case "dpd":
@@ -113,9 +115,11 @@ function ParcelDimensionFields({
 function InPostFields({
   values,
   onChange,
+  hideTargetPoint,
 }: {
   values: CarrierFieldValues;
   onChange: (field: string, value: unknown) => void;
+  hideTargetPoint?: boolean;
 }) {
   const serviceType = values.service_type ?? "inpost_locker_standard";
   const isLocker = serviceType === "inpost_locker_standard";
@@ -153,7 +157,12 @@ function InPostFields({
       </div>
 
       {/* Target point (only for locker) */}
-      {isLocker && (
+      {isLocker && hideTargetPoint && (
+        <p className="text-sm text-muted-foreground">
+          Paczkomat docelowy zostanie pobrany z każdego zamówienia.
+        </p>
+      )}
+      {isLocker && !hideTargetPoint && (
         <div className="space-y-2">
           <Label>Paczkomat docelowy</Label>
           {targetPoint && (
@@ -0,0 +1,149 @@
+"use client";
+
+import { useState, useEffect } from "react";
+import { toast } from "sonner";
+import { useUpdateSupplier } from "@/hooks/use-suppliers";
+import { getErrorMessage } from "@/lib/api-client";
+import { Button } from "@/components/ui/button";
+import { Input } from "@/components/ui/input";
+import { Label } from "@/components/ui/label";
+import {
+  Select,
+  SelectContent,
+  SelectItem,
+  SelectTrigger,
+  SelectValue,
+} from "@/components/ui/select";
+import {
+  Card,
+  CardContent,
+  CardDescription,
+  CardHeader,
+  CardTitle,
+} from "@/components/ui/card";
+import type { Supplier, SupplierDropshipSettings } from "@/types/api";
+
+const NO_CHANNEL = "none";
+
+interface DropshipSettingsCardProps {
+  supplier: Supplier;
+}
+
+export function DropshipSettingsCard({ supplier }: DropshipSettingsCardProps) {
+  const updateSupplier = useUpdateSupplier(supplier.id);
+
+  const [channel, setChannel] = useState<string>(NO_CHANNEL);
+  const [email, setEmail] = useState("");
+  const [attachment, setAttachment] = useState("pdf");
+  const [webhookUrl, setWebhookUrl] = useState("");
+  const [webhookSecret, setWebhookSecret] = useState("");
+
+  useEffect(() => {
+    const ds = (supplier.settings?.dropship ?? {}) as Partial<SupplierDropshipSettings>;
+    setChannel(ds.channel || NO_CHANNEL);
+    setEmail(ds.email ?? "");
+    setAttachment(ds.attachment || "pdf");
+    setWebhookUrl(ds.webhook_url ?? "");
+    setWebhookSecret(ds.webhook_secret ?? "");
+  }, [supplier]);
+
+  const handleSave = () => {
+    const dropship: SupplierDropshipSettings =
+      channel === "email"
+        ? { channel: "email", email, attachment: attachment as "pdf" | "csv" }
+        : channel === "webhook"
+          ? { channel: "webhook", webhook_url: webhookUrl, webhook_secret: webhookSecret || undefined }
+          : { channel: "" };
+    updateSupplier.mutate(
+      { settings: { ...supplier.settings, dropship } },
+      {
+        onSuccess: () => toast.success("Ustawienia dropshippingu zapisane"),
+        onError: (error) => toast.error(getErrorMessage(error)),
+      }
+    );
+  };
+
+  return (
+    <Card>
+      <CardHeader>
+        <CardTitle>Dropshipping</CardTitle>
+        <CardDescription>
+          Sposób przekazywania dostawcy zamówień do wysyłki bezpośrednio do klienta
+        </CardDescription>
+      </CardHeader>
+      <CardContent className="space-y-4">
+        <div className="space-y-2">
+          <Label>Kanał</Label>
+          <Select value={channel} onValueChange={setChannel}>
+            <SelectTrigger><SelectValue /></SelectTrigger>
+            <SelectContent>
+              <SelectItem value={NO_CHANNEL}>Wyłączony</SelectItem>
+              <SelectItem value="email">E-mail</SelectItem>
+              <SelectItem value="webhook">Webhook</SelectItem>
+            </SelectContent>
+          </Select>
+        </div>
+
+        {channel === "email" && (
+          <div className="grid gap-4 md:grid-cols-2">
+            <div className="space-y-2">
+              <Label htmlFor="dropshipEmail">Adres e-mail</Label>
+              <Input
+                id="dropshipEmail"
+                type="email"
+                value={email}
+                onChange={(e) => setEmail(e.target.value)}
+                placeholder="zamowienia@dostawca.pl"
+              />
+            </div>
+            <div className="space-y-2">
+              <Label>Załącznik</Label>
+              <Select value={attachment} onValueChange={setAttachment}>
+                <SelectTrigger><SelectValue /></SelectTrigger>
+                <SelectContent>
+                  <SelectItem value="pdf">PDF</SelectItem>
+                  <SelectItem value="csv">CSV</SelectItem>
+                </SelectContent>
+              </Select>
+            </div>
+          </div>
+        )}
+
+        {channel === "webhook" && (
+          <div className="grid gap-4 md:grid-cols-2">
+            <div className="space-y-2">
+              <Label htmlFor="dropshipWebhookUrl">URL webhooka</Label>
+              <Input
+                id="dropshipWebhookUrl"
+                value={webhookUrl}
+                onChange={(e) => setWebhookUrl(e.target.value)}
+                placeholder="https://api.dostawca.pl/orders"
+              />
+            </div>
+            <div className="space-y-2">
+              <Label htmlFor="dropshipWebhookSecret">Sekret podpisu (opcjonalnie)</Label>
+              <Input
+                id="dropshipWebhookSecret"
+                type="password"
+                value={webhookSecret}
+                onChange={(e) => setWebhookSecret(e.target.value)}
+              />
+            </div>
+          </div>
+        )}
+
+        {channel !== NO_CHANNEL && (
+          <p className="text-sm text-muted-foreground">
+            {channel === "email"
+              ? "Wiadomość zawiera link, pod którym dostawca wpisuje numer przesyłki."
+              : "Żądanie zawiera callback_url, na który dostawca odsyła przewoźnika i numer przesyłki."}
+          </p>
+        )}
+
+        <Button onClick={handleSave} disabled={updateSupplier.isPending}>
+          {updateSupplier.isPending ? "Zapisywanie..." : "Zapisz"}
+        </Button>
+      </CardContent>
+    </Card>
+  );
+}
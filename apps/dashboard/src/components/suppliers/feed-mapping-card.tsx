@@ -0,0 +1,302 @@
+"use client";
+
+import { useState, useEffect } from "react";
+import { toast } from "sonner";
+import { Plus, Trash2, Eye } from "lucide-react";
+import {
+  usePreviewSupplierFeed,
+  useUpdateSupplier,
+} from "@/hooks/use-suppliers";
+import { getErrorMessage } from "@/lib/api-client";
+import { formatCurrency } from "@/lib/utils";
+import { Button } from "@/components/ui/button";
+import { Input } from "@/components/ui/input";
+import { Label } from "@/components/ui/label";
+import { Switch } from "@/components/ui/switch";
+import {
+  Select,
+  SelectContent,
+  SelectItem,
+  SelectTrigger,
+  SelectValue,
+} from "@/components/ui/select";
+import {
+  Card,
+  CardContent,
+  CardDescription,
+  CardHeader,
+  CardTitle,
+} from "@/components/ui/card";
+import {
+  Table,
+  TableBody,
+  TableCell,
+  TableHead,
+  TableHeader,
+  TableRow,
+} from "@/components/ui/table";
+import { Badge } from "@/components/ui/badge";
+import type {
+  Supplier,
+  SupplierFeedMapping,
+  SupplierFeedSettings,
+  SupplierFeedPreview,
+} from "@/types/api";
+
+const FEED_FIELDS = [
+  { value: "external_id", label: "ID zewnętrzne" },
+  { value: "name", label: "Nazwa" },
+  { value: "ean", label: "EAN" },
+  { value: "sku", label: "SKU" },
+  { value: "price", label: "Cena" },
+  { value: "stock", label: "Stan" },
+  { value: "description", label: "Opis" },
+  { value: "category", label: "Kategoria" },
+  { value: "image_url", label: "URL zdjęcia" },
+  { value: "weight", label: "Waga" },
+];
+
+const AUTO_DELIMITER = "auto";
+const TAB = "\t";
+
+interface FeedMappingCardProps {
+  supplier: Supplier;
+  feedUrl: string;
+  feedFormat: string;
+}
+
+export function FeedMappingCard({ supplier, feedUrl, feedFormat }: FeedMappingCardProps) {
+  const updateSupplier = useUpdateSupplier(supplier.id);
+  const previewFeed = usePreviewSupplierFeed(supplier.id);
+  const isCSV = feedFormat === "csv";
+
+  const [delimiter, setDelimiter] = useState(AUTO_DELIMITER);
+  const [encoding, setEncoding] = useState("utf-8");
+  const [noHeader, setNoHeader] = useState(false);
+  const [itemPath, setItemPath] = useState("");
+  const [mappings, setMappings] = useState<SupplierFeedMapping[]>([]);
+  const [preview, setPreview] = useState<SupplierFeedPreview | null>(null);
+
+  useEffect(() => {
+    const feed = (supplier.settings?.feed ?? {}) as SupplierFeedSettings;
+    setDelimiter(feed.csv?.delimiter || AUTO_DELIMITER);
+    setEncoding(feed.csv?.encoding || "utf-8");
+    setNoHeader(feed.csv?.no_header ?? false);
+    setItemPath(feed.xml?.item_path ?? "");
+    setMappings((isCSV ? feed.csv?.mappings : feed.xml?.mappings) ?? [
+      { source: "", field: "external_id" },
+      { source: "", field: "name" },
+    ]);
+    setPreview(null);
+  }, [supplier, isCSV]);
+
+  const buildSettings = () => {
+    const feed: SupplierFeedSettings = {
+      ...((supplier.settings?.feed ?? {}) as SupplierFeedSettings),
+    };
+    if (isCSV) {
+      feed.csv = {
+        delimiter: delimiter === AUTO_DELIMITER ? undefined : delimiter,
+        encoding,
+        no_header: noHeader,
+        mappings,
+      };
+    } else {
+      feed.xml = { item_path: itemPath, mappings };
+    }
+    return { ...supplier.settings, feed };
+  };
+
+  const updateMapping = (index: number, patch: Partial<SupplierFeedMapping>) => {
+    setMappings((prev) => prev.map((m, i) => (i === index ? { ...m, ...patch } : m)));
+  };
+
+  const handleSave = () => {
+    updateSupplier.mutate(
+      { settings: buildSettings() },
+      {
+        onSuccess: () => toast.success("Mapowanie feeda zapisane"),
+        onError: (error) => toast.error(getErrorMessage(error)),
+      }
+    );
+  };
+
+  const handlePreview = () => {
+    previewFeed.mutate(
+      {
+        feed_url: feedUrl || undefined,
+        feed_format: feedFormat,
+        settings: buildSettings(),
+      },
+      {
+        onSuccess: (data) => setPreview(data),
+        onError: (error) => toast.error(getErrorMessage(error)),
+      }
+    );
+  };
+
+  return (
+    <Card>
+      <CardHeader>
+        <CardTitle>Mapowanie feeda {isCSV ? "CSV" : "XML"}</CardTitle>
+        <CardDescription>
+          {isCSV
+            ? "Przypisz kolumny pliku CSV do pól produktu dostawcy"
+            : "Wskaż elementy produktów i ścieżki XPath ich pól, np. //product, @id, price[@type='gross']"}
+        </CardDescription>
+      </CardHeader>
+      <CardContent className="space-y-4">
+        {isCSV ? (
+          <div className="grid gap-4 md:grid-cols-3">
+            <div className="space-y-2">
+              <Label>Separator</Label>
+              <Select value={delimiter} onValueChange={setDelimiter}>
+                <SelectTrigger><SelectValue /></SelectTrigger>
+                <SelectContent>
+                  <SelectItem value={AUTO_DELIMITER}>Wykryj automatycznie</SelectItem>
+                  <SelectItem value=";">Średnik (;)</SelectItem>
+                  <SelectItem value=",">Przecinek (,)</SelectItem>
+                  <SelectItem value={TAB}>Tabulator</SelectItem>
+                  <SelectItem value="|">Pionowa kreska (|)</SelectItem>
+                </SelectContent>
+              </Select>
+            </div>
+            <div className="space-y-2">
+              <Label>Kodowanie</Label>
+              <Select value={encoding} onValueChange={setEncoding}>
+                <SelectTrigger><SelectValue /></SelectTrigger>
+                <SelectContent>
+                  <SelectItem value="utf-8">UTF-8</SelectItem>
+                  <SelectItem value="cp1250">Windows-1250 (CP1250)</SelectItem>
+                  <SelectItem value="iso-8859-2">ISO-8859-2</SelectItem>
+                </SelectContent>
+              </Select>
+            </div>
+            <div className="flex items-center gap-2 pt-8">
+              <Switch id="noHeader" checked={noHeader} onCheckedChange={setNoHeader} />
+              <Label htmlFor="noHeader">Brak nagłówka (numery kolumn)</Label>
+            </div>
+          </div>
+        ) : (
+          <div className="space-y-2">
+            <Label htmlFor="itemPath">Ścieżka produktów</Label>
+            <Input
+              id="itemPath"
+              value={itemPath}
+              onChange={(e) => setItemPath(e.target.value)}
+              placeholder="//product"
+            />
+          </div>
+        )}
+
+        <div className="space-y-2">
+          <Label>Mapowanie pól</Label>
+          {mappings.map((m, i) => (
+            <div key={i} className="flex gap-2">
+              <Input
+                value={m.source}
+                onChange={(e) => updateMapping(i, { source: e.target.value })}
+                placeholder={isCSV ? (noHeader ? "Nr kolumny, np. 1" : "Nazwa kolumny") : "XPath, np. name"}
+                list={isCSV && preview?.columns ? "feed-columns" : undefined}
+              />
+              <Input
+                className="w-56"
+                value={m.field}
+                onChange={(e) => updateMapping(i, { field: e.target.value })}
+                placeholder="pole lub attr:nazwa"
+                list="feed-fields"
+              />
+              <Button
+                variant="ghost"
+                size="icon"
+                onClick={() => setMappings((prev) => prev.filter((_, j) => j !== i))}
+              >
+                <Trash2 className="h-4 w-4" />
+              </Button>
+            </div>
+          ))}
+          <datalist id="feed-fields">
+            {FEED_FIELDS.map((f) => (
+              <option key={f.value} value={f.value}>{f.label}</option>
+            ))}
+          </datalist>
+          {preview?.columns && (
+            <datalist id="feed-columns">
+              {preview.columns.map((c) => (
+                <option key={c} value={c} />
+              ))}
+            </datalist>
+          )}
+          <Button
+            variant="outline"
+            size="sm"
+            onClick={() => setMappings((prev) => [...prev, { source: "", field: "" }])}
+          >
+            <Plus className="h-4 w-4 mr-1" />
+            Dodaj pole
+          </Button>
+        </div>
+
+        <div className="flex justify-end gap-2">
+          <Button variant="outline" onClick={handlePreview} disabled={previewFeed.isPending}>
+            <Eye className="h-4 w-4 mr-2" />
+            {previewFeed.isPending ? "Wczytywanie..." : "Podgląd"}
+          </Button>
+          <Button onClick={handleSave} disabled={updateSupplier.isPending}>
+            {updateSupplier.isPending ? "Zapisywanie..." : "Zapisz mapowanie"}
+          </Button>
+        </div>
+
+        {preview && (
+          <div className="space-y-3 border-t pt-4">
+            <p className="text-sm text-muted-foreground">
+              Odczytano produktów: {preview.total_products}
+              {preview.total_products > preview.products.length &&
+                ` (pokazano pierwsze ${preview.products.length})`}
+            </p>
+            {preview.columns && preview.columns.length > 0 && (
+              <div className="flex flex-wrap gap-1">
+                {preview.columns.map((c) => (
+                  <Badge key={c} variant="outline">{c}</Badge>
+                ))}
+              </div>
+            )}
+            {preview.warnings.length > 0 && (
+              <div className="rounded-md border border-yellow-500/50 bg-yellow-500/10 p-3 text-sm space-y-1">
+                {preview.warnings.map((w, i) => (
+                  <p key={i}>{w}</p>
+                ))}
+              </div>
+            )}
+            {preview.products.length > 0 && (
+              <Table>
+                <TableHeader>
+                  <TableRow>
+                    <TableHead>ID</TableHead>
+                    <TableHead>Nazwa</TableHead>
+                    <TableHead>EAN</TableHead>
+                    <TableHead>SKU</TableHead>
+                    <TableHead className="text-right">Cena</TableHead>
+                    <TableHead className="text-right">Stan</TableHead>
+                  </TableRow>
+                </TableHeader>
+                <TableBody>
+                  {preview.products.map((p) => (
+                    <TableRow key={p.external_id}>
+                      <TableCell className="font-mono text-xs">{p.external_id}</TableCell>
+                      <TableCell className="max-w-[250px] truncate">{p.name}</TableCell>
+                      <TableCell>{p.ean || "---"}</TableCell>
+                      <TableCell>{p.sku || "---"}</TableCell>
+                      <TableCell className="text-right">{formatCurrency(p.price)}</TableCell>
+                      <TableCell className="text-right">{p.stock}</TableCell>
+                    </TableRow>
+                  ))}
+                </TableBody>
+              </Table>
+            )}
+          </div>
+        )}
+      </CardContent>
+    </Card>
+  );
+}
@@ -1618,6 +1618,32 @@ export function useCreateProductListing(productId: string) {
   });
 }
 
+export interface CreateVariantListingsRequest extends Omit<CreateProductListingRequest, "price_override" | "stock_override"> {
+  set_name?: string;
+  variant_parameter_ids: string[];
+  variants: {
+    variant_id: string;
+    parameters?: { id: string; valuesIds?: string[]; values?: string[] }[];
+    color_pattern?: string;
+    price_override?: number;
+    stock_override?: number;
+  }[];
+}
+
+export function useCreateVariantListings(productId: string) {
+  const queryClient = useQueryClient();
+  return useMutation({
+    mutationFn: (data: CreateVariantListingsRequest) =>
+      apiClient<{ variant_set_id?: string; listings: ProductListing[] }>(
+        `/v1/products/${productId}/listings/allegro/variants`,
+        { method: "POST", body: JSON.stringify(data) }
+      ),
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey: ["products", productId, "listings"] });
+    },
+  });
+}
+
 export function useDeleteProductListing(productId: string) {
   const queryClient = useQueryClient();
   return useMutation({
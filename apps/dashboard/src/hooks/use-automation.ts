@@ -1,6 +1,8 @@
 import { useQuery, useMutation, useQueryClient } from "@tanstack/react-query";
 import { apiClient } from "@/lib/api-client";
 import type {
+  AutomationEvent,
+  AutomationEventListParams,
   AutomationRule,
   AutomationRuleLog,
   DelayedAction,
@@ -114,3 +116,34 @@ export function useDelayedActions() {
     queryFn: () => apiClient<DelayedAction[]>("/v1/automation/delayed"),
   });
 }
+
+export function useAutomationEvents(params: AutomationEventListParams = {}) {
+  const query = new URLSearchParams();
+  if (params.status) query.set("status", params.status);
+  if (params.limit != null) query.set("limit", String(params.limit));
+  if (params.offset != null) query.set("offset", String(params.offset));
+
+  const qs = query.toString();
+
+  return useQuery({
+    queryKey: ["automation-events", params],
+    queryFn: () =>
+      apiClient<ListResponse<AutomationEvent>>(
+        `/v1/automation/events${qs ? `?${qs}` : ""}`
+      ),
+  });
+}
+
+export function useRetryAutomationEvent() {
+  const queryClient = useQueryClient();
+
+  return useMutation({
+    mutationFn: (id: string) =>
+      apiClient<AutomationEvent>(`/v1/automation/events/${id}/retry`, {
+        method: "POST",
+      }),
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey: ["automation-events"] });
+    },
+  });
+}
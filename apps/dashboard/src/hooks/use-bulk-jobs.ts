@@ -0,0 +1,42 @@
+"use client";
+
+import { useQuery, useMutation, useQueryClient } from "@tanstack/react-query";
+import { apiClient } from "@/lib/api-client";
+import type { BulkJob, CreateBulkJobRequest, ListResponse } from "@/types/api";
+
+function isActive(job: BulkJob): boolean {
+  return job.status === "pending" || job.status === "running";
+}
+
+export function useBulkJobs(limit = 5) {
+  return useQuery({
+    queryKey: ["bulk-jobs", limit],
+    queryFn: () =>
+      apiClient<ListResponse<BulkJob>>(`/v1/orders/bulk-jobs?limit=${limit}`),
+    // Progress arrives over the WebSocket; polling covers a lost connection.
+    refetchInterval: (query) =>
+      query.state.data?.items.some(isActive) ? 5000 : false,
+  });
+}
+
+export function useBulkJob(id: string) {
+  return useQuery({
+    queryKey: ["bulk-jobs", "detail", id],
+    queryFn: () => apiClient<BulkJob>(`/v1/orders/bulk-jobs/${encodeURIComponent(id)}`),
+    enabled: !!id,
+  });
+}
+
+export function useCreateBulkJob() {
+  const queryClient = useQueryClient();
+  return useMutation({
+    mutationFn: (data: CreateBulkJobRequest) =>
+      apiClient<BulkJob>("/v1/orders/bulk-jobs", {
+        method: "POST",
+        body: JSON.stringify(data),
+      }),
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey: ["bulk-jobs"] });
+    },
+  });
+}
@@ -0,0 +1,126 @@
+import { useQuery, useMutation, useQueryClient } from "@tanstack/react-query";
+import { apiClient } from "@/lib/api-client";
+import type {
+  ListResponse,
+  FulfillmentRule,
+  SetFulfillmentRuleRequest,
+  SupplierOrder,
+  SupplierOrderListParams,
+  SupplierTrackingRequest,
+  DropshipRouting,
+} from "@/types/api";
+
+export function useFulfillmentRule(productId: string) {
+  return useQuery({
+    queryKey: ["fulfillment-rule", productId],
+    queryFn: async () => {
+      try {
+        return await apiClient<FulfillmentRule>(`/v1/products/${productId}/fulfillment-rule`);
+      } catch {
+        return null;
+      }
+    },
+    enabled: !!productId,
+  });
+}
+
+export function useSetFulfillmentRule(productId: string) {
+  const queryClient = useQueryClient();
+  return useMutation({
+    mutationFn: (data: SetFulfillmentRuleRequest) =>
+      apiClient<FulfillmentRule>(`/v1/products/${productId}/fulfillment-rule`, {
+        method: "PUT",
+        body: JSON.stringify(data),
+      }),
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey: ["fulfillment-rule", productId] });
+    },
+  });
+}
+
+export function useDeleteFulfillmentRule(productId: string) {
+  const queryClient = useQueryClient();
+  return useMutation({
+    mutationFn: () =>
+      apiClient<void>(`/v1/products/${productId}/fulfillment-rule`, { method: "DELETE" }),
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey: ["fulfillment-rule", productId] });
+    },
+  });
+}
+
+export function useSupplierOrders(params: SupplierOrderListParams = {}) {
+  const query = new URLSearchParams();
+  if (params.limit != null) query.set("limit", String(params.limit));
+  if (params.offset != null) query.set("offset", String(params.offset));
+  if (params.status) query.set("status", params.status);
+  if (params.supplier_id) query.set("supplier_id", params.supplier_id);
+  if (params.order_id) query.set("order_id", params.order_id);
+  if (params.sort_by) query.set("sort_by", params.sort_by);
+  if (params.sort_order) query.set("sort_order", params.sort_order);
+
+  const qs = query.toString();
+
+  return useQuery({
+    queryKey: ["supplier-orders", params],
+    queryFn: () =>
+      apiClient<ListResponse<SupplierOrder>>(`/v1/supplier-orders${qs ? `?${qs}` : ""}`),
+  });
+}
+
+export function useOrderSupplierOrders(orderId: string) {
+  return useQuery({
+    queryKey: ["supplier-orders", "order", orderId],
+    queryFn: () => apiClient<SupplierOrder[]>(`/v1/orders/${orderId}/supplier-orders`),
+    enabled: !!orderId,
+  });
+}
+
+export function useResendSupplierOrder() {
+  const queryClient = useQueryClient();
+  return useMutation({
+    mutationFn: (id: string) =>
+      apiClient<SupplierOrder>(`/v1/supplier-orders/${id}/send`, { method: "POST" }),
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey: ["supplier-orders"] });
+    },
+  });
+}
+
+export function useRecordSupplierTracking() {
+  const queryClient = useQueryClient();
+  return useMutation({
+    mutationFn: ({ id, data }: { id: string; data: SupplierTrackingRequest }) =>
+      apiClient<SupplierOrder>(`/v1/supplier-orders/${id}/tracking`, {
+        method: "POST",
+        body: JSON.stringify(data),
+      }),
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey: ["supplier-orders"] });
+      queryClient.invalidateQueries({ queryKey: ["shipments"] });
+    },
+  });
+}
+
+export function useCancelSupplierOrder() {
+  const queryClient = useQueryClient();
+  return useMutation({
+    mutationFn: (id: string) =>
+      apiClient<SupplierOrder>(`/v1/supplier-orders/${id}/cancel`, { method: "POST" }),
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey: ["supplier-orders"] });
+    },
+  });
+}
+
+export function useRouteOrderToSuppliers(orderId: string) {
+  const queryClient = useQueryClient();
+  return useMutation({
+    mutationFn: () =>
+      apiClient<DropshipRouting>(`/v1/orders/${orderId}/dropship`, { method: "POST" }),
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey: ["supplier-orders"] });
+      queryClient.invalidateQueries({ queryKey: ["orders", orderId] });
+    },
+  });
+}
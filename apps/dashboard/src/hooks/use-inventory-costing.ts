@@ -0,0 +1,65 @@
+"use client";
+
+import { useQuery } from "@tanstack/react-query";
+import { apiClient, apiFetch } from "@/lib/api-client";
+import type {
+  COGSParams,
+  COGSReport,
+  InventoryValuationParams,
+  InventoryValuationReport,
+} from "@/types/api";
+
+function searchParams(params: InventoryValuationParams | COGSParams) {
+  const query = new URLSearchParams();
+  Object.entries(params).forEach(([key, value]) => {
+    if (value) query.set(key, value);
+  });
+  return query.toString();
+}
+
+async function download(path: string, filename: string) {
+  const response = await apiFetch(path);
+
+  const blob = await response.blob();
+  const url = URL.createObjectURL(blob);
+  const a = document.createElement("a");
+  a.href = url;
+  a.download = filename;
+  document.body.appendChild(a);
+  a.click();
+  document.body.removeChild(a);
+  URL.revokeObjectURL(url);
+}
+
+export function useInventoryValuation(params: InventoryValuationParams) {
+  return useQuery({
+    queryKey: ["inventory", "valuation", params],
+    queryFn: () =>
+      apiClient<InventoryValuationReport>(
+        `/v1/inventory/valuation?${searchParams(params)}`
+      ),
+  });
+}
+
+export function useCOGS(params: COGSParams) {
+  return useQuery({
+    queryKey: ["inventory", "cogs", params],
+    queryFn: () =>
+      apiClient<COGSReport>(`/v1/inventory/cogs?${searchParams(params)}`),
+  });
+}
+
+export async function exportInventoryValuationCSV(params: InventoryValuationParams) {
+  const date = params.at ?? new Date().toISOString().slice(0, 10);
+  await download(
+    `/v1/inventory/valuation/export?${searchParams(params)}`,
+    `wycena-magazynu-${date}.csv`
+  );
+}
+
+export async function exportCOGSCSV(params: COGSParams) {
+  await download(
+    `/v1/inventory/cogs/export?${searchParams(params)}`,
+    `koszt-sprzedazy-${params.group_by}-${new Date().toISOString().slice(0, 10)}.csv`
+  );
+}
@@ -0,0 +1,66 @@
+"use client";
+
+import { useQuery, useMutation, useQueryClient } from "@tanstack/react-query";
+import { apiClient } from "@/lib/api-client";
+import type {
+  OrderHold,
+  HeldOrder,
+  HeldOrderListParams,
+  ListResponse,
+  PlaceHoldRequest,
+  ReleaseHoldRequest,
+} from "@/types/api";
+
+export function useHeldOrders(params: HeldOrderListParams = {}) {
+  const query = new URLSearchParams();
+  if (params.limit != null) query.set("limit", String(params.limit));
+  if (params.offset != null) query.set("offset", String(params.offset));
+  if (params.reason) query.set("reason", params.reason);
+  if (params.source) query.set("source", params.source);
+
+  const qs = query.toString();
+
+  return useQuery({
+    queryKey: ["order-holds", params],
+    queryFn: () =>
+      apiClient<ListResponse<HeldOrder>>(`/v1/order-holds${qs ? `?${qs}` : ""}`),
+  });
+}
+
+export function useOrderHolds(orderId: string) {
+  return useQuery({
+    queryKey: ["order-holds", "order", orderId],
+    queryFn: () => apiClient<OrderHold[]>(`/v1/orders/${orderId}/holds`),
+    enabled: !!orderId,
+  });
+}
+
+export function usePlaceHold(orderId: string) {
+  const queryClient = useQueryClient();
+  return useMutation({
+    mutationFn: (data: PlaceHoldRequest) =>
+      apiClient<OrderHold>(`/v1/orders/${orderId}/holds`, {
+        method: "POST",
+        body: JSON.stringify(data),
+      }),
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey: ["order-holds"] });
+      queryClient.invalidateQueries({ queryKey: ["orders", orderId, "timeline"] });
+    },
+  });
+}
+
+export function useReleaseHold(orderId: string) {
+  const queryClient = useQueryClient();
+  return useMutation({
+    mutationFn: ({ holdId, ...data }: ReleaseHoldRequest & { holdId: string }) =>
+      apiClient<OrderHold>(`/v1/orders/${orderId}/holds/${holdId}/release`, {
+        method: "POST",
+        body: JSON.stringify(data),
+      }),
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey: ["order-holds"] });
+      queryClient.invalidateQueries({ queryKey: ["orders", orderId, "timeline"] });
+    },
+  });
+}
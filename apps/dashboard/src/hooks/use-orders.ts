@@ -2,6 +2,7 @@
 
 import { useQuery, useMutation, useQueryClient } from "@tanstack/react-query";
 import { apiClient, apiFetch } from "@/lib/api-client";
+import { orderFilterSearchParams } from "@/lib/order-filters";
 import type {
   Order,
   ListResponse,
@@ -10,19 +11,20 @@ import type {
   UpdateOrderRequest,
   StatusTransitionRequest,
   AuditLogEntry,
+  TimelineEntry,
+  TimelineParams,
+  BulkStatusTransitionRequest,
   BulkStatusTransitionResponse,
+  OrderFilterParams,
+  OrderView,
+  CreateOrderViewRequest,
+  UpdateOrderViewRequest,
 } from "@/types/api";
 
 export function useOrders(params: OrderListParams = {}) {
-  const searchParams = new URLSearchParams();
+  const searchParams = orderFilterSearchParams(params);
   if (params.limit != null) searchParams.set("limit", String(params.limit));
   if (params.offset != null) searchParams.set("offset", String(params.offset));
-  if (params.status) searchParams.set("status", params.status);
-  if (params.source) searchParams.set("source", params.source);
-  if (params.search) searchParams.set("search", params.search);
-  if (params.payment_status) searchParams.set("payment_status", params.payment_status);
-  if (params.tag) searchParams.set("tag", params.tag);
-  if (params.priority) searchParams.set("priority", params.priority);
   if (params.sort_by) searchParams.set("sort_by", params.sort_by);
   if (params.sort_order) searchParams.set("sort_order", params.sort_order);
 
@@ -92,7 +94,7 @@ export function useTransitionOrderStatus(id: string) {
 export function useBulkTransitionStatus() {
   const queryClient = useQueryClient();
   return useMutation({
-    mutationFn: (data: { order_ids: string[]; status: string; force?: boolean }) =>
+    mutationFn: (data: BulkStatusTransitionRequest) =>
       apiClient<BulkStatusTransitionResponse>("/v1/orders/bulk-status", {
         method: "POST",
         body: JSON.stringify(data),
@@ -111,6 +113,23 @@ export function useOrderAudit(id: string) {
   });
 }
 
+export function useOrderTimeline(id: string, params: TimelineParams = {}) {
+  const searchParams = new URLSearchParams();
+  if (params.source && params.source.length > 0) searchParams.set("source", params.source.join(","));
+  if (params.type) searchParams.set("type", params.type);
+  if (params.from) searchParams.set("from", params.from);
+  if (params.to) searchParams.set("to", params.to);
+  if (params.limit != null) searchParams.set("limit", String(params.limit));
+
+  const query = searchParams.toString();
+  return useQuery({
+    queryKey: ["orders", id, "timeline", params],
+    queryFn: () =>
+      apiClient<TimelineEntry[]>(`/v1/orders/${id}/timeline${query ? `?${query}` : ""}`),
+    enabled: !!id,
+  });
+}
+
 export function useDuplicateOrder() {
   const queryClient = useQueryClient();
   return useMutation({
@@ -122,13 +141,8 @@ export function useDuplicateOrder() {
   });
 }
 
-export async function exportOrdersCSV(params: OrderListParams) {
-  const searchParams = new URLSearchParams();
-  if (params.status) searchParams.set("status", params.status);
-  if (params.source) searchParams.set("source", params.source);
-  if (params.search) searchParams.set("search", params.search);
-  if (params.payment_status) searchParams.set("payment_status", params.payment_status);
-  if (params.tag) searchParams.set("tag", params.tag);
+export async function exportOrdersCSV(params: OrderFilterParams) {
+  const searchParams = orderFilterSearchParams(params);
 
   const response = await apiFetch(`/v1/orders/export?${searchParams}`);
 
@@ -142,3 +156,51 @@ export async function exportOrdersCSV(params: OrderListParams) {
   document.body.removeChild(a);
   URL.revokeObjectURL(url);
 }
+
+// --- Saved views ---
+
+export function useOrderViews() {
+  return useQuery({
+    queryKey: ["order-views"],
+    queryFn: () => apiClient<OrderView[]>("/v1/orders/views"),
+  });
+}
+
+export function useCreateOrderView() {
+  const queryClient = useQueryClient();
+  return useMutation({
+    mutationFn: (data: CreateOrderViewRequest) =>
+      apiClient<OrderView>("/v1/orders/views", {
+        method: "POST",
+        body: JSON.stringify(data),
+      }),
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey: ["order-views"] });
+    },
+  });
+}
+
+export function useUpdateOrderView() {
+  const queryClient = useQueryClient();
+  return useMutation({
+    mutationFn: ({ id, data }: { id: string; data: UpdateOrderViewRequest }) =>
+      apiClient<OrderView>(`/v1/orders/views/${id}`, {
+        method: "PATCH",
+        body: JSON.stringify(data),
+      }),
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey: ["order-views"] });
+    },
+  });
+}
+
+export function useDeleteOrderView() {
+  const queryClient = useQueryClient();
+  return useMutation({
+    mutationFn: (id: string) =>
+      apiClient<void>(`/v1/orders/views/${id}`, { method: "DELETE" }),
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey: ["order-views"] });
+    },
+  });
+}
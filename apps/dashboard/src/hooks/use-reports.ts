@@ -1,8 +1,14 @@
 "use client";
 
 import { useQuery } from "@tanstack/react-query";
-import { apiClient } from "@/lib/api-client";
-import type { TopProduct, SourceRevenue, DailyOrderTrend } from "@/types/api";
+import { apiClient, apiFetch } from "@/lib/api-client";
+import type {
+  TopProduct,
+  SourceRevenue,
+  DailyOrderTrend,
+  ProfitabilityParams,
+  ProfitabilityReport,
+} from "@/types/api";
 
 export function useTopProducts(limit = 10) {
   return useQuery({
@@ -35,3 +41,37 @@ export function usePaymentMethodStats() {
       apiClient<Record<string, number>>(`/v1/stats/payment-methods`),
   });
 }
+
+function profitabilitySearchParams(params: ProfitabilityParams) {
+  const searchParams = new URLSearchParams();
+  Object.entries(params).forEach(([key, value]) => {
+    if (value) searchParams.set(key, value);
+  });
+  return searchParams.toString();
+}
+
+export function useProfitability(params: ProfitabilityParams) {
+  return useQuery({
+    queryKey: ["stats", "profitability", params],
+    queryFn: () =>
+      apiClient<ProfitabilityReport>(
+        `/v1/stats/profitability?${profitabilitySearchParams(params)}`
+      ),
+  });
+}
+
+export async function exportProfitabilityCSV(params: ProfitabilityParams) {
+  const response = await apiFetch(
+    `/v1/stats/profitability/export?${profitabilitySearchParams(params)}`
+  );
+
+  const blob = await response.blob();
+  const url = URL.createObjectURL(blob);
+  const a = document.createElement("a");
+  a.href = url;
+  a.download = `rentowność-${params.group_by}-${new Date().toISOString().slice(0, 10)}.csv`;
+  document.body.appendChild(a);
+  a.click();
+  document.body.removeChild(a);
+  URL.revokeObjectURL(url);
+}
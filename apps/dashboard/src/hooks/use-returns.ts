@@ -7,6 +7,9 @@ import type {
   CreateReturnRequest,
   UpdateReturnRequest,
   ReturnStatusRequest,
+  ReceiveReturnRequest,
+  RefundReturnRequest,
+  ExchangeReturnRequest,
 } from "@/types/api";
 
 export function useReturns(params: ReturnListParams = {}) {
@@ -81,6 +84,70 @@ export function useTransitionReturnStatus(id: string) {
   });
 }
 
+export function useReceiveReturn(id: string) {
+  const queryClient = useQueryClient();
+
+  return useMutation({
+    mutationFn: (data: ReceiveReturnRequest) =>
+      apiClient<Return>(`/v1/returns/${id}/receive`, {
+        method: "POST",
+        body: JSON.stringify(data),
+      }),
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey: ["returns"] });
+      queryClient.invalidateQueries({ queryKey: ["returns", id] });
+    },
+  });
+}
+
+export function useRefundReturn(id: string) {
+  const queryClient = useQueryClient();
+
+  return useMutation({
+    mutationFn: (data: RefundReturnRequest) =>
+      apiClient<Return>(`/v1/returns/${id}/refund`, {
+        method: "POST",
+        body: JSON.stringify(data),
+      }),
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey: ["returns"] });
+      queryClient.invalidateQueries({ queryKey: ["returns", id] });
+    },
+  });
+}
+
+export function useExchangeReturn(id: string) {
+  const queryClient = useQueryClient();
+
+  return useMutation({
+    mutationFn: (data: ExchangeReturnRequest) =>
+      apiClient<Return>(`/v1/returns/${id}/exchange`, {
+        method: "POST",
+        body: JSON.stringify(data),
+      }),
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey: ["returns"] });
+      queryClient.invalidateQueries({ queryKey: ["returns", id] });
+      queryClient.invalidateQueries({ queryKey: ["orders"] });
+    },
+  });
+}
+
+export function useCreateReturnLabel(id: string) {
+  const queryClient = useQueryClient();
+
+  return useMutation({
+    mutationFn: () =>
+      apiClient<Return>(`/v1/returns/${id}/label`, {
+        method: "POST",
+      }),
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey: ["returns"] });
+      queryClient.invalidateQueries({ queryKey: ["returns", id] });
+    },
+  });
+}
+
 export function useDeleteReturn() {
   const queryClient = useQueryClient();
 
@@ -0,0 +1,79 @@
+import { useQuery, useMutation, useQueryClient } from "@tanstack/react-query";
+import { apiClient } from "@/lib/api-client";
+import type {
+  ListResponse,
+  ScheduledReport,
+  ScheduledReportRun,
+  CreateScheduledReportRequest,
+  UpdateScheduledReportRequest,
+} from "@/types/api";
+
+export function useScheduledReports() {
+  return useQuery({
+    queryKey: ["scheduled-reports"],
+    queryFn: () => apiClient<ScheduledReport[]>("/v1/scheduled-reports"),
+  });
+}
+
+export function useScheduledReportRuns(reportId: string | null, limit = 20) {
+  return useQuery({
+    queryKey: ["scheduled-reports", reportId, "runs", limit],
+    queryFn: () =>
+      apiClient<ListResponse<ScheduledReportRun>>(
+        `/v1/scheduled-reports/${reportId}/runs?limit=${limit}`
+      ),
+    enabled: !!reportId,
+  });
+}
+
+export function useCreateScheduledReport() {
+  const queryClient = useQueryClient();
+  return useMutation({
+    mutationFn: (data: CreateScheduledReportRequest) =>
+      apiClient<ScheduledReport>("/v1/scheduled-reports", {
+        method: "POST",
+        body: JSON.stringify(data),
+      }),
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey: ["scheduled-reports"] });
+    },
+  });
+}
+
+export function useUpdateScheduledReport(id: string) {
+  const queryClient = useQueryClient();
+  return useMutation({
+    mutationFn: (data: UpdateScheduledReportRequest) =>
+      apiClient<ScheduledReport>(`/v1/scheduled-reports/${id}`, {
+        method: "PATCH",
+        body: JSON.stringify(data),
+      }),
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey: ["scheduled-reports"] });
+    },
+  });
+}
+
+export function useDeleteScheduledReport() {
+  const queryClient = useQueryClient();
+  return useMutation({
+    mutationFn: (id: string) =>
+      apiClient<void>(`/v1/scheduled-reports/${id}`, { method: "DELETE" }),
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey: ["scheduled-reports"] });
+    },
+  });
+}
+
+export function useRunScheduledReport() {
+  const queryClient = useQueryClient();
+  return useMutation({
+    mutationFn: (id: string) =>
+      apiClient<ScheduledReportRun>(`/v1/scheduled-reports/${id}/run`, {
+        method: "POST",
+      }),
+    onSuccess: (_, id) => {
+      queryClient.invalidateQueries({ queryKey: ["scheduled-reports", id, "runs"] });
+    },
+  });
+}
@@ -2,7 +2,7 @@
 
 import { useQuery, useMutation, useQueryClient } from "@tanstack/react-query";
 import { apiClient } from "@/lib/api-client";
-import type { EmailSettings, CompanySettings, OrderStatusConfig, CustomFieldsConfig, InventorySettings } from "@/types/api";
+import type { EmailSettings, CompanySettings, OrderStatusConfig, CustomFieldsConfig, InventorySettings, ReturnSettings, DropshipSettings, OrderHoldSettings, OrderRiskSettings } from "@/types/api";
 
 export function useEmailSettings() {
   return useQuery({
@@ -104,3 +104,87 @@ export function useUpdateInventorySettings() {
     },
   });
 }
+
+export function useDropshipSettings() {
+  return useQuery({
+    queryKey: ["settings", "dropshipping"],
+    queryFn: () => apiClient<DropshipSettings>("/v1/settings/dropshipping"),
+  });
+}
+
+export function useUpdateDropshipSettings() {
+  const queryClient = useQueryClient();
+  return useMutation({
+    mutationFn: (data: DropshipSettings) =>
+      apiClient<DropshipSettings>("/v1/settings/dropshipping", {
+        method: "PUT",
+        body: JSON.stringify(data),
+      }),
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey: ["settings", "dropshipping"] });
+    },
+  });
+}
+
+export function useReturnSettings() {
+  return useQuery({
+    queryKey: ["settings", "returns"],
+    queryFn: () => apiClient<ReturnSettings>("/v1/settings/returns"),
+  });
+}
+
+export function useUpdateReturnSettings() {
+  const queryClient = useQueryClient();
+  return useMutation({
+    mutationFn: (data: ReturnSettings) =>
+      apiClient<ReturnSettings>("/v1/settings/returns", {
+        method: "PUT",
+        body: JSON.stringify(data),
+      }),
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey: ["settings", "returns"] });
+    },
+  });
+}
+
+export function useOrderHoldSettings() {
+  return useQuery({
+    queryKey: ["settings", "order-holds"],
+    queryFn: () => apiClient<OrderHoldSettings>("/v1/settings/order-holds"),
+  });
+}
+
+export function useUpdateOrderHoldSettings() {
+  const queryClient = useQueryClient();
+  return useMutation({
+    mutationFn: (data: OrderHoldSettings) =>
+      apiClient<OrderHoldSettings>("/v1/settings/order-holds", {
+        method: "PUT",
+        body: JSON.stringify(data),
+      }),
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey: ["settings", "order-holds"] });
+    },
+  });
+}
+
+export function useOrderRiskSettings() {
+  return useQuery({
+    queryKey: ["settings", "order-risk"],
+    queryFn: () => apiClient<OrderRiskSettings>("/v1/settings/order-risk"),
+  });
+}
+
+export function useUpdateOrderRiskSettings() {
+  const queryClient = useQueryClient();
+  return useMutation({
+    mutationFn: (data: OrderRiskSettings) =>
+      apiClient<OrderRiskSettings>("/v1/settings/order-risk", {
+        method: "PUT",
+        body: JSON.stringify(data),
+      }),
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey: ["settings", "order-risk"] });
+    },
+  });
+}
@@ -8,6 +8,8 @@ import type {
   UpdateSupplierRequest,
   SupplierProduct,
   SupplierProductListParams,
+  SupplierFeedPreview,
+  SupplierFeedPreviewRequest,
 } from "@/types/api";
 
 export function useSuppliers(params: SupplierListParams = {}) {
@@ -88,6 +90,16 @@ export function useSyncSupplier() {
   });
 }
 
+export function usePreviewSupplierFeed(id: string) {
+  return useMutation({
+    mutationFn: (data: SupplierFeedPreviewRequest) =>
+      apiClient<SupplierFeedPreview>(`/v1/suppliers/${id}/feed/preview`, {
+        method: "POST",
+        body: JSON.stringify(data),
+      }),
+  });
+}
+
 export function useLinkSupplierProduct(supplierId: string) {
   const queryClient = useQueryClient();
   return useMutation({
@@ -1,6 +1,6 @@
 "use client";
 
-import { useQuery } from "@tanstack/react-query";
+import { useQuery, useMutation, useQueryClient } from "@tanstack/react-query";
 import { apiClient } from "@/lib/api-client";
 import type { SyncJob, SyncJobListParams, ListResponse } from "@/types/api";
 
@@ -26,3 +26,16 @@ export function useSyncJob(id: string) {
     enabled: !!id,
   });
 }
+
+export function useStartCatalogImport() {
+  const queryClient = useQueryClient();
+  return useMutation({
+    mutationFn: (integrationId: string) =>
+      apiClient<SyncJob>(`/v1/integrations/${encodeURIComponent(integrationId)}/catalog-import`, {
+        method: "POST",
+      }),
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey: ["sync-jobs"] });
+    },
+  });
+}
@@ -9,6 +9,9 @@ import type {
   CreateWarehouseRequest,
   UpdateWarehouseRequest,
   UpsertWarehouseStockRequest,
+  StockMovement,
+  StockMovementParams,
+  ProductStockAt,
 } from "@/types/api";
 
 export function useWarehouses(params: WarehouseListParams = {}) {
@@ -111,6 +114,7 @@ export function useUpsertWarehouseStock(warehouseId: string) {
         queryKey: ["warehouse-stock", warehouseId],
       });
       queryClient.invalidateQueries({ queryKey: ["product-stock"] });
+      queryClient.invalidateQueries({ queryKey: ["stock-movements"] });
     },
   });
 }
@@ -123,3 +127,35 @@ export function useProductStock(productId: string) {
     enabled: !!productId,
   });
 }
+
+export function useStockMovements(productId: string, params: StockMovementParams = {}) {
+  const query = new URLSearchParams();
+  if (params.limit != null) query.set("limit", String(params.limit));
+  if (params.offset != null) query.set("offset", String(params.offset));
+  if (params.warehouse_id) query.set("warehouse_id", params.warehouse_id);
+  if (params.variant_id) query.set("variant_id", params.variant_id);
+  if (params.from) query.set("from", params.from);
+  if (params.to) query.set("to", params.to);
+
+  const qs = query.toString();
+
+  return useQuery({
+    queryKey: ["stock-movements", productId, params],
+    queryFn: () =>
+      apiClient<ListResponse<StockMovement>>(
+        `/v1/products/${productId}/stock-movements${qs ? `?${qs}` : ""}`
+      ),
+    enabled: !!productId,
+  });
+}
+
+export function useProductStockAt(productId: string, at?: string) {
+  return useQuery({
+    queryKey: ["stock-movements", productId, "at", at],
+    queryFn: () =>
+      apiClient<ProductStockAt>(
+        `/v1/products/${productId}/stock-at?at=${encodeURIComponent(at ?? "")}`
+      ),
+    enabled: !!productId && !!at,
+  });
+}
@@ -28,6 +28,8 @@ const EVENT_INVALIDATION_MAP: Record<string, string[][]> = {
   "warehouse_document.cancelled": [["warehouse-documents"]],
   "customer.created": [["customers"]],
   "customer.updated": [["customers"]],
+  "bulk_job.progress": [["bulk-jobs"]],
+  "bulk_job.finished": [["bulk-jobs"], ["orders"], ["shipments"], ["invoices"]],
 };
 
 interface UseWebSocketReturn {
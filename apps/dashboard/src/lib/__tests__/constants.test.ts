@@ -22,6 +22,15 @@ import {
   AUTOMATION_TRIGGER_LABELS,
   AUTOMATION_ACTION_TYPES,
   AUTOMATION_ACTION_LABELS,
+  AUTOMATION_ACTION_PARAMS,
+  TIMELINE_SOURCES,
+  HOLD_REASONS,
+  HOLD_IMPORT_CHECKS,
+  HOLD_AUTO_RELEASE_REASONS,
+  ADDRESS_ISSUE_CODES,
+  ADDRESS_ISSUE_FIELDS,
+  RISK_SIGNALS,
+  riskLevel,
 } from "@/lib/constants";
 
 describe("ORDER_STATUSES", () => {
@@ -94,7 +103,7 @@ describe("SHIPMENT_TRANSITIONS", () => {
 
 describe("RETURN_STATUSES", () => {
   it("has expected keys", () => {
-    const expectedKeys = ["requested", "approved", "received", "refunded", "rejected", "cancelled"];
+    const expectedKeys = ["requested", "approved", "received", "partially_refunded", "refunded", "exchanged", "rejected", "cancelled"];
     for (const key of expectedKeys) {
       expect(RETURN_STATUSES).toHaveProperty(key);
     }
@@ -109,6 +118,69 @@ describe("RETURN_TRANSITIONS", () => {
   it("rejected has no transitions (terminal state)", () => {
     expect(RETURN_TRANSITIONS.rejected).toEqual([]);
   });
+
+  it("exchanged has no transitions (terminal state)", () => {
+    expect(RETURN_TRANSITIONS.exchanged).toEqual([]);
+  });
+});
+
+describe("TIMELINE_SOURCES", () => {
+  it("has a label for every timeline source", () => {
+    const expectedKeys = [
+      "order", "shipment", "return", "invoice",
+      "automation", "notification", "marketplace", "webhook",
+    ];
+    expect(Object.keys(TIMELINE_SOURCES)).toEqual(expectedKeys);
+  });
+});
+
+describe("HOLD_REASONS", () => {
+  it("has a label for every hold reason", () => {
+    const expectedKeys = [
+      "fraud_check", "address_verification", "awaiting_payment",
+      "out_of_stock", "customer_request",
+    ];
+    expect(Object.keys(HOLD_REASONS)).toEqual(expectedKeys);
+  });
+
+  it("import checks and auto-release reasons are hold reasons", () => {
+    for (const reason of [...HOLD_IMPORT_CHECKS, ...HOLD_AUTO_RELEASE_REASONS]) {
+      expect(HOLD_REASONS).toHaveProperty(reason);
+    }
+  });
+});
+
+describe("ADDRESS_ISSUE_CODES", () => {
+  it("has a label for every address issue code", () => {
+    expect(Object.keys(ADDRESS_ISSUE_CODES)).toEqual([
+      "missing", "invalid_format", "city_mismatch", "house_number_missing",
+    ]);
+  });
+
+  it("has a label for every validated field", () => {
+    expect(Object.keys(ADDRESS_ISSUE_FIELDS)).toEqual([
+      "street", "city", "postal_code", "country", "phone", "customer_phone",
+    ]);
+  });
+});
+
+describe("RISK_SIGNALS", () => {
+  it("has a label for every risk signal", () => {
+    expect(Object.keys(RISK_SIGNALS)).toEqual([
+      "refused_cod", "frequent_returns", "high_value_first_order", "manual_cod_new_customer",
+      "country_mismatch", "disposable_email", "phone_velocity", "address_velocity", "trusted_customer",
+    ]);
+  });
+});
+
+describe("riskLevel", () => {
+  it("maps scores to bands", () => {
+    expect(riskLevel(0).label).toBe("Niskie");
+    expect(riskLevel(29).label).toBe("Niskie");
+    expect(riskLevel(30).label).toBe("Średnie");
+    expect(riskLevel(60).label).toBe("Wysokie");
+    expect(riskLevel(100).label).toBe("Wysokie");
+  });
 });
 
 describe("INTEGRATION_STATUSES", () => {
@@ -208,4 +280,10 @@ describe("Automation constants", () => {
       expect(AUTOMATION_ACTION_LABELS).toHaveProperty(action);
     }
   });
+
+  it("AUTOMATION_ACTION_PARAMS has fields for all action types", () => {
+    for (const action of AUTOMATION_ACTION_TYPES) {
+      expect(AUTOMATION_ACTION_PARAMS).toHaveProperty(action);
+    }
+  });
 });
@@ -0,0 +1,46 @@
+import { describe, it, expect } from "vitest";
+import {
+  orderFilterValues,
+  parseOrderFilterValues,
+  countAdvancedOrderFilters,
+} from "@/lib/order-filters";
+
+describe("orderFilterValues", () => {
+  it("drops empty values and prefixes custom fields", () => {
+    expect(
+      orderFilterValues({
+        status: "new,confirmed",
+        search: "",
+        amount_min: "100",
+        custom_fields: { nip: "123", empty: "" },
+      })
+    ).toEqual({ status: "new,confirmed", amount_min: "100", "cf.nip": "123" });
+  });
+});
+
+describe("parseOrderFilterValues", () => {
+  it("round-trips filters and ignores unknown keys", () => {
+    const filters = parseOrderFilterValues({
+      carrier: "inpost",
+      unpacked_days: "2",
+      address_issues: "true",
+      risk_min: "70",
+      "cf.nip": "123",
+      sort_by: "ordered_at",
+    });
+    expect(filters).toEqual({
+      carrier: "inpost",
+      unpacked_days: "2",
+      address_issues: "true",
+      risk_min: "70",
+      custom_fields: { nip: "123" },
+    });
+    expect(parseOrderFilterValues(orderFilterValues(filters))).toEqual(filters);
+  });
+});
+
+describe("countAdvancedOrderFilters", () => {
+  it("counts only advanced filters", () => {
+    expect(countAdvancedOrderFilters({ status: "new", sku: "A", risk_min: "50", custom_fields: { nip: "1" } })).toBe(3);
+  });
+});
@@ -32,6 +32,24 @@ export const ORDER_TRANSITIONS: Record<string, string[]> = {
   split: [],
 };
 
+export const ORDER_STATUS_CATEGORIES: Record<string, string> = {
+  new: "Nowe",
+  processing: "W realizacji",
+  on_hold: "Wstrzymane",
+  shipped: "Wysłane",
+  delivered: "Dostarczone",
+  completed: "Zakończone",
+  cancelled: "Anulowane",
+  refunded: "Zwrócone",
+};
+
+export const ORDER_STATUS_GUARDS: Record<string, string> = {
+  paid: "Opłacone",
+  has_shipment: "Ma przesyłkę",
+  has_customer_email: "Ma e-mail klienta",
+  has_shipping_address: "Ma adres dostawy",
+};
+
 export const SHIPMENT_STATUSES: Record<string, { label: string; color: string }> = {
   created: { label: "Utworzona", color: "bg-blue-100 text-blue-800 dark:bg-blue-900 dark:text-blue-200" },
   label_ready: { label: "Etykieta gotowa", color: "bg-indigo-100 text-indigo-800 dark:bg-indigo-900 dark:text-indigo-200" },
@@ -58,7 +76,9 @@ export const RETURN_STATUSES: Record<string, { label: string; color: string }> =
   requested: { label: "Zgłoszone", color: "bg-yellow-100 text-yellow-800 dark:bg-yellow-900 dark:text-yellow-200" },
   approved: { label: "Zatwierdzone", color: "bg-blue-100 text-blue-800 dark:bg-blue-900 dark:text-blue-200" },
   received: { label: "Odebrane", color: "bg-purple-100 text-purple-800 dark:bg-purple-900 dark:text-purple-200" },
+  partially_refunded: { label: "Częściowo zwrócone", color: "bg-teal-100 text-teal-800 dark:bg-teal-900 dark:text-teal-200" },
   refunded: { label: "Zwrócone", color: "bg-green-100 text-green-800 dark:bg-green-900 dark:text-green-200" },
+  exchanged: { label: "Wymienione", color: "bg-indigo-100 text-indigo-800 dark:bg-indigo-900 dark:text-indigo-200" },
   rejected: { label: "Odrzucone", color: "bg-red-100 text-red-800 dark:bg-red-900 dark:text-red-200" },
   cancelled: { label: "Anulowane", color: "bg-gray-100 text-gray-800 dark:bg-gray-800 dark:text-gray-200" },
 };
@@ -66,18 +86,101 @@ export const RETURN_STATUSES: Record<string, { label: string; color: string }> =
 export const RETURN_TRANSITIONS: Record<string, string[]> = {
   requested: ["approved", "rejected", "cancelled"],
   approved: ["received", "cancelled"],
-  received: ["refunded", "cancelled"],
+  received: ["refunded", "partially_refunded", "exchanged", "cancelled"],
+  partially_refunded: ["partially_refunded", "refunded"],
   refunded: [],
+  exchanged: [],
   rejected: [],
   cancelled: [],
 };
 
+export const RETURN_TYPES: Record<string, string> = {
+  refund: "Zwrot pieniędzy",
+  exchange: "Wymiana",
+};
+
+export const TIMELINE_SOURCES: Record<string, string> = {
+  order: "Zamówienie",
+  shipment: "Przesyłki",
+  return: "Zwroty",
+  invoice: "Faktury",
+  automation: "Automatyzacje",
+  notification: "Powiadomienia",
+  marketplace: "Marketplace",
+  webhook: "Webhooki",
+};
+
+export const HOLD_REASONS: Record<string, string> = {
+  fraud_check: "Weryfikacja pod kątem oszustwa",
+  address_verification: "Weryfikacja adresu",
+  awaiting_payment: "Oczekuje na płatność",
+  out_of_stock: "Brak towaru",
+  customer_request: "Na prośbę klienta",
+};
+
+export const HOLD_SOURCES: Record<string, string> = {
+  manual: "Ręcznie",
+  automation: "Automatyzacja",
+  import: "Import",
+};
+
+// Hold reasons checked on new orders and released automatically once cleared.
+export const HOLD_IMPORT_CHECKS = ["awaiting_payment", "out_of_stock", "address_verification"] as const;
+export const HOLD_AUTO_RELEASE_REASONS = ["awaiting_payment", "out_of_stock"] as const;
+
+export const ADDRESS_ISSUE_CODES: Record<string, string> = {
+  missing: "Brak wartości",
+  invalid_format: "Nieprawidłowy format",
+  city_mismatch: "Kod pocztowy nie pasuje do miejscowości",
+  house_number_missing: "Brak numeru domu",
+};
+
+export const RISK_SIGNALS: Record<string, string> = {
+  refused_cod: "Nieodebrane przesyłki za pobraniem",
+  frequent_returns: "Częste zwroty",
+  high_value_first_order: "Wysoka kwota pierwszego zamówienia",
+  manual_cod_new_customer: "Ręczne zamówienie za pobraniem od nowego klienta",
+  country_mismatch: "Inny kraj rozliczenia i dostawy",
+  disposable_email: "Jednorazowy adres e-mail",
+  phone_velocity: "Wiele zamówień z tego numeru telefonu",
+  address_velocity: "Wiele zamówień na ten adres",
+  trusted_customer: "Zaufany klient",
+};
+
+// Risk score bands: low below 30, medium below 60, high from 60.
+export function riskLevel(score: number): { label: string; color: string } {
+  if (score >= 60) {
+    return { label: "Wysokie", color: "bg-red-100 text-red-800 dark:bg-red-900 dark:text-red-200" };
+  }
+  if (score >= 30) {
+    return { label: "Średnie", color: "bg-yellow-100 text-yellow-800 dark:bg-yellow-900 dark:text-yellow-200" };
+  }
+  return { label: "Niskie", color: "bg-green-100 text-green-800 dark:bg-green-900 dark:text-green-200" };
+}
+
+export const ADDRESS_ISSUE_FIELDS: Record<string, string> = {
+  street: "Ulica",
+  city: "Miejscowość",
+  postal_code: "Kod pocztowy",
+  country: "Kraj",
+  phone: "Telefon w adresie",
+  customer_phone: "Telefon klienta",
+};
+
 export const SUPPLIER_STATUSES: Record<string, { label: string; color: string }> = {
   active: { label: "Aktywny", color: "bg-green-100 text-green-800 dark:bg-green-900 dark:text-green-200" },
   inactive: { label: "Nieaktywny", color: "bg-gray-100 text-gray-800 dark:bg-gray-800 dark:text-gray-200" },
   error: { label: "Błąd", color: "bg-red-100 text-red-800 dark:bg-red-900 dark:text-red-200" },
 };
 
+export const SUPPLIER_ORDER_STATUSES: Record<string, { label: string; color: string }> = {
+  pending: { label: "Oczekuje", color: "bg-yellow-100 text-yellow-800 dark:bg-yellow-900 dark:text-yellow-200" },
+  sent: { label: "Wysłane", color: "bg-blue-100 text-blue-800 dark:bg-blue-900 dark:text-blue-200" },
+  failed: { label: "Błąd wysyłki", color: "bg-red-100 text-red-800 dark:bg-red-900 dark:text-red-200" },
+  shipped: { label: "Nadane", color: "bg-green-100 text-green-800 dark:bg-green-900 dark:text-green-200" },
+  cancelled: { label: "Anulowane", color: "bg-gray-100 text-gray-800 dark:bg-gray-800 dark:text-gray-200" },
+};
+
 export const INTEGRATION_STATUSES: Record<string, { label: string; color: string }> = {
   active: { label: "Aktywna", color: "bg-green-100 text-green-800 dark:bg-green-900 dark:text-green-200" },
   inactive: { label: "Nieaktywna", color: "bg-gray-100 text-gray-800 dark:bg-gray-800 dark:text-gray-200" },
@@ -90,7 +193,7 @@ export const ROLES: Record<string, string> = {
   member: "Członek",
 };
 
-export const ORDER_SOURCES = ["manual", "allegro", "amazon", "empik", "erli", "ebay", "kaufland", "olx", "woocommerce"] as const;
+export const ORDER_SOURCES = ["manual", "allegro", "amazon", "empik", "erli", "ebay", "kaufland", "olx", "woocommerce", "shopify", "prestashop"] as const;
 
 export const ORDER_PRIORITIES: Record<string, { label: string; color: string }> = {
   urgent: { label: "Pilne", color: "bg-red-100 text-red-800 dark:bg-red-900 dark:text-red-200" },
@@ -109,7 +212,7 @@ export const PAYMENT_STATUSES: Record<string, { label: string; color: string }>
 
 export const PAYMENT_METHODS = ["przelew", "pobranie", "karta", "PayU", "Przelewy24", "BLIK"] as const;
 export const SHIPMENT_PROVIDERS = ["inpost", "dhl", "dpd", "gls", "ups", "poczta_polska", "orlen_paczka", "fedex", "manual"] as const;
-export const INTEGRATION_PROVIDERS = ["allegro", "amazon", "woocommerce", "ebay", "kaufland", "olx", "erli", "empik", "inpost", "dhl", "dpd", "gls", "ups", "fedex", "poczta_polska", "orlen_paczka", "fakturownia"] as const;
+export const INTEGRATION_PROVIDERS = ["allegro", "amazon", "woocommerce", "shopify", "prestashop", "ebay", "kaufland", "olx", "erli", "empik", "inpost", "dhl", "dpd", "gls", "ups", "fedex", "poczta_polska", "orlen_paczka", "fakturownia"] as const;
 
 export const ORDER_SOURCE_LABELS: Record<string, string> = {
   manual: "Ręczne",
@@ -121,6 +224,8 @@ export const ORDER_SOURCE_LABELS: Record<string, string> = {
   kaufland: "Kaufland",
   olx: "OLX",
   woocommerce: "WooCommerce",
+  shopify: "Shopify",
+  prestashop: "PrestaShop",
 };
 
 export const SHIPMENT_PROVIDER_LABELS: Record<string, string> = {
@@ -147,6 +252,8 @@ export const INTEGRATION_PROVIDER_LABELS: Record<string, string> = {
   dhl: "DHL",
   dpd: "DPD",
   woocommerce: "WooCommerce",
+  shopify: "Shopify",
+  prestashop: "PrestaShop",
   gls: "GLS",
   ups: "UPS",
   fedex: "FedEx",
@@ -203,6 +310,18 @@ export const AUTOMATION_TRIGGER_EVENTS = [
   "return.status_changed",
   "product.created",
   "product.updated",
+  "schedule",
+  "order.status_duration",
+  "shipment.status_duration",
+  "return.status_duration",
+  "order.hold_placed",
+  "order.hold_released",
+] as const;
+
+export const AUTOMATION_STATUS_DURATION_TRIGGERS = [
+  "order.status_duration",
+  "shipment.status_duration",
+  "return.status_duration",
 ] as const;
 
 export const AUTOMATION_TRIGGER_LABELS: Record<string, string> = {
@@ -215,6 +334,12 @@ export const AUTOMATION_TRIGGER_LABELS: Record<string, string> = {
   "return.status_changed": "Zmiana statusu zwrotu",
   "product.created": "Produkt utworzony",
   "product.updated": "Produkt zaktualizowany",
+  schedule: "Harmonogram (cron)",
+  "order.status_duration": "Zamówienie w statusie przez określony czas",
+  "shipment.status_duration": "Przesyłka w statusie przez określony czas",
+  "return.status_duration": "Zwrot w statusie przez określony czas",
+  "order.hold_placed": "Zamówienie wstrzymane",
+  "order.hold_released": "Zwolniono wstrzymanie zamówienia",
 };
 
 export const AUTOMATION_OPERATORS = [
@@ -229,6 +354,15 @@ export const AUTOMATION_OPERATORS = [
   "contains",
   "not_contains",
   "starts_with",
+  "regex",
+  "between",
+  "is_empty",
+  "is_not_empty",
+  "length_eq",
+  "length_gt",
+  "length_lt",
+  "older_than",
+  "newer_than",
 ] as const;
 
 export const AUTOMATION_OPERATOR_LABELS: Record<string, string> = {
@@ -243,6 +377,21 @@ export const AUTOMATION_OPERATOR_LABELS: Record<string, string> = {
   contains: "Zawiera",
   not_contains: "Nie zawiera",
   starts_with: "Zaczyna się od",
+  regex: "Pasuje do wyrażenia regularnego",
+  between: "Pomiędzy (włącznie)",
+  is_empty: "Jest puste",
+  is_not_empty: "Nie jest puste",
+  length_eq: "Liczba elementów =",
+  length_gt: "Liczba elementów >",
+  length_lt: "Liczba elementów <",
+  older_than: "Starsze niż",
+  newer_than: "Nowsze niż",
+};
+
+export const AUTOMATION_GROUP_LABELS: Record<string, string> = {
+  and: "Wszystkie (I)",
+  or: "Dowolny (LUB)",
+  not: "Zaprzeczenie (NIE)",
 };
 
 export const AUTOMATION_ACTION_TYPES = [
@@ -251,6 +400,16 @@ export const AUTOMATION_ACTION_TYPES = [
   "send_email",
   "create_invoice",
   "webhook",
+  "assign_user",
+  "set_priority",
+  "create_shipment",
+  "send_sms",
+  "create_freshdesk_ticket",
+  "split_order_by_warehouse",
+  "add_internal_note",
+  "allegro_message_buyer",
+  "place_hold",
+  "release_hold",
 ] as const;
 
 export const AUTOMATION_ACTION_LABELS: Record<string, string> = {
@@ -259,8 +418,101 @@ export const AUTOMATION_ACTION_LABELS: Record<string, string> = {
   send_email: "Wyślij e-mail",
   create_invoice: "Utwórz fakturę",
   webhook: "Wywołaj webhook",
+  assign_user: "Przypisz do użytkownika",
+  set_priority: "Ustaw priorytet",
+  create_shipment: "Utwórz przesyłkę",
+  send_sms: "Wyślij SMS",
+  create_freshdesk_ticket: "Utwórz zgłoszenie Freshdesk",
+  split_order_by_warehouse: "Podziel wg magazynów",
+  add_internal_note: "Dodaj notatkę wewnętrzną",
+  allegro_message_buyer: "Wiadomość do kupującego (Allegro)",
+  place_hold: "Wstrzymaj zamówienie",
+  release_hold: "Zwolnij wstrzymanie",
+};
+
+export interface AutomationActionParamField {
+  key: string;
+  label: string;
+  placeholder?: string;
+  type: "text" | "textarea" | "select" | "checkbox" | "user";
+  required?: boolean;
+  // template fields accept {{order.field}} placeholders
+  template?: boolean;
+  options?: { value: string; label: string }[];
+}
+
+// AUTOMATION_ACTION_PARAMS mirrors the server-side action catalogue.
+export const AUTOMATION_ACTION_PARAMS: Record<string, AutomationActionParamField[]> = {
+  set_status: [{ key: "status", label: "Nowy status", placeholder: "np. confirmed", type: "text", required: true }],
+  add_tag: [{ key: "tag", label: "Tag", placeholder: "np. vip", type: "text", required: true }],
+  send_email: [
+    { key: "to", label: "Adres e-mail", placeholder: "np. {{order.customer_email}}", type: "text", required: true, template: true },
+    { key: "subject", label: "Temat", type: "text", template: true },
+    { key: "body", label: "Treść", type: "textarea", template: true },
+  ],
+  create_invoice: [{ key: "invoice_type", label: "Typ faktury", placeholder: "np. vat", type: "text" }],
+  webhook: [{ key: "url", label: "URL webhooka", placeholder: "https://...", type: "text", required: true }],
+  assign_user: [{ key: "user_id", label: "Użytkownik", type: "user", required: true }],
+  set_priority: [
+    {
+      key: "priority",
+      label: "Priorytet",
+      type: "select",
+      required: true,
+      options: [
+        { value: "low", label: "Niski" },
+        { value: "normal", label: "Normalny" },
+        { value: "high", label: "Wysoki" },
+        { value: "urgent", label: "Pilny" },
+      ],
+    },
+  ],
+  create_shipment: [
+    { key: "provider", label: "Przewoźnik", placeholder: "np. inpost", type: "text", required: true },
+    { key: "generate_label", label: "Generuj etykietę", type: "checkbox" },
+    { key: "service_type", label: "Usługa", placeholder: "np. inpost_locker_standard", type: "text" },
+    { key: "parcel_size", label: "Gabaryt", placeholder: "np. small", type: "text" },
+    {
+      key: "label_format",
+      label: "Format etykiety",
+      type: "select",
+      options: [
+        { value: "pdf", label: "PDF" },
+        { value: "zpl", label: "ZPL" },
+        { value: "epl", label: "EPL" },
+      ],
+    },
+  ],
+  send_sms: [
+    { key: "message", label: "Treść SMS", placeholder: "Dzień dobry {{order.customer_name}}, ...", type: "textarea", required: true, template: true },
+    { key: "phone", label: "Numer telefonu", placeholder: "domyślnie telefon klienta", type: "text", template: true },
+  ],
+  create_freshdesk_ticket: [
+    { key: "subject", label: "Temat", placeholder: "Zamówienie {{order.external_id}}", type: "text", required: true, template: true },
+    { key: "description", label: "Opis", type: "textarea", required: true, template: true },
+    { key: "email", label: "E-mail zgłaszającego", placeholder: "domyślnie e-mail klienta", type: "text", template: true },
+  ],
+  split_order_by_warehouse: [],
+  add_internal_note: [
+    { key: "note", label: "Notatka", placeholder: "np. Klient VIP — {{order.customer_name}}", type: "textarea", required: true, template: true },
+  ],
+  allegro_message_buyer: [
+    { key: "message", label: "Treść wiadomości", type: "textarea", required: true, template: true },
+  ],
+  place_hold: [
+    { key: "reason", label: "Powód", type: "select", required: true, options: holdReasonOptions() },
+    { key: "note", label: "Notatka", type: "textarea", template: true },
+  ],
+  release_hold: [
+    { key: "reason", label: "Powód", type: "select", required: true, options: holdReasonOptions() },
+    { key: "note", label: "Notatka", type: "textarea", template: true },
+  ],
 };
 
+function holdReasonOptions(): { value: string; label: string }[] {
+  return Object.entries(HOLD_REASONS).map(([value, label]) => ({ value, label }));
+}
+
 // === Integration Provider Credential Fields ===
 
 export interface CredentialField {
@@ -274,7 +526,7 @@ export interface CredentialField {
 }
 
 export const PROVIDER_CATEGORIES: Record<string, { label: string; providers: string[] }> = {
-  marketplace: { label: "Marketplace", providers: ["allegro", "amazon", "woocommerce", "ebay", "kaufland", "olx", "erli", "empik"] },
+  marketplace: { label: "Marketplace", providers: ["allegro", "amazon", "woocommerce", "shopify", "prestashop", "ebay", "kaufland", "olx", "erli", "empik"] },
   carrier: { label: "Kurierzy", providers: ["inpost", "dhl", "dpd", "gls", "ups", "fedex", "poczta_polska", "orlen_paczka"] },
   invoicing: { label: "Fakturowanie", providers: ["fakturownia"] },
 };
@@ -305,6 +557,15 @@ export const PROVIDER_CREDENTIAL_FIELDS: Record<string, CredentialField[]> = {
     { key: "consumer_key", label: "Consumer Key", placeholder: "ck_...", helpText: "WooCommerce > Ustawienia > Zaawansowane > REST API > Dodaj klucz", type: "password", required: true },
     { key: "consumer_secret", label: "Consumer Secret", placeholder: "cs_...", type: "password", required: true },
   ],
+  shopify: [
+    { key: "shop_domain", label: "Domena sklepu", placeholder: "twoj-sklep.myshopify.com", helpText: "Adres sklepu w domenie myshopify.com", type: "text", required: true },
+    { key: "access_token", label: "Admin API Access Token", placeholder: "shpat_...", helpText: "Shopify > Ustawienia > Aplikacje > Utwórz aplikację > Admin API", type: "password", required: true },
+    { key: "api_version", label: "Wersja API", placeholder: "2025-01", helpText: "Opcjonalnie — domyślnie 2025-01", type: "text", required: false },
+  ],
+  prestashop: [
+    { key: "shop_url", label: "URL sklepu", placeholder: "https://twoj-sklep.pl", helpText: "Adres sklepu bez /api na końcu", type: "text", required: true },
+    { key: "api_key", label: "Klucz webservice", placeholder: "32-znakowy klucz", helpText: "PrestaShop > Zaawansowane > Webservice > Dodaj nowy klucz (uprawnienia: orders, order_histories, order_states, stock_availables, products, combinations, addresses, customers, countries, currencies)", type: "password", required: true },
+  ],
   ebay: [
     { key: "app_id", label: "App ID (Client ID)", placeholder: "Twój App ID z developer.ebay.com", helpText: "Znajdziesz w eBay Developer Program: developer.ebay.com", type: "text", required: true },
     { key: "cert_id", label: "Cert ID (Client Secret)", placeholder: "", type: "password", required: true },
@@ -7,6 +7,8 @@ export const INTEGRATION_STATUS: Record<string, IntegrationMaturity> = {
   // In Development — implemented, not yet production-verified
   amazon: "in_development",
   woocommerce: "in_development",
+  shopify: "in_development",
+  prestashop: "in_development",
   ebay: "in_development",
   kaufland: "in_development",
   olx: "in_development",
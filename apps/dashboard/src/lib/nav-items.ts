@@ -1,5 +1,6 @@
 import {
   LayoutDashboard,
+  ShieldAlert,
   ShoppingCart,
   Truck,
   RotateCcw,
@@ -42,6 +43,8 @@ import {
   Scale,
   Calculator,
   Ruler,
+  PackageSearch,
+  PauseCircle,
 } from "lucide-react";
 
 export interface NavItem {
@@ -72,6 +75,7 @@ export const navItems: NavItem[] = [
   { href: "/shipments", label: "Przesyłki", icon: Truck, group: "Sprzedaż" },
   { href: "/returns", label: "Zwroty", icon: RotateCcw, group: "Sprzedaż" },
   { href: "/invoices", label: "Faktury", icon: FileText, group: "Sprzedaż" },
+  { href: "/orders/holds", label: "Wstrzymane", icon: PauseCircle, group: "Sprzedaż" },
   { href: "/orders/import", label: "Import", icon: Upload, group: "Sprzedaż" },
   { href: "/customers", label: "Klienci", icon: Contact, group: "Sprzedaż" },
   { href: "/packing", label: "Pakowanie", icon: ScanBarcode, group: "Sprzedaż" },
@@ -113,6 +117,9 @@ export const navItems: NavItem[] = [
   { href: "/settings/price-lists", label: "Cenniki", icon: BadgePercent, adminOnly: true, group: "Sprzedaż - ustawienia" },
   { href: "/settings/invoicing", label: "Fakturowanie", icon: Receipt, adminOnly: true, group: "Sprzedaż - ustawienia" },
   { href: "/settings/ksef", label: "KSeF", icon: FileText, adminOnly: true, group: "Sprzedaż - ustawienia" },
+  { href: "/settings/returns", label: "Zwroty", icon: RotateCcw, adminOnly: true, group: "Sprzedaż - ustawienia" },
+  { href: "/settings/order-holds", label: "Wstrzymania", icon: PauseCircle, adminOnly: true, group: "Sprzedaż - ustawienia" },
+  { href: "/settings/order-risk", label: "Ocena ryzyka", icon: ShieldAlert, adminOnly: true, group: "Sprzedaż - ustawienia" },
   // Powiadomienia
   { href: "/settings/notifications", label: "Powiadomienia", icon: Bell, adminOnly: true, group: "Powiadomienia" },
   { href: "/settings/webhooks", label: "Webhooki", icon: Webhook, adminOnly: true, group: "Powiadomienia" },
@@ -128,6 +135,8 @@ export const navItems: NavItem[] = [
   { href: "/settings/marketing", label: "Marketing", icon: Send, adminOnly: true, group: "Narzędzia" },
   { href: "/settings/helpdesk", label: "Helpdesk", icon: Headphones, adminOnly: true, group: "Narzędzia" },
   { href: "/suppliers", label: "Dostawcy", icon: Factory, adminOnly: true, group: "Narzędzia" },
+  { href: "/supplier-orders", label: "Zamówienia u dostawców", icon: PackageSearch, adminOnly: true, group: "Narzędzia" },
+  { href: "/settings/dropshipping", label: "Dropshipping", icon: Factory, adminOnly: true, group: "Narzędzia" },
   // Monitoring
   { href: "/settings/sync-jobs", label: "Synchronizacja", icon: RefreshCw, adminOnly: true, group: "Monitoring" },
   { href: "/settings/webhooks/deliveries", label: "Dostawy webhooków", icon: Webhook, adminOnly: true, group: "Monitoring" },
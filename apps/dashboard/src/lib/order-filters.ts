@@ -0,0 +1,80 @@
+import type { OrderFilterParams } from "@/types/api";
+
+const CUSTOM_FIELD_PREFIX = "cf.";
+
+const FILTER_KEYS = [
+  "status",
+  "source",
+  "search",
+  "payment_status",
+  "tag",
+  "priority",
+  "integration_id",
+  "customer_id",
+  "carrier",
+  "sku",
+  "ordered_from",
+  "ordered_to",
+  "amount_min",
+  "amount_max",
+  "unpacked_days",
+  "address_issues",
+  "risk_min",
+] as const;
+
+type FilterKey = (typeof FILTER_KEYS)[number];
+
+/** Flattens filters into the query-parameter map understood by the API. */
+export function orderFilterValues(filters: OrderFilterParams): Record<string, string> {
+  const values: Record<string, string> = {};
+  for (const key of FILTER_KEYS) {
+    const value = filters[key];
+    if (value) values[key] = value;
+  }
+  for (const [key, value] of Object.entries(filters.custom_fields ?? {})) {
+    if (value) values[`${CUSTOM_FIELD_PREFIX}${key}`] = value;
+  }
+  return values;
+}
+
+/** Inverse of orderFilterValues; unknown keys such as sort_by are dropped. */
+export function parseOrderFilterValues(values: Record<string, string>): OrderFilterParams {
+  const filters: OrderFilterParams = {};
+  for (const [key, value] of Object.entries(values)) {
+    if (!value) continue;
+    if (key.startsWith(CUSTOM_FIELD_PREFIX)) {
+      filters.custom_fields = {
+        ...filters.custom_fields,
+        [key.slice(CUSTOM_FIELD_PREFIX.length)]: value,
+      };
+    } else if ((FILTER_KEYS as readonly string[]).includes(key)) {
+      filters[key as FilterKey] = value;
+    }
+  }
+  return filters;
+}
+
+export function orderFilterSearchParams(filters: OrderFilterParams): URLSearchParams {
+  return new URLSearchParams(orderFilterValues(filters));
+}
+
+/** Number of filters beyond the ones always visible in the filter bar. */
+export function countAdvancedOrderFilters(filters: OrderFilterParams): number {
+  const advanced: FilterKey[] = [
+    "integration_id",
+    "customer_id",
+    "carrier",
+    "sku",
+    "ordered_from",
+    "ordered_to",
+    "amount_min",
+    "amount_max",
+    "unpacked_days",
+    "address_issues",
+    "risk_min",
+  ];
+  return (
+    advanced.filter((key) => !!filters[key]).length +
+    Object.values(filters.custom_fields ?? {}).filter(Boolean).length
+  );
+}
@@ -108,6 +108,7 @@ export interface OrderItem {
   sku?: string;
   quantity: number;
   price: number;
+  unit_price?: number;
 }
 
 export interface ReturnItem {
@@ -141,6 +142,7 @@ export interface Order {
   notes?: string;
   internal_notes?: string;
   priority?: "urgent" | "high" | "normal" | "low";
+  assigned_to?: string;
   metadata?: Record<string, unknown>;
   tags: string[];
   ordered_at?: string;
@@ -154,10 +156,30 @@ export interface Order {
   customer_id?: string;
   merged_into?: string;
   split_from?: string;
+  /** Null when the shipping address was not validated */
+  address_issues?: AddressIssue[] | null;
+  /** 0-100; null when the order was not scored */
+  risk_score?: number | null;
+  risk_signals?: RiskSignal[] | null;
+  status_changed_at?: string;
   created_at: string;
   updated_at: string;
 }
 
+export interface RiskSignal {
+  code: string;
+  points: number;
+  detail: string;
+}
+
+export type AddressIssueCode = "missing" | "invalid_format" | "city_mismatch" | "house_number_missing";
+
+export interface AddressIssue {
+  field: string;
+  code: AddressIssueCode;
+  message: string;
+}
+
 export interface CreateOrderRequest {
   external_id?: string;
   source: string;
@@ -173,6 +195,7 @@ export interface CreateOrderRequest {
   notes?: string;
   internal_notes?: string;
   priority?: "urgent" | "high" | "normal" | "low";
+  assigned_to?: string;
   metadata?: Record<string, unknown>;
   tags?: string[];
   delivery_method?: string;
@@ -197,6 +220,7 @@ export interface UpdateOrderRequest {
   notes?: string;
   internal_notes?: string;
   priority?: "urgent" | "high" | "normal" | "low";
+  assigned_to?: string;
   metadata?: Record<string, unknown>;
   tags?: string[];
   delivery_method?: string;
@@ -211,13 +235,55 @@ export interface StatusTransitionRequest {
   force?: boolean;
 }
 
-export interface OrderListParams extends PaginationParams {
+// Order filter language shared by the order list, CSV export, saved views
+// and bulk operations. Values are query-string encoded.
+export interface OrderFilterParams {
+  /** One status or a comma-separated list */
   status?: string;
   source?: string;
   search?: string;
   payment_status?: string;
   tag?: string;
   priority?: string;
+  integration_id?: string;
+  customer_id?: string;
+  carrier?: string;
+  sku?: string;
+  /** YYYY-MM-DD, inclusive */
+  ordered_from?: string;
+  /** YYYY-MM-DD, inclusive */
+  ordered_to?: string;
+  amount_min?: string;
+  amount_max?: string;
+  unpacked_days?: string;
+  /** "true" or "false" */
+  address_issues?: string;
+  /** Minimum risk score, 0-100 */
+  risk_min?: string;
+  /** Custom field key -> value, sent as cf.<key> */
+  custom_fields?: Record<string, string>;
+}
+
+export interface OrderListParams extends PaginationParams, OrderFilterParams {}
+
+export interface OrderView {
+  id: string;
+  tenant_id: string;
+  user_id: string;
+  name: string;
+  filters: Record<string, string>;
+  created_at: string;
+  updated_at: string;
+}
+
+export interface CreateOrderViewRequest {
+  name: string;
+  filters: Record<string, string>;
+}
+
+export interface UpdateOrderViewRequest {
+  name?: string;
+  filters?: Record<string, string>;
 }
 
 // === Shipments ===
@@ -232,6 +298,7 @@ export interface Shipment {
   label_url?: string;
   carrier_data?: Record<string, unknown>;
   warehouse_id?: string;
+  shipping_cost?: number;
   created_at: string;
   updated_at: string;
 }
@@ -250,6 +317,7 @@ export interface UpdateShipmentRequest {
   tracking_number?: string;
   label_url?: string;
   carrier_data?: Record<string, unknown>;
+  shipping_cost?: number;
 }
 
 export interface ShipmentListParams extends PaginationParams {
@@ -295,6 +363,7 @@ export interface Product {
   sku?: string;
   ean?: string;
   price: number;
+  cost_price?: number;
   stock_quantity: number;
   metadata?: Record<string, unknown>;
   tags: string[];
@@ -320,6 +389,7 @@ export interface CreateProductRequest {
   sku?: string;
   ean?: string;
   price: number;
+  cost_price?: number;
   stock_quantity: number;
   metadata?: Record<string, unknown>;
   tags?: string[];
@@ -341,6 +411,7 @@ export interface UpdateProductRequest {
   sku?: string;
   ean?: string;
   price?: number;
+  cost_price?: number;
   stock_quantity?: number;
   metadata?: Record<string, unknown>;
   tags?: string[];
@@ -370,6 +441,7 @@ export interface Return {
   tenant_id: string;
   order_id: string;
   status: string;
+  return_type: ReturnType;
   reason: string;
   items: ReturnItem[];
   refund_amount: number;
@@ -377,16 +449,96 @@ export interface Return {
   return_token?: string;
   customer_email?: string;
   customer_notes?: string;
+  received_items: ReturnReceivedItem[];
+  received_at?: string;
+  restock_document_id?: string;
+  shipping_refund: number;
+  refund_method?: RefundMethod;
+  refund_reference?: string;
+  refunded_at?: string;
+  refunded_amount: number;
+  refund_key?: string;
+  refund_started_at?: string;
+  replacement_order_id?: string;
+  grading_pending: boolean;
+  return_provider?: string;
+  return_integration_id?: string;
+  return_external_id?: string;
+  return_tracking_number?: string;
+  return_label_url?: string;
+  return_shipment_status?: string;
   created_at: string;
   updated_at: string;
 }
 
+export type ReturnCondition = "resellable" | "damaged" | "to_supplier";
+
+export type RefundMethod = "channel" | "manual";
+
+export type ReturnType = "refund" | "exchange";
+
+export interface ReturnReceivedItem {
+  order_item_index: number;
+  product_id?: string;
+  variant_id?: string;
+  name: string;
+  sku?: string;
+  external_id?: string;
+  quantity: number;
+  unit_price: number;
+  condition: ReturnCondition;
+  deduction?: number;
+  notes?: string;
+}
+
+export interface ReceiveReturnItem {
+  order_item_index: number;
+  quantity: number;
+  condition: ReturnCondition;
+  deduction?: number;
+  notes?: string;
+}
+
+export interface ReceiveReturnRequest {
+  items: ReceiveReturnItem[];
+  warehouse_id?: string;
+}
+
+export interface RefundReturnRequest {
+  method?: RefundMethod;
+  reference?: string;
+  amount?: number;
+}
+
+export interface ExchangeReturnRequest {
+  items?: OrderItem[];
+}
+
+export type ReturnCarrierProvider = "inpost" | "dpd" | "orlen_paczka";
+
+export interface ReturnCarrierSettings {
+  provider: ReturnCarrierProvider | "";
+  service_type?: string;
+  sending_method?: string;
+  target_point?: string;
+  parcel_size?: string;
+  weight_kg?: number;
+  receiver: Address & { phone?: string; email?: string };
+}
+
+export interface ReturnSettings {
+  shipping_refund: "never" | "full_return" | "always";
+  default_warehouse_id?: string;
+  return_carrier?: ReturnCarrierSettings;
+}
+
 export interface CreateReturnRequest {
   order_id: string;
   reason: string;
   items?: ReturnItem[];
   refund_amount: number;
   notes?: string;
+  return_type?: ReturnType;
 }
 
 export interface UpdateReturnRequest {
@@ -528,6 +680,92 @@ export interface DailyOrderTrend {
   avg_value: number;
 }
 
+export type ProfitabilityGroupBy = "order" | "sku" | "channel" | "period";
+
+export interface ProfitabilityParams {
+  group_by: ProfitabilityGroupBy;
+  period?: "day" | "week" | "month";
+  from?: string;
+  to?: string;
+  currency?: string;
+}
+
+export interface ProfitabilityRow {
+  key: string;
+  label: string;
+  orders: number;
+  quantity: number;
+  revenue: number;
+  product_cost: number;
+  shipping_cost: number;
+  marketplace_fee: number;
+  gross_margin: number;
+  margin_percent: number | null;
+  missing_cost_lines: number;
+}
+
+export interface ProfitabilityReport {
+  group_by: ProfitabilityGroupBy;
+  period?: string;
+  currency: string;
+  rows: ProfitabilityRow[];
+  totals: ProfitabilityRow;
+}
+
+// === Scheduled Reports ===
+export type ScheduledReportType =
+  | "sales_by_channel"
+  | "stock_value"
+  | "vat_summary"
+  | "profitability";
+export type ScheduledReportFormat = "csv" | "xlsx" | "pdf";
+export type ScheduledReportPeriod = "day" | "week" | "month";
+
+export interface ScheduledReport {
+  id: string;
+  tenant_id: string;
+  name: string;
+  report_type: ScheduledReportType;
+  schedule: string;
+  timezone: string;
+  period: ScheduledReportPeriod;
+  format: ScheduledReportFormat;
+  recipients: string[];
+  active: boolean;
+  last_run_at?: string;
+  next_run_at?: string;
+  created_by?: string;
+  created_at: string;
+  updated_at: string;
+}
+
+export interface CreateScheduledReportRequest {
+  name: string;
+  report_type: ScheduledReportType;
+  schedule: string;
+  timezone?: string;
+  period?: ScheduledReportPeriod;
+  format: ScheduledReportFormat;
+  recipients: string[];
+  active?: boolean;
+}
+
+export type UpdateScheduledReportRequest = Partial<CreateScheduledReportRequest>;
+
+export interface ScheduledReportRun {
+  id: string;
+  tenant_id: string;
+  report_id: string;
+  status: "success" | "failed";
+  format: ScheduledReportFormat;
+  period_from?: string;
+  period_to?: string;
+  recipients: string[];
+  file_url?: string;
+  error_message?: string;
+  created_at: string;
+}
+
 // === Audit Log ===
 export interface AuditLogEntry {
   id: number;
@@ -540,6 +778,34 @@ export interface AuditLogEntry {
   created_at: string;
 }
 
+export type TimelineSource =
+  | "order"
+  | "shipment"
+  | "return"
+  | "invoice"
+  | "automation"
+  | "notification"
+  | "marketplace"
+  | "webhook";
+
+export interface TimelineEntry {
+  occurred_at: string;
+  source: TimelineSource;
+  type: string;
+  entity_type?: string;
+  entity_id?: string;
+  actor?: string;
+  data: Record<string, unknown>;
+}
+
+export interface TimelineParams {
+  source?: TimelineSource[];
+  type?: string;
+  from?: string;
+  to?: string;
+  limit?: number;
+}
+
 export interface AuditListParams extends PaginationParams {
   entity_type?: string;
   action?: string;
@@ -553,12 +819,69 @@ export interface BulkStatusResult {
   error?: string;
 }
 
+export interface BulkStatusTransitionRequest {
+  /** Either order_ids or filter (query-parameter map, max 100 matches) */
+  order_ids?: string[];
+  filter?: Record<string, string>;
+  status: string;
+  force?: boolean;
+}
+
 export interface BulkStatusTransitionResponse {
   results: BulkStatusResult[];
   succeeded: number;
   failed: number;
 }
 
+// === Bulk Jobs ===
+export type BulkJobAction =
+  | "status"
+  | "tags"
+  | "labels"
+  | "invoices"
+  | "packing_slips"
+  | "label_pdf";
+
+export interface CreateBulkJobRequest {
+  action: BulkJobAction;
+  /** Either order_ids or filter (query-parameter map, max 1000 matches) */
+  order_ids?: string[];
+  filter?: Record<string, string>;
+  status?: string;
+  force?: boolean;
+  add_tags?: string[];
+  remove_tags?: string[];
+  label?: GenerateLabelRequest & { provider: string };
+  invoice?: {
+    provider?: string;
+    invoice_type?: string;
+    payment_method?: string;
+  };
+}
+
+export interface BulkJobItemResult {
+  order_id: string;
+  success: boolean;
+  skipped?: boolean;
+  error?: string;
+  shipment_id?: string;
+  tracking_number?: string;
+  invoice_id?: string;
+}
+
+export interface BulkJobMetadata {
+  request: CreateBulkJobRequest;
+  created_by: string;
+  ip_address?: string;
+  total: number;
+  results: BulkJobItemResult[] | null;
+  file_url?: string;
+}
+
+export interface BulkJob extends Omit<SyncJob, "metadata"> {
+  metadata: BulkJobMetadata;
+}
+
 // === Email Settings ===
 export interface EmailSettings {
   enabled: boolean;
@@ -590,6 +913,8 @@ export interface StatusDef {
   label: string;
   color: string; // preset name: "blue", "green", "red", etc.
   position: number;
+  category?: string; // "new", "processing", "shipped", "cancelled", etc.
+  requires?: string[]; // guards checked when entering the status, e.g. "paid"
 }
 
 export interface OrderStatusConfig {
@@ -660,14 +985,17 @@ export interface ProductListing {
   id: string;
   tenant_id: string;
   product_id: string;
+  variant_id?: string;
   integration_id: string;
   external_id?: string;
+  external_group_id?: string;
   status: string;
   url?: string;
   price_override?: number;
   stock_override?: number;
   sync_status: string;
   last_synced_at?: string;
+  last_synced_price?: number;
   error_message?: string;
   metadata: Record<string, unknown>;
   created_at: string;
@@ -678,7 +1006,7 @@ export interface ProductListing {
 export interface SyncJob {
   id: string;
   tenant_id: string;
-  integration_id: string;
+  integration_id?: string;
   job_type: string;
   status: string;
   started_at?: string;
@@ -751,11 +1079,158 @@ export interface SupplierListParams extends PaginationParams {
   status?: string;
 }
 
+export interface SupplierFeedMapping {
+  source: string;
+  field: string;
+}
+
+export interface SupplierCSVSettings {
+  delimiter?: string;
+  encoding?: string;
+  no_header?: boolean;
+  mappings: SupplierFeedMapping[];
+}
+
+export interface SupplierXMLSettings {
+  item_path: string;
+  mappings: SupplierFeedMapping[];
+}
+
+export interface SupplierFeedSettings {
+  csv?: SupplierCSVSettings;
+  xml?: SupplierXMLSettings;
+}
+
+export interface SupplierFeedProduct {
+  external_id: string;
+  name: string;
+  ean?: string;
+  sku?: string;
+  price: number;
+  stock: number;
+  description?: string;
+  category?: string;
+  image_url?: string;
+  weight?: number;
+  attributes?: Record<string, string>;
+}
+
+export interface SupplierFeedPreviewRequest {
+  feed_url?: string;
+  feed_format?: string;
+  settings?: Record<string, unknown>;
+}
+
+export interface SupplierFeedPreview {
+  feed_format: string;
+  total_products: number;
+  products: SupplierFeedProduct[];
+  columns?: string[];
+  warnings: string[];
+}
+
 export interface SupplierProductListParams extends PaginationParams {
   ean?: string;
   linked?: boolean;
 }
 
+// === Dropshipping ===
+export type FulfillmentMode = "own_stock" | "stock_first" | "dropship";
+
+export interface FulfillmentRule {
+  product_id: string;
+  tenant_id: string;
+  mode: FulfillmentMode;
+  supplier_id?: string;
+  created_at: string;
+  updated_at: string;
+}
+
+export interface SetFulfillmentRuleRequest {
+  mode: FulfillmentMode;
+  supplier_id?: string;
+}
+
+export interface DropshipSettings {
+  enabled: boolean;
+  route_statuses?: string[];
+}
+
+export interface SupplierDropshipSettings {
+  channel: "" | "email" | "webhook";
+  email?: string;
+  attachment?: "pdf" | "csv";
+  webhook_url?: string;
+  webhook_secret?: string;
+}
+
+export type SupplierOrderStatus = "pending" | "sent" | "failed" | "shipped" | "cancelled";
+
+export interface SupplierOrderItem {
+  line_index: number;
+  product_id: string;
+  variant_id?: string;
+  supplier_product_id: string;
+  external_id: string;
+  name: string;
+  sku?: string;
+  ean?: string;
+  quantity: number;
+  unit_cost: number;
+}
+
+export interface SupplierOrder {
+  id: string;
+  tenant_id: string;
+  supplier_id: string;
+  supplier_name?: string;
+  order_id: string;
+  status: SupplierOrderStatus;
+  items: SupplierOrderItem[];
+  total_cost: number;
+  channel: string;
+  attempts: number;
+  error_message?: string;
+  sent_at?: string;
+  carrier?: string;
+  tracking_number?: string;
+  shipment_id?: string;
+  shipped_at?: string;
+  created_at: string;
+  updated_at: string;
+}
+
+export interface SupplierOrderListParams extends PaginationParams {
+  status?: SupplierOrderStatus;
+  supplier_id?: string;
+  order_id?: string;
+}
+
+export interface SupplierTrackingRequest {
+  carrier?: string;
+  tracking_number: string;
+}
+
+export interface DropshipRouting {
+  order_id: string;
+  supplier_orders: SupplierOrder[];
+  warnings: string[];
+}
+
+export interface PublicSupplierOrder {
+  id: string;
+  status: SupplierOrderStatus;
+  company_name?: string;
+  items: SupplierOrderItem[];
+  total_cost: number;
+  customer_name: string;
+  customer_phone?: string;
+  shipping_address?: Address & { name?: string; company?: string };
+  carrier?: string;
+  tracking_number?: string;
+  created_at: string;
+}
+
 // === Invoices ===
 export interface Invoice {
   id: string;
@@ -850,6 +1325,18 @@ export interface AutomationCondition {
   value: unknown;
 }
 
+export type AutomationConditionGroupOp = "and" | "or" | "not";
+
+export interface AutomationConditionGroup {
+  op: AutomationConditionGroupOp;
+  conditions: AutomationConditionNode[];
+}
+
+export type AutomationConditionNode = AutomationCondition | AutomationConditionGroup;
+
+// Rules created before condition groups store a flat list, which is ANDed.
+export type AutomationConditions = AutomationCondition[] | AutomationConditionGroup;
+
 export interface AutomationAction {
   type: string;
   config: Record<string, unknown>;
@@ -871,6 +1358,33 @@ export interface DelayedAction {
   event_data: Record<string, unknown>;
 }
 
+export type AutomationEventStatus = "pending" | "processing" | "done" | "dead";
+
+export interface AutomationEvent {
+  id: string;
+  seq: number;
+  tenant_id: string;
+  event_type: string;
+  entity_type: string;
+  entity_id: string;
+  /** Set for the events of schedule and status duration rules */
+  rule_id?: string;
+  data: Record<string, unknown>;
+  status: AutomationEventStatus;
+  attempts: number;
+  next_attempt_at: string;
+  locked_at?: string;
+  last_error?: string;
+  processed_at?: string;
+  created_at: string;
+}
+
+export interface AutomationEventListParams {
+  status?: AutomationEventStatus;
+  limit?: number;
+  offset?: number;
+}
+
 export interface BatchLabelsRequest {
   shipment_ids: string[];
 }
@@ -892,6 +1406,13 @@ export interface DispatchOrderResponse {
   status: string;
 }
 
+export interface AutomationTriggerConfig {
+  schedule?: string;
+  timezone?: string;
+  status?: string;
+  duration?: string;
+}
+
 export interface AutomationRule {
   id: string;
   tenant_id: string;
@@ -900,9 +1421,11 @@ export interface AutomationRule {
   enabled: boolean;
   priority: number;
   trigger_event: string;
-  conditions: AutomationCondition[];
+  trigger_config: AutomationTriggerConfig;
+  conditions: AutomationConditions;
   actions: AutomationAction[];
   last_fired_at?: string;
+  next_run_at?: string;
   fire_count: number;
   created_at: string;
   updated_at: string;
@@ -914,7 +1437,8 @@ export interface CreateAutomationRuleRequest {
   enabled?: boolean;
   priority?: number;
   trigger_event: string;
-  conditions: AutomationCondition[];
+  trigger_config?: AutomationTriggerConfig;
+  conditions: AutomationConditions;
   actions: AutomationAction[];
 }
 
@@ -924,7 +1448,8 @@ export interface UpdateAutomationRuleRequest {
   enabled?: boolean;
   priority?: number;
   trigger_event?: string;
-  conditions?: AutomationCondition[];
+  trigger_config?: AutomationTriggerConfig;
+  conditions?: AutomationConditions;
   actions?: AutomationAction[];
 }
 
@@ -951,9 +1476,19 @@ export interface TestAutomationRuleRequest {
 }
 
 export interface TestAutomationRuleResponse {
+  condition_tree?: ConditionTreeResult;
   condition_results: ConditionResult[];
   all_conditions_met: boolean;
   actions_to_execute: AutomationAction[];
+  action_results: ActionDryRun[];
+}
+
+export interface ActionDryRun {
+  type: string;
+  params: Record<string, unknown>;
+  delay_seconds?: number;
+  missing: string[];
+  error?: string;
 }
 
 export interface ConditionResult {
@@ -961,6 +1496,16 @@ export interface ConditionResult {
   met: boolean;
 }
 
+export interface ConditionTreeResult {
+  op?: AutomationConditionGroupOp;
+  field?: string;
+  operator?: string;
+  value?: unknown;
+  actual?: unknown;
+  met: boolean;
+  conditions?: ConditionTreeResult[];
+}
+
 // === Import ===
 export interface ImportColumnMapping {
   csv_column: string;
@@ -1003,6 +1548,7 @@ export interface ProductVariant {
   name: string;
   attributes: Record<string, string>;
   price_override?: number;
+  cost_price?: number;
   stock_quantity: number;
   weight?: number;
   image_url?: string;
@@ -1018,6 +1564,7 @@ export interface CreateVariantRequest {
   name: string;
   attributes?: Record<string, string>;
   price_override?: number;
+  cost_price?: number;
   stock_quantity: number;
   weight?: number;
   image_url?: string;
@@ -1031,6 +1578,7 @@ export interface UpdateVariantRequest {
   name?: string;
   attributes?: Record<string, string>;
   price_override?: number;
+  cost_price?: number;
   stock_quantity?: number;
   weight?: number;
   image_url?: string;
@@ -1090,6 +1638,52 @@ export interface UpsertWarehouseStockRequest {
   quantity: number;
   reserved: number;
   min_stock: number;
+  reason?: string;
+}
+
+export type StockMovementSource = "opening" | "manual" | "warehouse_document" | "stocktake";
+
+export interface StockMovement {
+  id: string;
+  tenant_id: string;
+  warehouse_id: string;
+  product_id: string;
+  variant_id?: string;
+  source: StockMovementSource;
+  document_id?: string;
+  stocktake_id?: string;
+  delta: number;
+  quantity_before: number;
+  quantity_after: number;
+  actor_id?: string;
+  note?: string;
+  created_at: string;
+  warehouse_name?: string;
+  document_number?: string;
+  document_type?: string;
+  actor_name?: string;
+}
+
+export interface StockMovementParams extends PaginationParams {
+  warehouse_id?: string;
+  variant_id?: string;
+  from?: string;
+  to?: string;
+}
+
+export interface StockAtRow {
+  warehouse_id: string;
+  warehouse_name: string;
+  variant_id?: string;
+  variant_name?: string;
+  quantity: number;
+}
+
+export interface ProductStockAt {
+  product_id: string;
+  at: string;
+  rows: StockAtRow[];
+  quantity: number;
 }
 
 export interface WarehouseListParams extends PaginationParams {
@@ -1099,8 +1693,72 @@ export interface WarehouseListParams extends PaginationParams {
 export interface WarehouseStockListParams extends PaginationParams {}
 
 // === Inventory Settings ===
+export type CostingMethod = "fifo" | "weighted_average";
+
 export interface InventorySettings {
   strict_mode: boolean;
+  costing_method?: CostingMethod;
+}
+
+// === Inventory Costing ===
+export interface InventoryValuationParams {
+  at?: string;
+  warehouse_id?: string;
+}
+
+export interface InventoryValuationRow {
+  warehouse_id: string;
+  warehouse_name: string;
+  product_id: string;
+  variant_id?: string;
+  sku: string;
+  name: string;
+  quantity: number;
+  value: number;
+  unit_cost: number | null;
+}
+
+export interface InventoryValuationTotal {
+  warehouse_id: string;
+  warehouse_name: string;
+  quantity: number;
+  value: number;
+}
+
+export interface InventoryValuationReport {
+  at: string;
+  method: CostingMethod;
+  rows: InventoryValuationRow[];
+  warehouses: InventoryValuationTotal[];
+  quantity: number;
+  value: number;
+}
+
+export type COGSGroupBy = "document" | "product" | "warehouse";
+
+export interface COGSParams {
+  group_by: COGSGroupBy;
+  from?: string;
+  to?: string;
+  warehouse_id?: string;
+}
+
+export interface COGSRow {
+  key: string;
+  label: string;
+  warehouse?: string;
+  date?: string;
+  documents: number;
+  quantity: number;
+  cost: number;
+  estimated: boolean;
+}
+
+export interface COGSReport {
+  group_by: COGSGroupBy;
+  rows: COGSRow[];
+  quantity: number;
+  cost: number;
 }
 
 // === Product Import ===
@@ -1203,6 +1861,74 @@ export interface SplitOrderRequest {
   notes?: string;
 }
 
+// === Order Holds ===
+export type HoldReason =
+  | "fraud_check"
+  | "address_verification"
+  | "awaiting_payment"
+  | "out_of_stock"
+  | "customer_request";
+
+export type HoldSource = "manual" | "automation" | "import";
+
+export interface OrderHold {
+  id: string;
+  tenant_id: string;
+  order_id: string;
+  reason: HoldReason;
+  note: string;
+  source: HoldSource;
+  created_by?: string;
+  created_at: string;
+  released_at?: string;
+  released_by?: string;
+  release_note: string;
+  auto_release: boolean;
+}
+
+export interface PlaceHoldRequest {
+  reason: HoldReason;
+  note?: string;
+}
+
+export interface ReleaseHoldRequest {
+  note?: string;
+}
+
+export interface HeldOrder {
+  order_id: string;
+  external_id?: string;
+  source: string;
+  status: string;
+  customer_name: string;
+  total_amount: number;
+  currency: string;
+  payment_status: string;
+  ordered_at?: string;
+  held_since: string;
+  holds: OrderHold[];
+}
+
+export interface HeldOrderListParams extends PaginationParams {
+  reason?: HoldReason;
+  source?: HoldSource;
+}
+
+export interface OrderHoldSettings {
+  import_checks: HoldReason[];
+}
+
+export interface OrderRiskSettings {
+  /** 0 never holds */
+  hold_threshold: number;
+  high_value_amount: number;
+  /** currency of high_value_amount; other order currencies are converted */
+  currency: string;
+  velocity_hours: number;
+  velocity_limit: number;
+  disposable_domains: string[];
+}
+
 // === Product Bundles ===
 export interface BundleComponent {
   id: string;
@@ -1444,11 +2170,19 @@ export interface PublicReturnResponse {
   created_at: string;
 }
 
+export interface PublicReturnLabel {
+  provider?: string;
+  tracking_number: string;
+  label_url?: string;
+  status?: string;
+}
+
 export interface PublicReturnStatus {
   id: string;
   status: string;
   reason: string;
   items: ReturnItem[];
+  return_label?: PublicReturnLabel | null;
   created_at: string;
   updated_at: string;
 }
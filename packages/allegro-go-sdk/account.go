@@ -77,6 +77,9 @@ func (s *AccountService) ListBilling(ctx context.Context, params *BillingParams)
 		if params.TypeGroup != "" {
 			v.Set("type.group", params.TypeGroup)
 		}
+		if params.OrderID != "" {
+			v.Set("order.id", params.OrderID)
+		}
 		if encoded := v.Encode(); encoded != "" {
 			path += "?" + encoded
 		}
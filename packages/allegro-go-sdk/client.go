@@ -54,6 +54,7 @@ type Client struct {
 	DeliverySettings   *DeliverySettingsService
 	AfterSales         *AfterSalesService
 	SizeTables         *SizeTableService
+	OfferVariants      *OfferVariantService
 }
 
 // Option configures a Client.
@@ -97,6 +98,7 @@ func NewClient(clientID, clientSecret string, opts ...Option) *Client {
 	c.DeliverySettings = &DeliverySettingsService{client: c}
 	c.AfterSales = &AfterSalesService{client: c}
 	c.SizeTables = &SizeTableService{client: c}
+	c.OfferVariants = &OfferVariantService{client: c}
 
 	return c
 }
@@ -80,3 +80,13 @@ func (s *MessageService) SendMessage(ctx context.Context, threadID string, msg S
 	}
 	return &result, nil
 }
+
+// CreateMessage sends a message to a user. Allegro adds it to the thread
+// with that user, creating the thread when there is none.
+func (s *MessageService) CreateMessage(ctx context.Context, msg NewMessageRequest) (*Message, error) {
+	var result Message
+	if err := s.client.do(ctx, "POST", "/messaging/messages", msg, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
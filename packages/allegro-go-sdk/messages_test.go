@@ -0,0 +1,53 @@
+package allegro
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMessagesCreateMessage(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/messaging/messages" {
+			t.Errorf("path = %q, want /messaging/messages", r.URL.Path)
+		}
+		if r.Method != http.MethodPost {
+			t.Errorf("method = %q, want POST", r.Method)
+		}
+		var body NewMessageRequest
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		if body.Recipient.Login != "jan" {
+			t.Errorf("recipient = %q, want jan", body.Recipient.Login)
+		}
+		if body.Order == nil || body.Order.ID != "order-1" {
+			t.Errorf("order = %+v, want order-1", body.Order)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id": "msg-1", "text": "Dziekujemy", "author": {"login": "shop", "isInterlocutor": false}, "type": "REGULAR"}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient("id", "secret",
+		WithBaseURL(srv.URL),
+		WithHTTPClient(srv.Client()),
+	)
+	defer c.Close()
+
+	msg, err := c.Messages.CreateMessage(context.Background(), NewMessageRequest{
+		Recipient: MessageRecipient{Login: "jan"},
+		Text:      "Dziekujemy",
+		Order:     &MessageOrder{ID: "order-1"},
+	})
+	if err != nil {
+		t.Fatalf("Messages.CreateMessage error: %v", err)
+	}
+	if msg.ID != "msg-1" {
+		t.Errorf("ID = %q, want msg-1", msg.ID)
+	}
+}
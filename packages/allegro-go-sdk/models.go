@@ -294,6 +294,8 @@ type BillingParams struct {
 	Limit     int
 	Offset    int
 	TypeGroup string
+	// OrderID limits the entries to those charged for one checkout form.
+	OrderID string
 }
 
 // BillingList represents a paginated list of billing entries.
@@ -304,10 +306,16 @@ type BillingList struct {
 
 // BillingEntry represents a single billing entry.
 type BillingEntry struct {
-	ID         string      `json:"id"`
-	Type       BillingType `json:"type"`
-	Amount     Amount      `json:"amount"`
-	OccurredAt string      `json:"occurredAt"`
+	ID         string        `json:"id"`
+	Type       BillingType   `json:"type"`
+	Amount     Amount        `json:"amount"`
+	OccurredAt string        `json:"occurredAt"`
+	Order      *BillingOrder `json:"order,omitempty"`
+}
+
+// BillingOrder references the order a billing entry was charged for.
+type BillingOrder struct {
+	ID string `json:"id"`
 }
 
 // BillingType describes the type of a billing entry.
@@ -536,6 +544,24 @@ type SendMessageRequest struct {
 	Text string `json:"text"`
 }
 
+// NewMessageRequest is the request body for writing to a user outside an
+// existing thread, optionally about one of their orders.
+type NewMessageRequest struct {
+	Recipient MessageRecipient `json:"recipient"`
+	Text      string           `json:"text"`
+	Order     *MessageOrder    `json:"order,omitempty"`
+}
+
+// MessageRecipient identifies the user a new message is sent to.
+type MessageRecipient struct {
+	Login string `json:"login"`
+}
+
+// MessageOrder links a new message to a checkout form.
+type MessageOrder struct {
+	ID string `json:"id"`
+}
+
 // --- Customer return models ---
 
 // ListReturnsParams are the optional parameters for listing customer returns.
@@ -639,11 +665,17 @@ type RefundLineItem struct {
 	Amount   Amount `json:"amount"`
 }
 
+// RefundDelivery is the refunded part of the delivery cost.
+type RefundDelivery struct {
+	Value Amount `json:"value"`
+}
+
 // CreateRefundRequest is the request body for creating a refund.
 type CreateRefundRequest struct {
 	Payment   RefundPayment    `json:"payment"`
 	Reason    string           `json:"reason"`
 	LineItems []RefundLineItem `json:"lineItems"`
+	Delivery  *RefundDelivery  `json:"delivery,omitempty"`
 }
 
 // --- Category models ---
@@ -1205,3 +1237,41 @@ type CreateSizeTableRequest struct {
 	Headers []SizeHeader `json:"headers"`
 	Values  [][]string   `json:"values"`
 }
+
+// --- Offer Variant Set models ---
+
+// ColorPatternParameterID is the special variant parameter ID for grouping offers by colour/pattern.
+const ColorPatternParameterID = "color/pattern"
+
+// OfferVariantSet represents an Allegro variant set grouping several offers
+// (e.g. sizes of the same garment) into one listing on the site.
+type OfferVariantSet struct {
+	ID         string                     `json:"id,omitempty"`
+	Name       string                     `json:"name"`
+	Parameters []OfferVariantSetParameter `json:"parameters"`
+	Offers     []OfferVariantSetOffer     `json:"offers"`
+}
+
+// OfferVariantSetParameter identifies a category parameter that differentiates the offers of a set.
+type OfferVariantSetParameter struct {
+	ID string `json:"id"`
+}
+
+// OfferVariantSetOffer is an offer belonging to a variant set.
+// ColorPattern groups offers when the set varies by ColorPatternParameterID.
+type OfferVariantSetOffer struct {
+	ID           string `json:"id"`
+	ColorPattern string `json:"colorPattern,omitempty"`
+}
+
+// OfferVariantSetList represents a list of the seller's variant sets.
+type OfferVariantSetList struct {
+	OfferVariants []OfferVariantSetSummary `json:"offerVariants"`
+	Count         int                      `json:"count"`
+}
+
+// OfferVariantSetSummary is a variant set entry in a list response.
+type OfferVariantSetSummary struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
@@ -0,0 +1,70 @@
+package allegro
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// OfferVariantService handles Allegro offer variant sets (zestawy wariantow),
+// which present several offers (e.g. sizes or colours) as one listing.
+type OfferVariantService struct {
+	client *Client
+}
+
+// List lists the seller's variant sets.
+// GET /sale/offer-variants
+func (s *OfferVariantService) List(ctx context.Context, userID string, offset, limit int) (*OfferVariantSetList, error) {
+	q := url.Values{}
+	q.Set("user.id", userID)
+	if offset > 0 {
+		q.Set("offset", strconv.Itoa(offset))
+	}
+	if limit > 0 {
+		q.Set("limit", strconv.Itoa(limit))
+	}
+	var result OfferVariantSetList
+	if err := s.client.do(ctx, "GET", "/sale/offer-variants?"+q.Encode(), nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// Get gets a single variant set.
+// GET /sale/offer-variants/{setId}
+func (s *OfferVariantService) Get(ctx context.Context, setID string) (*OfferVariantSet, error) {
+	var result OfferVariantSet
+	if err := s.client.do(ctx, "GET", fmt.Sprintf("/sale/offer-variants/%s", setID), nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// Create creates a new variant set.
+// POST /sale/offer-variants
+func (s *OfferVariantService) Create(ctx context.Context, set OfferVariantSet) (*OfferVariantSet, error) {
+	set.ID = ""
+	var result OfferVariantSet
+	if err := s.client.do(ctx, "POST", "/sale/offer-variants", set, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// Update replaces the offers and parameters of a variant set.
+// PUT /sale/offer-variants/{setId}
+func (s *OfferVariantService) Update(ctx context.Context, setID string, set OfferVariantSet) (*OfferVariantSet, error) {
+	set.ID = ""
+	var result OfferVariantSet
+	if err := s.client.do(ctx, "PUT", fmt.Sprintf("/sale/offer-variants/%s", setID), set, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// Delete deletes a variant set. The offers themselves are not affected.
+// DELETE /sale/offer-variants/{setId}
+func (s *OfferVariantService) Delete(ctx context.Context, setID string) error {
+	return s.client.do(ctx, "DELETE", fmt.Sprintf("/sale/offer-variants/%s", setID), nil, nil)
+}
@@ -2,6 +2,7 @@ package allegro
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -138,3 +139,50 @@ func TestOffersGet(t *testing.T) {
 		t.Errorf("Name = %q, want %q", offer.Name, "Super Widget")
 	}
 }
+
+func TestOfferVariantsCreate(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/sale/offer-variants" {
+			t.Errorf("%s %s, want POST /sale/offer-variants", r.Method, r.URL.Path)
+		}
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		if _, ok := body["id"]; ok {
+			t.Error("id must not be sent on create")
+		}
+		if offers, _ := body["offers"].([]any); len(offers) != 2 {
+			t.Errorf("offers = %v, want 2 entries", body["offers"])
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{
+			"id": "set-1",
+			"name": "Koszulka",
+			"parameters": [{"id": "236"}],
+			"offers": [{"id": "off-1"}, {"id": "off-2"}]
+		}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient("id", "secret",
+		WithBaseURL(srv.URL),
+		WithHTTPClient(srv.Client()),
+	)
+	defer c.Close()
+
+	set, err := c.OfferVariants.Create(context.Background(), OfferVariantSet{
+		ID:         "ignored",
+		Name:       "Koszulka",
+		Parameters: []OfferVariantSetParameter{{ID: "236"}},
+		Offers:     []OfferVariantSetOffer{{ID: "off-1"}, {ID: "off-2"}},
+	})
+	if err != nil {
+		t.Fatalf("OfferVariants.Create error: %v", err)
+	}
+	if set.ID != "set-1" || len(set.Offers) != 2 {
+		t.Errorf("set = %+v", set)
+	}
+}
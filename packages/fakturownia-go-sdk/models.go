@@ -33,6 +33,10 @@ type InvoiceRequestData struct {
 	Currency          string            `json:"currency,omitempty"`
 	Description       string            `json:"description,omitempty"`
 	Positions         []InvoicePosition `json:"positions"`
+	// Correction invoices (kind "correction") reference the corrected invoice.
+	InvoiceID         *int              `json:"invoice_id,omitempty"`
+	FromInvoiceID     *int              `json:"from_invoice_id,omitempty"`
+	CorrectionReason  string            `json:"correction_reason,omitempty"`
 }
 
 // InvoiceResponse is the response from Fakturownia after creating/getting an invoice.
@@ -19,7 +19,10 @@ const (
 
 // CreateShipmentRequest is the payload sent to create a new shipment.
 type CreateShipmentRequest struct {
-	Receiver         Receiver          `json:"receiver"`
+	Receiver Receiver `json:"receiver"`
+	// Sender overrides the organization's default sender, e.g. the customer
+	// on a return shipment. It has the same shape as the receiver.
+	Sender           *Receiver         `json:"sender,omitempty"`
 	Parcels          []Parcel          `json:"parcels"`
 	Service          ServiceType       `json:"service"`
 	Reference        string            `json:"reference,omitempty"`
@@ -37,10 +40,11 @@ type Receiver struct {
 
 // Address is a physical mailing address.
 type Address struct {
-	Street      string `json:"street"`
-	City        string `json:"city"`
-	PostCode    string `json:"post_code"`
-	CountryCode string `json:"country_code"`
+	Street         string `json:"street"`
+	BuildingNumber string `json:"building_number,omitempty"`
+	City           string `json:"city"`
+	PostCode       string `json:"post_code"`
+	CountryCode    string `json:"country_code"`
 }
 
 // Parcel describes a parcel to ship.
@@ -3,9 +3,15 @@
 //
 // Standalone package licensed under MIT.
 //
-// Features (planned):
+// Features:
 //   - Order status transitions with validation
 //   - Allowed transitions map
+//   - Tenant-defined workflows: custom states mapped to status categories,
+//     with guard conditions (e.g. paid) checked on entering a state
+//   - Order holds: concurrent hold reasons that block fulfilment, with
+//     automatic release when payment or stock arrives
+//   - Return state machine with refund and exchange flows, partial refunds
+//     and side effects (restock, refund, correction invoice, replacement order)
 //   - Domain events (OrderStatusChanged, etc.)
-//   - Side effects (shipped_at, delivered_at timestamps)
+//   - Side effects (shipped_at, delivered_at timestamps) keyed off categories
 package engine
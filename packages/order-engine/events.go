@@ -8,6 +8,7 @@ type EventType string
 const (
 	EventOrderStatusChanged    EventType = "order.status_changed"
 	EventShipmentStatusChanged EventType = "shipment.status_changed"
+	EventReturnStatusChanged   EventType = "return.status_changed"
 )
 
 // Event represents a domain event emitted by a state transition.
@@ -29,10 +30,17 @@ type ShipmentStatusChanged struct {
 	To   ShipmentStatus
 }
 
+// ReturnStatusChanged is the payload for return transition events.
+type ReturnStatusChanged struct {
+	From ReturnStatus
+	To   ReturnStatus
+}
+
 // OrderTransitionResult is returned by TransitionOrder on success.
 type OrderTransitionResult struct {
 	From           OrderStatus
 	To             OrderStatus
+	Category       StatusCategory // category of the target state
 	Event          Event
 	SetShippedAt   *time.Time // non-nil when transitioning to shipped
 	SetDeliveredAt *time.Time // non-nil when transitioning to delivered
@@ -44,3 +52,21 @@ type ShipmentTransitionResult struct {
 	To    ShipmentStatus
 	Event Event
 }
+
+// ReturnTransitionResult is returned by TransitionReturn on success. The side
+// effects are executed by the caller.
+type ReturnTransitionResult struct {
+	From  ReturnStatus
+	To    ReturnStatus
+	Event Event
+	// Restock puts the resellable received lines back into stock.
+	Restock bool
+	// Refund is the amount to refund now; non-zero for refund transitions.
+	Refund float64
+	// CorrectionInvoice issues a correction of the order's invoice for Refund.
+	CorrectionInvoice bool
+	// CreateReplacementOrder creates the replacement order of an exchange.
+	CreateReplacementOrder bool
+	SetReceivedAt          *time.Time // non-nil when transitioning to received
+	SetRefundedAt          *time.Time // non-nil when a refund is made
+}
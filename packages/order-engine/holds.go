@@ -0,0 +1,68 @@
+package engine
+
+import "errors"
+
+var ErrOrderHeld = errors.New("order is on hold")
+
+// HoldReason is why an order is held. An order may have several holds at
+// once, each with its own reason; it stays blocked until all are released.
+type HoldReason string
+
+const (
+	HoldFraudCheck          HoldReason = "fraud_check"
+	HoldAddressVerification HoldReason = "address_verification"
+	HoldAwaitingPayment     HoldReason = "awaiting_payment"
+	HoldOutOfStock          HoldReason = "out_of_stock"
+	HoldCustomerRequest     HoldReason = "customer_request"
+)
+
+var knownHoldReasons = map[HoldReason]bool{
+	HoldFraudCheck:          true,
+	HoldAddressVerification: true,
+	HoldAwaitingPayment:     true,
+	HoldOutOfStock:          true,
+	HoldCustomerRequest:     true,
+}
+
+// Valid returns true if the reason is a recognized hold reason.
+func (r HoldReason) Valid() bool {
+	return knownHoldReasons[r]
+}
+
+// HoldFacts describes the held order, for deciding whether the condition of
+// a hold has cleared.
+type HoldFacts struct {
+	Paid bool
+	// InStock is true when every order line is covered by stock.
+	InStock bool
+}
+
+// AutoReleases reports whether holds with this reason are released
+// automatically once their condition clears. Fraud checks, address
+// verification and customer requests need someone to release them.
+func (r HoldReason) AutoReleases() bool {
+	return r == HoldAwaitingPayment || r == HoldOutOfStock
+}
+
+// Cleared reports whether the condition of an automatically released hold
+// has cleared: the order is paid, or its lines are in stock.
+func (r HoldReason) Cleared(facts HoldFacts) bool {
+	switch r {
+	case HoldAwaitingPayment:
+		return facts.Paid
+	case HoldOutOfStock:
+		return facts.InStock
+	}
+	return false
+}
+
+// BlockedByHolds reports whether a held order may not enter states of this
+// category: holds stop fulfilment, but the order can still be put on hold,
+// cancelled or refunded.
+func (c StatusCategory) BlockedByHolds() bool {
+	switch c {
+	case CategoryProcessing, CategoryShipped, CategoryDelivered, CategoryCompleted:
+		return true
+	}
+	return false
+}
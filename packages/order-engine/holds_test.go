@@ -0,0 +1,63 @@
+package engine
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestHoldReason_Valid(t *testing.T) {
+	for _, r := range []HoldReason{HoldFraudCheck, HoldAddressVerification, HoldAwaitingPayment, HoldOutOfStock, HoldCustomerRequest} {
+		if !r.Valid() {
+			t.Errorf("%s.Valid() = false; want true", r)
+		}
+	}
+	if HoldReason("bogus").Valid() {
+		t.Error("bogus.Valid() = true; want false")
+	}
+}
+
+func TestHoldReason_Cleared(t *testing.T) {
+	tests := []struct {
+		reason HoldReason
+		facts  HoldFacts
+		want   bool
+	}{
+		{HoldAwaitingPayment, HoldFacts{Paid: true}, true},
+		{HoldAwaitingPayment, HoldFacts{InStock: true}, false},
+		{HoldOutOfStock, HoldFacts{InStock: true}, true},
+		{HoldOutOfStock, HoldFacts{Paid: true}, false},
+		{HoldFraudCheck, HoldFacts{Paid: true, InStock: true}, false},
+		{HoldAddressVerification, HoldFacts{Paid: true, InStock: true}, false},
+		{HoldCustomerRequest, HoldFacts{Paid: true, InStock: true}, false},
+	}
+	for _, tc := range tests {
+		if got := tc.reason.Cleared(tc.facts); got != tc.want {
+			t.Errorf("%s.Cleared(%+v) = %v; want %v", tc.reason, tc.facts, got, tc.want)
+		}
+		if tc.want && !tc.reason.AutoReleases() {
+			t.Errorf("%s clears but AutoReleases() = false", tc.reason)
+		}
+	}
+}
+
+func TestWorkflowTransition_Held(t *testing.T) {
+	w := customWorkflow(t)
+	now := time.Now()
+	held := OrderFacts{Paid: true, Held: true}
+
+	if _, err := w.Transition("new", "packing", held, now); !errors.Is(err, ErrOrderHeld) {
+		t.Errorf("new -> packing while held: error = %v; want ErrOrderHeld", err)
+	}
+	for _, to := range []OrderStatus{"awaiting_payment", "cancelled"} {
+		if _, err := w.Transition("new", to, held, now); err != nil {
+			t.Errorf("new -> %s while held: %v", to, err)
+		}
+	}
+	if _, err := w.ForceTransition("new", "packing", now); err != nil {
+		t.Errorf("forced new -> packing while held: %v", err)
+	}
+	if _, err := w.Transition("new", "packing", OrderFacts{Paid: true}, now); err != nil {
+		t.Errorf("new -> packing after release: %v", err)
+	}
+}
@@ -0,0 +1,120 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+)
+
+var ErrInvalidRefund = errors.New("invalid refund amount")
+
+// allowedReturnTransitions maps each return status to its allowed targets.
+// A partially refunded return stays partially refunded until the refunds add
+// up to the amount due.
+var allowedReturnTransitions = map[ReturnStatus][]ReturnStatus{
+	ReturnRequested:         {ReturnApproved, ReturnRejected, ReturnCancelled},
+	ReturnApproved:          {ReturnReceived, ReturnCancelled},
+	ReturnReceived:          {ReturnRefunded, ReturnPartiallyRefunded, ReturnExchanged, ReturnCancelled},
+	ReturnPartiallyRefunded: {ReturnPartiallyRefunded, ReturnRefunded},
+	ReturnRejected:          {},
+	ReturnRefunded:          {},
+	ReturnExchanged:         {},
+	ReturnCancelled:         {},
+}
+
+// CanTransitionReturn reports whether a return can move from one status to another.
+func CanTransitionReturn(from, to ReturnStatus) bool {
+	return slices.Contains(allowedReturnTransitions[from], to)
+}
+
+// ReturnFacts describes the return being transitioned, for evaluating guards
+// and deciding side effects.
+type ReturnFacts struct {
+	Type ReturnType
+	// HasResellable is true when received lines are in resellable condition.
+	HasResellable bool
+	// RefundDue is the refund calculated at receipt; Refunded the part of it
+	// already refunded and RefundAmount the amount of this refund.
+	RefundDue    float64
+	Refunded     float64
+	RefundAmount float64
+	// Invoiced is true when the order has an issued invoice to correct.
+	Invoiced bool
+}
+
+// remaining is the part of the refund due that is not refunded yet.
+func (f ReturnFacts) remaining() float64 {
+	return math.Max(0, roundCents(f.RefundDue-f.Refunded))
+}
+
+// RefundTarget returns the status a refund of facts.RefundAmount leads to:
+// refunded when it settles the remaining amount, partially refunded otherwise.
+func RefundTarget(facts ReturnFacts) ReturnStatus {
+	if roundCents(facts.RefundAmount) >= facts.remaining() {
+		return ReturnRefunded
+	}
+	return ReturnPartiallyRefunded
+}
+
+// TransitionReturn validates and performs a return status transition. Refunds
+// are only made on refund returns and replacement orders on exchange returns;
+// a refund may not exceed the remaining amount due.
+func TransitionReturn(from, to ReturnStatus, facts ReturnFacts, now time.Time) (ReturnTransitionResult, error) {
+	if !from.Valid() {
+		return ReturnTransitionResult{}, fmt.Errorf("%w: %q", ErrUnknownStatus, from)
+	}
+	if !to.Valid() {
+		return ReturnTransitionResult{}, fmt.Errorf("%w: %q", ErrUnknownStatus, to)
+	}
+	if !CanTransitionReturn(from, to) {
+		return ReturnTransitionResult{}, fmt.Errorf("%w: %s -> %s", ErrInvalidTransition, from, to)
+	}
+
+	result := ReturnTransitionResult{
+		From: from,
+		To:   to,
+		Event: Event{
+			Type:      EventReturnStatusChanged,
+			Timestamp: now,
+			Payload:   ReturnStatusChanged{From: from, To: to},
+		},
+	}
+
+	switch to {
+	case ReturnReceived:
+		result.Restock = facts.HasResellable
+		result.SetReceivedAt = &now
+	case ReturnRefunded, ReturnPartiallyRefunded:
+		if facts.Type == ReturnTypeExchange {
+			return ReturnTransitionResult{}, fmt.Errorf("%w: exchange returns are settled with a replacement order", ErrGuardFailed)
+		}
+		amount := roundCents(facts.RefundAmount)
+		remaining := facts.remaining()
+		switch {
+		case amount < 0:
+			return ReturnTransitionResult{}, fmt.Errorf("%w: amount must be non-negative", ErrInvalidRefund)
+		case amount > remaining:
+			return ReturnTransitionResult{}, fmt.Errorf("%w: %.2f exceeds the remaining %.2f", ErrInvalidRefund, amount, remaining)
+		case amount == 0 && remaining > 0:
+			return ReturnTransitionResult{}, fmt.Errorf("%w: amount must be positive", ErrInvalidRefund)
+		case RefundTarget(facts) != to:
+			return ReturnTransitionResult{}, fmt.Errorf("%w: a refund of %.2f of the remaining %.2f leaves the return %s", ErrInvalidRefund, amount, remaining, RefundTarget(facts))
+		}
+		result.Refund = amount
+		result.CorrectionInvoice = facts.Invoiced && amount > 0
+		result.SetRefundedAt = &now
+	case ReturnExchanged:
+		if facts.Type != ReturnTypeExchange {
+			return ReturnTransitionResult{}, fmt.Errorf("%w: only exchange returns get a replacement order", ErrGuardFailed)
+		}
+		result.CreateReplacementOrder = true
+	}
+
+	return result, nil
+}
+
+func roundCents(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,146 @@
+package engine
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestAllowedReturnTransitions(t *testing.T) {
+	allowed := []struct {
+		from, to ReturnStatus
+	}{
+		{ReturnRequested, ReturnApproved},
+		{ReturnRequested, ReturnRejected},
+		{ReturnRequested, ReturnCancelled},
+		{ReturnApproved, ReturnReceived},
+		{ReturnApproved, ReturnCancelled},
+		{ReturnReceived, ReturnRefunded},
+		{ReturnReceived, ReturnPartiallyRefunded},
+		{ReturnReceived, ReturnExchanged},
+		{ReturnReceived, ReturnCancelled},
+		{ReturnPartiallyRefunded, ReturnPartiallyRefunded},
+		{ReturnPartiallyRefunded, ReturnRefunded},
+	}
+	for _, tc := range allowed {
+		if !CanTransitionReturn(tc.from, tc.to) {
+			t.Errorf("CanTransitionReturn(%s, %s) = false; want true", tc.from, tc.to)
+		}
+	}
+
+	disallowed := []struct {
+		from, to ReturnStatus
+	}{
+		{ReturnRequested, ReturnReceived},
+		{ReturnApproved, ReturnRefunded},
+		{ReturnRefunded, ReturnRequested},
+		{ReturnRejected, ReturnApproved},
+		{ReturnExchanged, ReturnRefunded},
+		{ReturnPartiallyRefunded, ReturnCancelled},
+	}
+	for _, tc := range disallowed {
+		if CanTransitionReturn(tc.from, tc.to) {
+			t.Errorf("CanTransitionReturn(%s, %s) = true; want false", tc.from, tc.to)
+		}
+	}
+}
+
+func TestTransitionReturn_Received(t *testing.T) {
+	now := time.Now()
+
+	result, err := TransitionReturn(ReturnApproved, ReturnReceived, ReturnFacts{HasResellable: true}, now)
+	if err != nil {
+		t.Fatalf("TransitionReturn: %v", err)
+	}
+	if !result.Restock {
+		t.Error("Restock = false; want true")
+	}
+	if result.SetReceivedAt == nil {
+		t.Error("SetReceivedAt = nil; want timestamp")
+	}
+	if result.Event.Type != EventReturnStatusChanged {
+		t.Errorf("Event.Type = %s; want %s", result.Event.Type, EventReturnStatusChanged)
+	}
+
+	result, err = TransitionReturn(ReturnApproved, ReturnReceived, ReturnFacts{}, now)
+	if err != nil {
+		t.Fatalf("TransitionReturn: %v", err)
+	}
+	if result.Restock {
+		t.Error("Restock = true without resellable lines; want false")
+	}
+}
+
+func TestTransitionReturn_PartialRefunds(t *testing.T) {
+	now := time.Now()
+	facts := ReturnFacts{Type: ReturnTypeRefund, RefundDue: 100, RefundAmount: 40, Invoiced: true}
+
+	if got := RefundTarget(facts); got != ReturnPartiallyRefunded {
+		t.Fatalf("RefundTarget = %s; want partially_refunded", got)
+	}
+	result, err := TransitionReturn(ReturnReceived, ReturnPartiallyRefunded, facts, now)
+	if err != nil {
+		t.Fatalf("first refund: %v", err)
+	}
+	if result.Refund != 40 || !result.CorrectionInvoice || result.SetRefundedAt == nil {
+		t.Errorf("result = %+v; want refund 40 with correction invoice", result)
+	}
+
+	facts.Refunded, facts.RefundAmount = 40, 60
+	if got := RefundTarget(facts); got != ReturnRefunded {
+		t.Fatalf("RefundTarget = %s; want refunded", got)
+	}
+	if _, err := TransitionReturn(ReturnPartiallyRefunded, ReturnRefunded, facts, now); err != nil {
+		t.Fatalf("final refund: %v", err)
+	}
+
+	facts.RefundAmount = 61
+	if _, err := TransitionReturn(ReturnPartiallyRefunded, ReturnRefunded, facts, now); !errors.Is(err, ErrInvalidRefund) {
+		t.Errorf("over-refund: error = %v; want ErrInvalidRefund", err)
+	}
+
+	facts.RefundAmount = 10
+	if _, err := TransitionReturn(ReturnPartiallyRefunded, ReturnRefunded, facts, now); !errors.Is(err, ErrInvalidRefund) {
+		t.Errorf("partial amount to refunded: error = %v; want ErrInvalidRefund", err)
+	}
+}
+
+func TestTransitionReturn_ZeroRefundClosesReturn(t *testing.T) {
+	result, err := TransitionReturn(ReturnReceived, ReturnRefunded, ReturnFacts{Type: ReturnTypeRefund, Invoiced: true}, time.Now())
+	if err != nil {
+		t.Fatalf("TransitionReturn: %v", err)
+	}
+	if result.CorrectionInvoice {
+		t.Error("CorrectionInvoice = true for a zero refund; want false")
+	}
+}
+
+func TestTransitionReturn_ExchangeGuards(t *testing.T) {
+	now := time.Now()
+
+	result, err := TransitionReturn(ReturnReceived, ReturnExchanged, ReturnFacts{Type: ReturnTypeExchange}, now)
+	if err != nil {
+		t.Fatalf("exchange: %v", err)
+	}
+	if !result.CreateReplacementOrder {
+		t.Error("CreateReplacementOrder = false; want true")
+	}
+
+	if _, err := TransitionReturn(ReturnReceived, ReturnExchanged, ReturnFacts{Type: ReturnTypeRefund}, now); !errors.Is(err, ErrGuardFailed) {
+		t.Errorf("exchange of refund return: error = %v; want ErrGuardFailed", err)
+	}
+	facts := ReturnFacts{Type: ReturnTypeExchange, RefundDue: 50, RefundAmount: 50}
+	if _, err := TransitionReturn(ReturnReceived, ReturnRefunded, facts, now); !errors.Is(err, ErrGuardFailed) {
+		t.Errorf("refund of exchange return: error = %v; want ErrGuardFailed", err)
+	}
+}
+
+func TestTransitionReturn_Invalid(t *testing.T) {
+	now := time.Now()
+	if _, err := TransitionReturn(ReturnRequested, ReturnReceived, ReturnFacts{}, now); !errors.Is(err, ErrInvalidTransition) {
+		t.Errorf("error = %v; want ErrInvalidTransition", err)
+	}
+	if _, err := TransitionReturn("bogus", ReturnApproved, ReturnFacts{}, now); !errors.Is(err, ErrUnknownStatus) {
+		t.Errorf("error = %v; want ErrUnknownStatus", err)
+	}
+}
@@ -89,3 +89,57 @@ func ParseShipmentStatus(s string) (ShipmentStatus, error) {
 	}
 	return ss, nil
 }
+
+// ReturnStatus represents the lifecycle state of a return.
+type ReturnStatus string
+
+const (
+	ReturnRequested         ReturnStatus = "requested"
+	ReturnApproved          ReturnStatus = "approved"
+	ReturnRejected          ReturnStatus = "rejected"
+	ReturnReceived          ReturnStatus = "received"
+	ReturnPartiallyRefunded ReturnStatus = "partially_refunded"
+	ReturnRefunded          ReturnStatus = "refunded"
+	ReturnExchanged         ReturnStatus = "exchanged"
+	ReturnCancelled         ReturnStatus = "cancelled"
+)
+
+var knownReturnStatuses = map[ReturnStatus]bool{
+	ReturnRequested:         true,
+	ReturnApproved:          true,
+	ReturnRejected:          true,
+	ReturnReceived:          true,
+	ReturnPartiallyRefunded: true,
+	ReturnRefunded:          true,
+	ReturnExchanged:         true,
+	ReturnCancelled:         true,
+}
+
+// Valid returns true if the status is a recognized return status.
+func (s ReturnStatus) Valid() bool {
+	return knownReturnStatuses[s]
+}
+
+// ParseReturnStatus converts a string to ReturnStatus, returning
+// ErrUnknownStatus if the value is not recognized.
+func ParseReturnStatus(s string) (ReturnStatus, error) {
+	rs := ReturnStatus(s)
+	if !rs.Valid() {
+		return "", fmt.Errorf("%w: %q", ErrUnknownStatus, s)
+	}
+	return rs, nil
+}
+
+// ReturnType is how a return is settled: with a refund or with a
+// replacement order.
+type ReturnType string
+
+const (
+	ReturnTypeRefund   ReturnType = "refund"
+	ReturnTypeExchange ReturnType = "exchange"
+)
+
+// Valid returns true if the type is a recognized return type.
+func (t ReturnType) Valid() bool {
+	return t == ReturnTypeRefund || t == ReturnTypeExchange
+}
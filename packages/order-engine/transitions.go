@@ -72,8 +72,9 @@ func TransitionOrder(from, to OrderStatus, now time.Time) (OrderTransitionResult
 	}
 
 	result := OrderTransitionResult{
-		From: from,
-		To:   to,
+		From:     from,
+		To:       to,
+		Category: DefaultCategory(to),
 		Event: Event{
 			Type:      EventOrderStatusChanged,
 			Timestamp: now,
@@ -0,0 +1,286 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+var (
+	ErrGuardFailed     = errors.New("transition guard not met")
+	ErrInvalidWorkflow = errors.New("invalid workflow")
+)
+
+// StatusCategory tells the rest of the system what a workflow state means.
+// Side effects and reporting key off the category, so tenant-defined states
+// behave like the built-in ones of the same category.
+type StatusCategory string
+
+const (
+	CategoryNew        StatusCategory = "new"
+	CategoryProcessing StatusCategory = "processing"
+	CategoryOnHold     StatusCategory = "on_hold"
+	CategoryShipped    StatusCategory = "shipped"
+	CategoryDelivered  StatusCategory = "delivered"
+	CategoryCompleted  StatusCategory = "completed"
+	CategoryCancelled  StatusCategory = "cancelled"
+	CategoryRefunded   StatusCategory = "refunded"
+)
+
+var knownCategories = map[StatusCategory]bool{
+	CategoryNew:        true,
+	CategoryProcessing: true,
+	CategoryOnHold:     true,
+	CategoryShipped:    true,
+	CategoryDelivered:  true,
+	CategoryCompleted:  true,
+	CategoryCancelled:  true,
+	CategoryRefunded:   true,
+}
+
+// Valid returns true if the category is a recognized status category.
+func (c StatusCategory) Valid() bool {
+	return knownCategories[c]
+}
+
+// Closed reports whether orders in this category are no longer sales:
+// cancelled and refunded orders.
+func (c StatusCategory) Closed() bool {
+	return c == CategoryCancelled || c == CategoryRefunded
+}
+
+// defaultCategories assigns a category to each built-in order status.
+var defaultCategories = map[OrderStatus]StatusCategory{
+	OrderNew:            CategoryNew,
+	OrderConfirmed:      CategoryProcessing,
+	OrderProcessing:     CategoryProcessing,
+	OrderReadyToShip:    CategoryProcessing,
+	OrderShipped:        CategoryShipped,
+	OrderInTransit:      CategoryShipped,
+	OrderOutForDelivery: CategoryShipped,
+	OrderDelivered:      CategoryDelivered,
+	OrderCompleted:      CategoryCompleted,
+	OrderOnHold:         CategoryOnHold,
+	OrderCancelled:      CategoryCancelled,
+	OrderRefunded:       CategoryRefunded,
+}
+
+// DefaultCategory returns the category of a built-in status. Other statuses
+// default to processing.
+func DefaultCategory(s OrderStatus) StatusCategory {
+	if c, ok := defaultCategories[s]; ok {
+		return c
+	}
+	return CategoryProcessing
+}
+
+// Guard is a condition an order must meet to enter a state.
+type Guard string
+
+const (
+	GuardPaid               Guard = "paid"
+	GuardHasShipment        Guard = "has_shipment"
+	GuardHasCustomerEmail   Guard = "has_customer_email"
+	GuardHasShippingAddress Guard = "has_shipping_address"
+)
+
+var knownGuards = map[Guard]bool{
+	GuardPaid:               true,
+	GuardHasShipment:        true,
+	GuardHasCustomerEmail:   true,
+	GuardHasShippingAddress: true,
+}
+
+// Valid returns true if the guard is a recognized guard.
+func (g Guard) Valid() bool {
+	return knownGuards[g]
+}
+
+// OrderFacts describes the order being transitioned, for evaluating guards.
+// Held is true while the order has active holds.
+type OrderFacts struct {
+	Paid               bool
+	HasShipment        bool
+	HasCustomerEmail   bool
+	HasShippingAddress bool
+	Held               bool
+}
+
+func (f OrderFacts) satisfies(g Guard) bool {
+	switch g {
+	case GuardPaid:
+		return f.Paid
+	case GuardHasShipment:
+		return f.HasShipment
+	case GuardHasCustomerEmail:
+		return f.HasCustomerEmail
+	case GuardHasShippingAddress:
+		return f.HasShippingAddress
+	}
+	return false
+}
+
+// State is an order status of a workflow. Requires lists the guards an order
+// must meet to enter the state.
+type State struct {
+	Key      OrderStatus
+	Category StatusCategory
+	Requires []Guard
+}
+
+// Workflow is an order state machine: its states and the allowed transitions
+// between them.
+type Workflow struct {
+	states      map[OrderStatus]State
+	transitions map[OrderStatus][]OrderStatus
+}
+
+// NewWorkflow builds a workflow, checking that states are unique, categories
+// and guards are recognized and transitions only reference known states.
+// States without a category get DefaultCategory.
+func NewWorkflow(states []State, transitions map[OrderStatus][]OrderStatus) (*Workflow, error) {
+	w := &Workflow{
+		states:      make(map[OrderStatus]State, len(states)),
+		transitions: make(map[OrderStatus][]OrderStatus, len(transitions)),
+	}
+	for _, s := range states {
+		if s.Key == "" {
+			return nil, fmt.Errorf("%w: status key is required", ErrInvalidWorkflow)
+		}
+		if _, ok := w.states[s.Key]; ok {
+			return nil, fmt.Errorf("%w: duplicate status key: %s", ErrInvalidWorkflow, s.Key)
+		}
+		if s.Category == "" {
+			s.Category = DefaultCategory(s.Key)
+		}
+		if !s.Category.Valid() {
+			return nil, fmt.Errorf("%w: unknown category %q for status %s", ErrInvalidWorkflow, s.Category, s.Key)
+		}
+		for _, g := range s.Requires {
+			if !g.Valid() {
+				return nil, fmt.Errorf("%w: unknown guard %q for status %s", ErrInvalidWorkflow, g, s.Key)
+			}
+		}
+		w.states[s.Key] = s
+	}
+
+	froms := make([]OrderStatus, 0, len(transitions))
+	for from := range transitions {
+		froms = append(froms, from)
+	}
+	slices.Sort(froms)
+	for _, from := range froms {
+		if _, ok := w.states[from]; !ok {
+			return nil, fmt.Errorf("%w: transition from unknown status: %s", ErrInvalidWorkflow, from)
+		}
+		for _, to := range transitions[from] {
+			if _, ok := w.states[to]; !ok {
+				return nil, fmt.Errorf("%w: transition to unknown status: %s", ErrInvalidWorkflow, to)
+			}
+		}
+		w.transitions[from] = slices.Clone(transitions[from])
+	}
+	return w, nil
+}
+
+// DefaultWorkflow returns the built-in order workflow, without guards.
+func DefaultWorkflow() *Workflow {
+	states := make([]State, 0, len(defaultCategories))
+	for s, c := range defaultCategories {
+		states = append(states, State{Key: s, Category: c})
+	}
+	w, err := NewWorkflow(states, allowedOrderTransitions)
+	if err != nil {
+		panic(err)
+	}
+	return w
+}
+
+// State returns a state of the workflow.
+func (w *Workflow) State(s OrderStatus) (State, bool) {
+	st, ok := w.states[s]
+	return st, ok
+}
+
+// Category returns the category of a state, or DefaultCategory for a status
+// that is not part of the workflow.
+func (w *Workflow) Category(s OrderStatus) StatusCategory {
+	if st, ok := w.states[s]; ok {
+		return st.Category
+	}
+	return DefaultCategory(s)
+}
+
+// CanTransition reports whether the workflow allows moving from one state to
+// another. Guards are not evaluated.
+func (w *Workflow) CanTransition(from, to OrderStatus) bool {
+	return slices.Contains(w.transitions[from], to)
+}
+
+// UnmetGuards returns the guards of the target state that facts do not meet.
+func (w *Workflow) UnmetGuards(to OrderStatus, facts OrderFacts) []Guard {
+	var unmet []Guard
+	for _, g := range w.states[to].Requires {
+		if !facts.satisfies(g) {
+			unmet = append(unmet, g)
+		}
+	}
+	return unmet
+}
+
+// Transition validates and performs a status transition: both states must be
+// known, the transition allowed and the guards of the target state met. A
+// held order cannot enter a state whose category is blocked by holds.
+func (w *Workflow) Transition(from, to OrderStatus, facts OrderFacts, now time.Time) (OrderTransitionResult, error) {
+	if _, ok := w.states[from]; !ok {
+		return OrderTransitionResult{}, fmt.Errorf("%w: %q", ErrUnknownStatus, from)
+	}
+	if _, ok := w.states[to]; !ok {
+		return OrderTransitionResult{}, fmt.Errorf("%w: %q", ErrUnknownStatus, to)
+	}
+	if !w.CanTransition(from, to) {
+		return OrderTransitionResult{}, fmt.Errorf("%w: %s -> %s", ErrInvalidTransition, from, to)
+	}
+	if facts.Held && w.states[to].Category.BlockedByHolds() {
+		return OrderTransitionResult{}, fmt.Errorf("%w: release its holds before moving it to %s", ErrOrderHeld, to)
+	}
+	if unmet := w.UnmetGuards(to, facts); len(unmet) > 0 {
+		return OrderTransitionResult{}, fmt.Errorf("%w: %s requires %s", ErrGuardFailed, to, unmet[0])
+	}
+	return w.result(from, to, now), nil
+}
+
+// ForceTransition moves an order to a known state, skipping the transition
+// map and guards. The current state may be unknown.
+func (w *Workflow) ForceTransition(from, to OrderStatus, now time.Time) (OrderTransitionResult, error) {
+	if _, ok := w.states[to]; !ok {
+		return OrderTransitionResult{}, fmt.Errorf("%w: %q", ErrUnknownStatus, to)
+	}
+	return w.result(from, to, now), nil
+}
+
+// result builds the transition result. Entering the shipped or delivered
+// category from another category sets the matching timestamp.
+func (w *Workflow) result(from, to OrderStatus, now time.Time) OrderTransitionResult {
+	fromCat, toCat := w.Category(from), w.Category(to)
+	result := OrderTransitionResult{
+		From:     from,
+		To:       to,
+		Category: toCat,
+		Event: Event{
+			Type:      EventOrderStatusChanged,
+			Timestamp: now,
+			Payload:   OrderStatusChanged{From: from, To: to},
+		},
+	}
+	if fromCat != toCat {
+		switch toCat {
+		case CategoryShipped:
+			result.SetShippedAt = &now
+		case CategoryDelivered:
+			result.SetDeliveredAt = &now
+		}
+	}
+	return result
+}
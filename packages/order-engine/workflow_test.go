@@ -0,0 +1,173 @@
+package engine
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func customWorkflow(t *testing.T) *Workflow {
+	t.Helper()
+	w, err := NewWorkflow([]State{
+		{Key: "new"},
+		{Key: "awaiting_payment", Category: CategoryOnHold},
+		{Key: "packing", Category: CategoryProcessing, Requires: []Guard{GuardPaid}},
+		{Key: "handed_to_courier", Category: CategoryShipped, Requires: []Guard{GuardHasShipment}},
+		{Key: "in_transit", Category: CategoryShipped},
+		{Key: "received", Category: CategoryDelivered},
+		{Key: "cancelled"},
+	}, map[OrderStatus][]OrderStatus{
+		"new":               {"awaiting_payment", "packing", "cancelled"},
+		"awaiting_payment":  {"packing", "cancelled"},
+		"packing":           {"handed_to_courier"},
+		"handed_to_courier": {"in_transit", "received"},
+		"in_transit":        {"received"},
+	})
+	if err != nil {
+		t.Fatalf("NewWorkflow: %v", err)
+	}
+	return w
+}
+
+func TestNewWorkflow_DefaultCategories(t *testing.T) {
+	w := customWorkflow(t)
+
+	tests := []struct {
+		status OrderStatus
+		want   StatusCategory
+	}{
+		{"new", CategoryNew},
+		{"cancelled", CategoryCancelled},
+		{"awaiting_payment", CategoryOnHold},
+		{"received", CategoryDelivered},
+		{"unknown_status", CategoryProcessing},
+	}
+	for _, tc := range tests {
+		if got := w.Category(tc.status); got != tc.want {
+			t.Errorf("Category(%s) = %s; want %s", tc.status, got, tc.want)
+		}
+	}
+}
+
+func TestNewWorkflow_Invalid(t *testing.T) {
+	tests := []struct {
+		name        string
+		states      []State
+		transitions map[OrderStatus][]OrderStatus
+	}{
+		{"empty key", []State{{Key: ""}}, nil},
+		{"duplicate key", []State{{Key: "new"}, {Key: "new"}}, nil},
+		{"unknown category", []State{{Key: "new", Category: "archived"}}, nil},
+		{"unknown guard", []State{{Key: "new", Requires: []Guard{"vip"}}}, nil},
+		{"transition from unknown", []State{{Key: "new"}}, map[OrderStatus][]OrderStatus{"bogus": {"new"}}},
+		{"transition to unknown", []State{{Key: "new"}}, map[OrderStatus][]OrderStatus{"new": {"bogus"}}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewWorkflow(tc.states, tc.transitions)
+			if !errors.Is(err, ErrInvalidWorkflow) {
+				t.Errorf("error = %v; want ErrInvalidWorkflow", err)
+			}
+		})
+	}
+}
+
+func TestDefaultWorkflow_MatchesTransitionMap(t *testing.T) {
+	w := DefaultWorkflow()
+	for from, targets := range allowedOrderTransitions {
+		for to := range knownOrderStatuses {
+			want := false
+			for _, target := range targets {
+				if target == to {
+					want = true
+				}
+			}
+			if got := w.CanTransition(from, to); got != want {
+				t.Errorf("CanTransition(%s, %s) = %v; want %v", from, to, got, want)
+			}
+		}
+	}
+}
+
+func TestWorkflowTransition_Guards(t *testing.T) {
+	w := customWorkflow(t)
+	now := time.Now()
+
+	_, err := w.Transition("new", "packing", OrderFacts{}, now)
+	if !errors.Is(err, ErrGuardFailed) {
+		t.Fatalf("unpaid order: error = %v; want ErrGuardFailed", err)
+	}
+
+	result, err := w.Transition("new", "packing", OrderFacts{Paid: true}, now)
+	if err != nil {
+		t.Fatalf("paid order: %v", err)
+	}
+	if result.Category != CategoryProcessing {
+		t.Errorf("Category = %s; want processing", result.Category)
+	}
+
+	if unmet := w.UnmetGuards("handed_to_courier", OrderFacts{Paid: true}); len(unmet) != 1 || unmet[0] != GuardHasShipment {
+		t.Errorf("UnmetGuards = %v; want [has_shipment]", unmet)
+	}
+}
+
+func TestWorkflowTransition_Errors(t *testing.T) {
+	w := customWorkflow(t)
+	now := time.Now()
+
+	if _, err := w.Transition("new", "received", OrderFacts{}, now); !errors.Is(err, ErrInvalidTransition) {
+		t.Errorf("new -> received: error = %v; want ErrInvalidTransition", err)
+	}
+	if _, err := w.Transition("shipped", "received", OrderFacts{}, now); !errors.Is(err, ErrUnknownStatus) {
+		t.Errorf("unknown from: error = %v; want ErrUnknownStatus", err)
+	}
+	if _, err := w.Transition("new", "shipped", OrderFacts{}, now); !errors.Is(err, ErrUnknownStatus) {
+		t.Errorf("unknown to: error = %v; want ErrUnknownStatus", err)
+	}
+}
+
+func TestWorkflowTransition_CategoryTimestamps(t *testing.T) {
+	w := customWorkflow(t)
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	result, err := w.Transition("packing", "handed_to_courier", OrderFacts{HasShipment: true}, now)
+	if err != nil {
+		t.Fatalf("packing -> handed_to_courier: %v", err)
+	}
+	if result.SetShippedAt == nil || !result.SetShippedAt.Equal(now) {
+		t.Errorf("SetShippedAt = %v; want %v", result.SetShippedAt, now)
+	}
+
+	// Moving within the shipped category keeps the original timestamp.
+	result, err = w.Transition("handed_to_courier", "in_transit", OrderFacts{}, now)
+	if err != nil {
+		t.Fatalf("handed_to_courier -> in_transit: %v", err)
+	}
+	if result.SetShippedAt != nil {
+		t.Errorf("SetShippedAt = %v; want nil", result.SetShippedAt)
+	}
+
+	result, err = w.Transition("in_transit", "received", OrderFacts{}, now)
+	if err != nil {
+		t.Fatalf("in_transit -> received: %v", err)
+	}
+	if result.SetDeliveredAt == nil {
+		t.Error("SetDeliveredAt = nil; want timestamp")
+	}
+}
+
+func TestWorkflowForceTransition(t *testing.T) {
+	w := customWorkflow(t)
+	now := time.Now()
+
+	result, err := w.ForceTransition("legacy_status", "received", now)
+	if err != nil {
+		t.Fatalf("ForceTransition: %v", err)
+	}
+	if result.SetDeliveredAt == nil {
+		t.Error("SetDeliveredAt = nil; want timestamp")
+	}
+	if _, err := w.ForceTransition("new", "bogus", now); !errors.Is(err, ErrUnknownStatus) {
+		t.Errorf("error = %v; want ErrUnknownStatus", err)
+	}
+}
@@ -4,12 +4,15 @@ import "time"
 
 // CreateShipmentRequest is the payload sent to create a new Orlen Paczka shipment.
 type CreateShipmentRequest struct {
-	Receiver    Receiver `json:"receiver"`
-	Parcel      Parcel   `json:"parcel"`
-	TargetPoint string   `json:"targetPoint"`
-	Reference   string   `json:"reference,omitempty"`
-	COD         *COD     `json:"cod,omitempty"`
-	Insurance   *Money   `json:"insurance,omitempty"`
+	Receiver Receiver `json:"receiver"`
+	// Sender overrides the account's default sender, e.g. the customer on a
+	// return shipment.
+	Sender      *Receiver `json:"sender,omitempty"`
+	Parcel      Parcel    `json:"parcel"`
+	TargetPoint string    `json:"targetPoint"`
+	Reference   string    `json:"reference,omitempty"`
+	COD         *COD      `json:"cod,omitempty"`
+	Insurance   *Money    `json:"insurance,omitempty"`
 }
 
 // Receiver contains recipient details.
@@ -0,0 +1,93 @@
+package prestashop
+
+import (
+	"context"
+	"net/url"
+)
+
+// AddressService handles lookups of addresses and related reference data
+// (customers, countries, currencies) needed to assemble an order.
+type AddressService struct {
+	client *Client
+}
+
+// Address is a customer's postal address.
+type Address struct {
+	ID          string `xml:"id"`
+	IDCustomer  string `xml:"id_customer"`
+	IDCountry   string `xml:"id_country"`
+	Company     string `xml:"company"`
+	Lastname    string `xml:"lastname"`
+	Firstname   string `xml:"firstname"`
+	Address1    string `xml:"address1"`
+	Address2    string `xml:"address2"`
+	Postcode    string `xml:"postcode"`
+	City        string `xml:"city"`
+	Phone       string `xml:"phone"`
+	PhoneMobile string `xml:"phone_mobile"`
+	VATNumber   string `xml:"vat_number"`
+}
+
+// Customer is a shop customer account.
+type Customer struct {
+	ID        string `xml:"id"`
+	Firstname string `xml:"firstname"`
+	Lastname  string `xml:"lastname"`
+	Email     string `xml:"email"`
+}
+
+// Country holds the ISO code of a configured country.
+type Country struct {
+	ID      string `xml:"id"`
+	ISOCode string `xml:"iso_code"`
+}
+
+// Currency holds the ISO code of a configured currency.
+type Currency struct {
+	ID      string `xml:"id"`
+	ISOCode string `xml:"iso_code"`
+}
+
+// Get retrieves a single address by ID.
+func (s *AddressService) Get(ctx context.Context, id string) (*Address, error) {
+	var result struct {
+		Address Address `xml:"address"`
+	}
+	if err := s.client.do(ctx, "GET", "/addresses/"+url.PathEscape(id), nil, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result.Address, nil
+}
+
+// GetCustomer retrieves a single customer by ID.
+func (s *AddressService) GetCustomer(ctx context.Context, id string) (*Customer, error) {
+	var result struct {
+		Customer Customer `xml:"customer"`
+	}
+	if err := s.client.do(ctx, "GET", "/customers/"+url.PathEscape(id), nil, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result.Customer, nil
+}
+
+// GetCountry retrieves a single country by ID.
+func (s *AddressService) GetCountry(ctx context.Context, id string) (*Country, error) {
+	var result struct {
+		Country Country `xml:"country"`
+	}
+	if err := s.client.do(ctx, "GET", "/countries/"+url.PathEscape(id), nil, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result.Country, nil
+}
+
+// GetCurrency retrieves a single currency by ID.
+func (s *AddressService) GetCurrency(ctx context.Context, id string) (*Currency, error) {
+	var result struct {
+		Currency Currency `xml:"currency"`
+	}
+	if err := s.client.do(ctx, "GET", "/currencies/"+url.PathEscape(id), nil, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result.Currency, nil
+}
@@ -0,0 +1,206 @@
+package prestashop
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Client is the PrestaShop Webservice API client.
+// Authentication uses HTTP Basic Auth with the webservice key as the username
+// and an empty password.
+type Client struct {
+	httpClient *http.Client
+	baseURL    string
+	apiKey     string
+
+	Orders       *OrderService
+	Stock        *StockService
+	Products     *ProductService
+	Combinations *CombinationService
+	Addresses    *AddressService
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// NewClient creates a new PrestaShop Webservice client.
+// shopURL is the store root URL (e.g. "https://sklep.pl"); the webservice is
+// resolved as shopURL + "/api".
+func NewClient(shopURL, apiKey string, opts ...Option) *Client {
+	c := &Client{
+		httpClient: http.DefaultClient,
+		baseURL:    strings.TrimRight(shopURL, "/") + "/api",
+		apiKey:     apiKey,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	c.Orders = &OrderService{client: c}
+	c.Stock = &StockService{client: c}
+	c.Products = &ProductService{client: c}
+	c.Combinations = &CombinationService{client: c}
+	c.Addresses = &AddressService{client: c}
+
+	return c
+}
+
+// WithHTTPClient sets a custom HTTP client.
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = hc
+	}
+}
+
+// WithBaseURL overrides the full webservice base URL (useful for testing).
+func WithBaseURL(u string) Option {
+	return func(c *Client) {
+		c.baseURL = strings.TrimRight(u, "/")
+	}
+}
+
+// do executes an authenticated request. A non-nil body is marshalled to XML and
+// wrapped in the <prestashop> root element; the response is decoded into result.
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body any, result any) error {
+	var payload []byte
+	if body != nil {
+		inner, err := xml.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("prestashop: marshal request body: %w", err)
+		}
+		var buf bytes.Buffer
+		buf.WriteString(xml.Header)
+		buf.WriteString("<prestashop>")
+		buf.Write(inner)
+		buf.WriteString("</prestashop>")
+		payload = buf.Bytes()
+	}
+
+	data, err := c.doRaw(ctx, method, path, query, payload)
+	if err != nil {
+		return err
+	}
+
+	if result != nil && len(bytes.TrimSpace(data)) > 0 {
+		if err := xml.Unmarshal(data, result); err != nil {
+			return fmt.Errorf("prestashop: decode response: %w", err)
+		}
+	}
+	return nil
+}
+
+// doRaw executes an authenticated request with a raw XML body and returns the raw response body.
+func (c *Client) doRaw(ctx context.Context, method, path string, query url.Values, payload []byte) ([]byte, error) {
+	fullURL := c.baseURL + path
+	if encoded := query.Encode(); encoded != "" {
+		fullURL += "?" + encoded
+	}
+
+	var bodyReader io.Reader
+	if payload != nil {
+		bodyReader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, fullURL, bodyReader)
+	if err != nil {
+		return nil, fmt.Errorf("prestashop: create request: %w", err)
+	}
+
+	req.SetBasicAuth(c.apiKey, "")
+	req.Header.Set("Accept", "application/xml")
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/xml")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("prestashop: execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("prestashop: read response: %w", err)
+	}
+
+	if resp.StatusCode >= 400 {
+		apiErr := &APIError{StatusCode: resp.StatusCode}
+		var errResp errorResponse
+		if err := xml.Unmarshal(data, &errResp); err == nil && len(errResp.Errors) > 0 {
+			apiErr.Code = strings.TrimSpace(errResp.Errors[0].Code)
+			apiErr.Message = strings.TrimSpace(errResp.Errors[0].Message)
+		} else {
+			apiErr.Message = http.StatusText(resp.StatusCode)
+		}
+		return nil, apiErr
+	}
+
+	return data, nil
+}
+
+// ListParams are the common parameters for listing webservice resources.
+type ListParams struct {
+	// Filter maps field names to webservice filter expressions,
+	// e.g. {"id_product": "[12]"} or {"date_upd": "[2025-01-01 00:00:00,2099-12-31 23:59:59]"}.
+	Filter map[string]string
+	// Sort is a sort expression such as "[date_upd_ASC]".
+	Sort   string
+	Limit  int
+	Offset int
+}
+
+// values converts ListParams into query parameters, always requesting full objects.
+func (p *ListParams) values() url.Values {
+	v := url.Values{}
+	v.Set("display", "full")
+	if p == nil {
+		return v
+	}
+	for field, expr := range p.Filter {
+		v.Set("filter["+field+"]", expr)
+		if strings.HasPrefix(field, "date_") {
+			v.Set("date", "1")
+		}
+	}
+	if p.Sort != "" {
+		v.Set("sort", p.Sort)
+	}
+	if p.Limit > 0 {
+		v.Set("limit", fmt.Sprintf("%d,%d", p.Offset, p.Limit))
+	}
+	return v
+}
+
+// LanguageValue is a single translation of a multi-language field.
+type LanguageValue struct {
+	ID    string `xml:"id,attr"`
+	Value string `xml:",chardata"`
+}
+
+// Multilang is a multi-language field such as a product name.
+type Multilang []LanguageValue
+
+// Get returns the value for the given language ID, falling back to the first translation.
+func (m Multilang) Get(languageID string) string {
+	for _, lv := range m {
+		if lv.ID == languageID {
+			return lv.Value
+		}
+	}
+	if len(m) > 0 {
+		return m[0].Value
+	}
+	return ""
+}
+
+// IDRef is an association entry that references another resource by ID.
+type IDRef struct {
+	ID string `xml:"id"`
+}
@@ -0,0 +1,239 @@
+package prestashop
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestNewClientDefaults(t *testing.T) {
+	c := NewClient("https://sklep.example.pl/", "KEY123")
+
+	if c.baseURL != "https://sklep.example.pl/api" {
+		t.Errorf("baseURL = %q, want %q", c.baseURL, "https://sklep.example.pl/api")
+	}
+	if c.Orders == nil || c.Stock == nil || c.Products == nil || c.Combinations == nil || c.Addresses == nil {
+		t.Error("services must be initialised")
+	}
+}
+
+func TestDoSetsBasicAuthWithKey(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		want := "Basic " + base64.StdEncoding.EncodeToString([]byte("KEY123:"))
+		if got := r.Header.Get("Authorization"); got != want {
+			t.Errorf("Authorization = %q, want %q", got, want)
+		}
+		w.Write([]byte(`<?xml version="1.0"?><prestashop></prestashop>`))
+	}))
+	defer srv.Close()
+
+	c := NewClient("https://shop", "KEY123", WithBaseURL(srv.URL), WithHTTPClient(srv.Client()))
+	if err := c.do(context.Background(), "GET", "/orders", nil, nil, nil); err != nil {
+		t.Fatalf("do() returned error: %v", err)
+	}
+}
+
+func TestDoHandlesErrorResponse(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?>
+<prestashop xmlns:xlink="http://www.w3.org/1999/xlink">
+<errors><error><code><![CDATA[90]]></code><message><![CDATA[Id(s) not exists: 999]]></message></error></errors>
+</prestashop>`))
+	}))
+	defer srv.Close()
+
+	c := NewClient("https://shop", "KEY", WithBaseURL(srv.URL), WithHTTPClient(srv.Client()))
+	_, err := c.Orders.Get(context.Background(), "999")
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected *APIError, got %T", err)
+	}
+	if apiErr.Code != "90" || apiErr.Message != "Id(s) not exists: 999" {
+		t.Errorf("APIError = %+v", apiErr)
+	}
+	if !errors.Is(err, ErrNotFound) {
+		t.Error("expected error to wrap ErrNotFound")
+	}
+}
+
+func TestOrdersListWithRows(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if r.URL.Path != "/orders" {
+			t.Errorf("path = %q", r.URL.Path)
+		}
+		if q.Get("display") != "full" || q.Get("date") != "1" || q.Get("sort") != "[date_upd_ASC]" {
+			t.Errorf("query = %v", q)
+		}
+		if q.Get("filter[date_upd]") != "[2025-01-01 00:00:00,2099-12-31 23:59:59]" {
+			t.Errorf("filter = %q", q.Get("filter[date_upd]"))
+		}
+		if q.Get("limit") != "0,50" {
+			t.Errorf("limit = %q", q.Get("limit"))
+		}
+		w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?>
+<prestashop xmlns:xlink="http://www.w3.org/1999/xlink">
+<orders>
+<order>
+	<id><![CDATA[5]]></id>
+	<reference><![CDATA[XKBKNABJK]]></reference>
+	<id_address_delivery xlink:href="https://shop/api/addresses/4"><![CDATA[4]]></id_address_delivery>
+	<current_state><![CDATA[2]]></current_state>
+	<valid><![CDATA[1]]></valid>
+	<total_paid_tax_incl><![CDATA[61.800000]]></total_paid_tax_incl>
+	<date_upd><![CDATA[2025-01-02 10:00:00]]></date_upd>
+	<associations>
+	<order_rows nodeType="order_row" virtualEntity="true">
+	<order_row>
+		<id><![CDATA[11]]></id>
+		<product_id><![CDATA[1]]></product_id>
+		<product_attribute_id><![CDATA[7]]></product_attribute_id>
+		<product_quantity><![CDATA[2]]></product_quantity>
+		<product_name><![CDATA[T-shirt - Size : M]]></product_name>
+		<product_reference><![CDATA[TS-M]]></product_reference>
+		<unit_price_tax_incl><![CDATA[23.900000]]></unit_price_tax_incl>
+	</order_row>
+	</order_rows>
+	</associations>
+</order>
+</orders>
+</prestashop>`))
+	}))
+	defer srv.Close()
+
+	c := NewClient("https://shop", "KEY", WithBaseURL(srv.URL), WithHTTPClient(srv.Client()))
+	orders, err := c.Orders.List(context.Background(), &ListParams{
+		Filter: map[string]string{"date_upd": "[2025-01-01 00:00:00,2099-12-31 23:59:59]"},
+		Sort:   "[date_upd_ASC]",
+		Limit:  50,
+	})
+	if err != nil {
+		t.Fatalf("List() error: %v", err)
+	}
+	if len(orders) != 1 {
+		t.Fatalf("got %d orders, want 1", len(orders))
+	}
+	o := orders[0]
+	if o.ID != "5" || o.IDAddressDelivery != "4" || o.TotalPaidTaxIncl != "61.800000" {
+		t.Errorf("unexpected order: %+v", o)
+	}
+	if len(o.Rows) != 1 || o.Rows[0].ProductAttributeID != "7" || o.Rows[0].ProductReference != "TS-M" {
+		t.Errorf("unexpected rows: %+v", o.Rows)
+	}
+}
+
+func TestChangeStatePostsOrderHistory(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/order_histories" {
+			t.Errorf("%s %s", r.Method, r.URL.Path)
+		}
+		if r.URL.Query().Get("sendemail") != "1" {
+			t.Error("expected sendemail=1")
+		}
+		body, _ := io.ReadAll(r.Body)
+		if !strings.Contains(string(body), "<prestashop><order_history><id_order>5</id_order><id_order_state>4</id_order_state></order_history></prestashop>") {
+			t.Errorf("body = %s", body)
+		}
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`<prestashop><order_history><id>30</id><id_order>5</id_order><id_order_state>4</id_order_state></order_history></prestashop>`))
+	}))
+	defer srv.Close()
+
+	c := NewClient("https://shop", "KEY", WithBaseURL(srv.URL), WithHTTPClient(srv.Client()))
+	h, err := c.Orders.ChangeState(context.Background(), "5", "4", true)
+	if err != nil {
+		t.Fatalf("ChangeState() error: %v", err)
+	}
+	if h.ID != "30" {
+		t.Errorf("history ID = %q", h.ID)
+	}
+}
+
+func TestStockSetQuantity(t *testing.T) {
+	var putBody string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			q := r.URL.Query()
+			if q.Get("filter[id_product]") != "[1]" || q.Get("filter[id_product_attribute]") != "[7]" {
+				t.Errorf("query = %v", q)
+			}
+			w.Write([]byte(`<prestashop><stock_availables><stock_available>
+				<id>42</id><id_product>1</id_product><id_product_attribute>7</id_product_attribute>
+				<id_shop>1</id_shop><id_shop_group>0</id_shop_group><quantity>3</quantity>
+				<depends_on_stock>0</depends_on_stock><out_of_stock>2</out_of_stock><location></location>
+			</stock_available></stock_availables></prestashop>`))
+		case "PUT":
+			if r.URL.Path != "/stock_availables/42" {
+				t.Errorf("path = %q", r.URL.Path)
+			}
+			b, _ := io.ReadAll(r.Body)
+			putBody = string(b)
+			w.Write([]byte(`<prestashop></prestashop>`))
+		}
+	}))
+	defer srv.Close()
+
+	c := NewClient("https://shop", "KEY", WithBaseURL(srv.URL), WithHTTPClient(srv.Client()))
+	if err := c.Stock.SetQuantity(context.Background(), "1", "7", 15); err != nil {
+		t.Fatalf("SetQuantity() error: %v", err)
+	}
+	if !strings.Contains(putBody, "<quantity>15</quantity>") || !strings.Contains(putBody, "<out_of_stock>2</out_of_stock>") {
+		t.Errorf("PUT body = %s", putBody)
+	}
+}
+
+func TestProductUpdatePriceRoundTrip(t *testing.T) {
+	var putBody string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			w.Write([]byte(`<prestashop><product>
+				<id><![CDATA[1]]></id>
+				<manufacturer_name notFilterable="true"><![CDATA[ACME]]></manufacturer_name>
+				<quantity notFilterable="true"><![CDATA[10]]></quantity>
+				<price><![CDATA[10.000000]]></price>
+				<wholesale_price><![CDATA[5.000000]]></wholesale_price>
+				<associations><product_bundle><product><id>9</id><quantity>2</quantity></product></product_bundle></associations>
+			</product></prestashop>`))
+			return
+		}
+		b, _ := io.ReadAll(r.Body)
+		putBody = string(b)
+		w.Write([]byte(`<prestashop></prestashop>`))
+	}))
+	defer srv.Close()
+
+	c := NewClient("https://shop", "KEY", WithBaseURL(srv.URL), WithHTTPClient(srv.Client()))
+	if err := c.Products.UpdatePrice(context.Background(), "1", 19.99); err != nil {
+		t.Fatalf("UpdatePrice() error: %v", err)
+	}
+	if !strings.Contains(putBody, "<price><![CDATA[19.990000]]></price>") {
+		t.Errorf("price not replaced: %s", putBody)
+	}
+	if !strings.Contains(putBody, "<wholesale_price><![CDATA[5.000000]]></wholesale_price>") {
+		t.Errorf("wholesale_price must be kept: %s", putBody)
+	}
+	if strings.Contains(putBody, "manufacturer_name") || strings.Contains(putBody, "<quantity notFilterable") {
+		t.Errorf("read-only fields not stripped: %s", putBody)
+	}
+	if !strings.Contains(putBody, "<quantity>2</quantity>") {
+		t.Errorf("association quantity must be kept: %s", putBody)
+	}
+}
+
+func TestMultilangGet(t *testing.T) {
+	m := Multilang{{ID: "1", Value: "Koszulka"}, {ID: "2", Value: "T-shirt"}}
+	if m.Get("2") != "T-shirt" {
+		t.Errorf("Get(2) = %q", m.Get("2"))
+	}
+	if m.Get("9") != "Koszulka" {
+		t.Errorf("Get(9) fallback = %q", m.Get("9"))
+	}
+}
@@ -0,0 +1,16 @@
+// Package prestashop provides a Go client for the PrestaShop XML Webservice API.
+//
+// This is a standalone package licensed under MIT. It can be imported
+// independently of the main OpenOMS application.
+//
+// Features:
+//   - Order listing with date_upd filtering, retrieval and order rows
+//   - Order state changes via order_histories, order state listing
+//   - Stock availability per product and combination (stock_availables)
+//   - Product and combination listing, product creation and price updates
+//   - Addresses, customers, countries and currencies lookup
+//   - Basic Auth with the webservice key
+//
+// Status: In Development — this package has been implemented but not yet
+// verified against the real API in a production environment.
+package prestashop
@@ -0,0 +1,60 @@
+package prestashop
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrUnauthorized = errors.New("prestashop: unauthorized")
+	ErrForbidden    = errors.New("prestashop: forbidden")
+	ErrNotFound     = errors.New("prestashop: not found")
+	ErrRateLimited  = errors.New("prestashop: rate limited")
+	ErrServerError  = errors.New("prestashop: server error")
+)
+
+// APIError represents an error response from the PrestaShop webservice.
+// The webservice reports errors as <prestashop><errors><error>...</error></errors></prestashop>.
+type APIError struct {
+	StatusCode int
+	Code       string
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "prestashop: HTTP %d", e.StatusCode)
+	if e.Code != "" {
+		fmt.Fprintf(&b, " [%s]", e.Code)
+	}
+	if e.Message != "" {
+		fmt.Fprintf(&b, ": %s", e.Message)
+	}
+	return b.String()
+}
+
+// Unwrap returns a sentinel error based on the HTTP status code.
+func (e *APIError) Unwrap() error {
+	switch {
+	case e.StatusCode == 401:
+		return ErrUnauthorized
+	case e.StatusCode == 403:
+		return ErrForbidden
+	case e.StatusCode == 404:
+		return ErrNotFound
+	case e.StatusCode == 429:
+		return ErrRateLimited
+	case e.StatusCode >= 500:
+		return ErrServerError
+	default:
+		return nil
+	}
+}
+
+type errorResponse struct {
+	Errors []struct {
+		Code    string `xml:"code"`
+		Message string `xml:"message"`
+	} `xml:"errors>error"`
+}
@@ -0,0 +1,3 @@
+module github.com/openoms-org/openoms/packages/prestashop-go-sdk
+
+go 1.24
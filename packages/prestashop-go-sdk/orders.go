@@ -0,0 +1,137 @@
+package prestashop
+
+import (
+	"context"
+	"encoding/xml"
+	"net/url"
+)
+
+// OrderService handles communication with the orders, order_histories and
+// order_states webservice resources.
+type OrderService struct {
+	client *Client
+}
+
+// Order represents a PrestaShop order.
+// Numeric fields are kept as strings, exactly as returned by the webservice.
+type Order struct {
+	XMLName              xml.Name   `xml:"order"`
+	ID                   string     `xml:"id"`
+	Reference            string     `xml:"reference"`
+	IDCustomer           string     `xml:"id_customer"`
+	IDAddressDelivery    string     `xml:"id_address_delivery"`
+	IDAddressInvoice     string     `xml:"id_address_invoice"`
+	IDCurrency           string     `xml:"id_currency"`
+	IDCarrier            string     `xml:"id_carrier"`
+	CurrentState         string     `xml:"current_state"`
+	Module               string     `xml:"module"`
+	Payment              string     `xml:"payment"`
+	Valid                string     `xml:"valid"`
+	TotalPaidTaxIncl     string     `xml:"total_paid_tax_incl"`
+	TotalShippingTaxIncl string     `xml:"total_shipping_tax_incl"`
+	ConversionRate       string     `xml:"conversion_rate"`
+	DateAdd              string     `xml:"date_add"`
+	DateUpd              string     `xml:"date_upd"`
+	Rows                 []OrderRow `xml:"associations>order_rows>order_row"`
+}
+
+// OrderRow is a single line of a PrestaShop order.
+// ProductAttributeID is the combination ID ("0" for products without combinations).
+type OrderRow struct {
+	ID                 string `xml:"id"`
+	ProductID          string `xml:"product_id"`
+	ProductAttributeID string `xml:"product_attribute_id"`
+	ProductQuantity    string `xml:"product_quantity"`
+	ProductName        string `xml:"product_name"`
+	ProductReference   string `xml:"product_reference"`
+	ProductEAN13       string `xml:"product_ean13"`
+	UnitPriceTaxIncl   string `xml:"unit_price_tax_incl"`
+	UnitPriceTaxExcl   string `xml:"unit_price_tax_excl"`
+}
+
+// OrderHistory is an entry of an order's state history.
+// Creating an order history changes the order's current state.
+type OrderHistory struct {
+	XMLName      xml.Name `xml:"order_history"`
+	ID           string   `xml:"id,omitempty"`
+	IDOrder      string   `xml:"id_order"`
+	IDOrderState string   `xml:"id_order_state"`
+	IDEmployee   string   `xml:"id_employee,omitempty"`
+	DateAdd      string   `xml:"date_add,omitempty"`
+}
+
+// OrderState is a configured order state (e.g. "Payment accepted", "Shipped").
+type OrderState struct {
+	ID        string    `xml:"id"`
+	Name      Multilang `xml:"name>language"`
+	Paid      string    `xml:"paid"`
+	Shipped   string    `xml:"shipped"`
+	Delivered string    `xml:"delivered"`
+	Color     string    `xml:"color"`
+}
+
+// List returns orders matching the given parameters.
+func (s *OrderService) List(ctx context.Context, params *ListParams) ([]Order, error) {
+	var result struct {
+		Orders []Order `xml:"orders>order"`
+	}
+	if err := s.client.do(ctx, "GET", "/orders", params.values(), nil, &result); err != nil {
+		return nil, err
+	}
+	return result.Orders, nil
+}
+
+// Get retrieves a single order by ID.
+func (s *OrderService) Get(ctx context.Context, id string) (*Order, error) {
+	var result struct {
+		Order Order `xml:"order"`
+	}
+	if err := s.client.do(ctx, "GET", "/orders/"+url.PathEscape(id), nil, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result.Order, nil
+}
+
+// ListHistory returns the state history of an order, oldest first.
+func (s *OrderService) ListHistory(ctx context.Context, orderID string) ([]OrderHistory, error) {
+	params := &ListParams{
+		Filter: map[string]string{"id_order": "[" + orderID + "]"},
+		Sort:   "[id_ASC]",
+	}
+	var result struct {
+		Histories []OrderHistory `xml:"order_histories>order_history"`
+	}
+	if err := s.client.do(ctx, "GET", "/order_histories", params.values(), nil, &result); err != nil {
+		return nil, err
+	}
+	return result.Histories, nil
+}
+
+// ChangeState moves an order to a new state by creating an order history entry.
+// When sendEmail is true, PrestaShop sends the e-mail template configured for the state.
+func (s *OrderService) ChangeState(ctx context.Context, orderID, stateID string, sendEmail bool) (*OrderHistory, error) {
+	query := url.Values{}
+	if sendEmail {
+		query.Set("sendemail", "1")
+	}
+	body := OrderHistory{IDOrder: orderID, IDOrderState: stateID}
+
+	var result struct {
+		History OrderHistory `xml:"order_history"`
+	}
+	if err := s.client.do(ctx, "POST", "/order_histories", query, body, &result); err != nil {
+		return nil, err
+	}
+	return &result.History, nil
+}
+
+// ListStates returns all configured order states.
+func (s *OrderService) ListStates(ctx context.Context) ([]OrderState, error) {
+	var result struct {
+		States []OrderState `xml:"order_states>order_state"`
+	}
+	if err := s.client.do(ctx, "GET", "/order_states", (&ListParams{}).values(), nil, &result); err != nil {
+		return nil, err
+	}
+	return result.States, nil
+}
@@ -0,0 +1,162 @@
+package prestashop
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+)
+
+// ProductService handles communication with the products webservice resource.
+type ProductService struct {
+	client *Client
+}
+
+// CombinationService handles communication with the combinations webservice resource.
+// A combination is a sellable variant of a product (e.g. size M, colour red).
+type CombinationService struct {
+	client *Client
+}
+
+// Product represents a PrestaShop product.
+type Product struct {
+	XMLName           xml.Name  `xml:"product"`
+	ID                string    `xml:"id,omitempty"`
+	IDCategoryDefault string    `xml:"id_category_default,omitempty"`
+	IDTaxRulesGroup   string    `xml:"id_tax_rules_group,omitempty"`
+	Reference         string    `xml:"reference"`
+	EAN13             string    `xml:"ean13"`
+	Price             string    `xml:"price"`
+	WholesalePrice    string    `xml:"wholesale_price,omitempty"`
+	Weight            string    `xml:"weight,omitempty"`
+	Active            string    `xml:"active"`
+	State             string    `xml:"state,omitempty"`
+	AvailableForOrder string    `xml:"available_for_order,omitempty"`
+	ShowPrice         string    `xml:"show_price,omitempty"`
+	MinimalQuantity   string    `xml:"minimal_quantity,omitempty"`
+	DateUpd           string    `xml:"date_upd,omitempty"`
+	Name              Multilang `xml:"name>language"`
+	LinkRewrite       Multilang `xml:"link_rewrite>language"`
+	Description       Multilang `xml:"description>language"`
+	DescriptionShort  Multilang `xml:"description_short>language"`
+	Categories        []IDRef   `xml:"associations>categories>category"`
+	Combinations      []IDRef   `xml:"associations>combinations>combination"`
+}
+
+// Combination represents a product combination (variant).
+// Price is the price impact relative to the product's base price.
+type Combination struct {
+	XMLName             xml.Name `xml:"combination"`
+	ID                  string   `xml:"id"`
+	IDProduct           string   `xml:"id_product"`
+	Reference           string   `xml:"reference"`
+	EAN13               string   `xml:"ean13"`
+	Price               string   `xml:"price"`
+	Weight              string   `xml:"weight"`
+	MinimalQuantity     string   `xml:"minimal_quantity"`
+	DefaultOn           string   `xml:"default_on"`
+	ProductOptionValues []IDRef  `xml:"associations>product_option_values>product_option_value"`
+}
+
+// List returns products matching the given parameters.
+func (s *ProductService) List(ctx context.Context, params *ListParams) ([]Product, error) {
+	var result struct {
+		Products []Product `xml:"products>product"`
+	}
+	if err := s.client.do(ctx, "GET", "/products", params.values(), nil, &result); err != nil {
+		return nil, err
+	}
+	return result.Products, nil
+}
+
+// Get retrieves a single product by ID.
+func (s *ProductService) Get(ctx context.Context, id string) (*Product, error) {
+	var result struct {
+		Product Product `xml:"product"`
+	}
+	if err := s.client.do(ctx, "GET", "/products/"+url.PathEscape(id), nil, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result.Product, nil
+}
+
+// Create creates a new product and returns it with its assigned ID.
+func (s *ProductService) Create(ctx context.Context, p *Product) (*Product, error) {
+	var result struct {
+		Product Product `xml:"product"`
+	}
+	if err := s.client.do(ctx, "POST", "/products", nil, p, &result); err != nil {
+		return nil, err
+	}
+	return &result.Product, nil
+}
+
+var (
+	priceElementRe = regexp.MustCompile(`(?s)<price>.*?</price>`)
+	// Fields returned by GET that the webservice rejects on PUT.
+	readOnlyProductFieldRe = regexp.MustCompile(`(?s)<(?:manufacturer_name|quantity|position_in_category)\b[^>]*?(?:/>|>.*?</(?:manufacturer_name|quantity|position_in_category)>)`)
+)
+
+// UpdatePrice sets the base (tax excluded) price of a product.
+// The webservice only supports full-object PUT, so the product is fetched as raw XML,
+// its price replaced and read-only fields stripped before it is sent back.
+func (s *ProductService) UpdatePrice(ctx context.Context, id string, price float64) error {
+	return s.client.updatePrice(ctx, "/products/"+url.PathEscape(id), price, true)
+}
+
+// UpdatePriceImpact sets the price impact of a combination relative to its product's base price.
+func (s *CombinationService) UpdatePriceImpact(ctx context.Context, id string, impact float64) error {
+	return s.client.updatePrice(ctx, "/combinations/"+url.PathEscape(id), impact, false)
+}
+
+// updatePrice replaces the top-level <price> element of a resource via a raw GET/PUT round trip.
+func (c *Client) updatePrice(ctx context.Context, path string, price float64, stripReadOnly bool) error {
+	raw, err := c.doRaw(ctx, "GET", path, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	// Only top-level fields are touched; associations (e.g. pack items) keep their own elements.
+	head, tail := raw, []byte(nil)
+	if i := bytes.Index(raw, []byte("<associations")); i >= 0 {
+		head, tail = raw[:i], raw[i:]
+	}
+	if !priceElementRe.Match(head) {
+		return fmt.Errorf("prestashop: %s has no price element", path)
+	}
+	newPrice := []byte("<price><![CDATA[" + strconv.FormatFloat(price, 'f', 6, 64) + "]]></price>")
+	head = priceElementRe.ReplaceAllLiteral(head, newPrice)
+	if stripReadOnly {
+		head = readOnlyProductFieldRe.ReplaceAll(head, nil)
+	}
+
+	payload := append(append([]byte{}, head...), tail...)
+	_, err = c.doRaw(ctx, "PUT", path, nil, payload)
+	return err
+}
+
+// ListByProduct returns all combinations of a product.
+func (s *CombinationService) ListByProduct(ctx context.Context, productID string) ([]Combination, error) {
+	params := &ListParams{Filter: map[string]string{"id_product": "[" + productID + "]"}}
+	var result struct {
+		Combinations []Combination `xml:"combinations>combination"`
+	}
+	if err := s.client.do(ctx, "GET", "/combinations", params.values(), nil, &result); err != nil {
+		return nil, err
+	}
+	return result.Combinations, nil
+}
+
+// Get retrieves a single combination by ID.
+func (s *CombinationService) Get(ctx context.Context, id string) (*Combination, error) {
+	var result struct {
+		Combination Combination `xml:"combination"`
+	}
+	if err := s.client.do(ctx, "GET", "/combinations/"+url.PathEscape(id), nil, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result.Combination, nil
+}
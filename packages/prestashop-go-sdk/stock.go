@@ -0,0 +1,80 @@
+package prestashop
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// StockService handles communication with the stock_availables webservice resource.
+type StockService struct {
+	client *Client
+}
+
+// StockAvailable is the available quantity of a product or a single combination.
+// IDProductAttribute is "0" for the product-level (total) stock row.
+type StockAvailable struct {
+	XMLName            xml.Name `xml:"stock_available"`
+	ID                 string   `xml:"id"`
+	IDProduct          string   `xml:"id_product"`
+	IDProductAttribute string   `xml:"id_product_attribute"`
+	IDShop             string   `xml:"id_shop"`
+	IDShopGroup        string   `xml:"id_shop_group"`
+	Quantity           string   `xml:"quantity"`
+	DependsOnStock     string   `xml:"depends_on_stock"`
+	OutOfStock         string   `xml:"out_of_stock"`
+	Location           string   `xml:"location"`
+}
+
+// ListByProduct returns all stock rows of a product (the product row and one per combination).
+func (s *StockService) ListByProduct(ctx context.Context, productID string) ([]StockAvailable, error) {
+	params := &ListParams{Filter: map[string]string{"id_product": "[" + productID + "]"}}
+	var result struct {
+		Stock []StockAvailable `xml:"stock_availables>stock_available"`
+	}
+	if err := s.client.do(ctx, "GET", "/stock_availables", params.values(), nil, &result); err != nil {
+		return nil, err
+	}
+	return result.Stock, nil
+}
+
+// Find returns the stock row of a product and combination (use "0" for the product itself).
+func (s *StockService) Find(ctx context.Context, productID, combinationID string) (*StockAvailable, error) {
+	if combinationID == "" {
+		combinationID = "0"
+	}
+	params := &ListParams{Filter: map[string]string{
+		"id_product":           "[" + productID + "]",
+		"id_product_attribute": "[" + combinationID + "]",
+	}}
+	var result struct {
+		Stock []StockAvailable `xml:"stock_availables>stock_available"`
+	}
+	if err := s.client.do(ctx, "GET", "/stock_availables", params.values(), nil, &result); err != nil {
+		return nil, err
+	}
+	if len(result.Stock) == 0 {
+		return nil, ErrNotFound
+	}
+	return &result.Stock[0], nil
+}
+
+// Update replaces a stock row. The webservice requires the complete object on PUT.
+func (s *StockService) Update(ctx context.Context, sa *StockAvailable) error {
+	if sa.ID == "" {
+		return fmt.Errorf("prestashop: stock_available ID is required")
+	}
+	return s.client.do(ctx, "PUT", "/stock_availables/"+url.PathEscape(sa.ID), nil, sa, nil)
+}
+
+// SetQuantity sets the available quantity of a product or combination.
+func (s *StockService) SetQuantity(ctx context.Context, productID, combinationID string, quantity int) error {
+	sa, err := s.Find(ctx, productID, combinationID)
+	if err != nil {
+		return err
+	}
+	sa.Quantity = strconv.Itoa(quantity)
+	return s.Update(ctx, sa)
+}
@@ -0,0 +1,149 @@
+package shopify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// DefaultAPIVersion is the Admin API version used when none is configured.
+const DefaultAPIVersion = "2025-01"
+
+// Client is the Shopify Admin GraphQL API client.
+// Authentication uses the X-Shopify-Access-Token header with an Admin API access token.
+type Client struct {
+	httpClient  *http.Client
+	baseURL     string
+	accessToken string
+	apiVersion  string
+
+	Orders       *OrderService
+	Fulfillments *FulfillmentService
+	Inventory    *InventoryService
+	Products     *ProductService
+}
+
+// Option configures a Client.
+type Option func(*Client)
+
+// NewClient creates a new Shopify Admin API client.
+// shopDomain may be given as "my-shop", "my-shop.myshopify.com" or a full URL.
+func NewClient(shopDomain, accessToken string, opts ...Option) *Client {
+	c := &Client{
+		httpClient:  http.DefaultClient,
+		accessToken: accessToken,
+		apiVersion:  DefaultAPIVersion,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if c.baseURL == "" {
+		c.baseURL = "https://" + NormalizeShopDomain(shopDomain) + "/admin/api/" + c.apiVersion
+	}
+
+	c.Orders = &OrderService{client: c}
+	c.Fulfillments = &FulfillmentService{client: c}
+	c.Inventory = &InventoryService{client: c}
+	c.Products = &ProductService{client: c}
+
+	return c
+}
+
+// WithHTTPClient sets a custom HTTP client.
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = hc
+	}
+}
+
+// WithBaseURL overrides the full API base URL (useful for testing).
+// The GraphQL endpoint is resolved as baseURL + "/graphql.json".
+func WithBaseURL(url string) Option {
+	return func(c *Client) {
+		c.baseURL = strings.TrimRight(url, "/")
+	}
+}
+
+// WithAPIVersion sets the Admin API version (e.g. "2025-01").
+func WithAPIVersion(version string) Option {
+	return func(c *Client) {
+		if version != "" {
+			c.apiVersion = version
+		}
+	}
+}
+
+// NormalizeShopDomain strips scheme and trailing slashes from a shop domain
+// and appends ".myshopify.com" when only the shop handle is given.
+func NormalizeShopDomain(shopDomain string) string {
+	d := strings.TrimSpace(shopDomain)
+	d = strings.TrimPrefix(d, "https://")
+	d = strings.TrimPrefix(d, "http://")
+	d = strings.TrimRight(d, "/")
+	if d != "" && !strings.Contains(d, ".") {
+		d += ".myshopify.com"
+	}
+	return d
+}
+
+type graphQLRequest struct {
+	Query     string         `json:"query"`
+	Variables map[string]any `json:"variables,omitempty"`
+}
+
+type graphQLResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors GraphQLErrors   `json:"errors"`
+}
+
+// do executes a GraphQL query or mutation and decodes the "data" object into result.
+func (c *Client) do(ctx context.Context, query string, variables map[string]any, result any) error {
+	data, err := json.Marshal(graphQLRequest{Query: query, Variables: variables})
+	if err != nil {
+		return fmt.Errorf("shopify: marshal request body: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/graphql.json", bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("shopify: create request: %w", err)
+	}
+
+	req.Header.Set("X-Shopify-Access-Token", c.accessToken)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("shopify: execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		apiErr := &APIError{StatusCode: resp.StatusCode}
+		if err := json.NewDecoder(resp.Body).Decode(apiErr); err != nil {
+			apiErr.Message = http.StatusText(resp.StatusCode)
+		}
+		return apiErr
+	}
+
+	var gqlResp graphQLResponse
+	if err := json.NewDecoder(resp.Body).Decode(&gqlResp); err != nil {
+		return fmt.Errorf("shopify: decode response: %w", err)
+	}
+	if len(gqlResp.Errors) > 0 {
+		return gqlResp.Errors
+	}
+
+	if result != nil && len(gqlResp.Data) > 0 {
+		if err := json.Unmarshal(gqlResp.Data, result); err != nil {
+			return fmt.Errorf("shopify: decode data: %w", err)
+		}
+	}
+
+	return nil
+}
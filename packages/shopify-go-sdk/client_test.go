@@ -0,0 +1,248 @@
+package shopify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func newTestServer(t *testing.T, handler func(t *testing.T, req graphQLRequest) string) (*httptest.Server, *Client) {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/graphql.json" {
+			t.Errorf("path = %q, want /graphql.json", r.URL.Path)
+		}
+		if r.Method != http.MethodPost {
+			t.Errorf("method = %q, want POST", r.Method)
+		}
+		var req graphQLRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("decode request: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(handler(t, req)))
+	}))
+	t.Cleanup(srv.Close)
+
+	c := NewClient("test-shop", "shpat_test", WithBaseURL(srv.URL), WithHTTPClient(srv.Client()))
+	return srv, c
+}
+
+func TestNewClientDefaults(t *testing.T) {
+	c := NewClient("my-shop", "shpat_abc")
+
+	want := "https://my-shop.myshopify.com/admin/api/" + DefaultAPIVersion
+	if c.baseURL != want {
+		t.Errorf("baseURL = %q, want %q", c.baseURL, want)
+	}
+	if c.Orders == nil || c.Fulfillments == nil || c.Inventory == nil || c.Products == nil {
+		t.Error("services must be initialised")
+	}
+}
+
+func TestNewClientAPIVersion(t *testing.T) {
+	c := NewClient("https://my-shop.myshopify.com/", "shpat_abc", WithAPIVersion("2024-10"))
+
+	want := "https://my-shop.myshopify.com/admin/api/2024-10"
+	if c.baseURL != want {
+		t.Errorf("baseURL = %q, want %q", c.baseURL, want)
+	}
+}
+
+func TestNormalizeShopDomain(t *testing.T) {
+	tests := map[string]string{
+		"my-shop":                        "my-shop.myshopify.com",
+		"my-shop.myshopify.com":          "my-shop.myshopify.com",
+		"https://my-shop.myshopify.com/": "my-shop.myshopify.com",
+		" http://shop.example.com ":      "shop.example.com",
+	}
+	for in, want := range tests {
+		if got := NormalizeShopDomain(in); got != want {
+			t.Errorf("NormalizeShopDomain(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestGIDAndLegacyID(t *testing.T) {
+	if got := GID("Order", "123"); got != "gid://shopify/Order/123" {
+		t.Errorf("GID = %q", got)
+	}
+	if got := GID("Order", "gid://shopify/Order/123"); got != "gid://shopify/Order/123" {
+		t.Errorf("GID passthrough = %q", got)
+	}
+	if got := LegacyID("gid://shopify/ProductVariant/456"); got != "456" {
+		t.Errorf("LegacyID = %q", got)
+	}
+	if got := LegacyID("789"); got != "789" {
+		t.Errorf("LegacyID passthrough = %q", got)
+	}
+}
+
+func TestDoSetsAccessTokenHeader(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("X-Shopify-Access-Token"); got != "shpat_xyz" {
+			t.Errorf("X-Shopify-Access-Token = %q, want %q", got, "shpat_xyz")
+		}
+		w.Write([]byte(`{"data":{}}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient("shop", "shpat_xyz", WithBaseURL(srv.URL), WithHTTPClient(srv.Client()))
+	if err := c.do(context.Background(), "{ shop { id } }", nil, nil); err != nil {
+		t.Fatalf("do() returned error: %v", err)
+	}
+}
+
+func TestDoHandlesHTTPError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"errors":"[API] Invalid API key or access token"}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient("shop", "bad", WithBaseURL(srv.URL), WithHTTPClient(srv.Client()))
+	err := c.do(context.Background(), "{ shop { id } }", nil, nil)
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected *APIError, got %T", err)
+	}
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Error("expected error to wrap ErrUnauthorized")
+	}
+	if !strings.Contains(apiErr.Message, "Invalid API key") {
+		t.Errorf("Message = %q", apiErr.Message)
+	}
+}
+
+func TestDoHandlesGraphQLErrors(t *testing.T) {
+	_, c := newTestServer(t, func(t *testing.T, req graphQLRequest) string {
+		return `{"errors":[{"message":"Throttled","extensions":{"code":"THROTTLED"}}]}`
+	})
+
+	err := c.do(context.Background(), "{ shop { id } }", nil, nil)
+	if !errors.Is(err, ErrRateLimited) {
+		t.Errorf("expected ErrRateLimited, got %v", err)
+	}
+}
+
+func TestOrdersList(t *testing.T) {
+	_, c := newTestServer(t, func(t *testing.T, req graphQLRequest) string {
+		if got := req.Variables["query"]; got != "updated_at:>='2025-01-01T00:00:00Z'" {
+			t.Errorf("query variable = %v", got)
+		}
+		if got := req.Variables["first"]; got != float64(10) {
+			t.Errorf("first = %v, want 10", got)
+		}
+		return `{"data":{"orders":{
+			"nodes":[{"id":"gid://shopify/Order/1001","name":"#1001","updatedAt":"2025-01-02T10:00:00Z",
+				"totalPriceSet":{"shopMoney":{"amount":"99.90","currencyCode":"PLN"}},
+				"lineItems":{"nodes":[{"id":"gid://shopify/LineItem/1","name":"Mug","sku":"MUG-1","quantity":2,
+					"originalUnitPriceSet":{"shopMoney":{"amount":"49.95","currencyCode":"PLN"}},
+					"variant":{"id":"gid://shopify/ProductVariant/55","barcode":"590"}}]}}],
+			"pageInfo":{"hasNextPage":true,"endCursor":"abc"}}}}`
+	})
+
+	conn, err := c.Orders.List(context.Background(), &OrderListParams{UpdatedAtMin: "2025-01-01T00:00:00Z", First: 10})
+	if err != nil {
+		t.Fatalf("List() error: %v", err)
+	}
+	if len(conn.Nodes) != 1 {
+		t.Fatalf("got %d orders, want 1", len(conn.Nodes))
+	}
+	o := conn.Nodes[0]
+	if LegacyID(o.ID) != "1001" {
+		t.Errorf("order id = %q", o.ID)
+	}
+	if o.TotalPriceSet.ShopMoney.Float() != 99.90 {
+		t.Errorf("total = %v", o.TotalPriceSet.ShopMoney.Amount)
+	}
+	if len(o.LineItems.Nodes) != 1 || o.LineItems.Nodes[0].Variant.ID != "gid://shopify/ProductVariant/55" {
+		t.Errorf("unexpected line items: %+v", o.LineItems.Nodes)
+	}
+	if !conn.PageInfo.HasNextPage || conn.PageInfo.EndCursor != "abc" {
+		t.Errorf("pageInfo = %+v", conn.PageInfo)
+	}
+}
+
+func TestFulfillmentCreateResolvesOpenFulfillmentOrders(t *testing.T) {
+	calls := 0
+	_, c := newTestServer(t, func(t *testing.T, req graphQLRequest) string {
+		calls++
+		if strings.Contains(req.Query, "fulfillmentOrders") {
+			return `{"data":{"order":{"fulfillmentOrders":{"nodes":[
+				{"id":"gid://shopify/FulfillmentOrder/1","status":"OPEN"},
+				{"id":"gid://shopify/FulfillmentOrder/2","status":"CLOSED"}]}}}}`
+		}
+		f := req.Variables["fulfillment"].(map[string]any)
+		items := f["lineItemsByFulfillmentOrder"].([]any)
+		if len(items) != 1 {
+			t.Errorf("got %d fulfillment orders, want 1", len(items))
+		}
+		tracking := f["trackingInfo"].(map[string]any)
+		if tracking["number"] != "TRK123" || tracking["company"] != "InPost" {
+			t.Errorf("trackingInfo = %v", tracking)
+		}
+		return `{"data":{"fulfillmentCreate":{"fulfillment":{"id":"gid://shopify/Fulfillment/9","status":"SUCCESS"},"userErrors":[]}}}`
+	})
+
+	f, err := c.Fulfillments.Create(context.Background(), FulfillmentInput{
+		OrderID:         "1001",
+		TrackingNumber:  "TRK123",
+		TrackingCompany: "InPost",
+	})
+	if err != nil {
+		t.Fatalf("Create() error: %v", err)
+	}
+	if f.ID != "gid://shopify/Fulfillment/9" {
+		t.Errorf("fulfillment id = %q", f.ID)
+	}
+	if calls != 2 {
+		t.Errorf("calls = %d, want 2", calls)
+	}
+}
+
+func TestInventorySetAvailable(t *testing.T) {
+	_, c := newTestServer(t, func(t *testing.T, req graphQLRequest) string {
+		input := req.Variables["input"].(map[string]any)
+		if input["name"] != "available" {
+			t.Errorf("name = %v", input["name"])
+		}
+		q := input["quantities"].([]any)[0].(map[string]any)
+		if q["inventoryItemId"] != "gid://shopify/InventoryItem/7" || q["locationId"] != "gid://shopify/Location/3" {
+			t.Errorf("quantities = %v", q)
+		}
+		if q["quantity"] != float64(12) {
+			t.Errorf("quantity = %v", q["quantity"])
+		}
+		return `{"data":{"inventorySetQuantities":{"userErrors":[]}}}`
+	})
+
+	if err := c.Inventory.SetAvailable(context.Background(), "7", "3", 12); err != nil {
+		t.Fatalf("SetAvailable() error: %v", err)
+	}
+}
+
+func TestUpdateVariantPriceUserErrors(t *testing.T) {
+	_, c := newTestServer(t, func(t *testing.T, req graphQLRequest) string {
+		v := req.Variables["variants"].([]any)[0].(map[string]any)
+		if v["price"] != "19.99" {
+			t.Errorf("price = %v", v["price"])
+		}
+		return `{"data":{"productVariantsBulkUpdate":{"productVariants":[],
+			"userErrors":[{"field":["variants","0","price"],"message":"is invalid"}]}}}`
+	})
+
+	err := c.Products.UpdateVariantPrice(context.Background(), "1", "2", 19.99)
+	var ue UserErrors
+	if !errors.As(err, &ue) {
+		t.Fatalf("expected UserErrors, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "variants.0.price: is invalid") {
+		t.Errorf("error = %q", err.Error())
+	}
+}
@@ -0,0 +1,15 @@
+// Package shopify provides a Go client for the Shopify Admin GraphQL API.
+//
+// This is a standalone package licensed under MIT. It can be imported
+// independently of the main OpenOMS application.
+//
+// Features:
+//   - Order listing with updated_at filtering and cursor pagination
+//   - Fulfillment order lookup and fulfillment creation with tracking
+//   - Locations and inventory levels, per-location quantity updates
+//   - Product and variant listing, creation and price updates
+//   - Access token authentication (custom or public app)
+//
+// Status: In Development — this package has been implemented but not yet
+// verified against the real API in a production environment.
+package shopify
@@ -0,0 +1,109 @@
+package shopify
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrUnauthorized = errors.New("shopify: unauthorized")
+	ErrForbidden    = errors.New("shopify: forbidden")
+	ErrNotFound     = errors.New("shopify: not found")
+	ErrRateLimited  = errors.New("shopify: rate limited")
+	ErrServerError  = errors.New("shopify: server error")
+)
+
+// APIError represents a non-2xx HTTP response from the Shopify API.
+type APIError struct {
+	StatusCode int    `json:"-"`
+	Message    string `json:"errors"`
+}
+
+func (e *APIError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "shopify: HTTP %d", e.StatusCode)
+	if e.Message != "" {
+		fmt.Fprintf(&b, ": %s", e.Message)
+	}
+	return b.String()
+}
+
+// Unwrap returns a sentinel error based on the HTTP status code.
+func (e *APIError) Unwrap() error {
+	switch {
+	case e.StatusCode == 401:
+		return ErrUnauthorized
+	case e.StatusCode == 403:
+		return ErrForbidden
+	case e.StatusCode == 404:
+		return ErrNotFound
+	case e.StatusCode == 429:
+		return ErrRateLimited
+	case e.StatusCode >= 500:
+		return ErrServerError
+	default:
+		return nil
+	}
+}
+
+// GraphQLError is a single entry of the top-level "errors" array of a GraphQL response.
+type GraphQLError struct {
+	Message    string `json:"message"`
+	Extensions struct {
+		Code string `json:"code"`
+	} `json:"extensions"`
+}
+
+// GraphQLErrors is returned when a GraphQL response contains top-level errors.
+type GraphQLErrors []GraphQLError
+
+func (e GraphQLErrors) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, ge := range e {
+		msgs = append(msgs, ge.Message)
+	}
+	return "shopify: graphql: " + strings.Join(msgs, "; ")
+}
+
+// Unwrap maps well-known GraphQL error codes to sentinel errors.
+func (e GraphQLErrors) Unwrap() error {
+	for _, ge := range e {
+		switch ge.Extensions.Code {
+		case "THROTTLED":
+			return ErrRateLimited
+		case "ACCESS_DENIED":
+			return ErrForbidden
+		}
+	}
+	return nil
+}
+
+// UserError is a validation error returned in the userErrors field of a mutation.
+type UserError struct {
+	Field   []string `json:"field"`
+	Message string   `json:"message"`
+}
+
+// UserErrors is returned when a mutation responds with non-empty userErrors.
+type UserErrors []UserError
+
+func (e UserErrors) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, ue := range e {
+		if len(ue.Field) > 0 {
+			msgs = append(msgs, strings.Join(ue.Field, ".")+": "+ue.Message)
+		} else {
+			msgs = append(msgs, ue.Message)
+		}
+	}
+	return "shopify: " + strings.Join(msgs, "; ")
+}
+
+// userErrorsOrNil converts a userErrors slice to an error, returning nil when empty.
+func userErrorsOrNil(errs []UserError) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	return UserErrors(errs)
+}
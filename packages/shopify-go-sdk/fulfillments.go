@@ -0,0 +1,117 @@
+package shopify
+
+import (
+	"context"
+	"fmt"
+)
+
+// FulfillmentService handles fulfillment orders and fulfillment creation.
+type FulfillmentService struct {
+	client *Client
+}
+
+// FulfillmentInput describes a fulfillment to create.
+type FulfillmentInput struct {
+	// FulfillmentOrderIDs are the fulfillment orders to fulfil in full.
+	// When empty, all open fulfillment orders of OrderID are used.
+	FulfillmentOrderIDs []string
+	// OrderID is used to resolve fulfillment orders when FulfillmentOrderIDs is empty.
+	OrderID         string
+	TrackingNumber  string
+	TrackingCompany string
+	TrackingURL     string
+	NotifyCustomer  bool
+}
+
+// ListFulfillmentOrders returns the fulfillment orders of an order.
+func (s *FulfillmentService) ListFulfillmentOrders(ctx context.Context, orderID string) ([]FulfillmentOrder, error) {
+	query := `query FulfillmentOrders($id: ID!) {
+		order(id: $id) {
+			fulfillmentOrders(first: 50) {
+				nodes {
+					id status
+					assignedLocation { location { id name } }
+					lineItems(first: 250) { nodes { id remainingQuantity } }
+				}
+			}
+		}
+	}`
+
+	var result struct {
+		Order *struct {
+			FulfillmentOrders struct {
+				Nodes []FulfillmentOrder `json:"nodes"`
+			} `json:"fulfillmentOrders"`
+		} `json:"order"`
+	}
+	if err := s.client.do(ctx, query, map[string]any{"id": GID("Order", orderID)}, &result); err != nil {
+		return nil, err
+	}
+	if result.Order == nil {
+		return nil, ErrNotFound
+	}
+	return result.Order.FulfillmentOrders.Nodes, nil
+}
+
+// Create creates a fulfillment with optional tracking information.
+func (s *FulfillmentService) Create(ctx context.Context, input FulfillmentInput) (*Fulfillment, error) {
+	ids := input.FulfillmentOrderIDs
+	if len(ids) == 0 {
+		if input.OrderID == "" {
+			return nil, fmt.Errorf("shopify: fulfillment requires fulfillment order IDs or an order ID")
+		}
+		fos, err := s.ListFulfillmentOrders(ctx, input.OrderID)
+		if err != nil {
+			return nil, err
+		}
+		for _, fo := range fos {
+			if fo.Status == "OPEN" || fo.Status == "IN_PROGRESS" {
+				ids = append(ids, fo.ID)
+			}
+		}
+		if len(ids) == 0 {
+			return nil, fmt.Errorf("shopify: order %s has no open fulfillment orders", input.OrderID)
+		}
+	}
+
+	lineItems := make([]map[string]any, 0, len(ids))
+	for _, id := range ids {
+		lineItems = append(lineItems, map[string]any{"fulfillmentOrderId": GID("FulfillmentOrder", id)})
+	}
+
+	fulfillment := map[string]any{
+		"lineItemsByFulfillmentOrder": lineItems,
+		"notifyCustomer":              input.NotifyCustomer,
+	}
+	if input.TrackingNumber != "" || input.TrackingCompany != "" || input.TrackingURL != "" {
+		fulfillment["trackingInfo"] = TrackingInfo{
+			Number:  input.TrackingNumber,
+			Company: input.TrackingCompany,
+			URL:     input.TrackingURL,
+		}
+	}
+
+	query := `mutation FulfillmentCreate($fulfillment: FulfillmentInput!) {
+		fulfillmentCreate(fulfillment: $fulfillment) {
+			fulfillment { id status trackingInfo { number company url } }
+			userErrors { field message }
+		}
+	}`
+
+	var result struct {
+		FulfillmentCreate struct {
+			Fulfillment *Fulfillment `json:"fulfillment"`
+			UserErrors  []UserError  `json:"userErrors"`
+		} `json:"fulfillmentCreate"`
+	}
+	if err := s.client.do(ctx, query, map[string]any{"fulfillment": fulfillment}, &result); err != nil {
+		return nil, err
+	}
+	if err := userErrorsOrNil(result.FulfillmentCreate.UserErrors); err != nil {
+		return nil, err
+	}
+	if result.FulfillmentCreate.Fulfillment == nil {
+		return nil, fmt.Errorf("shopify: fulfillmentCreate returned no fulfillment")
+	}
+	return result.FulfillmentCreate.Fulfillment, nil
+}
@@ -0,0 +1,89 @@
+package shopify
+
+import (
+	"context"
+	"fmt"
+)
+
+// InventoryService handles locations and inventory levels.
+type InventoryService struct {
+	client *Client
+}
+
+// ListLocations returns the shop's locations.
+func (s *InventoryService) ListLocations(ctx context.Context) ([]Location, error) {
+	query := `query Locations {
+		locations(first: 250) { nodes { id name isActive } }
+	}`
+
+	var result struct {
+		Locations struct {
+			Nodes []Location `json:"nodes"`
+		} `json:"locations"`
+	}
+	if err := s.client.do(ctx, query, nil, &result); err != nil {
+		return nil, err
+	}
+	return result.Locations.Nodes, nil
+}
+
+// GetLevels returns the inventory levels of an inventory item across all locations.
+func (s *InventoryService) GetLevels(ctx context.Context, inventoryItemID string) ([]InventoryLevel, error) {
+	query := `query InventoryLevels($id: ID!) {
+		inventoryItem(id: $id) {
+			inventoryLevels(first: 250) {
+				nodes {
+					id
+					location { id name isActive }
+					quantities(names: ["available", "on_hand"]) { name quantity }
+				}
+			}
+		}
+	}`
+
+	var result struct {
+		InventoryItem *struct {
+			InventoryLevels struct {
+				Nodes []InventoryLevel `json:"nodes"`
+			} `json:"inventoryLevels"`
+		} `json:"inventoryItem"`
+	}
+	if err := s.client.do(ctx, query, map[string]any{"id": GID("InventoryItem", inventoryItemID)}, &result); err != nil {
+		return nil, err
+	}
+	if result.InventoryItem == nil {
+		return nil, ErrNotFound
+	}
+	return result.InventoryItem.InventoryLevels.Nodes, nil
+}
+
+// SetAvailable sets the absolute "available" quantity of an inventory item at a location.
+func (s *InventoryService) SetAvailable(ctx context.Context, inventoryItemID, locationID string, quantity int) error {
+	query := `mutation InventorySet($input: InventorySetQuantitiesInput!) {
+		inventorySetQuantities(input: $input) {
+			inventoryAdjustmentGroup { reason }
+			userErrors { field message }
+		}
+	}`
+
+	input := map[string]any{
+		"name":                  "available",
+		"reason":                "correction",
+		"ignoreCompareQuantity": true,
+		"quantities": []map[string]any{{
+			"inventoryItemId": GID("InventoryItem", inventoryItemID),
+			"locationId":      GID("Location", locationID),
+			"quantity":        quantity,
+		}},
+	}
+
+	var result struct {
+		InventorySetQuantities struct {
+			UserErrors []UserError `json:"userErrors"`
+		} `json:"inventorySetQuantities"`
+	}
+	if err := s.client.do(ctx, query, map[string]any{"input": input}, &result); err != nil {
+		return fmt.Errorf("shopify: set inventory: %w", err)
+	}
+	return userErrorsOrNil(result.InventorySetQuantities.UserErrors)
+}
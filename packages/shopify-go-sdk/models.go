@@ -0,0 +1,240 @@
+package shopify
+
+import (
+	"strconv"
+	"strings"
+)
+
+// GID builds a Shopify global ID (e.g. "gid://shopify/Order/123") from a
+// resource type and a numeric or string ID. IDs already in GID form are returned as-is.
+func GID(resource, id string) string {
+	if strings.HasPrefix(id, "gid://") {
+		return id
+	}
+	return "gid://shopify/" + resource + "/" + id
+}
+
+// LegacyID extracts the trailing numeric part of a Shopify global ID.
+// Non-GID values are returned unchanged.
+func LegacyID(gid string) string {
+	if !strings.HasPrefix(gid, "gid://") {
+		return gid
+	}
+	id := gid[strings.LastIndex(gid, "/")+1:]
+	if i := strings.Index(id, "?"); i >= 0 {
+		id = id[:i]
+	}
+	return id
+}
+
+// PageInfo carries cursor pagination data for a connection.
+type PageInfo struct {
+	HasNextPage bool   `json:"hasNextPage"`
+	EndCursor   string `json:"endCursor"`
+}
+
+// Money is an amount with its currency code. Shopify encodes amounts as decimal strings.
+type Money struct {
+	Amount       string `json:"amount"`
+	CurrencyCode string `json:"currencyCode"`
+}
+
+// Float returns the amount parsed as float64 (0 if unparsable).
+func (m Money) Float() float64 {
+	f, _ := strconv.ParseFloat(m.Amount, 64)
+	return f
+}
+
+// MoneyBag holds an amount in the shop currency.
+type MoneyBag struct {
+	ShopMoney Money `json:"shopMoney"`
+}
+
+// MailingAddress is a postal address on an order.
+type MailingAddress struct {
+	FirstName    string `json:"firstName"`
+	LastName     string `json:"lastName"`
+	Name         string `json:"name"`
+	Company      string `json:"company"`
+	Address1     string `json:"address1"`
+	Address2     string `json:"address2"`
+	City         string `json:"city"`
+	Zip          string `json:"zip"`
+	ProvinceCode string `json:"provinceCode"`
+	CountryCode  string `json:"countryCodeV2"`
+	Phone        string `json:"phone"`
+}
+
+// Customer is the buyer attached to an order.
+type Customer struct {
+	ID        string `json:"id"`
+	FirstName string `json:"firstName"`
+	LastName  string `json:"lastName"`
+	Email     string `json:"email"`
+	Phone     string `json:"phone"`
+}
+
+// ShippingLine is the shipping method selected at checkout.
+type ShippingLine struct {
+	Title string `json:"title"`
+	Code  string `json:"code"`
+}
+
+// TaxLine is a tax applied to a line item.
+type TaxLine struct {
+	Rate  float64 `json:"rate"`
+	Title string  `json:"title"`
+}
+
+// VariantRef is a minimal reference to a product variant on a line item.
+type VariantRef struct {
+	ID      string `json:"id"`
+	Barcode string `json:"barcode"`
+}
+
+// LineItem represents a single line in a Shopify order.
+type LineItem struct {
+	ID                   string      `json:"id"`
+	Name                 string      `json:"name"`
+	SKU                  string      `json:"sku"`
+	Quantity             int         `json:"quantity"`
+	OriginalUnitPriceSet MoneyBag    `json:"originalUnitPriceSet"`
+	DiscountedTotalSet   MoneyBag    `json:"discountedTotalSet"`
+	Variant              *VariantRef `json:"variant"`
+	TaxLines             []TaxLine   `json:"taxLines"`
+}
+
+// Order represents a Shopify order.
+type Order struct {
+	ID                       string          `json:"id"`
+	Name                     string          `json:"name"`
+	Email                    string          `json:"email"`
+	Phone                    string          `json:"phone"`
+	Note                     string          `json:"note"`
+	CreatedAt                string          `json:"createdAt"`
+	UpdatedAt                string          `json:"updatedAt"`
+	CancelledAt              *string         `json:"cancelledAt"`
+	CurrencyCode             string          `json:"currencyCode"`
+	DisplayFinancialStatus   string          `json:"displayFinancialStatus"`
+	DisplayFulfillmentStatus string          `json:"displayFulfillmentStatus"`
+	PaymentGatewayNames      []string        `json:"paymentGatewayNames"`
+	TotalPriceSet            MoneyBag        `json:"totalPriceSet"`
+	Customer                 *Customer       `json:"customer"`
+	ShippingAddress          *MailingAddress `json:"shippingAddress"`
+	BillingAddress           *MailingAddress `json:"billingAddress"`
+	ShippingLine             *ShippingLine   `json:"shippingLine"`
+	LineItems                struct {
+		Nodes []LineItem `json:"nodes"`
+	} `json:"lineItems"`
+}
+
+// OrderConnection is a page of orders.
+type OrderConnection struct {
+	Nodes    []Order  `json:"nodes"`
+	PageInfo PageInfo `json:"pageInfo"`
+}
+
+// Location is a Shopify inventory location (store, warehouse, app location).
+type Location struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	IsActive bool   `json:"isActive"`
+}
+
+// InventoryQuantity is a named quantity (e.g. "available", "on_hand") at a location.
+type InventoryQuantity struct {
+	Name     string `json:"name"`
+	Quantity int    `json:"quantity"`
+}
+
+// InventoryLevel is the inventory of one item at one location.
+type InventoryLevel struct {
+	ID         string              `json:"id"`
+	Location   Location            `json:"location"`
+	Quantities []InventoryQuantity `json:"quantities"`
+}
+
+// Available returns the "available" quantity of the level (0 if absent).
+func (l InventoryLevel) Available() int {
+	for _, q := range l.Quantities {
+		if q.Name == "available" {
+			return q.Quantity
+		}
+	}
+	return 0
+}
+
+// SelectedOption is a variant option value such as Size=M.
+type SelectedOption struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// ProductVariant represents a sellable variant of a product.
+type ProductVariant struct {
+	ID                string           `json:"id"`
+	Title             string           `json:"title"`
+	SKU               string           `json:"sku"`
+	Barcode           string           `json:"barcode"`
+	Price             string           `json:"price"`
+	InventoryQuantity int              `json:"inventoryQuantity"`
+	SelectedOptions   []SelectedOption `json:"selectedOptions"`
+	InventoryItem     struct {
+		ID string `json:"id"`
+	} `json:"inventoryItem"`
+	Product *struct {
+		ID string `json:"id"`
+	} `json:"product,omitempty"`
+}
+
+// Product represents a Shopify product with its variants.
+type Product struct {
+	ID              string `json:"id"`
+	Title           string `json:"title"`
+	Handle          string `json:"handle"`
+	Status          string `json:"status"`
+	DescriptionHTML string `json:"descriptionHtml"`
+	Vendor          string `json:"vendor"`
+	ProductType     string `json:"productType"`
+	Variants        struct {
+		Nodes []ProductVariant `json:"nodes"`
+	} `json:"variants"`
+}
+
+// ProductConnection is a page of products.
+type ProductConnection struct {
+	Nodes    []Product `json:"nodes"`
+	PageInfo PageInfo  `json:"pageInfo"`
+}
+
+// FulfillmentOrderLineItem is a line item on a fulfillment order.
+type FulfillmentOrderLineItem struct {
+	ID                string `json:"id"`
+	RemainingQuantity int    `json:"remainingQuantity"`
+}
+
+// FulfillmentOrder groups order lines to be fulfilled from a single location.
+type FulfillmentOrder struct {
+	ID               string `json:"id"`
+	Status           string `json:"status"`
+	AssignedLocation struct {
+		Location *Location `json:"location"`
+	} `json:"assignedLocation"`
+	LineItems struct {
+		Nodes []FulfillmentOrderLineItem `json:"nodes"`
+	} `json:"lineItems"`
+}
+
+// TrackingInfo is the carrier tracking attached to a fulfillment.
+type TrackingInfo struct {
+	Number  string `json:"number,omitempty"`
+	Company string `json:"company,omitempty"`
+	URL     string `json:"url,omitempty"`
+}
+
+// Fulfillment is a shipment of order lines created by the merchant.
+type Fulfillment struct {
+	ID           string         `json:"id"`
+	Status       string         `json:"status"`
+	TrackingInfo []TrackingInfo `json:"trackingInfo"`
+}
@@ -0,0 +1,102 @@
+package shopify
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// OrderService handles communication with order-related Shopify queries.
+type OrderService struct {
+	client *Client
+}
+
+// OrderListParams are the optional parameters for listing orders.
+type OrderListParams struct {
+	// UpdatedAtMin limits results to orders updated at or after this RFC3339 timestamp.
+	UpdatedAtMin string
+	// Query is an additional Shopify search query (e.g. "financial_status:paid").
+	Query string
+	// First is the page size (default 50, max 250).
+	First int
+	// After is the cursor returned in PageInfo.EndCursor of the previous page.
+	After string
+}
+
+const orderFields = `
+	id name email phone note createdAt updatedAt cancelledAt currencyCode
+	displayFinancialStatus displayFulfillmentStatus paymentGatewayNames
+	totalPriceSet { shopMoney { amount currencyCode } }
+	customer { id firstName lastName email phone }
+	shippingAddress { firstName lastName name company address1 address2 city zip provinceCode countryCodeV2 phone }
+	billingAddress { firstName lastName name company address1 address2 city zip provinceCode countryCodeV2 phone }
+	shippingLine { title code }
+	lineItems(first: 250) {
+		nodes {
+			id name sku quantity
+			originalUnitPriceSet { shopMoney { amount currencyCode } }
+			discountedTotalSet { shopMoney { amount currencyCode } }
+			variant { id barcode }
+			taxLines { rate title }
+		}
+	}`
+
+// List returns a page of orders sorted by update time (oldest first).
+func (s *OrderService) List(ctx context.Context, params *OrderListParams) (*OrderConnection, error) {
+	first := 50
+	var queryParts []string
+	var after any
+	if params != nil {
+		if params.First > 0 {
+			first = min(params.First, 250)
+		}
+		if params.UpdatedAtMin != "" {
+			queryParts = append(queryParts, fmt.Sprintf("updated_at:>='%s'", params.UpdatedAtMin))
+		}
+		if params.Query != "" {
+			queryParts = append(queryParts, params.Query)
+		}
+		if params.After != "" {
+			after = params.After
+		}
+	}
+
+	query := `query Orders($first: Int!, $after: String, $query: String) {
+		orders(first: $first, after: $after, query: $query, sortKey: UPDATED_AT) {
+			nodes {` + orderFields + `}
+			pageInfo { hasNextPage endCursor }
+		}
+	}`
+
+	var q any
+	if len(queryParts) > 0 {
+		q = strings.Join(queryParts, " AND ")
+	}
+
+	var result struct {
+		Orders OrderConnection `json:"orders"`
+	}
+	vars := map[string]any{"first": first, "after": after, "query": q}
+	if err := s.client.do(ctx, query, vars, &result); err != nil {
+		return nil, err
+	}
+	return &result.Orders, nil
+}
+
+// Get returns a single order by numeric ID or global ID.
+func (s *OrderService) Get(ctx context.Context, id string) (*Order, error) {
+	query := `query Order($id: ID!) {
+		order(id: $id) {` + orderFields + `}
+	}`
+
+	var result struct {
+		Order *Order `json:"order"`
+	}
+	if err := s.client.do(ctx, query, map[string]any{"id": GID("Order", id)}, &result); err != nil {
+		return nil, err
+	}
+	if result.Order == nil {
+		return nil, ErrNotFound
+	}
+	return result.Order, nil
+}
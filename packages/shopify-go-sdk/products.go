@@ -0,0 +1,216 @@
+package shopify
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+// ProductService handles products and variants.
+type ProductService struct {
+	client *Client
+}
+
+// ProductListParams are the optional parameters for listing products.
+type ProductListParams struct {
+	// Query is a Shopify search query (e.g. "status:active").
+	Query string
+	// First is the page size (default 50, max 250).
+	First int
+	// After is the cursor returned in PageInfo.EndCursor of the previous page.
+	After string
+}
+
+const productFields = `
+	id title handle status descriptionHtml vendor productType
+	variants(first: 100) {
+		nodes {
+			id title sku barcode price inventoryQuantity
+			selectedOptions { name value }
+			inventoryItem { id }
+		}
+	}`
+
+// ProductInput describes a product to create.
+type ProductInput struct {
+	Title           string
+	DescriptionHTML string
+	Vendor          string
+	ProductType     string
+	// Status is ACTIVE, DRAFT or ARCHIVED. Defaults to DRAFT when empty.
+	Status string
+}
+
+// List returns a page of products.
+func (s *ProductService) List(ctx context.Context, params *ProductListParams) (*ProductConnection, error) {
+	first := 50
+	var after, q any
+	if params != nil {
+		if params.First > 0 {
+			first = min(params.First, 250)
+		}
+		if params.After != "" {
+			after = params.After
+		}
+		if params.Query != "" {
+			q = params.Query
+		}
+	}
+
+	query := `query Products($first: Int!, $after: String, $query: String) {
+		products(first: $first, after: $after, query: $query) {
+			nodes {` + productFields + `}
+			pageInfo { hasNextPage endCursor }
+		}
+	}`
+
+	var result struct {
+		Products ProductConnection `json:"products"`
+	}
+	vars := map[string]any{"first": first, "after": after, "query": q}
+	if err := s.client.do(ctx, query, vars, &result); err != nil {
+		return nil, err
+	}
+	return &result.Products, nil
+}
+
+// Get returns a single product by numeric ID or global ID.
+func (s *ProductService) Get(ctx context.Context, id string) (*Product, error) {
+	query := `query Product($id: ID!) {
+		product(id: $id) {` + productFields + `}
+	}`
+
+	var result struct {
+		Product *Product `json:"product"`
+	}
+	if err := s.client.do(ctx, query, map[string]any{"id": GID("Product", id)}, &result); err != nil {
+		return nil, err
+	}
+	if result.Product == nil {
+		return nil, ErrNotFound
+	}
+	return result.Product, nil
+}
+
+// GetVariant returns a single variant with its inventory item and parent product IDs.
+func (s *ProductService) GetVariant(ctx context.Context, id string) (*ProductVariant, error) {
+	query := `query Variant($id: ID!) {
+		productVariant(id: $id) {
+			id title sku barcode price inventoryQuantity
+			selectedOptions { name value }
+			inventoryItem { id }
+			product { id }
+		}
+	}`
+
+	var result struct {
+		ProductVariant *ProductVariant `json:"productVariant"`
+	}
+	if err := s.client.do(ctx, query, map[string]any{"id": GID("ProductVariant", id)}, &result); err != nil {
+		return nil, err
+	}
+	if result.ProductVariant == nil {
+		return nil, ErrNotFound
+	}
+	return result.ProductVariant, nil
+}
+
+// Create creates a product. Shopify creates a default variant for every new product.
+func (s *ProductService) Create(ctx context.Context, input ProductInput) (*Product, error) {
+	status := input.Status
+	if status == "" {
+		status = "DRAFT"
+	}
+	product := map[string]any{
+		"title":  input.Title,
+		"status": status,
+	}
+	if input.DescriptionHTML != "" {
+		product["descriptionHtml"] = input.DescriptionHTML
+	}
+	if input.Vendor != "" {
+		product["vendor"] = input.Vendor
+	}
+	if input.ProductType != "" {
+		product["productType"] = input.ProductType
+	}
+
+	query := `mutation ProductCreate($product: ProductCreateInput!) {
+		productCreate(product: $product) {
+			product {` + productFields + `}
+			userErrors { field message }
+		}
+	}`
+
+	var result struct {
+		ProductCreate struct {
+			Product    *Product    `json:"product"`
+			UserErrors []UserError `json:"userErrors"`
+		} `json:"productCreate"`
+	}
+	if err := s.client.do(ctx, query, map[string]any{"product": product}, &result); err != nil {
+		return nil, err
+	}
+	if err := userErrorsOrNil(result.ProductCreate.UserErrors); err != nil {
+		return nil, err
+	}
+	if result.ProductCreate.Product == nil {
+		return nil, fmt.Errorf("shopify: productCreate returned no product")
+	}
+	return result.ProductCreate.Product, nil
+}
+
+// VariantUpdate describes changes to a single variant in a bulk update.
+// Nil fields are left unchanged.
+type VariantUpdate struct {
+	ID      string
+	Price   *float64
+	SKU     *string
+	Barcode *string
+}
+
+// UpdateVariants updates variants of a single product via productVariantsBulkUpdate.
+func (s *ProductService) UpdateVariants(ctx context.Context, productID string, updates []VariantUpdate) ([]ProductVariant, error) {
+	variants := make([]map[string]any, 0, len(updates))
+	for _, u := range updates {
+		v := map[string]any{"id": GID("ProductVariant", u.ID)}
+		if u.Price != nil {
+			v["price"] = strconv.FormatFloat(*u.Price, 'f', 2, 64)
+		}
+		if u.Barcode != nil {
+			v["barcode"] = *u.Barcode
+		}
+		if u.SKU != nil {
+			v["inventoryItem"] = map[string]any{"sku": *u.SKU}
+		}
+		variants = append(variants, v)
+	}
+
+	query := `mutation VariantsUpdate($productId: ID!, $variants: [ProductVariantsBulkInput!]!) {
+		productVariantsBulkUpdate(productId: $productId, variants: $variants) {
+			productVariants { id title sku barcode price inventoryQuantity inventoryItem { id } }
+			userErrors { field message }
+		}
+	}`
+
+	var result struct {
+		ProductVariantsBulkUpdate struct {
+			ProductVariants []ProductVariant `json:"productVariants"`
+			UserErrors      []UserError      `json:"userErrors"`
+		} `json:"productVariantsBulkUpdate"`
+	}
+	vars := map[string]any{"productId": GID("Product", productID), "variants": variants}
+	if err := s.client.do(ctx, query, vars, &result); err != nil {
+		return nil, err
+	}
+	if err := userErrorsOrNil(result.ProductVariantsBulkUpdate.UserErrors); err != nil {
+		return nil, err
+	}
+	return result.ProductVariantsBulkUpdate.ProductVariants, nil
+}
+
+// UpdateVariantPrice sets the price of a single variant.
+func (s *ProductService) UpdateVariantPrice(ctx context.Context, productID, variantID string, price float64) error {
+	_, err := s.UpdateVariants(ctx, productID, []VariantUpdate{{ID: variantID, Price: &price}})
+	return err
+}
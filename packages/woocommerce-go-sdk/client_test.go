@@ -655,6 +655,50 @@ func TestProductsUpdate(t *testing.T) {
 	}
 }
 
+func TestProductsListVariations(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/products/20/variations" {
+			t.Errorf("path = %q, want /products/20/variations", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[
+			{
+				"id": 21,
+				"sku": "TS-M",
+				"price": "59.00",
+				"stock_quantity": 4,
+				"global_unique_id": "5901234123457",
+				"image": {"id": 7, "src": "https://shop.example.com/ts-m.jpg"},
+				"attributes": [{"id": 1, "name": "Size", "option": "M"}]
+			}
+		]`))
+	}))
+	defer srv.Close()
+
+	c := NewClient("https://shop.example.com", "ck", "cs",
+		WithBaseURL(srv.URL),
+		WithHTTPClient(srv.Client()),
+	)
+
+	variations, err := c.Products.ListVariations(context.Background(), 20)
+	if err != nil {
+		t.Fatalf("Products.ListVariations error: %v", err)
+	}
+	if len(variations) != 1 {
+		t.Fatalf("len(variations) = %d, want 1", len(variations))
+	}
+	v := variations[0]
+	if v.SKU != "TS-M" || v.GlobalUniqueID != "5901234123457" {
+		t.Errorf("variation = %+v, want SKU TS-M and GTIN 5901234123457", v)
+	}
+	if v.Image == nil || v.Image.Src != "https://shop.example.com/ts-m.jpg" {
+		t.Errorf("variation image = %+v", v.Image)
+	}
+	if len(v.Attributes) != 1 || v.Attributes[0].Option != "M" {
+		t.Errorf("variation attributes = %+v", v.Attributes)
+	}
+}
+
 // --- Webhook tests ---
 
 func TestWebhooksList(t *testing.T) {
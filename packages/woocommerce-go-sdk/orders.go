@@ -58,14 +58,15 @@ type WooAddress struct {
 
 // WooLineItem represents a single line item in a WooCommerce order.
 type WooLineItem struct {
-	ID        int     `json:"id"`
-	Name      string  `json:"name"`
-	SKU       string  `json:"sku"`
-	Quantity  int     `json:"quantity"`
-	Total     string  `json:"total"`
-	TotalTax  string  `json:"total_tax"`
-	Price     float64 `json:"price"`
-	ProductID int     `json:"product_id"`
+	ID          int     `json:"id"`
+	Name        string  `json:"name"`
+	SKU         string  `json:"sku"`
+	Quantity    int     `json:"quantity"`
+	Total       string  `json:"total"`
+	TotalTax    string  `json:"total_tax"`
+	Price       float64 `json:"price"`
+	ProductID   int     `json:"product_id"`
+	VariationID int     `json:"variation_id"`
 }
 
 // List retrieves a list of orders with optional filtering.
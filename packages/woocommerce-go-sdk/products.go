@@ -25,17 +25,31 @@ type ProductListParams struct {
 
 // WooProduct represents a WooCommerce product.
 type WooProduct struct {
-	ID            int      `json:"id"`
-	Name          string   `json:"name"`
-	SKU           string   `json:"sku"`
-	Price         string   `json:"price"`
-	RegularPrice  string   `json:"regular_price"`
-	StockQuantity *int     `json:"stock_quantity"`
-	StockStatus   string   `json:"stock_status"`
-	Status        string   `json:"status"`
-	Description   string   `json:"description"`
-	ShortDesc     string   `json:"short_description"`
-	Categories    []WooCat `json:"categories"`
+	ID            int        `json:"id"`
+	Name          string     `json:"name"`
+	Type          string     `json:"type"` // "simple", "variable", "grouped", "external"
+	Permalink     string     `json:"permalink"`
+	SKU           string     `json:"sku"`
+	Price         string     `json:"price"`
+	RegularPrice  string     `json:"regular_price"`
+	StockQuantity *int       `json:"stock_quantity"`
+	StockStatus   string     `json:"stock_status"`
+	Status        string     `json:"status"`
+	Description   string     `json:"description"`
+	ShortDesc     string     `json:"short_description"`
+	Weight        string     `json:"weight"`
+	Categories    []WooCat   `json:"categories"`
+	Images        []WooImage `json:"images"`
+	Variations    []int      `json:"variations"`
+
+	// GlobalUniqueID is the GTIN/EAN/UPC/ISBN field (WooCommerce 9.2+).
+	GlobalUniqueID string `json:"global_unique_id"`
+}
+
+// WooImage represents a product or variation image.
+type WooImage struct {
+	ID  int    `json:"id"`
+	Src string `json:"src"`
 }
 
 // WooCat represents a WooCommerce product category reference.
@@ -120,3 +134,52 @@ func (s *ProductService) Create(ctx context.Context, data map[string]any) (*WooP
 	}
 	return &result, nil
 }
+
+// WooVariation represents a variation of a variable WooCommerce product.
+type WooVariation struct {
+	ID            int                  `json:"id"`
+	SKU           string               `json:"sku"`
+	Price         string               `json:"price"`
+	RegularPrice  string               `json:"regular_price"`
+	StockQuantity *int                 `json:"stock_quantity"`
+	StockStatus   string               `json:"stock_status"`
+	Weight        string               `json:"weight"`
+	Image         *WooImage            `json:"image"`
+	Attributes    []WooVariationOption `json:"attributes"`
+
+	// GlobalUniqueID is the GTIN/EAN/UPC/ISBN field (WooCommerce 9.2+).
+	GlobalUniqueID string `json:"global_unique_id"`
+}
+
+// WooVariationOption is the attribute value that identifies a variation (e.g. Size: M).
+type WooVariationOption struct {
+	ID     int    `json:"id"`
+	Name   string `json:"name"`
+	Option string `json:"option"`
+}
+
+// ListVariations retrieves all variations of a variable product.
+func (s *ProductService) ListVariations(ctx context.Context, productID int) ([]WooVariation, error) {
+	var result []WooVariation
+	if err := s.client.do(ctx, "GET", fmt.Sprintf("/products/%d/variations?per_page=100", productID), nil, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// UpdateVariationStock updates the stock quantity for a product variation.
+func (s *ProductService) UpdateVariationStock(ctx context.Context, productID, variationID int, quantity int) error {
+	body := map[string]any{
+		"stock_quantity": quantity,
+		"manage_stock":   true,
+	}
+	return s.client.do(ctx, "PUT", fmt.Sprintf("/products/%d/variations/%d", productID, variationID), body, nil)
+}
+
+// UpdateVariationPrice updates the regular price for a product variation.
+func (s *ProductService) UpdateVariationPrice(ctx context.Context, productID, variationID int, price string) error {
+	body := map[string]any{
+		"regular_price": price,
+	}
+	return s.client.do(ctx, "PUT", fmt.Sprintf("/products/%d/variations/%d", productID, variationID), body, nil)
+}